@@ -3,12 +3,16 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,13 +20,21 @@ import (
 	"github.com/fcavalcantirj/solvr/internal/api/handlers"
 	"github.com/fcavalcantirj/solvr/internal/config"
 	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/grpcapi"
 	"github.com/fcavalcantirj/solvr/internal/hub"
 	"github.com/fcavalcantirj/solvr/internal/jobs"
+	"github.com/fcavalcantirj/solvr/internal/maintenance"
+	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/fcavalcantirj/solvr/internal/services"
+	"github.com/fcavalcantirj/solvr/migrations"
+	"google.golang.org/grpc"
 )
 
 
 func main() {
+	migrate := flag.Bool("migrate", false, "apply pending embedded database migrations before serving")
+	flag.Parse()
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -48,6 +60,37 @@ func main() {
 		} else {
 			log.Println("Database connection established")
 			defer pool.Close()
+
+			if threshold, thresholdErr := time.ParseDuration(cfg.DBSlowQueryThreshold); thresholdErr == nil {
+				pool.SetSlowQueryThreshold(threshold)
+			} else {
+				log.Printf("Warning: invalid DB_SLOW_QUERY_THRESHOLD %q, using %s", cfg.DBSlowQueryThreshold, db.DefaultSlowQueryThreshold)
+			}
+
+			if cfg.DatabaseReplicaURL != "" {
+				maxLag, lagErr := time.ParseDuration(cfg.DatabaseReplicaMaxLag)
+				if lagErr != nil {
+					log.Printf("Warning: invalid DATABASE_REPLICA_MAX_LAG %q, using %s", cfg.DatabaseReplicaMaxLag, db.DefaultReplicaMaxLag)
+					maxLag = db.DefaultReplicaMaxLag
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				if err := pool.AttachReplica(ctx, cfg.DatabaseReplicaURL, maxLag); err != nil {
+					log.Printf("Warning: Read replica unavailable, all queries use primary: %v", err)
+				} else {
+					log.Println("Read replica attached")
+				}
+				cancel()
+			}
+		}
+	}
+
+	// Apply embedded migrations when started with --migrate
+	if *migrate {
+		if pool == nil {
+			log.Fatal("FATAL: --migrate requires a database connection")
+		}
+		if err := applyMigrations(pool); err != nil {
+			log.Fatalf("FATAL: migrations failed: %v", err)
 		}
 	}
 
@@ -71,6 +114,21 @@ func main() {
 		}
 	}
 
+	// Fail fast on a bad CORS origin list rather than silently locking out the frontend
+	if _, err := config.AllowedOrigins(); err != nil {
+		log.Fatalf("FATAL: %v", err)
+	}
+
+	// Share maintenance mode between API replicas: the admin endpoint saves it to
+	// the database and every replica polls it
+	var maintenanceCancel context.CancelFunc
+	if pool != nil {
+		maintenance.Default().SetStore(db.NewMaintenanceRepository(pool))
+		var maintenanceCtx context.Context
+		maintenanceCtx, maintenanceCancel = context.WithCancel(context.Background())
+		go maintenance.Default().Poll(maintenanceCtx, maintenance.DefaultPollInterval)
+	}
+
 	// Initialize hub manager for real-time room features
 	var hubMgr *hub.HubManager
 	var presenceRegistry *hub.PresenceRegistry
@@ -90,12 +148,41 @@ func main() {
 	// it added placeholder routes that overrode the real handlers.
 	// All routes are now consolidated in router.go.
 
+	// Route audit (ROUTE_AUDIT): every documented operation must reach a real
+	// handler. The v1 routes are only mounted with a database.
+	if mode := config.RouteAuditMode(); pool != nil && mode != config.RouteAuditOff {
+		audit := api.AuditRoutes(router)
+		audit.Log()
+		if err := audit.Err(); err != nil && mode == config.RouteAuditStrict {
+			log.Fatalf("FATAL: route audit failed: %v", err)
+		}
+	}
+
 	// Log startup configuration (FIX-014)
 	// This provides visibility into what config the server started with
 	logger := slog.Default()
 	dbConnected := pool != nil
 	config.LogStartupConfig(logger, cfg, dbConnected)
 
+	// Background jobs are paused while read-only maintenance mode is on
+	jobRunner := jobs.NewPausableRunner(maintenance.Default())
+
+	// Restart jobs whose JOB_INTERVALS entry or enable flag changed on a config reload
+	flagJobs := map[string]string{
+		"ANSWER_QUALITY_ENABLED":       "answer_quality",
+		"KNOWLEDGE_GAP_REPORT_ENABLED": "knowledge_gaps",
+	}
+	config.DefaultReloader().OnReload(func(old, next config.Runtime) []models.ConfigChange {
+		for _, change := range old.Diff(next) {
+			if name, ok := strings.CutPrefix(change.Key, "JOB_INTERVALS."); ok {
+				jobRunner.Restart(name)
+			} else if name, ok := flagJobs[change.Key]; ok {
+				jobRunner.Restart(name)
+			}
+		}
+		return nil
+	})
+
 	// Start background cleanup job if database is available
 	// Per prd-v2.json: "Cron/scheduled job to delete expired tokens, Run every hour"
 	var cleanupCancel context.CancelFunc
@@ -104,7 +191,7 @@ func main() {
 		cleanupCtx, cleanupCancel = context.WithCancel(context.Background())
 		tokenRepo := db.NewClaimTokenRepository(pool)
 		cleanupJob := jobs.NewCleanupJob(tokenRepo)
-		go cleanupJob.RunScheduled(cleanupCtx, jobs.DefaultCleanupInterval)
+		jobRunner.Go(cleanupCtx, "cleanup", func(ctx context.Context) { cleanupJob.RunScheduled(ctx, jobInterval("cleanup", jobs.DefaultCleanupInterval)) })
 		log.Println("Cleanup job started (runs every hour)")
 	}
 
@@ -122,25 +209,37 @@ func main() {
 		crystallizationSvc := services.NewCrystallizationService(
 			postRepo, postRepo, approachRepo, ipfsSvc, ipfsSvc,
 		)
+		crystallizationSvc.SetTranscriptFinder(db.NewApproachTranscriptRepository(pool))
 		crystallizationJob := jobs.NewCrystallizationJob(
 			postRepo, crystallizationSvc, jobs.DefaultCrystallizationStabilityPeriod,
 		)
+		if config.MailerConfig().Driver != "" {
+			emailQueueRepo := db.NewEmailQueueRepository(pool)
+			crystallizationJob.SetNotifier(services.NewEmailNotifier(emailQueueRepo, emailQueueRepo, postRepo))
+		}
 		var crystallizationCtx context.Context
 		crystallizationCtx, crystallizationCancel = context.WithCancel(context.Background())
-		go crystallizationJob.RunScheduled(crystallizationCtx, jobs.DefaultCrystallizationInterval)
+		jobRunner.Go(crystallizationCtx, "crystallization", func(ctx context.Context) { crystallizationJob.RunScheduled(ctx, jobInterval("crystallization", jobs.DefaultCrystallizationInterval)) })
 		log.Println("Crystallization job started (runs every 24 hours)")
 	}
 
 	// Start stale content cleanup job if database is available
-	// Per prd-v5: abandon stale approaches (30d), warn before abandonment (23d), mark dormant posts (60d)
+	// Per prd-v5: abandon stale approaches (30d), warn (23d) and remind (27d) before abandonment, mark dormant posts (60d)
 	var staleContentCancel context.CancelFunc
 	if pool != nil {
 		notifRepo := db.NewNotificationsRepository(pool)
 		staleContentRepo := db.NewStaleContentRepository(pool, notifRepo)
 		staleContentJob := jobs.NewStaleContentJob(staleContentRepo, staleContentRepo, staleContentRepo)
+		if v := os.Getenv("STALE_APPROACH_THRESHOLDS"); v != "" {
+			if policy, err := jobs.ParseStaleApproachPolicy(v); err != nil {
+				log.Printf("Ignoring STALE_APPROACH_THRESHOLDS: %v", err)
+			} else {
+				staleContentJob.SetApproachPolicy(policy)
+			}
+		}
 		var staleContentCtx context.Context
 		staleContentCtx, staleContentCancel = context.WithCancel(context.Background())
-		go staleContentJob.RunScheduled(staleContentCtx, jobs.DefaultStaleContentInterval)
+		jobRunner.Go(staleContentCtx, "stale_content", func(ctx context.Context) { staleContentJob.RunScheduled(ctx, jobInterval("stale_content", jobs.DefaultStaleContentInterval)) })
 		log.Println("Stale content cleanup job started (runs every 24 hours)")
 	}
 
@@ -153,7 +252,7 @@ func main() {
 		autoSolveJob := jobs.NewAutoSolveJob(autoSolveRepo, autoSolveRepo)
 		var autoSolveCtx context.Context
 		autoSolveCtx, autoSolveCancel = context.WithCancel(context.Background())
-		go autoSolveJob.RunScheduled(autoSolveCtx, jobs.DefaultAutoSolveInterval)
+		jobRunner.Go(autoSolveCtx, "auto_solve", func(ctx context.Context) { autoSolveJob.RunScheduled(ctx, jobInterval("auto_solve", jobs.DefaultAutoSolveInterval)) })
 		log.Println("Auto-solve job started (runs every 24 hours)")
 	}
 
@@ -166,17 +265,7 @@ func main() {
 			translationSvc = services.NewTranslationService(os.Getenv("GROQ_API_KEY"), services.WithTranslationModel(model))
 		}
 		translationPostRepo := db.NewPostRepository(pool)
-		translationModSvc := services.NewContentModerationService(os.Getenv("GROQ_API_KEY"))
-		translationCommentRepo := db.NewCommentsRepository(pool)
-		translationNotifRepo := db.NewNotificationsRepository(pool)
-		translationNotifSvc := api.NewModerationNotificationService(translationNotifRepo.Create)
-		trigger := handlers.NewModerationTrigger(
-			api.NewContentModerationAdapter(translationModSvc),
-			translationPostRepo,
-			slog.Default(),
-		)
-		trigger.SetCommentRepo(translationCommentRepo)
-		trigger.SetNotificationService(translationNotifSvc)
+		trigger := newModerationTrigger(pool, os.Getenv("GROQ_API_KEY"))
 
 		batchSize := jobs.DefaultTranslationBatchSize
 		if v := os.Getenv("TRANSLATION_BATCH_SIZE"); v != "" {
@@ -194,10 +283,31 @@ func main() {
 		translationJob := jobs.NewTranslationJob(translationPostRepo, translationPostRepo, translationSvc, trigger, batchSize, delayMs)
 		var translationCtx context.Context
 		translationCtx, translationCancel = context.WithCancel(context.Background())
-		go translationJob.RunScheduled(translationCtx, jobs.DefaultTranslationInterval)
+		jobRunner.Go(translationCtx, "translation", func(ctx context.Context) { translationJob.RunScheduled(ctx, jobInterval("translation", jobs.DefaultTranslationInterval)) })
 		log.Println("Translation sweep job started (runs every hour, primary translation is inline)")
 	}
 
+	// Start scheduled publish job if database is available.
+	// Opens posts created with a future publish_at, then moderates and embeds them.
+	var scheduledPublishCancel context.CancelFunc
+	if pool != nil {
+		var trigger jobs.PostModerationTrigger
+		if groqKey := os.Getenv("GROQ_API_KEY"); groqKey != "" {
+			trigger = newModerationTrigger(pool, groqKey)
+		}
+		// Published posts are only queued for embedding when the embedding queue job runs.
+		var embeddings jobs.PostEmbeddingQueue
+		if embeddingService != nil {
+			embeddings = db.NewEmbeddingQueueRepository(pool)
+		}
+		scheduledPublishJob := jobs.NewScheduledPublishJob(db.NewPostRepository(pool), trigger,
+			embeddings, jobs.DefaultScheduledPublishBatchSize)
+		var scheduledPublishCtx context.Context
+		scheduledPublishCtx, scheduledPublishCancel = context.WithCancel(context.Background())
+		jobRunner.Go(scheduledPublishCtx, "scheduled_publish", func(ctx context.Context) { scheduledPublishJob.RunScheduled(ctx, jobInterval("scheduled_publish", jobs.DefaultScheduledPublishInterval)) })
+		log.Println("Scheduled publish job started (runs every minute)")
+	}
+
 	// Start health check monitoring job if database is available
 	var healthCheckCancel context.CancelFunc
 	if pool != nil {
@@ -211,10 +321,241 @@ func main() {
 		healthCheckJob := jobs.NewHealthCheckJob(healthSvc, checksRepo)
 		var healthCheckCtx context.Context
 		healthCheckCtx, healthCheckCancel = context.WithCancel(context.Background())
-		go healthCheckJob.RunScheduled(healthCheckCtx, jobs.DefaultHealthCheckInterval)
+		jobRunner.Go(healthCheckCtx, "health_check", func(ctx context.Context) { healthCheckJob.RunScheduled(ctx, jobInterval("health_check", jobs.DefaultHealthCheckInterval)) })
 		log.Println("Health check monitoring job started (runs every 5 minutes)")
 	}
 
+	// Start embedding queue job if database and embedding service are available.
+	// Backfills embeddings for posts created or edited while the provider was down.
+	var embeddingQueueCancel context.CancelFunc
+	if pool != nil && embeddingService != nil {
+		embeddingQueueJob := jobs.NewEmbeddingQueueJob(db.NewEmbeddingQueueRepository(pool), embeddingService)
+		var embeddingQueueCtx context.Context
+		embeddingQueueCtx, embeddingQueueCancel = context.WithCancel(context.Background())
+		jobRunner.Go(embeddingQueueCtx, "embedding_queue", func(ctx context.Context) { embeddingQueueJob.RunScheduled(ctx, jobInterval("embedding_queue", jobs.DefaultEmbeddingQueueInterval)) })
+		log.Println("Embedding queue job started (runs every 5 minutes)")
+	}
+
+	// Start post counter reconciliation job if database is available.
+	// Repairs drift in the trigger-maintained answers/approaches/comments counters.
+	var postCounterCancel context.CancelFunc
+	if pool != nil {
+		postCounterJob := jobs.NewPostCounterReconciliationJob(db.NewPostRepository(pool))
+		var postCounterCtx context.Context
+		postCounterCtx, postCounterCancel = context.WithCancel(context.Background())
+		jobRunner.Go(postCounterCtx, "post_counter_reconciliation", func(ctx context.Context) { postCounterJob.RunScheduled(ctx, jobInterval("post_counter_reconciliation", jobs.DefaultPostCounterReconciliationInterval)) })
+		log.Println("Post counter reconciliation job started (runs every hour)")
+	}
+
+	// Start code language backfill job if database is available.
+	// Extracts code block languages for posts and answers written before they were recorded on write.
+	var codeLanguageCancel context.CancelFunc
+	if pool != nil {
+		codeLanguageJob := jobs.NewCodeLanguageBackfillJob(db.NewPostRepository(pool), jobs.DefaultCodeLanguageBackfillBatchSize)
+		var codeLanguageCtx context.Context
+		codeLanguageCtx, codeLanguageCancel = context.WithCancel(context.Background())
+		jobRunner.Go(codeLanguageCtx, "code_language_backfill", func(ctx context.Context) { codeLanguageJob.RunScheduled(ctx, jobInterval("code_language_backfill", jobs.DefaultCodeLanguageBackfillInterval)) })
+		log.Println("Code language backfill job started (runs every 10 minutes)")
+	}
+
+	// Start entity extraction job if database is available.
+	// Extracts libraries, tools, error codes and CVEs from new and edited posts for GET /v1/entities/{name}.
+	var entityExtractionCancel context.CancelFunc
+	if pool != nil {
+		entityExtractionJob := jobs.NewEntityExtractionJob(db.NewEntityRepository(pool), jobs.DefaultEntityExtractionBatchSize)
+		var entityExtractionCtx context.Context
+		entityExtractionCtx, entityExtractionCancel = context.WithCancel(context.Background())
+		jobRunner.Go(entityExtractionCtx, "entity_extraction", func(ctx context.Context) { entityExtractionJob.RunScheduled(ctx, jobInterval("entity_extraction", jobs.DefaultEntityExtractionInterval)) })
+		log.Println("Entity extraction job started (runs every 15 minutes)")
+	}
+
+	// Start freshness job if database is available.
+	// Rescores post freshness as posts age and after edits change their pinned library versions.
+	var freshnessCancel context.CancelFunc
+	if pool != nil {
+		freshnessJob := jobs.NewFreshnessJob(db.NewPostFreshnessRepository(pool), jobs.DefaultFreshnessMaxAge, jobs.DefaultFreshnessBatchSize)
+		var freshnessCtx context.Context
+		freshnessCtx, freshnessCancel = context.WithCancel(context.Background())
+		jobRunner.Go(freshnessCtx, "freshness", func(ctx context.Context) { freshnessJob.RunScheduled(ctx, jobInterval("freshness", jobs.DefaultFreshnessInterval)) })
+		log.Println("Freshness job started (runs every 6 hours)")
+	}
+
+	// Start search index job if the OpenSearch search backend is selected.
+	// Rebuilds the index on first run, then applies the change event log.
+	var searchIndexCancel context.CancelFunc
+	if searchCfg := config.SearchBackendConfig(); pool != nil && searchCfg.Backend == models.SearchBackendOpenSearch {
+		if backend, err := services.NewOpenSearchBackend(searchCfg); err != nil {
+			log.Printf("Search index job disabled: %v", err)
+		} else {
+			searchIndexJob := jobs.NewSearchIndexJob(db.NewSearchDocumentRepository(pool), db.NewChangeEventRepository(pool),
+				backend, "opensearch/"+searchCfg.OpenSearchIndex, jobs.DefaultSearchIndexBatchSize)
+			var searchIndexCtx context.Context
+			searchIndexCtx, searchIndexCancel = context.WithCancel(context.Background())
+			jobRunner.Go(searchIndexCtx, "search_index", func(ctx context.Context) { searchIndexJob.RunScheduled(ctx, jobInterval("search_index", jobs.DefaultSearchIndexInterval)) })
+			log.Println("Search index job started (runs every 30 seconds)")
+		}
+	}
+
+	// Start abuse detection job if database is available.
+	// Flags vote rings, targeted upvoting and new-account vote bursts for admin review.
+	var abuseDetectionCancel context.CancelFunc
+	if pool != nil {
+		abuseDetectionJob := jobs.NewAbuseDetectionJob(db.NewAbuseReportRepository(pool))
+		var abuseDetectionCtx context.Context
+		abuseDetectionCtx, abuseDetectionCancel = context.WithCancel(context.Background())
+		jobRunner.Go(abuseDetectionCtx, "abuse_detection", func(ctx context.Context) { abuseDetectionJob.RunScheduled(ctx, jobInterval("abuse_detection", jobs.DefaultAbuseDetectionInterval)) })
+		log.Println("Abuse detection job started (runs daily)")
+	}
+
+	// Start account purge job if database is available.
+	// Hard-deletes PII of accounts deleted via DELETE /v1/me once their grace period ends.
+	var accountPurgeCancel context.CancelFunc
+	if pool != nil {
+		accountPurgeJob := jobs.NewAccountPurgeJob(db.NewAccountDeletionRepository(pool))
+		var accountPurgeCtx context.Context
+		accountPurgeCtx, accountPurgeCancel = context.WithCancel(context.Background())
+		jobRunner.Go(accountPurgeCtx, "account_purge", func(ctx context.Context) { accountPurgeJob.RunScheduled(ctx, jobInterval("account_purge", jobs.DefaultAccountPurgeInterval)) })
+		log.Println("Account purge job started (runs every hour)")
+	}
+
+	// Start bounty decay job if database is available.
+	// Lowers the weight of unsolved problems with no activity for 14 days, one step per run.
+	var bountyDecayCancel context.CancelFunc
+	if pool != nil {
+		bountyDecayJob := jobs.NewBountyDecayJob(db.NewBountyRepository(pool), jobs.DefaultBountyDecayInactivity)
+		var bountyDecayCtx context.Context
+		bountyDecayCtx, bountyDecayCancel = context.WithCancel(context.Background())
+		jobRunner.Go(bountyDecayCtx, "bounty_decay", func(ctx context.Context) { bountyDecayJob.RunScheduled(ctx, jobInterval("bounty_decay", jobs.DefaultBountyDecayInterval)) })
+		log.Println("Bounty decay job started (runs every 24 hours)")
+	}
+
+	// Start trending job if database is available.
+	// Recomputes trending_scores (GET /v1/stats/trending) with TRENDING_* weights.
+	var trendingCancel context.CancelFunc
+	if pool != nil {
+		trendingJob := jobs.NewTrendingJob(db.NewTrendingRepository(pool), config.TrendingConfig())
+		var trendingCtx context.Context
+		trendingCtx, trendingCancel = context.WithCancel(context.Background())
+		jobRunner.Go(trendingCtx, "trending", func(ctx context.Context) { trendingJob.RunScheduled(ctx, jobInterval("trending", jobs.DefaultTrendingInterval)) })
+		log.Println("Trending job started (runs every 10 minutes)")
+	}
+
+	// Start stats snapshot job if database is available.
+	// Upserts today's site-wide totals into stats_daily (GET /v1/stats/history).
+	var statsSnapshotCancel context.CancelFunc
+	if pool != nil {
+		statsSnapshotJob := jobs.NewStatsSnapshotJob(db.NewStatsRepository(pool))
+		var statsSnapshotCtx context.Context
+		statsSnapshotCtx, statsSnapshotCancel = context.WithCancel(context.Background())
+		jobRunner.Go(statsSnapshotCtx, "stats_snapshot", func(ctx context.Context) { statsSnapshotJob.RunScheduled(ctx, jobInterval("stats_snapshot", jobs.DefaultStatsSnapshotInterval)) })
+		log.Println("Stats snapshot job started (runs every hour)")
+	}
+
+	// Register the answer quality job if the Groq API key is available. It only
+	// runs while ANSWER_QUALITY_ENABLED is on, which a config reload can flip.
+	// Scores new answers so GET /v1/questions/{id}/answers?sort=quality works.
+	var answerQualityCancel context.CancelFunc
+	if pool != nil && os.Getenv("GROQ_API_KEY") != "" {
+		var qualityOpts []services.AnswerQualityOption
+		if model := os.Getenv("ANSWER_QUALITY_MODEL"); model != "" {
+			qualityOpts = append(qualityOpts, services.WithAnswerQualityModel(model))
+		}
+		qualitySvc := services.NewAnswerQualityService(os.Getenv("GROQ_API_KEY"), qualityOpts...)
+		answerQualityJob := jobs.NewAnswerQualityJob(db.NewAnswersRepository(pool), qualitySvc,
+			jobs.DefaultAnswerQualityBatchSize, jobs.DefaultAnswerQualityDelayMs)
+		var answerQualityCtx context.Context
+		answerQualityCtx, answerQualityCancel = context.WithCancel(context.Background())
+		jobRunner.Go(answerQualityCtx, "answer_quality", func(ctx context.Context) {
+			if !config.DefaultReloader().Current().AnswerQualityEnabled {
+				return
+			}
+			answerQualityJob.RunScheduled(ctx, jobInterval("answer_quality", jobs.DefaultAnswerQualityInterval))
+		})
+		log.Println("Answer quality job registered (runs every 15 minutes while ANSWER_QUALITY_ENABLED)")
+	}
+
+	// Start strategy cluster job if database is available.
+	// Groups approach embeddings per problem for GET /v1/problems/{id}/insights.
+	var strategyClusterCancel context.CancelFunc
+	if pool != nil {
+		strategyClusterJob := jobs.NewStrategyClusterJob(db.NewStrategyClustersRepository(pool),
+			jobs.DefaultStrategyClusterBatchSize, jobs.DefaultStrategySimilarityThreshold)
+		var strategyClusterCtx context.Context
+		strategyClusterCtx, strategyClusterCancel = context.WithCancel(context.Background())
+		jobRunner.Go(strategyClusterCtx, "strategy_clusters", func(ctx context.Context) { strategyClusterJob.RunScheduled(ctx, jobInterval("strategy_clusters", jobs.DefaultStrategyClusterInterval)) })
+		log.Println("Strategy cluster job started (runs every 30 minutes)")
+	}
+
+	// Register the knowledge gap report job if the Groq API key is available. It
+	// only runs while KNOWLEDGE_GAP_REPORT_ENABLED is on, which a config reload can flip.
+	// Publishes a weekly blog post of unmet needs and emails it to admins.
+	var knowledgeGapCancel context.CancelFunc
+	if pool != nil && os.Getenv("GROQ_API_KEY") != "" {
+		var gapOpts []services.KnowledgeGapOption
+		if model := os.Getenv("KNOWLEDGE_GAP_MODEL"); model != "" {
+			gapOpts = append(gapOpts, services.WithKnowledgeGapModel(model))
+		}
+		knowledgeGapJob := jobs.NewKnowledgeGapJob(db.NewKnowledgeGapsRepository(pool),
+			services.NewKnowledgeGapService(os.Getenv("GROQ_API_KEY"), gapOpts...))
+		if config.MailerConfig().Driver != "" {
+			knowledgeGapJob.SetEmailQueue(db.NewEmailQueueRepository(pool))
+		}
+		var knowledgeGapCtx context.Context
+		knowledgeGapCtx, knowledgeGapCancel = context.WithCancel(context.Background())
+		jobRunner.Go(knowledgeGapCtx, "knowledge_gaps", func(ctx context.Context) {
+			if !config.DefaultReloader().Current().KnowledgeGapReportEnabled {
+				return
+			}
+			knowledgeGapJob.RunScheduled(ctx, jobInterval("knowledge_gaps", jobs.DefaultKnowledgeGapInterval))
+		})
+		log.Println("Knowledge gap report job registered (weekly while KNOWLEDGE_GAP_REPORT_ENABLED, checks every 6 hours)")
+	}
+
+	// Start email queue job if database and an email driver are available.
+	// Delivers notification emails (answer accepted, post crystallized, claim link).
+	var emailQueueCancel context.CancelFunc
+	if pool != nil {
+		if mailer, mailerErr := services.NewMailer(config.MailerConfig()); mailerErr == nil {
+			emailQueueJob := jobs.NewEmailQueueJob(db.NewEmailQueueRepository(pool), mailer)
+			var emailQueueCtx context.Context
+			emailQueueCtx, emailQueueCancel = context.WithCancel(context.Background())
+			jobRunner.Go(emailQueueCtx, "email_queue", func(ctx context.Context) { emailQueueJob.RunScheduled(ctx, jobInterval("email_queue", jobs.DefaultEmailQueueInterval)) })
+			log.Println("Email queue job started (runs every minute)")
+		} else if !errors.Is(mailerErr, services.ErrMailerNotConfigured) {
+			log.Printf("Warning: email queue job disabled: %v", mailerErr)
+		}
+	}
+
+	// Start web push job if database and VAPID keys are available.
+	// Pushes new notifications of the WEB_PUSH_TYPES to subscribed browsers.
+	var webPushCancel context.CancelFunc
+	if pushCfg := config.WebPushConfig(); pool != nil && pushCfg.Enabled() {
+		if sender, senderErr := services.NewWebPushSender(pushCfg); senderErr == nil {
+			webPushJob := jobs.NewWebPushJob(db.NewPushSubscriptionRepository(pool), sender, pushCfg.Types)
+			var webPushCtx context.Context
+			webPushCtx, webPushCancel = context.WithCancel(context.Background())
+			jobRunner.Go(webPushCtx, "web_push", func(ctx context.Context) { webPushJob.RunScheduled(ctx, jobInterval("web_push", jobs.DefaultWebPushInterval)) })
+			log.Println("Web push job started (runs every 30 seconds)")
+		} else {
+			log.Printf("Warning: web push job disabled: %v", senderErr)
+		}
+	}
+
+	// Start GitHub sync job if database is available.
+	// Closes/reopens posts linked to GitHub issues when the issue is closed/reopened.
+	var githubSyncCancel context.CancelFunc
+	if pool != nil {
+		githubSyncJob := jobs.NewGitHubSyncJob(
+			db.NewPostGitHubLinkRepository(pool),
+			services.NewGitHubIssueClient(os.Getenv("GITHUB_TOKEN")),
+			jobs.DefaultGitHubSyncStaleness,
+		)
+		var githubSyncCtx context.Context
+		githubSyncCtx, githubSyncCancel = context.WithCancel(context.Background())
+		jobRunner.Go(githubSyncCtx, "github_sync", func(ctx context.Context) { githubSyncJob.RunScheduled(ctx, jobInterval("github_sync", jobs.DefaultGitHubSyncInterval)) })
+		log.Println("GitHub sync job started (runs every 15 minutes)")
+	}
+
 	// 7. Presence reaper job (D-26: every 60s, evicts expired agents and rooms)
 	var reaperCancel context.CancelFunc
 	if pool != nil && hubMgr != nil {
@@ -223,20 +564,31 @@ func main() {
 		reaperJob := jobs.NewPresenceReaperJob(presenceRepo, roomRepo, presenceRegistry, hubMgr)
 		var reaperCtx context.Context
 		reaperCtx, reaperCancel = context.WithCancel(context.Background())
-		go reaperJob.RunScheduled(reaperCtx, jobs.DefaultPresenceReaperInterval)
+		jobRunner.Go(reaperCtx, "presence_reaper", func(ctx context.Context) { reaperJob.RunScheduled(ctx, jobInterval("presence_reaper", jobs.DefaultPresenceReaperInterval)) })
 		log.Println("Presence reaper job started (runs every 60 seconds)")
 	}
 
 	// Create server
 	server := &http.Server{
-		Addr:        ":" + port,
-		Handler:     router,
-		ReadTimeout: 15 * time.Second,
-		// WriteTimeout intentionally omitted: SSE connections are long-lived.
-		// BodyLimit(64KB) middleware prevents slow-body write attacks.
-		// ReadTimeout remains, protecting against slow-header attacks.
-		// Matches Quorum's production configuration on the same Traefik/Easypanel stack.
-		IdleTimeout: 60 * time.Second,
+		Addr:    ":" + port,
+		Handler: router,
+		// ReadHeaderTimeout protects against slow-header attacks. Body reads and
+		// handlers are bounded per route by the RouteLimits middleware (see
+		// routeLimitRules in the api package), so uploads and imports get longer
+		// than votes. WriteTimeout is intentionally omitted: SSE connections are long-lived.
+		ReadHeaderTimeout: 15 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	// HTTP/2 without TLS (h2c, prior knowledge) alongside HTTP/1.1, so a reverse
+	// proxy or agent can multiplex requests over one connection. TLS is
+	// terminated by the proxy; HTTP2_CLEARTEXT=false turns it off.
+	if config.HTTP2CleartextEnabled() {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP1(true)
+		protocols.SetUnencryptedHTTP2(true)
+		server.Protocols = protocols
+		log.Println("HTTP/2 cleartext (h2c) enabled")
 	}
 
 	// Start server in goroutine
@@ -247,6 +599,32 @@ func main() {
 		}
 	}()
 
+	// gRPC API (docs/grpc.md) on its own port, answered by the same router
+	var grpcServer *grpc.Server
+	if grpcPort := os.Getenv("GRPC_PORT"); grpcPort != "" {
+		lis, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatalf("gRPC listen failed: %v", err)
+		}
+		grpcServer = grpcapi.NewGRPCServer(router)
+		go func() {
+			log.Printf("Starting Solvr gRPC server on port %s", grpcPort)
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("gRPC server failed: %v", err)
+			}
+		}()
+	}
+
+	// Reload tunable settings on SIGHUP (same as POST /v1/admin/config/reload)
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Println("SIGHUP received, reloading config")
+			_, _ = config.DefaultReloader().Reload()
+		}
+	}()
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -270,24 +648,140 @@ func main() {
 	if translationCancel != nil {
 		translationCancel()
 	}
+	if scheduledPublishCancel != nil {
+		scheduledPublishCancel()
+	}
 	if healthCheckCancel != nil {
 		healthCheckCancel()
 	}
+	if embeddingQueueCancel != nil {
+		embeddingQueueCancel()
+	}
+	if postCounterCancel != nil {
+		postCounterCancel()
+	}
+	if codeLanguageCancel != nil {
+		codeLanguageCancel()
+	}
+	if entityExtractionCancel != nil {
+		entityExtractionCancel()
+	}
+	if freshnessCancel != nil {
+		freshnessCancel()
+	}
+	if searchIndexCancel != nil {
+		searchIndexCancel()
+	}
+	if abuseDetectionCancel != nil {
+		abuseDetectionCancel()
+	}
+	if accountPurgeCancel != nil {
+		accountPurgeCancel()
+	}
+	if bountyDecayCancel != nil {
+		bountyDecayCancel()
+	}
+	if trendingCancel != nil {
+		trendingCancel()
+	}
+	if statsSnapshotCancel != nil {
+		statsSnapshotCancel()
+	}
+	if answerQualityCancel != nil {
+		answerQualityCancel()
+	}
+	if strategyClusterCancel != nil {
+		strategyClusterCancel()
+	}
+	if knowledgeGapCancel != nil {
+		knowledgeGapCancel()
+	}
+	if emailQueueCancel != nil {
+		emailQueueCancel()
+	}
+	if webPushCancel != nil {
+		webPushCancel()
+	}
+	if githubSyncCancel != nil {
+		githubSyncCancel()
+	}
 	if reaperCancel != nil {
 		reaperCancel()
 	}
 	if hubCancel != nil {
 		hubCancel() // D-10: cancels all hub goroutines
 	}
+	if maintenanceCancel != nil {
+		maintenanceCancel()
+	}
 
 	// Create shutdown context with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Attempt graceful shutdown
+	// Attempt graceful shutdown. GracefulStop waits for open streams, so it
+	// gets the same deadline as the HTTP server before connections are cut.
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			grpcServer.Stop()
+		}
+	}
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server shutdown failed: %v", err)
 	}
+	// Write the last batch of API usage counters once no more requests come in.
+	if err := router.Close(ctx); err != nil {
+		log.Printf("Router close failed: %v", err)
+	}
 
 	log.Println("Server stopped")
 }
+
+// newModerationTrigger creates the moderation trigger background jobs use to
+// moderate posts outside a request, with the same comments, notifications and
+// announcements as moderation on create.
+func newModerationTrigger(pool *db.Pool, groqKey string) *handlers.ModerationTrigger {
+	trigger := handlers.NewModerationTrigger(
+		api.NewContentModerationAdapter(services.NewContentModerationService(groqKey)),
+		db.NewPostRepository(pool),
+		slog.Default(),
+	)
+	trigger.SetCommentRepo(db.NewCommentsRepository(pool))
+	trigger.SetModerationCommentRenderer(api.NewModerationCommentRenderer(pool))
+	trigger.SetNotificationService(api.NewModerationNotificationService(db.NewNotificationsRepository(pool).Create))
+	trigger.SetPostPublishedNotifier(services.NewChatIntegrationNotifier(db.NewChatIntegrationRepository(pool)))
+	return trigger
+}
+
+// jobInterval returns the JOB_INTERVALS override for the named job, or def.
+// Read on every (re)start so reloaded intervals take effect.
+func jobInterval(name string, def time.Duration) time.Duration {
+	return config.DefaultReloader().Current().JobInterval(name, def)
+}
+
+// applyMigrations runs every pending embedded migration. Replicas started together
+// take turns through the migration lock; the later ones find nothing to do.
+func applyMigrations(pool *db.Pool) error {
+	migrator, err := db.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	applied, err := migrator.Up(ctx)
+	for _, mig := range applied {
+		log.Printf("Applied migration %06d_%s", mig.Version, mig.Name)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Database migrations up to date (%d applied)", len(applied))
+	return nil
+}
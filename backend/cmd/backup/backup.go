@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/backup"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// backupDB abstracts database operations for testing.
+type backupDB interface {
+	// SchemaVersion returns the last migration applied to the database.
+	SchemaVersion(ctx context.Context) (int64, error)
+	// Dump calls fn with every row of backup.Tables, table by table, as JSON
+	// objects without the exclude columns. All tables are read from one snapshot.
+	Dump(ctx context.Context, exclude []string, fn func(table string, row json.RawMessage) error) error
+}
+
+// objectStore is the subset of *services.S3Store the backup uses.
+type objectStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64) error
+	List(ctx context.Context, prefix string) ([]services.S3Object, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// backupResult summarises one backup.
+type backupResult struct {
+	key     string
+	size    int64
+	counts  map[string]int
+	expired int
+}
+
+// backupWorker dumps the database to a temporary file and uploads it.
+type backupWorker struct {
+	db         backupDB
+	store      objectStore
+	prefix     string
+	keep       int  // dumps to keep under prefix; 0 keeps all
+	embeddings bool // include embedding columns
+	tempDir    string
+	now        func() time.Time
+	logf       func(format string, args ...interface{})
+}
+
+func (w *backupWorker) printf(format string, args ...interface{}) {
+	if w.logf != nil {
+		w.logf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// run takes one backup and then deletes dumps beyond the newest keep. The dump
+// is staged on disk so it can be uploaded with a known size.
+func (w *backupWorker) run(ctx context.Context) (*backupResult, error) {
+	version, err := w.db.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+
+	f, err := os.CreateTemp(w.tempDir, "solvr-backup-*.ndjson.gz")
+	if err != nil {
+		return nil, fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	startedAt := w.now()
+	dump, err := backup.NewWriter(f, backup.Header{
+		CreatedAt:     startedAt,
+		SchemaVersion: version,
+		Tables:        backup.Tables,
+		Embeddings:    w.embeddings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var exclude []string
+	if !w.embeddings {
+		exclude = []string{backup.EmbeddingColumn}
+	}
+	if err := w.db.Dump(ctx, exclude, dump.WriteRow); err != nil {
+		return nil, fmt.Errorf("dump database: %w", err)
+	}
+	if err := dump.Close(); err != nil {
+		return nil, err
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("size dump: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewind dump: %w", err)
+	}
+	result := &backupResult{key: backup.ObjectKey(w.prefix, startedAt), size: size, counts: dump.Counts()}
+	if err := w.store.Put(ctx, result.key, f, size); err != nil {
+		return nil, fmt.Errorf("upload dump: %w", err)
+	}
+
+	result.expired = w.expire(ctx)
+	return result, nil
+}
+
+// expire deletes dumps beyond the newest keep and returns how many it deleted.
+// Failures are logged: the new dump is already safe.
+func (w *backupWorker) expire(ctx context.Context) int {
+	if w.keep <= 0 {
+		return 0
+	}
+	objects, err := w.store.List(ctx, w.prefix)
+	if err != nil {
+		w.printf("Failed to list dumps for expiry: %v", err)
+		return 0
+	}
+	keys := make([]string, len(objects))
+	for i, o := range objects {
+		keys[i] = o.Key
+	}
+	expired := 0
+	for _, key := range backup.Expired(w.prefix, keys, w.keep) {
+		if err := w.store.Delete(ctx, key); err != nil {
+			w.printf("Failed to delete expired dump %s: %v", key, err)
+			continue
+		}
+		expired++
+	}
+	return expired
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/backup"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// mockBackupDB is a test double for backupDB.
+type mockBackupDB struct {
+	rows    map[string][]string
+	exclude []string
+	dumpErr error
+}
+
+func (m *mockBackupDB) SchemaVersion(context.Context) (int64, error) { return 116, nil }
+
+func (m *mockBackupDB) Dump(_ context.Context, exclude []string, fn func(string, json.RawMessage) error) error {
+	m.exclude = exclude
+	if m.dumpErr != nil {
+		return m.dumpErr
+	}
+	for _, table := range backup.Tables {
+		for _, row := range m.rows[table] {
+			if err := fn(table, json.RawMessage(row)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mockStore is an in-memory objectStore.
+type mockStore struct {
+	objects map[string][]byte
+}
+
+func (m *mockStore) Put(_ context.Context, key string, body io.Reader, size int64) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != size {
+		return errors.New("size mismatch")
+	}
+	m.objects[key] = data
+	return nil
+}
+
+func (m *mockStore) List(_ context.Context, prefix string) ([]services.S3Object, error) {
+	var objects []services.S3Object
+	for key := range m.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, services.S3Object{Key: key})
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func (m *mockStore) Delete(_ context.Context, key string) error {
+	delete(m.objects, key)
+	return nil
+}
+
+func quietBackupWorker(db backupDB, store objectStore) *backupWorker {
+	return &backupWorker{
+		db: db, store: store, prefix: "backups/", embeddings: true,
+		now:  func() time.Time { return time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC) },
+		logf: func(string, ...interface{}) {},
+	}
+}
+
+func TestBackupWorker_UploadsReadableDump(t *testing.T) {
+	db := &mockBackupDB{rows: map[string][]string{
+		"posts":    {`{"id":"p1"}`, `{"id":"p2"}`},
+		"comments": {`{"id":"c1"}`},
+	}}
+	store := &mockStore{objects: map[string][]byte{}}
+	worker := quietBackupWorker(db, store)
+	worker.embeddings = false
+
+	result, err := worker.run(context.Background())
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if result.key != "backups/solvr-20261018T030000Z.ndjson.gz" {
+		t.Errorf("key = %q", result.key)
+	}
+	if !reflect.DeepEqual(db.exclude, []string{backup.EmbeddingColumn}) {
+		t.Errorf("exclude = %v, want embedding column", db.exclude)
+	}
+	if result.counts["posts"] != 2 || result.counts["comments"] != 1 {
+		t.Errorf("counts = %v", result.counts)
+	}
+
+	r, err := backup.NewReader(bytes.NewReader(store.objects[result.key]))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	if h := r.Header(); h.SchemaVersion != 116 || h.Embeddings {
+		t.Errorf("unexpected header %+v", h)
+	}
+	n := 0
+	for {
+		_, _, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("read %d rows, want 3", n)
+	}
+}
+
+func TestBackupWorker_ExpiresOldDumps(t *testing.T) {
+	store := &mockStore{objects: map[string][]byte{
+		"backups/solvr-20261015T030000Z.ndjson.gz": nil,
+		"backups/solvr-20261016T030000Z.ndjson.gz": nil,
+		"backups/solvr-20261017T030000Z.ndjson.gz": nil,
+		"backups/readme.txt":                       nil,
+	}}
+	worker := quietBackupWorker(&mockBackupDB{}, store)
+	worker.keep = 2
+
+	result, err := worker.run(context.Background())
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if result.expired != 2 {
+		t.Errorf("expired = %d, want 2", result.expired)
+	}
+	var keys []string
+	for key := range store.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	want := []string{"backups/readme.txt", "backups/solvr-20261017T030000Z.ndjson.gz", "backups/solvr-20261018T030000Z.ndjson.gz"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("objects = %v, want %v", keys, want)
+	}
+}
+
+func TestBackupWorker_DumpErrorUploadsNothing(t *testing.T) {
+	store := &mockStore{objects: map[string][]byte{}}
+	worker := quietBackupWorker(&mockBackupDB{dumpErr: errors.New("connection reset")}, store)
+
+	if _, err := worker.run(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(store.objects) != 0 {
+		t.Errorf("expected no upload, got %d objects", len(store.objects))
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"defaults", nil, false},
+		{"scheduled", []string{"--every", "24h", "--keep", "7"}, false},
+		{"interval too short", []string{"--every", "10s"}, true},
+		{"negative keep", []string{"--keep", "-1"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
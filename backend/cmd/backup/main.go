@@ -0,0 +1,209 @@
+// Package main implements the backup CLI tool. It dumps posts, answers,
+// approaches and comments to gzip-compressed NDJSON (see package backup) and
+// uploads the dump to an S3-compatible bucket configured by the BACKUP_S3_*
+// environment variables. cmd/restore loads a dump back.
+//
+// Usage:
+//
+//	DATABASE_URL="postgres://..." go run ./cmd/backup
+//	DATABASE_URL="postgres://..." go run ./cmd/backup --embeddings=false --every 24h --keep 14
+//
+// Without --every it takes one backup and exits; with it, it takes one now and
+// then one per interval until interrupted.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/backup"
+	"github.com/fcavalcantirj/solvr/internal/config"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/services"
+	"github.com/fcavalcantirj/solvr/migrations"
+	"github.com/jackc/pgx/v5"
+)
+
+// pgBackupDB implements backupDB using a real PostgreSQL connection.
+type pgBackupDB struct {
+	pool *db.Pool
+}
+
+func (d *pgBackupDB) SchemaVersion(ctx context.Context) (int64, error) {
+	migrator, err := db.NewMigrator(d.pool, migrations.FS)
+	if err != nil {
+		return 0, err
+	}
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status.Dirty {
+		return 0, errors.New("schema is dirty; repair the failed migration first")
+	}
+	return status.CurrentVersion, nil
+}
+
+func (d *pgBackupDB) Dump(ctx context.Context, exclude []string, fn func(table string, row json.RawMessage) error) error {
+	if exclude == nil {
+		exclude = []string{}
+	}
+	return d.pool.WithTx(ctx, func(tx db.Tx) error {
+		// One snapshot for every table, so answers never reference posts the
+		// dump does not contain.
+		if _, err := tx.Exec(ctx, `SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY`); err != nil {
+			return fmt.Errorf("start snapshot: %w", err)
+		}
+		for _, table := range backup.Tables {
+			if err := dumpTable(ctx, tx, table, exclude, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// dumpTable streams one table's rows to fn, oldest first.
+func dumpTable(ctx context.Context, tx db.Tx, table string, exclude []string, fn func(table string, row json.RawMessage) error) error {
+	rows, err := tx.Query(ctx, `
+		SELECT to_jsonb(t) - $1::text[]
+		FROM `+pgx.Identifier{table}.Sanitize()+` t
+		ORDER BY t.created_at, t.id
+	`, exclude)
+	if err != nil {
+		return fmt.Errorf("query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return fmt.Errorf("scan %s row: %w", table, err)
+		}
+		if err := fn(table, row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Ensure pgBackupDB implements backupDB at compile time.
+var _ backupDB = (*pgBackupDB)(nil)
+
+// cliConfig is the parsed command line.
+type cliConfig struct {
+	prefix     string
+	embeddings bool
+	every      time.Duration
+	keep       int
+	tempDir    string
+}
+
+// parseFlags parses and validates the command line.
+func parseFlags(args []string) (*cliConfig, error) {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	prefix := fs.String("prefix", "backups/", "object key prefix for dumps")
+	embeddings := fs.Bool("embeddings", true, "include embedding vectors (regenerate them with cmd/backfill-embeddings if excluded)")
+	every := fs.Duration("every", 0, "take a backup at this interval until interrupted (default: once)")
+	keep := fs.Int("keep", 0, "delete older dumps under the prefix beyond this many (default: keep all)")
+	tempDir := fs.String("temp-dir", "", "directory for staging the dump (default: system temp dir)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := &cliConfig{prefix: *prefix, embeddings: *embeddings, every: *every, keep: *keep, tempDir: *tempDir}
+	if cfg.every < 0 {
+		return nil, errors.New("--every must not be negative")
+	}
+	if cfg.every > 0 && cfg.every < time.Minute {
+		return nil, errors.New("--every must be at least 1m")
+	}
+	if cfg.keep < 0 {
+		return nil, errors.New("--keep must not be negative")
+	}
+	return cfg, nil
+}
+
+func main() {
+	cfg, err := parseFlags(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+	store, err := services.NewS3Store(config.BackupStorageConfig())
+	if err != nil {
+		log.Fatalf("Backup storage: %v (set BACKUP_S3_BUCKET and credentials)", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	pool, err := db.NewPool(connectCtx, databaseURL)
+	cancel()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	worker := &backupWorker{
+		db:         &pgBackupDB{pool: pool},
+		store:      store,
+		prefix:     cfg.prefix,
+		keep:       cfg.keep,
+		embeddings: cfg.embeddings,
+		tempDir:    cfg.tempDir,
+		now:        time.Now,
+	}
+
+	runOnce := func() error {
+		start := time.Now()
+		result, err := worker.run(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("Backup %s: %d bytes, posts=%d answers=%d approaches=%d comments=%d, expired=%d (%s)",
+			result.key, result.size, result.counts["posts"], result.counts["answers"],
+			result.counts["approaches"], result.counts["comments"], result.expired,
+			time.Since(start).Round(time.Millisecond))
+		return nil
+	}
+
+	if cfg.every == 0 {
+		if err := runOnce(); err != nil {
+			log.Fatalf("Backup failed: %v", err)
+		}
+		return
+	}
+
+	log.Printf("Backing up every %s (keep=%d)", cfg.every, cfg.keep)
+	ticker := time.NewTicker(cfg.every)
+	defer ticker.Stop()
+	for {
+		if err := runOnce(); err != nil {
+			log.Printf("Backup failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			log.Println("Stopping backups")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package main implements the loadgen CLI tool. It has three subcommands:
+//
+//   - populate fills a database with a large, deterministic set of posts (plus
+//     answers and approaches) so list, count and search queries run against
+//     realistic volumes.
+//   - clean removes everything populate created.
+//   - run drives a running API with a weighted mix of GET /v1/posts,
+//     GET /v1/search and POST /v1/posts requests and reports latency
+//     percentiles per scenario. It exits non-zero when a threshold is exceeded,
+//     so it can gate a deploy.
+//
+// Rows created by populate and by the create scenario carry the "loadgen" tag.
+//
+// Usage:
+//
+//	DATABASE_URL="postgres://..." go run ./cmd/loadgen populate --posts 300000
+//	DATABASE_URL="postgres://..." go run ./cmd/loadgen clean
+//	go run ./cmd/loadgen run --base-url http://localhost:8080 --duration 1m --max-p95 300ms
+//	go run ./cmd/loadgen run --api-key solvr_... --mix list=60,search=30,create=10
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+)
+
+// loadgenTag marks every row loadgen creates, so clean can find them.
+const loadgenTag = "loadgen"
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var err error
+	switch os.Args[1] {
+	case "populate":
+		err = populateCmd(ctx, os.Args[2:])
+	case "clean":
+		err = cleanCmd(ctx, os.Args[2:])
+	case "run":
+		err = runCmd(ctx, os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		stop()
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: loadgen <populate|clean|run> [flags]")
+}
+
+func populateCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("populate", flag.ExitOnError)
+	cfg := defaultPopulateConfig()
+	fs.IntVar(&cfg.Posts, "posts", cfg.Posts, "number of posts to add")
+	fs.IntVar(&cfg.BatchSize, "batch", cfg.BatchSize, "posts inserted per transaction")
+	fs.IntVar(&cfg.MaxAnswers, "answers", cfg.MaxAnswers, "maximum answers per question (0 to skip)")
+	fs.IntVar(&cfg.MaxApproaches, "approaches", cfg.MaxApproaches, "maximum approaches per problem (0 to skip)")
+	fs.IntVar(&cfg.Agents, "agents", cfg.Agents, "number of distinct agent authors")
+	force := fs.Bool("force", false, "populate even when APP_ENV=production")
+	fs.Parse(args)
+
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	pool, err := connect(ctx, *force)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return populate(ctx, pool, cfg, log.Printf)
+}
+
+func cleanCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("clean", flag.ExitOnError)
+	force := fs.Bool("force", false, "clean even when APP_ENV=production")
+	fs.Parse(args)
+
+	pool, err := connect(ctx, *force)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	return clean(ctx, pool, log.Printf)
+}
+
+func runCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	cfg := defaultRunConfig()
+	mix := fs.String("mix", "list=70,search=25,create=5", "scenario weights (list, search, create)")
+	fs.StringVar(&cfg.BaseURL, "base-url", cfg.BaseURL, "API base URL")
+	fs.StringVar(&cfg.APIKey, "api-key", os.Getenv("SOLVR_API_KEY"), "agent API key; the create scenario is skipped without one")
+	fs.DurationVar(&cfg.Duration, "duration", cfg.Duration, "how long to run")
+	fs.IntVar(&cfg.Concurrency, "concurrency", cfg.Concurrency, "concurrent workers")
+	fs.DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "per-request timeout")
+	fs.DurationVar(&cfg.MaxP95, "max-p95", 0, "fail when any scenario's p95 latency exceeds this (0 disables)")
+	fs.Float64Var(&cfg.MaxErrorRate, "max-error-rate", cfg.MaxErrorRate, "fail when any scenario's error rate exceeds this fraction")
+	fs.Parse(args)
+
+	weights, err := parseMix(*mix)
+	if err != nil {
+		return err
+	}
+	cfg.Weights = weights
+	if cfg.APIKey == "" && cfg.Weights[scenarioCreate] > 0 {
+		log.Printf("no --api-key given, skipping the %s scenario", scenarioCreate)
+		delete(cfg.Weights, scenarioCreate)
+	}
+
+	report, err := run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	report.print(os.Stdout)
+	if failures := report.check(cfg.MaxP95, cfg.MaxErrorRate); len(failures) > 0 {
+		for _, f := range failures {
+			fmt.Fprintln(os.Stderr, "FAIL:", f)
+		}
+		os.Exit(1)
+	}
+	return nil
+}
+
+// connect opens a pool from DATABASE_URL, refusing APP_ENV=production unless
+// forced.
+func connect(ctx context.Context, force bool) (*db.Pool, error) {
+	if os.Getenv("APP_ENV") == "production" && !force {
+		return nil, fmt.Errorf("refusing to run with APP_ENV=production (use --force to override)")
+	}
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return nil, fmt.Errorf("DATABASE_URL is required")
+	}
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	pool, err := db.NewPool(connectCtx, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	return pool, nil
+}
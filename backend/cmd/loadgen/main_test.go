@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseMix(t *testing.T) {
+	weights, err := parseMix("list=70, search=25,create=0")
+	if err != nil {
+		t.Fatalf("parseMix() error = %v", err)
+	}
+	if weights[scenarioList] != 70 || weights[scenarioSearch] != 25 || weights[scenarioCreate] != 0 {
+		t.Errorf("parseMix() = %v", weights)
+	}
+
+	for _, bad := range []string{"", "list", "list=-1", "list=x", "browse=5", "list=0,search=0"} {
+		if _, err := parseMix(bad); err == nil {
+			t.Errorf("parseMix(%q) expected error", bad)
+		}
+	}
+}
+
+func TestPicker_FollowsWeights(t *testing.T) {
+	p := newPicker(map[string]int{scenarioList: 3, scenarioSearch: 1, scenarioCreate: 0})
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	counts := map[string]int{}
+	for range 4000 {
+		counts[p.pick(rng)]++
+	}
+	if counts[scenarioCreate] != 0 {
+		t.Errorf("zero-weight scenario picked %d times", counts[scenarioCreate])
+	}
+	if got := counts[scenarioList]; got < 2800 || got > 3200 {
+		t.Errorf("list picked %d of 4000 times, want about 3000", got)
+	}
+}
+
+func TestBuildRequest(t *testing.T) {
+	cfg := runConfig{BaseURL: "http://api.test/", APIKey: "solvr_key"}
+	rng := rand.New(rand.NewPCG(3, 4))
+	ctx := context.Background()
+
+	list, err := buildRequest(ctx, cfg, scenarioList, rng)
+	if err != nil {
+		t.Fatalf("buildRequest(list) error = %v", err)
+	}
+	if list.URL.Path != "/v1/posts" || list.URL.Query().Get("sort") == "" {
+		t.Errorf("list request = %s", list.URL)
+	}
+
+	search, _ := buildRequest(ctx, cfg, scenarioSearch, rng)
+	if search.URL.Path != "/v1/search" || search.URL.Query().Get("q") == "" {
+		t.Errorf("search request = %s", search.URL)
+	}
+
+	create, _ := buildRequest(ctx, cfg, scenarioCreate, rng)
+	if create.Method != http.MethodPost || create.Header.Get("Authorization") != "Bearer solvr_key" {
+		t.Errorf("create request = %s %s", create.Method, create.Header)
+	}
+	var body struct {
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		Tags        []string `json:"tags"`
+	}
+	if err := json.NewDecoder(create.Body).Decode(&body); err != nil {
+		t.Fatalf("decode create body: %v", err)
+	}
+	if len(body.Title) < 10 || len(body.Description) < 50 || body.Tags[0] != loadgenTag {
+		t.Errorf("create body = %+v", body)
+	}
+}
+
+func TestScenarioStats_Percentile(t *testing.T) {
+	s := &scenarioStats{}
+	if got := s.percentile(95); got != 0 {
+		t.Errorf("percentile of no samples = %s, want 0", got)
+	}
+	for i := 1; i <= 100; i++ {
+		s.Latencies = append(s.Latencies, time.Duration(i)*time.Millisecond)
+	}
+	for p, want := range map[float64]time.Duration{50: 50 * time.Millisecond, 95: 95 * time.Millisecond, 100: 100 * time.Millisecond} {
+		if got := s.percentile(p); got != want {
+			t.Errorf("percentile(%v) = %s, want %s", p, got, want)
+		}
+	}
+}
+
+func TestRunReport_Check(t *testing.T) {
+	report := &runReport{Scenarios: []*scenarioStats{
+		{Name: scenarioList, Requests: 100, Errors: 0, Latencies: []time.Duration{10 * time.Millisecond}},
+		{Name: scenarioSearch, Requests: 100, Errors: 5, Latencies: []time.Duration{900 * time.Millisecond}},
+	}}
+
+	if failures := report.check(0, 0.1); len(failures) != 0 {
+		t.Errorf("check() with loose thresholds = %v", failures)
+	}
+	failures := report.check(500*time.Millisecond, 0.01)
+	if len(failures) != 2 || !strings.Contains(failures[0], "search p95") || !strings.Contains(failures[1], "search error rate") {
+		t.Errorf("check() = %v, want search p95 and error rate failures", failures)
+	}
+	if failures := (&runReport{}).check(0, 1); len(failures) != 1 {
+		t.Errorf("check() on empty report = %v", failures)
+	}
+}
+
+func TestRun_AgainstServer(t *testing.T) {
+	var creates atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/posts":
+			creates.Add(1)
+			w.WriteHeader(http.StatusCreated)
+		case r.URL.Path == "/v1/search":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	cfg := defaultRunConfig()
+	cfg.BaseURL = srv.URL
+	cfg.APIKey = "solvr_key"
+	cfg.Duration = 200 * time.Millisecond
+	cfg.Concurrency = 2
+	cfg.Weights = map[string]int{scenarioList: 1, scenarioSearch: 1, scenarioCreate: 1}
+
+	report, err := run(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if len(report.Scenarios) != 3 {
+		t.Fatalf("run() scenarios = %d, want 3", len(report.Scenarios))
+	}
+	for _, s := range report.Scenarios {
+		if s.Requests == 0 {
+			t.Errorf("%s: no requests", s.Name)
+		}
+		wantErrors := 0
+		if s.Name == scenarioSearch {
+			wantErrors = s.Requests
+		}
+		if s.Errors != wantErrors {
+			t.Errorf("%s: errors = %d, want %d", s.Name, s.Errors, wantErrors)
+		}
+	}
+	if creates.Load() == 0 {
+		t.Error("create scenario never reached the server")
+	}
+
+	var out strings.Builder
+	report.print(&out)
+	if !strings.Contains(out.String(), "429:") {
+		t.Errorf("report does not show status breakdown:\n%s", out.String())
+	}
+}
+
+func TestPopulateConfig_Validate(t *testing.T) {
+	if err := defaultPopulateConfig().validate(); err != nil {
+		t.Errorf("default config invalid: %v", err)
+	}
+	bad := defaultPopulateConfig()
+	bad.BatchSize = 0
+	if err := bad.validate(); err == nil {
+		t.Error("expected error for zero batch size")
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+)
+
+// topics and symptoms are combined into titles, descriptions and tags. The run
+// command searches for the same words, so search has matches at every volume.
+var (
+	topics = []string{
+		"postgres", "kubernetes", "docker", "golang", "python", "react",
+		"redis", "terraform", "nginx", "kafka", "graphql", "webpack",
+	}
+	symptoms = []string{
+		"connection timeout", "memory leak", "deadlock", "slow query",
+		"race condition", "permission denied", "out of memory", "panic on startup",
+		"certificate error", "stale cache",
+	}
+)
+
+type logFunc func(format string, args ...any)
+
+type populateConfig struct {
+	Posts         int
+	BatchSize     int
+	MaxAnswers    int
+	MaxApproaches int
+	Agents        int
+}
+
+func defaultPopulateConfig() populateConfig {
+	return populateConfig{
+		Posts:         100000,
+		BatchSize:     2000,
+		MaxAnswers:    4,
+		MaxApproaches: 3,
+		Agents:        50,
+	}
+}
+
+func (c populateConfig) validate() error {
+	switch {
+	case c.Posts < 1:
+		return fmt.Errorf("--posts must be positive")
+	case c.BatchSize < 1:
+		return fmt.Errorf("--batch must be positive")
+	case c.MaxAnswers < 0 || c.MaxApproaches < 0:
+		return fmt.Errorf("--answers and --approaches must not be negative")
+	case c.Agents < 1:
+		return fmt.Errorf("--agents must be positive")
+	}
+	return nil
+}
+
+// Post i gets its type from i % 10 (half problems, 30% questions, 20% ideas),
+// a status that fits the type, vote counts and an age of up to a year. Numbering
+// continues after existing loadgen posts, so repeated runs add rather than
+// duplicate.
+const insertPostsSQL = `
+	INSERT INTO posts (
+		type, title, description, tags,
+		posted_by_type, posted_by_id, status,
+		upvotes, downvotes, created_at, updated_at
+	)
+	SELECT
+		g.type,
+		format('%s: %s (#%s)', g.topic, g.symptom, g.i),
+		format('The %s service hits a %s after a routine deploy. Restarting helps for a while, then the %s comes back under load. Generated by loadgen, post %s.',
+			g.topic, g.symptom, g.symptom, g.i),
+		ARRAY[$6::text, g.topic],
+		'agent',
+		'loadgen_agent_' || (g.i % $5),
+		CASE g.type
+			WHEN 'problem' THEN (ARRAY['open', 'open', 'in_progress', 'solved'])[1 + (g.i / 10) % 4]
+			WHEN 'question' THEN (ARRAY['open', 'answered', 'solved'])[1 + (g.i / 10) % 3]
+			ELSE (ARRAY['open', 'active', 'dormant'])[1 + (g.i / 10) % 3]
+		END,
+		(g.i::bigint * 7919) % 60,
+		(g.i::bigint * 104729) % 6,
+		g.created_at,
+		g.created_at
+	FROM (
+		SELECT
+			i,
+			CASE WHEN i % 10 < 5 THEN 'problem' WHEN i % 10 < 8 THEN 'question' ELSE 'idea' END AS type,
+			($3::text[])[1 + i % cardinality($3::text[])] AS topic,
+			($4::text[])[1 + (i / 7) % cardinality($4::text[])] AS symptom,
+			NOW() - make_interval(secs => (i::bigint * 7907) % 31536000) AS created_at
+		FROM generate_series($1::int, $2::int) AS i
+	) g
+	RETURNING id, type`
+
+// Question number ord gets ord % (max + 1) answers, so counts range from zero to
+// the maximum; approaches are spread over problems the same way.
+const insertAnswersSQL = `
+	INSERT INTO answers (question_id, author_type, author_id, content)
+	SELECT q.id, 'agent', 'loadgen_agent_' || ((q.ord + n) % $3),
+		format('Answer %s: check the pool and timeout settings first, then capture a profile while the problem is happening.', n)
+	FROM unnest($1::uuid[]) WITH ORDINALITY AS q(id, ord)
+	CROSS JOIN LATERAL generate_series(1, (q.ord % ($2 + 1))::int) AS n`
+
+const insertApproachesSQL = `
+	INSERT INTO approaches (problem_id, author_type, author_id, angle, method)
+	SELECT p.id, 'agent', 'loadgen_agent_' || ((p.ord + n) % $3),
+		format('Approach %s: isolate the failing component', n),
+		'Reproduce locally with production-like data, then bisect configuration changes.'
+	FROM unnest($1::uuid[]) WITH ORDINALITY AS p(id, ord)
+	CROSS JOIN LATERAL generate_series(1, (p.ord % ($2 + 1))::int) AS n`
+
+// populate adds cfg.Posts posts in batches of cfg.BatchSize, one transaction per
+// batch, with answers for questions and approaches for problems. Counter columns
+// are kept up to date by the usual triggers.
+func populate(ctx context.Context, pool *db.Pool, cfg populateConfig, logf logFunc) error {
+	var existing int
+	if err := pool.QueryRow(ctx, `SELECT COUNT(*) FROM posts WHERE $1 = ANY(tags)`, loadgenTag).Scan(&existing); err != nil {
+		return fmt.Errorf("count existing posts: %w", err)
+	}
+
+	start := time.Now()
+	var answers, approaches int64
+	for done := 0; done < cfg.Posts; done += cfg.BatchSize {
+		from := existing + done
+		to := from + min(cfg.BatchSize, cfg.Posts-done) - 1
+
+		err := pool.WithTx(ctx, func(tx db.Tx) error {
+			a, p, err := insertBatch(ctx, tx, cfg, from, to)
+			answers += a
+			approaches += p
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("insert posts %d-%d: %w", from, to, err)
+		}
+		logf("inserted posts %d-%d (%.0f posts/s)", from, to, float64(done+to-from+1)/time.Since(start).Seconds())
+	}
+
+	logf("added %d posts, %d answers and %d approaches in %s; running ANALYZE",
+		cfg.Posts, answers, approaches, time.Since(start).Round(time.Second))
+	for _, table := range []string{"posts", "answers", "approaches"} {
+		if _, err := pool.Exec(ctx, "ANALYZE "+table); err != nil {
+			return fmt.Errorf("analyze %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// insertBatch inserts posts numbered from..to and their children, returning the
+// number of answers and approaches created.
+func insertBatch(ctx context.Context, tx db.Tx, cfg populateConfig, from, to int) (int64, int64, error) {
+	rows, err := tx.Query(ctx, insertPostsSQL, from, to, topics, symptoms, cfg.Agents, loadgenTag)
+	if err != nil {
+		return 0, 0, err
+	}
+	var questionIDs, problemIDs []string
+	for rows.Next() {
+		var id, postType string
+		if err := rows.Scan(&id, &postType); err != nil {
+			rows.Close()
+			return 0, 0, err
+		}
+		switch postType {
+		case "question":
+			questionIDs = append(questionIDs, id)
+		case "problem":
+			problemIDs = append(problemIDs, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	var answers, approaches int64
+	if cfg.MaxAnswers > 0 && len(questionIDs) > 0 {
+		tag, err := tx.Exec(ctx, insertAnswersSQL, questionIDs, cfg.MaxAnswers, cfg.Agents)
+		if err != nil {
+			return 0, 0, fmt.Errorf("insert answers: %w", err)
+		}
+		answers = tag.RowsAffected()
+	}
+	if cfg.MaxApproaches > 0 && len(problemIDs) > 0 {
+		tag, err := tx.Exec(ctx, insertApproachesSQL, problemIDs, cfg.MaxApproaches, cfg.Agents)
+		if err != nil {
+			return 0, 0, fmt.Errorf("insert approaches: %w", err)
+		}
+		approaches = tag.RowsAffected()
+	}
+	return answers, approaches, nil
+}
+
+// clean hard-deletes every loadgen post and the answers and approaches on them.
+func clean(ctx context.Context, pool *db.Pool, logf logFunc) error {
+	return pool.WithTx(ctx, func(tx db.Tx) error {
+		const loadgenPosts = `SELECT id FROM posts WHERE $1 = ANY(tags)`
+		answers, err := tx.Exec(ctx, `DELETE FROM answers WHERE question_id IN (`+loadgenPosts+`)`, loadgenTag)
+		if err != nil {
+			return fmt.Errorf("delete answers: %w", err)
+		}
+		approaches, err := tx.Exec(ctx, `DELETE FROM approaches WHERE problem_id IN (`+loadgenPosts+`)`, loadgenTag)
+		if err != nil {
+			return fmt.Errorf("delete approaches: %w", err)
+		}
+		posts, err := tx.Exec(ctx, `DELETE FROM posts WHERE $1 = ANY(tags)`, loadgenTag)
+		if err != nil {
+			return fmt.Errorf("delete posts: %w", err)
+		}
+		logf("removed %d posts, %d answers and %d approaches",
+			posts.RowsAffected(), answers.RowsAffected(), approaches.RowsAffected())
+		return nil
+	})
+}
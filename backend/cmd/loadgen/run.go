@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	scenarioList   = "list"
+	scenarioSearch = "search"
+	scenarioCreate = "create"
+)
+
+var (
+	listSorts = []string{"newest", "votes", "hot", "answers", "approaches"}
+	listTypes = []string{"", "problem", "question", "idea"}
+)
+
+type runConfig struct {
+	BaseURL      string
+	APIKey       string
+	Duration     time.Duration
+	Concurrency  int
+	Timeout      time.Duration
+	MaxP95       time.Duration
+	MaxErrorRate float64
+	Weights      map[string]int
+}
+
+func defaultRunConfig() runConfig {
+	return runConfig{
+		BaseURL:      "http://localhost:8080",
+		Duration:     30 * time.Second,
+		Concurrency:  8,
+		Timeout:      10 * time.Second,
+		MaxErrorRate: 0.01,
+	}
+}
+
+// parseMix parses scenario weights such as "list=70,search=25,create=5".
+func parseMix(s string) (map[string]int, error) {
+	weights := make(map[string]int)
+	total := 0
+	for _, part := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid mix entry %q (want name=weight)", part)
+		}
+		switch name {
+		case scenarioList, scenarioSearch, scenarioCreate:
+		default:
+			return nil, fmt.Errorf("unknown scenario %q (want list, search or create)", name)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q for %s", value, name)
+		}
+		weights[name] = weight
+		total += weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("mix %q has no positive weights", s)
+	}
+	return weights, nil
+}
+
+// picker chooses scenarios in proportion to their weights.
+type picker struct {
+	names      []string
+	cumulative []int
+}
+
+func newPicker(weights map[string]int) picker {
+	var p picker
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	total := 0
+	for _, name := range names {
+		if weights[name] > 0 {
+			total += weights[name]
+			p.names = append(p.names, name)
+			p.cumulative = append(p.cumulative, total)
+		}
+	}
+	return p
+}
+
+func (p picker) pick(rng *rand.Rand) string {
+	n := rng.IntN(p.cumulative[len(p.cumulative)-1])
+	i := sort.SearchInts(p.cumulative, n+1)
+	return p.names[i]
+}
+
+// buildRequest creates a randomized request for a scenario.
+func buildRequest(ctx context.Context, cfg runConfig, scenario string, rng *rand.Rand) (*http.Request, error) {
+	base := strings.TrimRight(cfg.BaseURL, "/")
+	switch scenario {
+	case scenarioList:
+		q := url.Values{}
+		q.Set("sort", listSorts[rng.IntN(len(listSorts))])
+		q.Set("page", strconv.Itoa(1+rng.IntN(5)))
+		q.Set("per_page", "20")
+		if t := listTypes[rng.IntN(len(listTypes))]; t != "" {
+			q.Set("type", t)
+		}
+		return http.NewRequestWithContext(ctx, http.MethodGet, base+"/v1/posts?"+q.Encode(), nil)
+
+	case scenarioSearch:
+		query := topics[rng.IntN(len(topics))]
+		if rng.IntN(2) == 0 {
+			query += " " + symptoms[rng.IntN(len(symptoms))]
+		}
+		q := url.Values{}
+		q.Set("q", query)
+		q.Set("page", strconv.Itoa(1+rng.IntN(3)))
+		return http.NewRequestWithContext(ctx, http.MethodGet, base+"/v1/search?"+q.Encode(), nil)
+
+	case scenarioCreate:
+		topic := topics[rng.IntN(len(topics))]
+		symptom := symptoms[rng.IntN(len(symptoms))]
+		body, err := json.Marshal(map[string]any{
+			"type":        []string{"problem", "question", "idea"}[rng.IntN(3)],
+			"title":       fmt.Sprintf("%s: %s during load test", topic, symptom),
+			"description": fmt.Sprintf("The %s service hits a %s while loadgen is running. This post was created by the load test and can be removed with loadgen clean.", topic, symptom),
+			"tags":        []string{loadgenTag, topic},
+		})
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/v1/posts", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+cfg.APIKey)
+		return req, nil
+	}
+	return nil, fmt.Errorf("unknown scenario %q", scenario)
+}
+
+// scenarioStats collects the outcome of one scenario's requests.
+type scenarioStats struct {
+	Name      string
+	Requests  int
+	Errors    int
+	Statuses  map[int]int
+	Latencies []time.Duration
+}
+
+func (s *scenarioStats) merge(o *scenarioStats) {
+	s.Requests += o.Requests
+	s.Errors += o.Errors
+	for code, n := range o.Statuses {
+		s.Statuses[code] += n
+	}
+	s.Latencies = append(s.Latencies, o.Latencies...)
+}
+
+func (s *scenarioStats) errorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// percentile returns the nearest-rank percentile of the latencies, which must be
+// sorted.
+func (s *scenarioStats) percentile(p float64) time.Duration {
+	if len(s.Latencies) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(s.Latencies))+0.5) - 1
+	rank = max(0, min(rank, len(s.Latencies)-1))
+	return s.Latencies[rank]
+}
+
+type runReport struct {
+	Elapsed   time.Duration
+	Scenarios []*scenarioStats
+}
+
+// run drives the API with cfg.Concurrency workers until cfg.Duration elapses or
+// ctx is cancelled.
+func run(ctx context.Context, cfg runConfig) (*runReport, error) {
+	if len(cfg.Weights) == 0 {
+		return nil, errors.New("no scenarios to run")
+	}
+	if cfg.Concurrency < 1 {
+		return nil, errors.New("--concurrency must be positive")
+	}
+	p := newPicker(cfg.Weights)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = cfg.Concurrency
+	client := &http.Client{Timeout: cfg.Timeout, Transport: transport}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	start := time.Now()
+	results := make([]map[string]*scenarioStats, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for w := range cfg.Concurrency {
+		results[w] = make(map[string]*scenarioStats)
+		wg.Add(1)
+		go func(stats map[string]*scenarioStats, rng *rand.Rand) {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				scenario := p.pick(rng)
+				req, err := buildRequest(ctx, cfg, scenario, rng)
+				if err != nil {
+					return
+				}
+				began := time.Now()
+				status, err := do(client, req)
+				if ctx.Err() != nil {
+					return // cut off by the end of the run, not a failure
+				}
+				s := stats[scenario]
+				if s == nil {
+					s = &scenarioStats{Name: scenario, Statuses: make(map[int]int)}
+					stats[scenario] = s
+				}
+				s.Requests++
+				s.Latencies = append(s.Latencies, time.Since(began))
+				s.Statuses[status]++
+				if err != nil || status >= 400 {
+					s.Errors++
+				}
+			}
+		}(results[w], rand.New(rand.NewPCG(uint64(start.UnixNano()), uint64(w))))
+	}
+	wg.Wait()
+
+	report := &runReport{Elapsed: time.Since(start)}
+	merged := make(map[string]*scenarioStats)
+	for _, stats := range results {
+		for name, s := range stats {
+			if merged[name] == nil {
+				merged[name] = &scenarioStats{Name: name, Statuses: make(map[int]int)}
+			}
+			merged[name].merge(s)
+		}
+	}
+	for _, s := range merged {
+		sort.Slice(s.Latencies, func(i, j int) bool { return s.Latencies[i] < s.Latencies[j] })
+		report.Scenarios = append(report.Scenarios, s)
+	}
+	sort.Slice(report.Scenarios, func(i, j int) bool { return report.Scenarios[i].Name < report.Scenarios[j].Name })
+	return report, nil
+}
+
+// do sends a request and drains the body so the connection is reused. Transport
+// errors are reported with status 0.
+func do(client *http.Client, req *http.Request) (int, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, err
+}
+
+func (r *runReport) print(out io.Writer) {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCENARIO\tREQUESTS\tRPS\tERRORS\tP50\tP95\tP99\tMAX\tSTATUSES")
+	for _, s := range r.Scenarios {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%d (%.1f%%)\t%s\t%s\t%s\t%s\t%s\n",
+			s.Name, s.Requests, float64(s.Requests)/r.Elapsed.Seconds(),
+			s.Errors, s.errorRate()*100,
+			s.percentile(50).Round(time.Microsecond*100), s.percentile(95).Round(time.Microsecond*100),
+			s.percentile(99).Round(time.Microsecond*100), s.percentile(100).Round(time.Microsecond*100),
+			formatStatuses(s.Statuses))
+	}
+	tw.Flush()
+}
+
+func formatStatuses(statuses map[int]int) string {
+	codes := make([]int, 0, len(statuses))
+	for code := range statuses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		label := strconv.Itoa(code)
+		if code == 0 {
+			label = "transport"
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d", label, statuses[code]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// check returns a description of every threshold the run exceeded. A maxP95 of
+// zero disables the latency check.
+func (r *runReport) check(maxP95 time.Duration, maxErrorRate float64) []string {
+	var failures []string
+	if len(r.Scenarios) == 0 {
+		return []string{"no requests completed"}
+	}
+	for _, s := range r.Scenarios {
+		if p95 := s.percentile(95); maxP95 > 0 && p95 > maxP95 {
+			failures = append(failures, fmt.Sprintf("%s p95 %s exceeds %s", s.Name, p95, maxP95))
+		}
+		if rate := s.errorRate(); rate > maxErrorRate {
+			failures = append(failures, fmt.Sprintf("%s error rate %.2f%% exceeds %.2f%%", s.Name, rate*100, maxErrorRate*100))
+		}
+	}
+	return failures
+}
@@ -0,0 +1,112 @@
+// Package main implements the migrate CLI tool. It applies the SQL migrations
+// embedded from backend/migrations and reads and writes the same schema_migrations
+// table as the golang-migrate CLI.
+//
+// Usage:
+//
+//	DATABASE_URL="postgres://..." go run ./cmd/migrate/ up
+//	DATABASE_URL="postgres://..." go run ./cmd/migrate/ down [N]   # default 1
+//	DATABASE_URL="postgres://..." go run ./cmd/migrate/ status
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/migrations"
+)
+
+const usage = "usage: migrate up | down [N] | status"
+
+// schemaMigrator is the subset of *db.Migrator used by the commands.
+type schemaMigrator interface {
+	Up(ctx context.Context) ([]db.Migration, error)
+	Down(ctx context.Context, steps int) ([]db.Migration, error)
+	Status(ctx context.Context) (models.SchemaStatus, error)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	pool, err := db.NewPool(connectCtx, databaseURL)
+	cancel()
+	if err != nil {
+		log.Fatalf("connect: %v", err)
+	}
+	defer pool.Close()
+
+	migrator, err := db.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		log.Fatalf("load migrations: %v", err)
+	}
+	if err := run(ctx, os.Args[1:], migrator, os.Stdout); err != nil {
+		pool.Close()
+		log.Fatal(err)
+	}
+}
+
+// run executes one command and prints what it did to out.
+func run(ctx context.Context, args []string, m schemaMigrator, out io.Writer) error {
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, mig := range applied {
+			fmt.Fprintf(out, "applied %06d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "no pending migrations")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("down: N must be a positive integer, got %q", args[1])
+			}
+			steps = n
+		}
+		reverted, err := m.Down(ctx, steps)
+		for _, mig := range reverted {
+			fmt.Fprintf(out, "reverted %06d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Fprintln(out, "nothing to revert")
+		}
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "current version:  %d\nexpected version: %d\npending:          %d\ndirty:            %t\n",
+			status.CurrentVersion, status.ExpectedVersion, status.Pending, status.Dirty)
+	default:
+		return errors.New(usage)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type fakeMigrator struct {
+	applied   []db.Migration
+	downSteps int
+	status    models.SchemaStatus
+	err       error
+}
+
+func (f *fakeMigrator) Up(ctx context.Context) ([]db.Migration, error) {
+	return f.applied, f.err
+}
+
+func (f *fakeMigrator) Down(ctx context.Context, steps int) ([]db.Migration, error) {
+	f.downSteps = steps
+	return f.applied, f.err
+}
+
+func (f *fakeMigrator) Status(ctx context.Context) (models.SchemaStatus, error) {
+	return f.status, f.err
+}
+
+func TestRun_Up(t *testing.T) {
+	m := &fakeMigrator{applied: []db.Migration{{Version: 104, Name: "add_post_stack_traces"}, {Version: 105, Name: "add_code_languages"}}}
+	var out bytes.Buffer
+
+	if err := run(context.Background(), []string{"up"}, m, &out); err != nil {
+		t.Fatalf("run() error: %v", err)
+	}
+	if !strings.Contains(out.String(), "applied 000104_add_post_stack_traces") || !strings.Contains(out.String(), "applied 000105_add_code_languages") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestRun_Down(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantSteps int
+		wantErr   bool
+	}{
+		{"default one step", []string{"down"}, 1, false},
+		{"explicit steps", []string{"down", "3"}, 3, false},
+		{"invalid steps", []string{"down", "zero"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &fakeMigrator{}
+			var out bytes.Buffer
+			err := run(context.Background(), tt.args, m, &out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if m.downSteps != tt.wantSteps {
+				t.Errorf("expected %d steps, got %d", tt.wantSteps, m.downSteps)
+			}
+		})
+	}
+}
+
+func TestRun_Status(t *testing.T) {
+	m := &fakeMigrator{status: models.SchemaStatus{CurrentVersion: 103, ExpectedVersion: 105, Pending: 2}}
+	var out bytes.Buffer
+
+	if err := run(context.Background(), []string{"status"}, m, &out); err != nil {
+		t.Fatalf("run() error: %v", err)
+	}
+	if !strings.Contains(out.String(), "current version:  103") || !strings.Contains(out.String(), "pending:          2") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	if err := run(context.Background(), []string{"sideways"}, &fakeMigrator{}, &bytes.Buffer{}); err == nil {
+		t.Error("expected usage error for unknown command")
+	}
+	m := &fakeMigrator{err: db.ErrDirtySchema}
+	if err := run(context.Background(), []string{"up"}, m, &bytes.Buffer{}); !errors.Is(err, db.ErrDirtySchema) {
+		t.Errorf("expected dirty schema error, got %v", err)
+	}
+}
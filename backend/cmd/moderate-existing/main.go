@@ -16,6 +16,7 @@ import (
 
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
 	"github.com/fcavalcantirj/solvr/internal/services"
 )
 
@@ -52,6 +53,7 @@ type moderationWorker struct {
 	batchSize int
 	delay     time.Duration
 	dryRun    bool
+	templates *modtemplate.Renderer // nil renders the built-in templates
 }
 
 // truncateTitle returns the first maxLen characters of title, appending "..." if truncated.
@@ -198,7 +200,7 @@ func (w *moderationWorker) rejectPost(ctx context.Context, postID string, result
 		return fmt.Errorf("update status: %w", err)
 	}
 
-	content := fmt.Sprintf(services.ModerationRejectedFormat, result.Explanation)
+	content := services.RenderModerationComment(ctx, w.templates, modtemplate.KeyRejected, postID, result)
 	if err := w.db.CreateSystemComment(ctx, postID, content); err != nil {
 		return fmt.Errorf("create comment: %w", err)
 	}
@@ -317,6 +319,7 @@ func main() {
 		batchSize: *batchSize,
 		delay:     *delay,
 		dryRun:    *dryRun,
+		templates: modtemplate.NewRenderer(db.NewModerationTemplateRepository(pool), modtemplate.AppealURLFromEnv()),
 	}
 
 	result, err := worker.run(ctx)
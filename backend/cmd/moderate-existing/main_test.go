@@ -236,7 +236,7 @@ func TestModerationWorker_RejectPost(t *testing.T) {
 		t.Errorf("expected comment on post-123, got: %v", mockDB.commentPostIDs)
 	}
 
-	// Verify comment content uses the ModerationRejectedFormat
+	// Verify comment content uses the built-in rejected template
 	expectedContent := "Post rejected by Solvr moderation.\n\nReason: Content is in Chinese, not English\n\nYou can edit your post and resubmit for review."
 	if mockDB.commentContents[0] != expectedContent {
 		t.Errorf("unexpected comment content:\ngot:  %q\nwant: %q", mockDB.commentContents[0], expectedContent)
@@ -0,0 +1,245 @@
+// Package main implements the restore CLI tool. It loads a dump written by
+// cmd/backup into a database migrated with cmd/migrate, for disaster recovery.
+// Rows already present are skipped, so an interrupted restore can be re-run.
+//
+// Usage:
+//
+//	DATABASE_URL="postgres://..." go run ./cmd/restore                       # newest dump under --prefix
+//	DATABASE_URL="postgres://..." go run ./cmd/restore --key backups/solvr-20261018T030000Z.ndjson.gz --dry-run=false
+//	DATABASE_URL="postgres://..." go run ./cmd/restore --file ./solvr-20261018T030000Z.ndjson.gz --dry-run=false
+//
+// Runs are dry runs unless --dry-run=false is given. Foreign keys and triggers
+// are suspended while rows load (session_replication_role = replica), which
+// needs a superuser or a role granted SET on that parameter; pass
+// --disable-triggers=false to restore without it.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/backup"
+	"github.com/fcavalcantirj/solvr/internal/config"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/services"
+	"github.com/fcavalcantirj/solvr/migrations"
+	"github.com/jackc/pgx/v5"
+)
+
+// pgRestoreDB implements restoreDB using a real PostgreSQL connection.
+type pgRestoreDB struct {
+	pool            *db.Pool
+	disableTriggers bool
+}
+
+func (d *pgRestoreDB) SchemaVersion(ctx context.Context) (int64, error) {
+	migrator, err := db.NewMigrator(d.pool, migrations.FS)
+	if err != nil {
+		return 0, err
+	}
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if status.Dirty {
+		return 0, errors.New("schema is dirty; repair the failed migration first")
+	}
+	return status.CurrentVersion, nil
+}
+
+func (d *pgRestoreDB) Columns(ctx context.Context, table string) ([]string, error) {
+	rows, err := d.pool.Query(ctx, `
+		SELECT column_name
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 AND is_generated = 'NEVER'
+		ORDER BY ordinal_position
+	`, table)
+	if err != nil {
+		return nil, fmt.Errorf("query columns: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, fmt.Errorf("scan column: %w", err)
+		}
+		columns = append(columns, c)
+	}
+	return columns, rows.Err()
+}
+
+func (d *pgRestoreDB) InsertRows(ctx context.Context, table string, columns []string, rows []json.RawMessage) (int, error) {
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = pgx.Identifier{c}.Sanitize()
+	}
+	list := strings.Join(quoted, ", ")
+	ident := pgx.Identifier{table}.Sanitize()
+
+	// Rows arrive as to_jsonb objects; jsonb_populate_recordset turns them back
+	// into the table's row type, parsing each value with its column's type.
+	payload, err := json.Marshal(rows)
+	if err != nil {
+		return 0, fmt.Errorf("encode rows: %w", err)
+	}
+
+	inserted := 0
+	err = d.pool.WithTx(ctx, func(tx db.Tx) error {
+		if d.disableTriggers {
+			if _, err := tx.Exec(ctx, `SET LOCAL session_replication_role = replica`); err != nil {
+				return fmt.Errorf("disable triggers (or pass --disable-triggers=false): %w", err)
+			}
+		}
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO `+ident+` (`+list+`)
+			SELECT `+list+` FROM jsonb_populate_recordset(NULL::`+ident+`, $1::jsonb)
+			ON CONFLICT DO NOTHING
+		`, string(payload))
+		if err != nil {
+			return err
+		}
+		inserted = int(tag.RowsAffected())
+		return nil
+	})
+	return inserted, err
+}
+
+// Ensure pgRestoreDB implements restoreDB at compile time.
+var _ restoreDB = (*pgRestoreDB)(nil)
+
+// cliConfig is the parsed command line.
+type cliConfig struct {
+	key             string
+	file            string
+	prefix          string
+	batchSize       int
+	dryRun          bool
+	disableTriggers bool
+}
+
+// parseFlags parses and validates the command line.
+func parseFlags(args []string) (*cliConfig, error) {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	key := fs.String("key", "", "object key of the dump to restore (default: newest under --prefix)")
+	file := fs.String("file", "", "restore a local dump file instead of one from the bucket")
+	prefix := fs.String("prefix", "backups/", "object key prefix searched for the newest dump")
+	batchSize := fs.Int("batch-size", 500, "number of rows inserted per statement")
+	dryRun := fs.Bool("dry-run", true, "read and check the dump without writing (default: true)")
+	disableTriggers := fs.Bool("disable-triggers", true, "suspend foreign keys and triggers while loading rows")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := &cliConfig{key: *key, file: *file, prefix: *prefix, batchSize: *batchSize, dryRun: *dryRun, disableTriggers: *disableTriggers}
+	if cfg.key != "" && cfg.file != "" {
+		return nil, errors.New("give --key or --file, not both")
+	}
+	if cfg.batchSize < 1 {
+		return nil, errors.New("--batch-size must be at least 1")
+	}
+	return cfg, nil
+}
+
+// openDump opens the dump named by cfg: a local file, a bucket key, or the
+// newest dump under the prefix.
+func openDump(ctx context.Context, cfg *cliConfig) (io.ReadCloser, string, error) {
+	if cfg.file != "" {
+		f, err := os.Open(cfg.file)
+		return f, cfg.file, err
+	}
+	store, err := services.NewS3Store(config.BackupStorageConfig())
+	if err != nil {
+		return nil, "", fmt.Errorf("backup storage: %w (set BACKUP_S3_BUCKET and credentials)", err)
+	}
+	key := cfg.key
+	if key == "" {
+		objects, err := store.List(ctx, cfg.prefix)
+		if err != nil {
+			return nil, "", err
+		}
+		keys := make([]string, len(objects))
+		for i, o := range objects {
+			keys[i] = o.Key
+		}
+		if key = backup.Latest(cfg.prefix, keys); key == "" {
+			return nil, "", fmt.Errorf("no dumps under %q", cfg.prefix)
+		}
+	}
+	body, err := store.Get(ctx, key)
+	return body, key, err
+}
+
+func main() {
+	cfg, err := parseFlags(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	pool, err := db.NewPool(connectCtx, databaseURL)
+	cancel()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	body, source, err := openDump(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Open dump: %v", err)
+	}
+	defer body.Close()
+	dump, err := backup.NewReader(body)
+	if err != nil {
+		log.Fatalf("Read %s: %v", source, err)
+	}
+
+	mode := "LIVE"
+	if cfg.dryRun {
+		mode = "DRY RUN"
+	}
+	h := dump.Header()
+	log.Printf("[%s] Restoring %s (taken %s, schema %d, embeddings=%v)",
+		mode, source, h.CreatedAt.Format(time.RFC3339), h.SchemaVersion, h.Embeddings)
+
+	worker := &restoreWorker{
+		db:        &pgRestoreDB{pool: pool, disableTriggers: cfg.disableTriggers},
+		batchSize: cfg.batchSize,
+		dryRun:    cfg.dryRun,
+	}
+	result, err := worker.run(ctx, dump)
+	if result != nil {
+		for _, table := range backup.Tables {
+			log.Printf("%s: read=%d inserted=%d", table, result.read[table], result.inserted[table])
+		}
+	}
+	if err != nil {
+		log.Fatalf("Restore failed: %v", err)
+	}
+	if !cfg.dryRun && !h.Embeddings {
+		log.Println("Dump has no embeddings; run cmd/backfill-embeddings to regenerate them")
+	}
+}
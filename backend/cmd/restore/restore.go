@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/fcavalcantirj/solvr/internal/backup"
+)
+
+// restoreDB abstracts database operations for testing.
+type restoreDB interface {
+	// SchemaVersion returns the last migration applied to the database.
+	SchemaVersion(ctx context.Context) (int64, error)
+	// Columns returns the insertable (non-generated) columns of table.
+	Columns(ctx context.Context, table string) ([]string, error)
+	// InsertRows inserts rows into table, setting only columns, and skips rows
+	// that conflict with existing ones. It returns the number inserted.
+	InsertRows(ctx context.Context, table string, columns []string, rows []json.RawMessage) (int, error)
+}
+
+// restoreResult summarises a restore.
+type restoreResult struct {
+	read     map[string]int
+	inserted map[string]int
+}
+
+// restoreWorker loads a dump into the database in batches.
+type restoreWorker struct {
+	db        restoreDB
+	batchSize int
+	dryRun    bool
+	logf      func(format string, args ...interface{})
+
+	columns map[string][]string // insertable columns per table
+	dropped map[string]bool     // "table.column" in the dump but not the database, warned once
+}
+
+func (w *restoreWorker) printf(format string, args ...interface{}) {
+	if w.logf != nil {
+		w.logf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// run restores every row of the dump on r. Rows already in the database are
+// skipped, so a failed restore can simply be run again. In a dry run the dump is
+// read and checked but nothing is written.
+func (w *restoreWorker) run(ctx context.Context, r *backup.Reader) (*restoreResult, error) {
+	h := r.Header()
+	version, err := w.db.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+	if h.SchemaVersion > version {
+		return nil, fmt.Errorf("dump is from schema version %d but the database is at %d; run cmd/migrate up first", h.SchemaVersion, version)
+	}
+	if h.SchemaVersion < version {
+		w.printf("Dump is from schema version %d, database is at %d: newer columns get their defaults", h.SchemaVersion, version)
+	}
+
+	known := make(map[string]bool, len(backup.Tables))
+	for _, t := range backup.Tables {
+		known[t] = true
+	}
+	w.columns = map[string][]string{}
+	w.dropped = map[string]bool{}
+
+	result := &restoreResult{read: map[string]int{}, inserted: map[string]int{}}
+	var table string
+	var batch []json.RawMessage
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := w.insert(ctx, table, batch)
+		if err != nil {
+			return fmt.Errorf("restore %s: %w", table, err)
+		}
+		result.inserted[table] += n
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		t, row, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+		if !known[t] {
+			return result, fmt.Errorf("dump contains unknown table %q", t)
+		}
+		if t != table || len(batch) >= w.batchSize {
+			if err := flush(); err != nil {
+				return result, err
+			}
+			table = t
+		}
+		batch = append(batch, row)
+		result.read[t]++
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// insert writes one batch of table rows, limited to the columns both the dump
+// and the database have. In a dry run it only checks the rows decode.
+func (w *restoreWorker) insert(ctx context.Context, table string, rows []json.RawMessage) (int, error) {
+	present := map[string]bool{}
+	for _, row := range rows {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(row, &fields); err != nil {
+			return 0, fmt.Errorf("decode row: %w", err)
+		}
+		for name := range fields {
+			present[name] = true
+		}
+	}
+	if w.dryRun {
+		return 0, nil
+	}
+
+	dbColumns, ok := w.columns[table]
+	if !ok {
+		var err error
+		if dbColumns, err = w.db.Columns(ctx, table); err != nil {
+			return 0, fmt.Errorf("read columns: %w", err)
+		}
+		if len(dbColumns) == 0 {
+			return 0, errors.New("table not found; run cmd/migrate up first")
+		}
+		w.columns[table] = dbColumns
+	}
+
+	inDB := make(map[string]bool, len(dbColumns))
+	var columns []string
+	for _, c := range dbColumns {
+		inDB[c] = true
+		if present[c] {
+			columns = append(columns, c)
+		}
+	}
+	var missing []string
+	for name := range present {
+		if !inDB[name] && !w.dropped[table+"."+name] {
+			w.dropped[table+"."+name] = true
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		w.printf("Skipping %s columns the database does not have: %v", table, missing)
+	}
+	return w.db.InsertRows(ctx, table, columns, rows)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/backup"
+)
+
+// mockRestoreDB is a test double for restoreDB that keeps rows by ID.
+type mockRestoreDB struct {
+	version int64
+	columns map[string][]string
+	rows    map[string]map[string]bool // table -> IDs present
+	inserts []string                   // "table:columns:n" per InsertRows call
+}
+
+func (m *mockRestoreDB) SchemaVersion(context.Context) (int64, error) { return m.version, nil }
+
+func (m *mockRestoreDB) Columns(_ context.Context, table string) ([]string, error) {
+	return m.columns[table], nil
+}
+
+func (m *mockRestoreDB) InsertRows(_ context.Context, table string, columns []string, rows []json.RawMessage) (int, error) {
+	m.inserts = append(m.inserts, fmt.Sprintf("%s:%s:%d", table, strings.Join(columns, ","), len(rows)))
+	if m.rows[table] == nil {
+		m.rows[table] = map[string]bool{}
+	}
+	inserted := 0
+	for _, row := range rows {
+		var r struct{ ID string }
+		if err := json.Unmarshal(row, &r); err != nil {
+			return inserted, err
+		}
+		if !m.rows[table][r.ID] {
+			m.rows[table][r.ID] = true
+			inserted++
+		}
+	}
+	return inserted, nil
+}
+
+func newMockRestoreDB() *mockRestoreDB {
+	return &mockRestoreDB{
+		version: 116,
+		columns: map[string][]string{
+			"posts":      {"id", "title", "tenant_id"},
+			"answers":    {"id", "question_id"},
+			"approaches": {"id"},
+			"comments":   {"id"},
+		},
+		rows: map[string]map[string]bool{},
+	}
+}
+
+func dumpReader(t *testing.T, schemaVersion int64, rows map[string][]string) *backup.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := backup.NewWriter(&buf, backup.Header{CreatedAt: time.Now(), SchemaVersion: schemaVersion, Tables: backup.Tables})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	for _, table := range backup.Tables {
+		for _, row := range rows[table] {
+			if err := w.WriteRow(table, json.RawMessage(row)); err != nil {
+				t.Fatalf("WriteRow() error = %v", err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	r, err := backup.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	return r
+}
+
+func quietRestoreWorker(db restoreDB, dryRun bool) *restoreWorker {
+	return &restoreWorker{db: db, batchSize: 2, dryRun: dryRun, logf: func(string, ...interface{}) {}}
+}
+
+var testDumpRows = map[string][]string{
+	"posts":   {`{"id":"p1","title":"A","legacy":1}`, `{"id":"p2","title":"B","legacy":2}`, `{"id":"p3","title":"C","legacy":3}`},
+	"answers": {`{"id":"a1","question_id":"p1"}`},
+}
+
+func TestRestoreWorker_Run(t *testing.T) {
+	db := newMockRestoreDB()
+	result, err := quietRestoreWorker(db, false).run(context.Background(), dumpReader(t, 115, testDumpRows))
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	// Batches of two, split at table boundaries; columns the database lacks
+	// (legacy) are dropped and columns the dump lacks (tenant_id) left to defaults.
+	wantInserts := []string{"posts:id,title:2", "posts:id,title:1", "answers:id,question_id:1"}
+	if !reflect.DeepEqual(db.inserts, wantInserts) {
+		t.Errorf("inserts = %v, want %v", db.inserts, wantInserts)
+	}
+	if result.read["posts"] != 3 || result.inserted["posts"] != 3 || result.inserted["answers"] != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	// A second run skips every row already restored.
+	result, err = quietRestoreWorker(db, false).run(context.Background(), dumpReader(t, 115, testDumpRows))
+	if err != nil {
+		t.Fatalf("second run() error = %v", err)
+	}
+	if result.read["posts"] != 3 || result.inserted["posts"] != 0 {
+		t.Errorf("expected re-run to insert nothing, got %+v", result)
+	}
+}
+
+func TestRestoreWorker_DryRunWritesNothing(t *testing.T) {
+	db := newMockRestoreDB()
+	result, err := quietRestoreWorker(db, true).run(context.Background(), dumpReader(t, 116, testDumpRows))
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if len(db.inserts) != 0 {
+		t.Errorf("expected no inserts, got %v", db.inserts)
+	}
+	if result.read["posts"] != 3 || result.read["answers"] != 1 {
+		t.Errorf("unexpected read counts %v", result.read)
+	}
+}
+
+func TestRestoreWorker_RejectsNewerSchema(t *testing.T) {
+	db := newMockRestoreDB()
+	if _, err := quietRestoreWorker(db, false).run(context.Background(), dumpReader(t, 117, testDumpRows)); err == nil {
+		t.Fatal("expected an error for a dump newer than the database schema")
+	}
+	if len(db.inserts) != 0 {
+		t.Errorf("expected no inserts, got %v", db.inserts)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"defaults", nil, false},
+		{"key", []string{"--key", "backups/solvr-20261018T030000Z.ndjson.gz", "--dry-run=false"}, false},
+		{"key and file", []string{"--key", "k", "--file", "f"}, true},
+		{"bad batch size", []string{"--batch-size", "0"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tt.name == "defaults" && (!cfg.dryRun || !cfg.disableTriggers) {
+				t.Errorf("expected dry run with triggers disabled by default, got %+v", cfg)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+// Package main implements the retag CLI tool. It adds, removes and renames tags
+// on every post matching a filter, in batches. Each changed post gets an
+// audit_log record holding its tags before and after, under the run's ID, so a
+// run can be undone with --undo.
+//
+// Usage:
+//
+//	DATABASE_URL="postgres://..." go run ./cmd/retag --tag golang --rename golang=go
+//	DATABASE_URL="postgres://..." go run ./cmd/retag --query kubernetes --since 2025-01-01 --add k8s --dry-run=false
+//	DATABASE_URL="postgres://..." go run ./cmd/retag --undo <run-id> --dry-run=false
+//
+// Runs are dry runs unless --dry-run=false is given.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/google/uuid"
+)
+
+// auditActorID identifies the tool in audit_log.
+const auditActorID = "retag-cli"
+
+// dateLayout is the layout of --since and --until.
+const dateLayout = "2006-01-02"
+
+// pgRetagDB implements retagDB using a real PostgreSQL connection.
+type pgRetagDB struct {
+	pool *db.Pool
+}
+
+func (d *pgRetagDB) FindPosts(ctx context.Context, filter postFilter, afterID string, limit int) ([]taggedPost, error) {
+	var since, until *time.Time
+	if !filter.Since.IsZero() {
+		since = &filter.Since
+	}
+	if !filter.Until.IsZero() {
+		until = &filter.Until
+	}
+
+	rows, err := d.pool.Query(ctx, `
+		SELECT id, title, COALESCE(tags, '{}')
+		FROM posts
+		WHERE deleted_at IS NULL
+			AND ($1 = '' OR id > $1::uuid)
+			AND ($2 = '' OR $2 = ANY(tags))
+			AND ($3 = '' OR title ILIKE '%' || $3 || '%' OR description ILIKE '%' || $3 || '%')
+			AND ($4::timestamptz IS NULL OR created_at >= $4)
+			AND ($5::timestamptz IS NULL OR created_at < $5)
+			AND (COALESCE(cardinality($6::text[]), 0) = 0 OR tags && $6::text[])
+		ORDER BY id
+		LIMIT $7
+	`, afterID, filter.Tag, filter.Query, since, until, filter.AnyTags, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query posts: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []taggedPost
+	for rows.Next() {
+		var p taggedPost
+		if err := rows.Scan(&p.ID, &p.Title, &p.Tags); err != nil {
+			return nil, fmt.Errorf("scan post: %w", err)
+		}
+		posts = append(posts, p)
+	}
+	return posts, rows.Err()
+}
+
+func (d *pgRetagDB) ApplyChange(ctx context.Context, change tagChange, action, runID string) (bool, error) {
+	details, err := json.Marshal(map[string]interface{}{
+		"run_id": runID,
+		"before": change.Before,
+		"after":  change.After,
+	})
+	if err != nil {
+		return false, fmt.Errorf("marshal audit details: %w", err)
+	}
+
+	// A NULL array would never compare equal to the post's tags.
+	before, after := change.Before, change.After
+	if before == nil {
+		before = []string{}
+	}
+	if after == nil {
+		after = []string{}
+	}
+
+	applied := false
+	err = d.pool.WithTx(ctx, func(tx db.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE posts SET tags = $2, updated_at = NOW()
+			WHERE id = $1 AND COALESCE(tags, '{}') = $3::text[]
+		`, change.PostID, after, before)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+		applied = true
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO audit_log (actor_type, actor_id, action, route, path, target_type, target_id, status_code, details)
+			VALUES ($1, $2, $3, 'cmd/retag', '', 'post', $4, 200, $5)
+		`, models.AuditActorAdmin, auditActorID, action, change.PostID, details)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
+
+func (d *pgRetagDB) RunChanges(ctx context.Context, runID string) ([]tagChange, error) {
+	rows, err := d.pool.Query(ctx, `
+		SELECT target_id, details
+		FROM audit_log
+		WHERE action = $1 AND actor_id = $2 AND details->>'run_id' = $3
+		ORDER BY created_at DESC
+	`, auditActionRetag, auditActorID, runID)
+	if err != nil {
+		return nil, fmt.Errorf("query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []tagChange
+	for rows.Next() {
+		var postID string
+		var raw []byte
+		if err := rows.Scan(&postID, &raw); err != nil {
+			return nil, fmt.Errorf("scan audit entry: %w", err)
+		}
+		var details struct {
+			Before []string `json:"before"`
+			After  []string `json:"after"`
+		}
+		if err := json.Unmarshal(raw, &details); err != nil {
+			return nil, fmt.Errorf("decode audit details for post %s: %w", postID, err)
+		}
+		changes = append(changes, tagChange{PostID: postID, Before: details.Before, After: details.After})
+	}
+	return changes, rows.Err()
+}
+
+// Ensure pgRetagDB implements retagDB at compile time.
+var _ retagDB = (*pgRetagDB)(nil)
+
+// config is the parsed command line.
+type config struct {
+	filter    postFilter
+	ops       tagOps
+	undoRunID string
+	batchSize int
+	dryRun    bool
+}
+
+// parseFlags parses and validates the command line.
+func parseFlags(args []string) (*config, error) {
+	fs := flag.NewFlagSet("retag", flag.ContinueOnError)
+	tag := fs.String("tag", "", "only posts carrying this tag")
+	query := fs.String("query", "", "only posts whose title or description contains this text")
+	since := fs.String("since", "", "only posts created on or after this date (YYYY-MM-DD)")
+	until := fs.String("until", "", "only posts created on or before this date (YYYY-MM-DD)")
+	add := fs.String("add", "", "comma-separated tags to add")
+	remove := fs.String("remove", "", "comma-separated tags to remove")
+	rename := fs.String("rename", "", "comma-separated renames, e.g. golang=go,js=javascript")
+	undo := fs.String("undo", "", "restore the tags changed by this run ID")
+	batchSize := fs.Int("batch-size", 100, "number of posts to process per batch")
+	dryRun := fs.Bool("dry-run", true, "preview changes without writing them (default: true)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := &config{undoRunID: *undo, batchSize: *batchSize, dryRun: *dryRun}
+	if cfg.batchSize < 1 {
+		return nil, errors.New("--batch-size must be at least 1")
+	}
+	if cfg.undoRunID != "" {
+		if *tag != "" || *query != "" || *since != "" || *until != "" || *add != "" || *remove != "" || *rename != "" {
+			return nil, errors.New("--undo cannot be combined with filters or operations")
+		}
+		return cfg, nil
+	}
+
+	var err error
+	cfg.filter.Tag = models.NormalizeTag(*tag)
+	cfg.filter.Query = *query
+	if *since != "" {
+		if cfg.filter.Since, err = time.Parse(dateLayout, *since); err != nil {
+			return nil, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if *until != "" {
+		if cfg.filter.Until, err = time.Parse(dateLayout, *until); err != nil {
+			return nil, fmt.Errorf("invalid --until: %w", err)
+		}
+		cfg.filter.Until = cfg.filter.Until.AddDate(0, 0, 1)
+	}
+	if cfg.ops.Add, err = parseTagList(*add); err != nil {
+		return nil, fmt.Errorf("invalid --add: %w", err)
+	}
+	if cfg.ops.Remove, err = parseTagList(*remove); err != nil {
+		return nil, fmt.Errorf("invalid --remove: %w", err)
+	}
+	if cfg.ops.Rename, err = parseRenames(*rename); err != nil {
+		return nil, fmt.Errorf("invalid --rename: %w", err)
+	}
+
+	if cfg.ops.empty() {
+		return nil, errors.New("nothing to do: give --add, --remove or --rename")
+	}
+	if len(cfg.ops.Add) == 0 {
+		// Renames and removals only affect posts carrying the tags they touch.
+		cfg.filter.AnyTags = cfg.ops.touchedTags()
+	} else if cfg.filter.Tag == "" && cfg.filter.Query == "" && *since == "" && *until == "" {
+		return nil, errors.New("--add needs a filter (--tag, --query, --since or --until)")
+	}
+	return cfg, nil
+}
+
+func main() {
+	cfg, err := parseFlags(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	ctx := context.Background()
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	pool, err := db.NewPool(connectCtx, databaseURL)
+	cancel()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	worker := &retagWorker{
+		db:        &pgRetagDB{pool: pool},
+		batchSize: cfg.batchSize,
+		dryRun:    cfg.dryRun,
+		runID:     uuid.NewString(),
+	}
+
+	mode := "LIVE"
+	if cfg.dryRun {
+		mode = "DRY RUN"
+	}
+
+	var result *retagResult
+	if cfg.undoRunID != "" {
+		log.Printf("[%s] Undoing retag run %s", mode, cfg.undoRunID)
+		result, err = worker.undo(ctx, cfg.undoRunID)
+	} else {
+		log.Printf("[%s] Retagging posts (batch_size=%d)", mode, cfg.batchSize)
+		result, err = worker.run(ctx, cfg.filter, cfg.ops)
+	}
+	if result != nil {
+		log.Printf("Done: scanned=%d changed=%d skipped=%d unchanged=%d",
+			result.scanned, result.changed, result.skipped, result.unchanged)
+	}
+	if err != nil {
+		log.Fatalf("Retag failed: %v", err)
+	}
+	if !cfg.dryRun && result.changed > 0 {
+		log.Printf("Run ID: %s (undo with --undo %s)", worker.runID, worker.runID)
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Audit actions recorded for each changed post.
+const (
+	auditActionRetag     = "retag"
+	auditActionRetagUndo = "retag_undo"
+)
+
+// postFilter selects the posts a run looks at. Zero fields don't filter.
+type postFilter struct {
+	Tag   string    // posts carrying this tag
+	Query string    // case-insensitive match on title or description
+	Since time.Time // created at or after
+	Until time.Time // created before
+	// AnyTags limits the run to posts carrying at least one of these tags. It is
+	// set from the operations when they only rename or remove tags.
+	AnyTags []string
+}
+
+// tagOps are the changes applied to every selected post, in order: renames,
+// then removals, then additions.
+type tagOps struct {
+	Rename map[string]string
+	Remove []string
+	Add    []string
+}
+
+// taggedPost is a post and its current tags.
+type taggedPost struct {
+	ID    string
+	Title string
+	Tags  []string
+}
+
+// tagChange is one post's tags before and after a run. It is stored in the
+// audit record so the run can be undone.
+type tagChange struct {
+	PostID string
+	Before []string
+	After  []string
+}
+
+// retagDB abstracts database operations for testing.
+type retagDB interface {
+	// FindPosts returns up to limit posts matching filter with IDs after afterID, by ID.
+	FindPosts(ctx context.Context, filter postFilter, afterID string, limit int) ([]taggedPost, error)
+	// ApplyChange sets the post's tags to change.After and writes an audit record
+	// with action and runID. It returns false without changing anything when the
+	// post's tags are no longer change.Before.
+	ApplyChange(ctx context.Context, change tagChange, action, runID string) (bool, error)
+	// RunChanges returns the changes recorded for a run, most recent first.
+	RunChanges(ctx context.Context, runID string) ([]tagChange, error)
+}
+
+// retagResult summarises a run.
+type retagResult struct {
+	scanned   int
+	changed   int
+	skipped   int // conflicting concurrent edits, or too many tags
+	unchanged int
+}
+
+// parseTagList parses a comma-separated tag list, normalizing and validating each tag.
+func parseTagList(s string) ([]string, error) {
+	var tags []string
+	for _, raw := range strings.Split(s, ",") {
+		tag := models.NormalizeTag(raw)
+		if tag == "" {
+			continue
+		}
+		if !models.IsValidTag(tag) {
+			return nil, fmt.Errorf("invalid tag %q", tag)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// parseRenames parses "old=new,old2=new2".
+func parseRenames(s string) (map[string]string, error) {
+	renames := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		oldName, newName, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rename %q, want old=new", pair)
+		}
+		oldName, newName = models.NormalizeTag(oldName), models.NormalizeTag(newName)
+		if !models.IsValidTag(oldName) || !models.IsValidTag(newName) {
+			return nil, fmt.Errorf("invalid rename %q", pair)
+		}
+		renames[oldName] = newName
+	}
+	return renames, nil
+}
+
+// empty reports whether ops would change nothing.
+func (o tagOps) empty() bool {
+	return len(o.Rename) == 0 && len(o.Remove) == 0 && len(o.Add) == 0
+}
+
+// touchedTags returns the tags a post must carry for renames and removals to
+// affect it.
+func (o tagOps) touchedTags() []string {
+	var tags []string
+	for oldName := range o.Rename {
+		tags = append(tags, oldName)
+	}
+	return append(tags, o.Remove...)
+}
+
+// apply returns tags with ops applied, keeping their order and dropping the
+// duplicates a rename creates.
+func (o tagOps) apply(tags []string) []string {
+	removed := make(map[string]bool, len(o.Remove))
+	for _, tag := range o.Remove {
+		removed[tag] = true
+	}
+
+	seen := map[string]bool{}
+	result := []string{}
+	add := func(tag string) {
+		if !seen[tag] && !removed[tag] {
+			seen[tag] = true
+			result = append(result, tag)
+		}
+	}
+	for _, tag := range tags {
+		if newName, ok := o.Rename[tag]; ok {
+			tag = newName
+		}
+		add(tag)
+	}
+	for _, tag := range o.Add {
+		add(tag)
+	}
+	return result
+}
+
+// sameTags reports whether a and b hold the same tags in the same order.
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// retagWorker applies tag operations to matching posts in batches.
+type retagWorker struct {
+	db        retagDB
+	batchSize int
+	dryRun    bool
+	runID     string
+	logf      func(format string, args ...interface{})
+}
+
+func (w *retagWorker) printf(format string, args ...interface{}) {
+	if w.logf != nil {
+		w.logf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
+
+// run applies ops to every post matching filter.
+func (w *retagWorker) run(ctx context.Context, filter postFilter, ops tagOps) (*retagResult, error) {
+	result := &retagResult{}
+	afterID := ""
+	for {
+		posts, err := w.db.FindPosts(ctx, filter, afterID, w.batchSize)
+		if err != nil {
+			return result, fmt.Errorf("find posts: %w", err)
+		}
+		if len(posts) == 0 {
+			return result, nil
+		}
+
+		for _, post := range posts {
+			result.scanned++
+			after := ops.apply(post.Tags)
+			switch {
+			case sameTags(post.Tags, after):
+				result.unchanged++
+				continue
+			case len(after) > models.MaxTagsPerPost:
+				w.printf("SKIP %s: would have %d tags (max %d)", post.ID, len(after), models.MaxTagsPerPost)
+				result.skipped++
+				continue
+			}
+
+			change := tagChange{PostID: post.ID, Before: post.Tags, After: after}
+			if w.dryRun {
+				w.printf("[DRY RUN] %s %q: %v -> %v", post.ID, post.Title, change.Before, change.After)
+				result.changed++
+				continue
+			}
+
+			applied, err := w.db.ApplyChange(ctx, change, auditActionRetag, w.runID)
+			if err != nil {
+				return result, fmt.Errorf("retag post %s: %w", post.ID, err)
+			}
+			if !applied {
+				w.printf("SKIP %s: tags changed during the run", post.ID)
+				result.skipped++
+				continue
+			}
+			w.printf("%s %q: %v -> %v", post.ID, post.Title, change.Before, change.After)
+			result.changed++
+		}
+
+		afterID = posts[len(posts)-1].ID
+		if len(posts) < w.batchSize {
+			return result, nil
+		}
+	}
+}
+
+// undo restores the tags a previous run changed. Posts whose tags were edited
+// since are skipped.
+func (w *retagWorker) undo(ctx context.Context, undoRunID string) (*retagResult, error) {
+	changes, err := w.db.RunChanges(ctx, undoRunID)
+	if err != nil {
+		return nil, fmt.Errorf("load run %s: %w", undoRunID, err)
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no changes recorded for run %s", undoRunID)
+	}
+
+	result := &retagResult{}
+	for _, c := range changes {
+		result.scanned++
+		reverse := tagChange{PostID: c.PostID, Before: c.After, After: c.Before}
+		if w.dryRun {
+			w.printf("[DRY RUN] %s: %v -> %v", c.PostID, reverse.Before, reverse.After)
+			result.changed++
+			continue
+		}
+
+		applied, err := w.db.ApplyChange(ctx, reverse, auditActionRetagUndo, w.runID)
+		if err != nil {
+			return result, fmt.Errorf("restore post %s: %w", c.PostID, err)
+		}
+		if !applied {
+			w.printf("SKIP %s: tags changed since the run", c.PostID)
+			result.skipped++
+			continue
+		}
+		w.printf("%s: %v -> %v", c.PostID, reverse.Before, reverse.After)
+		result.changed++
+	}
+	return result, nil
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// mockRetagDB is a test double for retagDB holding posts sorted by ID.
+type mockRetagDB struct {
+	posts     []taggedPost
+	conflicts map[string]bool // post IDs whose ApplyChange reports a concurrent edit
+	applyErr  error
+	applied   []tagChange
+	actions   []string
+	runs      map[string][]tagChange
+}
+
+func (m *mockRetagDB) FindPosts(_ context.Context, _ postFilter, afterID string, limit int) ([]taggedPost, error) {
+	var page []taggedPost
+	for _, p := range m.posts {
+		if p.ID > afterID && len(page) < limit {
+			page = append(page, p)
+		}
+	}
+	return page, nil
+}
+
+func (m *mockRetagDB) ApplyChange(_ context.Context, change tagChange, action, runID string) (bool, error) {
+	if m.applyErr != nil {
+		return false, m.applyErr
+	}
+	if m.conflicts[change.PostID] {
+		return false, nil
+	}
+	m.applied = append(m.applied, change)
+	m.actions = append(m.actions, action)
+	return true, nil
+}
+
+func (m *mockRetagDB) RunChanges(_ context.Context, runID string) ([]tagChange, error) {
+	return m.runs[runID], nil
+}
+
+func quietWorker(db retagDB, dryRun bool) *retagWorker {
+	return &retagWorker{db: db, batchSize: 2, dryRun: dryRun, runID: "run-2", logf: func(string, ...interface{}) {}}
+}
+
+func TestTagOps_Apply(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  tagOps
+		tags []string
+		want []string
+	}{
+		{"rename keeps order", tagOps{Rename: map[string]string{"golang": "go"}}, []string{"api", "golang", "http"}, []string{"api", "go", "http"}},
+		{"rename drops duplicate", tagOps{Rename: map[string]string{"golang": "go"}}, []string{"go", "golang"}, []string{"go"}},
+		{"remove", tagOps{Remove: []string{"old"}}, []string{"old", "new"}, []string{"new"}},
+		{"add appends once", tagOps{Add: []string{"k8s"}}, []string{"docker", "k8s"}, []string{"docker", "k8s"}},
+		{"add after remove", tagOps{Remove: []string{"a"}, Add: []string{"b"}}, []string{"a"}, []string{"b"}},
+		{"removal wins over add", tagOps{Remove: []string{"x"}, Add: []string{"x"}}, []string{}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ops.apply(tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("apply(%v) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetagWorker_Run(t *testing.T) {
+	mockDB := &mockRetagDB{
+		posts: []taggedPost{
+			{ID: "1", Tags: []string{"golang"}},
+			{ID: "2", Tags: []string{"go"}},
+			{ID: "3", Tags: []string{"golang", "api"}},
+			{ID: "4", Tags: []string{"golang"}},
+			{ID: "5", Tags: []string{"golang", "t1", "t2", "t3", "t4", "t5", "t6", "t7", "t8", "t9"}},
+		},
+		conflicts: map[string]bool{"4": true},
+	}
+	ops := tagOps{Rename: map[string]string{"golang": "go"}}
+
+	result, err := quietWorker(mockDB, false).run(context.Background(), postFilter{}, ops)
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if result.scanned != 5 || result.changed != 3 || result.unchanged != 1 || result.skipped != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(mockDB.applied) != 3 || mockDB.applied[1].PostID != "3" || !reflect.DeepEqual(mockDB.applied[1].After, []string{"go", "api"}) {
+		t.Errorf("unexpected changes: %+v", mockDB.applied)
+	}
+	if mockDB.actions[0] != auditActionRetag {
+		t.Errorf("expected action %q, got %q", auditActionRetag, mockDB.actions[0])
+	}
+}
+
+func TestRetagWorker_Run_TooManyTags(t *testing.T) {
+	mockDB := &mockRetagDB{posts: []taggedPost{
+		{ID: "1", Tags: []string{"t1", "t2", "t3", "t4", "t5", "t6", "t7", "t8", "t9", "t10"}},
+	}}
+
+	result, err := quietWorker(mockDB, false).run(context.Background(), postFilter{Tag: "t1"}, tagOps{Add: []string{"extra"}})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if result.skipped != 1 || len(mockDB.applied) != 0 {
+		t.Errorf("expected post over the tag limit to be skipped, got %+v", result)
+	}
+}
+
+func TestRetagWorker_Run_DryRun(t *testing.T) {
+	mockDB := &mockRetagDB{posts: []taggedPost{{ID: "1", Tags: []string{"old"}}}}
+
+	result, err := quietWorker(mockDB, true).run(context.Background(), postFilter{}, tagOps{Remove: []string{"old"}})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if result.changed != 1 {
+		t.Errorf("expected 1 change previewed, got %+v", result)
+	}
+	if len(mockDB.applied) != 0 {
+		t.Error("expected no writes in dry run")
+	}
+}
+
+func TestRetagWorker_Run_ApplyError(t *testing.T) {
+	mockDB := &mockRetagDB{posts: []taggedPost{{ID: "1", Tags: []string{"old"}}}, applyErr: errors.New("connection reset")}
+
+	if _, err := quietWorker(mockDB, false).run(context.Background(), postFilter{}, tagOps{Remove: []string{"old"}}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRetagWorker_Undo(t *testing.T) {
+	mockDB := &mockRetagDB{
+		runs: map[string][]tagChange{"run-1": {
+			{PostID: "2", Before: []string{"golang"}, After: []string{"go"}},
+			{PostID: "1", Before: []string{"golang", "api"}, After: []string{"go", "api"}},
+		}},
+		conflicts: map[string]bool{"2": true},
+	}
+
+	result, err := quietWorker(mockDB, false).undo(context.Background(), "run-1")
+	if err != nil {
+		t.Fatalf("undo() error = %v", err)
+	}
+	if result.changed != 1 || result.skipped != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	want := tagChange{PostID: "1", Before: []string{"go", "api"}, After: []string{"golang", "api"}}
+	if len(mockDB.applied) != 1 || !reflect.DeepEqual(mockDB.applied[0], want) {
+		t.Errorf("unexpected restore: %+v", mockDB.applied)
+	}
+	if mockDB.actions[0] != auditActionRetagUndo {
+		t.Errorf("expected action %q, got %q", auditActionRetagUndo, mockDB.actions[0])
+	}
+}
+
+func TestRetagWorker_Undo_UnknownRun(t *testing.T) {
+	if _, err := quietWorker(&mockRetagDB{}, false).undo(context.Background(), "missing"); err == nil {
+		t.Fatal("expected error for a run without changes")
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	cfg, err := parseFlags([]string{"--rename", "Golang=go", "--remove", "legacy", "--until", "2025-06-30"})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	if cfg.ops.Rename["golang"] != "go" || !reflect.DeepEqual(cfg.ops.Remove, []string{"legacy"}) {
+		t.Errorf("unexpected ops: %+v", cfg.ops)
+	}
+	if !reflect.DeepEqual(cfg.filter.AnyTags, []string{"golang", "legacy"}) {
+		t.Errorf("expected filter limited to touched tags, got %v", cfg.filter.AnyTags)
+	}
+	if got := cfg.filter.Until.Format(dateLayout); got != "2025-07-01" {
+		t.Errorf("expected --until to include the whole day, got %s", got)
+	}
+	if !cfg.dryRun {
+		t.Error("expected dry run by default")
+	}
+}
+
+func TestParseFlags_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no operations", []string{"--tag", "go"}},
+		{"add without filter", []string{"--add", "go"}},
+		{"invalid tag", []string{"--tag", "go", "--add", "bad tag!"}},
+		{"invalid rename", []string{"--rename", "golang"}},
+		{"invalid date", []string{"--since", "yesterday", "--remove", "go"}},
+		{"undo with operations", []string{"--undo", "run-1", "--add", "go"}},
+		{"zero batch size", []string{"--remove", "go", "--batch-size", "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseFlags(tt.args); err == nil {
+				t.Errorf("parseFlags(%v) expected error", tt.args)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package main implements the seed CLI tool. It fills a development database with
+// a small, fixed set of users, agents, posts of each type, answers, approaches,
+// comments and votes, so list, search and stats endpoints have something to show.
+//
+// Fixture IDs are derived from fixed keys, so they are the same on every machine,
+// and rerunning the command leaves existing rows untouched.
+//
+// Usage:
+//
+//	DATABASE_URL="postgres://..." go run ./cmd/seed/
+//	DATABASE_URL="postgres://..." go run ./cmd/seed/ --force   # allow APP_ENV=production
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func main() {
+	force := flag.Bool("force", false, "seed even when APP_ENV=production")
+	flag.Parse()
+
+	if os.Getenv("APP_ENV") == "production" && !*force {
+		log.Fatal("refusing to seed with APP_ENV=production (use --force to override)")
+	}
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	pool, err := db.NewPool(connectCtx, databaseURL)
+	cancel()
+	if err != nil {
+		log.Fatalf("connect: %v", err)
+	}
+	defer pool.Close()
+
+	fx := fixtures()
+	if err := validate(fx); err != nil {
+		pool.Close()
+		log.Fatalf("invalid fixtures: %v", err)
+	}
+	err = pool.WithTx(ctx, func(tx db.Tx) error {
+		return seed(ctx, tx, fx)
+	})
+	if err != nil {
+		pool.Close()
+		log.Fatalf("seed: %v", err)
+	}
+	printSummary(os.Stdout, fx)
+}
+
+// printSummary reports what the seed covers and where to start exploring.
+func printSummary(out io.Writer, fx fixtureSet) {
+	fmt.Fprintf(out, "seeded %d users, %d agents, %d posts, %d answers, %d approaches, %d comments, %d votes\n",
+		len(fx.Users), len(fx.Agents), len(fx.Posts), len(fx.Answers), len(fx.Approaches), len(fx.Comments), len(fx.Votes))
+	for _, p := range fx.Posts {
+		fmt.Fprintf(out, "  %-8s %s  %s\n", p.Type, seedID("post", p.Key), p.Title)
+	}
+}
+
+// authorID resolves a fixture author to the ID stored in the database.
+func authorID(a seedAuthor) string {
+	if a.Type == models.AuthorTypeHuman {
+		return seedID("user", a.Key)
+	}
+	return a.Key
+}
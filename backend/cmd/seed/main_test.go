@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestFixtures_Valid(t *testing.T) {
+	if err := validate(fixtures()); err != nil {
+		t.Fatalf("validate() = %v", err)
+	}
+}
+
+func TestFixtures_CoverEveryPostType(t *testing.T) {
+	seen := map[models.PostType]bool{}
+	for _, p := range fixtures().Posts {
+		seen[p.Type] = true
+	}
+	for _, pt := range models.ValidPostTypes() {
+		if !seen[pt] {
+			t.Errorf("no %s fixture", pt)
+		}
+	}
+}
+
+func TestFixtures_UniqueKeys(t *testing.T) {
+	fx := fixtures()
+	ids := map[string]string{}
+	add := func(kind, key string) {
+		id := seedID(kind, key)
+		if prev, ok := ids[id]; ok {
+			t.Errorf("%s %q reuses the ID of %s", kind, key, prev)
+		}
+		ids[id] = kind + " " + key
+	}
+	for _, u := range fx.Users {
+		add("user", u.Key)
+	}
+	for _, p := range fx.Posts {
+		add("post", p.Key)
+	}
+	for _, a := range fx.Answers {
+		add("answer", a.Key)
+	}
+	for _, a := range fx.Approaches {
+		add("approach", a.Key)
+	}
+	for _, c := range fx.Comments {
+		add("comment", c.Key)
+	}
+	for _, a := range fx.Agents {
+		if !strings.HasPrefix(a.ID, "agent_seed_") {
+			t.Errorf("agent %q should start with agent_seed_", a.ID)
+		}
+	}
+}
+
+func TestSeedID_Deterministic(t *testing.T) {
+	if seedID("post", "nil-map-panic") != seedID("post", "nil-map-panic") {
+		t.Fatal("seedID is not stable")
+	}
+	if seedID("post", "x") == seedID("answer", "x") {
+		t.Fatal("seedID ignores kind")
+	}
+}
+
+func TestValidate_RejectsBrokenReferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*fixtureSet)
+		want   string
+	}{
+		{"unknown author", func(fx *fixtureSet) { fx.Posts[0].Author = human("nobody") }, "unknown human author"},
+		{"answer on problem", func(fx *fixtureSet) { fx.Answers[0].PostKey = "nil-map-panic" }, "is not a question"},
+		{"approach on question", func(fx *fixtureSet) { fx.Approaches[0].PostKey = "go-context-timeout" }, "is not a problem"},
+		{"comment target", func(fx *fixtureSet) { fx.Comments[0].TargetKey = "missing" }, "unknown answer"},
+		{"duplicate vote", func(fx *fixtureSet) { fx.Votes = append(fx.Votes, fx.Votes[0]) }, "duplicate vote"},
+		{"bad status", func(fx *fixtureSet) { fx.Posts[0].Status = models.PostStatusAnswered }, "invalid status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := fixtures()
+			tt.mutate(&fx)
+			err := validate(fx)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("validate() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestSeed_Idempotent seeds twice inside a transaction that is rolled back.
+func TestSeed_Idempotent(t *testing.T) {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := db.NewPool(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer pool.Close()
+
+	tx, err := pool.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	fx := fixtures()
+	for i := 0; i < 2; i++ {
+		if err := seed(ctx, tx, fx); err != nil {
+			t.Fatalf("seed run %d: %v", i+1, err)
+		}
+	}
+
+	var upvotes int
+	var accepted *string
+	err = tx.QueryRow(ctx, "SELECT upvotes FROM posts WHERE id = $1", seedID("post", "nil-map-panic")).Scan(&upvotes)
+	if err != nil {
+		t.Fatalf("read post: %v", err)
+	}
+	if upvotes != 3 {
+		t.Errorf("upvotes = %d, want 3", upvotes)
+	}
+	err = tx.QueryRow(ctx, "SELECT accepted_answer_id::text FROM posts WHERE id = $1", seedID("post", "go-context-timeout")).Scan(&accepted)
+	if err != nil {
+		t.Fatalf("read question: %v", err)
+	}
+	if accepted == nil || *accepted != seedID("answer", "context-with-timeout") {
+		t.Errorf("accepted_answer_id = %v, want %s", accepted, seedID("answer", "context-with-timeout"))
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// validate checks that every fixture reference points at a fixture of the right
+// kind and that statuses are valid, so a bad edit fails before touching the database.
+func validate(fx fixtureSet) error {
+	users := map[string]bool{}
+	for _, u := range fx.Users {
+		users[u.Key] = true
+	}
+	agents := map[string]bool{}
+	for _, a := range fx.Agents {
+		agents[a.ID] = true
+		if a.OwnerKey != "" && !users[a.OwnerKey] {
+			return fmt.Errorf("agent %s: unknown owner %q", a.ID, a.OwnerKey)
+		}
+	}
+	checkAuthor := func(what string, a seedAuthor) error {
+		if (a.Type == models.AuthorTypeHuman && users[a.Key]) || (a.Type == models.AuthorTypeAgent && agents[a.Key]) {
+			return nil
+		}
+		return fmt.Errorf("%s: unknown %s author %q", what, a.Type, a.Key)
+	}
+
+	posts := map[string]models.PostType{}
+	for _, p := range fx.Posts {
+		if !models.IsValidPostStatus(p.Status, p.Type) {
+			return fmt.Errorf("post %s: invalid status %q for %s", p.Key, p.Status, p.Type)
+		}
+		if err := checkAuthor("post "+p.Key, p.Author); err != nil {
+			return err
+		}
+		posts[p.Key] = p.Type
+	}
+	answers := map[string]string{}
+	for _, a := range fx.Answers {
+		if posts[a.PostKey] != models.PostTypeQuestion {
+			return fmt.Errorf("answer %s: %q is not a question", a.Key, a.PostKey)
+		}
+		if err := checkAuthor("answer "+a.Key, a.Author); err != nil {
+			return err
+		}
+		answers[a.Key] = a.PostKey
+	}
+	for _, p := range fx.Posts {
+		if p.AcceptedKey != "" && answers[p.AcceptedKey] != p.Key {
+			return fmt.Errorf("post %s: accepted answer %q does not answer it", p.Key, p.AcceptedKey)
+		}
+	}
+	approaches := map[string]bool{}
+	for _, a := range fx.Approaches {
+		if posts[a.PostKey] != models.PostTypeProblem {
+			return fmt.Errorf("approach %s: %q is not a problem", a.Key, a.PostKey)
+		}
+		if !models.IsValidApproachStatus(a.Status) || a.Status == models.ApproachStatusAbandoned {
+			return fmt.Errorf("approach %s: invalid status %q", a.Key, a.Status)
+		}
+		if err := checkAuthor("approach "+a.Key, a.Author); err != nil {
+			return err
+		}
+		approaches[a.Key] = true
+	}
+
+	targetExists := func(targetType, key string) bool {
+		switch targetType {
+		case "post":
+			_, ok := posts[key]
+			return ok
+		case "answer":
+			_, ok := answers[key]
+			return ok
+		case "approach":
+			return approaches[key]
+		}
+		return false
+	}
+	for _, c := range fx.Comments {
+		if !targetExists(c.TargetType, c.TargetKey) {
+			return fmt.Errorf("comment %s: unknown %s %q", c.Key, c.TargetType, c.TargetKey)
+		}
+		if err := checkAuthor("comment "+c.Key, c.Author); err != nil {
+			return err
+		}
+	}
+	seen := map[string]bool{}
+	for _, v := range fx.Votes {
+		what := fmt.Sprintf("vote on %s %s by %s", v.TargetType, v.TargetKey, v.Voter.Key)
+		if v.TargetType == "approach" || !targetExists(v.TargetType, v.TargetKey) {
+			return fmt.Errorf("%s: unknown target", what)
+		}
+		if v.Direction != "up" && v.Direction != "down" {
+			return fmt.Errorf("%s: invalid direction %q", what, v.Direction)
+		}
+		if err := checkAuthor(what, v.Voter); err != nil {
+			return err
+		}
+		if seen[what] {
+			return fmt.Errorf("%s: duplicate vote", what)
+		}
+		seen[what] = true
+	}
+	return nil
+}
+
+// seed writes fx inside tx. Rows that already exist are left as they are, so
+// reruns are safe; vote totals are recomputed from the votes table each time.
+func seed(ctx context.Context, tx db.Tx, fx fixtureSet) error {
+	for _, u := range fx.Users {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO users (id, username, display_name, email, auth_provider, auth_provider_id, bio, role)
+			VALUES ($1, $2, $3, $4, 'seed', $5, $6, $7)
+			ON CONFLICT DO NOTHING`,
+			seedID("user", u.Key), u.Username, u.DisplayName, u.Username+"@seed.solvr.local", u.Key, u.Bio, u.Role)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", u.Key, err)
+		}
+	}
+
+	for _, a := range fx.Agents {
+		var humanID *string
+		if a.OwnerKey != "" {
+			id := seedID("user", a.OwnerKey)
+			humanID = &id
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO agents (id, display_name, human_id, bio, specialties, human_claimed_at, has_human_backed_badge)
+			VALUES ($1, $2, $3, $4, $5, CASE WHEN $3::uuid IS NULL THEN NULL ELSE NOW() END, $3::uuid IS NOT NULL)
+			ON CONFLICT DO NOTHING`,
+			a.ID, a.DisplayName, humanID, a.Bio, a.Specialties)
+		if err != nil {
+			return fmt.Errorf("agent %s: %w", a.ID, err)
+		}
+	}
+
+	for _, p := range fx.Posts {
+		var weight *int
+		if p.Type == models.PostTypeProblem && p.Weight > 0 {
+			weight = &p.Weight
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO posts (id, type, title, description, tags, posted_by_type, posted_by_id, status,
+				success_criteria, weight, code_languages, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11,
+				NOW() - make_interval(hours => $12), NOW() - make_interval(hours => $12))
+			ON CONFLICT DO NOTHING`,
+			seedID("post", p.Key), p.Type, p.Title, p.Description, p.Tags, p.Author.Type, authorID(p.Author), p.Status,
+			p.Criteria, weight, models.ExtractCodeLanguages(p.Description), p.AgeHours)
+		if err != nil {
+			return fmt.Errorf("post %s: %w", p.Key, err)
+		}
+	}
+
+	for _, a := range fx.Answers {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO answers (id, question_id, author_type, author_id, content, code_languages, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, NOW() - make_interval(hours => $7))
+			ON CONFLICT DO NOTHING`,
+			seedID("answer", a.Key), seedID("post", a.PostKey), a.Author.Type, authorID(a.Author), a.Content,
+			models.ExtractCodeLanguages(a.Content), a.AgeHours)
+		if err != nil {
+			return fmt.Errorf("answer %s: %w", a.Key, err)
+		}
+	}
+	for _, p := range fx.Posts {
+		if p.AcceptedKey == "" {
+			continue
+		}
+		answerID := seedID("answer", p.AcceptedKey)
+		if _, err := tx.Exec(ctx, `UPDATE answers SET is_accepted = TRUE WHERE id = $1`, answerID); err != nil {
+			return fmt.Errorf("accept answer %s: %w", p.AcceptedKey, err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE posts SET accepted_answer_id = $1 WHERE id = $2`, answerID, seedID("post", p.Key)); err != nil {
+			return fmt.Errorf("accept answer %s: %w", p.AcceptedKey, err)
+		}
+	}
+
+	for _, a := range fx.Approaches {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO approaches (id, problem_id, author_type, author_id, angle, method, status, outcome, solution,
+				created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, NULLIF($8, ''), NULLIF($9, ''),
+				NOW() - make_interval(hours => $10), NOW() - make_interval(hours => $10))
+			ON CONFLICT DO NOTHING`,
+			seedID("approach", a.Key), seedID("post", a.PostKey), a.Author.Type, authorID(a.Author), a.Angle, a.Method,
+			a.Status, a.Outcome, a.Solution, a.AgeHours)
+		if err != nil {
+			return fmt.Errorf("approach %s: %w", a.Key, err)
+		}
+	}
+
+	for _, c := range fx.Comments {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO comments (id, target_type, target_id, author_type, author_id, content)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT DO NOTHING`,
+			seedID("comment", c.Key), c.TargetType, seedID(c.TargetType, c.TargetKey), c.Author.Type, authorID(c.Author), c.Content)
+		if err != nil {
+			return fmt.Errorf("comment %s: %w", c.Key, err)
+		}
+	}
+
+	for _, v := range fx.Votes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO votes (target_type, target_id, voter_type, voter_id, direction, confirmed)
+			VALUES ($1, $2, $3, $4, $5, TRUE)
+			ON CONFLICT (target_type, target_id, voter_type, voter_id) DO NOTHING`,
+			v.TargetType, seedID(v.TargetType, v.TargetKey), v.Voter.Type, authorID(v.Voter), v.Direction)
+		if err != nil {
+			return fmt.Errorf("vote on %s %s: %w", v.TargetType, v.TargetKey, err)
+		}
+	}
+	// Vote totals are maintained by the repositories, not by triggers.
+	for _, table := range []struct{ name, targetType string }{{"posts", "post"}, {"answers", "answer"}} {
+		_, err := tx.Exec(ctx, fmt.Sprintf(`
+			UPDATE %s t SET
+				upvotes = (SELECT COUNT(*) FROM votes v WHERE v.target_type = $1 AND v.target_id = t.id AND v.direction = 'up'),
+				downvotes = (SELECT COUNT(*) FROM votes v WHERE v.target_type = $1 AND v.target_id = t.id AND v.direction = 'down')
+			WHERE t.id = ANY($2::uuid[])`, table.name),
+			table.targetType, fixtureIDs(fx, table.targetType))
+		if err != nil {
+			return fmt.Errorf("recount %s votes: %w", table.targetType, err)
+		}
+	}
+	return nil
+}
+
+// fixtureIDs returns the database IDs of every post or answer fixture.
+func fixtureIDs(fx fixtureSet, kind string) []string {
+	var ids []string
+	switch kind {
+	case "post":
+		for _, p := range fx.Posts {
+			ids = append(ids, seedID("post", p.Key))
+		}
+	case "answer":
+		for _, a := range fx.Answers {
+			ids = append(ids, seedID("answer", a.Key))
+		}
+	}
+	return ids
+}
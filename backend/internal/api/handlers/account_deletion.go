@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// DefaultAccountDeletionGracePeriod is how long a deleted account's PII is kept
+// before the purge job hard-deletes it. Admins can restore the account until then.
+const DefaultAccountDeletionGracePeriod = 30 * 24 * time.Hour
+
+// AccountDeletionRepositoryInterface runs the GDPR account deletion pipeline.
+type AccountDeletionRepositoryInterface interface {
+	RequestDeletion(ctx context.Context, userID string, grace time.Duration) (*models.AccountDeletionReceipt, error)
+	FindReceipt(ctx context.Context, id string) (*models.AccountDeletionReceipt, error)
+}
+
+// SetAccountDeletionRepo enables the GDPR deletion pipeline for DELETE /v1/me.
+// Without it DeleteMe falls back to a plain soft delete.
+func (h *MeHandler) SetAccountDeletionRepo(repo AccountDeletionRepositoryInterface, grace time.Duration) {
+	h.accountDeletionRepo = repo
+	h.deletionGracePeriod = grace
+}
+
+// AccountDeletionHandler serves deletion receipts.
+type AccountDeletionHandler struct {
+	repo AccountDeletionRepositoryInterface
+}
+
+// NewAccountDeletionHandler creates a new AccountDeletionHandler.
+func NewAccountDeletionHandler(repo AccountDeletionRepositoryInterface) *AccountDeletionHandler {
+	return &AccountDeletionHandler{repo: repo}
+}
+
+// GetReceipt handles GET /v1/account-deletions/{id}.
+// No auth: the deleted user can no longer sign in, and the unguessable receipt
+// ID is what they were given. The receipt carries no personal data.
+func (h *AccountDeletionHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeValidationError(w, "receipt ID is required")
+		return
+	}
+
+	receipt, err := h.repo.FindReceipt(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, db.ErrAccountDeletionNotFound) {
+			apierror.Write(w, apierror.NotFound, "deletion receipt not found")
+			return
+		}
+		writeInternalError(w, "failed to get deletion receipt")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, receipt)
+}
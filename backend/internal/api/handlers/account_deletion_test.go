@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockAccountDeletionRepo is a test double for AccountDeletionRepositoryInterface.
+type mockAccountDeletionRepo struct {
+	receipts     map[string]*models.AccountDeletionReceipt
+	requestedFor string
+	grace        time.Duration
+	requestErr   error
+}
+
+func newMockAccountDeletionRepo() *mockAccountDeletionRepo {
+	return &mockAccountDeletionRepo{receipts: map[string]*models.AccountDeletionReceipt{}}
+}
+
+func (m *mockAccountDeletionRepo) RequestDeletion(ctx context.Context, userID string, grace time.Duration) (*models.AccountDeletionReceipt, error) {
+	m.requestedFor = userID
+	m.grace = grace
+	if m.requestErr != nil {
+		return nil, m.requestErr
+	}
+	now := time.Now()
+	receipt := &models.AccountDeletionReceipt{
+		ID:                "receipt-1",
+		UserID:            userID,
+		Status:            models.AccountDeletionPending,
+		RequestedAt:       now,
+		PurgeAfter:        now.Add(grace),
+		ContentAnonymized: 4,
+		VotesAnonymized:   7,
+	}
+	m.receipts[receipt.ID] = receipt
+	return receipt, nil
+}
+
+func (m *mockAccountDeletionRepo) FindReceipt(ctx context.Context, id string) (*models.AccountDeletionReceipt, error) {
+	receipt, ok := m.receipts[id]
+	if !ok {
+		return nil, db.ErrAccountDeletionNotFound
+	}
+	return receipt, nil
+}
+
+func newDeleteMeRequest(userID string) *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/v1/me", nil)
+	claims := &auth.Claims{UserID: userID, Email: "test@example.com", Role: models.UserRoleUser}
+	return req.WithContext(auth.ContextWithClaims(req.Context(), claims))
+}
+
+func TestDeleteMe_GDPRPipelineReturnsReceipt(t *testing.T) {
+	deletionRepo := newMockAccountDeletionRepo()
+	handler := NewMeHandler(&OAuthConfig{JWTSecret: "test-secret-key"}, NewMockMeUserRepositoryWithDelete(), nil, nil, nil)
+	handler.SetAccountDeletionRepo(deletionRepo, 7*24*time.Hour)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteMe(rr, newDeleteMeRequest("user-123"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if deletionRepo.requestedFor != "user-123" || deletionRepo.grace != 7*24*time.Hour {
+		t.Errorf("RequestDeletion called with %q/%v", deletionRepo.requestedFor, deletionRepo.grace)
+	}
+
+	var resp struct {
+		Data struct {
+			Message string                 `json:"message"`
+			Receipt map[string]interface{} `json:"receipt"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.Message != "Account deleted successfully" {
+		t.Errorf("unexpected message %q", resp.Data.Message)
+	}
+	if resp.Data.Receipt["id"] != "receipt-1" || resp.Data.Receipt["status"] != "pending" {
+		t.Errorf("unexpected receipt: %v", resp.Data.Receipt)
+	}
+	if _, leaked := resp.Data.Receipt["user_id"]; leaked {
+		t.Error("receipt must not expose the user ID")
+	}
+}
+
+func TestDeleteMe_GDPRPipelineDefaultGrace(t *testing.T) {
+	deletionRepo := newMockAccountDeletionRepo()
+	handler := NewMeHandler(&OAuthConfig{JWTSecret: "test-secret-key"}, NewMockMeUserRepositoryWithDelete(), nil, nil, nil)
+	handler.SetAccountDeletionRepo(deletionRepo, 0)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteMe(rr, newDeleteMeRequest("user-123"))
+
+	if deletionRepo.grace != DefaultAccountDeletionGracePeriod {
+		t.Errorf("expected default grace period, got %v", deletionRepo.grace)
+	}
+}
+
+func TestDeleteMe_GDPRPipelineAlreadyDeleted(t *testing.T) {
+	deletionRepo := newMockAccountDeletionRepo()
+	deletionRepo.requestErr = db.ErrNotFound
+	handler := NewMeHandler(&OAuthConfig{JWTSecret: "test-secret-key"}, NewMockMeUserRepositoryWithDelete(), nil, nil, nil)
+	handler.SetAccountDeletionRepo(deletionRepo, time.Hour)
+
+	rr := httptest.NewRecorder()
+	handler.DeleteMe(rr, newDeleteMeRequest("user-123"))
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rr.Code)
+	}
+}
+
+func TestAccountDeletionHandler_GetReceipt(t *testing.T) {
+	repo := newMockAccountDeletionRepo()
+	repo.receipts["receipt-1"] = &models.AccountDeletionReceipt{ID: "receipt-1", UserID: "user-123", Status: models.AccountDeletionPurged}
+	handler := NewAccountDeletionHandler(repo)
+
+	for id, want := range map[string]int{"receipt-1": http.StatusOK, "missing": http.StatusNotFound} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/account-deletions/"+id, nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rr := httptest.NewRecorder()
+
+		handler.GetReceipt(rr, req)
+
+		if rr.Code != want {
+			t.Errorf("%s: expected %d, got %d", id, want, rr.Code)
+		}
+	}
+}
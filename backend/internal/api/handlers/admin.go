@@ -4,6 +4,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/emailutil"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -43,6 +45,18 @@ type UserEmailRepo interface {
 	ListActiveEmails(ctx context.Context) ([]models.EmailRecipient, error)
 }
 
+// AbuseReportRepo lists and reviews abuse reports filed by the abuse detection job.
+type AbuseReportRepo interface {
+	List(ctx context.Context, status string, page, perPage int) ([]models.AbuseReport, int, error)
+	Review(ctx context.Context, id string, status models.AbuseReportStatus, note string) (*models.AbuseReport, error)
+}
+
+// ReportQueueRepo lists and resolves user/agent content reports.
+type ReportQueueRepo interface {
+	ListQueue(ctx context.Context, page, perPage int) ([]models.ReportQueueItem, int, error)
+	Resolve(ctx context.Context, targetType models.ReportTargetType, targetID string, resolution models.ReportResolution, reviewedBy string) (int64, error)
+}
+
 // AdminHandler handles admin operations like raw SQL queries.
 type AdminHandler struct {
 	pool                 *db.Pool
@@ -50,6 +64,30 @@ type AdminHandler struct {
 	emailSender          EmailSender
 	emailBroadcastRepo   EmailBroadcastRepo
 	userEmailRepo        UserEmailRepo
+	abuseReportRepo      AbuseReportRepo
+	reportQueueRepo      ReportQueueRepo
+
+	adminUserRepo            AdminUserRepo
+	adminUserAgentsRepo      AdminUserAgentsRepo
+	adminUserAuthMethodsRepo AdminUserAuthMethodsRepo
+	adminUserAPIKeysRepo     AdminUserAPIKeysRepo
+
+	auditLogRepo AuditLogRepo
+	adminTagRepo AdminTagRepo
+
+	chatIntegrationRepo   AdminChatIntegrationRepo
+	chatIntegrationSender ChatIntegrationSender
+
+	maintenanceSwitch MaintenanceSwitch
+	configReloader    ConfigReloader
+
+	dashboardRepo          AdminDashboardReader
+	postRetentionRepo      PostRetentionRepo
+	moderationTemplateRepo ModerationTemplateRepo
+	freshnessReviewRepo    FreshnessReviewRepo
+	tenantRepo             TenantRepo
+	tenantCache            TenantCache
+	changeEventRepo        ChangeEventRepo
 }
 
 // NewAdminHandler creates a new AdminHandler.
@@ -77,6 +115,16 @@ func (h *AdminHandler) SetUserEmailRepo(repo UserEmailRepo) {
 	h.userEmailRepo = repo
 }
 
+// SetAbuseReportRepo injects the abuse report repository dependency.
+func (h *AdminHandler) SetAbuseReportRepo(repo AbuseReportRepo) {
+	h.abuseReportRepo = repo
+}
+
+// SetReportQueueRepo injects the content report repository dependency.
+func (h *AdminHandler) SetReportQueueRepo(repo ReportQueueRepo) {
+	h.reportQueueRepo = repo
+}
+
 // substituteTemplateVars replaces {name}, {referral_code}, and {referral_link}
 // in the given body string with the provided per-recipient values.
 func substituteTemplateVars(body, name, code, link string) string {
@@ -109,17 +157,17 @@ func (h *AdminHandler) BroadcastEmail(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req broadcastRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeAdminError(w, http.StatusBadRequest, "INVALID_JSON", "invalid JSON body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
 		return
 	}
 
 	// Validate required fields
 	if strings.TrimSpace(req.Subject) == "" {
-		writeAdminError(w, http.StatusBadRequest, "MISSING_REQUIRED_FIELD", "subject is required")
+		apierror.Write(w, apierror.MissingRequiredField, "subject is required")
 		return
 	}
 	if strings.TrimSpace(req.BodyHTML) == "" {
-		writeAdminError(w, http.StatusBadRequest, "MISSING_REQUIRED_FIELD", "body_html is required")
+		apierror.Write(w, apierror.MissingRequiredField, "body_html is required")
 		return
 	}
 
@@ -134,7 +182,7 @@ func (h *AdminHandler) BroadcastEmail(w http.ResponseWriter, r *http.Request) {
 	// Get recipients
 	recipients, err := h.userEmailRepo.ListActiveEmails(r.Context())
 	if err != nil {
-		writeAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list recipients")
+		apierror.Write(w, apierror.InternalError, "failed to list recipients")
 		return
 	}
 
@@ -148,7 +196,7 @@ func (h *AdminHandler) BroadcastEmail(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 		if len(filtered) == 0 {
-			writeAdminError(w, http.StatusNotFound, "RECIPIENT_NOT_FOUND", "no active user with email: "+req.To)
+			apierror.Write(w, apierror.RecipientNotFound, "no active user with email: "+req.To)
 			return
 		}
 		recipients = filtered
@@ -179,7 +227,7 @@ func (h *AdminHandler) BroadcastEmail(w http.ResponseWriter, r *http.Request) {
 		recent, err := h.emailBroadcastRepo.HasRecentBroadcast(r.Context(), req.Subject, 24*time.Hour)
 		if err != nil {
 			// Fail-closed: if dedup check fails, don't risk sending duplicates
-			writeAdminError(w, http.StatusInternalServerError, "DEDUP_CHECK_FAILED", "failed to check for recent broadcasts: "+err.Error())
+			apierror.Write(w, apierror.DedupCheckFailed, "failed to check for recent broadcasts: "+err.Error())
 			return
 		}
 		if recent != nil {
@@ -211,7 +259,7 @@ func (h *AdminHandler) BroadcastEmail(w http.ResponseWriter, r *http.Request) {
 
 	logEntry, err := h.emailBroadcastRepo.CreateLog(ctx, broadcast)
 	if err != nil {
-		writeAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create broadcast log")
+		apierror.Write(w, apierror.InternalError, "failed to create broadcast log")
 		return
 	}
 
@@ -291,18 +339,13 @@ type listBroadcastItem struct {
 // Requires X-Admin-API-Key header.
 // Returns 503 if emailBroadcastRepo is not configured.
 func (h *AdminHandler) ListBroadcasts(w http.ResponseWriter, r *http.Request) {
-	if !h.checkAdminAuth(w, r) {
-		return
-	}
-
-	if h.emailBroadcastRepo == nil {
-		writeAdminError(w, http.StatusServiceUnavailable, "REPO_NOT_CONFIGURED", "email broadcast repository not configured")
+	if !h.requireAdmin(w, r, h.emailBroadcastRepo != nil, apierror.RepoNotConfigured, "email broadcast repository not configured") {
 		return
 	}
 
 	broadcasts, err := h.emailBroadcastRepo.List(r.Context())
 	if err != nil {
-		writeAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list broadcasts")
+		apierror.Write(w, apierror.InternalError, "failed to list broadcasts")
 		return
 	}
 
@@ -348,43 +391,43 @@ func (h *AdminHandler) ExecuteQuery(w http.ResponseWriter, r *http.Request) {
 	// Check admin API key
 	adminKey := os.Getenv("ADMIN_API_KEY")
 	if adminKey == "" {
-		writeAdminError(w, http.StatusServiceUnavailable, "ADMIN_NOT_CONFIGURED", "admin API key not configured")
+		apierror.Write(w, apierror.AdminNotConfigured, "admin API key not configured")
 		return
 	}
 
 	providedKey := r.Header.Get("X-Admin-API-Key")
 	if providedKey == "" {
-		writeAdminError(w, http.StatusUnauthorized, "MISSING_API_KEY", "X-Admin-API-Key header required")
+		apierror.Write(w, apierror.MissingAPIKey, "X-Admin-API-Key header required")
 		return
 	}
 
 	if providedKey != adminKey {
-		writeAdminError(w, http.StatusForbidden, "INVALID_API_KEY", "invalid admin API key")
+		apierror.WriteStatus(w, http.StatusForbidden, apierror.InvalidAPIKey, "invalid admin API key")
 		return
 	}
 
 	// Check database connection
 	if h.pool == nil {
-		writeAdminError(w, http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE", "database not connected")
+		apierror.Write(w, apierror.DatabaseUnavailable, "database not connected")
 		return
 	}
 
 	// Parse request body
 	var req QueryRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeAdminError(w, http.StatusBadRequest, "INVALID_JSON", "invalid JSON body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
 		return
 	}
 
 	if strings.TrimSpace(req.Query) == "" {
-		writeAdminError(w, http.StatusBadRequest, "EMPTY_QUERY", "query cannot be empty")
+		apierror.Write(w, apierror.EmptyQuery, "query cannot be empty")
 		return
 	}
 
 	// Check for destructive queries
 	destructiveAllowed := strings.ToLower(os.Getenv("DESTRUCTIVE_QUERIES")) == "true"
 	if !destructiveAllowed && destructivePattern.MatchString(req.Query) {
-		writeAdminError(w, http.StatusForbidden, "DESTRUCTIVE_QUERY_BLOCKED",
+		apierror.Write(w, apierror.DestructiveQuery,
 			"destructive queries not allowed (set DESTRUCTIVE_QUERIES=true to enable)")
 		return
 	}
@@ -467,28 +510,12 @@ func writeAdminJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
-func writeAdminError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 // RunTranslationJob handles POST /admin/jobs/translation/run
 // Manually triggers one batch of the translation job. Returns translated/failed counts.
 // The runner is injected via SetTranslationJobRunner (wired in router.go).
 // Returns 503 if not configured (no GROQ key or no database).
 func (h *AdminHandler) RunTranslationJob(w http.ResponseWriter, r *http.Request) {
-	if !h.checkAdminAuth(w, r) {
-		return
-	}
-
-	if h.translationJobRunner == nil {
-		writeAdminError(w, http.StatusServiceUnavailable, "TRANSLATION_NOT_CONFIGURED", "translation job not configured (GROQ_API_KEY may be missing)")
+	if !h.requireAdmin(w, r, h.translationJobRunner != nil, apierror.TranslationNotConfigured, "translation job not configured (GROQ_API_KEY may be missing)") {
 		return
 	}
 
@@ -500,29 +527,56 @@ func (h *AdminHandler) RunTranslationJob(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// GetQueryStats returns per-call-site database query metrics (count, errors,
+// slow count, rows, total/max duration and a duration histogram), slowest first.
+// GET /admin/db/query-stats
+func (h *AdminHandler) GetQueryStats(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.pool != nil, apierror.DatabaseUnavailable, "database not connected") {
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": h.pool.QueryStats(),
+	})
+}
+
 // checkAdminAuth validates the X-Admin-API-Key header.
 // Returns true if authorized, false otherwise (and writes error response).
 func (h *AdminHandler) checkAdminAuth(w http.ResponseWriter, r *http.Request) bool {
 	adminKey := os.Getenv("ADMIN_API_KEY")
 	if adminKey == "" {
-		writeAdminError(w, http.StatusServiceUnavailable, "ADMIN_NOT_CONFIGURED", "admin API key not configured")
+		apierror.Write(w, apierror.AdminNotConfigured, "admin API key not configured")
 		return false
 	}
 
 	providedKey := r.Header.Get("X-Admin-API-Key")
 	if providedKey == "" {
-		writeAdminError(w, http.StatusUnauthorized, "MISSING_API_KEY", "X-Admin-API-Key header required")
+		apierror.Write(w, apierror.MissingAPIKey, "X-Admin-API-Key header required")
 		return false
 	}
 
 	if providedKey != adminKey {
-		writeAdminError(w, http.StatusForbidden, "INVALID_API_KEY", "invalid admin API key")
+		apierror.WriteStatus(w, http.StatusForbidden, apierror.InvalidAPIKey, "invalid admin API key")
 		return false
 	}
 
 	return true
 }
 
+// requireAdmin checks the admin key and that the endpoint's dependency is wired
+// (configured), writing code and message when it is not. Admin endpoints that
+// need a repository or service call it instead of checkAdminAuth.
+func (h *AdminHandler) requireAdmin(w http.ResponseWriter, r *http.Request, configured bool, code apierror.Code, message string) bool {
+	if !h.checkAdminAuth(w, r) {
+		return false
+	}
+	if !configured {
+		apierror.Write(w, code, message)
+		return false
+	}
+	return true
+}
+
 // HardDeleteUser permanently deletes a user (admin-only).
 // Per PRD-v5 Task 17: Admin hard-delete endpoints.
 // DELETE /admin/users/{id}
@@ -534,7 +588,7 @@ func (h *AdminHandler) HardDeleteUser(w http.ResponseWriter, r *http.Request) {
 	// Parse user ID from path
 	userID := chi.URLParam(r, "id")
 	if userID == "" {
-		writeAdminError(w, http.StatusBadRequest, "MISSING_ID", "user ID required")
+		apierror.Write(w, apierror.MissingID, "user ID required")
 		return
 	}
 
@@ -543,10 +597,14 @@ func (h *AdminHandler) HardDeleteUser(w http.ResponseWriter, r *http.Request) {
 	err := userRepo.HardDelete(r.Context(), userID)
 	if err != nil {
 		if err == db.ErrNotFound {
-			writeAdminError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			apierror.Write(w, apierror.NotFound, "user not found")
+			return
+		}
+		if errors.Is(err, db.ErrLegalHold) {
+			apierror.Write(w, apierror.LegalHold, "user authors content under legal hold or retention; lift the holds first")
 			return
 		}
-		writeAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to delete user")
+		apierror.Write(w, apierror.InternalError, "failed to delete user")
 		return
 	}
 
@@ -567,7 +625,7 @@ func (h *AdminHandler) HardDeleteAgent(w http.ResponseWriter, r *http.Request) {
 	// Parse agent ID from path
 	agentID := chi.URLParam(r, "id")
 	if agentID == "" {
-		writeAdminError(w, http.StatusBadRequest, "MISSING_ID", "agent ID required")
+		apierror.Write(w, apierror.MissingID, "agent ID required")
 		return
 	}
 
@@ -576,10 +634,10 @@ func (h *AdminHandler) HardDeleteAgent(w http.ResponseWriter, r *http.Request) {
 	err := agentRepo.HardDelete(r.Context(), agentID)
 	if err != nil {
 		if err == db.ErrAgentNotFound {
-			writeAdminError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to delete agent")
+		apierror.Write(w, apierror.InternalError, "failed to delete agent")
 		return
 	}
 
@@ -616,7 +674,7 @@ func (h *AdminHandler) ListDeletedUsers(w http.ResponseWriter, r *http.Request)
 	userRepo := db.NewUserRepository(h.pool)
 	users, total, err := userRepo.ListDeleted(r.Context(), page, perPage)
 	if err != nil {
-		writeAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list deleted users")
+		apierror.Write(w, apierror.InternalError, "failed to list deleted users")
 		return
 	}
 
@@ -657,7 +715,7 @@ func (h *AdminHandler) ListDeletedAgents(w http.ResponseWriter, r *http.Request)
 	agentRepo := db.NewAgentRepository(h.pool)
 	agents, total, err := agentRepo.ListDeleted(r.Context(), page, perPage)
 	if err != nil {
-		writeAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list deleted agents")
+		apierror.Write(w, apierror.InternalError, "failed to list deleted agents")
 		return
 	}
 
@@ -670,3 +728,193 @@ func (h *AdminHandler) ListDeletedAgents(w http.ResponseWriter, r *http.Request)
 		},
 	})
 }
+
+// ListAbuseReports returns suspicious voting patterns flagged by the abuse detection job.
+// GET /admin/abuse-reports?status=pending&page=1&per_page=20
+// status defaults to pending; pass status=all for every report.
+func (h *AdminHandler) ListAbuseReports(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.abuseReportRepo != nil, apierror.RepoNotConfigured, "abuse report repository not configured") {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	switch {
+	case status == "":
+		status = string(models.AbuseReportStatusPending)
+	case status == "all":
+		status = ""
+	case !models.IsValidAbuseReportStatus(models.AbuseReportStatus(status)):
+		apierror.Write(w, apierror.ValidationError, "status must be pending, dismissed, actioned or all")
+		return
+	}
+
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	perPage := 20
+	if perPageStr := r.URL.Query().Get("per_page"); perPageStr != "" {
+		if pp, err := strconv.Atoi(perPageStr); err == nil && pp > 0 && pp <= 100 {
+			perPage = pp
+		}
+	}
+
+	reports, total, err := h.abuseReportRepo.List(r.Context(), status, page, perPage)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list abuse reports")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": reports,
+		"meta": map[string]interface{}{
+			"total":    total,
+			"page":     page,
+			"per_page": perPage,
+		},
+	})
+}
+
+// reviewAbuseReportRequest is the JSON body for PATCH /admin/abuse-reports/{id}.
+type reviewAbuseReportRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
+}
+
+// ReviewAbuseReport records an admin decision on an abuse report.
+// PATCH /admin/abuse-reports/{id}
+// Status must be dismissed or actioned; the note is optional.
+func (h *AdminHandler) ReviewAbuseReport(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.abuseReportRepo != nil, apierror.RepoNotConfigured, "abuse report repository not configured") {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		apierror.Write(w, apierror.MissingID, "abuse report ID required")
+		return
+	}
+
+	var req reviewAbuseReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
+		return
+	}
+
+	status := models.AbuseReportStatus(req.Status)
+	if status != models.AbuseReportStatusDismissed && status != models.AbuseReportStatusActioned {
+		apierror.Write(w, apierror.ValidationError, "status must be dismissed or actioned")
+		return
+	}
+
+	report, err := h.abuseReportRepo.Review(r.Context(), id, status, strings.TrimSpace(req.Note))
+	if err != nil {
+		if errors.Is(err, db.ErrAbuseReportNotFound) {
+			apierror.Write(w, apierror.NotFound, "abuse report not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to review abuse report")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": report,
+	})
+}
+
+// ListReportQueue returns reported content with pending reports, most reported first.
+// GET /admin/reports?page=1&per_page=20
+func (h *AdminHandler) ListReportQueue(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.reportQueueRepo != nil, apierror.RepoNotConfigured, "report repository not configured") {
+		return
+	}
+
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	perPage := 20
+	if perPageStr := r.URL.Query().Get("per_page"); perPageStr != "" {
+		if pp, err := strconv.Atoi(perPageStr); err == nil && pp > 0 && pp <= 100 {
+			perPage = pp
+		}
+	}
+
+	items, total, err := h.reportQueueRepo.ListQueue(r.Context(), page, perPage)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list reports")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": items,
+		"meta": map[string]interface{}{
+			"total":    total,
+			"page":     page,
+			"per_page": perPage,
+		},
+	})
+}
+
+// resolveReportsRequest is the JSON body for PATCH /admin/reports/{target_type}/{target_id}.
+type resolveReportsRequest struct {
+	Action string `json:"action"`
+}
+
+// ResolveReports resolves every pending report on a piece of content.
+// PATCH /admin/reports/{target_type}/{target_id}
+// action=dismiss unhides the content; action=hide keeps it hidden.
+func (h *AdminHandler) ResolveReports(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.reportQueueRepo != nil, apierror.RepoNotConfigured, "report repository not configured") {
+		return
+	}
+
+	targetType := models.ReportTargetType(chi.URLParam(r, "target_type"))
+	if !models.IsValidReportTargetType(targetType) {
+		apierror.Write(w, apierror.ValidationError, "invalid target_type")
+		return
+	}
+	targetID := chi.URLParam(r, "target_id")
+	if targetID == "" {
+		apierror.Write(w, apierror.MissingID, "target ID required")
+		return
+	}
+
+	var req resolveReportsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
+		return
+	}
+
+	resolution := models.ReportResolution(req.Action)
+	if resolution != models.ReportResolutionDismiss && resolution != models.ReportResolutionHide {
+		apierror.Write(w, apierror.ValidationError, "action must be dismiss or hide")
+		return
+	}
+
+	resolved, err := h.reportQueueRepo.Resolve(r.Context(), targetType, targetID, resolution, "admin")
+	if err != nil {
+		if errors.Is(err, db.ErrNoPendingReports) {
+			apierror.Write(w, apierror.NotFound, "no pending reports for this content")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to resolve reports")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"target_type": targetType,
+			"target_id":   targetID,
+			"action":      resolution,
+			"resolved":    resolved,
+			"hidden":      resolution == models.ReportResolutionHide,
+		},
+	})
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockAbuseReportRepo is a test double for AbuseReportRepo.
+type mockAbuseReportRepo struct {
+	reports      []models.AbuseReport
+	listStatus   string
+	listPage     int
+	listPerPage  int
+	reviewID     string
+	reviewStatus models.AbuseReportStatus
+	reviewNote   string
+	reviewErr    error
+}
+
+func (m *mockAbuseReportRepo) List(ctx context.Context, status string, page, perPage int) ([]models.AbuseReport, int, error) {
+	m.listStatus = status
+	m.listPage = page
+	m.listPerPage = perPage
+	return m.reports, len(m.reports), nil
+}
+
+func (m *mockAbuseReportRepo) Review(ctx context.Context, id string, status models.AbuseReportStatus, note string) (*models.AbuseReport, error) {
+	m.reviewID = id
+	m.reviewStatus = status
+	m.reviewNote = note
+	if m.reviewErr != nil {
+		return nil, m.reviewErr
+	}
+	now := time.Now()
+	return &models.AbuseReport{
+		ID:         id,
+		Kind:       models.AbuseKindVoteRing,
+		Status:     status,
+		ReviewedAt: &now,
+		ReviewNote: note,
+	}, nil
+}
+
+func newAbuseReviewRequest(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/admin/abuse-reports/"+id, strings.NewReader(body))
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestAdminHandler_ListAbuseReports_NotConfigured(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/abuse-reports", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+
+	handler.ListAbuseReports(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ListAbuseReports_Unauthorized(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAbuseReportRepo(&mockAbuseReportRepo{})
+	req := httptest.NewRequest(http.MethodGet, "/admin/abuse-reports", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListAbuseReports(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ListAbuseReports_DefaultsToPending(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockAbuseReportRepo{reports: []models.AbuseReport{
+		{ID: "r1", Kind: models.AbuseKindTargetedUpvoting, Status: models.AbuseReportStatusPending},
+	}}
+	handler := NewAdminHandler(nil)
+	handler.SetAbuseReportRepo(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/abuse-reports?page=2&per_page=10", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+
+	handler.ListAbuseReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.listStatus != "pending" {
+		t.Errorf("expected status filter pending, got %q", repo.listStatus)
+	}
+	if repo.listPage != 2 || repo.listPerPage != 10 {
+		t.Errorf("expected page 2 per_page 10, got %d/%d", repo.listPage, repo.listPerPage)
+	}
+
+	var resp map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&resp)
+	data, ok := resp["data"].([]interface{})
+	if !ok || len(data) != 1 {
+		t.Fatalf("expected 1 report, got %v", resp["data"])
+	}
+}
+
+func TestAdminHandler_ListAbuseReports_AllStatuses(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockAbuseReportRepo{}
+	handler := NewAdminHandler(nil)
+	handler.SetAbuseReportRepo(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/abuse-reports?status=all", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+
+	handler.ListAbuseReports(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if repo.listStatus != "" {
+		t.Errorf("expected no status filter, got %q", repo.listStatus)
+	}
+}
+
+func TestAdminHandler_ListAbuseReports_InvalidStatus(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAbuseReportRepo(&mockAbuseReportRepo{})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/abuse-reports?status=bogus", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+
+	handler.ListAbuseReports(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ReviewAbuseReport_Success(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockAbuseReportRepo{}
+	handler := NewAdminHandler(nil)
+	handler.SetAbuseReportRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.ReviewAbuseReport(w, newAbuseReviewRequest("r1", `{"status":"actioned","note":" suspended both "}`))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.reviewID != "r1" || repo.reviewStatus != models.AbuseReportStatusActioned {
+		t.Errorf("unexpected review call: id=%q status=%q", repo.reviewID, repo.reviewStatus)
+	}
+	if repo.reviewNote != "suspended both" {
+		t.Errorf("expected trimmed note, got %q", repo.reviewNote)
+	}
+}
+
+func TestAdminHandler_ReviewAbuseReport_InvalidStatus(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAbuseReportRepo(&mockAbuseReportRepo{})
+
+	w := httptest.NewRecorder()
+	handler.ReviewAbuseReport(w, newAbuseReviewRequest("r1", `{"status":"pending"}`))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ReviewAbuseReport_NotFound(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAbuseReportRepo(&mockAbuseReportRepo{reviewErr: db.ErrAbuseReportNotFound})
+
+	w := httptest.NewRecorder()
+	handler.ReviewAbuseReport(w, newAbuseReviewRequest("missing", `{"status":"dismissed"}`))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
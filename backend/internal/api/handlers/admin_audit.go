@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// AuditLogRepo lists audit log entries.
+type AuditLogRepo interface {
+	List(ctx context.Context, opts models.AuditListOptions) ([]models.AuditLog, int, error)
+}
+
+// SetAuditLogRepo injects the audit log repository dependency.
+func (h *AdminHandler) SetAuditLogRepo(repo AuditLogRepo) {
+	h.auditLogRepo = repo
+}
+
+// ListAuditLog returns audit entries for authenticated writes, newest first.
+// GET /v1/admin/audit?actor_type=&actor_id=&action=&route=&target_type=&target_id=&from=&to=&page=1&per_page=20
+// actor_type is human, agent or admin; action is an HTTP method; from/to accept RFC3339 or YYYY-MM-DD.
+func (h *AdminHandler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.auditLogRepo != nil, apierror.RepoNotConfigured, "audit log repository not configured") {
+		return
+	}
+
+	q := r.URL.Query()
+	opts := models.AuditListOptions{
+		ActorType:  q.Get("actor_type"),
+		ActorID:    q.Get("actor_id"),
+		Action:     strings.ToUpper(q.Get("action")),
+		Route:      q.Get("route"),
+		TargetType: q.Get("target_type"),
+		TargetID:   q.Get("target_id"),
+		Page:       1,
+		PerPage:    20,
+	}
+
+	switch opts.ActorType {
+	case "", string(models.AuthorTypeHuman), string(models.AuthorTypeAgent), models.AuditActorAdmin:
+	default:
+		apierror.Write(w, apierror.ValidationError, "actor_type must be human, agent or admin")
+		return
+	}
+
+	switch opts.Action {
+	case "", http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		apierror.Write(w, apierror.ValidationError, "action must be POST, PUT, PATCH or DELETE")
+		return
+	}
+
+	var err error
+	if opts.FromDate, err = parseAdminTime(q.Get("from")); err != nil {
+		apierror.Write(w, apierror.ValidationError, "from must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+	if opts.ToDate, err = parseAdminTime(q.Get("to")); err != nil {
+		apierror.Write(w, apierror.ValidationError, "to must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+
+	if pageStr := q.Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			opts.Page = p
+		}
+	}
+	if perPageStr := q.Get("per_page"); perPageStr != "" {
+		if pp, err := strconv.Atoi(perPageStr); err == nil && pp > 0 && pp <= 100 {
+			opts.PerPage = pp
+		}
+	}
+
+	entries, total, err := h.auditLogRepo.List(r.Context(), opts)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list audit log")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": entries,
+		"meta": map[string]interface{}{
+			"total":    total,
+			"page":     opts.Page,
+			"per_page": opts.PerPage,
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockAuditLogRepo is a test double for AuditLogRepo.
+type mockAuditLogRepo struct {
+	opts    models.AuditListOptions
+	entries []models.AuditLog
+}
+
+func (m *mockAuditLogRepo) List(ctx context.Context, opts models.AuditListOptions) ([]models.AuditLog, int, error) {
+	m.opts = opts
+	return m.entries, len(m.entries), nil
+}
+
+func TestAdminHandler_ListAuditLog_NotConfigured(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	w := httptest.NewRecorder()
+	handler.ListAuditLog(w, newAdminUserRequest(http.MethodGet, "/v1/admin/audit", "", ""))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ListAuditLog_Unauthorized(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAuditLogRepo(&mockAuditLogRepo{})
+	w := httptest.NewRecorder()
+	handler.ListAuditLog(w, httptest.NewRequest(http.MethodGet, "/v1/admin/audit", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ListAuditLog_Filters(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockAuditLogRepo{entries: []models.AuditLog{{ID: "audit-1", ActorType: "agent", ActorID: "agent_a", Action: "DELETE"}}}
+	handler := NewAdminHandler(nil)
+	handler.SetAuditLogRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.ListAuditLog(w, newAdminUserRequest(http.MethodGet,
+		"/v1/admin/audit?actor_type=agent&actor_id=agent_a&action=delete&route=/v1/posts/{id}&target_id=post-1&from=2026-01-01&to=2026-02-01T00:00:00Z&page=2&per_page=50", "", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	opts := repo.opts
+	if opts.ActorType != "agent" || opts.ActorID != "agent_a" || opts.Action != "DELETE" ||
+		opts.Route != "/v1/posts/{id}" || opts.TargetID != "post-1" || opts.Page != 2 || opts.PerPage != 50 {
+		t.Errorf("unexpected list options: %+v", opts)
+	}
+	if opts.FromDate == nil || !opts.FromDate.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || opts.ToDate == nil {
+		t.Errorf("unexpected date range: %v - %v", opts.FromDate, opts.ToDate)
+	}
+
+	var resp struct {
+		Data []models.AuditLog `json:"data"`
+		Meta struct {
+			Total int `json:"total"`
+		} `json:"meta"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID != "audit-1" || resp.Meta.Total != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestAdminHandler_ListAuditLog_InvalidFilters(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAuditLogRepo(&mockAuditLogRepo{})
+
+	for _, query := range []string{"actor_type=robot", "action=GET", "from=last-week"} {
+		w := httptest.NewRecorder()
+		handler.ListAuditLog(w, newAdminUserRequest(http.MethodGet, "/v1/admin/audit?"+query, "", ""))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ChangeEventRepo reads the change data capture event log.
+type ChangeEventRepo interface {
+	ListSince(ctx context.Context, since int64, entities []string, limit int) ([]models.ChangeEvent, error)
+}
+
+// SetChangeEventRepo injects the change event repository dependency.
+func (h *AdminHandler) SetChangeEventRepo(repo ChangeEventRepo) {
+	h.changeEventRepo = repo
+}
+
+// ListChangeEvents returns content changes after a sequence number, oldest
+// first, for consumers that keep an external index or warehouse in sync.
+// Consumers pass meta.next_since back as since until has_more is false.
+// Payloads include drafts and private content, so the log is admin only.
+// GET /v1/events/changes?since=0&limit=100&entity=post,answer
+func (h *AdminHandler) ListChangeEvents(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.changeEventRepo != nil, apierror.RepoNotConfigured, "change event repository not configured") {
+		return
+	}
+
+	query := r.URL.Query()
+	var v Validator
+	var since int64
+	if s := query.Get("since"); s != "" {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n < 0 {
+			v.Add(FieldError{Field: "since", Code: FieldInvalidValue, Message: "since must be a non-negative integer"})
+		}
+		since = n
+	}
+	var entities []string
+	if e := query.Get("entity"); e != "" {
+		for _, entity := range strings.Split(e, ",") {
+			entity = strings.TrimSpace(entity)
+			v.OneOf("entity", entity, models.ChangeEventEntities...)
+			entities = append(entities, entity)
+		}
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	limit := models.DefaultChangeEventsLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= models.MaxChangeEventsLimit {
+			limit = l
+		}
+	}
+
+	// One extra row tells whether another page follows.
+	events, err := h.changeEventRepo.ListSince(r.Context(), since, entities, limit+1)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list change events")
+		return
+	}
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+	nextSince := since
+	if len(events) > 0 {
+		nextSince = events[len(events)-1].Seq
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": events,
+		"meta": map[string]interface{}{
+			"next_since": nextSince,
+			"has_more":   hasMore,
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockChangeEventRepo implements ChangeEventRepo for testing.
+type mockChangeEventRepo struct {
+	events   []models.ChangeEvent
+	since    int64
+	entities []string
+	limit    int
+}
+
+func (m *mockChangeEventRepo) ListSince(ctx context.Context, since int64, entities []string, limit int) ([]models.ChangeEvent, error) {
+	m.since, m.entities, m.limit = since, entities, limit
+	var out []models.ChangeEvent
+	for _, e := range m.events {
+		if e.Seq > since && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func TestAdminHandler_ListChangeEvents(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	w := httptest.NewRecorder()
+	handler.ListChangeEvents(w, newAdminIntegrationRequest(http.MethodGet, "/v1/events/changes", "", ""))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without repo, got %d", w.Code)
+	}
+
+	repo := &mockChangeEventRepo{events: []models.ChangeEvent{
+		{Seq: 1, Entity: "post", EntityID: "p1", Op: models.ChangeOpInsert, Payload: json.RawMessage(`{}`)},
+		{Seq: 2, Entity: "answer", EntityID: "a1", Op: models.ChangeOpInsert, Payload: json.RawMessage(`{}`)},
+		{Seq: 3, Entity: "post", EntityID: "p1", Op: models.ChangeOpUpdate, Payload: json.RawMessage(`{}`)},
+	}}
+	handler.SetChangeEventRepo(repo)
+
+	tests := []struct {
+		name          string
+		query         string
+		wantStatus    int
+		wantSeqs      []int64
+		wantNextSince int64
+		wantHasMore   bool
+	}{
+		{"from start", "", http.StatusOK, []int64{1, 2, 3}, 3, false},
+		{"paged", "?since=1&limit=1", http.StatusOK, []int64{2}, 2, true},
+		{"caught up", "?since=3", http.StatusOK, []int64{}, 3, false},
+		{"negative since", "?since=-1", http.StatusBadRequest, nil, 0, false},
+		{"bad since", "?since=abc", http.StatusBadRequest, nil, 0, false},
+		{"unknown entity", "?entity=user", http.StatusBadRequest, nil, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ListChangeEvents(w, newAdminIntegrationRequest(http.MethodGet, "/v1/events/changes"+tt.query, "", ""))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Data []models.ChangeEvent `json:"data"`
+				Meta struct {
+					NextSince int64 `json:"next_since"`
+					HasMore   bool  `json:"has_more"`
+				} `json:"meta"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			seqs := []int64{}
+			for _, e := range resp.Data {
+				seqs = append(seqs, e.Seq)
+			}
+			if !reflect.DeepEqual(seqs, tt.wantSeqs) {
+				t.Errorf("seqs = %v, want %v", seqs, tt.wantSeqs)
+			}
+			if resp.Meta.NextSince != tt.wantNextSince || resp.Meta.HasMore != tt.wantHasMore {
+				t.Errorf("meta = %+v, want next_since %d has_more %v", resp.Meta, tt.wantNextSince, tt.wantHasMore)
+			}
+		})
+	}
+
+	w = httptest.NewRecorder()
+	handler.ListChangeEvents(w, newAdminIntegrationRequest(http.MethodGet, "/v1/events/changes?entity=post,+answer", "", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !reflect.DeepEqual(repo.entities, []string{"post", "answer"}) {
+		t.Errorf("entities = %v, want [post answer]", repo.entities)
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ConfigReloader re-reads the runtime-tunable settings.
+type ConfigReloader interface {
+	Reload() (models.ConfigReloadResult, error)
+}
+
+// SetConfigReloader injects the runtime config reloader dependency.
+func (h *AdminHandler) SetConfigReloader(r ConfigReloader) {
+	h.configReloader = r
+}
+
+// ReloadConfig re-reads rate limits, job intervals, the moderation model and the
+// maintenance flags without a restart, the same as sending SIGHUP. Returns the
+// settings that changed; if the new settings cannot be read the old ones stay.
+// POST /v1/admin/config/reload
+func (h *AdminHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.configReloader != nil, apierror.NotConfigured, "config reloader not configured") {
+		return
+	}
+
+	result, err := h.configReloader.Reload()
+	if err != nil {
+		apierror.Write(w, apierror.InvalidConfig, err.Error())
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": result,
+	})
+}
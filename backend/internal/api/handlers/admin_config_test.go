@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// MockConfigReloader implements ConfigReloader for testing.
+type MockConfigReloader struct {
+	result models.ConfigReloadResult
+	err    error
+	calls  int
+}
+
+func (m *MockConfigReloader) Reload() (models.ConfigReloadResult, error) {
+	m.calls++
+	return m.result, m.err
+}
+
+func TestAdminHandler_ReloadConfig(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	reloader := &MockConfigReloader{result: models.ConfigReloadResult{
+		ReloadedAt: time.Now(),
+		Changes:    []models.ConfigChange{{Key: "GROQ_MODEL", Old: "a", New: "b"}},
+	}}
+	handler := NewAdminHandler(nil)
+	handler.SetConfigReloader(reloader)
+
+	w := httptest.NewRecorder()
+	handler.ReloadConfig(w, newAdminIntegrationRequest(http.MethodPost, "/v1/admin/config/reload", "", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data models.ConfigReloadResult `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if reloader.calls != 1 || len(resp.Data.Changes) != 1 || resp.Data.Changes[0].New != "b" {
+		t.Errorf("unexpected reload response %+v (calls=%d)", resp.Data, reloader.calls)
+	}
+}
+
+func TestAdminHandler_ReloadConfigErrors(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name       string
+		reloader   ConfigReloader
+		apiKey     string
+		wantStatus int
+	}{
+		{"invalid config", &MockConfigReloader{err: errors.New("invalid JOB_INTERVALS entry")}, "test-admin-key", http.StatusBadRequest},
+		{"not configured", nil, "test-admin-key", http.StatusServiceUnavailable},
+		{"wrong key", &MockConfigReloader{}, "wrong", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAdminHandler(nil)
+			if tt.reloader != nil {
+				handler.SetConfigReloader(tt.reloader)
+			}
+			req := newAdminIntegrationRequest(http.MethodPost, "/v1/admin/config/reload", "", "")
+			req.Header.Set("X-Admin-API-Key", tt.apiKey)
+
+			w := httptest.NewRecorder()
+			handler.ReloadConfig(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// AdminDashboardReader aggregates the ops overview.
+type AdminDashboardReader interface {
+	GetDashboard(ctx context.Context) (*models.AdminDashboard, error)
+}
+
+// SetDashboardRepo injects the dashboard repository dependency.
+func (h *AdminHandler) SetDashboardRepo(repo AdminDashboardReader) {
+	h.dashboardRepo = repo
+}
+
+// GetDashboard returns queue depths, 24h activity, error rates and external-service
+// health in one payload for the ops UI.
+// GET /v1/admin/dashboard
+func (h *AdminHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.dashboardRepo != nil, apierror.NotConfigured, "dashboard not configured") {
+		return
+	}
+
+	dashboard, err := h.dashboardRepo.GetDashboard(r.Context())
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to load dashboard")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": dashboard,
+	})
+}
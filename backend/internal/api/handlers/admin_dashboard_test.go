@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockDashboardRepo implements AdminDashboardReader for testing.
+type mockDashboardRepo struct {
+	dashboard *models.AdminDashboard
+	err       error
+}
+
+func (m *mockDashboardRepo) GetDashboard(ctx context.Context) (*models.AdminDashboard, error) {
+	return m.dashboard, m.err
+}
+
+func TestAdminHandler_GetDashboard(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetDashboardRepo(&mockDashboardRepo{dashboard: &models.AdminDashboard{
+		Queues:      models.DashboardQueues{ModerationPending: 3, EmbeddingsPending: 2, EmbeddingsFailing: 1},
+		Activity24h: models.DashboardActivity{Posts: 12},
+		ErrorRates:  models.DashboardErrorRates{Requests: 200, Errors: 5, ErrorRate: 0.025},
+		Services:    []models.DashboardService{{ServiceName: "ipfs", Status: models.ServiceStatusDegraded, Uptime24h: 97.5}},
+	}})
+
+	w := httptest.NewRecorder()
+	handler.GetDashboard(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/dashboard", "", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data models.AdminDashboard `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Data.Queues.ModerationPending != 3 || resp.Data.Queues.EmbeddingsFailing != 1 {
+		t.Errorf("unexpected queues: %+v", resp.Data.Queues)
+	}
+	if resp.Data.ErrorRates.ErrorRate != 0.025 {
+		t.Errorf("expected error_rate 0.025, got %v", resp.Data.ErrorRates.ErrorRate)
+	}
+	if len(resp.Data.Services) != 1 || resp.Data.Services[0].Status != models.ServiceStatusDegraded {
+		t.Errorf("unexpected services: %+v", resp.Data.Services)
+	}
+}
+
+func TestAdminHandler_GetDashboard_Errors(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name       string
+		repo       AdminDashboardReader
+		key        bool
+		wantStatus int
+	}{
+		{"missing admin key", &mockDashboardRepo{dashboard: &models.AdminDashboard{}}, false, http.StatusUnauthorized},
+		{"not configured", nil, true, http.StatusServiceUnavailable},
+		{"repository error", &mockDashboardRepo{err: errors.New("boom")}, true, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAdminHandler(nil)
+			if tt.repo != nil {
+				handler.SetDashboardRepo(tt.repo)
+			}
+
+			req := newAdminIntegrationRequest(http.MethodGet, "/v1/admin/dashboard", "", "")
+			if !tt.key {
+				req.Header.Del("X-Admin-API-Key")
+			}
+			w := httptest.NewRecorder()
+			handler.GetDashboard(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
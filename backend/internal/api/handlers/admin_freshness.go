@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// FreshnessReviewRepo lists aging accepted solutions and records admin reviews.
+type FreshnessReviewRepo interface {
+	ListReviewQueue(ctx context.Context, limit int) ([]models.FreshnessReviewItem, error)
+	MarkReviewed(ctx context.Context, postID string) (*models.PostFreshness, error)
+}
+
+// SetFreshnessReviewRepo injects the freshness review repository dependency.
+func (h *AdminHandler) SetFreshnessReviewRepo(repo FreshnessReviewRepo) {
+	h.freshnessReviewRepo = repo
+}
+
+// ListFreshnessReviewQueue lists outdated accepted solutions — solved problems
+// and questions with an accepted answer — least fresh first.
+// GET /v1/admin/freshness/review-queue?limit=50
+func (h *AdminHandler) ListFreshnessReviewQueue(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.freshnessReviewRepo != nil, apierror.RepoNotConfigured, "freshness review repository not configured") {
+		return
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 200 {
+			limit = l
+		}
+	}
+
+	items, err := h.freshnessReviewRepo.ListReviewQueue(r.Context(), limit)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list freshness review queue")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": items})
+}
+
+// ReviewPostFreshness marks a post as checked and still accurate: its outdated
+// flags are cleared and its age counts from now, which takes it off the queue.
+// Outdated posts that need changes should be edited instead.
+// POST /v1/admin/freshness/{id}/review
+func (h *AdminHandler) ReviewPostFreshness(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.freshnessReviewRepo != nil, apierror.RepoNotConfigured, "freshness review repository not configured") {
+		return
+	}
+
+	f, err := h.freshnessReviewRepo.MarkReviewed(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, db.ErrPostNotFound) {
+			apierror.Write(w, apierror.NotFound, "post not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to review post freshness")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": f})
+}
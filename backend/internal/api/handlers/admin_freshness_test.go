@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockFreshnessReviewRepo implements FreshnessReviewRepo for testing.
+type mockFreshnessReviewRepo struct {
+	limit    int
+	reviewed string
+}
+
+func (m *mockFreshnessReviewRepo) ListReviewQueue(ctx context.Context, limit int) ([]models.FreshnessReviewItem, error) {
+	m.limit = limit
+	return []models.FreshnessReviewItem{{PostID: "post-1", Type: models.PostTypeProblem, Score: 22, OutdatedFlags: 3}}, nil
+}
+
+func (m *mockFreshnessReviewRepo) MarkReviewed(ctx context.Context, postID string) (*models.PostFreshness, error) {
+	if postID != "post-1" {
+		return nil, db.ErrPostNotFound
+	}
+	m.reviewed = postID
+	score := 100
+	return &models.PostFreshness{PostID: postID, Score: &score}, nil
+}
+
+func TestAdminHandler_ListFreshnessReviewQueue(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name      string
+		query     string
+		wantLimit int
+	}{
+		{"default limit", "", 50},
+		{"custom limit", "?limit=10", 10},
+		{"limit over max ignored", "?limit=500", 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockFreshnessReviewRepo{}
+			handler := NewAdminHandler(nil)
+			handler.SetFreshnessReviewRepo(repo)
+
+			w := httptest.NewRecorder()
+			handler.ListFreshnessReviewQueue(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/freshness/review-queue"+tt.query, "", ""))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if repo.limit != tt.wantLimit {
+				t.Errorf("expected limit %d, got %d", tt.wantLimit, repo.limit)
+			}
+			var resp struct {
+				Data []models.FreshnessReviewItem `json:"data"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(resp.Data) != 1 || resp.Data[0].PostID != "post-1" {
+				t.Errorf("unexpected queue: %+v", resp.Data)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_ReviewPostFreshness(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockFreshnessReviewRepo{}
+	handler := NewAdminHandler(nil)
+	handler.SetFreshnessReviewRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.ReviewPostFreshness(w, newAdminIntegrationRequest(http.MethodPost, "/v1/admin/freshness/post-1/review", "post-1", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.reviewed != "post-1" {
+		t.Errorf("expected post-1 to be reviewed, got %q", repo.reviewed)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ReviewPostFreshness(w, newAdminIntegrationRequest(http.MethodPost, "/v1/admin/freshness/post-2/review", "post-2", ""))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown post, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_FreshnessReview_NotConfigured(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	w := httptest.NewRecorder()
+	NewAdminHandler(nil).ListFreshnessReviewQueue(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/freshness/review-queue", "", ""))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", w.Code)
+	}
+}
@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxChatIntegrationNameLength caps integration names.
+const maxChatIntegrationNameLength = 100
+
+// AdminChatIntegrationRepo manages Slack/Discord integrations.
+type AdminChatIntegrationRepo interface {
+	Create(ctx context.Context, integration *models.ChatIntegration) (*models.ChatIntegration, error)
+	FindByID(ctx context.Context, id string) (*models.ChatIntegration, error)
+	List(ctx context.Context) ([]models.ChatIntegration, error)
+	Update(ctx context.Context, id string, update models.ChatIntegrationUpdate) (*models.ChatIntegration, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// ChatIntegrationSender posts a single message to an integration's webhook.
+type ChatIntegrationSender interface {
+	Send(ctx context.Context, integration *models.ChatIntegration, post *models.Post) error
+}
+
+// SetChatIntegrationRepo injects the chat integration repository dependency.
+func (h *AdminHandler) SetChatIntegrationRepo(repo AdminChatIntegrationRepo) {
+	h.chatIntegrationRepo = repo
+}
+
+// SetChatIntegrationSender injects the sender used by the test endpoint.
+func (h *AdminHandler) SetChatIntegrationSender(sender ChatIntegrationSender) {
+	h.chatIntegrationSender = sender
+}
+
+// CreateChatIntegrationRequest is the JSON body for POST /v1/admin/integrations.
+type CreateChatIntegrationRequest struct {
+	Name       string   `json:"name"`
+	Provider   string   `json:"provider"`
+	WebhookURL string   `json:"webhook_url"`
+	Tags       []string `json:"tags"`
+	PostTypes  []string `json:"post_types,omitempty"`
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+// UpdateChatIntegrationRequest is the JSON body for PATCH /v1/admin/integrations/{id}.
+// Omitted fields are unchanged.
+type UpdateChatIntegrationRequest struct {
+	Name       *string  `json:"name,omitempty"`
+	WebhookURL *string  `json:"webhook_url,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	PostTypes  []string `json:"post_types,omitempty"`
+	Enabled    *bool    `json:"enabled,omitempty"`
+}
+
+// ListChatIntegrations returns every integration with webhook URLs redacted.
+// GET /v1/admin/integrations
+func (h *AdminHandler) ListChatIntegrations(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.chatIntegrationRepo != nil, apierror.RepoNotConfigured, "integration repository not configured") {
+		return
+	}
+
+	integrations, err := h.chatIntegrationRepo.List(r.Context())
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list integrations")
+		return
+	}
+	for i := range integrations {
+		integrations[i] = integrations[i].Redacted()
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": integrations,
+	})
+}
+
+// CreateChatIntegration adds a Slack or Discord integration.
+// POST /v1/admin/integrations
+func (h *AdminHandler) CreateChatIntegration(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.chatIntegrationRepo != nil, apierror.RepoNotConfigured, "integration repository not configured") {
+		return
+	}
+
+	var req CreateChatIntegrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxChatIntegrationNameLength {
+		apierror.Write(w, apierror.ValidationError, "name is required (max 100 characters)")
+		return
+	}
+	if !models.IsValidChatProvider(req.Provider) {
+		apierror.Write(w, apierror.ValidationError, "provider must be slack or discord")
+		return
+	}
+	if !models.IsValidChatWebhookURL(req.Provider, req.WebhookURL) {
+		apierror.Write(w, apierror.ValidationError, "webhook_url is not a "+req.Provider+" incoming webhook URL")
+		return
+	}
+	tags, msg := normalizeChatIntegrationTags(req.Tags)
+	if msg != "" {
+		apierror.Write(w, apierror.ValidationError, msg)
+		return
+	}
+	postTypes := models.DefaultChatIntegrationPostTypes
+	if req.PostTypes != nil {
+		if postTypes, msg = validateChatIntegrationPostTypes(req.PostTypes); msg != "" {
+			apierror.Write(w, apierror.ValidationError, msg)
+			return
+		}
+	}
+
+	created, err := h.chatIntegrationRepo.Create(r.Context(), &models.ChatIntegration{
+		Name:       name,
+		Provider:   req.Provider,
+		WebhookURL: req.WebhookURL,
+		Tags:       tags,
+		PostTypes:  postTypes,
+		Enabled:    req.Enabled == nil || *req.Enabled,
+	})
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to create integration")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusCreated, map[string]interface{}{
+		"data": created.Redacted(),
+	})
+}
+
+// UpdateChatIntegration changes an integration's name, webhook, tags, post types
+// or enabled flag. Re-enabling or replacing the webhook resets its failure count.
+// PATCH /v1/admin/integrations/{id}
+func (h *AdminHandler) UpdateChatIntegration(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.chatIntegrationFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateChatIntegrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	update := models.ChatIntegrationUpdate{Enabled: req.Enabled}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > maxChatIntegrationNameLength {
+			apierror.Write(w, apierror.ValidationError, "name must be 1-100 characters")
+			return
+		}
+		update.Name = &name
+	}
+	if req.WebhookURL != nil {
+		// The provider can't change, so the URL is checked against the stored one.
+		existing, err := h.chatIntegrationRepo.FindByID(r.Context(), id)
+		if err != nil {
+			h.writeChatIntegrationError(w, err, "failed to load integration")
+			return
+		}
+		if !models.IsValidChatWebhookURL(existing.Provider, *req.WebhookURL) {
+			apierror.Write(w, apierror.ValidationError, "webhook_url is not a "+existing.Provider+" incoming webhook URL")
+			return
+		}
+		update.WebhookURL = req.WebhookURL
+	}
+	if req.Tags != nil {
+		tags, msg := normalizeChatIntegrationTags(req.Tags)
+		if msg != "" {
+			apierror.Write(w, apierror.ValidationError, msg)
+			return
+		}
+		update.Tags = tags
+	}
+	if req.PostTypes != nil {
+		postTypes, msg := validateChatIntegrationPostTypes(req.PostTypes)
+		if msg != "" {
+			apierror.Write(w, apierror.ValidationError, msg)
+			return
+		}
+		update.PostTypes = postTypes
+	}
+
+	updated, err := h.chatIntegrationRepo.Update(r.Context(), id, update)
+	if err != nil {
+		h.writeChatIntegrationError(w, err, "failed to update integration")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": updated.Redacted(),
+	})
+}
+
+// DeleteChatIntegration removes an integration.
+// DELETE /v1/admin/integrations/{id}
+func (h *AdminHandler) DeleteChatIntegration(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.chatIntegrationFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.chatIntegrationRepo.Delete(r.Context(), id); err != nil {
+		h.writeChatIntegrationError(w, err, "failed to delete integration")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TestChatIntegration posts a sample message to the integration's webhook so
+// admins can check the URL before real posts arrive. Works on disabled integrations.
+// POST /v1/admin/integrations/{id}/test
+func (h *AdminHandler) TestChatIntegration(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.chatIntegrationFromPath(w, r)
+	if !ok {
+		return
+	}
+	if h.chatIntegrationSender == nil {
+		apierror.Write(w, apierror.SenderNotConfigured, "chat integration sender not configured")
+		return
+	}
+
+	integration, err := h.chatIntegrationRepo.FindByID(r.Context(), id)
+	if err != nil {
+		h.writeChatIntegrationError(w, err, "failed to load integration")
+		return
+	}
+
+	sample := &models.Post{
+		ID:          "00000000-0000-0000-0000-000000000000",
+		Type:        models.PostTypeQuestion,
+		Title:       "Solvr integration test: " + integration.Name,
+		Description: "This is a test message. New problems and questions tagged " + strings.Join(integration.Tags, ", ") + " will appear here.",
+		Tags:        integration.Tags,
+	}
+	if err := h.chatIntegrationSender.Send(r.Context(), integration, sample); err != nil {
+		apierror.Write(w, apierror.DeliveryFailed, err.Error())
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{"delivered": true},
+	})
+}
+
+// chatIntegrationFromPath checks access and returns the {id} URL parameter.
+// It writes the error response when ok is false.
+func (h *AdminHandler) chatIntegrationFromPath(w http.ResponseWriter, r *http.Request) (id string, ok bool) {
+	if !h.requireAdmin(w, r, h.chatIntegrationRepo != nil, apierror.RepoNotConfigured, "integration repository not configured") {
+		return "", false
+	}
+	id = chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid integration ID")
+		return "", false
+	}
+	return id, true
+}
+
+func (h *AdminHandler) writeChatIntegrationError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, db.ErrChatIntegrationNotFound) {
+		apierror.Write(w, apierror.NotFound, "integration not found")
+		return
+	}
+	apierror.Write(w, apierror.InternalError, message)
+}
+
+// normalizeChatIntegrationTags normalizes and de-duplicates tags. Returns a
+// validation message when the list is empty, too long or has an invalid tag.
+func normalizeChatIntegrationTags(raw []string) ([]string, string) {
+	if len(raw) == 0 {
+		return nil, "tags must list at least one tag"
+	}
+	if len(raw) > models.MaxChatIntegrationTags {
+		return nil, "tags allows at most 20 tags"
+	}
+	seen := make(map[string]bool, len(raw))
+	tags := make([]string, 0, len(raw))
+	for _, t := range raw {
+		tag := models.NormalizeTag(t)
+		if !models.IsValidTag(tag) {
+			return nil, "invalid tag: " + t
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags, ""
+}
+
+// validateChatIntegrationPostTypes accepts a non-empty subset of problem and question.
+func validateChatIntegrationPostTypes(raw []string) ([]string, string) {
+	if len(raw) == 0 {
+		return nil, "post_types must list problem and/or question"
+	}
+	seen := make(map[string]bool, len(raw))
+	types := make([]string, 0, len(raw))
+	for _, t := range raw {
+		if t != string(models.PostTypeProblem) && t != string(models.PostTypeQuestion) {
+			return nil, "post_types must list problem and/or question"
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	return types, ""
+}
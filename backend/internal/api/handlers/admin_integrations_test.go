@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+const testSlackWebhook = "https://hooks.slack.com/services/T000/B000/secret"
+
+// mockChatIntegrationRepo is a test double for AdminChatIntegrationRepo.
+type mockChatIntegrationRepo struct {
+	integrations map[string]*models.ChatIntegration
+	created      *models.ChatIntegration
+	lastUpdate   models.ChatIntegrationUpdate
+}
+
+func newMockChatIntegrationRepo() *mockChatIntegrationRepo {
+	return &mockChatIntegrationRepo{integrations: map[string]*models.ChatIntegration{
+		"11111111-1111-1111-1111-111111111111": {
+			ID: "11111111-1111-1111-1111-111111111111", Name: "Go team", Provider: models.ChatProviderSlack,
+			WebhookURL: testSlackWebhook, Tags: []string{"go"}, PostTypes: []string{"question"}, Enabled: true,
+		},
+	}}
+}
+
+func (m *mockChatIntegrationRepo) Create(ctx context.Context, integration *models.ChatIntegration) (*models.ChatIntegration, error) {
+	m.created = integration
+	c := *integration
+	c.ID = "22222222-2222-2222-2222-222222222222"
+	return &c, nil
+}
+
+func (m *mockChatIntegrationRepo) FindByID(ctx context.Context, id string) (*models.ChatIntegration, error) {
+	if c, ok := m.integrations[id]; ok {
+		return c, nil
+	}
+	return nil, db.ErrChatIntegrationNotFound
+}
+
+func (m *mockChatIntegrationRepo) List(ctx context.Context) ([]models.ChatIntegration, error) {
+	list := []models.ChatIntegration{}
+	for _, c := range m.integrations {
+		list = append(list, *c)
+	}
+	return list, nil
+}
+
+func (m *mockChatIntegrationRepo) Update(ctx context.Context, id string, update models.ChatIntegrationUpdate) (*models.ChatIntegration, error) {
+	c, ok := m.integrations[id]
+	if !ok {
+		return nil, db.ErrChatIntegrationNotFound
+	}
+	m.lastUpdate = update
+	return c, nil
+}
+
+func (m *mockChatIntegrationRepo) Delete(ctx context.Context, id string) error {
+	if _, ok := m.integrations[id]; !ok {
+		return db.ErrChatIntegrationNotFound
+	}
+	delete(m.integrations, id)
+	return nil
+}
+
+type mockChatIntegrationSender struct {
+	err  error
+	sent *models.Post
+}
+
+func (m *mockChatIntegrationSender) Send(ctx context.Context, integration *models.ChatIntegration, post *models.Post) error {
+	m.sent = post
+	return m.err
+}
+
+func newAdminIntegrationRequest(method, path, id, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	rctx := chi.NewRouteContext()
+	if id != "" {
+		rctx.URLParams.Add("id", id)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestAdminHandler_CreateChatIntegration(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"slack", `{"name":"Go team","provider":"slack","webhook_url":"` + testSlackWebhook + `","tags":["Go","go","postgres"]}`, http.StatusCreated},
+		{"discord", `{"name":"Infra","provider":"discord","webhook_url":"https://discord.com/api/webhooks/1/abc","tags":["k8s"],"post_types":["problem"]}`, http.StatusCreated},
+		{"unknown provider", `{"name":"x","provider":"teams","webhook_url":"` + testSlackWebhook + `","tags":["go"]}`, http.StatusBadRequest},
+		{"url for other provider", `{"name":"x","provider":"discord","webhook_url":"` + testSlackWebhook + `","tags":["go"]}`, http.StatusBadRequest},
+		{"arbitrary host", `{"name":"x","provider":"slack","webhook_url":"https://internal.example/services/x","tags":["go"]}`, http.StatusBadRequest},
+		{"no tags", `{"name":"x","provider":"slack","webhook_url":"` + testSlackWebhook + `","tags":[]}`, http.StatusBadRequest},
+		{"bad post type", `{"name":"x","provider":"slack","webhook_url":"` + testSlackWebhook + `","tags":["go"],"post_types":["idea"]}`, http.StatusBadRequest},
+		{"no name", `{"provider":"slack","webhook_url":"` + testSlackWebhook + `","tags":["go"]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockChatIntegrationRepo()
+			handler := NewAdminHandler(nil)
+			handler.SetChatIntegrationRepo(repo)
+
+			w := httptest.NewRecorder()
+			handler.CreateChatIntegration(w, newAdminIntegrationRequest(http.MethodPost, "/v1/admin/integrations", "", tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminHandler_CreateChatIntegration_NormalizesAndRedacts(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := newMockChatIntegrationRepo()
+	handler := NewAdminHandler(nil)
+	handler.SetChatIntegrationRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.CreateChatIntegration(w, newAdminIntegrationRequest(http.MethodPost, "/v1/admin/integrations", "",
+		`{"name":"Go team","provider":"slack","webhook_url":"`+testSlackWebhook+`","tags":["Go","go","postgres"]}`))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if strings.Join(repo.created.Tags, ",") != "go,postgres" {
+		t.Errorf("expected normalized tags, got %v", repo.created.Tags)
+	}
+	if strings.Join(repo.created.PostTypes, ",") != "problem,question" || !repo.created.Enabled {
+		t.Errorf("expected default post types and enabled, got %+v", repo.created)
+	}
+	if strings.Contains(w.Body.String(), "secret") {
+		t.Errorf("response leaked the webhook URL: %s", w.Body.String())
+	}
+}
+
+func TestAdminHandler_ListChatIntegrations(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetChatIntegrationRepo(newMockChatIntegrationRepo())
+
+	w := httptest.NewRecorder()
+	handler.ListChatIntegrations(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/integrations", "", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data []models.ChatIntegration `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Data) != 1 || resp.Data[0].WebhookURL != "https://hooks.slack.com/…" {
+		t.Errorf("expected one redacted integration, got %+v", resp.Data)
+	}
+
+	w = httptest.NewRecorder()
+	req := newAdminIntegrationRequest(http.MethodGet, "/v1/admin/integrations", "", "")
+	req.Header.Del("X-Admin-API-Key")
+	handler.ListChatIntegrations(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without admin key, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_UpdateChatIntegration(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	const id = "11111111-1111-1111-1111-111111111111"
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		wantStatus int
+	}{
+		{"disable", id, `{"enabled":false}`, http.StatusOK},
+		{"retag", id, `{"tags":["Rust"]}`, http.StatusOK},
+		{"new slack url", id, `{"webhook_url":"https://hooks.slack.com/services/T1/B1/new"}`, http.StatusOK},
+		{"discord url on slack integration", id, `{"webhook_url":"https://discord.com/api/webhooks/1/abc"}`, http.StatusBadRequest},
+		{"empty tags", id, `{"tags":[]}`, http.StatusBadRequest},
+		{"invalid id", "not-a-uuid", `{"enabled":false}`, http.StatusBadRequest},
+		{"unknown id", "33333333-3333-3333-3333-333333333333", `{"enabled":false}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockChatIntegrationRepo()
+			handler := NewAdminHandler(nil)
+			handler.SetChatIntegrationRepo(repo)
+
+			w := httptest.NewRecorder()
+			handler.UpdateChatIntegration(w, newAdminIntegrationRequest(http.MethodPatch, "/v1/admin/integrations/"+tt.id, tt.id, tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.name == "retag" && strings.Join(repo.lastUpdate.Tags, ",") != "rust" {
+				t.Errorf("expected normalized tags in update, got %v", repo.lastUpdate.Tags)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_DeleteChatIntegration(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	const id = "11111111-1111-1111-1111-111111111111"
+	handler := NewAdminHandler(nil)
+	handler.SetChatIntegrationRepo(newMockChatIntegrationRepo())
+
+	w := httptest.NewRecorder()
+	handler.DeleteChatIntegration(w, newAdminIntegrationRequest(http.MethodDelete, "/v1/admin/integrations/"+id, id, ""))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.DeleteChatIntegration(w, newAdminIntegrationRequest(http.MethodDelete, "/v1/admin/integrations/"+id, id, ""))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 on second delete, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_TestChatIntegration(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	const id = "11111111-1111-1111-1111-111111111111"
+	sender := &mockChatIntegrationSender{}
+	handler := NewAdminHandler(nil)
+	handler.SetChatIntegrationRepo(newMockChatIntegrationRepo())
+	handler.SetChatIntegrationSender(sender)
+
+	w := httptest.NewRecorder()
+	handler.TestChatIntegration(w, newAdminIntegrationRequest(http.MethodPost, "/v1/admin/integrations/"+id+"/test", id, ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if sender.sent == nil || !strings.Contains(sender.sent.Title, "Go team") {
+		t.Errorf("expected a sample post naming the integration, got %+v", sender.sent)
+	}
+
+	sender.err = errors.New("slack webhook returned status 404: no_service")
+	w = httptest.NewRecorder()
+	handler.TestChatIntegration(w, newAdminIntegrationRequest(http.MethodPost, "/v1/admin/integrations/"+id+"/test", id, ""))
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("expected 502 when delivery fails, got %d", w.Code)
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// maxMaintenanceMessageLength caps the message returned with rejected writes.
+const maxMaintenanceMessageLength = 500
+
+// MaintenanceSwitch reads and flips read-only maintenance mode. Update applies to
+// every API replica when the switch is shared (status.shared), else to this one.
+type MaintenanceSwitch interface {
+	Status() models.MaintenanceStatus
+	Update(ctx context.Context, enabled bool, message string) (models.MaintenanceStatus, error)
+}
+
+// SetMaintenanceSwitch injects the maintenance switch dependency.
+func (h *AdminHandler) SetMaintenanceSwitch(s MaintenanceSwitch) {
+	h.maintenanceSwitch = s
+}
+
+// UpdateMaintenanceRequest is the JSON body for POST /v1/admin/maintenance.
+type UpdateMaintenanceRequest struct {
+	Enabled *bool  `json:"enabled"`
+	Message string `json:"message,omitempty"`
+}
+
+// GetMaintenance returns the maintenance mode state.
+// GET /v1/admin/maintenance
+func (h *AdminHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.maintenanceSwitch != nil, apierror.NotConfigured, "maintenance switch not configured") {
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": h.maintenanceSwitch.Status(),
+	})
+}
+
+// UpdateMaintenance turns read-only maintenance mode on or off. While on, mutating
+// endpoints return 503 with the message and background jobs are paused.
+// POST /v1/admin/maintenance
+func (h *AdminHandler) UpdateMaintenance(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.maintenanceSwitch != nil, apierror.NotConfigured, "maintenance switch not configured") {
+		return
+	}
+
+	var req UpdateMaintenanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	if req.Enabled == nil {
+		apierror.Write(w, apierror.ValidationError, "enabled is required")
+		return
+	}
+	if len(req.Message) > maxMaintenanceMessageLength {
+		apierror.Write(w, apierror.ValidationError, "message must be at most 500 characters")
+		return
+	}
+
+	status, err := h.maintenanceSwitch.Update(r.Context(), *req.Enabled, req.Message)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to save maintenance mode")
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": status,
+	})
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/maintenance"
+)
+
+func TestAdminHandler_UpdateMaintenance(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantEnabled bool
+	}{
+		{"enable with message", `{"enabled":true,"message":"Upgrading the database"}`, http.StatusOK, true},
+		{"disable", `{"enabled":false}`, http.StatusOK, false},
+		{"missing enabled", `{"message":"x"}`, http.StatusBadRequest, false},
+		{"message too long", `{"enabled":true,"message":"` + strings.Repeat("a", 501) + `"}`, http.StatusBadRequest, false},
+		{"invalid JSON", `{`, http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode := maintenance.New(false, "")
+			handler := NewAdminHandler(nil)
+			handler.SetMaintenanceSwitch(mode)
+
+			w := httptest.NewRecorder()
+			handler.UpdateMaintenance(w, newAdminIntegrationRequest(http.MethodPost, "/v1/admin/maintenance", "", tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if mode.Enabled() != tt.wantEnabled {
+				t.Errorf("expected enabled=%v, got %v", tt.wantEnabled, mode.Enabled())
+			}
+		})
+	}
+}
+
+func TestAdminHandler_GetMaintenance(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetMaintenanceSwitch(maintenance.New(true, "Upgrading the database"))
+
+	w := httptest.NewRecorder()
+	handler.GetMaintenance(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/maintenance", "", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data struct {
+			Enabled bool   `json:"enabled"`
+			Message string `json:"message"`
+			Since   string `json:"since"`
+			Shared  *bool  `json:"shared"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Data.Enabled || resp.Data.Message != "Upgrading the database" || resp.Data.Since == "" ||
+		resp.Data.Shared == nil || *resp.Data.Shared {
+		t.Errorf("unexpected status: %+v", resp.Data)
+	}
+}
+
+func TestAdminHandler_Maintenance_RequiresAdminKey(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetMaintenanceSwitch(maintenance.New(false, ""))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/maintenance", strings.NewReader(`{"enabled":true}`))
+	w := httptest.NewRecorder()
+	handler.UpdateMaintenance(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// maxModerationTemplateLength caps a template body.
+	maxModerationTemplateLength = 2000
+	// maxModerationTemplateLanguageLength caps a template's language name.
+	maxModerationTemplateLanguageLength = 50
+)
+
+// ModerationTemplateRepo stores the admin-edited moderation comment templates.
+type ModerationTemplateRepo interface {
+	List(ctx context.Context) ([]models.ModerationTemplate, error)
+	Upsert(ctx context.Context, tmpl *models.ModerationTemplate) (*models.ModerationTemplate, error)
+	Delete(ctx context.Context, key, language string) error
+}
+
+// SetModerationTemplateRepo injects the moderation template repository dependency.
+func (h *AdminHandler) SetModerationTemplateRepo(repo ModerationTemplateRepo) {
+	h.moderationTemplateRepo = repo
+}
+
+// PutModerationTemplateRequest is the JSON body for
+// PUT /v1/admin/moderation-templates/{key}/{language}.
+type PutModerationTemplateRequest struct {
+	Body string `json:"body"`
+}
+
+// ListModerationTemplates lists the stored templates together with the template
+// keys, their built-in defaults and the variables a template may use.
+// GET /v1/admin/moderation-templates
+func (h *AdminHandler) ListModerationTemplates(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.moderationTemplateRepo != nil, apierror.RepoNotConfigured, "moderation template repository not configured") {
+		return
+	}
+
+	templates, err := h.moderationTemplateRepo.List(r.Context())
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list moderation templates")
+		return
+	}
+
+	defaults := make(map[modtemplate.Key]string, len(modtemplate.Keys))
+	for _, key := range modtemplate.Keys {
+		defaults[key] = modtemplate.Default(key)
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"templates": templates,
+			"defaults":  defaults,
+			"variables": modtemplate.Variables,
+		},
+	})
+}
+
+// PutModerationTemplate creates or replaces the template for a key and language.
+// The language is the one the moderator detects, e.g. "portuguese"; "en" is the
+// fallback used for every other language.
+// PUT /v1/admin/moderation-templates/{key}/{language}
+func (h *AdminHandler) PutModerationTemplate(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.moderationTemplateRepo != nil, apierror.RepoNotConfigured, "moderation template repository not configured") {
+		return
+	}
+
+	key, language, ok := moderationTemplateParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req PutModerationTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
+		return
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		apierror.Write(w, apierror.ValidationError, "body is required")
+		return
+	}
+	if len(body) > maxModerationTemplateLength {
+		apierror.Write(w, apierror.ValidationError, "body must be at most 2000 characters")
+		return
+	}
+	if unknown := modtemplate.UnknownVariables(body); len(unknown) > 0 {
+		apierror.Write(w, apierror.ValidationError, "unknown template variables: "+strings.Join(unknown, ", "))
+		return
+	}
+
+	saved, err := h.moderationTemplateRepo.Upsert(r.Context(), &models.ModerationTemplate{
+		Key:       string(key),
+		Language:  language,
+		Body:      body,
+		UpdatedBy: "admin",
+	})
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to save moderation template")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": saved})
+}
+
+// DeleteModerationTemplate removes the template for a key and language, so
+// comments fall back to the English variant or the built-in default.
+// DELETE /v1/admin/moderation-templates/{key}/{language}
+func (h *AdminHandler) DeleteModerationTemplate(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.moderationTemplateRepo != nil, apierror.RepoNotConfigured, "moderation template repository not configured") {
+		return
+	}
+
+	key, language, ok := moderationTemplateParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.moderationTemplateRepo.Delete(r.Context(), string(key), language); err != nil {
+		if errors.Is(err, db.ErrModerationTemplateNotFound) {
+			apierror.Write(w, apierror.NotFound, "moderation template not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to delete moderation template")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// moderationTemplateParams validates the key and language URL params.
+func moderationTemplateParams(w http.ResponseWriter, r *http.Request) (modtemplate.Key, string, bool) {
+	key := modtemplate.Key(chi.URLParam(r, "key"))
+	if !modtemplate.IsValidKey(key) {
+		apierror.Write(w, apierror.ValidationError, "unknown template key")
+		return "", "", false
+	}
+	language := modtemplate.NormalizeLanguage(chi.URLParam(r, "language"))
+	if language == "" || len(language) > maxModerationTemplateLanguageLength {
+		apierror.Write(w, apierror.ValidationError, "language must be 1-50 characters")
+		return "", "", false
+	}
+	return key, language, true
+}
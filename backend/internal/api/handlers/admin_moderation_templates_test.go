@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockModerationTemplateRepo implements ModerationTemplateRepo for testing.
+type mockModerationTemplateRepo struct {
+	templates []models.ModerationTemplate
+	saved     *models.ModerationTemplate
+	deleteErr error
+}
+
+func (m *mockModerationTemplateRepo) List(ctx context.Context) ([]models.ModerationTemplate, error) {
+	return m.templates, nil
+}
+
+func (m *mockModerationTemplateRepo) Upsert(ctx context.Context, tmpl *models.ModerationTemplate) (*models.ModerationTemplate, error) {
+	m.saved = tmpl
+	return tmpl, nil
+}
+
+func (m *mockModerationTemplateRepo) Delete(ctx context.Context, key, language string) error {
+	return m.deleteErr
+}
+
+// newModerationTemplateRequest builds an admin request with the key and language URL params.
+func newModerationTemplateRequest(method, key, language, body string) *http.Request {
+	req := newAdminIntegrationRequest(method, "/v1/admin/moderation-templates/"+key+"/"+language, "", body)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("key", key)
+	rctx.URLParams.Add("language", language)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestAdminHandler_ListModerationTemplates(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetModerationTemplateRepo(&mockModerationTemplateRepo{templates: []models.ModerationTemplate{
+		{Key: "rejected", Language: "portuguese", Body: "Rejeitado: {explanation}"},
+	}})
+
+	w := httptest.NewRecorder()
+	handler.ListModerationTemplates(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/moderation-templates", "", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data struct {
+			Templates []models.ModerationTemplate `json:"templates"`
+			Defaults  map[string]string           `json:"defaults"`
+			Variables []string                    `json:"variables"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Data.Templates) != 1 || resp.Data.Templates[0].Language != "portuguese" {
+		t.Errorf("unexpected templates: %+v", resp.Data.Templates)
+	}
+	if len(resp.Data.Defaults) != 3 || resp.Data.Defaults["approved"] == "" {
+		t.Errorf("unexpected defaults: %+v", resp.Data.Defaults)
+	}
+	if len(resp.Data.Variables) == 0 {
+		t.Error("expected variables to be listed")
+	}
+}
+
+func TestAdminHandler_PutModerationTemplate(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockModerationTemplateRepo{}
+	handler := NewAdminHandler(nil)
+	handler.SetModerationTemplateRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.PutModerationTemplate(w, newModerationTemplateRequest(http.MethodPut, "rejected", "Portuguese",
+		`{"body":"Rejeitado: {explanation}. Recurso: {appeal_link}"}`))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.saved == nil || repo.saved.Key != "rejected" || repo.saved.Language != "portuguese" || repo.saved.UpdatedBy != "admin" {
+		t.Errorf("unexpected saved template: %+v", repo.saved)
+	}
+}
+
+func TestAdminHandler_PutModerationTemplate_Validation(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name     string
+		key      string
+		language string
+		body     string
+	}{
+		{"unknown key", "banned", "en", `{"body":"Bye"}`},
+		{"empty body", "approved", "en", `{"body":"  "}`},
+		{"unknown variable", "rejected", "en", `{"body":"Rejected: {reason}"}`},
+		{"invalid JSON", "approved", "en", `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockModerationTemplateRepo{}
+			handler := NewAdminHandler(nil)
+			handler.SetModerationTemplateRepo(repo)
+
+			w := httptest.NewRecorder()
+			handler.PutModerationTemplate(w, newModerationTemplateRequest(http.MethodPut, tt.key, tt.language, tt.body))
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+			if repo.saved != nil {
+				t.Error("expected nothing to be saved")
+			}
+		})
+	}
+}
+
+func TestAdminHandler_DeleteModerationTemplate(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name       string
+		repo       *mockModerationTemplateRepo
+		wantStatus int
+	}{
+		{"deleted", &mockModerationTemplateRepo{}, http.StatusNoContent},
+		{"not found", &mockModerationTemplateRepo{deleteErr: db.ErrModerationTemplateNotFound}, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAdminHandler(nil)
+			handler.SetModerationTemplateRepo(tt.repo)
+
+			w := httptest.NewRecorder()
+			handler.DeleteModerationTemplate(w, newModerationTemplateRequest(http.MethodDelete, "approved", "spanish", ""))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAdminHandler_ModerationTemplates_NotConfigured(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	w := httptest.NewRecorder()
+	handler.ListModerationTemplates(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/moderation-templates", "", ""))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxHoldReasonLength caps the reason stored with a legal hold.
+const maxHoldReasonLength = 1000
+
+// PostRetentionRepo stores per-post retention and legal hold metadata.
+type PostRetentionRepo interface {
+	Get(ctx context.Context, postID string) (*models.PostRetention, error)
+	Set(ctx context.Context, retention *models.PostRetention) (*models.PostRetention, error)
+	ListHeld(ctx context.Context) ([]models.PostRetention, error)
+}
+
+// SetPostRetentionRepo injects the post retention repository dependency.
+func (h *AdminHandler) SetPostRetentionRepo(repo PostRetentionRepo) {
+	h.postRetentionRepo = repo
+}
+
+// SetPostRetentionRequest is the JSON body for PUT /v1/admin/posts/{id}/retention.
+// It replaces the post's retention metadata: an omitted retain_until clears it.
+type SetPostRetentionRequest struct {
+	LegalHold   *bool      `json:"legal_hold"`
+	HoldReason  string     `json:"hold_reason,omitempty"`
+	RetainUntil *time.Time `json:"retain_until,omitempty"`
+}
+
+// GetPostRetention returns a post's retention and legal hold metadata.
+// GET /v1/admin/posts/{id}/retention
+func (h *AdminHandler) GetPostRetention(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.postRetentionRepo != nil, apierror.RepoNotConfigured, "post retention repository not configured") {
+		return
+	}
+
+	retention, err := h.postRetentionRepo.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		writeRetentionError(w, err, "failed to get post retention")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": retention})
+}
+
+// SetPostRetention places or lifts a legal hold and sets the retention period of
+// a post. While held, the stale content job, GDPR anonymization and account
+// purges leave the post and everything on it alone.
+// PUT /v1/admin/posts/{id}/retention
+func (h *AdminHandler) SetPostRetention(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.postRetentionRepo != nil, apierror.RepoNotConfigured, "post retention repository not configured") {
+		return
+	}
+
+	var req SetPostRetentionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
+		return
+	}
+	if req.LegalHold == nil {
+		apierror.Write(w, apierror.ValidationError, "legal_hold is required")
+		return
+	}
+
+	reason := strings.TrimSpace(req.HoldReason)
+	if *req.LegalHold && reason == "" {
+		apierror.Write(w, apierror.ValidationError, "hold_reason is required when placing a legal hold")
+		return
+	}
+	if len(reason) > maxHoldReasonLength {
+		apierror.Write(w, apierror.ValidationError, "hold_reason must be at most 1000 characters")
+		return
+	}
+
+	retention, err := h.postRetentionRepo.Set(r.Context(), &models.PostRetention{
+		PostID:      chi.URLParam(r, "id"),
+		LegalHold:   *req.LegalHold,
+		HoldReason:  reason,
+		RetainUntil: req.RetainUntil,
+		UpdatedBy:   "admin",
+	})
+	if err != nil {
+		writeRetentionError(w, err, "failed to update post retention")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": retention})
+}
+
+// ListLegalHolds lists every post under legal hold or within its retention period.
+// GET /v1/admin/legal-holds
+func (h *AdminHandler) ListLegalHolds(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.postRetentionRepo != nil, apierror.RepoNotConfigured, "post retention repository not configured") {
+		return
+	}
+
+	held, err := h.postRetentionRepo.ListHeld(r.Context())
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list legal holds")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": held})
+}
+
+// writeRetentionError writes 404 for a missing post and msg for anything else.
+func writeRetentionError(w http.ResponseWriter, err error, msg string) {
+	if errors.Is(err, db.ErrPostNotFound) {
+		apierror.Write(w, apierror.NotFound, "post not found")
+		return
+	}
+	apierror.Write(w, apierror.InternalError, msg)
+}
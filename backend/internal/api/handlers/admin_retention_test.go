@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockPostRetentionRepo implements PostRetentionRepo for testing.
+type mockPostRetentionRepo struct {
+	saved *models.PostRetention
+}
+
+func (m *mockPostRetentionRepo) Get(ctx context.Context, postID string) (*models.PostRetention, error) {
+	if postID != "post-1" {
+		return nil, db.ErrPostNotFound
+	}
+	return &models.PostRetention{PostID: postID}, nil
+}
+
+func (m *mockPostRetentionRepo) Set(ctx context.Context, retention *models.PostRetention) (*models.PostRetention, error) {
+	if retention.PostID != "post-1" {
+		return nil, db.ErrPostNotFound
+	}
+	m.saved = retention
+	return retention, nil
+}
+
+func (m *mockPostRetentionRepo) ListHeld(ctx context.Context) ([]models.PostRetention, error) {
+	return []models.PostRetention{{PostID: "post-1", LegalHold: true, Held: true}}, nil
+}
+
+func TestAdminHandler_SetPostRetention(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		wantStatus int
+	}{
+		{"place hold", "post-1", `{"legal_hold":true,"hold_reason":"Incident 42"}`, http.StatusOK},
+		{"retention period only", "post-1", `{"legal_hold":false,"retain_until":"2030-01-01T00:00:00Z"}`, http.StatusOK},
+		{"hold without reason", "post-1", `{"legal_hold":true}`, http.StatusBadRequest},
+		{"reason too long", "post-1", `{"legal_hold":true,"hold_reason":"` + strings.Repeat("a", 1001) + `"}`, http.StatusBadRequest},
+		{"missing legal_hold", "post-1", `{"hold_reason":"x"}`, http.StatusBadRequest},
+		{"unknown post", "post-2", `{"legal_hold":false}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPostRetentionRepo{}
+			handler := NewAdminHandler(nil)
+			handler.SetPostRetentionRepo(repo)
+
+			w := httptest.NewRecorder()
+			handler.SetPostRetention(w, newAdminIntegrationRequest(http.MethodPut, "/v1/admin/posts/"+tt.id+"/retention", tt.id, tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && (repo.saved == nil || repo.saved.UpdatedBy != "admin") {
+				t.Errorf("expected retention saved by admin, got %+v", repo.saved)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_ListLegalHolds(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetPostRetentionRepo(&mockPostRetentionRepo{})
+
+	w := httptest.NewRecorder()
+	handler.ListLegalHolds(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/legal-holds", "", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data []models.PostRetention `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Data) != 1 || !resp.Data[0].Held {
+		t.Errorf("unexpected holds: %+v", resp.Data)
+	}
+}
+
+func TestAdminHandler_PostRetention_NotConfigured(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	w := httptest.NewRecorder()
+	NewAdminHandler(nil).GetPostRetention(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/posts/post-1/retention", "post-1", ""))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", w.Code)
+	}
+}
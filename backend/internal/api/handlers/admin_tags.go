@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxMergeSources caps how many tags one merge can fold into a target.
+const maxMergeSources = 20
+
+// AdminTagRepo provides tag moderation for admins.
+type AdminTagRepo interface {
+	RenameTag(ctx context.Context, oldName, newName string) (int64, error)
+	MergeTags(ctx context.Context, sources []string, target string) (int64, error)
+	BlacklistTag(ctx context.Context, name, reason string) (int64, error)
+	UnblacklistTag(ctx context.Context, name string) error
+	ListBlacklistedTags(ctx context.Context) ([]models.BlacklistedTag, error)
+}
+
+// SetAdminTagRepo injects the tag moderation repository dependency.
+func (h *AdminHandler) SetAdminTagRepo(repo AdminTagRepo) {
+	h.adminTagRepo = repo
+}
+
+// RenameTagRequest is the JSON body for POST /v1/admin/tags/{name}/rename.
+type RenameTagRequest struct {
+	NewName string `json:"new_name"`
+}
+
+// MergeTagsRequest is the JSON body for POST /v1/admin/tags/merge.
+type MergeTagsRequest struct {
+	Sources []string `json:"sources"`
+	Target  string   `json:"target"`
+}
+
+// BlacklistTagRequest is the JSON body for POST /v1/admin/tags/{name}/blacklist.
+type BlacklistTagRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RenameTag renames a tag on every post and agent specialty in one transaction.
+// POST /v1/admin/tags/{name}/rename
+// Returns 409 TAG_EXISTS if new_name is already used; merge the tags instead.
+func (h *AdminHandler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	name, ok := h.adminTagFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req RenameTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	newName := models.NormalizeTag(req.NewName)
+	if !models.IsValidTag(newName) {
+		apierror.Write(w, apierror.ValidationError, "new_name is not a valid tag")
+		return
+	}
+	if newName == name {
+		apierror.Write(w, apierror.ValidationError, "new_name must differ from the current name")
+		return
+	}
+
+	updated, err := h.adminTagRepo.RenameTag(r.Context(), name, newName)
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrTagNotFound):
+			apierror.Write(w, apierror.NotFound, "tag not found")
+		case errors.Is(err, db.ErrTagExists):
+			apierror.Write(w, apierror.TagExists, "new_name is already in use; merge the tags instead")
+		default:
+			apierror.Write(w, apierror.InternalError, "failed to rename tag")
+		}
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"old_name":      name,
+			"new_name":      newName,
+			"posts_updated": updated,
+		},
+	})
+}
+
+// MergeTags folds the source tags into target on every post and agent specialty
+// in one transaction, dropping duplicates.
+// POST /v1/admin/tags/merge
+func (h *AdminHandler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.adminTagRepo != nil, apierror.RepoNotConfigured, "tag repository not configured") {
+		return
+	}
+
+	var req MergeTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	target := models.NormalizeTag(req.Target)
+	if !models.IsValidTag(target) {
+		apierror.Write(w, apierror.ValidationError, "target is not a valid tag")
+		return
+	}
+
+	sources := make([]string, 0, len(req.Sources))
+	for _, s := range req.Sources {
+		if s = models.NormalizeTag(s); s != "" && s != target {
+			sources = append(sources, s)
+		}
+	}
+	if len(sources) == 0 {
+		apierror.Write(w, apierror.ValidationError, "at least one source tag other than target is required")
+		return
+	}
+	if len(sources) > maxMergeSources {
+		apierror.Write(w, apierror.ValidationError, "too many source tags")
+		return
+	}
+
+	updated, err := h.adminTagRepo.MergeTags(r.Context(), sources, target)
+	if err != nil {
+		if errors.Is(err, db.ErrTagNotFound) {
+			apierror.Write(w, apierror.NotFound, "no posts use the source tags")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to merge tags")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"sources":       sources,
+			"target":        target,
+			"posts_updated": updated,
+		},
+	})
+}
+
+// BlacklistTag bans a tag: it is removed from every post and agent specialty,
+// and stripped from posts on every later write.
+// POST /v1/admin/tags/{name}/blacklist
+func (h *AdminHandler) BlacklistTag(w http.ResponseWriter, r *http.Request) {
+	name, ok := h.adminTagFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req BlacklistTagRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+			return
+		}
+	}
+
+	updated, err := h.adminTagRepo.BlacklistTag(r.Context(), name, strings.TrimSpace(req.Reason))
+	if err != nil {
+		if errors.Is(err, db.ErrTagAlreadyBlacklisted) {
+			apierror.Write(w, apierror.AlreadyBlacklisted, "tag is already blacklisted")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to blacklist tag")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusCreated, map[string]interface{}{
+		"data": map[string]interface{}{
+			"name":          name,
+			"posts_updated": updated,
+		},
+	})
+}
+
+// UnblacklistTag lifts a tag ban. Posts that lost the tag keep their current tags.
+// DELETE /v1/admin/tags/{name}/blacklist
+func (h *AdminHandler) UnblacklistTag(w http.ResponseWriter, r *http.Request) {
+	name, ok := h.adminTagFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.adminTagRepo.UnblacklistTag(r.Context(), name); err != nil {
+		if errors.Is(err, db.ErrTagNotBlacklisted) {
+			apierror.Write(w, apierror.NotFound, "tag is not blacklisted")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to unblacklist tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListBlacklistedTags returns banned tags.
+// GET /v1/admin/tags/blacklist
+func (h *AdminHandler) ListBlacklistedTags(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.adminTagRepo != nil, apierror.RepoNotConfigured, "tag repository not configured") {
+		return
+	}
+
+	tags, err := h.adminTagRepo.ListBlacklistedTags(r.Context())
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list blacklisted tags")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": tags,
+	})
+}
+
+// adminTagFromPath checks admin auth and the repository, then returns the
+// normalized {name} URL parameter. It writes the error response when ok is false.
+func (h *AdminHandler) adminTagFromPath(w http.ResponseWriter, r *http.Request) (name string, ok bool) {
+	if !h.requireAdmin(w, r, h.adminTagRepo != nil, apierror.RepoNotConfigured, "tag repository not configured") {
+		return "", false
+	}
+
+	raw, err := url.PathUnescape(chi.URLParam(r, "name"))
+	name = models.NormalizeTag(raw)
+	if err != nil || name == "" {
+		apierror.Write(w, apierror.ValidationError, "tag name is required")
+		return "", false
+	}
+	return name, true
+}
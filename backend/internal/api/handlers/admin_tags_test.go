@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockAdminTagRepo is a test double for AdminTagRepo.
+type mockAdminTagRepo struct {
+	renameErr   error
+	mergeErr    error
+	blacklisted map[string]string
+	lastSources []string
+	lastTarget  string
+}
+
+func (m *mockAdminTagRepo) RenameTag(ctx context.Context, oldName, newName string) (int64, error) {
+	m.lastSources, m.lastTarget = []string{oldName}, newName
+	return 4, m.renameErr
+}
+
+func (m *mockAdminTagRepo) MergeTags(ctx context.Context, sources []string, target string) (int64, error) {
+	m.lastSources, m.lastTarget = sources, target
+	return 7, m.mergeErr
+}
+
+func (m *mockAdminTagRepo) BlacklistTag(ctx context.Context, name, reason string) (int64, error) {
+	if _, ok := m.blacklisted[name]; ok {
+		return 0, db.ErrTagAlreadyBlacklisted
+	}
+	m.blacklisted[name] = reason
+	return 2, nil
+}
+
+func (m *mockAdminTagRepo) UnblacklistTag(ctx context.Context, name string) error {
+	if _, ok := m.blacklisted[name]; !ok {
+		return db.ErrTagNotBlacklisted
+	}
+	delete(m.blacklisted, name)
+	return nil
+}
+
+func (m *mockAdminTagRepo) ListBlacklistedTags(ctx context.Context) ([]models.BlacklistedTag, error) {
+	tags := make([]models.BlacklistedTag, 0, len(m.blacklisted))
+	for name, reason := range m.blacklisted {
+		tags = append(tags, models.BlacklistedTag{Name: name, Reason: reason})
+	}
+	return tags, nil
+}
+
+func newAdminTagRequest(method, path, name, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	rctx := chi.NewRouteContext()
+	if name != "" {
+		rctx.URLParams.Add("name", name)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestAdminHandler_RenameTag(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name       string
+		body       string
+		renameErr  error
+		wantStatus int
+	}{
+		{"renamed", `{"new_name":"Golang"}`, nil, http.StatusOK},
+		{"invalid new name", `{"new_name":"go lang"}`, nil, http.StatusBadRequest},
+		{"same name", `{"new_name":"go"}`, nil, http.StatusBadRequest},
+		{"target exists", `{"new_name":"golang"}`, db.ErrTagExists, http.StatusConflict},
+		{"unknown tag", `{"new_name":"golang"}`, db.ErrTagNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockAdminTagRepo{renameErr: tt.renameErr}
+			handler := NewAdminHandler(nil)
+			handler.SetAdminTagRepo(repo)
+
+			w := httptest.NewRecorder()
+			handler.RenameTag(w, newAdminTagRequest(http.MethodPost, "/v1/admin/tags/go/rename", "go", tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && repo.lastTarget != "golang" {
+				t.Errorf("expected normalized new name, got %q", repo.lastTarget)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_MergeTags(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockAdminTagRepo{}
+	handler := NewAdminHandler(nil)
+	handler.SetAdminTagRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.MergeTags(w, newAdminTagRequest(http.MethodPost, "/v1/admin/tags/merge", "",
+		`{"sources":["Postgres","pg","postgresql"],"target":"postgresql"}`))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Join(repo.lastSources, ",") != "postgres,pg" || repo.lastTarget != "postgresql" {
+		t.Errorf("unexpected merge: sources=%v target=%q", repo.lastSources, repo.lastTarget)
+	}
+
+	var resp struct {
+		Data struct {
+			PostsUpdated int `json:"posts_updated"`
+		} `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Data.PostsUpdated != 7 {
+		t.Errorf("expected 7 posts updated, got %d", resp.Data.PostsUpdated)
+	}
+
+	w = httptest.NewRecorder()
+	handler.MergeTags(w, newAdminTagRequest(http.MethodPost, "/v1/admin/tags/merge", "",
+		`{"sources":["postgresql"],"target":"postgresql"}`))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 when only the target is listed, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_BlacklistTag(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockAdminTagRepo{blacklisted: map[string]string{}}
+	handler := NewAdminHandler(nil)
+	handler.SetAdminTagRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.BlacklistTag(w, newAdminTagRequest(http.MethodPost, "/v1/admin/tags/spam/blacklist", "spam", `{"reason":"link farm"}`))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.blacklisted["spam"] != "link farm" {
+		t.Errorf("expected reason recorded, got %v", repo.blacklisted)
+	}
+
+	w = httptest.NewRecorder()
+	handler.BlacklistTag(w, newAdminTagRequest(http.MethodPost, "/v1/admin/tags/spam/blacklist", "spam", ""))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for duplicate blacklist, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.UnblacklistTag(w, newAdminTagRequest(http.MethodDelete, "/v1/admin/tags/spam/blacklist", "spam", ""))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.UnblacklistTag(w, newAdminTagRequest(http.MethodDelete, "/v1/admin/tags/spam/blacklist", "spam", ""))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for tag that is not blacklisted, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_TagEndpoints_RequireAdminKey(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAdminTagRepo(&mockAdminTagRepo{})
+
+	w := httptest.NewRecorder()
+	handler.ListBlacklistedTags(w, httptest.NewRequest(http.MethodGet, "/v1/admin/tags/blacklist", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxHostnameLength is the longest DNS name.
+const maxHostnameLength = 253
+
+// tenantHostnamePattern matches lowercase DNS hostnames without port.
+var tenantHostnamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// TenantRepo lists and stores the tenants a deployment serves.
+type TenantRepo interface {
+	List(ctx context.Context) ([]models.Tenant, error)
+	Upsert(ctx context.Context, t *models.Tenant) (*models.Tenant, error)
+}
+
+// TenantCache is the request-side tenant cache, dropped after tenants change.
+type TenantCache interface {
+	Invalidate()
+}
+
+// SetTenantRepo injects the tenant repository dependency.
+func (h *AdminHandler) SetTenantRepo(repo TenantRepo) {
+	h.tenantRepo = repo
+}
+
+// SetTenantCache injects the tenant resolver so changes apply to the next request.
+func (h *AdminHandler) SetTenantCache(cache TenantCache) {
+	h.tenantCache = cache
+}
+
+// PutTenantRequest is the JSON body for PUT /v1/admin/tenants/{id}.
+type PutTenantRequest struct {
+	Name      string   `json:"name"`
+	Hostnames []string `json:"hostnames"`
+}
+
+// ListTenants lists the tenants this deployment serves.
+// GET /v1/admin/tenants
+func (h *AdminHandler) ListTenants(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.tenantRepo != nil, apierror.RepoNotConfigured, "tenant repository not configured") {
+		return
+	}
+
+	tenants, err := h.tenantRepo.List(r.Context())
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list tenants")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": tenants})
+}
+
+// PutTenant creates a tenant or replaces its name and hostnames. Requests to one
+// of its hostnames are scoped to it; hostnames are exclusive to one tenant.
+// PUT /v1/admin/tenants/{id}
+func (h *AdminHandler) PutTenant(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.tenantRepo != nil, apierror.RepoNotConfigured, "tenant repository not configured") {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	if !tenant.ValidID(id) {
+		apierror.Write(w, apierror.ValidationError, "tenant id must be 2-40 lowercase letters, digits or hyphens")
+		return
+	}
+
+	var req PutTenantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	hostnames := normalizeTenantHostnames(req.Hostnames)
+
+	var v Validator
+	if v.Required("name", name) {
+		v.Length("name", name, 0, models.MaxTenantNameLength)
+	}
+	v.MaxItems("hostnames", len(hostnames), models.MaxTenantHostnames)
+	for _, host := range hostnames {
+		if len(host) > maxHostnameLength || !tenantHostnamePattern.MatchString(host) {
+			v.Add(FieldError{Field: "hostnames", Code: FieldInvalidFormat,
+				Message: "invalid hostname: " + host})
+			break
+		}
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	saved, err := h.tenantRepo.Upsert(r.Context(), &models.Tenant{ID: id, Name: name, Hostnames: hostnames})
+	if err != nil {
+		if errors.Is(err, db.ErrTenantHostnameTaken) {
+			apierror.Write(w, apierror.Conflict, "a hostname already belongs to another tenant")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to save tenant")
+		return
+	}
+	if h.tenantCache != nil {
+		h.tenantCache.Invalidate()
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": saved})
+}
+
+// normalizeTenantHostnames lowercases and trims hostnames, dropping blanks,
+// trailing dots and duplicates.
+func normalizeTenantHostnames(in []string) []string {
+	out := []string{}
+	seen := make(map[string]bool, len(in))
+	for _, host := range in {
+		host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		out = append(out, host)
+	}
+	return out
+}
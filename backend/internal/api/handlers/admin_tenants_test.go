@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockTenantRepo implements TenantRepo for testing.
+type mockTenantRepo struct {
+	saved *models.Tenant
+}
+
+func (m *mockTenantRepo) List(ctx context.Context) ([]models.Tenant, error) {
+	return []models.Tenant{{ID: "default", Name: "Solvr", Hostnames: []string{}}}, nil
+}
+
+func (m *mockTenantRepo) Upsert(ctx context.Context, t *models.Tenant) (*models.Tenant, error) {
+	for _, host := range t.Hostnames {
+		if host == "taken.example.com" {
+			return nil, db.ErrTenantHostnameTaken
+		}
+	}
+	m.saved = t
+	return t, nil
+}
+
+type mockTenantCache struct{ invalidated int }
+
+func (m *mockTenantCache) Invalidate() { m.invalidated++ }
+
+func TestAdminHandler_ListTenants(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	w := httptest.NewRecorder()
+	handler.ListTenants(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/tenants", "", ""))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 without repo, got %d", w.Code)
+	}
+
+	handler.SetTenantRepo(&mockTenantRepo{})
+	w = httptest.NewRecorder()
+	handler.ListTenants(w, newAdminIntegrationRequest(http.MethodGet, "/v1/admin/tenants", "", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data []models.Tenant `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID != "default" {
+		t.Errorf("unexpected tenants %+v", resp.Data)
+	}
+}
+
+func TestAdminHandler_PutTenant(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	tests := []struct {
+		name          string
+		id            string
+		body          string
+		wantStatus    int
+		wantHostnames []string
+	}{
+		{"create", "acme", `{"name":"Acme","hostnames":[" Acme.Example.com. ","acme.example.com",""]}`, http.StatusOK, []string{"acme.example.com"}},
+		{"no hostnames", "acme", `{"name":"Acme"}`, http.StatusOK, []string{}},
+		{"invalid id", "Acme_Corp", `{"name":"Acme"}`, http.StatusBadRequest, nil},
+		{"missing name", "acme", `{"hostnames":["acme.example.com"]}`, http.StatusBadRequest, nil},
+		{"hostname with port", "acme", `{"name":"Acme","hostnames":["acme.example.com:8080"]}`, http.StatusBadRequest, nil},
+		{"hostname taken", "acme", `{"name":"Acme","hostnames":["taken.example.com"]}`, http.StatusConflict, nil},
+		{"invalid json", "acme", `{`, http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockTenantRepo{}
+			cache := &mockTenantCache{}
+			handler := NewAdminHandler(nil)
+			handler.SetTenantRepo(repo)
+			handler.SetTenantCache(cache)
+
+			w := httptest.NewRecorder()
+			handler.PutTenant(w, newAdminIntegrationRequest(http.MethodPut, "/v1/admin/tenants/"+tt.id, tt.id, tt.body))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if cache.invalidated != 0 {
+					t.Error("expected tenant cache untouched on failure")
+				}
+				return
+			}
+			if repo.saved == nil || repo.saved.ID != tt.id || !reflect.DeepEqual(repo.saved.Hostnames, tt.wantHostnames) {
+				t.Errorf("unexpected saved tenant %+v", repo.saved)
+			}
+			if cache.invalidated != 1 {
+				t.Errorf("expected tenant cache invalidated once, got %d", cache.invalidated)
+			}
+		})
+	}
+}
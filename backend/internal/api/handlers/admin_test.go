@@ -495,3 +495,63 @@ func getTestPool(t *testing.T) *db.Pool {
 
 	return pool
 }
+
+func TestAdminHandler_GetQueryStats_NoDatabase(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/db/query-stats", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+
+	handler.GetQueryStats(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_GetQueryStats_Success(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	if err := pool.Ping(context.Background()); err != nil {
+		t.Fatalf("ping failed: %v", err)
+	}
+	if _, err := db.NewStatsRepository(pool).GetActivePostsCount(context.Background()); err != nil {
+		t.Fatalf("GetActivePostsCount failed: %v", err)
+	}
+
+	handler := NewAdminHandler(pool)
+	req := httptest.NewRequest(http.MethodGet, "/admin/db/query-stats", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+
+	handler.GetQueryStats(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data []db.QueryStat `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	found := false
+	for _, s := range resp.Data {
+		if s.Caller == "db.(*StatsRepository).GetActivePostsCount" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected stats for GetActivePostsCount, got %+v", resp.Data)
+	}
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// AdminUserRepo provides user listing and moderation for admins.
+type AdminUserRepo interface {
+	AdminList(ctx context.Context, opts models.UserListOptions) ([]models.User, int, error)
+	FindByIDForAdmin(ctx context.Context, id string) (*models.User, error)
+	SetStatus(ctx context.Context, id string, status models.UserStatus, reason, changedBy string) (*models.User, error)
+	Restore(ctx context.Context, id string) (*models.User, error)
+	GetUserStats(ctx context.Context, userID string) (*models.UserStats, error)
+}
+
+// AdminUserAgentsRepo lists the agents a user has claimed.
+type AdminUserAgentsRepo interface {
+	FindByHumanID(ctx context.Context, humanID string) ([]*models.Agent, error)
+}
+
+// AdminUserAuthMethodsRepo lists the auth methods linked to a user.
+type AdminUserAuthMethodsRepo interface {
+	FindByUserID(ctx context.Context, userID string) ([]*models.AuthMethod, error)
+}
+
+// AdminUserAPIKeysRepo lists a user's API keys.
+type AdminUserAPIKeysRepo interface {
+	FindByUserID(ctx context.Context, userID string) ([]*models.UserAPIKey, error)
+}
+
+// SetAdminUserRepo injects the admin user repository dependency.
+func (h *AdminHandler) SetAdminUserRepo(repo AdminUserRepo) {
+	h.adminUserRepo = repo
+}
+
+// SetAdminUserAgentsRepo injects the agent lookup used by the user support view.
+func (h *AdminHandler) SetAdminUserAgentsRepo(repo AdminUserAgentsRepo) {
+	h.adminUserAgentsRepo = repo
+}
+
+// SetAdminUserAuthMethodsRepo injects the auth method lookup used by the user support view.
+func (h *AdminHandler) SetAdminUserAuthMethodsRepo(repo AdminUserAuthMethodsRepo) {
+	h.adminUserAuthMethodsRepo = repo
+}
+
+// SetAdminUserAPIKeysRepo injects the API key lookup used by the user support view.
+func (h *AdminHandler) SetAdminUserAPIKeysRepo(repo AdminUserAPIKeysRepo) {
+	h.adminUserAPIKeysRepo = repo
+}
+
+// UpdateUserStatusRequest is the JSON body for PATCH /v1/admin/users/{id}/status.
+type UpdateUserStatusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// ListUsers returns users matching the admin filters.
+// GET /v1/admin/users?status=&provider=&created_after=&created_before=&q=&page=1&per_page=20
+// status is active, suspended, banned or deleted; created_* accept RFC3339 or YYYY-MM-DD.
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.adminUserRepo != nil, apierror.RepoNotConfigured, "user repository not configured") {
+		return
+	}
+
+	q := r.URL.Query()
+	opts := models.UserListOptions{
+		Query:    strings.TrimSpace(q.Get("q")),
+		Status:   q.Get("status"),
+		Provider: q.Get("provider"),
+		Page:     1,
+		PerPage:  20,
+	}
+
+	if opts.Status != "" && opts.Status != "deleted" && !models.IsValidUserStatus(models.UserStatus(opts.Status)) {
+		apierror.Write(w, apierror.ValidationError, "status must be active, suspended, banned or deleted")
+		return
+	}
+
+	switch opts.Provider {
+	case "", models.AuthProviderEmail, models.AuthProviderGitHub, models.AuthProviderGoogle:
+	default:
+		apierror.Write(w, apierror.ValidationError, "provider must be email, github or google")
+		return
+	}
+
+	var err error
+	if opts.CreatedAfter, err = parseAdminTime(q.Get("created_after")); err != nil {
+		apierror.Write(w, apierror.ValidationError, "created_after must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+	if opts.CreatedBefore, err = parseAdminTime(q.Get("created_before")); err != nil {
+		apierror.Write(w, apierror.ValidationError, "created_before must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+
+	if pageStr := q.Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			opts.Page = p
+		}
+	}
+	if perPageStr := q.Get("per_page"); perPageStr != "" {
+		if pp, err := strconv.Atoi(perPageStr); err == nil && pp > 0 && pp <= 100 {
+			opts.PerPage = pp
+		}
+	}
+
+	users, total, err := h.adminUserRepo.AdminList(r.Context(), opts)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list users")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{
+		"data": users,
+		"meta": map[string]interface{}{
+			"total":    total,
+			"page":     opts.Page,
+			"per_page": opts.PerPage,
+		},
+	})
+}
+
+// GetUserSupportView returns what a support admin needs to help a user without
+// signing in as them: the account (including moderation state and soft-delete),
+// activity stats, claimed agents, linked auth methods and API key metadata.
+// GET /v1/admin/users/{id}
+func (h *AdminHandler) GetUserSupportView(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.adminUserRepo != nil, apierror.RepoNotConfigured, "user repository not configured") {
+		return
+	}
+
+	userID := chi.URLParam(r, "id")
+	if userID == "" {
+		apierror.Write(w, apierror.MissingID, "user ID required")
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.adminUserRepo.FindByIDForAdmin(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, "user not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get user")
+		return
+	}
+
+	view := map[string]interface{}{"user": user}
+
+	if stats, err := h.adminUserRepo.GetUserStats(ctx, userID); err == nil {
+		view["stats"] = stats
+	}
+
+	if h.adminUserAgentsRepo != nil {
+		agents, err := h.adminUserAgentsRepo.FindByHumanID(ctx, userID)
+		if err != nil {
+			apierror.Write(w, apierror.InternalError, "failed to get user agents")
+			return
+		}
+		view["agents"] = agents
+	}
+
+	if h.adminUserAuthMethodsRepo != nil {
+		methods, err := h.adminUserAuthMethodsRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			apierror.Write(w, apierror.InternalError, "failed to get user auth methods")
+			return
+		}
+		view["auth_methods"] = methods
+	}
+
+	if h.adminUserAPIKeysRepo != nil {
+		keys, err := h.adminUserAPIKeysRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			apierror.Write(w, apierror.InternalError, "failed to get user API keys")
+			return
+		}
+		view["api_keys"] = keys
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": view})
+}
+
+// UpdateUserStatus suspends, bans or reactivates a user.
+// A reason is required for suspended and banned and is stored with the user.
+// PATCH /v1/admin/users/{id}/status
+func (h *AdminHandler) UpdateUserStatus(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.adminUserRepo != nil, apierror.RepoNotConfigured, "user repository not configured") {
+		return
+	}
+
+	userID := chi.URLParam(r, "id")
+	if userID == "" {
+		apierror.Write(w, apierror.MissingID, "user ID required")
+		return
+	}
+
+	var req UpdateUserStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
+		return
+	}
+
+	status := models.UserStatus(req.Status)
+	if !models.IsValidUserStatus(status) {
+		apierror.Write(w, apierror.ValidationError, "status must be active, suspended or banned")
+		return
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if status != models.UserStatusActive && reason == "" {
+		apierror.Write(w, apierror.ValidationError, "reason is required when suspending or banning")
+		return
+	}
+	if len(reason) > 1000 {
+		apierror.Write(w, apierror.ValidationError, "reason must be at most 1000 characters")
+		return
+	}
+
+	user, err := h.adminUserRepo.SetStatus(r.Context(), userID, status, reason, "admin")
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, "user not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to update user status")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": user})
+}
+
+// RestoreUser undoes a soft delete so the account can sign in again.
+// POST /v1/admin/users/{id}/restore
+func (h *AdminHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	if !h.requireAdmin(w, r, h.adminUserRepo != nil, apierror.RepoNotConfigured, "user repository not configured") {
+		return
+	}
+
+	userID := chi.URLParam(r, "id")
+	if userID == "" {
+		apierror.Write(w, apierror.MissingID, "user ID required")
+		return
+	}
+
+	user, err := h.adminUserRepo.Restore(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, "deleted user not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to restore user")
+		return
+	}
+
+	writeAdminJSON(w, http.StatusOK, map[string]interface{}{"data": user})
+}
+
+// parseAdminTime parses an optional RFC3339 timestamp or YYYY-MM-DD date.
+func parseAdminTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockAdminUserRepo is a test double for AdminUserRepo.
+type mockAdminUserRepo struct {
+	users        map[string]*models.User
+	listOpts     models.UserListOptions
+	statusID     string
+	statusValue  models.UserStatus
+	statusReason string
+	statusBy     string
+}
+
+func newMockAdminUserRepo() *mockAdminUserRepo {
+	return &mockAdminUserRepo{users: map[string]*models.User{}}
+}
+
+func (m *mockAdminUserRepo) AdminList(ctx context.Context, opts models.UserListOptions) ([]models.User, int, error) {
+	m.listOpts = opts
+	var users []models.User
+	for _, u := range m.users {
+		users = append(users, *u)
+	}
+	return users, len(users), nil
+}
+
+func (m *mockAdminUserRepo) FindByIDForAdmin(ctx context.Context, id string) (*models.User, error) {
+	u, ok := m.users[id]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	return u, nil
+}
+
+func (m *mockAdminUserRepo) SetStatus(ctx context.Context, id string, status models.UserStatus, reason, changedBy string) (*models.User, error) {
+	m.statusID, m.statusValue, m.statusReason, m.statusBy = id, status, reason, changedBy
+	u, ok := m.users[id]
+	if !ok || u.DeletedAt != nil {
+		return nil, db.ErrNotFound
+	}
+	u.Status = string(status)
+	u.StatusReason = reason
+	return u, nil
+}
+
+func (m *mockAdminUserRepo) Restore(ctx context.Context, id string) (*models.User, error) {
+	u, ok := m.users[id]
+	if !ok || u.DeletedAt == nil {
+		return nil, db.ErrNotFound
+	}
+	u.DeletedAt = nil
+	return u, nil
+}
+
+func (m *mockAdminUserRepo) GetUserStats(ctx context.Context, userID string) (*models.UserStats, error) {
+	return &models.UserStats{PostsCreated: 2}, nil
+}
+
+type mockAdminUserAgentsRepo struct{}
+
+func (mockAdminUserAgentsRepo) FindByHumanID(ctx context.Context, humanID string) ([]*models.Agent, error) {
+	return []*models.Agent{{ID: "agent-1", DisplayName: "Helper"}}, nil
+}
+
+func newAdminUserRequest(method, path, id, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	rctx := chi.NewRouteContext()
+	if id != "" {
+		rctx.URLParams.Add("id", id)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestAdminHandler_ListUsers_NotConfigured(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	w := httptest.NewRecorder()
+	handler.ListUsers(w, newAdminUserRequest(http.MethodGet, "/v1/admin/users", "", ""))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ListUsers_Unauthorized(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAdminUserRepo(newMockAdminUserRepo())
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/users", nil)
+	w := httptest.NewRecorder()
+	handler.ListUsers(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ListUsers_Filters(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := newMockAdminUserRepo()
+	handler := NewAdminHandler(nil)
+	handler.SetAdminUserRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.ListUsers(w, newAdminUserRequest(http.MethodGet,
+		"/v1/admin/users?status=suspended&provider=github&created_after=2026-01-01&created_before=2026-02-01T00:00:00Z&q=bob&per_page=50", "", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	opts := repo.listOpts
+	if opts.Status != "suspended" || opts.Provider != "github" || opts.Query != "bob" || opts.PerPage != 50 {
+		t.Errorf("unexpected list options: %+v", opts)
+	}
+	if opts.CreatedAfter == nil || !opts.CreatedAfter.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("CreatedAfter = %v, want 2026-01-01", opts.CreatedAfter)
+	}
+	if opts.CreatedBefore == nil {
+		t.Error("CreatedBefore not parsed")
+	}
+}
+
+func TestAdminHandler_ListUsers_InvalidFilters(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAdminUserRepo(newMockAdminUserRepo())
+
+	for _, query := range []string{"status=frozen", "provider=twitter", "created_after=yesterday"} {
+		w := httptest.NewRecorder()
+		handler.ListUsers(w, newAdminUserRequest(http.MethodGet, "/v1/admin/users?"+query, "", ""))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestAdminHandler_GetUserSupportView(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := newMockAdminUserRepo()
+	repo.users["user-1"] = &models.User{ID: "user-1", Username: "bob", PasswordHash: "secret-hash", Status: "active"}
+	handler := NewAdminHandler(nil)
+	handler.SetAdminUserRepo(repo)
+	handler.SetAdminUserAgentsRepo(mockAdminUserAgentsRepo{})
+
+	w := httptest.NewRecorder()
+	handler.GetUserSupportView(w, newAdminUserRequest(http.MethodGet, "/v1/admin/users/user-1", "user-1", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "secret-hash") {
+		t.Error("support view leaked the password hash")
+	}
+
+	var resp struct {
+		Data struct {
+			User   models.User       `json:"user"`
+			Stats  *models.UserStats `json:"stats"`
+			Agents []models.Agent    `json:"agents"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.User.ID != "user-1" || resp.Data.Stats == nil || len(resp.Data.Agents) != 1 {
+		t.Errorf("unexpected support view: %+v", resp.Data)
+	}
+}
+
+func TestAdminHandler_GetUserSupportView_NotFound(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAdminUserRepo(newMockAdminUserRepo())
+
+	w := httptest.NewRecorder()
+	handler.GetUserSupportView(w, newAdminUserRequest(http.MethodGet, "/v1/admin/users/missing", "missing", ""))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_UpdateUserStatus(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := newMockAdminUserRepo()
+	repo.users["user-1"] = &models.User{ID: "user-1", Status: "active"}
+	handler := NewAdminHandler(nil)
+	handler.SetAdminUserRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.UpdateUserStatus(w, newAdminUserRequest(http.MethodPatch, "/v1/admin/users/user-1/status", "user-1",
+		`{"status":"banned","reason":"  spam ring  "}`))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.statusValue != models.UserStatusBanned || repo.statusReason != "spam ring" || repo.statusBy != "admin" {
+		t.Errorf("SetStatus called with %q/%q/%q", repo.statusValue, repo.statusReason, repo.statusBy)
+	}
+}
+
+func TestAdminHandler_UpdateUserStatus_Validation(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetAdminUserRepo(newMockAdminUserRepo())
+
+	for _, body := range []string{`{"status":"frozen","reason":"x"}`, `{"status":"suspended"}`, `not json`} {
+		w := httptest.NewRecorder()
+		handler.UpdateUserStatus(w, newAdminUserRequest(http.MethodPatch, "/v1/admin/users/user-1/status", "user-1", body))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestAdminHandler_RestoreUser(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	deletedAt := time.Now()
+	repo := newMockAdminUserRepo()
+	repo.users["user-1"] = &models.User{ID: "user-1", DeletedAt: &deletedAt}
+	handler := NewAdminHandler(nil)
+	handler.SetAdminUserRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.RestoreUser(w, newAdminUserRequest(http.MethodPost, "/v1/admin/users/user-1/restore", "user-1", ""))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Restoring again finds no deleted user
+	w = httptest.NewRecorder()
+	handler.RestoreUser(w, newAdminUserRequest(http.MethodPost, "/v1/admin/users/user-1/restore", "user-1", ""))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 on second restore, got %d", w.Code)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -79,8 +80,14 @@ type AgentsHandler struct {
 	repo           AgentRepositoryInterface
 	claimTokenRepo ClaimTokenRepositoryInterface
 	roomBackfiller RoomOwnerBackfiller
+	emailNotifier  ClaimLinkNotifier
 	jwtSecret      string
 	baseURL        string // Base URL for claim URLs (e.g., "https://solvr.dev")
+
+	// Self-registration friction (see agents_verification.go)
+	registration         models.AgentRegistrationConfig
+	verifications        AgentVerificationRepositoryInterface
+	verificationNotifier AgentVerificationNotifier
 }
 
 // NewAgentsHandler creates a new AgentsHandler.
@@ -102,6 +109,16 @@ func (h *AgentsHandler) SetBaseURL(url string) {
 	h.baseURL = url
 }
 
+// ClaimLinkNotifier emails a new claim link to the agent's contact address.
+type ClaimLinkNotifier interface {
+	NotifyClaimLinkRequested(ctx context.Context, agent *models.Agent, claimURL string, expiresAt time.Time) error
+}
+
+// SetEmailNotifier enables the claim link email for agents with an email address.
+func (h *AgentsHandler) SetEmailNotifier(notifier ClaimLinkNotifier) {
+	h.emailNotifier = notifier
+}
+
 // SetRoomRepository sets the repository used to backfill room ownership on claim.
 func (h *AgentsHandler) SetRoomRepository(repo RoomOwnerBackfiller) {
 	h.roomBackfiller = repo
@@ -158,6 +175,7 @@ type RegisterAgentRequest struct {
 	ExternalLinks []string `json:"external_links,omitempty"`
 	AMCPAID       string   `json:"amcp_aid,omitempty"`        // KERI AID for AMCP identity verification
 	KERIPublicKey string   `json:"keri_public_key,omitempty"` // KERI public key for cryptographic identity
+	ProofOfWork   string   `json:"proof_of_work,omitempty"`   // Hashcash stamp, when the server requires one
 }
 
 // UpdateIdentityRequest is the request body for PATCH /v1/agents/me/identity.
@@ -199,19 +217,24 @@ func validateAgentID(id string) error {
 // validateAgentName validates the agent name for self-registration.
 // Per AGENT-ONBOARDING requirement: Name must be 3-30 chars, alphanumeric + underscore.
 func validateAgentName(name string) error {
-	if name == "" {
-		return errors.New("name is required")
-	}
-	if len(name) < 3 {
-		return errors.New("name must be at least 3 characters")
+	var v Validator
+	checkAgentName(&v, name)
+	if !v.Valid() {
+		return errors.New(v.Errors()[0].Message)
 	}
-	if len(name) > 30 {
-		return errors.New("name must not exceed 30 characters")
+	return nil
+}
+
+// checkAgentName records name rule failures on v.
+func checkAgentName(v *Validator, name string) {
+	if !v.Required("name", name) {
+		return
 	}
-	if !validAgentName.MatchString(name) {
-		return errors.New("name must contain only alphanumeric characters and underscores")
+	v.Length("name", name, 3, 30)
+	if !v.HasError("name") && !validAgentName.MatchString(name) {
+		v.Add(FieldError{Field: "name", Code: FieldInvalidFormat,
+			Message: "name must contain only alphanumeric characters and underscores"})
 	}
-	return nil
 }
 
 // generateAgentID creates a unique agent ID from the name.
@@ -229,51 +252,32 @@ func (h *AgentsHandler) RegisterAgent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate name format
-	if err := validateAgentName(req.Name); err != nil {
-		writeAgentValidationError(w, err.Error())
-		return
-	}
-
-	// Validate description length
-	if len(req.Description) > 500 {
-		writeAgentValidationError(w, "description must not exceed 500 characters")
-		return
-	}
-
-	// Validate model length
-	if len(req.Model) > 100 {
-		writeAgentValidationError(w, "model must not exceed 100 characters")
-		return
-	}
-
-	// Validate email length
-	if len(req.Email) > 255 {
-		writeAgentValidationError(w, "email must not exceed 255 characters")
-		return
-	}
-
-	// Validate external_links count and length
-	if len(req.ExternalLinks) > 10 {
-		writeAgentValidationError(w, "external_links must not exceed 10 items")
-		return
+	var v Validator
+	checkAgentName(&v, req.Name)
+	v.Length("description", req.Description, 0, 500)
+	v.Length("model", req.Model, 0, 100)
+	v.Length("email", req.Email, 0, 255)
+	pending := h.requiresEmailVerification()
+	if pending && v.Required("email", req.Email) && !v.HasError("email") {
+		if err := validateEmail(req.Email); err != nil {
+			v.Add(FieldError{Field: "email", Code: FieldInvalidValue, Message: err.Error()})
+		}
 	}
+	v.MaxItems("external_links", len(req.ExternalLinks), 10)
 	for _, link := range req.ExternalLinks {
 		if len(link) > 500 {
-			writeAgentValidationError(w, "each external link must not exceed 500 characters")
-			return
+			v.Add(FieldError{Field: "external_links", Code: FieldTooLong, Max: 500,
+				Message: "each external link must be at most 500 characters"})
+			break
 		}
 	}
-
-	// Validate AMCP AID length if provided
-	if len(req.AMCPAID) > 255 {
-		writeAgentValidationError(w, "amcp_aid must not exceed 255 characters")
+	v.Length("amcp_aid", req.AMCPAID, 0, 255)
+	v.Length("keri_public_key", req.KERIPublicKey, 0, 512)
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
 		return
 	}
-
-	// Validate KERI public key length if provided
-	if len(req.KERIPublicKey) > 512 {
-		writeAgentValidationError(w, "keri_public_key must not exceed 512 characters")
+	if !h.checkRegistrationProofOfWork(w, r, &req) {
 		return
 	}
 
@@ -284,10 +288,16 @@ func (h *AgentsHandler) RegisterAgent(w http.ResponseWriter, r *http.Request) {
 	apiKey := auth.GenerateAPIKey()
 	apiKeyHash, err := auth.HashAPIKey(apiKey)
 	if err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to generate API key")
+		apierror.Write(w, apierror.InternalError, "failed to generate API key")
 		return
 	}
 
+	// Active immediately, unless the operator must verify their email first
+	status := models.AgentStatusActive
+	if pending {
+		status = models.AgentStatusPendingVerification
+	}
+
 	// Create agent (no human_id for self-registered agents)
 	now := time.Now()
 	agent := &models.Agent{
@@ -302,7 +312,7 @@ func (h *AgentsHandler) RegisterAgent(w http.ResponseWriter, r *http.Request) {
 		Model:         req.Model,
 		Email:         req.Email,
 		ExternalLinks: req.ExternalLinks,
-		Status:        "active", // Active immediately per requirement
+		Status:        string(status),
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
@@ -331,7 +341,7 @@ func (h *AgentsHandler) RegisterAgent(w http.ResponseWriter, r *http.Request) {
 			writeDuplicateNameError(w, req.Name, checkExists)
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create agent")
+		apierror.Write(w, apierror.InternalError, "failed to create agent")
 		return
 	}
 
@@ -354,6 +364,15 @@ func (h *AgentsHandler) RegisterAgent(w http.ResponseWriter, r *http.Request) {
 	if req.Model == "" {
 		nextSteps = append(nextSteps, "Set model via PATCH /v1/agents/"+agent.ID+" with {\"model\":\"your-model\"} for +10 reputation bonus")
 	}
+	if pending {
+		// The agent exists either way; if the email fails it can ask for another.
+		if _, err := h.sendEmailVerification(r.Context(), agent); err != nil {
+			slog.Error("register: failed to send verification email", "error", err, "agent", agent.ID)
+		}
+		nextSteps = append([]string{
+			"Ask your operator to open the verification link sent to " + agent.Email + " — until then this API key is read-only. Resend it with POST /v1/agents/me/verify-email",
+		}, nextSteps...)
+	}
 
 	// Return response with API key (shown only once per requirement)
 	resp := RegisterAgentResponse{
@@ -388,7 +407,7 @@ func (h *AgentsHandler) CreateAgent(w http.ResponseWriter, r *http.Request) {
 
 	// Validate ID format
 	if err := validateAgentID(req.ID); err != nil {
-		writeAgentError(w, http.StatusBadRequest, "INVALID_ID", err.Error())
+		apierror.Write(w, apierror.InvalidID, err.Error())
 		return
 	}
 
@@ -418,7 +437,7 @@ func (h *AgentsHandler) CreateAgent(w http.ResponseWriter, r *http.Request) {
 	apiKey := auth.GenerateAPIKey()
 	apiKeyHash, err := auth.HashAPIKey(apiKey)
 	if err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to generate API key")
+		apierror.Write(w, apierror.InternalError, "failed to generate API key")
 		return
 	}
 
@@ -441,10 +460,10 @@ func (h *AgentsHandler) CreateAgent(w http.ResponseWriter, r *http.Request) {
 	if err := h.repo.Create(r.Context(), agent); err != nil {
 		// FIX-027: Check for both local ErrDuplicateAgentID (mock) and db.ErrDuplicateAgentID (real DB)
 		if errors.Is(err, ErrDuplicateAgentID) || errors.Is(err, db.ErrDuplicateAgentID) {
-			writeAgentError(w, http.StatusConflict, "DUPLICATE_ID", "agent ID already exists")
+			apierror.Write(w, apierror.DuplicateID, "agent ID already exists")
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create agent")
+		apierror.Write(w, apierror.InternalError, "failed to create agent")
 		return
 	}
 
@@ -464,10 +483,10 @@ func (h *AgentsHandler) GetAgent(w http.ResponseWriter, r *http.Request, agentID
 	if err != nil {
 		// FIX-026: Check for both local ErrAgentNotFound (mock) and db.ErrAgentNotFound (real DB)
 		if errors.Is(err, ErrAgentNotFound) || errors.Is(err, db.ErrAgentNotFound) {
-			writeAgentError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
@@ -503,10 +522,10 @@ func (h *AgentsHandler) UpdateAgent(w http.ResponseWriter, r *http.Request, agen
 	if err != nil {
 		// FIX-026: Check for both local ErrAgentNotFound (mock) and db.ErrAgentNotFound (real DB)
 		if errors.Is(err, ErrAgentNotFound) || errors.Is(err, db.ErrAgentNotFound) {
-			writeAgentError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
@@ -519,7 +538,7 @@ func (h *AgentsHandler) UpdateAgent(w http.ResponseWriter, r *http.Request, agen
 		isOwner = true
 	}
 	if !isOwner {
-		writeAgentError(w, http.StatusForbidden, "FORBIDDEN", "you do not own this agent")
+		apierror.Write(w, apierror.Forbidden, "you do not own this agent")
 		return
 	}
 
@@ -589,7 +608,7 @@ func (h *AgentsHandler) UpdateAgent(w http.ResponseWriter, r *http.Request, agen
 	agent.UpdatedAt = time.Now()
 
 	if err := h.repo.Update(r.Context(), agent); err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update agent")
+		apierror.Write(w, apierror.InternalError, "failed to update agent")
 		return
 	}
 
@@ -630,16 +649,16 @@ func (h *AgentsHandler) RegenerateAPIKey(w http.ResponseWriter, r *http.Request,
 	if err != nil {
 		// FIX-026: Check for both local ErrAgentNotFound (mock) and db.ErrAgentNotFound (real DB)
 		if errors.Is(err, ErrAgentNotFound) || errors.Is(err, db.ErrAgentNotFound) {
-			writeAgentError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
 	// Verify ownership
 	if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-		writeAgentError(w, http.StatusForbidden, "FORBIDDEN", "you do not own this agent")
+		apierror.Write(w, apierror.Forbidden, "you do not own this agent")
 		return
 	}
 
@@ -647,13 +666,13 @@ func (h *AgentsHandler) RegenerateAPIKey(w http.ResponseWriter, r *http.Request,
 	apiKey := auth.GenerateAPIKey()
 	apiKeyHash, err := auth.HashAPIKey(apiKey)
 	if err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to generate API key")
+		apierror.Write(w, apierror.InternalError, "failed to generate API key")
 		return
 	}
 
 	// Update hash in database (invalidates old key)
 	if err := h.repo.UpdateAPIKeyHash(r.Context(), agentID, apiKeyHash, auth.SHA256APIKey(apiKey)); err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update API key")
+		apierror.Write(w, apierror.InternalError, "failed to update API key")
 		return
 	}
 
@@ -684,22 +703,22 @@ func (h *AgentsHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request, age
 	if err != nil {
 		// FIX-026: Check for both local ErrAgentNotFound (mock) and db.ErrAgentNotFound (real DB)
 		if errors.Is(err, ErrAgentNotFound) || errors.Is(err, db.ErrAgentNotFound) {
-			writeAgentError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
 	// Verify ownership
 	if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-		writeAgentError(w, http.StatusForbidden, "FORBIDDEN", "you do not own this agent")
+		apierror.Write(w, apierror.Forbidden, "you do not own this agent")
 		return
 	}
 
 	// Revoke API key (set hash to NULL)
 	if err := h.repo.RevokeAPIKey(r.Context(), agentID); err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to revoke API key")
+		apierror.Write(w, apierror.InternalError, "failed to revoke API key")
 		return
 	}
 
@@ -716,7 +735,7 @@ func (h *AgentsHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
 	// Check for JWT authentication (human trying to delete agent) - reject with 403
 	claims := auth.ClaimsFromContext(ctx)
 	if claims != nil {
-		writeAgentError(w, http.StatusForbidden, "FORBIDDEN",
+		apierror.Write(w, apierror.Forbidden,
 			"Humans cannot delete agents. Use DELETE /v1/me instead.")
 		return
 	}
@@ -724,7 +743,7 @@ func (h *AgentsHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
 	// Require API key authentication
 	agent := auth.AgentFromContext(ctx)
 	if agent == nil {
-		writeAgentError(w, http.StatusUnauthorized, "UNAUTHORIZED",
+		apierror.Write(w, apierror.Unauthorized,
 			"API key authentication required")
 		return
 	}
@@ -733,12 +752,12 @@ func (h *AgentsHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
 	err := h.repo.Delete(ctx, agent.ID)
 	if err != nil {
 		if errors.Is(err, db.ErrAgentNotFound) {
-			writeAgentError(w, http.StatusNotFound, "NOT_FOUND",
+			apierror.Write(w, apierror.NotFound,
 				"Agent not found or already deleted")
 			return
 		}
 		slog.Error("failed to delete agent", "error", err, "agent_id", agent.ID)
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR",
+		apierror.Write(w, apierror.InternalError,
 			"Failed to delete agent")
 		return
 	}
@@ -759,7 +778,7 @@ func (h *AgentsHandler) UpdateIdentity(w http.ResponseWriter, r *http.Request) {
 	// Require agent API key auth — reject human JWT callers with 403
 	agent := auth.AgentFromContext(ctx)
 	if agent == nil {
-		writeAgentError(w, http.StatusForbidden, "FORBIDDEN",
+		apierror.Write(w, apierror.Forbidden,
 			"Identity endpoint is agent-only. Use API key authentication.")
 		return
 	}
@@ -787,14 +806,14 @@ func (h *AgentsHandler) UpdateIdentity(w http.ResponseWriter, r *http.Request) {
 	updated, err := h.repo.UpdateIdentity(ctx, agent.ID, req.AMCPAID, req.KERIPublicKey)
 	if err != nil {
 		if errors.Is(err, ErrAgentNotFound) || errors.Is(err, db.ErrAgentNotFound) {
-			writeAgentError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
 		if errors.Is(err, ErrDuplicateAMCPAID) || errors.Is(err, db.ErrDuplicateAMCPAID) {
-			writeAgentError(w, http.StatusConflict, "DUPLICATE_AID", "amcp_aid already in use by another agent")
+			apierror.Write(w, apierror.DuplicateAID, "amcp_aid already in use by another agent")
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update identity")
+		apierror.Write(w, apierror.InternalError, "failed to update identity")
 		return
 	}
 
@@ -853,10 +872,10 @@ func (h *AgentsHandler) GetActivity(w http.ResponseWriter, r *http.Request, agen
 	if err != nil {
 		// FIX-026: Check for both local ErrAgentNotFound (mock) and db.ErrAgentNotFound (real DB)
 		if errors.Is(err, ErrAgentNotFound) || errors.Is(err, db.ErrAgentNotFound) {
-			writeAgentError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get activity")
+		apierror.Write(w, apierror.InternalError, "failed to get activity")
 		return
 	}
 
@@ -879,26 +898,14 @@ func (h *AgentsHandler) GetActivity(w http.ResponseWriter, r *http.Request, agen
 	json.NewEncoder(w).Encode(resp)
 }
 
-// writeAgentError writes an error response.
-func writeAgentError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 // writeAgentUnauthorized writes a 401 Unauthorized error.
 func writeAgentUnauthorized(w http.ResponseWriter, message string) {
-	writeAgentError(w, http.StatusUnauthorized, "UNAUTHORIZED", message)
+	apierror.Write(w, apierror.Unauthorized, message)
 }
 
 // writeAgentValidationError writes a 400 Validation Error.
 func writeAgentValidationError(w http.ResponseWriter, message string) {
-	writeAgentError(w, http.StatusBadRequest, "VALIDATION_ERROR", message)
+	apierror.Write(w, apierror.ValidationError, message)
 }
 
 // generateNameSuggestions generates alternative name suggestions for a duplicate name.
@@ -953,7 +960,7 @@ func writeDuplicateNameError(w http.ResponseWriter, baseName string, checkExists
 
 	response := map[string]interface{}{
 		"error": map[string]interface{}{
-			"code":        "DUPLICATE_NAME",
+			"code":        apierror.DuplicateName,
 			"message":     "agent name already exists",
 			"suggestions": suggestions,
 		},
@@ -1043,7 +1050,7 @@ func (h *AgentsHandler) ListAgents(w http.ResponseWriter, r *http.Request) {
 	// Fetch agents
 	agents, total, err := h.repo.List(r.Context(), opts)
 	if err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list agents")
+		apierror.Write(w, apierror.InternalError, "failed to list agents")
 		return
 	}
 
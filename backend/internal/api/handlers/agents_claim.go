@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
@@ -65,7 +66,7 @@ func (h *AgentsHandler) GenerateClaim(w http.ResponseWriter, r *http.Request) {
 
 	// Check if claim token repository is configured
 	if h.claimTokenRepo == nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "claim token repository not configured")
+		apierror.Write(w, apierror.InternalError, "claim token repository not configured")
 		return
 	}
 
@@ -92,7 +93,7 @@ func (h *AgentsHandler) GenerateClaim(w http.ResponseWriter, r *http.Request) {
 	// Generate new claim token (32 bytes = 64 hex characters)
 	tokenBytes := make([]byte, 32)
 	if _, err := rand.Read(tokenBytes); err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to generate token")
+		apierror.Write(w, apierror.InternalError, "failed to generate token")
 		return
 	}
 	tokenValue := hex.EncodeToString(tokenBytes)
@@ -107,14 +108,22 @@ func (h *AgentsHandler) GenerateClaim(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.claimTokenRepo.Create(r.Context(), claimToken); err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create claim token")
+		apierror.Write(w, apierror.InternalError, "failed to create claim token")
 		return
 	}
 
+	// Email the link to the agent's contact address, if it has one
+	claimURL := "https://solvr.dev/claim/" + tokenValue
+	if h.emailNotifier != nil {
+		if err := h.emailNotifier.NotifyClaimLinkRequested(r.Context(), agent, claimURL, claimToken.ExpiresAt); err != nil {
+			slog.Warn("claim: failed to queue claim link email", "error", err, "agent", agent.ID)
+		}
+	}
+
 	// Return token and instructions
 	resp := GenerateClaimResponse{
 		Token:        tokenValue,
-		ClaimURL:     "https://solvr.dev/claim/" + tokenValue,
+		ClaimURL:     claimURL,
 		ExpiresAt:    claimToken.ExpiresAt,
 		Instructions: generateClaimInstructions(),
 	}
@@ -153,37 +162,38 @@ func (h *AgentsHandler) ClaimAgentWithToken(w http.ResponseWriter, r *http.Reque
 	// Parse request body
 	var req ClaimAgentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeAgentError(w, http.StatusBadRequest, "INVALID_REQUEST", "invalid request body")
+		apierror.Write(w, apierror.InvalidRequest, "invalid request body")
 		return
 	}
 
 	if req.Token == "" {
-		writeAgentError(w, http.StatusBadRequest, "MISSING_TOKEN", "token is required")
+		apierror.Write(w, apierror.MissingToken, "token is required")
 		return
 	}
 
 	// Check if claim token repository is configured
 	if h.claimTokenRepo == nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "claim token repository not configured")
+		apierror.Write(w, apierror.InternalError, "claim token repository not configured")
 		return
 	}
 
 	// Find the claim token
+	// Guest post tokens (no agent) are claimed through POST /v1/guest/claim
 	claimToken, err := h.claimTokenRepo.FindByToken(r.Context(), req.Token)
-	if err != nil || claimToken == nil {
-		writeAgentError(w, http.StatusNotFound, "TOKEN_NOT_FOUND", "token not found")
+	if err != nil || claimToken == nil || claimToken.AgentID == "" {
+		apierror.Write(w, apierror.TokenNotFound, "token not found")
 		return
 	}
 
 	// Check if token is expired
 	if claimToken.IsExpired() {
-		writeAgentError(w, http.StatusGone, "TOKEN_EXPIRED", "token has expired")
+		apierror.WriteStatus(w, http.StatusGone, apierror.TokenExpired, "token has expired")
 		return
 	}
 
 	// Check if token is already used
 	if claimToken.IsUsed() {
-		writeAgentError(w, http.StatusConflict, "TOKEN_USED", "token has already been used")
+		apierror.Write(w, apierror.TokenUsed, "token has already been used")
 		return
 	}
 
@@ -191,22 +201,22 @@ func (h *AgentsHandler) ClaimAgentWithToken(w http.ResponseWriter, r *http.Reque
 	agent, err := h.repo.FindByID(r.Context(), claimToken.AgentID)
 	if err != nil {
 		if errors.Is(err, ErrAgentNotFound) {
-			writeAgentError(w, http.StatusNotFound, "AGENT_NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.AgentNotFound, "agent not found")
 			return
 		}
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
 	// Check if agent is already claimed by a human
 	if agent.HumanID != nil {
-		writeAgentError(w, http.StatusConflict, "ALREADY_CLAIMED", "agent is already claimed")
+		apierror.Write(w, apierror.AlreadyClaimed, "agent is already claimed")
 		return
 	}
 
 	// Link agent to human
 	if err := h.repo.LinkHuman(r.Context(), agent.ID, claims.UserID); err != nil {
-		writeAgentError(w, http.StatusInternalServerError, "LINK_FAILED", "failed to claim agent")
+		apierror.Write(w, apierror.LinkFailed, "failed to claim agent")
 		return
 	}
 
@@ -271,13 +281,13 @@ func (h *AgentsHandler) GetClaimInfo(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if h.claimTokenRepo == nil {
-		writeAgentError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "claim token repository not configured")
+		apierror.Write(w, apierror.InternalError, "claim token repository not configured")
 		return
 	}
 
 	// Find the claim token
 	claimToken, err := h.claimTokenRepo.FindByToken(r.Context(), tokenValue)
-	if err != nil || claimToken == nil {
+	if err != nil || claimToken == nil || claimToken.AgentID == "" {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(ClaimInfoResponse{
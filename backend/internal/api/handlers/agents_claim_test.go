@@ -796,3 +796,54 @@ func TestGenerateClaim_4HourExpiry(t *testing.T) {
 			createdToken.ExpiresAt, time.Until(createdToken.ExpiresAt))
 	}
 }
+
+type mockClaimLinkNotifier struct {
+	claimURLs []string
+}
+
+func (m *mockClaimLinkNotifier) NotifyClaimLinkRequested(ctx context.Context, agent *models.Agent, claimURL string, expiresAt time.Time) error {
+	m.claimURLs = append(m.claimURLs, claimURL)
+	return nil
+}
+
+// TestGenerateClaim_EmailsClaimLink tests that a new claim token is emailed to the
+// agent, and that returning an existing token doesn't email it again.
+func TestGenerateClaim_EmailsClaimLink(t *testing.T) {
+	testAgent := &models.Agent{ID: "test_agent", DisplayName: "Test Agent", Email: "ops@example.com", Status: "active"}
+	agentRepo := NewMockAgentRepository()
+	agentRepo.agents[testAgent.ID] = testAgent
+
+	claimRepo := NewMockClaimTokenRepository()
+	claimRepo.findActiveByAgentErr = errors.New("not found")
+
+	notifier := &mockClaimLinkNotifier{}
+	handler := NewAgentsHandler(agentRepo, "test-secret")
+	handler.SetClaimTokenRepository(claimRepo)
+	handler.SetEmailNotifier(notifier)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/agents/me/claim", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), testAgent))
+	w := httptest.NewRecorder()
+	handler.GenerateClaim(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var resp GenerateClaimResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(notifier.claimURLs) != 1 || notifier.claimURLs[0] != resp.ClaimURL {
+		t.Errorf("expected claim URL %s emailed, got %v", resp.ClaimURL, notifier.claimURLs)
+	}
+
+	claimRepo.findActiveByAgentErr = nil
+	w = httptest.NewRecorder()
+	handler.GenerateClaim(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected existing token with status %d, got %d", http.StatusOK, w.Code)
+	}
+	if len(notifier.claimURLs) != 1 {
+		t.Errorf("expected no email for an existing token, got %d", len(notifier.claimURLs))
+	}
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/token"
+)
+
+// AgentEmailVerificationTTL is how long an operator has to follow the link that
+// activates a self-registered agent. The agent can request a new one.
+const AgentEmailVerificationTTL = 72 * time.Hour
+
+// AgentVerificationRepositoryInterface stores agent email verification tokens by hash.
+type AgentVerificationRepositoryInterface interface {
+	Issue(ctx context.Context, agentID, email, tokenHash string, expiresAt time.Time) error
+	Verify(ctx context.Context, tokenHash string) (string, error)
+}
+
+// AgentVerificationNotifier emails an operator the link that activates their agent.
+type AgentVerificationNotifier interface {
+	NotifyAgentEmailVerification(ctx context.Context, agent *models.Agent, verifyURL string, expiresAt time.Time) error
+}
+
+// SetRegistrationPolicy sets the proof-of-work difficulty and email requirement
+// for POST /v1/agents/register. The per-IP cap is enforced by the router.
+func (h *AgentsHandler) SetRegistrationPolicy(cfg models.AgentRegistrationConfig) {
+	h.registration = cfg
+}
+
+// SetEmailVerification enables operator email verification. Registration only
+// requires it when the policy does and both are set, since a pending agent can
+// only be activated through the email.
+func (h *AgentsHandler) SetEmailVerification(repo AgentVerificationRepositoryInterface, notifier AgentVerificationNotifier) {
+	h.verifications = repo
+	h.verificationNotifier = notifier
+}
+
+// AgentEmailVerificationRequest is the request body for POST /v1/agents/verify-email.
+type AgentEmailVerificationRequest struct {
+	Token string `json:"token"`
+}
+
+// ResendAgentVerificationRequest is the request body for POST /v1/agents/me/verify-email.
+type ResendAgentVerificationRequest struct {
+	Email string `json:"email,omitempty"` // Replaces the operator address when set
+}
+
+// requiresEmailVerification reports whether new agents start pending_verification.
+func (h *AgentsHandler) requiresEmailVerification() bool {
+	return h.registration.RequireEmail && h.verifications != nil && h.verificationNotifier != nil
+}
+
+// checkRegistrationProofOfWork verifies the hashcash stamp a registration must
+// carry when the policy sets a difficulty, in the proof_of_work field or the
+// X-Hashcash header, and writes the error response when it is missing or invalid.
+func (h *AgentsHandler) checkRegistrationProofOfWork(w http.ResponseWriter, r *http.Request, req *RegisterAgentRequest) bool {
+	bits := h.registration.ProofOfWorkBits
+	if bits <= 0 {
+		return true
+	}
+	stamp := req.ProofOfWork
+	if stamp == "" {
+		stamp = r.Header.Get("X-Hashcash")
+	}
+	message := "registration requires a hashcash proof of work"
+	if stamp != "" {
+		err := auth.VerifyProofOfWork(stamp, req.Name, bits, time.Now())
+		if err == nil {
+			return true
+		}
+		message = err.Error()
+	}
+	apierror.WriteDetails(w, apierror.ProofOfWorkRequired, message, map[string]interface{}{
+		"bits":     bits,
+		"resource": strings.ToLower(req.Name),
+		"format":   auth.ProofOfWorkFormat,
+		"hash":     "sha256",
+	})
+	return false
+}
+
+// sendEmailVerification issues a verification token for agent and emails the
+// link to agent.Email. It returns the link's expiry.
+func (h *AgentsHandler) sendEmailVerification(ctx context.Context, agent *models.Agent) (time.Time, error) {
+	plaintext, hash, err := token.GenerateAgentVerificationToken()
+	if err != nil {
+		return time.Time{}, err
+	}
+	expiresAt := time.Now().Add(AgentEmailVerificationTTL)
+	if err := h.verifications.Issue(ctx, agent.ID, agent.Email, hash, expiresAt); err != nil {
+		return time.Time{}, err
+	}
+	verifyURL := h.baseURL + "/agents/verify-email/" + plaintext
+	if err := h.verificationNotifier.NotifyAgentEmailVerification(ctx, agent, verifyURL, expiresAt); err != nil {
+		return time.Time{}, err
+	}
+	return expiresAt, nil
+}
+
+// VerifyAgentEmail handles POST /v1/agents/verify-email - the operator confirms
+// their address with the token from the verification email, which activates the
+// agent's API key. Public: the token is the credential.
+func (h *AgentsHandler) VerifyAgentEmail(w http.ResponseWriter, r *http.Request) {
+	if h.verifications == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "agent email verification is not available")
+		return
+	}
+
+	var req AgentEmailVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAgentValidationError(w, "invalid JSON body")
+		return
+	}
+	if req.Token == "" {
+		apierror.Write(w, apierror.MissingToken, "token is required")
+		return
+	}
+
+	agentID, err := h.verifications.Verify(r.Context(), token.HashToken(req.Token))
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrAgentVerificationNotFound):
+			apierror.Write(w, apierror.TokenNotFound, "token not found")
+		case errors.Is(err, db.ErrAgentVerificationExpired):
+			apierror.WriteStatus(w, http.StatusGone, apierror.TokenExpired, "token has expired; the agent can request a new one with POST /v1/agents/me/verify-email")
+		default:
+			apierror.Write(w, apierror.InternalError, "failed to verify email")
+		}
+		return
+	}
+
+	agent, err := h.repo.FindByID(r.Context(), agentID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"agent_id": agent.ID,
+		"status":   agent.Status,
+		"email":    agent.Email,
+	})
+}
+
+// ResendAgentVerification handles POST /v1/agents/me/verify-email - a pending
+// agent asks for a new verification email, optionally to a corrected address.
+// The earlier link stops working.
+func (h *AgentsHandler) ResendAgentVerification(w http.ResponseWriter, r *http.Request) {
+	agent := auth.AgentFromContext(r.Context())
+	if agent == nil {
+		writeAgentUnauthorized(w, "agent authentication required")
+		return
+	}
+	if h.verifications == nil || h.verificationNotifier == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "agent email verification is not available")
+		return
+	}
+	if agent.Status != string(models.AgentStatusPendingVerification) {
+		apierror.Write(w, apierror.InvalidStatus, "agent email is already verified")
+		return
+	}
+
+	var req ResendAgentVerificationRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeAgentValidationError(w, "invalid JSON body")
+			return
+		}
+	}
+	if req.Email != "" && req.Email != agent.Email {
+		if err := validateEmail(req.Email); err != nil {
+			writeFieldErrors(w, apierror.ValidationError, []FieldError{{Field: "email", Code: FieldInvalidValue, Message: err.Error()}})
+			return
+		}
+		agent.Email = req.Email
+		if err := h.repo.Update(r.Context(), agent); err != nil {
+			apierror.Write(w, apierror.InternalError, "failed to update email")
+			return
+		}
+	}
+
+	expiresAt, err := h.sendEmailVerification(r.Context(), agent)
+	if err != nil {
+		slog.Error("agent verification: failed to resend", "error", err, "agent", agent.ID)
+		apierror.Write(w, apierror.InternalError, "failed to send verification email")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"email":      agent.Email,
+		"expires_at": expiresAt,
+	})
+}
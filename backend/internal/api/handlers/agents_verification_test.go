@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"math/bits"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/token"
+)
+
+type mockAgentVerificationRepo struct {
+	issued    map[string]string // agent ID -> token hash
+	emails    map[string]string // agent ID -> email
+	verifyID  string
+	verifyErr error
+}
+
+func newMockAgentVerificationRepo() *mockAgentVerificationRepo {
+	return &mockAgentVerificationRepo{issued: map[string]string{}, emails: map[string]string{}}
+}
+
+func (m *mockAgentVerificationRepo) Issue(ctx context.Context, agentID, email, tokenHash string, expiresAt time.Time) error {
+	m.issued[agentID] = tokenHash
+	m.emails[agentID] = email
+	return nil
+}
+
+func (m *mockAgentVerificationRepo) Verify(ctx context.Context, tokenHash string) (string, error) {
+	return m.verifyID, m.verifyErr
+}
+
+type mockAgentVerificationNotifier struct {
+	urls []string
+}
+
+func (m *mockAgentVerificationNotifier) NotifyAgentEmailVerification(ctx context.Context, agent *models.Agent, verifyURL string, expiresAt time.Time) error {
+	m.urls = append(m.urls, verifyURL)
+	return nil
+}
+
+// mintTestStamp finds a hashcash stamp for resource with at least n zero bits.
+func mintTestStamp(resource string, n int) string {
+	prefix := "1:" + strconv.Itoa(n) + ":" + time.Now().UTC().Format("060102") + ":" + resource + "::dGVzdA:"
+	for counter := 0; ; counter++ {
+		stamp := prefix + strconv.Itoa(counter)
+		sum := sha256.Sum256([]byte(stamp))
+		zeros := 0
+		for _, b := range sum {
+			zeros += bits.LeadingZeros8(b)
+			if b != 0 {
+				break
+			}
+		}
+		if zeros >= n {
+			return stamp
+		}
+	}
+}
+
+func postRegisterAgent(h *AgentsHandler, body map[string]interface{}, header string) *httptest.ResponseRecorder {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/v1/agents/register", bytes.NewReader(b))
+	if header != "" {
+		req.Header.Set("X-Hashcash", header)
+	}
+	rr := httptest.NewRecorder()
+	h.RegisterAgent(rr, req)
+	return rr
+}
+
+func TestRegisterAgent_ProofOfWork(t *testing.T) {
+	h := NewAgentsHandler(NewMockAgentRepositoryWithNameLookup(), "test-jwt-secret")
+	h.SetRegistrationPolicy(models.AgentRegistrationConfig{ProofOfWorkBits: 8})
+
+	rr := postRegisterAgent(h, map[string]interface{}{"name": "pow_agent"}, "")
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "PROOF_OF_WORK_REQUIRED") {
+		t.Fatalf("expected 400 PROOF_OF_WORK_REQUIRED without a stamp, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var errResp struct {
+		Error struct {
+			Details map[string]interface{} `json:"details"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &errResp)
+	if errResp.Error.Details["bits"] != float64(8) || errResp.Error.Details["resource"] != "pow_agent" {
+		t.Errorf("expected difficulty and resource in details, got %v", errResp.Error.Details)
+	}
+
+	// A stamp minted for another name does not count.
+	rr = postRegisterAgent(h, map[string]interface{}{"name": "pow_agent", "proof_of_work": mintTestStamp("other_agent", 8)}, "")
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a stamp minted for another name, got %d", rr.Code)
+	}
+
+	rr = postRegisterAgent(h, map[string]interface{}{"name": "pow_agent", "proof_of_work": mintTestStamp("pow_agent", 8)}, "")
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected 201 with a valid stamp, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = postRegisterAgent(h, map[string]interface{}{"name": "pow_header_agent"}, mintTestStamp("pow_header_agent", 8))
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected 201 with a valid X-Hashcash header, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestRegisterAgent_RequireEmail(t *testing.T) {
+	verifications := newMockAgentVerificationRepo()
+	notifier := &mockAgentVerificationNotifier{}
+	h := NewAgentsHandler(NewMockAgentRepositoryWithNameLookup(), "test-jwt-secret")
+	h.SetRegistrationPolicy(models.AgentRegistrationConfig{RequireEmail: true})
+	h.SetEmailVerification(verifications, notifier)
+
+	rr := postRegisterAgent(h, map[string]interface{}{"name": "email_agent"}, "")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without an email, got %d", rr.Code)
+	}
+
+	rr = postRegisterAgent(h, map[string]interface{}{"name": "email_agent", "email": "ops@example.com"}, "")
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp RegisterAgentResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Agent.Status != string(models.AgentStatusPendingVerification) {
+		t.Errorf("expected status pending_verification, got %q", resp.Agent.Status)
+	}
+	if resp.APIKey == "" {
+		t.Error("expected the API key to be returned while pending")
+	}
+	if len(notifier.urls) != 1 || !strings.HasPrefix(notifier.urls[0], "https://solvr.dev/agents/verify-email/solvr_ev_") {
+		t.Fatalf("expected one verification email, got %v", notifier.urls)
+	}
+	plaintext := strings.TrimPrefix(notifier.urls[0], "https://solvr.dev/agents/verify-email/")
+	if verifications.issued[resp.Agent.ID] != token.HashToken(plaintext) {
+		t.Error("expected only the token hash to be stored")
+	}
+}
+
+func TestVerifyAgentEmail(t *testing.T) {
+	repo := NewMockAgentRepository()
+	repo.agents["agent_verified"] = &models.Agent{ID: "agent_verified", Status: string(models.AgentStatusActive), Email: "ops@example.com"}
+
+	tests := []struct {
+		name       string
+		body       string
+		verifyErr  error
+		wantStatus int
+	}{
+		{name: "verified", body: `{"token":"solvr_ev_abc"}`, wantStatus: http.StatusOK},
+		{name: "missing token", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown token", body: `{"token":"solvr_ev_abc"}`, verifyErr: db.ErrAgentVerificationNotFound, wantStatus: http.StatusNotFound},
+		{name: "expired token", body: `{"token":"solvr_ev_abc"}`, verifyErr: db.ErrAgentVerificationExpired, wantStatus: http.StatusGone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifications := newMockAgentVerificationRepo()
+			verifications.verifyID = "agent_verified"
+			verifications.verifyErr = tt.verifyErr
+			h := NewAgentsHandler(repo, "test-jwt-secret")
+			h.SetEmailVerification(verifications, &mockAgentVerificationNotifier{})
+
+			rr := httptest.NewRecorder()
+			h.VerifyAgentEmail(rr, httptest.NewRequest(http.MethodPost, "/v1/agents/verify-email", strings.NewReader(tt.body)))
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestResendAgentVerification(t *testing.T) {
+	repo := NewMockAgentRepository()
+	pending := &models.Agent{ID: "agent_pending", DisplayName: "pending", Status: string(models.AgentStatusPendingVerification), Email: "typo@exmaple.com"}
+	repo.agents[pending.ID] = pending
+	verifications := newMockAgentVerificationRepo()
+	notifier := &mockAgentVerificationNotifier{}
+	h := NewAgentsHandler(repo, "test-jwt-secret")
+	h.SetEmailVerification(verifications, notifier)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/agents/me/verify-email", strings.NewReader(`{"email":"ops@example.com"}`))
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), pending))
+	rr := httptest.NewRecorder()
+	h.ResendAgentVerification(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if repo.agents[pending.ID].Email != "ops@example.com" || verifications.emails[pending.ID] != "ops@example.com" {
+		t.Errorf("expected the corrected address to be stored, got agent %q, verification %q", repo.agents[pending.ID].Email, verifications.emails[pending.ID])
+	}
+	if len(notifier.urls) != 1 {
+		t.Errorf("expected one email, got %d", len(notifier.urls))
+	}
+
+	active := &models.Agent{ID: "agent_active", Status: string(models.AgentStatusActive)}
+	req = httptest.NewRequest(http.MethodPost, "/v1/agents/me/verify-email", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), active))
+	rr = httptest.NewRecorder()
+	h.ResendAgentVerification(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a verified agent, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// maxAnswerDuplicates caps the possible duplicates returned when creating an answer.
+const maxAnswerDuplicates = 3
+
+// AnswerDuplicateFinder finds answers on a question close to a new answer's embedding.
+type AnswerDuplicateFinder interface {
+	FindAnswerDuplicates(ctx context.Context, questionID, embedding string, minSimilarity float64, limit int) ([]models.AnswerDuplicate, error)
+}
+
+// SetAnswerDuplicateFinder compares new answers with the question's existing
+// answers: close ones are listed under possible_duplicates and near-identical
+// ones are rejected. Needs the embedding service; answers without an embedding
+// are not checked.
+func (h *QuestionsHandler) SetAnswerDuplicateFinder(finder AnswerDuplicateFinder, cfg models.AnswerDuplicateConfig) {
+	h.answerDuplicates = finder
+	h.answerDuplicateConfig = cfg
+}
+
+// findAnswerDuplicates returns the question's existing answers within the warn
+// threshold of the new answer, most similar first, leaving out the answer it
+// supersedes: an improved version is expected to resemble it. Lookup failures are
+// logged and treated as no duplicates, so they never fail the create.
+func (h *QuestionsHandler) findAnswerDuplicates(ctx context.Context, answer *models.Answer) []models.AnswerDuplicate {
+	if h.answerDuplicates == nil || answer.EmbeddingStr == nil {
+		return nil
+	}
+	found, err := h.answerDuplicates.FindAnswerDuplicates(ctx, answer.QuestionID, *answer.EmbeddingStr,
+		h.answerDuplicateConfig.WarnThreshold, maxAnswerDuplicates+1)
+	if err != nil {
+		h.logger.Warn("failed to look up answer duplicates", "questionID", answer.QuestionID, "error", err)
+		return nil
+	}
+	duplicates := make([]models.AnswerDuplicate, 0, len(found))
+	for _, d := range found {
+		if answer.SupersedesAnswerID != nil && d.ID == *answer.SupersedesAnswerID {
+			continue
+		}
+		duplicates = append(duplicates, d)
+	}
+	if len(duplicates) > maxAnswerDuplicates {
+		duplicates = duplicates[:maxAnswerDuplicates]
+	}
+	return duplicates
+}
+
+// writeAnswerDuplicate rejects an answer that restates an existing one, pointing
+// at it in details so the caller can vote on it or supersede it instead.
+func writeAnswerDuplicate(w http.ResponseWriter, duplicate models.AnswerDuplicate) {
+	apierror.WriteDetails(w, apierror.DuplicateContent,
+		"a nearly identical answer already exists; upvote it, or post an improved version with supersedes_answer_id",
+		map[string]interface{}{"duplicate_answer_id": duplicate.ID, "similarity": duplicate.Similarity})
+}
+
+// createdAnswerResponse is the body for a created answer, listing close existing
+// answers under possible_duplicates when there are any.
+func createdAnswerResponse(answer *models.Answer, duplicates []models.AnswerDuplicate) map[string]interface{} {
+	resp := map[string]interface{}{"data": answer}
+	if len(duplicates) > 0 {
+		resp["possible_duplicates"] = duplicates
+	}
+	return resp
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+type mockAnswerDuplicateFinder struct {
+	duplicates    []models.AnswerDuplicate
+	err           error
+	minSimilarity float64
+}
+
+func (m *mockAnswerDuplicateFinder) FindAnswerDuplicates(ctx context.Context, questionID, embedding string, minSimilarity float64, limit int) ([]models.AnswerDuplicate, error) {
+	m.minSimilarity = minSimilarity
+	return m.duplicates, m.err
+}
+
+func postAnswerWithDuplicates(t *testing.T, finder *mockAnswerDuplicateFinder) (*MockQuestionsRepository, *httptest.ResponseRecorder) {
+	t.Helper()
+	repo := NewMockQuestionsRepository()
+	question := createTestQuestion("question-123", "Pool leak")
+	repo.SetQuestion(&question)
+	handler := NewQuestionsHandler(repo)
+	handler.SetEmbeddingService(&MockEmbeddingService{embedding: []float32{0.1, 0.2, 0.3}})
+	handler.SetAnswerDuplicateFinder(finder, models.DefaultAnswerDuplicateConfig())
+
+	body, _ := json.Marshal(map[string]string{"content": "Call pool.Close() when the server shuts down."})
+	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/answers", bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "question-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addQuestionsAuthContext(req, "agent-1", "agent")
+	w := httptest.NewRecorder()
+	handler.CreateAnswer(w, req)
+	return repo, w
+}
+
+func TestCreateAnswer_ListsPossibleDuplicates(t *testing.T) {
+	finder := &mockAnswerDuplicateFinder{duplicates: []models.AnswerDuplicate{{ID: "answer-1", Similarity: 0.93}}}
+	_, w := postAnswerWithDuplicates(t, finder)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if finder.minSimilarity != models.DefaultAnswerDuplicateConfig().WarnThreshold {
+		t.Errorf("looked up duplicates above %v, want the warn threshold", finder.minSimilarity)
+	}
+	var resp struct {
+		PossibleDuplicates []models.AnswerDuplicate `json:"possible_duplicates"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.PossibleDuplicates) != 1 || resp.PossibleDuplicates[0].ID != "answer-1" {
+		t.Errorf("possible_duplicates = %+v, want answer-1", resp.PossibleDuplicates)
+	}
+}
+
+func TestCreateAnswer_RejectsNearIdenticalAnswer(t *testing.T) {
+	finder := &mockAnswerDuplicateFinder{duplicates: []models.AnswerDuplicate{{ID: "answer-1", Similarity: 0.99}}}
+	repo, w := postAnswerWithDuplicates(t, finder)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.createdAnswer != nil {
+		t.Error("a rejected answer should not be created")
+	}
+	var resp struct {
+		Error struct {
+			Code    string                 `json:"code"`
+			Details map[string]interface{} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Error.Code != "DUPLICATE_CONTENT" || resp.Error.Details["duplicate_answer_id"] != "answer-1" {
+		t.Errorf("error = %+v, want DUPLICATE_CONTENT pointing at answer-1", resp.Error)
+	}
+}
+
+func TestCreateAnswer_DuplicateLookupFailureStillCreates(t *testing.T) {
+	_, w := postAnswerWithDuplicates(t, &mockAnswerDuplicateFinder{err: errors.New("db down")})
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte("possible_duplicates")) {
+		t.Error("expected no possible_duplicates when the lookup fails")
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+// AnswerReviewStore hides answers while an edit to them is re-moderated.
+type AnswerReviewStore interface {
+	// HoldAnswerForReview hides the answer; false if it is already hidden on reports.
+	HoldAnswerForReview(ctx context.Context, answerID string) (bool, error)
+	// ReleaseAnswerReview shows a held answer again.
+	ReleaseAnswerReview(ctx context.Context, answerID string) error
+	// IsAnswerHeldForReview reports whether the answer is held.
+	IsAnswerHeldForReview(ctx context.Context, answerID string) (bool, error)
+}
+
+// SetAnswerRemoderation re-moderates answer edits of at least
+// cfg.MinChangedChars. Edits past cfg.HoldRatio, and any edit to an answer
+// still held, hide the answer until moderation approves it; a rejected
+// background edit hides it too.
+func (h *QuestionsHandler) SetAnswerRemoderation(svc ContentModerationServiceInterface, store AnswerReviewStore, cfg models.RemoderationConfig) {
+	h.answerModeration = svc
+	h.answerReviews = store
+	h.answerRemoderation = cfg
+}
+
+// remoderateAnswerEdit starts re-moderation of an answer edited from existing
+// to content, holding it first if needed. Returns true when the answer is held.
+func (h *QuestionsHandler) remoderateAnswerEdit(ctx context.Context, existing *models.AnswerWithAuthor, content string) bool {
+	if h.answerModeration == nil || h.answerReviews == nil {
+		return false
+	}
+
+	action := h.answerRemoderation.Decide(existing.Content, content)
+	held, err := h.answerReviews.IsAnswerHeldForReview(ctx, existing.ID)
+	if err != nil {
+		h.logger.Warn("failed to check answer review hold", "answerID", existing.ID, "error", err)
+	}
+	if held {
+		action = models.RemoderationHold
+	}
+	if action == models.RemoderationNone {
+		return false
+	}
+
+	if action == models.RemoderationHold {
+		if held, err = h.answerReviews.HoldAnswerForReview(ctx, existing.ID); err != nil {
+			h.logger.Error("failed to hold answer for review", "answerID", existing.ID, "error", err)
+		}
+	}
+
+	questionTitle := ""
+	if question, err := h.repo.FindQuestionByID(ctx, existing.QuestionID); err == nil {
+		questionTitle = question.Title
+	}
+	go h.remoderateAnswerAsync(tenant.FromContext(ctx), existing.ID, questionTitle, content, held)
+	return held
+}
+
+// remoderateAnswerAsync moderates an edited answer against its question's
+// title. Approval releases a held answer; rejection hides the answer until an
+// approved edit releases it. It runs in tenantID, the answer's tenant.
+func (h *QuestionsHandler) remoderateAnswerAsync(tenantID, answerID, questionTitle, content string, held bool) {
+	ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), tenantID), 60*time.Second)
+	defer cancel()
+
+	result, err := moderateWithRetry(ctx, h.answerModeration, ModerationInput{
+		Title:       questionTitle,
+		Description: content,
+	}, defaultRetryDelays, h.logger, "answerID", answerID)
+	if err != nil {
+		// A held answer stays hidden; its next edit retries moderation.
+		return
+	}
+
+	switch {
+	case result.Approved && held:
+		if err := h.answerReviews.ReleaseAnswerReview(ctx, answerID); err != nil {
+			h.logger.Error("failed to release answer after re-moderation", "answerID", answerID, "error", err)
+		}
+	case !result.Approved:
+		h.logger.Warn("edited answer rejected by moderation", "answerID", answerID, "reasons", result.RejectionReasons)
+		if _, err := h.answerReviews.HoldAnswerForReview(ctx, answerID); err != nil {
+			h.logger.Error("failed to hide rejected answer", "answerID", answerID, "error", err)
+		}
+	}
+}
+
+// moderateWithRetry moderates input, waiting out rate limits and retrying other
+// errors after each of delays. Once the retries are exhausted it returns the
+// last error.
+func moderateWithRetry(ctx context.Context, svc ContentModerationServiceInterface, input ModerationInput, delays []time.Duration, logger *slog.Logger, idKey, id string) (*ModerationResult, error) {
+	attempt := 0
+	for {
+		result, err := svc.ModerateContent(ctx, input)
+		if err == nil {
+			return result, nil
+		}
+
+		var rateLimitErr RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			retryAfter := rateLimitErr.GetRetryAfter()
+			logger.Warn("moderation rate limited, retrying", idKey, id, "retryAfter", retryAfter)
+			time.Sleep(retryAfter)
+			continue
+		}
+
+		attempt++
+		logger.Warn("moderation attempt failed", idKey, id, "attempt", attempt, "error", err)
+		if attempt >= len(delays) {
+			logger.Error("moderation failed after all retries", idKey, id, "attempts", attempt)
+			return nil, err
+		}
+		time.Sleep(delays[attempt-1])
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// MockAnswerReviewStore records review holds in memory.
+type MockAnswerReviewStore struct {
+	mu       sync.Mutex
+	held     map[string]bool
+	released []string
+}
+
+func NewMockAnswerReviewStore() *MockAnswerReviewStore {
+	return &MockAnswerReviewStore{held: map[string]bool{}}
+}
+
+func (m *MockAnswerReviewStore) HoldAnswerForReview(ctx context.Context, answerID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.held[answerID] = true
+	return true, nil
+}
+
+func (m *MockAnswerReviewStore) ReleaseAnswerReview(ctx context.Context, answerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.held, answerID)
+	m.released = append(m.released, answerID)
+	return nil
+}
+
+func (m *MockAnswerReviewStore) IsAnswerHeldForReview(ctx context.Context, answerID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.held[answerID], nil
+}
+
+func (m *MockAnswerReviewStore) isHeld(answerID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.held[answerID]
+}
+
+func (m *MockAnswerReviewStore) releases() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.released)
+}
+
+func TestUpdateAnswer_Remoderation(t *testing.T) {
+	original := createTestAnswer("answer-123", "question-123").Content
+	rejected := &ModerationResult{Approved: false, RejectionReasons: []string{"SPAM"}}
+
+	tests := []struct {
+		name         string
+		content      string
+		alreadyHeld  bool
+		result       *ModerationResult
+		wantCalls    int
+		wantResponse bool // held_for_review on the response
+		wantHeld     bool // held once moderation finished
+		wantReleases int
+	}{
+		{"small edit is not re-moderated", original + " (typo fixed)", false, nil, 0, false, false, 0},
+		{"large addition is re-moderated in the background", original + strings.Repeat(" more detail", 2), false, nil, 1, false, false, 0},
+		{"rejected background edit is hidden", original + strings.Repeat(" buy pills", 3), false, rejected, 1, false, true, 0},
+		{"drastic rewrite is held until approved", strings.Repeat("rewritten ", 8), false, nil, 1, true, false, 1},
+		{"drastic rewrite stays held when rejected", strings.Repeat("buy pills ", 8), false, rejected, 1, true, true, 0},
+		{"any edit of a held answer is re-moderated", original + "!", true, nil, 1, true, false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockQuestionsRepository()
+			answer := createTestAnswer("answer-123", "question-123")
+			repo.SetAnswer(&answer)
+			question := createTestQuestion("question-123", "How do I close a pgx pool?")
+			repo.SetQuestion(&question)
+
+			modService := NewMockContentModerationService()
+			if tt.result != nil {
+				modService.SetResult(tt.result)
+			}
+			store := NewMockAnswerReviewStore()
+			if tt.alreadyHeld {
+				store.held["answer-123"] = true
+			}
+
+			handler := NewQuestionsHandler(repo)
+			handler.SetAnswerRemoderation(modService, store, models.RemoderationConfig{MinChangedChars: 20, HoldRatio: 0.5})
+
+			jsonBody, _ := json.Marshal(map[string]interface{}{"content": tt.content})
+			req := httptest.NewRequest(http.MethodPatch, "/v1/answers/answer-123", bytes.NewReader(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "answer-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = addQuestionsAuthContext(req, "user-456", "user")
+			w := httptest.NewRecorder()
+
+			handler.UpdateAnswer(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var resp struct {
+				Data models.Answer `json:"data"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Data.HeldForReview != tt.wantResponse {
+				t.Errorf("held_for_review = %v, want %v", resp.Data.HeldForReview, tt.wantResponse)
+			}
+
+			// Wait for async moderation goroutine to complete
+			time.Sleep(200 * time.Millisecond)
+
+			if calls := modService.GetCalls(); calls != tt.wantCalls {
+				t.Errorf("expected %d moderation calls, got %d", tt.wantCalls, calls)
+			}
+			if held := store.isHeld("answer-123"); held != tt.wantHeld {
+				t.Errorf("held after moderation = %v, want %v", held, tt.wantHeld)
+			}
+			if n := store.releases(); n != tt.wantReleases {
+				t.Errorf("expected %d releases, got %d", tt.wantReleases, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// AnswerVersionsRepositoryInterface reads the version chains answers form
+// through supersedes_answer_id.
+type AnswerVersionsRepositoryInterface interface {
+	GetAnswerVersionChain(ctx context.Context, answerID string) (*models.AnswerVersionChain, error)
+}
+
+// SetAnswerVersionsRepository enables GET /v1/answers/{id}/versions.
+func (h *QuestionsHandler) SetAnswerVersionsRepository(repo AnswerVersionsRepositoryInterface) {
+	h.versionsRepo = repo
+}
+
+// GetAnswerVersions handles GET /v1/answers/{id}/versions.
+// Returns the answer's chain of versions, oldest first, and the newest verified one.
+func (h *QuestionsHandler) GetAnswerVersions(w http.ResponseWriter, r *http.Request) {
+	if h.versionsRepo == nil {
+		apierror.Write(w, apierror.NotImplemented, "version history not available")
+		return
+	}
+
+	answerID := chi.URLParam(r, "id")
+	if answerID == "" {
+		apierror.Write(w, apierror.ValidationError, "answer ID is required")
+		return
+	}
+
+	chain, err := h.versionsRepo.GetAnswerVersionChain(r.Context(), answerID)
+	if err != nil {
+		if errors.Is(err, ErrAnswerNotFound) || errors.Is(err, db.ErrAnswerNotFound) {
+			apierror.Write(w, apierror.NotFound, "answer not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get answer versions")
+		return
+	}
+
+	writeQuestionsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": chain,
+	})
+}
+
+// checkSupersededAnswer validates supersedes_answer_id on a new answer: the older
+// answer must exist, belong to the same question and not already be superseded.
+// Writes the error response and returns false when it is invalid.
+func (h *QuestionsHandler) checkSupersededAnswer(w http.ResponseWriter, r *http.Request, questionID, supersededID string) bool {
+	old, err := h.repo.FindAnswerByID(r.Context(), supersededID)
+	if err != nil {
+		if errors.Is(err, ErrAnswerNotFound) || errors.Is(err, db.ErrAnswerNotFound) {
+			writeFieldErrors(w, apierror.ValidationError, []FieldError{
+				{Field: "supersedes_answer_id", Code: FieldInvalidValue, Message: "answer not found"},
+			})
+			return false
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get superseded answer")
+		return false
+	}
+	if old.QuestionID != questionID {
+		writeFieldErrors(w, apierror.ValidationError, []FieldError{
+			{Field: "supersedes_answer_id", Code: FieldInvalidValue, Message: "must be an answer to the same question"},
+		})
+		return false
+	}
+	if old.SupersededByID != nil {
+		writeAnswerAlreadySuperseded(w, *old.SupersededByID)
+		return false
+	}
+	return true
+}
+
+// writeAnswerAlreadySuperseded writes the 409 for superseding an answer that
+// already has a newer version. supersededByID may be empty when unknown.
+func writeAnswerAlreadySuperseded(w http.ResponseWriter, supersededByID string) {
+	details := map[string]interface{}{}
+	if supersededByID != "" {
+		details["superseded_by_id"] = supersededByID
+	}
+	apierror.WriteDetails(w, apierror.Conflict, "answer already has a newer version; supersede the latest version instead", details)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db/memdb"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// answerVersionsTest is a handler backed by an in-memory store holding two
+// questions with one answer each.
+type answerVersionsTest struct {
+	handler     *QuestionsHandler
+	store       *memdb.Store
+	questionID  string
+	answerID    string // answer to questionID
+	otherAnswer string // answer to the other question
+}
+
+func newAnswerVersionsTest(t *testing.T) answerVersionsTest {
+	t.Helper()
+	store := memdb.NewStore()
+	ctx := context.Background()
+
+	var questionIDs []string
+	for _, title := range []string{"How do I rotate Postgres credentials?", "Why does my cron job skip runs?"} {
+		q, err := store.Questions().CreateQuestion(ctx, &models.Post{
+			Title:        title,
+			Description:  "A question with a description long enough to be valid content.",
+			PostedByType: models.AuthorTypeHuman,
+			PostedByID:   "user-1",
+			Status:       models.PostStatusOpen,
+		})
+		if err != nil {
+			t.Fatalf("create question: %v", err)
+		}
+		questionIDs = append(questionIDs, q.ID)
+	}
+	answer, err := store.Answers().CreateAnswer(ctx, &models.Answer{
+		QuestionID: questionIDs[0],
+		AuthorType: models.AuthorTypeHuman,
+		AuthorID:   "user-2",
+		Content:    "Use ALTER ROLE with a new password and restart the pool.",
+	})
+	if err != nil {
+		t.Fatalf("create answer: %v", err)
+	}
+	other, err := store.Answers().CreateAnswer(ctx, &models.Answer{
+		QuestionID: questionIDs[1],
+		AuthorType: models.AuthorTypeHuman,
+		AuthorID:   "user-2",
+		Content:    "Check the timezone of the cron daemon.",
+	})
+	if err != nil {
+		t.Fatalf("create answer: %v", err)
+	}
+
+	handler := NewQuestionsHandler(store.Questions())
+	handler.SetAnswerVersionsRepository(store.Answers())
+	return answerVersionsTest{handler: handler, store: store, questionID: questionIDs[0], answerID: answer.ID, otherAnswer: other.ID}
+}
+
+func postAnswer(handler *QuestionsHandler, questionID string, body map[string]interface{}) *httptest.ResponseRecorder {
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/v1/questions/"+questionID+"/answers", bytes.NewReader(jsonBody))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", questionID)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addQuestionsAuthContext(req, "user-3", "user")
+	w := httptest.NewRecorder()
+	handler.CreateAnswer(w, req)
+	return w
+}
+
+func getAnswerVersions(handler *QuestionsHandler, answerID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/answers/"+answerID+"/versions", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", answerID)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+	handler.GetAnswerVersions(w, req)
+	return w
+}
+
+func TestCreateAnswer_Supersedes(t *testing.T) {
+	tc := newAnswerVersionsTest(t)
+	handler, store, questionID, oldID := tc.handler, tc.store, tc.questionID, tc.answerID
+
+	w := postAnswer(handler, questionID, map[string]interface{}{
+		"content":              "Rotate with a second role first, then swap the pool's credentials without downtime.",
+		"supersedes_answer_id": oldID,
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data models.Answer `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Data.SupersedesAnswerID == nil || *resp.Data.SupersedesAnswerID != oldID {
+		t.Errorf("expected supersedes_answer_id %s, got %v", oldID, resp.Data.SupersedesAnswerID)
+	}
+
+	old, err := store.Answers().FindAnswerByID(context.Background(), oldID)
+	if err != nil {
+		t.Fatalf("FindAnswerByID() error = %v", err)
+	}
+	if old.SupersededByID == nil || *old.SupersededByID != resp.Data.ID {
+		t.Errorf("expected old answer superseded by %s, got %v", resp.Data.ID, old.SupersededByID)
+	}
+
+	// A version can only be superseded once
+	w = postAnswer(handler, questionID, map[string]interface{}{
+		"content":              "Another take on rotating the credentials.",
+		"supersedes_answer_id": oldID,
+	})
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 superseding twice, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateAnswer_SupersedesInvalid(t *testing.T) {
+	tc := newAnswerVersionsTest(t)
+
+	tests := []struct {
+		name       string
+		supersedes string
+	}{
+		{"unknown answer", "missing-answer"},
+		{"answer to another question", tc.otherAnswer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postAnswer(tc.handler, tc.questionID, map[string]interface{}{
+				"content":              "An improved answer.",
+				"supersedes_answer_id": tt.supersedes,
+			})
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetAnswerVersions(t *testing.T) {
+	tc := newAnswerVersionsTest(t)
+	handler, store, questionID, oldID := tc.handler, tc.store, tc.questionID, tc.answerID
+	ctx := context.Background()
+
+	newer, err := store.Answers().CreateAnswer(ctx, &models.Answer{
+		QuestionID:         questionID,
+		AuthorType:         models.AuthorTypeAgent,
+		AuthorID:           "agent-1",
+		Content:            "Improved: rotate through a second role.",
+		SupersedesAnswerID: &oldID,
+	})
+	if err != nil {
+		t.Fatalf("create answer: %v", err)
+	}
+	if err := store.Answers().VoteOnAnswer(ctx, newer.ID, "human", "user-9", "up"); err != nil {
+		t.Fatalf("vote: %v", err)
+	}
+
+	w := getAnswerVersions(handler, newer.ID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data models.AnswerVersionChain `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Data.Versions) != 2 || resp.Data.Versions[0].ID != oldID || resp.Data.Versions[1].ID != newer.ID {
+		t.Fatalf("expected [old, newer] versions, got %+v", resp.Data.Versions)
+	}
+	if resp.Data.LatestVerifiedID == nil || *resp.Data.LatestVerifiedID != newer.ID {
+		t.Errorf("expected upvoted newer version to be latest verified, got %v", resp.Data.LatestVerifiedID)
+	}
+}
+
+func TestGetAnswerVersions_NotFound(t *testing.T) {
+	tc := newAnswerVersionsTest(t)
+
+	if w := getAnswerVersions(tc.handler, "missing"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
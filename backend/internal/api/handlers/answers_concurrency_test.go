@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+)
+
+func newAnswerPatchRequest(t *testing.T, body map[string]interface{}) *http.Request {
+	t.Helper()
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPatch, "/v1/answers/answer-123", bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "answer-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	return addQuestionsAuthContext(req, "user-456", "user") // Same as answer author
+}
+
+// TestUpdateAnswer_ExpectedUpdatedAtStale tests that a stale expected_updated_at returns 409.
+func TestUpdateAnswer_ExpectedUpdatedAtStale(t *testing.T) {
+	repo := NewMockQuestionsRepository()
+	answer := createTestAnswer("answer-123", "question-123")
+	answer.UpdatedAt = answer.CreatedAt
+	repo.SetAnswer(&answer)
+
+	handler := NewQuestionsHandler(repo)
+	w := httptest.NewRecorder()
+
+	handler.UpdateAnswer(w, newAnswerPatchRequest(t, map[string]interface{}{
+		"content":             "An edit based on an older copy of this answer.",
+		"expected_updated_at": answer.UpdatedAt.Add(-time.Minute),
+	}))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.updatedAnswer != nil {
+		t.Error("expected answer not to be updated on conflict")
+	}
+}
+
+// TestUpdateAnswer_RepositoryConflict tests that a lost race in the repository returns 409 with the current answer.
+func TestUpdateAnswer_RepositoryConflict(t *testing.T) {
+	repo := NewMockQuestionsRepository()
+	answer := createTestAnswer("answer-123", "question-123")
+	repo.SetAnswer(&answer)
+	repo.err = db.ErrVersionConflict
+
+	handler := NewQuestionsHandler(repo)
+	w := httptest.NewRecorder()
+
+	handler.UpdateAnswer(w, newAnswerPatchRequest(t, map[string]interface{}{
+		"content": "An edit that raced with another writer on this answer.",
+	}))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	data, ok := resp["data"].(map[string]interface{})
+	if !ok || data["id"] != "answer-123" {
+		t.Errorf("expected current answer in response, got %v", resp["data"])
+	}
+}
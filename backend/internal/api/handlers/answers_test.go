@@ -5,24 +5,42 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/db/memdb"
+	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
 
+// newAnswersTestHandler returns a QuestionsHandler backed by an in-memory store
+// holding question-123, asked by user-123, with answer-123 by user-456.
+func newAnswersTestHandler(t *testing.T) (*QuestionsHandler, *memdb.Store) {
+	t.Helper()
+	store := memdb.NewStore()
+	ctx := context.Background()
+	question := createTestQuestion("question-123", "Test Question")
+	if _, err := store.Questions().CreateQuestion(ctx, &question.Post); err != nil {
+		t.Fatalf("create question: %v", err)
+	}
+	answer := createTestAnswer("answer-123", "question-123")
+	if _, err := store.Answers().CreateAnswer(ctx, &answer.Answer); err != nil {
+		t.Fatalf("create answer: %v", err)
+	}
+	return NewQuestionsHandler(store.Questions()), store
+}
+
 // ============================================================================
 // POST /v1/questions/:id/answers - Create Answer Tests
 // ============================================================================
 
 // TestCreateAnswer_Success tests successful answer creation.
 func TestCreateAnswer_Success(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	question := createTestQuestion("question-123", "Test Question")
-	repo.SetQuestion(&question)
-
-	handler := NewQuestionsHandler(repo)
+	handler, store := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"content": "This is a test answer with sufficient content length to be a valid answer.",
@@ -52,12 +70,14 @@ func TestCreateAnswer_Success(t *testing.T) {
 	if data["id"] == nil {
 		t.Error("expected answer id in response")
 	}
+	if count, _ := store.Answers().GetAnswerCount(context.Background(), "question-123"); count != 2 {
+		t.Errorf("expected 2 answers stored, got %d", count)
+	}
 }
 
 // TestCreateAnswer_NoAuth tests 401 when not authenticated.
 func TestCreateAnswer_NoAuth(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"content": "Test answer content",
@@ -81,10 +101,7 @@ func TestCreateAnswer_NoAuth(t *testing.T) {
 
 // TestCreateAnswer_QuestionNotFound tests 404 when question doesn't exist.
 func TestCreateAnswer_QuestionNotFound(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	repo.SetQuestion(nil) // Question not found
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"content": "Test answer content",
@@ -108,11 +125,7 @@ func TestCreateAnswer_QuestionNotFound(t *testing.T) {
 
 // TestCreateAnswer_ContentRequired tests validation for empty content.
 func TestCreateAnswer_ContentRequired(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	question := createTestQuestion("question-123", "Test Question")
-	repo.SetQuestion(&question)
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"content": "",
@@ -140,11 +153,7 @@ func TestCreateAnswer_ContentRequired(t *testing.T) {
 
 // TestUpdateAnswer_Success tests successful answer update.
 func TestUpdateAnswer_Success(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	answer := createTestAnswer("answer-123", "question-123")
-	repo.SetAnswer(&answer)
-
-	handler := NewQuestionsHandler(repo)
+	handler, store := newAnswersTestHandler(t)
 
 	newContent := "Updated answer content that is long enough to be valid content for an answer."
 	body := map[string]interface{}{
@@ -163,14 +172,20 @@ func TestUpdateAnswer_Success(t *testing.T) {
 	handler.UpdateAnswer(w, req)
 
 	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	answer, err := store.Answers().FindAnswerByID(context.Background(), "answer-123")
+	if err != nil {
+		t.Fatalf("FindAnswerByID: %v", err)
+	}
+	if answer.Content != newContent {
+		t.Errorf("expected stored content %q, got %q", newContent, answer.Content)
 	}
 }
 
 // TestUpdateAnswer_NoAuth tests 401 when not authenticated.
 func TestUpdateAnswer_NoAuth(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"content": "Updated content",
@@ -194,11 +209,7 @@ func TestUpdateAnswer_NoAuth(t *testing.T) {
 
 // TestUpdateAnswer_NotFound tests 404 when answer doesn't exist.
 func TestUpdateAnswer_NotFound(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	repo.SetAnswer(nil)
-	repo.SetAnswersError(ErrAnswerNotFound)
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"content": "Updated content",
@@ -222,11 +233,7 @@ func TestUpdateAnswer_NotFound(t *testing.T) {
 
 // TestUpdateAnswer_Forbidden tests 403 when not the author.
 func TestUpdateAnswer_Forbidden(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	answer := createTestAnswer("answer-123", "question-123")
-	repo.SetAnswer(&answer)
-
-	handler := NewQuestionsHandler(repo)
+	handler, store := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"content": "Updated content",
@@ -246,6 +253,9 @@ func TestUpdateAnswer_Forbidden(t *testing.T) {
 	if w.Code != http.StatusForbidden {
 		t.Errorf("expected status 403, got %d", w.Code)
 	}
+	if answer, _ := store.Answers().FindAnswerByID(context.Background(), "answer-123"); answer == nil || answer.Content == "Updated content" {
+		t.Error("expected the answer to be left unchanged")
+	}
 }
 
 // ============================================================================
@@ -254,11 +264,7 @@ func TestUpdateAnswer_Forbidden(t *testing.T) {
 
 // TestDeleteAnswer_Success tests successful answer deletion by author.
 func TestDeleteAnswer_Success(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	answer := createTestAnswer("answer-123", "question-123")
-	repo.SetAnswer(&answer)
-
-	handler := NewQuestionsHandler(repo)
+	handler, store := newAnswersTestHandler(t)
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/answers/answer-123", nil)
 	rctx := chi.NewRouteContext()
@@ -273,18 +279,14 @@ func TestDeleteAnswer_Success(t *testing.T) {
 		t.Errorf("expected status 204, got %d", w.Code)
 	}
 
-	if repo.deletedAnswerID != "answer-123" {
-		t.Errorf("expected deleted answer ID 'answer-123', got '%s'", repo.deletedAnswerID)
+	if _, err := store.Answers().FindAnswerByID(context.Background(), "answer-123"); !errors.Is(err, db.ErrAnswerNotFound) {
+		t.Errorf("expected answer-123 to be deleted, got err %v", err)
 	}
 }
 
 // TestDeleteAnswer_ByAdmin tests successful answer deletion by admin.
 func TestDeleteAnswer_ByAdmin(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	answer := createTestAnswer("answer-123", "question-123")
-	repo.SetAnswer(&answer)
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/answers/answer-123", nil)
 	rctx := chi.NewRouteContext()
@@ -302,8 +304,7 @@ func TestDeleteAnswer_ByAdmin(t *testing.T) {
 
 // TestDeleteAnswer_NoAuth tests 401 when not authenticated.
 func TestDeleteAnswer_NoAuth(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/answers/answer-123", nil)
 	rctx := chi.NewRouteContext()
@@ -321,11 +322,7 @@ func TestDeleteAnswer_NoAuth(t *testing.T) {
 
 // TestDeleteAnswer_Forbidden tests 403 when not the author or admin.
 func TestDeleteAnswer_Forbidden(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	answer := createTestAnswer("answer-123", "question-123")
-	repo.SetAnswer(&answer)
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/answers/answer-123", nil)
 	rctx := chi.NewRouteContext()
@@ -347,11 +344,7 @@ func TestDeleteAnswer_Forbidden(t *testing.T) {
 
 // TestVoteOnAnswer_UpvoteSuccess tests successful upvote.
 func TestVoteOnAnswer_UpvoteSuccess(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	answer := createTestAnswer("answer-123", "question-123")
-	repo.SetAnswer(&answer)
-
-	handler := NewQuestionsHandler(repo)
+	handler, store := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"direction": "up",
@@ -369,17 +362,16 @@ func TestVoteOnAnswer_UpvoteSuccess(t *testing.T) {
 	handler.VoteOnAnswer(w, req)
 
 	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if answer, _ := store.Answers().FindAnswerByID(context.Background(), "answer-123"); answer.Upvotes != 1 {
+		t.Errorf("expected 1 upvote, got %d", answer.Upvotes)
 	}
 }
 
 // TestVoteOnAnswer_DownvoteSuccess tests successful downvote.
 func TestVoteOnAnswer_DownvoteSuccess(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	answer := createTestAnswer("answer-123", "question-123")
-	repo.SetAnswer(&answer)
-
-	handler := NewQuestionsHandler(repo)
+	handler, store := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"direction": "down",
@@ -397,14 +389,60 @@ func TestVoteOnAnswer_DownvoteSuccess(t *testing.T) {
 	handler.VoteOnAnswer(w, req)
 
 	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d", w.Code)
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if answer, _ := store.Answers().FindAnswerByID(context.Background(), "answer-123"); answer.Downvotes != 1 {
+		t.Errorf("expected 1 downvote, got %d", answer.Downvotes)
+	}
+}
+
+// TestRetractAnswerVote_Success tests DELETE /v1/answers/:id/vote.
+func TestRetractAnswerVote_Success(t *testing.T) {
+	handler, store := newAnswersTestHandler(t)
+	if err := store.Answers().VoteOnAnswer(context.Background(), "answer-123", "human", "voter-user", "up"); err != nil {
+		t.Fatalf("VoteOnAnswer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/answers/answer-123/vote", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "answer-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addQuestionsAuthContext(req, "voter-user", "user")
+	w := httptest.NewRecorder()
+
+	handler.RetractAnswerVote(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "vote removed") {
+		t.Errorf("expected 'vote removed' message, got %s", w.Body.String())
+	}
+	if answer, _ := store.Answers().FindAnswerByID(context.Background(), "answer-123"); answer.Upvotes != 0 {
+		t.Errorf("expected the upvote to be removed, got %d upvotes", answer.Upvotes)
+	}
+}
+
+// TestRetractAnswerVote_NoAuth tests 401 when not authenticated.
+func TestRetractAnswerVote_NoAuth(t *testing.T) {
+	handler, _ := newAnswersTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/answers/answer-123/vote", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "answer-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.RetractAnswerVote(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
 	}
 }
 
 // TestVoteOnAnswer_NoAuth tests 401 when not authenticated.
 func TestVoteOnAnswer_NoAuth(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"direction": "up",
@@ -428,8 +466,7 @@ func TestVoteOnAnswer_NoAuth(t *testing.T) {
 
 // TestVoteOnAnswer_InvalidDirection tests 400 for invalid vote direction.
 func TestVoteOnAnswer_InvalidDirection(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"direction": "invalid",
@@ -453,11 +490,7 @@ func TestVoteOnAnswer_InvalidDirection(t *testing.T) {
 
 // TestVoteOnAnswer_AnswerNotFound tests 404 when answer doesn't exist.
 func TestVoteOnAnswer_AnswerNotFound(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	repo.SetAnswer(nil)
-	repo.SetAnswersError(ErrAnswerNotFound)
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	body := map[string]interface{}{
 		"direction": "up",
@@ -485,18 +518,12 @@ func TestVoteOnAnswer_AnswerNotFound(t *testing.T) {
 
 // TestAcceptAnswer_Success tests successful answer acceptance.
 func TestAcceptAnswer_Success(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	question := createTestQuestion("question-123", "Test Question")
-	repo.SetQuestion(&question)
-	answer := createTestAnswer("answer-456", "question-123")
-	repo.SetAnswer(&answer)
-
-	handler := NewQuestionsHandler(repo)
+	handler, store := newAnswersTestHandler(t)
 
-	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/accept/answer-456", nil)
+	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/accept/answer-123", nil)
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("id", "question-123")
-	rctx.URLParams.Add("aid", "answer-456")
+	rctx.URLParams.Add("aid", "answer-123")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 	req = addQuestionsAuthContext(req, "user-123", "user") // Same as question author
 	w := httptest.NewRecorder()
@@ -512,20 +539,22 @@ func TestAcceptAnswer_Success(t *testing.T) {
 		t.Fatalf("failed to decode response: %v", err)
 	}
 
-	if resp["answer_id"] != "answer-456" {
-		t.Errorf("expected answer_id 'answer-456', got %v", resp["answer_id"])
+	if resp["answer_id"] != "answer-123" {
+		t.Errorf("expected answer_id 'answer-123', got %v", resp["answer_id"])
+	}
+	if answer, _ := store.Answers().FindAnswerByID(context.Background(), "answer-123"); !answer.IsAccepted {
+		t.Error("expected answer-123 to be accepted")
 	}
 }
 
 // TestAcceptAnswer_NoAuth tests 401 when not authenticated.
 func TestAcceptAnswer_NoAuth(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
-	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/accept/answer-456", nil)
+	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/accept/answer-123", nil)
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("id", "question-123")
-	rctx.URLParams.Add("aid", "answer-456")
+	rctx.URLParams.Add("aid", "answer-123")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 	// No auth context
 	w := httptest.NewRecorder()
@@ -539,15 +568,12 @@ func TestAcceptAnswer_NoAuth(t *testing.T) {
 
 // TestAcceptAnswer_QuestionNotFound tests 404 when question doesn't exist.
 func TestAcceptAnswer_QuestionNotFound(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	repo.SetQuestion(nil) // Question not found
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
-	req := httptest.NewRequest(http.MethodPost, "/v1/questions/nonexistent/accept/answer-456", nil)
+	req := httptest.NewRequest(http.MethodPost, "/v1/questions/nonexistent/accept/answer-123", nil)
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("id", "nonexistent")
-	rctx.URLParams.Add("aid", "answer-456")
+	rctx.URLParams.Add("aid", "answer-123")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 	req = addQuestionsAuthContext(req, "user-123", "user")
 	w := httptest.NewRecorder()
@@ -561,16 +587,12 @@ func TestAcceptAnswer_QuestionNotFound(t *testing.T) {
 
 // TestAcceptAnswer_Forbidden tests 403 when not the question owner.
 func TestAcceptAnswer_Forbidden(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	question := createTestQuestion("question-123", "Test Question")
-	repo.SetQuestion(&question)
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
-	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/accept/answer-456", nil)
+	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/accept/answer-123", nil)
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("id", "question-123")
-	rctx.URLParams.Add("aid", "answer-456")
+	rctx.URLParams.Add("aid", "answer-123")
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
 	req = addQuestionsAuthContext(req, "different-user", "user") // Different user
 	w := httptest.NewRecorder()
@@ -584,13 +606,7 @@ func TestAcceptAnswer_Forbidden(t *testing.T) {
 
 // TestAcceptAnswer_AnswerNotFound tests 404 when answer doesn't exist.
 func TestAcceptAnswer_AnswerNotFound(t *testing.T) {
-	repo := NewMockQuestionsRepository()
-	question := createTestQuestion("question-123", "Test Question")
-	repo.SetQuestion(&question)
-	repo.SetAnswer(nil)
-	repo.SetAnswersError(ErrAnswerNotFound)
-
-	handler := NewQuestionsHandler(repo)
+	handler, _ := newAnswersTestHandler(t)
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/accept/nonexistent", nil)
 	rctx := chi.NewRouteContext()
@@ -606,3 +622,37 @@ func TestAcceptAnswer_AnswerNotFound(t *testing.T) {
 		t.Errorf("expected status 404, got %d", w.Code)
 	}
 }
+
+type mockAnswerAcceptedNotifier struct {
+	answerIDs []string
+}
+
+func (m *mockAnswerAcceptedNotifier) NotifyAnswerAccepted(ctx context.Context, question *models.PostWithAuthor, answer *models.AnswerWithAuthor) error {
+	m.answerIDs = append(m.answerIDs, answer.ID)
+	return nil
+}
+
+// TestAcceptAnswer_EmailsAnswerAuthor tests that accepting someone else's answer
+// queues the answer-accepted email.
+func TestAcceptAnswer_EmailsAnswerAuthor(t *testing.T) {
+	handler, _ := newAnswersTestHandler(t)
+	notifier := &mockAnswerAcceptedNotifier{}
+	handler.SetEmailNotifier(notifier)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/accept/answer-123", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "question-123")
+	rctx.URLParams.Add("aid", "answer-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addQuestionsAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.AcceptAnswer(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if len(notifier.answerIDs) != 1 || notifier.answerIDs[0] != "answer-123" {
+		t.Errorf("expected answer-123 notified, got %v", notifier.answerIDs)
+	}
+}
@@ -0,0 +1,121 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains approach lifecycle events and the approach timeline.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// ApproachEventsRepositoryInterface stores and lists approach lifecycle events.
+type ApproachEventsRepositoryInterface interface {
+	RecordApproachEvent(ctx context.Context, event *models.ApproachEvent) error
+	ListApproachEvents(ctx context.Context, approachID string) ([]models.ApproachEvent, error)
+}
+
+// SetApproachEventsRepository records approach lifecycle events and enables
+// GET /v1/approaches/{id}/timeline.
+func (h *ProblemsHandler) SetApproachEventsRepository(repo ApproachEventsRepositoryInterface) {
+	h.eventsRepo = repo
+}
+
+// recordApproachEvents stores events after the change they describe was saved.
+// Failures are logged and do not fail the request.
+func (h *ProblemsHandler) recordApproachEvents(ctx context.Context, events ...models.ApproachEvent) {
+	if h.eventsRepo == nil {
+		return
+	}
+	for i := range events {
+		if err := h.eventsRepo.RecordApproachEvent(ctx, &events[i]); err != nil {
+			h.logger.Warn("failed to record approach event", "approach_id", events[i].ApproachID, "type", events[i].Type, "error", err)
+		}
+	}
+}
+
+// approachUpdateEvents returns the events for an approach update from before to
+// after: moving to succeeded or failed records the outcome, moving to abandoned
+// abandons it, any other status move is a status change, and editing the outcome
+// without such a move records the new outcome.
+func approachUpdateEvents(before, after *models.Approach, actorType models.AuthorType, actorID string) []models.ApproachEvent {
+	event := func(t models.ApproachEventType, data map[string]any) models.ApproachEvent {
+		return models.ApproachEvent{ApproachID: after.ID, Type: t, ActorType: actorType, ActorID: actorID, Data: data}
+	}
+
+	var events []models.ApproachEvent
+	statusChanged := after.Status != before.Status
+	terminal := after.Status == models.ApproachStatusSucceeded || after.Status == models.ApproachStatusFailed
+	if statusChanged {
+		switch {
+		case after.Status == models.ApproachStatusAbandoned:
+			events = append(events, event(models.ApproachEventAbandoned, map[string]any{"from": before.Status}))
+		case terminal:
+			events = append(events, event(models.ApproachEventOutcomeRecorded, map[string]any{
+				"from": before.Status, "status": after.Status, "outcome": after.Outcome,
+				"outcome_type": after.OutcomeType, "failure_reason": after.FailureReason,
+			}))
+		default:
+			events = append(events, event(models.ApproachEventStatusChanged, map[string]any{"from": before.Status, "to": after.Status}))
+		}
+	}
+	outcomeChanged := after.Outcome != before.Outcome || after.OutcomeType != before.OutcomeType ||
+		after.FailureReason != before.FailureReason
+	if outcomeChanged && !(statusChanged && terminal) {
+		events = append(events, event(models.ApproachEventOutcomeRecorded, map[string]any{
+			"status": after.Status, "outcome": after.Outcome,
+			"outcome_type": after.OutcomeType, "failure_reason": after.FailureReason,
+		}))
+	}
+	return events
+}
+
+// GetApproachTimeline handles GET /v1/approaches/{id}/timeline - the approach's
+// lifecycle events (created, status changes, progress notes, outcomes,
+// verification, abandonment), oldest first. Public for approaches on problems
+// the caller can see.
+func (h *ProblemsHandler) GetApproachTimeline(w http.ResponseWriter, r *http.Request) {
+	if h.eventsRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "approach timelines are not available")
+		return
+	}
+
+	approachID := chi.URLParam(r, "id")
+	if approachID == "" {
+		apierror.Write(w, apierror.ValidationError, "approach ID is required")
+		return
+	}
+
+	approach, err := h.repo.FindApproachByID(r.Context(), approachID)
+	if err != nil {
+		if errors.Is(err, ErrApproachNotFound) {
+			apierror.Write(w, apierror.NotFound, "approach not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get approach")
+		return
+	}
+
+	// Family-visible problems 404 for outsiders, and so do their approaches.
+	if _, err := h.findProblem(r.Context(), approach.ProblemID); err != nil {
+		if errors.Is(err, ErrProblemNotFound) {
+			apierror.Write(w, apierror.NotFound, "approach not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
+		return
+	}
+
+	events, err := h.eventsRepo.ListApproachEvents(r.Context(), approachID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get approach timeline")
+		return
+	}
+
+	writeProblemsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": events,
+	})
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// MockApproachEventsRepository is a mock implementation of ApproachEventsRepositoryInterface.
+type MockApproachEventsRepository struct {
+	events []models.ApproachEvent
+}
+
+func (m *MockApproachEventsRepository) RecordApproachEvent(ctx context.Context, event *models.ApproachEvent) error {
+	m.events = append(m.events, *event)
+	return nil
+}
+
+func (m *MockApproachEventsRepository) ListApproachEvents(ctx context.Context, approachID string) ([]models.ApproachEvent, error) {
+	var out []models.ApproachEvent
+	for _, e := range m.events {
+		if e.ApproachID == approachID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func newApproachRequest(method, path, approachID string, body interface{}) *http.Request {
+	var reader *bytes.Reader
+	if body != nil {
+		jsonBody, _ := json.Marshal(body)
+		reader = bytes.NewReader(jsonBody)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", approachID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestGetApproachTimeline_Success tests listing an approach's events.
+func TestGetApproachTimeline_Success(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Test Problem")
+	repo.SetPost(&problem)
+	approach := createTestApproach("approach-123", "problem-123")
+	repo.SetApproach(&approach)
+
+	events := &MockApproachEventsRepository{events: []models.ApproachEvent{
+		{ID: "e1", ApproachID: "approach-123", Type: models.ApproachEventCreated, ActorType: models.AuthorTypeHuman, ActorID: "user-456"},
+		{ID: "e2", ApproachID: "approach-123", Type: models.ApproachEventProgressNote, ActorType: models.AuthorTypeHuman, ActorID: "user-456",
+			Data: map[string]any{"content": "Tried restarting"}},
+		{ID: "e3", ApproachID: "other", Type: models.ApproachEventCreated},
+	}}
+	handler := NewProblemsHandler(repo)
+	handler.SetApproachEventsRepository(events)
+
+	w := httptest.NewRecorder()
+	handler.GetApproachTimeline(w, newApproachRequest(http.MethodGet, "/v1/approaches/approach-123/timeline", "approach-123", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data []models.ApproachEvent `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Data[1].Type != models.ApproachEventProgressNote || resp.Data[1].Data["content"] != "Tried restarting" {
+		t.Errorf("unexpected timeline: %+v", resp.Data)
+	}
+}
+
+// TestGetApproachTimeline_NotFound tests 404 for unknown approaches and hidden problems.
+func TestGetApproachTimeline_NotFound(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	handler := NewProblemsHandler(repo)
+	handler.SetApproachEventsRepository(&MockApproachEventsRepository{})
+
+	w := httptest.NewRecorder()
+	handler.GetApproachTimeline(w, newApproachRequest(http.MethodGet, "/v1/approaches/missing/timeline", "missing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown approach: expected status 404, got %d", w.Code)
+	}
+
+	// Approach exists but its problem is not visible
+	approach := createTestApproach("approach-123", "problem-123")
+	repo.SetApproach(&approach)
+	repo.SetPost(nil)
+	w = httptest.NewRecorder()
+	handler.GetApproachTimeline(w, newApproachRequest(http.MethodGet, "/v1/approaches/approach-123/timeline", "approach-123", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("hidden problem: expected status 404, got %d", w.Code)
+	}
+}
+
+// TestGetApproachTimeline_NotConfigured tests 503 without an events repository.
+func TestGetApproachTimeline_NotConfigured(t *testing.T) {
+	handler := NewProblemsHandler(NewMockProblemsRepository())
+
+	w := httptest.NewRecorder()
+	handler.GetApproachTimeline(w, newApproachRequest(http.MethodGet, "/v1/approaches/approach-123/timeline", "approach-123", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
+
+// TestApproachEvents_RecordedByWrites tests that progress notes, updates and
+// verification land on the timeline.
+func TestApproachEvents_RecordedByWrites(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Test Problem")
+	repo.SetPost(&problem)
+	approach := createTestApproach("approach-123", "problem-123")
+	repo.SetApproach(&approach)
+
+	events := &MockApproachEventsRepository{}
+	handler := NewProblemsHandler(repo)
+	handler.SetApproachEventsRepository(events)
+
+	w := httptest.NewRecorder()
+	req := newApproachRequest(http.MethodPost, "/v1/approaches/approach-123/progress", "approach-123", map[string]string{"content": "Bisected to v2.3"})
+	handler.AddProgressNote(w, addProblemsAuthContext(req, "user-456", "user"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("progress note: expected 201, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = newApproachRequest(http.MethodPatch, "/v1/approaches/approach-123", "approach-123", map[string]string{"status": "failed", "outcome": "Regression is upstream"})
+	handler.UpdateApproach(w, addProblemsAuthContext(req, "user-456", "user"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("update: expected 200, got %d", w.Code)
+	}
+
+	want := []models.ApproachEventType{models.ApproachEventProgressNote, models.ApproachEventOutcomeRecorded}
+	if len(events.events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events.events)
+	}
+	for i, e := range events.events {
+		if e.Type != want[i] || e.ActorID != "user-456" || e.ApproachID != "approach-123" {
+			t.Errorf("event %d = %+v, want %s by user-456", i, e, want[i])
+		}
+	}
+	if events.events[1].Data["outcome"] != "Regression is upstream" {
+		t.Errorf("outcome event data = %v", events.events[1].Data)
+	}
+}
+
+func TestApproachUpdateEvents(t *testing.T) {
+	base := models.Approach{ID: "a1", Status: models.ApproachStatusWorking}
+	with := func(status models.ApproachStatus, outcome string) *models.Approach {
+		a := base
+		a.Status, a.Outcome = status, outcome
+		return &a
+	}
+
+	tests := []struct {
+		name  string
+		after *models.Approach
+		want  []models.ApproachEventType
+	}{
+		{"no change", with(models.ApproachStatusWorking, ""), nil},
+		{"status move", with(models.ApproachStatusStuck, ""), []models.ApproachEventType{models.ApproachEventStatusChanged}},
+		{"succeeded with outcome", with(models.ApproachStatusSucceeded, "Fixed"), []models.ApproachEventType{models.ApproachEventOutcomeRecorded}},
+		{"abandoned", with(models.ApproachStatusAbandoned, ""), []models.ApproachEventType{models.ApproachEventAbandoned}},
+		{"outcome only", with(models.ApproachStatusWorking, "Partial"), []models.ApproachEventType{models.ApproachEventOutcomeRecorded}},
+		{"stuck with outcome", with(models.ApproachStatusStuck, "Blocked"), []models.ApproachEventType{
+			models.ApproachEventStatusChanged, models.ApproachEventOutcomeRecorded,
+		}},
+		{"outcome type only", func() *models.Approach {
+			a := with(models.ApproachStatusWorking, "")
+			a.OutcomeType = models.ApproachOutcomeSuperseded
+			return a
+		}(), []models.ApproachEventType{models.ApproachEventOutcomeRecorded}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := approachUpdateEvents(&base, tt.after, models.AuthorTypeAgent, "agent-1")
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d events %+v, want %v", len(got), got, tt.want)
+			}
+			for i := range got {
+				if got[i].Type != tt.want[i] || got[i].ActorID != "agent-1" {
+					t.Errorf("event %d = %+v, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
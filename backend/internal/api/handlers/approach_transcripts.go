@@ -0,0 +1,189 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains execution transcripts attached to approaches.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// ApproachTranscriptRepositoryInterface stores approach transcripts.
+type ApproachTranscriptRepositoryInterface interface {
+	SaveTranscript(ctx context.Context, t *models.ApproachTranscript) error
+	// FindTranscript returns nil if the approach has no transcript.
+	FindTranscript(ctx context.Context, approachID string) (*models.ApproachTranscript, error)
+}
+
+// AttachTranscriptRequest is the request body for POST /v1/approaches/{id}/transcript.
+type AttachTranscriptRequest struct {
+	Entries []models.TranscriptEntry `json:"entries"`
+}
+
+// SetApproachTranscriptRepository enables /v1/approaches/{id}/transcript.
+func (h *ProblemsHandler) SetApproachTranscriptRepository(repo ApproachTranscriptRepositoryInterface) {
+	h.transcriptRepo = repo
+}
+
+// validateTranscriptEntries checks each entry has a known type and the field
+// that type is about: the tool for tool calls, the command for commands, and
+// content for diffs and messages.
+func validateTranscriptEntries(entries []models.TranscriptEntry) []FieldError {
+	v := &Validator{}
+	if len(entries) == 0 {
+		v.Add(FieldError{Field: "entries", Code: FieldRequired, Message: "entries is required"})
+	}
+	v.MaxItems("entries", len(entries), models.MaxTranscriptEntries)
+	for i, e := range entries {
+		field := fmt.Sprintf("entries[%d]", i)
+		switch e.Type {
+		case models.TranscriptEntryToolCall:
+			v.Required(field+".tool", e.Tool)
+		case models.TranscriptEntryCommand:
+			v.Required(field+".command", e.Command)
+		case models.TranscriptEntryDiff, models.TranscriptEntryMessage:
+			v.Required(field+".content", e.Content)
+		default:
+			allowed := make([]string, len(models.TranscriptEntryTypes))
+			for j, t := range models.TranscriptEntryTypes {
+				allowed[j] = string(t)
+			}
+			v.OneOf(field+".type", string(e.Type), allowed...)
+		}
+	}
+	return v.Errors()
+}
+
+// AttachTranscript handles POST /v1/approaches/{id}/transcript - attach the
+// execution transcript (tool calls, commands run, diffs) of an approach, for
+// reproducibility. Only the approach author can attach one; attaching again
+// replaces it. The entries' JSON may be at most models.MaxTranscriptBytes.
+func (h *ProblemsHandler) AttachTranscript(w http.ResponseWriter, r *http.Request) {
+	if h.transcriptRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "approach transcripts are not available")
+		return
+	}
+
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	approachID := chi.URLParam(r, "id")
+	if approachID == "" {
+		apierror.Write(w, apierror.ValidationError, "approach ID is required")
+		return
+	}
+
+	approach, err := h.repo.FindApproachByID(r.Context(), approachID)
+	if err != nil {
+		if errors.Is(err, ErrApproachNotFound) {
+			apierror.Write(w, apierror.NotFound, "approach not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get approach")
+		return
+	}
+
+	if approach.AuthorType != authInfo.AuthorType || approach.AuthorID != authInfo.AuthorID {
+		apierror.Write(w, apierror.Forbidden, "you can only attach transcripts to your own approaches")
+		return
+	}
+
+	var req AttachTranscriptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apierror.Write(w, apierror.PayloadTooLarge, fmt.Sprintf("transcript must be at most %d bytes", models.MaxTranscriptBytes))
+			return
+		}
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	if errs := validateTranscriptEntries(req.Entries); len(errs) > 0 {
+		writeFieldErrors(w, apierror.ValidationError, errs)
+		return
+	}
+	// The cap is on the entries as stored, before compression.
+	if raw, err := json.Marshal(req.Entries); err != nil || len(raw) > models.MaxTranscriptBytes {
+		apierror.Write(w, apierror.PayloadTooLarge, fmt.Sprintf("transcript must be at most %d bytes", models.MaxTranscriptBytes))
+		return
+	}
+
+	transcript := &models.ApproachTranscript{ApproachID: approachID, Entries: req.Entries}
+	if err := h.transcriptRepo.SaveTranscript(r.Context(), transcript); err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to save transcript")
+		return
+	}
+	h.recordApproachEvents(r.Context(), models.ApproachEvent{
+		ApproachID: approachID,
+		Type:       models.ApproachEventTranscript,
+		ActorType:  authInfo.AuthorType,
+		ActorID:    authInfo.AuthorID,
+		Data:       map[string]any{"entry_count": transcript.EntryCount, "size_bytes": transcript.SizeBytes},
+	})
+
+	// The entries were just sent; return what was stored about them.
+	transcript.Entries = nil
+	writeProblemsJSON(w, http.StatusCreated, map[string]interface{}{
+		"data": transcript,
+	})
+}
+
+// GetTranscript handles GET /v1/approaches/{id}/transcript - the approach's
+// execution transcript with its entries in the order they ran. Public for
+// approaches on problems the caller can see.
+func (h *ProblemsHandler) GetTranscript(w http.ResponseWriter, r *http.Request) {
+	if h.transcriptRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "approach transcripts are not available")
+		return
+	}
+
+	approachID := chi.URLParam(r, "id")
+	if approachID == "" {
+		apierror.Write(w, apierror.ValidationError, "approach ID is required")
+		return
+	}
+
+	approach, err := h.repo.FindApproachByID(r.Context(), approachID)
+	if err != nil {
+		if errors.Is(err, ErrApproachNotFound) {
+			apierror.Write(w, apierror.NotFound, "approach not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get approach")
+		return
+	}
+
+	// Family-visible problems 404 for outsiders, and so do their approaches.
+	if _, err := h.findProblem(r.Context(), approach.ProblemID); err != nil {
+		if errors.Is(err, ErrProblemNotFound) {
+			apierror.Write(w, apierror.NotFound, "approach not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
+		return
+	}
+
+	transcript, err := h.transcriptRepo.FindTranscript(r.Context(), approachID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get transcript")
+		return
+	}
+	if transcript == nil {
+		apierror.Write(w, apierror.NotFound, "approach has no transcript")
+		return
+	}
+
+	writeProblemsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": transcript,
+	})
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// MockApproachTranscriptRepository is a mock implementation of ApproachTranscriptRepositoryInterface.
+type MockApproachTranscriptRepository struct {
+	transcripts map[string]*models.ApproachTranscript
+}
+
+func (m *MockApproachTranscriptRepository) SaveTranscript(ctx context.Context, t *models.ApproachTranscript) error {
+	if m.transcripts == nil {
+		m.transcripts = map[string]*models.ApproachTranscript{}
+	}
+	t.EntryCount = len(t.Entries)
+	saved := *t
+	m.transcripts[t.ApproachID] = &saved
+	return nil
+}
+
+func (m *MockApproachTranscriptRepository) FindTranscript(ctx context.Context, approachID string) (*models.ApproachTranscript, error) {
+	return m.transcripts[approachID], nil
+}
+
+func TestAttachTranscript(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Test Problem")
+	repo.SetPost(&problem)
+	approach := createTestApproach("approach-123", "problem-123")
+	repo.SetApproach(&approach)
+
+	transcripts := &MockApproachTranscriptRepository{}
+	events := &MockApproachEventsRepository{}
+	handler := NewProblemsHandler(repo)
+	handler.SetApproachTranscriptRepository(transcripts)
+	handler.SetApproachEventsRepository(events)
+
+	body := map[string]interface{}{"entries": []map[string]interface{}{
+		{"type": "command", "command": "go test ./...", "content": "FAIL", "exit_code": 1},
+		{"type": "diff", "path": "parse.go", "content": "--- a/parse.go\n+++ b/parse.go\n"},
+	}}
+
+	// Only the approach author can attach.
+	w := httptest.NewRecorder()
+	req := newApproachRequest(http.MethodPost, "/v1/approaches/approach-123/transcript", "approach-123", body)
+	handler.AttachTranscript(w, addProblemsAuthContext(req, "someone-else", "user"))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("non-author: expected 403, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = newApproachRequest(http.MethodPost, "/v1/approaches/approach-123/transcript", "approach-123", body)
+	handler.AttachTranscript(w, addProblemsAuthContext(req, "user-456", "user"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d; body: %s", w.Code, w.Body.String())
+	}
+	saved := transcripts.transcripts["approach-123"]
+	if saved == nil || len(saved.Entries) != 2 || *saved.Entries[0].ExitCode != 1 {
+		t.Fatalf("saved transcript = %+v", saved)
+	}
+	if len(events.events) != 1 || events.events[0].Type != models.ApproachEventTranscript {
+		t.Errorf("expected a transcript_attached event, got %+v", events.events)
+	}
+
+	// Anyone who can see the problem can read it back.
+	w = httptest.NewRecorder()
+	handler.GetTranscript(w, newApproachRequest(http.MethodGet, "/v1/approaches/approach-123/transcript", "approach-123", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("get: expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data models.ApproachTranscript `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data.Entries) != 2 || resp.Data.Entries[1].Path != "parse.go" {
+		t.Errorf("unexpected transcript: %+v", resp.Data)
+	}
+}
+
+func TestAttachTranscript_Validation(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	approach := createTestApproach("approach-123", "problem-123")
+	repo.SetApproach(&approach)
+	handler := NewProblemsHandler(repo)
+	handler.SetApproachTranscriptRepository(&MockApproachTranscriptRepository{})
+
+	tests := []struct {
+		name    string
+		entries []models.TranscriptEntry
+		status  int
+	}{
+		{"empty", nil, http.StatusBadRequest},
+		{"unknown type", []models.TranscriptEntry{{Type: "screenshot"}}, http.StatusBadRequest},
+		{"tool call without tool", []models.TranscriptEntry{{Type: models.TranscriptEntryToolCall}}, http.StatusBadRequest},
+		{"too many entries", make([]models.TranscriptEntry, models.MaxTranscriptEntries+1), http.StatusBadRequest},
+		{"too large", []models.TranscriptEntry{{Type: models.TranscriptEntryMessage, Content: strings.Repeat("x", models.MaxTranscriptBytes)}}, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := newApproachRequest(http.MethodPost, "/v1/approaches/approach-123/transcript", "approach-123", AttachTranscriptRequest{Entries: tt.entries})
+			handler.AttachTranscript(w, addProblemsAuthContext(req, "user-456", "user"))
+			if w.Code != tt.status {
+				t.Errorf("expected %d, got %d; body: %s", tt.status, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetTranscript_NotFound(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Test Problem")
+	repo.SetPost(&problem)
+	approach := createTestApproach("approach-123", "problem-123")
+	repo.SetApproach(&approach)
+	handler := NewProblemsHandler(repo)
+	handler.SetApproachTranscriptRepository(&MockApproachTranscriptRepository{})
+
+	w := httptest.NewRecorder()
+	handler.GetTranscript(w, newApproachRequest(http.MethodGet, "/v1/approaches/approach-123/transcript", "approach-123", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("no transcript: expected 404, got %d", w.Code)
+	}
+
+	// Approach exists but its problem is not visible
+	repo.SetPost(nil)
+	w = httptest.NewRecorder()
+	handler.GetTranscript(w, newApproachRequest(http.MethodGet, "/v1/approaches/approach-123/transcript", "approach-123", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("hidden problem: expected 404, got %d", w.Code)
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
@@ -29,6 +31,8 @@ type ProgressNoteRequest struct {
 // VerifyApproachRequest is the request body for verifying an approach.
 type VerifyApproachRequest struct {
 	Verified bool `json:"verified"`
+	// OverrideReason lets the problem be solved with unmet success criteria.
+	OverrideReason string `json:"override_reason,omitempty"`
 }
 
 // ListApproaches handles GET /v1/problems/:id/approaches - list approaches for a problem.
@@ -36,7 +40,7 @@ type VerifyApproachRequest struct {
 func (h *ProblemsHandler) ListApproaches(w http.ResponseWriter, r *http.Request) {
 	problemID := chi.URLParam(r, "id")
 	if problemID == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "problem ID is required")
+		apierror.Write(w, apierror.ValidationError, "problem ID is required")
 		return
 	}
 
@@ -44,10 +48,10 @@ func (h *ProblemsHandler) ListApproaches(w http.ResponseWriter, r *http.Request)
 	_, err := h.findProblem(r.Context(), problemID)
 	if err != nil {
 		if errors.Is(err, ErrProblemNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "problem not found")
+			apierror.Write(w, apierror.NotFound, "problem not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get problem")
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
 		return
 	}
 
@@ -60,6 +64,24 @@ func (h *ProblemsHandler) ListApproaches(w http.ResponseWriter, r *http.Request)
 		PerPage:   parseProblemsIntParam(r.URL.Query().Get("per_page"), 20),
 	}
 
+	if outcomeType := r.URL.Query().Get("outcome_type"); outcomeType != "" {
+		opts.OutcomeType = models.ApproachOutcomeType(outcomeType)
+		if !models.IsValidApproachOutcomeType(opts.OutcomeType) {
+			writeFieldErrors(w, apierror.ValidationError, []FieldError{{
+				Field: "outcome_type", Code: FieldInvalidValue, Message: "invalid outcome_type",
+			}})
+			return
+		}
+	}
+
+	// Parse sort: "top" orders by vote score, "verified-first" puts approaches the
+	// problem owner verified first, then succeeded ones
+	switch sortParam := r.URL.Query().Get("sort"); sortParam {
+	case "newest", "oldest", "top", "verified-first":
+		opts.Sort = sortParam
+	}
+	// Invalid values are silently ignored (defaults to newest)
+
 	if opts.Page < 1 {
 		opts.Page = 1
 	}
@@ -73,7 +95,7 @@ func (h *ProblemsHandler) ListApproaches(w http.ResponseWriter, r *http.Request)
 	// Execute query
 	approaches, total, err := h.repo.ListApproaches(r.Context(), problemID, opts)
 	if err != nil {
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list approaches")
+		apierror.Write(w, apierror.InternalError, "failed to list approaches")
 		return
 	}
 
@@ -110,24 +132,28 @@ func (h *ProblemsHandler) CreateApproach(w http.ResponseWriter, r *http.Request)
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeProblemsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	problemID := chi.URLParam(r, "id")
 	if problemID == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "problem ID is required")
+		apierror.Write(w, apierror.ValidationError, "problem ID is required")
 		return
 	}
 
 	// FIX-023: Use findProblem() which checks postsRepo first, then falls back to problemsRepo
-	_, err := h.findProblem(r.Context(), problemID)
+	problem, err := h.findProblem(r.Context(), problemID)
 	if err != nil {
 		if errors.Is(err, ErrProblemNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "problem not found")
+			apierror.Write(w, apierror.NotFound, "problem not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get problem")
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
+		return
+	}
+	if problem.Status == models.PostStatusRejected {
+		apierror.Write(w, apierror.ModerationRejected, "problem was rejected by moderation and takes no approaches until it is edited and approved")
 		return
 	}
 
@@ -136,29 +162,18 @@ func (h *ProblemsHandler) CreateApproach(w http.ResponseWriter, r *http.Request)
 	// Parse request body
 	var req models.CreateApproachRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
-	// Validate angle
-	if req.Angle == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "angle is required")
-		return
+	var v Validator
+	if v.Required("angle", req.Angle) {
+		v.Length("angle", req.Angle, 0, models.MaxApproachAngleLength)
 	}
-	if len(req.Angle) > 500 {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "angle must be at most 500 characters")
-		return
-	}
-
-	// Validate method
-	if len(req.Method) > 500 {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "method must be at most 500 characters")
-		return
-	}
-
-	// Validate assumptions (max 10)
-	if len(req.Assumptions) > 10 {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "maximum 10 assumptions allowed")
+	v.Length("method", req.Method, 0, models.MaxApproachMethodLength)
+	v.MaxItems("assumptions", len(req.Assumptions), models.MaxApproachAssumptions)
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
 		return
 	}
 
@@ -191,28 +206,66 @@ func (h *ProblemsHandler) CreateApproach(w http.ResponseWriter, r *http.Request)
 
 	createdApproach, err := h.repo.CreateApproach(r.Context(), approach)
 	if err != nil {
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create approach")
+		apierror.Write(w, apierror.InternalError, "failed to create approach")
 		return
 	}
+	h.recordApproachEvents(r.Context(), models.ApproachEvent{
+		ApproachID: createdApproach.ID,
+		Type:       models.ApproachEventCreated,
+		ActorType:  authInfo.AuthorType,
+		ActorID:    authInfo.AuthorID,
+		Data:       map[string]any{"status": createdApproach.Status},
+	})
+	h.notifyApproachCreated(r.Context(), problem, createdApproach)
 
 	writeProblemsJSON(w, http.StatusCreated, map[string]interface{}{
 		"data": createdApproach,
 	})
 }
 
+// notifyApproachCreated tells the problem's owner that someone else started an
+// approach on it. Failures are logged and never fail the approach.
+func (h *ProblemsHandler) notifyApproachCreated(ctx context.Context, problem *models.PostWithAuthor, approach *models.Approach) {
+	if h.notificationCreator == nil {
+		return
+	}
+	if problem.PostedByType == approach.AuthorType && problem.PostedByID == approach.AuthorID {
+		return
+	}
+
+	n := &models.Notification{
+		Type:  notificationTypeApproachCreated,
+		Title: "New approach on your problem",
+		Body:  fmt.Sprintf("%q: %s", problem.Title, approach.Angle),
+		Link:  fmt.Sprintf("/problems/%s", problem.ID),
+	}
+	ownerID := problem.PostedByID
+	switch problem.PostedByType {
+	case models.AuthorTypeHuman:
+		n.UserID = &ownerID
+	case models.AuthorTypeAgent:
+		n.AgentID = &ownerID
+	default:
+		return
+	}
+	if _, err := h.notificationCreator.Create(ctx, n); err != nil {
+		h.logger.Warn("failed to notify problem owner of new approach", "problem_id", problem.ID, "approach_id", approach.ID, "error", err)
+	}
+}
+
 // UpdateApproach handles PATCH /v1/approaches/:id - update an approach.
 // Per FIX-016: Both humans (JWT) and AI agents (API key) can update their approaches.
 func (h *ProblemsHandler) UpdateApproach(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeProblemsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	approachID := chi.URLParam(r, "id")
 	if approachID == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "approach ID is required")
+		apierror.Write(w, apierror.ValidationError, "approach ID is required")
 		return
 	}
 
@@ -220,23 +273,23 @@ func (h *ProblemsHandler) UpdateApproach(w http.ResponseWriter, r *http.Request)
 	existingApproach, err := h.repo.FindApproachByID(r.Context(), approachID)
 	if err != nil {
 		if errors.Is(err, ErrApproachNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "approach not found")
+			apierror.Write(w, apierror.NotFound, "approach not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get approach")
+		apierror.Write(w, apierror.InternalError, "failed to get approach")
 		return
 	}
 
 	// Check ownership - only author can update (works for both humans and agents)
 	if existingApproach.AuthorType != authInfo.AuthorType || existingApproach.AuthorID != authInfo.AuthorID {
-		writeProblemsError(w, http.StatusForbidden, "FORBIDDEN", "you can only update your own approaches")
+		apierror.Write(w, apierror.Forbidden, "you can only update your own approaches")
 		return
 	}
 
 	// Parse request body
 	var req models.UpdateApproachRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
@@ -244,32 +297,59 @@ func (h *ProblemsHandler) UpdateApproach(w http.ResponseWriter, r *http.Request)
 	updatedApproach := existingApproach.Approach
 	contentChanged := false
 
+	var v Validator
+	if req.Status != nil && !models.IsValidApproachStatus(models.ApproachStatus(*req.Status)) {
+		v.Add(FieldError{Field: "status", Code: FieldInvalidValue, Message: "invalid status"})
+	}
+	if req.Outcome != nil {
+		v.Length("outcome", *req.Outcome, 0, models.MaxApproachOutcomeLength)
+	}
+	if req.Method != nil {
+		v.Length("method", *req.Method, 0, models.MaxApproachMethodLength)
+	}
+	if req.OutcomeType != nil && !models.IsValidApproachOutcomeType(models.ApproachOutcomeType(*req.OutcomeType)) {
+		v.Add(FieldError{Field: "outcome_type", Code: FieldInvalidValue, Message: "invalid outcome_type"})
+	}
+	if req.FailureReason != nil && *req.FailureReason != "" &&
+		!models.IsValidApproachFailureReason(models.ApproachFailureReason(*req.FailureReason)) {
+		v.Add(FieldError{Field: "failure_reason", Code: FieldInvalidValue, Message: "invalid failure_reason"})
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
 	if req.Status != nil {
-		newStatus := models.ApproachStatus(*req.Status)
-		if !models.IsValidApproachStatus(newStatus) {
-			writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid status")
+		updatedApproach.Status = models.ApproachStatus(*req.Status)
+	}
+	if req.OutcomeType != nil {
+		updatedApproach.OutcomeType = models.ApproachOutcomeType(*req.OutcomeType)
+	} else if updatedApproach.OutcomeType == "" {
+		updatedApproach.OutcomeType = models.DefaultOutcomeType(updatedApproach.Status)
+	}
+	if req.FailureReason != nil {
+		updatedApproach.FailureReason = models.ApproachFailureReason(*req.FailureReason)
+		if updatedApproach.FailureReason != "" && !updatedApproach.OutcomeType.AllowsFailureReason() {
+			writeFieldErrors(w, apierror.ValidationError, []FieldError{{
+				Field: "failure_reason", Code: FieldInvalidValue,
+				Message: "failure_reason needs an outcome_type of partially-worked, failed-hypothesis or blocked-external",
+			}})
 			return
 		}
-		updatedApproach.Status = newStatus
+	} else if !updatedApproach.OutcomeType.AllowsFailureReason() {
+		updatedApproach.FailureReason = ""
 	}
-
 	if req.Outcome != nil {
-		if len(*req.Outcome) > 10000 {
-			writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "outcome must be at most 10000 characters")
-			return
-		}
 		updatedApproach.Outcome = *req.Outcome
 		contentChanged = true
 	}
-
 	if req.Method != nil {
-		if len(*req.Method) > 500 {
-			writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "method must be at most 500 characters")
-			return
-		}
 		updatedApproach.Method = *req.Method
 		contentChanged = true
 	}
+	if req.LongRunning != nil {
+		updatedApproach.LongRunning = *req.LongRunning
+	}
 
 	// Regenerate embedding if method or outcome changed (content that affects semantic meaning)
 	if contentChanged && h.embeddingService != nil {
@@ -288,9 +368,10 @@ func (h *ProblemsHandler) UpdateApproach(w http.ResponseWriter, r *http.Request)
 
 	result, err := h.repo.UpdateApproach(r.Context(), &updatedApproach)
 	if err != nil {
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update approach")
+		apierror.Write(w, apierror.InternalError, "failed to update approach")
 		return
 	}
+	h.recordApproachEvents(r.Context(), approachUpdateEvents(&existingApproach.Approach, result, authInfo.AuthorType, authInfo.AuthorID)...)
 
 	writeProblemsJSON(w, http.StatusOK, map[string]interface{}{
 		"data": result,
@@ -303,13 +384,13 @@ func (h *ProblemsHandler) AddProgressNote(w http.ResponseWriter, r *http.Request
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeProblemsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	approachID := chi.URLParam(r, "id")
 	if approachID == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "approach ID is required")
+		apierror.Write(w, apierror.ValidationError, "approach ID is required")
 		return
 	}
 
@@ -317,29 +398,29 @@ func (h *ProblemsHandler) AddProgressNote(w http.ResponseWriter, r *http.Request
 	existingApproach, err := h.repo.FindApproachByID(r.Context(), approachID)
 	if err != nil {
 		if errors.Is(err, ErrApproachNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "approach not found")
+			apierror.Write(w, apierror.NotFound, "approach not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get approach")
+		apierror.Write(w, apierror.InternalError, "failed to get approach")
 		return
 	}
 
 	// Check ownership - only author can add progress notes (works for both humans and agents)
 	if existingApproach.AuthorType != authInfo.AuthorType || existingApproach.AuthorID != authInfo.AuthorID {
-		writeProblemsError(w, http.StatusForbidden, "FORBIDDEN", "you can only add progress notes to your own approaches")
+		apierror.Write(w, apierror.Forbidden, "you can only add progress notes to your own approaches")
 		return
 	}
 
 	// Parse request body
 	var req ProgressNoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	// Validate content
 	if req.Content == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content is required")
+		apierror.Write(w, apierror.ValidationError, "content is required")
 		return
 	}
 
@@ -351,9 +432,16 @@ func (h *ProblemsHandler) AddProgressNote(w http.ResponseWriter, r *http.Request
 
 	createdNote, err := h.repo.AddProgressNote(r.Context(), note)
 	if err != nil {
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to add progress note")
+		apierror.Write(w, apierror.InternalError, "failed to add progress note")
 		return
 	}
+	h.recordApproachEvents(r.Context(), models.ApproachEvent{
+		ApproachID: approachID,
+		Type:       models.ApproachEventProgressNote,
+		ActorType:  authInfo.AuthorType,
+		ActorID:    authInfo.AuthorID,
+		Data:       map[string]any{"note_id": createdNote.ID, "content": createdNote.Content},
+	})
 
 	writeProblemsJSON(w, http.StatusCreated, map[string]interface{}{
 		"data": createdNote,
@@ -366,13 +454,13 @@ func (h *ProblemsHandler) VerifyApproach(w http.ResponseWriter, r *http.Request)
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeProblemsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	approachID := chi.URLParam(r, "id")
 	if approachID == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "approach ID is required")
+		apierror.Write(w, apierror.ValidationError, "approach ID is required")
 		return
 	}
 
@@ -380,10 +468,10 @@ func (h *ProblemsHandler) VerifyApproach(w http.ResponseWriter, r *http.Request)
 	approach, err := h.repo.FindApproachByID(r.Context(), approachID)
 	if err != nil {
 		if errors.Is(err, ErrApproachNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "approach not found")
+			apierror.Write(w, apierror.NotFound, "approach not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get approach")
+		apierror.Write(w, apierror.InternalError, "failed to get approach")
 		return
 	}
 
@@ -392,28 +480,37 @@ func (h *ProblemsHandler) VerifyApproach(w http.ResponseWriter, r *http.Request)
 	problem, err := h.findProblem(r.Context(), approach.ProblemID)
 	if err != nil {
 		if errors.Is(err, ErrProblemNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "problem not found")
+			apierror.Write(w, apierror.NotFound, "problem not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get problem")
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
 		return
 	}
 
 	// Check ownership - only problem owner can verify (works for both humans and agents)
 	if problem.PostedByType != authInfo.AuthorType || problem.PostedByID != authInfo.AuthorID {
-		writeProblemsError(w, http.StatusForbidden, "FORBIDDEN", "only the problem owner can verify approaches")
+		apierror.Write(w, apierror.Forbidden, "only the problem owner can verify approaches")
 		return
 	}
 
 	// Parse request body
 	var req VerifyApproachRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	// If verified and approach succeeded, update problem status to solved
 	if req.Verified && approach.Status == models.ApproachStatusSucceeded {
+		// Every success criterion must be met first, or an override reason given
+		var criteriaOverride *models.SuccessCriteriaOverride
+		if problem.Status != models.PostStatusSolved {
+			var ok bool
+			if criteriaOverride, ok = checkSolveCriteria(w, r, h.criteriaRepo, problem.ID, req.OverrideReason); !ok {
+				return
+			}
+		}
+
 		// FIX-025: Try postsRepo first (where most problems are stored), then fall back to problemsRepo
 		var updateErr error
 		if h.postsRepo != nil {
@@ -439,11 +536,20 @@ func (h *ProblemsHandler) VerifyApproach(w http.ResponseWriter, r *http.Request)
 			updateErr = h.repo.UpdateProblemStatus(r.Context(), problem.ID, models.PostStatusSolved)
 		}
 		if updateErr != nil {
-			writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update problem status")
+			apierror.Write(w, apierror.InternalError, "failed to update problem status")
 			return
 		}
+		recordSolveOverride(r.Context(), h.criteriaRepo, criteriaOverride, h.logger)
 	}
 
+	h.recordApproachEvents(r.Context(), models.ApproachEvent{
+		ApproachID: approachID,
+		Type:       models.ApproachEventVerified,
+		ActorType:  authInfo.AuthorType,
+		ActorID:    authInfo.AuthorID,
+		Data:       map[string]any{"verified": req.Verified},
+	})
+
 	writeProblemsJSON(w, http.StatusOK, map[string]interface{}{
 		"message":  "approach verified",
 		"verified": req.Verified,
@@ -455,13 +561,13 @@ func (h *ProblemsHandler) VerifyApproach(w http.ResponseWriter, r *http.Request)
 // Public endpoint (no auth required).
 func (h *ProblemsHandler) GetApproachHistory(w http.ResponseWriter, r *http.Request) {
 	if h.relRepo == nil {
-		writeProblemsError(w, http.StatusNotImplemented, "NOT_IMPLEMENTED", "version history not available")
+		apierror.Write(w, apierror.NotImplemented, "version history not available")
 		return
 	}
 
 	approachID := chi.URLParam(r, "approachId")
 	if approachID == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "approach ID is required")
+		apierror.Write(w, apierror.ValidationError, "approach ID is required")
 		return
 	}
 
@@ -469,10 +575,10 @@ func (h *ProblemsHandler) GetApproachHistory(w http.ResponseWriter, r *http.Requ
 	_, err := h.repo.FindApproachByID(r.Context(), approachID)
 	if err != nil {
 		if errors.Is(err, ErrApproachNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "approach not found")
+			apierror.Write(w, apierror.NotFound, "approach not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get approach")
+		apierror.Write(w, apierror.InternalError, "failed to get approach")
 		return
 	}
 
@@ -486,7 +592,7 @@ func (h *ProblemsHandler) GetApproachHistory(w http.ResponseWriter, r *http.Requ
 
 	history, err := h.relRepo.GetVersionChain(r.Context(), approachID, depth)
 	if err != nil {
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get version history")
+		apierror.Write(w, apierror.InternalError, "failed to get version history")
 		return
 	}
 
@@ -121,6 +121,44 @@ func TestCreateApproach_Success(t *testing.T) {
 	}
 }
 
+// TestCreateApproach_NotifiesProblemOwner tests that the problem owner hears
+// about approaches by others, and not about their own.
+func TestCreateApproach_NotifiesProblemOwner(t *testing.T) {
+	for _, tt := range []struct {
+		authorID string
+		want     int
+	}{{"user-456", 1}, {"user-123", 0}} {
+		repo := NewMockProblemsRepository()
+		problem := createTestProblem("problem-123", "Connection pool leak")
+		repo.SetPost(&problem)
+		notifier := &MockNotificationCreator{}
+		handler := NewProblemsHandler(repo)
+		handler.SetNotificationCreator(notifier)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/problems/problem-123/approaches",
+			bytes.NewReader([]byte(`{"angle":"Raise the pool size"}`)))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "problem-123")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		req = addProblemsAuthContext(req, tt.authorID, "user")
+		w := httptest.NewRecorder()
+		handler.CreateApproach(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d; body: %s", w.Code, w.Body.String())
+		}
+		if len(notifier.created) != tt.want {
+			t.Fatalf("approach by %s: expected %d notifications, got %d", tt.authorID, tt.want, len(notifier.created))
+		}
+		if tt.want == 1 {
+			n := notifier.created[0]
+			if n.Type != "approach.created" || n.UserID == nil || *n.UserID != "user-123" || n.Link != "/problems/problem-123" {
+				t.Errorf("unexpected notification %+v", n)
+			}
+		}
+	}
+}
+
 // TestCreateApproach_NoAuth tests 401 when not authenticated.
 func TestCreateApproach_NoAuth(t *testing.T) {
 	repo := NewMockProblemsRepository()
@@ -687,3 +725,142 @@ func TestGetApproachHistory_NotFound(t *testing.T) {
 		t.Errorf("expected status 404, got %d", w.Code)
 	}
 }
+
+// ============================================================================
+// Approach outcome types
+// ============================================================================
+
+// TestListApproaches_OutcomeTypeFilter tests the ?outcome_type= filter.
+func TestListApproaches_OutcomeTypeFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantFilter models.ApproachOutcomeType
+	}{
+		{name: "no filter", query: "", wantStatus: http.StatusOK},
+		{name: "valid filter", query: "?outcome_type=blocked-external", wantStatus: http.StatusOK, wantFilter: models.ApproachOutcomeBlockedExternal},
+		{name: "invalid filter", query: "?outcome_type=meh", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockProblemsRepository()
+			problem := createTestProblem("problem-123", "Test Problem")
+			repo.SetPost(&problem)
+			handler := NewProblemsHandler(repo)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/problems/problem-123/approaches"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "problem-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.ListApproaches(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d; body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code == http.StatusOK && repo.approachOpts.OutcomeType != tt.wantFilter {
+				t.Errorf("expected outcome_type filter %q, got %q", tt.wantFilter, repo.approachOpts.OutcomeType)
+			}
+		})
+	}
+}
+
+// TestListApproaches_SortParam tests that known sort values are passed through
+// and unknown sort values fall back to the default order.
+func TestListApproaches_SortParam(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantSort string
+	}{
+		{"?sort=top", "top"},
+		{"?sort=oldest", "oldest"},
+		{"?sort=verified-first", "verified-first"},
+		{"?sort=quality", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			repo := NewMockProblemsRepository()
+			problem := createTestProblem("problem-123", "Test Problem")
+			repo.SetPost(&problem)
+			handler := NewProblemsHandler(repo)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/problems/problem-123/approaches"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "problem-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.ListApproaches(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+			}
+			if repo.approachOpts.Sort != tt.wantSort {
+				t.Errorf("expected sort %q, got %q", tt.wantSort, repo.approachOpts.Sort)
+			}
+		})
+	}
+}
+
+// TestUpdateApproach_OutcomeType tests outcome_type and failure_reason
+// validation, the default type for terminal statuses, and clearing the
+// failure reason when the type no longer allows one.
+func TestUpdateApproach_OutcomeType(t *testing.T) {
+	tests := []struct {
+		name          string
+		existingType  models.ApproachOutcomeType
+		existingCause models.ApproachFailureReason
+		body          string
+		wantStatus    int
+		wantType      models.ApproachOutcomeType
+		wantCause     models.ApproachFailureReason
+	}{
+		{name: "type with reason", body: `{"status":"failed","outcome_type":"blocked-external","failure_reason":"missing-access"}`,
+			wantStatus: http.StatusOK, wantType: models.ApproachOutcomeBlockedExternal, wantCause: models.FailureReasonMissingAccess},
+		{name: "succeeded defaults to worked", body: `{"status":"succeeded"}`,
+			wantStatus: http.StatusOK, wantType: models.ApproachOutcomeWorked},
+		{name: "failed defaults to failed-hypothesis", body: `{"status":"failed","failure_reason":"wrong-assumption"}`,
+			wantStatus: http.StatusOK, wantType: models.ApproachOutcomeFailedHypothesis, wantCause: models.FailureReasonWrongAssumption},
+		{name: "existing reason kept", existingType: models.ApproachOutcomeFailedHypothesis, existingCause: models.FailureReasonDependencyIssue,
+			body: `{"outcome":"Pinned the older client"}`, wantStatus: http.StatusOK,
+			wantType: models.ApproachOutcomeFailedHypothesis, wantCause: models.FailureReasonDependencyIssue},
+		{name: "reason cleared on superseded", existingType: models.ApproachOutcomeFailedHypothesis, existingCause: models.FailureReasonDependencyIssue,
+			body: `{"outcome_type":"superseded"}`, wantStatus: http.StatusOK, wantType: models.ApproachOutcomeSuperseded},
+		{name: "invalid type", body: `{"outcome_type":"kind-of"}`, wantStatus: http.StatusBadRequest},
+		{name: "invalid reason", body: `{"outcome_type":"failed-hypothesis","failure_reason":"bad-luck"}`, wantStatus: http.StatusBadRequest},
+		{name: "reason on worked", body: `{"outcome_type":"worked","failure_reason":"other"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockProblemsRepository()
+			approach := createTestApproach("approach-123", "problem-123")
+			approach.OutcomeType = tt.existingType
+			approach.FailureReason = tt.existingCause
+			repo.SetApproach(&approach)
+			handler := NewProblemsHandler(repo)
+
+			req := httptest.NewRequest(http.MethodPatch, "/v1/approaches/approach-123", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "approach-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = addProblemsAuthContext(req, "user-456", "user")
+			w := httptest.NewRecorder()
+
+			handler.UpdateApproach(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d; body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			if got := repo.updatedApproach; got.OutcomeType != tt.wantType || got.FailureReason != tt.wantCause {
+				t.Errorf("expected %q/%q, got %q/%q", tt.wantType, tt.wantCause, got.OutcomeType, got.FailureReason)
+			}
+		})
+	}
+}
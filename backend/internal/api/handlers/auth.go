@@ -12,9 +12,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -30,6 +32,8 @@ type AuthHandlers struct {
 	userRepo       UserRepositoryForAuth
 	authMethodRepo AuthMethodRepository
 	referralRepo   ReferralRepositoryForAuth
+	// securityMonitor records successful logins; nil disables it.
+	securityMonitor SecurityMonitorInterface
 }
 
 // UserRepositoryForAuth defines required DB methods for auth operations.
@@ -110,6 +114,12 @@ func NewAuthHandlers(config *OAuthConfig, userRepo UserRepositoryForAuth, authMe
 	}
 }
 
+// SetSecurityMonitor records successful password logins so anomalous ones
+// (new country, impossible travel) alert the user.
+func (h *AuthHandlers) SetSecurityMonitor(monitor SecurityMonitorInterface) {
+	h.securityMonitor = monitor
+}
+
 // Register handles POST /v1/auth/register for email/password registration.
 // Per PRD Task 48: Email/password registration with bcrypt.
 func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
@@ -118,23 +128,23 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 	// Step 1: Parse request body
 	var req RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		apierror.Write(w, apierror.InvalidRequest, "Invalid request body")
 		return
 	}
 
 	// Step 2: Validate input
 	if err := validateEmail(req.Email); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "INVALID_EMAIL", err.Error())
+		apierror.Write(w, apierror.InvalidEmail, err.Error())
 		return
 	}
 
 	if err := validatePassword(req.Password); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "INVALID_PASSWORD", err.Error())
+		apierror.Write(w, apierror.InvalidPassword, err.Error())
 		return
 	}
 
 	if err := validateUsername(req.Username); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "INVALID_USERNAME", err.Error())
+		apierror.Write(w, apierror.InvalidUsername, err.Error())
 		return
 	}
 
@@ -146,7 +156,7 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if existingUser != nil {
-		writeErrorResponse(w, http.StatusConflict, "DUPLICATE_EMAIL", "Email already registered")
+		apierror.Write(w, apierror.DuplicateEmail, "Email already registered")
 		return
 	}
 
@@ -158,7 +168,7 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if existingUser != nil {
-		writeErrorResponse(w, http.StatusConflict, "DUPLICATE_USERNAME", "Username already taken")
+		apierror.Write(w, apierror.DuplicateUsername, "Username already taken")
 		return
 	}
 
@@ -184,11 +194,11 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		// Handle duplicate errors (race condition)
 		if errors.Is(err, db.ErrDuplicateEmail) {
-			writeErrorResponse(w, http.StatusConflict, "DUPLICATE_EMAIL", "Email already registered")
+			apierror.Write(w, apierror.DuplicateEmail, "Email already registered")
 			return
 		}
 		if errors.Is(err, db.ErrDuplicateUsername) {
-			writeErrorResponse(w, http.StatusConflict, "DUPLICATE_USERNAME", "Username already taken")
+			apierror.Write(w, apierror.DuplicateUsername, "Username already taken")
 			return
 		}
 		slog.Error("user creation failed", "error", err, "op", "Register")
@@ -217,7 +227,7 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 				"user_id", createdUser.ID)
 		}
 
-		writeErrorResponse(w, http.StatusInternalServerError, "REGISTRATION_FAILED",
+		apierror.Write(w, apierror.RegistrationFailed,
 			"Failed to complete registration. Please try again.")
 		return
 	}
@@ -254,7 +264,7 @@ func (h *AuthHandlers) Register(w http.ResponseWriter, r *http.Request) {
 		jwtExpiry = 15 * time.Minute // Default
 	}
 
-	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, createdUser.ID, createdUser.Email, createdUser.Role, jwtExpiry)
+	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, createdUser.ID, createdUser.Email, createdUser.Role, tenant.FromContext(r.Context()), jwtExpiry)
 	if err != nil {
 		slog.Error("JWT generation failed", "error", err, "op", "Register")
 		writeInternalError(w, "Failed to generate access token")
@@ -290,13 +300,13 @@ func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 	// Step 1: Parse request body
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body")
+		apierror.Write(w, apierror.InvalidRequest, "Invalid request body")
 		return
 	}
 
 	// Step 2: Validate input
 	if req.Email == "" || req.Password == "" {
-		writeErrorResponse(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid email or password")
+		apierror.Write(w, apierror.InvalidCredentials, "Invalid email or password")
 		return
 	}
 
@@ -305,7 +315,7 @@ func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		// User not found - return generic error (no email enumeration)
 		if errors.Is(err, db.ErrNotFound) {
-			writeErrorResponse(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid email or password")
+			apierror.Write(w, apierror.InvalidCredentials, "Invalid email or password")
 			return
 		}
 		// Database error
@@ -348,14 +358,21 @@ func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 				oauthProviders[0])
 		}
 
-		writeErrorResponse(w, http.StatusUnauthorized, "OAUTH_ONLY_USER", message)
+		apierror.Write(w, apierror.OAuthOnlyUser, message)
 		return
 	}
 
 	// Step 5: Verify password with bcrypt
 	if err := bcrypt.CompareHashAndPassword([]byte(emailMethod.PasswordHash), []byte(req.Password)); err != nil {
 		// Wrong password - return generic error (no password enumeration)
-		writeErrorResponse(w, http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid email or password")
+		apierror.Write(w, apierror.InvalidCredentials, "Invalid email or password")
+		return
+	}
+
+	// Suspended and banned accounts can't sign in (checked after the password so
+	// account status isn't disclosed to someone without the credentials)
+	if models.IsBlockedUserStatus(user.Status) {
+		apierror.Write(w, apierror.AccountSuspended, "This account has been "+user.Status)
 		return
 	}
 
@@ -371,7 +388,7 @@ func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 		jwtExpiry = 15 * time.Minute // Default
 	}
 
-	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, user.ID, user.Email, user.Role, jwtExpiry)
+	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, user.ID, user.Email, user.Role, tenant.FromContext(r.Context()), jwtExpiry)
 	if err != nil {
 		slog.Error("JWT generation failed", "error", err, "op", "Login")
 		writeInternalError(w, "Failed to generate access token")
@@ -381,6 +398,9 @@ func (h *AuthHandlers) Login(w http.ResponseWriter, r *http.Request) {
 	// Step 7: Generate refresh token
 	refreshToken := auth.GenerateRefreshToken()
 
+	// Step 7.5: Record the login so logins from new countries raise an alert
+	recordSignIn(r, h.securityMonitor, user.ID, models.SecurityMethodPassword)
+
 	// Step 8: Return success response
 	resp := LoginResponse{
 		AccessToken:  accessToken,
@@ -433,18 +453,6 @@ func validateUsername(username string) error {
 	return nil
 }
 
-// writeErrorResponse writes a JSON error response with given status code and error details.
-func writeErrorResponse(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 // ClaimReferral handles POST /v1/auth/claim-referral
 // Called after OAuth signup to attribute a referral that was stored in localStorage.
 // Requires JWT authentication. Silently succeeds if ref is invalid or user already has a referral.
@@ -454,7 +462,7 @@ func (h *AuthHandlers) ClaimReferral(w http.ResponseWriter, r *http.Request) {
 	// Get authenticated user ID from JWT context
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeErrorResponse(w, http.StatusUnauthorized, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
@@ -16,18 +16,14 @@ type AuthInfo struct {
 }
 
 // GetAuthInfo extracts auth info from request context.
-// Checks agent (API key) FIRST, then JWT claims.
-// This matches the priority in Me handler and GetMyPosts.
+// It is a view of auth.PrincipalFromContext, which checks the agent (API key)
+// FIRST, then JWT claims, matching the priority in Me handler and GetMyPosts.
 func GetAuthInfo(r *http.Request) *AuthInfo {
-	// Agent first (more specific — prevents misattribution)
-	if agent := auth.AgentFromContext(r.Context()); agent != nil {
-		return &AuthInfo{AuthorType: models.AuthorTypeAgent, AuthorID: agent.ID}
+	p := auth.PrincipalFromContext(r.Context())
+	if p == nil {
+		return nil
 	}
-	// JWT claims second
-	if claims := auth.ClaimsFromContext(r.Context()); claims != nil {
-		return &AuthInfo{AuthorType: models.AuthorTypeHuman, AuthorID: claims.UserID, Role: claims.Role}
-	}
-	return nil
+	return &AuthInfo{AuthorType: p.Type, AuthorID: p.ID, Role: p.Role}
 }
 
 // callerHumanID returns the caller's family human UUID for BART-151 visibility scoping:
@@ -41,14 +37,8 @@ func callerHumanID(r *http.Request) string {
 // callerHumanFromCtx is the context-based form of callerHumanID, so shared helpers that
 // only carry a context (e.g. findQuestion/findIdea/findProblem) can scope by family too.
 func callerHumanFromCtx(ctx context.Context) string {
-	if agent := auth.AgentFromContext(ctx); agent != nil {
-		if agent.HumanID != nil {
-			return *agent.HumanID
-		}
-		return ""
-	}
-	if claims := auth.ClaimsFromContext(ctx); claims != nil {
-		return claims.UserID
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		return p.HumanID
 	}
 	return ""
 }
@@ -723,6 +723,49 @@ func TestLogin_WrongPassword(t *testing.T) {
 	}
 }
 
+// TestLogin_SuspendedUser tests that a suspended user with the right password gets 403.
+func TestLogin_SuspendedUser(t *testing.T) {
+	mockRepo := newMockUserRepoForAuth()
+	config := &OAuthConfig{
+		JWTSecret:     "test-secret",
+		JWTExpiry:     "15m",
+		RefreshExpiry: "168h",
+	}
+	mockAuthMethodRepo := newMockAuthMethodRepoStub()
+	handler := NewAuthHandlers(config, mockRepo, mockAuthMethodRepo, nil)
+
+	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
+	mockRepo.users["user@example.com"] = &models.User{
+		ID:           "user-123",
+		Email:        "user@example.com",
+		Username:     "testuser",
+		AuthProvider: models.AuthProviderEmail,
+		PasswordHash: string(passwordHash),
+		Role:         models.UserRoleUser,
+		Status:       string(models.UserStatusSuspended),
+	}
+	mockAuthMethodRepo.methods["user-123"] = []*models.AuthMethod{{
+		ID:           "auth-method-123",
+		UserID:       "user-123",
+		AuthProvider: models.AuthProviderEmail,
+		PasswordHash: string(passwordHash),
+	}}
+
+	body, _ := json.Marshal(LoginRequest{Email: "user@example.com", Password: "correctpassword"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.Login(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "ACCOUNT_SUSPENDED") {
+		t.Errorf("expected ACCOUNT_SUSPENDED error, got %s", w.Body.String())
+	}
+}
+
 // TestLogin_NonExistentEmail tests login with non-existent email returns 401 (no email enumeration).
 func TestLogin_NonExistentEmail(t *testing.T) {
 	mockRepo := newMockUserRepoForAuth()
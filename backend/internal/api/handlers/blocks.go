@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// BlocksRepositoryInterface defines the database operations for block and mute lists.
+type BlocksRepositoryInterface interface {
+	Block(ctx context.Context, blockerType, blockerID string, blockedType models.AuthorType, blockedID string, mode models.BlockMode) (*models.PrincipalBlock, error)
+	Unblock(ctx context.Context, blockerType, blockerID string, blockedType models.AuthorType, blockedID string) error
+	List(ctx context.Context, blockerType, blockerID string, page, perPage int) ([]models.PrincipalBlock, int, error)
+}
+
+// BlocksHandler handles the caller's block and mute list. Blocked principals'
+// answers and comments are left out of list responses to the caller; blocked
+// and muted principals' mentions don't notify the caller.
+type BlocksHandler struct {
+	repo BlocksRepositoryInterface
+}
+
+// NewBlocksHandler creates a new BlocksHandler.
+func NewBlocksHandler(repo BlocksRepositoryInterface) *BlocksHandler {
+	return &BlocksHandler{repo: repo}
+}
+
+// BlockRequest is the optional request body for POST /v1/me/blocks/{principal}.
+type BlockRequest struct {
+	Mode models.BlockMode `json:"mode,omitempty"` // "block" (default) or "mute"
+}
+
+// Block handles POST /v1/me/blocks/{principal} - block or mute a human or
+// agent. Blocking again changes the mode.
+func (h *BlocksHandler) Block(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	blockedType, blockedID, ok := principalFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	req := BlockRequest{Mode: models.BlockModeBlock}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.WriteValidationError(w, "invalid JSON body", nil)
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = models.BlockModeBlock
+	}
+	if !models.IsValidBlockMode(req.Mode) {
+		response.WriteValidationError(w, "mode must be 'block' or 'mute'", nil)
+		return
+	}
+	if blockedType == authInfo.AuthorType && blockedID == authInfo.AuthorID {
+		response.WriteValidationError(w, "cannot block yourself", nil)
+		return
+	}
+
+	block, err := h.repo.Block(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, blockedType, blockedID, req.Mode)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, string(blockedType)+" not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to block")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, block)
+}
+
+// Unblock handles DELETE /v1/me/blocks/{principal} - remove a block or mute.
+func (h *BlocksHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	blockedType, blockedID, ok := principalFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.Unblock(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, blockedType, blockedID); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, "not blocked")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to unblock")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// List handles GET /v1/me/blocks - the caller's blocks and mutes, newest first.
+func (h *BlocksHandler) List(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	page, perPage, err := parsePaginationParams(r)
+	if err != nil {
+		response.WriteValidationError(w, err.Error(), nil)
+		return
+	}
+
+	blocks, total, err := h.repo.List(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, page, perPage)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list blocks")
+		return
+	}
+
+	response.WriteJSONWithMeta(w, http.StatusOK, blocks, response.Meta{
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+		HasMore: page*perPage < total,
+	})
+}
+
+// principalFromPath parses the {principal} path parameter, "human:<user id>"
+// or "agent:<agent id>", writing a 400 and returning ok=false when it is malformed.
+func principalFromPath(w http.ResponseWriter, r *http.Request) (models.AuthorType, string, bool) {
+	kind, id, found := strings.Cut(chi.URLParam(r, "principal"), ":")
+	switch {
+	case !found || id == "":
+	case kind == string(models.AuthorTypeHuman):
+		if _, err := uuid.Parse(id); err == nil {
+			return models.AuthorTypeHuman, id, true
+		}
+	case kind == string(models.AuthorTypeAgent):
+		return models.AuthorTypeAgent, id, true
+	}
+	response.WriteValidationError(w, "principal must be 'human:<user id>' or 'agent:<agent id>'", nil)
+	return "", "", false
+}
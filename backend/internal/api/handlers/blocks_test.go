@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+const (
+	blockerUserID = "0c9b8a7f-6e5d-4c3b-8a29-1f0e0d0c0b0a"
+	blockedUserID = "7d5f7a1e-3c2b-4c9a-9d7e-0a1b2c3d4e5f"
+)
+
+type mockBlocksRepo struct {
+	blocks map[string]models.BlockMode
+}
+
+func (m *mockBlocksRepo) Block(ctx context.Context, blockerType, blockerID string, blockedType models.AuthorType, blockedID string, mode models.BlockMode) (*models.PrincipalBlock, error) {
+	if blockedID == "ghost" {
+		return nil, db.ErrNotFound
+	}
+	m.blocks[string(blockedType)+":"+blockedID] = mode
+	return &models.PrincipalBlock{BlockedType: blockedType, BlockedID: blockedID, Mode: mode}, nil
+}
+
+func (m *mockBlocksRepo) Unblock(ctx context.Context, blockerType, blockerID string, blockedType models.AuthorType, blockedID string) error {
+	key := string(blockedType) + ":" + blockedID
+	if _, ok := m.blocks[key]; !ok {
+		return db.ErrNotFound
+	}
+	delete(m.blocks, key)
+	return nil
+}
+
+func (m *mockBlocksRepo) List(ctx context.Context, blockerType, blockerID string, page, perPage int) ([]models.PrincipalBlock, int, error) {
+	var blocks []models.PrincipalBlock
+	for key, mode := range m.blocks {
+		kind, id, _ := strings.Cut(key, ":")
+		blocks = append(blocks, models.PrincipalBlock{BlockedType: models.AuthorType(kind), BlockedID: id, Mode: mode})
+	}
+	return blocks, len(blocks), nil
+}
+
+func blocksRequest(method, principal, body string) *http.Request {
+	req := httptest.NewRequest(method, "/v1/me/blocks/"+principal, strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("principal", principal)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	return req.WithContext(auth.ContextWithClaims(ctx, &auth.Claims{UserID: blockerUserID, Role: "user"}))
+}
+
+func TestBlocksHandler_Block(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal string
+		body      string
+		status    int
+		mode      models.BlockMode
+	}{
+		{"human defaults to block", "human:" + blockedUserID, "", http.StatusOK, models.BlockModeBlock},
+		{"agent mute", "agent:helper_bot", `{"mode":"mute"}`, http.StatusOK, models.BlockModeMute},
+		{"invalid mode", "agent:helper_bot", `{"mode":"hide"}`, http.StatusBadRequest, ""},
+		{"missing type", "helper_bot", "", http.StatusBadRequest, ""},
+		{"human id not a uuid", "human:ana", "", http.StatusBadRequest, ""},
+		{"yourself", "human:" + blockerUserID, "", http.StatusBadRequest, ""},
+		{"unknown principal", "agent:ghost", "", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockBlocksRepo{blocks: map[string]models.BlockMode{}}
+			rr := httptest.NewRecorder()
+			NewBlocksHandler(repo).Block(rr, blocksRequest(http.MethodPost, tt.principal, tt.body))
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				if len(repo.blocks) != 0 {
+					t.Errorf("blocks = %v, want none", repo.blocks)
+				}
+				return
+			}
+			var resp struct {
+				Data models.PrincipalBlock `json:"data"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.Data.Mode != tt.mode || repo.blocks[tt.principal] != tt.mode {
+				t.Errorf("mode = %q (stored %q), want %q", resp.Data.Mode, repo.blocks[tt.principal], tt.mode)
+			}
+		})
+	}
+}
+
+func TestBlocksHandler_UnblockAndList(t *testing.T) {
+	repo := &mockBlocksRepo{blocks: map[string]models.BlockMode{"agent:helper_bot": models.BlockModeMute}}
+	h := NewBlocksHandler(repo)
+
+	rr := httptest.NewRecorder()
+	h.List(rr, blocksRequest(http.MethodGet, "", ""))
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), `"blocked_id":"helper_bot"`) {
+		t.Fatalf("list = %d %s, want helper_bot", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	h.Unblock(rr, blocksRequest(http.MethodDelete, "agent:helper_bot", ""))
+	if rr.Code != http.StatusNoContent || len(repo.blocks) != 0 {
+		t.Fatalf("unblock status = %d with %d blocks left, want 204 and none", rr.Code, len(repo.blocks))
+	}
+
+	rr = httptest.NewRecorder()
+	h.Unblock(rr, blocksRequest(http.MethodDelete, "agent:helper_bot", ""))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("second unblock status = %d, want 404", rr.Code)
+	}
+}
+
+func TestBlocksHandler_RequiresAuth(t *testing.T) {
+	h := NewBlocksHandler(&mockBlocksRepo{blocks: map[string]models.BlockMode{}})
+	rr := httptest.NewRecorder()
+	h.List(rr, httptest.NewRequest(http.MethodGet, "/v1/me/blocks", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", rr.Code)
+	}
+}
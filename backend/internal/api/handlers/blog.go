@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
@@ -20,7 +21,7 @@ import (
 type BlogPostRepositoryInterface interface {
 	List(ctx context.Context, opts models.BlogPostListOptions) ([]models.BlogPostWithAuthor, int, error)
 	FindBySlug(ctx context.Context, slug string) (*models.BlogPostWithAuthor, error)
-	FindBySlugForViewer(ctx context.Context, slug string, viewerType models.AuthorType, viewerID string) (*models.BlogPostWithAuthor, error)
+	FindBySlugForViewer(ctx context.Context, slug string) (*models.BlogPostWithAuthor, error)
 	Create(ctx context.Context, post *models.BlogPost) (*models.BlogPost, error)
 	Update(ctx context.Context, post *models.BlogPost) (*models.BlogPost, error)
 	Delete(ctx context.Context, slug string) error
@@ -119,12 +120,6 @@ func (h *BlogHandler) List(w http.ResponseWriter, r *http.Request) {
 		opts.Sort = sortParam
 	}
 
-	// Pass viewer info for user_vote lookup
-	if authInfo := GetAuthInfo(r); authInfo != nil {
-		opts.ViewerType = authInfo.AuthorType
-		opts.ViewerID = authInfo.AuthorID
-	}
-
 	posts, total, err := h.repo.List(r.Context(), opts)
 	if err != nil {
 		ctx := response.LogContext{
@@ -155,22 +150,15 @@ func (h *BlogHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *BlogHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
-	var post *models.BlogPostWithAuthor
-	var err error
-
-	if authInfo := GetAuthInfo(r); authInfo != nil {
-		post, err = h.repo.FindBySlugForViewer(r.Context(), slug, authInfo.AuthorType, authInfo.AuthorID)
-	} else {
-		post, err = h.repo.FindBySlug(r.Context(), slug)
-	}
+	post, err := h.repo.FindBySlugForViewer(r.Context(), slug)
 
 	if err != nil {
 		if errors.Is(err, db.ErrBlogPostNotFound) {
-			writeBlogError(w, http.StatusNotFound, "NOT_FOUND", "blog post not found")
+			apierror.Write(w, apierror.NotFound, "blog post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -190,43 +178,43 @@ func (h *BlogHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
 func (h *BlogHandler) Create(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeBlogError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	var req CreateBlogPostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	// Validate title
 	if req.Title == "" {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title is required")
+		apierror.Write(w, apierror.ValidationError, "title is required")
 		return
 	}
 	if len(req.Title) < 10 {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at least 10 characters")
+		apierror.Write(w, apierror.ValidationError, "title must be at least 10 characters")
 		return
 	}
 	if len(req.Title) > 300 {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at most 300 characters")
+		apierror.Write(w, apierror.ValidationError, "title must be at most 300 characters")
 		return
 	}
 
 	// Validate body
 	if req.Body == "" {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "body is required")
+		apierror.Write(w, apierror.ValidationError, "body is required")
 		return
 	}
 	if len(req.Body) < 50 {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "body must be at least 50 characters")
+		apierror.Write(w, apierror.ValidationError, "body must be at least 50 characters")
 		return
 	}
 
 	// Validate tags
 	if len(req.Tags) > 10 {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "maximum 10 tags allowed")
+		apierror.Write(w, apierror.ValidationError, "maximum 10 tags allowed")
 		return
 	}
 
@@ -238,7 +226,7 @@ func (h *BlogHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Validate slug format
 	if !validateSlug(slug) {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid slug format")
+		apierror.Write(w, apierror.ValidationError, "invalid slug format")
 		return
 	}
 
@@ -246,7 +234,7 @@ func (h *BlogHandler) Create(w http.ResponseWriter, r *http.Request) {
 	status := models.BlogPostStatusDraft
 	if req.Status != "" {
 		if !models.IsValidBlogPostStatus(req.Status) {
-			writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "status must be one of: draft, published, archived")
+			apierror.Write(w, apierror.ValidationError, "status must be one of: draft, published, archived")
 			return
 		}
 		status = models.BlogPostStatus(req.Status)
@@ -276,7 +264,7 @@ func (h *BlogHandler) Create(w http.ResponseWriter, r *http.Request) {
 	createdPost, err := h.repo.Create(r.Context(), post)
 	if err != nil {
 		if errors.Is(err, db.ErrDuplicateSlug) {
-			writeBlogError(w, http.StatusConflict, "DUPLICATE_CONTENT", "a blog post with this slug already exists")
+			apierror.Write(w, apierror.DuplicateContent, "a blog post with this slug already exists")
 			return
 		}
 		ctx := response.LogContext{
@@ -299,13 +287,13 @@ func (h *BlogHandler) Create(w http.ResponseWriter, r *http.Request) {
 func (h *BlogHandler) Update(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeBlogError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
@@ -313,7 +301,7 @@ func (h *BlogHandler) Update(w http.ResponseWriter, r *http.Request) {
 	existing, err := h.repo.FindBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrBlogPostNotFound) {
-			writeBlogError(w, http.StatusNotFound, "NOT_FOUND", "blog post not found")
+			apierror.Write(w, apierror.NotFound, "blog post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -328,14 +316,14 @@ func (h *BlogHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	// Verify ownership
 	if existing.PostedByType != authInfo.AuthorType || existing.PostedByID != authInfo.AuthorID {
-		writeBlogError(w, http.StatusForbidden, "FORBIDDEN", "you can only update your own blog posts")
+		apierror.Write(w, apierror.Forbidden, "you can only update your own blog posts")
 		return
 	}
 
 	// Parse update request
 	var req UpdateBlogPostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
@@ -344,11 +332,11 @@ func (h *BlogHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	if req.Title != nil {
 		if len(*req.Title) < 10 {
-			writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at least 10 characters")
+			apierror.Write(w, apierror.ValidationError, "title must be at least 10 characters")
 			return
 		}
 		if len(*req.Title) > 300 {
-			writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at most 300 characters")
+			apierror.Write(w, apierror.ValidationError, "title must be at most 300 characters")
 			return
 		}
 		updatedPost.Title = *req.Title
@@ -356,7 +344,7 @@ func (h *BlogHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	if req.Body != nil {
 		if len(*req.Body) < 50 {
-			writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "body must be at least 50 characters")
+			apierror.Write(w, apierror.ValidationError, "body must be at least 50 characters")
 			return
 		}
 		updatedPost.Body = *req.Body
@@ -369,7 +357,7 @@ func (h *BlogHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	if req.Tags != nil {
 		if len(req.Tags) > 10 {
-			writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "maximum 10 tags allowed")
+			apierror.Write(w, apierror.ValidationError, "maximum 10 tags allowed")
 			return
 		}
 		updatedPost.Tags = req.Tags
@@ -385,7 +373,7 @@ func (h *BlogHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	if req.Status != nil {
 		if !models.IsValidBlogPostStatus(*req.Status) {
-			writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "status must be one of: draft, published, archived")
+			apierror.Write(w, apierror.ValidationError, "status must be one of: draft, published, archived")
 			return
 		}
 		newStatus := models.BlogPostStatus(*req.Status)
@@ -416,13 +404,13 @@ func (h *BlogHandler) Update(w http.ResponseWriter, r *http.Request) {
 func (h *BlogHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeBlogError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
@@ -430,7 +418,7 @@ func (h *BlogHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	existing, err := h.repo.FindBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrBlogPostNotFound) {
-			writeBlogError(w, http.StatusNotFound, "NOT_FOUND", "blog post not found")
+			apierror.Write(w, apierror.NotFound, "blog post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -445,7 +433,7 @@ func (h *BlogHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	// Verify ownership
 	if existing.PostedByType != authInfo.AuthorType || existing.PostedByID != authInfo.AuthorID {
-		writeBlogError(w, http.StatusForbidden, "FORBIDDEN", "you can only delete your own blog posts")
+		apierror.Write(w, apierror.Forbidden, "you can only delete your own blog posts")
 		return
 	}
 
@@ -467,24 +455,24 @@ func (h *BlogHandler) Delete(w http.ResponseWriter, r *http.Request) {
 func (h *BlogHandler) Vote(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeBlogError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
 	var req VoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	if req.Direction != "up" && req.Direction != "down" {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "direction must be 'up' or 'down'")
+		apierror.Write(w, apierror.ValidationError, "direction must be 'up' or 'down'")
 		return
 	}
 
@@ -492,7 +480,7 @@ func (h *BlogHandler) Vote(w http.ResponseWriter, r *http.Request) {
 	post, err := h.repo.FindBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrBlogPostNotFound) {
-			writeBlogError(w, http.StatusNotFound, "NOT_FOUND", "blog post not found")
+			apierror.Write(w, apierror.NotFound, "blog post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -507,7 +495,7 @@ func (h *BlogHandler) Vote(w http.ResponseWriter, r *http.Request) {
 
 	// Prevent self-vote
 	if post.PostedByType == authInfo.AuthorType && post.PostedByID == authInfo.AuthorID {
-		writeBlogError(w, http.StatusForbidden, "FORBIDDEN", "cannot vote on your own blog post")
+		apierror.Write(w, apierror.Forbidden, "cannot vote on your own blog post")
 		return
 	}
 
@@ -537,13 +525,13 @@ func (h *BlogHandler) Vote(w http.ResponseWriter, r *http.Request) {
 func (h *BlogHandler) RecordView(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		writeBlogError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
 	if err := h.repo.IncrementViewCount(r.Context(), slug); err != nil {
 		if errors.Is(err, db.ErrBlogPostNotFound) {
-			writeBlogError(w, http.StatusNotFound, "NOT_FOUND", "blog post not found")
+			apierror.Write(w, apierror.NotFound, "blog post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -564,7 +552,7 @@ func (h *BlogHandler) GetFeatured(w http.ResponseWriter, r *http.Request) {
 	post, err := h.repo.GetFeatured(r.Context())
 	if err != nil {
 		if errors.Is(err, db.ErrBlogPostNotFound) {
-			writeBlogError(w, http.StatusNotFound, "NOT_FOUND", "no featured blog post found")
+			apierror.Write(w, apierror.NotFound, "no featured blog post found")
 			return
 		}
 		ctx := response.LogContext{
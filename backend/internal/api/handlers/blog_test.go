@@ -65,7 +65,7 @@ func (m *MockBlogPostRepository) FindBySlug(ctx context.Context, slug string) (*
 	return m.post, nil
 }
 
-func (m *MockBlogPostRepository) FindBySlugForViewer(ctx context.Context, slug string, viewerType models.AuthorType, viewerID string) (*models.BlogPostWithAuthor, error) {
+func (m *MockBlogPostRepository) FindBySlugForViewer(ctx context.Context, slug string) (*models.BlogPostWithAuthor, error) {
 	return m.FindBySlug(ctx, slug)
 }
 
@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
@@ -62,25 +63,25 @@ type BookmarksListResponse struct {
 func (h *BookmarksHandler) Add(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeBookmarksError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	var req BookmarkRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeBookmarksError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	if req.PostID == "" {
-		writeBookmarksError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post_id is required")
+		apierror.Write(w, apierror.ValidationError, "post_id is required")
 		return
 	}
 
 	bookmark, err := h.repo.Add(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, req.PostID)
 	if err != nil {
 		if errors.Is(err, db.ErrBookmarkExists) {
-			writeBookmarksError(w, http.StatusConflict, "BOOKMARK_EXISTS", "post is already bookmarked")
+			apierror.Write(w, apierror.BookmarkExists, "post is already bookmarked")
 			return
 		}
 		ctx := response.LogContext{
@@ -101,20 +102,20 @@ func (h *BookmarksHandler) Add(w http.ResponseWriter, r *http.Request) {
 func (h *BookmarksHandler) Remove(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeBookmarksError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writeBookmarksError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
 	err := h.repo.Remove(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, postID)
 	if err != nil {
 		if errors.Is(err, db.ErrBookmarkNotFound) {
-			writeBookmarksError(w, http.StatusNotFound, "NOT_FOUND", "bookmark not found")
+			apierror.Write(w, apierror.NotFound, "bookmark not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -133,7 +134,7 @@ func (h *BookmarksHandler) Remove(w http.ResponseWriter, r *http.Request) {
 func (h *BookmarksHandler) List(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeBookmarksError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
@@ -170,13 +171,13 @@ func (h *BookmarksHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *BookmarksHandler) Check(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeBookmarksError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writeBookmarksError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
@@ -204,15 +205,3 @@ func writeBookmarksJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-// writeBookmarksError writes an error JSON response.
-func writeBookmarksError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
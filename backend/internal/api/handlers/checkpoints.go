@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -90,18 +91,18 @@ func (h *CheckpointsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Parse request body as generic map to capture dynamic meta fields
 	var rawBody map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&rawBody); err != nil {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	// Extract CID (required)
 	cid, _ := rawBody["cid"].(string)
 	if cid == "" {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "cid is required")
+		apierror.Write(w, apierror.ValidationError, "cid is required")
 		return
 	}
 	if !IsValidCID(cid) {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "invalid CID format: must be a valid CIDv0 (Qm...) or CIDv1 (bafy...)")
+		apierror.Write(w, apierror.ValidationError, "invalid CID format: must be a valid CIDv0 (Qm...) or CIDv1 (bafy...)")
 		return
 	}
 
@@ -115,7 +116,7 @@ func (h *CheckpointsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			h.logger.Error("failed to check storage quota", "ownerID", agent.ID, "error", err.Error())
 			// Fail open — allow the checkpoint if we can't check quota
 		} else if used >= quota {
-			response.WriteError(w, http.StatusPaymentRequired, "QUOTA_EXCEEDED", "storage quota exceeded")
+			apierror.Write(w, apierror.QuotaExceeded, "storage quota exceeded")
 			return
 		}
 	}
@@ -162,7 +163,7 @@ func (h *CheckpointsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	err := h.repo.Create(r.Context(), pin)
 	if err != nil {
 		if errors.Is(err, db.ErrDuplicatePin) {
-			response.WriteError(w, http.StatusConflict, response.ErrCodeDuplicateContent, "checkpoint already exists for this CID")
+			apierror.Write(w, apierror.DuplicateContent, "checkpoint already exists for this CID")
 			return
 		}
 		logCtx := response.LogContext{
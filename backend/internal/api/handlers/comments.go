@@ -5,9 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"strings"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
@@ -40,8 +43,9 @@ type CommentsAgentRepositoryInterface interface {
 
 // CommentsHandler handles comment-related HTTP requests.
 type CommentsHandler struct {
-	repo      CommentsRepositoryInterface
-	agentRepo CommentsAgentRepositoryInterface
+	repo            CommentsRepositoryInterface
+	agentRepo       CommentsAgentRepositoryInterface
+	mentionNotifier MentionNotifier // see mentions.go
 }
 
 // NewCommentsHandler creates a new CommentsHandler.
@@ -77,7 +81,7 @@ func (h *CommentsHandler) List(w http.ResponseWriter, r *http.Request) {
 	// Validate target type
 	targetType := models.CommentTargetType(targetTypeStr)
 	if !models.IsValidCommentTargetType(targetType) {
-		writeCommentsError(w, http.StatusBadRequest, "VALIDATION_ERROR",
+		apierror.Write(w, apierror.ValidationError,
 			"invalid target type, must be one of: approach, answer, response")
 		return
 	}
@@ -103,7 +107,7 @@ func (h *CommentsHandler) List(w http.ResponseWriter, r *http.Request) {
 	// Query comments
 	comments, total, err := h.repo.List(r.Context(), opts)
 	if err != nil {
-		writeCommentsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list comments")
+		apierror.Write(w, apierror.InternalError, "failed to list comments")
 		return
 	}
 
@@ -133,7 +137,7 @@ func (h *CommentsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeCommentsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
@@ -144,7 +148,7 @@ func (h *CommentsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Validate target type
 	targetType := models.CommentTargetType(targetTypeStr)
 	if !models.IsValidCommentTargetType(targetType) {
-		writeCommentsError(w, http.StatusBadRequest, "VALIDATION_ERROR",
+		apierror.Write(w, apierror.ValidationError,
 			"invalid target type, must be one of: approach, answer, response")
 		return
 	}
@@ -152,29 +156,28 @@ func (h *CommentsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req models.CreateCommentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeCommentsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
-	// Validate content
 	content := strings.TrimSpace(req.Content)
-	if content == "" {
-		writeCommentsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content is required")
-		return
+	var v Validator
+	if v.Required("content", content) {
+		v.Length("content", content, 0, models.MaxCommentContentLength)
 	}
-	if len(content) > models.MaxCommentContentLength {
-		writeCommentsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content must be at most 2000 characters")
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
 		return
 	}
 
 	// Check if target exists
 	exists, err := h.repo.TargetExists(r.Context(), targetType, targetID)
 	if err != nil {
-		writeCommentsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to verify target")
+		apierror.Write(w, apierror.InternalError, "failed to verify target")
 		return
 	}
 	if !exists {
-		writeCommentsError(w, http.StatusNotFound, "NOT_FOUND", "target not found")
+		apierror.Write(w, apierror.NotFound, "target not found")
 		return
 	}
 
@@ -189,10 +192,12 @@ func (h *CommentsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	createdComment, err := h.repo.Create(r.Context(), comment)
 	if err != nil {
-		writeCommentsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create comment")
+		apierror.Write(w, apierror.InternalError, "failed to create comment")
 		return
 	}
 
+	notifyMentionsAsync(r.Context(), h.mentionNotifier, createdComment.Content, createdComment.AuthorType, createdComment.AuthorID, commentLink(createdComment), slog.Default())
+
 	writeCommentsJSON(w, http.StatusCreated, map[string]interface{}{
 		"data": createdComment,
 	})
@@ -204,24 +209,24 @@ func (h *CommentsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeCommentsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	commentID := chi.URLParam(r, "id")
 	if commentID == "" {
-		writeCommentsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "comment ID is required")
+		apierror.Write(w, apierror.ValidationError, "comment ID is required")
 		return
 	}
 
 	// Get existing comment
 	comment, err := h.repo.FindByID(r.Context(), commentID)
 	if err != nil {
-		if errors.Is(err, ErrCommentNotFound) {
-			writeCommentsError(w, http.StatusNotFound, "NOT_FOUND", "comment not found")
+		if errors.Is(err, ErrCommentNotFound) || errors.Is(err, db.ErrCommentNotFound) {
+			apierror.Write(w, apierror.NotFound, "comment not found")
 			return
 		}
-		writeCommentsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get comment")
+		apierror.Write(w, apierror.InternalError, "failed to get comment")
 		return
 	}
 
@@ -239,12 +244,12 @@ func (h *CommentsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !isOwner && !isAgentOwner && !isAdmin {
-		writeCommentsError(w, http.StatusForbidden, "FORBIDDEN", "you can only delete your own comments")
+		apierror.Write(w, apierror.Forbidden, "you can only delete your own comments")
 		return
 	}
 
 	if err := h.repo.Delete(r.Context(), commentID); err != nil {
-		writeCommentsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to delete comment")
+		apierror.Write(w, apierror.InternalError, "failed to delete comment")
 		return
 	}
 
@@ -257,16 +262,3 @@ func writeCommentsJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-// writeCommentsError writes an error JSON response.
-func writeCommentsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
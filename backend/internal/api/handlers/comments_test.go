@@ -8,67 +8,62 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
-	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/db/memdb"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
 
-// MockCommentsRepository is a mock implementation of CommentsRepositoryInterface.
-type MockCommentsRepository struct {
-	comments       []models.CommentWithAuthor
-	createErr      error
-	listErr        error
-	deleteErr      error
-	findByIDResult *models.CommentWithAuthor
-	findByIDErr    error
-	targetExists   bool
-	targetExistsErr error
-}
-
-func (m *MockCommentsRepository) List(ctx context.Context, opts models.CommentListOptions) ([]models.CommentWithAuthor, int, error) {
-	if m.listErr != nil {
-		return nil, 0, m.listErr
-	}
-	// Filter by target
-	var filtered []models.CommentWithAuthor
-	for _, c := range m.comments {
-		if c.TargetType == opts.TargetType && c.TargetID == opts.TargetID && c.DeletedAt == nil {
-			filtered = append(filtered, c)
+// newCommentsTestStore returns an in-memory store holding question post-123 with
+// answer answer-123, and problem problem-123 with approach approach-123, all by
+// user-1, plus the given comments.
+func newCommentsTestStore(t *testing.T, comments ...models.Comment) *memdb.Store {
+	t.Helper()
+	store := memdb.NewStore()
+	ctx := context.Background()
+	for _, post := range []models.Post{
+		{ID: "post-123", Type: models.PostTypeQuestion, Title: "How do I pin a Go toolchain?", Status: models.PostStatusOpen},
+		{ID: "problem-123", Type: models.PostTypeProblem, Title: "Builds fail on the new runner", Status: models.PostStatusOpen},
+	} {
+		post.Description = "A description long enough to be valid content for a post."
+		post.PostedByType, post.PostedByID = models.AuthorTypeHuman, "user-1"
+		if _, err := store.Posts().Create(ctx, &post); err != nil {
+			t.Fatalf("create post: %v", err)
 		}
 	}
-	return filtered, len(filtered), nil
-}
-
-func (m *MockCommentsRepository) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
-	if m.createErr != nil {
-		return nil, m.createErr
-	}
-	comment.ID = "comment-123"
-	comment.CreatedAt = time.Now()
-	return comment, nil
-}
-
-func (m *MockCommentsRepository) FindByID(ctx context.Context, id string) (*models.CommentWithAuthor, error) {
-	if m.findByIDErr != nil {
-		return nil, m.findByIDErr
-	}
-	if m.findByIDResult != nil {
-		return m.findByIDResult, nil
+	if _, err := store.Answers().CreateAnswer(ctx, &models.Answer{
+		ID: "answer-123", QuestionID: "post-123", AuthorType: models.AuthorTypeHuman, AuthorID: "user-1",
+		Content: "Add a toolchain directive to go.mod.",
+	}); err != nil {
+		t.Fatalf("create answer: %v", err)
+	}
+	if _, err := store.Approaches().CreateApproach(ctx, &models.Approach{
+		ID: "approach-123", ProblemID: "problem-123", AuthorType: models.AuthorTypeHuman, AuthorID: "user-1",
+		Angle: "Pin the runner image",
+	}); err != nil {
+		t.Fatalf("create approach: %v", err)
+	}
+	for _, c := range comments {
+		if _, err := store.Comments().Create(ctx, &c); err != nil {
+			t.Fatalf("create comment: %v", err)
+		}
 	}
-	return nil, ErrCommentNotFound
+	return store
 }
 
-func (m *MockCommentsRepository) Delete(ctx context.Context, id string) error {
-	return m.deleteErr
+// responseTargetsRepository treats every idea response as an existing comment
+// target; memdb does not model responses.
+type responseTargetsRepository struct {
+	*memdb.CommentsRepository
 }
 
-func (m *MockCommentsRepository) TargetExists(ctx context.Context, targetType models.CommentTargetType, targetID string) (bool, error) {
-	if m.targetExistsErr != nil {
-		return false, m.targetExistsErr
+func (r responseTargetsRepository) TargetExists(ctx context.Context, targetType models.CommentTargetType, targetID string) (bool, error) {
+	if targetType == models.CommentTargetResponse {
+		return true, nil
 	}
-	return m.targetExists, nil
+	return r.CommentsRepository.TargetExists(ctx, targetType, targetID)
 }
 
 // Helper to add JWT claims to context for comments tests.
@@ -85,46 +80,26 @@ func addCommentsAuthContext(r *http.Request, userID, role string) *http.Request
 // Test List Comments
 
 func TestListComments_Success(t *testing.T) {
-	now := time.Now()
-	mockRepo := &MockCommentsRepository{
-		comments: []models.CommentWithAuthor{
-			{
-				Comment: models.Comment{
-					ID:         "comment-1",
-					TargetType: models.CommentTargetApproach,
-					TargetID:   "approach-123",
-					AuthorType: models.AuthorTypeHuman,
-					AuthorID:   "user-1",
-					Content:    "Great approach!",
-					CreatedAt:  now,
-				},
-				Author: models.CommentAuthor{
-					ID:          "user-1",
-					Type:        models.AuthorTypeHuman,
-					DisplayName: "Test User",
-				},
-			},
-			{
-				Comment: models.Comment{
-					ID:         "comment-2",
-					TargetType: models.CommentTargetApproach,
-					TargetID:   "approach-123",
-					AuthorType: models.AuthorTypeAgent,
-					AuthorID:   "agent-1",
-					Content:    "I agree with this approach.",
-					CreatedAt:  now.Add(time.Minute),
-				},
-				Author: models.CommentAuthor{
-					ID:          "agent-1",
-					Type:        models.AuthorTypeAgent,
-					DisplayName: "Claude",
-				},
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-1",
+			TargetType: models.CommentTargetApproach,
+			TargetID:   "approach-123",
+			AuthorType: models.AuthorTypeHuman,
+			AuthorID:   "user-1",
+			Content:    "Great approach!",
 		},
-		targetExists: true,
-	}
+		models.Comment{
+			ID:         "comment-2",
+			TargetType: models.CommentTargetApproach,
+			TargetID:   "approach-123",
+			AuthorType: models.AuthorTypeAgent,
+			AuthorID:   "agent-1",
+			Content:    "I agree with this approach.",
+		},
+	)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	// Create request with chi context
 	req := httptest.NewRequest(http.MethodGet, "/v1/approaches/approach-123/comments", nil)
@@ -161,12 +136,9 @@ func TestListComments_Success(t *testing.T) {
 }
 
 func TestListComments_EmptyResult(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		comments:     []models.CommentWithAuthor{},
-		targetExists: true,
-	}
+	store := newCommentsTestStore(t)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/approaches/approach-123/comments", nil)
 	rctx := chi.NewRouteContext()
@@ -195,8 +167,8 @@ func TestListComments_EmptyResult(t *testing.T) {
 }
 
 func TestListComments_InvalidTargetType(t *testing.T) {
-	mockRepo := &MockCommentsRepository{}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/invalid/approach-123/comments", nil)
 	rctx := chi.NewRouteContext()
@@ -215,11 +187,9 @@ func TestListComments_InvalidTargetType(t *testing.T) {
 // Test Create Comment
 
 func TestCreateComment_Success(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		targetExists: true,
-	}
+	store := newCommentsTestStore(t)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	body := `{"content": "This is a helpful comment on this approach."}`
 	req := httptest.NewRequest(http.MethodPost, "/v1/approaches/approach-123/comments", bytes.NewBufferString(body))
@@ -248,11 +218,14 @@ func TestCreateComment_Success(t *testing.T) {
 	if response.Data.Content != "This is a helpful comment on this approach." {
 		t.Errorf("expected content to match, got: %s", response.Data.Content)
 	}
+	if _, err := store.Comments().FindByID(context.Background(), response.Data.ID); err != nil {
+		t.Errorf("expected the comment to be stored: %v", err)
+	}
 }
 
 func TestCreateComment_NoAuth(t *testing.T) {
-	mockRepo := &MockCommentsRepository{}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	body := `{"content": "Hello world"}`
 	req := httptest.NewRequest(http.MethodPost, "/v1/approaches/approach-123/comments", bytes.NewBufferString(body))
@@ -271,8 +244,8 @@ func TestCreateComment_NoAuth(t *testing.T) {
 }
 
 func TestCreateComment_InvalidJSON(t *testing.T) {
-	mockRepo := &MockCommentsRepository{}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodPost, "/v1/approaches/approach-123/comments", bytes.NewBufferString("not json"))
 	req.Header.Set("Content-Type", "application/json")
@@ -291,10 +264,8 @@ func TestCreateComment_InvalidJSON(t *testing.T) {
 }
 
 func TestCreateComment_EmptyContent(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		targetExists: true,
-	}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	body := `{"content": ""}`
 	req := httptest.NewRequest(http.MethodPost, "/v1/approaches/approach-123/comments", bytes.NewBufferString(body))
@@ -326,10 +297,8 @@ func TestCreateComment_EmptyContent(t *testing.T) {
 }
 
 func TestCreateComment_ContentTooLong(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		targetExists: true,
-	}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	// Create content with more than 2000 characters
 	longContent := make([]byte, 2001)
@@ -355,10 +324,8 @@ func TestCreateComment_ContentTooLong(t *testing.T) {
 }
 
 func TestCreateComment_TargetNotFound(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		targetExists: false,
-	}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	body := `{"content": "This is a comment"}`
 	req := httptest.NewRequest(http.MethodPost, "/v1/approaches/nonexistent/comments", bytes.NewBufferString(body))
@@ -378,8 +345,8 @@ func TestCreateComment_TargetNotFound(t *testing.T) {
 }
 
 func TestCreateComment_InvalidTargetType(t *testing.T) {
-	mockRepo := &MockCommentsRepository{}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	body := `{"content": "Hello"}`
 	req := httptest.NewRequest(http.MethodPost, "/v1/invalid/123/comments", bytes.NewBufferString(body))
@@ -401,27 +368,18 @@ func TestCreateComment_InvalidTargetType(t *testing.T) {
 // Test Delete Comment
 
 func TestDeleteComment_OwnerCanDelete(t *testing.T) {
-	now := time.Now()
-	mockRepo := &MockCommentsRepository{
-		findByIDResult: &models.CommentWithAuthor{
-			Comment: models.Comment{
-				ID:         "comment-123",
-				TargetType: models.CommentTargetApproach,
-				TargetID:   "approach-123",
-				AuthorType: models.AuthorTypeHuman,
-				AuthorID:   "user-123",
-				Content:    "My comment",
-				CreatedAt:  now,
-			},
-			Author: models.CommentAuthor{
-				ID:          "user-123",
-				Type:        models.AuthorTypeHuman,
-				DisplayName: "Test User",
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-123",
+			TargetType: models.CommentTargetApproach,
+			TargetID:   "approach-123",
+			AuthorType: models.AuthorTypeHuman,
+			AuthorID:   "user-123",
+			Content:    "My comment",
 		},
-	}
+	)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/comment-123", nil)
 	req = addCommentsAuthContext(req, "user-123", "user")
@@ -435,30 +393,24 @@ func TestDeleteComment_OwnerCanDelete(t *testing.T) {
 	if rec.Code != http.StatusNoContent {
 		t.Errorf("expected status 204, got %d; body: %s", rec.Code, rec.Body.String())
 	}
+	if _, err := store.Comments().FindByID(context.Background(), "comment-123"); !errors.Is(err, db.ErrCommentNotFound) {
+		t.Errorf("expected comment-123 to be deleted, got err %v", err)
+	}
 }
 
 func TestDeleteComment_AdminCanDelete(t *testing.T) {
-	now := time.Now()
-	mockRepo := &MockCommentsRepository{
-		findByIDResult: &models.CommentWithAuthor{
-			Comment: models.Comment{
-				ID:         "comment-123",
-				TargetType: models.CommentTargetApproach,
-				TargetID:   "approach-123",
-				AuthorType: models.AuthorTypeHuman,
-				AuthorID:   "other-user",
-				Content:    "Someone else's comment",
-				CreatedAt:  now,
-			},
-			Author: models.CommentAuthor{
-				ID:          "other-user",
-				Type:        models.AuthorTypeHuman,
-				DisplayName: "Other User",
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-123",
+			TargetType: models.CommentTargetApproach,
+			TargetID:   "approach-123",
+			AuthorType: models.AuthorTypeHuman,
+			AuthorID:   "other-user",
+			Content:    "Someone else's comment",
 		},
-	}
+	)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/comment-123", nil)
 	req = addCommentsAuthContext(req, "admin-user", "admin")
@@ -475,27 +427,18 @@ func TestDeleteComment_AdminCanDelete(t *testing.T) {
 }
 
 func TestDeleteComment_OthersForbidden(t *testing.T) {
-	now := time.Now()
-	mockRepo := &MockCommentsRepository{
-		findByIDResult: &models.CommentWithAuthor{
-			Comment: models.Comment{
-				ID:         "comment-123",
-				TargetType: models.CommentTargetApproach,
-				TargetID:   "approach-123",
-				AuthorType: models.AuthorTypeHuman,
-				AuthorID:   "other-user",
-				Content:    "Someone else's comment",
-				CreatedAt:  now,
-			},
-			Author: models.CommentAuthor{
-				ID:          "other-user",
-				Type:        models.AuthorTypeHuman,
-				DisplayName: "Other User",
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-123",
+			TargetType: models.CommentTargetApproach,
+			TargetID:   "approach-123",
+			AuthorType: models.AuthorTypeHuman,
+			AuthorID:   "other-user",
+			Content:    "Someone else's comment",
 		},
-	}
+	)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/comment-123", nil)
 	req = addCommentsAuthContext(req, "different-user", "user")
@@ -509,11 +452,14 @@ func TestDeleteComment_OthersForbidden(t *testing.T) {
 	if rec.Code != http.StatusForbidden {
 		t.Errorf("expected status 403, got %d", rec.Code)
 	}
+	if _, err := store.Comments().FindByID(context.Background(), "comment-123"); err != nil {
+		t.Errorf("expected comment-123 to be kept: %v", err)
+	}
 }
 
 func TestDeleteComment_NoAuth(t *testing.T) {
-	mockRepo := &MockCommentsRepository{}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/comment-123", nil)
 	rctx := chi.NewRouteContext()
@@ -529,10 +475,8 @@ func TestDeleteComment_NoAuth(t *testing.T) {
 }
 
 func TestDeleteComment_NotFound(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		findByIDErr: ErrCommentNotFound,
-	}
-	handler := NewCommentsHandler(mockRepo)
+	store := newCommentsTestStore(t)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/nonexistent", nil)
 	req = addCommentsAuthContext(req, "user-123", "user")
@@ -551,11 +495,9 @@ func TestDeleteComment_NotFound(t *testing.T) {
 // Test with different target types
 
 func TestCreateComment_OnAnswer(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		targetExists: true,
-	}
+	store := newCommentsTestStore(t)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	body := `{"content": "Thanks for this answer!"}`
 	req := httptest.NewRequest(http.MethodPost, "/v1/answers/answer-123/comments", bytes.NewBufferString(body))
@@ -584,11 +526,9 @@ func TestCreateComment_OnAnswer(t *testing.T) {
 }
 
 func TestCreateComment_OnResponse(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		targetExists: true,
-	}
+	store := newCommentsTestStore(t)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(responseTargetsRepository{store.Comments()})
 
 	body := `{"content": "Great response to the idea!"}`
 	req := httptest.NewRequest(http.MethodPost, "/v1/responses/response-123/comments", bytes.NewBufferString(body))
@@ -658,11 +598,9 @@ func addCommentsAgentAuthContext(r *http.Request, agentID string) *http.Request
 // TestCreateComment_SuccessWithAPIKey tests that agents can create comments via API key auth.
 // Per SPEC.md Part 1.4 and FIX-025: Both humans (JWT) and AI agents (API key) can comment.
 func TestCreateComment_SuccessWithAPIKey(t *testing.T) {
-	mockRepo := &MockCommentsRepository{
-		targetExists: true,
-	}
+	store := newCommentsTestStore(t)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	body := `{"content": "AI agent comment on this approach."}`
 	req := httptest.NewRequest(http.MethodPost, "/v1/approaches/approach-123/comments", bytes.NewBufferString(body))
@@ -702,27 +640,18 @@ func TestCreateComment_SuccessWithAPIKey(t *testing.T) {
 
 // TestDeleteComment_AgentCanDeleteOwnComment tests that agents can delete their own comments.
 func TestDeleteComment_AgentCanDeleteOwnComment(t *testing.T) {
-	now := time.Now()
-	mockRepo := &MockCommentsRepository{
-		findByIDResult: &models.CommentWithAuthor{
-			Comment: models.Comment{
-				ID:         "comment-123",
-				TargetType: models.CommentTargetApproach,
-				TargetID:   "approach-123",
-				AuthorType: models.AuthorTypeAgent,
-				AuthorID:   "test-agent-123",
-				Content:    "Agent's own comment",
-				CreatedAt:  now,
-			},
-			Author: models.CommentAuthor{
-				ID:          "test-agent-123",
-				Type:        models.AuthorTypeAgent,
-				DisplayName: "Test Agent",
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-123",
+			TargetType: models.CommentTargetApproach,
+			TargetID:   "approach-123",
+			AuthorType: models.AuthorTypeAgent,
+			AuthorID:   "test-agent-123",
+			Content:    "Agent's own comment",
 		},
-	}
+	)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/comment-123", nil)
 	// Use API key auth (agent authentication)
@@ -741,26 +670,17 @@ func TestDeleteComment_AgentCanDeleteOwnComment(t *testing.T) {
 
 // TestDeleteComment_AgentOwnerCanDelete tests that a human who claimed an agent can delete the agent's comments.
 func TestDeleteComment_AgentOwnerCanDelete(t *testing.T) {
-	now := time.Now()
 	humanID := "human-owner-123"
-	mockRepo := &MockCommentsRepository{
-		findByIDResult: &models.CommentWithAuthor{
-			Comment: models.Comment{
-				ID:         "comment-123",
-				TargetType: models.CommentTargetPost,
-				TargetID:   "post-123",
-				AuthorType: models.AuthorTypeAgent,
-				AuthorID:   "agent-phil",
-				Content:    "Agent Phil's comment",
-				CreatedAt:  now,
-			},
-			Author: models.CommentAuthor{
-				ID:          "agent-phil",
-				Type:        models.AuthorTypeAgent,
-				DisplayName: "agent_Phil",
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-123",
+			TargetType: models.CommentTargetPost,
+			TargetID:   "post-123",
+			AuthorType: models.AuthorTypeAgent,
+			AuthorID:   "agent-phil",
+			Content:    "Agent Phil's comment",
 		},
-	}
+	)
 
 	// Mock agent repo: agent-phil is claimed by human-owner-123
 	agentRepo := NewMockAgentRepository()
@@ -770,7 +690,7 @@ func TestDeleteComment_AgentOwnerCanDelete(t *testing.T) {
 		HumanID:     &humanID,
 	}
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 	handler.SetAgentRepository(agentRepo)
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/comment-123", nil)
@@ -789,26 +709,17 @@ func TestDeleteComment_AgentOwnerCanDelete(t *testing.T) {
 
 // TestDeleteComment_NonOwnerHumanCannotDeleteAgentComment tests that a human who does NOT own the agent cannot delete its comments.
 func TestDeleteComment_NonOwnerHumanCannotDeleteAgentComment(t *testing.T) {
-	now := time.Now()
 	ownerID := "actual-owner"
-	mockRepo := &MockCommentsRepository{
-		findByIDResult: &models.CommentWithAuthor{
-			Comment: models.Comment{
-				ID:         "comment-123",
-				TargetType: models.CommentTargetPost,
-				TargetID:   "post-123",
-				AuthorType: models.AuthorTypeAgent,
-				AuthorID:   "agent-phil",
-				Content:    "Agent Phil's comment",
-				CreatedAt:  now,
-			},
-			Author: models.CommentAuthor{
-				ID:          "agent-phil",
-				Type:        models.AuthorTypeAgent,
-				DisplayName: "agent_Phil",
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-123",
+			TargetType: models.CommentTargetPost,
+			TargetID:   "post-123",
+			AuthorType: models.AuthorTypeAgent,
+			AuthorID:   "agent-phil",
+			Content:    "Agent Phil's comment",
 		},
-	}
+	)
 
 	// Mock agent repo: agent-phil is claimed by actual-owner, NOT by different-human
 	agentRepo := NewMockAgentRepository()
@@ -818,7 +729,7 @@ func TestDeleteComment_NonOwnerHumanCannotDeleteAgentComment(t *testing.T) {
 		HumanID:     &ownerID,
 	}
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 	handler.SetAgentRepository(agentRepo)
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/comment-123", nil)
@@ -837,27 +748,18 @@ func TestDeleteComment_NonOwnerHumanCannotDeleteAgentComment(t *testing.T) {
 
 // TestDeleteComment_AgentCannotDeleteOthersComment tests that agents cannot delete other's comments.
 func TestDeleteComment_AgentCannotDeleteOthersComment(t *testing.T) {
-	now := time.Now()
-	mockRepo := &MockCommentsRepository{
-		findByIDResult: &models.CommentWithAuthor{
-			Comment: models.Comment{
-				ID:         "comment-123",
-				TargetType: models.CommentTargetApproach,
-				TargetID:   "approach-123",
-				AuthorType: models.AuthorTypeHuman,
-				AuthorID:   "user-456",
-				Content:    "Human's comment",
-				CreatedAt:  now,
-			},
-			Author: models.CommentAuthor{
-				ID:          "user-456",
-				Type:        models.AuthorTypeHuman,
-				DisplayName: "Human User",
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-123",
+			TargetType: models.CommentTargetApproach,
+			TargetID:   "approach-123",
+			AuthorType: models.AuthorTypeHuman,
+			AuthorID:   "user-456",
+			Content:    "Human's comment",
 		},
-	}
+	)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodDelete, "/v1/comments/comment-123", nil)
 	// Use API key auth (agent authentication)
@@ -879,46 +781,26 @@ func TestDeleteComment_AgentCannotDeleteOthersComment(t *testing.T) {
 // ============================================================================
 
 func TestListComments_IncludesSystem(t *testing.T) {
-	now := time.Now()
-	mockRepo := &MockCommentsRepository{
-		comments: []models.CommentWithAuthor{
-			{
-				Comment: models.Comment{
-					ID:         "comment-human",
-					TargetType: models.CommentTargetPost,
-					TargetID:   "post-123",
-					AuthorType: models.AuthorTypeHuman,
-					AuthorID:   "user-1",
-					Content:    "Nice post!",
-					CreatedAt:  now,
-				},
-				Author: models.CommentAuthor{
-					ID:          "user-1",
-					Type:        models.AuthorTypeHuman,
-					DisplayName: "Test User",
-				},
-			},
-			{
-				Comment: models.Comment{
-					ID:         "comment-system",
-					TargetType: models.CommentTargetPost,
-					TargetID:   "post-123",
-					AuthorType: models.AuthorTypeSystem,
-					AuthorID:   "solvr-moderator",
-					Content:    "Post approved by Solvr moderation. Your post is now visible in the feed.",
-					CreatedAt:  now.Add(time.Second),
-				},
-				Author: models.CommentAuthor{
-					ID:          "solvr-moderator",
-					Type:        models.AuthorTypeSystem,
-					DisplayName: "Solvr Moderator",
-				},
-			},
+	store := newCommentsTestStore(t,
+		models.Comment{
+			ID:         "comment-human",
+			TargetType: models.CommentTargetPost,
+			TargetID:   "post-123",
+			AuthorType: models.AuthorTypeHuman,
+			AuthorID:   "user-1",
+			Content:    "Nice post!",
 		},
-		targetExists: true,
-	}
+		models.Comment{
+			ID:         "comment-system",
+			TargetType: models.CommentTargetPost,
+			TargetID:   "post-123",
+			AuthorType: models.AuthorTypeSystem,
+			AuthorID:   "solvr-moderator",
+			Content:    "Post approved by Solvr moderation. Your post is now visible in the feed.",
+		},
+	)
 
-	handler := NewCommentsHandler(mockRepo)
+	handler := NewCommentsHandler(store.Comments())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-123/comments", nil)
 	rctx := chi.NewRouteContext()
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// contentLimitsSource returns the current content limits. It is read per
+// request so config reloads apply; a nil source uses the defaults.
+type contentLimitsSource func() models.ContentLimits
+
+// For returns the limits of postType.
+func (s contentLimitsSource) For(postType models.PostType) models.PostTypeLimits {
+	if s == nil {
+		return models.DefaultContentLimits().For(postType)
+	}
+	return s().For(postType)
+}
+
+// SetContentLimits sets the limits POST /v1/posts, PATCH /v1/posts/{id}, guest
+// problems and GitHub imports enforce.
+func (h *PostsHandler) SetContentLimits(limits func() models.ContentLimits) {
+	h.contentLimits = limits
+}
+
+// SetContentLimits sets the limits POST /v1/problems enforces.
+func (h *ProblemsHandler) SetContentLimits(limits func() models.ContentLimits) {
+	h.contentLimits = limits
+}
+
+// SetContentLimits sets the limits POST /v1/questions enforces.
+func (h *QuestionsHandler) SetContentLimits(limits func() models.ContentLimits) {
+	h.contentLimits = limits
+}
+
+// SetContentLimits sets the limits POST /v1/ideas enforces.
+func (h *IdeasHandler) SetContentLimits(limits func() models.ContentLimits) {
+	h.contentLimits = limits
+}
+
+// SetContentLimits sets the limits the MCP prompts list and solvr_post checks
+// drafts against.
+func (h *MCPHandler) SetContentLimits(limits func() models.ContentLimits) {
+	h.contentLimits = limits
+}
+
+// validatePostLengths records title, description and success criteria that
+// fall outside limits. Nil fields are not checked.
+func validatePostLengths(v *Validator, limits models.PostTypeLimits, title, description *string, criteria []string) {
+	if title != nil {
+		v.Length("title", *title, limits.Title.Min, limits.Title.Max)
+	}
+	if description != nil {
+		v.Length("description", *description, limits.Description.Min, limits.Description.Max)
+	}
+	for i, c := range criteria {
+		v.Length(fmt.Sprintf("success_criteria[%d]", i), c, limits.SuccessCriterion.Min, limits.SuccessCriterion.Max)
+	}
+}
+
+// ContentLimitsResponse is the response for GET /v1/config/limits.
+type ContentLimitsResponse struct {
+	// PostTypes maps each post type to its field length limits, in bytes.
+	// A max of 0 means no upper limit.
+	PostTypes          models.ContentLimits `json:"post_types"`
+	MaxTags            int                  `json:"max_tags"`
+	MaxSuccessCriteria int                  `json:"max_success_criteria"`
+}
+
+// ContentLimitsHandler serves the content limits clients validate against.
+type ContentLimitsHandler struct {
+	limits contentLimitsSource
+}
+
+// NewContentLimitsHandler creates a new ContentLimitsHandler. A nil limits
+// source serves the defaults.
+func NewContentLimitsHandler(limits func() models.ContentLimits) *ContentLimitsHandler {
+	return &ContentLimitsHandler{limits: limits}
+}
+
+// Get handles GET /v1/config/limits - the length limits posts are validated
+// against, so the CLI, MCP clients and frontend can check before submitting.
+func (h *ContentLimitsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	limits := models.ContentLimits{}
+	for _, t := range models.ValidPostTypes() {
+		limits[t] = h.limits.For(t)
+	}
+	response.WriteJSON(w, http.StatusOK, ContentLimitsResponse{
+		PostTypes:          limits,
+		MaxTags:            models.MaxTagsPerPost,
+		MaxSuccessCriteria: models.MaxSuccessCriteria,
+	})
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// strictProblemLimits allows shorter titles for questions than for problems.
+func strictProblemLimits() models.ContentLimits {
+	limits := models.DefaultContentLimits()
+	problem := limits[models.PostTypeProblem]
+	problem.Title = models.LengthLimit{Min: 30, Max: 120}
+	problem.SuccessCriterion = models.LengthLimit{Min: 5, Max: 40}
+	limits[models.PostTypeProblem] = problem
+	return limits
+}
+
+func TestGetContentLimits(t *testing.T) {
+	handler := NewContentLimitsHandler(strictProblemLimits)
+
+	w := httptest.NewRecorder()
+	handler.Get(w, httptest.NewRequest(http.MethodGet, "/v1/config/limits", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var resp struct {
+		Data ContentLimitsResponse `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got := resp.Data.PostTypes[models.PostTypeProblem].Title; got != (models.LengthLimit{Min: 30, Max: 120}) {
+		t.Errorf("problem title = %+v, want 30-120", got)
+	}
+	if got := resp.Data.PostTypes[models.PostTypeQuestion].Title; got != (models.LengthLimit{Min: 10, Max: 200}) {
+		t.Errorf("question title = %+v, want the default 10-200", got)
+	}
+	if resp.Data.MaxTags != models.MaxTagsPerPost || resp.Data.MaxSuccessCriteria != models.MaxSuccessCriteria {
+		t.Errorf("unexpected counts %+v", resp.Data)
+	}
+}
+
+func TestCreatePost_PerTypeContentLimits(t *testing.T) {
+	description := "This is a test description that needs to be at least fifty characters long."
+	tests := []struct {
+		name      string
+		body      map[string]interface{}
+		wantField string
+	}{
+		{"problem title under the problem minimum", map[string]interface{}{
+			"type": "problem", "title": "Build fails on CI", "description": description,
+		}, "title"},
+		{"success criterion too long", map[string]interface{}{
+			"type": "problem", "title": "Build fails on CI with exit code 137 during linking", "description": description,
+			"success_criteria": []string{"CI passes", strings.Repeat("a", 41)},
+		}, "success_criteria[1]"},
+		{"same title is fine for a question", map[string]interface{}{
+			"type": "question", "title": "Build fails on CI", "description": description,
+		}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewPostsHandler(NewMockPostsRepository())
+			handler.SetContentLimits(strictProblemLimits)
+
+			jsonBody, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/v1/posts", bytes.NewReader(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			req = addAuthContext(req, "user-123", "user")
+			w := httptest.NewRecorder()
+
+			handler.Create(w, req)
+
+			if tt.wantField == "" {
+				if w.Code != http.StatusCreated {
+					t.Errorf("expected status 201, got %d: %s", w.Code, w.Body.String())
+				}
+				return
+			}
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d", w.Code)
+			}
+			if !strings.Contains(w.Body.String(), `"field":"`+tt.wantField+`"`) {
+				t.Errorf("expected a %s field error, got %s", tt.wantField, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestMCPPostDraft_ReportsContentLimits(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	handler.SetContentLimits(strictProblemLimits)
+
+	result, err := handler.executePost(t.Context(), map[string]interface{}{
+		"type": "problem", "title": "Build fails on CI", "description": "too short",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.(map[string]interface{})["content"].([]map[string]interface{})[0]["text"].(string)
+	for _, want := range []string{"would be rejected", "title must be at least 30 characters", "description must be at least 50 characters"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in draft, got:\n%s", want, text)
+		}
+	}
+}
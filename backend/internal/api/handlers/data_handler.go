@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -92,7 +93,7 @@ type publicTrending struct {
 func (h *DataHandler) GetTrending(w http.ResponseWriter, r *http.Request) {
 	window, ok := parseWindowParam(r)
 	if !ok {
-		writeSearchError(w, http.StatusBadRequest, "INVALID_PARAM", "window must be one of: 1h, 24h, 7d")
+		apierror.Write(w, apierror.InvalidParam, "window must be one of: 1h, 24h, 7d")
 		return
 	}
 	includeBots := parseIncludeBots(r)
@@ -110,7 +111,7 @@ func (h *DataHandler) GetTrending(w http.ResponseWriter, r *http.Request) {
 		return public, nil
 	})
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get trending queries")
+		apierror.Write(w, apierror.InternalError, "failed to get trending queries")
 		return
 	}
 
@@ -128,7 +129,7 @@ func (h *DataHandler) GetTrending(w http.ResponseWriter, r *http.Request) {
 func (h *DataHandler) GetBreakdown(w http.ResponseWriter, r *http.Request) {
 	window, ok := parseWindowParam(r)
 	if !ok {
-		writeSearchError(w, http.StatusBadRequest, "INVALID_PARAM", "window must be one of: 1h, 24h, 7d")
+		apierror.Write(w, apierror.InvalidParam, "window must be one of: 1h, 24h, 7d")
 		return
 	}
 	includeBots := parseIncludeBots(r)
@@ -138,7 +139,7 @@ func (h *DataHandler) GetBreakdown(w http.ResponseWriter, r *http.Request) {
 		return h.repo.GetBreakdown(r.Context(), window, !includeBots)
 	})
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get search breakdown")
+		apierror.Write(w, apierror.InternalError, "failed to get search breakdown")
 		return
 	}
 
@@ -159,7 +160,7 @@ func (h *DataHandler) GetBreakdown(w http.ResponseWriter, r *http.Request) {
 func (h *DataHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
 	window, ok := parseWindowParam(r)
 	if !ok {
-		writeSearchError(w, http.StatusBadRequest, "INVALID_PARAM", "window must be one of: 1h, 24h, 7d")
+		apierror.Write(w, apierror.InvalidParam, "window must be one of: 1h, 24h, 7d")
 		return
 	}
 	includeBots := parseIncludeBots(r)
@@ -169,7 +170,7 @@ func (h *DataHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
 		return h.repo.GetCategories(r.Context(), window, !includeBots)
 	})
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get search categories")
+		apierror.Write(w, apierror.InternalError, "failed to get search categories")
 		return
 	}
 
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// EmailPreferencesRepositoryInterface reads and updates per-event email opt-outs.
+type EmailPreferencesRepositoryInterface interface {
+	GetEmailPreferences(ctx context.Context, userID string) (*models.EmailPreferences, error)
+	UpdateEmailPreferences(ctx context.Context, userID string, events map[string]bool) (*models.EmailPreferences, error)
+}
+
+// UpdateEmailPreferencesRequest is the request body for PATCH /v1/me/email-preferences.
+type UpdateEmailPreferencesRequest struct {
+	Events map[string]bool `json:"events"`
+}
+
+// EmailPreferencesHandler handles notification email settings for humans.
+type EmailPreferencesHandler struct {
+	repo   EmailPreferencesRepositoryInterface
+	logger *slog.Logger
+}
+
+// NewEmailPreferencesHandler creates a new EmailPreferencesHandler.
+func NewEmailPreferencesHandler(repo EmailPreferencesRepositoryInterface) *EmailPreferencesHandler {
+	return &EmailPreferencesHandler{
+		repo:   repo,
+		logger: slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+	}
+}
+
+// Get handles GET /v1/me/email-preferences.
+// Returns which notification emails the caller receives.
+func (h *EmailPreferencesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := emailPreferencesUser(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.repo.GetEmailPreferences(r.Context(), userID)
+	if err != nil {
+		h.writeRepoError(w, r, "GetEmailPreferences", err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, prefs)
+}
+
+// Update handles PATCH /v1/me/email-preferences.
+// Body: {"events": {"answer_accepted": false}}. Events left out are unchanged.
+func (h *EmailPreferencesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := emailPreferencesUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateEmailPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteValidationError(w, "invalid JSON body", nil)
+		return
+	}
+	if len(req.Events) == 0 {
+		response.WriteValidationError(w, "events is required", nil)
+		return
+	}
+	for event := range req.Events {
+		if !models.IsOptOutEmailEvent(event) {
+			response.WriteValidationError(w, "unknown email event: "+event, map[string]interface{}{
+				"allowed": models.OptOutEmailEvents,
+			})
+			return
+		}
+	}
+
+	prefs, err := h.repo.UpdateEmailPreferences(r.Context(), userID, req.Events)
+	if err != nil {
+		h.writeRepoError(w, r, "UpdateEmailPreferences", err)
+		return
+	}
+	response.WriteJSON(w, http.StatusOK, prefs)
+}
+
+// emailPreferencesUser returns the caller's user ID. Agents have no notification
+// emails to manage and get 403.
+func emailPreferencesUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		response.WriteUnauthorized(w, "authentication required")
+		return "", false
+	}
+	if authInfo.AuthorType != models.AuthorTypeHuman {
+		response.WriteForbidden(w, "email preferences are only available to human accounts")
+		return "", false
+	}
+	return authInfo.AuthorID, true
+}
+
+func (h *EmailPreferencesHandler) writeRepoError(w http.ResponseWriter, r *http.Request, op string, err error) {
+	if errors.Is(err, db.ErrNotFound) {
+		response.WriteNotFound(w, "user not found")
+		return
+	}
+	response.WriteInternalErrorWithLog(w, "failed to load email preferences", err, response.LogContext{
+		Operation: op,
+		Resource:  "users",
+		RequestID: r.Header.Get("X-Request-ID"),
+	}, h.logger)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockEmailPreferencesRepo struct {
+	optOuts map[string]bool
+}
+
+func (m *mockEmailPreferencesRepo) prefs() *models.EmailPreferences {
+	prefs := &models.EmailPreferences{Events: map[string]bool{}}
+	for _, event := range models.OptOutEmailEvents {
+		prefs.Events[event] = !m.optOuts[event]
+	}
+	return prefs
+}
+
+func (m *mockEmailPreferencesRepo) GetEmailPreferences(ctx context.Context, userID string) (*models.EmailPreferences, error) {
+	return m.prefs(), nil
+}
+
+func (m *mockEmailPreferencesRepo) UpdateEmailPreferences(ctx context.Context, userID string, events map[string]bool) (*models.EmailPreferences, error) {
+	for event, on := range events {
+		m.optOuts[event] = !on
+	}
+	return m.prefs(), nil
+}
+
+func emailPreferencesRequest(method, body string, ctx context.Context) *http.Request {
+	req := httptest.NewRequest(method, "/v1/me/email-preferences", strings.NewReader(body))
+	return req.WithContext(ctx)
+}
+
+func TestEmailPreferencesHandler_Update(t *testing.T) {
+	repo := &mockEmailPreferencesRepo{optOuts: map[string]bool{}}
+	h := NewEmailPreferencesHandler(repo)
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Role: models.UserRoleUser})
+
+	w := httptest.NewRecorder()
+	h.Update(w, emailPreferencesRequest(http.MethodPatch, `{"events":{"answer_accepted":false}}`, ctx))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data models.EmailPreferences `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.Events[models.EmailEventAnswerAccepted] || !resp.Data.Events[models.EmailEventPostCrystallized] {
+		t.Errorf("unexpected preferences %+v", resp.Data)
+	}
+
+	w = httptest.NewRecorder()
+	h.Get(w, emailPreferencesRequest(http.MethodGet, "", ctx))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"answer_accepted":false`) {
+		t.Errorf("expected stored opt-out, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestEmailPreferencesHandler_Validation(t *testing.T) {
+	h := NewEmailPreferencesHandler(&mockEmailPreferencesRepo{optOuts: map[string]bool{}})
+	human := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Role: models.UserRoleUser})
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		body string
+		want int
+	}{
+		{"unauthenticated", context.Background(), `{"events":{"answer_accepted":false}}`, http.StatusUnauthorized},
+		{"agent", auth.ContextWithAgent(context.Background(), &models.Agent{ID: "agent_x"}), `{"events":{"answer_accepted":false}}`, http.StatusForbidden},
+		{"empty", human, `{}`, http.StatusBadRequest},
+		{"claim link not opt-out-able", human, `{"events":{"claim_link":false}}`, http.StatusBadRequest},
+		{"bad json", human, `{`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		h.Update(w, emailPreferencesRequest(http.MethodPatch, tc.body, tc.ctx))
+		if w.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d: %s", tc.name, tc.want, w.Code, w.Body.String())
+		}
+	}
+}
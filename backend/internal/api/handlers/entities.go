@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// Entity page limits.
+const (
+	entityTopPostsLimit = 10
+	entityRelatedLimit  = 10
+)
+
+// EntitiesRepositoryInterface defines the database operations for entity pages.
+type EntitiesRepositoryInterface interface {
+	// GetEntity returns db.ErrEntityNotFound if no visible post mentions the entity.
+	GetEntity(ctx context.Context, name string) (*models.Entity, error)
+	ListEntityPosts(ctx context.Context, name string, limit int) ([]models.EntityPost, error)
+	ListRelatedEntities(ctx context.Context, name string, limit int) ([]models.RelatedEntity, error)
+}
+
+// EntitiesHandler handles knowledge graph entity HTTP requests.
+type EntitiesHandler struct {
+	repo EntitiesRepositoryInterface
+}
+
+// NewEntitiesHandler creates a new EntitiesHandler.
+func NewEntitiesHandler(repo EntitiesRepositoryInterface) *EntitiesHandler {
+	return &EntitiesHandler{repo: repo}
+}
+
+// EntityDetailResponse is the data for GET /v1/entities/{name}.
+type EntityDetailResponse struct {
+	models.Entity
+	TopPosts []models.EntityPost    `json:"top_posts"`
+	Related  []models.RelatedEntity `json:"related"`
+}
+
+// GetEntity handles GET /v1/entities/{name} - everything known about an
+// entity (e.g. "pgx v5", "econnrefused", "cve-2024-3094"): post counts, the
+// top posts mentioning it and the entities mentioned alongside it.
+func (h *EntitiesHandler) GetEntity(w http.ResponseWriter, r *http.Request) {
+	name, err := url.PathUnescape(chi.URLParam(r, "name"))
+	if err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid entity name")
+		return
+	}
+	name = models.NormalizeEntityName(name)
+	if name == "" {
+		apierror.Write(w, apierror.ValidationError, "entity name is required")
+		return
+	}
+
+	entity, err := h.repo.GetEntity(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, db.ErrEntityNotFound) {
+			apierror.Write(w, apierror.NotFound, "entity not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get entity")
+		return
+	}
+
+	posts, err := h.repo.ListEntityPosts(r.Context(), name, entityTopPostsLimit)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get entity posts")
+		return
+	}
+	related, err := h.repo.ListRelatedEntities(r.Context(), name, entityRelatedLimit)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get related entities")
+		return
+	}
+
+	writeEntitiesJSON(w, http.StatusOK, map[string]interface{}{
+		"data": EntityDetailResponse{Entity: *entity, TopPosts: posts, Related: related},
+	})
+}
+
+// writeEntitiesJSON writes a JSON response.
+func writeEntitiesJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
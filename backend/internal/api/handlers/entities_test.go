@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockEntitiesRepo is a test double for EntitiesRepositoryInterface.
+type mockEntitiesRepo struct {
+	entities map[string]models.Entity
+	posts    []models.EntityPost
+	related  []models.RelatedEntity
+}
+
+func (m *mockEntitiesRepo) GetEntity(ctx context.Context, name string) (*models.Entity, error) {
+	e, ok := m.entities[name]
+	if !ok {
+		return nil, db.ErrEntityNotFound
+	}
+	return &e, nil
+}
+
+func (m *mockEntitiesRepo) ListEntityPosts(ctx context.Context, name string, limit int) ([]models.EntityPost, error) {
+	return m.posts, nil
+}
+
+func (m *mockEntitiesRepo) ListRelatedEntities(ctx context.Context, name string, limit int) ([]models.RelatedEntity, error) {
+	return m.related, nil
+}
+
+func newEntityRequest(name string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/entities/"+name, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("name", name)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestEntitiesHandler_GetEntity(t *testing.T) {
+	repo := &mockEntitiesRepo{
+		entities: map[string]models.Entity{
+			"pgx v5": {Name: "pgx v5", Kind: models.EntityKindLibrary, PostCount: 3, ProblemCount: 2, ResolvedCount: 1},
+		},
+		posts:   []models.EntityPost{{ID: "post-1", Type: models.PostTypeProblem, Title: "pgx v5 pool exhausted"}},
+		related: []models.RelatedEntity{{Name: "postgresql", Kind: models.EntityKindTool, SharedPosts: 2}},
+	}
+	handler := NewEntitiesHandler(repo)
+
+	tests := []struct {
+		name       string
+		param      string
+		wantStatus int
+	}{
+		{name: "escaped and mixed case", param: "PGX%20v5", wantStatus: http.StatusOK},
+		{name: "unknown", param: "left-pad", wantStatus: http.StatusNotFound},
+		{name: "blank", param: "%20", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.GetEntity(w, newEntityRequest(tt.param))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Data EntityDetailResponse `json:"data"`
+			}
+			json.NewDecoder(w.Body).Decode(&resp)
+			if resp.Data.Name != "pgx v5" || resp.Data.PostCount != 3 || len(resp.Data.TopPosts) != 1 || len(resp.Data.Related) != 1 {
+				t.Errorf("unexpected response: %+v", resp.Data)
+			}
+		})
+	}
+}
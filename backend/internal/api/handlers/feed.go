@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -24,11 +25,20 @@ type FeedRepositoryInterface interface {
 	// GetUnansweredQuestions returns questions with zero answers.
 	// Per SPEC.md Part 5.6: GET /feed/unanswered - Unanswered questions
 	GetUnansweredQuestions(ctx context.Context, page, perPage int) ([]models.FeedItem, int, error)
+
+	// GetFollowingFeed returns posts ranked for a follower: posts matching
+	// followed tags and bookmarked posts rank higher.
+	// Per SPEC.md Part 5.6: GET /feed?scope=following
+	GetFollowingFeed(ctx context.Context, followerType, followerID string, page, perPage int) ([]models.FeedItem, int, error)
 }
 
 // FeedHandler handles feed-related HTTP requests.
 type FeedHandler struct {
 	repo FeedRepositoryInterface
+
+	// Daily briefings (see feed_briefing.go)
+	briefingRepo       FeedBriefingRepositoryInterface
+	briefingSummarizer FeedBriefingSummarizer
 }
 
 // NewFeedHandler creates a new FeedHandler.
@@ -73,27 +83,33 @@ func writeFeedJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
-// writeFeedError writes an error JSON response.
-func writeFeedError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 // Feed handles GET /v1/feed - recent activity.
 // Per SPEC.md Part 5.6: GET /feed -> Recent activity
 // Returns recent posts and answers, union ordered by created_at DESC.
+// scope=following (auth required) ranks posts matching the caller's followed
+// tags and bookmarked posts higher.
 func (h *FeedHandler) Feed(w http.ResponseWriter, r *http.Request) {
 	page, perPage := parseFeedPagination(r)
 
-	items, total, err := h.repo.GetRecentActivity(r.Context(), page, perPage)
+	var items []models.FeedItem
+	var total int
+	var err error
+	switch r.URL.Query().Get("scope") {
+	case "", "all":
+		items, total, err = h.repo.GetRecentActivity(r.Context(), page, perPage)
+	case "following":
+		authInfo := GetAuthInfo(r)
+		if authInfo == nil {
+			apierror.Write(w, apierror.Unauthorized, "authentication required for scope=following")
+			return
+		}
+		items, total, err = h.repo.GetFollowingFeed(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, page, perPage)
+	default:
+		apierror.Write(w, apierror.ValidationError, "scope must be 'all' or 'following'")
+		return
+	}
 	if err != nil {
-		writeFeedError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get feed")
+		apierror.Write(w, apierror.InternalError, "failed to get feed")
 		return
 	}
 
@@ -123,7 +139,7 @@ func (h *FeedHandler) Stuck(w http.ResponseWriter, r *http.Request) {
 
 	items, total, err := h.repo.GetStuckProblems(r.Context(), page, perPage)
 	if err != nil {
-		writeFeedError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get stuck problems")
+		apierror.Write(w, apierror.InternalError, "failed to get stuck problems")
 		return
 	}
 
@@ -153,7 +169,7 @@ func (h *FeedHandler) Unanswered(w http.ResponseWriter, r *http.Request) {
 
 	items, total, err := h.repo.GetUnansweredQuestions(r.Context(), page, perPage)
 	if err != nil {
-		writeFeedError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get unanswered questions")
+		apierror.Write(w, apierror.InternalError, "failed to get unanswered questions")
 		return
 	}
 
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// quietBriefingSummary is the summary of a briefing with nothing in it; no model
+// is asked for it.
+const quietBriefingSummary = "Nothing new in your followed tags in the last day."
+
+// FeedBriefingRepositoryInterface gathers and caches daily feed briefings.
+type FeedBriefingRepositoryInterface interface {
+	// GetBriefingSections returns the follower's followed tags and the posts for
+	// their briefing since the given time, at most limit per section.
+	GetBriefingSections(ctx context.Context, followerType, followerID string, since time.Time, limit int) (*models.FeedBriefing, error)
+	// FindBriefing returns nil if no briefing is cached for the date.
+	FindBriefing(ctx context.Context, followerType, followerID, date string) (*models.FeedBriefing, error)
+	SaveBriefing(ctx context.Context, followerType, followerID string, b *models.FeedBriefing) error
+}
+
+// FeedBriefingSummarizer writes the summary of a briefing.
+type FeedBriefingSummarizer interface {
+	SummarizeBriefing(ctx context.Context, b *models.FeedBriefing) (string, error)
+}
+
+// SetBriefingRepository enables GET /v1/feed/briefing.
+func (h *FeedHandler) SetBriefingRepository(repo FeedBriefingRepositoryInterface) {
+	h.briefingRepo = repo
+}
+
+// SetBriefingSummarizer adds the generated summary to briefings. Without it
+// briefings list the posts only.
+func (h *FeedHandler) SetBriefingSummarizer(s FeedBriefingSummarizer) {
+	h.briefingSummarizer = s
+}
+
+// Briefing handles GET /v1/feed/briefing - one compact daily update for the
+// caller: the last day's new problems, solved problems and answered questions,
+// and trending questions in their followed tags (all tags if they follow none),
+// with a generated summary. Summarized briefings are cached for the rest of the
+// UTC day; if the summary fails the posts are still returned, uncached.
+func (h *FeedHandler) Briefing(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if h.briefingRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "feed briefings are not available")
+		return
+	}
+
+	ctx := r.Context()
+	followerType := string(authInfo.AuthorType)
+	now := time.Now().UTC()
+	date := now.Format(time.DateOnly)
+
+	cached, err := h.briefingRepo.FindBriefing(ctx, followerType, authInfo.AuthorID, date)
+	if err != nil {
+		slog.Warn("feed briefing: failed to read cache", "error", err, "follower_id", authInfo.AuthorID)
+	}
+	if cached != nil {
+		writeFeedJSON(w, http.StatusOK, map[string]interface{}{"data": cached})
+		return
+	}
+
+	briefing, err := h.briefingRepo.GetBriefingSections(ctx, followerType, authInfo.AuthorID, now.Add(-24*time.Hour), models.FeedBriefingSectionLimit)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get feed briefing")
+		return
+	}
+	briefing.Date = date
+	briefing.GeneratedAt = now
+
+	switch {
+	case len(briefing.NewProblems)+len(briefing.SolvedPosts)+len(briefing.TrendingQuestions) == 0:
+		briefing.Summary = quietBriefingSummary
+	case h.briefingSummarizer != nil:
+		summary, err := h.briefingSummarizer.SummarizeBriefing(ctx, briefing)
+		if err != nil {
+			slog.Warn("feed briefing: summary failed", "error", err, "follower_id", authInfo.AuthorID)
+		}
+		briefing.Summary = summary
+	}
+	if briefing.Summary != "" {
+		if err := h.briefingRepo.SaveBriefing(ctx, followerType, authInfo.AuthorID, briefing); err != nil {
+			slog.Warn("feed briefing: failed to cache", "error", err, "follower_id", authInfo.AuthorID)
+		}
+	}
+
+	writeFeedJSON(w, http.StatusOK, map[string]interface{}{"data": briefing})
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockFeedBriefingRepo struct {
+	sections *models.FeedBriefing
+	cached   map[string]*models.FeedBriefing
+	since    time.Time
+	calls    int
+}
+
+func (m *mockFeedBriefingRepo) GetBriefingSections(ctx context.Context, followerType, followerID string, since time.Time, limit int) (*models.FeedBriefing, error) {
+	m.since = since
+	m.calls++
+	b := *m.sections
+	return &b, nil
+}
+
+func (m *mockFeedBriefingRepo) FindBriefing(ctx context.Context, followerType, followerID, date string) (*models.FeedBriefing, error) {
+	return m.cached[followerType+":"+followerID+":"+date], nil
+}
+
+func (m *mockFeedBriefingRepo) SaveBriefing(ctx context.Context, followerType, followerID string, b *models.FeedBriefing) error {
+	if m.cached == nil {
+		m.cached = map[string]*models.FeedBriefing{}
+	}
+	m.cached[followerType+":"+followerID+":"+b.Date] = b
+	return nil
+}
+
+type mockFeedBriefingSummarizer struct {
+	summary string
+	err     error
+	calls   int
+}
+
+func (m *mockFeedBriefingSummarizer) SummarizeBriefing(ctx context.Context, b *models.FeedBriefing) (string, error) {
+	m.calls++
+	return m.summary, m.err
+}
+
+func getBriefing(t *testing.T, h *FeedHandler) (int, models.FeedBriefing) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/v1/feed/briefing", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_briefing"}))
+	rr := httptest.NewRecorder()
+	h.Briefing(rr, req)
+
+	var resp struct {
+		Data models.FeedBriefing `json:"data"`
+	}
+	if rr.Code == http.StatusOK {
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+	}
+	return rr.Code, resp.Data
+}
+
+func TestFeedBriefing_SummarizesAndCachesPerDay(t *testing.T) {
+	repo := &mockFeedBriefingRepo{sections: &models.FeedBriefing{
+		Tags:        []string{"go"},
+		NewProblems: []models.FeedItem{createTestFeedItem("p1", "pgx pool exhausted", "problem", "open")},
+	}}
+	summarizer := &mockFeedBriefingSummarizer{summary: "One new pgx problem."}
+	h := NewFeedHandler(&MockFeedRepository{})
+	h.SetBriefingRepository(repo)
+	h.SetBriefingSummarizer(summarizer)
+
+	code, b := getBriefing(t, h)
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if b.Summary != "One new pgx problem." || len(b.NewProblems) != 1 || b.Date != time.Now().UTC().Format(time.DateOnly) {
+		t.Errorf("unexpected briefing %+v", b)
+	}
+	if since := time.Since(repo.since); since < 23*time.Hour || since > 25*time.Hour {
+		t.Errorf("sections since %s ago, want the last day", since)
+	}
+
+	// The second call of the day is served from the cache.
+	if code, b = getBriefing(t, h); code != http.StatusOK || b.Summary != "One new pgx problem." {
+		t.Fatalf("cached briefing: %d %+v", code, b)
+	}
+	if repo.calls != 1 || summarizer.calls != 1 {
+		t.Errorf("expected one generation, got %d section queries and %d summaries", repo.calls, summarizer.calls)
+	}
+}
+
+func TestFeedBriefing_SummaryFailureIsNotCached(t *testing.T) {
+	repo := &mockFeedBriefingRepo{sections: &models.FeedBriefing{
+		TrendingQuestions: []models.FeedItem{createTestFeedItem("q1", "How to size a pool?", "question", "open")},
+	}}
+	h := NewFeedHandler(&MockFeedRepository{})
+	h.SetBriefingRepository(repo)
+	h.SetBriefingSummarizer(&mockFeedBriefingSummarizer{err: errors.New("groq down")})
+
+	code, b := getBriefing(t, h)
+	if code != http.StatusOK || b.Summary != "" || len(b.TrendingQuestions) != 1 {
+		t.Fatalf("expected the posts without a summary, got %d %+v", code, b)
+	}
+	if len(repo.cached) != 0 {
+		t.Error("a briefing without a summary should not be cached")
+	}
+}
+
+func TestFeedBriefing_QuietDaySkipsTheModel(t *testing.T) {
+	summarizer := &mockFeedBriefingSummarizer{summary: "unused"}
+	h := NewFeedHandler(&MockFeedRepository{})
+	h.SetBriefingRepository(&mockFeedBriefingRepo{sections: &models.FeedBriefing{}})
+	h.SetBriefingSummarizer(summarizer)
+
+	code, b := getBriefing(t, h)
+	if code != http.StatusOK || b.Summary != quietBriefingSummary || summarizer.calls != 0 {
+		t.Errorf("expected the quiet summary without a model call, got %d %+v (%d calls)", code, b, summarizer.calls)
+	}
+}
+
+func TestFeedBriefing_RequiresAuth(t *testing.T) {
+	h := NewFeedHandler(&MockFeedRepository{})
+	h.SetBriefingRepository(&mockFeedBriefingRepo{})
+
+	rr := httptest.NewRecorder()
+	h.Briefing(rr, httptest.NewRequest(http.MethodGet, "/v1/feed/briefing", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rr.Code)
+	}
+}
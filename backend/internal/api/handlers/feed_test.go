@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -28,6 +29,13 @@ type MockFeedRepository struct {
 	unansweredQuestions      []models.FeedItem
 	unansweredQuestionsTotal int
 	unansweredQuestionsErr   error
+
+	// GetFollowingFeed returns
+	followingItems        []models.FeedItem
+	followingTotal        int
+	followingErr          error
+	followingFollowerType string
+	followingFollowerID   string
 }
 
 func (m *MockFeedRepository) GetRecentActivity(ctx context.Context, page, perPage int) ([]models.FeedItem, int, error) {
@@ -42,6 +50,12 @@ func (m *MockFeedRepository) GetUnansweredQuestions(ctx context.Context, page, p
 	return m.unansweredQuestions, m.unansweredQuestionsTotal, m.unansweredQuestionsErr
 }
 
+func (m *MockFeedRepository) GetFollowingFeed(ctx context.Context, followerType, followerID string, page, perPage int) ([]models.FeedItem, int, error) {
+	m.followingFollowerType = followerType
+	m.followingFollowerID = followerID
+	return m.followingItems, m.followingTotal, m.followingErr
+}
+
 func createTestFeedItem(id, title, itemType, status string) models.FeedItem {
 	return models.FeedItem{
 		ID:          id,
@@ -276,6 +290,61 @@ func TestFeed_RecentActivity_IncludesAllTypes(t *testing.T) {
 	}
 }
 
+func TestFeed_ScopeFollowing_UsesCaller(t *testing.T) {
+	mockRepo := &MockFeedRepository{
+		followingItems: []models.FeedItem{createTestFeedItem("p1", "Followed Tag Post", "problem", "open")},
+		followingTotal: 1,
+	}
+	handler := NewFeedHandler(mockRepo)
+
+	req := httptest.NewRequest("GET", "/v1/feed?scope=following", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent-1"}))
+	w := httptest.NewRecorder()
+
+	handler.Feed(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if mockRepo.followingFollowerType != "agent" || mockRepo.followingFollowerID != "agent-1" {
+		t.Errorf("expected follower agent/agent-1, got %s/%s", mockRepo.followingFollowerType, mockRepo.followingFollowerID)
+	}
+
+	var response models.FeedResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Data) != 1 || response.Data[0].ID != "p1" {
+		t.Errorf("expected following feed item p1, got %+v", response.Data)
+	}
+}
+
+func TestFeed_ScopeFollowing_RequiresAuth(t *testing.T) {
+	handler := NewFeedHandler(&MockFeedRepository{})
+
+	req := httptest.NewRequest("GET", "/v1/feed?scope=following", nil)
+	w := httptest.NewRecorder()
+
+	handler.Feed(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+func TestFeed_InvalidScope(t *testing.T) {
+	handler := NewFeedHandler(&MockFeedRepository{})
+
+	req := httptest.NewRequest("GET", "/v1/feed?scope=friends", nil)
+	w := httptest.NewRecorder()
+
+	handler.Feed(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
 // =========================================================================
 // GET /v1/feed/stuck - Stuck problems tests
 // =========================================================================
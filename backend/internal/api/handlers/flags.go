@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/google/uuid"
@@ -47,25 +48,25 @@ func (h *FlagsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Require authentication
 	claims := auth.ClaimsFromContext(r.Context())
 	if claims == nil {
-		writeFlagsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse request body
 	var req CreateFlagRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeFlagsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	// Validate target_type
 	targetType := strings.TrimSpace(req.TargetType)
 	if targetType == "" {
-		writeFlagsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "target_type is required")
+		apierror.Write(w, apierror.ValidationError, "target_type is required")
 		return
 	}
 	if !isValidFlagTargetType(targetType) {
-		writeFlagsError(w, http.StatusBadRequest, "VALIDATION_ERROR",
+		apierror.Write(w, apierror.ValidationError,
 			"invalid target_type, must be one of: post, comment, answer, approach, response")
 		return
 	}
@@ -73,22 +74,22 @@ func (h *FlagsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Validate target_id
 	targetID := strings.TrimSpace(req.TargetID)
 	if targetID == "" {
-		writeFlagsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "target_id is required")
+		apierror.Write(w, apierror.ValidationError, "target_id is required")
 		return
 	}
 	if _, err := uuid.Parse(targetID); err != nil {
-		writeFlagsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "target_id must be a valid UUID")
+		apierror.Write(w, apierror.ValidationError, "target_id must be a valid UUID")
 		return
 	}
 
 	// Validate reason
 	reason := strings.TrimSpace(req.Reason)
 	if reason == "" {
-		writeFlagsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "reason is required")
+		apierror.Write(w, apierror.ValidationError, "reason is required")
 		return
 	}
 	if !models.IsValidFlagReason(reason) {
-		writeFlagsError(w, http.StatusBadRequest, "VALIDATION_ERROR",
+		apierror.Write(w, apierror.ValidationError,
 			"invalid reason, must be one of: spam, offensive, duplicate, incorrect, low_quality, other")
 		return
 	}
@@ -103,22 +104,22 @@ func (h *FlagsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Check if target exists
 	exists, err := h.repo.TargetExists(r.Context(), targetType, targetID)
 	if err != nil {
-		writeFlagsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to verify target")
+		apierror.Write(w, apierror.InternalError, "failed to verify target")
 		return
 	}
 	if !exists {
-		writeFlagsError(w, http.StatusNotFound, "NOT_FOUND", "target not found")
+		apierror.Write(w, apierror.NotFound, "target not found")
 		return
 	}
 
 	// Check for duplicate flag
 	duplicate, err := h.repo.FlagExists(r.Context(), targetType, targetID, reporterType, reporterID)
 	if err != nil {
-		writeFlagsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to check for duplicate flag")
+		apierror.Write(w, apierror.InternalError, "failed to check for duplicate flag")
 		return
 	}
 	if duplicate {
-		writeFlagsError(w, http.StatusConflict, "DUPLICATE_FLAG", "you have already flagged this content")
+		apierror.Write(w, apierror.DuplicateFlag, "you have already flagged this content")
 		return
 	}
 
@@ -138,7 +139,7 @@ func (h *FlagsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	createdFlag, err := h.repo.CreateFlag(r.Context(), flag)
 	if err != nil {
-		writeFlagsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create flag")
+		apierror.Write(w, apierror.InternalError, "failed to create flag")
 		return
 	}
 
@@ -163,15 +164,3 @@ func writeFlagsJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-// writeFlagsError writes an error JSON response.
-func writeFlagsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
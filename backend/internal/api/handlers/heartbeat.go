@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
@@ -129,14 +130,7 @@ func (h *HeartbeatHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "UNAUTHORIZED",
-			"message": "authentication required",
-		},
-	})
+	apierror.Write(w, apierror.Unauthorized, "authentication required")
 }
 
 func (h *HeartbeatHandler) handleAgentHeartbeat(w http.ResponseWriter, ctx context.Context, agent *models.Agent) {
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db/memdb"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Benchmarks for the hot endpoints' handler overhead (parsing, validation, JSON
+// encoding) with in-memory repositories. Query cost is covered by the db package
+// benchmarks and end-to-end latency by cmd/loadgen.
+//
+// Run: go test ./internal/api/handlers/ -run='^$' -bench=HotEndpoint -benchmem
+
+// newBenchPostsStore returns a store holding n open posts spread over the three
+// post types.
+func newBenchPostsStore(b *testing.B, n int) *memdb.Store {
+	b.Helper()
+	store := memdb.NewStore()
+	types := []models.PostType{models.PostTypeProblem, models.PostTypeQuestion, models.PostTypeIdea}
+	for i := 0; i < n; i++ {
+		_, err := store.Posts().Create(context.Background(), &models.Post{
+			Type:         types[i%len(types)],
+			Title:        fmt.Sprintf("Bench post %d about connection timeouts", i),
+			Description:  fmt.Sprintf("Benchmark post %d with a description long enough to look like real content.", i),
+			Tags:         []string{"bench", "perf"},
+			PostedByType: models.AuthorTypeAgent,
+			PostedByID:   fmt.Sprintf("bench_agent_%d", i%10),
+			Status:       models.PostStatusOpen,
+			Upvotes:      i % 50,
+		})
+		if err != nil {
+			b.Fatalf("create bench post: %v", err)
+		}
+	}
+	return store
+}
+
+func BenchmarkHotEndpoint_ListPosts(b *testing.B) {
+	handler := NewPostsHandler(newBenchPostsStore(b, 1000).Posts())
+
+	for _, sort := range []string{"newest", "votes", "hot"} {
+		b.Run(sort, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/v1/posts?per_page=20&sort="+sort, nil)
+				w := httptest.NewRecorder()
+				handler.List(w, req)
+				if w.Code != http.StatusOK {
+					b.Fatalf("List() status = %d", w.Code)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHotEndpoint_CreatePost(b *testing.B) {
+	handler := NewPostsHandler(memdb.NewStore().Posts())
+	body := []byte(`{"type":"problem","title":"Connection timeout under load",` +
+		`"description":"The service times out when more than a hundred clients connect at once.","tags":["go","postgres"]}`)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/v1/posts", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = addAuthContext(req, "user-123", "user")
+		w := httptest.NewRecorder()
+		handler.Create(w, req)
+		if w.Code != http.StatusCreated {
+			b.Fatalf("Create() status = %d: %s", w.Code, w.Body.String())
+		}
+	}
+}
+
+// benchSearchRepo returns the same page of results for every query.
+type benchSearchRepo struct {
+	results []models.SearchResult
+}
+
+func (r *benchSearchRepo) Search(ctx context.Context, query string, opts models.SearchOptions) ([]models.SearchResult, int, string, *float64, error) {
+	return r.results, 500, "fulltext_only", nil, nil
+}
+
+func BenchmarkHotEndpoint_Search(b *testing.B) {
+	repo := &benchSearchRepo{}
+	for i := 0; i < 20; i++ {
+		repo.results = append(repo.results, models.SearchResult{
+			ID:          fmt.Sprintf("post-%d", i),
+			Type:        "problem",
+			Title:       fmt.Sprintf("Bench result %d about connection timeouts", i),
+			Description: "A description long enough to look like real content, repeated for every result.",
+			Snippet:     "connection <mark>timeout</mark> when more than a hundred clients connect",
+			Tags:        []string{"bench", "perf"},
+			Status:      "open",
+			AuthorID:    "bench_agent",
+			AuthorType:  "agent",
+			AuthorName:  "Bench Agent",
+			Score:       0.5,
+			CreatedAt:   time.Now(),
+			Source:      "post",
+		})
+	}
+	handler := NewSearchHandler(repo)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/v1/search?q=connection+timeout", nil)
+		w := httptest.NewRecorder()
+		handler.Search(w, req)
+		if w.Code != http.StatusOK {
+			b.Fatalf("Search() status = %d", w.Code)
+		}
+	}
+}
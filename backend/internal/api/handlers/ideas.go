@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
@@ -42,6 +43,8 @@ type IdeasRepositoryInterface interface {
 type IdeasHandler struct {
 	repo      IdeasRepositoryInterface
 	postsRepo PostsRepositoryInterface // For listing ideas (shares data with /v1/posts)
+
+	contentLimits contentLimitsSource // see content_limits.go
 }
 
 // NewIdeasHandler creates a new IdeasHandler.
@@ -64,7 +67,7 @@ func (h *IdeasHandler) findIdea(ctx context.Context, id string) (*models.PostWit
 	// First try postsRepo if available (this is where POST /v1/posts stores ideas)
 	if h.postsRepo != nil {
 		// BART-151: family-scoped — a family caller sees its own private idea; others 404.
-		idea, err := h.postsRepo.FindByIDForViewer(ctx, id, "", "", callerHumanFromCtx(ctx))
+		idea, err := h.postsRepo.FindByIDForViewer(ctx, id)
 		if err == nil {
 			// Verify it's actually an idea
 			if idea.Type != models.PostTypeIdea {
@@ -174,7 +177,7 @@ func (h *IdeasHandler) List(w http.ResponseWriter, r *http.Request) {
 		ideas, total, err = h.repo.ListIdeas(r.Context(), opts)
 	}
 	if err != nil {
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list ideas")
+		apierror.Write(w, apierror.InternalError, "failed to list ideas")
 		return
 	}
 
@@ -199,7 +202,7 @@ func (h *IdeasHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *IdeasHandler) Get(w http.ResponseWriter, r *http.Request) {
 	ideaID := chi.URLParam(r, "id")
 	if ideaID == "" {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "idea ID is required")
+		apierror.Write(w, apierror.ValidationError, "idea ID is required")
 		return
 	}
 
@@ -207,10 +210,10 @@ func (h *IdeasHandler) Get(w http.ResponseWriter, r *http.Request) {
 	idea, err := h.findIdea(r.Context(), ideaID)
 	if err != nil {
 		if errors.Is(err, ErrIdeaNotFound) {
-			writeIdeasError(w, http.StatusNotFound, "NOT_FOUND", "idea not found")
+			apierror.Write(w, apierror.NotFound, "idea not found")
 			return
 		}
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get idea")
+		apierror.Write(w, apierror.InternalError, "failed to get idea")
 		return
 	}
 
@@ -223,7 +226,7 @@ func (h *IdeasHandler) Get(w http.ResponseWriter, r *http.Request) {
 		PerPage: 100, // Get up to 100 responses
 	})
 	if err != nil {
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get responses")
+		apierror.Write(w, apierror.InternalError, "failed to get responses")
 		return
 	}
 
@@ -249,7 +252,7 @@ type ResponsesListResponse struct {
 func (h *IdeasHandler) ListResponses(w http.ResponseWriter, r *http.Request) {
 	ideaID := chi.URLParam(r, "id")
 	if ideaID == "" {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "idea ID is required")
+		apierror.Write(w, apierror.ValidationError, "idea ID is required")
 		return
 	}
 
@@ -257,10 +260,10 @@ func (h *IdeasHandler) ListResponses(w http.ResponseWriter, r *http.Request) {
 	_, err := h.findIdea(r.Context(), ideaID)
 	if err != nil {
 		if errors.Is(err, ErrIdeaNotFound) {
-			writeIdeasError(w, http.StatusNotFound, "NOT_FOUND", "idea not found")
+			apierror.Write(w, apierror.NotFound, "idea not found")
 			return
 		}
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get idea")
+		apierror.Write(w, apierror.InternalError, "failed to get idea")
 		return
 	}
 
@@ -286,7 +289,7 @@ func (h *IdeasHandler) ListResponses(w http.ResponseWriter, r *http.Request) {
 	// Execute query
 	responses, total, err := h.repo.ListResponses(r.Context(), ideaID, opts)
 	if err != nil {
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list responses")
+		apierror.Write(w, apierror.InternalError, "failed to list responses")
 		return
 	}
 
@@ -312,44 +315,42 @@ func (h *IdeasHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeIdeasError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse request body
 	var req CreateIdeaRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
-	// Validate title
+	// Validate title and description against the configured limits
 	if req.Title == "" {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title is required")
-		return
-	}
-	if len(req.Title) < 10 {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at least 10 characters")
+		apierror.Write(w, apierror.ValidationError, "title is required")
 		return
 	}
-	if len(req.Title) > 200 {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at most 200 characters")
-		return
-	}
-
-	// Validate description
 	if req.Description == "" {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description is required")
+		apierror.Write(w, apierror.ValidationError, "description is required")
 		return
 	}
-	if len(req.Description) < 50 {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description must be at least 50 characters")
+	var lengthCheck Validator
+	validatePostLengths(&lengthCheck, h.contentLimits.For(models.PostTypeIdea), &req.Title, &req.Description, nil)
+	if !lengthCheck.Valid() {
+		apierror.Write(w, apierror.ValidationError, lengthCheck.Errors()[0].Message)
 		return
 	}
 
 	// Validate tags
 	if len(req.Tags) > models.MaxTagsPerPost {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("maximum %d tags allowed", models.MaxTagsPerPost))
+		apierror.Write(w, apierror.ValidationError, fmt.Sprintf("maximum %d tags allowed", models.MaxTagsPerPost))
+		return
+	}
+	var tagCheck Validator
+	req.Tags = validateTags(&tagCheck, req.Tags)
+	if !tagCheck.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, tagCheck.Errors())
 		return
 	}
 
@@ -366,7 +367,7 @@ func (h *IdeasHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	createdPost, err := h.repo.CreateIdea(r.Context(), post)
 	if err != nil {
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create idea")
+		apierror.Write(w, apierror.InternalError, "failed to create idea")
 		return
 	}
 
@@ -382,13 +383,13 @@ func (h *IdeasHandler) CreateResponse(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeIdeasError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	ideaID := chi.URLParam(r, "id")
 	if ideaID == "" {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "idea ID is required")
+		apierror.Write(w, apierror.ValidationError, "idea ID is required")
 		return
 	}
 
@@ -396,10 +397,10 @@ func (h *IdeasHandler) CreateResponse(w http.ResponseWriter, r *http.Request) {
 	_, err := h.findIdea(r.Context(), ideaID)
 	if err != nil {
 		if errors.Is(err, ErrIdeaNotFound) {
-			writeIdeasError(w, http.StatusNotFound, "NOT_FOUND", "idea not found")
+			apierror.Write(w, apierror.NotFound, "idea not found")
 			return
 		}
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get idea")
+		apierror.Write(w, apierror.InternalError, "failed to get idea")
 		return
 	}
 
@@ -408,23 +409,23 @@ func (h *IdeasHandler) CreateResponse(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req models.CreateResponseRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	// Validate content
 	if req.Content == "" {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content is required")
+		apierror.Write(w, apierror.ValidationError, "content is required")
 		return
 	}
 	if len(req.Content) > 10000 {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content must be at most 10000 characters")
+		apierror.Write(w, apierror.ValidationError, "content must be at most 10000 characters")
 		return
 	}
 
 	// Validate response type
 	if !models.IsValidResponseType(req.ResponseType) {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "response_type must be one of: build, critique, expand, question, support")
+		apierror.Write(w, apierror.ValidationError, "response_type must be one of: build, critique, expand, question, support")
 		return
 	}
 
@@ -439,7 +440,7 @@ func (h *IdeasHandler) CreateResponse(w http.ResponseWriter, r *http.Request) {
 
 	createdResponse, err := h.repo.CreateResponse(r.Context(), response)
 	if err != nil {
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create response")
+		apierror.Write(w, apierror.InternalError, "failed to create response")
 		return
 	}
 
@@ -455,14 +456,14 @@ func (h *IdeasHandler) Evolve(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeIdeasError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 	_ = authInfo // Used for authentication check
 
 	ideaID := chi.URLParam(r, "id")
 	if ideaID == "" {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "idea ID is required")
+		apierror.Write(w, apierror.ValidationError, "idea ID is required")
 		return
 	}
 
@@ -470,10 +471,10 @@ func (h *IdeasHandler) Evolve(w http.ResponseWriter, r *http.Request) {
 	_, err := h.findIdea(r.Context(), ideaID)
 	if err != nil {
 		if errors.Is(err, ErrIdeaNotFound) {
-			writeIdeasError(w, http.StatusNotFound, "NOT_FOUND", "idea not found")
+			apierror.Write(w, apierror.NotFound, "idea not found")
 			return
 		}
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get idea")
+		apierror.Write(w, apierror.InternalError, "failed to get idea")
 		return
 	}
 
@@ -482,13 +483,13 @@ func (h *IdeasHandler) Evolve(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req EvolveRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	// Validate evolved_post_id
 	if req.EvolvedPostID == "" {
-		writeIdeasError(w, http.StatusBadRequest, "VALIDATION_ERROR", "evolved_post_id is required")
+		apierror.Write(w, apierror.ValidationError, "evolved_post_id is required")
 		return
 	}
 
@@ -496,16 +497,16 @@ func (h *IdeasHandler) Evolve(w http.ResponseWriter, r *http.Request) {
 	_, err = h.repo.FindPostByID(r.Context(), req.EvolvedPostID)
 	if err != nil {
 		if errors.Is(err, ErrIdeaNotFound) {
-			writeIdeasError(w, http.StatusNotFound, "NOT_FOUND", "evolved post not found")
+			apierror.Write(w, apierror.NotFound, "evolved post not found")
 			return
 		}
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get evolved post")
+		apierror.Write(w, apierror.InternalError, "failed to get evolved post")
 		return
 	}
 
 	// Add evolved link
 	if err := h.repo.AddEvolvedInto(r.Context(), ideaID, req.EvolvedPostID); err != nil {
-		writeIdeasError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to add evolved link")
+		apierror.Write(w, apierror.InternalError, "failed to add evolved link")
 		return
 	}
 
@@ -534,15 +535,3 @@ func writeIdeasJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-// writeIdeasError writes an error JSON response.
-func writeIdeasError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
@@ -51,12 +52,12 @@ func (h *IncidentAdminHandler) CreateIncident(w http.ResponseWriter, r *http.Req
 
 	var req createIncidentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeIncidentAdminError(w, http.StatusBadRequest, "INVALID_JSON", "invalid JSON body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
 		return
 	}
 
 	if req.ID == "" || req.Title == "" {
-		writeIncidentAdminError(w, http.StatusBadRequest, "MISSING_FIELDS", "id and title are required")
+		apierror.Write(w, apierror.MissingFields, "id and title are required")
 		return
 	}
 
@@ -74,7 +75,7 @@ func (h *IncidentAdminHandler) CreateIncident(w http.ResponseWriter, r *http.Req
 	}
 
 	if err := h.repo.Create(r.Context(), incident); err != nil {
-		writeIncidentAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create incident")
+		apierror.Write(w, apierror.InternalError, "failed to create incident")
 		return
 	}
 
@@ -96,23 +97,23 @@ func (h *IncidentAdminHandler) UpdateIncidentStatus(w http.ResponseWriter, r *ht
 
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		writeIncidentAdminError(w, http.StatusBadRequest, "MISSING_ID", "incident ID required")
+		apierror.Write(w, apierror.MissingID, "incident ID required")
 		return
 	}
 
 	var req updateIncidentStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeIncidentAdminError(w, http.StatusBadRequest, "INVALID_JSON", "invalid JSON body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
 		return
 	}
 
 	if req.Status == "" {
-		writeIncidentAdminError(w, http.StatusBadRequest, "MISSING_STATUS", "status is required")
+		apierror.Write(w, apierror.MissingStatus, "status is required")
 		return
 	}
 
 	if err := h.repo.UpdateStatus(r.Context(), id, req.Status); err != nil {
-		writeIncidentAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update incident")
+		apierror.Write(w, apierror.InternalError, "failed to update incident")
 		return
 	}
 
@@ -133,18 +134,18 @@ func (h *IncidentAdminHandler) AddIncidentUpdate(w http.ResponseWriter, r *http.
 
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		writeIncidentAdminError(w, http.StatusBadRequest, "MISSING_ID", "incident ID required")
+		apierror.Write(w, apierror.MissingID, "incident ID required")
 		return
 	}
 
 	var req addIncidentUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeIncidentAdminError(w, http.StatusBadRequest, "INVALID_JSON", "invalid JSON body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid JSON body")
 		return
 	}
 
 	if req.Status == "" || req.Message == "" {
-		writeIncidentAdminError(w, http.StatusBadRequest, "MISSING_FIELDS", "status and message are required")
+		apierror.Write(w, apierror.MissingFields, "status and message are required")
 		return
 	}
 
@@ -155,7 +156,7 @@ func (h *IncidentAdminHandler) AddIncidentUpdate(w http.ResponseWriter, r *http.
 	}
 
 	if err := h.repo.AddUpdate(r.Context(), update); err != nil {
-		writeIncidentAdminError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to add incident update")
+		apierror.Write(w, apierror.InternalError, "failed to add incident update")
 		return
 	}
 
@@ -173,28 +174,17 @@ func (h *IncidentAdminHandler) AddIncidentUpdate(w http.ResponseWriter, r *http.
 func checkIncidentAdminAuth(w http.ResponseWriter, r *http.Request) bool {
 	adminKey := os.Getenv("ADMIN_API_KEY")
 	if adminKey == "" {
-		writeIncidentAdminError(w, http.StatusServiceUnavailable, "ADMIN_NOT_CONFIGURED", "admin API key not configured")
+		apierror.Write(w, apierror.AdminNotConfigured, "admin API key not configured")
 		return false
 	}
 	providedKey := r.Header.Get("X-Admin-API-Key")
 	if providedKey == "" {
-		writeIncidentAdminError(w, http.StatusUnauthorized, "MISSING_API_KEY", "X-Admin-API-Key header required")
+		apierror.Write(w, apierror.MissingAPIKey, "X-Admin-API-Key header required")
 		return false
 	}
 	if providedKey != adminKey {
-		writeIncidentAdminError(w, http.StatusForbidden, "INVALID_API_KEY", "invalid admin API key")
+		apierror.WriteStatus(w, http.StatusForbidden, apierror.InvalidAPIKey, "invalid admin API key")
 		return false
 	}
 	return true
 }
-
-func writeIncidentAdminError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]any{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
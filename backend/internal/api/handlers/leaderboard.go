@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -79,7 +80,7 @@ func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Reque
 	// Fetch leaderboard data
 	entries, total, err := h.repo.GetLeaderboard(r.Context(), opts)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to fetch leaderboard")
+		apierror.Write(w, apierror.InternalError, "failed to fetch leaderboard")
 		return
 	}
 
@@ -107,7 +108,7 @@ func (h *LeaderboardHandler) GetLeaderboardByTag(w http.ResponseWriter, r *http.
 	// Extract tag from URL path parameter
 	tag := r.PathValue("tag")
 	if tag == "" {
-		writeError(w, http.StatusBadRequest, "INVALID_TAG", "tag parameter is required")
+		apierror.Write(w, apierror.InvalidTag, "tag parameter is required")
 		return
 	}
 
@@ -146,7 +147,7 @@ func (h *LeaderboardHandler) GetLeaderboardByTag(w http.ResponseWriter, r *http.
 	// Call repository
 	entries, total, err := h.repo.GetLeaderboardByTag(r.Context(), tag, opts)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to fetch leaderboard")
+		apierror.Write(w, apierror.InternalError, "failed to fetch leaderboard")
 		return
 	}
 
@@ -167,14 +168,3 @@ func (h *LeaderboardHandler) GetLeaderboardByTag(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(response)
 }
-
-// writeError writes a JSON error response.
-func writeError(w http.ResponseWriter, status int, code, message string) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
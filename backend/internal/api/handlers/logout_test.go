@@ -45,7 +45,7 @@ func TestLogout_Success(t *testing.T) {
 	handler := NewOAuthHandlersWithLogout(cfg, nil, mockDB, mockDB)
 
 	// Create valid JWT for authentication
-	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", 15*time.Minute)
+	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", "", 15*time.Minute)
 	if err != nil {
 		t.Fatalf("failed to generate JWT: %v", err)
 	}
@@ -101,7 +101,7 @@ func TestLogout_MissingRefreshToken(t *testing.T) {
 	handler := NewOAuthHandlersWithLogout(cfg, nil, mockDB, mockDB)
 
 	// Create valid JWT for authentication
-	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", 15*time.Minute)
+	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", "", 15*time.Minute)
 	if err != nil {
 		t.Fatalf("failed to generate JWT: %v", err)
 	}
@@ -153,7 +153,7 @@ func TestLogout_InvalidJSON(t *testing.T) {
 	handler := NewOAuthHandlersWithLogout(cfg, nil, mockDB, mockDB)
 
 	// Create valid JWT for authentication
-	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", 15*time.Minute)
+	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", "", 15*time.Minute)
 	if err != nil {
 		t.Fatalf("failed to generate JWT: %v", err)
 	}
@@ -194,7 +194,7 @@ func TestLogout_TokenNotFound(t *testing.T) {
 	handler := NewOAuthHandlersWithLogout(cfg, nil, mockDB, mockDB)
 
 	// Create valid JWT for authentication
-	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", 15*time.Minute)
+	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", "", 15*time.Minute)
 	if err != nil {
 		t.Fatalf("failed to generate JWT: %v", err)
 	}
@@ -303,7 +303,7 @@ func TestLogout_SubsequentRefreshFails(t *testing.T) {
 	refreshHandler := NewOAuthHandlersWithRefresh(cfg, nil, mockDB, mockUserRepo)
 
 	// Create valid JWT for authentication
-	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", 15*time.Minute)
+	jwt, err := auth.GenerateJWT(cfg.JWTSecret, "user-id-456", "test@example.com", "user", "", 15*time.Minute)
 	if err != nil {
 		t.Fatalf("failed to generate JWT: %v", err)
 	}
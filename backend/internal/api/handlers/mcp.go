@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
@@ -12,17 +13,32 @@ import (
 // MCPHandler handles MCP (Model Context Protocol) HTTP requests.
 // This implements MCP over HTTP transport per the MCP specification.
 type MCPHandler struct {
-	searchRepo          SearchRepositoryInterface
+	searchRepo          SearchBackend
 	postsRepo           PostsRepositoryInterface
 	confidenceThreshold float64
+	relatedFinder       RelatedContentFinder
+	approachWorkflow    ApproachWorkflow
+	contextBuilder      QuestionContextBuilder
+	crashDuplicates     CrashDuplicateFinder
+	rateLimiter         MCPRateLimiter
+	maintenance         MaintenanceStatusReader
+	sessions            *mcpSessionStore
+	contentLimits       contentLimitsSource // see content_limits.go
+}
+
+// RelatedContentFinder finds posts semantically close to a free-text problem description.
+// Implemented by db.SearchRepository when an embedding service is configured.
+type RelatedContentFinder interface {
+	FindRelated(ctx context.Context, text string, limit int) ([]models.RelatedPost, error)
 }
 
 // NewMCPHandler creates a new MCPHandler.
-func NewMCPHandler(searchRepo SearchRepositoryInterface, postsRepo PostsRepositoryInterface) *MCPHandler {
+func NewMCPHandler(searchRepo SearchBackend, postsRepo PostsRepositoryInterface) *MCPHandler {
 	return &MCPHandler{
 		searchRepo:          searchRepo,
 		postsRepo:           postsRepo,
 		confidenceThreshold: DefaultSearchConfidenceThreshold,
+		sessions:            newMCPSessionStore(),
 	}
 }
 
@@ -32,6 +48,18 @@ func (h *MCPHandler) SetConfidenceThreshold(threshold float64) {
 	h.confidenceThreshold = threshold
 }
 
+// SetCrashDuplicateFinder lets the solvr_post pre-check list posts with the same
+// stack trace as the draft.
+func (h *MCPHandler) SetCrashDuplicateFinder(finder CrashDuplicateFinder) {
+	h.crashDuplicates = finder
+}
+
+// SetRelatedFinder enables the solvr_related tool. When nil, the tool reports that
+// semantic lookup is unavailable.
+func (h *MCPHandler) SetRelatedFinder(finder RelatedContentFinder) {
+	h.relatedFinder = finder
+}
+
 // JSON-RPC 2.0 structures
 type jsonRPCRequest struct {
 	JSONRPC string                 `json:"jsonrpc"`
@@ -48,17 +76,20 @@ type jsonRPCResponse struct {
 }
 
 type rpcError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
 }
 
 // MCP server info
 var mcpServerInfo = map[string]interface{}{
 	"name":            "solvr",
 	"version":         "1.0.0",
-	"protocolVersion": "2024-11-05",
+	"protocolVersion": mcpProtocolVersions[0],
 	"capabilities": map[string]interface{}{
-		"tools": map[string]interface{}{},
+		"tools":     map[string]interface{}{},
+		"resources": map[string]interface{}{},
+		"prompts":   map[string]interface{}{},
 	},
 }
 
@@ -87,6 +118,24 @@ var mcpTools = []map[string]interface{}{
 			"required": []string{"query"},
 		},
 	},
+	{
+		"name":        "solvr_related",
+		"description": "Find Solvr posts semantically related to the problem you are working on, with a summary of how each was solved. Describe the problem in plain language (symptoms, error, context) and call this before attempting a fix.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"description": map[string]interface{}{
+					"type":        "string",
+					"description": "Free-text description of your current problem",
+				},
+				"limit": map[string]interface{}{
+					"type":        "number",
+					"description": "Maximum number of related posts to return (default: 5, max: 20)",
+				},
+			},
+			"required": []string{"description"},
+		},
+	},
 	{
 		"name":        "solvr_get",
 		"description": "Get full details of a Solvr post by ID, including approaches, answers, and comments.",
@@ -106,9 +155,27 @@ var mcpTools = []map[string]interface{}{
 			"required": []string{"id"},
 		},
 	},
+	{
+		"name":        "solvr_context",
+		"description": "Get everything needed to answer a Solvr question in one call: the question, its existing answers, crystallized solutions to related problems, and similar questions with accepted answers, trimmed to fit a token budget. Call this before solvr_answer.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"question_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the question to answer",
+				},
+				"max_tokens": map[string]interface{}{
+					"type":        "number",
+					"description": "Approximate size limit of the context (default: 8000, min: 1000, max: 32000)",
+				},
+			},
+			"required": []string{"question_id"},
+		},
+	},
 	{
 		"name":        "solvr_post",
-		"description": "Create a new problem, question, or idea on Solvr to share knowledge or get help.",
+		"description": "Create a new problem, question, or idea on Solvr to share knowledge or get help. Existing similar questions, and posts with the same stack trace, are listed so duplicates can be avoided.",
 		"inputSchema": map[string]interface{}{
 			"type": "object",
 			"properties": map[string]interface{}{
@@ -119,7 +186,7 @@ var mcpTools = []map[string]interface{}{
 				},
 				"title": map[string]interface{}{
 					"type":        "string",
-					"description": "Title of the post (max 200 characters)",
+					"description": "Title of the post (length limits per type: GET /v1/config/limits)",
 				},
 				"description": map[string]interface{}{
 					"type":        "string",
@@ -156,21 +223,93 @@ var mcpTools = []map[string]interface{}{
 			"required": []string{"post_id", "content"},
 		},
 	},
+	{
+		"name":        "solvr_approach",
+		"description": "Start an approach on a Solvr problem: declare the angle you are taking before you work on it. Requires authentication.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"problem_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the problem to work on",
+				},
+				"angle": map[string]interface{}{
+					"type":        "string",
+					"description": "The perspective or strategy you are taking (max 500 characters)",
+				},
+				"method": map[string]interface{}{
+					"type":        "string",
+					"description": "The specific technique you will use (max 500 characters)",
+				},
+				"assumptions": map[string]interface{}{
+					"type":        "array",
+					"description": "Assumptions the approach relies on (max 10)",
+					"items":       map[string]interface{}{"type": "string"},
+				},
+			},
+			"required": []string{"problem_id", "angle"},
+		},
+	},
+	{
+		"name":        "solvr_progress",
+		"description": "Append a progress note to one of your approaches so others can follow what you tried. Requires authentication.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"approach_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of your approach",
+				},
+				"content": map[string]interface{}{
+					"type":        "string",
+					"description": "What you did, found, or learned",
+				},
+			},
+			"required": []string{"approach_id", "content"},
+		},
+	},
+	{
+		"name":        "solvr_verify",
+		"description": "Verify that a succeeded approach solves your problem, marking the problem solved. Only the problem owner can verify. Requires authentication.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"approach_id": map[string]interface{}{
+					"type":        "string",
+					"description": "The ID of the approach to verify",
+				},
+				"verified": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Whether the approach solved the problem (default: true)",
+				},
+				"override_reason": map[string]interface{}{
+					"type":        "string",
+					"description": "Why the problem is solved although some success criteria are not met",
+				},
+			},
+			"required": []string{"approach_id"},
+		},
+	},
 }
 
-// Handle handles POST /mcp - MCP over HTTP transport.
+// Handle handles /mcp - MCP over the Streamable HTTP transport (see mcp_transport.go).
+// POST carries JSON-RPC messages, GET opens a session's event stream, and DELETE ends a session.
 func (h *MCPHandler) Handle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		h.writeRPCError(w, nil, -32600, "Method not allowed. Use POST.")
-		return
-	}
-
-	var req jsonRPCRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeRPCError(w, nil, -32700, "Parse error: "+err.Error())
-		return
+	switch r.Method {
+	case http.MethodPost:
+		h.handlePost(w, r)
+	case http.MethodGet:
+		h.handleStream(w, r)
+	case http.MethodDelete:
+		h.handleDeleteSession(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		h.writeRPCErrorStatus(w, http.StatusMethodNotAllowed, -32600, "Method not allowed. Use POST.")
 	}
+}
 
+// dispatch handles a single JSON-RPC request and writes its response to w.
+func (h *MCPHandler) dispatch(w http.ResponseWriter, ctx context.Context, req jsonRPCRequest) {
 	if req.JSONRPC != "2.0" {
 		h.writeRPCError(w, req.ID, -32600, "Invalid JSON-RPC version")
 		return
@@ -185,7 +324,17 @@ func (h *MCPHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	case "tools/list":
 		h.handleToolsList(w, req)
 	case "tools/call":
-		h.handleToolsCall(w, r.Context(), req)
+		h.handleToolsCall(w, ctx, req)
+	case "resources/list":
+		h.handleResourcesList(w, ctx, req)
+	case "resources/templates/list":
+		h.handleResourceTemplatesList(w, req)
+	case "resources/read":
+		h.handleResourcesRead(w, ctx, req)
+	case "prompts/list":
+		h.handlePromptsList(w, req)
+	case "prompts/get":
+		h.handlePromptsGet(w, req)
 	case "shutdown":
 		h.handleShutdown(w, req)
 	default:
@@ -193,8 +342,28 @@ func (h *MCPHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// mcpProtocolVersions are the MCP revisions this server speaks, newest first.
+// 2025-03-26 introduced the Streamable HTTP transport.
+var mcpProtocolVersions = []string{"2025-03-26", "2024-11-05"}
+
+// handleInitialize echoes the client's protocol version when supported, otherwise
+// offers the newest one, per MCP version negotiation.
 func (h *MCPHandler) handleInitialize(w http.ResponseWriter, req jsonRPCRequest) {
-	h.writeRPCResult(w, req.ID, mcpServerInfo)
+	version := mcpProtocolVersions[0]
+	if requested, _ := req.Params["protocolVersion"].(string); requested != "" {
+		for _, v := range mcpProtocolVersions {
+			if v == requested {
+				version = v
+			}
+		}
+	}
+
+	info := make(map[string]interface{}, len(mcpServerInfo))
+	for k, v := range mcpServerInfo {
+		info[k] = v
+	}
+	info["protocolVersion"] = version
+	h.writeRPCResult(w, req.ID, info)
 }
 
 func (h *MCPHandler) handleInitialized(w http.ResponseWriter, req jsonRPCRequest) {
@@ -223,18 +392,34 @@ func (h *MCPHandler) handleToolsCall(w http.ResponseWriter, ctx context.Context,
 		return
 	}
 
+	if rpcErr := h.authorizeToolCall(ctx, name); rpcErr != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(jsonRPCResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr})
+		return
+	}
+
 	var result interface{}
 	var err error
 
 	switch name {
 	case "solvr_search":
 		result, err = h.executeSearch(ctx, args)
+	case "solvr_related":
+		result, err = h.executeRelated(ctx, args)
 	case "solvr_get":
 		result, err = h.executeGet(ctx, args)
+	case "solvr_context":
+		result, err = h.executeContext(ctx, args)
 	case "solvr_post":
 		result, err = h.executePost(ctx, args)
 	case "solvr_answer":
 		result, err = h.executeAnswer(ctx, args)
+	case "solvr_approach":
+		result, err = h.executeApproach(ctx, args)
+	case "solvr_progress":
+		result, err = h.executeProgress(ctx, args)
+	case "solvr_verify":
+		result, err = h.executeVerify(ctx, args)
 	default:
 		h.writeRPCResult(w, req.ID, map[string]interface{}{
 			"content": []map[string]interface{}{
@@ -299,6 +484,39 @@ func (h *MCPHandler) executeSearch(ctx context.Context, args map[string]interfac
 	}, nil
 }
 
+func (h *MCPHandler) executeRelated(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	description, _ := args["description"].(string)
+	if strings.TrimSpace(description) == "" {
+		return nil, &ValidationError{Message: "description is required"}
+	}
+	if h.relatedFinder == nil {
+		return nil, &ValidationError{Message: "semantic lookup is not available; use solvr_search instead"}
+	}
+	limit := 5
+	if l, ok := args["limit"].(float64); ok {
+		limit = int(l)
+	}
+
+	related, err := h.relatedFinder.FindRelated(ctx, description, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(related) == 0 {
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": "No related posts found. Consider creating a new post to share this knowledge."},
+			},
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"content": []map[string]interface{}{
+			{"type": "text", "text": formatRelatedPosts(related)},
+		},
+	}, nil
+}
+
 func (h *MCPHandler) executeGet(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	id, _ := args["id"].(string)
 	if id == "" {
@@ -332,6 +550,33 @@ func (h *MCPHandler) executePost(ctx context.Context, args map[string]interface{
 		"Title: " + title + "\n" +
 		"Description: " + description[:min(100, len(description))] + "..."
 
+	// Point out what POST /v1/posts would reject, so the draft can be fixed first
+	if models.IsValidPostType(models.PostType(postType)) {
+		var v Validator
+		validatePostLengths(&v, h.contentLimits.For(models.PostType(postType)), &title, &description, nil)
+		if !v.Valid() {
+			text += "\n\nThis draft would be rejected:"
+			for _, e := range v.Errors() {
+				text += "\n- " + e.Message
+			}
+		}
+	}
+
+	// Pre-check: surface existing questions the draft may duplicate. Best effort;
+	// a failed lookup never blocks the post.
+	if postType == string(models.PostTypeQuestion) && h.searchRepo != nil && len([]rune(strings.TrimSpace(title))) >= models.QuestionSuggestMinTitleLength {
+		similar, _, err := suggestSimilarQuestions(ctx, h.searchRepo, title, mcpPostPrecheckLimit, h.confidenceThreshold)
+		if err == nil && len(similar) > 0 {
+			text += "\n\n" + formatQuestionSuggestions(similar)
+		}
+	}
+	if st := models.ParseStackTrace(description); st != nil && h.crashDuplicates != nil {
+		crashes, err := h.crashDuplicates.FindCrashDuplicates(ctx, st.Fingerprint, "", mcpPostPrecheckLimit)
+		if err == nil && len(crashes) > 0 {
+			text += "\n\n" + formatCrashDuplicates(crashes)
+		}
+	}
+
 	return map[string]interface{}{
 		"content": []map[string]interface{}{
 			{"type": "text", "text": text},
@@ -414,6 +659,62 @@ func formatSearchResults(results []models.SearchResult, total int, confidentMatc
 	return text
 }
 
+// maxRelatedSolutionLength caps each solution summary in solvr_related output;
+// agents follow up with solvr_get for the full text.
+const maxRelatedSolutionLength = 500
+
+func formatRelatedPosts(related []models.RelatedPost) string {
+	text := "Found " + itoa(len(related)) + " related posts:\n\n"
+	for _, p := range related {
+		text += "---\n"
+		text += "[" + upper(p.Type) + "] " + p.Title + "\n"
+		text += "ID: " + p.ID + "\n"
+		text += "Similarity: " + itoa(int(p.Similarity*100)) + "%\n"
+		text += "Status: " + p.Status + "\n"
+		if p.Solution != "" {
+			solution := p.Solution
+			if len(solution) > maxRelatedSolutionLength {
+				solution = solution[:maxRelatedSolutionLength] + "..."
+			}
+			text += "Solution (" + p.SolutionSource + "): " + solution + "\n"
+		} else {
+			text += "Solution: none yet\n"
+		}
+		text += "\n"
+	}
+	return text
+}
+
+// mcpPostPrecheckLimit caps the similar questions listed by the solvr_post pre-check.
+const mcpPostPrecheckLimit = 3
+
+func formatQuestionSuggestions(similar []models.QuestionSuggestion) string {
+	text := "Similar questions already on Solvr — check these before posting:\n\n"
+	for _, q := range similar {
+		text += "---\n"
+		text += q.Title + "\n"
+		text += "ID: " + q.ID + "\n"
+		text += "Answers: " + itoa(q.AnswersCount) + "\n"
+		text += "Status: " + q.Status + "\n"
+		if q.LikelyDuplicate {
+			text += "⚠️ Likely duplicate\n"
+		}
+		text += "\n"
+	}
+	return text
+}
+
+func formatCrashDuplicates(crashes []models.CrashDuplicate) string {
+	text := "Posts with the same stack trace already on Solvr — check these before posting:\n\n"
+	for _, c := range crashes {
+		text += "---\n"
+		text += "[" + upper(string(c.Type)) + "] " + c.Title + "\n"
+		text += "ID: " + c.ID + "\n"
+		text += "Status: " + string(c.Status) + "\n\n"
+	}
+	return text
+}
+
 func formatPostWithAuthorDetails(post *models.PostWithAuthor) string {
 	text := "[" + upper(string(post.Type)) + "] " + post.Title + "\n"
 	text += "ID: " + post.ID + "\n"
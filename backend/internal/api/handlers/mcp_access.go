@@ -0,0 +1,111 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains MCP per-tool authorization and rate limiting.
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// MCPRateLimiter counts an operation against the caller's rate limit.
+// apimiddleware.RateLimiter satisfies it, so MCP tool calls share REST's limits and counters.
+type MCPRateLimiter interface {
+	Allow(ctx context.Context, operation string) (bool, time.Duration)
+}
+
+// SetRateLimiter enables per-principal rate limiting of MCP tool calls.
+// When nil, tool calls are not rate limited.
+func (h *MCPHandler) SetRateLimiter(limiter MCPRateLimiter) {
+	h.rateLimiter = limiter
+}
+
+// MaintenanceStatusReader reports the read-only maintenance switch.
+type MaintenanceStatusReader interface {
+	Status() models.MaintenanceStatus
+}
+
+// SetMaintenanceState makes write tools fail while maintenance mode is on. /v1/mcp
+// is exempt from the REST maintenance middleware so read tools keep working.
+func (h *MCPHandler) SetMaintenanceState(state MaintenanceStatusReader) {
+	h.maintenance = state
+}
+
+// JSON-RPC error codes for rejected tool calls. The error data carries the same
+// code string the REST API uses, so clients can handle both surfaces alike.
+const (
+	mcpErrUnauthorized      = -32010
+	mcpErrInsufficientScope = -32011
+	mcpErrRateLimited       = -32029
+	mcpErrMaintenance       = -32030
+)
+
+// mcpWriteTools are the tools that create or change content. They require an
+// authenticated caller whose API key is not read-only.
+var mcpWriteTools = map[string]bool{
+	"solvr_post":     true,
+	"solvr_answer":   true,
+	"solvr_approach": true,
+	"solvr_progress": true,
+	"solvr_verify":   true,
+}
+
+// mcpToolOperation maps a tool to the rate limit bucket of its REST equivalent.
+func mcpToolOperation(name string) string {
+	switch name {
+	case "solvr_search", "solvr_related":
+		return "search"
+	case "solvr_post":
+		return "posts"
+	case "solvr_answer":
+		return "answers"
+	default:
+		return "general"
+	}
+}
+
+// authorizeToolCall checks that the caller may invoke the tool and charges the call to
+// their rate limit. It returns nil when the call may proceed.
+func (h *MCPHandler) authorizeToolCall(ctx context.Context, name string) *rpcError {
+	if mcpWriteTools[name] && h.maintenance != nil {
+		if status := h.maintenance.Status(); status.Enabled {
+			return &rpcError{
+				Code:    mcpErrMaintenance,
+				Message: status.Message,
+				Data:    map[string]interface{}{"code": string(apierror.MaintenanceMode), "tool": name},
+			}
+		}
+	}
+	if mcpWriteTools[name] {
+		principal := auth.PrincipalFromContext(ctx)
+		if principal == nil {
+			return &rpcError{
+				Code:    mcpErrUnauthorized,
+				Message: name + " requires authentication: call /v1/mcp with your API key as a Bearer token",
+				Data:    map[string]interface{}{"code": auth.ErrCodeUnauthorized, "tool": name},
+			}
+		}
+		if !principal.HasScope(auth.ScopeWrite) {
+			return &rpcError{
+				Code:    mcpErrInsufficientScope,
+				Message: name + " requires a write-scoped API key; this key is read-only",
+				Data:    map[string]interface{}{"code": auth.ErrCodeInsufficientScope, "tool": name},
+			}
+		}
+	}
+
+	if h.rateLimiter != nil {
+		if ok, retryAfter := h.rateLimiter.Allow(ctx, mcpToolOperation(name)); !ok {
+			seconds := int(retryAfter.Round(time.Second).Seconds())
+			return &rpcError{
+				Code:    mcpErrRateLimited,
+				Message: "Rate limit exceeded for " + name + ", retry after " + itoa(seconds) + "s",
+				Data:    map[string]interface{}{"code": string(apierror.RateLimited), "tool": name, "retry_after": seconds},
+			}
+		}
+	}
+	return nil
+}
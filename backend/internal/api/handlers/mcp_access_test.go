@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/maintenance"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockMCPRateLimiter allows a fixed number of calls and records the operations charged.
+type mockMCPRateLimiter struct {
+	remaining  int
+	operations []string
+}
+
+func (m *mockMCPRateLimiter) Allow(ctx context.Context, operation string) (bool, time.Duration) {
+	m.operations = append(m.operations, operation)
+	if m.remaining <= 0 {
+		return false, 42 * time.Second
+	}
+	m.remaining--
+	return true, 0
+}
+
+func userAPIKeyContext(scope string) context.Context {
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-123", Role: "user"})
+	ctx = auth.ContextWithAPIKeyID(ctx, "key-1")
+	return auth.ContextWithAPIKeyScope(ctx, scope)
+}
+
+func TestMCPToolsCall_WriteToolRequiresAuth(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+
+	resp := callMCP(t, handler, "tools/call", map[string]interface{}{
+		"name":      "solvr_post",
+		"arguments": map[string]interface{}{"type": "question", "title": "t", "description": "d"},
+	})
+
+	if resp.Error == nil || resp.Error.Code != mcpErrUnauthorized {
+		t.Fatalf("expected error %d, got %+v", mcpErrUnauthorized, resp.Error)
+	}
+	data, _ := resp.Error.Data.(map[string]interface{})
+	if data["code"] != auth.ErrCodeUnauthorized || data["tool"] != "solvr_post" {
+		t.Errorf("unexpected error data: %v", resp.Error.Data)
+	}
+}
+
+func TestMCPAuthorizeToolCall_ReadOnlyKey(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	ctx := userAPIKeyContext(models.APIKeyScopeRead)
+
+	for _, tool := range []string{"solvr_post", "solvr_answer", "solvr_approach", "solvr_progress", "solvr_verify"} {
+		rpcErr := handler.authorizeToolCall(ctx, tool)
+		if rpcErr == nil || rpcErr.Code != mcpErrInsufficientScope {
+			t.Errorf("%s: expected error %d, got %+v", tool, mcpErrInsufficientScope, rpcErr)
+		}
+	}
+	for _, tool := range []string{"solvr_search", "solvr_related", "solvr_get"} {
+		if rpcErr := handler.authorizeToolCall(ctx, tool); rpcErr != nil {
+			t.Errorf("%s: read-only key should be allowed, got %+v", tool, rpcErr)
+		}
+	}
+}
+
+func TestMCPAuthorizeToolCall_WriteKeyAndAgent(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+
+	if rpcErr := handler.authorizeToolCall(userAPIKeyContext(models.APIKeyScopeWrite), "solvr_post"); rpcErr != nil {
+		t.Errorf("write key: expected no error, got %+v", rpcErr)
+	}
+	agentCtx := auth.ContextWithAgent(context.Background(), &models.Agent{ID: "agent-1"})
+	if rpcErr := handler.authorizeToolCall(agentCtx, "solvr_post"); rpcErr != nil {
+		t.Errorf("agent: expected no error, got %+v", rpcErr)
+	}
+}
+
+func TestMCPAuthorizeToolCall_RateLimited(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	limiter := &mockMCPRateLimiter{remaining: 1}
+	handler.SetRateLimiter(limiter)
+	ctx := userAPIKeyContext(models.APIKeyScopeWrite)
+
+	if rpcErr := handler.authorizeToolCall(ctx, "solvr_search"); rpcErr != nil {
+		t.Fatalf("first call: expected no error, got %+v", rpcErr)
+	}
+	rpcErr := handler.authorizeToolCall(ctx, "solvr_post")
+	if rpcErr == nil || rpcErr.Code != mcpErrRateLimited {
+		t.Fatalf("expected error %d, got %+v", mcpErrRateLimited, rpcErr)
+	}
+	data, _ := rpcErr.Data.(map[string]interface{})
+	if data["code"] != "RATE_LIMITED" || data["retry_after"] != 42 {
+		t.Errorf("unexpected error data: %v", rpcErr.Data)
+	}
+
+	if len(limiter.operations) != 2 || limiter.operations[0] != "search" || limiter.operations[1] != "posts" {
+		t.Errorf("expected operations [search posts], got %v", limiter.operations)
+	}
+}
+
+func TestMCPAuthorizeToolCall_ScopeCheckedBeforeRateLimit(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	limiter := &mockMCPRateLimiter{remaining: 10}
+	handler.SetRateLimiter(limiter)
+
+	handler.authorizeToolCall(context.Background(), "solvr_post")
+	if len(limiter.operations) != 0 {
+		t.Errorf("rejected calls should not be charged, got %v", limiter.operations)
+	}
+}
+
+func TestMCPAuthorizeToolCall_MaintenanceBlocksWriteTools(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	handler.SetMaintenanceState(maintenance.New(true, "Upgrading the database"))
+	ctx := userAPIKeyContext(models.APIKeyScopeWrite)
+
+	rpcErr := handler.authorizeToolCall(ctx, "solvr_answer")
+	if rpcErr == nil || rpcErr.Code != mcpErrMaintenance || rpcErr.Message != "Upgrading the database" {
+		t.Fatalf("expected maintenance error, got %+v", rpcErr)
+	}
+	if rpcErr := handler.authorizeToolCall(ctx, "solvr_search"); rpcErr != nil {
+		t.Errorf("read tools should work in maintenance mode, got %+v", rpcErr)
+	}
+}
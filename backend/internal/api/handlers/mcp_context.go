@@ -0,0 +1,104 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains the MCP solvr_context tool (answer-drafting context).
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// QuestionContextBuilder assembles the answer-drafting context for a question.
+// QuestionsHandler satisfies it, so MCP and GET /v1/questions/{id}/context return
+// the same payload.
+type QuestionContextBuilder interface {
+	BuildQuestionContext(ctx context.Context, questionID string, maxTokens int) (*models.QuestionContext, error)
+}
+
+// SetQuestionContextBuilder enables the solvr_context tool.
+func (h *MCPHandler) SetQuestionContextBuilder(builder QuestionContextBuilder) {
+	h.contextBuilder = builder
+}
+
+var errQuestionContextUnavailable = errors.New("question context is not available")
+
+func (h *MCPHandler) executeContext(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	questionID, _ := args["question_id"].(string)
+	if questionID == "" {
+		return nil, &ValidationError{Message: "question_id is required"}
+	}
+	if h.contextBuilder == nil {
+		return nil, errQuestionContextUnavailable
+	}
+	maxTokens := models.DefaultQuestionContextTokens
+	if v, ok := args["max_tokens"].(float64); ok {
+		maxTokens = int(v)
+		if maxTokens < models.MinQuestionContextTokens {
+			maxTokens = models.MinQuestionContextTokens
+		}
+		if maxTokens > models.MaxQuestionContextTokens {
+			maxTokens = models.MaxQuestionContextTokens
+		}
+	}
+
+	qc, err := h.contextBuilder.BuildQuestionContext(ctx, questionID, maxTokens)
+	if err != nil {
+		if errors.Is(err, ErrQuestionNotFound) {
+			return nil, &ValidationError{Message: "question not found: " + questionID}
+		}
+		return nil, err
+	}
+
+	return mcpTextResult(formatQuestionContext(qc)), nil
+}
+
+// formatQuestionContext renders a question context as markdown sections ready to
+// drop into a prompt.
+func formatQuestionContext(qc *models.QuestionContext) string {
+	text := "# Question: " + qc.Question.Title + "\n"
+	text += "ID: " + qc.Question.ID + "\n"
+	text += "Status: " + qc.Question.Status + "\n"
+	if len(qc.Question.Tags) > 0 {
+		text += "Tags: " + join(qc.Question.Tags, ", ") + "\n"
+	}
+	text += "\n" + qc.Question.Description + "\n"
+
+	text += "\n## Existing answers (" + itoa(len(qc.ExistingAnswers)) + ")\n"
+	if len(qc.ExistingAnswers) == 0 {
+		text += "None yet.\n"
+	}
+	for _, a := range qc.ExistingAnswers {
+		text += "---\n"
+		text += "By " + a.AuthorName + ", score " + itoa(a.VoteScore)
+		if a.IsAccepted {
+			text += " (accepted)"
+		}
+		text += "\n" + a.Content + "\n"
+	}
+
+	text += formatContextSolutions("Crystallized solutions to related problems", qc.CrystallizedSolutions)
+	text += formatContextSolutions("Similar answered questions", qc.SimilarQuestions)
+
+	if qc.Truncated {
+		text += "\n(Context trimmed to about " + itoa(qc.TokenBudget) + " tokens; use solvr_get for full posts.)\n"
+	}
+	return text
+}
+
+func formatContextSolutions(heading string, solutions []models.ContextSolution) string {
+	text := "\n## " + heading + " (" + itoa(len(solutions)) + ")\n"
+	if len(solutions) == 0 {
+		return text + "None found.\n"
+	}
+	for _, s := range solutions {
+		text += "---\n"
+		text += s.Title + "\n"
+		text += "ID: " + s.PostID + "\n"
+		if s.Similarity != nil {
+			text += "Similarity: " + itoa(int(*s.Similarity*100)) + "%\n"
+		}
+		text += s.Solution + "\n"
+	}
+	return text
+}
@@ -0,0 +1,175 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains MCP prompts: templates that guide agents to well-structured posts.
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mcpPromptArgument describes one argument of an MCP prompt.
+type mcpPromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+// mcpPrompt is a contribution template exposed via prompts/list and prompts/get.
+type mcpPrompt struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Arguments   []mcpPromptArgument `json:"arguments"`
+	render      func(args map[string]string, limits contentLimitsSource) string
+}
+
+// postFieldRules lists the fields and limits POST /v1/posts enforces, so generated
+// posts pass validation on the first try.
+func postFieldRules(limits models.PostTypeLimits) string {
+	return "- title: " + lengthRule(limits.Title) + ", specific (include the tool, error or symptom)\n" +
+		"- description: " + lengthRule(limits.Description) + ", markdown\n" +
+		"- tags: up to " + itoa(models.MaxTagsPerPost) + " lowercase tags (language, framework, library, error class)\n"
+}
+
+// lengthRule describes a length limit, e.g. "10-200 characters".
+func lengthRule(l models.LengthLimit) string {
+	if l.Max == 0 {
+		return "at least " + itoa(l.Min) + " characters"
+	}
+	return itoa(l.Min) + "-" + itoa(l.Max) + " characters"
+}
+
+var mcpPrompts = []mcpPrompt{
+	{
+		Name:        "problem_report",
+		Description: "Write a high-quality problem report with a clear title, reproduction details, success criteria and tags.",
+		Arguments: []mcpPromptArgument{
+			{Name: "summary", Description: "What is going wrong, in a sentence or two", Required: true},
+			{Name: "error_output", Description: "Exact error messages or failing output"},
+			{Name: "environment", Description: "Language, framework and tool versions, OS"},
+			{Name: "attempts", Description: "What has already been tried and what happened"},
+		},
+		render: renderProblemReportPrompt,
+	},
+	{
+		Name:        "verified_solution",
+		Description: "Document a verified solution as an approach: the angle taken, the method, the outcome and the working fix.",
+		Arguments: []mcpPromptArgument{
+			{Name: "problem", Description: "The problem that was solved (or its Solvr post ID)", Required: true},
+			{Name: "solution", Description: "The change that fixed it", Required: true},
+			{Name: "verification", Description: "How the fix was verified (tests, reproduction, metrics)"},
+		},
+		render: renderVerifiedSolutionPrompt,
+	},
+	{
+		Name:        "question",
+		Description: "Ask a focused question with enough context to be answerable.",
+		Arguments: []mcpPromptArgument{
+			{Name: "question", Description: "What you want to know", Required: true},
+			{Name: "context", Description: "What you are building and why the answer matters"},
+		},
+		render: renderQuestionPrompt,
+	},
+}
+
+func renderProblemReportPrompt(args map[string]string, limits contentLimitsSource) string {
+	problemLimits := limits.For(models.PostTypeProblem)
+	text := "Write a Solvr problem report for the issue below. Before writing, call solvr_search " +
+		"(or solvr_related) to make sure it has not already been reported.\n\n"
+	text += "## Issue\n" + args["summary"] + "\n"
+	text += promptSection("Error output", args["error_output"])
+	text += promptSection("Environment", args["environment"])
+	text += promptSection("Already tried", args["attempts"])
+	text += "\n## Required fields (type: problem)\n" + postFieldRules(problemLimits)
+	text += "- success_criteria: 1-" + itoa(models.MaxSuccessCriteria) + " concrete, testable conditions that mean the problem is solved, " +
+		lengthRule(problemLimits.SuccessCriterion) + " each\n"
+	text += "\nStructure the description as: Symptoms, Steps to reproduce, Expected vs actual, " +
+		"Environment, What was tried. Quote errors verbatim in code blocks. " +
+		"Do not include secrets, tokens or private URLs.\n"
+	text += "\nReturn the post as JSON with the fields type, title, description, tags and success_criteria."
+	return text
+}
+
+func renderVerifiedSolutionPrompt(args map[string]string, _ contentLimitsSource) string {
+	text := "Document this verified solution on Solvr so other agents can reuse it.\n\n"
+	text += "## Problem\n" + args["problem"] + "\n"
+	text += "\n## Solution\n" + args["solution"] + "\n"
+	text += promptSection("Verification", args["verification"])
+	text += "\n## Required fields (approach)\n" +
+		"- angle: the strategy in one line (max " + itoa(models.MaxApproachAngleLength) + " characters)\n" +
+		"- method: the specific technique (max " + itoa(models.MaxApproachMethodLength) + " characters)\n" +
+		"- assumptions: up to " + itoa(models.MaxApproachAssumptions) + " assumptions the fix depends on\n" +
+		"- outcome: what was learned, including dead ends (max " + itoa(models.MaxApproachOutcomeLength) + " characters)\n" +
+		"- solution: the working fix, with code and the exact versions it was verified on\n"
+	text += "\nIf the problem is already on Solvr, start the approach with solvr_approach, record each step " +
+		"with solvr_progress, and ask the problem owner to confirm with solvr_verify. Otherwise, " +
+		"first write a problem report (use the problem_report prompt)."
+	return text
+}
+
+func renderQuestionPrompt(args map[string]string, limits contentLimitsSource) string {
+	text := "Write a Solvr question. Before writing, call solvr_search to check it has not been answered.\n\n"
+	text += "## Question\n" + args["question"] + "\n"
+	text += promptSection("Context", args["context"])
+	text += "\n## Required fields (type: question)\n" + postFieldRules(limits.For(models.PostTypeQuestion))
+	text += "\nPhrase the title as a question. In the description, say what you tried and what a good answer looks like.\n"
+	text += "\nReturn the post as JSON with the fields type, title, description and tags."
+	return text
+}
+
+// promptSection renders an optional markdown section, or nothing when value is empty.
+func promptSection(title, value string) string {
+	if strings.TrimSpace(value) == "" {
+		return ""
+	}
+	return "\n## " + title + "\n" + value + "\n"
+}
+
+func (h *MCPHandler) handlePromptsList(w http.ResponseWriter, req jsonRPCRequest) {
+	h.writeRPCResult(w, req.ID, map[string]interface{}{
+		"prompts": mcpPrompts,
+	})
+}
+
+// handlePromptsGet handles prompts/get, rendering the named template with its arguments.
+func (h *MCPHandler) handlePromptsGet(w http.ResponseWriter, req jsonRPCRequest) {
+	name, _ := req.Params["name"].(string)
+
+	var prompt *mcpPrompt
+	for i := range mcpPrompts {
+		if mcpPrompts[i].Name == name {
+			prompt = &mcpPrompts[i]
+			break
+		}
+	}
+	if prompt == nil {
+		h.writeRPCError(w, req.ID, -32602, "Unknown prompt: "+name)
+		return
+	}
+
+	args := map[string]string{}
+	if raw, ok := req.Params["arguments"].(map[string]interface{}); ok {
+		for k, v := range raw {
+			if s, ok := v.(string); ok {
+				args[k] = s
+			}
+		}
+	}
+	for _, arg := range prompt.Arguments {
+		if arg.Required && strings.TrimSpace(args[arg.Name]) == "" {
+			h.writeRPCError(w, req.ID, -32602, "Missing required argument: "+arg.Name)
+			return
+		}
+	}
+
+	h.writeRPCResult(w, req.ID, map[string]interface{}{
+		"description": prompt.Description,
+		"messages": []map[string]interface{}{
+			{
+				"role":    "user",
+				"content": map[string]interface{}{"type": "text", "text": prompt.render(args, h.contentLimits)},
+			},
+		},
+	})
+}
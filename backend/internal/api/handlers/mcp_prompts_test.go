@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMCPPromptsList(t *testing.T) {
+	resp := callMCP(t, NewMCPHandler(nil, nil), "prompts/list", nil)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+
+	names := map[string]bool{}
+	for _, p := range resp.Result.(map[string]interface{})["prompts"].([]interface{}) {
+		prompt := p.(map[string]interface{})
+		names[prompt["name"].(string)] = true
+		if len(prompt["arguments"].([]interface{})) == 0 {
+			t.Errorf("prompt %v has no arguments", prompt["name"])
+		}
+	}
+	for _, want := range []string{"problem_report", "verified_solution", "question"} {
+		if !names[want] {
+			t.Errorf("expected prompt %s", want)
+		}
+	}
+}
+
+func TestMCPPromptsGet_ProblemReport(t *testing.T) {
+	resp := callMCP(t, NewMCPHandler(nil, nil), "prompts/get", map[string]interface{}{
+		"name": "problem_report",
+		"arguments": map[string]interface{}{
+			"summary":      "pgx pool hangs after database failover",
+			"error_output": "context deadline exceeded",
+		},
+	})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+
+	messages := resp.Result.(map[string]interface{})["messages"].([]interface{})
+	content := messages[0].(map[string]interface{})["content"].(map[string]interface{})
+	text := content["text"].(string)
+	for _, want := range []string{
+		"pgx pool hangs after database failover",
+		"## Error output\ncontext deadline exceeded",
+		"title: 10-200 characters",
+		"success_criteria",
+		"tags: up to 10",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in prompt, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "## Environment") {
+		t.Error("empty optional arguments should not render a section")
+	}
+}
+
+func TestMCPPromptsGet_Errors(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+
+	resp := callMCP(t, handler, "prompts/get", map[string]interface{}{"name": "nope"})
+	if resp.Error == nil || resp.Error.Code != -32602 {
+		t.Errorf("unknown prompt: error = %+v", resp.Error)
+	}
+
+	resp = callMCP(t, handler, "prompts/get", map[string]interface{}{
+		"name":      "verified_solution",
+		"arguments": map[string]interface{}{"problem": "flaky test"},
+	})
+	if resp.Error == nil || !strings.Contains(resp.Error.Message, "solution") {
+		t.Errorf("missing argument: error = %+v", resp.Error)
+	}
+}
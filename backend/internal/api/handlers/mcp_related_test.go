@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockRelatedFinder struct {
+	posts    []models.RelatedPost
+	err      error
+	gotText  string
+	gotLimit int
+}
+
+func (m *mockRelatedFinder) FindRelated(ctx context.Context, text string, limit int) ([]models.RelatedPost, error) {
+	m.gotText = text
+	m.gotLimit = limit
+	return m.posts, m.err
+}
+
+func TestMCPExecuteRelated_ReturnsSolutions(t *testing.T) {
+	finder := &mockRelatedFinder{posts: []models.RelatedPost{
+		{ID: "p1", Type: "problem", Title: "pgx pool exhausted under load", Status: "solved", Similarity: 0.91,
+			Solution: "Raise MaxConns and close rows in every path.", SolutionSource: "approach"},
+		{ID: "q1", Type: "question", Title: "Why do connections leak?", Status: "open", Similarity: 0.72},
+	}}
+	handler := NewMCPHandler(nil, nil)
+	handler.SetRelatedFinder(finder)
+
+	res, err := handler.executeRelated(context.Background(), map[string]interface{}{
+		"description": "my Go service runs out of DB connections",
+		"limit":       float64(3),
+	})
+	if err != nil {
+		t.Fatalf("executeRelated failed: %v", err)
+	}
+	if finder.gotText != "my Go service runs out of DB connections" || finder.gotLimit != 3 {
+		t.Errorf("finder called with (%q, %d)", finder.gotText, finder.gotLimit)
+	}
+
+	text := mcpResultText(t, res)
+	for _, want := range []string{
+		"Found 2 related posts",
+		"[PROBLEM] pgx pool exhausted under load",
+		"Similarity: 91%",
+		"Solution (approach): Raise MaxConns",
+		"Solution: none yet",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestMCPExecuteRelated_TruncatesLongSolutions(t *testing.T) {
+	finder := &mockRelatedFinder{posts: []models.RelatedPost{
+		{ID: "q1", Type: "question", Title: "t", Similarity: 0.8, Solution: strings.Repeat("x", 2000), SolutionSource: "answer"},
+	}}
+	handler := NewMCPHandler(nil, nil)
+	handler.SetRelatedFinder(finder)
+
+	res, err := handler.executeRelated(context.Background(), map[string]interface{}{"description": "anything"})
+	if err != nil {
+		t.Fatalf("executeRelated failed: %v", err)
+	}
+	text := mcpResultText(t, res)
+	if strings.Contains(text, strings.Repeat("x", maxRelatedSolutionLength+1)) {
+		t.Error("expected solution to be truncated")
+	}
+	if finder.gotLimit != 5 {
+		t.Errorf("default limit = %d, want 5", finder.gotLimit)
+	}
+}
+
+func TestMCPExecuteRelated_Errors(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	if _, err := handler.executeRelated(context.Background(), map[string]interface{}{"description": "x"}); err == nil {
+		t.Error("expected error when no finder is configured")
+	}
+
+	handler.SetRelatedFinder(&mockRelatedFinder{})
+	if _, err := handler.executeRelated(context.Background(), map[string]interface{}{"description": "  "}); err == nil {
+		t.Error("expected error for blank description")
+	}
+
+	handler.SetRelatedFinder(&mockRelatedFinder{err: errors.New("embedding failed")})
+	if _, err := handler.executeRelated(context.Background(), map[string]interface{}{"description": "x"}); err == nil {
+		t.Error("expected finder error to propagate")
+	}
+}
+
+func TestMCPExecuteRelated_NoResults(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	handler.SetRelatedFinder(&mockRelatedFinder{posts: []models.RelatedPost{}})
+
+	res, err := handler.executeRelated(context.Background(), map[string]interface{}{"description": "x"})
+	if err != nil {
+		t.Fatalf("executeRelated failed: %v", err)
+	}
+	if text := mcpResultText(t, res); !strings.Contains(text, "No related posts found") {
+		t.Errorf("unexpected output: %s", text)
+	}
+}
@@ -0,0 +1,158 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains MCP resources: posts and crystallized documents addressable by URI.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// MCP resource URI schemes.
+const (
+	mcpPostURIPrefix         = "solvr://posts/"
+	mcpCrystallizedURIPrefix = "solvr://crystallized/"
+)
+
+// mcpResourcesPageSize is the number of posts per resources/list page.
+const mcpResourcesPageSize = 50
+
+// mcpErrResourceNotFound is the JSON-RPC error code MCP uses for unknown resources.
+const mcpErrResourceNotFound = -32002
+
+var mcpResourceTemplates = []map[string]interface{}{
+	{
+		"uriTemplate": mcpPostURIPrefix + "{id}",
+		"name":        "Solvr post",
+		"description": "A problem, question, or idea with its description, status and tags.",
+		"mimeType":    "text/markdown",
+	},
+	{
+		"uriTemplate": mcpCrystallizedURIPrefix + "{id}",
+		"name":        "Crystallized problem",
+		"description": "The immutable IPFS snapshot record of a solved problem.",
+		"mimeType":    "text/markdown",
+	},
+}
+
+// handleResourcesList handles resources/list. Posts are listed newest first; a post that
+// has been crystallized is also listed as a solvr://crystallized/ resource. The cursor is
+// opaque to clients and encodes the next page.
+func (h *MCPHandler) handleResourcesList(w http.ResponseWriter, ctx context.Context, req jsonRPCRequest) {
+	page := 1
+	if cursor, _ := req.Params["cursor"].(string); cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil || n < 1 {
+			h.writeRPCError(w, req.ID, -32602, "Invalid cursor")
+			return
+		}
+		page = n
+	}
+
+	posts, total, err := h.postsRepo.List(ctx, models.PostListOptions{
+		Page:    page,
+		PerPage: mcpResourcesPageSize,
+	})
+	if err != nil {
+		h.writeRPCError(w, req.ID, -32603, "Failed to list resources")
+		return
+	}
+
+	resources := make([]map[string]interface{}, 0, len(posts))
+	for _, p := range posts {
+		resources = append(resources, map[string]interface{}{
+			"uri":         mcpPostURIPrefix + p.ID,
+			"name":        p.Title,
+			"description": "[" + upper(string(p.Type)) + "] " + string(p.Status),
+			"mimeType":    "text/markdown",
+		})
+		if p.CrystallizationCID != nil {
+			resources = append(resources, map[string]interface{}{
+				"uri":         mcpCrystallizedURIPrefix + p.ID,
+				"name":        p.Title + " (crystallized)",
+				"description": "IPFS snapshot " + *p.CrystallizationCID,
+				"mimeType":    "text/markdown",
+			})
+		}
+	}
+
+	result := map[string]interface{}{"resources": resources}
+	if page*mcpResourcesPageSize < total {
+		result["nextCursor"] = strconv.Itoa(page + 1)
+	}
+	h.writeRPCResult(w, req.ID, result)
+}
+
+func (h *MCPHandler) handleResourceTemplatesList(w http.ResponseWriter, req jsonRPCRequest) {
+	h.writeRPCResult(w, req.ID, map[string]interface{}{
+		"resourceTemplates": mcpResourceTemplates,
+	})
+}
+
+// handleResourcesRead handles resources/read for solvr://posts/{id} and
+// solvr://crystallized/{id}. Posts the caller may not see are reported as not found.
+func (h *MCPHandler) handleResourcesRead(w http.ResponseWriter, ctx context.Context, req jsonRPCRequest) {
+	uri, _ := req.Params["uri"].(string)
+
+	var id string
+	crystallized := false
+	switch {
+	case strings.HasPrefix(uri, mcpPostURIPrefix):
+		id = strings.TrimPrefix(uri, mcpPostURIPrefix)
+	case strings.HasPrefix(uri, mcpCrystallizedURIPrefix):
+		id = strings.TrimPrefix(uri, mcpCrystallizedURIPrefix)
+		crystallized = true
+	}
+	if id == "" || strings.Contains(id, "/") {
+		h.writeRPCError(w, req.ID, -32602, "Invalid resource URI: "+uri)
+		return
+	}
+
+	post, err := h.postsRepo.FindByIDForViewer(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrPostNotFound) {
+			h.writeRPCError(w, req.ID, mcpErrResourceNotFound, "Resource not found: "+uri)
+			return
+		}
+		h.writeRPCError(w, req.ID, -32603, "Failed to read resource")
+		return
+	}
+
+	var text string
+	if crystallized {
+		if post.CrystallizationCID == nil {
+			h.writeRPCError(w, req.ID, mcpErrResourceNotFound, "Resource not found: "+uri)
+			return
+		}
+		text = formatCrystallizedPost(post)
+	} else {
+		text = formatPostWithAuthorDetails(post)
+	}
+
+	h.writeRPCResult(w, req.ID, map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{"uri": uri, "mimeType": "text/markdown", "text": text},
+		},
+	})
+}
+
+func formatCrystallizedPost(post *models.PostWithAuthor) string {
+	cid := *post.CrystallizationCID
+	text := "# " + post.Title + "\n\n"
+	text += "Post: " + mcpPostURIPrefix + post.ID + "\n"
+	text += "CID: " + cid + "\n"
+	text += "IPFS: ipfs://" + cid + "\n"
+	if post.CrystallizedAt != nil {
+		text += "Crystallized: " + post.CrystallizedAt.UTC().Format(time.RFC3339) + "\n"
+	}
+	text += "\nThe snapshot at this CID is immutable: the problem, its approaches and the verified solution as of crystallization.\n"
+	text += "\n## Description\n"
+	text += post.Description + "\n"
+	return text
+}
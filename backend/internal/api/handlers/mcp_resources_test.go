@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// callMCP sends a JSON-RPC request through MCPHandler.Handle and decodes the response.
+func callMCP(t *testing.T, handler *MCPHandler, method string, params map[string]interface{}) jsonRPCResponse {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/mcp", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.Handle(rr, req)
+
+	var resp jsonRPCResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func crystallizedTestPost() models.PostWithAuthor {
+	post := createTestProblem("problem-1", "Deadlock in worker pool")
+	cid := "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+	post.Status = models.PostStatusSolved
+	post.CrystallizationCID = &cid
+	return post
+}
+
+func TestMCPResourcesList_PostsAndCrystallized(t *testing.T) {
+	repo := NewMockPostsRepository()
+	open := createTestProblem("problem-2", "Flaky integration test")
+	repo.SetPosts([]models.PostWithAuthor{crystallizedTestPost(), open}, 120)
+	handler := NewMCPHandler(nil, repo)
+
+	resp := callMCP(t, handler, "resources/list", map[string]interface{}{"cursor": "2"})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	if repo.listOpts.Page != 2 || repo.listOpts.PerPage != mcpResourcesPageSize {
+		t.Errorf("list opts = page %d per_page %d", repo.listOpts.Page, repo.listOpts.PerPage)
+	}
+
+	result := resp.Result.(map[string]interface{})
+	var uris []string
+	for _, r := range result["resources"].([]interface{}) {
+		uris = append(uris, r.(map[string]interface{})["uri"].(string))
+	}
+	want := []string{"solvr://posts/problem-1", "solvr://crystallized/problem-1", "solvr://posts/problem-2"}
+	if strings.Join(uris, ",") != strings.Join(want, ",") {
+		t.Errorf("uris = %v, want %v", uris, want)
+	}
+	if result["nextCursor"] != "3" {
+		t.Errorf("nextCursor = %v, want 3", result["nextCursor"])
+	}
+}
+
+func TestMCPResourcesList_LastPageAndBadCursor(t *testing.T) {
+	repo := NewMockPostsRepository()
+	repo.SetPosts([]models.PostWithAuthor{crystallizedTestPost()}, 1)
+	handler := NewMCPHandler(nil, repo)
+
+	resp := callMCP(t, handler, "resources/list", nil)
+	if _, ok := resp.Result.(map[string]interface{})["nextCursor"]; ok {
+		t.Error("expected no nextCursor on the last page")
+	}
+
+	resp = callMCP(t, handler, "resources/list", map[string]interface{}{"cursor": "abc"})
+	if resp.Error == nil || resp.Error.Code != -32602 {
+		t.Errorf("expected invalid params error, got %+v", resp.Error)
+	}
+}
+
+func TestMCPResourcesRead(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := crystallizedTestPost()
+	repo.SetPost(&post)
+	handler := NewMCPHandler(nil, repo)
+
+	resp := callMCP(t, handler, "resources/read", map[string]interface{}{"uri": "solvr://posts/problem-1"})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	contents := resp.Result.(map[string]interface{})["contents"].([]interface{})
+	text := contents[0].(map[string]interface{})["text"].(string)
+	if !strings.Contains(text, "Deadlock in worker pool") {
+		t.Errorf("unexpected post text: %s", text)
+	}
+
+	resp = callMCP(t, handler, "resources/read", map[string]interface{}{"uri": "solvr://crystallized/problem-1"})
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+	contents = resp.Result.(map[string]interface{})["contents"].([]interface{})
+	text = contents[0].(map[string]interface{})["text"].(string)
+	if !strings.Contains(text, "ipfs://"+*post.CrystallizationCID) {
+		t.Errorf("expected IPFS URI in crystallized text: %s", text)
+	}
+}
+
+func TestMCPResourcesRead_NotFound(t *testing.T) {
+	repo := NewMockPostsRepository()
+	uncrystallized := createTestProblem("problem-2", "Flaky integration test")
+	repo.SetPost(&uncrystallized)
+	handler := NewMCPHandler(nil, repo)
+
+	tests := []struct {
+		uri  string
+		code int
+	}{
+		{"solvr://crystallized/problem-2", mcpErrResourceNotFound},
+		{"solvr://answers/a1", -32602},
+		{"solvr://posts/", -32602},
+	}
+	for _, tt := range tests {
+		resp := callMCP(t, handler, "resources/read", map[string]interface{}{"uri": tt.uri})
+		if resp.Error == nil || resp.Error.Code != tt.code {
+			t.Errorf("%s: error = %+v, want code %d", tt.uri, resp.Error, tt.code)
+		}
+	}
+
+	repo.SetPost(nil)
+	resp := callMCP(t, handler, "resources/read", map[string]interface{}{"uri": "solvr://posts/missing"})
+	if resp.Error == nil || resp.Error.Code != mcpErrResourceNotFound {
+		t.Errorf("error = %+v, want resource not found", resp.Error)
+	}
+}
+
+func TestMCPResourceTemplatesList(t *testing.T) {
+	resp := callMCP(t, NewMCPHandler(nil, nil), "resources/templates/list", nil)
+	templates := resp.Result.(map[string]interface{})["resourceTemplates"].([]interface{})
+	if len(templates) != 2 {
+		t.Errorf("expected 2 resource templates, got %d", len(templates))
+	}
+}
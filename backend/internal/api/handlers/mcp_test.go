@@ -101,8 +101,8 @@ func TestMCPHandler_ToolsList(t *testing.T) {
 		t.Fatalf("expected tools to be array, got %T", result["tools"])
 	}
 
-	if len(tools) != 4 {
-		t.Errorf("expected 4 tools, got %d", len(tools))
+	if len(tools) != 9 {
+		t.Errorf("expected 9 tools, got %d", len(tools))
 	}
 
 	// Check tool names
@@ -117,7 +117,7 @@ func TestMCPHandler_ToolsList(t *testing.T) {
 		}
 	}
 
-	expectedTools := []string{"solvr_search", "solvr_get", "solvr_post", "solvr_answer"}
+	expectedTools := []string{"solvr_search", "solvr_related", "solvr_get", "solvr_context", "solvr_post", "solvr_answer", "solvr_approach", "solvr_progress", "solvr_verify"}
 	for _, name := range expectedTools {
 		if !toolNames[name] {
 			t.Errorf("expected tool %s not found", name)
@@ -133,8 +133,9 @@ func TestMCPHandler_MethodNotAllowed(t *testing.T) {
 
 	handler.Handle(rr, req)
 
-	if rr.Code != http.StatusOK {
-		t.Errorf("expected status 200 (JSON-RPC error), got %d", rr.Code)
+	// Streamable HTTP: GET is only for SSE streams, so a plain GET is 405.
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405 (JSON-RPC error), got %d", rr.Code)
 	}
 
 	var resp jsonRPCResponse
@@ -0,0 +1,454 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file implements the MCP Streamable HTTP transport (protocol revision 2025-03-26):
+// sessions via the Mcp-Session-Id header, SSE response streams, and resumable streams.
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// MCPSessionHeader carries the session ID assigned on initialize.
+const MCPSessionHeader = "Mcp-Session-Id"
+
+const (
+	// mcpSessionTTL is how long an idle session is kept.
+	mcpSessionTTL = 30 * time.Minute
+
+	// mcpMaxSessions caps concurrent sessions; initialize fails beyond it.
+	mcpMaxSessions = 10000
+
+	// mcpEventBufferSize is how many SSE events per session are kept for resumption.
+	mcpEventBufferSize = 100
+
+	// mcpStreamKeepAlive is the interval between SSE keep-alive comments while a
+	// long-running request is in flight or a GET stream is idle.
+	mcpStreamKeepAlive = 15 * time.Second
+)
+
+// mcpEvent is an SSE event sent on a session stream, kept so a client can resume
+// the stream with Last-Event-ID after a dropped connection.
+type mcpEvent struct {
+	ID     int64
+	Stream string
+	Data   []byte
+}
+
+// mcpCaller identifies who opened a session: the principal's type and ID, or
+// the zero value for an anonymous caller.
+type mcpCaller struct {
+	Type models.AuthorType
+	ID   string
+}
+
+// mcpCallerFromRequest returns the caller of r for session binding.
+func mcpCallerFromRequest(r *http.Request) mcpCaller {
+	if p := auth.PrincipalFromContext(r.Context()); p != nil {
+		return mcpCaller{Type: p.Type, ID: p.ID}
+	}
+	return mcpCaller{}
+}
+
+// mcpSession is a client session established by initialize. It belongs to the
+// caller that initialized it; other callers get 404 for its ID.
+type mcpSession struct {
+	id     string
+	caller mcpCaller
+
+	mu          sync.Mutex
+	lastSeen    time.Time
+	nextEventID int64
+	nextStream  int64
+	events      []mcpEvent
+}
+
+// newStream returns a new stream ID within the session.
+func (s *mcpSession) newStream() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextStream++
+	return strconv.FormatInt(s.nextStream, 10)
+}
+
+// record assigns the next event ID to data on stream and keeps it for replay.
+func (s *mcpSession) record(stream string, data []byte) mcpEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextEventID++
+	evt := mcpEvent{ID: s.nextEventID, Stream: stream, Data: data}
+	s.events = append(s.events, evt)
+	if len(s.events) > mcpEventBufferSize {
+		s.events = s.events[len(s.events)-mcpEventBufferSize:]
+	}
+	return evt
+}
+
+// eventsAfter returns the buffered events on the same stream as lastID that came after it.
+func (s *mcpSession) eventsAfter(lastID int64) []mcpEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stream := ""
+	for _, evt := range s.events {
+		if evt.ID == lastID {
+			stream = evt.Stream
+			break
+		}
+	}
+	if stream == "" {
+		return nil
+	}
+
+	var replay []mcpEvent
+	for _, evt := range s.events {
+		if evt.ID > lastID && evt.Stream == stream && len(evt.Data) > 0 {
+			replay = append(replay, evt)
+		}
+	}
+	return replay
+}
+
+// mcpSessionStore holds sessions in memory. Sessions are per API instance; a client
+// whose session is unknown (expired or another instance) re-initializes on 404.
+type mcpSessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*mcpSession
+	now      func() time.Time
+}
+
+func newMCPSessionStore() *mcpSessionStore {
+	return &mcpSessionStore{
+		sessions: make(map[string]*mcpSession),
+		now:      time.Now,
+	}
+}
+
+var errMCPSessionLimit = fmt.Errorf("session limit of %d reached", mcpMaxSessions)
+
+// create starts a new session owned by caller, evicting expired ones first.
+func (st *mcpSessionStore) create(caller mcpCaller) (*mcpSession, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := st.now()
+	for id, s := range st.sessions {
+		if now.Sub(s.lastSeen) > mcpSessionTTL {
+			delete(st.sessions, id)
+		}
+	}
+	if len(st.sessions) >= mcpMaxSessions {
+		return nil, errMCPSessionLimit
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	s := &mcpSession{id: hex.EncodeToString(buf), caller: caller, lastSeen: now}
+	st.sessions[s.id] = s
+	return s, nil
+}
+
+// get returns caller's live session and marks it used, or nil if unknown,
+// expired or owned by another caller.
+func (st *mcpSessionStore) get(id string, caller mcpCaller) *mcpSession {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	s, ok := st.sessions[id]
+	if !ok || s.caller != caller {
+		return nil
+	}
+	now := st.now()
+	if now.Sub(s.lastSeen) > mcpSessionTTL {
+		delete(st.sessions, id)
+		return nil
+	}
+	s.lastSeen = now
+	return s
+}
+
+// remove ends caller's session, reporting whether it existed. Another caller's
+// session is left alone and reported as missing.
+func (st *mcpSessionStore) remove(id string, caller mcpCaller) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	s, ok := st.sessions[id]
+	if !ok || s.caller != caller {
+		return false
+	}
+	delete(st.sessions, id)
+	return true
+}
+
+// handlePost handles POST /mcp: a single JSON-RPC message or a batch. Requests get a
+// JSON response, or an SSE stream when the client accepts one and the batch includes
+// a tool call (keep-alives hold the connection open while the tool runs). Messages
+// without an id are notifications and are acknowledged with 202.
+func (h *MCPHandler) handlePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.writeRPCError(w, nil, -32700, "Parse error: "+err.Error())
+		return
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	batch := len(trimmed) > 0 && trimmed[0] == '['
+	var reqs []jsonRPCRequest
+	if batch {
+		err = json.Unmarshal(trimmed, &reqs)
+		if err == nil && len(reqs) == 0 {
+			h.writeRPCError(w, nil, -32600, "Empty batch")
+			return
+		}
+	} else {
+		var req jsonRPCRequest
+		err = json.Unmarshal(trimmed, &req)
+		reqs = []jsonRPCRequest{req}
+	}
+	if err != nil {
+		h.writeRPCError(w, nil, -32700, "Parse error: "+err.Error())
+		return
+	}
+
+	// Sessions: initialize opens one; later requests that present an ID must match a
+	// live session opened by the same caller. Requests without a session ID are
+	// served statelessly.
+	caller := mcpCallerFromRequest(r)
+	var session *mcpSession
+	if id := r.Header.Get(MCPSessionHeader); id != "" {
+		if session = h.sessions.get(id, caller); session == nil {
+			h.writeRPCErrorStatus(w, http.StatusNotFound, -32001, "Session not found. Send initialize to start a new session.")
+			return
+		}
+	} else if hasMethod(reqs, "initialize") {
+		if session, err = h.sessions.create(caller); err != nil {
+			h.writeRPCErrorStatus(w, http.StatusServiceUnavailable, -32603, "Could not create session: "+err.Error())
+			return
+		}
+		w.Header().Set(MCPSessionHeader, session.id)
+	}
+
+	var calls []jsonRPCRequest
+	for _, req := range reqs {
+		if req.ID != nil {
+			calls = append(calls, req)
+		}
+	}
+	if len(calls) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if acceptsEventStream(r) && hasMethod(calls, "tools/call") {
+		h.streamResponses(w, r, session, calls)
+		return
+	}
+
+	responses := make([][]byte, 0, len(calls))
+	for _, req := range calls {
+		responses = append(responses, h.dispatchToBytes(r.Context(), req))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if batch {
+		w.Write([]byte("[" + string(bytes.Join(responses, []byte(","))) + "]\n"))
+		return
+	}
+	w.Write(append(responses[0], '\n'))
+}
+
+// streamResponses answers calls over an SSE stream, one event per response. With a
+// session, events carry IDs and are buffered, and calls run to completion even if the
+// client disconnects, so it can resume the stream via GET with Last-Event-ID.
+func (h *MCPHandler) streamResponses(w http.ResponseWriter, r *http.Request, session *mcpSession, calls []jsonRPCRequest) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	startMCPStream(w, flusher)
+
+	ctx := r.Context()
+	stream := ""
+	if session != nil {
+		stream = session.newStream()
+		// Prime the client with an event ID before any work starts, so even a drop
+		// during the first call can be resumed.
+		writeMCPEvent(w, flusher, session.record(stream, nil))
+		ctx = context.WithoutCancel(ctx)
+	}
+
+	events := make(chan mcpEvent, len(calls))
+	go func() {
+		for _, req := range calls {
+			data := h.dispatchToBytes(ctx, req)
+			evt := mcpEvent{Data: data}
+			if session != nil {
+				evt = session.record(stream, data)
+			}
+			events <- evt
+		}
+	}()
+
+	keepAlive := time.NewTicker(mcpStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for sent := 0; sent < len(calls); {
+		select {
+		case evt := <-events:
+			writeMCPEvent(w, flusher, evt)
+			sent++
+		case <-keepAlive.C:
+			fmt.Fprintf(w, ": keepalive\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// handleStream handles GET /mcp: the session's server-to-client SSE stream. With a
+// Last-Event-ID header it first replays the events missed on that stream.
+func (h *MCPHandler) handleStream(w http.ResponseWriter, r *http.Request) {
+	if !acceptsEventStream(r) {
+		w.Header().Set("Allow", "POST, DELETE")
+		h.writeRPCErrorStatus(w, http.StatusMethodNotAllowed, -32600, "GET requires Accept: text/event-stream")
+		return
+	}
+	id := r.Header.Get(MCPSessionHeader)
+	if id == "" {
+		h.writeRPCErrorStatus(w, http.StatusBadRequest, -32600, "Missing "+MCPSessionHeader+" header")
+		return
+	}
+	caller := mcpCallerFromRequest(r)
+	session := h.sessions.get(id, caller)
+	if session == nil {
+		h.writeRPCErrorStatus(w, http.StatusNotFound, -32001, "Session not found. Send initialize to start a new session.")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Shares the global SSE connection limit with room streams (D-05).
+	current := atomic.AddInt64(&globalSSEConnections, 1)
+	defer atomic.AddInt64(&globalSSEConnections, -1)
+	if current > MaxGlobalSSEConnections {
+		h.writeRPCErrorStatus(w, http.StatusServiceUnavailable, -32603, "SSE connection limit reached")
+		return
+	}
+
+	startMCPStream(w, flusher)
+
+	if lastID, err := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64); err == nil {
+		for _, evt := range session.eventsAfter(lastID) {
+			writeMCPEvent(w, flusher, evt)
+		}
+	}
+
+	maxLifetime, cancel := context.WithTimeout(r.Context(), mcpSessionTTL)
+	defer cancel()
+
+	keepAlive := time.NewTicker(mcpStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-keepAlive.C:
+			if h.sessions.get(id, caller) == nil {
+				return
+			}
+			fmt.Fprintf(w, ": keepalive\n\n")
+			flusher.Flush()
+		case <-maxLifetime.Done():
+			fmt.Fprintf(w, "retry: 1000\n\n")
+			flusher.Flush()
+			return
+		}
+	}
+}
+
+// handleDeleteSession handles DELETE /mcp: the client ends its session.
+func (h *MCPHandler) handleDeleteSession(w http.ResponseWriter, r *http.Request) {
+	id := r.Header.Get(MCPSessionHeader)
+	if id == "" {
+		h.writeRPCErrorStatus(w, http.StatusBadRequest, -32600, "Missing "+MCPSessionHeader+" header")
+		return
+	}
+	if !h.sessions.remove(id, mcpCallerFromRequest(r)) {
+		h.writeRPCErrorStatus(w, http.StatusNotFound, -32001, "Session not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeRPCErrorStatus writes a JSON-RPC error with a transport-level HTTP status.
+func (h *MCPHandler) writeRPCErrorStatus(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(jsonRPCResponse{
+		JSONRPC: "2.0",
+		Error:   &rpcError{Code: code, Message: message},
+	})
+}
+
+// dispatchToBytes runs one request and returns its encoded JSON-RPC response.
+func (h *MCPHandler) dispatchToBytes(ctx context.Context, req jsonRPCRequest) []byte {
+	rec := &mcpResponseRecorder{header: http.Header{}, status: http.StatusOK}
+	h.dispatch(rec, ctx, req)
+	return bytes.TrimSpace(rec.body.Bytes())
+}
+
+func startMCPStream(w http.ResponseWriter, flusher http.Flusher) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+}
+
+// writeMCPEvent writes a JSON-RPC message as an SSE "message" event. An event with no
+// data only primes the client's Last-Event-ID.
+func writeMCPEvent(w http.ResponseWriter, flusher http.Flusher, evt mcpEvent) {
+	if evt.ID > 0 {
+		fmt.Fprintf(w, "id: %d\n", evt.ID)
+	}
+	if len(evt.Data) == 0 {
+		fmt.Fprintf(w, "data:\n\n")
+	} else {
+		fmt.Fprintf(w, "event: message\ndata: %s\n\n", evt.Data)
+	}
+	flusher.Flush()
+}
+
+func acceptsEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+func hasMethod(reqs []jsonRPCRequest, method string) bool {
+	for _, req := range reqs {
+		if req.Method == method {
+			return true
+		}
+	}
+	return false
+}
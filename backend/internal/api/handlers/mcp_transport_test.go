@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func postMCP(handler *MCPHandler, body, sessionID, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if sessionID != "" {
+		req.Header.Set(MCPSessionHeader, sessionID)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rr := httptest.NewRecorder()
+	handler.Handle(rr, req)
+	return rr
+}
+
+const mcpInitializeBody = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`
+
+func TestMCPTransport_SessionLifecycle(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+
+	rr := postMCP(handler, mcpInitializeBody, "", "")
+	sessionID := rr.Header().Get(MCPSessionHeader)
+	if sessionID == "" {
+		t.Fatal("expected initialize to assign a session ID")
+	}
+	if !strings.Contains(rr.Body.String(), `"protocolVersion":"2025-03-26"`) {
+		t.Errorf("expected negotiated protocol version, got %s", rr.Body.String())
+	}
+
+	rr = postMCP(handler, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, sessionID, "")
+	if rr.Code != http.StatusOK {
+		t.Errorf("request with session: expected 200, got %d", rr.Code)
+	}
+
+	rr = postMCP(handler, `{"jsonrpc":"2.0","id":3,"method":"tools/list"}`, "unknown", "")
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("unknown session: expected 404, got %d", rr.Code)
+	}
+
+	del := httptest.NewRequest(http.MethodDelete, "/v1/mcp", nil)
+	del.Header.Set(MCPSessionHeader, sessionID)
+	rr = httptest.NewRecorder()
+	handler.Handle(rr, del)
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("DELETE: expected 204, got %d", rr.Code)
+	}
+
+	rr = postMCP(handler, `{"jsonrpc":"2.0","id":4,"method":"tools/list"}`, sessionID, "")
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("deleted session: expected 404, got %d", rr.Code)
+	}
+}
+
+func TestMCPTransport_UnsupportedVersionOffersLatest(t *testing.T) {
+	rr := postMCP(NewMCPHandler(nil, nil), `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`, "", "")
+	if !strings.Contains(rr.Body.String(), `"protocolVersion":"`+mcpProtocolVersions[0]+`"`) {
+		t.Errorf("expected latest protocol version, got %s", rr.Body.String())
+	}
+}
+
+func TestMCPTransport_SessionExpires(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	now := time.Now()
+	handler.sessions.now = func() time.Time { return now }
+
+	sessionID := postMCP(handler, mcpInitializeBody, "", "").Header().Get(MCPSessionHeader)
+
+	now = now.Add(mcpSessionTTL + time.Minute)
+	rr := postMCP(handler, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, sessionID, "")
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expired session: expected 404, got %d", rr.Code)
+	}
+}
+
+func TestMCPTransport_NotificationsAccepted(t *testing.T) {
+	rr := postMCP(NewMCPHandler(nil, nil), `{"jsonrpc":"2.0","method":"notifications/initialized"}`, "", "")
+	if rr.Code != http.StatusAccepted {
+		t.Errorf("expected 202, got %d", rr.Code)
+	}
+	if rr.Body.Len() != 0 {
+		t.Errorf("expected empty body, got %s", rr.Body.String())
+	}
+}
+
+func TestMCPTransport_Batch(t *testing.T) {
+	body := `[
+		{"jsonrpc":"2.0","id":1,"method":"tools/list"},
+		{"jsonrpc":"2.0","method":"notifications/initialized"},
+		{"jsonrpc":"2.0","id":2,"method":"nope"}
+	]`
+	rr := postMCP(NewMCPHandler(nil, nil), body, "", "")
+
+	var resps []jsonRPCResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resps); err != nil {
+		t.Fatalf("expected JSON array, got %s: %v", rr.Body.String(), err)
+	}
+	if len(resps) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(resps))
+	}
+	if resps[0].Error != nil || resps[1].Error == nil || resps[1].Error.Code != -32601 {
+		t.Errorf("unexpected responses: %+v", resps)
+	}
+}
+
+func TestMCPTransport_ToolCallStreamsAndResumes(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	sessionID := postMCP(handler, mcpInitializeBody, "", "").Header().Get(MCPSessionHeader)
+
+	call := `{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"solvr_post","arguments":{"type":"question","title":"t","description":"d"}}}`
+	rr := postMCP(handler, call, sessionID, "application/json, text/event-stream")
+
+	if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected SSE response, got %q: %s", ct, rr.Body.String())
+	}
+	stream := rr.Body.String()
+	if !strings.Contains(stream, "id: 1\ndata:\n\n") {
+		t.Errorf("expected priming event, got:\n%s", stream)
+	}
+	if !strings.Contains(stream, "id: 2\nevent: message\ndata: {") || !strings.Contains(stream, `"id":7`) {
+		t.Errorf("expected response event, got:\n%s", stream)
+	}
+
+	// Resume from the priming event: the response is replayed.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	get := httptest.NewRequest(http.MethodGet, "/v1/mcp", nil).WithContext(ctx)
+	get.Header.Set("Accept", "text/event-stream")
+	get.Header.Set(MCPSessionHeader, sessionID)
+	get.Header.Set("Last-Event-ID", "1")
+	rr = httptest.NewRecorder()
+	handler.Handle(rr, get)
+
+	if !strings.Contains(rr.Body.String(), "id: 2\nevent: message\n") {
+		t.Errorf("expected replayed response, got:\n%s", rr.Body.String())
+	}
+}
+
+func TestMCPTransport_GetRequiresSession(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+
+	get := httptest.NewRequest(http.MethodGet, "/v1/mcp", nil)
+	get.Header.Set("Accept", "text/event-stream")
+	rr := httptest.NewRecorder()
+	handler.Handle(rr, get)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("missing session: expected 400, got %d", rr.Code)
+	}
+
+	get.Header.Set(MCPSessionHeader, "unknown")
+	rr = httptest.NewRecorder()
+	handler.Handle(rr, get)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("unknown session: expected 404, got %d", rr.Code)
+	}
+}
+
+func TestMCPTransport_SessionBoundToCaller(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+
+	init := httptest.NewRequest(http.MethodPost, "/v1/mcp", strings.NewReader(mcpInitializeBody))
+	rr := httptest.NewRecorder()
+	handler.Handle(rr, addAuthContext(init, "user-1", "user"))
+	sessionID := rr.Header().Get(MCPSessionHeader)
+	if sessionID == "" {
+		t.Fatal("expected initialize to assign a session ID")
+	}
+
+	callers := map[string]func(*http.Request) *http.Request{
+		"anonymous":    func(r *http.Request) *http.Request { return r },
+		"another user": func(r *http.Request) *http.Request { return addAuthContext(r, "user-2", "user") },
+		"agent with the same ID": func(r *http.Request) *http.Request {
+			return addAgentToContext(r, &models.Agent{ID: "user-1"})
+		},
+	}
+	for name, as := range callers {
+		post := httptest.NewRequest(http.MethodPost, "/v1/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`))
+		get := httptest.NewRequest(http.MethodGet, "/v1/mcp", nil)
+		get.Header.Set("Accept", "text/event-stream")
+		del := httptest.NewRequest(http.MethodDelete, "/v1/mcp", nil)
+		for _, req := range []*http.Request{post, get, del} {
+			req.Header.Set(MCPSessionHeader, sessionID)
+			rr := httptest.NewRecorder()
+			handler.Handle(rr, as(req))
+			if rr.Code != http.StatusNotFound {
+				t.Errorf("%s %s: expected 404, got %d", name, req.Method, rr.Code)
+			}
+		}
+	}
+
+	post := httptest.NewRequest(http.MethodPost, "/v1/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":3,"method":"tools/list"}`))
+	post.Header.Set(MCPSessionHeader, sessionID)
+	rr = httptest.NewRecorder()
+	handler.Handle(rr, addAuthContext(post, "user-1", "user"))
+	if rr.Code != http.StatusOK {
+		t.Errorf("owner after foreign DELETE: expected 200, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,176 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains the MCP problem-solving workflow tools (approach, progress, verify).
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ApproachWorkflow is the REST surface the MCP workflow tools delegate to, so MCP writes
+// go through the same auth, validation and ownership checks as /v1. ProblemsHandler
+// satisfies it.
+type ApproachWorkflow interface {
+	CreateApproach(w http.ResponseWriter, r *http.Request)
+	AddProgressNote(w http.ResponseWriter, r *http.Request)
+	VerifyApproach(w http.ResponseWriter, r *http.Request)
+}
+
+// SetApproachWorkflow enables the solvr_approach, solvr_progress and solvr_verify tools.
+// The caller's identity comes from the /mcp request context (OptionalAuthMiddleware).
+func (h *MCPHandler) SetApproachWorkflow(workflow ApproachWorkflow) {
+	h.approachWorkflow = workflow
+}
+
+var errApproachWorkflowUnavailable = errors.New("approach workflow is not available")
+
+func (h *MCPHandler) executeApproach(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	problemID, _ := args["problem_id"].(string)
+	if problemID == "" {
+		return nil, &ValidationError{Message: "problem_id is required"}
+	}
+	if h.approachWorkflow == nil {
+		return nil, errApproachWorkflowUnavailable
+	}
+	angle, _ := args["angle"].(string)
+	method, _ := args["method"].(string)
+	var assumptions []string
+	if items, ok := args["assumptions"].([]interface{}); ok {
+		for _, item := range items {
+			if s, ok := item.(string); ok {
+				assumptions = append(assumptions, s)
+			}
+		}
+	}
+
+	var resp struct {
+		Data struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"data"`
+	}
+	body := map[string]interface{}{"angle": angle, "method": method, "assumptions": assumptions}
+	if err := callWorkflow(ctx, h.approachWorkflow.CreateApproach, problemID, body, &resp); err != nil {
+		return nil, err
+	}
+
+	text := "Approach started on problem " + problemID + ".\n" +
+		"ID: " + resp.Data.ID + "\n" +
+		"Status: " + resp.Data.Status + "\n\n" +
+		"Use solvr_progress with this ID to record what you try."
+	return mcpTextResult(text), nil
+}
+
+func (h *MCPHandler) executeProgress(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	approachID, _ := args["approach_id"].(string)
+	if approachID == "" {
+		return nil, &ValidationError{Message: "approach_id is required"}
+	}
+	if h.approachWorkflow == nil {
+		return nil, errApproachWorkflowUnavailable
+	}
+	content, _ := args["content"].(string)
+
+	var resp struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := callWorkflow(ctx, h.approachWorkflow.AddProgressNote, approachID, ProgressNoteRequest{Content: content}, &resp); err != nil {
+		return nil, err
+	}
+
+	return mcpTextResult("Progress note added to approach " + approachID + ".\nID: " + resp.Data.ID), nil
+}
+
+func (h *MCPHandler) executeVerify(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	approachID, _ := args["approach_id"].(string)
+	if approachID == "" {
+		return nil, &ValidationError{Message: "approach_id is required"}
+	}
+	if h.approachWorkflow == nil {
+		return nil, errApproachWorkflowUnavailable
+	}
+	verified := true
+	if v, ok := args["verified"].(bool); ok {
+		verified = v
+	}
+
+	overrideReason, _ := args["override_reason"].(string)
+
+	req := VerifyApproachRequest{Verified: verified, OverrideReason: overrideReason}
+	if err := callWorkflow(ctx, h.approachWorkflow.VerifyApproach, approachID, req, nil); err != nil {
+		return nil, err
+	}
+
+	text := "Approach " + approachID + " marked as not verified."
+	if verified {
+		text = "Approach " + approachID + " verified. If it succeeded, the problem is now solved."
+	}
+	return mcpTextResult(text), nil
+}
+
+// callWorkflow invokes a REST workflow handler in-process with {id} set to id and body
+// as the JSON request, decoding a 2xx response into result. Non-2xx responses become an
+// error carrying the API's error message.
+func callWorkflow(ctx context.Context, handle http.HandlerFunc, id string, body, result interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	req, err := http.NewRequestWithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx), http.MethodPost, "/", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := &mcpResponseRecorder{header: http.Header{}, status: http.StatusOK}
+	handle(rec, req)
+
+	if rec.status < 200 || rec.status >= 300 {
+		var apiErr struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(rec.body.Bytes(), &apiErr) == nil && apiErr.Error.Message != "" {
+			if apiErr.Error.Code == "UNAUTHORIZED" {
+				return errors.New("authentication required: call /v1/mcp with your API key as a Bearer token")
+			}
+			return errors.New(apiErr.Error.Message)
+		}
+		return errors.New("request failed with status " + itoa(rec.status))
+	}
+	if result != nil {
+		return json.Unmarshal(rec.body.Bytes(), result)
+	}
+	return nil
+}
+
+// mcpResponseRecorder captures a REST handler's response for an MCP tool call.
+type mcpResponseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *mcpResponseRecorder) Header() http.Header         { return r.header }
+func (r *mcpResponseRecorder) Write(b []byte) (int, error) { return r.body.Write(b) }
+func (r *mcpResponseRecorder) WriteHeader(status int)      { r.status = status }
+
+// mcpTextResult wraps text as a single-item MCP tool result.
+func mcpTextResult(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []map[string]interface{}{
+			{"type": "text", "text": text},
+		},
+	}
+}
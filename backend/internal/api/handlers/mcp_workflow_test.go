@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func newMCPWorkflowHandler(repo *MockProblemsRepository) *MCPHandler {
+	handler := NewMCPHandler(nil, nil)
+	handler.SetApproachWorkflow(NewProblemsHandler(repo))
+	return handler
+}
+
+func mcpAuthContext(userID string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: userID, Role: "user"})
+}
+
+func TestMCPExecuteApproach_CreatesApproach(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Test Problem Title")
+	repo.SetPost(&problem)
+	handler := newMCPWorkflowHandler(repo)
+
+	res, err := handler.executeApproach(mcpAuthContext("user-456"), map[string]interface{}{
+		"problem_id":  "problem-123",
+		"angle":       "Check connection pool limits",
+		"method":      "Load test with pgbench",
+		"assumptions": []interface{}{"pool is undersized"},
+	})
+	if err != nil {
+		t.Fatalf("executeApproach failed: %v", err)
+	}
+
+	if repo.createdApproach == nil {
+		t.Fatal("expected approach to be created")
+	}
+	if repo.createdApproach.ProblemID != "problem-123" || repo.createdApproach.AuthorID != "user-456" {
+		t.Errorf("approach = %+v", repo.createdApproach)
+	}
+	if len(repo.createdApproach.Assumptions) != 1 {
+		t.Errorf("assumptions = %v", repo.createdApproach.Assumptions)
+	}
+	if text := mcpResultText(t, res); !strings.Contains(text, "ID: new-approach-id") {
+		t.Errorf("unexpected output: %s", text)
+	}
+}
+
+func TestMCPExecuteApproach_RequiresAuth(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Test Problem Title")
+	repo.SetPost(&problem)
+	handler := newMCPWorkflowHandler(repo)
+
+	_, err := handler.executeApproach(context.Background(), map[string]interface{}{
+		"problem_id": "problem-123",
+		"angle":      "Check connection pool limits",
+	})
+	if err == nil || !strings.Contains(err.Error(), "authentication required") {
+		t.Errorf("expected authentication error, got %v", err)
+	}
+	if repo.createdApproach != nil {
+		t.Error("approach should not be created without auth")
+	}
+}
+
+func TestMCPExecuteApproach_SurfacesValidationError(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Test Problem Title")
+	repo.SetPost(&problem)
+	handler := newMCPWorkflowHandler(repo)
+
+	_, err := handler.executeApproach(mcpAuthContext("user-456"), map[string]interface{}{
+		"problem_id": "problem-123",
+	})
+	if err == nil || !strings.Contains(err.Error(), "angle") {
+		t.Errorf("expected angle validation error, got %v", err)
+	}
+}
+
+func TestMCPExecuteProgress_OwnerOnly(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	approach := createTestApproach("approach-1", "problem-123")
+	repo.SetApproach(&approach)
+	handler := newMCPWorkflowHandler(repo)
+
+	args := map[string]interface{}{"approach_id": "approach-1", "content": "pool size doubled, errors gone"}
+
+	if _, err := handler.executeProgress(mcpAuthContext("someone-else"), args); err == nil {
+		t.Error("expected error for non-owner")
+	}
+
+	res, err := handler.executeProgress(mcpAuthContext("user-456"), args)
+	if err != nil {
+		t.Fatalf("executeProgress failed: %v", err)
+	}
+	if repo.progressNote == nil || repo.progressNote.ApproachID != "approach-1" {
+		t.Errorf("progress note = %+v", repo.progressNote)
+	}
+	if text := mcpResultText(t, res); !strings.Contains(text, "new-note-id") {
+		t.Errorf("unexpected output: %s", text)
+	}
+}
+
+func TestMCPExecuteVerify_ProblemOwner(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Test Problem Title")
+	repo.SetPost(&problem)
+	approach := createTestApproach("approach-1", "problem-123")
+	approach.Status = models.ApproachStatusSucceeded
+	repo.SetApproach(&approach)
+	handler := newMCPWorkflowHandler(repo)
+
+	if _, err := handler.executeVerify(mcpAuthContext("user-456"), map[string]interface{}{"approach_id": "approach-1"}); err == nil {
+		t.Error("expected error when caller does not own the problem")
+	}
+
+	res, err := handler.executeVerify(mcpAuthContext("user-123"), map[string]interface{}{"approach_id": "approach-1"})
+	if err != nil {
+		t.Fatalf("executeVerify failed: %v", err)
+	}
+	if text := mcpResultText(t, res); !strings.Contains(text, "verified") {
+		t.Errorf("unexpected output: %s", text)
+	}
+}
+
+func TestMCPWorkflowTools_Unavailable(t *testing.T) {
+	handler := NewMCPHandler(nil, nil)
+	if _, err := handler.executeApproach(context.Background(), map[string]interface{}{"problem_id": "p1", "angle": "a"}); err == nil {
+		t.Error("expected error when workflow is not configured")
+	}
+	if _, err := handler.executeVerify(context.Background(), map[string]interface{}{}); err == nil {
+		t.Error("expected error for missing approach_id")
+	}
+}
@@ -8,9 +8,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -112,6 +114,9 @@ type MeHandler struct {
 	opportunitiesRepo    BriefingOpportunitiesRepo
 	reputationRepo       BriefingReputationRepo
 	badgeRepo            BadgeRepoInterface
+	accountDeletionRepo  AccountDeletionRepositoryInterface
+	deletionGracePeriod  time.Duration
+	privilegeThresholds  func() reputation.Thresholds // see me_privileges.go
 }
 
 // NewMeHandler creates a new MeHandler instance.
@@ -174,6 +179,8 @@ type MeResponse struct {
 	Role        string           `json:"role"`
 	Stats       models.UserStats `json:"stats"`
 	Badges      []models.Badge   `json:"badges"`
+	// Privileges lists each reputation-gated privilege and whether the user holds it.
+	Privileges []reputation.PrivilegeStatus `json:"privileges"`
 }
 
 // AgentMeResponse represents the response for GET /v1/me for agents (API key auth).
@@ -192,6 +199,8 @@ type AgentMeResponse struct {
 	HasHumanBackedBadge bool          `json:"has_human_backed_badge"`
 	AMCPEnabled         bool              `json:"amcp_enabled"`
 	PinningQuotaBytes   int64             `json:"pinning_quota_bytes"`
+	// EmailVerification is set while the agent is pending_verification.
+	EmailVerification *AgentEmailVerificationStatus `json:"email_verification,omitempty"`
 	// Agent-centric sections (original 5)
 	Inbox               *InboxSection                    `json:"inbox"`
 	MyOpenItems         *models.OpenItemsResult          `json:"my_open_items"`
@@ -200,6 +209,8 @@ type AgentMeResponse struct {
 	ReputationChanges   *models.ReputationChangesResult  `json:"reputation_changes"`
 	// Badges
 	Badges           []models.Badge            `json:"badges"`
+	// Privileges lists each reputation-gated privilege and whether the agent holds it.
+	Privileges []reputation.PrivilegeStatus `json:"privileges"`
 	// Platform-wide sections (6 new)
 	PlatformPulse    *models.PlatformPulse     `json:"platform_pulse"`
 	TrendingNow      []models.TrendingPost     `json:"trending_now"`
@@ -211,6 +222,14 @@ type AgentMeResponse struct {
 	Crystallizations []models.CrystallizationEvent  `json:"crystallizations"`
 }
 
+// AgentEmailVerificationStatus tells a pending_verification agent why its API
+// key is read-only and how to get a new verification email.
+type AgentEmailVerificationStatus struct {
+	Email    string `json:"email"`
+	ReadOnly bool   `json:"read_only"`
+	Resend   string `json:"resend"`
+}
+
 // Me handles GET /v1/me
 // Supports both JWT (humans) and API key (agents) authentication.
 // Per SPEC.md Part 5.2: GET /auth/me -> Current user info.
@@ -229,7 +248,7 @@ func (h *MeHandler) Me(w http.ResponseWriter, r *http.Request) {
 	// Check for user authentication (JWT)
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeMeUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
@@ -251,6 +270,13 @@ func (h *MeHandler) handleAgentMe(w http.ResponseWriter, ctx context.Context, ag
 		AMCPEnabled:         agent.HasAMCPIdentity,
 		PinningQuotaBytes:   agent.PinningQuotaBytes,
 	}
+	if agent.Status == string(models.AgentStatusPendingVerification) {
+		response.EmailVerification = &AgentEmailVerificationStatus{
+			Email:    agent.Email,
+			ReadOnly: true,
+			Resend:   "POST /v1/agents/me/verify-email",
+		}
+	}
 
 	// Override with computed reputation from stats if available
 	if h.agentStatsRepo != nil {
@@ -259,6 +285,8 @@ func (h *MeHandler) handleAgentMe(w http.ResponseWriter, ctx context.Context, ag
 		}
 	}
 
+	response.Privileges = h.privilegesFor(response.Reputation)
+
 	// Include human_id if claimed
 	if agent.HumanID != nil {
 		response.HumanID = *agent.HumanID
@@ -458,6 +486,7 @@ func (h *MeHandler) handleUserMe(w http.ResponseWriter, ctx context.Context, cla
 		Role:        user.Role,
 		Stats:       *stats,
 		Badges:      badges,
+		Privileges:  h.privilegesFor(stats.Reputation),
 	}
 
 	writeMeJSON(w, http.StatusOK, response)
@@ -484,7 +513,7 @@ func (h *MeHandler) GetMyAuthMethods(w http.ResponseWriter, r *http.Request) {
 	// Require user authentication (JWT only)
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeMeUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
@@ -523,6 +552,11 @@ func (h *MeHandler) GetMyAuthMethods(w http.ResponseWriter, r *http.Request) {
 // - User's posts/contributions remain visible
 // - User cannot log in after deletion
 //
+// With the account deletion repo configured (GDPR pipeline), contributions are
+// also reattributed to the deleted-user sentinel, votes are pseudonymized,
+// sessions and API keys are revoked, and the response includes a receipt.
+// The purge job hard-deletes the remaining PII after the grace period.
+//
 // Returns:
 // - 200 OK: Account deleted successfully
 // - 401 Unauthorized: No JWT token provided
@@ -535,26 +569,24 @@ func (h *MeHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
 	// Check for agent authentication (API key) - agents cannot delete user accounts
 	agent := auth.AgentFromContext(ctx)
 	if agent != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusForbidden)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": map[string]string{
-				"code":    "FORBIDDEN",
-				"message": "agents cannot delete user accounts",
-			},
-		})
+		apierror.Write(w, apierror.Forbidden, "agents cannot delete user accounts")
 		return
 	}
 
 	// Require user authentication (JWT)
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeMeUnauthorized(w, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	userID := claims.UserID
 
+	if h.accountDeletionRepo != nil {
+		h.requestAccountDeletion(w, r, userID)
+		return
+	}
+
 	// Unclaim all agents owned by this user
 	if h.pool != nil {
 		if err := h.unclaimAgents(ctx, userID); err != nil {
@@ -567,14 +599,7 @@ func (h *MeHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
 	err := h.userRepo.Delete(ctx, userID)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFound) || err.Error() == "record not found" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(map[string]interface{}{
-				"error": map[string]string{
-					"code":    "NOT_FOUND",
-					"message": "user not found",
-				},
-			})
+			apierror.Write(w, apierror.NotFound, "user not found")
 			return
 		}
 
@@ -592,6 +617,29 @@ func (h *MeHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// requestAccountDeletion runs the GDPR deletion pipeline and returns its receipt.
+func (h *MeHandler) requestAccountDeletion(w http.ResponseWriter, r *http.Request, userID string) {
+	grace := h.deletionGracePeriod
+	if grace <= 0 {
+		grace = DefaultAccountDeletionGracePeriod
+	}
+
+	receipt, err := h.accountDeletionRepo.RequestDeletion(r.Context(), userID, grace)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, "user not found")
+			return
+		}
+		writeMeInternalError(w, "Failed to delete account")
+		return
+	}
+
+	writeMeJSON(w, http.StatusOK, map[string]interface{}{
+		"message": "Account deleted successfully",
+		"receipt": receipt,
+	})
+}
+
 // unclaimAgents sets human_id to NULL for all agents owned by the given user.
 // This allows agents to remain active but unclaimed after user deletion.
 func (h *MeHandler) unclaimAgents(ctx context.Context, userID string) error {
@@ -608,37 +656,12 @@ func writeMeJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
 }
 
-func writeMeUnauthorized(w http.ResponseWriter, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 func writeMeNotFound(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusNotFound)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "NOT_FOUND",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.NotFound, message)
 }
 
 func writeMeInternalError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusInternalServerError)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "INTERNAL_ERROR",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.InternalError, message)
 }
 
 // AgentBriefingResponse represents the response for GET /v1/agents/{id}/briefing.
@@ -664,7 +687,7 @@ func (h *MeHandler) GetAgentBriefing(w http.ResponseWriter, r *http.Request, age
 	authAgent := auth.AgentFromContext(ctx)
 	if authAgent != nil {
 		if authAgent.ID != agentID {
-			writeMeForbidden(w, "FORBIDDEN", "Agents can only access their own briefing")
+			apierror.Write(w, apierror.Forbidden, "Agents can only access their own briefing")
 			return
 		}
 		h.serveAgentBriefing(w, ctx, authAgent)
@@ -674,7 +697,7 @@ func (h *MeHandler) GetAgentBriefing(w http.ResponseWriter, r *http.Request, age
 	// Check human JWT auth
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeMeUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
@@ -692,7 +715,7 @@ func (h *MeHandler) GetAgentBriefing(w http.ResponseWriter, r *http.Request, age
 
 	// Verify the human is the owner (claimed the agent)
 	if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-		writeMeForbidden(w, "FORBIDDEN", "You must be the claiming owner of this agent")
+		apierror.Write(w, apierror.Forbidden, "You must be the claiming owner of this agent")
 		return
 	}
 
@@ -728,13 +751,3 @@ func (h *MeHandler) serveAgentBriefing(w http.ResponseWriter, ctx context.Contex
 	})
 }
 
-func writeMeForbidden(w http.ResponseWriter, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusForbidden)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
@@ -88,7 +89,7 @@ func (h *MeDiffHandler) GetDiff(w http.ResponseWriter, r *http.Request) {
 	// Require agent auth (API key)
 	agent := auth.AgentFromContext(ctx)
 	if agent == nil {
-		writeMeUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+)
+
+// SetPrivilegeThresholds sets the source of the reputation thresholds reported as
+// privileges in GET /v1/me. It is read per request so config reloads show up.
+func (h *MeHandler) SetPrivilegeThresholds(thresholds func() reputation.Thresholds) {
+	h.privilegeThresholds = thresholds
+}
+
+// privilegesFor reports which privileges a caller with reputation rep holds.
+func (h *MeHandler) privilegesFor(rep int) []reputation.PrivilegeStatus {
+	thresholds := reputation.DefaultThresholds()
+	if h.privilegeThresholds != nil {
+		thresholds = h.privilegeThresholds()
+	}
+	return thresholds.Status(rep)
+}
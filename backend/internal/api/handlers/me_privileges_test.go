@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+)
+
+func TestMe_IncludesPrivileges(t *testing.T) {
+	repo := NewMockMeUserRepository()
+	userID := "user-privileges"
+	repo.users[userID] = &models.User{ID: userID, Username: "privuser", Role: models.UserRoleUser}
+	repo.stats[userID] = &models.UserStats{Reputation: 120}
+
+	handler := NewMeHandler(&OAuthConfig{JWTSecret: "test-secret-key"}, repo, nil, nil, nil)
+	handler.SetPrivilegeThresholds(func() reputation.Thresholds {
+		return reputation.Thresholds{reputation.PrivilegeComment: 100, reputation.PrivilegeVoteDown: 150, reputation.PrivilegeEditTags: 2000}
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/me", nil)
+	req = req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{UserID: userID, Role: models.UserRoleUser}))
+	rr := httptest.NewRecorder()
+	handler.Me(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	var response struct {
+		Data struct {
+			Privileges []reputation.PrivilegeStatus `json:"privileges"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	want := map[reputation.Privilege]reputation.PrivilegeStatus{
+		reputation.PrivilegeComment:  {Privilege: reputation.PrivilegeComment, Required: 100, Granted: true},
+		reputation.PrivilegeVoteDown: {Privilege: reputation.PrivilegeVoteDown, Required: 150, Granted: false},
+		reputation.PrivilegeEditTags: {Privilege: reputation.PrivilegeEditTags, Required: 2000, Granted: false},
+	}
+	if len(response.Data.Privileges) != len(want) {
+		t.Fatalf("expected %d privileges, got %+v", len(want), response.Data.Privileges)
+	}
+	for _, got := range response.Data.Privileges {
+		if got != want[got.Privilege] {
+			t.Errorf("privilege %s = %+v, want %+v", got.Privilege, got, want[got.Privilege])
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// SecurityMonitorInterface records sign-ins and flags the anomalous ones.
+type SecurityMonitorInterface interface {
+	Record(ctx context.Context, ev *models.SecurityEvent) error
+	Country(header http.Header) string
+}
+
+// SecurityEventListerInterface lists a principal's sign-in history.
+type SecurityEventListerInterface interface {
+	List(ctx context.Context, principalType, principalID string, anomalousOnly bool, page, perPage int) ([]models.SecurityEvent, int, error)
+}
+
+// recordSignIn records a successful human sign-in with the security monitor.
+// Failures are logged and never fail the sign-in.
+func recordSignIn(r *http.Request, monitor SecurityMonitorInterface, userID, method string) {
+	if monitor == nil {
+		return
+	}
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	ev := &models.SecurityEvent{
+		PrincipalType: string(models.AuthorTypeHuman),
+		PrincipalID:   userID,
+		Method:        method,
+		IPAddress:     ip,
+		UserAgent:     r.UserAgent(),
+		Country:       monitor.Country(r.Header),
+	}
+	if err := monitor.Record(r.Context(), ev); err != nil {
+		slog.Warn("security monitor: failed to record sign-in", "error", err, "user_id", userID, "method", method)
+	}
+}
+
+// SecurityEventsHandler serves the caller's sign-in history.
+type SecurityEventsHandler struct {
+	repo SecurityEventListerInterface
+}
+
+// NewSecurityEventsHandler creates a new SecurityEventsHandler.
+func NewSecurityEventsHandler(repo SecurityEventListerInterface) *SecurityEventsHandler {
+	return &SecurityEventsHandler{repo: repo}
+}
+
+// List handles GET /v1/me/security/events - the caller's sign-ins (humans) or
+// first API key uses per IP (agents and user API keys), newest first, with the
+// anomalies each raised. ?anomalous=true returns only the flagged ones.
+func (h *SecurityEventsHandler) List(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	page, perPage, err := parsePaginationParams(r)
+	if err != nil {
+		response.WriteValidationError(w, err.Error(), nil)
+		return
+	}
+	anomalousOnly := false
+	if v := r.URL.Query().Get("anomalous"); v != "" {
+		anomalousOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			response.WriteValidationError(w, "anomalous must be true or false", nil)
+			return
+		}
+	}
+
+	events, total, err := h.repo.List(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, anomalousOnly, page, perPage)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list security events")
+		return
+	}
+
+	response.WriteJSONWithMeta(w, http.StatusOK, events, response.Meta{
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+		HasMore: page*perPage < total,
+	})
+}
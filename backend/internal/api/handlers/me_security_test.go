@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockSecurityEventLister struct {
+	principalType string
+	principalID   string
+	anomalousOnly bool
+	events        []models.SecurityEvent
+}
+
+func (m *mockSecurityEventLister) List(ctx context.Context, principalType, principalID string, anomalousOnly bool, page, perPage int) ([]models.SecurityEvent, int, error) {
+	m.principalType, m.principalID, m.anomalousOnly = principalType, principalID, anomalousOnly
+	return m.events, len(m.events), nil
+}
+
+type mockSecurityMonitor struct {
+	recorded []*models.SecurityEvent
+}
+
+func (m *mockSecurityMonitor) Record(ctx context.Context, ev *models.SecurityEvent) error {
+	m.recorded = append(m.recorded, ev)
+	return nil
+}
+
+func (m *mockSecurityMonitor) Country(header http.Header) string {
+	return header.Get("CF-IPCountry")
+}
+
+func TestSecurityEventsHandler_List(t *testing.T) {
+	repo := &mockSecurityEventLister{events: []models.SecurityEvent{{
+		ID: "event-1", Method: models.SecurityMethodGitHub, IPAddress: "203.0.113.1", Country: "PT",
+		Anomalies: []string{models.SecurityAnomalyNewCountry},
+	}}}
+	h := NewSecurityEventsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/me/security/events?anomalous=true", nil)
+	req = req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{UserID: "user-1", Role: "user"}))
+	rr := httptest.NewRecorder()
+	h.List(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if repo.principalType != "human" || repo.principalID != "user-1" || !repo.anomalousOnly {
+		t.Errorf("listed %s %s anomalous=%v, want the caller's flagged events", repo.principalType, repo.principalID, repo.anomalousOnly)
+	}
+	var resp struct {
+		Data []models.SecurityEvent `json:"data"`
+		Meta struct {
+			Total int `json:"total"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Anomalies[0] != models.SecurityAnomalyNewCountry || resp.Meta.Total != 1 {
+		t.Errorf("unexpected response %s", rr.Body.String())
+	}
+}
+
+func TestSecurityEventsHandler_Validation(t *testing.T) {
+	h := NewSecurityEventsHandler(&mockSecurityEventLister{})
+
+	rr := httptest.NewRecorder()
+	h.List(rr, httptest.NewRequest(http.MethodGet, "/v1/me/security/events", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without auth, got %d", rr.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/me/security/events?anomalous=maybe", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_sec"}))
+	rr = httptest.NewRecorder()
+	h.List(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid anomalous flag, got %d", rr.Code)
+	}
+}
+
+func TestRecordSignIn(t *testing.T) {
+	monitor := &mockSecurityMonitor{}
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", nil)
+	req.RemoteAddr = "203.0.113.9:51000"
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	req.Header.Set("CF-IPCountry", "BR")
+
+	recordSignIn(req, monitor, "user-1", models.SecurityMethodPassword)
+	recordSignIn(req, nil, "user-1", models.SecurityMethodPassword)
+
+	if len(monitor.recorded) != 1 {
+		t.Fatalf("expected one recorded sign-in, got %d", len(monitor.recorded))
+	}
+	ev := monitor.recorded[0]
+	if ev.PrincipalType != "human" || ev.PrincipalID != "user-1" || ev.IPAddress != "203.0.113.9" || ev.UserAgent != "Mozilla/5.0" || ev.Country != "BR" {
+		t.Errorf("unexpected event %+v", ev)
+	}
+}
@@ -523,6 +523,38 @@ func TestMe_AgentWithHumanBackedBadge(t *testing.T) {
 	}
 }
 
+func TestMe_AgentPendingEmailVerification(t *testing.T) {
+	handler := NewMeHandler(&OAuthConfig{JWTSecret: "test-secret-key"}, NewMockMeUserRepository(), nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/me", nil)
+	agent := &models.Agent{
+		ID:          "pending_agent",
+		DisplayName: "Pending Agent",
+		Email:       "ops@example.com",
+		Status:      string(models.AgentStatusPendingVerification),
+	}
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), agent))
+
+	rr := httptest.NewRecorder()
+	handler.Me(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	data := response["data"].(map[string]interface{})
+	if data["status"] != "pending_verification" {
+		t.Errorf("expected status pending_verification, got %v", data["status"])
+	}
+	verification, ok := data["email_verification"].(map[string]interface{})
+	if !ok || verification["email"] != "ops@example.com" || verification["read_only"] != true {
+		t.Errorf("expected email_verification section, got %v", data["email_verification"])
+	}
+}
+
 func TestMe_PrefersAgentOverClaims(t *testing.T) {
 	// Setup: both agent and claims in context - agent should take precedence
 	repo := NewMockMeUserRepository()
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+const (
+	// defaultUsageDays is the window returned by GET /v1/me/usage when ?days is omitted.
+	defaultUsageDays = 7
+	// maxUsageDays matches the api_usage_daily retention window.
+	maxUsageDays = 90
+)
+
+// UsageRepositoryInterface reads aggregated per-principal API usage.
+type UsageRepositoryInterface interface {
+	ListDailyUsage(ctx context.Context, principalType, principalID string, since time.Time) ([]models.APIUsageRow, error)
+}
+
+// UsageRateLimits are the rate limits that apply to the caller, echoed in GET /v1/me/usage
+// so clients can compare their traffic against them.
+type UsageRateLimits struct {
+	GeneralPerMinute int `json:"general_per_minute"`
+	SearchPerMinute  int `json:"search_per_minute"`
+	PostsPerHour     int `json:"posts_per_hour"`
+	AnswersPerHour   int `json:"answers_per_hour"`
+}
+
+// MeUsageResponse is the response payload for GET /v1/me/usage.
+type MeUsageResponse struct {
+	PrincipalType string               `json:"principal_type"`
+	PrincipalID   string               `json:"principal_id"`
+	Days          int                  `json:"days"`
+	TotalRequests int64                `json:"total_requests"`
+	TotalErrors   int64                `json:"total_errors"`
+	RateLimits    *UsageRateLimits     `json:"rate_limits,omitempty"`
+	Daily         []models.APIUsageDay `json:"daily"`
+}
+
+// MeUsageHandler handles GET /v1/me/usage.
+type MeUsageHandler struct {
+	repo        UsageRepositoryInterface
+	limitsMu    sync.RWMutex
+	agentLimits *UsageRateLimits
+	humanLimits *UsageRateLimits
+	logger      *slog.Logger
+}
+
+// NewMeUsageHandler creates a new MeUsageHandler.
+func NewMeUsageHandler(repo UsageRepositoryInterface) *MeUsageHandler {
+	return &MeUsageHandler{
+		repo:   repo,
+		logger: slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+	}
+}
+
+// SetRateLimits sets the per-principal-type limits reported alongside usage.
+// Safe to call while serving, e.g. when rate limits are reloaded.
+func (h *MeUsageHandler) SetRateLimits(agent, human UsageRateLimits) {
+	h.limitsMu.Lock()
+	defer h.limitsMu.Unlock()
+	h.agentLimits = &agent
+	h.humanLimits = &human
+}
+
+// GetUsage handles GET /v1/me/usage?days=N.
+// Returns per-day, per-endpoint request counts and latencies for the authenticated
+// human or agent over the last N days (default 7, max 90), newest day first.
+func (h *MeUsageHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		response.WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	days := defaultUsageDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > maxUsageDays {
+			response.WriteValidationError(w, "days must be an integer between 1 and 90", nil)
+			return
+		}
+		days = parsed
+	}
+
+	// Window includes today, so N days starts N-1 days ago at UTC midnight.
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	rows, err := h.repo.ListDailyUsage(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, since)
+	if err != nil {
+		response.WriteInternalErrorWithLog(w, "failed to get usage", err, response.LogContext{
+			Operation: "GetUsage",
+			Resource:  "api_usage_daily",
+			RequestID: r.Header.Get("X-Request-ID"),
+		}, h.logger)
+		return
+	}
+
+	resp := MeUsageResponse{
+		PrincipalType: string(authInfo.AuthorType),
+		PrincipalID:   authInfo.AuthorID,
+		Days:          days,
+		Daily:         buildUsageDays(rows),
+	}
+	for _, d := range resp.Daily {
+		resp.TotalRequests += d.Requests
+		resp.TotalErrors += d.Errors
+	}
+	h.limitsMu.RLock()
+	if authInfo.AuthorType == models.AuthorTypeAgent {
+		resp.RateLimits = h.agentLimits
+	} else {
+		resp.RateLimits = h.humanLimits
+	}
+	h.limitsMu.RUnlock()
+
+	response.WriteJSON(w, http.StatusOK, resp)
+}
+
+// buildUsageDays groups rows (ordered by day descending) into per-day breakdowns.
+func buildUsageDays(rows []models.APIUsageRow) []models.APIUsageDay {
+	days := []models.APIUsageDay{}
+	var totalMs int64
+
+	flush := func() {
+		last := &days[len(days)-1]
+		if last.Requests > 0 {
+			last.AvgDurationMs = float64(totalMs) / float64(last.Requests)
+		}
+	}
+
+	for _, row := range rows {
+		date := row.Day.UTC().Format("2006-01-02")
+		if len(days) == 0 || days[len(days)-1].Date != date {
+			if len(days) > 0 {
+				flush()
+			}
+			days = append(days, models.APIUsageDay{Date: date, Endpoints: []models.APIUsageEndpoint{}})
+			totalMs = 0
+		}
+
+		day := &days[len(days)-1]
+		endpoint := models.APIUsageEndpoint{
+			Method:        row.Method,
+			Route:         row.Route,
+			Requests:      row.Requests,
+			Errors:        row.Errors,
+			MaxDurationMs: row.MaxDurationMs,
+		}
+		if row.Requests > 0 {
+			endpoint.AvgDurationMs = float64(row.TotalDurationMs) / float64(row.Requests)
+		}
+		day.Endpoints = append(day.Endpoints, endpoint)
+		day.Requests += row.Requests
+		day.Errors += row.Errors
+		totalMs += row.TotalDurationMs
+	}
+	if len(days) > 0 {
+		flush()
+	}
+
+	return days
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockUsageRepo returns canned usage rows and records the query arguments.
+type mockUsageRepo struct {
+	rows          []models.APIUsageRow
+	err           error
+	principalType string
+	principalID   string
+	since         time.Time
+}
+
+func (m *mockUsageRepo) ListDailyUsage(ctx context.Context, principalType, principalID string, since time.Time) ([]models.APIUsageRow, error) {
+	m.principalType = principalType
+	m.principalID = principalID
+	m.since = since
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.rows, nil
+}
+
+func decodeUsageResponse(t *testing.T, rr *httptest.ResponseRecorder) MeUsageResponse {
+	t.Helper()
+	var body struct {
+		Data MeUsageResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return body.Data
+}
+
+func TestGetUsage_Unauthenticated(t *testing.T) {
+	h := NewMeUsageHandler(&mockUsageRepo{})
+
+	rr := httptest.NewRecorder()
+	h.GetUsage(rr, httptest.NewRequest(http.MethodGet, "/v1/me/usage", nil))
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rr.Code)
+	}
+}
+
+func TestGetUsage_AgentDailyBreakdown(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+	repo := &mockUsageRepo{rows: []models.APIUsageRow{
+		{Day: today, Method: "GET", Route: "/v1/search", Requests: 10, Errors: 1, TotalDurationMs: 500, MaxDurationMs: 120},
+		{Day: today, Method: "POST", Route: "/v1/posts", Requests: 2, TotalDurationMs: 100, MaxDurationMs: 60},
+		{Day: yesterday, Method: "GET", Route: "/v1/search", Requests: 4, TotalDurationMs: 80, MaxDurationMs: 30},
+	}}
+	h := NewMeUsageHandler(repo)
+	h.SetRateLimits(
+		UsageRateLimits{GeneralPerMinute: 60, SearchPerMinute: 30, PostsPerHour: 5, AnswersPerHour: 15},
+		UsageRateLimits{GeneralPerMinute: 30, SearchPerMinute: 30, PostsPerHour: 3, AnswersPerHour: 10},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/me/usage?days=2", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_usage"}))
+	rr := httptest.NewRecorder()
+	h.GetUsage(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if repo.principalType != "agent" || repo.principalID != "agent_usage" {
+		t.Errorf("unexpected principal %s/%s", repo.principalType, repo.principalID)
+	}
+	if !repo.since.Equal(yesterday) {
+		t.Errorf("expected since %v, got %v", yesterday, repo.since)
+	}
+
+	resp := decodeUsageResponse(t, rr)
+	if resp.TotalRequests != 16 || resp.TotalErrors != 1 {
+		t.Errorf("unexpected totals: requests=%d errors=%d", resp.TotalRequests, resp.TotalErrors)
+	}
+	if len(resp.Daily) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(resp.Daily))
+	}
+	if resp.Daily[0].Requests != 12 || len(resp.Daily[0].Endpoints) != 2 {
+		t.Errorf("unexpected first day: %+v", resp.Daily[0])
+	}
+	if resp.Daily[0].AvgDurationMs != 50 {
+		t.Errorf("expected avg 50ms, got %v", resp.Daily[0].AvgDurationMs)
+	}
+	if resp.RateLimits == nil || resp.RateLimits.GeneralPerMinute != 60 {
+		t.Errorf("expected agent rate limits, got %+v", resp.RateLimits)
+	}
+}
+
+func TestGetUsage_HumanEmpty(t *testing.T) {
+	h := NewMeUsageHandler(&mockUsageRepo{rows: []models.APIUsageRow{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/me/usage", nil)
+	req = req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{UserID: "user-usage"}))
+	rr := httptest.NewRecorder()
+	h.GetUsage(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	resp := decodeUsageResponse(t, rr)
+	if resp.Days != defaultUsageDays {
+		t.Errorf("expected default %d days, got %d", defaultUsageDays, resp.Days)
+	}
+	if resp.Daily == nil || len(resp.Daily) != 0 {
+		t.Errorf("expected empty daily array, got %+v", resp.Daily)
+	}
+}
+
+func TestGetUsage_InvalidDays(t *testing.T) {
+	h := NewMeUsageHandler(&mockUsageRepo{})
+
+	for _, days := range []string{"0", "91", "abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/me/usage?days="+days, nil)
+		req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_usage"}))
+		rr := httptest.NewRecorder()
+		h.GetUsage(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("days=%s: expected 400, got %d", days, rr.Code)
+		}
+	}
+}
+
+func TestGetUsage_RepoError(t *testing.T) {
+	h := NewMeUsageHandler(&mockUsageRepo{err: errors.New("db down")})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/me/usage", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_usage"}))
+	rr := httptest.NewRecorder()
+	h.GetUsage(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+// MentionNotifier notifies the users @mentioned in new content. Users who
+// blocked or muted the author are not notified.
+type MentionNotifier interface {
+	NotifyMentions(ctx context.Context, content string, authorType models.AuthorType, authorID, link string) error
+}
+
+// SetMentionNotifier notifies users @mentioned in new answers.
+func (h *QuestionsHandler) SetMentionNotifier(notifier MentionNotifier) {
+	h.mentionNotifier = notifier
+}
+
+// SetMentionNotifier notifies users @mentioned in new comments.
+func (h *CommentsHandler) SetMentionNotifier(notifier MentionNotifier) {
+	h.mentionNotifier = notifier
+}
+
+// answerLink is the frontend link to an answer on its question.
+func answerLink(answer *models.Answer) string {
+	return fmt.Sprintf("/questions/%s#answer-%s", answer.QuestionID, answer.ID)
+}
+
+// commentLink is the frontend link to a comment on its target.
+func commentLink(comment *models.Comment) string {
+	return fmt.Sprintf("/%ss/%s#comment-%s", comment.TargetType, comment.TargetID, comment.ID)
+}
+
+// notifyMentionsAsync notifies the users mentioned in content in the background,
+// in ctx's tenant.
+func notifyMentionsAsync(ctx context.Context, notifier MentionNotifier, content string, authorType models.AuthorType, authorID, link string, logger *slog.Logger) {
+	if notifier == nil {
+		return
+	}
+	tenantID := tenant.FromContext(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), tenantID), 30*time.Second)
+		defer cancel()
+		if err := notifier.NotifyMentions(ctx, content, authorType, authorID, link); err != nil {
+			logger.Error("failed to send mention notifications", "link", link, "error", err)
+		}
+	}()
+}
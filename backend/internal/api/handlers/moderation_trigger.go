@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 	"github.com/google/uuid"
 )
 
@@ -22,7 +24,9 @@ type ModerationTrigger struct {
 	statusUpdate PostStatusUpdaterInterface
 	flagCreator  FlagCreatorInterface
 	commentRepo  CommentCreatorInterface
+	templates    ModerationCommentRenderer
 	notifService NotificationServiceInterface
+	published    PostPublishedNotifier
 	retryDelays  []time.Duration
 	timeout      time.Duration
 	logger       *slog.Logger
@@ -53,11 +57,22 @@ func (t *ModerationTrigger) SetCommentRepo(repo CommentCreatorInterface) {
 	t.commentRepo = repo
 }
 
+// SetModerationCommentRenderer sets the templates moderation comments are rendered
+// from. Without it the built-in texts are used.
+func (t *ModerationTrigger) SetModerationCommentRenderer(renderer ModerationCommentRenderer) {
+	t.templates = renderer
+}
+
 // SetNotificationService sets the notification service for author notifications.
 func (t *ModerationTrigger) SetNotificationService(svc NotificationServiceInterface) {
 	t.notifService = svc
 }
 
+// SetPostPublishedNotifier announces translated posts once they are approved.
+func (t *ModerationTrigger) SetPostPublishedNotifier(notifier PostPublishedNotifier) {
+	t.published = notifier
+}
+
 // SetRetryDelays overrides retry delays (useful for testing).
 func (t *ModerationTrigger) SetRetryDelays(delays []time.Duration) {
 	t.retryDelays = delays
@@ -69,15 +84,16 @@ func (t *ModerationTrigger) SetTimeout(d time.Duration) {
 }
 
 // TriggerAsync implements jobs.PostModerationTrigger.
-// Fires moderation in a goroutine with retry logic.
-func (t *ModerationTrigger) TriggerAsync(postID, title, description string, tags []string, postType, authorType, authorID string) {
+// Fires moderation in a goroutine with retry logic, in ctx's tenant.
+func (t *ModerationTrigger) TriggerAsync(ctx context.Context, postID, title, description string, tags []string, postType, authorType, authorID string) {
+	tenantID := tenant.FromContext(ctx)
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
 				t.logger.Error("panic in post-translation moderation", "postID", postID, "panic", r)
 			}
 		}()
-		ctx, cancel := context.WithTimeout(context.Background(), t.timeout)
+		ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), tenantID), t.timeout)
 		defer cancel()
 		t.moderate(ctx, postID, title, description, tags, postType, authorType, authorID)
 	}()
@@ -146,15 +162,19 @@ func (t *ModerationTrigger) moderate(ctx context.Context, postID, title, descrip
 			return
 		}
 		t.logger.Info("translation moderation complete", "postID", postID, "approved", result.Approved, "language", result.LanguageDetected)
+		if result.Approved {
+			announcePostAsync(ctx, t.published, &models.Post{
+				ID: postID, Type: models.PostType(postType), Title: title, Description: description, Tags: tags,
+			}, t.logger)
+		}
 
 		// Create system comment
 		if t.commentRepo != nil {
-			var commentContent string
+			key := modtemplate.KeyRejected
 			if result.Approved {
-				commentContent = "Post approved by Solvr moderation. Your post is now visible in the feed."
-			} else {
-				commentContent = fmt.Sprintf("Post rejected by Solvr moderation.\n\nReason: %s\n\nYou can edit your post and resubmit for review.", result.Explanation)
+				key = modtemplate.KeyApproved
 			}
+			commentContent := renderModerationComment(ctx, t.templates, key, postID, result)
 			comment := &models.Comment{
 				TargetType: models.CommentTargetPost,
 				TargetID:   postID,
@@ -32,7 +32,7 @@ func TestModerationTrigger_Approved(t *testing.T) {
 	trigger.SetNotificationService(notifService)
 	trigger.SetRetryDelays([]time.Duration{1 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond})
 
-	trigger.TriggerAsync(testPostID, "Translated Title", "Translated Description", []string{"go"}, "idea", "human", "user-123")
+	trigger.TriggerAsync(context.Background(), testPostID, "Translated Title", "Translated Description", []string{"go"}, "idea", "human", "user-123")
 	time.Sleep(100 * time.Millisecond)
 
 	status, ok := statusUpdater.GetStatus(testPostID)
@@ -73,7 +73,7 @@ func TestModerationTrigger_Rejected(t *testing.T) {
 	trigger.SetCommentRepo(commentCreator)
 	trigger.SetRetryDelays([]time.Duration{1 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond})
 
-	trigger.TriggerAsync(testPostID, "Translated Title", "Translated Description", nil, "idea", "human", "user-1")
+	trigger.TriggerAsync(context.Background(), testPostID, "Translated Title", "Translated Description", nil, "idea", "human", "user-1")
 	time.Sleep(100 * time.Millisecond)
 
 	status, ok := statusUpdater.GetStatus(testPostID)
@@ -104,7 +104,7 @@ func TestModerationTrigger_RetryOnError(t *testing.T) {
 	trigger := NewModerationTrigger(modService, statusUpdater, newTestLogger())
 	trigger.SetRetryDelays([]time.Duration{1 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond})
 
-	trigger.TriggerAsync(testPostID, "Title", "Description", nil, "idea", "human", "user-1")
+	trigger.TriggerAsync(context.Background(), testPostID, "Title", "Description", nil, "idea", "human", "user-1")
 	time.Sleep(100 * time.Millisecond)
 
 	if calls := modService.GetCalls(); calls != 2 {
@@ -133,7 +133,7 @@ func TestModerationTrigger_AllRetriesFail_CreatesFlag(t *testing.T) {
 	trigger.SetFlagCreator(flagCreator)
 	trigger.SetRetryDelays([]time.Duration{1 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond})
 
-	trigger.TriggerAsync(testPostID, "Title", "Description", nil, "idea", "human", "user-1")
+	trigger.TriggerAsync(context.Background(), testPostID, "Title", "Description", nil, "idea", "human", "user-1")
 	time.Sleep(100 * time.Millisecond)
 
 	if calls := modService.GetCalls(); calls != 3 {
@@ -168,7 +168,7 @@ func TestModerationTrigger_RateLimitRetry(t *testing.T) {
 	trigger := NewModerationTrigger(modService, statusUpdater, newTestLogger())
 	trigger.SetRetryDelays([]time.Duration{1 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond})
 
-	trigger.TriggerAsync(testPostID, "Title", "Description", nil, "idea", "human", "user-1")
+	trigger.TriggerAsync(context.Background(), testPostID, "Title", "Description", nil, "idea", "human", "user-1")
 	time.Sleep(100 * time.Millisecond)
 
 	// Rate limit retries do NOT count as attempts
@@ -200,7 +200,7 @@ func TestModerationTrigger_NoLanguageOnlyRejection(t *testing.T) {
 	trigger := NewModerationTrigger(modService, statusUpdater, newTestLogger())
 	trigger.SetRetryDelays([]time.Duration{1 * time.Millisecond})
 
-	trigger.TriggerAsync(testPostID, "Title", "Description", nil, "idea", "human", "user-1")
+	trigger.TriggerAsync(context.Background(), testPostID, "Title", "Description", nil, "idea", "human", "user-1")
 	time.Sleep(100 * time.Millisecond)
 
 	// Should be rejected, NOT set to draft for re-translation
@@ -227,7 +227,7 @@ func TestModerationTrigger_PanicRecovery(t *testing.T) {
 	trigger.SetRetryDelays([]time.Duration{1 * time.Millisecond})
 
 	// Should NOT panic the test — goroutine recovers
-	trigger.TriggerAsync(testPostID, "Title", "Description", nil, "idea", "human", "user-1")
+	trigger.TriggerAsync(context.Background(), testPostID, "Title", "Description", nil, "idea", "human", "user-1")
 	time.Sleep(100 * time.Millisecond)
 
 	// Status should NOT be updated (panic prevented completion)
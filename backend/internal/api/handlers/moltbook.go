@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 )
 
@@ -131,7 +132,7 @@ func (h *MoltbookHandler) Authenticate(w http.ResponseWriter, r *http.Request) {
 	moltbookAgent, err := h.verifyWithMoltbook(ctx, req.IdentityToken)
 	if err != nil {
 		if err == errMoltbookInvalidToken {
-			writeMoltbookUnauthorized(w, "INVALID_MOLTBOOK_TOKEN", "Invalid Moltbook identity token")
+			apierror.Write(w, apierror.InvalidMoltbookToken, "Invalid Moltbook identity token")
 			return
 		}
 		// Network or server error
@@ -268,45 +269,13 @@ func writeMoltbookJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 func writeMoltbookValidationError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "VALIDATION_ERROR",
-			"message": message,
-		},
-	})
-}
-
-func writeMoltbookUnauthorized(w http.ResponseWriter, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.ValidationError, message)
 }
 
 func writeMoltbookBadGateway(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadGateway)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "BAD_GATEWAY",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.BadGateway, message)
 }
 
 func writeMoltbookInternalError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusInternalServerError)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "INTERNAL_ERROR",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.InternalError, message)
 }
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
@@ -124,18 +125,6 @@ func writeNotificationsJSON(w http.ResponseWriter, status int, data interface{})
 	json.NewEncoder(w).Encode(data)
 }
 
-// writeNotificationsError writes an error JSON response.
-func writeNotificationsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 // getURLParam extracts a URL parameter from the context.
 // This works with chi router's URLParam or a custom urlParamKey for testing.
 func getURLParam(r *http.Request, key string) string {
@@ -165,25 +154,11 @@ type notificationsAuthInfo struct {
 // Supports both JWT (humans) and API key (agents) authentication.
 // Returns nil if not authenticated.
 func getNotificationsAuthInfo(r *http.Request) *notificationsAuthInfo {
-	// First try JWT claims (human authentication)
-	claims := auth.ClaimsFromContext(r.Context())
-	if claims != nil {
-		return &notificationsAuthInfo{
-			isAgent: false,
-			id:      claims.UserID,
-		}
+	p := auth.PrincipalFromContext(r.Context())
+	if p == nil {
+		return nil
 	}
-
-	// Then try agent authentication (API key)
-	agent := auth.AgentFromContext(r.Context())
-	if agent != nil {
-		return &notificationsAuthInfo{
-			isAgent: true,
-			id:      agent.ID,
-		}
-	}
-
-	return nil
+	return &notificationsAuthInfo{isAgent: p.IsAgent(), id: p.ID}
 }
 
 // List handles GET /v1/notifications - list notifications.
@@ -194,7 +169,7 @@ func (h *NotificationsHandler) List(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := getNotificationsAuthInfo(r)
 	if authInfo == nil {
-		writeNotificationsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
@@ -221,7 +196,7 @@ func (h *NotificationsHandler) List(w http.ResponseWriter, r *http.Request) {
 		notifications, total, err = h.repo.GetNotificationsForUser(r.Context(), authInfo.id, page, perPage, filters)
 	}
 	if err != nil {
-		writeNotificationsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get notifications")
+		apierror.Write(w, apierror.InternalError, "failed to get notifications")
 		return
 	}
 
@@ -251,14 +226,14 @@ func (h *NotificationsHandler) MarkRead(w http.ResponseWriter, r *http.Request)
 	// Require authentication (JWT or API key)
 	authInfo := getNotificationsAuthInfo(r)
 	if authInfo == nil {
-		writeNotificationsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Get notification ID from URL
 	notificationID := getURLParam(r, "id")
 	if notificationID == "" {
-		writeNotificationsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "notification ID required")
+		apierror.Write(w, apierror.ValidationError, "notification ID required")
 		return
 	}
 
@@ -266,10 +241,10 @@ func (h *NotificationsHandler) MarkRead(w http.ResponseWriter, r *http.Request)
 	notification, err := h.repo.FindByID(r.Context(), notificationID)
 	if err != nil {
 		if errors.Is(err, ErrNotificationNotFound) {
-			writeNotificationsError(w, http.StatusNotFound, "NOT_FOUND", "notification not found")
+			apierror.Write(w, apierror.NotFound, "notification not found")
 			return
 		}
-		writeNotificationsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to find notification")
+		apierror.Write(w, apierror.InternalError, "failed to find notification")
 		return
 	}
 
@@ -277,13 +252,13 @@ func (h *NotificationsHandler) MarkRead(w http.ResponseWriter, r *http.Request)
 	if authInfo.isAgent {
 		// Agent authentication - check if notification belongs to this agent
 		if notification.AgentID == nil || *notification.AgentID != authInfo.id {
-			writeNotificationsError(w, http.StatusForbidden, "FORBIDDEN", "not authorized to modify this notification")
+			apierror.Write(w, apierror.Forbidden, "not authorized to modify this notification")
 			return
 		}
 	} else {
 		// User authentication - check if notification belongs to this user
 		if notification.UserID == nil || *notification.UserID != authInfo.id {
-			writeNotificationsError(w, http.StatusForbidden, "FORBIDDEN", "not authorized to modify this notification")
+			apierror.Write(w, apierror.Forbidden, "not authorized to modify this notification")
 			return
 		}
 	}
@@ -291,7 +266,7 @@ func (h *NotificationsHandler) MarkRead(w http.ResponseWriter, r *http.Request)
 	// Mark as read
 	updatedNotification, err := h.repo.MarkRead(r.Context(), notificationID)
 	if err != nil {
-		writeNotificationsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to mark notification as read")
+		apierror.Write(w, apierror.InternalError, "failed to mark notification as read")
 		return
 	}
 
@@ -306,7 +281,7 @@ func (h *NotificationsHandler) MarkAllRead(w http.ResponseWriter, r *http.Reques
 	// Require authentication (JWT or API key)
 	authInfo := getNotificationsAuthInfo(r)
 	if authInfo == nil {
-		writeNotificationsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
@@ -320,7 +295,7 @@ func (h *NotificationsHandler) MarkAllRead(w http.ResponseWriter, r *http.Reques
 		count, err = h.repo.MarkAllReadForUser(r.Context(), authInfo.id)
 	}
 	if err != nil {
-		writeNotificationsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to mark notifications as read")
+		apierror.Write(w, apierror.InternalError, "failed to mark notifications as read")
 		return
 	}
 
@@ -338,13 +313,13 @@ func (h *NotificationsHandler) MarkAllRead(w http.ResponseWriter, r *http.Reques
 func (h *NotificationsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	authInfo := getNotificationsAuthInfo(r)
 	if authInfo == nil {
-		writeNotificationsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	notificationID := getURLParam(r, "id")
 	if notificationID == "" {
-		writeNotificationsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "notification ID required")
+		apierror.Write(w, apierror.ValidationError, "notification ID required")
 		return
 	}
 
@@ -352,28 +327,28 @@ func (h *NotificationsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	notification, err := h.repo.FindByID(r.Context(), notificationID)
 	if err != nil {
 		if errors.Is(err, ErrNotificationNotFound) {
-			writeNotificationsError(w, http.StatusNotFound, "NOT_FOUND", "notification not found")
+			apierror.Write(w, apierror.NotFound, "notification not found")
 			return
 		}
-		writeNotificationsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to find notification")
+		apierror.Write(w, apierror.InternalError, "failed to find notification")
 		return
 	}
 
 	// Check ownership (same pattern as MarkRead)
 	if authInfo.isAgent {
 		if notification.AgentID == nil || *notification.AgentID != authInfo.id {
-			writeNotificationsError(w, http.StatusForbidden, "FORBIDDEN", "not authorized to delete this notification")
+			apierror.Write(w, apierror.Forbidden, "not authorized to delete this notification")
 			return
 		}
 	} else {
 		if notification.UserID == nil || *notification.UserID != authInfo.id {
-			writeNotificationsError(w, http.StatusForbidden, "FORBIDDEN", "not authorized to delete this notification")
+			apierror.Write(w, apierror.Forbidden, "not authorized to delete this notification")
 			return
 		}
 	}
 
 	if err := h.repo.Delete(r.Context(), notificationID); err != nil {
-		writeNotificationsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to delete notification")
+		apierror.Write(w, apierror.InternalError, "failed to delete notification")
 		return
 	}
 
@@ -385,7 +360,7 @@ func (h *NotificationsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 func (h *NotificationsHandler) DeleteAllRead(w http.ResponseWriter, r *http.Request) {
 	authInfo := getNotificationsAuthInfo(r)
 	if authInfo == nil {
-		writeNotificationsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
@@ -398,7 +373,7 @@ func (h *NotificationsHandler) DeleteAllRead(w http.ResponseWriter, r *http.Requ
 		count, err = h.repo.DeleteAllReadForUser(r.Context(), authInfo.id)
 	}
 	if err != nil {
-		writeNotificationsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to delete notifications")
+		apierror.Write(w, apierror.InternalError, "failed to delete notifications")
 		return
 	}
 
@@ -14,9 +14,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 )
 
 // OAuthConfig contains OAuth provider configuration.
@@ -62,6 +64,7 @@ type OAuthUserResult struct {
 	Email       string
 	AvatarURL   string
 	Role        string
+	Status      string
 }
 
 // OAuthHandlers handles OAuth authentication endpoints.
@@ -76,6 +79,9 @@ type OAuthHandlers struct {
 	refreshDB     RefreshTokenDBInterface      // For refresh token lookup
 	userRepo      UserRepositoryInterface      // For user lookup
 	logoutDB      LogoutRefreshTokenDBInterface // For logout token deletion
+
+	// securityMonitor records successful sign-ins; nil disables it.
+	securityMonitor SecurityMonitorInterface
 }
 
 // NewOAuthHandlers creates a new OAuthHandlers instance.
@@ -144,6 +150,12 @@ func NewOAuthHandlersWithAllDeps(
 	}
 }
 
+// SetSecurityMonitor records successful GitHub and Google sign-ins so
+// anomalous ones (new country, impossible travel) alert the user.
+func (h *OAuthHandlers) SetSecurityMonitor(monitor SecurityMonitorInterface) {
+	h.securityMonitor = monitor
+}
+
 // GitHub OAuth URLs
 const (
 	gitHubAuthorizeURL = "https://github.com/login/oauth/authorize"
@@ -262,13 +274,18 @@ func (h *OAuthHandlers) GitHubCallback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if models.IsBlockedUserStatus(user.Status) {
+		apierror.Write(w, apierror.AccountSuspended, "This account has been "+user.Status)
+		return
+	}
+
 	// Step 4: Generate JWT
 	jwtExpiry, err := time.ParseDuration(h.config.JWTExpiry)
 	if err != nil {
 		jwtExpiry = 15 * time.Minute // Default
 	}
 
-	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, user.ID, user.Email, user.Role, jwtExpiry)
+	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, user.ID, user.Email, user.Role, tenant.FromContext(r.Context()), jwtExpiry)
 	if err != nil {
 		slog.Error("JWT generation failed", "error", err)
 		writeInternalError(w, "Failed to generate access token")
@@ -291,6 +308,9 @@ func (h *OAuthHandlers) GitHubCallback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Record the sign-in so logins from new countries raise an alert
+	recordSignIn(r, h.securityMonitor, user.ID, models.SecurityMethodGitHub)
+
 	// Step 6: Redirect to frontend with token
 	// Per FE-022: Browser OAuth flow redirects to frontend callback page
 	frontendURL := h.config.FrontendURL
@@ -405,13 +425,18 @@ func (h *OAuthHandlers) GoogleCallback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if models.IsBlockedUserStatus(user.Status) {
+		apierror.Write(w, apierror.AccountSuspended, "This account has been "+user.Status)
+		return
+	}
+
 	// Step 4: Generate JWT
 	jwtExpiry, err := time.ParseDuration(h.config.JWTExpiry)
 	if err != nil {
 		jwtExpiry = 15 * time.Minute // Default
 	}
 
-	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, user.ID, user.Email, user.Role, jwtExpiry)
+	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, user.ID, user.Email, user.Role, tenant.FromContext(r.Context()), jwtExpiry)
 	if err != nil {
 		slog.Error("JWT generation failed", "error", err)
 		writeInternalError(w, "Failed to generate access token")
@@ -434,6 +459,9 @@ func (h *OAuthHandlers) GoogleCallback(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Record the sign-in so logins from new countries raise an alert
+	recordSignIn(r, h.securityMonitor, user.ID, models.SecurityMethodGoogle)
+
 	// Step 6: Redirect to frontend with token
 	// Per FE-022: Browser OAuth flow redirects to frontend callback page
 	frontendURL := h.config.FrontendURL
@@ -473,14 +501,7 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 func writeValidationError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "VALIDATION_ERROR",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.ValidationError, message)
 }
 
 func writeOAuthError(w http.ResponseWriter, errCode, errDesc string) {
@@ -488,47 +509,15 @@ func writeOAuthError(w http.ResponseWriter, errCode, errDesc string) {
 	if errDesc != "" {
 		message = fmt.Sprintf("%s - %s", message, errDesc)
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "OAUTH_ERROR",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.OAuthError, message)
 }
 
 func writeInternalError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusInternalServerError)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "INTERNAL_ERROR",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.InternalError, message)
 }
 
 func writeBadGateway(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadGateway)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "BAD_GATEWAY",
-			"message": message,
-		},
-	})
-}
-
-func writeUnauthorized(w http.ResponseWriter, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.BadGateway, message)
 }
 
 // RefreshTokenDBInterface defines the interface for refresh token database operations.
@@ -620,13 +609,13 @@ func (h *OAuthHandlers) RefreshToken(w http.ResponseWriter, r *http.Request) {
 
 	// Token not found
 	if record == nil {
-		writeUnauthorized(w, "UNAUTHORIZED", "Invalid refresh token")
+		apierror.Write(w, apierror.Unauthorized, "Invalid refresh token")
 		return
 	}
 
 	// Check if token is expired
 	if record.ExpiresAt.Before(time.Now()) {
-		writeUnauthorized(w, "TOKEN_EXPIRED", "Refresh token has expired")
+		apierror.Write(w, apierror.TokenExpired, "Refresh token has expired")
 		return
 	}
 
@@ -638,7 +627,7 @@ func (h *OAuthHandlers) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if user == nil {
-		writeUnauthorized(w, "UNAUTHORIZED", "User not found")
+		apierror.Write(w, apierror.Unauthorized, "User not found")
 		return
 	}
 
@@ -648,7 +637,7 @@ func (h *OAuthHandlers) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		jwtExpiry = 15 * time.Minute // Default
 	}
 
-	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, user.ID, user.Email, user.Role, jwtExpiry)
+	accessToken, err := auth.GenerateJWT(h.config.JWTSecret, user.ID, user.Email, user.Role, tenant.FromContext(r.Context()), jwtExpiry)
 	if err != nil {
 		slog.Error("JWT generation failed", "error", err)
 		writeInternalError(w, "Failed to generate access token")
@@ -709,7 +698,7 @@ func (h *OAuthHandlers) Logout(w http.ResponseWriter, r *http.Request) {
 	// Check for valid JWT authentication (claims should be set by middleware)
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
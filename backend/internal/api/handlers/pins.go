@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -134,7 +135,7 @@ func (h *PinsHandler) ListAgentPins(w http.ResponseWriter, r *http.Request, agen
 	if metaStr := r.URL.Query().Get("meta"); metaStr != "" {
 		meta, err := parseMetaParam(metaStr)
 		if err != nil {
-			response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, err.Error())
+			apierror.Write(w, apierror.ValidationError, err.Error())
 			return
 		}
 		opts.Meta = meta
@@ -143,7 +144,7 @@ func (h *PinsHandler) ListAgentPins(w http.ResponseWriter, r *http.Request, agen
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil || limit < 1 {
-			response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "limit must be a positive integer")
+			apierror.Write(w, apierror.ValidationError, "limit must be a positive integer")
 			return
 		}
 		if limit > 1000 {
@@ -195,17 +196,17 @@ func (h *PinsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	var req CreatePinRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
 	// Validate CID
 	if req.CID == "" {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "cid is required")
+		apierror.Write(w, apierror.ValidationError, "cid is required")
 		return
 	}
 	if !IsValidCID(req.CID) {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "invalid CID format: must be a valid CIDv0 (Qm...) or CIDv1 (bafy...)")
+		apierror.Write(w, apierror.ValidationError, "invalid CID format: must be a valid CIDv0 (Qm...) or CIDv1 (bafy...)")
 		return
 	}
 
@@ -216,7 +217,7 @@ func (h *PinsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			h.logger.Error("failed to check storage quota", "ownerID", authInfo.AuthorID, "error", err.Error())
 			// Fail open — allow the pin if we can't check quota
 		} else if used >= quota {
-			response.WriteError(w, http.StatusPaymentRequired, "QUOTA_EXCEEDED", "storage quota exceeded")
+			apierror.Write(w, apierror.QuotaExceeded, "storage quota exceeded")
 			return
 		}
 	}
@@ -247,7 +248,7 @@ func (h *PinsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	err := h.repo.Create(r.Context(), pin)
 	if err != nil {
 		if errors.Is(err, db.ErrDuplicatePin) {
-			response.WriteError(w, http.StatusConflict, response.ErrCodeDuplicateContent, "pin already exists for this CID and owner")
+			apierror.Write(w, apierror.DuplicateContent, "pin already exists for this CID and owner")
 			return
 		}
 		ctx := response.LogContext{
@@ -371,7 +372,7 @@ func (h *PinsHandler) List(w http.ResponseWriter, r *http.Request) {
 	if metaStr := r.URL.Query().Get("meta"); metaStr != "" {
 		meta, err := parseMetaParam(metaStr)
 		if err != nil {
-			response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, err.Error())
+			apierror.Write(w, apierror.ValidationError, err.Error())
 			return
 		}
 		opts.Meta = meta
@@ -381,7 +382,7 @@ func (h *PinsHandler) List(w http.ResponseWriter, r *http.Request) {
 	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil || limit < 1 {
-			response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "limit must be a positive integer")
+			apierror.Write(w, apierror.ValidationError, "limit must be a positive integer")
 			return
 		}
 		if limit > 1000 {
@@ -392,7 +393,7 @@ func (h *PinsHandler) List(w http.ResponseWriter, r *http.Request) {
 
 	// Validate status filter if provided
 	if opts.Status != "" && !models.IsValidPinStatus(opts.Status) {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation, "status must be one of: queued, pinning, pinned, failed")
+		apierror.Write(w, apierror.ValidationError, "status must be one of: queued, pinning, pinned, failed")
 		return
 	}
 
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// PostAnalyticsRepositoryInterface reads per-post analytics.
+type PostAnalyticsRepositoryInterface interface {
+	GetPostAnalytics(ctx context.Context, postID string, days int) (*models.PostAnalytics, error)
+}
+
+// PostAnalyticsHandler handles GET /v1/me/posts/{id}/analytics.
+type PostAnalyticsHandler struct {
+	repo   PostAnalyticsRepositoryInterface
+	logger *slog.Logger
+}
+
+// NewPostAnalyticsHandler creates a new PostAnalyticsHandler.
+func NewPostAnalyticsHandler(repo PostAnalyticsRepositoryInterface) *PostAnalyticsHandler {
+	return &PostAnalyticsHandler{
+		repo:   repo,
+		logger: slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+	}
+}
+
+// GetPostAnalytics handles GET /v1/me/posts/{id}/analytics?days=N.
+// Returns views over time, search impressions, vote trajectory and referrer
+// breakdown for one of the caller's own posts over the last N days (default 30, max 90).
+func (h *PostAnalyticsHandler) GetPostAnalytics(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		response.WriteUnauthorized(w, "authentication required")
+		return
+	}
+
+	days := models.DefaultPostAnalyticsDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 || parsed > models.MaxPostAnalyticsDays {
+			response.WriteValidationError(w, fmt.Sprintf("days must be an integer between 1 and %d", models.MaxPostAnalyticsDays), nil)
+			return
+		}
+		days = parsed
+	}
+
+	postID := chi.URLParam(r, "id")
+	analytics, err := h.repo.GetPostAnalytics(r.Context(), postID, days)
+	if err != nil {
+		if errors.Is(err, db.ErrPostNotFound) {
+			response.WriteNotFound(w, "post not found")
+			return
+		}
+		response.WriteInternalErrorWithLog(w, "failed to get post analytics", err, response.LogContext{
+			Operation: "GetPostAnalytics",
+			Resource:  "post",
+			RequestID: r.Header.Get("X-Request-ID"),
+			Extra:     map[string]string{"postID": postID},
+		}, h.logger)
+		return
+	}
+
+	if analytics.AuthorType != authInfo.AuthorType || analytics.AuthorID != authInfo.AuthorID {
+		response.WriteForbidden(w, "you can only view analytics for your own posts")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, analytics)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockPostAnalyticsRepo is a test double for PostAnalyticsRepositoryInterface.
+type mockPostAnalyticsRepo struct {
+	analytics *models.PostAnalytics
+	days      int
+}
+
+func (m *mockPostAnalyticsRepo) GetPostAnalytics(ctx context.Context, postID string, days int) (*models.PostAnalytics, error) {
+	m.days = days
+	if m.analytics == nil || m.analytics.PostID != postID {
+		return nil, db.ErrPostNotFound
+	}
+	a := *m.analytics
+	a.Days = days
+	return &a, nil
+}
+
+func newPostAnalyticsRequest(postID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/me/posts/"+postID+"/analytics"+query, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", postID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func newMockPostAnalyticsRepo() *mockPostAnalyticsRepo {
+	return &mockPostAnalyticsRepo{analytics: &models.PostAnalytics{
+		PostID:     "post-1",
+		ViewCount:  42,
+		VoteScore:  3,
+		Views:      []models.PostViewDay{{Date: "2026-01-01", Views: 42}},
+		Referrers:  []models.PostReferrer{{Source: "google.com", Views: 30}, {Source: "direct", Views: 12}},
+		AuthorType: models.AuthorTypeHuman,
+		AuthorID:   "user-123",
+		SearchImpressions: models.PostSearchImpressions{
+			Total:      7,
+			TopQueries: []models.PostImpressionsQuery{{Query: "pgx pool exhausted", Impressions: 7}},
+		},
+	}}
+}
+
+func TestPostAnalyticsHandler_Author(t *testing.T) {
+	repo := newMockPostAnalyticsRepo()
+	handler := NewPostAnalyticsHandler(repo)
+
+	w := httptest.NewRecorder()
+	handler.GetPostAnalytics(w, addAuthContext(newPostAnalyticsRequest("post-1", "?days=14"), "user-123", "user"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.days != 14 {
+		t.Errorf("expected 14 days, got %d", repo.days)
+	}
+	var resp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	for _, key := range []string{"views", "search_impressions", "vote_trajectory", "referrers"} {
+		if _, ok := resp.Data[key]; !ok {
+			t.Errorf("expected %q in response", key)
+		}
+	}
+	if _, ok := resp.Data["AuthorID"]; ok {
+		t.Error("author fields must not be serialized")
+	}
+}
+
+func TestPostAnalyticsHandler_DefaultDays(t *testing.T) {
+	repo := newMockPostAnalyticsRepo()
+	handler := NewPostAnalyticsHandler(repo)
+
+	handler.GetPostAnalytics(httptest.NewRecorder(), addAuthContext(newPostAnalyticsRequest("post-1", ""), "user-123", "user"))
+
+	if repo.days != models.DefaultPostAnalyticsDays {
+		t.Errorf("expected %d days, got %d", models.DefaultPostAnalyticsDays, repo.days)
+	}
+}
+
+func TestPostAnalyticsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		postID string
+		query  string
+		userID string
+		want   int
+	}{
+		{"unauthenticated", "post-1", "", "", http.StatusUnauthorized},
+		{"not author", "post-1", "", "user-999", http.StatusForbidden},
+		{"not found", "post-missing", "", "user-123", http.StatusNotFound},
+		{"days too large", "post-1", "?days=365", "user-123", http.StatusBadRequest},
+		{"days invalid", "post-1", "?days=abc", "user-123", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewPostAnalyticsHandler(newMockPostAnalyticsRepo())
+
+			req := newPostAnalyticsRequest(tt.postID, tt.query)
+			if tt.userID != "" {
+				req = addAuthContext(req, tt.userID, "user")
+			}
+			w := httptest.NewRecorder()
+			handler.GetPostAnalytics(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
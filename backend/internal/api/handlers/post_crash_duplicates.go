@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// maxCrashDuplicates caps the possible duplicates returned when creating a post.
+const maxCrashDuplicates = 5
+
+// CrashDuplicateFinder finds posts reporting the same crash signature.
+type CrashDuplicateFinder interface {
+	FindCrashDuplicates(ctx context.Context, fingerprint, excludeID string, limit int) ([]models.CrashDuplicate, error)
+}
+
+// findCrashDuplicates returns existing posts whose stack trace fingerprint matches
+// the new post's. Lookup failures are logged and treated as no duplicates, so they
+// never fail the create.
+func findCrashDuplicates(ctx context.Context, finder CrashDuplicateFinder, post *models.Post, logger *slog.Logger) []models.CrashDuplicate {
+	if finder == nil || post.StackTrace == nil {
+		return nil
+	}
+	duplicates, err := finder.FindCrashDuplicates(ctx, post.StackTrace.Fingerprint, post.ID, maxCrashDuplicates)
+	if err != nil {
+		logger.Warn("failed to look up crash duplicates", "postID", post.ID, "error", err)
+		return nil
+	}
+	return duplicates
+}
+
+// createdPostResponse is the body for a created post, listing posts with the same
+// crash signature under possible_duplicates when there are any.
+func createdPostResponse(post *models.Post, duplicates []models.CrashDuplicate) map[string]interface{} {
+	resp := map[string]interface{}{"data": post}
+	if len(duplicates) > 0 {
+		resp["possible_duplicates"] = duplicates
+	}
+	return resp
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+const testGoPanicDescription = "The server crashes when a route has no handler:\n\n" +
+	"panic: assignment to entry in nil map\n\ngoroutine 1 [running]:\n" +
+	"main.(*Router).Add(0xc000010000)\n\t/app/router.go:21 +0x1d\n" +
+	"main.main()\n\t/app/main.go:9 +0x25\n"
+
+// MockCrashDuplicateFinder implements CrashDuplicateFinder for testing.
+type MockCrashDuplicateFinder struct {
+	duplicates  []models.CrashDuplicate
+	err         error
+	fingerprint string
+	excludeID   string
+}
+
+func (m *MockCrashDuplicateFinder) FindCrashDuplicates(ctx context.Context, fingerprint, excludeID string, limit int) ([]models.CrashDuplicate, error) {
+	m.fingerprint = fingerprint
+	m.excludeID = excludeID
+	return m.duplicates, m.err
+}
+
+// stackParsingPostsRepository parses stack traces on create like PostRepository does.
+type stackParsingPostsRepository struct {
+	*MockPostsRepository
+}
+
+func (m stackParsingPostsRepository) Create(ctx context.Context, post *models.Post) (*models.Post, error) {
+	post.StackTrace = models.ParseStackTrace(post.Description)
+	return m.MockPostsRepository.Create(ctx, post)
+}
+
+func createPostWithDescription(t *testing.T, handler *PostsHandler, description string) map[string]interface{} {
+	t.Helper()
+	body, _ := json.Marshal(map[string]interface{}{
+		"type": "problem", "title": "Server panics on startup with nil map", "description": description,
+	})
+	req := addAuthContext(httptest.NewRequest(http.MethodPost, "/v1/posts", bytes.NewReader(body)), "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestCreatePost_ListsCrashDuplicates(t *testing.T) {
+	finder := &MockCrashDuplicateFinder{duplicates: []models.CrashDuplicate{
+		{ID: "old-post", Type: models.PostTypeProblem, Title: "Nil map panic in router", Status: models.PostStatusSolved, CreatedAt: time.Now()},
+	}}
+	handler := NewPostsHandler(stackParsingPostsRepository{NewMockPostsRepository()})
+	handler.SetCrashDuplicateFinder(finder)
+
+	resp := createPostWithDescription(t, handler, testGoPanicDescription)
+
+	data := resp["data"].(map[string]interface{})
+	stack, ok := data["stack_trace"].(map[string]interface{})
+	if !ok || stack["language"] != models.StackLanguageGo || stack["exception_type"] != "assignment to entry in nil map" {
+		t.Errorf("expected parsed go stack trace, got %v", data["stack_trace"])
+	}
+	if finder.fingerprint != stack["fingerprint"] || finder.excludeID != "new-post-id" {
+		t.Errorf("expected lookup by fingerprint excluding new post, got %q %q", finder.fingerprint, finder.excludeID)
+	}
+	duplicates, ok := resp["possible_duplicates"].([]interface{})
+	if !ok || len(duplicates) != 1 || duplicates[0].(map[string]interface{})["id"] != "old-post" {
+		t.Errorf("expected possible_duplicates with old-post, got %v", resp["possible_duplicates"])
+	}
+}
+
+func TestCreatePost_NoStackTraceSkipsDuplicateLookup(t *testing.T) {
+	finder := &MockCrashDuplicateFinder{}
+	handler := NewPostsHandler(stackParsingPostsRepository{NewMockPostsRepository()})
+	handler.SetCrashDuplicateFinder(finder)
+
+	resp := createPostWithDescription(t, handler, "The server panics on startup but I have no logs to share yet, only this description.")
+
+	if finder.fingerprint != "" {
+		t.Error("expected no duplicate lookup without a stack trace")
+	}
+	if _, ok := resp["possible_duplicates"]; ok {
+		t.Error("expected no possible_duplicates key")
+	}
+}
+
+func TestCreatePost_CrashDuplicateLookupFailureIgnored(t *testing.T) {
+	finder := &MockCrashDuplicateFinder{err: errors.New("db down")}
+	handler := NewPostsHandler(stackParsingPostsRepository{NewMockPostsRepository()})
+	handler.SetCrashDuplicateFinder(finder)
+
+	resp := createPostWithDescription(t, handler, testGoPanicDescription)
+
+	if _, ok := resp["possible_duplicates"]; ok {
+		t.Error("expected no possible_duplicates when the lookup fails")
+	}
+}
+
+func TestMCPExecutePost_ListsCrashDuplicates(t *testing.T) {
+	finder := &MockCrashDuplicateFinder{duplicates: []models.CrashDuplicate{
+		{ID: "old-post", Type: models.PostTypeProblem, Title: "Nil map panic in router", Status: models.PostStatusOpen},
+	}}
+	handler := NewMCPHandler(nil, nil)
+	handler.SetCrashDuplicateFinder(finder)
+
+	res, err := handler.executePost(context.Background(), map[string]interface{}{
+		"type": "problem", "title": "Server panics on startup", "description": testGoPanicDescription,
+	})
+	if err != nil {
+		t.Fatalf("executePost failed: %v", err)
+	}
+	text := mcpResultText(t, res)
+	if !strings.Contains(text, "same stack trace") || !strings.Contains(text, "ID: old-post") {
+		t.Errorf("expected crash duplicate pre-check, got:\n%s", text)
+	}
+}
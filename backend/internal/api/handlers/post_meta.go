@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// postMetaMaxAnswers caps the suggested answers embedded in QAPage JSON-LD.
+const postMetaMaxAnswers = 20
+
+// PostMetaPostsRepositoryInterface finds public posts for SEO metadata.
+type PostMetaPostsRepositoryInterface interface {
+	FindByID(ctx context.Context, id string) (*models.PostWithAuthor, error)
+}
+
+// PostMetaAnswersRepositoryInterface reads a question's answers for QAPage markup.
+type PostMetaAnswersRepositoryInterface interface {
+	ListAnswers(ctx context.Context, questionID string, opts models.AnswerListOptions) ([]models.AnswerWithAuthor, int, error)
+	FindAnswerByID(ctx context.Context, id string) (*models.AnswerWithAuthor, error)
+}
+
+// PostMetaApproachesRepositoryInterface reads a problem's approaches for QAPage markup.
+type PostMetaApproachesRepositoryInterface interface {
+	ListApproaches(ctx context.Context, problemID string, opts models.ApproachListOptions) ([]models.ApproachWithAuthor, int, error)
+}
+
+// PostMetaHandler handles GET /v1/posts/{id}/meta.
+type PostMetaHandler struct {
+	posts      PostMetaPostsRepositoryInterface
+	answers    PostMetaAnswersRepositoryInterface
+	approaches PostMetaApproachesRepositoryInterface
+	siteURL    string
+	logger     *slog.Logger
+}
+
+// NewPostMetaHandler creates a new PostMetaHandler. siteURL is the frontend
+// origin used for canonical and author URLs (e.g. https://solvr.dev).
+func NewPostMetaHandler(posts PostMetaPostsRepositoryInterface, siteURL string) *PostMetaHandler {
+	return &PostMetaHandler{
+		posts:   posts,
+		siteURL: strings.TrimRight(siteURL, "/"),
+		logger:  slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+	}
+}
+
+// SetAnswersRepo sets the repository used to embed answers in question markup.
+func (h *PostMetaHandler) SetAnswersRepo(repo PostMetaAnswersRepositoryInterface) {
+	h.answers = repo
+}
+
+// SetApproachesRepo sets the repository used to embed the solution in problem markup.
+func (h *PostMetaHandler) SetApproachesRepo(repo PostMetaApproachesRepositoryInterface) {
+	h.approaches = repo
+}
+
+// GetPostMeta handles GET /v1/posts/{id}/meta - SEO metadata for a public post.
+// Questions and problems get schema.org QAPage JSON-LD (the accepted answer or
+// succeeded approach as acceptedAnswer); ideas get DiscussionForumPosting.
+// Family-visibility, deleted and unpublished posts are 404.
+func (h *PostMetaHandler) GetPostMeta(w http.ResponseWriter, r *http.Request) {
+	postID := chi.URLParam(r, "id")
+
+	post, err := h.posts.FindByID(r.Context(), postID)
+	if err != nil {
+		if errors.Is(err, db.ErrPostNotFound) {
+			response.WriteNotFound(w, "post not found")
+			return
+		}
+		h.writeInternalError(w, r, "failed to get post", err, postID)
+		return
+	}
+	// Family posts (BART-151) stay out of crawler-facing metadata.
+	if post.DeletedAt != nil || !isPublishedStatus(post.Status) ||
+		(post.Visibility != "" && post.Visibility != models.VisibilityPublic) {
+		response.WriteNotFound(w, "post not found")
+		return
+	}
+
+	canonical := h.postURL(post.Type, post.ID)
+	description := metaDescription(post.Description)
+	meta := models.PostMeta{
+		Title:        post.Title + " | Solvr",
+		Description:  description,
+		CanonicalURL: canonical,
+		OpenGraph: models.OpenGraphMeta{
+			Type:          "article",
+			Title:         post.Title,
+			Description:   description,
+			URL:           canonical,
+			SiteName:      "Solvr",
+			PublishedTime: post.CreatedAt.UTC().Format(time.RFC3339),
+			ModifiedTime:  post.UpdatedAt.UTC().Format(time.RFC3339),
+			Tags:          post.Tags,
+		},
+		Twitter: models.TwitterCardMeta{
+			Card:        "summary",
+			Title:       post.Title,
+			Description: description,
+		},
+	}
+	if meta.OpenGraph.Tags == nil {
+		meta.OpenGraph.Tags = []string{}
+	}
+
+	switch post.Type {
+	case models.PostTypeQuestion:
+		meta.JSONLD, err = h.questionJSONLD(r.Context(), post, canonical)
+	case models.PostTypeProblem:
+		meta.JSONLD, err = h.problemJSONLD(r.Context(), post, canonical)
+	default:
+		meta.JSONLD = h.discussionJSONLD(post, canonical)
+	}
+	if err != nil {
+		h.writeInternalError(w, r, "failed to build structured data", err, postID)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	response.WriteJSON(w, http.StatusOK, meta)
+}
+
+// questionJSONLD builds QAPage markup with the accepted answer and the top answers by quality.
+func (h *PostMetaHandler) questionJSONLD(ctx context.Context, post *models.PostWithAuthor, canonical string) (map[string]interface{}, error) {
+	question := h.questionEntity(post, post.AnswersCount)
+
+	if h.answers != nil {
+		answers, _, err := h.answers.ListAnswers(ctx, post.ID, models.AnswerListOptions{
+			QuestionID: post.ID, Page: 1, PerPage: postMetaMaxAnswers, Sort: "quality",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var accepted *models.AnswerWithAuthor
+		suggested := make([]map[string]interface{}, 0, len(answers))
+		for i := range answers {
+			if answers[i].IsAccepted {
+				accepted = &answers[i]
+				continue
+			}
+			suggested = append(suggested, h.answerEntity(answers[i].Content, answers[i].VoteScore, answers[i].CreatedAt,
+				canonical+"#answer-"+answers[i].ID, answers[i].Author.Type, answers[i].Author.ID, answers[i].Author.DisplayName))
+		}
+		if accepted == nil && post.AcceptedAnswerID != nil {
+			accepted, err = h.answers.FindAnswerByID(ctx, *post.AcceptedAnswerID)
+			if err != nil && !errors.Is(err, db.ErrAnswerNotFound) {
+				return nil, err
+			}
+		}
+
+		if accepted != nil {
+			question["acceptedAnswer"] = h.answerEntity(accepted.Content, accepted.VoteScore, accepted.CreatedAt,
+				canonical+"#answer-"+accepted.ID, accepted.Author.Type, accepted.Author.ID, accepted.Author.DisplayName)
+		}
+		if len(suggested) > 0 {
+			question["suggestedAnswer"] = suggested
+		}
+	}
+
+	return map[string]interface{}{
+		"@context":   "https://schema.org",
+		"@type":      "QAPage",
+		"mainEntity": question,
+	}, nil
+}
+
+// problemJSONLD builds QAPage markup with the earliest succeeded approach as the accepted answer.
+func (h *PostMetaHandler) problemJSONLD(ctx context.Context, post *models.PostWithAuthor, canonical string) (map[string]interface{}, error) {
+	question := h.questionEntity(post, post.ApproachesCount)
+
+	if h.approaches != nil {
+		approaches, _, err := h.approaches.ListApproaches(ctx, post.ID, models.ApproachListOptions{
+			ProblemID: post.ID, Page: 1, PerPage: 50,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Approaches come newest first; the earliest success is the accepted one.
+		for i := len(approaches) - 1; i >= 0; i-- {
+			a := approaches[i]
+			if a.Status != models.ApproachStatusSucceeded {
+				continue
+			}
+			text := a.Solution
+			if text == "" {
+				text = a.Outcome
+			}
+			if text == "" {
+				text = a.Angle
+			}
+			question["acceptedAnswer"] = h.answerEntity(text, 0, a.CreatedAt,
+				canonical+"#approach-"+a.ID, a.Author.Type, a.Author.ID, a.Author.DisplayName)
+			break
+		}
+	}
+
+	return map[string]interface{}{
+		"@context":   "https://schema.org",
+		"@type":      "QAPage",
+		"mainEntity": question,
+	}, nil
+}
+
+// discussionJSONLD builds DiscussionForumPosting markup for ideas.
+func (h *PostMetaHandler) discussionJSONLD(post *models.PostWithAuthor, canonical string) map[string]interface{} {
+	return map[string]interface{}{
+		"@context":      "https://schema.org",
+		"@type":         "DiscussionForumPosting",
+		"headline":      post.Title,
+		"text":          post.Description,
+		"url":           canonical,
+		"datePublished": post.CreatedAt.UTC().Format(time.RFC3339),
+		"dateModified":  post.UpdatedAt.UTC().Format(time.RFC3339),
+		"author":        h.personEntity(post.Author.Type, post.Author.ID, post.Author.DisplayName),
+		"keywords":      strings.Join(post.Tags, ", "),
+		"interactionStatistic": map[string]interface{}{
+			"@type":                "InteractionCounter",
+			"interactionType":      "https://schema.org/LikeAction",
+			"userInteractionCount": post.VoteScore,
+		},
+	}
+}
+
+func (h *PostMetaHandler) questionEntity(post *models.PostWithAuthor, answerCount int) map[string]interface{} {
+	return map[string]interface{}{
+		"@type":       "Question",
+		"name":        post.Title,
+		"text":        post.Description,
+		"answerCount": answerCount,
+		"upvoteCount": post.VoteScore,
+		"dateCreated": post.CreatedAt.UTC().Format(time.RFC3339),
+		"author":      h.personEntity(post.Author.Type, post.Author.ID, post.Author.DisplayName),
+	}
+}
+
+func (h *PostMetaHandler) answerEntity(text string, upvotes int, createdAt time.Time, url string, authorType models.AuthorType, authorID, authorName string) map[string]interface{} {
+	return map[string]interface{}{
+		"@type":       "Answer",
+		"text":        text,
+		"upvoteCount": upvotes,
+		"dateCreated": createdAt.UTC().Format(time.RFC3339),
+		"url":         url,
+		"author":      h.personEntity(authorType, authorID, authorName),
+	}
+}
+
+// personEntity describes an author. Agents are typed as Person too, since
+// schema.org has no type for software agents.
+func (h *PostMetaHandler) personEntity(authorType models.AuthorType, id, name string) map[string]interface{} {
+	path := "/users/"
+	if authorType == models.AuthorTypeAgent {
+		path = "/agents/"
+	}
+	if name == "" {
+		name = id
+	}
+	return map[string]interface{}{
+		"@type": "Person",
+		"name":  name,
+		"url":   h.siteURL + path + id,
+	}
+}
+
+// postURL returns the frontend URL of a post page.
+func (h *PostMetaHandler) postURL(postType models.PostType, id string) string {
+	return h.siteURL + "/" + string(postType) + "s/" + id
+}
+
+func (h *PostMetaHandler) writeInternalError(w http.ResponseWriter, r *http.Request, message string, err error, postID string) {
+	response.WriteInternalErrorWithLog(w, message, err, response.LogContext{
+		Operation: "GetPostMeta",
+		Resource:  "post",
+		RequestID: r.Header.Get("X-Request-ID"),
+		Extra:     map[string]string{"postID": postID},
+	}, h.logger)
+}
+
+// isPublishedStatus reports whether posts in status are publicly listed.
+func isPublishedStatus(status models.PostStatus) bool {
+	switch status {
+	case models.PostStatusDraft, models.PostStatusPendingReview, models.PostStatusRejected:
+		return false
+	}
+	return true
+}
+
+// metaDescription flattens markdown-ish text to one line and truncates it to
+// models.MetaDescriptionLength runes on a word boundary.
+func metaDescription(text string) string {
+	text = strings.NewReplacer("#", "", "*", "", "`", "", ">", "", "_", " ").Replace(text)
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= models.MetaDescriptionLength {
+		return text
+	}
+	runes := []rune(text)[:models.MetaDescriptionLength-1]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > models.MetaDescriptionLength/2 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
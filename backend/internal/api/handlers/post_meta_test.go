@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+type mockPostMetaPostsRepo struct {
+	post *models.PostWithAuthor
+}
+
+func (m *mockPostMetaPostsRepo) FindByID(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	if m.post == nil {
+		return nil, db.ErrPostNotFound
+	}
+	return m.post, nil
+}
+
+type mockPostMetaAnswersRepo struct {
+	answers  []models.AnswerWithAuthor
+	accepted *models.AnswerWithAuthor
+	opts     models.AnswerListOptions
+}
+
+func (m *mockPostMetaAnswersRepo) ListAnswers(ctx context.Context, questionID string, opts models.AnswerListOptions) ([]models.AnswerWithAuthor, int, error) {
+	m.opts = opts
+	return m.answers, len(m.answers), nil
+}
+
+func (m *mockPostMetaAnswersRepo) FindAnswerByID(ctx context.Context, id string) (*models.AnswerWithAuthor, error) {
+	if m.accepted == nil || m.accepted.ID != id {
+		return nil, db.ErrAnswerNotFound
+	}
+	return m.accepted, nil
+}
+
+type mockPostMetaApproachesRepo struct {
+	approaches []models.ApproachWithAuthor
+}
+
+func (m *mockPostMetaApproachesRepo) ListApproaches(ctx context.Context, problemID string, opts models.ApproachListOptions) ([]models.ApproachWithAuthor, int, error) {
+	return m.approaches, len(m.approaches), nil
+}
+
+func metaTestPost(postType models.PostType) *models.PostWithAuthor {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &models.PostWithAuthor{
+		Post: models.Post{
+			ID:          "post-1",
+			Type:        postType,
+			Title:       "pgx pool exhausted under load",
+			Description: "## Context\n\nOur **pgx** pool runs out of connections.",
+			Tags:        []string{"go", "postgres"},
+			Status:      models.PostStatusOpen,
+			CreatedAt:   created,
+			UpdatedAt:   created,
+		},
+		Author:    models.PostAuthor{Type: models.AuthorTypeAgent, ID: "claude_bot", DisplayName: "Claude Bot"},
+		VoteScore: 7,
+	}
+}
+
+func getPostMeta(t *testing.T, handler *PostMetaHandler) (*httptest.ResponseRecorder, models.PostMeta) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-1/meta", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	handler.GetPostMeta(w, req)
+
+	var resp struct {
+		Data models.PostMeta `json:"data"`
+	}
+	if w.Code == http.StatusOK {
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return w, resp.Data
+}
+
+func TestPostMeta_QuestionQAPage(t *testing.T) {
+	post := metaTestPost(models.PostTypeQuestion)
+	acceptedID := "ans-2"
+	post.AcceptedAnswerID = &acceptedID
+	post.AnswersCount = 2
+
+	answers := &mockPostMetaAnswersRepo{answers: []models.AnswerWithAuthor{
+		{Answer: models.Answer{ID: "ans-1", Content: "Raise MaxConns."}, Author: models.AnswerAuthor{Type: models.AuthorTypeHuman, ID: "user-1", DisplayName: "Ana"}, VoteScore: 3},
+		{Answer: models.Answer{ID: "ans-2", Content: "Release rows.", IsAccepted: true}, Author: models.AnswerAuthor{Type: models.AuthorTypeAgent, ID: "claude_bot"}, VoteScore: 9},
+	}}
+	handler := NewPostMetaHandler(&mockPostMetaPostsRepo{post: post}, "https://solvr.dev/")
+	handler.SetAnswersRepo(answers)
+
+	w, meta := getPostMeta(t, handler)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if meta.CanonicalURL != "https://solvr.dev/questions/post-1" || meta.OpenGraph.URL != meta.CanonicalURL {
+		t.Errorf("unexpected canonical URL %q / og:url %q", meta.CanonicalURL, meta.OpenGraph.URL)
+	}
+	if meta.Description != "Context Our pgx pool runs out of connections." {
+		t.Errorf("unexpected description %q", meta.Description)
+	}
+	if meta.Twitter.Card != "summary" || meta.OpenGraph.Type != "article" {
+		t.Errorf("unexpected card data %+v / %+v", meta.Twitter, meta.OpenGraph)
+	}
+	if answers.opts.Sort != "quality" {
+		t.Errorf("expected answers sorted by quality, got %q", answers.opts.Sort)
+	}
+
+	if meta.JSONLD["@type"] != "QAPage" {
+		t.Fatalf("expected QAPage, got %v", meta.JSONLD["@type"])
+	}
+	question := meta.JSONLD["mainEntity"].(map[string]interface{})
+	if question["name"] != post.Title || question["answerCount"] != float64(2) {
+		t.Errorf("unexpected question entity %+v", question)
+	}
+	author := question["author"].(map[string]interface{})
+	if author["url"] != "https://solvr.dev/agents/claude_bot" {
+		t.Errorf("unexpected author url %v", author["url"])
+	}
+	accepted := question["acceptedAnswer"].(map[string]interface{})
+	if accepted["text"] != "Release rows." || accepted["url"] != "https://solvr.dev/questions/post-1#answer-ans-2" {
+		t.Errorf("unexpected accepted answer %+v", accepted)
+	}
+	suggested := question["suggestedAnswer"].([]interface{})
+	if len(suggested) != 1 {
+		t.Errorf("expected 1 suggested answer, got %d", len(suggested))
+	}
+}
+
+func TestPostMeta_ProblemAcceptsSucceededApproach(t *testing.T) {
+	post := metaTestPost(models.PostTypeProblem)
+	handler := NewPostMetaHandler(&mockPostMetaPostsRepo{post: post}, "https://solvr.dev")
+	handler.SetApproachesRepo(&mockPostMetaApproachesRepo{approaches: []models.ApproachWithAuthor{
+		{Approach: models.Approach{ID: "ap-2", Status: models.ApproachStatusFailed, Angle: "retry"}},
+		{Approach: models.Approach{ID: "ap-1", Status: models.ApproachStatusSucceeded, Angle: "pool", Solution: "Close rows in a defer."}},
+	}})
+
+	w, meta := getPostMeta(t, handler)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	question := meta.JSONLD["mainEntity"].(map[string]interface{})
+	accepted, ok := question["acceptedAnswer"].(map[string]interface{})
+	if !ok || accepted["text"] != "Close rows in a defer." {
+		t.Errorf("expected the succeeded approach as accepted answer, got %+v", question["acceptedAnswer"])
+	}
+}
+
+func TestPostMeta_IdeaDiscussionPosting(t *testing.T) {
+	handler := NewPostMetaHandler(&mockPostMetaPostsRepo{post: metaTestPost(models.PostTypeIdea)}, "https://solvr.dev")
+
+	w, meta := getPostMeta(t, handler)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if meta.JSONLD["@type"] != "DiscussionForumPosting" || meta.CanonicalURL != "https://solvr.dev/ideas/post-1" {
+		t.Errorf("unexpected idea markup %v at %q", meta.JSONLD["@type"], meta.CanonicalURL)
+	}
+}
+
+func TestPostMeta_NotFound(t *testing.T) {
+	deleted := time.Now()
+	tests := map[string]*models.PostWithAuthor{
+		"missing": nil,
+		"deleted": func() *models.PostWithAuthor {
+			p := metaTestPost(models.PostTypeQuestion)
+			p.DeletedAt = &deleted
+			return p
+		}(),
+		"draft": func() *models.PostWithAuthor {
+			p := metaTestPost(models.PostTypeQuestion)
+			p.Status = models.PostStatusDraft
+			return p
+		}(),
+		"family": func() *models.PostWithAuthor {
+			p := metaTestPost(models.PostTypeQuestion)
+			p.Visibility = models.VisibilityFamily
+			return p
+		}(),
+	}
+	for name, post := range tests {
+		handler := NewPostMetaHandler(&mockPostMetaPostsRepo{post: post}, "https://solvr.dev")
+		w, _ := getPostMeta(t, handler)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", name, w.Code)
+		}
+	}
+}
+
+func TestMetaDescription_Truncates(t *testing.T) {
+	desc := metaDescription(strings.Repeat("connection pool ", 30))
+	if n := len([]rune(desc)); n > models.MetaDescriptionLength {
+		t.Errorf("expected at most %d runes, got %d", models.MetaDescriptionLength, n)
+	}
+	if !strings.HasSuffix(desc, "…") {
+		t.Errorf("expected ellipsis, got %q", desc)
+	}
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+// PostPublishedNotifier is told when a post becomes publicly visible, either on
+// creation or when moderation approves it. Used for Slack/Discord integrations.
+type PostPublishedNotifier interface {
+	NotifyPostPublished(ctx context.Context, post *models.Post) error
+}
+
+// announcePostAsync notifies in the background so chat webhooks never slow down
+// the request or moderation path. A nil notifier is a no-op. The announcement
+// keeps ctx's tenant but not its deadline.
+func announcePostAsync(ctx context.Context, notifier PostPublishedNotifier, post *models.Post, logger *slog.Logger) {
+	if notifier == nil {
+		return
+	}
+	tenantID := tenant.FromContext(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), tenantID), 60*time.Second)
+		defer cancel()
+		if err := notifier.NotifyPostPublished(ctx, post); err != nil {
+			logger.Error("failed to announce published post", "postID", post.ID, "error", err)
+		}
+	}()
+}
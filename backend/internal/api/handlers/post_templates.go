@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// PostTemplateResponse is one entry of GET /v1/templates.
+type PostTemplateResponse struct {
+	models.PostTemplate
+	// Skeleton is a markdown description with every section heading, ready
+	// to prefill the editor.
+	Skeleton string `json:"skeleton"`
+}
+
+// ListPostTemplates handles GET /v1/templates - the post templates, optionally
+// filtered by ?type=problem|question|idea. No auth required.
+func ListPostTemplates(w http.ResponseWriter, r *http.Request) {
+	postType := models.PostType(r.URL.Query().Get("type"))
+	if postType != "" && !models.IsValidPostType(postType) {
+		apierror.Write(w, apierror.InvalidType, "type must be problem, question or idea")
+		return
+	}
+
+	templates := models.PostTemplates(postType)
+	data := make([]PostTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		data = append(data, PostTemplateResponse{PostTemplate: t, Skeleton: t.Skeleton()})
+	}
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{"data": data})
+}
+
+// validatePostTemplate checks a post created with template_id against the
+// template: it must exist, match the post type, and have every required
+// section filled in its description.
+func validatePostTemplate(v *Validator, templateID string, postType models.PostType, description string) {
+	if templateID == "" {
+		return
+	}
+	tmpl, ok := models.FindPostTemplate(templateID)
+	if !ok {
+		v.Add(FieldError{Field: "template_id", Code: FieldInvalidValue, Message: "unknown template_id " + templateID})
+		return
+	}
+	if models.IsValidPostType(postType) && tmpl.Type != postType {
+		v.Add(FieldError{Field: "template_id", Code: FieldInvalidValue,
+			Message: "template " + tmpl.ID + " is for " + string(tmpl.Type) + " posts"})
+		return
+	}
+	if description == "" {
+		return
+	}
+	if missing := tmpl.MissingSections(description); len(missing) > 0 {
+		headings := make([]string, len(missing))
+		for i, s := range missing {
+			headings[i] = s.Heading
+		}
+		v.Add(FieldError{Field: "description", Code: FieldRequired,
+			Message: "description is missing required sections: " + strings.Join(headings, ", ")})
+	}
+}
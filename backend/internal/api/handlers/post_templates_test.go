@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListPostTemplates(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/templates?type=problem", nil)
+	rr := httptest.NewRecorder()
+	ListPostTemplates(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data []PostTemplateResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Data) == 0 {
+		t.Fatal("expected problem templates")
+	}
+	for _, tmpl := range resp.Data {
+		if tmpl.Type != "problem" {
+			t.Errorf("template %s has type %s", tmpl.ID, tmpl.Type)
+		}
+		if len(tmpl.Sections) == 0 || !strings.Contains(tmpl.Skeleton, "## "+tmpl.Sections[0].Heading) {
+			t.Errorf("template %s: expected sections and a skeleton", tmpl.ID)
+		}
+	}
+
+	rr = httptest.NewRecorder()
+	ListPostTemplates(rr, httptest.NewRequest(http.MethodGet, "/v1/templates?type=rant", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an unknown type, got %d", rr.Code)
+	}
+}
+
+func TestCreatePost_Template(t *testing.T) {
+	const filled = "## Expected behavior\nThe importer finishes.\n\n## Actual behavior\npanic: assignment to entry in nil map\n\n" +
+		"## Steps to reproduce\nImport an empty archive.\n\n## Environment\nGo 1.24 on Linux"
+	tests := []struct {
+		name       string
+		postType   string
+		templateID string
+		desc       string
+		wantStatus int
+		wantError  string
+	}{
+		{name: "sections filled", postType: "problem", templateID: "bug-report", desc: filled, wantStatus: http.StatusCreated},
+		{name: "missing sections", postType: "problem", templateID: "bug-report",
+			desc:       "## Expected behavior\nThe importer finishes without crashing on an empty archive.",
+			wantStatus: http.StatusBadRequest, wantError: "Steps to reproduce"},
+		{name: "type mismatch", postType: "question", templateID: "bug-report", desc: filled,
+			wantStatus: http.StatusBadRequest, wantError: "template_id"},
+		{name: "unknown template", postType: "problem", templateID: "haiku", desc: filled,
+			wantStatus: http.StatusBadRequest, wantError: "template_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockPostsRepository()
+			handler := NewPostsHandler(repo)
+
+			body, _ := json.Marshal(CreatePostRequest{
+				Type:        tt.postType,
+				Title:       "Importer panics on an empty archive",
+				Description: tt.desc,
+				TemplateID:  tt.templateID,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(string(body)))
+			req = addAuthContext(req, "user-123", "user")
+			rr := httptest.NewRecorder()
+			handler.Create(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+			if tt.wantError != "" && !strings.Contains(rr.Body.String(), tt.wantError) {
+				t.Errorf("expected error mentioning %q, got %s", tt.wantError, rr.Body.String())
+			}
+		})
+	}
+}
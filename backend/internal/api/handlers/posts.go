@@ -14,9 +14,12 @@ import (
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -74,7 +77,11 @@ type PostsRepositoryInterface interface {
 
 	// FindByIDForViewer returns a single post by ID with the viewer's vote direction.
 	// callerHuman is the caller's family human UUID for visibility scoping ("" = public-only).
-	FindByIDForViewer(ctx context.Context, id string, viewerType models.AuthorType, viewerID string, callerHuman string) (*models.PostWithAuthor, error)
+	FindByIDForViewer(ctx context.Context, id string) (*models.PostWithAuthor, error)
+
+	// FindByIDFromPrimary is FindByIDForViewer read from the primary, never the
+	// replica. Update, delete and vote use it so they act on the latest row.
+	FindByIDFromPrimary(ctx context.Context, id string) (*models.PostWithAuthor, error)
 
 	// Create creates a new post and returns it.
 	Create(ctx context.Context, post *models.Post) (*models.Post, error)
@@ -147,6 +154,12 @@ type CommentCreatorInterface interface {
 	Create(ctx context.Context, comment *models.Comment) (*models.Comment, error)
 }
 
+// ModerationCommentRenderer renders the system comment for a moderation outcome
+// from the admin-edited templates (see modtemplate.Renderer).
+type ModerationCommentRenderer interface {
+	Render(ctx context.Context, key modtemplate.Key, vars modtemplate.Vars) string
+}
+
 // NotificationServiceInterface sends notifications on moderation decisions.
 type NotificationServiceInterface interface {
 	NotifyOnModerationResult(ctx context.Context, postID, postTitle, postType, authorType, authorID string, approved bool, explanation string) error
@@ -161,7 +174,13 @@ type ApproachCheckerInterface interface {
 // for posts that were rejected solely for language. Called inline from
 // moderatePostAsync when a language-only rejection is detected.
 type PostTranslationTrigger interface {
-	TranslateAndModerateAsync(postID, title, description string, tags []string, language, postType, authorType, authorID string)
+	TranslateAndModerateAsync(ctx context.Context, postID, title, description string, tags []string, language, postType, authorType, authorID string)
+}
+
+// EmbeddingQueueInterface queues posts whose embedding could not be generated inline,
+// so a background job can embed them once the provider is available again.
+type EmbeddingQueueInterface interface {
+	Enqueue(ctx context.Context, postID, reason string) error
 }
 
 // Default retry delays for content moderation (exponential backoff: 2s, 4s, 8s).
@@ -171,13 +190,25 @@ type PostsHandler struct {
 	repo              PostsRepositoryInterface
 	logger            *slog.Logger
 	embeddingService  EmbeddingServiceInterface
+	embeddingQueue    EmbeddingQueueInterface
 	contentModService ContentModerationServiceInterface
 	statusUpdater     PostStatusUpdaterInterface
 	flagCreator       FlagCreatorInterface
 	commentRepo       CommentCreatorInterface
+	commentTemplates  ModerationCommentRenderer
 	notifService      NotificationServiceInterface
 	approachChecker      ApproachCheckerInterface
+	criteriaRepo         SuccessCriteriaRepositoryInterface // see problems_criteria.go
 	translationTrigger   PostTranslationTrigger
+	publishedNotifier    PostPublishedNotifier
+	githubFetcher        GitHubIssueFetcher                // see posts_github.go
+	githubLinks          PostGitHubLinkRepositoryInterface // see posts_github.go
+	crashDuplicates      CrashDuplicateFinder
+	guestClaims          GuestClaimRepositoryInterface // see posts_guest.go
+	guestNotifier        GuestClaimNotifier            // see posts_guest.go
+	freshness            PostFreshnessRepositoryInterface // see posts_freshness.go
+	collaborators        PostCollaboratorRepositoryInterface // see posts_collaborators.go
+	contentLimits      contentLimitsSource                 // see content_limits.go
 	retryDelays          []time.Duration
 }
 
@@ -202,6 +233,12 @@ func (h *PostsHandler) SetEmbeddingService(svc EmbeddingServiceInterface) {
 	h.embeddingService = svc
 }
 
+// SetEmbeddingQueue sets the queue used when an embedding can't be generated inline
+// (embedding service unset or failing). Without it those posts stay unembedded.
+func (h *PostsHandler) SetEmbeddingQueue(queue EmbeddingQueueInterface) {
+	h.embeddingQueue = queue
+}
+
 // SetContentModerationService sets the content moderation service.
 // When set, post creation triggers async moderation via Groq.
 func (h *PostsHandler) SetContentModerationService(svc ContentModerationServiceInterface) {
@@ -223,6 +260,12 @@ func (h *PostsHandler) SetCommentRepo(repo CommentCreatorInterface) {
 	h.commentRepo = repo
 }
 
+// SetModerationCommentRenderer sets the templates moderation comments are rendered
+// from. Without it the built-in texts are used.
+func (h *PostsHandler) SetModerationCommentRenderer(renderer ModerationCommentRenderer) {
+	h.commentTemplates = renderer
+}
+
 // SetNotificationService sets the notification service for moderation notifications.
 func (h *PostsHandler) SetNotificationService(svc NotificationServiceInterface) {
 	h.notifService = svc
@@ -233,6 +276,12 @@ func (h *PostsHandler) SetApproachChecker(checker ApproachCheckerInterface) {
 	h.approachChecker = checker
 }
 
+// SetSuccessCriteriaRepository requires a problem's success criteria to be met,
+// or an override_reason given, before it can be updated to solved.
+func (h *PostsHandler) SetSuccessCriteriaRepository(repo SuccessCriteriaRepositoryInterface) {
+	h.criteriaRepo = repo
+}
+
 // SetTranslationTrigger sets the inline translation trigger.
 // When set, language-only rejections trigger immediate translation
 // instead of waiting for the hourly sweep.
@@ -240,6 +289,17 @@ func (h *PostsHandler) SetTranslationTrigger(trigger PostTranslationTrigger) {
 	h.translationTrigger = trigger
 }
 
+// SetPostPublishedNotifier announces public posts once moderation approves them.
+// Family posts skip moderation and are never announced.
+func (h *PostsHandler) SetPostPublishedNotifier(notifier PostPublishedNotifier) {
+	h.publishedNotifier = notifier
+}
+
+// SetCrashDuplicateFinder lists posts with the same stack trace when a post is created.
+func (h *PostsHandler) SetCrashDuplicateFinder(finder CrashDuplicateFinder) {
+	h.crashDuplicates = finder
+}
+
 // SetRetryDelays overrides retry delays (useful for testing).
 func (h *PostsHandler) SetRetryDelays(delays []time.Duration) {
 	h.retryDelays = delays
@@ -247,11 +307,11 @@ func (h *PostsHandler) SetRetryDelays(delays []time.Duration) {
 
 // TriggerModerationAsync implements jobs.PostModerationTrigger.
 // Fires off moderatePostAsync in a goroutine so the translation job can trigger re-moderation.
-func (h *PostsHandler) TriggerAsync(postID, title, description string, tags []string, postType, authorType, authorID string) {
+func (h *PostsHandler) TriggerAsync(ctx context.Context, postID, title, description string, tags []string, postType, authorType, authorID string) {
 	if h.contentModService == nil {
 		return
 	}
-	go h.moderatePostAsync(postID, title, description, tags, postType, authorType, authorID)
+	go h.moderatePostAsync(tenant.FromContext(ctx), postID, title, description, tags, postType, authorType, authorID)
 }
 
 // CreatePostRequest is the request body for creating a post.
@@ -264,6 +324,12 @@ type CreatePostRequest struct {
 	SuccessCriteria []string `json:"success_criteria,omitempty"` // For problems
 	Weight          *int     `json:"weight,omitempty"`           // For problems
 	Visibility      string   `json:"visibility,omitempty"`       // "public" (default) or "family" (BART-151)
+	// PublishAt schedules the post: it stays a draft until then, and is only
+	// moderated and embedded once the scheduled publish job opens it.
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	// TemplateID, when set, names a post template (GET /v1/templates) whose
+	// required sections the description must fill in.
+	TemplateID string `json:"template_id,omitempty"`
 }
 
 // UpdatePostRequest is the request body for updating a post.
@@ -272,11 +338,16 @@ type UpdatePostRequest struct {
 	Description *string  `json:"description,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
 	Status      *string  `json:"status,omitempty"`
+	// ExpectedUpdatedAt, when set, must equal the post's current updated_at
+	// or the update is rejected with 409 Conflict.
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty"`
+	// OverrideReason lets a problem be solved with unmet success criteria.
+	OverrideReason string `json:"override_reason,omitempty"`
 }
 
 // VoteRequest is the request body for voting.
 type VoteRequest struct {
-	Direction string `json:"direction"` // "up" or "down"
+	Direction string `json:"direction"` // "up", "down", or "none" to retract
 }
 
 // PostsListResponse is the response for listing posts.
@@ -363,14 +434,6 @@ func (h *PostsHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Pass viewer info for user_vote lookup (works when OptionalAuthMiddleware is applied)
-	if authInfo := GetAuthInfo(r); authInfo != nil {
-		opts.ViewerType = authInfo.AuthorType
-		opts.ViewerID = authInfo.AuthorID
-	}
-	// BART-151: caller's family human for visibility scoping ("" = public-only).
-	opts.ViewerHuman = callerHumanID(r)
-
 	// Execute query
 	posts, total, err := h.repo.List(r.Context(), opts)
 	if err != nil {
@@ -403,22 +466,16 @@ func (h *PostsHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *PostsHandler) Get(w http.ResponseWriter, r *http.Request) {
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
-	// Use FindByIDForViewer when authenticated to include user_vote
-	var post *models.PostWithAuthor
-	var err error
-	authInfo := GetAuthInfo(r)
-	if authInfo != nil {
-		post, err = h.repo.FindByIDForViewer(r.Context(), postID, authInfo.AuthorType, authInfo.AuthorID, callerHumanID(r))
-	} else {
-		post, err = h.repo.FindByID(r.Context(), postID)
-	}
+	// The caller's vote and family visibility come from the principal in the context
+	// (works when OptionalAuthMiddleware is applied).
+	post, err := h.repo.FindByIDForViewer(r.Context(), postID)
 	if err != nil {
 		if errors.Is(err, db.ErrPostNotFound) {
-			writePostsError(w, http.StatusNotFound, "NOT_FOUND", "post not found")
+			apierror.Write(w, apierror.NotFound, "post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -433,13 +490,17 @@ func (h *PostsHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	// Check if deleted
 	if post.DeletedAt != nil {
-		writePostsError(w, http.StatusNotFound, "NOT_FOUND", "post not found")
+		apierror.Write(w, apierror.NotFound, "post not found")
 		return
 	}
 
+	// Tagged before the swap below, like the stored post write paths check If-Match
+	// against; the swap depends only on the viewer, which the tag already covers.
+	etag := postETag(post, etagViewer(r))
+
 	// Server-side swap: if viewer is the author (or the human owner of the agent author)
 	// and post was translated, show original language content in title/description fields.
-	if authInfo != nil && post.OriginalTitle != "" {
+	if authInfo := GetAuthInfo(r); authInfo != nil && post.OriginalTitle != "" {
 		isAuthor := authInfo.AuthorType == post.PostedByType &&
 			authInfo.AuthorID == post.PostedByID
 		isAgentOwner := authInfo.AuthorType == models.AuthorTypeHuman &&
@@ -453,6 +514,7 @@ func (h *PostsHandler) Get(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	w.Header().Set("ETag", etag)
 	writePostsJSON(w, http.StatusOK, PostResponse{Data: *post})
 }
 
@@ -462,35 +524,14 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writePostsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse request body
 	var req CreatePostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
-		return
-	}
-
-	// Validate type
-	postType := models.PostType(req.Type)
-	if !models.IsValidPostType(postType) {
-		writePostsError(w, http.StatusBadRequest, "INVALID_TYPE", "type must be one of: problem, question, idea")
-		return
-	}
-
-	// Validate title
-	if req.Title == "" {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title is required")
-		return
-	}
-	if len(req.Title) < 10 {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at least 10 characters")
-		return
-	}
-	if len(req.Title) > 200 {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at most 200 characters")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
@@ -499,32 +540,29 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		req.Description = req.Content
 	}
 
-	// Validate description
-	if req.Description == "" {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description is required")
-		return
+	postType := models.PostType(req.Type)
+	var v Validator
+	if !models.IsValidPostType(postType) {
+		v.OneOf("type", req.Type, string(models.PostTypeProblem), string(models.PostTypeQuestion), string(models.PostTypeIdea))
 	}
-	if len(req.Description) < 50 {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description must be at least 50 characters")
-		return
+	limits := h.contentLimits.For(postType)
+	if v.Required("title", req.Title) {
+		validatePostLengths(&v, limits, &req.Title, nil, nil)
 	}
-
-	// Validate tags
-	if len(req.Tags) > models.MaxTagsPerPost {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("maximum %d tags allowed", models.MaxTagsPerPost))
-		return
+	if v.Required("description", req.Description) {
+		validatePostLengths(&v, limits, nil, &req.Description, nil)
 	}
+	v.MaxItems("tags", len(req.Tags), models.MaxTagsPerPost)
+	req.Tags = validateTags(&v, req.Tags)
+	validatePostTemplate(&v, req.TemplateID, postType, req.Description)
 
-	// Validate problem-specific fields
+	// Problem-specific fields
 	if postType == models.PostTypeProblem {
-		if req.Weight != nil && (*req.Weight < 1 || *req.Weight > 5) {
-			writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "weight must be between 1 and 5")
-			return
-		}
-		if len(req.SuccessCriteria) > 10 {
-			writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "maximum 10 success criteria allowed")
-			return
+		if req.Weight != nil {
+			v.Range("weight", *req.Weight, 1, 5)
 		}
+		v.MaxItems("success_criteria", len(req.SuccessCriteria), models.MaxSuccessCriteria)
+		validatePostLengths(&v, limits, nil, nil, req.SuccessCriteria)
 	}
 
 	// Visibility (BART-151): default "public". A "family" post is owned by the author's
@@ -536,9 +574,25 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	case models.VisibilityFamily:
 		visibility = models.VisibilityFamily
 	default:
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "visibility must be 'public' or 'family'")
+		v.OneOf("visibility", req.Visibility, models.VisibilityPublic, models.VisibilityFamily)
+	}
+
+	if req.PublishAt != nil {
+		if now := time.Now(); !req.PublishAt.After(now) || req.PublishAt.After(now.Add(models.MaxPostPublishDelay)) {
+			v.Add(FieldError{Field: "publish_at", Code: FieldOutOfRange,
+				Message: "publish_at must be in the future and at most 365 days ahead"})
+		}
+	}
+
+	if !v.Valid() {
+		code := apierror.ValidationError
+		if v.HasError("type") {
+			code = apierror.InvalidType
+		}
+		writeFieldErrors(w, code, v.Errors())
 		return
 	}
+
 	// Derive the owning human for family scoping: claimed agent → its human_id; human → user id.
 	var ownerHumanID *string
 	if agent := auth.AgentFromContext(r.Context()); agent != nil {
@@ -548,7 +602,7 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		ownerHumanID = &id
 	}
 	if visibility == models.VisibilityFamily && ownerHumanID == nil {
-		writePostsError(w, http.StatusBadRequest, "UNCLAIMED_AGENT",
+		apierror.Write(w, apierror.UnclaimedAgent,
 			"claim your agent to a human before creating family-private posts")
 		return
 	}
@@ -560,6 +614,12 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if visibility == models.VisibilityFamily {
 		initialStatus = models.PostStatusOpen
 	}
+	// Scheduled posts wait as drafts; the scheduled publish job applies the rules
+	// above at publish_at.
+	scheduled := req.PublishAt != nil
+	if scheduled {
+		initialStatus = models.PostStatusDraft
+	}
 
 	// Create post with author info from authentication
 	post := &models.Post{
@@ -574,21 +634,15 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Weight:          req.Weight,
 		Visibility:      visibility,
 		OwnerHumanID:    ownerHumanID,
+		PublishAt:       req.PublishAt,
 	}
 
-	// Synchronous embedding adds ~50-100ms latency but ensures post is immediately searchable
-	if h.embeddingService != nil {
-		embedCtx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		text := post.Title + " " + post.Description
-		embedding, embedErr := h.embeddingService.GenerateEmbedding(embedCtx, text)
-		if embedErr != nil {
-			h.logger.Warn("failed to generate embedding for post", "error", embedErr)
-		} else {
-			vecStr := float32SliceToVectorString(embedding)
-			post.EmbeddingStr = &vecStr
-		}
+	// Synchronous embedding adds ~50-100ms latency but ensures post is immediately searchable.
+	// On failure the post is still created and queued for a background retry.
+	// Scheduled posts are queued for embedding when they are published.
+	var embedQueueReason string
+	if !scheduled {
+		embedQueueReason = h.embedPost(r.Context(), post.Title, post.Description, &post.EmbeddingStr)
 	}
 
 	createdPost, err := h.repo.Create(r.Context(), post)
@@ -607,17 +661,20 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if embedQueueReason != "" {
+		h.enqueueEmbedding(r.Context(), createdPost.ID, embedQueueReason)
+	}
+
 	// Trigger async content moderation for everything EXCEPT family posts (BART-154).
 	// Family/private posts are visible only to their owner's family, so they skip the
 	// moderation gate entirely and are already 'open'. Fail-safe: any non-family visibility
 	// still gets moderated.
-	if h.contentModService != nil && visibility != models.VisibilityFamily {
-		go h.moderatePostAsync(createdPost.ID, post.Title, post.Description, post.Tags, string(post.Type), string(authInfo.AuthorType), authInfo.AuthorID)
+	if h.contentModService != nil && visibility != models.VisibilityFamily && !scheduled {
+		go h.moderatePostAsync(tenant.FromContext(r.Context()), createdPost.ID, post.Title, post.Description, post.Tags, string(post.Type), string(authInfo.AuthorType), authInfo.AuthorID)
 	}
 
-	writePostsJSON(w, http.StatusCreated, map[string]interface{}{
-		"data": createdPost,
-	})
+	duplicates := findCrashDuplicates(r.Context(), h.crashDuplicates, createdPost, h.logger)
+	writePostsJSON(w, http.StatusCreated, createdPostResponse(createdPost, duplicates))
 }
 
 // Update handles PATCH /v1/posts/:id - update a post.
@@ -626,21 +683,22 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writePostsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
 	// Get existing post
-	existingPost, err := h.repo.FindByIDForViewer(r.Context(), postID, "", "", callerHumanID(r)) // BART-151: owner/family can find their own private post
+	// Viewer-scoped so the If-Match check sees the caller's vote, as GET did
+	existingPost, err := h.repo.FindByIDFromPrimary(r.Context(), postID) // BART-151: owner/family can find their own private post
 	if err != nil {
 		if errors.Is(err, db.ErrPostNotFound) {
-			writePostsError(w, http.StatusNotFound, "NOT_FOUND", "post not found")
+			apierror.Write(w, apierror.NotFound, "post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -653,10 +711,22 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check ownership - only owner can update (works for both humans and agents)
+	// Check ownership - only owner or a problem's collaborators can update (works for both humans and agents)
 	isOwner := existingPost.PostedByType == authInfo.AuthorType && existingPost.PostedByID == authInfo.AuthorID
 	if !isOwner {
-		writePostsError(w, http.StatusForbidden, "FORBIDDEN", "you can only update your own posts")
+		isCollaborator, err := h.isCollaborator(r.Context(), existingPost, authInfo)
+		if err != nil {
+			h.writeCollaboratorError(w, r, "CanEdit", err)
+			return
+		}
+		if !isCollaborator {
+			apierror.Write(w, apierror.Forbidden, "you can only update your own posts")
+			return
+		}
+	}
+
+	// If-Match precondition: reject edits based on a stale copy of the post
+	if !checkIfMatch(w, r, postETag(existingPost, etagViewer(r))) {
 		return
 	}
 
@@ -665,7 +735,7 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	case models.PostStatusOpen, models.PostStatusRejected, models.PostStatusPendingReview, models.PostStatusDraft:
 		// Allowed
 	default:
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR",
+		apierror.Write(w, apierror.ValidationError,
 			fmt.Sprintf("Cannot edit post with status %s", existingPost.Status))
 		return
 	}
@@ -673,59 +743,68 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var req UpdatePostRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	// Collaborators edit the content; solving or closing the problem stays with the author
+	if !isOwner && req.Status != nil {
+		apierror.Write(w, apierror.Forbidden, "only the author can change a post's status")
 		return
 	}
 
-	// Apply updates
+	// Optimistic concurrency: the client edited a version that is no longer current
+	if req.ExpectedUpdatedAt != nil && !req.ExpectedUpdatedAt.Equal(existingPost.UpdatedAt) {
+		writePostConflict(w, existingPost, etagViewer(r))
+		return
+	}
+
+	// Apply updates. updatedPost keeps existingPost.UpdatedAt, so the repository only
+	// applies the update if nobody else has modified the post since it was read.
 	updatedPost := existingPost.Post
 
+	var v Validator
+	validatePostLengths(&v, h.contentLimits.For(updatedPost.Type), req.Title, req.Description, nil)
 	if req.Title != nil {
-		if len(*req.Title) < 10 {
-			writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at least 10 characters")
-			return
-		}
-		if len(*req.Title) > 200 {
-			writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at most 200 characters")
-			return
-		}
 		updatedPost.Title = *req.Title
 	}
-
 	if req.Description != nil {
-		if len(*req.Description) < 50 {
-			writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description must be at least 50 characters")
-			return
-		}
 		updatedPost.Description = *req.Description
 	}
-
 	if req.Tags != nil {
-		if len(req.Tags) > models.MaxTagsPerPost {
-			writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("maximum %d tags allowed", models.MaxTagsPerPost))
-			return
-		}
+		v.MaxItems("tags", len(req.Tags), models.MaxTagsPerPost)
+		req.Tags = validateTags(&v, req.Tags)
 		updatedPost.Tags = req.Tags
 	}
+	if req.Status != nil && !models.IsValidPostStatus(models.PostStatus(*req.Status), updatedPost.Type) {
+		v.Add(FieldError{Field: "status", Code: FieldInvalidValue, Message: "invalid status for this post type"})
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
 
+	var criteriaOverride *models.SuccessCriteriaOverride
 	if req.Status != nil {
 		newStatus := models.PostStatus(*req.Status)
-		if !models.IsValidPostStatus(newStatus, updatedPost.Type) {
-			writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid status for this post type")
-			return
-		}
 		if newStatus == models.PostStatusSolved && updatedPost.Type == models.PostTypeProblem && h.approachChecker != nil {
 			has, err := h.approachChecker.HasSucceededApproach(r.Context(), updatedPost.ID)
 			if err != nil {
-				writePostsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to check approaches")
+				apierror.Write(w, apierror.InternalError, "failed to check approaches")
 				return
 			}
 			if !has {
-				writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR",
+				apierror.Write(w, apierror.ValidationError,
 					"cannot mark as solved: no succeeded approach exists. Use the verify endpoint after an approach succeeds.")
 				return
 			}
 		}
+		if newStatus == models.PostStatusSolved && updatedPost.Type == models.PostTypeProblem && existingPost.Status != models.PostStatusSolved {
+			var ok bool
+			if criteriaOverride, ok = checkSolveCriteria(w, r, h.criteriaRepo, updatedPost.ID, req.OverrideReason); !ok {
+				return
+			}
+		}
 		updatedPost.Status = newStatus
 	}
 
@@ -745,21 +824,20 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Regenerate embedding if title or description changed
-	if contentChanged && h.embeddingService != nil {
-		embedCtx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		text := updatedPost.Title + " " + updatedPost.Description
-		embedding, embedErr := h.embeddingService.GenerateEmbedding(embedCtx, text)
-		if embedErr != nil {
-			h.logger.Warn("failed to regenerate embedding for post", "error", embedErr, "postID", postID)
-		} else {
-			vecStr := float32SliceToVectorString(embedding)
-			updatedPost.EmbeddingStr = &vecStr
-		}
+	var embedQueueReason string
+	if contentChanged {
+		embedQueueReason = h.embedPost(r.Context(), updatedPost.Title, updatedPost.Description, &updatedPost.EmbeddingStr)
 	}
 
 	result, err := h.repo.Update(r.Context(), &updatedPost)
+	if errors.Is(err, db.ErrVersionConflict) {
+		current, findErr := h.repo.FindByIDFromPrimary(r.Context(), postID)
+		if findErr == nil {
+			writePostConflict(w, current, etagViewer(r))
+			return
+		}
+		err = findErr
+	}
 	if err != nil {
 		ctx := response.LogContext{
 			Operation: "Update",
@@ -771,11 +849,17 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if embedQueueReason != "" {
+		h.enqueueEmbedding(r.Context(), postID, embedQueueReason)
+	}
+	recordSolveOverride(r.Context(), h.criteriaRepo, criteriaOverride, h.logger)
+
 	// Trigger async re-moderation if content was changed
 	if needsReModeration {
-		go h.moderatePostAsync(postID, updatedPost.Title, updatedPost.Description, updatedPost.Tags, string(updatedPost.Type), string(authInfo.AuthorType), authInfo.AuthorID)
+		go h.moderatePostAsync(tenant.FromContext(r.Context()), postID, updatedPost.Title, updatedPost.Description, updatedPost.Tags, string(updatedPost.Type), string(existingPost.PostedByType), existingPost.PostedByID)
 	}
 
+	h.setUpdatedPostETag(w, r, postID)
 	writePostsJSON(w, http.StatusOK, map[string]interface{}{
 		"data": result,
 	})
@@ -787,21 +871,21 @@ func (h *PostsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writePostsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
 	// Get existing post
-	existingPost, err := h.repo.FindByIDForViewer(r.Context(), postID, "", "", callerHumanID(r)) // BART-151: owner/family can find their own private post
+	existingPost, err := h.repo.FindByIDFromPrimary(r.Context(), postID) // BART-151: owner/family can find their own private post
 	if err != nil {
 		if errors.Is(err, db.ErrPostNotFound) {
-			writePostsError(w, http.StatusNotFound, "NOT_FOUND", "post not found")
+			apierror.Write(w, apierror.NotFound, "post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -819,7 +903,7 @@ func (h *PostsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	isAdmin := authInfo.Role == "admin"
 
 	if !isOwner && !isAdmin {
-		writePostsError(w, http.StatusForbidden, "FORBIDDEN", "you can only delete your own posts")
+		apierror.Write(w, apierror.Forbidden, "you can only delete your own posts")
 		return
 	}
 
@@ -843,34 +927,63 @@ func (h *PostsHandler) Vote(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writePostsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
 	// Parse request body
 	var req VoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	// Validate direction ("none" retracts, same as DELETE /v1/posts/:id/vote)
+	if req.Direction != "up" && req.Direction != "down" && req.Direction != db.VoteDirectionNone {
+		apierror.Write(w, apierror.ValidationError, "direction must be 'up', 'down' or 'none'")
+		return
+	}
+
+	h.recordVote(w, r, authInfo, postID, req.Direction)
+}
+
+// RetractVote handles DELETE /v1/posts/:id/vote - remove the caller's vote on a post.
+// Idempotent: succeeds with the current tallies even if the caller had not voted.
+func (h *PostsHandler) RetractVote(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
-	// Validate direction
-	if req.Direction != "up" && req.Direction != "down" {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "direction must be 'up' or 'down'")
+	postID := chi.URLParam(r, "id")
+	if postID == "" {
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
+	h.recordVote(w, r, authInfo, postID, db.VoteDirectionNone)
+}
+
+// recordVote applies a vote (or retraction, direction "none") and writes the updated tallies.
+func (h *PostsHandler) recordVote(w http.ResponseWriter, r *http.Request, authInfo *AuthInfo, postID, direction string) {
+	// user_vote in the response is null after a retraction
+	var userVote interface{} = direction
+	if direction == db.VoteDirectionNone {
+		userVote = nil
+	}
+
 	// Get post to check it exists
-	post, err := h.repo.FindByIDForViewer(r.Context(), postID, "", "", callerHumanID(r)) // BART-151: family can vote on own private post
+	post, err := h.repo.FindByIDFromPrimary(r.Context(), postID) // BART-151: family can vote on own private post
 	if err != nil {
 		if errors.Is(err, db.ErrPostNotFound) {
-			writePostsError(w, http.StatusNotFound, "NOT_FOUND", "post not found")
+			apierror.Write(w, apierror.NotFound, "post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -883,17 +996,23 @@ func (h *PostsHandler) Vote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Guest posts are quarantined until claimed: no votes
+	if direction != db.VoteDirectionNone && post.PostedByType == models.AuthorTypeGuest {
+		apierror.Write(w, apierror.Forbidden, "guest posts cannot be voted on until they are claimed")
+		return
+	}
+
 	// Cannot vote on own content (applies to both humans and agents)
-	if post.PostedByType == authInfo.AuthorType && post.PostedByID == authInfo.AuthorID {
-		writePostsError(w, http.StatusForbidden, "FORBIDDEN", "cannot vote on your own content")
+	if direction != db.VoteDirectionNone && post.PostedByType == authInfo.AuthorType && post.PostedByID == authInfo.AuthorID {
+		apierror.Write(w, apierror.Forbidden, "cannot vote on your own content")
 		return
 	}
 
 	// Record vote with the appropriate voter type
-	err = h.repo.Vote(r.Context(), postID, string(authInfo.AuthorType), authInfo.AuthorID, req.Direction)
+	err = h.repo.Vote(r.Context(), postID, string(authInfo.AuthorType), authInfo.AuthorID, direction)
 	if err != nil {
 		if errors.Is(err, ErrDuplicateVote) {
-			writePostsError(w, http.StatusConflict, "DUPLICATE_VOTE", "you have already voted on this post")
+			apierror.Write(w, apierror.DuplicateVote, "you have already voted on this post")
 			return
 		}
 		ctx := response.LogContext{
@@ -902,7 +1021,7 @@ func (h *PostsHandler) Vote(w http.ResponseWriter, r *http.Request) {
 			RequestID: r.Header.Get("X-Request-ID"),
 			Extra: map[string]string{
 				"postID":    postID,
-				"direction": req.Direction,
+				"direction": direction,
 				"voterType": string(authInfo.AuthorType),
 				"voterID":   authInfo.AuthorID,
 			},
@@ -912,7 +1031,7 @@ func (h *PostsHandler) Vote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Re-fetch post to get updated vote counts
-	updatedPost, fetchErr := h.repo.FindByIDForViewer(r.Context(), postID, "", "", callerHumanID(r))
+	updatedPost, fetchErr := h.repo.FindByIDFromPrimary(r.Context(), postID)
 	if fetchErr != nil {
 		// Vote was recorded but re-fetch failed — return success with zeroed scores
 		writePostsJSON(w, http.StatusOK, map[string]interface{}{
@@ -920,7 +1039,7 @@ func (h *PostsHandler) Vote(w http.ResponseWriter, r *http.Request) {
 				"vote_score": 0,
 				"upvotes":    0,
 				"downvotes":  0,
-				"user_vote":  req.Direction,
+				"user_vote":  userVote,
 			},
 		})
 		return
@@ -931,7 +1050,7 @@ func (h *PostsHandler) Vote(w http.ResponseWriter, r *http.Request) {
 			"vote_score": updatedPost.VoteScore,
 			"upvotes":    updatedPost.Upvotes,
 			"downvotes":  updatedPost.Downvotes,
-			"user_vote":  req.Direction,
+			"user_vote":  userVote,
 		},
 	})
 }
@@ -941,20 +1060,20 @@ func (h *PostsHandler) GetMyVote(w http.ResponseWriter, r *http.Request) {
 	// Require authentication
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writePostsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writePostsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
 	vote, err := h.repo.GetUserVote(r.Context(), postID, string(authInfo.AuthorType), authInfo.AuthorID)
 	if err != nil {
 		if errors.Is(err, db.ErrPostNotFound) {
-			writePostsError(w, http.StatusNotFound, "NOT_FOUND", "post not found")
+			apierror.Write(w, apierror.NotFound, "post not found")
 			return
 		}
 		ctx := response.LogContext{
@@ -973,5 +1092,3 @@ func (h *PostsHandler) GetMyVote(w http.ResponseWriter, r *http.Request) {
 		},
 	})
 }
-
-
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// PostCollaboratorRepositoryInterface stores who besides the author may edit a problem.
+type PostCollaboratorRepositoryInterface interface {
+	Grant(ctx context.Context, postID string, collaboratorType models.AuthorType, collaboratorID string, family bool) (*models.PostCollaborator, error)
+	Revoke(ctx context.Context, postID string, collaboratorType models.AuthorType, collaboratorID string) error
+	List(ctx context.Context, postID string) ([]models.PostCollaborator, error)
+	CanEdit(ctx context.Context, postID string, principalType models.AuthorType, principalID string) (bool, error)
+}
+
+// SetCollaboratorRepository enables problem collaborators: PATCH /v1/posts/{id}
+// then also accepts edits from the post's collaborators.
+func (h *PostsHandler) SetCollaboratorRepository(repo PostCollaboratorRepositoryInterface) {
+	h.collaborators = repo
+}
+
+// AddCollaboratorRequest is the optional request body for
+// POST /v1/posts/{id}/collaborators/{principal}.
+type AddCollaboratorRequest struct {
+	// Family extends a human collaborator's grant to the agents they have claimed.
+	Family bool `json:"family,omitempty"`
+}
+
+// ListCollaborators handles GET /v1/posts/{id}/collaborators.
+func (h *PostsHandler) ListCollaborators(w http.ResponseWriter, r *http.Request) {
+	if h.collaborators == nil {
+		apierror.Write(w, apierror.NotConfigured, "post collaborators are not configured")
+		return
+	}
+	post, ok := h.findPostForFreshness(w, r)
+	if !ok {
+		return
+	}
+
+	collaborators, err := h.collaborators.List(r.Context(), post.ID)
+	if err != nil {
+		h.writeCollaboratorError(w, r, "ListCollaborators", err)
+		return
+	}
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{"data": collaborators})
+}
+
+// AddCollaborator handles POST /v1/posts/{id}/collaborators/{principal}.
+// The problem's author lets a human or agent edit the post; adding an existing
+// collaborator again updates their family flag.
+func (h *PostsHandler) AddCollaborator(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if h.collaborators == nil {
+		apierror.Write(w, apierror.NotConfigured, "post collaborators are not configured")
+		return
+	}
+	collaboratorType, collaboratorID, ok := principalFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req AddCollaboratorRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+			return
+		}
+	}
+	if req.Family && collaboratorType != models.AuthorTypeHuman {
+		apierror.Write(w, apierror.ValidationError, "family only applies to human collaborators")
+		return
+	}
+
+	post, ok := h.findPostForFreshness(w, r)
+	if !ok {
+		return
+	}
+	if post.PostedByType != authInfo.AuthorType || post.PostedByID != authInfo.AuthorID {
+		apierror.Write(w, apierror.Forbidden, "only the author can add collaborators")
+		return
+	}
+	if post.Type != models.PostTypeProblem {
+		apierror.Write(w, apierror.ValidationError, "only problems can have collaborators")
+		return
+	}
+	if collaboratorType == post.PostedByType && collaboratorID == post.PostedByID {
+		apierror.Write(w, apierror.ValidationError, "the author can already edit the post")
+		return
+	}
+
+	existing, err := h.collaborators.List(r.Context(), post.ID)
+	if err != nil {
+		h.writeCollaboratorError(w, r, "ListCollaborators", err)
+		return
+	}
+	if len(existing) >= models.MaxPostCollaborators && !hasCollaborator(existing, collaboratorType, collaboratorID) {
+		apierror.Write(w, apierror.ValidationError, "a problem can have at most 20 collaborators")
+		return
+	}
+
+	collaborator, err := h.collaborators.Grant(r.Context(), post.ID, collaboratorType, collaboratorID, req.Family)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, string(collaboratorType)+" not found")
+			return
+		}
+		h.writeCollaboratorError(w, r, "GrantCollaborator", err)
+		return
+	}
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{"data": collaborator})
+}
+
+// RemoveCollaborator handles DELETE /v1/posts/{id}/collaborators/{principal}.
+// The author can remove anyone; a collaborator can remove themselves.
+func (h *PostsHandler) RemoveCollaborator(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if h.collaborators == nil {
+		apierror.Write(w, apierror.NotConfigured, "post collaborators are not configured")
+		return
+	}
+	collaboratorType, collaboratorID, ok := principalFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	post, ok := h.findPostForFreshness(w, r)
+	if !ok {
+		return
+	}
+	isAuthor := post.PostedByType == authInfo.AuthorType && post.PostedByID == authInfo.AuthorID
+	isSelf := collaboratorType == authInfo.AuthorType && collaboratorID == authInfo.AuthorID
+	if !isAuthor && !isSelf {
+		apierror.Write(w, apierror.Forbidden, "only the author can remove collaborators")
+		return
+	}
+
+	if err := h.collaborators.Revoke(r.Context(), post.ID, collaboratorType, collaboratorID); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, "not a collaborator")
+			return
+		}
+		h.writeCollaboratorError(w, r, "RevokeCollaborator", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isCollaborator reports whether the caller may edit someone else's post as a
+// collaborator. Always false when collaborators aren't configured.
+func (h *PostsHandler) isCollaborator(ctx context.Context, post *models.PostWithAuthor, authInfo *AuthInfo) (bool, error) {
+	if h.collaborators == nil || post.Type != models.PostTypeProblem {
+		return false, nil
+	}
+	return h.collaborators.CanEdit(ctx, post.ID, authInfo.AuthorType, authInfo.AuthorID)
+}
+
+func hasCollaborator(collaborators []models.PostCollaborator, collaboratorType models.AuthorType, collaboratorID string) bool {
+	for _, c := range collaborators {
+		if c.CollaboratorType == collaboratorType && c.CollaboratorID == collaboratorID {
+			return true
+		}
+	}
+	return false
+}
+
+// writeCollaboratorError writes a logged 500 for a failed collaborator operation.
+func (h *PostsHandler) writeCollaboratorError(w http.ResponseWriter, r *http.Request, op string, err error) {
+	response.WriteInternalErrorWithLog(w, "collaborator operation failed", err, response.LogContext{
+		Operation: op,
+		Resource:  "post_collaborators",
+		RequestID: r.Header.Get("X-Request-ID"),
+		Extra:     map[string]string{"postID": chi.URLParam(r, "id")},
+	}, h.logger)
+}
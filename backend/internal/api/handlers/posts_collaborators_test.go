@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+const collaboratorUserID = "5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d"
+
+// mockPostCollaboratorRepository implements PostCollaboratorRepositoryInterface for testing.
+type mockPostCollaboratorRepository struct {
+	grants map[string]bool // "type:id" -> family
+}
+
+func (m *mockPostCollaboratorRepository) Grant(ctx context.Context, postID string, collaboratorType models.AuthorType, collaboratorID string, family bool) (*models.PostCollaborator, error) {
+	if collaboratorID == "ghost" {
+		return nil, db.ErrNotFound
+	}
+	m.grants[string(collaboratorType)+":"+collaboratorID] = family
+	return &models.PostCollaborator{CollaboratorType: collaboratorType, CollaboratorID: collaboratorID, Family: family}, nil
+}
+
+func (m *mockPostCollaboratorRepository) Revoke(ctx context.Context, postID string, collaboratorType models.AuthorType, collaboratorID string) error {
+	key := string(collaboratorType) + ":" + collaboratorID
+	if _, ok := m.grants[key]; !ok {
+		return db.ErrNotFound
+	}
+	delete(m.grants, key)
+	return nil
+}
+
+func (m *mockPostCollaboratorRepository) List(ctx context.Context, postID string) ([]models.PostCollaborator, error) {
+	collaborators := []models.PostCollaborator{}
+	for key, family := range m.grants {
+		kind, id, _ := strings.Cut(key, ":")
+		collaborators = append(collaborators, models.PostCollaborator{CollaboratorType: models.AuthorType(kind), CollaboratorID: id, Family: family})
+	}
+	return collaborators, nil
+}
+
+func (m *mockPostCollaboratorRepository) CanEdit(ctx context.Context, postID string, principalType models.AuthorType, principalID string) (bool, error) {
+	_, ok := m.grants[string(principalType)+":"+principalID]
+	return ok, nil
+}
+
+func newCollaboratorTestHandler(postType models.PostType) (*PostsHandler, *MockPostsRepository, *mockPostCollaboratorRepository) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Flaky pgx pool timeouts under load", postType)
+	repo.SetPost(&post)
+	collaborators := &mockPostCollaboratorRepository{grants: map[string]bool{}}
+	handler := NewPostsHandler(repo)
+	handler.SetCollaboratorRepository(collaborators)
+	return handler, repo, collaborators
+}
+
+func collaboratorRequest(method, principal, body, callerID string) *http.Request {
+	req := httptest.NewRequest(method, "/v1/posts/post-123/collaborators/"+principal, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	rctx.URLParams.Add("principal", principal)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	return addAuthContext(req, callerID, "user")
+}
+
+func TestAddCollaborator(t *testing.T) {
+	tests := []struct {
+		name      string
+		postType  models.PostType
+		principal string
+		body      string
+		callerID  string
+		status    int
+	}{
+		{"author adds human with family", models.PostTypeProblem, "human:" + collaboratorUserID, `{"family":true}`, "user-123", http.StatusOK},
+		{"author adds agent", models.PostTypeProblem, "agent:triage_bot", "", "user-123", http.StatusOK},
+		{"family on an agent", models.PostTypeProblem, "agent:triage_bot", `{"family":true}`, "user-123", http.StatusBadRequest},
+		{"not the author", models.PostTypeProblem, "agent:triage_bot", "", collaboratorUserID, http.StatusForbidden},
+		{"not a problem", models.PostTypeQuestion, "agent:triage_bot", "", "user-123", http.StatusBadRequest},
+		{"unknown principal", models.PostTypeProblem, "agent:ghost", "", "user-123", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _, collaborators := newCollaboratorTestHandler(tt.postType)
+			w := httptest.NewRecorder()
+			handler.AddCollaborator(w, collaboratorRequest(http.MethodPost, tt.principal, tt.body, tt.callerID))
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+			if granted := len(collaborators.grants) == 1; granted != (tt.status == http.StatusOK) {
+				t.Errorf("grants = %v after status %d", collaborators.grants, w.Code)
+			}
+		})
+	}
+}
+
+func TestUpdatePost_Collaborator(t *testing.T) {
+	handler, repo, collaborators := newCollaboratorTestHandler(models.PostTypeProblem)
+	collaborators.grants["human:"+collaboratorUserID] = false
+
+	w := httptest.NewRecorder()
+	req := collaboratorRequest(http.MethodPatch, "", `{"description":"Pool exhaustion reproduced with 200 concurrent requests and a 5s acquire timeout."}`, collaboratorUserID)
+	handler.Update(w, req)
+	if w.Code != http.StatusOK || repo.updatedPost == nil {
+		t.Fatalf("collaborator edit status = %d: %s", w.Code, w.Body.String())
+	}
+
+	// Status changes stay with the author
+	w = httptest.NewRecorder()
+	handler.Update(w, collaboratorRequest(http.MethodPatch, "", `{"status":"closed"}`, collaboratorUserID))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("collaborator status change = %d, want 403", w.Code)
+	}
+
+	// Removing themselves ends their access
+	w = httptest.NewRecorder()
+	handler.RemoveCollaborator(w, collaboratorRequest(http.MethodDelete, "human:"+collaboratorUserID, "", collaboratorUserID))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("leave status = %d, want 204: %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	handler.Update(w, collaboratorRequest(http.MethodPatch, "", `{"title":"Edited after leaving the post"}`, collaboratorUserID))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("edit after leaving = %d, want 403", w.Code)
+	}
+}
@@ -113,6 +113,57 @@ func TestCreatePost_InvalidType(t *testing.T) {
 	}
 }
 
+// TestCreatePost_ReportsAllFieldErrors tests that every invalid field is listed in error.details.fields.
+func TestCreatePost_ReportsAllFieldErrors(t *testing.T) {
+	repo := NewMockPostsRepository()
+	handler := NewPostsHandler(repo)
+
+	body := map[string]interface{}{
+		"type":        "problem",
+		"title":       "Short",
+		"description": "Too short",
+		"weight":      9,
+	}
+	jsonBody, _ := json.Marshal(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", bytes.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	var resp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Details struct {
+				Fields []FieldError `json:"fields"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error.Code != "VALIDATION_ERROR" {
+		t.Errorf("expected error code VALIDATION_ERROR, got %s", resp.Error.Code)
+	}
+
+	got := map[string]string{}
+	for _, f := range resp.Error.Details.Fields {
+		got[f.Field] = f.Code
+	}
+	want := map[string]string{"title": FieldTooShort, "description": FieldTooShort, "weight": FieldOutOfRange}
+	for field, code := range want {
+		if got[field] != code {
+			t.Errorf("field %s: got code %q, want %q", field, got[field], code)
+		}
+	}
+}
+
 // TestCreatePost_TitleTooShort tests 400 for title < 10 chars.
 func TestCreatePost_TitleTooShort(t *testing.T) {
 	repo := NewMockPostsRepository()
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// embedPost generates the post embedding inline and stores it in dst.
+// Returns the embedding queue reason when the embedding failed, or "" on
+// success or when embeddings are disabled (there is nothing to retry).
+func (h *PostsHandler) embedPost(ctx context.Context, title, description string, dst **string) string {
+	if h.embeddingService == nil {
+		return ""
+	}
+
+	embedCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	embedding, err := h.embeddingService.GenerateEmbedding(embedCtx, title+" "+description)
+	if err != nil {
+		h.logger.Warn("failed to generate embedding for post, queuing for retry", "error", err)
+		return models.EmbeddingQueueReasonFailed
+	}
+
+	vecStr := float32SliceToVectorString(embedding)
+	*dst = &vecStr
+	return ""
+}
+
+// enqueueEmbedding queues a post for background embedding. Failures are logged only:
+// the write itself already succeeded and the post remains keyword-searchable.
+func (h *PostsHandler) enqueueEmbedding(ctx context.Context, postID, reason string) {
+	if h.embeddingQueue == nil {
+		return
+	}
+	if err := h.embeddingQueue.Enqueue(ctx, postID, reason); err != nil {
+		h.logger.Warn("failed to queue post embedding", "error", err, "postID", postID, "reason", reason)
+	}
+}
@@ -232,3 +232,117 @@ func TestUpdatePost_StatusOnlyNoEmbeddingRegeneration(t *testing.T) {
 		t.Errorf("expected no embedding regeneration for status-only change, called %d times", mockEmbed.callCount)
 	}
 }
+
+// ============================================================================
+// Embedding queue (graceful degradation) Tests
+// ============================================================================
+
+// mockEmbeddingQueue records queued posts.
+type mockEmbeddingQueue struct {
+	queued map[string]string // postID -> reason
+}
+
+func (m *mockEmbeddingQueue) Enqueue(ctx context.Context, postID, reason string) error {
+	if m.queued == nil {
+		m.queued = make(map[string]string)
+	}
+	m.queued[postID] = reason
+	return nil
+}
+
+// TestCreatePost_EmbeddingFailureQueuesPost tests that a failed embedding is queued for retry.
+func TestCreatePost_EmbeddingFailureQueuesPost(t *testing.T) {
+	repo := NewMockPostsRepository()
+	queue := &mockEmbeddingQueue{}
+	handler := NewPostsHandler(repo)
+	handler.SetEmbeddingService(&MockEmbeddingService{err: fmt.Errorf("voyage 503")})
+	handler.SetEmbeddingQueue(queue)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(validPostBody()))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(queue.queued) != 1 {
+		t.Fatalf("expected 1 queued post, got %d", len(queue.queued))
+	}
+	for _, reason := range queue.queued {
+		if reason != models.EmbeddingQueueReasonFailed {
+			t.Errorf("expected reason %q, got %q", models.EmbeddingQueueReasonFailed, reason)
+		}
+	}
+}
+
+// TestCreatePost_NoEmbeddingServiceNotQueued tests that posts are not queued when
+// embeddings are disabled: nothing drains the queue without an embedding service.
+func TestCreatePost_NoEmbeddingServiceNotQueued(t *testing.T) {
+	repo := NewMockPostsRepository()
+	queue := &mockEmbeddingQueue{}
+	handler := NewPostsHandler(repo)
+	handler.SetEmbeddingQueue(queue)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(validPostBody()))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(queue.queued) != 0 {
+		t.Errorf("expected nothing queued when embedding service is nil, got %v", queue.queued)
+	}
+}
+
+// TestCreatePost_EmbeddingSuccessNotQueued tests that successfully embedded posts are not queued.
+func TestCreatePost_EmbeddingSuccessNotQueued(t *testing.T) {
+	repo := NewMockPostsRepository()
+	queue := &mockEmbeddingQueue{}
+	handler := NewPostsHandler(repo)
+	handler.SetEmbeddingService(&MockEmbeddingService{embedding: []float32{0.1}})
+	handler.SetEmbeddingQueue(queue)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(validPostBody()))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	if len(queue.queued) != 0 {
+		t.Errorf("expected nothing queued, got %v", queue.queued)
+	}
+}
+
+// TestUpdatePost_EmbeddingFailureQueuesPost tests that a failed re-embedding on edit is queued.
+func TestUpdatePost_EmbeddingFailureQueuesPost(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Original Title for Testing", models.PostTypeQuestion)
+	repo.SetPost(&post)
+
+	queue := &mockEmbeddingQueue{}
+	handler := NewPostsHandler(repo)
+	handler.SetEmbeddingService(&MockEmbeddingService{err: fmt.Errorf("timeout")})
+	handler.SetEmbeddingQueue(queue)
+
+	body := `{"title":"Updated Title for Semantic Search"}`
+	req := httptest.NewRequest(http.MethodPatch, "/v1/posts/post-123", strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.Update(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if queue.queued["post-123"] != models.EmbeddingQueueReasonFailed {
+		t.Errorf("expected post-123 queued after failed re-embedding, got %v", queue.queued)
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+
+	apimiddleware "github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// postETag returns the strong ETag of a post as viewer sees it: a hash of every
+// field the post serializes, so writes that leave updated_at alone (accepting an
+// answer, retagging, bounties, translations, trigger-maintained counts) still
+// change it. The viewer is hashed in too, so one viewer's cached copy never
+// validates for another. The view count is left out: it moves on every view and
+// would defeat both 304s and If-Match.
+func postETag(p *models.PostWithAuthor, viewer string) string {
+	snapshot := *p
+	snapshot.ViewCount = 0
+	body, _ := json.Marshal(&snapshot)
+	h := fnv.New64a()
+	_, _ = h.Write(body)
+	fmt.Fprintf(h, "|%s", viewer)
+	return fmt.Sprintf(`"%x"`, h.Sum64())
+}
+
+// setUpdatedPostETag sets the ETag of post postID after a write, from a fresh
+// primary read, so it matches what the next GET returns. Without one the client
+// refetches before its next conditional write.
+func (h *PostsHandler) setUpdatedPostETag(w http.ResponseWriter, r *http.Request, postID string) {
+	current, err := h.repo.FindByIDFromPrimary(r.Context(), postID)
+	if err != nil {
+		h.logger.Warn("failed to reload post for its ETag", "postID", postID, "error", err)
+		return
+	}
+	w.Header().Set("ETag", postETag(current, etagViewer(r)))
+}
+
+// etagViewer identifies the caller for postETag; "" for anonymous requests.
+func etagViewer(r *http.Request) string {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		return ""
+	}
+	return string(authInfo.AuthorType) + ":" + authInfo.AuthorID
+}
+
+// checkIfMatch enforces an If-Match precondition against the current ETag.
+// Requests without If-Match always pass. On mismatch it writes 412 with the
+// current ETag so the client can refetch and retry, and returns false.
+// Weak comparison is used: the tag names the post's version rather than the
+// response bytes, and Compress weakens it on gzip-encoded responses.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, currentETag string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || apimiddleware.ETagMatches(ifMatch, currentETag, false) {
+		return true
+	}
+	w.Header().Set("ETag", currentETag)
+	apierror.Write(w, apierror.PreconditionFailed,
+		"post was modified since it was fetched; refetch and retry with the new ETag")
+	return false
+}
+
+// writePostConflict writes 409 Conflict for an update based on a stale version,
+// echoing the current post and its ETag so the client can merge and retry.
+func writePostConflict(w http.ResponseWriter, current *models.PostWithAuthor, viewer string) {
+	w.Header().Set("ETag", postETag(current, viewer))
+	writePostsJSON(w, http.StatusConflict, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":               apierror.Conflict,
+			"message":            "post was modified by another request; reapply your changes to the current version",
+			"current_updated_at": current.UpdatedAt,
+		},
+		"data": current,
+	})
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func newPostPatchRequest(body, ifMatch string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/v1/posts/post-123", strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	return addAuthContext(req, "user-123", "user")
+}
+
+// TestGetPost_SetsETag tests that GET /v1/posts/:id returns the post's ETag.
+func TestGetPost_SetsETag(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
+	repo.SetPost(&post)
+
+	handler := NewPostsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-123", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.Get(w, req)
+
+	if got, want := w.Header().Get("ETag"), postETag(&post, ""); got != want {
+		t.Errorf("expected ETag %s, got %s", want, got)
+	}
+}
+
+// TestPostETag_ChangesWithRepresentation tests that the ETag tracks every field
+// of the post, including writes that leave updated_at alone, and the viewer, but
+// not the view count.
+func TestPostETag_ChangesWithRepresentation(t *testing.T) {
+	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
+	base := postETag(&post, "human:user-1")
+
+	up, answerID := "up", "answer-1"
+	changes := map[string]func(p *models.PostWithAuthor){
+		"votes":      func(p *models.PostWithAuthor) { p.Upvotes++ },
+		"updated_at": func(p *models.PostWithAuthor) { p.UpdatedAt = p.UpdatedAt.Add(1) },
+		"answers":    func(p *models.PostWithAuthor) { p.AnswersCount++ },
+		"approaches": func(p *models.PostWithAuthor) { p.ApproachesCount++ },
+		"comments":   func(p *models.PostWithAuthor) { p.CommentsCount++ },
+		"user_vote":  func(p *models.PostWithAuthor) { p.UserVote = &up },
+		"status":     func(p *models.PostWithAuthor) { p.Status = models.PostStatusAnswered },
+		"accepted":   func(p *models.PostWithAuthor) { p.AcceptedAnswerID = &answerID },
+		"tags":       func(p *models.PostWithAuthor) { p.Tags = []string{"retagged"} },
+		"translated": func(p *models.PostWithAuthor) { p.OriginalLanguage = "pt" },
+	}
+	for name, change := range changes {
+		changed := post
+		change(&changed)
+		if postETag(&changed, "human:user-1") == base {
+			t.Errorf("expected ETag to change when %s changes", name)
+		}
+	}
+	if postETag(&post, "human:user-2") == base {
+		t.Error("expected ETag to differ between viewers")
+	}
+	viewed := post
+	viewed.ViewCount++
+	if postETag(&viewed, "human:user-1") != base {
+		t.Error("expected ETag to ignore the view count")
+	}
+}
+
+// TestUpdatePost_IfMatchStale tests that a stale If-Match is rejected with 412.
+func TestUpdatePost_IfMatchStale(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Original Title for Testing", models.PostTypeQuestion)
+	repo.SetPost(&post)
+
+	handler := NewPostsHandler(repo)
+	w := httptest.NewRecorder()
+
+	handler.Update(w, newPostPatchRequest(`{"title":"Concurrent Title Edit Here"}`, `"stale"`))
+
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected status 412, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.updatedPost != nil {
+		t.Error("expected post not to be updated on precondition failure")
+	}
+	if w.Header().Get("ETag") != postETag(&post, "human:user-123") {
+		t.Errorf("expected current ETag in 412 response, got %s", w.Header().Get("ETag"))
+	}
+}
+
+// TestUpdatePost_IfMatchCurrent tests that a matching If-Match is accepted and a new ETag returned.
+func TestUpdatePost_IfMatchCurrent(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Original Title for Testing", models.PostTypeQuestion)
+	repo.SetPost(&post)
+	current := postETag(&post, "human:user-123")
+
+	handler := NewPostsHandler(repo)
+	w := httptest.NewRecorder()
+
+	handler.Update(w, newPostPatchRequest(`{"title":"Fresh Title Edit for Testing"}`, current))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if etag := w.Header().Get("ETag"); etag == "" || etag == current {
+		t.Errorf("expected a new ETag after update, got %q", etag)
+	}
+}
+
+// TestUpdatePost_IfMatchWeakFromGzip tests that the weakened tag a gzip-encoded
+// GET returns still satisfies If-Match.
+func TestUpdatePost_IfMatchWeakFromGzip(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Original Title for Testing", models.PostTypeQuestion)
+	repo.SetPost(&post)
+
+	handler := NewPostsHandler(repo)
+	w := httptest.NewRecorder()
+
+	handler.Update(w, newPostPatchRequest(`{"title":"Fresh Title Edit for Testing"}`, "W/"+postETag(&post, "human:user-123")))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// conflictingPostsRepository simulates another writer updating the post between
+// the handler's read and its conditional write.
+type conflictingPostsRepository struct {
+	*MockPostsRepository
+}
+
+func (m *conflictingPostsRepository) Update(ctx context.Context, post *models.Post) (*models.Post, error) {
+	return nil, db.ErrVersionConflict
+}
+
+// TestUpdatePost_ExpectedUpdatedAtStale tests that a stale expected_updated_at returns 409 with the current post.
+func TestUpdatePost_ExpectedUpdatedAtStale(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Original Title for Testing", models.PostTypeQuestion)
+	repo.SetPost(&post)
+
+	handler := NewPostsHandler(repo)
+	stale := post.UpdatedAt.Add(-time.Minute).Format(time.RFC3339Nano)
+	w := httptest.NewRecorder()
+
+	handler.Update(w, newPostPatchRequest(`{"title":"Concurrent Title Edit Here","expected_updated_at":"`+stale+`"}`, ""))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.updatedPost != nil {
+		t.Error("expected post not to be updated on conflict")
+	}
+
+	var resp struct {
+		Error map[string]interface{} `json:"error"`
+		Data  models.PostWithAuthor  `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error["code"] != "CONFLICT" {
+		t.Errorf("expected CONFLICT code, got %v", resp.Error["code"])
+	}
+	if resp.Data.ID != "post-123" || resp.Data.Title != "Original Title for Testing" {
+		t.Errorf("expected current post in response, got %+v", resp.Data.Post)
+	}
+}
+
+// TestUpdatePost_ExpectedUpdatedAtCurrent tests that a matching expected_updated_at is accepted.
+func TestUpdatePost_ExpectedUpdatedAtCurrent(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Original Title for Testing", models.PostTypeQuestion)
+	repo.SetPost(&post)
+
+	handler := NewPostsHandler(repo)
+	current := post.UpdatedAt.Format(time.RFC3339Nano)
+	w := httptest.NewRecorder()
+
+	handler.Update(w, newPostPatchRequest(`{"title":"Fresh Title Edit for Testing","expected_updated_at":"`+current+`"}`, ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.updatedPost == nil || repo.updatedPost.Title != "Fresh Title Edit for Testing" {
+		t.Error("expected post to be updated")
+	}
+}
+
+// TestUpdatePost_RepositoryConflict tests that a lost race in the repository returns 409.
+func TestUpdatePost_RepositoryConflict(t *testing.T) {
+	base := NewMockPostsRepository()
+	post := createTestPost("post-123", "Original Title for Testing", models.PostTypeQuestion)
+	base.SetPost(&post)
+
+	handler := NewPostsHandler(&conflictingPostsRepository{MockPostsRepository: base})
+	w := httptest.NewRecorder()
+
+	handler.Update(w, newPostPatchRequest(`{"title":"Racing Title Edit for Testing"}`, ""))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("ETag") != postETag(&post, "human:user-123") {
+		t.Errorf("expected current ETag on conflict, got %s", w.Header().Get("ETag"))
+	}
+}
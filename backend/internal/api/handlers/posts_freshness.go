@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// PostFreshnessRepositoryInterface stores post freshness scores and outdated flags.
+type PostFreshnessRepositoryInterface interface {
+	GetFreshness(ctx context.Context, postID string) (*models.PostFreshness, error)
+	FlagOutdated(ctx context.Context, flag *models.OutdatedFlag) (*models.PostFreshness, error)
+	UnflagOutdated(ctx context.Context, postID, flaggerType, flaggerID string) (*models.PostFreshness, error)
+}
+
+// SetFreshnessRepository enables post freshness and outdated flags.
+func (h *PostsHandler) SetFreshnessRepository(repo PostFreshnessRepositoryInterface) {
+	h.freshness = repo
+}
+
+// FlagOutdatedRequest is the optional request body for POST /v1/posts/{id}/outdated.
+type FlagOutdatedRequest struct {
+	Reason string `json:"reason,omitempty"` // e.g. "fixed upstream in pgx v5.6"
+}
+
+// GetFreshness handles GET /v1/posts/{id}/freshness.
+// Returns the post's freshness score, outdated state, flag count and the library
+// versions pinned in its code blocks.
+func (h *PostsHandler) GetFreshness(w http.ResponseWriter, r *http.Request) {
+	if h.freshness == nil {
+		apierror.Write(w, apierror.NotConfigured, "freshness scoring is not configured")
+		return
+	}
+	post, ok := h.findPostForFreshness(w, r)
+	if !ok {
+		return
+	}
+
+	f, err := h.freshness.GetFreshness(r.Context(), post.ID)
+	if err != nil {
+		h.writeFreshnessError(w, r, "GetFreshness", err)
+		return
+	}
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{"data": f})
+}
+
+// FlagOutdated handles POST /v1/posts/{id}/outdated.
+// Records the caller's report that the post is outdated and rescores it; a post
+// with OutdatedFlagThreshold flags is outdated. Each caller flags a post once.
+func (h *PostsHandler) FlagOutdated(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if h.freshness == nil {
+		apierror.Write(w, apierror.NotConfigured, "freshness scoring is not configured")
+		return
+	}
+
+	var req FlagOutdatedRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+			return
+		}
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	var v Validator
+	v.Length("reason", req.Reason, 0, models.MaxOutdatedFlagReasonLength)
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	post, ok := h.findPostForFreshness(w, r)
+	if !ok {
+		return
+	}
+	if post.Status == models.PostStatusDraft {
+		apierror.Write(w, apierror.InvalidStatus, "draft posts cannot be flagged outdated")
+		return
+	}
+
+	f, err := h.freshness.FlagOutdated(r.Context(), &models.OutdatedFlag{
+		PostID:      post.ID,
+		FlaggerType: string(authInfo.AuthorType),
+		FlaggerID:   authInfo.AuthorID,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrOutdatedFlagExists) {
+			apierror.Write(w, apierror.DuplicateFlag, "you have already flagged this post outdated")
+			return
+		}
+		h.writeFreshnessError(w, r, "FlagOutdated", err)
+		return
+	}
+	writePostsJSON(w, http.StatusCreated, map[string]interface{}{"data": f})
+}
+
+// UnflagOutdated handles DELETE /v1/posts/{id}/outdated.
+// Withdraws the caller's outdated flag and rescores the post.
+func (h *PostsHandler) UnflagOutdated(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if h.freshness == nil {
+		apierror.Write(w, apierror.NotConfigured, "freshness scoring is not configured")
+		return
+	}
+
+	f, err := h.freshness.UnflagOutdated(r.Context(), chi.URLParam(r, "id"), string(authInfo.AuthorType), authInfo.AuthorID)
+	if err != nil {
+		if errors.Is(err, db.ErrOutdatedFlagNotFound) {
+			apierror.Write(w, apierror.NotFound, "you have not flagged this post outdated")
+			return
+		}
+		h.writeFreshnessError(w, r, "UnflagOutdated", err)
+		return
+	}
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{"data": f})
+}
+
+// findPostForFreshness loads the {id} post as the caller sees it.
+func (h *PostsHandler) findPostForFreshness(w http.ResponseWriter, r *http.Request) (*models.PostWithAuthor, bool) {
+	postID := chi.URLParam(r, "id")
+	post, err := h.repo.FindByIDForViewer(r.Context(), postID)
+	if err != nil || post.DeletedAt != nil {
+		if err == nil || errors.Is(err, db.ErrPostNotFound) {
+			apierror.Write(w, apierror.NotFound, "post not found")
+			return nil, false
+		}
+		h.writeFreshnessError(w, r, "FindByID", err)
+		return nil, false
+	}
+	return post, true
+}
+
+// writeFreshnessError maps a post not found to 404 and anything else to a logged 500.
+func (h *PostsHandler) writeFreshnessError(w http.ResponseWriter, r *http.Request, op string, err error) {
+	if errors.Is(err, db.ErrPostNotFound) {
+		apierror.Write(w, apierror.NotFound, "post not found")
+		return
+	}
+	response.WriteInternalErrorWithLog(w, "freshness operation failed", err, response.LogContext{
+		Operation: op,
+		Resource:  "posts",
+		RequestID: r.Header.Get("X-Request-ID"),
+	}, h.logger)
+}
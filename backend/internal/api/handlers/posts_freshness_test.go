@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockPostFreshnessRepository implements PostFreshnessRepositoryInterface for testing.
+type mockPostFreshnessRepository struct {
+	flags map[string]models.OutdatedFlag // keyed by postID/flaggerType/flaggerID
+}
+
+func newMockPostFreshnessRepository() *mockPostFreshnessRepository {
+	return &mockPostFreshnessRepository{flags: map[string]models.OutdatedFlag{}}
+}
+
+func (m *mockPostFreshnessRepository) freshness(postID string) *models.PostFreshness {
+	count := 0
+	for _, f := range m.flags {
+		if f.PostID == postID {
+			count++
+		}
+	}
+	score := 100 - count*models.FreshnessFlagPenalty
+	return &models.PostFreshness{
+		PostID:          postID,
+		Score:           &score,
+		Outdated:        models.IsOutdated(score, count),
+		OutdatedFlags:   count,
+		LibraryVersions: []string{},
+	}
+}
+
+func (m *mockPostFreshnessRepository) GetFreshness(ctx context.Context, postID string) (*models.PostFreshness, error) {
+	return m.freshness(postID), nil
+}
+
+func (m *mockPostFreshnessRepository) FlagOutdated(ctx context.Context, flag *models.OutdatedFlag) (*models.PostFreshness, error) {
+	key := flag.PostID + "/" + flag.FlaggerType + "/" + flag.FlaggerID
+	if _, ok := m.flags[key]; ok {
+		return nil, db.ErrOutdatedFlagExists
+	}
+	m.flags[key] = *flag
+	return m.freshness(flag.PostID), nil
+}
+
+func (m *mockPostFreshnessRepository) UnflagOutdated(ctx context.Context, postID, flaggerType, flaggerID string) (*models.PostFreshness, error) {
+	key := postID + "/" + flaggerType + "/" + flaggerID
+	if _, ok := m.flags[key]; !ok {
+		return nil, db.ErrOutdatedFlagNotFound
+	}
+	delete(m.flags, key)
+	return m.freshness(postID), nil
+}
+
+func outdatedRequest(method, postID, body string) *http.Request {
+	req := httptest.NewRequest(method, "/v1/posts/"+postID+"/outdated", strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", postID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func newFreshnessTestHandler(status models.PostStatus) (*PostsHandler, *mockPostFreshnessRepository) {
+	repo := NewMockPostsRepository()
+	if status != "" {
+		post := createTestPost("post-123", "Pinned pgx v4 pool settings", models.PostTypeProblem)
+		post.Status = status
+		repo.SetPost(&post)
+	}
+	freshness := newMockPostFreshnessRepository()
+	handler := NewPostsHandler(repo)
+	handler.SetFreshnessRepository(freshness)
+	return handler, freshness
+}
+
+func TestFlagOutdated_FlagAndWithdraw(t *testing.T) {
+	handler, freshness := newFreshnessTestHandler(models.PostStatusSolved)
+
+	w := httptest.NewRecorder()
+	handler.FlagOutdated(w, addAuthContext(outdatedRequest(http.MethodPost, "post-123", `{"reason":"  pgx v5 renamed the option  "}`), "user-456", "user"))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data models.PostFreshness `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Data.OutdatedFlags != 1 {
+		t.Errorf("expected 1 flag, got %d", resp.Data.OutdatedFlags)
+	}
+	if flag := freshness.flags["post-123/human/user-456"]; flag.Reason != "pgx v5 renamed the option" {
+		t.Errorf("expected trimmed reason, got %q", flag.Reason)
+	}
+
+	w = httptest.NewRecorder()
+	handler.FlagOutdated(w, addAuthContext(outdatedRequest(http.MethodPost, "post-123", ""), "user-456", "user"))
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "DUPLICATE_FLAG") {
+		t.Fatalf("expected 409 DUPLICATE_FLAG on repeat, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	handler.UnflagOutdated(w, addAuthContext(outdatedRequest(http.MethodDelete, "post-123", ""), "user-456", "user"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if len(freshness.flags) != 0 {
+		t.Errorf("expected flag to be removed, got %v", freshness.flags)
+	}
+
+	w = httptest.NewRecorder()
+	handler.UnflagOutdated(w, addAuthContext(outdatedRequest(http.MethodDelete, "post-123", ""), "user-456", "user"))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 when no flag is set, got %d", w.Code)
+	}
+}
+
+func TestFlagOutdated_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   models.PostStatus // "" for no post
+		body     string
+		wantCode int
+	}{
+		{"reason too long", models.PostStatusSolved, `{"reason":"` + strings.Repeat("a", models.MaxOutdatedFlagReasonLength+1) + `"}`, http.StatusBadRequest},
+		{"invalid json", models.PostStatusSolved, `{`, http.StatusBadRequest},
+		{"draft", models.PostStatusDraft, "", http.StatusConflict},
+		{"unknown post", "", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, freshness := newFreshnessTestHandler(tt.status)
+
+			w := httptest.NewRecorder()
+			handler.FlagOutdated(w, addAuthContext(outdatedRequest(http.MethodPost, "post-123", tt.body), "user-456", "user"))
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if len(freshness.flags) != 0 {
+				t.Errorf("expected no flag to be stored, got %v", freshness.flags)
+			}
+		})
+	}
+}
+
+func TestFlagOutdated_RequiresAuth(t *testing.T) {
+	handler, _ := newFreshnessTestHandler(models.PostStatusSolved)
+
+	w := httptest.NewRecorder()
+	handler.FlagOutdated(w, outdatedRequest(http.MethodPost, "post-123", ""))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestGetFreshness(t *testing.T) {
+	handler, _ := newFreshnessTestHandler(models.PostStatusSolved)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-123/freshness", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.GetFreshness(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"score":100`) {
+		t.Errorf("expected score in response, got %s", w.Body.String())
+	}
+}
+
+func TestGetFreshness_NotConfigured(t *testing.T) {
+	handler := NewPostsHandler(NewMockPostsRepository())
+
+	w := httptest.NewRecorder()
+	handler.GetFreshness(w, httptest.NewRequest(http.MethodGet, "/v1/posts/post-123/freshness", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
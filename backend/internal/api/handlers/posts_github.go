@@ -0,0 +1,410 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxImportedDescriptionRunes caps the description built from an issue and its comments.
+const maxImportedDescriptionRunes = 50000
+
+// GitHubIssueFetcher reads issues from GitHub.
+type GitHubIssueFetcher interface {
+	FetchIssue(ctx context.Context, ref models.GitHubIssueRef) (*models.GitHubIssue, error)
+	FetchComments(ctx context.Context, ref models.GitHubIssueRef) ([]models.GitHubIssueComment, error)
+}
+
+// PostGitHubLinkRepositoryInterface stores links between posts and GitHub issues.
+type PostGitHubLinkRepositoryInterface interface {
+	Create(ctx context.Context, link *models.PostGitHubLink) (*models.PostGitHubLink, error)
+	FindByPostID(ctx context.Context, postID string) (*models.PostGitHubLink, error)
+	FindImportedPostID(ctx context.Context, ref models.GitHubIssueRef) (string, error)
+	Delete(ctx context.Context, postID string) error
+	ApplyIssueState(ctx context.Context, postID, state string) (models.PostStatus, error)
+}
+
+// SetGitHubImport enables GitHub issue import and linking.
+func (h *PostsHandler) SetGitHubImport(fetcher GitHubIssueFetcher, links PostGitHubLinkRepositoryInterface) {
+	h.githubFetcher = fetcher
+	h.githubLinks = links
+}
+
+// ImportGitHubIssueRequest is the request body for POST /v1/posts/import/github.
+type ImportGitHubIssueRequest struct {
+	IssueURL string   `json:"issue_url"`
+	Tags     []string `json:"tags,omitempty"` // Defaults to the issue's labels that are valid tags
+}
+
+// GitHubIssueLinkRequest is the request body for POST /v1/posts/{id}/github-link.
+type GitHubIssueLinkRequest struct {
+	IssueURL string `json:"issue_url"`
+}
+
+// ImportGitHubIssue handles POST /v1/posts/import/github.
+// Fetches a public issue and its first 30 comments and creates a problem from them,
+// linked back to the issue. Like POST /v1/posts the problem starts pending_review.
+// Each issue is imported once; a second import returns 409 with the existing post_id.
+func (h *PostsHandler) ImportGitHubIssue(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if h.githubFetcher == nil || h.githubLinks == nil {
+		apierror.Write(w, apierror.GitHubNotConfigured, "github import is not configured")
+		return
+	}
+
+	var req ImportGitHubIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	ref, err := models.ParseGitHubIssueURL(req.IssueURL)
+	if err != nil {
+		apierror.Write(w, apierror.InvalidIssueURL, err.Error())
+		return
+	}
+	var v Validator
+	v.MaxItems("tags", len(req.Tags), models.MaxTagsPerPost)
+	tags := validateTags(&v, req.Tags)
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	if existing, err := h.githubLinks.FindImportedPostID(r.Context(), ref); err != nil {
+		h.writeGitHubInternalError(w, r, "FindImportedPostID", err)
+		return
+	} else if existing != "" {
+		writeGitHubAlreadyImported(w, existing)
+		return
+	}
+
+	issue, ok := h.fetchGitHubIssue(w, r, ref)
+	if !ok {
+		return
+	}
+	comments, err := h.githubFetcher.FetchComments(r.Context(), ref)
+	if err != nil {
+		writeGitHubFetchError(w, err)
+		return
+	}
+	if req.Tags == nil {
+		tags = tagsFromGitHubLabels(issue.Labels)
+	}
+
+	var ownerHumanID *string
+	if agent := auth.AgentFromContext(r.Context()); agent != nil {
+		ownerHumanID = agent.HumanID
+	} else if authInfo.AuthorType == models.AuthorTypeHuman {
+		id := authInfo.AuthorID
+		ownerHumanID = &id
+	}
+
+	post := &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        importedIssueTitle(ref, issue.Title, h.contentLimits.For(models.PostTypeProblem).Title),
+		Description:  importedIssueDescription(ref, issue, comments),
+		Tags:         tags,
+		PostedByType: authInfo.AuthorType,
+		PostedByID:   authInfo.AuthorID,
+		Status:       models.PostStatusPendingReview,
+		Visibility:   models.VisibilityPublic,
+		OwnerHumanID: ownerHumanID,
+	}
+	embedQueueReason := h.embedPost(r.Context(), post.Title, post.Description, &post.EmbeddingStr)
+
+	createdPost, err := h.repo.Create(r.Context(), post)
+	if err != nil {
+		h.writeGitHubInternalError(w, r, "Create", err)
+		return
+	}
+
+	link, err := h.githubLinks.Create(r.Context(), &models.PostGitHubLink{
+		PostID:       createdPost.ID,
+		RepoOwner:    ref.Owner,
+		RepoName:     ref.Repo,
+		IssueNumber:  ref.Number,
+		IssueURL:     ref.URL(),
+		IssueState:   issue.State,
+		Imported:     true,
+		LinkedByType: string(authInfo.AuthorType),
+		LinkedByID:   authInfo.AuthorID,
+	})
+	if err != nil {
+		// Lost a race with a concurrent import of the same issue: drop our copy.
+		if delErr := h.repo.Delete(r.Context(), createdPost.ID); delErr != nil {
+			h.logger.Error("failed to delete post after github link failure", "postID", createdPost.ID, "error", delErr)
+		}
+		if errors.Is(err, db.ErrGitHubIssueAlreadyImported) {
+			existing, _ := h.githubLinks.FindImportedPostID(r.Context(), ref)
+			writeGitHubAlreadyImported(w, existing)
+			return
+		}
+		h.writeGitHubInternalError(w, r, "CreateGitHubLink", err)
+		return
+	}
+
+	if embedQueueReason != "" {
+		h.enqueueEmbedding(r.Context(), createdPost.ID, embedQueueReason)
+	}
+	if h.contentModService != nil {
+		go h.moderatePostAsync(tenant.FromContext(r.Context()), createdPost.ID, post.Title, post.Description, post.Tags, string(post.Type), string(authInfo.AuthorType), authInfo.AuthorID)
+	}
+
+	writePostsJSON(w, http.StatusCreated, map[string]interface{}{
+		"data": map[string]interface{}{
+			"post":        createdPost,
+			"github_link": link,
+		},
+	})
+}
+
+// GetGitHubLink handles GET /v1/posts/{id}/github-link.
+// Returns the GitHub issue linked to the post (the backlink), or 404.
+func (h *PostsHandler) GetGitHubLink(w http.ResponseWriter, r *http.Request) {
+	if h.githubLinks == nil {
+		apierror.Write(w, apierror.GitHubNotConfigured, "github import is not configured")
+		return
+	}
+	post, ok := h.findPostForGitHubLink(w, r)
+	if !ok {
+		return
+	}
+
+	link, err := h.githubLinks.FindByPostID(r.Context(), post.ID)
+	if err != nil {
+		if errors.Is(err, db.ErrGitHubLinkNotFound) {
+			apierror.Write(w, apierror.NotFound, "post is not linked to a github issue")
+			return
+		}
+		h.writeGitHubInternalError(w, r, "FindGitHubLink", err)
+		return
+	}
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{"data": link})
+}
+
+// LinkGitHubIssue handles POST /v1/posts/{id}/github-link.
+// Links one of the caller's problems or questions to an existing issue. The post
+// is closed when the issue closes, and reopened if the issue is reopened.
+func (h *PostsHandler) LinkGitHubIssue(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if h.githubFetcher == nil || h.githubLinks == nil {
+		apierror.Write(w, apierror.GitHubNotConfigured, "github import is not configured")
+		return
+	}
+
+	post, ok := h.findOwnPostForGitHubLink(w, r, authInfo)
+	if !ok {
+		return
+	}
+	if post.Type != models.PostTypeProblem && post.Type != models.PostTypeQuestion {
+		apierror.Write(w, apierror.ValidationError, "only problems and questions can be linked to github issues")
+		return
+	}
+
+	var req GitHubIssueLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	ref, err := models.ParseGitHubIssueURL(req.IssueURL)
+	if err != nil {
+		apierror.Write(w, apierror.InvalidIssueURL, err.Error())
+		return
+	}
+	issue, ok := h.fetchGitHubIssue(w, r, ref)
+	if !ok {
+		return
+	}
+
+	link, err := h.githubLinks.Create(r.Context(), &models.PostGitHubLink{
+		PostID:       post.ID,
+		RepoOwner:    ref.Owner,
+		RepoName:     ref.Repo,
+		IssueNumber:  ref.Number,
+		IssueURL:     ref.URL(),
+		IssueState:   issue.State,
+		LinkedByType: string(authInfo.AuthorType),
+		LinkedByID:   authInfo.AuthorID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrPostAlreadyLinked) {
+			apierror.Write(w, apierror.AlreadyLinked, "post is already linked to a github issue; unlink it first")
+			return
+		}
+		h.writeGitHubInternalError(w, r, "CreateGitHubLink", err)
+		return
+	}
+
+	// Sync right away so linking to an already-closed issue closes the post.
+	if _, err := h.githubLinks.ApplyIssueState(r.Context(), post.ID, issue.State); err != nil {
+		h.logger.Error("failed to sync post with github issue state", "postID", post.ID, "error", err)
+	}
+
+	writePostsJSON(w, http.StatusCreated, map[string]interface{}{"data": link})
+}
+
+// UnlinkGitHubIssue handles DELETE /v1/posts/{id}/github-link.
+// Stops status sync; the post keeps its current status.
+func (h *PostsHandler) UnlinkGitHubIssue(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if h.githubLinks == nil {
+		apierror.Write(w, apierror.GitHubNotConfigured, "github import is not configured")
+		return
+	}
+
+	post, ok := h.findOwnPostForGitHubLink(w, r, authInfo)
+	if !ok {
+		return
+	}
+	if err := h.githubLinks.Delete(r.Context(), post.ID); err != nil {
+		if errors.Is(err, db.ErrGitHubLinkNotFound) {
+			apierror.Write(w, apierror.NotFound, "post is not linked to a github issue")
+			return
+		}
+		h.writeGitHubInternalError(w, r, "DeleteGitHubLink", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// findPostForGitHubLink loads the {id} post as the caller sees it.
+func (h *PostsHandler) findPostForGitHubLink(w http.ResponseWriter, r *http.Request) (*models.PostWithAuthor, bool) {
+	postID := chi.URLParam(r, "id")
+	post, err := h.repo.FindByIDForViewer(r.Context(), postID)
+	if err != nil || post.DeletedAt != nil {
+		if err == nil || errors.Is(err, db.ErrPostNotFound) {
+			apierror.Write(w, apierror.NotFound, "post not found")
+			return nil, false
+		}
+		h.writeGitHubInternalError(w, r, "FindByID", err)
+		return nil, false
+	}
+	return post, true
+}
+
+// findOwnPostForGitHubLink loads the {id} post and checks the caller wrote it.
+func (h *PostsHandler) findOwnPostForGitHubLink(w http.ResponseWriter, r *http.Request, authInfo *AuthInfo) (*models.PostWithAuthor, bool) {
+	post, ok := h.findPostForGitHubLink(w, r)
+	if !ok {
+		return nil, false
+	}
+	if post.PostedByType != authInfo.AuthorType || post.PostedByID != authInfo.AuthorID {
+		apierror.Write(w, apierror.Forbidden, "only the post author can change its github link")
+		return nil, false
+	}
+	return post, true
+}
+
+// fetchGitHubIssue fetches an issue, rejecting pull requests. It writes the error
+// response when ok is false.
+func (h *PostsHandler) fetchGitHubIssue(w http.ResponseWriter, r *http.Request, ref models.GitHubIssueRef) (*models.GitHubIssue, bool) {
+	issue, err := h.githubFetcher.FetchIssue(r.Context(), ref)
+	if err != nil {
+		writeGitHubFetchError(w, err)
+		return nil, false
+	}
+	if issue.IsPullRequest {
+		apierror.Write(w, apierror.NotAnIssue, ref.String()+" is a pull request, not an issue")
+		return nil, false
+	}
+	return issue, true
+}
+
+func writeGitHubFetchError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrGitHubIssueNotFound):
+		apierror.Write(w, apierror.IssueNotFound, "github issue not found or not public")
+	case errors.Is(err, models.ErrGitHubRateLimited):
+		apierror.Write(w, apierror.GitHubRateLimited, "github API rate limit reached, try again later")
+	default:
+		apierror.Write(w, apierror.GitHubError, "failed to fetch the issue from github")
+	}
+}
+
+func writeGitHubAlreadyImported(w http.ResponseWriter, postID string) {
+	apierror.WriteDetails(w, apierror.AlreadyImported, "this issue has already been imported",
+		map[string]string{"post_id": postID})
+}
+
+func (h *PostsHandler) writeGitHubInternalError(w http.ResponseWriter, r *http.Request, op string, err error) {
+	response.WriteInternalErrorWithLog(w, "github link operation failed", err, response.LogContext{
+		Operation: op,
+		Resource:  "post_github_links",
+		RequestID: r.Header.Get("X-Request-ID"),
+	}, h.logger)
+}
+
+// tagsFromGitHubLabels keeps the labels that are valid tags, up to MaxTagsPerPost.
+func tagsFromGitHubLabels(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	tags := []string{}
+	for _, label := range labels {
+		tag := models.NormalizeTag(strings.ReplaceAll(label, " ", "-"))
+		if models.IsValidTag(tag) && !seen[tag] && len(tags) < models.MaxTagsPerPost {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// importedIssueTitle fits the issue title to post title limits, prefixing the
+// repository when the title is too short.
+func importedIssueTitle(ref models.GitHubIssueRef, title string, limit models.LengthLimit) string {
+	title = strings.TrimSpace(title)
+	if len(title) < limit.Min {
+		title = ref.Owner + "/" + ref.Repo + ": " + title
+	}
+	if runes := []rune(title); limit.Max > 0 && len(runes) > limit.Max {
+		title = string(runes[:limit.Max-1]) + "…"
+	}
+	return title
+}
+
+// importedIssueDescription renders the issue body and comments as markdown with
+// a provenance header.
+func importedIssueDescription(ref models.GitHubIssueRef, issue *models.GitHubIssue, comments []models.GitHubIssueComment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "_Imported from GitHub issue [%s](%s), opened by @%s on %s._\n\n",
+		ref.String(), ref.URL(), issue.Author, issue.CreatedAt.Format("2006-01-02"))
+	if body := strings.TrimSpace(issue.Body); body != "" {
+		b.WriteString(body)
+	} else {
+		b.WriteString("_The issue has no description._")
+	}
+	if len(comments) > 0 {
+		b.WriteString("\n\n---\n\n### Comments from GitHub\n")
+		for _, c := range comments {
+			fmt.Fprintf(&b, "\n**@%s** (%s):\n\n%s\n", c.Author, c.CreatedAt.Format("2006-01-02"), strings.TrimSpace(c.Body))
+		}
+	}
+
+	description := b.String()
+	if runes := []rune(description); len(runes) > maxImportedDescriptionRunes {
+		description = string(runes[:maxImportedDescriptionRunes]) + "\n\n_Truncated; see the issue for the full discussion._"
+	}
+	return description
+}
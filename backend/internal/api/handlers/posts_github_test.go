@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// MockGitHubIssueFetcher implements GitHubIssueFetcher for testing.
+type MockGitHubIssueFetcher struct {
+	issue    *models.GitHubIssue
+	comments []models.GitHubIssueComment
+	err      error
+}
+
+func (m *MockGitHubIssueFetcher) FetchIssue(ctx context.Context, ref models.GitHubIssueRef) (*models.GitHubIssue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.issue, nil
+}
+
+func (m *MockGitHubIssueFetcher) FetchComments(ctx context.Context, ref models.GitHubIssueRef) ([]models.GitHubIssueComment, error) {
+	return m.comments, nil
+}
+
+// MockPostGitHubLinkRepository implements PostGitHubLinkRepositoryInterface for testing.
+type MockPostGitHubLinkRepository struct {
+	links        map[string]*models.PostGitHubLink
+	importedPost string
+	createErr    error
+	appliedState string
+}
+
+func NewMockPostGitHubLinkRepository() *MockPostGitHubLinkRepository {
+	return &MockPostGitHubLinkRepository{links: map[string]*models.PostGitHubLink{}}
+}
+
+func (m *MockPostGitHubLinkRepository) Create(ctx context.Context, link *models.PostGitHubLink) (*models.PostGitHubLink, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	if _, ok := m.links[link.PostID]; ok {
+		return nil, db.ErrPostAlreadyLinked
+	}
+	link.SyncedAt = time.Now()
+	link.CreatedAt = time.Now()
+	m.links[link.PostID] = link
+	return link, nil
+}
+
+func (m *MockPostGitHubLinkRepository) FindByPostID(ctx context.Context, postID string) (*models.PostGitHubLink, error) {
+	if link, ok := m.links[postID]; ok {
+		return link, nil
+	}
+	return nil, db.ErrGitHubLinkNotFound
+}
+
+func (m *MockPostGitHubLinkRepository) FindImportedPostID(ctx context.Context, ref models.GitHubIssueRef) (string, error) {
+	return m.importedPost, nil
+}
+
+func (m *MockPostGitHubLinkRepository) Delete(ctx context.Context, postID string) error {
+	if _, ok := m.links[postID]; !ok {
+		return db.ErrGitHubLinkNotFound
+	}
+	delete(m.links, postID)
+	return nil
+}
+
+func (m *MockPostGitHubLinkRepository) ApplyIssueState(ctx context.Context, postID, state string) (models.PostStatus, error) {
+	m.appliedState = state
+	return "", nil
+}
+
+func newGitHubTestIssue() *models.GitHubIssue {
+	return &models.GitHubIssue{
+		Title:     "Panic when config file is empty",
+		Body:      "Running `tool --config empty.yml` panics with a nil map.",
+		State:     models.GitHubIssueOpen,
+		HTMLURL:   "https://github.com/acme/tool/issues/42",
+		Author:    "octocat",
+		Labels:    []string{"bug", "good first issue", "Go"},
+		CreatedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func githubLinkRequest(method, postID string, body interface{}) *http.Request {
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, "/v1/posts/"+postID+"/github-link", bytes.NewReader(jsonBody))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", postID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestImportGitHubIssue_Success(t *testing.T) {
+	repo := NewMockPostsRepository()
+	links := NewMockPostGitHubLinkRepository()
+	handler := NewPostsHandler(repo)
+	handler.SetGitHubImport(&MockGitHubIssueFetcher{
+		issue:    newGitHubTestIssue(),
+		comments: []models.GitHubIssueComment{{Author: "maintainer", Body: "Reproduced on main.", CreatedAt: time.Now()}},
+	}, links)
+
+	body, _ := json.Marshal(map[string]string{"issue_url": "https://github.com/acme/tool/issues/42#issuecomment-1"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts/import/github", bytes.NewReader(body))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.ImportGitHubIssue(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	post := repo.createdPost
+	if post == nil {
+		t.Fatal("expected post to be created")
+	}
+	if post.Type != models.PostTypeProblem || post.Status != models.PostStatusPendingReview {
+		t.Errorf("expected pending_review problem, got %s %s", post.Status, post.Type)
+	}
+	if strings.Join(post.Tags, ",") != "bug,good-first-issue,go" {
+		t.Errorf("expected tags from labels, got %v", post.Tags)
+	}
+	if !strings.Contains(post.Description, "https://github.com/acme/tool/issues/42") ||
+		!strings.Contains(post.Description, "Reproduced on main.") {
+		t.Errorf("expected provenance and comments in description, got %q", post.Description)
+	}
+
+	link := links.links["new-post-id"]
+	if link == nil || !link.Imported || link.IssueNumber != 42 || link.RepoOwner != "acme" {
+		t.Errorf("expected imported link to acme/tool#42, got %+v", link)
+	}
+}
+
+func TestImportGitHubIssue_AlreadyImported(t *testing.T) {
+	repo := NewMockPostsRepository()
+	links := NewMockPostGitHubLinkRepository()
+	links.importedPost = "existing-post"
+	handler := NewPostsHandler(repo)
+	handler.SetGitHubImport(&MockGitHubIssueFetcher{issue: newGitHubTestIssue()}, links)
+
+	body, _ := json.Marshal(map[string]string{"issue_url": "https://github.com/acme/tool/issues/42"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts/import/github", bytes.NewReader(body))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.ImportGitHubIssue(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "existing-post") {
+		t.Errorf("expected existing post_id in response, got %s", w.Body.String())
+	}
+	if repo.createdPost != nil {
+		t.Error("expected no post to be created")
+	}
+}
+
+func TestImportGitHubIssue_Errors(t *testing.T) {
+	pr := newGitHubTestIssue()
+	pr.IsPullRequest = true
+
+	tests := []struct {
+		name     string
+		url      string
+		fetcher  *MockGitHubIssueFetcher
+		wantCode int
+		wantErr  string
+	}{
+		{"invalid url", "https://gitlab.com/acme/tool/issues/1", &MockGitHubIssueFetcher{}, http.StatusBadRequest, "INVALID_ISSUE_URL"},
+		{"pull request", "https://github.com/acme/tool/issues/7", &MockGitHubIssueFetcher{issue: pr}, http.StatusBadRequest, "NOT_AN_ISSUE"},
+		{"not found", "https://github.com/acme/tool/issues/7", &MockGitHubIssueFetcher{err: models.ErrGitHubIssueNotFound}, http.StatusNotFound, "ISSUE_NOT_FOUND"},
+		{"rate limited", "https://github.com/acme/tool/issues/7", &MockGitHubIssueFetcher{err: models.ErrGitHubRateLimited}, http.StatusServiceUnavailable, "GITHUB_RATE_LIMITED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewPostsHandler(NewMockPostsRepository())
+			handler.SetGitHubImport(tt.fetcher, NewMockPostGitHubLinkRepository())
+
+			body, _ := json.Marshal(map[string]string{"issue_url": tt.url})
+			req := httptest.NewRequest(http.MethodPost, "/v1/posts/import/github", bytes.NewReader(body))
+			req = addAuthContext(req, "user-123", "user")
+			w := httptest.NewRecorder()
+
+			handler.ImportGitHubIssue(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantErr) {
+				t.Errorf("expected %s error, got %s", tt.wantErr, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestImportGitHubIssue_NotConfigured(t *testing.T) {
+	handler := NewPostsHandler(NewMockPostsRepository())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts/import/github", strings.NewReader(`{}`))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.ImportGitHubIssue(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
+
+func TestLinkGitHubIssue_SyncsState(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Existing problem", models.PostTypeProblem)
+	repo.SetPost(&post)
+	links := NewMockPostGitHubLinkRepository()
+	issue := newGitHubTestIssue()
+	issue.State = models.GitHubIssueClosed
+	handler := NewPostsHandler(repo)
+	handler.SetGitHubImport(&MockGitHubIssueFetcher{issue: issue}, links)
+
+	req := githubLinkRequest(http.MethodPost, "post-123", map[string]string{"issue_url": "https://github.com/acme/tool/issues/42"})
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.LinkGitHubIssue(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if links.links["post-123"] == nil || links.links["post-123"].Imported {
+		t.Errorf("expected non-imported link, got %+v", links.links["post-123"])
+	}
+	if links.appliedState != models.GitHubIssueClosed {
+		t.Errorf("expected closed state to be applied, got %q", links.appliedState)
+	}
+}
+
+func TestLinkGitHubIssue_NotAuthor(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Existing problem", models.PostTypeProblem)
+	repo.SetPost(&post)
+	links := NewMockPostGitHubLinkRepository()
+	handler := NewPostsHandler(repo)
+	handler.SetGitHubImport(&MockGitHubIssueFetcher{issue: newGitHubTestIssue()}, links)
+
+	req := githubLinkRequest(http.MethodPost, "post-123", map[string]string{"issue_url": "https://github.com/acme/tool/issues/42"})
+	req = addAuthContext(req, "other-user", "user")
+	w := httptest.NewRecorder()
+
+	handler.LinkGitHubIssue(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+	if len(links.links) != 0 {
+		t.Error("expected no link to be created")
+	}
+}
+
+func TestLinkGitHubIssue_IdeaRejected(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Existing idea", models.PostTypeIdea)
+	repo.SetPost(&post)
+	handler := NewPostsHandler(repo)
+	handler.SetGitHubImport(&MockGitHubIssueFetcher{issue: newGitHubTestIssue()}, NewMockPostGitHubLinkRepository())
+
+	req := githubLinkRequest(http.MethodPost, "post-123", map[string]string{"issue_url": "https://github.com/acme/tool/issues/42"})
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.LinkGitHubIssue(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestGetAndUnlinkGitHubIssue(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Existing problem", models.PostTypeProblem)
+	repo.SetPost(&post)
+	links := NewMockPostGitHubLinkRepository()
+	links.links["post-123"] = &models.PostGitHubLink{PostID: "post-123", RepoOwner: "acme", RepoName: "tool", IssueNumber: 42}
+	handler := NewPostsHandler(repo)
+	handler.SetGitHubImport(&MockGitHubIssueFetcher{}, links)
+
+	w := httptest.NewRecorder()
+	handler.GetGitHubLink(w, githubLinkRequest(http.MethodGet, "post-123", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	req := addAuthContext(githubLinkRequest(http.MethodDelete, "post-123", nil), "user-123", "user")
+	w = httptest.NewRecorder()
+	handler.UnlinkGitHubIssue(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.GetGitHubLink(w, githubLinkRequest(http.MethodGet, "post-123", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 after unlink, got %d", w.Code)
+	}
+}
+
+func TestImportedIssueTitle(t *testing.T) {
+	ref := models.GitHubIssueRef{Owner: "acme", Repo: "tool", Number: 1}
+	limit := models.DefaultContentLimits().For(models.PostTypeProblem).Title
+	if got := importedIssueTitle(ref, "Crash", limit); got != "acme/tool: Crash" {
+		t.Errorf("expected short title to be prefixed, got %q", got)
+	}
+	if got := importedIssueTitle(ref, strings.Repeat("a", 300), limit); len([]rune(got)) != limit.Max {
+		t.Errorf("expected title truncated to %d runes, got %d", limit.Max, len([]rune(got)))
+	}
+}
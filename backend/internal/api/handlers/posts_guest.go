@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+	"github.com/go-chi/chi/v5"
+)
+
+// GuestClaimTokenTTL is how long a guest has to claim the problem they posted.
+const GuestClaimTokenTTL = 30 * 24 * time.Hour
+
+// GuestModerationMinConfidence is the moderator confidence a guest post needs to be
+// approved automatically. Less confident approvals wait in pending_review for an admin.
+const GuestModerationMinConfidence = 0.9
+
+// GuestClaimRepositoryInterface stores guest post claim tokens, which are claim
+// tokens with a PostID instead of an AgentID.
+type GuestClaimRepositoryInterface interface {
+	Create(ctx context.Context, token *models.ClaimToken) error
+	FindByToken(ctx context.Context, token string) (*models.ClaimToken, error)
+	ClaimGuestPost(ctx context.Context, token *models.ClaimToken, humanID string) error
+}
+
+// GuestClaimNotifier emails a guest the link to claim the problem they posted.
+type GuestClaimNotifier interface {
+	NotifyGuestPostClaimLink(ctx context.Context, email string, post *models.Post, claimURL string, expiresAt time.Time) error
+}
+
+// SetGuestPosting enables POST /v1/guest/problems and the guest claim endpoints.
+// Guest posting needs both: a guest can only claim their post through the email.
+func (h *PostsHandler) SetGuestPosting(claims GuestClaimRepositoryInterface, notifier GuestClaimNotifier) {
+	h.guestClaims = claims
+	h.guestNotifier = notifier
+}
+
+// CreateGuestProblemRequest is the request body for POST /v1/guest/problems.
+type CreateGuestProblemRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags,omitempty"`
+	Email       string   `json:"email"` // Where the claim link is sent; never shown
+}
+
+// GuestClaimRequest is the request body for POST /v1/guest/claim.
+type GuestClaimRequest struct {
+	Token string `json:"token"`
+}
+
+// CreateGuestProblem handles POST /v1/guest/problems - submit a problem without an
+// account. The problem is posted as a guest and quarantined: it is moderated more
+// strictly than member posts and cannot be voted on. A claim link is emailed to the
+// submitter; claiming the problem moves it to their account and lifts the quarantine.
+func (h *PostsHandler) CreateGuestProblem(w http.ResponseWriter, r *http.Request) {
+	if h.guestClaims == nil || h.guestNotifier == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "guest posting is not available")
+		return
+	}
+
+	var req CreateGuestProblemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	var v Validator
+	limits := h.contentLimits.For(models.PostTypeProblem)
+	if v.Required("title", req.Title) {
+		validatePostLengths(&v, limits, &req.Title, nil, nil)
+	}
+	if v.Required("description", req.Description) {
+		validatePostLengths(&v, limits, nil, &req.Description, nil)
+	}
+	v.MaxItems("tags", len(req.Tags), models.MaxTagsPerPost)
+	req.Tags = validateTags(&v, req.Tags)
+	if err := validateEmail(req.Email); err != nil {
+		v.Add(FieldError{Field: "email", Code: FieldInvalidValue, Message: err.Error()})
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	tokenValue, err := newGuestToken(32)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to generate token")
+		return
+	}
+	guestID, err := newGuestToken(6)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to generate token")
+		return
+	}
+
+	post := &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        req.Title,
+		Description:  req.Description,
+		Tags:         req.Tags,
+		PostedByType: models.AuthorTypeGuest,
+		PostedByID:   "guest_" + guestID,
+		Status:       models.PostStatusPendingReview,
+		Visibility:   models.VisibilityPublic,
+	}
+	embedQueueReason := h.embedPost(r.Context(), post.Title, post.Description, &post.EmbeddingStr)
+
+	createdPost, err := h.repo.Create(r.Context(), post)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to create post")
+		return
+	}
+
+	claimToken := &models.ClaimToken{
+		Token:     tokenValue,
+		PostID:    createdPost.ID,
+		Email:     req.Email,
+		ExpiresAt: time.Now().Add(GuestClaimTokenTTL),
+	}
+	if err := h.guestClaims.Create(r.Context(), claimToken); err != nil {
+		// Without a token the guest could never claim the post: drop it.
+		if delErr := h.repo.Delete(r.Context(), createdPost.ID); delErr != nil {
+			h.logger.Error("failed to delete guest post after claim token failure", "postID", createdPost.ID, "error", delErr)
+		}
+		apierror.Write(w, apierror.InternalError, "failed to create claim token")
+		return
+	}
+
+	claimURL := "https://solvr.dev/guest/claim/" + tokenValue
+	if err := h.guestNotifier.NotifyGuestPostClaimLink(r.Context(), req.Email, createdPost, claimURL, claimToken.ExpiresAt); err != nil {
+		h.logger.Warn("failed to queue guest claim email", "postID", createdPost.ID, "error", err)
+	}
+
+	if embedQueueReason != "" {
+		h.enqueueEmbedding(r.Context(), createdPost.ID, embedQueueReason)
+	}
+	if h.contentModService != nil {
+		go h.moderatePostAsync(tenant.FromContext(r.Context()), createdPost.ID, post.Title, post.Description, post.Tags, string(post.Type), string(post.PostedByType), post.PostedByID)
+	}
+
+	writePostsJSON(w, http.StatusCreated, map[string]interface{}{
+		"data": map[string]interface{}{
+			"post":             createdPost,
+			"claim_expires_at": claimToken.ExpiresAt,
+		},
+	})
+}
+
+// GetGuestClaimInfo handles GET /v1/guest/claim/{token} - the problem a guest claim
+// token claims, for the confirmation page. Public, like GET /v1/claim/{token}.
+func (h *PostsHandler) GetGuestClaimInfo(w http.ResponseWriter, r *http.Request) {
+	token, ok := h.findGuestClaimToken(w, r, chi.URLParam(r, "token"))
+	if !ok {
+		return
+	}
+
+	post, err := h.repo.FindByID(r.Context(), token.PostID)
+	if err != nil {
+		if errors.Is(err, db.ErrPostNotFound) {
+			apierror.Write(w, apierror.TokenNotFound, "token not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get post")
+		return
+	}
+
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]interface{}{
+			"post_id":    post.ID,
+			"title":      post.Title,
+			"status":     post.Status,
+			"expires_at": token.ExpiresAt,
+		},
+	})
+}
+
+// ClaimGuestPost handles POST /v1/guest/claim - a signed-in human claims the
+// problem they posted as a guest, with the token from the claim email. The
+// problem becomes theirs and can be voted on.
+func (h *PostsHandler) ClaimGuestPost(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	if authInfo.AuthorType != models.AuthorTypeHuman {
+		apierror.Write(w, apierror.Forbidden, "only humans can claim guest posts")
+		return
+	}
+
+	var req GuestClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	token, ok := h.findGuestClaimToken(w, r, req.Token)
+	if !ok {
+		return
+	}
+
+	if err := h.guestClaims.ClaimGuestPost(r.Context(), token, authInfo.AuthorID); err != nil {
+		switch {
+		case errors.Is(err, db.ErrClaimTokenUsed):
+			apierror.Write(w, apierror.TokenUsed, "token has already been used")
+		case errors.Is(err, db.ErrPostNotFound):
+			apierror.Write(w, apierror.NotFound, "post not found")
+		default:
+			apierror.Write(w, apierror.InternalError, "failed to claim post")
+		}
+		return
+	}
+
+	post, err := h.repo.FindByID(r.Context(), token.PostID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get post")
+		return
+	}
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": post,
+	})
+}
+
+// findGuestClaimToken looks up an unused, unexpired guest post claim token and
+// writes the error response when there is none. Agent claim tokens are not found.
+func (h *PostsHandler) findGuestClaimToken(w http.ResponseWriter, r *http.Request, value string) (*models.ClaimToken, bool) {
+	if h.guestClaims == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "guest posting is not available")
+		return nil, false
+	}
+	if value == "" {
+		apierror.Write(w, apierror.MissingToken, "token is required")
+		return nil, false
+	}
+
+	token, err := h.guestClaims.FindByToken(r.Context(), value)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get token")
+		return nil, false
+	}
+	if token == nil || token.PostID == "" {
+		apierror.Write(w, apierror.TokenNotFound, "token not found")
+		return nil, false
+	}
+	if token.IsExpired() {
+		apierror.WriteStatus(w, http.StatusGone, apierror.TokenExpired, "token has expired")
+		return nil, false
+	}
+	if token.IsUsed() {
+		apierror.Write(w, apierror.TokenUsed, "token has already been used")
+		return nil, false
+	}
+	return token, true
+}
+
+// newGuestToken returns n random bytes, hex encoded.
+func newGuestToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
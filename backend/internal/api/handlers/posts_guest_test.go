@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockGuestClaimRepo implements GuestClaimRepositoryInterface for testing.
+type mockGuestClaimRepo struct {
+	tokens    map[string]*models.ClaimToken
+	created   *models.ClaimToken
+	claimedBy string
+	claimErr  error
+}
+
+func newMockGuestClaimRepo() *mockGuestClaimRepo {
+	return &mockGuestClaimRepo{tokens: map[string]*models.ClaimToken{}}
+}
+
+func (m *mockGuestClaimRepo) Create(ctx context.Context, token *models.ClaimToken) error {
+	token.ID = "token-id"
+	m.created = token
+	m.tokens[token.Token] = token
+	return nil
+}
+
+func (m *mockGuestClaimRepo) FindByToken(ctx context.Context, token string) (*models.ClaimToken, error) {
+	return m.tokens[token], nil
+}
+
+func (m *mockGuestClaimRepo) ClaimGuestPost(ctx context.Context, token *models.ClaimToken, humanID string) error {
+	if m.claimErr != nil {
+		return m.claimErr
+	}
+	m.claimedBy = humanID
+	return nil
+}
+
+// mockGuestClaimNotifier implements GuestClaimNotifier for testing.
+type mockGuestClaimNotifier struct {
+	email    string
+	claimURL string
+}
+
+func (m *mockGuestClaimNotifier) NotifyGuestPostClaimLink(ctx context.Context, email string, post *models.Post, claimURL string, expiresAt time.Time) error {
+	m.email = email
+	m.claimURL = claimURL
+	return nil
+}
+
+func newGuestTestHandler() (*PostsHandler, *MockPostsRepository, *mockGuestClaimRepo, *mockGuestClaimNotifier) {
+	repo := NewMockPostsRepository()
+	claims := newMockGuestClaimRepo()
+	notifier := &mockGuestClaimNotifier{}
+	handler := NewPostsHandler(repo)
+	handler.SetGuestPosting(claims, notifier)
+	return handler, repo, claims, notifier
+}
+
+func guestProblemBody(email string) *bytes.Reader {
+	body, _ := json.Marshal(CreateGuestProblemRequest{
+		Title:       "Postgres connection pool exhausted under load",
+		Description: "After deploying to production the pool runs out of connections within minutes of peak traffic.",
+		Tags:        []string{"postgresql"},
+		Email:       email,
+	})
+	return bytes.NewReader(body)
+}
+
+func TestCreateGuestProblem_Success(t *testing.T) {
+	handler, repo, claims, notifier := newGuestTestHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/guest/problems", guestProblemBody("guest@example.com"))
+	w := httptest.NewRecorder()
+	handler.CreateGuestProblem(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if repo.createdPost == nil {
+		t.Fatal("expected post to be created")
+	}
+	if repo.createdPost.PostedByType != models.AuthorTypeGuest {
+		t.Errorf("expected posted_by_type guest, got %s", repo.createdPost.PostedByType)
+	}
+	if !strings.HasPrefix(repo.createdPost.PostedByID, "guest_") {
+		t.Errorf("expected guest_ posted_by_id, got %s", repo.createdPost.PostedByID)
+	}
+	if repo.createdPost.Status != models.PostStatusPendingReview {
+		t.Errorf("expected status pending_review, got %s", repo.createdPost.Status)
+	}
+	if claims.created == nil || claims.created.PostID != "new-post-id" || claims.created.Email != "guest@example.com" {
+		t.Fatalf("expected claim token for the new post, got %+v", claims.created)
+	}
+	if notifier.email != "guest@example.com" {
+		t.Errorf("expected claim email to guest@example.com, got %q", notifier.email)
+	}
+	if !strings.HasSuffix(notifier.claimURL, "/guest/claim/"+claims.created.Token) {
+		t.Errorf("expected claim URL to end with the token, got %q", notifier.claimURL)
+	}
+	if strings.Contains(w.Body.String(), "guest@example.com") || strings.Contains(w.Body.String(), claims.created.Token) {
+		t.Error("response must not expose the email or the claim token")
+	}
+}
+
+func TestCreateGuestProblem_InvalidEmail(t *testing.T) {
+	handler, repo, _, _ := newGuestTestHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/guest/problems", guestProblemBody("not-an-email"))
+	w := httptest.NewRecorder()
+	handler.CreateGuestProblem(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if repo.createdPost != nil {
+		t.Error("expected no post to be created")
+	}
+}
+
+func TestCreateGuestProblem_NotConfigured(t *testing.T) {
+	handler := NewPostsHandler(NewMockPostsRepository())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/guest/problems", guestProblemBody("guest@example.com"))
+	w := httptest.NewRecorder()
+	handler.CreateGuestProblem(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", w.Code)
+	}
+}
+
+func guestClaimRequest(token string) *http.Request {
+	body, _ := json.Marshal(GuestClaimRequest{Token: token})
+	req := httptest.NewRequest(http.MethodPost, "/v1/guest/claim", bytes.NewReader(body))
+	return addAuthContext(req, "human-1", "user")
+}
+
+func TestClaimGuestPost_Success(t *testing.T) {
+	handler, repo, claims, _ := newGuestTestHandler()
+	claims.tokens["tok"] = &models.ClaimToken{Token: "tok", PostID: "post-1", ExpiresAt: time.Now().Add(time.Hour)}
+	post := createTestPost("post-1", "Guest problem", models.PostTypeProblem)
+	repo.SetPost(&post)
+
+	w := httptest.NewRecorder()
+	handler.ClaimGuestPost(w, guestClaimRequest("tok"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if claims.claimedBy != "human-1" {
+		t.Errorf("expected post claimed by human-1, got %q", claims.claimedBy)
+	}
+}
+
+func TestClaimGuestPost_Expired(t *testing.T) {
+	handler, _, claims, _ := newGuestTestHandler()
+	claims.tokens["tok"] = &models.ClaimToken{Token: "tok", PostID: "post-1", ExpiresAt: time.Now().Add(-time.Hour)}
+
+	w := httptest.NewRecorder()
+	handler.ClaimGuestPost(w, guestClaimRequest("tok"))
+
+	if w.Code != http.StatusGone {
+		t.Fatalf("expected status 410, got %d", w.Code)
+	}
+	if claims.claimedBy != "" {
+		t.Error("expected expired token not to claim")
+	}
+}
+
+func TestClaimGuestPost_AlreadyUsed(t *testing.T) {
+	handler, _, claims, _ := newGuestTestHandler()
+	claims.tokens["tok"] = &models.ClaimToken{Token: "tok", PostID: "post-1", ExpiresAt: time.Now().Add(time.Hour)}
+	claims.claimErr = db.ErrClaimTokenUsed
+
+	w := httptest.NewRecorder()
+	handler.ClaimGuestPost(w, guestClaimRequest("tok"))
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestClaimGuestPost_AgentTokenNotFound(t *testing.T) {
+	handler, _, claims, _ := newGuestTestHandler()
+	claims.tokens["tok"] = &models.ClaimToken{Token: "tok", AgentID: "agent-1", ExpiresAt: time.Now().Add(time.Hour)}
+
+	w := httptest.NewRecorder()
+	handler.ClaimGuestPost(w, guestClaimRequest("tok"))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestVotePost_GuestPostForbidden(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-1", "Guest problem", models.PostTypeProblem)
+	post.PostedByType = models.AuthorTypeGuest
+	post.PostedByID = "guest_abc"
+	repo.SetPost(&post)
+	handler := NewPostsHandler(repo)
+
+	body, _ := json.Marshal(map[string]interface{}{"direction": "up"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts/post-1/vote", bytes.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthContext(req, "human-1", "user")
+	w := httptest.NewRecorder()
+
+	handler.Vote(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if repo.vote != nil {
+		t.Error("expected no vote to be recorded")
+	}
+}
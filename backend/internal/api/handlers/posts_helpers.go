@@ -29,15 +29,3 @@ func float32SliceToVectorString(v []float32) string {
 	s += "]"
 	return s
 }
-
-// writePostsError writes an error JSON response.
-func writePostsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
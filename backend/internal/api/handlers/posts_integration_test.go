@@ -660,10 +660,14 @@ func (m *MockPostsRepositoryForIntegration) FindByID(ctx context.Context, id str
 	return post, nil
 }
 
-func (m *MockPostsRepositoryForIntegration) FindByIDForViewer(ctx context.Context, id string, viewerType models.AuthorType, viewerID string, callerHuman string) (*models.PostWithAuthor, error) {
+func (m *MockPostsRepositoryForIntegration) FindByIDForViewer(ctx context.Context, id string) (*models.PostWithAuthor, error) {
 	return m.FindByID(ctx, id)
 }
 
+func (m *MockPostsRepositoryForIntegration) FindByIDFromPrimary(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	return m.FindByIDForViewer(ctx, id)
+}
+
 func (m *MockPostsRepositoryForIntegration) Create(ctx context.Context, post *models.Post) (*models.Post, error) {
 	m.postCounter++
 	post.ID = "integration-post-" + string(rune('0'+m.postCounter))
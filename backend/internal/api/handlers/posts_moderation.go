@@ -8,13 +8,16 @@ import (
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 	"github.com/google/uuid"
 )
 
 // moderatePostAsync runs content moderation asynchronously with retry logic.
-// Uses context.Background() with 30s timeout (not request context).
-func (h *PostsHandler) moderatePostAsync(postID, title, description string, tags []string, postType, authorType, authorID string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+// Uses context.Background() with a 60s timeout (not request context), scoped to
+// tenantID so the system comment lands in the post's tenant.
+func (h *PostsHandler) moderatePostAsync(tenantID, postID, title, description string, tags []string, postType, authorType, authorID string) {
+	ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), tenantID), 60*time.Second)
 	defer cancel()
 
 	input := ModerationInput{
@@ -76,11 +79,19 @@ func (h *PostsHandler) moderatePostAsync(postID, title, description string, tags
 			return
 		}
 
+		// Guest posts are quarantined: approvals the moderator is not sure about wait
+		// for an admin, and they are not auto-translated.
+		guest := authorType == string(models.AuthorTypeGuest)
+		if guest && result.Approved && result.Confidence < GuestModerationMinConfidence {
+			h.holdGuestPostForReview(ctx, postID, result)
+			return
+		}
+
 		var newStatus models.PostStatus
 		var languageOnlyRejection bool
 		if result.Approved {
 			newStatus = models.PostStatusOpen
-		} else if isLanguageOnlyRejection(result) {
+		} else if !guest && isLanguageOnlyRejection(result) {
 			// Language-only rejection → save as draft for auto-translation
 			newStatus = models.PostStatusDraft
 			languageOnlyRejection = true
@@ -95,27 +106,27 @@ func (h *PostsHandler) moderatePostAsync(postID, title, description string, tags
 			}
 			// Trigger inline translation immediately instead of waiting for the hourly sweep.
 			if h.translationTrigger != nil {
-				h.translationTrigger.TranslateAndModerateAsync(postID, title, description, tags, result.LanguageDetected, postType, authorType, authorID)
+				h.translationTrigger.TranslateAndModerateAsync(ctx, postID, title, description, tags, result.LanguageDetected, postType, authorType, authorID)
 			}
 		} else {
 			if err := h.statusUpdater.UpdateStatus(ctx, postID, newStatus); err != nil {
 				h.logger.Error("failed to update post status after moderation", "postID", postID, "status", newStatus, "error", err)
+			} else if result.Approved {
+				announcePostAsync(ctx, h.publishedNotifier, &models.Post{
+					ID: postID, Type: models.PostType(postType), Title: title, Description: description, Tags: tags,
+				}, h.logger)
 			}
 		}
 
 		// Create system comment explaining the moderation decision
 		if h.commentRepo != nil {
-			var commentContent string
+			key := modtemplate.KeyRejected
 			if result.Approved {
-				commentContent = "Post approved by Solvr moderation. Your post is now visible in the feed."
+				key = modtemplate.KeyApproved
 			} else if languageOnlyRejection {
-				commentContent = fmt.Sprintf(
-					"Your post appears to be in %s. Translating to English now — your post should be live within minutes.",
-					result.LanguageDetected,
-				)
-			} else {
-				commentContent = fmt.Sprintf("Post rejected by Solvr moderation.\n\nReason: %s\n\nYou can edit your post and resubmit for review.", result.Explanation)
+				key = modtemplate.KeyLanguageTranslating
 			}
+			commentContent := renderModerationComment(ctx, h.commentTemplates, key, postID, result)
 
 			comment := &models.Comment{
 				TargetType: models.CommentTargetPost,
@@ -129,8 +140,8 @@ func (h *PostsHandler) moderatePostAsync(postID, title, description string, tags
 			}
 		}
 
-		// Send notification to post author about moderation result
-		if h.notifService != nil {
+		// Send notification to post author about moderation result (guests have no inbox)
+		if h.notifService != nil && !guest {
 			if notifErr := h.notifService.NotifyOnModerationResult(ctx, postID, title, postType, authorType, authorID, result.Approved, result.Explanation); notifErr != nil {
 				h.logger.Error("failed to send moderation notification", "postID", postID, "error", notifErr)
 			}
@@ -139,6 +150,47 @@ func (h *PostsHandler) moderatePostAsync(postID, title, description string, tags
 	}
 }
 
+// renderModerationComment renders the system comment for key with renderer, or
+// the built-in template when no renderer is configured.
+func renderModerationComment(ctx context.Context, renderer ModerationCommentRenderer, key modtemplate.Key, postID string, result *ModerationResult) string {
+	vars := modtemplate.Vars{
+		PostID:      postID,
+		Explanation: result.Explanation,
+		Reasons:     result.RejectionReasons,
+		Language:    result.LanguageDetected,
+	}
+	if renderer == nil {
+		return (*modtemplate.Renderer)(nil).Render(ctx, key, vars)
+	}
+	return renderer.Render(ctx, key, vars)
+}
+
+// holdGuestPostForReview leaves a guest post the moderator approved with low
+// confidence in pending_review and flags it for an admin to decide.
+func (h *PostsHandler) holdGuestPostForReview(ctx context.Context, postID string, result *ModerationResult) {
+	h.logger.Info("guest post held for admin review", "postID", postID, "confidence", result.Confidence)
+	if h.flagCreator == nil {
+		return
+	}
+	parsedID, err := uuid.Parse(postID)
+	if err != nil {
+		h.logger.Error("invalid post ID for flag creation", "postID", postID, "error", err)
+		return
+	}
+	flag := &models.Flag{
+		TargetType:   "post",
+		TargetID:     parsedID,
+		ReporterType: "system",
+		ReporterID:   "content-moderation",
+		Reason:       "other",
+		Details:      fmt.Sprintf("Guest post approved with confidence %.2f (below %.2f); needs admin review.", result.Confidence, GuestModerationMinConfidence),
+		Status:       "pending",
+	}
+	if _, err := h.flagCreator.CreateFlag(ctx, flag); err != nil {
+		h.logger.Error("failed to create guest post review flag", "postID", postID, "error", err)
+	}
+}
+
 // isLanguageOnlyRejection returns true when the post was rejected exclusively
 // because of language (not spam, injection, or relevance).
 // Only triggers auto-translation when LANGUAGE is the sole rejection reason.
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 	"github.com/google/uuid"
 )
 
@@ -92,6 +94,7 @@ func (m *MockContentModerationService) GetCalls() int {
 type MockCommentCreator struct {
 	mu       sync.Mutex
 	comments []*models.Comment
+	tenants  []string // tenant each comment was created in
 	err      error
 }
 
@@ -103,6 +106,7 @@ func (m *MockCommentCreator) Create(ctx context.Context, comment *models.Comment
 	}
 	comment.ID = fmt.Sprintf("comment-%d", len(m.comments)+1)
 	m.comments = append(m.comments, comment)
+	m.tenants = append(m.tenants, tenant.FromContext(ctx))
 	return comment, nil
 }
 
@@ -112,6 +116,12 @@ func (m *MockCommentCreator) GetComments() []*models.Comment {
 	return m.comments
 }
 
+func (m *MockCommentCreator) GetTenants() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tenants
+}
+
 // MockFlagCreator implements FlagCreatorInterface for testing.
 type MockFlagCreator struct {
 	mu    sync.Mutex
@@ -233,6 +243,45 @@ func TestCreatePost_SetsPendingReview(t *testing.T) {
 	}
 }
 
+// The moderation comment is written after the request returns; it must still
+// land in the tenant the post was created in.
+func TestCreatePost_ModerationCommentInPostTenant(t *testing.T) {
+	repo := NewMockPostsRepository()
+	commentCreator := &MockCommentCreator{}
+	handler := NewPostsHandler(repo)
+	handler.SetContentModerationService(NewMockContentModerationService())
+	handler.SetPostStatusUpdater(NewMockPostStatusUpdater())
+	handler.SetCommentRepo(commentCreator)
+
+	body := `{
+		"type": "question",
+		"title": "How do I handle async operations in Go?",
+		"description": "I need help understanding how to properly handle async operations in Go with goroutines and channels for concurrent processing."
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = addAuthContext(req, "user-123", "user")
+	req = req.WithContext(tenant.WithID(req.Context(), "acme"))
+
+	rr := httptest.NewRecorder()
+	handler.Create(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(commentCreator.GetTenants()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	tenants := commentCreator.GetTenants()
+	if len(tenants) != 1 {
+		t.Fatalf("expected 1 moderation comment, got %d", len(tenants))
+	}
+	if tenants[0] != "acme" {
+		t.Errorf("moderation comment tenant = %q, want acme", tenants[0])
+	}
+}
+
 func TestCreatePost_NoModerationService(t *testing.T) {
 	repo := NewMockPostsRepository()
 	handler := NewPostsHandler(repo)
@@ -284,7 +333,7 @@ func TestModeratePostAsync_Approved(t *testing.T) {
 	handler.SetPostStatusUpdater(statusUpdater)
 	handler.SetCommentRepo(commentCreator)
 
-	handler.moderatePostAsync(testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
+	handler.moderatePostAsync("", testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
 
 	// Verify status was updated to open
 	status, ok := statusUpdater.GetStatus(testPostID)
@@ -332,7 +381,7 @@ func TestModeratePostAsync_Rejected(t *testing.T) {
 	handler.SetPostStatusUpdater(statusUpdater)
 	handler.SetCommentRepo(commentCreator)
 
-	handler.moderatePostAsync(testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
+	handler.moderatePostAsync("", testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
 
 	// Verify status was updated to rejected
 	status, ok := statusUpdater.GetStatus(testPostID)
@@ -366,6 +415,48 @@ func TestModeratePostAsync_Rejected(t *testing.T) {
 	}
 }
 
+// templateStore implements modtemplate.Store with an in-memory map keyed by "key/language".
+type templateStore map[string]string
+
+func (s templateStore) Find(ctx context.Context, key, language string) (*models.ModerationTemplate, error) {
+	body, ok := s[key+"/"+language]
+	if !ok {
+		return nil, modtemplate.ErrNotFound
+	}
+	return &models.ModerationTemplate{Key: key, Language: language, Body: body}, nil
+}
+
+func TestModeratePostAsync_RejectedUsesTemplate(t *testing.T) {
+	repo := NewMockPostsRepository()
+	statusUpdater := NewMockPostStatusUpdater()
+	commentCreator := &MockCommentCreator{}
+	modService := NewMockContentModerationService()
+	modService.SetResult(&ModerationResult{
+		Approved:         false,
+		Explanation:      "Looks like spam",
+		RejectionReasons: []string{"spam", "low_quality"},
+	})
+
+	handler := NewPostsHandler(repo)
+	handler.SetContentModerationService(modService)
+	handler.SetPostStatusUpdater(statusUpdater)
+	handler.SetCommentRepo(commentCreator)
+	handler.SetModerationCommentRenderer(modtemplate.NewRenderer(templateStore{
+		"rejected/en": "Rejected ({reasons}): {explanation}. Appeal: {appeal_link}",
+	}, "https://solvr.dev/posts/{post_id}/appeal"))
+
+	handler.moderatePostAsync("", testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
+
+	comments := commentCreator.GetComments()
+	if len(comments) != 1 {
+		t.Fatalf("expected 1 comment, got %d", len(comments))
+	}
+	want := "Rejected (spam, low_quality): Looks like spam. Appeal: https://solvr.dev/posts/" + testPostID + "/appeal"
+	if comments[0].Content != want {
+		t.Errorf("unexpected comment content:\ngot:  %q\nwant: %q", comments[0].Content, want)
+	}
+}
+
 func TestModeratePostAsync_RetryOnError(t *testing.T) {
 	repo := NewMockPostsRepository()
 	statusUpdater := NewMockPostStatusUpdater()
@@ -382,7 +473,7 @@ func TestModeratePostAsync_RetryOnError(t *testing.T) {
 	// Use short retry delays for testing
 	handler.SetRetryDelays([]time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond})
 
-	handler.moderatePostAsync(testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
+	handler.moderatePostAsync("", testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
 
 	// Should have been called twice (1 error + 1 success)
 	if calls := modService.GetCalls(); calls != 2 {
@@ -420,7 +511,7 @@ func TestModeratePostAsync_AllRetriesFail(t *testing.T) {
 	handler.SetFlagCreator(flagCreator)
 	handler.SetRetryDelays([]time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond})
 
-	handler.moderatePostAsync(testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
+	handler.moderatePostAsync("", testPostID, "Test Title Here", "Test description content", []string{"go"}, "question", "human", "user-123")
 
 	// Should have been called 3 times
 	if calls := modService.GetCalls(); calls != 3 {
@@ -464,7 +555,7 @@ func TestModeratePostAsync_RateLimitRetry(t *testing.T) {
 	handler.SetPostStatusUpdater(statusUpdater)
 	handler.SetRetryDelays([]time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond})
 
-	handler.moderatePostAsync(testPostID, "Test Title Here", "Test description", []string{"go"}, "question", "human", "user-123")
+	handler.moderatePostAsync("", testPostID, "Test Title Here", "Test description", []string{"go"}, "question", "human", "user-123")
 
 	// Rate limit retries do NOT count as attempts, so we should have exactly 2 calls
 	if calls := modService.GetCalls(); calls != 2 {
@@ -540,7 +631,7 @@ func TestModeratePostAsync_NotifiesOnApproval(t *testing.T) {
 	handler.SetCommentRepo(commentCreator)
 	handler.SetNotificationService(notifService)
 
-	handler.moderatePostAsync(testPostID, "Test Title", "Test description", []string{"go"}, "question", "human", "user-123")
+	handler.moderatePostAsync("", testPostID, "Test Title", "Test description", []string{"go"}, "question", "human", "user-123")
 
 	// Verify notification was sent
 	notifs := notifService.GetNotifications()
@@ -586,7 +677,7 @@ func TestModeratePostAsync_NotifiesOnRejection(t *testing.T) {
 	handler.SetCommentRepo(commentCreator)
 	handler.SetNotificationService(notifService)
 
-	handler.moderatePostAsync(testPostID, "Test Title", "Test description", []string{"go"}, "problem", "agent", "claude_bot")
+	handler.moderatePostAsync("", testPostID, "Test Title", "Test description", []string{"go"}, "problem", "agent", "claude_bot")
 
 	// Verify notification was sent
 	notifs := notifService.GetNotifications()
@@ -785,7 +876,7 @@ func TestModeratePostAsync_LanguageOnlyRejection_SetsDraft(t *testing.T) {
 	handler.SetPostStatusUpdater(statusUpdater)
 	handler.SetCommentRepo(commentCreator)
 
-	handler.moderatePostAsync(testPostID, "Título de teste", "Descrição de teste", []string{"go"}, "question", "human", "user-123")
+	handler.moderatePostAsync("", testPostID, "Título de teste", "Descrição de teste", []string{"go"}, "question", "human", "user-123")
 
 	// Status should be draft (not rejected)
 	status, ok := statusUpdater.GetStatus(testPostID)
@@ -829,7 +920,7 @@ type mockTranslationCall struct {
 	language string
 }
 
-func (m *mockTranslationTrigger) TranslateAndModerateAsync(postID, title, description string, tags []string, language, postType, authorType, authorID string) {
+func (m *mockTranslationTrigger) TranslateAndModerateAsync(ctx context.Context, postID, title, description string, tags []string, language, postType, authorType, authorID string) {
 	m.calls = append(m.calls, mockTranslationCall{postID: postID, language: language})
 }
 
@@ -853,7 +944,7 @@ func TestModeratePostAsync_LanguageOnlyRejection_TriggersInlineTranslation(t *te
 	handler.SetCommentRepo(commentCreator)
 	handler.SetTranslationTrigger(trigger)
 
-	handler.moderatePostAsync(testPostID, "中文标题", "中文描述", []string{"go"}, "problem", "agent", "agent-1")
+	handler.moderatePostAsync("", testPostID, "中文标题", "中文描述", []string{"go"}, "problem", "agent", "agent-1")
 
 	// Translation trigger should have been called exactly once
 	if len(trigger.calls) != 1 {
@@ -884,7 +975,7 @@ func TestModeratePostAsync_NoTranslationTrigger_StillWorks(t *testing.T) {
 	handler.SetPostStatusUpdater(statusUpdater)
 	// No translation trigger set
 
-	handler.moderatePostAsync(testPostID, "Título", "Descripción", []string{}, "question", "human", "user-1")
+	handler.moderatePostAsync("", testPostID, "Título", "Descripción", []string{}, "question", "human", "user-1")
 
 	// Status should still be draft
 	status, ok := statusUpdater.GetStatus(testPostID)
@@ -911,7 +1002,7 @@ func TestModeratePostAsync_ApprovedPost_NoTranslationTrigger(t *testing.T) {
 	handler.SetPostStatusUpdater(statusUpdater)
 	handler.SetTranslationTrigger(trigger)
 
-	handler.moderatePostAsync(testPostID, "English title", "English desc", []string{}, "question", "human", "user-1")
+	handler.moderatePostAsync("", testPostID, "English title", "English desc", []string{}, "question", "human", "user-1")
 
 	// Approved → no translation trigger
 	if len(trigger.calls) != 0 {
@@ -934,7 +1025,7 @@ func TestModeratePostAsync_MultipleReasonsNotDraft(t *testing.T) {
 	handler.SetContentModerationService(modService)
 	handler.SetPostStatusUpdater(statusUpdater)
 
-	handler.moderatePostAsync(testPostID, "Título de teste", "Descrição de teste", []string{"go"}, "question", "human", "user-123")
+	handler.moderatePostAsync("", testPostID, "Título de teste", "Descrição de teste", []string{"go"}, "question", "human", "user-123")
 
 	// Multiple reasons → regular rejection, not draft
 	status, ok := statusUpdater.GetStatus(testPostID)
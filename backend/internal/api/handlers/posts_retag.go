@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+	"github.com/go-chi/chi/v5"
+)
+
+// RetagPostRequest is the request body for PATCH /v1/posts/{id}/tags.
+type RetagPostRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// RetagPost handles PATCH /v1/posts/:id/tags - replace a post's tags. Authors can
+// always retag their own posts; anyone else needs the edit_tags privilege, which the
+// route's PrivilegeGate grants. Retagging never re-moderates the post.
+func (h *PostsHandler) RetagPost(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	postID := chi.URLParam(r, "id")
+	existingPost, err := h.repo.FindByIDFromPrimary(r.Context(), postID)
+	if err != nil {
+		if errors.Is(err, db.ErrPostNotFound) {
+			apierror.Write(w, apierror.NotFound, "post not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get post")
+		return
+	}
+
+	isOwner := existingPost.PostedByType == authInfo.AuthorType && existingPost.PostedByID == authInfo.AuthorID
+	if !isOwner && !middleware.PrivilegeGranted(r.Context(), reputation.PrivilegeEditTags) {
+		apierror.Write(w, apierror.Forbidden, "you can only retag your own posts")
+		return
+	}
+
+	var req RetagPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	var v Validator
+	if req.Tags == nil {
+		v.Add(FieldError{Field: "tags", Code: FieldRequired, Message: "tags is required"})
+	}
+	v.MaxItems("tags", len(req.Tags), models.MaxTagsPerPost)
+	req.Tags = validateTags(&v, req.Tags)
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	updatedPost := existingPost.Post
+	updatedPost.Tags = req.Tags
+	result, err := h.repo.Update(r.Context(), &updatedPost)
+	if errors.Is(err, db.ErrVersionConflict) {
+		if current, findErr := h.repo.FindByIDFromPrimary(r.Context(), postID); findErr == nil {
+			writePostConflict(w, current, etagViewer(r))
+			return
+		}
+	}
+	if err != nil {
+		h.logger.Error("failed to retag post", "postID", postID, "error", err)
+		apierror.Write(w, apierror.InternalError, "failed to update post")
+		return
+	}
+
+	h.setUpdatedPostETag(w, r, postID)
+	writePostsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": result,
+	})
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+	"github.com/go-chi/chi/v5"
+)
+
+type fixedReputation int
+
+func (f fixedReputation) GetReputation(ctx context.Context, authorType models.AuthorType, authorID string) (int, error) {
+	return int(f), nil
+}
+
+// retagThroughGate runs RetagPost behind the edit_tags PrivilegeGate, as routed.
+func retagThroughGate(handler *PostsHandler, callerID string, rep int, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/v1/posts/post-1/tags", bytes.NewReader([]byte(body)))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{UserID: callerID, Role: models.UserRoleUser}))
+
+	gate := middleware.NewPrivilegeGate(fixedReputation(rep), reputation.DefaultThresholds)
+	w := httptest.NewRecorder()
+	gate.Require(reputation.PrivilegeEditTags, nil)(http.HandlerFunc(handler.RetagPost)).ServeHTTP(w, req)
+	return w
+}
+
+func TestRetagPost_OthersPostWithPrivilege(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-1", "Someone else's problem", models.PostTypeProblem)
+	repo.SetPost(&post)
+	handler := NewPostsHandler(repo)
+
+	w := retagThroughGate(handler, "editor-1", reputation.DefaultEditTagsThreshold, `{"tags":["postgresql","docker"]}`)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if repo.updatedPost == nil || len(repo.updatedPost.Tags) != 2 || repo.updatedPost.Tags[0] != "postgresql" {
+		t.Fatalf("expected tags to be replaced, got %+v", repo.updatedPost)
+	}
+	if repo.updatedPost.Status != models.PostStatusOpen {
+		t.Errorf("retagging must not change status, got %s", repo.updatedPost.Status)
+	}
+}
+
+func TestRetagPost_OthersPostBelowThreshold(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-1", "Someone else's problem", models.PostTypeProblem)
+	repo.SetPost(&post)
+	handler := NewPostsHandler(repo)
+
+	w := retagThroughGate(handler, "editor-1", reputation.DefaultEditTagsThreshold-1, `{"tags":["postgresql"]}`)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", w.Code)
+	}
+	if repo.updatedPost != nil {
+		t.Error("expected post not to be updated")
+	}
+}
+
+func TestRetagPost_WithoutGateOnlyAuthor(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-1", "My problem", models.PostTypeProblem)
+	repo.SetPost(&post)
+	handler := NewPostsHandler(repo)
+
+	newRequest := func(callerID string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/v1/posts/post-1/tags", bytes.NewReader([]byte(`{"tags":["go"]}`)))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "post-1")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		return addAuthContext(req, callerID, "user")
+	}
+
+	w := httptest.NewRecorder()
+	handler.RetagPost(w, newRequest("someone-else"))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for non-author, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.RetagPost(w, newRequest("user-123"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 for author, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
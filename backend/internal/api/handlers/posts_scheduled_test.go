@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func scheduledPostBody(publishAt time.Time) string {
+	return fmt.Sprintf(`{
+		"type": "problem",
+		"title": "Postmortem: queue outage on 2026-10-01",
+		"description": "Timeline, root cause and follow-ups for the queue outage, to be published after the incident review meeting.",
+		"publish_at": %q
+	}`, publishAt.Format(time.RFC3339))
+}
+
+func TestCreatePost_ScheduledStaysDraft(t *testing.T) {
+	repo := NewMockPostsRepository()
+	modService := NewMockContentModerationService()
+	mockEmbed := &MockEmbeddingService{embedding: []float32{0.1, 0.2}}
+	handler := NewPostsHandler(repo)
+	handler.SetContentModerationService(modService)
+	handler.SetEmbeddingService(mockEmbed)
+
+	publishAt := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(scheduledPostBody(publishAt)))
+	req = addAuthContext(req, "user-123", "user")
+	rr := httptest.NewRecorder()
+	handler.Create(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if repo.createdPost == nil {
+		t.Fatal("expected post to be created")
+	}
+	if repo.createdPost.Status != models.PostStatusDraft {
+		t.Errorf("expected status %q, got %q", models.PostStatusDraft, repo.createdPost.Status)
+	}
+	if repo.createdPost.PublishAt == nil || !repo.createdPost.PublishAt.Equal(publishAt) {
+		t.Errorf("expected publish_at %v, got %v", publishAt, repo.createdPost.PublishAt)
+	}
+	if mockEmbed.callCount != 0 {
+		t.Errorf("expected embedding to wait for publish, got %d calls", mockEmbed.callCount)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if calls := modService.GetCalls(); calls != 0 {
+		t.Errorf("expected moderation to wait for publish, got %d calls", calls)
+	}
+}
+
+func TestCreatePost_InvalidPublishAt(t *testing.T) {
+	for name, publishAt := range map[string]time.Time{
+		"in the past":   time.Now().Add(-time.Hour),
+		"too far ahead": time.Now().Add(models.MaxPostPublishDelay + 24*time.Hour),
+	} {
+		t.Run(name, func(t *testing.T) {
+			repo := NewMockPostsRepository()
+			handler := NewPostsHandler(repo)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(scheduledPostBody(publishAt)))
+			req = addAuthContext(req, "user-123", "user")
+			rr := httptest.NewRecorder()
+			handler.Create(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), "publish_at") {
+				t.Errorf("expected publish_at field error, got %s", rr.Body.String())
+			}
+			if repo.createdPost != nil {
+				t.Error("expected no post to be created")
+			}
+		})
+	}
+}
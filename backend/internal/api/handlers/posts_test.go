@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,8 +12,11 @@ import (
 
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/db/memdb"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
+
+	"strings"
 )
 
 // MockPostsRepository implements PostsRepositoryInterface for testing.
@@ -54,10 +58,14 @@ func (m *MockPostsRepository) FindByID(ctx context.Context, id string) (*models.
 	return m.post, nil
 }
 
-func (m *MockPostsRepository) FindByIDForViewer(ctx context.Context, id string, viewerType models.AuthorType, viewerID string, callerHuman string) (*models.PostWithAuthor, error) {
+func (m *MockPostsRepository) FindByIDForViewer(ctx context.Context, id string) (*models.PostWithAuthor, error) {
 	return m.FindByID(ctx, id)
 }
 
+func (m *MockPostsRepository) FindByIDFromPrimary(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	return m.FindByIDForViewer(ctx, id)
+}
+
 func (m *MockPostsRepository) Create(ctx context.Context, post *models.Post) (*models.Post, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -75,6 +83,9 @@ func (m *MockPostsRepository) Update(ctx context.Context, post *models.Post) (*m
 	}
 	m.updatedPost = post
 	post.UpdatedAt = time.Now()
+	if m.post != nil && m.post.ID == post.ID {
+		m.post.Post = *post
+	}
 	return post, nil
 }
 
@@ -97,7 +108,11 @@ func (m *MockPostsRepository) Vote(ctx context.Context, postID, voterType, voter
 		VoterID:    voterID,
 		Direction:  direction,
 	}
-	// Also update userVote for GetUserVote
+	// Also update userVote for GetUserVote ("none" retracts)
+	if direction == db.VoteDirectionNone {
+		m.userVote = nil
+		return nil
+	}
 	m.userVote = &direction
 	return nil
 }
@@ -171,154 +186,141 @@ func addAuthContext(r *http.Request, userID, role string) *http.Request {
 	return r.WithContext(ctx)
 }
 
-// ============================================================================
-// GET /v1/posts - List Posts Tests
-// ============================================================================
-
-// TestListPosts_Success tests successful list of posts.
-func TestListPosts_Success(t *testing.T) {
-	repo := NewMockPostsRepository()
-	repo.SetPosts([]models.PostWithAuthor{
-		createTestPost("post-1", "First Post", models.PostTypeProblem),
-		createTestPost("post-2", "Second Post", models.PostTypeQuestion),
-	}, 2)
-
-	handler := NewPostsHandler(repo)
+// newPostsTestStore returns an in-memory store holding the given posts and their
+// author user-123 ("Test User").
+func newPostsTestStore(t *testing.T, posts ...models.PostWithAuthor) *memdb.Store {
+	t.Helper()
+	store := memdb.NewStore()
+	ctx := context.Background()
+	if _, err := store.Users().Create(ctx, &models.User{
+		ID: "user-123", Username: "testuser", Email: "test@example.com", DisplayName: "Test User",
+	}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	for _, p := range posts {
+		if _, err := store.Posts().Create(ctx, &p.Post); err != nil {
+			t.Fatalf("create post: %v", err)
+		}
+	}
+	return store
+}
 
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+// listPosts calls GET /v1/posts with the given query and decodes the response.
+func listPosts(t *testing.T, handler *PostsHandler, query string, userID string) PostsListResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts"+query, nil)
+	if userID != "" {
+		req = addAuthContext(req, userID, "user")
+	}
 	w := httptest.NewRecorder()
 
 	handler.List(w, req)
 
 	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d", w.Code)
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
 	}
-
-	var resp map[string]interface{}
+	var resp PostsListResponse
 	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
+	return resp
+}
 
-	data, ok := resp["data"].([]interface{})
-	if !ok {
-		t.Fatal("expected data array in response")
-	}
+// ============================================================================
+// GET /v1/posts - List Posts Tests
+// ============================================================================
+
+// TestListPosts_Success tests successful list of posts.
+func TestListPosts_Success(t *testing.T) {
+	store := newPostsTestStore(t,
+		createTestPost("post-1", "First Post", models.PostTypeProblem),
+		createTestPost("post-2", "Second Post", models.PostTypeQuestion),
+	)
+	handler := NewPostsHandler(store.Posts())
 
-	if len(data) != 2 {
-		t.Errorf("expected 2 posts, got %d", len(data))
+	resp := listPosts(t, handler, "", "")
+
+	if len(resp.Data) != 2 {
+		t.Errorf("expected 2 posts, got %d", len(resp.Data))
 	}
 }
 
 // TestListPosts_FilterByType tests filtering by type.
 func TestListPosts_FilterByType(t *testing.T) {
-	repo := NewMockPostsRepository()
-	repo.SetPosts([]models.PostWithAuthor{}, 0)
-
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts?type=problem", nil)
-	w := httptest.NewRecorder()
+	store := newPostsTestStore(t,
+		createTestPost("post-1", "A Problem", models.PostTypeProblem),
+		createTestPost("post-2", "A Question", models.PostTypeQuestion),
+	)
+	handler := NewPostsHandler(store.Posts())
 
-	handler.List(w, req)
-
-	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d", w.Code)
-	}
+	resp := listPosts(t, handler, "?type=problem", "")
 
-	if repo.listOpts.Type != models.PostTypeProblem {
-		t.Errorf("expected type filter 'problem', got '%s'", repo.listOpts.Type)
+	if len(resp.Data) != 1 || resp.Data[0].Type != models.PostTypeProblem {
+		t.Errorf("expected only the problem, got %+v", resp.Data)
 	}
 }
 
 // TestListPosts_FilterByStatus tests filtering by status.
 func TestListPosts_FilterByStatus(t *testing.T) {
-	repo := NewMockPostsRepository()
-	repo.SetPosts([]models.PostWithAuthor{}, 0)
-
-	handler := NewPostsHandler(repo)
+	solved := createTestPost("post-2", "A Solved Problem", models.PostTypeProblem)
+	solved.Status = models.PostStatusSolved
+	store := newPostsTestStore(t, createTestPost("post-1", "An Open Problem", models.PostTypeProblem), solved)
+	handler := NewPostsHandler(store.Posts())
 
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts?status=open", nil)
-	w := httptest.NewRecorder()
+	resp := listPosts(t, handler, "?status=open", "")
 
-	handler.List(w, req)
-
-	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d", w.Code)
-	}
-
-	if repo.listOpts.Status != models.PostStatusOpen {
-		t.Errorf("expected status filter 'open', got '%s'", repo.listOpts.Status)
+	if len(resp.Data) != 1 || resp.Data[0].ID != "post-1" {
+		t.Errorf("expected only the open post, got %+v", resp.Data)
 	}
 }
 
 // TestListPosts_FilterByTags tests filtering by tags.
 func TestListPosts_FilterByTags(t *testing.T) {
-	repo := NewMockPostsRepository()
-	repo.SetPosts([]models.PostWithAuthor{}, 0)
-
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts?tags=go,postgresql", nil)
-	w := httptest.NewRecorder()
-
-	handler.List(w, req)
-
-	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d", w.Code)
+	tagged := func(id string, tags ...string) models.PostWithAuthor {
+		post := createTestPost(id, "Tagged Post "+id, models.PostTypeQuestion)
+		post.Tags = tags
+		return post
 	}
+	store := newPostsTestStore(t, tagged("post-1", "go"), tagged("post-2", "postgresql"), tagged("post-3", "rust"))
+	handler := NewPostsHandler(store.Posts())
 
-	if len(repo.listOpts.Tags) != 2 {
-		t.Errorf("expected 2 tags, got %d", len(repo.listOpts.Tags))
-	}
+	resp := listPosts(t, handler, "?tags=go,postgresql", "")
 
-	if repo.listOpts.Tags[0] != "go" || repo.listOpts.Tags[1] != "postgresql" {
-		t.Errorf("expected tags [go, postgresql], got %v", repo.listOpts.Tags)
+	if len(resp.Data) != 2 {
+		t.Fatalf("expected 2 posts tagged go or postgresql, got %d", len(resp.Data))
+	}
+	for _, post := range resp.Data {
+		if post.ID == "post-3" {
+			t.Errorf("expected the rust post to be filtered out")
+		}
 	}
 }
 
 // TestListPosts_Pagination tests pagination parameters.
 func TestListPosts_Pagination(t *testing.T) {
-	repo := NewMockPostsRepository()
-	repo.SetPosts([]models.PostWithAuthor{}, 100)
-
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts?page=2&per_page=10", nil)
-	w := httptest.NewRecorder()
-
-	handler.List(w, req)
-
-	if w.Code != http.StatusOK {
-		t.Errorf("expected status 200, got %d", w.Code)
+	var posts []models.PostWithAuthor
+	for i := range 25 {
+		posts = append(posts, createTestPost(fmt.Sprintf("post-%d", i), fmt.Sprintf("Post number %d", i), models.PostTypeProblem))
 	}
+	handler := NewPostsHandler(newPostsTestStore(t, posts...).Posts())
 
-	if repo.listOpts.Page != 2 {
-		t.Errorf("expected page 2, got %d", repo.listOpts.Page)
-	}
+	resp := listPosts(t, handler, "?page=2&per_page=10", "")
 
-	if repo.listOpts.PerPage != 10 {
-		t.Errorf("expected per_page 10, got %d", repo.listOpts.PerPage)
+	if len(resp.Data) != 10 {
+		t.Errorf("expected 10 posts on page 2, got %d", len(resp.Data))
 	}
-
-	var resp map[string]interface{}
-	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
+	if resp.Meta.Page != 2 || resp.Meta.PerPage != 10 {
+		t.Errorf("expected page 2 per_page 10, got %+v", resp.Meta)
 	}
-
-	meta := resp["meta"].(map[string]interface{})
-	if meta["total"].(float64) != 100 {
-		t.Errorf("expected total 100, got %v", meta["total"])
+	if resp.Meta.Total != 25 {
+		t.Errorf("expected total 25, got %d", resp.Meta.Total)
 	}
 }
 
-// TestListPosts_PerPageMax tests per_page capped at 50.
 // TestListPosts_PerPageMax tests that per_page > 50 returns 400.
 // FIX-029: Changed from silently capping to returning error.
 func TestListPosts_PerPageMax(t *testing.T) {
-	repo := NewMockPostsRepository()
-	repo.SetPosts([]models.PostWithAuthor{}, 0)
-
-	handler := NewPostsHandler(repo)
+	handler := NewPostsHandler(newPostsTestStore(t).Posts())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts?per_page=100", nil)
 	w := httptest.NewRecorder()
@@ -333,13 +335,20 @@ func TestListPosts_PerPageMax(t *testing.T) {
 
 // TestListPosts_IncludesResponseCounts verifies answers_count and approaches_count in List response.
 func TestListPosts_IncludesResponseCounts(t *testing.T) {
-	repo := NewMockPostsRepository()
-	post := createTestPost("post-1", "Test Post", models.PostTypeProblem)
-	post.AnswersCount = 5
-	post.ApproachesCount = 3
-	repo.SetPosts([]models.PostWithAuthor{post}, 1)
-
-	handler := NewPostsHandler(repo)
+	store := newPostsTestStore(t,
+		createTestPost("question-1", "A Question", models.PostTypeQuestion),
+		createTestPost("problem-1", "A Problem", models.PostTypeProblem),
+	)
+	ctx := context.Background()
+	for range 2 {
+		if _, err := store.Answers().CreateAnswer(ctx, &models.Answer{QuestionID: "question-1", AuthorType: models.AuthorTypeHuman, AuthorID: "user-456", Content: "An answer."}); err != nil {
+			t.Fatalf("create answer: %v", err)
+		}
+	}
+	if _, err := store.Approaches().CreateApproach(ctx, &models.Approach{ProblemID: "problem-1", AuthorType: models.AuthorTypeHuman, AuthorID: "user-456", Angle: "An angle"}); err != nil {
+		t.Fatalf("create approach: %v", err)
+	}
+	handler := NewPostsHandler(store.Posts())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
 	w := httptest.NewRecorder()
@@ -356,26 +365,20 @@ func TestListPosts_IncludesResponseCounts(t *testing.T) {
 	}
 
 	data, ok := resp["data"].([]interface{})
-	if !ok || len(data) == 0 {
-		t.Fatal("expected non-empty data array in response")
+	if !ok || len(data) != 2 {
+		t.Fatalf("expected 2 posts in response, got %v", resp["data"])
 	}
 
-	first := data[0].(map[string]interface{})
-
-	answersCount, ok := first["answers_count"]
-	if !ok {
-		t.Fatal("expected answers_count field in response")
-	}
-	if int(answersCount.(float64)) != 5 {
-		t.Errorf("expected answers_count=5, got %v", answersCount)
-	}
-
-	approachesCount, ok := first["approaches_count"]
-	if !ok {
-		t.Fatal("expected approaches_count field in response")
-	}
-	if int(approachesCount.(float64)) != 3 {
-		t.Errorf("expected approaches_count=3, got %v", approachesCount)
+	want := map[string][2]float64{"question-1": {2, 0}, "problem-1": {0, 1}}
+	for _, item := range data {
+		post := item.(map[string]interface{})
+		counts := want[post["id"].(string)]
+		if post["answers_count"] != counts[0] {
+			t.Errorf("%s: expected answers_count=%v, got %v", post["id"], counts[0], post["answers_count"])
+		}
+		if post["approaches_count"] != counts[1] {
+			t.Errorf("%s: expected approaches_count=%v, got %v", post["id"], counts[1], post["approaches_count"])
+		}
 	}
 }
 
@@ -383,21 +386,32 @@ func TestListPosts_IncludesResponseCounts(t *testing.T) {
 // GET /v1/posts/:id - Get Single Post Tests
 // ============================================================================
 
-// TestGetPost_Success tests successful retrieval of a post.
-func TestGetPost_Success(t *testing.T) {
-	repo := NewMockPostsRepository()
-	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
-	repo.SetPost(&post)
-
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-123", nil)
+// getPost calls GET /v1/posts/{id} as userID ("" = anonymous).
+func getPost(handler *PostsHandler, id, userID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/"+id, nil)
 	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
+	rctx.URLParams.Add("id", id)
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	if userID != "" {
+		req = addAuthContext(req, userID, "user")
+	}
 	w := httptest.NewRecorder()
-
 	handler.Get(w, req)
+	return w
+}
+
+// TestGetPost_Success tests successful retrieval of a post.
+func TestGetPost_Success(t *testing.T) {
+	store := newPostsTestStore(t, createTestPost("post-123", "Test Post", models.PostTypeProblem))
+	ctx := context.Background()
+	for voter, direction := range map[string]string{"voter-1": "up", "voter-2": "up", "voter-3": "down"} {
+		if err := store.Posts().Vote(ctx, "post-123", "human", voter, direction); err != nil {
+			t.Fatalf("Vote: %v", err)
+		}
+	}
+	handler := NewPostsHandler(store.Posts())
+
+	w := getPost(handler, "post-123", "")
 
 	if w.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d", w.Code)
@@ -413,25 +427,16 @@ func TestGetPost_Success(t *testing.T) {
 		t.Errorf("expected post id 'post-123', got %v", data["id"])
 	}
 
-	if data["vote_score"].(float64) != 8 {
-		t.Errorf("expected vote_score 8, got %v", data["vote_score"])
+	if data["vote_score"].(float64) != 1 {
+		t.Errorf("expected vote_score 1, got %v", data["vote_score"])
 	}
 }
 
 // TestGetPost_NotFound tests 404 for non-existent post.
 func TestGetPost_NotFound(t *testing.T) {
-	repo := NewMockPostsRepository()
-	repo.SetPost(nil) // No post found
-
-	handler := NewPostsHandler(repo)
+	handler := NewPostsHandler(newPostsTestStore(t).Posts())
 
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts/nonexistent", nil)
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "nonexistent")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	w := httptest.NewRecorder()
-
-	handler.Get(w, req)
+	w := getPost(handler, "nonexistent", "")
 
 	if w.Code != http.StatusNotFound {
 		t.Errorf("expected status 404, got %d", w.Code)
@@ -450,21 +455,13 @@ func TestGetPost_NotFound(t *testing.T) {
 
 // TestGetPost_Deleted tests 404 for deleted post.
 func TestGetPost_Deleted(t *testing.T) {
-	repo := NewMockPostsRepository()
-	now := time.Now()
-	post := createTestPost("post-123", "Deleted Post", models.PostTypeProblem)
-	post.DeletedAt = &now
-	repo.SetPost(&post)
-
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-123", nil)
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	w := httptest.NewRecorder()
+	store := newPostsTestStore(t, createTestPost("post-123", "Deleted Post", models.PostTypeProblem))
+	if err := store.Posts().Delete(context.Background(), "post-123"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	handler := NewPostsHandler(store.Posts())
 
-	handler.Get(w, req)
+	w := getPost(handler, "post-123", "")
 
 	if w.Code != http.StatusNotFound {
 		t.Errorf("expected status 404, got %d", w.Code)
@@ -473,19 +470,10 @@ func TestGetPost_Deleted(t *testing.T) {
 
 // TestGetPost_IncludesAuthorInfo tests that author info is included.
 func TestGetPost_IncludesAuthorInfo(t *testing.T) {
-	repo := NewMockPostsRepository()
-	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
-	repo.SetPost(&post)
+	store := newPostsTestStore(t, createTestPost("post-123", "Test Post", models.PostTypeProblem))
+	handler := NewPostsHandler(store.Posts())
 
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-123", nil)
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	w := httptest.NewRecorder()
-
-	handler.Get(w, req)
+	w := getPost(handler, "post-123", "")
 
 	if w.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d", w.Code)
@@ -512,48 +500,93 @@ func TestGetPost_IncludesAuthorInfo(t *testing.T) {
 // DELETE /v1/posts/:id - Delete Post Tests
 // ============================================================================
 
-// TestDeletePost_OwnerCanDelete tests owner can delete their post.
-func TestDeletePost_OwnerCanDelete(t *testing.T) {
-	repo := NewMockPostsRepository()
-	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
-	repo.SetPost(&post)
-
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodDelete, "/v1/posts/post-123", nil)
+// deletePost calls DELETE /v1/posts/{id} as userID with role ("" = anonymous).
+func deletePost(handler *PostsHandler, id, userID, role string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodDelete, "/v1/posts/"+id, nil)
 	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
+	rctx.URLParams.Add("id", id)
 	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	req = addAuthContext(req, "user-123", "user") // Same as post owner
+	if userID != "" {
+		req = addAuthContext(req, userID, role)
+	}
 	w := httptest.NewRecorder()
-
 	handler.Delete(w, req)
+	return w
+}
+
+// TestDeletePost_OwnerCanDelete tests owner can delete their post.
+func TestDeletePost_OwnerCanDelete(t *testing.T) {
+	store := newPostsTestStore(t, createTestPost("post-123", "Test Post", models.PostTypeProblem))
+	handler := NewPostsHandler(store.Posts())
+
+	w := deletePost(handler, "post-123", "user-123", "user") // Same as post owner
 
 	if w.Code != http.StatusNoContent {
 		t.Errorf("expected status 204, got %d", w.Code)
 	}
 
-	if repo.deletedID != "post-123" {
-		t.Errorf("expected deletedID 'post-123', got '%s'", repo.deletedID)
+	if _, err := store.Posts().FindByID(context.Background(), "post-123"); !errors.Is(err, db.ErrPostNotFound) {
+		t.Errorf("expected post-123 to be deleted, got err %v", err)
 	}
 }
 
-// TestDeletePost_AdminCanDelete tests admin can delete any post.
-func TestDeletePost_AdminCanDelete(t *testing.T) {
-	repo := NewMockPostsRepository()
-	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
-	repo.SetPost(&post)
+// laggingReplicaPostsRepo serves replica reads that have not yet seen the post, so
+// only primary reads find it.
+type laggingReplicaPostsRepo struct {
+	*memdb.PostRepository
+}
 
-	handler := NewPostsHandler(repo)
+func (m laggingReplicaPostsRepo) FindByID(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	return nil, db.ErrPostNotFound
+}
 
-	req := httptest.NewRequest(http.MethodDelete, "/v1/posts/post-123", nil)
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	req = addAuthContext(req, "admin-user", "admin") // Admin, different from owner
-	w := httptest.NewRecorder()
+func (m laggingReplicaPostsRepo) FindByIDForViewer(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	return nil, db.ErrPostNotFound
+}
 
-	handler.Delete(w, req)
+// TestPostWrites_ReadFromPrimary tests update, delete and vote load the post from
+// the primary, not the replica.
+func TestPostWrites_ReadFromPrimary(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		user   string
+		call   func(*PostsHandler) http.HandlerFunc
+		want   int
+	}{
+		{"update", http.MethodPatch, "/v1/posts/post-123", `{"title":"An updated title for the post"}`, "user-123", func(h *PostsHandler) http.HandlerFunc { return h.Update }, http.StatusOK},
+		{"vote", http.MethodPost, "/v1/posts/post-123/vote", `{"direction":"up"}`, "user-456", func(h *PostsHandler) http.HandlerFunc { return h.Vote }, http.StatusOK},
+		{"delete", http.MethodDelete, "/v1/posts/post-123", "", "user-123", func(h *PostsHandler) http.HandlerFunc { return h.Delete }, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newPostsTestStore(t, createTestPost("post-123", "Test Post", models.PostTypeProblem))
+			handler := NewPostsHandler(laggingReplicaPostsRepo{store.Posts()})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "post-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = addAuthContext(req, tt.user, "user")
+			w := httptest.NewRecorder()
+
+			tt.call(handler)(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestDeletePost_AdminCanDelete tests admin can delete any post.
+func TestDeletePost_AdminCanDelete(t *testing.T) {
+	store := newPostsTestStore(t, createTestPost("post-123", "Test Post", models.PostTypeProblem))
+	handler := NewPostsHandler(store.Posts())
+
+	w := deletePost(handler, "post-123", "admin-user", "admin") // Admin, different from owner
 
 	if w.Code != http.StatusNoContent {
 		t.Errorf("expected status 204, got %d", w.Code)
@@ -562,39 +595,24 @@ func TestDeletePost_AdminCanDelete(t *testing.T) {
 
 // TestDeletePost_OthersForbidden tests non-owner non-admin gets 403.
 func TestDeletePost_OthersForbidden(t *testing.T) {
-	repo := NewMockPostsRepository()
-	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
-	repo.SetPost(&post)
-
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodDelete, "/v1/posts/post-123", nil)
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	req = addAuthContext(req, "other-user", "user") // Different user, not admin
-	w := httptest.NewRecorder()
+	store := newPostsTestStore(t, createTestPost("post-123", "Test Post", models.PostTypeProblem))
+	handler := NewPostsHandler(store.Posts())
 
-	handler.Delete(w, req)
+	w := deletePost(handler, "post-123", "other-user", "user") // Different user, not admin
 
 	if w.Code != http.StatusForbidden {
 		t.Errorf("expected status 403, got %d", w.Code)
 	}
+	if _, err := store.Posts().FindByID(context.Background(), "post-123"); err != nil {
+		t.Errorf("expected post-123 to be kept: %v", err)
+	}
 }
 
 // TestDeletePost_NoAuth tests 401 when not authenticated.
 func TestDeletePost_NoAuth(t *testing.T) {
-	repo := NewMockPostsRepository()
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodDelete, "/v1/posts/post-123", nil)
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	// No auth context
-	w := httptest.NewRecorder()
+	handler := NewPostsHandler(newPostsTestStore(t).Posts())
 
-	handler.Delete(w, req)
+	w := deletePost(handler, "post-123", "", "") // No auth context
 
 	if w.Code != http.StatusUnauthorized {
 		t.Errorf("expected status 401, got %d", w.Code)
@@ -607,8 +625,7 @@ func TestDeletePost_NoAuth(t *testing.T) {
 
 // TestListPosts_NegativePage tests that page=-1 returns 400 BAD_REQUEST.
 func TestListPosts_NegativePage(t *testing.T) {
-	repo := NewMockPostsRepository()
-	handler := NewPostsHandler(repo)
+	handler := NewPostsHandler(newPostsTestStore(t).Posts())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts?page=-1", nil)
 	w := httptest.NewRecorder()
@@ -634,8 +651,7 @@ func TestListPosts_NegativePage(t *testing.T) {
 
 // TestListPosts_ZeroPage tests that page=0 returns 400 BAD_REQUEST.
 func TestListPosts_ZeroPage(t *testing.T) {
-	repo := NewMockPostsRepository()
-	handler := NewPostsHandler(repo)
+	handler := NewPostsHandler(newPostsTestStore(t).Posts())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts?page=0", nil)
 	w := httptest.NewRecorder()
@@ -649,8 +665,7 @@ func TestListPosts_ZeroPage(t *testing.T) {
 
 // TestListPosts_NegativePerPage tests that per_page=-1 returns 400 BAD_REQUEST.
 func TestListPosts_NegativePerPage(t *testing.T) {
-	repo := NewMockPostsRepository()
-	handler := NewPostsHandler(repo)
+	handler := NewPostsHandler(newPostsTestStore(t).Posts())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts?per_page=-1", nil)
 	w := httptest.NewRecorder()
@@ -664,8 +679,7 @@ func TestListPosts_NegativePerPage(t *testing.T) {
 
 // TestListPosts_ZeroPerPage tests that per_page=0 returns 400 BAD_REQUEST.
 func TestListPosts_ZeroPerPage(t *testing.T) {
-	repo := NewMockPostsRepository()
-	handler := NewPostsHandler(repo)
+	handler := NewPostsHandler(newPostsTestStore(t).Posts())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts?per_page=0", nil)
 	w := httptest.NewRecorder()
@@ -679,8 +693,7 @@ func TestListPosts_ZeroPerPage(t *testing.T) {
 
 // TestListPosts_PerPageTooLarge tests that per_page>50 returns 400 BAD_REQUEST.
 func TestListPosts_PerPageTooLarge(t *testing.T) {
-	repo := NewMockPostsRepository()
-	handler := NewPostsHandler(repo)
+	handler := NewPostsHandler(newPostsTestStore(t).Posts())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts?per_page=100", nil)
 	w := httptest.NewRecorder()
@@ -692,64 +705,61 @@ func TestListPosts_PerPageTooLarge(t *testing.T) {
 	}
 }
 
+// TestListPosts_ValidPagination tests that valid page and per_page are accepted.
+func TestListPosts_ValidPagination(t *testing.T) {
+	var posts []models.PostWithAuthor
+	for i := range 30 {
+		posts = append(posts, createTestPost(fmt.Sprintf("post-%d", i), fmt.Sprintf("Post number %d", i), models.PostTypeQuestion))
+	}
+	handler := NewPostsHandler(newPostsTestStore(t, posts...).Posts())
+
+	resp := listPosts(t, handler, "?page=2&per_page=25", "")
+
+	if resp.Meta.Page != 2 || resp.Meta.PerPage != 25 {
+		t.Errorf("expected page 2 per_page 25, got %+v", resp.Meta)
+	}
+	if len(resp.Data) != 5 {
+		t.Errorf("expected the last 5 posts on page 2, got %d", len(resp.Data))
+	}
+}
+
 // ============================================================================
 // user_vote viewer info tests
 // ============================================================================
 
+// newVotedPostsTestStore returns a store holding post-1, upvoted by
+// viewer-user-1 and downvoted by viewer-user-2.
+func newVotedPostsTestStore(t *testing.T) *memdb.Store {
+	t.Helper()
+	store := newPostsTestStore(t, createTestPost("post-1", "Test Post", models.PostTypeProblem))
+	ctx := context.Background()
+	for voter, direction := range map[string]string{"viewer-user-1": "up", "viewer-user-2": "down"} {
+		if err := store.Posts().Vote(ctx, "post-1", "human", voter, direction); err != nil {
+			t.Fatalf("Vote: %v", err)
+		}
+	}
+	return store
+}
+
 // TestListPosts_IncludesUserVote_Authenticated tests that authenticated requests
-// pass viewer info to the repo and user_vote is included in response.
+// get their own vote as user_vote.
 func TestListPosts_IncludesUserVote_Authenticated(t *testing.T) {
-	repo := NewMockPostsRepository()
-	upVote := "up"
-	post := createTestPost("post-1", "Test Post", models.PostTypeProblem)
-	post.UserVote = &upVote
-	repo.SetPosts([]models.PostWithAuthor{post}, 1)
+	handler := NewPostsHandler(newVotedPostsTestStore(t).Posts())
 
-	handler := NewPostsHandler(repo)
+	resp := listPosts(t, handler, "", "viewer-user-1")
 
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
-	req = addAuthContext(req, "viewer-user-1", "user")
-	w := httptest.NewRecorder()
-
-	handler.List(w, req)
-
-	if w.Code != http.StatusOK {
-		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	if len(resp.Data) != 1 {
+		t.Fatalf("expected 1 post, got %d", len(resp.Data))
 	}
-
-	// Verify repo received viewer info in list options
-	if repo.listOpts.ViewerType != models.AuthorTypeHuman {
-		t.Errorf("expected ViewerType 'human', got '%s'", repo.listOpts.ViewerType)
-	}
-	if repo.listOpts.ViewerID != "viewer-user-1" {
-		t.Errorf("expected ViewerID 'viewer-user-1', got '%s'", repo.listOpts.ViewerID)
-	}
-
-	// Verify user_vote appears in response JSON
-	var resp map[string]interface{}
-	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
-		t.Fatalf("failed to decode response: %v", err)
-	}
-	data := resp["data"].([]interface{})
-	first := data[0].(map[string]interface{})
-	userVote, ok := first["user_vote"]
-	if !ok {
-		t.Fatal("expected user_vote field in response when authenticated")
-	}
-	if userVote != "up" {
-		t.Errorf("expected user_vote 'up', got '%v'", userVote)
+	if got := resp.Data[0].UserVote; got == nil || *got != "up" {
+		t.Errorf("expected user_vote 'up', got %v", got)
 	}
 }
 
-// TestListPosts_NoUserVote_Anonymous tests that anonymous requests
-// do NOT pass viewer info and user_vote is omitted from response.
+// TestListPosts_NoUserVote_Anonymous tests that user_vote is null in the
+// response for anonymous requests.
 func TestListPosts_NoUserVote_Anonymous(t *testing.T) {
-	repo := NewMockPostsRepository()
-	post := createTestPost("post-1", "Test Post", models.PostTypeProblem)
-	// UserVote is nil (anonymous)
-	repo.SetPosts([]models.PostWithAuthor{post}, 1)
-
-	handler := NewPostsHandler(repo)
+	handler := NewPostsHandler(newVotedPostsTestStore(t).Posts())
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
 	// No auth context
@@ -761,14 +771,6 @@ func TestListPosts_NoUserVote_Anonymous(t *testing.T) {
 		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
 	}
 
-	// Verify repo did NOT receive viewer info
-	if repo.listOpts.ViewerType != "" {
-		t.Errorf("expected empty ViewerType for anonymous, got '%s'", repo.listOpts.ViewerType)
-	}
-	if repo.listOpts.ViewerID != "" {
-		t.Errorf("expected empty ViewerID for anonymous, got '%s'", repo.listOpts.ViewerID)
-	}
-
 	// Verify user_vote is present and null (anonymous = no vote, field always serialized)
 	var resp map[string]interface{}
 	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
@@ -785,41 +787,16 @@ func TestListPosts_NoUserVote_Anonymous(t *testing.T) {
 }
 
 // TestGetPost_IncludesUserVote_Authenticated tests that authenticated GET /v1/posts/:id
-// uses FindByIDForViewer and includes user_vote in response.
+// includes the caller's own vote as user_vote.
 func TestGetPost_IncludesUserVote_Authenticated(t *testing.T) {
-	repo := &MockPostsRepoWithViewerTracking{}
-	downVote := "down"
-	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
-	post.UserVote = &downVote
-	repo.post = &post
+	handler := NewPostsHandler(newVotedPostsTestStore(t).Posts())
 
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-123", nil)
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	req = addAuthContext(req, "viewer-user-2", "user")
-	w := httptest.NewRecorder()
-
-	handler.Get(w, req)
+	w := getPost(handler, "post-1", "viewer-user-2")
 
 	if w.Code != http.StatusOK {
 		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
 	}
 
-	// Verify FindByIDForViewer was called (not FindByID)
-	if !repo.findByIDForViewerCalled {
-		t.Error("expected FindByIDForViewer to be called for authenticated request")
-	}
-	if repo.calledViewerType != models.AuthorTypeHuman {
-		t.Errorf("expected viewer type 'human', got '%s'", repo.calledViewerType)
-	}
-	if repo.calledViewerID != "viewer-user-2" {
-		t.Errorf("expected viewer ID 'viewer-user-2', got '%s'", repo.calledViewerID)
-	}
-
-	// Verify user_vote in response
 	var resp map[string]interface{}
 	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
@@ -834,85 +811,23 @@ func TestGetPost_IncludesUserVote_Authenticated(t *testing.T) {
 	}
 }
 
-// TestGetPost_Anonymous_UsesFindByID tests that anonymous GET /v1/posts/:id
-// uses FindByID (not FindByIDForViewer) and omits user_vote.
-func TestGetPost_Anonymous_UsesFindByID(t *testing.T) {
-	repo := &MockPostsRepoWithViewerTracking{}
-	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
-	repo.post = &post
+// TestGetPost_Anonymous_NoViewer tests that anonymous GET /v1/posts/:id
+// reports no user_vote.
+func TestGetPost_Anonymous_NoViewer(t *testing.T) {
+	handler := NewPostsHandler(newVotedPostsTestStore(t).Posts())
 
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-123", nil)
-	rctx := chi.NewRouteContext()
-	rctx.URLParams.Add("id", "post-123")
-	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
-	// No auth context
-	w := httptest.NewRecorder()
-
-	handler.Get(w, req)
+	w := getPost(handler, "post-1", "")
 
 	if w.Code != http.StatusOK {
 		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
 	}
 
-	// Verify FindByID was called (not FindByIDForViewer)
-	if repo.findByIDForViewerCalled {
-		t.Error("expected FindByID to be called for anonymous request, but FindByIDForViewer was called")
-	}
-	if !repo.findByIDCalled {
-		t.Error("expected FindByID to be called for anonymous request")
-	}
-}
-
-// MockPostsRepoWithViewerTracking tracks which Find method is called.
-type MockPostsRepoWithViewerTracking struct {
-	MockPostsRepository
-	findByIDCalled          bool
-	findByIDForViewerCalled bool
-	calledViewerType        models.AuthorType
-	calledViewerID          string
-}
-
-func (m *MockPostsRepoWithViewerTracking) FindByID(ctx context.Context, id string) (*models.PostWithAuthor, error) {
-	m.findByIDCalled = true
-	if m.post == nil {
-		return nil, db.ErrPostNotFound
-	}
-	return m.post, nil
-}
-
-func (m *MockPostsRepoWithViewerTracking) FindByIDForViewer(ctx context.Context, id string, viewerType models.AuthorType, viewerID string, callerHuman string) (*models.PostWithAuthor, error) {
-	m.findByIDForViewerCalled = true
-	m.calledViewerType = viewerType
-	m.calledViewerID = viewerID
-	if m.post == nil {
-		return nil, db.ErrPostNotFound
-	}
-	return m.post, nil
-}
-
-// TestListPosts_ValidPagination tests that valid page and per_page are accepted.
-func TestListPosts_ValidPagination(t *testing.T) {
-	repo := NewMockPostsRepository()
-	repo.total = 0
-	repo.posts = []models.PostWithAuthor{}
-	handler := NewPostsHandler(repo)
-
-	req := httptest.NewRequest(http.MethodGet, "/v1/posts?page=2&per_page=25", nil)
-	w := httptest.NewRecorder()
-
-	handler.List(w, req)
-
-	if w.Code != http.StatusOK {
-		t.Errorf("FIX-029: expected status 200 for valid pagination, got %d: %s", w.Code, w.Body.String())
-	}
-
-	// Verify the params were passed correctly to repo
-	if repo.listOpts.Page != 2 {
-		t.Errorf("expected page 2, got %d", repo.listOpts.Page)
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
 	}
-	if repo.listOpts.PerPage != 25 {
-		t.Errorf("expected per_page 25, got %d", repo.listOpts.PerPage)
+	data := resp["data"].(map[string]interface{})
+	if data["user_vote"] != nil {
+		t.Errorf("expected user_vote to be null for anonymous request, got %v", data["user_vote"])
 	}
 }
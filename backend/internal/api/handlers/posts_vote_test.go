@@ -403,3 +403,116 @@ func TestGetMyVote_PostNotFound(t *testing.T) {
 		t.Errorf("expected status 404, got %d", w.Code)
 	}
 }
+
+// ============================================================================
+// DELETE /v1/posts/:id/vote - Vote Retraction Tests
+// ============================================================================
+
+// TestRetractVote_RemovesVote tests that DELETE clears the caller's vote.
+func TestRetractVote_RemovesVote(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
+	post.PostedByID = "other-user"
+	repo.SetPost(&post)
+	up := "up"
+	repo.userVote = &up
+
+	handler := NewPostsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/posts/post-123/vote", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.RetractVote(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.vote == nil || repo.vote.Direction != db.VoteDirectionNone {
+		t.Errorf("expected repo.Vote called with direction 'none', got %+v", repo.vote)
+	}
+	if repo.userVote != nil {
+		t.Errorf("expected vote to be cleared, got %q", *repo.userVote)
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	data := resp["data"].(map[string]interface{})
+	if v, ok := data["user_vote"]; !ok || v != nil {
+		t.Errorf("expected user_vote null, got %v", v)
+	}
+}
+
+// TestRetractVote_NoAuth tests 401 when not authenticated.
+func TestRetractVote_NoAuth(t *testing.T) {
+	repo := NewMockPostsRepository()
+	handler := NewPostsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/posts/post-123/vote", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	handler.RetractVote(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+// TestRetractVote_OwnPostAllowed tests retraction is not blocked by the own-content rule.
+func TestRetractVote_OwnPostAllowed(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
+	repo.SetPost(&post) // posted by user-123
+
+	handler := NewPostsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/posts/post-123/vote", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.RetractVote(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+// TestVote_DirectionNoneRetracts tests POST with direction "none" retracts like DELETE.
+func TestVote_DirectionNoneRetracts(t *testing.T) {
+	repo := NewMockPostsRepository()
+	post := createTestPost("post-123", "Test Post", models.PostTypeProblem)
+	post.PostedByID = "other-user"
+	repo.SetPost(&post)
+	down := "down"
+	repo.userVote = &down
+
+	handler := NewPostsHandler(repo)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts/post-123/vote", bytes.NewReader([]byte(`{"direction":"none"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.Vote(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.userVote != nil {
+		t.Errorf("expected vote to be cleared, got %q", *repo.userVote)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
@@ -62,7 +63,18 @@ type ProblemsHandler struct {
 	postsRepo        PostsRepositoryInterface // For listing problems (shares data with /v1/posts)
 	relRepo          ApproachRelationshipsRepositoryInterface
 	embeddingService EmbeddingServiceInterface
-	logger           *slog.Logger
+	// Stuck-problem escalation (see problems_stuck.go)
+	escalationRepo      StuckEscalationRepositoryInterface
+	notificationCreator NotificationCreatorInterface
+	bountyRepo          BountyRepositoryInterface             // see problems_bounty.go
+	insightsRepo        ProblemInsightsRepositoryInterface    // see problems_insights.go
+	eventsRepo          ApproachEventsRepositoryInterface     // see approach_timeline.go
+	criteriaRepo        SuccessCriteriaRepositoryInterface    // see problems_criteria.go
+	transcriptRepo      ApproachTranscriptRepositoryInterface // see approach_transcripts.go
+	publishedNotifier   PostPublishedNotifier
+	crashDuplicates     CrashDuplicateFinder
+	contentLimits       contentLimitsSource // see content_limits.go
+	logger              *slog.Logger
 }
 
 // NewProblemsHandler creates a new ProblemsHandler.
@@ -73,6 +85,16 @@ func NewProblemsHandler(repo ProblemsRepositoryInterface) *ProblemsHandler {
 	}
 }
 
+// SetPostPublishedNotifier announces new problems to chat integrations.
+func (h *ProblemsHandler) SetPostPublishedNotifier(notifier PostPublishedNotifier) {
+	h.publishedNotifier = notifier
+}
+
+// SetCrashDuplicateFinder lists posts with the same stack trace when a problem is created.
+func (h *ProblemsHandler) SetCrashDuplicateFinder(finder CrashDuplicateFinder) {
+	h.crashDuplicates = finder
+}
+
 // SetEmbeddingService sets the embedding service for generating approach embeddings.
 // When set, approach creation/update will generate and store embeddings for semantic search.
 func (h *ProblemsHandler) SetEmbeddingService(svc EmbeddingServiceInterface) {
@@ -99,7 +121,7 @@ func (h *ProblemsHandler) findProblem(ctx context.Context, id string) (*models.P
 	// First try postsRepo if available (this is where POST /v1/posts stores problems)
 	if h.postsRepo != nil {
 		// BART-151: family-scoped — a family caller sees its own private problem; others 404.
-		problem, err := h.postsRepo.FindByIDForViewer(ctx, id, "", "", callerHumanFromCtx(ctx))
+		problem, err := h.postsRepo.FindByIDForViewer(ctx, id)
 		if err == nil {
 			// Verify it's actually a problem
 			if problem.Type != models.PostTypeProblem {
@@ -160,6 +182,7 @@ type CreateProblemRequest struct {
 	Tags            []string `json:"tags,omitempty"`
 	SuccessCriteria []string `json:"success_criteria,omitempty"`
 	Weight          *int     `json:"weight,omitempty"`
+	TemplateID      string   `json:"template_id,omitempty"` // See GET /v1/templates
 }
 
 // List handles GET /v1/problems - list problems.
@@ -214,7 +237,7 @@ func (h *ProblemsHandler) List(w http.ResponseWriter, r *http.Request) {
 		problems, total, err = h.repo.ListProblems(r.Context(), opts)
 	}
 	if err != nil {
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list problems")
+		apierror.Write(w, apierror.InternalError, "failed to list problems")
 		return
 	}
 
@@ -239,7 +262,7 @@ func (h *ProblemsHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *ProblemsHandler) Get(w http.ResponseWriter, r *http.Request) {
 	problemID := chi.URLParam(r, "id")
 	if problemID == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "problem ID is required")
+		apierror.Write(w, apierror.ValidationError, "problem ID is required")
 		return
 	}
 
@@ -247,10 +270,10 @@ func (h *ProblemsHandler) Get(w http.ResponseWriter, r *http.Request) {
 	problem, err := h.findProblem(r.Context(), problemID)
 	if err != nil {
 		if errors.Is(err, ErrProblemNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "problem not found")
+			apierror.Write(w, apierror.NotFound, "problem not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get problem")
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
 		return
 	}
 
@@ -264,54 +287,59 @@ func (h *ProblemsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeProblemsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse request body
 	var req CreateProblemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
-	// Validate title
+	// Validate title and description against the configured limits
 	if req.Title == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title is required")
-		return
-	}
-	if len(req.Title) < 10 {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at least 10 characters")
-		return
-	}
-	if len(req.Title) > 200 {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at most 200 characters")
+		apierror.Write(w, apierror.ValidationError, "title is required")
 		return
 	}
-
-	// Validate description
 	if req.Description == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description is required")
+		apierror.Write(w, apierror.ValidationError, "description is required")
 		return
 	}
-	if len(req.Description) < 50 {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description must be at least 50 characters")
+	var lengthCheck Validator
+	validatePostLengths(&lengthCheck, h.contentLimits.For(models.PostTypeProblem), &req.Title, &req.Description, nil)
+	if !lengthCheck.Valid() {
+		apierror.Write(w, apierror.ValidationError, lengthCheck.Errors()[0].Message)
 		return
 	}
 
 	// Validate tags
 	if len(req.Tags) > models.MaxTagsPerPost {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("maximum %d tags allowed", models.MaxTagsPerPost))
+		apierror.Write(w, apierror.ValidationError, fmt.Sprintf("maximum %d tags allowed", models.MaxTagsPerPost))
+		return
+	}
+	var tagCheck Validator
+	req.Tags = validateTags(&tagCheck, req.Tags)
+	validatePostTemplate(&tagCheck, req.TemplateID, models.PostTypeProblem, req.Description)
+	if !tagCheck.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, tagCheck.Errors())
 		return
 	}
 
 	// Validate problem-specific fields
 	if req.Weight != nil && (*req.Weight < 1 || *req.Weight > 5) {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "weight must be between 1 and 5")
+		apierror.Write(w, apierror.ValidationError, "weight must be between 1 and 5")
+		return
+	}
+	if len(req.SuccessCriteria) > models.MaxSuccessCriteria {
+		apierror.Write(w, apierror.ValidationError, fmt.Sprintf("maximum %d success criteria allowed", models.MaxSuccessCriteria))
 		return
 	}
-	if len(req.SuccessCriteria) > 10 {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "maximum 10 success criteria allowed")
+	var criteriaCheck Validator
+	validatePostLengths(&criteriaCheck, h.contentLimits.For(models.PostTypeProblem), nil, nil, req.SuccessCriteria)
+	if !criteriaCheck.Valid() {
+		apierror.Write(w, apierror.ValidationError, criteriaCheck.Errors()[0].Message)
 		return
 	}
 
@@ -330,13 +358,13 @@ func (h *ProblemsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	createdPost, err := h.repo.CreateProblem(r.Context(), post)
 	if err != nil {
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create problem")
+		apierror.Write(w, apierror.InternalError, "failed to create problem")
 		return
 	}
+	announcePostAsync(r.Context(), h.publishedNotifier, createdPost, h.logger)
 
-	writeProblemsJSON(w, http.StatusCreated, map[string]interface{}{
-		"data": createdPost,
-	})
+	duplicates := findCrashDuplicates(r.Context(), h.crashDuplicates, createdPost, h.logger)
+	writeProblemsJSON(w, http.StatusCreated, createdPostResponse(createdPost, duplicates))
 }
 
 // parseProblemsIntParam parses a string to int with a default value.
@@ -362,7 +390,7 @@ type ProblemExportResponse struct {
 func (h *ProblemsHandler) Export(w http.ResponseWriter, r *http.Request) {
 	problemID := chi.URLParam(r, "id")
 	if problemID == "" {
-		writeProblemsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "problem ID is required")
+		apierror.Write(w, apierror.ValidationError, "problem ID is required")
 		return
 	}
 
@@ -370,10 +398,10 @@ func (h *ProblemsHandler) Export(w http.ResponseWriter, r *http.Request) {
 	problem, err := h.findProblem(r.Context(), problemID)
 	if err != nil {
 		if errors.Is(err, ErrProblemNotFound) {
-			writeProblemsError(w, http.StatusNotFound, "NOT_FOUND", "problem not found")
+			apierror.Write(w, apierror.NotFound, "problem not found")
 			return
 		}
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get problem")
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
 		return
 	}
 
@@ -385,7 +413,7 @@ func (h *ProblemsHandler) Export(w http.ResponseWriter, r *http.Request) {
 	}
 	approaches, _, err := h.repo.ListApproaches(r.Context(), problemID, opts)
 	if err != nil {
-		writeProblemsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get approaches")
+		apierror.Write(w, apierror.InternalError, "failed to get approaches")
 		return
 	}
 
@@ -509,15 +537,3 @@ func writeProblemsJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-// writeProblemsError writes an error JSON response.
-func writeProblemsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
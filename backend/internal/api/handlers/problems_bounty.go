@@ -0,0 +1,135 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains bounty (weight) adjustment on ProblemsHandler.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxProblemWeight is the highest bounty a problem can carry (posts_weight_check).
+const maxProblemWeight = 5
+
+// BountyRepositoryInterface defines the database operations for problem bounties.
+type BountyRepositoryInterface interface {
+	// RaiseWeight sets weight if it is higher than the current one.
+	// Returns db.ErrBountyNotRaised otherwise.
+	RaiseWeight(ctx context.Context, problemID string, weight int) (time.Time, error)
+}
+
+// UpdateBountyRequest is the request body for PATCH /v1/problems/{id}/bounty.
+type UpdateBountyRequest struct {
+	Weight int `json:"weight"`
+}
+
+// BountyResponse is the response for PATCH /v1/problems/{id}/bounty.
+type BountyResponse struct {
+	ProblemID       string    `json:"problem_id"`
+	Weight          int       `json:"weight"`
+	BountyPoints    int       `json:"bounty_points"` // reputation paid to the solver on solve
+	WeightUpdatedAt time.Time `json:"weight_updated_at"`
+}
+
+// SetBountyRepository enables PATCH /v1/problems/{id}/bounty.
+func (h *ProblemsHandler) SetBountyRepository(repo BountyRepositoryInterface) {
+	h.bountyRepo = repo
+}
+
+// bountyPoints returns the bonus reputation a solver earns for a problem of weight.
+func bountyPoints(weight int) int {
+	if weight <= 1 {
+		return 0
+	}
+	return (weight - 1) * reputation.PointsBountyPerWeight
+}
+
+// UpdateBounty handles PATCH /v1/problems/{id}/bounty - raise a problem's bounty.
+// Only the problem author can raise it, only upwards and only while the problem
+// is unsolved. Inactive problems lose one weight step every 14 days (bounty decay job).
+func (h *ProblemsHandler) UpdateBounty(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	if h.bountyRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "bounties are not available")
+		return
+	}
+
+	problemID := chi.URLParam(r, "id")
+	if problemID == "" {
+		apierror.Write(w, apierror.ValidationError, "problem ID is required")
+		return
+	}
+
+	var req UpdateBountyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	if req.Weight < 1 || req.Weight > maxProblemWeight {
+		apierror.Write(w, apierror.ValidationError,
+			fmt.Sprintf("weight must be between 1 and %d", maxProblemWeight))
+		return
+	}
+
+	problem, err := h.findProblem(r.Context(), problemID)
+	if err != nil {
+		if errors.Is(err, ErrProblemNotFound) {
+			apierror.Write(w, apierror.NotFound, "problem not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
+		return
+	}
+
+	if problem.PostedByType != authInfo.AuthorType || problem.PostedByID != authInfo.AuthorID {
+		apierror.Write(w, apierror.Forbidden, "only the problem owner can change its bounty")
+		return
+	}
+
+	switch problem.Status {
+	case models.PostStatusOpen, models.PostStatusInProgress, models.PostStatusDormant:
+	default:
+		apierror.Write(w, apierror.InvalidStatus,
+			fmt.Sprintf("bounty can only be raised on unsolved problems (status is %s)", problem.Status))
+		return
+	}
+
+	if problem.Weight != nil && req.Weight <= *problem.Weight {
+		apierror.Write(w, apierror.BountyNotRaised,
+			fmt.Sprintf("bounty can only be increased (current weight is %d)", *problem.Weight))
+		return
+	}
+
+	updatedAt, err := h.bountyRepo.RaiseWeight(r.Context(), problem.ID, req.Weight)
+	if err != nil {
+		if errors.Is(err, db.ErrBountyNotRaised) {
+			apierror.Write(w, apierror.BountyNotRaised, "bounty can only be increased")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to update bounty")
+		return
+	}
+
+	writeProblemsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": BountyResponse{
+			ProblemID:       problem.ID,
+			Weight:          req.Weight,
+			BountyPoints:    bountyPoints(req.Weight),
+			WeightUpdatedAt: updatedAt,
+		},
+	})
+}
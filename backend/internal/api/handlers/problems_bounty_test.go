@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// MockBountyRepository is a mock implementation of BountyRepositoryInterface.
+type MockBountyRepository struct {
+	raisedTo int
+	err      error
+}
+
+func (m *MockBountyRepository) RaiseWeight(ctx context.Context, problemID string, weight int) (time.Time, error) {
+	if m.err != nil {
+		return time.Time{}, m.err
+	}
+	m.raisedTo = weight
+	return time.Now(), nil
+}
+
+func newUpdateBountyRequest(problemID string, weight int) *http.Request {
+	body, _ := json.Marshal(UpdateBountyRequest{Weight: weight})
+	req := httptest.NewRequest(http.MethodPatch, "/v1/problems/"+problemID+"/bounty", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", problemID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestUpdateBounty_Success tests the owner raising a problem's bounty.
+func TestUpdateBounty_Success(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Connection pool leak") // weight 3
+	repo.SetPost(&problem)
+
+	bounties := &MockBountyRepository{}
+	handler := NewProblemsHandler(repo)
+	handler.SetBountyRepository(bounties)
+
+	req := addProblemsAuthContext(newUpdateBountyRequest("problem-123", 5), "user-123", "user")
+	w := httptest.NewRecorder()
+	handler.UpdateBounty(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data BountyResponse `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Data.Weight != 5 || resp.Data.BountyPoints != 100 {
+		t.Errorf("expected weight 5 worth 100 points, got %+v", resp.Data)
+	}
+	if bounties.raisedTo != 5 {
+		t.Errorf("expected weight raised to 5, got %d", bounties.raisedTo)
+	}
+}
+
+// TestUpdateBounty_Validation tests ownership, status, range and increase-only checks.
+func TestUpdateBounty_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     models.PostStatus
+		userID     string
+		weight     int
+		repoErr    error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not owner", models.PostStatusOpen, "user-999", 5, nil, http.StatusForbidden, "FORBIDDEN"},
+		{"out of range", models.PostStatusOpen, "user-123", 6, nil, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"decrease", models.PostStatusOpen, "user-123", 2, nil, http.StatusConflict, "BOUNTY_NOT_RAISED"},
+		{"solved problem", models.PostStatusSolved, "user-123", 5, nil, http.StatusConflict, "INVALID_STATUS"},
+		{"lost race", models.PostStatusInProgress, "user-123", 4, db.ErrBountyNotRaised, http.StatusConflict, "BOUNTY_NOT_RAISED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockProblemsRepository()
+			problem := createTestProblem("problem-123", "Connection pool leak")
+			problem.Status = tt.status
+			repo.SetPost(&problem)
+
+			handler := NewProblemsHandler(repo)
+			handler.SetBountyRepository(&MockBountyRepository{err: tt.repoErr})
+
+			req := addProblemsAuthContext(newUpdateBountyRequest("problem-123", tt.weight), tt.userID, "user")
+			w := httptest.NewRecorder()
+			handler.UpdateBounty(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d; body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantCode) {
+				t.Errorf("expected error code %s, got %s", tt.wantCode, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains success criteria check-off on ProblemsHandler and the
+// rule that a problem is only solved once its criteria are met.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxEvidenceURLLength caps the evidence link stored with a met criterion.
+const maxEvidenceURLLength = 2000
+
+// SuccessCriteriaRepositoryInterface defines the database operations for
+// checkable success criteria.
+type SuccessCriteriaRepositoryInterface interface {
+	ListCriteria(ctx context.Context, problemID string) ([]models.SuccessCriterion, error)
+	// SetMet returns db.ErrSuccessCriterionNotFound if the criterion isn't on the problem.
+	SetMet(ctx context.Context, problemID, criterionID string, met bool, verifierType, verifierID, evidenceURL string) (*models.SuccessCriterion, error)
+	CountUnmet(ctx context.Context, problemID string) (int, error)
+	RecordOverride(ctx context.Context, o models.SuccessCriteriaOverride) error
+	// FindOverride returns nil if the problem has no override.
+	FindOverride(ctx context.Context, problemID string) (*models.SuccessCriteriaOverride, error)
+}
+
+// UpdateSuccessCriterionRequest is the request body for
+// PATCH /v1/problems/{id}/criteria/{criterionId}.
+type UpdateSuccessCriterionRequest struct {
+	Met         *bool  `json:"met"`
+	EvidenceURL string `json:"evidence_url,omitempty"`
+}
+
+// SuccessCriteriaResponse is the data for GET /v1/problems/{id}/criteria.
+type SuccessCriteriaResponse struct {
+	ProblemID string                          `json:"problem_id"`
+	Criteria  []models.SuccessCriterion       `json:"criteria"`
+	Met       int                             `json:"met"`
+	Total     int                             `json:"total"`
+	Override  *models.SuccessCriteriaOverride `json:"override,omitempty"`
+}
+
+// SetSuccessCriteriaRepository enables /v1/problems/{id}/criteria and requires
+// met criteria (or an override reason) before an approach verification solves
+// the problem.
+func (h *ProblemsHandler) SetSuccessCriteriaRepository(repo SuccessCriteriaRepositoryInterface) {
+	h.criteriaRepo = repo
+}
+
+// ListSuccessCriteria handles GET /v1/problems/{id}/criteria - a problem's
+// success criteria with their met state. No auth required.
+func (h *ProblemsHandler) ListSuccessCriteria(w http.ResponseWriter, r *http.Request) {
+	if h.criteriaRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "success criteria are not available")
+		return
+	}
+
+	problem, err := h.findProblem(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, ErrProblemNotFound) {
+			apierror.Write(w, apierror.NotFound, "problem not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
+		return
+	}
+
+	criteria, err := h.criteriaRepo.ListCriteria(r.Context(), problem.ID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list success criteria")
+		return
+	}
+	override, err := h.criteriaRepo.FindOverride(r.Context(), problem.ID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list success criteria")
+		return
+	}
+
+	resp := SuccessCriteriaResponse{ProblemID: problem.ID, Criteria: criteria, Total: len(criteria), Override: override}
+	for _, c := range criteria {
+		if c.Met {
+			resp.Met++
+		}
+	}
+	writeProblemsJSON(w, http.StatusOK, map[string]interface{}{"data": resp})
+}
+
+// UpdateSuccessCriterion handles PATCH /v1/problems/{id}/criteria/{criterionId} -
+// mark a criterion met (with an optional evidence link) or unmet. Only the
+// problem owner can, and only before the problem is solved or closed.
+func (h *ProblemsHandler) UpdateSuccessCriterion(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	if h.criteriaRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "success criteria are not available")
+		return
+	}
+
+	var req UpdateSuccessCriterionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	var v Validator
+	if req.Met == nil {
+		v.Add(FieldError{Field: "met", Code: FieldRequired, Message: "met is required"})
+	}
+	if req.EvidenceURL != "" {
+		v.Length("evidence_url", req.EvidenceURL, 0, maxEvidenceURLLength)
+		if u, err := url.Parse(req.EvidenceURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			v.Add(FieldError{Field: "evidence_url", Code: FieldInvalidFormat, Message: "evidence_url must be an http(s) URL"})
+		}
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	problem, err := h.findProblem(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if errors.Is(err, ErrProblemNotFound) {
+			apierror.Write(w, apierror.NotFound, "problem not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
+		return
+	}
+
+	if problem.PostedByType != authInfo.AuthorType || problem.PostedByID != authInfo.AuthorID {
+		apierror.Write(w, apierror.Forbidden, "only the problem owner can check off success criteria")
+		return
+	}
+
+	switch problem.Status {
+	case models.PostStatusSolved, models.PostStatusClosed:
+		apierror.Write(w, apierror.InvalidStatus,
+			fmt.Sprintf("success criteria can't change once the problem is %s", problem.Status))
+		return
+	}
+
+	criterion, err := h.criteriaRepo.SetMet(r.Context(), problem.ID, chi.URLParam(r, "criterionId"),
+		*req.Met, string(authInfo.AuthorType), authInfo.AuthorID, req.EvidenceURL)
+	if err != nil {
+		if errors.Is(err, db.ErrSuccessCriterionNotFound) {
+			apierror.Write(w, apierror.NotFound, "success criterion not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to update success criterion")
+		return
+	}
+
+	writeProblemsJSON(w, http.StatusOK, map[string]interface{}{"data": criterion})
+}
+
+// checkSolveCriteria enforces that a problem only becomes solved with every
+// success criterion met, or with an override reason. It writes the error
+// response and returns false when the solve must not proceed; the returned
+// override (nil if none was needed) is recorded with recordSolveOverride once
+// the problem is solved. A nil repo allows every solve.
+func checkSolveCriteria(w http.ResponseWriter, r *http.Request, repo SuccessCriteriaRepositoryInterface, problemID, overrideReason string) (*models.SuccessCriteriaOverride, bool) {
+	if repo == nil {
+		return nil, true
+	}
+	unmet, err := repo.CountUnmet(r.Context(), problemID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to check success criteria")
+		return nil, false
+	}
+	if unmet == 0 {
+		return nil, true
+	}
+
+	reason := strings.TrimSpace(overrideReason)
+	if reason == "" {
+		apierror.Write(w, apierror.CriteriaUnmet,
+			fmt.Sprintf("cannot mark as solved: %d success criteria are not met. Check them off or give an override_reason.", unmet))
+		return nil, false
+	}
+	if len(reason) > models.MaxSolveOverrideReasonLength {
+		writeFieldErrors(w, apierror.ValidationError, []FieldError{{
+			Field: "override_reason", Code: FieldTooLong, Max: models.MaxSolveOverrideReasonLength,
+			Message: fmt.Sprintf("override_reason must be at most %d characters", models.MaxSolveOverrideReasonLength),
+		}})
+		return nil, false
+	}
+
+	authInfo := GetAuthInfo(r)
+	return &models.SuccessCriteriaOverride{
+		PostID:    problemID,
+		Reason:    reason,
+		ActorType: string(authInfo.AuthorType),
+		ActorID:   authInfo.AuthorID,
+	}, true
+}
+
+// recordSolveOverride stores the override returned by checkSolveCriteria. The
+// problem is already solved, so a failure is only logged.
+func recordSolveOverride(ctx context.Context, repo SuccessCriteriaRepositoryInterface, o *models.SuccessCriteriaOverride, logger *slog.Logger) {
+	if repo == nil || o == nil {
+		return
+	}
+	if err := repo.RecordOverride(ctx, *o); err != nil {
+		logger.Warn("failed to record success criteria override", "post_id", o.PostID, "error", err)
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// MockSuccessCriteriaRepository is an in-memory SuccessCriteriaRepositoryInterface.
+type MockSuccessCriteriaRepository struct {
+	criteria  []models.SuccessCriterion
+	overrides []models.SuccessCriteriaOverride
+}
+
+func newMockSuccessCriteria(met ...bool) *MockSuccessCriteriaRepository {
+	m := &MockSuccessCriteriaRepository{}
+	for i, isMet := range met {
+		m.criteria = append(m.criteria, models.SuccessCriterion{
+			ID: "crit-" + string(rune('a'+i)), PostID: "problem-123", Position: i,
+			Text: "criterion " + string(rune('a'+i)), Met: isMet,
+		})
+	}
+	return m
+}
+
+func (m *MockSuccessCriteriaRepository) ListCriteria(ctx context.Context, problemID string) ([]models.SuccessCriterion, error) {
+	return m.criteria, nil
+}
+
+func (m *MockSuccessCriteriaRepository) SetMet(ctx context.Context, problemID, criterionID string, met bool, verifierType, verifierID, evidenceURL string) (*models.SuccessCriterion, error) {
+	for i := range m.criteria {
+		c := &m.criteria[i]
+		if c.ID != criterionID || c.PostID != problemID {
+			continue
+		}
+		c.Met, c.VerifiedByType, c.VerifiedByID, c.EvidenceURL, c.VerifiedAt = met, "", "", "", nil
+		if met {
+			now := time.Now()
+			c.VerifiedByType, c.VerifiedByID, c.EvidenceURL, c.VerifiedAt = verifierType, verifierID, evidenceURL, &now
+		}
+		return c, nil
+	}
+	return nil, db.ErrSuccessCriterionNotFound
+}
+
+func (m *MockSuccessCriteriaRepository) CountUnmet(ctx context.Context, problemID string) (int, error) {
+	n := 0
+	for _, c := range m.criteria {
+		if !c.Met {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *MockSuccessCriteriaRepository) RecordOverride(ctx context.Context, o models.SuccessCriteriaOverride) error {
+	m.overrides = append(m.overrides, o)
+	return nil
+}
+
+func (m *MockSuccessCriteriaRepository) FindOverride(ctx context.Context, problemID string) (*models.SuccessCriteriaOverride, error) {
+	if len(m.overrides) == 0 {
+		return nil, nil
+	}
+	return &m.overrides[len(m.overrides)-1], nil
+}
+
+func newCriterionRequest(criterionID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/v1/problems/problem-123/criteria/"+criterionID, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "problem-123")
+	rctx.URLParams.Add("criterionId", criterionID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestUpdateSuccessCriterion(t *testing.T) {
+	tests := []struct {
+		name        string
+		criterionID string
+		body        string
+		userID      string
+		status      models.PostStatus
+		wantStatus  int
+	}{
+		{name: "owner marks met", criterionID: "crit-a", body: `{"met":true,"evidence_url":"https://ci.example.com/run/42"}`, wantStatus: http.StatusOK},
+		{name: "not the owner", criterionID: "crit-a", body: `{"met":true}`, userID: "other-user", wantStatus: http.StatusForbidden},
+		{name: "missing met", criterionID: "crit-a", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "bad evidence url", criterionID: "crit-a", body: `{"met":true,"evidence_url":"javascript:alert(1)"}`, wantStatus: http.StatusBadRequest},
+		{name: "unknown criterion", criterionID: "crit-z", body: `{"met":true}`, wantStatus: http.StatusNotFound},
+		{name: "problem solved", criterionID: "crit-a", body: `{"met":false}`, status: models.PostStatusSolved, wantStatus: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockProblemsRepository()
+			problem := createTestProblem("problem-123", "Connection pool leak")
+			if tt.status != "" {
+				problem.Status = tt.status
+			}
+			repo.SetPost(&problem)
+			criteria := newMockSuccessCriteria(false, false)
+			handler := NewProblemsHandler(repo)
+			handler.SetSuccessCriteriaRepository(criteria)
+
+			userID := tt.userID
+			if userID == "" {
+				userID = "user-123"
+			}
+			req := addProblemsAuthContext(newCriterionRequest(tt.criterionID, tt.body), userID, "user")
+			w := httptest.NewRecorder()
+			handler.UpdateSuccessCriterion(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d; body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				c := criteria.criteria[0]
+				if !c.Met || c.VerifiedByID != "user-123" || c.EvidenceURL != "https://ci.example.com/run/42" {
+					t.Errorf("expected criterion met by user-123 with evidence, got %+v", c)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyApproach_RequiresCriteriaMet(t *testing.T) {
+	tests := []struct {
+		name         string
+		met          []bool
+		body         string
+		wantStatus   int
+		wantOverride bool
+	}{
+		{name: "all met", met: []bool{true, true}, body: `{"verified":true}`, wantStatus: http.StatusOK},
+		{name: "unmet without reason", met: []bool{true, false}, body: `{"verified":true}`, wantStatus: http.StatusConflict},
+		{name: "unmet with override", met: []bool{true, false},
+			body:       `{"verified":true,"override_reason":"Criterion b no longer applies after the upstream fix"}`,
+			wantStatus: http.StatusOK, wantOverride: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockProblemsRepository()
+			problem := createTestProblem("problem-123", "Connection pool leak")
+			repo.SetPost(&problem)
+			approach := createTestApproach("approach-123", "problem-123")
+			approach.Status = models.ApproachStatusSucceeded
+			repo.SetApproach(&approach)
+			criteria := newMockSuccessCriteria(tt.met...)
+			handler := NewProblemsHandler(repo)
+			handler.SetSuccessCriteriaRepository(criteria)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/approaches/approach-123/verify", bytes.NewReader([]byte(tt.body)))
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "approach-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			req = addProblemsAuthContext(req, "user-123", "user")
+			w := httptest.NewRecorder()
+			handler.VerifyApproach(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d; body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if w.Code == http.StatusConflict {
+				var resp struct {
+					Error struct {
+						Code string `json:"code"`
+					} `json:"error"`
+				}
+				json.NewDecoder(w.Body).Decode(&resp)
+				if resp.Error.Code != "CRITERIA_UNMET" {
+					t.Errorf("expected CRITERIA_UNMET, got %q", resp.Error.Code)
+				}
+			}
+			if got := len(criteria.overrides) == 1; got != tt.wantOverride {
+				t.Errorf("override recorded = %v, want %v", got, tt.wantOverride)
+			}
+		})
+	}
+}
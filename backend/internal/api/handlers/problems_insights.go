@@ -0,0 +1,61 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains strategy insights on ProblemsHandler.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// ProblemInsightsRepositoryInterface reads the strategy clusters computed by the
+// strategy cluster job.
+type ProblemInsightsRepositoryInterface interface {
+	GetProblemInsights(ctx context.Context, problemID string) (*models.ProblemInsights, error)
+}
+
+// SetInsightsRepository enables GET /v1/problems/{id}/insights.
+func (h *ProblemsHandler) SetInsightsRepository(repo ProblemInsightsRepositoryInterface) {
+	h.insightsRepo = repo
+}
+
+// GetInsights handles GET /v1/problems/{id}/insights - the distinct strategies
+// tried on a problem. Approaches are grouped by embedding similarity, so authors
+// can see how many fundamentally different angles were attempted, how each one
+// fared, and which directions are still unexplored. Clusters are refreshed in the
+// background; approaches added since the last run count as unclustered.
+func (h *ProblemsHandler) GetInsights(w http.ResponseWriter, r *http.Request) {
+	if h.insightsRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "problem insights are not available")
+		return
+	}
+
+	problemID := chi.URLParam(r, "id")
+	if problemID == "" {
+		apierror.Write(w, apierror.ValidationError, "problem ID is required")
+		return
+	}
+
+	if _, err := h.findProblem(r.Context(), problemID); err != nil {
+		if errors.Is(err, ErrProblemNotFound) {
+			apierror.Write(w, apierror.NotFound, "problem not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
+		return
+	}
+
+	insights, err := h.insightsRepo.GetProblemInsights(r.Context(), problemID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get problem insights")
+		return
+	}
+
+	writeProblemsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": insights,
+	})
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// MockProblemInsightsRepository is a mock implementation of ProblemInsightsRepositoryInterface.
+type MockProblemInsightsRepository struct {
+	insights *models.ProblemInsights
+	err      error
+}
+
+func (m *MockProblemInsightsRepository) GetProblemInsights(ctx context.Context, problemID string) (*models.ProblemInsights, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.insights, nil
+}
+
+func newGetInsightsRequest(problemID string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/v1/problems/"+problemID+"/insights", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", problemID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestGetInsights_Success tests returning a problem's strategy clusters.
+func TestGetInsights_Success(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Connection pool leak")
+	repo.SetPost(&problem)
+
+	computed := time.Now()
+	rep := models.StrategyClusterApproach{ID: "approach-1", Angle: "Cache connections", Status: models.ApproachStatusFailed}
+	insights := &MockProblemInsightsRepository{insights: &models.ProblemInsights{
+		ProblemID:     "problem-123",
+		ApproachCount: 3,
+		StrategyCount: 1,
+		Clusters: []models.StrategyCluster{{
+			Size:           2,
+			Representative: rep,
+			Approaches:     []models.StrategyClusterApproach{rep, {ID: "approach-2", Angle: "Reuse pooled connections", Status: models.ApproachStatusFailed}},
+			StatusCounts:   map[models.ApproachStatus]int{models.ApproachStatusFailed: 2},
+		}},
+		UnclusteredCount:    1,
+		SimilarityThreshold: 0.82,
+		ComputedAt:          &computed,
+	}}
+	handler := NewProblemsHandler(repo)
+	handler.SetInsightsRepository(insights)
+
+	w := httptest.NewRecorder()
+	handler.GetInsights(w, newGetInsightsRequest("problem-123"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data models.ProblemInsights `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.StrategyCount != 1 || resp.Data.UnclusteredCount != 1 {
+		t.Errorf("expected 1 strategy and 1 unclustered, got %+v", resp.Data)
+	}
+	if len(resp.Data.Clusters) != 1 || resp.Data.Clusters[0].Representative.ID != "approach-1" {
+		t.Errorf("unexpected clusters: %+v", resp.Data.Clusters)
+	}
+	if resp.Data.Clusters[0].StatusCounts[models.ApproachStatusFailed] != 2 {
+		t.Errorf("expected 2 failed, got %v", resp.Data.Clusters[0].StatusCounts)
+	}
+}
+
+// TestGetInsights_ProblemNotFound tests 404 for unknown problems.
+func TestGetInsights_ProblemNotFound(t *testing.T) {
+	handler := NewProblemsHandler(NewMockProblemsRepository())
+	handler.SetInsightsRepository(&MockProblemInsightsRepository{})
+
+	w := httptest.NewRecorder()
+	handler.GetInsights(w, newGetInsightsRequest("missing"))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+// TestGetInsights_RepositoryError tests 500 when insights cannot be read.
+func TestGetInsights_RepositoryError(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Connection pool leak")
+	repo.SetPost(&problem)
+
+	handler := NewProblemsHandler(repo)
+	handler.SetInsightsRepository(&MockProblemInsightsRepository{err: errors.New("db down")})
+
+	w := httptest.NewRecorder()
+	handler.GetInsights(w, newGetInsightsRequest("problem-123"))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", w.Code)
+	}
+}
+
+// TestGetInsights_NotConfigured tests 503 without an insights repository.
+func TestGetInsights_NotConfigured(t *testing.T) {
+	handler := NewProblemsHandler(NewMockProblemsRepository())
+
+	w := httptest.NewRecorder()
+	handler.GetInsights(w, newGetInsightsRequest("problem-123"))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", w.Code)
+	}
+}
@@ -0,0 +1,192 @@
+// Package handlers contains HTTP request handlers for the Solvr API.
+// This file contains the stuck-problem escalation workflow on ProblemsHandler.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// minStuckSummaryLength keeps "tried everything" style escalations out of the stuck feed.
+	minStuckSummaryLength = 50
+	maxStuckSummaryLength = 5000
+
+	// maxStuckNotifications caps how many tag subscribers hear about one escalation.
+	maxStuckNotifications = 50
+
+	// notificationTypeProblemStuck is sent to agents whose specialties match a stuck problem.
+	notificationTypeProblemStuck = "problem.stuck"
+
+	// notificationTypeApproachCreated is sent to a problem's owner when someone
+	// else starts an approach on it.
+	notificationTypeApproachCreated = "approach.created"
+)
+
+// StuckEscalationRepositoryInterface defines the database operations for stuck escalations.
+type StuckEscalationRepositoryInterface interface {
+	// CreateEscalation opens an escalation. Returns db.ErrProblemAlreadyStuck if one is open.
+	CreateEscalation(ctx context.Context, e *models.ProblemEscalation) (*models.ProblemEscalation, error)
+
+	// FindTagSubscribers returns IDs of agents whose specialties overlap tags.
+	FindTagSubscribers(ctx context.Context, tags []string, excludeAgentID string, limit int) ([]string, error)
+}
+
+// NotificationCreatorInterface creates in-app notifications.
+type NotificationCreatorInterface interface {
+	Create(ctx context.Context, n *models.Notification) (*models.Notification, error)
+}
+
+// MarkStuckRequest is the request body for POST /v1/problems/{id}/stuck.
+type MarkStuckRequest struct {
+	// Summary describes what was tried and why it did not work.
+	Summary string `json:"summary"`
+}
+
+// MarkStuckResponse is the response for POST /v1/problems/{id}/stuck.
+type MarkStuckResponse struct {
+	Escalation    *models.ProblemEscalation `json:"escalation"`
+	NotifiedCount int                       `json:"notified_count"`
+}
+
+// SetStuckEscalationRepository enables POST /v1/problems/{id}/stuck.
+func (h *ProblemsHandler) SetStuckEscalationRepository(repo StuckEscalationRepositoryInterface) {
+	h.escalationRepo = repo
+}
+
+// SetNotificationCreator sets where stuck-problem notifications are written.
+// When nil, escalations are recorded without notifying tag subscribers.
+func (h *ProblemsHandler) SetNotificationCreator(creator NotificationCreatorInterface) {
+	h.notificationCreator = creator
+}
+
+// MarkStuck handles POST /v1/problems/{id}/stuck - escalate a problem as stuck.
+// Only the problem author can escalate, and must summarize what was tried. The
+// problem is boosted in /v1/feed/stuck until it is solved, closed or goes stale,
+// and agents whose specialties match its tags are notified.
+func (h *ProblemsHandler) MarkStuck(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	if h.escalationRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "stuck escalation is not available")
+		return
+	}
+
+	problemID := chi.URLParam(r, "id")
+	if problemID == "" {
+		apierror.Write(w, apierror.ValidationError, "problem ID is required")
+		return
+	}
+
+	problem, err := h.findProblem(r.Context(), problemID)
+	if err != nil {
+		if errors.Is(err, ErrProblemNotFound) {
+			apierror.Write(w, apierror.NotFound, "problem not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get problem")
+		return
+	}
+
+	if problem.PostedByType != authInfo.AuthorType || problem.PostedByID != authInfo.AuthorID {
+		apierror.Write(w, apierror.Forbidden, "only the problem owner can mark it as stuck")
+		return
+	}
+
+	if problem.Status != models.PostStatusOpen && problem.Status != models.PostStatusInProgress {
+		apierror.Write(w, apierror.InvalidStatus,
+			fmt.Sprintf("only open or in_progress problems can be marked as stuck (status is %s)", problem.Status))
+		return
+	}
+
+	var req MarkStuckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	req.Summary = strings.TrimSpace(req.Summary)
+	if len(req.Summary) < minStuckSummaryLength {
+		apierror.Write(w, apierror.ValidationError,
+			fmt.Sprintf("summary of what was tried must be at least %d characters", minStuckSummaryLength))
+		return
+	}
+	if len(req.Summary) > maxStuckSummaryLength {
+		apierror.Write(w, apierror.ValidationError,
+			fmt.Sprintf("summary must be at most %d characters", maxStuckSummaryLength))
+		return
+	}
+
+	escalation, err := h.escalationRepo.CreateEscalation(r.Context(), &models.ProblemEscalation{
+		ProblemID:       problem.ID,
+		Summary:         req.Summary,
+		EscalatedByType: string(authInfo.AuthorType),
+		EscalatedByID:   authInfo.AuthorID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrProblemAlreadyStuck) {
+			apierror.Write(w, apierror.AlreadyStuck, "problem is already marked as stuck")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to mark problem as stuck")
+		return
+	}
+
+	notified := h.notifyStuckSubscribers(r.Context(), problem, authInfo)
+
+	writeProblemsJSON(w, http.StatusCreated, map[string]interface{}{
+		"data": MarkStuckResponse{
+			Escalation:    escalation,
+			NotifiedCount: notified,
+		},
+	})
+}
+
+// notifyStuckSubscribers notifies agents whose specialties overlap the problem's
+// tags. Failures are logged and never fail the escalation.
+func (h *ProblemsHandler) notifyStuckSubscribers(ctx context.Context, problem *models.PostWithAuthor, authInfo *AuthInfo) int {
+	if h.notificationCreator == nil || len(problem.Tags) == 0 {
+		return 0
+	}
+
+	excludeID := ""
+	if authInfo.AuthorType == models.AuthorTypeAgent {
+		excludeID = authInfo.AuthorID
+	}
+
+	agentIDs, err := h.escalationRepo.FindTagSubscribers(ctx, problem.Tags, excludeID, maxStuckNotifications)
+	if err != nil {
+		h.logger.Warn("failed to find stuck problem subscribers", "problem_id", problem.ID, "error", err)
+		return 0
+	}
+
+	notified := 0
+	for _, agentID := range agentIDs {
+		_, err := h.notificationCreator.Create(ctx, &models.Notification{
+			AgentID: &agentID,
+			Type:    notificationTypeProblemStuck,
+			Title:   "Problem needs help",
+			Body:    fmt.Sprintf("%q is stuck and matches your specialties", problem.Title),
+			Link:    fmt.Sprintf("/problems/%s", problem.ID),
+		})
+		if err != nil {
+			h.logger.Warn("failed to notify stuck problem subscriber", "problem_id", problem.ID, "agent_id", agentID, "error", err)
+			continue
+		}
+		notified++
+	}
+	return notified
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// MockStuckEscalationRepository is a mock implementation of StuckEscalationRepositoryInterface.
+type MockStuckEscalationRepository struct {
+	escalations []models.ProblemEscalation
+	subscribers []string
+	lastTags    []string
+	lastExclude string
+	createErr   error
+}
+
+func (m *MockStuckEscalationRepository) CreateEscalation(ctx context.Context, e *models.ProblemEscalation) (*models.ProblemEscalation, error) {
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	created := *e
+	created.ID = "escalation-1"
+	created.CreatedAt = time.Now()
+	m.escalations = append(m.escalations, created)
+	return &created, nil
+}
+
+func (m *MockStuckEscalationRepository) FindTagSubscribers(ctx context.Context, tags []string, excludeAgentID string, limit int) ([]string, error) {
+	m.lastTags = tags
+	m.lastExclude = excludeAgentID
+	return m.subscribers, nil
+}
+
+// MockNotificationCreator records created notifications.
+type MockNotificationCreator struct {
+	created []models.Notification
+}
+
+func (m *MockNotificationCreator) Create(ctx context.Context, n *models.Notification) (*models.Notification, error) {
+	m.created = append(m.created, *n)
+	return n, nil
+}
+
+const testStuckSummary = "Tried raising the pool size, adding context timeouts and pgbouncer; connections still leak."
+
+func newMarkStuckRequest(problemID, summary string) *http.Request {
+	body, _ := json.Marshal(MarkStuckRequest{Summary: summary})
+	req := httptest.NewRequest(http.MethodPost, "/v1/problems/"+problemID+"/stuck", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", problemID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// TestMarkStuck_Success tests escalation by the owner notifies tag subscribers.
+func TestMarkStuck_Success(t *testing.T) {
+	repo := NewMockProblemsRepository()
+	problem := createTestProblem("problem-123", "Connection pool leak")
+	repo.SetPost(&problem)
+
+	escalations := &MockStuckEscalationRepository{subscribers: []string{"agent_go", "agent_pg"}}
+	notifier := &MockNotificationCreator{}
+	handler := NewProblemsHandler(repo)
+	handler.SetStuckEscalationRepository(escalations)
+	handler.SetNotificationCreator(notifier)
+
+	req := addProblemsAuthContext(newMarkStuckRequest("problem-123", testStuckSummary), "user-123", "user")
+	w := httptest.NewRecorder()
+	handler.MarkStuck(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d; body: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data MarkStuckResponse `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Data.NotifiedCount != 2 {
+		t.Errorf("expected 2 notified agents, got %d", resp.Data.NotifiedCount)
+	}
+	if resp.Data.Escalation == nil || resp.Data.Escalation.Summary != testStuckSummary {
+		t.Errorf("expected escalation with summary, got %+v", resp.Data.Escalation)
+	}
+
+	if len(escalations.escalations) != 1 || escalations.escalations[0].EscalatedByID != "user-123" {
+		t.Errorf("expected escalation recorded for the owner, got %+v", escalations.escalations)
+	}
+	if strings.Join(escalations.lastTags, ",") != "test,go" || escalations.lastExclude != "" {
+		t.Errorf("expected subscribers looked up by problem tags, got tags=%v exclude=%q", escalations.lastTags, escalations.lastExclude)
+	}
+	if len(notifier.created) != 2 || notifier.created[0].Type != notificationTypeProblemStuck || *notifier.created[1].AgentID != "agent_pg" {
+		t.Errorf("unexpected notifications: %+v", notifier.created)
+	}
+}
+
+// TestMarkStuck_Validation tests ownership, status and summary checks.
+func TestMarkStuck_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     models.PostStatus
+		userID     string
+		summary    string
+		createErr  error
+		wantStatus int
+		wantCode   string
+	}{
+		{"not owner", models.PostStatusOpen, "user-999", testStuckSummary, nil, http.StatusForbidden, "FORBIDDEN"},
+		{"short summary", models.PostStatusOpen, "user-123", "tried stuff", nil, http.StatusBadRequest, "VALIDATION_ERROR"},
+		{"solved problem", models.PostStatusSolved, "user-123", testStuckSummary, nil, http.StatusConflict, "INVALID_STATUS"},
+		{"already stuck", models.PostStatusInProgress, "user-123", testStuckSummary, db.ErrProblemAlreadyStuck, http.StatusConflict, "ALREADY_STUCK"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockProblemsRepository()
+			problem := createTestProblem("problem-123", "Connection pool leak")
+			problem.Status = tt.status
+			repo.SetPost(&problem)
+
+			handler := NewProblemsHandler(repo)
+			handler.SetStuckEscalationRepository(&MockStuckEscalationRepository{createErr: tt.createErr})
+
+			req := addProblemsAuthContext(newMarkStuckRequest("problem-123", tt.summary), tt.userID, "user")
+			w := httptest.NewRecorder()
+			handler.MarkStuck(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d; body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantCode) {
+				t.Errorf("expected error code %s, got %s", tt.wantCode, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestMarkStuck_NoAuth tests 401 without authentication.
+func TestMarkStuck_NoAuth(t *testing.T) {
+	handler := NewProblemsHandler(NewMockProblemsRepository())
+	handler.SetStuckEscalationRepository(&MockStuckEscalationRepository{})
+
+	w := httptest.NewRecorder()
+	handler.MarkStuck(w, newMarkStuckRequest("problem-123", testStuckSummary))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
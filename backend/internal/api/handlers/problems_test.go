@@ -23,6 +23,7 @@ type MockProblemsRepository struct {
 	err             error
 	listOpts        models.PostListOptions
 	approaches      []models.ApproachWithAuthor
+	approachOpts    models.ApproachListOptions
 	approach        *models.ApproachWithAuthor
 	approachesErr   error
 	createdPost     *models.Post
@@ -68,6 +69,7 @@ func (m *MockProblemsRepository) CreateProblem(ctx context.Context, post *models
 }
 
 func (m *MockProblemsRepository) ListApproaches(ctx context.Context, problemID string, opts models.ApproachListOptions) ([]models.ApproachWithAuthor, int, error) {
+	m.approachOpts = opts
 	if m.approachesErr != nil {
 		return nil, 0, m.approachesErr
 	}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// maxPushUserAgentLength caps the browser description stored with a subscription.
+const maxPushUserAgentLength = 500
+
+// PushSubscriptionsRepositoryInterface defines the database operations for
+// Web Push subscriptions.
+type PushSubscriptionsRepositoryInterface interface {
+	SaveSubscription(ctx context.Context, sub *models.PushSubscription) (*models.PushSubscription, error)
+	ListSubscriptions(ctx context.Context, userID string) ([]models.PushSubscription, error)
+	// DeleteSubscription returns db.ErrNotFound if the user has no subscription at endpoint.
+	DeleteSubscription(ctx context.Context, userID, endpoint string) error
+}
+
+// PushSubscriptionsHandler handles Web Push subscriptions for humans.
+type PushSubscriptionsHandler struct {
+	repo      PushSubscriptionsRepositoryInterface
+	publicKey string
+}
+
+// NewPushSubscriptionsHandler creates a new PushSubscriptionsHandler.
+// publicKey is the VAPID public key browsers subscribe with; when empty,
+// Web Push is not configured and subscribing returns 503.
+func NewPushSubscriptionsHandler(repo PushSubscriptionsRepositoryInterface, publicKey string) *PushSubscriptionsHandler {
+	return &PushSubscriptionsHandler{repo: repo, publicKey: publicKey}
+}
+
+// CreatePushSubscriptionRequest is the request body for
+// POST /v1/me/push-subscriptions: the browser's PushSubscription.toJSON().
+type CreatePushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// GetVAPIDPublicKey handles GET /v1/push/vapid-public-key - the key the web
+// frontend passes to PushManager.subscribe() as applicationServerKey.
+// No auth required.
+func (h *PushSubscriptionsHandler) GetVAPIDPublicKey(w http.ResponseWriter, r *http.Request) {
+	if h.publicKey == "" {
+		apierror.Write(w, apierror.ServiceUnavailable, "web push is not configured")
+		return
+	}
+	writePushJSON(w, http.StatusOK, map[string]interface{}{
+		"data": map[string]string{"public_key": h.publicKey},
+	})
+}
+
+// List handles GET /v1/me/push-subscriptions - the caller's subscribed browsers.
+func (h *PushSubscriptionsHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pushSubscriptionUser(w, r)
+	if !ok {
+		return
+	}
+	subs, err := h.repo.ListSubscriptions(r.Context(), userID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list push subscriptions")
+		return
+	}
+	writePushJSON(w, http.StatusOK, map[string]interface{}{"data": subs})
+}
+
+// Create handles POST /v1/me/push-subscriptions - subscribe the calling
+// browser to push messages for the caller's notifications. Subscribing an
+// endpoint again replaces its keys.
+func (h *PushSubscriptionsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pushSubscriptionUser(w, r)
+	if !ok {
+		return
+	}
+	if h.publicKey == "" {
+		apierror.Write(w, apierror.ServiceUnavailable, "web push is not configured")
+		return
+	}
+
+	var req CreatePushSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	var v Validator
+	if v.Required("endpoint", req.Endpoint) && !models.IsValidPushEndpoint(req.Endpoint) {
+		v.Add(FieldError{Field: "endpoint", Code: FieldInvalidFormat, Message: "endpoint must be an https URL on a browser push service"})
+	}
+	// p256dh is an uncompressed P-256 point, auth a 16-byte secret.
+	if v.Required("keys.p256dh", req.Keys.P256dh) && decodedPushKeyLength(req.Keys.P256dh) != 65 {
+		v.Add(FieldError{Field: "keys.p256dh", Code: FieldInvalidFormat, Message: "keys.p256dh must be a base64url P-256 public key"})
+	}
+	if v.Required("keys.auth", req.Keys.Auth) && decodedPushKeyLength(req.Keys.Auth) != 16 {
+		v.Add(FieldError{Field: "keys.auth", Code: FieldInvalidFormat, Message: "keys.auth must be a base64url 16-byte secret"})
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxPushUserAgentLength {
+		userAgent = userAgent[:maxPushUserAgentLength]
+	}
+	sub, err := h.repo.SaveSubscription(r.Context(), &models.PushSubscription{
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: userAgent,
+	})
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to save push subscription")
+		return
+	}
+	writePushJSON(w, http.StatusCreated, map[string]interface{}{"data": sub})
+}
+
+// Delete handles DELETE /v1/me/push-subscriptions?endpoint=... - unsubscribe
+// a browser, e.g. after PushSubscription.unsubscribe().
+func (h *PushSubscriptionsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := pushSubscriptionUser(w, r)
+	if !ok {
+		return
+	}
+	endpoint := r.URL.Query().Get("endpoint")
+	if endpoint == "" {
+		apierror.Write(w, apierror.ValidationError, "endpoint is required")
+		return
+	}
+	if err := h.repo.DeleteSubscription(r.Context(), userID, endpoint); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, "push subscription not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to delete push subscription")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pushSubscriptionUser returns the caller's user ID. Agents have no browser
+// to push to and get 403.
+func pushSubscriptionUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return "", false
+	}
+	if authInfo.AuthorType != models.AuthorTypeHuman {
+		apierror.Write(w, apierror.Forbidden, "push subscriptions are only available to human accounts")
+		return "", false
+	}
+	return authInfo.AuthorID, true
+}
+
+// decodedPushKeyLength returns the byte length of a base64url push key, or -1
+// if it doesn't decode.
+func decodedPushKeyLength(key string) int {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+	if err != nil {
+		return -1
+	}
+	return len(b)
+}
+
+// writePushJSON writes a JSON response.
+func writePushJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
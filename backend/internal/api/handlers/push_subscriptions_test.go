@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockPushSubscriptionsRepo struct {
+	subs []models.PushSubscription
+}
+
+func (m *mockPushSubscriptionsRepo) SaveSubscription(ctx context.Context, sub *models.PushSubscription) (*models.PushSubscription, error) {
+	saved := *sub
+	saved.ID = "sub-1"
+	m.subs = append(m.subs, saved)
+	return &saved, nil
+}
+
+func (m *mockPushSubscriptionsRepo) ListSubscriptions(ctx context.Context, userID string) ([]models.PushSubscription, error) {
+	return m.subs, nil
+}
+
+func (m *mockPushSubscriptionsRepo) DeleteSubscription(ctx context.Context, userID, endpoint string) error {
+	for i, s := range m.subs {
+		if s.UserID == userID && s.Endpoint == endpoint {
+			m.subs = append(m.subs[:i], m.subs[i+1:]...)
+			return nil
+		}
+	}
+	return db.ErrNotFound
+}
+
+func TestPushSubscriptionsHandler_Create(t *testing.T) {
+	p256dh := base64.RawURLEncoding.EncodeToString(append([]byte{4}, make([]byte, 64)...))
+	authKey := base64.RawURLEncoding.EncodeToString(make([]byte, 16))
+	valid := `{"endpoint":"https://fcm.googleapis.com/fcm/send/abc","keys":{"p256dh":"` + p256dh + `","auth":"` + authKey + `"}}`
+
+	human := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Role: models.UserRoleUser})
+	agent := auth.ContextWithAgent(context.Background(), &models.Agent{ID: "agent-1"})
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		publicKey  string
+		body       string
+		wantStatus int
+	}{
+		{name: "subscribes", ctx: human, publicKey: "pub", body: valid, wantStatus: http.StatusCreated},
+		{name: "agents can't subscribe", ctx: agent, publicKey: "pub", body: valid, wantStatus: http.StatusForbidden},
+		{name: "push not configured", ctx: human, body: valid, wantStatus: http.StatusServiceUnavailable},
+		{name: "endpoint off a push service", ctx: human, publicKey: "pub",
+			body:       strings.Replace(valid, "fcm.googleapis.com", "169.254.169.254", 1),
+			wantStatus: http.StatusBadRequest},
+		{name: "malformed keys", ctx: human, publicKey: "pub",
+			body:       `{"endpoint":"https://fcm.googleapis.com/fcm/send/abc","keys":{"p256dh":"short","auth":"!!"}}`,
+			wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPushSubscriptionsRepo{}
+			h := NewPushSubscriptionsHandler(repo, tt.publicKey)
+			req := httptest.NewRequest(http.MethodPost, "/v1/me/push-subscriptions", strings.NewReader(tt.body)).WithContext(tt.ctx)
+			w := httptest.NewRecorder()
+			h.Create(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d; body: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusCreated {
+				if len(repo.subs) != 1 || repo.subs[0].UserID != "user-1" || repo.subs[0].P256dh != p256dh {
+					t.Errorf("unexpected saved subscriptions %+v", repo.subs)
+				}
+				if strings.Contains(w.Body.String(), p256dh) {
+					t.Error("response must not echo the subscription keys")
+				}
+			}
+		})
+	}
+}
+
+func TestPushSubscriptionsHandler_Delete(t *testing.T) {
+	endpoint := "https://fcm.googleapis.com/fcm/send/abc"
+	repo := &mockPushSubscriptionsRepo{subs: []models.PushSubscription{{ID: "sub-1", UserID: "user-1", Endpoint: endpoint}}}
+	h := NewPushSubscriptionsHandler(repo, "pub")
+	ctx := auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Role: models.UserRoleUser})
+	target := "/v1/me/push-subscriptions?endpoint=" + url.QueryEscape(endpoint)
+
+	w := httptest.NewRecorder()
+	h.Delete(w, httptest.NewRequest(http.MethodDelete, target, nil).WithContext(ctx))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.Delete(w, httptest.NewRequest(http.MethodDelete, target, nil).WithContext(ctx))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 deleting twice, got %d", w.Code)
+	}
+}
+
+func TestPushSubscriptionsHandler_GetVAPIDPublicKey(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewPushSubscriptionsHandler(&mockPushSubscriptionsRepo{}, "BPub").GetVAPIDPublicKey(w, httptest.NewRequest(http.MethodGet, "/v1/push/vapid-public-key", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"public_key":"BPub"`) {
+		t.Errorf("expected the public key, got %d: %s", w.Code, w.Body.String())
+	}
+}
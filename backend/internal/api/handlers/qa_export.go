@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// qaExportFlushEvery is how many lines are written between flushes, so large
+// exports reach the client as they are read.
+const qaExportFlushEvery = 100
+
+// QAExportRepositoryInterface defines the database operations for QA pair export.
+type QAExportRepositoryInterface interface {
+	StreamQAPairs(ctx context.Context, filter models.QAExportFilter, fn func(*models.QAPair) error) error
+}
+
+// QAExportHandler streams question→answer and problem→solution pairs for
+// fine-tuning.
+type QAExportHandler struct {
+	repo    QAExportRepositoryInterface
+	siteURL string
+}
+
+// NewQAExportHandler creates a new QAExportHandler. siteURL is the frontend
+// base URL the pairs' source links point at.
+func NewQAExportHandler(repo QAExportRepositoryInterface, siteURL string) *QAExportHandler {
+	return &QAExportHandler{repo: repo, siteURL: strings.TrimRight(siteURL, "/")}
+}
+
+// qaExportMessage is one turn of a QAExportFormatMessages record.
+type qaExportMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// qaExportMetadata is the provenance and license every exported record carries.
+type qaExportMetadata struct {
+	ID           string                 `json:"id"`
+	PostID       string                 `json:"post_id"`
+	PostType     models.PostType        `json:"post_type"`
+	SourceURL    string                 `json:"source_url"`
+	Tags         []string               `json:"tags"`
+	License      string                 `json:"license"`
+	LicenseURL   string                 `json:"license_url"`
+	Attribution  string                 `json:"attribution"`
+	Contributors []models.QAContributor `json:"contributors"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// ExportQAPairs handles GET /v1/export/qa-pairs - accepted question→answer
+// and problem→verified-solution pairs as JSONL, one record per line in the
+// requested fine-tuning format, each with licensing metadata. Pairs come
+// oldest change first; pass the last record's metadata.updated_at as since
+// to fetch the next batch.
+//
+// Requires an API key (agent or user) or X-Admin-API-Key; browser sessions
+// are limited to admins.
+//
+// Query params: format (messages|alpaca|prompt_completion, default messages),
+// type (question|problem, default both), since (RFC3339), limit (default 10000).
+func (h *QAExportHandler) ExportQAPairs(w http.ResponseWriter, r *http.Request) {
+	if !checkQAExportAccess(w, r) {
+		return
+	}
+
+	query := r.URL.Query()
+	var v Validator
+	format := query.Get("format")
+	if format == "" {
+		format = models.QAExportFormatMessages
+	}
+	v.OneOf("format", format, models.ValidQAExportFormats...)
+	filter := models.QAExportFilter{Limit: models.DefaultQAExportLimit}
+	if t := query.Get("type"); t != "" {
+		v.OneOf("type", t, string(models.PostTypeQuestion), string(models.PostTypeProblem))
+		filter.PostType = models.PostType(t)
+	}
+	if s := query.Get("since"); s != "" {
+		since, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			v.Add(FieldError{Field: "since", Code: FieldInvalidFormat, Message: "since must be an RFC 3339 timestamp"})
+		}
+		filter.Since = &since
+	}
+	if l := query.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil {
+			n = 0
+		}
+		v.Range("limit", n, 1, models.MaxQAExportLimit)
+		filter.Limit = n
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
+		return
+	}
+
+	// Headers are sent with the first record, so a query that fails before
+	// any output still gets a JSON error.
+	started := false
+	start := func() {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="solvr-qa-pairs.jsonl"`)
+		w.Header().Set("Link", "<"+models.ContentLicenseURL+`>; rel="license"`)
+		w.WriteHeader(http.StatusOK)
+		started = true
+	}
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	written := 0
+
+	err := h.repo.StreamQAPairs(r.Context(), filter, func(pair *models.QAPair) error {
+		if !started {
+			start()
+		}
+		if err := enc.Encode(h.qaExportRecord(pair, format)); err != nil {
+			return err
+		}
+		written++
+		if flusher != nil && written%qaExportFlushEvery == 0 {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if !started {
+			apierror.Write(w, apierror.InternalError, "failed to export QA pairs")
+			return
+		}
+		// Mid-stream the status is already sent; the client sees a short export.
+		slog.Error("qa export: stream interrupted", "error", err, "written", written)
+		return
+	}
+	if !started {
+		start()
+	}
+}
+
+// qaExportRecord builds one JSONL record in format.
+func (h *QAExportHandler) qaExportRecord(pair *models.QAPair, format string) interface{} {
+	sourceURL := h.siteURL + "/" + string(pair.PostType) + "s/" + pair.PostID
+	contributors := []models.QAContributor{pair.Asker}
+	if pair.Responder != pair.Asker {
+		contributors = append(contributors, pair.Responder)
+	}
+	tags := pair.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	meta := qaExportMetadata{
+		ID:           pair.ResponseID,
+		PostID:       pair.PostID,
+		PostType:     pair.PostType,
+		SourceURL:    sourceURL,
+		Tags:         tags,
+		License:      models.ContentLicense,
+		LicenseURL:   models.ContentLicenseURL,
+		Attribution:  pair.Attribution(sourceURL),
+		Contributors: contributors,
+		CreatedAt:    pair.CreatedAt,
+		UpdatedAt:    pair.UpdatedAt,
+	}
+
+	switch format {
+	case models.QAExportFormatAlpaca:
+		return struct {
+			Instruction string           `json:"instruction"`
+			Input       string           `json:"input"`
+			Output      string           `json:"output"`
+			Metadata    qaExportMetadata `json:"metadata"`
+		}{pair.Title, pair.Body, pair.Response, meta}
+	case models.QAExportFormatPromptCompletion:
+		return struct {
+			Prompt     string           `json:"prompt"`
+			Completion string           `json:"completion"`
+			Metadata   qaExportMetadata `json:"metadata"`
+		}{pair.Prompt(), pair.Response, meta}
+	default:
+		return struct {
+			Messages []qaExportMessage `json:"messages"`
+			Metadata qaExportMetadata  `json:"metadata"`
+		}{[]qaExportMessage{
+			{Role: "user", Content: pair.Prompt()},
+			{Role: "assistant", Content: pair.Response},
+		}, meta}
+	}
+}
+
+// checkQAExportAccess allows the admin API key, any agent or user API key,
+// and admins' browser sessions. Other sessions get 403 so bulk export stays
+// on credentials that are rate limited and revocable per key.
+func checkQAExportAccess(w http.ResponseWriter, r *http.Request) bool {
+	if adminKey := os.Getenv("ADMIN_API_KEY"); adminKey != "" {
+		if provided := r.Header.Get("X-Admin-API-Key"); provided != "" {
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(adminKey)) != 1 {
+				apierror.WriteStatus(w, http.StatusForbidden, apierror.InvalidAPIKey, "invalid admin API key")
+				return false
+			}
+			return true
+		}
+	}
+
+	p := auth.PrincipalFromContext(r.Context())
+	if p == nil {
+		apierror.Write(w, apierror.Unauthorized, "an API key or X-Admin-API-Key header is required")
+		return false
+	}
+	if !p.IsAgent() && p.APIKeyID == "" && !p.IsAdmin() {
+		apierror.Write(w, apierror.Forbidden, "QA pair export requires an API key")
+		return false
+	}
+	return true
+}
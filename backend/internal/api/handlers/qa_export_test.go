@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockQAExportRepo struct {
+	pairs  []models.QAPair
+	err    error
+	filter models.QAExportFilter
+}
+
+func (m *mockQAExportRepo) StreamQAPairs(ctx context.Context, filter models.QAExportFilter, fn func(*models.QAPair) error) error {
+	m.filter = filter
+	if m.err != nil {
+		return m.err
+	}
+	for i := range m.pairs {
+		if err := fn(&m.pairs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testQAPair() models.QAPair {
+	return models.QAPair{
+		PostID: "q-1", PostType: models.PostTypeQuestion,
+		Title: "How do I cancel a pgx query?", Body: "It hangs forever.",
+		Tags: []string{"go", "pgx"}, ResponseID: "a-1", Response: "Pass a context with a timeout.",
+		Asker:     models.QAContributor{Type: models.AuthorTypeHuman, Handle: "alice"},
+		Responder: models.QAContributor{Type: models.AuthorTypeAgent, Handle: "agent_helper"},
+		UpdatedAt: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestExportQAPairs_Formats(t *testing.T) {
+	agent := auth.ContextWithAgent(context.Background(), &models.Agent{ID: "agent-1"})
+
+	tests := []struct {
+		format string
+		check  func(t *testing.T, rec map[string]interface{})
+	}{
+		{format: "", check: func(t *testing.T, rec map[string]interface{}) {
+			msgs, _ := rec["messages"].([]interface{})
+			if len(msgs) != 2 {
+				t.Fatalf("expected user and assistant messages, got %v", rec["messages"])
+			}
+			user := msgs[0].(map[string]interface{})
+			if user["role"] != "user" || user["content"] != "How do I cancel a pgx query?\n\nIt hangs forever." {
+				t.Errorf("unexpected user message %v", user)
+			}
+			if msgs[1].(map[string]interface{})["content"] != "Pass a context with a timeout." {
+				t.Errorf("unexpected assistant message %v", msgs[1])
+			}
+		}},
+		{format: "alpaca", check: func(t *testing.T, rec map[string]interface{}) {
+			if rec["instruction"] != "How do I cancel a pgx query?" || rec["input"] != "It hangs forever." || rec["output"] != "Pass a context with a timeout." {
+				t.Errorf("unexpected alpaca record %v", rec)
+			}
+		}},
+		{format: "prompt_completion", check: func(t *testing.T, rec map[string]interface{}) {
+			if rec["completion"] != "Pass a context with a timeout." || !strings.HasPrefix(rec["prompt"].(string), "How do I cancel") {
+				t.Errorf("unexpected prompt/completion record %v", rec)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run("format="+tt.format, func(t *testing.T) {
+			repo := &mockQAExportRepo{pairs: []models.QAPair{testQAPair()}}
+			h := NewQAExportHandler(repo, "https://solvr.dev")
+			req := httptest.NewRequest(http.MethodGet, "/v1/export/qa-pairs?format="+tt.format, nil).WithContext(agent)
+			w := httptest.NewRecorder()
+			h.ExportQAPairs(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+				t.Errorf("expected application/x-ndjson, got %q", ct)
+			}
+			var rec map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &rec); err != nil {
+				t.Fatalf("record is not one JSON object: %v", err)
+			}
+			tt.check(t, rec)
+
+			meta := rec["metadata"].(map[string]interface{})
+			if meta["license"] != models.ContentLicense || meta["source_url"] != "https://solvr.dev/questions/q-1" {
+				t.Errorf("unexpected metadata %v", meta)
+			}
+			if meta["attribution"] != "Source: Solvr (solvr.dev/questions/q-1) — Contributors: @alice, @agent_helper" {
+				t.Errorf("unexpected attribution %q", meta["attribution"])
+			}
+		})
+	}
+}
+
+func TestExportQAPairs_StreamsOneRecordPerLine(t *testing.T) {
+	second := testQAPair()
+	second.PostID, second.PostType, second.ResponseID = "p-1", models.PostTypeProblem, "ap-1"
+	repo := &mockQAExportRepo{pairs: []models.QAPair{testQAPair(), second}}
+	h := NewQAExportHandler(repo, "https://solvr.dev")
+	ctx := auth.ContextWithAgent(context.Background(), &models.Agent{ID: "agent-1"})
+	req := httptest.NewRequest(http.MethodGet, "/v1/export/qa-pairs?type=problem&since=2026-01-02T03:04:05Z&limit=5", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	h.ExportQAPairs(w, req)
+
+	if repo.filter.PostType != models.PostTypeProblem || repo.filter.Limit != 5 || repo.filter.Since == nil {
+		t.Errorf("unexpected filter %+v", repo.filter)
+	}
+	lines := 0
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("expected 2 lines, got %d", lines)
+	}
+}
+
+func TestExportQAPairs_Access(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "admin-secret")
+
+	withAPIKey := auth.ContextWithAPIKeyID(auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Role: models.UserRoleUser}), "key-1")
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		adminKey   string
+		wantStatus int
+	}{
+		{name: "anonymous", ctx: context.Background(), wantStatus: http.StatusUnauthorized},
+		{name: "admin key", ctx: context.Background(), adminKey: "admin-secret", wantStatus: http.StatusOK},
+		{name: "wrong admin key", ctx: context.Background(), adminKey: "nope", wantStatus: http.StatusForbidden},
+		{name: "agent API key", ctx: auth.ContextWithAgent(context.Background(), &models.Agent{ID: "agent-1"}), wantStatus: http.StatusOK},
+		{name: "user API key", ctx: withAPIKey, wantStatus: http.StatusOK},
+		{name: "browser session", ctx: auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Role: models.UserRoleUser}), wantStatus: http.StatusForbidden},
+		{name: "admin session", ctx: auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: "user-1", Role: models.UserRoleAdmin}), wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewQAExportHandler(&mockQAExportRepo{}, "https://solvr.dev")
+			req := httptest.NewRequest(http.MethodGet, "/v1/export/qa-pairs", nil).WithContext(tt.ctx)
+			if tt.adminKey != "" {
+				req.Header.Set("X-Admin-API-Key", tt.adminKey)
+			}
+			w := httptest.NewRecorder()
+			h.ExportQAPairs(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestExportQAPairs_Errors(t *testing.T) {
+	ctx := auth.ContextWithAgent(context.Background(), &models.Agent{ID: "agent-1"})
+
+	for _, query := range []string{"format=csv", "type=idea", "since=yesterday", "limit=0", "limit=1000000"} {
+		w := httptest.NewRecorder()
+		NewQAExportHandler(&mockQAExportRepo{}, "").ExportQAPairs(w, httptest.NewRequest(http.MethodGet, "/v1/export/qa-pairs?"+query, nil).WithContext(ctx))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	NewQAExportHandler(&mockQAExportRepo{err: errors.New("db down")}, "").ExportQAPairs(w, httptest.NewRequest(http.MethodGet, "/v1/export/qa-pairs", nil).WithContext(ctx))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when the query fails before any output, got %d", w.Code)
+	}
+}
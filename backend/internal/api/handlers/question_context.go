@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// Per-item caps used when fitting a question context into its token budget.
+const (
+	// questionContextItemChars caps a single answer or solution.
+	questionContextItemChars = 2000
+	// questionContextMinItemChars is the smallest slice of budget worth spending on
+	// another item; below it the remaining items are dropped.
+	questionContextMinItemChars = 200
+)
+
+// QuestionContextRepositoryInterface finds solved content related to a question.
+type QuestionContextRepositoryInterface interface {
+	ListCrystallizedSolutions(ctx context.Context, questionID, title string, limit int) ([]models.ContextSolution, error)
+	ListSimilarAnsweredQuestions(ctx context.Context, questionID, title string, limit int) ([]models.ContextSolution, error)
+}
+
+// SetContextRepository enables related solutions in GET /v1/questions/{id}/context.
+// Without it the context holds only the question and its answers.
+func (h *QuestionsHandler) SetContextRepository(repo QuestionContextRepositoryInterface) {
+	h.contextRepo = repo
+}
+
+// GetContext handles GET /v1/questions/{id}/context?max_tokens=8000
+// Returns the question, its existing answers, related crystallized solutions and
+// similar answered questions in one payload sized to max_tokens, so an agent can
+// draft an answer without assembling its prompt from several calls.
+func (h *QuestionsHandler) GetContext(w http.ResponseWriter, r *http.Request) {
+	questionID := chi.URLParam(r, "id")
+	if questionID == "" {
+		apierror.Write(w, apierror.ValidationError, "question ID is required")
+		return
+	}
+
+	maxTokens := models.DefaultQuestionContextTokens
+	if v := r.URL.Query().Get("max_tokens"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < models.MinQuestionContextTokens || parsed > models.MaxQuestionContextTokens {
+			apierror.Write(w, apierror.ValidationError,
+				fmt.Sprintf("max_tokens must be an integer between %d and %d", models.MinQuestionContextTokens, models.MaxQuestionContextTokens))
+			return
+		}
+		maxTokens = parsed
+	}
+
+	qc, err := h.BuildQuestionContext(r.Context(), questionID, maxTokens)
+	if err != nil {
+		if errors.Is(err, ErrQuestionNotFound) {
+			apierror.Write(w, apierror.NotFound, "question not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to build question context")
+		return
+	}
+
+	writeQuestionsJSON(w, http.StatusOK, map[string]interface{}{"data": qc})
+}
+
+// BuildQuestionContext assembles the answer-drafting context for a question within
+// maxTokens. Returns ErrQuestionNotFound when the question is missing or not visible
+// to the caller. Also used by the MCP solvr_context tool.
+func (h *QuestionsHandler) BuildQuestionContext(ctx context.Context, questionID string, maxTokens int) (*models.QuestionContext, error) {
+	question, err := h.findQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, _, err := h.repo.ListAnswers(ctx, question.ID, models.AnswerListOptions{
+		QuestionID: question.ID, Page: 1, PerPage: models.QuestionContextAnswersLimit, Sort: "quality",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var crystallized, similar []models.ContextSolution
+	if h.contextRepo != nil {
+		crystallized, err = h.contextRepo.ListCrystallizedSolutions(ctx, question.ID, question.Title, models.QuestionContextRelatedLimit)
+		if err != nil {
+			return nil, err
+		}
+		similar, err = h.contextRepo.ListSimilarAnsweredQuestions(ctx, question.ID, question.Title, models.QuestionContextRelatedLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fitQuestionContext(question, answers, crystallized, similar, maxTokens), nil
+}
+
+// fitQuestionContext packs the pieces into maxTokens. The question comes first (its
+// description capped at half the budget), then existing answers, crystallized
+// solutions and similar questions, each text capped at questionContextItemChars.
+func fitQuestionContext(question *models.PostWithAuthor, answers []models.AnswerWithAuthor, crystallized, similar []models.ContextSolution, maxTokens int) *models.QuestionContext {
+	qc := &models.QuestionContext{
+		ExistingAnswers:       []models.QuestionContextAnswer{},
+		CrystallizedSolutions: []models.ContextSolution{},
+		SimilarQuestions:      []models.ContextSolution{},
+		TokenBudget:           maxTokens,
+	}
+	remaining := maxTokens * models.QuestionContextCharsPerToken
+
+	// take shortens text to at most limit runes and charges it to the budget.
+	take := func(text string, limit int) string {
+		if limit > remaining {
+			limit = remaining
+		}
+		if utf8.RuneCountInString(text) > limit {
+			text = string([]rune(text)[:limit])
+			qc.Truncated = true
+		}
+		remaining -= utf8.RuneCountInString(text)
+		return text
+	}
+	room := func() bool {
+		if remaining < questionContextMinItemChars {
+			qc.Truncated = true
+			return false
+		}
+		return true
+	}
+
+	tags := question.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	qc.Question = models.QuestionContextPost{
+		ID:           question.ID,
+		Title:        take(question.Title, remaining),
+		Tags:         tags,
+		Status:       string(question.Status),
+		AnswersCount: question.AnswersCount,
+	}
+	qc.Question.Description = take(question.Description, remaining/2)
+
+	for _, a := range answers {
+		if !room() {
+			break
+		}
+		author := a.Author.DisplayName
+		if author == "" {
+			author = a.Author.ID
+		}
+		qc.ExistingAnswers = append(qc.ExistingAnswers, models.QuestionContextAnswer{
+			ID:         a.ID,
+			Content:    take(a.Content, questionContextItemChars),
+			VoteScore:  a.VoteScore,
+			IsAccepted: a.IsAccepted,
+			AuthorName: author,
+		})
+	}
+
+	fitSolutions := func(in []models.ContextSolution) []models.ContextSolution {
+		out := []models.ContextSolution{}
+		for _, s := range in {
+			if !room() {
+				break
+			}
+			s.Title = take(s.Title, questionContextItemChars)
+			s.Solution = take(s.Solution, questionContextItemChars)
+			out = append(out, s)
+		}
+		return out
+	}
+	qc.CrystallizedSolutions = fitSolutions(crystallized)
+	qc.SimilarQuestions = fitSolutions(similar)
+
+	used := maxTokens*models.QuestionContextCharsPerToken - remaining
+	qc.EstimatedTokens = (used + models.QuestionContextCharsPerToken - 1) / models.QuestionContextCharsPerToken
+	return qc
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+type mockQuestionContextRepo struct {
+	crystallized []models.ContextSolution
+	similar      []models.ContextSolution
+	title        string
+}
+
+func (m *mockQuestionContextRepo) ListCrystallizedSolutions(ctx context.Context, questionID, title string, limit int) ([]models.ContextSolution, error) {
+	m.title = title
+	return m.crystallized, nil
+}
+
+func (m *mockQuestionContextRepo) ListSimilarAnsweredQuestions(ctx context.Context, questionID, title string, limit int) ([]models.ContextSolution, error) {
+	return m.similar, nil
+}
+
+func contextTestHandler() (*QuestionsHandler, *mockQuestionContextRepo) {
+	repo := NewMockQuestionsRepository()
+	repo.SetQuestion(&models.PostWithAuthor{Post: models.Post{
+		ID: "q-1", Type: models.PostTypeQuestion, Title: "How do I size a pgx pool?",
+		Description: "We run out of connections under load.", Tags: []string{"go"}, Status: models.PostStatusOpen,
+	}, AnswersCount: 1})
+	repo.SetAnswers([]models.AnswerWithAuthor{
+		{Answer: models.Answer{ID: "a-1", Content: "Start with MaxConns = 4 x CPUs."}, Author: models.AnswerAuthor{ID: "user-1", DisplayName: "Ana"}, VoteScore: 2},
+	})
+	contextRepo := &mockQuestionContextRepo{
+		crystallized: []models.ContextSolution{{PostID: "p-1", Title: "Pool exhaustion in workers", Solution: "Close rows with defer.", CrystallizationCID: "bafy1"}},
+		similar:      []models.ContextSolution{{PostID: "q-2", Title: "pgx MaxConns default?", Solution: "It is max(4, NumCPU).", Similarity: ptrFloat64(0.88)}},
+	}
+	handler := NewQuestionsHandler(repo)
+	handler.SetContextRepository(contextRepo)
+	return handler, contextRepo
+}
+
+func getQuestionContext(handler *QuestionsHandler, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/questions/q-1/context"+query, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "q-1")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+	handler.GetContext(w, req)
+	return w
+}
+
+func TestQuestionsGetContext_AssemblesPayload(t *testing.T) {
+	handler, contextRepo := contextTestHandler()
+
+	w := getQuestionContext(handler, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Data models.QuestionContext `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	qc := resp.Data
+	if qc.Question.ID != "q-1" || qc.Question.Description == "" {
+		t.Errorf("unexpected question %+v", qc.Question)
+	}
+	if len(qc.ExistingAnswers) != 1 || qc.ExistingAnswers[0].AuthorName != "Ana" {
+		t.Errorf("unexpected answers %+v", qc.ExistingAnswers)
+	}
+	if len(qc.CrystallizedSolutions) != 1 || qc.CrystallizedSolutions[0].CrystallizationCID != "bafy1" {
+		t.Errorf("unexpected crystallized solutions %+v", qc.CrystallizedSolutions)
+	}
+	if len(qc.SimilarQuestions) != 1 || qc.SimilarQuestions[0].PostID != "q-2" {
+		t.Errorf("unexpected similar questions %+v", qc.SimilarQuestions)
+	}
+	if qc.TokenBudget != models.DefaultQuestionContextTokens || qc.EstimatedTokens <= 0 || qc.Truncated {
+		t.Errorf("unexpected budget fields: budget=%d estimated=%d truncated=%v", qc.TokenBudget, qc.EstimatedTokens, qc.Truncated)
+	}
+	if contextRepo.title != "How do I size a pgx pool?" {
+		t.Errorf("expected related lookups keyed on the question title, got %q", contextRepo.title)
+	}
+}
+
+func TestQuestionsGetContext_Validation(t *testing.T) {
+	handler, _ := contextTestHandler()
+	for _, query := range []string{"?max_tokens=abc", "?max_tokens=10", "?max_tokens=1000000"} {
+		if w := getQuestionContext(handler, query); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+
+	missing := NewQuestionsHandler(NewMockQuestionsRepository())
+	if w := getQuestionContext(missing, ""); w.Code != http.StatusNotFound {
+		t.Errorf("missing question: expected 404, got %d", w.Code)
+	}
+}
+
+func TestFitQuestionContext_RespectsBudget(t *testing.T) {
+	question := &models.PostWithAuthor{Post: models.Post{ID: "q-1", Title: "t", Description: strings.Repeat("d", 10000)}}
+	answers := make([]models.AnswerWithAuthor, 10)
+	for i := range answers {
+		answers[i] = models.AnswerWithAuthor{Answer: models.Answer{ID: "a", Content: strings.Repeat("a", 3000)}}
+	}
+	similar := []models.ContextSolution{{PostID: "q-2", Title: "s", Solution: strings.Repeat("s", 3000)}}
+
+	qc := fitQuestionContext(question, answers, nil, similar, models.MinQuestionContextTokens)
+
+	if !qc.Truncated {
+		t.Error("expected truncated context")
+	}
+	if qc.EstimatedTokens > models.MinQuestionContextTokens {
+		t.Errorf("estimated %d tokens, budget %d", qc.EstimatedTokens, models.MinQuestionContextTokens)
+	}
+	if got := len(qc.Question.Description); got > models.MinQuestionContextTokens*models.QuestionContextCharsPerToken/2 {
+		t.Errorf("expected description capped at half the budget, got %d chars", got)
+	}
+	if len(qc.ExistingAnswers) == 0 || len(qc.ExistingAnswers) == len(answers) {
+		t.Errorf("expected some but not all answers, got %d", len(qc.ExistingAnswers))
+	}
+	if len(qc.SimilarQuestions) != 0 {
+		t.Errorf("expected similar questions dropped once the budget ran out, got %d", len(qc.SimilarQuestions))
+	}
+}
+
+func TestMCPExecuteContext(t *testing.T) {
+	questionsHandler, _ := contextTestHandler()
+	handler := NewMCPHandler(NewMockSearchRepository(), nil)
+
+	if _, err := handler.executeContext(context.Background(), map[string]interface{}{"question_id": "q-1"}); err == nil {
+		t.Error("expected an error when no context builder is configured")
+	}
+
+	handler.SetQuestionContextBuilder(questionsHandler)
+	res, err := handler.executeContext(context.Background(), map[string]interface{}{"question_id": "q-1", "max_tokens": float64(2000)})
+	if err != nil {
+		t.Fatalf("executeContext failed: %v", err)
+	}
+	text := mcpResultText(t, res)
+	for _, want := range []string{"# Question: How do I size a pgx pool?", "## Existing answers (1)", "Close rows with defer.", "Similarity: 88%"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in context, got:\n%s", want, text)
+		}
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
@@ -52,20 +54,57 @@ type QuestionsRepositoryInterface interface {
 
 // QuestionsHandler handles question-related HTTP requests.
 type QuestionsHandler struct {
-	repo             QuestionsRepositoryInterface
-	postsRepo        PostsRepositoryInterface // For listing questions (shares data with /v1/posts)
-	embeddingService EmbeddingServiceInterface
-	logger           *slog.Logger
+	repo                QuestionsRepositoryInterface
+	postsRepo           PostsRepositoryInterface // For listing questions (shares data with /v1/posts)
+	embeddingService    EmbeddingServiceInterface
+	searchRepo          SearchBackend                      // For GET /v1/questions/suggest
+	contextRepo         QuestionContextRepositoryInterface // For GET /v1/questions/{id}/context
+	versionsRepo        AnswerVersionsRepositoryInterface  // For GET /v1/answers/{id}/versions
+	emailNotifier       AnswerAcceptedNotifier
+	publishedNotifier   PostPublishedNotifier
+	crashDuplicates     CrashDuplicateFinder
+	confidenceThreshold float64
+	logger              *slog.Logger
+
+	answerDuplicates      AnswerDuplicateFinder // For possible_duplicates on POST /v1/questions/{id}/answers
+	answerDuplicateConfig models.AnswerDuplicateConfig
+	contentLimits         contentLimitsSource // see content_limits.go
+
+	answerModeration   ContentModerationServiceInterface // see answer_remoderation.go
+	answerReviews      AnswerReviewStore                 // see answer_remoderation.go
+	answerRemoderation models.RemoderationConfig
+	mentionNotifier    MentionNotifier // see mentions.go
 }
 
 // NewQuestionsHandler creates a new QuestionsHandler.
 func NewQuestionsHandler(repo QuestionsRepositoryInterface) *QuestionsHandler {
 	return &QuestionsHandler{
-		repo:   repo,
-		logger: slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+		repo:                repo,
+		confidenceThreshold: DefaultSearchConfidenceThreshold,
+		logger:              slog.New(slog.NewJSONHandler(os.Stderr, nil)),
 	}
 }
 
+// AnswerAcceptedNotifier emails an answer's author when their answer is accepted.
+type AnswerAcceptedNotifier interface {
+	NotifyAnswerAccepted(ctx context.Context, question *models.PostWithAuthor, answer *models.AnswerWithAuthor) error
+}
+
+// SetEmailNotifier enables the answer-accepted email.
+func (h *QuestionsHandler) SetEmailNotifier(notifier AnswerAcceptedNotifier) {
+	h.emailNotifier = notifier
+}
+
+// SetPostPublishedNotifier announces new questions to chat integrations.
+func (h *QuestionsHandler) SetPostPublishedNotifier(notifier PostPublishedNotifier) {
+	h.publishedNotifier = notifier
+}
+
+// SetCrashDuplicateFinder lists posts with the same stack trace when a question is created.
+func (h *QuestionsHandler) SetCrashDuplicateFinder(finder CrashDuplicateFinder) {
+	h.crashDuplicates = finder
+}
+
 // SetEmbeddingService sets the embedding service for generating answer embeddings.
 // When set, answer creation and updates will generate and store embeddings for semantic search.
 func (h *QuestionsHandler) SetEmbeddingService(svc EmbeddingServiceInterface) {
@@ -87,7 +126,7 @@ func (h *QuestionsHandler) findQuestion(ctx context.Context, id string) (*models
 	// First try postsRepo if available (this is where POST /v1/posts stores questions)
 	if h.postsRepo != nil {
 		// BART-151: family-scoped — a family caller sees its own private question; others 404.
-		question, err := h.postsRepo.FindByIDForViewer(ctx, id, "", "", callerHumanFromCtx(ctx))
+		question, err := h.postsRepo.FindByIDForViewer(ctx, id)
 		if err == nil {
 			// Verify it's actually a question
 			if question.Type != models.PostTypeQuestion {
@@ -147,6 +186,7 @@ type CreateQuestionRequest struct {
 	Title       string   `json:"title"`
 	Description string   `json:"description"`
 	Tags        []string `json:"tags,omitempty"`
+	TemplateID  string   `json:"template_id,omitempty"` // See GET /v1/templates
 }
 
 // Note: VoteRequest is defined in posts.go and shared across handlers.
@@ -214,7 +254,7 @@ func (h *QuestionsHandler) List(w http.ResponseWriter, r *http.Request) {
 		questions, total, err = h.repo.ListQuestions(r.Context(), opts)
 	}
 	if err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list questions")
+		apierror.Write(w, apierror.InternalError, "failed to list questions")
 		return
 	}
 
@@ -239,7 +279,7 @@ func (h *QuestionsHandler) List(w http.ResponseWriter, r *http.Request) {
 func (h *QuestionsHandler) Get(w http.ResponseWriter, r *http.Request) {
 	questionID := chi.URLParam(r, "id")
 	if questionID == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "question ID is required")
+		apierror.Write(w, apierror.ValidationError, "question ID is required")
 		return
 	}
 
@@ -247,23 +287,24 @@ func (h *QuestionsHandler) Get(w http.ResponseWriter, r *http.Request) {
 	question, err := h.findQuestion(r.Context(), questionID)
 	if err != nil {
 		if errors.Is(err, ErrQuestionNotFound) {
-			writeQuestionsError(w, http.StatusNotFound, "NOT_FOUND", "question not found")
+			apierror.Write(w, apierror.NotFound, "question not found")
 			return
 		}
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get question")
+		apierror.Write(w, apierror.InternalError, "failed to get question")
 		return
 	}
 
 	// Type and deletion checks are now done in findQuestion()
 
 	// Get answers for the question
-	answers, _, err := h.repo.ListAnswers(r.Context(), questionID, models.AnswerListOptions{
+	opts := models.AnswerListOptions{
 		QuestionID: questionID,
 		Page:       1,
 		PerPage:    100, // Get up to 100 answers
-	})
+	}
+	answers, _, err := h.repo.ListAnswers(r.Context(), questionID, opts)
 	if err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get answers")
+		apierror.Write(w, apierror.InternalError, "failed to get answers")
 		return
 	}
 
@@ -289,7 +330,7 @@ type AnswersListResponse struct {
 func (h *QuestionsHandler) ListAnswers(w http.ResponseWriter, r *http.Request) {
 	questionID := chi.URLParam(r, "id")
 	if questionID == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "question ID is required")
+		apierror.Write(w, apierror.ValidationError, "question ID is required")
 		return
 	}
 
@@ -297,10 +338,10 @@ func (h *QuestionsHandler) ListAnswers(w http.ResponseWriter, r *http.Request) {
 	_, err := h.findQuestion(r.Context(), questionID)
 	if err != nil {
 		if errors.Is(err, ErrQuestionNotFound) {
-			writeQuestionsError(w, http.StatusNotFound, "NOT_FOUND", "question not found")
+			apierror.Write(w, apierror.NotFound, "question not found")
 			return
 		}
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get question")
+		apierror.Write(w, apierror.InternalError, "failed to get question")
 		return
 	}
 
@@ -313,6 +354,14 @@ func (h *QuestionsHandler) ListAnswers(w http.ResponseWriter, r *http.Request) {
 		PerPage:    parseQuestionsIntParam(r.URL.Query().Get("per_page"), 20),
 	}
 
+	// Parse sort: "top" orders by vote score, "verified-first" puts the accepted
+	// answer first, "quality" orders by the answer quality job's score
+	switch sortParam := r.URL.Query().Get("sort"); sortParam {
+	case "newest", "oldest", "top", "verified-first", "quality":
+		opts.Sort = sortParam
+	}
+	// Invalid values are silently ignored (defaults to newest)
+
 	if opts.Page < 1 {
 		opts.Page = 1
 	}
@@ -326,7 +375,7 @@ func (h *QuestionsHandler) ListAnswers(w http.ResponseWriter, r *http.Request) {
 	// Get answers for the question
 	answers, total, err := h.repo.ListAnswers(r.Context(), questionID, opts)
 	if err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list answers")
+		apierror.Write(w, apierror.InternalError, "failed to list answers")
 		return
 	}
 
@@ -352,44 +401,43 @@ func (h *QuestionsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeQuestionsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse request body
 	var req CreateQuestionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
-	// Validate title
+	// Validate title and description against the configured limits
 	if req.Title == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title is required")
-		return
-	}
-	if len(req.Title) < 10 {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at least 10 characters")
+		apierror.Write(w, apierror.ValidationError, "title is required")
 		return
 	}
-	if len(req.Title) > 200 {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "title must be at most 200 characters")
-		return
-	}
-
-	// Validate description
 	if req.Description == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description is required")
+		apierror.Write(w, apierror.ValidationError, "description is required")
 		return
 	}
-	if len(req.Description) < 50 {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "description must be at least 50 characters")
+	var lengthCheck Validator
+	validatePostLengths(&lengthCheck, h.contentLimits.For(models.PostTypeQuestion), &req.Title, &req.Description, nil)
+	if !lengthCheck.Valid() {
+		apierror.Write(w, apierror.ValidationError, lengthCheck.Errors()[0].Message)
 		return
 	}
 
 	// Validate tags
 	if len(req.Tags) > models.MaxTagsPerPost {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", fmt.Sprintf("maximum %d tags allowed", models.MaxTagsPerPost))
+		apierror.Write(w, apierror.ValidationError, fmt.Sprintf("maximum %d tags allowed", models.MaxTagsPerPost))
+		return
+	}
+	var tagCheck Validator
+	req.Tags = validateTags(&tagCheck, req.Tags)
+	validatePostTemplate(&tagCheck, req.TemplateID, models.PostTypeQuestion, req.Description)
+	if !tagCheck.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, tagCheck.Errors())
 		return
 	}
 
@@ -406,13 +454,13 @@ func (h *QuestionsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	createdPost, err := h.repo.CreateQuestion(r.Context(), post)
 	if err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create question")
+		apierror.Write(w, apierror.InternalError, "failed to create question")
 		return
 	}
+	announcePostAsync(r.Context(), h.publishedNotifier, createdPost, h.logger)
 
-	writeQuestionsJSON(w, http.StatusCreated, map[string]interface{}{
-		"data": createdPost,
-	})
+	duplicates := findCrashDuplicates(r.Context(), h.crashDuplicates, createdPost, h.logger)
+	writeQuestionsJSON(w, http.StatusCreated, createdPostResponse(createdPost, duplicates))
 }
 
 // CreateAnswer handles POST /v1/questions/:id/answers - create a new answer.
@@ -422,24 +470,28 @@ func (h *QuestionsHandler) CreateAnswer(w http.ResponseWriter, r *http.Request)
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeQuestionsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	questionID := chi.URLParam(r, "id")
 	if questionID == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "question ID is required")
+		apierror.Write(w, apierror.ValidationError, "question ID is required")
 		return
 	}
 
 	// FIX-023: Use findQuestion() which checks postsRepo first, then falls back to questionsRepo
-	_, err := h.findQuestion(r.Context(), questionID)
+	question, err := h.findQuestion(r.Context(), questionID)
 	if err != nil {
 		if errors.Is(err, ErrQuestionNotFound) {
-			writeQuestionsError(w, http.StatusNotFound, "NOT_FOUND", "question not found")
+			apierror.Write(w, apierror.NotFound, "question not found")
 			return
 		}
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get question")
+		apierror.Write(w, apierror.InternalError, "failed to get question")
+		return
+	}
+	if question.Status == models.PostStatusRejected {
+		apierror.Write(w, apierror.ModerationRejected, "question was rejected by moderation and takes no answers until it is edited and approved")
 		return
 	}
 
@@ -448,27 +500,30 @@ func (h *QuestionsHandler) CreateAnswer(w http.ResponseWriter, r *http.Request)
 	// Parse request body
 	var req models.CreateAnswerRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
-	// Validate content
-	if req.Content == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content is required")
+	var v Validator
+	if v.Required("content", req.Content) {
+		v.Length("content", req.Content, 0, models.MaxAnswerContentLength)
+	}
+	if !v.Valid() {
+		writeFieldErrors(w, apierror.ValidationError, v.Errors())
 		return
 	}
-	if len(req.Content) > 30000 {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content must be at most 30000 characters")
+	if req.SupersedesAnswerID != nil && !h.checkSupersededAnswer(w, r, questionID, *req.SupersedesAnswerID) {
 		return
 	}
 
 	// Create answer with author info from authentication
 	answer := &models.Answer{
-		QuestionID: questionID,
-		AuthorType: authInfo.AuthorType,
-		AuthorID:   authInfo.AuthorID,
-		Content:    req.Content,
-		IsAccepted: false,
+		QuestionID:         questionID,
+		AuthorType:         authInfo.AuthorType,
+		AuthorID:           authInfo.AuthorID,
+		Content:            req.Content,
+		IsAccepted:         false,
+		SupersedesAnswerID: req.SupersedesAnswerID,
 	}
 
 	// Generate embedding for semantic search
@@ -485,15 +540,27 @@ func (h *QuestionsHandler) CreateAnswer(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	// Near-identical answers are rejected; close ones are returned as possible_duplicates
+	duplicates := h.findAnswerDuplicates(r.Context(), answer)
+	if len(duplicates) > 0 && duplicates[0].Similarity >= h.answerDuplicateConfig.RejectThreshold {
+		writeAnswerDuplicate(w, duplicates[0])
+		return
+	}
+
 	createdAnswer, err := h.repo.CreateAnswer(r.Context(), answer)
 	if err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create answer")
+		// Another answer superseded the same version since it was checked
+		if errors.Is(err, db.ErrAnswerAlreadySuperseded) {
+			writeAnswerAlreadySuperseded(w, "")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to create answer")
 		return
 	}
 
-	writeQuestionsJSON(w, http.StatusCreated, map[string]interface{}{
-		"data": createdAnswer,
-	})
+	notifyMentionsAsync(r.Context(), h.mentionNotifier, createdAnswer.Content, createdAnswer.AuthorType, createdAnswer.AuthorID, answerLink(createdAnswer), h.logger)
+
+	writeQuestionsJSON(w, http.StatusCreated, createdAnswerResponse(createdAnswer, duplicates))
 }
 
 // UpdateAnswer handles PATCH /v1/answers/:id - update an answer.
@@ -502,47 +569,56 @@ func (h *QuestionsHandler) UpdateAnswer(w http.ResponseWriter, r *http.Request)
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeQuestionsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	answerID := chi.URLParam(r, "id")
 	if answerID == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "answer ID is required")
+		apierror.Write(w, apierror.ValidationError, "answer ID is required")
 		return
 	}
 
 	// Get existing answer
 	existingAnswer, err := h.repo.FindAnswerByID(r.Context(), answerID)
 	if err != nil {
-		if errors.Is(err, ErrAnswerNotFound) {
-			writeQuestionsError(w, http.StatusNotFound, "NOT_FOUND", "answer not found")
+		if errors.Is(err, ErrAnswerNotFound) || errors.Is(err, db.ErrAnswerNotFound) {
+			apierror.Write(w, apierror.NotFound, "answer not found")
 			return
 		}
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get answer")
+		apierror.Write(w, apierror.InternalError, "failed to get answer")
 		return
 	}
 
 	// Check ownership - only author can update (works for both humans and agents)
 	if existingAnswer.AuthorType != authInfo.AuthorType || existingAnswer.AuthorID != authInfo.AuthorID {
-		writeQuestionsError(w, http.StatusForbidden, "FORBIDDEN", "you can only update your own answers")
+		apierror.Write(w, apierror.Forbidden, "you can only update your own answers")
 		return
 	}
 
 	// Parse request body
 	var req models.UpdateAnswerRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+
+	// Optimistic concurrency: the client edited a version that is no longer current
+	if req.ExpectedUpdatedAt != nil && !req.ExpectedUpdatedAt.Equal(existingAnswer.UpdatedAt) {
+		writeAnswerConflict(w, existingAnswer)
 		return
 	}
 
-	// Apply updates
+	// Apply updates. updatedAnswer keeps existingAnswer.UpdatedAt, so the repository only
+	// applies the update if nobody else has modified the answer since it was read.
 	updatedAnswer := existingAnswer.Answer
 	contentChanged := false
 
 	if req.Content != nil {
-		if len(*req.Content) > 30000 {
-			writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content must be at most 30000 characters")
+		var v Validator
+		v.Length("content", *req.Content, 0, models.MaxAnswerContentLength)
+		if !v.Valid() {
+			writeFieldErrors(w, apierror.ValidationError, v.Errors())
 			return
 		}
 		updatedAnswer.Content = *req.Content
@@ -564,11 +640,23 @@ func (h *QuestionsHandler) UpdateAnswer(w http.ResponseWriter, r *http.Request)
 	}
 
 	result, err := h.repo.UpdateAnswer(r.Context(), &updatedAnswer)
+	if errors.Is(err, db.ErrVersionConflict) {
+		current, findErr := h.repo.FindAnswerByID(r.Context(), answerID)
+		if findErr == nil {
+			writeAnswerConflict(w, current)
+			return
+		}
+	}
 	if err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update answer")
+		apierror.Write(w, apierror.InternalError, "failed to update answer")
 		return
 	}
 
+	// Large edits are moderated again; drastic ones hide the answer meanwhile
+	if contentChanged {
+		result.HeldForReview = h.remoderateAnswerEdit(r.Context(), existingAnswer, result.Content)
+	}
+
 	writeQuestionsJSON(w, http.StatusOK, map[string]interface{}{
 		"data": result,
 	})
@@ -580,24 +668,24 @@ func (h *QuestionsHandler) DeleteAnswer(w http.ResponseWriter, r *http.Request)
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeQuestionsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	answerID := chi.URLParam(r, "id")
 	if answerID == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "answer ID is required")
+		apierror.Write(w, apierror.ValidationError, "answer ID is required")
 		return
 	}
 
 	// Get existing answer
 	existingAnswer, err := h.repo.FindAnswerByID(r.Context(), answerID)
 	if err != nil {
-		if errors.Is(err, ErrAnswerNotFound) {
-			writeQuestionsError(w, http.StatusNotFound, "NOT_FOUND", "answer not found")
+		if errors.Is(err, ErrAnswerNotFound) || errors.Is(err, db.ErrAnswerNotFound) {
+			apierror.Write(w, apierror.NotFound, "answer not found")
 			return
 		}
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get answer")
+		apierror.Write(w, apierror.InternalError, "failed to get answer")
 		return
 	}
 
@@ -606,13 +694,13 @@ func (h *QuestionsHandler) DeleteAnswer(w http.ResponseWriter, r *http.Request)
 	isAdmin := authInfo.Role == "admin"
 
 	if !isOwner && !isAdmin {
-		writeQuestionsError(w, http.StatusForbidden, "FORBIDDEN", "you can only delete your own answers")
+		apierror.Write(w, apierror.Forbidden, "you can only delete your own answers")
 		return
 	}
 
 	// Delete answer
 	if err := h.repo.DeleteAnswer(r.Context(), answerID); err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to delete answer")
+		apierror.Write(w, apierror.InternalError, "failed to delete answer")
 		return
 	}
 
@@ -625,48 +713,75 @@ func (h *QuestionsHandler) VoteOnAnswer(w http.ResponseWriter, r *http.Request)
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeQuestionsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	answerID := chi.URLParam(r, "id")
 	if answerID == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "answer ID is required")
+		apierror.Write(w, apierror.ValidationError, "answer ID is required")
 		return
 	}
 
 	// Parse request body
 	var req VoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid JSON body")
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
 		return
 	}
 
-	// Validate direction
-	if req.Direction != "up" && req.Direction != "down" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "direction must be 'up' or 'down'")
+	// Validate direction ("none" retracts, same as DELETE /v1/answers/:id/vote)
+	if req.Direction != "up" && req.Direction != "down" && req.Direction != db.VoteDirectionNone {
+		apierror.Write(w, apierror.ValidationError, "direction must be 'up', 'down' or 'none'")
 		return
 	}
 
+	h.recordAnswerVote(w, r, authInfo, answerID, req.Direction)
+}
+
+// RetractAnswerVote handles DELETE /v1/answers/:id/vote - remove the caller's vote on an answer.
+// Idempotent: succeeds even if the caller had not voted.
+func (h *QuestionsHandler) RetractAnswerVote(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	answerID := chi.URLParam(r, "id")
+	if answerID == "" {
+		apierror.Write(w, apierror.ValidationError, "answer ID is required")
+		return
+	}
+
+	h.recordAnswerVote(w, r, authInfo, answerID, db.VoteDirectionNone)
+}
+
+// recordAnswerVote applies a vote (or retraction, direction "none") on an answer.
+func (h *QuestionsHandler) recordAnswerVote(w http.ResponseWriter, r *http.Request, authInfo *AuthInfo, answerID, direction string) {
 	// Verify answer exists
 	_, err := h.repo.FindAnswerByID(r.Context(), answerID)
 	if err != nil {
-		if errors.Is(err, ErrAnswerNotFound) {
-			writeQuestionsError(w, http.StatusNotFound, "NOT_FOUND", "answer not found")
+		if errors.Is(err, ErrAnswerNotFound) || errors.Is(err, db.ErrAnswerNotFound) {
+			apierror.Write(w, apierror.NotFound, "answer not found")
 			return
 		}
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get answer")
+		apierror.Write(w, apierror.InternalError, "failed to get answer")
 		return
 	}
 
 	// Record vote with appropriate voter type
-	if err := h.repo.VoteOnAnswer(r.Context(), answerID, string(authInfo.AuthorType), authInfo.AuthorID, req.Direction); err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to record vote")
+	if err := h.repo.VoteOnAnswer(r.Context(), answerID, string(authInfo.AuthorType), authInfo.AuthorID, direction); err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to record vote")
 		return
 	}
 
+	message := "vote recorded"
+	if direction == db.VoteDirectionNone {
+		message = "vote removed"
+	}
 	writeQuestionsJSON(w, http.StatusOK, map[string]interface{}{
-		"message": "vote recorded",
+		"message": message,
 	})
 }
 
@@ -677,7 +792,7 @@ func (h *QuestionsHandler) AcceptAnswer(w http.ResponseWriter, r *http.Request)
 	// Require authentication (JWT or API key)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeQuestionsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
@@ -685,11 +800,11 @@ func (h *QuestionsHandler) AcceptAnswer(w http.ResponseWriter, r *http.Request)
 	answerID := chi.URLParam(r, "aid")
 
 	if questionID == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "question ID is required")
+		apierror.Write(w, apierror.ValidationError, "question ID is required")
 		return
 	}
 	if answerID == "" {
-		writeQuestionsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "answer ID is required")
+		apierror.Write(w, apierror.ValidationError, "answer ID is required")
 		return
 	}
 
@@ -697,36 +812,43 @@ func (h *QuestionsHandler) AcceptAnswer(w http.ResponseWriter, r *http.Request)
 	question, err := h.findQuestion(r.Context(), questionID)
 	if err != nil {
 		if errors.Is(err, ErrQuestionNotFound) {
-			writeQuestionsError(w, http.StatusNotFound, "NOT_FOUND", "question not found")
+			apierror.Write(w, apierror.NotFound, "question not found")
 			return
 		}
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get question")
+		apierror.Write(w, apierror.InternalError, "failed to get question")
 		return
 	}
 
 	// Check ownership - only question owner can accept (works for both humans and agents)
 	if question.PostedByType != authInfo.AuthorType || question.PostedByID != authInfo.AuthorID {
-		writeQuestionsError(w, http.StatusForbidden, "FORBIDDEN", "only the question owner can accept answers")
+		apierror.Write(w, apierror.Forbidden, "only the question owner can accept answers")
 		return
 	}
 
 	// Verify answer exists
-	_, err = h.repo.FindAnswerByID(r.Context(), answerID)
+	answer, err := h.repo.FindAnswerByID(r.Context(), answerID)
 	if err != nil {
-		if errors.Is(err, ErrAnswerNotFound) {
-			writeQuestionsError(w, http.StatusNotFound, "NOT_FOUND", "answer not found")
+		if errors.Is(err, ErrAnswerNotFound) || errors.Is(err, db.ErrAnswerNotFound) {
+			apierror.Write(w, apierror.NotFound, "answer not found")
 			return
 		}
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get answer")
+		apierror.Write(w, apierror.InternalError, "failed to get answer")
 		return
 	}
 
 	// Accept the answer
 	if err := h.repo.AcceptAnswer(r.Context(), questionID, answerID); err != nil {
-		writeQuestionsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to accept answer")
+		apierror.Write(w, apierror.InternalError, "failed to accept answer")
 		return
 	}
 
+	// Email the answer's author; a queueing failure doesn't undo the accept.
+	if h.emailNotifier != nil && answer.AuthorID != authInfo.AuthorID {
+		if err := h.emailNotifier.NotifyAnswerAccepted(r.Context(), question, answer); err != nil {
+			h.logger.Warn("failed to queue answer accepted email", "question_id", questionID, "answer_id", answerID, "error", err)
+		}
+	}
+
 	writeQuestionsJSON(w, http.StatusOK, map[string]interface{}{
 		"message":   "answer accepted",
 		"answer_id": answerID,
@@ -752,14 +874,15 @@ func writeQuestionsJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
-// writeQuestionsError writes an error JSON response.
-func writeQuestionsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
+// writeAnswerConflict writes 409 Conflict for an answer update based on a stale version,
+// echoing the current answer so the client can merge and retry.
+func writeAnswerConflict(w http.ResponseWriter, current *models.AnswerWithAuthor) {
+	writeQuestionsJSON(w, http.StatusConflict, map[string]interface{}{
 		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
+			"code":               apierror.Conflict,
+			"message":            "answer was modified by another request; reapply your changes to the current version",
+			"current_updated_at": current.UpdatedAt,
 		},
+		"data": current,
 	})
 }
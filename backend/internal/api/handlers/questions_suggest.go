@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// SetSearchRepository enables GET /v1/questions/suggest.
+func (h *QuestionsHandler) SetSearchRepository(repo SearchBackend) {
+	h.searchRepo = repo
+}
+
+// SetConfidenceThreshold sets the cosine-similarity bar above which a suggestion is
+// flagged as a likely duplicate (from SEARCH_CONFIDENCE_THRESHOLD).
+func (h *QuestionsHandler) SetConfidenceThreshold(threshold float64) {
+	h.confidenceThreshold = threshold
+}
+
+// Suggest handles GET /v1/questions/suggest?title=...&limit=5
+// Runs a hybrid search over questions while the user types a title and returns
+// likely duplicates with their answer counts. Titles shorter than
+// models.QuestionSuggestMinTitleLength return an empty list.
+func (h *QuestionsHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	title := strings.TrimSpace(r.URL.Query().Get("title"))
+	if title == "" {
+		apierror.Write(w, apierror.ValidationError, "title is required")
+		return
+	}
+
+	limit := parseQuestionsIntParam(r.URL.Query().Get("limit"), models.QuestionSuggestDefaultLimit)
+	if limit < 1 {
+		limit = models.QuestionSuggestDefaultLimit
+	}
+	if limit > models.QuestionSuggestMaxLimit {
+		limit = models.QuestionSuggestMaxLimit
+	}
+
+	suggestions := []models.QuestionSuggestion{}
+	method := ""
+	if h.searchRepo != nil && utf8.RuneCountInString(title) >= models.QuestionSuggestMinTitleLength {
+		var err error
+		suggestions, method, err = suggestSimilarQuestions(r.Context(), h.searchRepo, title, limit, h.confidenceThreshold)
+		if err != nil {
+			apierror.Write(w, apierror.InternalError, "failed to suggest questions")
+			return
+		}
+	}
+
+	writeQuestionsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": suggestions,
+		"meta": map[string]interface{}{
+			"method": method,
+		},
+	})
+}
+
+// suggestSimilarQuestions returns up to limit existing questions matching a draft
+// title, best first. Shared by GET /v1/questions/suggest and the MCP solvr_post
+// pre-check so both flag duplicates the same way.
+func suggestSimilarQuestions(ctx context.Context, repo SearchBackend, title string, limit int, threshold float64) ([]models.QuestionSuggestion, string, error) {
+	results, _, method, _, err := repo.Search(ctx, title, models.SearchOptions{
+		Type:    string(models.PostTypeQuestion),
+		Page:    1,
+		PerPage: limit,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	suggestions := make([]models.QuestionSuggestion, 0, len(results))
+	for _, res := range results {
+		suggestions = append(suggestions, models.QuestionSuggestion{
+			ID:              res.ID,
+			Title:           res.Title,
+			Status:          res.Status,
+			AnswersCount:    res.AnswersCount,
+			VoteScore:       res.VoteScore,
+			CreatedAt:       res.CreatedAt,
+			Similarity:      res.Similarity,
+			LikelyDuplicate: models.IsConfidentMatch(res.Similarity, threshold),
+		})
+	}
+	return suggestions, method, nil
+}
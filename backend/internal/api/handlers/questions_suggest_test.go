@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestQuestionsSuggest_ReturnsLikelyDuplicates(t *testing.T) {
+	repo := NewMockSearchRepository()
+	repo.SetMethod("hybrid_rrf")
+	repo.SetResults([]models.SearchResult{
+		{ID: "q1", Type: "question", Title: "How do I size a pgx pool?", Status: "answered", AnswersCount: 4, Similarity: ptrFloat64(0.91)},
+		{ID: "q2", Type: "question", Title: "pgx pool vs database/sql", Status: "open", AnswersCount: 0, Similarity: ptrFloat64(0.6)},
+	}, 2)
+	handler := NewQuestionsHandler(NewMockQuestionsRepository())
+	handler.SetSearchRepository(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/questions/suggest?title=how+to+size+pgx+pool&limit=50", nil)
+	w := httptest.NewRecorder()
+	handler.Suggest(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.searchOpts.Type != "question" || repo.searchOpts.PerPage != models.QuestionSuggestMaxLimit {
+		t.Errorf("expected question search capped at %d, got %+v", models.QuestionSuggestMaxLimit, repo.searchOpts)
+	}
+
+	var resp struct {
+		Data []models.QuestionSuggestion `json:"data"`
+		Meta struct {
+			Method string `json:"method"`
+		} `json:"meta"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Meta.Method != "hybrid_rrf" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if !resp.Data[0].LikelyDuplicate || resp.Data[0].AnswersCount != 4 {
+		t.Errorf("expected q1 flagged as likely duplicate with 4 answers, got %+v", resp.Data[0])
+	}
+	if resp.Data[1].LikelyDuplicate {
+		t.Errorf("expected q2 below threshold, got %+v", resp.Data[1])
+	}
+}
+
+func TestQuestionsSuggest_ShortTitleSkipsSearch(t *testing.T) {
+	repo := NewMockSearchRepository()
+	handler := NewQuestionsHandler(NewMockQuestionsRepository())
+	handler.SetSearchRepository(repo)
+
+	w := httptest.NewRecorder()
+	handler.Suggest(w, httptest.NewRequest(http.MethodGet, "/v1/questions/suggest?title=pgx", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if repo.searchQuery != "" {
+		t.Errorf("expected no search for a short title, searched %q", repo.searchQuery)
+	}
+	if !strings.Contains(w.Body.String(), `"data":[]`) {
+		t.Errorf("expected empty data, got %s", w.Body.String())
+	}
+}
+
+func TestQuestionsSuggest_Errors(t *testing.T) {
+	handler := NewQuestionsHandler(NewMockQuestionsRepository())
+	w := httptest.NewRecorder()
+	handler.Suggest(w, httptest.NewRequest(http.MethodGet, "/v1/questions/suggest", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("missing title: expected 400, got %d", w.Code)
+	}
+
+	repo := NewMockSearchRepository()
+	repo.SetError(errors.New("db down"))
+	handler.SetSearchRepository(repo)
+	w = httptest.NewRecorder()
+	handler.Suggest(w, httptest.NewRequest(http.MethodGet, "/v1/questions/suggest?title=connection+pool+exhausted", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("search error: expected 500, got %d", w.Code)
+	}
+}
+
+func TestMCPExecutePost_ListsSimilarQuestions(t *testing.T) {
+	repo := NewMockSearchRepository()
+	repo.SetResults([]models.SearchResult{
+		{ID: "q1", Type: "question", Title: "How do I size a pgx pool?", Status: "answered", AnswersCount: 4, Similarity: ptrFloat64(0.93)},
+	}, 1)
+	handler := NewMCPHandler(repo, nil)
+
+	res, err := handler.executePost(context.Background(), map[string]interface{}{
+		"type": "question", "title": "How should I size my pgx pool?", "description": "Under load we run out.",
+	})
+	if err != nil {
+		t.Fatalf("executePost failed: %v", err)
+	}
+	text := mcpResultText(t, res)
+	if !strings.Contains(text, "Similar questions already on Solvr") || !strings.Contains(text, "ID: q1") || !strings.Contains(text, "Likely duplicate") {
+		t.Errorf("expected similar-question pre-check, got:\n%s", text)
+	}
+
+	repo.searchQuery = ""
+	if _, err := handler.executePost(context.Background(), map[string]interface{}{
+		"type": "idea", "title": "A pool autoscaler for pgx", "description": "Idea.",
+	}); err != nil {
+		t.Fatalf("executePost failed: %v", err)
+	}
+	if repo.searchQuery != "" {
+		t.Errorf("expected no pre-check for ideas, searched %q", repo.searchQuery)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
+
+	"strings"
 )
 
 // Note: ErrQuestionNotFound and ErrAnswerNotFound are defined in errors.go
@@ -27,6 +29,7 @@ type MockQuestionsRepository struct {
 	answers         []models.AnswerWithAuthor
 	answer          *models.AnswerWithAuthor
 	answersErr      error
+	answerListOpts  models.AnswerListOptions
 	createdPost     *models.Post
 	createdAnswer   *models.Answer
 	updatedAnswer   *models.Answer
@@ -71,6 +74,7 @@ func (m *MockQuestionsRepository) CreateQuestion(ctx context.Context, post *mode
 }
 
 func (m *MockQuestionsRepository) ListAnswers(ctx context.Context, questionID string, opts models.AnswerListOptions) ([]models.AnswerWithAuthor, int, error) {
+	m.answerListOpts = opts
 	if m.answersErr != nil {
 		return nil, 0, m.answersErr
 	}
@@ -768,6 +772,36 @@ func TestCreateAnswer_SuccessWithAPIKey(t *testing.T) {
 	}
 }
 
+// TestCreateAnswer_RejectedQuestion tests that a question rejected by moderation
+// takes no answers.
+func TestCreateAnswer_RejectedQuestion(t *testing.T) {
+	repo := NewMockQuestionsRepository()
+	question := createTestQuestion("question-123", "Test Question")
+	question.Status = models.PostStatusRejected
+	repo.SetQuestion(&question)
+
+	handler := NewQuestionsHandler(repo)
+
+	jsonBody, _ := json.Marshal(map[string]interface{}{
+		"content": "This is a test answer with sufficient content length.",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/questions/question-123/answers", bytes.NewReader(jsonBody))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "question-123")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = addQuestionsAgentContext(req, "agent-claude")
+	w := httptest.NewRecorder()
+
+	handler.CreateAnswer(w, req)
+
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), `"MODERATION_REJECTED"`) {
+		t.Errorf("expected 409 MODERATION_REJECTED, got %d; body: %s", w.Code, w.Body.String())
+	}
+	if repo.createdAnswer != nil {
+		t.Error("expected no answer to be created")
+	}
+}
+
 // ============================================================================
 // FIX-023: Test that questions created via /v1/posts can receive answers
 // ============================================================================
@@ -802,10 +836,14 @@ func (m *MockPostsRepositoryForQuestions) FindByID(ctx context.Context, id strin
 	return nil, ErrQuestionNotFound // Return not found error
 }
 
-func (m *MockPostsRepositoryForQuestions) FindByIDForViewer(ctx context.Context, id string, viewerType models.AuthorType, viewerID string, callerHuman string) (*models.PostWithAuthor, error) {
+func (m *MockPostsRepositoryForQuestions) FindByIDForViewer(ctx context.Context, id string) (*models.PostWithAuthor, error) {
 	return m.FindByID(ctx, id)
 }
 
+func (m *MockPostsRepositoryForQuestions) FindByIDFromPrimary(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	return m.FindByIDForViewer(ctx, id)
+}
+
 func (m *MockPostsRepositoryForQuestions) Create(ctx context.Context, post *models.Post) (*models.Post, error) {
 	postWithAuthor := &models.PostWithAuthor{
 		Post: *post,
@@ -941,6 +979,47 @@ func TestListAnswers_QuestionFromPostsRepo(t *testing.T) {
 	}
 }
 
+// TestListAnswers_SortParam tests that known sort values are passed through and
+// unknown sort values fall back to the default order.
+func TestListAnswers_SortParam(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantSort string
+	}{
+		{"?sort=quality", "quality"},
+		{"?sort=newest", "newest"},
+		{"?sort=oldest", "oldest"},
+		{"?sort=top", "top"},
+		{"?sort=verified-first", "verified-first"},
+		{"?sort=bogus", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			repo := NewMockQuestionsRepository()
+			question := createTestQuestion("question-123", "Pool leak")
+			repo.question = &question
+			handler := NewQuestionsHandler(repo)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/questions/question-123/answers"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", "question-123")
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			w := httptest.NewRecorder()
+
+			handler.ListAnswers(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d; body: %s", w.Code, w.Body.String())
+			}
+			if repo.answerListOpts.Sort != tt.wantSort {
+				t.Errorf("expected sort %q, got %q", tt.wantSort, repo.answerListOpts.Sort)
+			}
+		})
+	}
+}
+
 func TestQuestionsHandler_List_HasAnswerFilter(t *testing.T) {
 	repo := NewMockQuestionsRepository()
 	handler := NewQuestionsHandler(repo)
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ReactionsRepositoryInterface defines the database operations for reactions.
+type ReactionsRepositoryInterface interface {
+	Toggle(ctx context.Context, targetType models.ReactionTargetType, targetID string, reactorType models.AuthorType, reactorID, emoji string) (bool, []models.ReactionCount, error)
+}
+
+// ReactionsHandler handles emoji reactions on answers and comments. Reactions
+// are acknowledgments without the reputation effects of votes.
+type ReactionsHandler struct {
+	repo ReactionsRepositoryInterface
+}
+
+// NewReactionsHandler creates a new ReactionsHandler.
+func NewReactionsHandler(repo ReactionsRepositoryInterface) *ReactionsHandler {
+	return &ReactionsHandler{repo: repo}
+}
+
+// ReactionRequest is the request body for toggling a reaction.
+type ReactionRequest struct {
+	Emoji string `json:"emoji"` // one of models.Reactions
+}
+
+// ReactionResponse is the response for toggling a reaction: whether the
+// caller's reaction is now present, and the target's updated counts.
+type ReactionResponse struct {
+	Emoji     string                 `json:"emoji"`
+	Reacted   bool                   `json:"reacted"`
+	Reactions []models.ReactionCount `json:"reactions"`
+}
+
+// ToggleAnswer handles POST /v1/answers/{id}/reactions.
+func (h *ReactionsHandler) ToggleAnswer(w http.ResponseWriter, r *http.Request) {
+	h.toggle(w, r, models.ReactionTargetAnswer)
+}
+
+// ToggleComment handles POST /v1/comments/{id}/reactions.
+func (h *ReactionsHandler) ToggleComment(w http.ResponseWriter, r *http.Request) {
+	h.toggle(w, r, models.ReactionTargetComment)
+}
+
+// toggle adds the caller's reaction, or removes it when they already added it.
+func (h *ReactionsHandler) toggle(w http.ResponseWriter, r *http.Request, targetType models.ReactionTargetType) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	var req ReactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid JSON body")
+		return
+	}
+	if !models.IsValidReaction(req.Emoji) {
+		apierror.Write(w, apierror.ValidationError, "emoji must be one of "+strings.Join(models.Reactions, " "))
+		return
+	}
+
+	reacted, counts, err := h.repo.Toggle(r.Context(), targetType, chi.URLParam(r, "id"), authInfo.AuthorType, authInfo.AuthorID, req.Emoji)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			apierror.Write(w, apierror.NotFound, string(targetType)+" not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to update reaction")
+		return
+	}
+
+	response.WriteJSON(w, http.StatusOK, ReactionResponse{Emoji: req.Emoji, Reacted: reacted, Reactions: counts})
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockReactionsRepo struct {
+	reactions map[string]bool // "target/reactor/emoji"
+}
+
+func (m *mockReactionsRepo) Toggle(ctx context.Context, targetType models.ReactionTargetType, targetID string, reactorType models.AuthorType, reactorID, emoji string) (bool, []models.ReactionCount, error) {
+	if targetID == "missing" {
+		return false, nil, db.ErrNotFound
+	}
+	key := string(targetType) + ":" + targetID + "/" + reactorID + "/" + emoji
+	m.reactions[key] = !m.reactions[key]
+	var counts []models.ReactionCount
+	if m.reactions[key] {
+		counts = append(counts, models.ReactionCount{Emoji: emoji, Count: 1, Reacted: true})
+	}
+	return m.reactions[key], counts, nil
+}
+
+func reactionRequest(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/answers/"+id+"/reactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	return addAuthContext(req, "user-123", "user")
+}
+
+func TestReactionsHandler_Toggle(t *testing.T) {
+	repo := &mockReactionsRepo{reactions: map[string]bool{}}
+	h := NewReactionsHandler(repo)
+
+	for _, want := range []bool{true, false} {
+		w := httptest.NewRecorder()
+		h.ToggleAnswer(w, reactionRequest("answer-1", `{"emoji":"🎉"}`))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Data ReactionResponse `json:"data"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if resp.Data.Reacted != want {
+			t.Errorf("reacted = %v, want %v", resp.Data.Reacted, want)
+		}
+	}
+	if !strings.HasPrefix(firstKey(repo.reactions), "answer:answer-1/") {
+		t.Errorf("reactions = %v, want one on answer-1", repo.reactions)
+	}
+}
+
+func TestReactionsHandler_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		body   string
+		status int
+	}{
+		{"unsupported emoji", "answer-1", `{"emoji":"👎"}`, http.StatusBadRequest},
+		{"missing emoji", "answer-1", `{}`, http.StatusBadRequest},
+		{"unknown comment", "missing", `{"emoji":"👍"}`, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewReactionsHandler(&mockReactionsRepo{reactions: map[string]bool{}})
+			w := httptest.NewRecorder()
+			h.ToggleComment(w, reactionRequest(tt.id, tt.body))
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+		})
+	}
+}
+
+func firstKey(m map[string]bool) string {
+	for k := range m {
+		return k
+	}
+	return ""
+}
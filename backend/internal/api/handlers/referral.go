@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 )
 
@@ -38,40 +39,19 @@ func NewReferralHandler(repo ReferralRepositoryInterface) *ReferralHandler {
 func (h *ReferralHandler) GetMyReferral(w http.ResponseWriter, r *http.Request) {
 	claims := auth.ClaimsFromContext(r.Context())
 	if claims == nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": map[string]string{
-				"code":    "UNAUTHORIZED",
-				"message": "authentication required",
-			},
-		})
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	code, err := h.repo.GetReferralCode(r.Context(), claims.UserID)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "failed to get referral code",
-			},
-		})
+		apierror.Write(w, apierror.InternalError, "failed to get referral code")
 		return
 	}
 
 	count, err := h.repo.CountByReferrer(r.Context(), claims.UserID)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "failed to get referral count",
-			},
-		})
+		apierror.Write(w, apierror.InternalError, "failed to get referral count")
 		return
 	}
 
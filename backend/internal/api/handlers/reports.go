@@ -9,27 +9,37 @@ import (
 	"os"
 
 	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
 )
 
+// DefaultReportAutoHideThreshold is how many independent pending reports hide a
+// post, answer or comment until an admin reviews it (SPEC.md Part 8.4).
+const DefaultReportAutoHideThreshold = 3
+
 // ReportsRepositoryInterface defines the database operations for reports.
 type ReportsRepositoryInterface interface {
 	Create(ctx context.Context, report *models.Report) (*models.Report, error)
 	HasReported(ctx context.Context, targetType models.ReportTargetType, targetID, reporterType, reporterID string) (bool, error)
+	TargetExists(ctx context.Context, targetType models.ReportTargetType, targetID string) (bool, error)
+	HideIfReported(ctx context.Context, targetType models.ReportTargetType, targetID string, threshold int) (bool, error)
 }
 
 // ReportsHandler handles report HTTP requests.
 type ReportsHandler struct {
-	repo   ReportsRepositoryInterface
-	logger *slog.Logger
+	repo              ReportsRepositoryInterface
+	logger            *slog.Logger
+	autoHideThreshold int
 }
 
 // NewReportsHandler creates a new ReportsHandler.
 func NewReportsHandler(repo ReportsRepositoryInterface) *ReportsHandler {
 	return &ReportsHandler{
-		repo:   repo,
-		logger: slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+		repo:              repo,
+		logger:            slog.New(slog.NewJSONHandler(os.Stderr, nil)),
+		autoHideThreshold: DefaultReportAutoHideThreshold,
 	}
 }
 
@@ -38,6 +48,11 @@ func (h *ReportsHandler) SetLogger(logger *slog.Logger) {
 	h.logger = logger
 }
 
+// SetAutoHideThreshold sets how many pending reports hide content. 0 disables auto-hide.
+func (h *ReportsHandler) SetAutoHideThreshold(threshold int) {
+	h.autoHideThreshold = threshold
+}
+
 // CreateReportRequest is the request body for creating a report.
 type CreateReportRequest struct {
 	TargetType string `json:"target_type"`
@@ -51,63 +66,144 @@ func (h *ReportsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Get auth info (must be authenticated)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeReportsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse request body
 	var req CreateReportRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeReportsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid request body")
+		apierror.Write(w, apierror.ValidationError, "invalid request body")
 		return
 	}
 
 	// Validate target type
 	targetType := models.ReportTargetType(req.TargetType)
 	if !models.IsValidReportTargetType(targetType) {
-		writeReportsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid target_type: must be post, answer, approach, response, or comment")
+		apierror.Write(w, apierror.ValidationError, "invalid target_type: must be post, answer, approach, response, or comment")
 		return
 	}
 
 	// Validate target ID
 	if req.TargetID == "" {
-		writeReportsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "target_id is required")
+		apierror.Write(w, apierror.ValidationError, "target_id is required")
 		return
 	}
 
 	// Validate reason
 	reason := models.ReportReason(req.Reason)
 	if !models.IsValidReportReason(reason) {
-		writeReportsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid reason: must be spam, offensive, off_topic, misleading, or other")
+		apierror.Write(w, apierror.ValidationError, "invalid reason: must be spam, offensive, off_topic, misleading, or other")
+		return
+	}
+
+	h.createReport(w, r, authInfo, targetType, req.TargetID, reason, req.Details)
+}
+
+// ReportContentRequest is the request body for reporting a specific post, answer or comment.
+type ReportContentRequest struct {
+	Reason  string `json:"reason"`
+	Details string `json:"details,omitempty"`
+}
+
+// ReportPost handles POST /v1/posts/{id}/report.
+func (h *ReportsHandler) ReportPost(w http.ResponseWriter, r *http.Request) {
+	h.reportContent(w, r, models.ReportTargetPost)
+}
+
+// ReportAnswer handles POST /v1/answers/{id}/report.
+func (h *ReportsHandler) ReportAnswer(w http.ResponseWriter, r *http.Request) {
+	h.reportContent(w, r, models.ReportTargetAnswer)
+}
+
+// ReportComment handles POST /v1/comments/{id}/report.
+func (h *ReportsHandler) ReportComment(w http.ResponseWriter, r *http.Request) {
+	h.reportContent(w, r, models.ReportTargetComment)
+}
+
+// reportContent reports the target named by the {id} URL param.
+// Unlike POST /v1/reports, the target must exist.
+func (h *ReportsHandler) reportContent(w http.ResponseWriter, r *http.Request, targetType models.ReportTargetType) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	targetID := chi.URLParam(r, "id")
+	if targetID == "" {
+		apierror.Write(w, apierror.ValidationError, string(targetType)+" ID is required")
+		return
+	}
+
+	var req ReportContentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid request body")
+		return
+	}
+
+	reason := models.ReportReason(req.Reason)
+	if !models.IsValidReportReason(reason) {
+		apierror.Write(w, apierror.ValidationError, "invalid reason: must be spam, offensive, off_topic, misleading, or other")
 		return
 	}
 
-	// Create report
+	exists, err := h.repo.TargetExists(r.Context(), targetType, targetID)
+	if err != nil {
+		ctx := response.LogContext{
+			Operation: "ReportContent",
+			Resource:  string(targetType),
+			RequestID: r.Header.Get("X-Request-ID"),
+			Extra:     map[string]string{"targetID": targetID},
+		}
+		response.WriteInternalErrorWithLog(w, "failed to look up reported content", err, ctx, h.logger)
+		return
+	}
+	if !exists {
+		apierror.Write(w, apierror.NotFound, string(targetType)+" not found")
+		return
+	}
+
+	h.createReport(w, r, authInfo, targetType, targetID, reason, req.Details)
+}
+
+// createReport stores the report and hides the target once it crosses the
+// auto-hide threshold. A failed hide check is logged but doesn't fail the report.
+func (h *ReportsHandler) createReport(w http.ResponseWriter, r *http.Request, authInfo *AuthInfo, targetType models.ReportTargetType, targetID string, reason models.ReportReason, details string) {
 	report := &models.Report{
 		TargetType:   targetType,
-		TargetID:     req.TargetID,
+		TargetID:     targetID,
 		ReporterType: authInfo.AuthorType,
 		ReporterID:   authInfo.AuthorID,
 		Reason:       reason,
-		Details:      req.Details,
+		Details:      details,
 	}
 
 	created, err := h.repo.Create(r.Context(), report)
 	if err != nil {
 		if err == db.ErrReportExists {
-			writeReportsError(w, http.StatusConflict, "ALREADY_REPORTED", "you have already reported this content")
+			apierror.Write(w, apierror.AlreadyReported, "you have already reported this content")
 			return
 		}
 		ctx := response.LogContext{
 			Operation: "CreateReport",
 			Resource:  "report",
 			RequestID: r.Header.Get("X-Request-ID"),
-			Extra:     map[string]string{"targetType": req.TargetType, "targetID": req.TargetID},
+			Extra:     map[string]string{"targetType": string(targetType), "targetID": targetID},
 		}
 		response.WriteInternalErrorWithLog(w, "failed to create report", err, ctx, h.logger)
 		return
 	}
 
+	if h.autoHideThreshold > 0 && models.IsHideableReportTarget(targetType) {
+		hidden, err := h.repo.HideIfReported(r.Context(), targetType, targetID, h.autoHideThreshold)
+		if err != nil {
+			h.logger.Error("auto-hide check failed", "targetType", targetType, "targetID", targetID, "error", err)
+		} else if hidden {
+			h.logger.Info("content auto-hidden by reports", "targetType", targetType, "targetID", targetID, "threshold", h.autoHideThreshold)
+		}
+	}
+
 	writeReportsJSON(w, http.StatusCreated, map[string]interface{}{
 		"data": map[string]interface{}{
 			"id":          created.ID,
@@ -125,7 +221,7 @@ func (h *ReportsHandler) Check(w http.ResponseWriter, r *http.Request) {
 	// Get auth info (must be authenticated)
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeReportsError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
@@ -133,14 +229,14 @@ func (h *ReportsHandler) Check(w http.ResponseWriter, r *http.Request) {
 	targetID := r.URL.Query().Get("target_id")
 
 	if targetType == "" || targetID == "" {
-		writeReportsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "target_type and target_id are required")
+		apierror.Write(w, apierror.ValidationError, "target_type and target_id are required")
 		return
 	}
 
 	// Validate target type
 	reportTargetType := models.ReportTargetType(targetType)
 	if !models.IsValidReportTargetType(reportTargetType) {
-		writeReportsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid target_type")
+		apierror.Write(w, apierror.ValidationError, "invalid target_type")
 		return
 	}
 
@@ -168,15 +264,3 @@ func writeReportsJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-// writeReportsError writes an error JSON response.
-func writeReportsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
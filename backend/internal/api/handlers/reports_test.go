@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockReportsRepository is a test double for ReportsRepositoryInterface.
+type mockReportsRepository struct {
+	reports       []*models.Report
+	missing       bool
+	hideThreshold int
+	hideCalls     int
+}
+
+func (m *mockReportsRepository) Create(ctx context.Context, report *models.Report) (*models.Report, error) {
+	for _, existing := range m.reports {
+		if existing.TargetID == report.TargetID && existing.ReporterID == report.ReporterID {
+			return nil, db.ErrReportExists
+		}
+	}
+	report.ID = "report-" + report.ReporterID
+	report.Status = models.ReportStatusPending
+	report.CreatedAt = time.Now()
+	m.reports = append(m.reports, report)
+	return report, nil
+}
+
+func (m *mockReportsRepository) HasReported(ctx context.Context, targetType models.ReportTargetType, targetID, reporterType, reporterID string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockReportsRepository) TargetExists(ctx context.Context, targetType models.ReportTargetType, targetID string) (bool, error) {
+	return !m.missing, nil
+}
+
+func (m *mockReportsRepository) HideIfReported(ctx context.Context, targetType models.ReportTargetType, targetID string, threshold int) (bool, error) {
+	m.hideCalls++
+	m.hideThreshold = threshold
+	return len(m.reports) >= threshold, nil
+}
+
+func newReportContentRequest(t *testing.T, path, id, userID, body string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	if userID != "" {
+		req = addAuthContext(req, userID, "user")
+	}
+	return req
+}
+
+func TestReportPost_Success(t *testing.T) {
+	repo := &mockReportsRepository{}
+	handler := NewReportsHandler(repo)
+
+	req := newReportContentRequest(t, "/v1/posts/post-1/report", "post-1", "user-1", `{"reason":"spam","details":"link farm"}`)
+	w := httptest.NewRecorder()
+	handler.ReportPost(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(repo.reports) != 1 {
+		t.Fatalf("expected 1 report, got %d", len(repo.reports))
+	}
+	got := repo.reports[0]
+	if got.TargetType != models.ReportTargetPost || got.TargetID != "post-1" || got.Reason != models.ReportReasonSpam {
+		t.Errorf("unexpected report: %+v", got)
+	}
+	if repo.hideCalls != 1 || repo.hideThreshold != DefaultReportAutoHideThreshold {
+		t.Errorf("expected one hide check at threshold %d, got %d calls at %d", DefaultReportAutoHideThreshold, repo.hideCalls, repo.hideThreshold)
+	}
+}
+
+func TestReportAnswer_TargetType(t *testing.T) {
+	repo := &mockReportsRepository{}
+	handler := NewReportsHandler(repo)
+
+	req := newReportContentRequest(t, "/v1/answers/answer-1/report", "answer-1", "user-1", `{"reason":"misleading"}`)
+	w := httptest.NewRecorder()
+	handler.ReportAnswer(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.reports[0].TargetType != models.ReportTargetAnswer {
+		t.Errorf("expected answer target, got %s", repo.reports[0].TargetType)
+	}
+}
+
+func TestReportComment_NotFound(t *testing.T) {
+	handler := NewReportsHandler(&mockReportsRepository{missing: true})
+
+	req := newReportContentRequest(t, "/v1/comments/missing/report", "missing", "user-1", `{"reason":"offensive"}`)
+	w := httptest.NewRecorder()
+	handler.ReportComment(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestReportPost_Unauthorized(t *testing.T) {
+	handler := NewReportsHandler(&mockReportsRepository{})
+
+	req := newReportContentRequest(t, "/v1/posts/post-1/report", "post-1", "", `{"reason":"spam"}`)
+	w := httptest.NewRecorder()
+	handler.ReportPost(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestReportPost_InvalidReason(t *testing.T) {
+	handler := NewReportsHandler(&mockReportsRepository{})
+
+	req := newReportContentRequest(t, "/v1/posts/post-1/report", "post-1", "user-1", `{"reason":"boring"}`)
+	w := httptest.NewRecorder()
+	handler.ReportPost(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestReportPost_Duplicate(t *testing.T) {
+	repo := &mockReportsRepository{}
+	handler := NewReportsHandler(repo)
+
+	for i, want := range []int{http.StatusCreated, http.StatusConflict} {
+		req := newReportContentRequest(t, "/v1/posts/post-1/report", "post-1", "user-1", `{"reason":"spam"}`)
+		w := httptest.NewRecorder()
+		handler.ReportPost(w, req)
+		if w.Code != want {
+			t.Errorf("attempt %d: expected %d, got %d", i+1, want, w.Code)
+		}
+	}
+}
+
+func TestReportPost_AutoHideDisabled(t *testing.T) {
+	repo := &mockReportsRepository{}
+	handler := NewReportsHandler(repo)
+	handler.SetAutoHideThreshold(0)
+
+	req := newReportContentRequest(t, "/v1/posts/post-1/report", "post-1", "user-1", `{"reason":"spam"}`)
+	w := httptest.NewRecorder()
+	handler.ReportPost(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+	if repo.hideCalls != 0 {
+		t.Errorf("expected no hide check when disabled, got %d", repo.hideCalls)
+	}
+}
+
+func TestCreateReport_ApproachSkipsAutoHide(t *testing.T) {
+	repo := &mockReportsRepository{}
+	handler := NewReportsHandler(repo)
+
+	body := `{"target_type":"approach","target_id":"approach-1","reason":"spam"}`
+	req := addAuthContext(httptest.NewRequest(http.MethodPost, "/v1/reports", strings.NewReader(body)), "user-1", "user")
+	w := httptest.NewRecorder()
+	handler.Create(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.hideCalls != 0 {
+		t.Errorf("approaches can't be hidden, expected no hide check, got %d", repo.hideCalls)
+	}
+}
+
+// mockReportQueueRepo is a test double for ReportQueueRepo.
+type mockReportQueueRepo struct {
+	items      []models.ReportQueueItem
+	resolveErr error
+	resolution models.ReportResolution
+	targetType models.ReportTargetType
+	targetID   string
+}
+
+func (m *mockReportQueueRepo) ListQueue(ctx context.Context, page, perPage int) ([]models.ReportQueueItem, int, error) {
+	return m.items, len(m.items), nil
+}
+
+func (m *mockReportQueueRepo) Resolve(ctx context.Context, targetType models.ReportTargetType, targetID string, resolution models.ReportResolution, reviewedBy string) (int64, error) {
+	if m.resolveErr != nil {
+		return 0, m.resolveErr
+	}
+	m.targetType = targetType
+	m.targetID = targetID
+	m.resolution = resolution
+	return 3, nil
+}
+
+func newResolveReportsRequest(targetType, targetID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/admin/reports/"+targetType+"/"+targetID, strings.NewReader(body))
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("target_type", targetType)
+	rctx.URLParams.Add("target_id", targetID)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestAdminHandler_ListReportQueue(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetReportQueueRepo(&mockReportQueueRepo{items: []models.ReportQueueItem{
+		{TargetType: models.ReportTargetPost, TargetID: "post-1", ReportCount: 3, Reasons: []models.ReportReason{models.ReportReasonSpam}},
+	}})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/reports", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+	handler.ListReportQueue(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&resp)
+	data, ok := resp["data"].([]interface{})
+	if !ok || len(data) != 1 {
+		t.Fatalf("expected 1 queue item, got %v", resp["data"])
+	}
+}
+
+func TestAdminHandler_ListReportQueue_NotConfigured(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	req := httptest.NewRequest(http.MethodGet, "/admin/reports", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+	handler.ListReportQueue(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ResolveReports_Dismiss(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	repo := &mockReportQueueRepo{}
+	handler := NewAdminHandler(nil)
+	handler.SetReportQueueRepo(repo)
+
+	w := httptest.NewRecorder()
+	handler.ResolveReports(w, newResolveReportsRequest("answer", "answer-1", `{"action":"dismiss"}`))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.targetType != models.ReportTargetAnswer || repo.targetID != "answer-1" || repo.resolution != models.ReportResolutionDismiss {
+		t.Errorf("unexpected resolve call: %s/%s %s", repo.targetType, repo.targetID, repo.resolution)
+	}
+}
+
+func TestAdminHandler_ResolveReports_InvalidAction(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetReportQueueRepo(&mockReportQueueRepo{})
+
+	w := httptest.NewRecorder()
+	handler.ResolveReports(w, newResolveReportsRequest("post", "post-1", `{"action":"delete"}`))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAdminHandler_ResolveReports_NoPending(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	handler := NewAdminHandler(nil)
+	handler.SetReportQueueRepo(&mockReportQueueRepo{resolveErr: db.ErrNoPendingReports})
+
+	w := httptest.NewRecorder()
+	handler.ResolveReports(w, newResolveReportsRequest("post", "post-1", `{"action":"hide"}`))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	apimiddleware "github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -65,7 +66,7 @@ func (h *RoomHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 	if claims != nil {
 		parsed, err := uuid.Parse(claims.UserID)
 		if err != nil {
-			roomWriteError(w, http.StatusBadRequest, "INVALID_USER_ID", "invalid user ID in token")
+			apierror.Write(w, apierror.InvalidUserID, "invalid user ID in token")
 			return
 		}
 		ownerID = parsed
@@ -83,18 +84,18 @@ func (h *RoomHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	} else {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	var req createRoomRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 
 	if req.DisplayName == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "display_name is required")
+		apierror.Write(w, apierror.ValidationError, "display_name is required")
 		return
 	}
 
@@ -112,11 +113,11 @@ func (h *RoomHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 	room, plainToken, err := h.roomRepo.Create(r.Context(), params)
 	if err != nil {
 		if errors.Is(err, db.ErrRoomSlugExists) {
-			roomWriteError(w, http.StatusConflict, "DUPLICATE_ROOM", "a room with this name already exists")
+			apierror.Write(w, apierror.DuplicateRoom, "a room with this name already exists")
 			return
 		}
 		slog.Error("failed to create room", "error", err)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create room")
+		apierror.Write(w, apierror.InternalError, "failed to create room")
 		return
 	}
 
@@ -137,18 +138,18 @@ func (h *RoomHandler) GetRoom(w http.ResponseWriter, r *http.Request) {
 	if room == nil {
 		slug := chi.URLParam(r, "slug")
 		if slug == "" {
-			roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+			apierror.Write(w, apierror.ValidationError, "slug is required")
 			return
 		}
 		var err error
 		room, err = h.roomRepo.GetBySlug(r.Context(), slug)
 		if err != nil {
 			if errors.Is(err, db.ErrRoomNotFound) {
-				roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+				apierror.Write(w, apierror.NotFound, "room not found")
 				return
 			}
 			slog.Error("failed to get room", "error", err, "slug", slug)
-			roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get room")
+			apierror.Write(w, apierror.InternalError, "failed to get room")
 			return
 		}
 	}
@@ -198,7 +199,7 @@ func (h *RoomHandler) ListRooms(w http.ResponseWriter, r *http.Request) {
 	rooms, err := h.roomRepo.List(r.Context(), limit, offset)
 	if err != nil {
 		slog.Error("failed to list rooms", "error", err)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list rooms")
+		apierror.Write(w, apierror.InternalError, "failed to list rooms")
 		return
 	}
 
@@ -214,44 +215,44 @@ func (h *RoomHandler) ListRooms(w http.ResponseWriter, r *http.Request) {
 func (h *RoomHandler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
 	claims := auth.ClaimsFromContext(r.Context())
 	agent := auth.AgentFromContext(r.Context())
 	if claims == nil && agent == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	room, err := h.roomRepo.GetBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrRoomNotFound) {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return
 		}
 		slog.Error("failed to get room for update", "error", err)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get room")
+		apierror.Write(w, apierror.InternalError, "failed to get room")
 		return
 	}
 
 	// Verify ownership (human, claimed agent, or agent owner-member) or admin role
 	if !h.canManage(r.Context(), claims, agent, room) {
-		roomWriteError(w, http.StatusForbidden, "FORBIDDEN", "only the room owner or admin can update this room")
+		apierror.Write(w, apierror.Forbidden, "only the room owner or admin can update this room")
 		return
 	}
 
 	var params models.UpdateRoomParams
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 
 	updated, err := h.roomRepo.Update(r.Context(), room.ID, params)
 	if err != nil {
 		slog.Error("failed to update room", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update room")
+		apierror.Write(w, apierror.InternalError, "failed to update room")
 		return
 	}
 
@@ -267,36 +268,36 @@ func (h *RoomHandler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
 func (h *RoomHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
 	claims := auth.ClaimsFromContext(r.Context())
 	agent := auth.AgentFromContext(r.Context())
 	if claims == nil && agent == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	room, err := h.roomRepo.GetBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrRoomNotFound) {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return
 		}
 		slog.Error("failed to get room for delete", "error", err)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get room")
+		apierror.Write(w, apierror.InternalError, "failed to get room")
 		return
 	}
 
 	if !h.canManage(r.Context(), claims, agent, room) {
-		roomWriteError(w, http.StatusForbidden, "FORBIDDEN", "only the room owner or admin can delete this room")
+		apierror.Write(w, apierror.Forbidden, "only the room owner or admin can delete this room")
 		return
 	}
 
 	if err := h.roomRepo.SoftDelete(r.Context(), room.ID); err != nil {
 		slog.Error("failed to delete room", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to delete room")
+		apierror.Write(w, apierror.InternalError, "failed to delete room")
 		return
 	}
 
@@ -310,37 +311,37 @@ func (h *RoomHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
 func (h *RoomHandler) RotateToken(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
 	claims := auth.ClaimsFromContext(r.Context())
 	agent := auth.AgentFromContext(r.Context())
 	if claims == nil && agent == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	room, err := h.roomRepo.GetBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrRoomNotFound) {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return
 		}
 		slog.Error("failed to get room for token rotation", "error", err)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get room")
+		apierror.Write(w, apierror.InternalError, "failed to get room")
 		return
 	}
 
 	if !h.canManage(r.Context(), claims, agent, room) {
-		roomWriteError(w, http.StatusForbidden, "FORBIDDEN", "only the room owner or admin can rotate the token")
+		apierror.Write(w, apierror.Forbidden, "only the room owner or admin can rotate the token")
 		return
 	}
 
 	newToken, err := h.roomRepo.RotateToken(r.Context(), room.ID)
 	if err != nil {
 		slog.Error("failed to rotate token", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to rotate token")
+		apierror.Write(w, apierror.InternalError, "failed to rotate token")
 		return
 	}
 
@@ -424,15 +425,3 @@ func roomWriteJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-// roomWriteError writes a JSON error response.
-func roomWriteError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
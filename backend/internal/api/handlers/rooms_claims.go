@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	apimiddleware "github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
@@ -42,24 +43,24 @@ type claimActionRequest struct {
 func (h *RoomClaimsHandler) Claim(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 	var req acquireClaimRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 	if req.Key == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "key is required")
+		apierror.Write(w, apierror.ValidationError, "key is required")
 		return
 	}
 	if req.Agent == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent is required")
+		apierror.Write(w, apierror.ValidationError, "agent is required")
 		return
 	}
 	if req.TTLSeconds > maxClaimTTLSeconds {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "ttl_seconds exceeds maximum of 86400")
+		apierror.Write(w, apierror.ValidationError, "ttl_seconds exceeds maximum of 86400")
 		return
 	}
 
@@ -71,7 +72,7 @@ func (h *RoomClaimsHandler) Claim(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		slog.Error("failed to acquire claim", "error", err, "room_id", room.ID, "key", req.Key)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to acquire claim")
+		apierror.Write(w, apierror.InternalError, "failed to acquire claim")
 		return
 	}
 
@@ -92,7 +93,7 @@ func (h *RoomClaimsHandler) Claim(w http.ResponseWriter, r *http.Request) {
 func (h *RoomClaimsHandler) RenewClaim(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 	req, ok := decodeClaimAction(w, r)
@@ -102,11 +103,11 @@ func (h *RoomClaimsHandler) RenewClaim(w http.ResponseWriter, r *http.Request) {
 	claim, err := h.claimRepo.Renew(r.Context(), room.ID, req.Key, req.Agent, req.TTLSeconds)
 	if err != nil {
 		if errors.Is(err, db.ErrClaimNotHeld) {
-			roomWriteError(w, http.StatusConflict, "CLAIM_NOT_HELD", "you are not the current holder of this claim")
+			apierror.Write(w, apierror.ClaimNotHeld, "you are not the current holder of this claim")
 			return
 		}
 		slog.Error("failed to renew claim", "error", err, "room_id", room.ID, "key", req.Key)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to renew claim")
+		apierror.Write(w, apierror.InternalError, "failed to renew claim")
 		return
 	}
 	roomWriteJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"claim": claim}})
@@ -117,7 +118,7 @@ func (h *RoomClaimsHandler) RenewClaim(w http.ResponseWriter, r *http.Request) {
 func (h *RoomClaimsHandler) ReleaseClaim(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 	req, ok := decodeClaimAction(w, r)
@@ -126,11 +127,11 @@ func (h *RoomClaimsHandler) ReleaseClaim(w http.ResponseWriter, r *http.Request)
 	}
 	if err := h.claimRepo.Release(r.Context(), room.ID, req.Key, req.Agent); err != nil {
 		if errors.Is(err, db.ErrClaimNotHeld) {
-			roomWriteError(w, http.StatusConflict, "CLAIM_NOT_HELD", "you are not the current holder of this claim")
+			apierror.Write(w, apierror.ClaimNotHeld, "you are not the current holder of this claim")
 			return
 		}
 		slog.Error("failed to release claim", "error", err, "room_id", room.ID, "key", req.Key)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to release claim")
+		apierror.Write(w, apierror.InternalError, "failed to release claim")
 		return
 	}
 	roomWriteJSON(w, http.StatusOK, map[string]any{"data": map[string]bool{"ok": true}})
@@ -140,13 +141,13 @@ func (h *RoomClaimsHandler) ReleaseClaim(w http.ResponseWriter, r *http.Request)
 func (h *RoomClaimsHandler) ListClaims(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 	claims, err := h.claimRepo.ListLive(r.Context(), room.ID)
 	if err != nil {
 		slog.Error("failed to list claims", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list claims")
+		apierror.Write(w, apierror.InternalError, "failed to list claims")
 		return
 	}
 	roomWriteJSON(w, http.StatusOK, map[string]any{"data": claims})
@@ -157,19 +158,19 @@ func (h *RoomClaimsHandler) ListClaims(w http.ResponseWriter, r *http.Request) {
 func decodeClaimAction(w http.ResponseWriter, r *http.Request) (claimActionRequest, bool) {
 	var req claimActionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return req, false
 	}
 	if req.Key == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "key is required")
+		apierror.Write(w, apierror.ValidationError, "key is required")
 		return req, false
 	}
 	if req.Agent == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent is required")
+		apierror.Write(w, apierror.ValidationError, "agent is required")
 		return req, false
 	}
 	if req.TTLSeconds > maxClaimTTLSeconds {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "ttl_seconds exceeds maximum of 86400")
+		apierror.Write(w, apierror.ValidationError, "ttl_seconds exceeds maximum of 86400")
 		return req, false
 	}
 	return req, true
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/google/uuid"
@@ -34,20 +35,20 @@ func (h *RoomHandler) ListMyRooms(w http.ResponseWriter, r *http.Request) {
 	} else if claims := auth.ClaimsFromContext(ctx); claims != nil {
 		humanID = claims.UserID
 	} else {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	ownerUUID, err := uuid.Parse(humanID)
 	if err != nil {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid owner id")
+		apierror.Write(w, apierror.ValidationError, "invalid owner id")
 		return
 	}
 
 	rooms, err := h.roomRepo.ListByOwner(ctx, ownerUUID)
 	if err != nil {
 		slog.Error("failed to list my rooms", "error", err, "owner_id", ownerUUID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list rooms")
+		apierror.Write(w, apierror.InternalError, "failed to list rooms")
 		return
 	}
 
@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	apimiddleware "github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/hub"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -40,24 +41,24 @@ type postEventRequest struct {
 func (h *RoomEventsHandler) PostEvent(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 	var req postEventRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 	if req.Type == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "type is required")
+		apierror.Write(w, apierror.ValidationError, "type is required")
 		return
 	}
 	if req.Actor == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "actor is required")
+		apierror.Write(w, apierror.ValidationError, "actor is required")
 		return
 	}
 	if len(req.Payload) > maxEventPayloadBytes {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "payload exceeds maximum size of 16384 bytes")
+		apierror.Write(w, apierror.ValidationError, "payload exceeds maximum size of 16384 bytes")
 		return
 	}
 
@@ -70,7 +71,7 @@ func (h *RoomEventsHandler) PostEvent(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		slog.Error("failed to create room event", "error", err, "room_id", room.ID, "type", req.Type)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create event")
+		apierror.Write(w, apierror.InternalError, "failed to create event")
 		return
 	}
 
@@ -97,7 +98,7 @@ func (h *RoomEventsHandler) PostEvent(w http.ResponseWriter, r *http.Request) {
 func (h *RoomEventsHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 	limit := 100
@@ -114,7 +115,7 @@ func (h *RoomEventsHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		slog.Error("failed to query room events", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to query events")
+		apierror.Write(w, apierror.InternalError, "failed to query events")
 		return
 	}
 	roomWriteJSON(w, http.StatusOK, map[string]any{"data": events})
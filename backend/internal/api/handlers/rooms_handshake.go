@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -38,11 +39,11 @@ type handshakeRequest struct {
 func (h *RoomHandler) Handshake(w http.ResponseWriter, r *http.Request) {
 	agent := auth.AgentFromContext(r.Context())
 	if agent == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "agent API key required to handshake")
+		apierror.Write(w, apierror.Unauthorized, "agent API key required to handshake")
 		return
 	}
 	if h.agentTokenRepo == nil {
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "per-agent tokens not configured")
+		apierror.Write(w, apierror.InternalError, "per-agent tokens not configured")
 		return
 	}
 
@@ -50,36 +51,36 @@ func (h *RoomHandler) Handshake(w http.ResponseWriter, r *http.Request) {
 	room, err := h.roomRepo.GetBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrRoomNotFound) {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return
 		}
 		slog.Error("handshake: failed to get room", "error", err, "slug", slug)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get room")
+		apierror.Write(w, apierror.InternalError, "failed to get room")
 		return
 	}
 
 	var req handshakeRequest
 	if r.ContentLength != 0 {
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+			apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 			return
 		}
 	}
 
 	allowed, err := h.handshakeAuthorized(r, room, agent, req.RoomToken)
 	if err != nil {
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to authorize handshake")
+		apierror.Write(w, apierror.InternalError, "failed to authorize handshake")
 		return
 	}
 	if !allowed {
-		roomWriteError(w, http.StatusForbidden, "FORBIDDEN", "not authorized to join this closed room; ask the owner to add you or present the room token")
+		apierror.Write(w, apierror.Forbidden, "not authorized to join this closed room; ask the owner to add you or present the room token")
 		return
 	}
 
 	// Admit to the allowlist (idempotent; preserves an existing owner role).
 	if _, err := h.ensureMember(r, room, agent); err != nil {
 		slog.Error("handshake: failed to add member", "error", err, "room_id", room.ID, "agent", agent.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to join room")
+		apierror.Write(w, apierror.InternalError, "failed to join room")
 		return
 	}
 
@@ -87,7 +88,7 @@ func (h *RoomHandler) Handshake(w http.ResponseWriter, r *http.Request) {
 	plaintext, err := h.agentTokenRepo.Issue(r.Context(), room.ID, agent.ID, req.TTLSeconds)
 	if err != nil {
 		slog.Error("handshake: failed to issue token", "error", err, "room_id", room.ID, "agent", agent.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to issue token")
+		apierror.Write(w, apierror.InternalError, "failed to issue token")
 		return
 	}
 
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -23,27 +24,27 @@ type addMemberRequest struct {
 func (h *RoomHandler) resolveRoomForManage(w http.ResponseWriter, r *http.Request) *models.Room {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return nil
 	}
 	claims := auth.ClaimsFromContext(r.Context())
 	agent := auth.AgentFromContext(r.Context())
 	if claims == nil && agent == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return nil
 	}
 	room, err := h.roomRepo.GetBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrRoomNotFound) {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return nil
 		}
 		slog.Error("failed to get room for member management", "error", err, "slug", slug)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get room")
+		apierror.Write(w, apierror.InternalError, "failed to get room")
 		return nil
 	}
 	if !h.canManage(r.Context(), claims, agent, room) {
-		roomWriteError(w, http.StatusForbidden, "FORBIDDEN", "only the room owner or admin can manage members")
+		apierror.Write(w, apierror.Forbidden, "only the room owner or admin can manage members")
 		return nil
 	}
 	return room
@@ -58,11 +59,11 @@ func (h *RoomHandler) AddMember(w http.ResponseWriter, r *http.Request) {
 	}
 	var req addMemberRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 	if req.AgentID == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent_id is required")
+		apierror.Write(w, apierror.ValidationError, "agent_id is required")
 		return
 	}
 	role := req.Role
@@ -70,7 +71,7 @@ func (h *RoomHandler) AddMember(w http.ResponseWriter, r *http.Request) {
 		role = models.RoleMember
 	}
 	if role != models.RoleMember && role != models.RoleOwner {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "role must be 'member' or 'owner'")
+		apierror.Write(w, apierror.ValidationError, "role must be 'member' or 'owner'")
 		return
 	}
 
@@ -83,7 +84,7 @@ func (h *RoomHandler) AddMember(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		// A missing agent violates the FK — report as a clear 400.
-		roomWriteError(w, http.StatusBadRequest, "INVALID_AGENT", "agent_id does not reference an existing agent")
+		apierror.Write(w, apierror.InvalidAgent, "agent_id does not reference an existing agent")
 		return
 	}
 	roomWriteJSON(w, http.StatusCreated, map[string]any{"data": member})
@@ -99,17 +100,17 @@ func (h *RoomHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
 	}
 	agentID := chi.URLParam(r, "agent_id")
 	if agentID == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent_id is required")
+		apierror.Write(w, apierror.ValidationError, "agent_id is required")
 		return
 	}
 
 	if err := h.memberRepo.Remove(r.Context(), room.ID, agentID); err != nil {
 		if errors.Is(err, db.ErrRoomMemberNotFound) {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "agent is not a member of this room")
+			apierror.Write(w, apierror.NotFound, "agent is not a member of this room")
 			return
 		}
 		slog.Error("failed to remove room member", "error", err, "room_id", room.ID, "agent", agentID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to remove member")
+		apierror.Write(w, apierror.InternalError, "failed to remove member")
 		return
 	}
 	// Revoke the agent's per-agent room token (best-effort; membership removal already
@@ -131,7 +132,7 @@ func (h *RoomHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
 	members, err := h.memberRepo.ListByRoom(r.Context(), room.ID)
 	if err != nil {
 		slog.Error("failed to list room members", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list members")
+		apierror.Write(w, apierror.InternalError, "failed to list members")
 		return
 	}
 	roomWriteJSON(w, http.StatusOK, map[string]any{"data": members})
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	apimiddleware "github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/hub"
@@ -69,27 +70,27 @@ type postHumanMessageRequest struct {
 func (h *RoomMessagesHandler) PostMessage(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 
 	var req postMessageRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 
 	// Validate required fields
 	if req.AgentName == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent_name is required")
+		apierror.Write(w, apierror.ValidationError, "agent_name is required")
 		return
 	}
 	if req.Content == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content is required")
+		apierror.Write(w, apierror.ValidationError, "content is required")
 		return
 	}
 	if len(req.Content) > maxMessageContentLen {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content exceeds maximum length of 65536 characters")
+		apierror.Write(w, apierror.ValidationError, "content exceeds maximum length of 65536 characters")
 		return
 	}
 
@@ -99,7 +100,7 @@ func (h *RoomMessagesHandler) PostMessage(w http.ResponseWriter, r *http.Request
 		contentType = "text"
 	}
 	if contentType != "text" && contentType != "markdown" && contentType != "json" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content_type must be text, markdown, or json")
+		apierror.Write(w, apierror.ValidationError, "content_type must be text, markdown, or json")
 		return
 	}
 
@@ -122,7 +123,7 @@ func (h *RoomMessagesHandler) PostMessage(w http.ResponseWriter, r *http.Request
 	msg, err := h.msgRepo.Create(r.Context(), params)
 	if err != nil {
 		slog.Error("failed to create message", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create message")
+		apierror.Write(w, apierror.InternalError, "failed to create message")
 		return
 	}
 
@@ -170,13 +171,13 @@ func (h *RoomMessagesHandler) PostHumanMessage(w http.ResponseWriter, r *http.Re
 	// T-16-03: Extract user identity from JWT claims (server-side only).
 	claims := auth.ClaimsFromContext(r.Context())
 	if claims == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
@@ -184,27 +185,27 @@ func (h *RoomMessagesHandler) PostHumanMessage(w http.ResponseWriter, r *http.Re
 	room, err := h.resolveRoomBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrRoomNotFound) {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return
 		}
 		slog.Error("failed to get room for human message", "error", err, "slug", slug)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get room")
+		apierror.Write(w, apierror.InternalError, "failed to get room")
 		return
 	}
 
 	var req postHumanMessageRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 
 	// T-16-01: Validate content length and enforce text-only content type.
 	if req.Content == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content is required")
+		apierror.Write(w, apierror.ValidationError, "content is required")
 		return
 	}
 	if len(req.Content) > maxMessageContentLen {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "content exceeds maximum length of 65536 characters")
+		apierror.Write(w, apierror.ValidationError, "content exceeds maximum length of 65536 characters")
 		return
 	}
 
@@ -222,7 +223,7 @@ func (h *RoomMessagesHandler) PostHumanMessage(w http.ResponseWriter, r *http.Re
 	msg, err := h.createMessage(r.Context(), params)
 	if err != nil {
 		slog.Error("failed to create human message", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create message")
+		apierror.Write(w, apierror.InternalError, "failed to create message")
 		return
 	}
 
@@ -281,13 +282,13 @@ func (h *RoomMessagesHandler) ListMessages(w http.ResponseWriter, r *http.Reques
 		// Public route: look up by slug
 		slug := chi.URLParam(r, "slug")
 		if slug == "" {
-			roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+			apierror.Write(w, apierror.ValidationError, "slug is required")
 			return
 		}
 		var err error
 		room, err = h.roomRepo.GetBySlug(r.Context(), slug)
 		if err != nil {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return
 		}
 	}
@@ -315,7 +316,7 @@ func (h *RoomMessagesHandler) ListMessages(w http.ResponseWriter, r *http.Reques
 	}
 	if err != nil {
 		slog.Error("failed to list messages", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list messages")
+		apierror.Write(w, apierror.InternalError, "failed to list messages")
 		return
 	}
 
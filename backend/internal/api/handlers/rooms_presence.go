@@ -7,6 +7,7 @@ import (
 
 	"github.com/a2aproject/a2a-go/a2a"
 	apimiddleware "github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/hub"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -55,18 +56,18 @@ type joinRoomRequest struct {
 func (h *RoomPresenceHandler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 
 	var req joinRoomRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 
 	if req.AgentName == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent_name is required")
+		apierror.Write(w, apierror.ValidationError, "agent_name is required")
 		return
 	}
 
@@ -78,7 +79,7 @@ func (h *RoomPresenceHandler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 
 	// Validate card_json size (D-33)
 	if len(req.Card) > maxCardJSONBytes {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "card exceeds maximum size of 16384 bytes")
+		apierror.Write(w, apierror.ValidationError, "card exceeds maximum size of 16384 bytes")
 		return
 	}
 
@@ -92,7 +93,7 @@ func (h *RoomPresenceHandler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 	record, err := h.presenceRepo.Upsert(r.Context(), params)
 	if err != nil {
 		slog.Error("failed to upsert presence", "error", err, "room_id", room.ID, "agent", req.AgentName)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to join room")
+		apierror.Write(w, apierror.InternalError, "failed to join room")
 		return
 	}
 
@@ -135,25 +136,25 @@ type heartbeatRequest struct {
 func (h *RoomPresenceHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 
 	var req heartbeatRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 
 	if req.AgentName == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent_name is required")
+		apierror.Write(w, apierror.ValidationError, "agent_name is required")
 		return
 	}
 
 	// Update heartbeat in database
 	if err := h.presenceRepo.UpdateHeartbeat(r.Context(), room.ID, req.AgentName); err != nil {
 		slog.Error("failed to update heartbeat", "error", err, "room_id", room.ID, "agent", req.AgentName)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update heartbeat")
+		apierror.Write(w, apierror.InternalError, "failed to update heartbeat")
 		return
 	}
 
@@ -177,13 +178,13 @@ func (h *RoomPresenceHandler) ListPresence(w http.ResponseWriter, r *http.Reques
 		// Public route: look up by slug
 		slug := chi.URLParam(r, "slug")
 		if slug == "" {
-			roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+			apierror.Write(w, apierror.ValidationError, "slug is required")
 			return
 		}
 		var err error
 		room, err = h.roomRepo.GetBySlug(r.Context(), slug)
 		if err != nil {
-			roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return
 		}
 	}
@@ -191,7 +192,7 @@ func (h *RoomPresenceHandler) ListPresence(w http.ResponseWriter, r *http.Reques
 	records, err := h.presenceRepo.ListByRoom(r.Context(), room.ID)
 	if err != nil {
 		slog.Error("failed to list presence", "error", err, "room_id", room.ID)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list agents")
+		apierror.Write(w, apierror.InternalError, "failed to list agents")
 		return
 	}
 
@@ -206,20 +207,20 @@ func (h *RoomPresenceHandler) ListPresence(w http.ResponseWriter, r *http.Reques
 func (h *RoomPresenceHandler) GetAgentCard(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 
 	agentName := chi.URLParam(r, "agent_name")
 	if agentName == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent_name is required")
+		apierror.Write(w, apierror.ValidationError, "agent_name is required")
 		return
 	}
 
 	// Look up in the in-memory registry for the full card
 	card, found := h.registry.ExtendedCard(hub.NewRoomID(room.ID), agentName)
 	if !found {
-		roomWriteError(w, http.StatusNotFound, "NOT_FOUND", "agent not found in room")
+		apierror.Write(w, apierror.NotFound, "agent not found in room")
 		return
 	}
 
@@ -240,18 +241,18 @@ type leaveRoomRequest struct {
 func (h *RoomPresenceHandler) LeaveRoom(w http.ResponseWriter, r *http.Request) {
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		roomWriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "room context missing")
+		apierror.Write(w, apierror.Unauthorized, "room context missing")
 		return
 	}
 
 	var req leaveRoomRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		roomWriteError(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		apierror.Write(w, apierror.InvalidJSON, "invalid request body")
 		return
 	}
 
 	if req.AgentName == "" {
-		roomWriteError(w, http.StatusBadRequest, "VALIDATION_ERROR", "agent_name is required")
+		apierror.Write(w, apierror.ValidationError, "agent_name is required")
 		return
 	}
 
@@ -260,7 +261,7 @@ func (h *RoomPresenceHandler) LeaveRoom(w http.ResponseWriter, r *http.Request)
 	// Remove from database
 	if err := h.presenceRepo.Remove(r.Context(), room.ID, req.AgentName); err != nil {
 		slog.Error("failed to remove presence", "error", err, "room_id", room.ID, "agent", req.AgentName)
-		roomWriteError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to leave room")
+		apierror.Write(w, apierror.InternalError, "failed to leave room")
 		return
 	}
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	apimiddleware "github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/hub"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -93,7 +94,7 @@ func (h *RoomSSEHandler) Stream(w http.ResponseWriter, r *http.Request) {
 	// that BearerGuard sets (middleware.RoomContextKey).
 	room := apimiddleware.RoomFromContext(r.Context())
 	if room == nil {
-		http.Error(w, `{"error":{"code":"NOT_FOUND","message":"room not found in context"}}`, http.StatusNotFound)
+		apierror.Write(w, apierror.NotFound, "room not found in context")
 		return
 	}
 
@@ -113,17 +114,17 @@ func (h *RoomSSEHandler) Stream(w http.ResponseWriter, r *http.Request) {
 func (h *RoomSSEHandler) PublicStream(w http.ResponseWriter, r *http.Request) {
 	slug := chi.URLParam(r, "slug")
 	if slug == "" {
-		http.Error(w, `{"error":{"code":"VALIDATION_ERROR","message":"slug is required"}}`, http.StatusBadRequest)
+		apierror.Write(w, apierror.ValidationError, "slug is required")
 		return
 	}
 
 	room, err := h.resolveSSERoomBySlug(r.Context(), slug)
 	if err != nil {
 		if errors.Is(err, db.ErrRoomNotFound) {
-			http.Error(w, `{"error":{"code":"NOT_FOUND","message":"room not found"}}`, http.StatusNotFound)
+			apierror.Write(w, apierror.NotFound, "room not found")
 			return
 		}
-		http.Error(w, `{"error":{"code":"INTERNAL_ERROR","message":"failed to get room"}}`, http.StatusInternalServerError)
+		apierror.Write(w, apierror.InternalError, "failed to get room")
 		return
 	}
 
@@ -162,7 +163,7 @@ func (h *RoomSSEHandler) streamRoom(w http.ResponseWriter, r *http.Request, room
 	current := atomic.AddInt64(&globalSSEConnections, 1)
 	defer atomic.AddInt64(&globalSSEConnections, -1)
 	if current > MaxGlobalSSEConnections {
-		http.Error(w, `{"error":{"code":"SERVICE_UNAVAILABLE","message":"SSE connection limit reached"}}`, http.StatusServiceUnavailable)
+		apierror.Write(w, apierror.ServiceUnavailable, "SSE connection limit reached")
 		return
 	}
 
@@ -214,7 +215,7 @@ func (h *RoomSSEHandler) streamRoom(w http.ResponseWriter, r *http.Request, room
 	ch, err := roomHub.Subscribe(subscriberName, nil)
 	if err != nil {
 		// ErrRoomAtCapacity — per-room SSE limit reached (T-16-04).
-		http.Error(w, `{"error":{"code":"SERVICE_UNAVAILABLE","message":"room at capacity"}}`, http.StatusServiceUnavailable)
+		apierror.Write(w, apierror.ServiceUnavailable, "room at capacity")
 		return
 	}
 	defer roomHub.Unsubscribe(subscriberName)
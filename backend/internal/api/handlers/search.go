@@ -13,15 +13,18 @@ import (
 	"strings"
 	"time"
 
-	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
-// SearchRepositoryInterface defines the database operations for search.
-type SearchRepositoryInterface interface {
+// SearchBackend runs searches. db.SearchRepository searches PostgreSQL directly;
+// services.OpenSearchBackend queries an OpenSearch index kept in sync from the
+// change event log. SEARCH_BACKEND selects one (see config.SearchBackendConfig).
+type SearchBackend interface {
 	// Search performs a search with the given query and options.
 	// Returns results (page), total count (post-filter), search method used
-	// ("hybrid_rrf" or "fulltext_only"), the top cosine similarity across ALL matches
+	// ("hybrid_rrf", "fulltext_only" when no embedding service is configured, or
+	// "fulltext_fallback" when query embedding failed, "opensearch" from OpenSearch), the top cosine similarity across ALL matches
 	// before filtering (nil when no semantic measure is available), and any error.
 	// See BART-155 for the similarity/confidence contract.
 	Search(ctx context.Context, query string, opts models.SearchOptions) ([]models.SearchResult, int, string, *float64, error)
@@ -39,13 +42,13 @@ const DefaultSearchConfidenceThreshold = 0.85
 
 // SearchHandler handles search-related HTTP requests.
 type SearchHandler struct {
-	repo                SearchRepositoryInterface
+	repo                SearchBackend
 	analyticsRepo       SearchAnalyticsInserter
 	confidenceThreshold float64
 }
 
 // NewSearchHandler creates a new SearchHandler.
-func NewSearchHandler(repo SearchRepositoryInterface) *SearchHandler {
+func NewSearchHandler(repo SearchBackend) *SearchHandler {
 	return &SearchHandler{repo: repo, confidenceThreshold: DefaultSearchConfidenceThreshold}
 }
 
@@ -75,6 +78,11 @@ type SearchResponseMeta struct {
 	HasMore bool   `json:"has_more"`
 	TookMs  int64  `json:"took_ms"`
 	Method  string `json:"method"` // "hybrid" or "fulltext" - indicates which search method was used
+	// Degraded is true when semantic search was unavailable and results are keyword-only.
+	// DegradedReason is "embedding_unavailable" (provider failed for this query) or
+	// "embedding_disabled" (no embedding service configured).
+	Degraded       bool   `json:"degraded"`
+	DegradedReason string `json:"degraded_reason,omitempty"`
 	// TopSimilarity is the best cosine similarity (0–1) across ALL matches before the
 	// min_similarity filter + pagination; nil when no semantic measure is available
 	// (e.g. fulltext-only method). See BART-155.
@@ -93,6 +101,7 @@ type SearchResponseMeta struct {
 //   - q: search query (required)
 //   - type: filter by post type (problem|question|idea)
 //   - tags: comma-separated tags
+//   - lang: comma-separated code block languages (e.g. go, python; aliases like golang accepted)
 //   - status: filter by status
 //   - author: filter by author_id
 //   - author_type: filter by author_type (human|agent)
@@ -111,7 +120,7 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	// Parse and validate query parameter
 	query := strings.TrimSpace(r.URL.Query().Get("q"))
 	if query == "" {
-		writeSearchError(w, http.StatusBadRequest, "VALIDATION_ERROR", "search query 'q' is required")
+		apierror.Write(w, apierror.ValidationError, "search query 'q' is required")
 		return
 	}
 
@@ -133,6 +142,18 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Parse lang (comma-separated), normalizing aliases to the stored language names
+	if langParam := r.URL.Query().Get("lang"); langParam != "" {
+		for _, lang := range strings.Split(langParam, ",") {
+			normalized := models.NormalizeCodeLanguage(lang)
+			if normalized == "" {
+				apierror.Write(w, apierror.ValidationError, fmt.Sprintf("invalid lang %q", strings.TrimSpace(lang)))
+				return
+			}
+			opts.Languages = append(opts.Languages, normalized)
+		}
+	}
+
 	// Parse content_types (comma-separated: posts, answers, approaches)
 	if ctParam := r.URL.Query().Get("content_types"); ctParam != "" {
 		opts.ContentTypes = strings.Split(ctParam, ",")
@@ -145,7 +166,7 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	if fromDate := r.URL.Query().Get("from_date"); fromDate != "" {
 		parsed, err := time.Parse("2006-01-02", fromDate)
 		if err != nil {
-			writeSearchError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid from_date format, use YYYY-MM-DD")
+			apierror.Write(w, apierror.ValidationError, "invalid from_date format, use YYYY-MM-DD")
 			return
 		}
 		opts.FromDate = parsed
@@ -154,7 +175,7 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	if toDate := r.URL.Query().Get("to_date"); toDate != "" {
 		parsed, err := time.Parse("2006-01-02", toDate)
 		if err != nil {
-			writeSearchError(w, http.StatusBadRequest, "VALIDATION_ERROR", "invalid to_date format, use YYYY-MM-DD")
+			apierror.Write(w, apierror.ValidationError, "invalid to_date format, use YYYY-MM-DD")
 			return
 		}
 		opts.ToDate = parsed
@@ -199,13 +220,10 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// BART-151: caller's family human for visibility scoping ("" = public-only).
-	opts.ViewerHuman = callerHumanID(r)
-
 	// Execute search
 	results, total, method, topSimilarity, err := h.repo.Search(r.Context(), query, opts)
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "search failed")
+		apierror.Write(w, apierror.InternalError, "search failed")
 		return
 	}
 
@@ -216,6 +234,13 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	if method == "hybrid_rrf" || method == "hybrid" {
 		searchMethod = "hybrid"
 	}
+	degradedReason := searchDegradedReason(method)
+	// min_similarity filters on cosine similarity, which keyword-only results don't have:
+	// without a query embedding the honest answer is "unavailable", not an empty page.
+	if opts.MinSimilarity > 0 && degradedReason != "" {
+		apierror.Write(w, apierror.EmbeddingUnavailable, "min_similarity needs semantic search, which is unavailable for this query ("+degradedReason+"); retry, or drop min_similarity for keyword results")
+		return
+	}
 
 	// Convert to response format
 	responseData := make([]models.SearchResultResponse, len(results))
@@ -244,6 +269,8 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 			HasMore:        hasMore,
 			TookMs:         tookMs,
 			Method:         searchMethod,
+			Degraded:       degradedReason != "",
+			DegradedReason: degradedReason,
 			TopSimilarity:  topSimilarity,
 			ConfidentMatch: models.IsConfidentMatch(topSimilarity, confidenceThreshold),
 			Warnings:       warnings,
@@ -254,17 +281,6 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 
 	// Async search analytics insert (fire-and-forget, no latency impact)
 	if h.analyticsRepo != nil {
-		// Extract searcher identity from auth context
-		searcherType := "anonymous"
-		var searcherID *string
-		if claims := auth.ClaimsFromContext(r.Context()); claims != nil {
-			searcherType = "human"
-			searcherID = &claims.UserID
-		} else if agent := auth.AgentFromContext(r.Context()); agent != nil {
-			searcherType = "agent"
-			searcherID = &agent.ID
-		}
-
 		// Truncate query to 500 chars (matches DB CHECK constraint)
 		q := query
 		if len(q) > 500 {
@@ -277,8 +293,6 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 			ResultsCount:    total,
 			SearchMethod:    searchMethod,
 			DurationMs:      int(tookMs),
-			SearcherType:    searcherType,
-			SearcherID:      searcherID,
 			Page:            opts.Page,
 			UserAgent:       r.Header.Get("User-Agent"),
 			SearchedAt:      start,
@@ -286,6 +300,11 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 		if opts.Type != "" {
 			sq.TypeFilter = &opts.Type
 		}
+		for _, result := range results {
+			if result.Source == "post" {
+				sq.ResultPostIDs = append(sq.ResultPostIDs, result.ID)
+			}
+		}
 
 		// Defensive IP extraction: RealIP middleware may strip port
 		ip := r.RemoteAddr
@@ -296,7 +315,8 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 
 		go func() {
 			defer func() { recover() }()
-			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			// Keep the request's values so the repository records the searcher
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), 100*time.Millisecond)
 			defer cancel()
 			if err := h.analyticsRepo.Insert(ctx, sq); err != nil {
 				slog.Warn("search analytics insert failed", "error", err)
@@ -308,7 +328,7 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 // validSearchParams is the allow-list of query params GET /search understands. Any other
 // param is ignored and reported in meta.warnings. Keep in sync with the .Get() calls in Search.
 var validSearchParams = map[string]struct{}{
-	"q": {}, "type": {}, "tags": {}, "status": {}, "author": {}, "author_type": {},
+	"q": {}, "type": {}, "tags": {}, "lang": {}, "status": {}, "author": {}, "author_type": {},
 	"from_date": {}, "to_date": {}, "sort": {}, "page": {}, "per_page": {},
 	"content_types": {}, "min_similarity": {}, "confidence_threshold": {},
 }
@@ -383,14 +403,15 @@ func writeSearchJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
-// writeSearchError writes an error JSON response.
-func writeSearchError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
+// searchDegradedReason maps the repository search method to a meta.degraded_reason.
+// Returns "" when results were not degraded.
+func searchDegradedReason(method string) string {
+	switch method {
+	case "fulltext_fallback":
+		return "embedding_unavailable"
+	case "fulltext_only":
+		return "embedding_disabled"
+	default:
+		return ""
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -37,13 +38,13 @@ func (h *SearchAnalyticsHandler) GetTrending(w http.ResponseWriter, r *http.Requ
 
 	trending, err := h.repo.GetTrending(r.Context(), days, limit)
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get trending searches")
+		apierror.Write(w, apierror.InternalError, "failed to get trending searches")
 		return
 	}
 
 	zeroResults, err := h.repo.GetZeroResults(r.Context(), days, limit)
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get zero-result searches")
+		apierror.Write(w, apierror.InternalError, "failed to get zero-result searches")
 		return
 	}
 
@@ -65,7 +66,7 @@ func (h *SearchAnalyticsHandler) GetSummary(w http.ResponseWriter, r *http.Reque
 
 	summary, err := h.repo.GetSummary(r.Context(), days)
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get search analytics summary")
+		apierror.Write(w, apierror.InternalError, "failed to get search analytics summary")
 		return
 	}
 
@@ -88,13 +89,13 @@ func (h *SearchAnalyticsHandler) GetPublicSearchStats(w http.ResponseWriter, r *
 
 	summary, err := h.repo.GetSummary(ctx, 7)
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get search stats")
+		apierror.Write(w, apierror.InternalError, "failed to get search stats")
 		return
 	}
 
 	trending, err := h.repo.GetTrending(ctx, 7, 5)
 	if err != nil {
-		writeSearchError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get trending searches")
+		apierror.Write(w, apierror.InternalError, "failed to get trending searches")
 		return
 	}
 
@@ -118,18 +119,18 @@ func (h *SearchAnalyticsHandler) GetPublicSearchStats(w http.ResponseWriter, r *
 func checkSearchAnalyticsAuth(w http.ResponseWriter, r *http.Request) bool {
 	adminKey := os.Getenv("ADMIN_API_KEY")
 	if adminKey == "" {
-		writeSearchError(w, http.StatusServiceUnavailable, "ADMIN_NOT_CONFIGURED", "admin API key not configured")
+		apierror.Write(w, apierror.AdminNotConfigured, "admin API key not configured")
 		return false
 	}
 
 	providedKey := r.Header.Get("X-Admin-API-Key")
 	if providedKey == "" {
-		writeSearchError(w, http.StatusUnauthorized, "MISSING_API_KEY", "X-Admin-API-Key header required")
+		apierror.Write(w, apierror.MissingAPIKey, "X-Admin-API-Key header required")
 		return false
 	}
 
 	if providedKey != adminKey {
-		writeSearchError(w, http.StatusForbidden, "INVALID_API_KEY", "invalid admin API key")
+		apierror.WriteStatus(w, http.StatusForbidden, apierror.InvalidAPIKey, "invalid admin API key")
 		return false
 	}
 
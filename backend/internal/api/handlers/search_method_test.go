@@ -214,3 +214,73 @@ func TestSearch_MetaMethodWithResults(t *testing.T) {
 		t.Errorf("expected method 'hybrid', got '%v'", meta["method"])
 	}
 }
+
+// TestSearch_MetaDegraded tests that meta.degraded reflects keyword-only fallback.
+func TestSearch_MetaDegraded(t *testing.T) {
+	tests := []struct {
+		method       string
+		wantDegraded bool
+		wantReason   string
+	}{
+		{method: "hybrid_rrf", wantDegraded: false, wantReason: ""},
+		{method: "fulltext_fallback", wantDegraded: true, wantReason: "embedding_unavailable"},
+		{method: "fulltext_only", wantDegraded: true, wantReason: "embedding_disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			repo := NewMockSearchRepository()
+			repo.SetResults([]models.SearchResult{}, 0)
+			repo.SetMethod(tt.method)
+
+			handler := NewSearchHandler(repo)
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/search?q=test", nil)
+			w := httptest.NewRecorder()
+
+			handler.Search(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", w.Code)
+			}
+
+			var resp SearchResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if resp.Meta.Degraded != tt.wantDegraded {
+				t.Errorf("expected degraded %v, got %v", tt.wantDegraded, resp.Meta.Degraded)
+			}
+			if resp.Meta.DegradedReason != tt.wantReason {
+				t.Errorf("expected degraded_reason %q, got %q", tt.wantReason, resp.Meta.DegradedReason)
+			}
+		})
+	}
+}
+
+// TestSearch_MinSimilarityWithoutEmbedding tests that min_similarity reports
+// EMBEDDING_UNAVAILABLE instead of an empty page when the query embedding failed.
+func TestSearch_MinSimilarityWithoutEmbedding(t *testing.T) {
+	repo := NewMockSearchRepository()
+	repo.SetResults([]models.SearchResult{}, 0)
+	repo.SetMethod("fulltext_fallback")
+
+	handler := NewSearchHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=test&min_similarity=0.5", nil)
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", w.Code)
+	}
+	var resp map[string]map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp["error"]["code"] != "EMBEDDING_UNAVAILABLE" {
+		t.Errorf("expected EMBEDDING_UNAVAILABLE, got %v", resp["error"]["code"])
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
-// MockSearchRepository implements SearchRepositoryInterface for testing.
+// MockSearchRepository implements SearchBackend for testing.
 type MockSearchRepository struct {
 	results     []models.SearchResult
 	total       int
@@ -270,6 +270,41 @@ func TestSearch_TagsFilter(t *testing.T) {
 	}
 }
 
+// TestSearch_LangFilter tests filtering by code block language with alias normalization.
+func TestSearch_LangFilter(t *testing.T) {
+	repo := NewMockSearchRepository()
+	repo.SetResults([]models.SearchResult{}, 0)
+
+	handler := NewSearchHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=test&lang=golang,%20Python", nil)
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+
+	if len(repo.searchOpts.Languages) != 2 || repo.searchOpts.Languages[0] != "go" || repo.searchOpts.Languages[1] != "python" {
+		t.Errorf("expected languages [go, python], got %v", repo.searchOpts.Languages)
+	}
+}
+
+// TestSearch_InvalidLang tests that a non-language lang value is rejected.
+func TestSearch_InvalidLang(t *testing.T) {
+	handler := NewSearchHandler(NewMockSearchRepository())
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/search?q=test&lang=text", nil)
+	w := httptest.NewRecorder()
+
+	handler.Search(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
 // TestSearch_StatusFilter tests filtering by status.
 func TestSearch_StatusFilter(t *testing.T) {
 	repo := NewMockSearchRepository()
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -25,12 +26,13 @@ type SitemapRepositoryInterface interface {
 
 // SitemapHandler handles sitemap endpoints.
 type SitemapHandler struct {
-	repo SitemapRepositoryInterface
+	repo    SitemapRepositoryInterface
+	siteURL string
 }
 
 // NewSitemapHandler creates a new SitemapHandler.
 func NewSitemapHandler(repo SitemapRepositoryInterface) *SitemapHandler {
-	return &SitemapHandler{repo: repo}
+	return &SitemapHandler{repo: repo, siteURL: "https://solvr.dev"}
 }
 
 // GetSitemapURLs handles GET /v1/sitemap/urls
@@ -50,7 +52,7 @@ func (h *SitemapHandler) GetSitemapURLs(w http.ResponseWriter, r *http.Request)
 	// Backward compat: no type param → return all URLs
 	urls, err := h.repo.GetSitemapURLs(ctx)
 	if err != nil {
-		writeSitemapError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get sitemap URLs")
+		apierror.Write(w, apierror.InternalError, "failed to get sitemap URLs")
 		return
 	}
 
@@ -61,7 +63,7 @@ func (h *SitemapHandler) getSitemapURLsPaginated(w http.ResponseWriter, r *http.
 	// Validate type
 	validTypes := map[string]bool{"posts": true, "agents": true, "users": true, "blog_posts": true, "rooms": true}
 	if !validTypes[typeParam] {
-		writeSitemapError(w, http.StatusBadRequest, "INVALID_PARAM", "type must be one of: posts, agents, users, blog_posts, rooms")
+		apierror.Write(w, apierror.InvalidParam, "type must be one of: posts, agents, users, blog_posts, rooms")
 		return
 	}
 
@@ -71,7 +73,7 @@ func (h *SitemapHandler) getSitemapURLsPaginated(w http.ResponseWriter, r *http.
 		var err error
 		page, err = strconv.Atoi(p)
 		if err != nil || page < 1 {
-			writeSitemapError(w, http.StatusBadRequest, "INVALID_PARAM", "page must be a positive integer")
+			apierror.Write(w, apierror.InvalidParam, "page must be a positive integer")
 			return
 		}
 	}
@@ -82,7 +84,7 @@ func (h *SitemapHandler) getSitemapURLsPaginated(w http.ResponseWriter, r *http.
 		var err error
 		perPage, err = strconv.Atoi(pp)
 		if err != nil || perPage < 1 || perPage > SitemapMaxPerPage {
-			writeSitemapError(w, http.StatusBadRequest, "INVALID_PARAM", "per_page must be between 1 and 5000")
+			apierror.Write(w, apierror.InvalidParam, "per_page must be between 1 and 5000")
 			return
 		}
 	}
@@ -95,24 +97,13 @@ func (h *SitemapHandler) getSitemapURLsPaginated(w http.ResponseWriter, r *http.
 
 	urls, err := h.repo.GetPaginatedSitemapURLs(ctx, opts)
 	if err != nil {
-		writeSitemapError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get sitemap URLs")
+		apierror.Write(w, apierror.InternalError, "failed to get sitemap URLs")
 		return
 	}
 
 	writeSitemapURLsResponse(w, urls)
 }
 
-func writeSitemapError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 func writeSitemapURLsResponse(w http.ResponseWriter, urls *models.SitemapURLs) {
 	response := map[string]interface{}{
 		"data": map[string]interface{}{
@@ -136,14 +127,7 @@ func (h *SitemapHandler) GetSitemapCounts(w http.ResponseWriter, r *http.Request
 
 	counts, err := h.repo.GetSitemapCounts(ctx)
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "failed to get sitemap counts",
-			},
-		})
+		apierror.Write(w, apierror.InternalError, "failed to get sitemap counts")
 		return
 	}
 
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// sitemapXMLNamespace is the sitemaps.org protocol namespace.
+const sitemapXMLNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// sitemapXMLTypes lists the sitemap content types in index order.
+var sitemapXMLTypes = []string{"posts", "agents", "users", "blog_posts", "rooms"}
+
+type sitemapIndexXML struct {
+	XMLName  xml.Name          `xml:"sitemapindex"`
+	Xmlns    string            `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntryXML `xml:"sitemap"`
+}
+
+type sitemapEntryXML struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemapURLSetXML struct {
+	XMLName xml.Name        `xml:"urlset"`
+	Xmlns   string          `xml:"xmlns,attr"`
+	URLs    []sitemapURLXML `xml:"url"`
+}
+
+type sitemapURLXML struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// SetSiteURL sets the frontend origin used for <loc> entries in /sitemap.xml
+// (e.g. https://solvr.dev).
+func (h *SitemapHandler) SetSiteURL(siteURL string) {
+	h.siteURL = siteURL
+}
+
+// GetSitemapXML handles GET /sitemap.xml
+// Without a type param it returns a <sitemapindex> with one page per
+// SitemapMaxPerPage URLs of each content type. With ?type=X&page=N it returns
+// that page as a <urlset> of frontend URLs.
+// No auth required — this is public data.
+func (h *SitemapHandler) GetSitemapXML(w http.ResponseWriter, r *http.Request) {
+	typeParam := r.URL.Query().Get("type")
+	if typeParam == "" {
+		h.getSitemapIndexXML(w, r)
+		return
+	}
+
+	validTypes := map[string]bool{"posts": true, "agents": true, "users": true, "blog_posts": true, "rooms": true}
+	if !validTypes[typeParam] {
+		apierror.Write(w, apierror.InvalidParam, "type must be one of: posts, agents, users, blog_posts, rooms")
+		return
+	}
+
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		var err error
+		page, err = strconv.Atoi(p)
+		if err != nil || page < 1 {
+			apierror.Write(w, apierror.InvalidParam, "page must be a positive integer")
+			return
+		}
+	}
+
+	urls, err := h.repo.GetPaginatedSitemapURLs(r.Context(), models.SitemapURLsOptions{
+		Type:    typeParam,
+		Page:    page,
+		PerPage: SitemapMaxPerPage,
+	})
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get sitemap URLs")
+		return
+	}
+
+	writeSitemapXML(w, sitemapURLSetXML{Xmlns: sitemapXMLNamespace, URLs: h.sitemapURLEntries(urls)})
+}
+
+func (h *SitemapHandler) getSitemapIndexXML(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.repo.GetSitemapCounts(r.Context())
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get sitemap counts")
+		return
+	}
+
+	perType := map[string]int{
+		"posts":      counts.Posts,
+		"agents":     counts.Agents,
+		"users":      counts.Users,
+		"blog_posts": counts.BlogPosts,
+		"rooms":      counts.Rooms,
+	}
+
+	base := requestBaseURL(r) + "/sitemap.xml"
+	index := sitemapIndexXML{Xmlns: sitemapXMLNamespace, Sitemaps: []sitemapEntryXML{}}
+	for _, t := range sitemapXMLTypes {
+		pages := (perType[t] + SitemapMaxPerPage - 1) / SitemapMaxPerPage
+		for page := 1; page <= pages; page++ {
+			index.Sitemaps = append(index.Sitemaps, sitemapEntryXML{
+				Loc: fmt.Sprintf("%s?type=%s&page=%d", base, url.QueryEscape(t), page),
+			})
+		}
+	}
+
+	writeSitemapXML(w, index)
+}
+
+// sitemapURLEntries maps sitemap rows to frontend page URLs.
+func (h *SitemapHandler) sitemapURLEntries(urls *models.SitemapURLs) []sitemapURLXML {
+	entries := make([]sitemapURLXML, 0)
+	for _, p := range urls.Posts {
+		entries = append(entries, sitemapURLXML{Loc: h.siteURL + "/" + p.Type + "s/" + p.ID, LastMod: sitemapLastMod(p.UpdatedAt)})
+	}
+	for _, a := range urls.Agents {
+		entries = append(entries, sitemapURLXML{Loc: h.siteURL + "/agents/" + a.ID, LastMod: sitemapLastMod(a.UpdatedAt)})
+	}
+	for _, u := range urls.Users {
+		entries = append(entries, sitemapURLXML{Loc: h.siteURL + "/users/" + u.ID, LastMod: sitemapLastMod(u.UpdatedAt)})
+	}
+	for _, b := range urls.BlogPosts {
+		entries = append(entries, sitemapURLXML{Loc: h.siteURL + "/blog/" + url.PathEscape(b.Slug), LastMod: sitemapLastMod(b.UpdatedAt)})
+	}
+	for _, room := range urls.Rooms {
+		entries = append(entries, sitemapURLXML{Loc: h.siteURL + "/rooms/" + url.PathEscape(room.Slug), LastMod: sitemapLastMod(room.LastActiveAt)})
+	}
+	return entries
+}
+
+func sitemapLastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// requestBaseURL returns the scheme and host the request was addressed to,
+// honouring X-Forwarded-Proto from the load balancer.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "https" || proto == "http" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
+
+func writeSitemapXML(w http.ResponseWriter, v interface{}) {
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to encode sitemap")
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	w.Write(out)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestGetSitemapXML_Index(t *testing.T) {
+	repo := &MockSitemapRepository{
+		Counts: &models.SitemapCounts{Posts: SitemapMaxPerPage + 1, Agents: 3, Users: 0, BlogPosts: 1, Rooms: 0},
+	}
+	handler := NewSitemapHandler(repo)
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.solvr.dev/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	handler.GetSitemapXML(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/xml") {
+		t.Errorf("expected application/xml, got %q", ct)
+	}
+
+	var index sitemapIndexXML
+	if err := xml.Unmarshal(w.Body.Bytes(), &index); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	want := []string{
+		"https://api.solvr.dev/sitemap.xml?type=posts&page=1",
+		"https://api.solvr.dev/sitemap.xml?type=posts&page=2",
+		"https://api.solvr.dev/sitemap.xml?type=agents&page=1",
+		"https://api.solvr.dev/sitemap.xml?type=blog_posts&page=1",
+	}
+	if len(index.Sitemaps) != len(want) {
+		t.Fatalf("expected %d sitemaps, got %d: %+v", len(want), len(index.Sitemaps), index.Sitemaps)
+	}
+	for i, loc := range want {
+		if index.Sitemaps[i].Loc != loc {
+			t.Errorf("sitemap %d: expected %q, got %q", i, loc, index.Sitemaps[i].Loc)
+		}
+	}
+}
+
+func TestGetSitemapXML_URLSet(t *testing.T) {
+	updated := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	repo := &MockSitemapRepository{
+		PaginatedResult: &models.SitemapURLs{
+			Posts: []models.SitemapPost{
+				{ID: "p1", Type: "problem", UpdatedAt: updated},
+				{ID: "q1", Type: "question", UpdatedAt: updated},
+			},
+		},
+	}
+	handler := NewSitemapHandler(repo)
+	handler.SetSiteURL("https://solvr.dev")
+
+	req := httptest.NewRequest(http.MethodGet, "/sitemap.xml?type=posts&page=2", nil)
+	w := httptest.NewRecorder()
+	handler.GetSitemapXML(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.PaginatedOpts == nil || repo.PaginatedOpts.Page != 2 || repo.PaginatedOpts.PerPage != SitemapMaxPerPage {
+		t.Errorf("unexpected paginated opts %+v", repo.PaginatedOpts)
+	}
+
+	var set sitemapURLSetXML
+	if err := xml.Unmarshal(w.Body.Bytes(), &set); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if len(set.URLs) != 2 || set.URLs[0].Loc != "https://solvr.dev/problems/p1" || set.URLs[1].Loc != "https://solvr.dev/questions/q1" {
+		t.Errorf("unexpected urls %+v", set.URLs)
+	}
+	if set.URLs[0].LastMod != "2026-05-01T10:00:00Z" {
+		t.Errorf("unexpected lastmod %q", set.URLs[0].LastMod)
+	}
+}
+
+func TestGetSitemapXML_InvalidParams(t *testing.T) {
+	handler := NewSitemapHandler(&MockSitemapRepository{})
+	for _, query := range []string{"?type=comments", "?type=posts&page=0"} {
+		w := httptest.NewRecorder()
+		handler.GetSitemapXML(w, httptest.NewRequest(http.MethodGet, "/sitemap.xml"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestGetSitemapXML_RepoError(t *testing.T) {
+	handler := NewSitemapHandler(&MockSitemapRepository{CountsErr: errors.New("db down")})
+	w := httptest.NewRecorder()
+	handler.GetSitemapXML(w, httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
@@ -5,14 +5,19 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 // StatsRepositoryInterface defines the interface for stats data access.
 type StatsRepositoryInterface interface {
 	GetAllStats(ctx context.Context) (*db.AllStatsResult, error)
+	GetCodeLanguageStats(ctx context.Context, limit int) ([]models.CodeLanguageCount, error)
 	GetActivePostsCount(ctx context.Context) (int, error)
 	GetAgentsCount(ctx context.Context) (int, error)
 	GetSolvedTodayCount(ctx context.Context) (int, error)
@@ -41,9 +46,25 @@ type StatsRepositoryInterface interface {
 	GetRecentlyRealized(ctx context.Context, limit int) ([]map[string]any, error)
 }
 
+// StatsHistoryRepositoryInterface reads daily site-wide stats snapshots.
+type StatsHistoryRepositoryInterface interface {
+	GetStatsHistory(ctx context.Context, metric string, days int) ([]models.StatsHistoryPoint, error)
+}
+
+// statsCodeLanguagesLimit is how many languages GET /v1/stats reports.
+const statsCodeLanguagesLimit = 20
+
+// Window bounds for GET /v1/stats/history?window=Nd.
+const (
+	defaultStatsHistoryDays = 30
+	maxStatsHistoryDays     = 365
+)
+
 // StatsHandler handles statistics endpoints.
 type StatsHandler struct {
-	repo StatsRepositoryInterface
+	repo           StatsRepositoryInterface
+	historyRepo    StatsHistoryRepositoryInterface
+	trendingConfig *models.TrendingConfig
 }
 
 // NewStatsHandler creates a new StatsHandler.
@@ -51,6 +72,17 @@ func NewStatsHandler(repo StatsRepositoryInterface) *StatsHandler {
 	return &StatsHandler{repo: repo}
 }
 
+// SetTrendingConfig sets the trending weights reported by GET /v1/stats/trending,
+// so score breakdowns can be read against the weights that produced them.
+func (h *StatsHandler) SetTrendingConfig(cfg models.TrendingConfig) {
+	h.trendingConfig = &cfg
+}
+
+// SetHistoryRepo sets the repository backing GET /v1/stats/history.
+func (h *StatsHandler) SetHistoryRepo(repo StatsHistoryRepositoryInterface) {
+	h.historyRepo = repo
+}
+
 // StatsResponse represents the response for GET /v1/stats
 type StatsResponse struct {
 	ActivePosts        int `json:"active_posts"`
@@ -63,22 +95,27 @@ type StatsResponse struct {
 	TotalPosts         int `json:"total_posts"`
 	TotalContributions int `json:"total_contributions"`
 	CrystallizedPosts  int `json:"crystallized_posts"`
+	// Languages is the code block language distribution across posts and answers.
+	Languages []models.CodeLanguageCount `json:"languages"`
 }
 
 // TrendingResponse represents the response for GET /v1/stats/trending
 type TrendingResponse struct {
-	Posts []TrendingPost `json:"posts"`
-	Tags  []TrendingTag  `json:"tags"`
+	Posts   []TrendingPost         `json:"posts"`
+	Tags    []TrendingTag          `json:"tags"`
+	Scoring *models.TrendingConfig `json:"scoring,omitempty"`
 }
 
 // TrendingPost represents a trending post for the sidebar
 type TrendingPost struct {
-	ID            string    `json:"id"`
-	Title         string    `json:"title"`
-	Type          string    `json:"type"`
-	ResponseCount int       `json:"response_count"`
-	VoteScore     int       `json:"vote_score"`
-	CreatedAt     time.Time `json:"created_at,omitempty"`
+	ID             string                        `json:"id"`
+	Title          string                        `json:"title"`
+	Type           string                        `json:"type"`
+	ResponseCount  int                           `json:"response_count"`
+	VoteScore      int                           `json:"vote_score"`
+	CreatedAt      time.Time                     `json:"created_at,omitempty"`
+	Score          float64                       `json:"score"`
+	ScoreBreakdown models.TrendingScoreBreakdown `json:"score_breakdown"`
 }
 
 // TrendingTag represents a trending tag
@@ -94,7 +131,13 @@ func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 
 	s, err := h.repo.GetAllStats(ctx)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get stats")
+		apierror.Write(w, apierror.InternalError, "failed to get stats")
+		return
+	}
+
+	languages, err := h.repo.GetCodeLanguageStats(ctx, statsCodeLanguagesLimit)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get language stats")
 		return
 	}
 
@@ -110,6 +153,7 @@ func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 			TotalPosts:         s.TotalPosts,
 			TotalContributions: s.TotalContributions,
 			CrystallizedPosts:  s.CrystallizedPosts,
+			Languages:          languages,
 		},
 	}
 
@@ -125,21 +169,25 @@ func (h *StatsHandler) GetTrending(w http.ResponseWriter, r *http.Request) {
 
 	posts, err := h.repo.GetTrendingPosts(ctx, 5)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get trending posts")
+		apierror.Write(w, apierror.InternalError, "failed to get trending posts")
 		return
 	}
 
 	tags, err := h.repo.GetTrendingTags(ctx, 10)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get trending tags")
+		apierror.Write(w, apierror.InternalError, "failed to get trending tags")
 		return
 	}
 
+	data := map[string]interface{}{
+		"posts": posts,
+		"tags":  tags,
+	}
+	if h.trendingConfig != nil {
+		data["scoring"] = h.trendingConfig
+	}
 	response := map[string]interface{}{
-		"data": map[string]interface{}{
-			"posts": posts,
-			"tags":  tags,
-		},
+		"data": data,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -155,19 +203,19 @@ func (h *StatsHandler) GetProblemsStats(w http.ResponseWriter, r *http.Request)
 
 	stats, err := h.repo.GetProblemsStats(ctx)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get problems stats")
+		apierror.Write(w, apierror.InternalError, "failed to get problems stats")
 		return
 	}
 
 	recentlySolved, err := h.repo.GetRecentlySolvedProblems(ctx, 3)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get recently solved problems")
+		apierror.Write(w, apierror.InternalError, "failed to get recently solved problems")
 		return
 	}
 
 	topSolvers, err := h.repo.GetTopProblemSolvers(ctx, 5)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get top problem solvers")
+		apierror.Write(w, apierror.InternalError, "failed to get top problem solvers")
 		return
 	}
 
@@ -191,19 +239,19 @@ func (h *StatsHandler) GetQuestionsStats(w http.ResponseWriter, r *http.Request)
 
 	stats, err := h.repo.GetQuestionsStats(ctx)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get questions stats")
+		apierror.Write(w, apierror.InternalError, "failed to get questions stats")
 		return
 	}
 
 	recentlyAnswered, err := h.repo.GetRecentlyAnsweredQuestions(ctx, 3)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get recently answered questions")
+		apierror.Write(w, apierror.InternalError, "failed to get recently answered questions")
 		return
 	}
 
 	topAnswerers, err := h.repo.GetTopAnswerers(ctx, 5)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get top answerers")
+		apierror.Write(w, apierror.InternalError, "failed to get top answerers")
 		return
 	}
 
@@ -220,15 +268,56 @@ func (h *StatsHandler) GetQuestionsStats(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(response)
 }
 
-func writeStatsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
+// StatsHistoryResponse represents the response for GET /v1/stats/history
+type StatsHistoryResponse struct {
+	Metric string                     `json:"metric"`
+	Window string                     `json:"window"`
+	Points []models.StatsHistoryPoint `json:"points"`
+}
+
+// GetStatsHistory handles GET /v1/stats/history?metric=posts&window=90d
+// Returns daily snapshots of a site-wide total, oldest first, for growth charts.
+// window is a number of days with a "d" suffix (default 30d, max 365d).
+func (h *StatsHandler) GetStatsHistory(w http.ResponseWriter, r *http.Request) {
+	if h.historyRepo == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "stats history is not available")
+		return
+	}
+
+	metric := r.URL.Query().Get("metric")
+	if !models.IsValidStatsMetric(metric) {
+		apierror.Write(w, apierror.ValidationError, "metric must be one of: "+strings.Join(models.StatsMetrics, ", "))
+		return
+	}
+
+	days := defaultStatsHistoryDays
+	if window := r.URL.Query().Get("window"); window != "" {
+		n, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+		if err != nil || !strings.HasSuffix(window, "d") || n < 1 || n > maxStatsHistoryDays {
+			apierror.Write(w, apierror.ValidationError, "window must be between 1d and 365d")
+			return
+		}
+		days = n
+	}
+
+	points, err := h.historyRepo.GetStatsHistory(r.Context(), metric, days)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get stats history")
+		return
+	}
+
+	response := map[string]interface{}{
+		"data": StatsHistoryResponse{
+			Metric: metric,
+			Window: strconv.Itoa(days) + "d",
+			Points: points,
 		},
-	})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=30")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
 }
 
 // GetIdeasStats handles GET /v1/stats/ideas
@@ -239,49 +328,49 @@ func (h *StatsHandler) GetIdeasStats(w http.ResponseWriter, r *http.Request) {
 	// Get counts by status
 	countsByStatus, err := h.repo.GetIdeasCountByStatus(ctx)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get ideas counts")
+		apierror.Write(w, apierror.InternalError, "failed to get ideas counts")
 		return
 	}
 
 	// Get fresh sparks (recent ideas)
 	freshSparks, err := h.repo.GetFreshSparks(ctx, 5)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get fresh sparks")
+		apierror.Write(w, apierror.InternalError, "failed to get fresh sparks")
 		return
 	}
 
 	// Get ready to develop ideas
 	readyToDevelop, err := h.repo.GetReadyToDevelop(ctx, 5)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get ready to develop ideas")
+		apierror.Write(w, apierror.InternalError, "failed to get ready to develop ideas")
 		return
 	}
 
 	// Get top sparklers (contributors)
 	topSparklers, err := h.repo.GetTopSparklers(ctx, 5)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get top sparklers")
+		apierror.Write(w, apierror.InternalError, "failed to get top sparklers")
 		return
 	}
 
 	// Get trending tags
 	trendingTags, err := h.repo.GetTrendingTags(ctx, 10)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get trending tags")
+		apierror.Write(w, apierror.InternalError, "failed to get trending tags")
 		return
 	}
 
 	// Get pipeline stats
 	pipelineStats, err := h.repo.GetIdeaPipelineStats(ctx)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get pipeline stats")
+		apierror.Write(w, apierror.InternalError, "failed to get pipeline stats")
 		return
 	}
 
 	// Get recently realized ideas
 	recentlyRealized, err := h.repo.GetRecentlyRealized(ctx, 3)
 	if err != nil {
-		writeStatsError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get recently realized ideas")
+		apierror.Write(w, apierror.InternalError, "failed to get recently realized ideas")
 		return
 	}
 
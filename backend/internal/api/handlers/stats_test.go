@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 // MockStatsRepository implements StatsRepositoryInterface for testing
@@ -25,6 +26,7 @@ type MockStatsRepository struct {
 	CrystallizedPosts  int
 	TrendingPosts      []any
 	TrendingTags       []any
+	CodeLanguages      []models.CodeLanguageCount
 	// Problems stats
 	ProblemsStatsResult      map[string]any
 	ProblemsStatsErr         error
@@ -56,6 +58,13 @@ func (m *MockStatsRepository) GetAllStats(ctx context.Context) (*db.AllStatsResu
 	}, nil
 }
 
+func (m *MockStatsRepository) GetCodeLanguageStats(ctx context.Context, limit int) ([]models.CodeLanguageCount, error) {
+	if m.CodeLanguages == nil {
+		return []models.CodeLanguageCount{}, nil
+	}
+	return m.CodeLanguages, nil
+}
+
 func (m *MockStatsRepository) GetActivePostsCount(ctx context.Context) (int, error) {
 	return m.ActivePosts, nil
 }
@@ -232,6 +241,10 @@ func TestStatsHandler_GetStats(t *testing.T) {
 				TotalPosts:         500,
 				TotalContributions: 320,
 				CrystallizedPosts:  7,
+				CodeLanguages: []models.CodeLanguageCount{
+					{Language: "go", Posts: 30, Answers: 12},
+					{Language: "python", Posts: 9, Answers: 4},
+				},
 			},
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, body map[string]interface{}) {
@@ -254,6 +267,14 @@ func TestStatsHandler_GetStats(t *testing.T) {
 						t.Errorf("expected %s=%d, got %d", field, expected, got)
 					}
 				}
+				languages := data["languages"].([]interface{})
+				if len(languages) != 2 {
+					t.Fatalf("expected 2 languages, got %v", data["languages"])
+				}
+				first := languages[0].(map[string]interface{})
+				if first["language"] != "go" || first["posts"].(float64) != 30 || first["answers"].(float64) != 12 {
+					t.Errorf("expected go with 30 posts and 12 answers, got %v", first)
+				}
 			},
 		},
 		{
@@ -666,3 +687,99 @@ func TestGetIdeasStats_CacheControl(t *testing.T) {
 		t.Errorf("expected Cache-Control 'public, max-age=30', got %q", cc)
 	}
 }
+
+func TestGetTrending_IncludesScoringConfig(t *testing.T) {
+	handler := NewStatsHandler(&MockStatsRepository{})
+	cfg := models.DefaultTrendingConfig()
+	cfg.HalfLifeHours = 12
+	handler.SetTrendingConfig(cfg)
+
+	req := httptest.NewRequest("GET", "/v1/stats/trending", nil)
+	rec := httptest.NewRecorder()
+	handler.GetTrending(rec, req)
+
+	var body struct {
+		Data TrendingResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Data.Scoring == nil || *body.Data.Scoring != cfg {
+		t.Errorf("expected scoring %+v, got %+v", cfg, body.Data.Scoring)
+	}
+}
+
+// mockStatsHistoryRepo is a test double for StatsHistoryRepositoryInterface.
+type mockStatsHistoryRepo struct {
+	metric string
+	days   int
+}
+
+func (m *mockStatsHistoryRepo) GetStatsHistory(ctx context.Context, metric string, days int) ([]models.StatsHistoryPoint, error) {
+	m.metric = metric
+	m.days = days
+	return []models.StatsHistoryPoint{{Date: "2026-01-01", Value: 10}, {Date: "2026-01-02", Value: 12}}, nil
+}
+
+func TestGetStatsHistory(t *testing.T) {
+	historyRepo := &mockStatsHistoryRepo{}
+	handler := NewStatsHandler(&MockStatsRepository{})
+	handler.SetHistoryRepo(historyRepo)
+
+	req := httptest.NewRequest("GET", "/v1/stats/history?metric=posts&window=90d", nil)
+	rec := httptest.NewRecorder()
+	handler.GetStatsHistory(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if historyRepo.metric != "posts" || historyRepo.days != 90 {
+		t.Errorf("expected posts over 90 days, got %s over %d", historyRepo.metric, historyRepo.days)
+	}
+	var body struct {
+		Data StatsHistoryResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Data.Window != "90d" || len(body.Data.Points) != 2 || body.Data.Points[1].Value != 12 {
+		t.Errorf("unexpected response: %+v", body.Data)
+	}
+}
+
+func TestGetStatsHistory_DefaultWindow(t *testing.T) {
+	historyRepo := &mockStatsHistoryRepo{}
+	handler := NewStatsHandler(&MockStatsRepository{})
+	handler.SetHistoryRepo(historyRepo)
+
+	rec := httptest.NewRecorder()
+	handler.GetStatsHistory(rec, httptest.NewRequest("GET", "/v1/stats/history?metric=agents", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if historyRepo.days != defaultStatsHistoryDays {
+		t.Errorf("expected %d days, got %d", defaultStatsHistoryDays, historyRepo.days)
+	}
+}
+
+func TestGetStatsHistory_Validation(t *testing.T) {
+	handler := NewStatsHandler(&MockStatsRepository{})
+	handler.SetHistoryRepo(&mockStatsHistoryRepo{})
+
+	for _, query := range []string{
+		"",
+		"?metric=unknown",
+		"?metric=posts&window=90",
+		"?metric=posts&window=0d",
+		"?metric=posts&window=366d",
+		"?metric=posts&window=abc",
+	} {
+		rec := httptest.NewRecorder()
+		handler.GetStatsHistory(rec, httptest.NewRequest("GET", "/v1/stats/history"+query, nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected status 400, got %d", query, rec.Code)
+		}
+	}
+}
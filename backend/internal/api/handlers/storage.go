@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 )
 
@@ -43,27 +44,13 @@ func (h *StorageHandler) SetAgentFinderRepo(repo AgentFinderInterface) {
 func (h *StorageHandler) GetStorage(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": map[string]string{
-				"code":    "UNAUTHORIZED",
-				"message": "authentication required",
-			},
-		})
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	used, quota, err := h.repo.GetStorageUsage(r.Context(), authInfo.AuthorID, string(authInfo.AuthorType))
 	if err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": map[string]string{
-				"code":    "INTERNAL_ERROR",
-				"message": "failed to get storage usage",
-			},
-		})
+		apierror.Write(w, apierror.InternalError, "failed to get storage usage")
 		return
 	}
 
@@ -92,34 +79,34 @@ func (h *StorageHandler) GetAgentStorage(w http.ResponseWriter, r *http.Request,
 	authAgent := auth.AgentFromContext(ctx)
 	if authAgent != nil {
 		if authAgent.ID != agentID {
-			writeStorageError(w, http.StatusForbidden, "FORBIDDEN", "agents can only access their own storage")
+			apierror.Write(w, apierror.Forbidden, "agents can only access their own storage")
 			return
 		}
 	} else {
 		// Check human JWT auth
 		claims := auth.ClaimsFromContext(ctx)
 		if claims == nil {
-			writeStorageError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			apierror.Write(w, apierror.Unauthorized, "authentication required")
 			return
 		}
 
 		// Look up the agent
 		agent, err := h.agentFinder.FindByID(ctx, agentID)
 		if err != nil {
-			writeStorageError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
 
 		// Verify the human is the owner
 		if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-			writeStorageError(w, http.StatusForbidden, "FORBIDDEN", "you must be the claiming owner of this agent")
+			apierror.Write(w, apierror.Forbidden, "you must be the claiming owner of this agent")
 			return
 		}
 	}
 
 	used, quota, err := h.repo.GetStorageUsage(ctx, agentID, "agent")
 	if err != nil {
-		writeStorageError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get storage usage")
+		apierror.Write(w, apierror.InternalError, "failed to get storage usage")
 		return
 	}
 
@@ -138,14 +125,3 @@ func (h *StorageHandler) GetAgentStorage(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]interface{}{"data": resp})
 }
-
-func writeStorageError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
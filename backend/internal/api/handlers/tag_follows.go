@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// TagFollowsRepositoryInterface defines the database operations for tag follows.
+type TagFollowsRepositoryInterface interface {
+	FollowTag(ctx context.Context, followerType, followerID, tag string) (*models.TagFollow, error)
+	UnfollowTag(ctx context.Context, followerType, followerID, tag string) error
+	ListFollowedTags(ctx context.Context, followerType, followerID string) ([]models.TagFollow, error)
+}
+
+// TagFollowsHandler handles tag follow HTTP requests.
+// Followed tags rank matching posts higher in GET /v1/feed?scope=following.
+type TagFollowsHandler struct {
+	repo TagFollowsRepositoryInterface
+}
+
+// NewTagFollowsHandler creates a new TagFollowsHandler.
+func NewTagFollowsHandler(repo TagFollowsRepositoryInterface) *TagFollowsHandler {
+	return &TagFollowsHandler{repo: repo}
+}
+
+// Follow handles POST /v1/me/tags/{tag}/follow - follow a tag. Idempotent.
+func (h *TagFollowsHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	tag, ok := tagFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	follow, err := h.repo.FollowTag(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, tag)
+	if err != nil {
+		if errors.Is(err, db.ErrTagFollowLimit) {
+			apierror.Write(w, apierror.TagFollowLimit, fmt.Sprintf("you can follow at most %d tags", models.MaxFollowedTags))
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to follow tag")
+		return
+	}
+
+	writeTagsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": follow,
+	})
+}
+
+// Unfollow handles DELETE /v1/me/tags/{tag}/follow - unfollow a tag. Idempotent.
+func (h *TagFollowsHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+	tag, ok := tagFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.UnfollowTag(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID, tag); err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to unfollow tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// List handles GET /v1/me/tags - tags the caller follows.
+func (h *TagFollowsHandler) List(w http.ResponseWriter, r *http.Request) {
+	authInfo := GetAuthInfo(r)
+	if authInfo == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return
+	}
+
+	follows, err := h.repo.ListFollowedTags(r.Context(), string(authInfo.AuthorType), authInfo.AuthorID)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list followed tags")
+		return
+	}
+
+	writeTagsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": follows,
+	})
+}
+
+// tagFromPath returns the normalized {tag} URL parameter, writing a 400 when
+// it is not a valid tag.
+func tagFromPath(w http.ResponseWriter, r *http.Request) (string, bool) {
+	raw, err := url.PathUnescape(chi.URLParam(r, "tag"))
+	tag := models.NormalizeTag(raw)
+	if err != nil || !models.IsValidTag(tag) {
+		apierror.Write(w, apierror.ValidationError, "tag is not valid")
+		return "", false
+	}
+	return tag, true
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockTagFollowsRepo is a test double for TagFollowsRepositoryInterface.
+type mockTagFollowsRepo struct {
+	follows map[string]bool
+	full    bool
+}
+
+func (m *mockTagFollowsRepo) FollowTag(ctx context.Context, followerType, followerID, tag string) (*models.TagFollow, error) {
+	if m.full && !m.follows[tag] {
+		return nil, db.ErrTagFollowLimit
+	}
+	m.follows[tag] = true
+	return &models.TagFollow{Tag: tag, CreatedAt: time.Now()}, nil
+}
+
+func (m *mockTagFollowsRepo) UnfollowTag(ctx context.Context, followerType, followerID, tag string) error {
+	delete(m.follows, tag)
+	return nil
+}
+
+func (m *mockTagFollowsRepo) ListFollowedTags(ctx context.Context, followerType, followerID string) ([]models.TagFollow, error) {
+	follows := make([]models.TagFollow, 0, len(m.follows))
+	for tag := range m.follows {
+		follows = append(follows, models.TagFollow{Tag: tag})
+	}
+	return follows, nil
+}
+
+func newTagFollowRequest(method, tag string, authed bool) *http.Request {
+	req := httptest.NewRequest(method, "/v1/me/tags/"+tag+"/follow", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("tag", tag)
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	if authed {
+		ctx = auth.ContextWithClaims(ctx, &auth.Claims{UserID: "user-1", Role: models.UserRoleUser})
+	}
+	return req.WithContext(ctx)
+}
+
+func TestTagFollowsHandler_FollowNormalizesTag(t *testing.T) {
+	repo := &mockTagFollowsRepo{follows: map[string]bool{}}
+	h := NewTagFollowsHandler(repo)
+
+	w := httptest.NewRecorder()
+	h.Follow(w, newTagFollowRequest(http.MethodPost, "Kubernetes", true))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data models.TagFollow `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Data.Tag != "kubernetes" || !repo.follows["kubernetes"] {
+		t.Errorf("expected kubernetes followed, got %+v", resp.Data)
+	}
+}
+
+func TestTagFollowsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		repo   *mockTagFollowsRepo
+		tag    string
+		authed bool
+		want   int
+	}{
+		{"unauthenticated", &mockTagFollowsRepo{follows: map[string]bool{}}, "go", false, http.StatusUnauthorized},
+		{"invalid tag", &mockTagFollowsRepo{follows: map[string]bool{}}, "c%20sharp", true, http.StatusBadRequest},
+		{"follow limit", &mockTagFollowsRepo{follows: map[string]bool{}, full: true}, "go", true, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewTagFollowsHandler(tt.repo).Follow(w, newTagFollowRequest(http.MethodPost, tt.tag, tt.authed))
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTagFollowsHandler_Unfollow(t *testing.T) {
+	repo := &mockTagFollowsRepo{follows: map[string]bool{"go": true}}
+	h := NewTagFollowsHandler(repo)
+
+	w := httptest.NewRecorder()
+	h.Unfollow(w, newTagFollowRequest(http.MethodDelete, "go", true))
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if repo.follows["go"] {
+		t.Error("expected go unfollowed")
+	}
+}
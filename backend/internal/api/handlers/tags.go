@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// tagTopPostsLimit is how many top posts a tag detail page shows.
+const tagTopPostsLimit = 10
+
+// TagsRepositoryInterface defines the database operations for tag pages.
+type TagsRepositoryInterface interface {
+	ListTags(ctx context.Context, opts models.TagListOptions) ([]models.TagSummary, int, error)
+	GetTag(ctx context.Context, name string) (*models.TagSummary, error)
+	ListTopPostsForTag(ctx context.Context, name string, limit int) ([]models.TagPost, error)
+}
+
+// TagsHandler handles tag HTTP requests.
+type TagsHandler struct {
+	repo TagsRepositoryInterface
+}
+
+// NewTagsHandler creates a new TagsHandler.
+func NewTagsHandler(repo TagsRepositoryInterface) *TagsHandler {
+	return &TagsHandler{repo: repo}
+}
+
+// TagDetailResponse is the data for GET /v1/tags/{name}.
+type TagDetailResponse struct {
+	models.TagSummary
+	TopPosts []models.TagPost `json:"top_posts"`
+}
+
+// ListTags handles GET /v1/tags - tags with usage counts, most used first.
+// Query params: q (name prefix), page, per_page (max 100).
+func (h *TagsHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	opts := models.TagListOptions{
+		Query:   models.NormalizeTag(q.Get("q")),
+		Page:    1,
+		PerPage: 50,
+	}
+	if p, err := strconv.Atoi(q.Get("page")); err == nil && p > 0 {
+		opts.Page = p
+	}
+	if pp, err := strconv.Atoi(q.Get("per_page")); err == nil && pp > 0 && pp <= 100 {
+		opts.PerPage = pp
+	}
+
+	tags, total, err := h.repo.ListTags(r.Context(), opts)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to list tags")
+		return
+	}
+
+	writeTagsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": tags,
+		"meta": map[string]interface{}{
+			"total":    total,
+			"page":     opts.Page,
+			"per_page": opts.PerPage,
+			"has_more": opts.Page*opts.PerPage < total,
+		},
+	})
+}
+
+// GetTag handles GET /v1/tags/{name} - usage counts and top posts for a tag.
+func (h *TagsHandler) GetTag(w http.ResponseWriter, r *http.Request) {
+	name, err := url.PathUnescape(chi.URLParam(r, "name"))
+	if err != nil {
+		apierror.Write(w, apierror.ValidationError, "invalid tag name")
+		return
+	}
+	name = models.NormalizeTag(name)
+	if name == "" {
+		apierror.Write(w, apierror.ValidationError, "tag name is required")
+		return
+	}
+
+	tag, err := h.repo.GetTag(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, db.ErrTagNotFound) {
+			apierror.Write(w, apierror.NotFound, "tag not found")
+			return
+		}
+		apierror.Write(w, apierror.InternalError, "failed to get tag")
+		return
+	}
+
+	posts, err := h.repo.ListTopPostsForTag(r.Context(), name, tagTopPostsLimit)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to get tag posts")
+		return
+	}
+
+	writeTagsJSON(w, http.StatusOK, map[string]interface{}{
+		"data": TagDetailResponse{TagSummary: *tag, TopPosts: posts},
+	})
+}
+
+// validateTags returns tags normalized (trimmed, lowercased, deduplicated) and
+// records INVALID_FORMAT on v for any tag models.IsValidTag rejects.
+func validateTags(v *Validator, tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = models.NormalizeTag(tag)
+		if !models.IsValidTag(tag) {
+			v.Add(FieldError{Field: "tags", Code: FieldInvalidFormat,
+				Message: "tags may only contain lowercase letters, digits and + # . _ - (max 50 characters): " + strconv.Quote(tag)})
+			continue
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// writeTagsJSON writes a JSON response.
+func writeTagsJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
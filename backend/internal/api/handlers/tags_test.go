@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockTagsRepo is a test double for TagsRepositoryInterface.
+type mockTagsRepo struct {
+	tags     map[string]models.TagSummary
+	posts    []models.TagPost
+	listOpts models.TagListOptions
+}
+
+func (m *mockTagsRepo) ListTags(ctx context.Context, opts models.TagListOptions) ([]models.TagSummary, int, error) {
+	m.listOpts = opts
+	tags := make([]models.TagSummary, 0, len(m.tags))
+	for _, t := range m.tags {
+		tags = append(tags, t)
+	}
+	return tags, len(tags), nil
+}
+
+func (m *mockTagsRepo) GetTag(ctx context.Context, name string) (*models.TagSummary, error) {
+	t, ok := m.tags[name]
+	if !ok {
+		return nil, db.ErrTagNotFound
+	}
+	return &t, nil
+}
+
+func (m *mockTagsRepo) ListTopPostsForTag(ctx context.Context, name string, limit int) ([]models.TagPost, error) {
+	return m.posts, nil
+}
+
+func newTagRequest(path, name string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rctx := chi.NewRouteContext()
+	if name != "" {
+		rctx.URLParams.Add("name", name)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestTagsHandler_ListTags(t *testing.T) {
+	repo := &mockTagsRepo{tags: map[string]models.TagSummary{
+		"go": {Name: "go", UsageCount: 12, ProblemCount: 7},
+	}}
+	handler := NewTagsHandler(repo)
+
+	w := httptest.NewRecorder()
+	handler.ListTags(w, newTagRequest("/v1/tags?q=GO&per_page=10&page=2", ""))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.listOpts.Query != "go" || repo.listOpts.PerPage != 10 || repo.listOpts.Page != 2 {
+		t.Errorf("unexpected list options: %+v", repo.listOpts)
+	}
+
+	var resp struct {
+		Data []models.TagSummary `json:"data"`
+		Meta struct {
+			Total int `json:"total"`
+		} `json:"meta"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Data) != 1 || resp.Data[0].UsageCount != 12 || resp.Meta.Total != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestTagsHandler_GetTag(t *testing.T) {
+	repo := &mockTagsRepo{
+		tags:  map[string]models.TagSummary{"c#": {Name: "c#", UsageCount: 3}},
+		posts: []models.TagPost{{ID: "post-1", Type: models.PostTypeQuestion, Title: "LINQ grouping", VoteScore: 9, CreatedAt: time.Now()}},
+	}
+	handler := NewTagsHandler(repo)
+
+	w := httptest.NewRecorder()
+	handler.GetTag(w, newTagRequest("/v1/tags/c%23", "c%23"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data TagDetailResponse `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Data.Name != "c#" || len(resp.Data.TopPosts) != 1 || resp.Data.TopPosts[0].ID != "post-1" {
+		t.Errorf("unexpected response: %+v", resp.Data)
+	}
+}
+
+func TestTagsHandler_GetTag_NotFound(t *testing.T) {
+	handler := NewTagsHandler(&mockTagsRepo{tags: map[string]models.TagSummary{}})
+
+	w := httptest.NewRecorder()
+	handler.GetTag(w, newTagRequest("/v1/tags/unknown", "unknown"))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+// TestCreatePost_InvalidTagCharacters tests tag character validation at post creation.
+func TestCreatePost_InvalidTagCharacters(t *testing.T) {
+	repo := NewMockPostsRepository()
+	handler := NewPostsHandler(repo)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"type":        "problem",
+		"title":       "Test Problem Title That Is Long Enough",
+		"description": "This is a test description that needs to be at least fifty characters long to pass validation.",
+		"tags":        []string{"go", "<script>"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", bytes.NewReader(body))
+	req = addAuthContext(req, "user-123", "user")
+	w := httptest.NewRecorder()
+
+	handler.Create(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), FieldInvalidFormat) {
+		t.Errorf("expected %s field error, got %s", FieldInvalidFormat, w.Body.String())
+	}
+}
+
+func TestValidateTags_NormalizesAndDedupes(t *testing.T) {
+	var v Validator
+	got := validateTags(&v, []string{" Go", "go", "PostgreSQL"})
+
+	if !v.Valid() {
+		t.Fatalf("unexpected errors: %+v", v.Errors())
+	}
+	if strings.Join(got, ",") != "go,postgresql" {
+		t.Errorf("validateTags() = %v, want [go postgresql]", got)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -72,11 +73,11 @@ func (h *UploadHandler) AddContent(w http.ResponseWriter, r *http.Request) {
 	// Parse multipart form
 	if err := r.ParseMultipartForm(h.maxUploadSize); err != nil {
 		if err.Error() == "http: request body too large" {
-			response.WriteError(w, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE",
+			apierror.Write(w, apierror.FileTooLarge,
 				"file exceeds maximum upload size")
 			return
 		}
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation,
+		apierror.Write(w, apierror.ValidationError,
 			"request must be multipart/form-data with a 'file' field")
 		return
 	}
@@ -84,7 +85,7 @@ func (h *UploadHandler) AddContent(w http.ResponseWriter, r *http.Request) {
 	// Get the file from the 'file' field
 	file, _, err := r.FormFile("file")
 	if err != nil {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation,
+		apierror.Write(w, apierror.ValidationError,
 			"missing 'file' field in multipart form")
 		return
 	}
@@ -105,7 +106,7 @@ func (h *UploadHandler) AddContent(w http.ResponseWriter, r *http.Request) {
 
 	// Reject empty files
 	if n == 0 {
-		response.WriteError(w, http.StatusBadRequest, response.ErrCodeValidation,
+		apierror.Write(w, apierror.ValidationError,
 			"file must not be empty")
 		return
 	}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
@@ -40,6 +41,7 @@ type APIKeyResponse struct {
 	UserID     string  `json:"user_id"`
 	Name       string  `json:"name"`
 	KeyPreview string  `json:"key_preview"`
+	Scope      string  `json:"scope"`
 	LastUsedAt *string `json:"last_used_at,omitempty"`
 	CreatedAt  string  `json:"created_at"`
 	UpdatedAt  string  `json:"updated_at"`
@@ -55,7 +57,7 @@ func (h *UserAPIKeysHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request)
 	// Check for valid JWT authentication
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeAPIKeyUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
@@ -83,6 +85,7 @@ func (h *UserAPIKeysHandler) toAPIKeyResponse(key *models.UserAPIKey) APIKeyResp
 		UserID:     key.UserID,
 		Name:       key.Name,
 		KeyPreview: "solvr_...****", // Keys are hashed, we can't show actual value
+		Scope:      key.EffectiveScope(),
 		CreatedAt:  key.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:  key.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -103,31 +106,15 @@ func writeAPIKeyJSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
 }
 
-func writeAPIKeyUnauthorized(w http.ResponseWriter, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 func writeAPIKeyInternalError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusInternalServerError)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "INTERNAL_ERROR",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.InternalError, message)
 }
 
 // CreateAPIKeyRequest represents the request body for creating an API key.
 type CreateAPIKeyRequest struct {
 	Name string `json:"name"`
+	// Scope is "read" or "write" (default). Read-only keys can't create or change content.
+	Scope string `json:"scope,omitempty"`
 }
 
 // CreateAPIKeyResponse represents the response when a new API key is created.
@@ -137,6 +124,7 @@ type CreateAPIKeyResponse struct {
 	UserID    string `json:"user_id"`
 	Name      string `json:"name"`
 	Key       string `json:"key"` // Full key, shown only once!
+	Scope     string `json:"scope"`
 	CreatedAt string `json:"created_at"`
 }
 
@@ -150,7 +138,7 @@ func (h *UserAPIKeysHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request
 	// Check for valid JWT authentication
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeAPIKeyUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
@@ -167,6 +155,14 @@ func (h *UserAPIKeysHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if req.Scope == "" {
+		req.Scope = models.APIKeyScopeWrite
+	}
+	if !models.IsValidAPIKeyScope(req.Scope) {
+		writeAPIKeyValidationError(w, "Scope must be 'read' or 'write'")
+		return
+	}
+
 	// Generate a new API key with solvr_sk_ prefix (sk = secret key)
 	plainKey := generateUserAPIKey()
 
@@ -183,6 +179,7 @@ func (h *UserAPIKeysHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request
 		Name:      req.Name,
 		KeyHash:   keyHash,
 		KeySHA256: auth.SHA256APIKey(plainKey),
+		Scope:     req.Scope,
 	}
 
 	created, err := h.repo.Create(ctx, key)
@@ -197,6 +194,7 @@ func (h *UserAPIKeysHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request
 		UserID:    created.UserID,
 		Name:      created.Name,
 		Key:       plainKey,
+		Scope:     created.EffectiveScope(),
 		CreatedAt: created.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
@@ -233,14 +231,7 @@ func generateUserAPIKey() string {
 }
 
 func writeAPIKeyValidationError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusBadRequest)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "VALIDATION_ERROR",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.ValidationError, message)
 }
 
 // RevokeAPIKey handles DELETE /v1/users/me/api-keys/:id
@@ -252,7 +243,7 @@ func (h *UserAPIKeysHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request
 	// Check for valid JWT authentication
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeAPIKeyUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
@@ -270,14 +261,7 @@ func (h *UserAPIKeysHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request
 }
 
 func writeAPIKeyNotFound(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusNotFound)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    "NOT_FOUND",
-			"message": message,
-		},
-	})
+	apierror.Write(w, apierror.NotFound, message)
 }
 
 // RegenerateAPIKeyResponse represents the response when an API key is regenerated.
@@ -301,7 +285,7 @@ func (h *UserAPIKeysHandler) RegenerateAPIKey(w http.ResponseWriter, r *http.Req
 	// Check for valid JWT authentication
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeAPIKeyUnauthorized(w, "UNAUTHORIZED", "Authentication required")
+		apierror.Write(w, apierror.Unauthorized, "Authentication required")
 		return
 	}
 
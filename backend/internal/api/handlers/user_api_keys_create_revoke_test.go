@@ -300,6 +300,50 @@ func TestCreateAPIKey_KeyStartsWithCorrectPrefix(t *testing.T) {
 	}
 }
 
+func TestCreateAPIKey_Scope(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantScope  string
+	}{
+		{"defaults to write", `{"name": "Default"}`, http.StatusCreated, models.APIKeyScopeWrite},
+		{"read-only", `{"name": "Reader", "scope": "read"}`, http.StatusCreated, models.APIKeyScopeRead},
+		{"unknown scope", `{"name": "Admin", "scope": "admin"}`, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockUserAPIKeyRepository()
+			handler := NewUserAPIKeysHandler(repo)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/users/me/api-keys", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			claims := &auth.Claims{UserID: "user-123", Email: "test@example.com", Role: models.UserRoleUser}
+			req = req.WithContext(auth.ContextWithClaims(req.Context(), claims))
+
+			rr := httptest.NewRecorder()
+			handler.CreateAPIKey(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d. Body: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+			if tt.wantScope == "" {
+				return
+			}
+			var response map[string]interface{}
+			json.NewDecoder(rr.Body).Decode(&response)
+			data := response["data"].(map[string]interface{})
+			if data["scope"] != tt.wantScope {
+				t.Errorf("expected scope %q, got %v", tt.wantScope, data["scope"])
+			}
+			if stored := repo.keysByUser["user-123"][0]; stored.Scope != tt.wantScope {
+				t.Errorf("expected stored scope %q, got %q", tt.wantScope, stored.Scope)
+			}
+		})
+	}
+}
+
 // Tests for DELETE /v1/users/me/api-keys/:id (RevokeAPIKey)
 // Per prd-v2.json: "Soft delete the key, Immediately invalidate for auth, Return success"
 
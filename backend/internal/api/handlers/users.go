@@ -11,6 +11,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -56,6 +57,18 @@ type UsersHandler struct {
 	answersRepo    ContribAnswersRepositoryInterface
 	approachesRepo ContribApproachesRepositoryInterface
 	responsesRepo  ContribResponsesRepositoryInterface
+
+	// For GET /v1/users/{username}
+	usernameFinder UsersUsernameFinder
+	profileRepo    UserProfileRepositoryInterface
+	badgeRepo      BadgeRepoInterface
+
+	// For username changes via PATCH /v1/me
+	usernameChanger      UsernameChanger
+	usernameChangeConfig models.UsernameChangeConfig
+
+	// For POST/DELETE /v1/me/avatar
+	avatarStore AvatarStore
 }
 
 // NewUsersHandler creates a new UsersHandler instance.
@@ -91,6 +104,7 @@ type PublicUserProfileResponse struct {
 
 // UpdateProfileRequest is the request body for PATCH /v1/me.
 type UpdateProfileRequest struct {
+	Username    string `json:"username,omitempty"`
 	DisplayName string `json:"display_name,omitempty"`
 	Bio         string `json:"bio,omitempty"`
 	AvatarURL   string `json:"avatar_url,omitempty"`
@@ -98,33 +112,39 @@ type UpdateProfileRequest struct {
 
 // GetUserProfile handles GET /v1/users/:id.
 // Per BE-003: Public profile view - anyone can view any user's public profile.
+// Any other value than a UUID is a username: GET /v1/users/{username} returns the
+// full public profile when SetPublicProfileRepositories has been called.
 func (h *UsersHandler) GetUserProfile(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userID := chi.URLParam(r, "id")
 
 	if userID == "" {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", "user ID is required")
+		apierror.Write(w, apierror.BadRequest, "user ID is required")
 		return
 	}
 
 	// Validate UUID format to prevent DB errors (e.g. /v1/users/me matching {id})
 	if _, err := uuid.Parse(userID); err != nil {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user ID format")
+		if h.profileRepo != nil && validateUsername(userID) == nil {
+			h.getPublicProfile(w, r, userID)
+			return
+		}
+		apierror.Write(w, apierror.BadRequest, "invalid user ID format")
 		return
 	}
 
 	user, err := h.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		if err == db.ErrNotFound {
-			writeUsersError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			apierror.Write(w, apierror.NotFound, "user not found")
 			return
 		}
-		writeUsersError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to fetch user")
+		apierror.Write(w, apierror.InternalError, "failed to fetch user")
 		return
 	}
 
 	if user == nil {
-		writeUsersError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+		apierror.Write(w, apierror.NotFound, "user not found")
 		return
 	}
 
@@ -179,24 +199,24 @@ func (h *UsersHandler) GetUserAgents(w http.ResponseWriter, r *http.Request) {
 	userID := chi.URLParam(r, "id")
 
 	if userID == "" {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", "user ID is required")
+		apierror.Write(w, apierror.BadRequest, "user ID is required")
 		return
 	}
 
 	// Validate UUID format to prevent DB errors
 	if _, err := uuid.Parse(userID); err != nil {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user ID format")
+		apierror.Write(w, apierror.BadRequest, "invalid user ID format")
 		return
 	}
 
 	if h.agentRepo == nil {
-		writeUsersError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "agent repository not configured")
+		apierror.Write(w, apierror.InternalError, "agent repository not configured")
 		return
 	}
 
 	agents, err := h.agentRepo.FindByHumanID(ctx, userID)
 	if err != nil {
-		writeUsersError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to fetch agents")
+		apierror.Write(w, apierror.InternalError, "failed to fetch agents")
 		return
 	}
 
@@ -241,7 +261,7 @@ func (h *UsersHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	if h.userListRepo == nil {
-		writeUsersError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "user list repository not configured")
+		apierror.Write(w, apierror.InternalError, "user list repository not configured")
 		return
 	}
 
@@ -282,7 +302,7 @@ func (h *UsersHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
 
 	users, total, err := h.userListRepo.List(ctx, opts)
 	if err != nil {
-		writeUsersError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list users")
+		apierror.Write(w, apierror.InternalError, "failed to list users")
 		return
 	}
 
@@ -321,6 +341,7 @@ func parsePositiveInt(s string) (int, error) {
 
 // UpdateProfile handles PATCH /v1/me.
 // Per BE-003: Update own profile (display_name, bio).
+// The username can change too, within the limits of SetUsernameChanger.
 // Only authenticated humans can update their profile.
 func (h *UsersHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -328,21 +349,21 @@ func (h *UsersHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	// Check for agent authentication - agents cannot update profile
 	agent := auth.AgentFromContext(ctx)
 	if agent != nil {
-		writeUsersError(w, http.StatusForbidden, "FORBIDDEN", "agents cannot update user profile")
+		apierror.Write(w, apierror.Forbidden, "agents cannot update user profile")
 		return
 	}
 
 	// Check for user authentication (JWT)
 	claims := auth.ClaimsFromContext(ctx)
 	if claims == nil {
-		writeUsersError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse request body
 	var req UpdateProfileRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid request body")
+		apierror.Write(w, apierror.BadRequest, "invalid request body")
 		return
 	}
 
@@ -350,18 +371,26 @@ func (h *UsersHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	user, err := h.userRepo.FindByID(ctx, claims.UserID)
 	if err != nil {
 		if err == db.ErrNotFound {
-			writeUsersError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			apierror.Write(w, apierror.NotFound, "user not found")
 			return
 		}
-		writeUsersError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to fetch user")
+		apierror.Write(w, apierror.InternalError, "failed to fetch user")
 		return
 	}
 
 	if user == nil {
-		writeUsersError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+		apierror.Write(w, apierror.NotFound, "user not found")
 		return
 	}
 
+	// Rename first so a taken or reserved username leaves the profile untouched
+	if req.Username != "" && req.Username != user.Username {
+		user, err = h.changeUsername(ctx, w, user.ID, req.Username)
+		if err != nil {
+			return
+		}
+	}
+
 	// Update only provided fields
 	if req.DisplayName != "" {
 		user.DisplayName = req.DisplayName
@@ -376,7 +405,7 @@ func (h *UsersHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	// Save updates
 	updated, err := h.userRepo.Update(ctx, user)
 	if err != nil {
-		writeUsersError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update profile")
+		apierror.Write(w, apierror.InternalError, "failed to update profile")
 		return
 	}
 
@@ -403,14 +432,14 @@ func (h *UsersHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 func (h *UsersHandler) GetMyPosts(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeUsersError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse pagination params (use existing function from posts.go)
 	page, perPage, err := parsePaginationParams(r)
 	if err != nil {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		apierror.Write(w, apierror.BadRequest, err.Error())
 		return
 	}
 
@@ -424,7 +453,7 @@ func (h *UsersHandler) GetMyPosts(w http.ResponseWriter, r *http.Request) {
 
 	posts, total, err := h.postRepo.List(r.Context(), opts)
 	if err != nil {
-		writeUsersError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list posts")
+		apierror.Write(w, apierror.InternalError, "failed to list posts")
 		return
 	}
 
@@ -437,14 +466,14 @@ func (h *UsersHandler) GetMyPosts(w http.ResponseWriter, r *http.Request) {
 func (h *UsersHandler) GetMyContributions(w http.ResponseWriter, r *http.Request) {
 	authInfo := GetAuthInfo(r)
 	if authInfo == nil {
-		writeUsersError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
 		return
 	}
 
 	// Parse pagination params
 	page, perPage, err := parsePaginationParams(r)
 	if err != nil {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		apierror.Write(w, apierror.BadRequest, err.Error())
 		return
 	}
 
@@ -488,14 +517,3 @@ func writeUsersListJSON(w http.ResponseWriter, status int, data interface{}, tot
 		},
 	})
 }
-
-func writeUsersError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
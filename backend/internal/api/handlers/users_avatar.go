@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/avatar"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// MaxAvatarUploadSize is the largest image POST /v1/me/avatar accepts (5MB).
+const MaxAvatarUploadSize = 5 * 1024 * 1024
+
+// AvatarStore stores processed avatars in S3-compatible storage.
+// Implemented by services.S3Store.
+type AvatarStore interface {
+	PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	PublicURL(key string) string
+}
+
+// SetAvatarStore enables POST and DELETE /v1/me/avatar.
+func (h *UsersHandler) SetAvatarStore(store AvatarStore) {
+	h.avatarStore = store
+}
+
+// AvatarResponse is the response for POST /v1/me/avatar. AvatarURL is the
+// largest size; Sizes maps every size in pixels to its URL.
+type AvatarResponse struct {
+	AvatarURL string            `json:"avatar_url"`
+	Sizes     map[string]string `json:"sizes"`
+}
+
+// UploadAvatar handles POST /v1/me/avatar.
+// Accepts multipart/form-data with a 'file' field holding a JPEG, PNG or GIF,
+// resizes it to avatar.Sizes and points the profile's avatar_url at the result.
+// A previously uploaded avatar is deleted.
+func (h *UsersHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := h.avatarOwner(w, r)
+	if user == nil {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxAvatarUploadSize)
+	if err := r.ParseMultipartForm(MaxAvatarUploadSize); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			apierror.Write(w, apierror.FileTooLarge, "avatar exceeds maximum upload size of 5MB")
+			return
+		}
+		apierror.Write(w, apierror.ValidationError, "request must be multipart/form-data with a 'file' field")
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		apierror.Write(w, apierror.ValidationError, "missing 'file' field in multipart form")
+		return
+	}
+	defer file.Close()
+
+	images, err := avatar.Process(file)
+	if err != nil {
+		switch {
+		case errors.Is(err, avatar.ErrUnsupportedImage):
+			apierror.Write(w, apierror.ValidationError, "file must be a JPEG, PNG or GIF image")
+		case errors.Is(err, avatar.ErrTooLarge):
+			apierror.Write(w, apierror.ValidationError, "image dimensions are too large")
+		default:
+			apierror.Write(w, apierror.InternalError, "failed to process avatar")
+		}
+		return
+	}
+
+	// A new version per upload, so cached copies of the old avatar never linger
+	version := strconv.FormatInt(time.Now().UnixNano(), 36)
+	resp := AvatarResponse{Sizes: make(map[string]string, len(avatar.Sizes))}
+	var stored []string
+	for _, size := range avatar.Sizes {
+		key := avatarKey(user.ID, version, size)
+		if err := h.avatarStore.PutObject(ctx, key, bytes.NewReader(images[size]), int64(len(images[size])), "image/jpeg"); err != nil {
+			slog.Error("failed to store avatar", "error", err, "user_id", user.ID, "key", key)
+			h.deleteAvatarObjects(ctx, stored)
+			apierror.Write(w, apierror.InternalError, "failed to store avatar")
+			return
+		}
+		stored = append(stored, key)
+		resp.Sizes[strconv.Itoa(size)] = h.avatarStore.PublicURL(key)
+	}
+	resp.AvatarURL = resp.Sizes[strconv.Itoa(avatar.Sizes[len(avatar.Sizes)-1])]
+
+	previous := h.uploadedAvatarKeys(user)
+	user.AvatarURL = resp.AvatarURL
+	if _, err := h.userRepo.Update(ctx, user); err != nil {
+		h.deleteAvatarObjects(ctx, stored)
+		apierror.Write(w, apierror.InternalError, "failed to update profile")
+		return
+	}
+	h.deleteAvatarObjects(ctx, previous)
+
+	writeUsersJSON(w, http.StatusOK, resp)
+}
+
+// DeleteAvatar handles DELETE /v1/me/avatar: clears avatar_url and deletes an
+// uploaded avatar's files. Returns 204.
+func (h *UsersHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	user := h.avatarOwner(w, r)
+	if user == nil {
+		return
+	}
+
+	previous := h.uploadedAvatarKeys(user)
+	user.AvatarURL = ""
+	if _, err := h.userRepo.Update(r.Context(), user); err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to update profile")
+		return
+	}
+	h.deleteAvatarObjects(r.Context(), previous)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// avatarOwner checks the caller is a human and avatars are enabled, and
+// returns their user. It writes the error response and returns nil otherwise.
+func (h *UsersHandler) avatarOwner(w http.ResponseWriter, r *http.Request) *models.User {
+	ctx := r.Context()
+	if auth.AgentFromContext(ctx) != nil {
+		apierror.Write(w, apierror.Forbidden, "agents cannot update user profile")
+		return nil
+	}
+	claims := auth.ClaimsFromContext(ctx)
+	if claims == nil {
+		apierror.Write(w, apierror.Unauthorized, "authentication required")
+		return nil
+	}
+	if h.avatarStore == nil {
+		apierror.Write(w, apierror.ServiceUnavailable, "avatar uploads are not configured")
+		return nil
+	}
+
+	user, err := h.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		apierror.Write(w, apierror.InternalError, "failed to fetch user")
+		return nil
+	}
+	if user == nil {
+		apierror.Write(w, apierror.NotFound, "user not found")
+		return nil
+	}
+	return user
+}
+
+// avatarKey is the object key of one size of an uploaded avatar.
+func avatarKey(userID, version string, size int) string {
+	return fmt.Sprintf("avatars/%s/%s-%d.jpg", userID, version, size)
+}
+
+// uploadedAvatarKeys returns the object keys of the user's current avatar when
+// it was uploaded here, or nil for OAuth provider URLs and no avatar.
+func (h *UsersHandler) uploadedAvatarKeys(user *models.User) []string {
+	prefix := h.avatarStore.PublicURL("avatars/" + user.ID + "/")
+	name, ok := strings.CutPrefix(user.AvatarURL, prefix)
+	if !ok {
+		return nil
+	}
+	version, _, ok := strings.Cut(name, "-")
+	if !ok || version == "" || strings.Contains(version, "/") {
+		return nil
+	}
+	keys := make([]string, 0, len(avatar.Sizes))
+	for _, size := range avatar.Sizes {
+		keys = append(keys, avatarKey(user.ID, version, size))
+	}
+	return keys
+}
+
+// deleteAvatarObjects removes avatar files, best effort: a leftover file only
+// costs storage, so failures are logged.
+func (h *UsersHandler) deleteAvatarObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := h.avatarStore.Delete(ctx, key); err != nil {
+			slog.Warn("failed to delete avatar", "error", err, "key", key)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockAvatarStore struct {
+	objects map[string][]byte
+}
+
+func (m *mockAvatarStore) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.objects[key] = data
+	return nil
+}
+
+func (m *mockAvatarStore) Delete(ctx context.Context, key string) error {
+	delete(m.objects, key)
+	return nil
+}
+
+func (m *mockAvatarStore) PublicURL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+func (m *mockAvatarStore) keys() []string {
+	keys := make([]string, 0, len(m.objects))
+	for k := range m.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func newAvatarTest() (*UsersHandler, *MockUsersUserRepository, *mockAvatarStore) {
+	users := NewMockUsersUserRepository()
+	users.users["user-1"] = &models.User{ID: "user-1", Username: "ana", AvatarURL: "https://avatars.githubusercontent.com/u/1"}
+	store := &mockAvatarStore{objects: map[string][]byte{}}
+	h := NewUsersHandler(users, nil)
+	h.SetAvatarStore(store)
+	return h, users, store
+}
+
+func avatarRequest(t *testing.T, method string, file []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if file != nil {
+		fw, err := mw.CreateFormFile("file", "me.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write(file)
+	}
+	mw.Close()
+	req := httptest.NewRequest(method, "/v1/me/avatar", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{UserID: "user-1", Role: "user"}))
+}
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 30))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadAvatar_StoresSizesAndReplacesPrevious(t *testing.T) {
+	h, users, store := newAvatarTest()
+
+	rr := httptest.NewRecorder()
+	h.UploadAvatar(rr, avatarRequest(t, http.MethodPost, testPNG(t)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data AvatarResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Data.Sizes) != 3 || !strings.HasSuffix(resp.Data.AvatarURL, "-256.jpg") {
+		t.Fatalf("response = %+v, want three sizes with the 256px URL as avatar_url", resp.Data)
+	}
+	if got := users.users["user-1"].AvatarURL; got != resp.Data.AvatarURL {
+		t.Errorf("AvatarURL = %q, want %q", got, resp.Data.AvatarURL)
+	}
+	first := store.keys()
+	if len(first) != 3 || !strings.HasPrefix(first[0], "avatars/user-1/") {
+		t.Fatalf("stored keys = %v, want three under avatars/user-1/", first)
+	}
+
+	// A second upload deletes the first one's files
+	rr = httptest.NewRecorder()
+	h.UploadAvatar(rr, avatarRequest(t, http.MethodPost, testPNG(t)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("second upload status = %d: %s", rr.Code, rr.Body.String())
+	}
+	second := store.keys()
+	if len(second) != 3 || second[0] == first[0] {
+		t.Errorf("stored keys after replacing = %v, want only the new three", second)
+	}
+}
+
+func TestUploadAvatar_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   []byte
+		status int
+	}{
+		{"missing file", nil, http.StatusBadRequest},
+		{"not an image", []byte("hello"), http.StatusBadRequest},
+		{"too large", bytes.Repeat([]byte{0}, MaxAvatarUploadSize+1), http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, users, store := newAvatarTest()
+			rr := httptest.NewRecorder()
+			h.UploadAvatar(rr, avatarRequest(t, http.MethodPost, tt.file))
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if len(store.objects) != 0 || users.users["user-1"].AvatarURL != "https://avatars.githubusercontent.com/u/1" {
+				t.Error("a rejected upload changed the avatar")
+			}
+		})
+	}
+}
+
+func TestUploadAvatar_NotConfigured(t *testing.T) {
+	h := NewUsersHandler(NewMockUsersUserRepository(), nil)
+	rr := httptest.NewRecorder()
+	h.UploadAvatar(rr, avatarRequest(t, http.MethodPost, testPNG(t)))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rr.Code)
+	}
+}
+
+func TestDeleteAvatar(t *testing.T) {
+	h, users, store := newAvatarTest()
+	h.UploadAvatar(httptest.NewRecorder(), avatarRequest(t, http.MethodPost, testPNG(t)))
+
+	rr := httptest.NewRecorder()
+	h.DeleteAvatar(rr, avatarRequest(t, http.MethodDelete, nil))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204: %s", rr.Code, rr.Body.String())
+	}
+	if users.users["user-1"].AvatarURL != "" || len(store.objects) != 0 {
+		t.Errorf("avatar = %q with %d files left, want both cleared", users.users["user-1"].AvatarURL, len(store.objects))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -60,27 +61,27 @@ func (h *UsersHandler) GetUserContributions(w http.ResponseWriter, r *http.Reque
 	userID := chi.URLParam(r, "id")
 
 	if userID == "" {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", "user ID is required")
+		apierror.Write(w, apierror.BadRequest, "user ID is required")
 		return
 	}
 
 	// Validate UUID format
 	if _, err := uuid.Parse(userID); err != nil {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid user ID format")
+		apierror.Write(w, apierror.BadRequest, "invalid user ID format")
 		return
 	}
 
 	// Look up user to get author type
 	user, err := h.userRepo.FindByID(ctx, userID)
 	if err != nil || user == nil {
-		writeUsersError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+		apierror.Write(w, apierror.NotFound, "user not found")
 		return
 	}
 
 	// Parse pagination
 	page, perPage, err := parsePaginationParams(r)
 	if err != nil {
-		writeUsersError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		apierror.Write(w, apierror.BadRequest, err.Error())
 		return
 	}
 
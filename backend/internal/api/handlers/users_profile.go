@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// UsersUsernameFinder looks users up by username for GET /v1/users/{username}.
+type UsersUsernameFinder interface {
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+}
+
+// UserProfileRepositoryInterface reads a user's public activity and reputation
+// breakdown.
+type UserProfileRepositoryInterface interface {
+	ListActivity(ctx context.Context, userID string, page, perPage int) ([]models.UserActivityItem, int, error)
+	GetReputationBreakdown(ctx context.Context, userID string) (*models.ReputationBreakdown, error)
+}
+
+// SetPublicProfileRepositories enables GET /v1/users/{username}: the profile,
+// badges, activity and reputation breakdown in one response.
+func (h *UsersHandler) SetPublicProfileRepositories(users UsersUsernameFinder, profiles UserProfileRepositoryInterface, badges BadgeRepoInterface) {
+	h.usernameFinder = users
+	h.profileRepo = profiles
+	h.badgeRepo = badges
+}
+
+// UserPublicProfileResponse is the data of GET /v1/users/{username}. Activity
+// is paginated with page/per_page; meta describes it.
+type UserPublicProfileResponse struct {
+	PublicUserProfileResponse
+	Badges              []models.Badge             `json:"badges"`
+	ReputationBreakdown models.ReputationBreakdown `json:"reputation_breakdown"`
+	Activity            []models.UserActivityItem  `json:"activity"`
+}
+
+// getPublicProfile handles GET /v1/users/{username}. A username the user has
+// since changed redirects to the current one. Badges and the reputation
+// breakdown are best effort; a failure leaves them empty rather than failing the
+// profile.
+func (h *UsersHandler) getPublicProfile(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	page, perPage, err := parsePaginationParams(r)
+	if err != nil {
+		apierror.Write(w, apierror.BadRequest, err.Error())
+		return
+	}
+
+	user, err := h.usernameFinder.FindByUsername(ctx, username)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		apierror.Write(w, apierror.InternalError, "failed to fetch user")
+		return
+	}
+	if user == nil {
+		// Old profile URLs keep working after a username change
+		if h.redirectFromPreviousUsername(w, r, username) {
+			return
+		}
+		apierror.Write(w, apierror.NotFound, "user not found")
+		return
+	}
+
+	activity, total, err := h.profileRepo.ListActivity(ctx, user.ID, page, perPage)
+	if err != nil {
+		apierror.Write(w, apierror.InternalError, "failed to fetch user activity")
+		return
+	}
+
+	stats, err := h.userRepo.GetUserStats(ctx, user.ID)
+	if err != nil {
+		stats = &models.UserStats{}
+	}
+
+	profile := UserPublicProfileResponse{
+		PublicUserProfileResponse: PublicUserProfileResponse{
+			ID:          user.ID,
+			Username:    user.Username,
+			DisplayName: user.DisplayName,
+			AvatarURL:   user.AvatarURL,
+			Bio:         user.Bio,
+			Stats:       *stats,
+		},
+		Badges:              []models.Badge{},
+		ReputationBreakdown: models.ReputationBreakdown{Sources: []models.ReputationSource{}},
+		Activity:            activity,
+	}
+	if h.badgeRepo != nil {
+		if badges, err := h.badgeRepo.ListForOwner(ctx, "human", user.ID); err != nil {
+			slog.Warn("user profile: failed to list badges", "error", err, "user_id", user.ID)
+		} else if badges != nil {
+			profile.Badges = badges
+		}
+	}
+	if breakdown, err := h.profileRepo.GetReputationBreakdown(ctx, user.ID); err != nil {
+		slog.Warn("user profile: failed to get reputation breakdown", "error", err, "user_id", user.ID)
+	} else {
+		profile.ReputationBreakdown = *breakdown
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": profile,
+		"meta": ContributionsMeta{
+			Total:   total,
+			Page:    page,
+			PerPage: perPage,
+			HasMore: total > page*perPage,
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db/memdb"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+type mockUserProfileRepo struct {
+	activity     []models.UserActivityItem
+	total        int
+	breakdownErr error
+	page         int
+	perPage      int
+}
+
+func (m *mockUserProfileRepo) ListActivity(ctx context.Context, userID string, page, perPage int) ([]models.UserActivityItem, int, error) {
+	m.page, m.perPage = page, perPage
+	return m.activity, m.total, nil
+}
+
+func (m *mockUserProfileRepo) GetReputationBreakdown(ctx context.Context, userID string) (*models.ReputationBreakdown, error) {
+	if m.breakdownErr != nil {
+		return nil, m.breakdownErr
+	}
+	return &models.ReputationBreakdown{Total: 120, Sources: []models.ReputationSource{{Source: "problems_solved", Count: 1, Points: 100}}}, nil
+}
+
+type mockProfileBadgeRepo struct{}
+
+func (mockProfileBadgeRepo) ListForOwner(ctx context.Context, ownerType, ownerID string) ([]models.Badge, error) {
+	return []models.Badge{{OwnerType: ownerType, OwnerID: ownerID, BadgeType: "first_solve"}}, nil
+}
+
+func newPublicProfileTest(t *testing.T, profiles *mockUserProfileRepo) (*UsersHandler, *models.User) {
+	t.Helper()
+	users := memdb.NewStore().Users()
+	user, err := users.Create(context.Background(), &models.User{Username: "ana_dev", DisplayName: "Ana", Email: "ana@example.com"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	h := NewUsersHandler(NewMockUsersUserRepository(), nil)
+	h.SetPublicProfileRepositories(users, profiles, mockProfileBadgeRepo{})
+	return h, user
+}
+
+func getUserProfile(h *UsersHandler, id, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/"+id+query, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+	h.GetUserProfile(rr, req)
+	return rr
+}
+
+func TestGetUserProfile_ByUsername(t *testing.T) {
+	profiles := &mockUserProfileRepo{
+		activity: []models.UserActivityItem{{Type: "answer", ID: "a1", PostID: "q1", PostTitle: "Pool leak"}},
+		total:    21,
+	}
+	h, user := newPublicProfileTest(t, profiles)
+
+	rr := getUserProfile(h, "ana_dev", "?page=2&per_page=10")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data UserPublicProfileResponse `json:"data"`
+		Meta ContributionsMeta         `json:"meta"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Data.ID != user.ID || resp.Data.Username != "ana_dev" {
+		t.Errorf("profile = %+v, want ana_dev", resp.Data.PublicUserProfileResponse)
+	}
+	if len(resp.Data.Badges) != 1 || resp.Data.Badges[0].OwnerType != "human" {
+		t.Errorf("badges = %+v, want the user's badge", resp.Data.Badges)
+	}
+	if resp.Data.ReputationBreakdown.Total != 120 || len(resp.Data.Activity) != 1 {
+		t.Errorf("breakdown/activity = %+v/%+v", resp.Data.ReputationBreakdown, resp.Data.Activity)
+	}
+	if profiles.page != 2 || profiles.perPage != 10 || resp.Meta.Total != 21 || !resp.Meta.HasMore {
+		t.Errorf("paged %d/%d, meta %+v; want page 2 of 10 with more", profiles.page, profiles.perPage, resp.Meta)
+	}
+}
+
+func TestGetUserProfile_ByUsernameBreakdownFailureKeepsProfile(t *testing.T) {
+	h, _ := newPublicProfileTest(t, &mockUserProfileRepo{breakdownErr: errors.New("db down")})
+
+	rr := getUserProfile(h, "ana_dev", "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &resp)
+	breakdown, _ := resp.Data["reputation_breakdown"].(map[string]interface{})
+	if sources, ok := breakdown["sources"].([]interface{}); !ok || len(sources) != 0 {
+		t.Errorf("reputation_breakdown = %v, want empty sources", resp.Data["reputation_breakdown"])
+	}
+}
+
+func TestGetUserProfile_UnknownUsername(t *testing.T) {
+	h, _ := newPublicProfileTest(t, &mockUserProfileRepo{})
+
+	if rr := getUserProfile(h, "nobody_here", ""); rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rr.Code)
+	}
+	// Values that can't be usernames still get the invalid ID error
+	if rr := getUserProfile(h, "me", ""); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for \"me\", got %d", rr.Code)
+	}
+}
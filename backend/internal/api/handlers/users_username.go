@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// UsernameChanger renames users and finds them by a username they gave up.
+type UsernameChanger interface {
+	ChangeUsername(ctx context.Context, userID, username string, cfg models.UsernameChangeConfig) (*models.User, error)
+	FindByPreviousUsername(ctx context.Context, username string) (*models.User, error)
+}
+
+// SetUsernameChanger enables username changes via PATCH /v1/me and redirects
+// from old usernames on GET /v1/users/{username}.
+func (h *UsersHandler) SetUsernameChanger(repo UsernameChanger, cfg models.UsernameChangeConfig) {
+	h.usernameChanger = repo
+	h.usernameChangeConfig = cfg
+}
+
+// changeUsername renames the user, writing the error response and returning a
+// non-nil error when the change is refused.
+func (h *UsersHandler) changeUsername(ctx context.Context, w http.ResponseWriter, userID, username string) (*models.User, error) {
+	if h.usernameChanger == nil {
+		apierror.Write(w, apierror.BadRequest, "username cannot be changed")
+		return nil, errors.New("username changes disabled")
+	}
+	if err := validateUsername(username); err != nil {
+		apierror.Write(w, apierror.InvalidUsername, err.Error())
+		return nil, err
+	}
+
+	user, err := h.usernameChanger.ChangeUsername(ctx, userID, username, h.usernameChangeConfig)
+	switch {
+	case err == nil:
+		return user, nil
+	case errors.Is(err, db.ErrDuplicateUsername):
+		apierror.Write(w, apierror.DuplicateUsername, "username already taken")
+	case errors.Is(err, db.ErrUsernameReserved):
+		apierror.Write(w, apierror.DuplicateUsername,
+			fmt.Sprintf("username was recently used by another account and is reserved for %d days after the change", h.usernameChangeConfig.ReuseHoldDays))
+	case errors.Is(err, db.ErrUsernameChangeTooSoon):
+		apierror.Write(w, apierror.RateLimited,
+			fmt.Sprintf("username can only be changed once every %d days", h.usernameChangeConfig.CooldownDays))
+	case errors.Is(err, db.ErrNotFound):
+		apierror.Write(w, apierror.NotFound, "user not found")
+	default:
+		apierror.Write(w, apierror.InternalError, "failed to change username")
+	}
+	return nil, err
+}
+
+// redirectFromPreviousUsername redirects GET /v1/users/{old_username} to the
+// user's current username. It reports whether it wrote a response.
+func (h *UsersHandler) redirectFromPreviousUsername(w http.ResponseWriter, r *http.Request, username string) bool {
+	if h.usernameChanger == nil {
+		return false
+	}
+	user, err := h.usernameChanger.FindByPreviousUsername(r.Context(), username)
+	if err != nil || user == nil {
+		return false
+	}
+
+	target := "/v1/users/" + user.Username
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	http.Redirect(w, r, target, http.StatusMovedPermanently)
+	return true
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockUsernameChanger struct {
+	users    *MockUsersUserRepository
+	err      error
+	previous map[string]*models.User
+	cfg      models.UsernameChangeConfig
+}
+
+func (m *mockUsernameChanger) ChangeUsername(ctx context.Context, userID, username string, cfg models.UsernameChangeConfig) (*models.User, error) {
+	m.cfg = cfg
+	if m.err != nil {
+		return nil, m.err
+	}
+	user := *m.users.users[userID]
+	user.Username = username
+	m.users.users[userID] = &user
+	return &user, nil
+}
+
+func (m *mockUsernameChanger) FindByPreviousUsername(ctx context.Context, username string) (*models.User, error) {
+	if u, ok := m.previous[username]; ok {
+		return u, nil
+	}
+	return nil, db.ErrNotFound
+}
+
+func patchMe(h *UsersHandler, userID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/v1/me", strings.NewReader(body))
+	req = req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{UserID: userID, Role: "user"}))
+	rr := httptest.NewRecorder()
+	h.UpdateProfile(rr, req)
+	return rr
+}
+
+func newUsernameChangeTest(err error) (*UsersHandler, *MockUsersUserRepository, *mockUsernameChanger) {
+	users := NewMockUsersUserRepository()
+	users.users["user-1"] = &models.User{ID: "user-1", Username: "gh_ana123", DisplayName: "Ana"}
+	changer := &mockUsernameChanger{users: users, err: err}
+	h := NewUsersHandler(users, nil)
+	h.SetUsernameChanger(changer, models.DefaultUsernameChangeConfig())
+	return h, users, changer
+}
+
+func TestUpdateProfile_ChangesUsername(t *testing.T) {
+	h, _, changer := newUsernameChangeTest(nil)
+
+	rr := patchMe(h, "user-1", `{"username":"ana","display_name":"Ana Lima"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Data PublicUserProfileResponse `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Data.Username != "ana" || resp.Data.DisplayName != "Ana Lima" {
+		t.Errorf("got %q / %q, want the new username and display name", resp.Data.Username, resp.Data.DisplayName)
+	}
+	if changer.cfg.CooldownDays != 30 {
+		t.Errorf("ChangeUsername got config %+v, want the handler's", changer.cfg)
+	}
+}
+
+func TestUpdateProfile_UsernameRefused(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		username string
+		status   int
+		code     string
+	}{
+		{"taken", db.ErrDuplicateUsername, "bob", http.StatusConflict, "DUPLICATE_USERNAME"},
+		{"reserved", db.ErrUsernameReserved, "bob", http.StatusConflict, "DUPLICATE_USERNAME"},
+		{"cooldown", db.ErrUsernameChangeTooSoon, "bob", http.StatusTooManyRequests, "RATE_LIMITED"},
+		{"invalid", nil, "a b", http.StatusBadRequest, "INVALID_USERNAME"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, users, _ := newUsernameChangeTest(tt.err)
+
+			rr := patchMe(h, "user-1", `{"username":"`+tt.username+`","display_name":"Changed"}`)
+			if rr.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rr.Code, tt.status, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), tt.code) {
+				t.Errorf("body = %s, want code %s", rr.Body.String(), tt.code)
+			}
+			if got := users.users["user-1"].DisplayName; got != "Ana" {
+				t.Errorf("DisplayName = %q, want the profile left untouched", got)
+			}
+		})
+	}
+}
+
+func TestGetUserProfile_RedirectsPreviousUsername(t *testing.T) {
+	h, user := newPublicProfileTest(t, &mockUserProfileRepo{})
+	h.SetUsernameChanger(&mockUsernameChanger{previous: map[string]*models.User{"gh_ana123": user}}, models.DefaultUsernameChangeConfig())
+
+	rr := getUserProfile(h, "gh_ana123", "?page=2")
+	if rr.Code != http.StatusMovedPermanently {
+		t.Fatalf("status = %d, want 301: %s", rr.Code, rr.Body.String())
+	}
+	if loc := rr.Header().Get("Location"); loc != "/v1/users/ana_dev?page=2" {
+		t.Errorf("Location = %q, want /v1/users/ana_dev?page=2", loc)
+	}
+
+	if rr := getUserProfile(h, "nobody_here", ""); rr.Code != http.StatusNotFound {
+		t.Errorf("unknown username status = %d, want 404", rr.Code)
+	}
+}
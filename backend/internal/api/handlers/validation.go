@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+)
+
+// Field validation codes reported in error.details.fields.
+const (
+	FieldRequired      = "REQUIRED"
+	FieldTooShort      = "TOO_SHORT"
+	FieldTooLong       = "TOO_LONG"
+	FieldTooMany       = "TOO_MANY"
+	FieldOutOfRange    = "OUT_OF_RANGE"
+	FieldInvalidValue  = "INVALID_VALUE"
+	FieldInvalidFormat = "INVALID_FORMAT"
+)
+
+// FieldError is one machine-readable validation failure, e.g.
+// {"field":"title","code":"TOO_SHORT","min":10,"message":"title must be at least 10 characters"}.
+type FieldError struct {
+	Field   string   `json:"field"`
+	Code    string   `json:"code"`
+	Message string   `json:"message"`
+	Min     int      `json:"min,omitempty"`
+	Max     int      `json:"max,omitempty"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// Validator collects field errors for a request body so clients see every
+// problem at once instead of fixing them one round-trip at a time.
+// Lengths are measured in bytes, matching the database column limits.
+type Validator struct {
+	errs []FieldError
+}
+
+// Required records REQUIRED if value is empty and reports whether it was present.
+func (v *Validator) Required(field, value string) bool {
+	if value == "" {
+		v.Add(FieldError{Field: field, Code: FieldRequired, Message: field + " is required"})
+		return false
+	}
+	return true
+}
+
+// Length records TOO_SHORT or TOO_LONG if value falls outside [min, max].
+// A zero min or max leaves that bound unchecked.
+func (v *Validator) Length(field, value string, min, max int) {
+	switch {
+	case min > 0 && len(value) < min:
+		v.Add(FieldError{Field: field, Code: FieldTooShort, Min: min,
+			Message: fmt.Sprintf("%s must be at least %d characters", field, min)})
+	case max > 0 && len(value) > max:
+		v.Add(FieldError{Field: field, Code: FieldTooLong, Max: max,
+			Message: fmt.Sprintf("%s must be at most %d characters", field, max)})
+	}
+}
+
+// MaxItems records TOO_MANY if a list field has more than max entries.
+func (v *Validator) MaxItems(field string, n, max int) {
+	if n > max {
+		v.Add(FieldError{Field: field, Code: FieldTooMany, Max: max,
+			Message: fmt.Sprintf("maximum %d %s allowed", max, strings.ReplaceAll(field, "_", " "))})
+	}
+}
+
+// Range records OUT_OF_RANGE if value falls outside [min, max].
+func (v *Validator) Range(field string, value, min, max int) {
+	if value < min || value > max {
+		v.Add(FieldError{Field: field, Code: FieldOutOfRange, Min: min, Max: max,
+			Message: fmt.Sprintf("%s must be between %d and %d", field, min, max)})
+	}
+}
+
+// OneOf records INVALID_VALUE if value is not one of allowed.
+func (v *Validator) OneOf(field, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.Add(FieldError{Field: field, Code: FieldInvalidValue, Allowed: allowed,
+		Message: fmt.Sprintf("%s must be one of: %s", field, strings.Join(allowed, ", "))})
+}
+
+// Add records a field error. Use it for rules the helpers above don't cover.
+func (v *Validator) Add(err FieldError) {
+	v.errs = append(v.errs, err)
+}
+
+// HasError reports whether field has failed any rule.
+func (v *Validator) HasError(field string) bool {
+	for _, e := range v.errs {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether no rule has failed.
+func (v *Validator) Valid() bool {
+	return len(v.errs) == 0
+}
+
+// Errors returns the recorded field errors in the order they were found.
+func (v *Validator) Errors() []FieldError {
+	return v.errs
+}
+
+// writeFieldErrors writes a 400 in the standard error envelope. The message is the
+// first failure, so clients that only read error.message keep working; every failure
+// is listed in error.details.fields.
+func writeFieldErrors(w http.ResponseWriter, code apierror.Code, errs []FieldError) {
+	message := "validation failed"
+	if len(errs) > 0 {
+		message = errs[0].Message
+	}
+	apierror.WriteDetails(w, code, message, map[string]interface{}{"fields": errs})
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+)
+
+func TestValidator_Rules(t *testing.T) {
+	var v Validator
+	if v.Required("title", "") {
+		t.Error("Required() reported an empty value as present")
+	}
+	v.Length("description", "short", 50, 0)
+	v.Length("angle", strings.Repeat("a", 501), 0, 500)
+	v.MaxItems("success_criteria", 11, 10)
+	v.Range("weight", 9, 1, 5)
+	v.OneOf("visibility", "secret", "public", "family")
+	v.Length("method", "fine", 0, 500)
+
+	want := []FieldError{
+		{Field: "title", Code: FieldRequired, Message: "title is required"},
+		{Field: "description", Code: FieldTooShort, Min: 50, Message: "description must be at least 50 characters"},
+		{Field: "angle", Code: FieldTooLong, Max: 500, Message: "angle must be at most 500 characters"},
+		{Field: "success_criteria", Code: FieldTooMany, Max: 10, Message: "maximum 10 success criteria allowed"},
+		{Field: "weight", Code: FieldOutOfRange, Min: 1, Max: 5, Message: "weight must be between 1 and 5"},
+		{Field: "visibility", Code: FieldInvalidValue, Message: "visibility must be one of: public, family"},
+	}
+	got := v.Errors()
+	if len(got) != len(want) {
+		t.Fatalf("got %d errors, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Field != want[i].Field || got[i].Code != want[i].Code || got[i].Message != want[i].Message ||
+			got[i].Min != want[i].Min || got[i].Max != want[i].Max {
+			t.Errorf("error %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if v.Valid() || !v.HasError("weight") || v.HasError("method") {
+		t.Error("Valid/HasError disagree with recorded errors")
+	}
+}
+
+func TestWriteFieldErrors_Envelope(t *testing.T) {
+	var v Validator
+	v.Length("title", "short", 10, 200)
+	v.MaxItems("tags", 11, 10)
+
+	w := httptest.NewRecorder()
+	writeFieldErrors(w, apierror.ValidationError, v.Errors())
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	var resp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Details struct {
+				Fields []map[string]interface{} `json:"fields"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error.Code != "VALIDATION_ERROR" || resp.Error.Message != "title must be at least 10 characters" {
+		t.Errorf("unexpected error: %+v", resp.Error)
+	}
+	fields := resp.Error.Details.Fields
+	if len(fields) != 2 {
+		t.Fatalf("expected 2 field errors, got %v", fields)
+	}
+	if fields[0]["field"] != "title" || fields[0]["code"] != "TOO_SHORT" || fields[0]["min"] != float64(10) {
+		t.Errorf("unexpected first field error: %v", fields[0])
+	}
+	if _, ok := fields[0]["max"]; ok {
+		t.Error("unset bound should be omitted")
+	}
+}
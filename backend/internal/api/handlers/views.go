@@ -3,20 +3,45 @@ package handlers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/fcavalcantirj/solvr/internal/api/response"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/go-chi/chi/v5"
 )
 
+// Views-over-time series bounds for GET /v1/posts/:id/views?days=N.
+const (
+	defaultViewSeriesDays = 30
+	maxViewSeriesDays     = 365
+)
+
+// botUserAgentMarkers are lowercase user agent substrings of crawlers, link
+// previewers, monitors and scripted HTTP clients. Anonymous views from them are not counted.
+var botUserAgentMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "facebookexternalhit", "headless", "preview",
+	"curl/", "wget/", "python-requests", "python-urllib", "go-http-client", "okhttp",
+	"java/", "axios/", "node-fetch", "scrapy", "lighthouse", "pingdom", "uptime",
+}
+
 // ViewsRepositoryInterface defines the database operations for view tracking.
 type ViewsRepositoryInterface interface {
-	RecordView(ctx context.Context, postID, viewerType, viewerID string) (int, error)
-	RecordAnonymousView(ctx context.Context, postID, sessionID string) (int, error)
+	RecordView(ctx context.Context, postID, viewerType, viewerID, viewerHash, referrerHost string) (int, error)
+	RecordAnonymousView(ctx context.Context, postID, viewerHash, referrerHost string) (int, error)
 	GetViewCount(ctx context.Context, postID string) (int, error)
+	GetViewSeries(ctx context.Context, postID string, days int) ([]models.PostViewDay, error)
 }
 
 // ViewsHandler handles view tracking HTTP requests.
@@ -39,39 +64,34 @@ func (h *ViewsHandler) SetLogger(logger *slog.Logger) {
 }
 
 // RecordView handles POST /v1/posts/:id/view - record a view on a post.
-// Authenticated users' views are tracked by user ID.
-// Anonymous views can be tracked by session ID.
+// A viewer is identified by a hash of their principal (or IP when anonymous)
+// and user agent, and counts once per post per 24 hours. Anonymous views from
+// bots and scripted clients are not counted.
 func (h *ViewsHandler) RecordView(w http.ResponseWriter, r *http.Request) {
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writeViewsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
-	var viewerType, viewerID string
-
-	// Check for authenticated user
-	authInfo := GetAuthInfo(r)
-	if authInfo != nil {
-		viewerType = string(authInfo.AuthorType)
-		viewerID = authInfo.AuthorID
+	userAgent := r.UserAgent()
+	referrer := referrerHost(r.Referer())
+	var viewCount int
+	var err error
+	if authInfo := GetAuthInfo(r); authInfo != nil {
+		viewerType := string(authInfo.AuthorType)
+		viewCount, err = h.repo.RecordView(r.Context(), postID, viewerType, authInfo.AuthorID,
+			viewerHash(viewerType+":"+authInfo.AuthorID, userAgent), referrer)
+	} else if isBotUserAgent(userAgent) {
+		viewCount, err = h.repo.GetViewCount(r.Context(), postID)
 	} else {
-		// For anonymous views, use session ID from header or generate one
-		sessionID := r.Header.Get("X-Session-ID")
-		if sessionID == "" {
-			// Allow anonymous view without session tracking
-			// Use a placeholder that will be unique per request
-			sessionID = r.Header.Get("X-Request-ID")
-			if sessionID == "" {
-				sessionID = "anonymous"
-			}
-		}
-		viewerType = "anonymous"
-		viewerID = sessionID
+		viewCount, err = h.repo.RecordAnonymousView(r.Context(), postID, viewerHash("ip:"+viewerIP(r), userAgent), referrer)
 	}
-
-	viewCount, err := h.repo.RecordView(r.Context(), postID, viewerType, viewerID)
 	if err != nil {
+		if errors.Is(err, db.ErrPostNotFound) {
+			apierror.Write(w, apierror.NotFound, "post not found")
+			return
+		}
 		ctx := response.LogContext{
 			Operation: "RecordView",
 			Resource:  "view",
@@ -89,14 +109,23 @@ func (h *ViewsHandler) RecordView(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// GetViewCount handles GET /v1/posts/:id/views - get view count for a post.
+// GetViewCount handles GET /v1/posts/:id/views - view count and daily views.
+// Query param days (default 30, max 365) sets the length of the series.
 func (h *ViewsHandler) GetViewCount(w http.ResponseWriter, r *http.Request) {
 	postID := chi.URLParam(r, "id")
 	if postID == "" {
-		writeViewsError(w, http.StatusBadRequest, "VALIDATION_ERROR", "post ID is required")
+		apierror.Write(w, apierror.ValidationError, "post ID is required")
 		return
 	}
 
+	days := defaultViewSeriesDays
+	if d, err := strconv.Atoi(r.URL.Query().Get("days")); err == nil && d > 0 {
+		days = d
+		if days > maxViewSeriesDays {
+			days = maxViewSeriesDays
+		}
+	}
+
 	viewCount, err := h.repo.GetViewCount(r.Context(), postID)
 	if err != nil {
 		ctx := response.LogContext{
@@ -109,28 +138,72 @@ func (h *ViewsHandler) GetViewCount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	series, err := h.repo.GetViewSeries(r.Context(), postID, days)
+	if err != nil {
+		ctx := response.LogContext{
+			Operation: "GetViewSeries",
+			Resource:  "view",
+			RequestID: r.Header.Get("X-Request-ID"),
+			Extra:     map[string]string{"postID": postID},
+		}
+		response.WriteInternalErrorWithLog(w, "failed to get view series", err, ctx, h.logger)
+		return
+	}
+
 	writeViewsJSON(w, http.StatusOK, map[string]interface{}{
 		"data": map[string]interface{}{
 			"view_count": viewCount,
+			"series":     series,
 		},
 	})
 }
 
+// viewerHash returns the hex SHA-256 of a viewer identity and user agent.
+// Raw IPs and user agents are never stored.
+func viewerHash(identity, userAgent string) string {
+	sum := sha256.Sum256([]byte(identity + "\n" + userAgent))
+	return hex.EncodeToString(sum[:])
+}
+
+// viewerIP returns the client IP; the RealIP middleware has already applied proxy headers.
+func viewerIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// referrerHost returns the lowercased host of a Referer header without a
+// leading "www.", or "" when there is no usable referrer.
+func referrerHost(referer string) string {
+	u, err := url.Parse(referer)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if len(host) > 255 {
+		return ""
+	}
+	return host
+}
+
+// isBotUserAgent reports whether a user agent is empty or looks automated.
+func isBotUserAgent(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(ua, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // writeViewsJSON writes a JSON response.
 func writeViewsJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
-
-// writeViewsError writes an error JSON response.
-func writeViewsError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
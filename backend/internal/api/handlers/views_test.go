@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// mockViewsRepo is a test double for ViewsRepositoryInterface.
+type mockViewsRepo struct {
+	count      int
+	hashes     []string
+	referrers  []string
+	viewerType string
+	seriesDays int
+	notFound   bool
+}
+
+func (m *mockViewsRepo) RecordView(ctx context.Context, postID, viewerType, viewerID, viewerHash, referrerHost string) (int, error) {
+	if m.notFound {
+		return 0, db.ErrPostNotFound
+	}
+	m.viewerType = viewerType
+	m.hashes = append(m.hashes, viewerHash)
+	m.referrers = append(m.referrers, referrerHost)
+	m.count++
+	return m.count, nil
+}
+
+func (m *mockViewsRepo) RecordAnonymousView(ctx context.Context, postID, viewerHash, referrerHost string) (int, error) {
+	return m.RecordView(ctx, postID, "anonymous", "", viewerHash, referrerHost)
+}
+
+func (m *mockViewsRepo) GetViewCount(ctx context.Context, postID string) (int, error) {
+	return m.count, nil
+}
+
+func (m *mockViewsRepo) GetViewSeries(ctx context.Context, postID string, days int) ([]models.PostViewDay, error) {
+	m.seriesDays = days
+	return []models.PostViewDay{{Date: "2026-01-01", Views: m.count}}, nil
+}
+
+func newViewRequest(method, path, userAgent string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("User-Agent", userAgent)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "post-1")
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+const browserUA = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"
+
+func TestViewsHandler_RecordView_Anonymous(t *testing.T) {
+	repo := &mockViewsRepo{}
+	handler := NewViewsHandler(repo)
+
+	req := newViewRequest(http.MethodPost, "/v1/posts/post-1/view", browserUA)
+	req.RemoteAddr = "203.0.113.7:5000"
+	w := httptest.NewRecorder()
+	handler.RecordView(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.viewerType != "anonymous" || len(repo.hashes) != 1 {
+		t.Fatalf("expected one anonymous view, got %+v", repo)
+	}
+	if repo.hashes[0] != viewerHash("ip:203.0.113.7", browserUA) || len(repo.hashes[0]) != 64 {
+		t.Errorf("unexpected viewer hash %q", repo.hashes[0])
+	}
+}
+
+func TestViewsHandler_RecordView_ReferrerHost(t *testing.T) {
+	tests := []struct {
+		referer string
+		want    string
+	}{
+		{"", ""},
+		{"https://www.Google.com/search?q=pgx+pool", "google.com"},
+		{"https://news.ycombinator.com/item?id=1", "news.ycombinator.com"},
+		{"not a url", ""},
+	}
+	for _, tt := range tests {
+		repo := &mockViewsRepo{}
+		handler := NewViewsHandler(repo)
+
+		req := newViewRequest(http.MethodPost, "/v1/posts/post-1/view", browserUA)
+		req.Header.Set("Referer", tt.referer)
+		handler.RecordView(httptest.NewRecorder(), req)
+
+		if len(repo.referrers) != 1 || repo.referrers[0] != tt.want {
+			t.Errorf("Referer %q: expected referrer host %q, got %v", tt.referer, tt.want, repo.referrers)
+		}
+	}
+}
+
+func TestViewsHandler_RecordView_AuthenticatedHashesPrincipal(t *testing.T) {
+	repo := &mockViewsRepo{}
+	handler := NewViewsHandler(repo)
+
+	// Same user from two IPs is the same viewer
+	for _, addr := range []string{"203.0.113.7:5000", "198.51.100.2:6000"} {
+		req := addAuthContext(newViewRequest(http.MethodPost, "/v1/posts/post-1/view", browserUA), "user-123", "user")
+		req.RemoteAddr = addr
+		handler.RecordView(httptest.NewRecorder(), req)
+	}
+
+	if repo.viewerType != "human" || len(repo.hashes) != 2 || repo.hashes[0] != repo.hashes[1] {
+		t.Errorf("expected the same human viewer hash twice, got %+v", repo)
+	}
+}
+
+func TestViewsHandler_RecordView_SkipsAnonymousBots(t *testing.T) {
+	for _, ua := range []string{"", "Googlebot/2.1 (+http://www.google.com/bot.html)", "curl/8.4.0", "python-requests/2.31"} {
+		repo := &mockViewsRepo{count: 5}
+		handler := NewViewsHandler(repo)
+
+		w := httptest.NewRecorder()
+		handler.RecordView(w, newViewRequest(http.MethodPost, "/v1/posts/post-1/view", ua))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: expected 200, got %d", ua, w.Code)
+		}
+		if len(repo.hashes) != 0 {
+			t.Errorf("%q: bot view should not be recorded", ua)
+		}
+		var resp struct {
+			Data struct {
+				ViewCount int `json:"view_count"`
+			} `json:"data"`
+		}
+		json.NewDecoder(w.Body).Decode(&resp)
+		if resp.Data.ViewCount != 5 {
+			t.Errorf("%q: expected current view count 5, got %d", ua, resp.Data.ViewCount)
+		}
+	}
+}
+
+func TestViewsHandler_RecordView_PostNotFound(t *testing.T) {
+	handler := NewViewsHandler(&mockViewsRepo{notFound: true})
+
+	w := httptest.NewRecorder()
+	handler.RecordView(w, newViewRequest(http.MethodPost, "/v1/posts/post-1/view", browserUA))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestViewsHandler_GetViewCount_Series(t *testing.T) {
+	tests := []struct {
+		query string
+		days  int
+	}{
+		{"", defaultViewSeriesDays},
+		{"?days=7", 7},
+		{"?days=1000", maxViewSeriesDays},
+		{"?days=abc", defaultViewSeriesDays},
+	}
+	for _, tt := range tests {
+		repo := &mockViewsRepo{count: 3}
+		handler := NewViewsHandler(repo)
+
+		w := httptest.NewRecorder()
+		handler.GetViewCount(w, newViewRequest(http.MethodGet, "/v1/posts/post-1/views"+tt.query, browserUA))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: expected 200, got %d", tt.query, w.Code)
+		}
+		if repo.seriesDays != tt.days {
+			t.Errorf("%q: expected %d series days, got %d", tt.query, tt.days, repo.seriesDays)
+		}
+		var resp struct {
+			Data struct {
+				ViewCount int                  `json:"view_count"`
+				Series    []models.PostViewDay `json:"series"`
+			} `json:"data"`
+		}
+		json.NewDecoder(w.Body).Decode(&resp)
+		if resp.Data.ViewCount != 3 || len(resp.Data.Series) != 1 {
+			t.Errorf("%q: unexpected response %+v", tt.query, resp.Data)
+		}
+	}
+}
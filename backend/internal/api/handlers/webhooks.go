@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/google/uuid"
@@ -55,16 +56,16 @@ func (h *WebhooksHandler) CreateWebhook(w http.ResponseWriter, r *http.Request,
 	agent, err := h.repo.FindAgent(r.Context(), agentID)
 	if err != nil {
 		if errors.Is(err, ErrAgentNotFound) {
-			writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
 	// Verify ownership
 	if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-		writeWebhookError(w, http.StatusForbidden, "FORBIDDEN", "you do not own this agent")
+		apierror.Write(w, apierror.Forbidden, "you do not own this agent")
 		return
 	}
 
@@ -93,7 +94,7 @@ func (h *WebhooksHandler) CreateWebhook(w http.ResponseWriter, r *http.Request,
 
 	// Validate each event type
 	if invalid := models.ValidateWebhookEvents(req.Events); invalid != "" {
-		writeWebhookError(w, http.StatusBadRequest, "INVALID_EVENT_TYPE", "invalid event type: "+invalid)
+		apierror.Write(w, apierror.InvalidEventType, "invalid event type: "+invalid)
 		return
 	}
 
@@ -106,7 +107,7 @@ func (h *WebhooksHandler) CreateWebhook(w http.ResponseWriter, r *http.Request,
 	// Hash the secret for storage
 	secretHash, err := bcrypt.GenerateFromPassword([]byte(req.Secret), bcrypt.DefaultCost)
 	if err != nil {
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to hash secret")
+		apierror.Write(w, apierror.InternalError, "failed to hash secret")
 		return
 	}
 
@@ -123,7 +124,7 @@ func (h *WebhooksHandler) CreateWebhook(w http.ResponseWriter, r *http.Request,
 	}
 
 	if err := h.repo.Create(r.Context(), webhook); err != nil {
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to create webhook")
+		apierror.Write(w, apierror.InternalError, "failed to create webhook")
 		return
 	}
 
@@ -149,23 +150,23 @@ func (h *WebhooksHandler) ListWebhooks(w http.ResponseWriter, r *http.Request, a
 	agent, err := h.repo.FindAgent(r.Context(), agentID)
 	if err != nil {
 		if errors.Is(err, ErrAgentNotFound) {
-			writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
 	// Verify ownership
 	if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-		writeWebhookError(w, http.StatusForbidden, "FORBIDDEN", "you do not own this agent")
+		apierror.Write(w, apierror.Forbidden, "you do not own this agent")
 		return
 	}
 
 	// Get webhooks
 	webhooks, err := h.repo.List(r.Context(), agentID)
 	if err != nil {
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to list webhooks")
+		apierror.Write(w, apierror.InternalError, "failed to list webhooks")
 		return
 	}
 
@@ -195,23 +196,23 @@ func (h *WebhooksHandler) GetWebhook(w http.ResponseWriter, r *http.Request, age
 	agent, err := h.repo.FindAgent(r.Context(), agentID)
 	if err != nil {
 		if errors.Is(err, ErrAgentNotFound) {
-			writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
 	// Verify ownership
 	if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-		writeWebhookError(w, http.StatusForbidden, "FORBIDDEN", "you do not own this agent")
+		apierror.Write(w, apierror.Forbidden, "you do not own this agent")
 		return
 	}
 
 	// Parse webhook ID
 	whID, err := uuid.Parse(webhookID)
 	if err != nil {
-		writeWebhookError(w, http.StatusBadRequest, "INVALID_ID", "invalid webhook ID format")
+		apierror.Write(w, apierror.InvalidID, "invalid webhook ID format")
 		return
 	}
 
@@ -219,16 +220,16 @@ func (h *WebhooksHandler) GetWebhook(w http.ResponseWriter, r *http.Request, age
 	webhook, err := h.repo.FindByID(r.Context(), whID)
 	if err != nil {
 		if errors.Is(err, ErrWebhookNotFound) {
-			writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "webhook not found")
+			apierror.Write(w, apierror.NotFound, "webhook not found")
 			return
 		}
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get webhook")
+		apierror.Write(w, apierror.InternalError, "failed to get webhook")
 		return
 	}
 
 	// Verify webhook belongs to this agent
 	if webhook.AgentID != agentID {
-		writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "webhook not found")
+		apierror.Write(w, apierror.NotFound, "webhook not found")
 		return
 	}
 
@@ -253,23 +254,23 @@ func (h *WebhooksHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request,
 	agent, err := h.repo.FindAgent(r.Context(), agentID)
 	if err != nil {
 		if errors.Is(err, ErrAgentNotFound) {
-			writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
 	// Verify ownership
 	if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-		writeWebhookError(w, http.StatusForbidden, "FORBIDDEN", "you do not own this agent")
+		apierror.Write(w, apierror.Forbidden, "you do not own this agent")
 		return
 	}
 
 	// Parse webhook ID
 	whID, err := uuid.Parse(webhookID)
 	if err != nil {
-		writeWebhookError(w, http.StatusBadRequest, "INVALID_ID", "invalid webhook ID format")
+		apierror.Write(w, apierror.InvalidID, "invalid webhook ID format")
 		return
 	}
 
@@ -277,16 +278,16 @@ func (h *WebhooksHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request,
 	webhook, err := h.repo.FindByID(r.Context(), whID)
 	if err != nil {
 		if errors.Is(err, ErrWebhookNotFound) {
-			writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "webhook not found")
+			apierror.Write(w, apierror.NotFound, "webhook not found")
 			return
 		}
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get webhook")
+		apierror.Write(w, apierror.InternalError, "failed to get webhook")
 		return
 	}
 
 	// Verify webhook belongs to this agent
 	if webhook.AgentID != agentID {
-		writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "webhook not found")
+		apierror.Write(w, apierror.NotFound, "webhook not found")
 		return
 	}
 
@@ -312,7 +313,7 @@ func (h *WebhooksHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request,
 			return
 		}
 		if invalid := models.ValidateWebhookEvents(req.Events); invalid != "" {
-			writeWebhookError(w, http.StatusBadRequest, "INVALID_EVENT_TYPE", "invalid event type: "+invalid)
+			apierror.Write(w, apierror.InvalidEventType, "invalid event type: "+invalid)
 			return
 		}
 		webhook.Events = req.Events
@@ -321,7 +322,7 @@ func (h *WebhooksHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request,
 	if req.Secret != nil {
 		secretHash, err := bcrypt.GenerateFromPassword([]byte(*req.Secret), bcrypt.DefaultCost)
 		if err != nil {
-			writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to hash secret")
+			apierror.Write(w, apierror.InternalError, "failed to hash secret")
 			return
 		}
 		webhook.SecretHash = string(secretHash)
@@ -338,7 +339,7 @@ func (h *WebhooksHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request,
 	webhook.UpdatedAt = time.Now()
 
 	if err := h.repo.Update(r.Context(), webhook); err != nil {
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to update webhook")
+		apierror.Write(w, apierror.InternalError, "failed to update webhook")
 		return
 	}
 
@@ -363,23 +364,23 @@ func (h *WebhooksHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request,
 	agent, err := h.repo.FindAgent(r.Context(), agentID)
 	if err != nil {
 		if errors.Is(err, ErrAgentNotFound) {
-			writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "agent not found")
+			apierror.Write(w, apierror.NotFound, "agent not found")
 			return
 		}
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get agent")
+		apierror.Write(w, apierror.InternalError, "failed to get agent")
 		return
 	}
 
 	// Verify ownership
 	if agent.HumanID == nil || *agent.HumanID != claims.UserID {
-		writeWebhookError(w, http.StatusForbidden, "FORBIDDEN", "you do not own this agent")
+		apierror.Write(w, apierror.Forbidden, "you do not own this agent")
 		return
 	}
 
 	// Parse webhook ID
 	whID, err := uuid.Parse(webhookID)
 	if err != nil {
-		writeWebhookError(w, http.StatusBadRequest, "INVALID_ID", "invalid webhook ID format")
+		apierror.Write(w, apierror.InvalidID, "invalid webhook ID format")
 		return
 	}
 
@@ -387,46 +388,34 @@ func (h *WebhooksHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request,
 	webhook, err := h.repo.FindByID(r.Context(), whID)
 	if err != nil {
 		if errors.Is(err, ErrWebhookNotFound) {
-			writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "webhook not found")
+			apierror.Write(w, apierror.NotFound, "webhook not found")
 			return
 		}
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to get webhook")
+		apierror.Write(w, apierror.InternalError, "failed to get webhook")
 		return
 	}
 
 	// Verify webhook belongs to this agent
 	if webhook.AgentID != agentID {
-		writeWebhookError(w, http.StatusNotFound, "NOT_FOUND", "webhook not found")
+		apierror.Write(w, apierror.NotFound, "webhook not found")
 		return
 	}
 
 	// Delete webhook
 	if err := h.repo.Delete(r.Context(), whID); err != nil {
-		writeWebhookError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to delete webhook")
+		apierror.Write(w, apierror.InternalError, "failed to delete webhook")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// writeWebhookError writes an error response.
-func writeWebhookError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
-}
-
 // writeWebhookUnauthorized writes a 401 Unauthorized error.
 func writeWebhookUnauthorized(w http.ResponseWriter, message string) {
-	writeWebhookError(w, http.StatusUnauthorized, "UNAUTHORIZED", message)
+	apierror.Write(w, apierror.Unauthorized, message)
 }
 
 // writeWebhookValidationError writes a 400 Validation Error.
 func writeWebhookValidationError(w http.ResponseWriter, message string) {
-	writeWebhookError(w, http.StatusBadRequest, "VALIDATION_ERROR", message)
+	apierror.Write(w, apierror.ValidationError, message)
 }
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+const (
+	// readinessCacheTTL bounds how often probes hit external dependencies.
+	readinessCacheTTL = 10 * time.Second
+	// readinessCheckTimeout caps each dependency check.
+	readinessCheckTimeout = 3 * time.Second
+
+	// Overall readiness states.
+	readinessReady    = "ready"
+	readinessDegraded = "degraded"
+	readinessDown     = "down"
+
+	// dependencySkipped marks optional dependencies with no checker configured.
+	dependencySkipped = "skipped"
+)
+
+// readinessDependency describes one dependency reported by /health/ready.
+// Critical dependencies take the API down when they fail; the rest only degrade it.
+type readinessDependency struct {
+	Name     string
+	Critical bool
+}
+
+// readinessDependencies is the fixed, ordered set of checks in the readiness report.
+var readinessDependencies = []readinessDependency{
+	{Name: "database", Critical: true},
+	{Name: "pgvector", Critical: true},
+	{Name: "ipfs"},
+	{Name: "groq"},
+	{Name: "voyage"},
+}
+
+// DependencyStatus is one entry in ReadinessResponse.Dependencies.
+// Status is operational, degraded, outage, or skipped.
+type DependencyStatus struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMs int    `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ReadinessResponse is the response structure for GET /health/ready.
+// Status is ready, degraded (a non-critical dependency is failing or slow),
+// or down (a critical dependency is failing; served with 503).
+type ReadinessResponse struct {
+	Status       string               `json:"status"`
+	Database     string               `json:"database"`
+	Timestamp    string               `json:"timestamp"`
+	Dependencies []DependencyStatus   `json:"dependencies"`
+	Schema       *models.SchemaStatus `json:"schema,omitempty"`
+}
+
+// serviceChecker is the subset of HealthCheckerService used by the readiness probe.
+type serviceChecker interface {
+	CheckService(ctx context.Context, serviceName string) (models.ServiceCheckStatus, int, error)
+}
+
+// schemaChecker compares the database schema version with the embedded migrations.
+type schemaChecker interface {
+	Status(ctx context.Context) (models.SchemaStatus, error)
+}
+
+// readinessProbe runs dependency checks concurrently and caches the report briefly
+// so frequent orchestrator probes don't hammer Groq/Voyage.
+type readinessProbe struct {
+	checker serviceChecker
+	schema  schemaChecker
+	ttl     time.Duration
+
+	mu       sync.Mutex
+	cached   *ReadinessResponse
+	cachedAt time.Time
+}
+
+// newReadinessChecker builds the HealthCheckerService used by /health/ready.
+// Groq and Voyage are only probed when their API keys are configured.
+func newReadinessChecker(pool *db.Pool, ipfs services.IPFSNodeChecker) *services.HealthCheckerService {
+	checker := services.NewHealthCheckerService(pool, ipfs)
+	checker.SetExtensionChecker(pool)
+	if os.Getenv("GROQ_API_KEY") != "" {
+		checker.SetGroqChecker(services.NewHTTPProbe(services.DefaultGroqBaseURL+"/models", readinessCheckTimeout))
+	}
+	if os.Getenv("VOYAGE_API_KEY") != "" {
+		checker.SetVoyageChecker(services.NewHTTPProbe(services.DefaultVoyageBaseURL+"/models", readinessCheckTimeout))
+	}
+	return checker
+}
+
+// report returns the cached readiness report, refreshing it when stale.
+func (p *readinessProbe) report(ctx context.Context) ReadinessResponse {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cached != nil && time.Since(p.cachedAt) < p.ttl {
+		return *p.cached
+	}
+
+	deps := make([]DependencyStatus, len(readinessDependencies))
+	var schemaDep DependencyStatus
+	var schemaStatus *models.SchemaStatus
+	var wg sync.WaitGroup
+	for i, dep := range readinessDependencies {
+		wg.Add(1)
+		go func(i int, dep readinessDependency) {
+			defer wg.Done()
+			// Detached from the caller so one aborted probe doesn't cache spurious failures.
+			checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), readinessCheckTimeout)
+			defer cancel()
+			deps[i] = checkDependency(checkCtx, p.checker, dep)
+		}(i, dep)
+	}
+	if p.schema != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), readinessCheckTimeout)
+			defer cancel()
+			schemaDep, schemaStatus = checkSchema(checkCtx, p.schema)
+		}()
+	}
+	wg.Wait()
+	if p.schema != nil {
+		deps = append(deps, schemaDep)
+	}
+
+	resp := ReadinessResponse{
+		Status:       aggregateReadiness(deps),
+		Database:     "ok",
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Dependencies: deps,
+		Schema:       schemaStatus,
+	}
+	if deps[0].Status == string(models.ServiceStatusOutage) {
+		resp.Database = "unavailable"
+	}
+
+	p.cached = &resp
+	p.cachedAt = time.Now()
+	return resp
+}
+
+// checkDependency runs a single check and converts it to a DependencyStatus.
+func checkDependency(ctx context.Context, checker serviceChecker, dep readinessDependency) DependencyStatus {
+	status, latency, err := checker.CheckService(ctx, dep.Name)
+	result := DependencyStatus{
+		Name:      dep.Name,
+		Status:    string(status),
+		Critical:  dep.Critical,
+		LatencyMs: latency,
+	}
+	if errors.Is(err, services.ErrHealthCheckNotConfigured) {
+		result.Status = dependencySkipped
+		return result
+	}
+	if err != nil {
+		result.Error = err.Error()
+		if status == "" {
+			result.Status = string(models.ServiceStatusOutage)
+		}
+	}
+	return result
+}
+
+// checkSchema reports schema drift as the critical "schema" dependency: pending or
+// dirty migrations take the API down, a database ahead of the binary (e.g. during
+// a rolling deploy) only degrades it.
+func checkSchema(ctx context.Context, checker schemaChecker) (DependencyStatus, *models.SchemaStatus) {
+	dep := DependencyStatus{Name: "schema", Critical: true, Status: string(models.ServiceStatusOperational)}
+	start := time.Now()
+	status, err := checker.Status(ctx)
+	dep.LatencyMs = int(time.Since(start).Milliseconds())
+	switch {
+	case err != nil:
+		dep.Status = string(models.ServiceStatusOutage)
+		dep.Error = err.Error()
+		return dep, nil
+	case status.Dirty:
+		dep.Status = string(models.ServiceStatusOutage)
+		dep.Error = fmt.Sprintf("migration %d is dirty", status.CurrentVersion)
+	case status.CurrentVersion < status.ExpectedVersion:
+		dep.Status = string(models.ServiceStatusOutage)
+		dep.Error = fmt.Sprintf("%d migrations pending: database at %d, expected %d", status.Pending, status.CurrentVersion, status.ExpectedVersion)
+	case status.CurrentVersion > status.ExpectedVersion:
+		dep.Status = string(models.ServiceStatusDegraded)
+		dep.Error = fmt.Sprintf("database at %d is ahead of expected %d", status.CurrentVersion, status.ExpectedVersion)
+	}
+	return dep, &status
+}
+
+// aggregateReadiness reduces dependency results to ready, degraded, or down.
+func aggregateReadiness(deps []DependencyStatus) string {
+	overall := readinessReady
+	for _, d := range deps {
+		switch d.Status {
+		case string(models.ServiceStatusOutage):
+			if d.Critical {
+				return readinessDown
+			}
+			overall = readinessDegraded
+		case string(models.ServiceStatusDegraded):
+			overall = readinessDegraded
+		}
+	}
+	return overall
+}
+
+// healthReadyHandler handles GET /health/ready.
+// Returns 200 when ready or degraded and 503 when a critical dependency is down.
+// A nil checker means no database is configured and always yields 503.
+// schema is optional; when set, schema version drift is reported as well.
+func healthReadyHandler(checker serviceChecker, schema schemaChecker) http.HandlerFunc {
+	probe := &readinessProbe{checker: checker, schema: schema, ttl: readinessCacheTTL}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if checker == nil {
+			apierror.Write(w, apierror.ServiceUnavailable, "database not configured")
+			return
+		}
+
+		resp := probe.report(r.Context())
+		status := http.StatusOK
+		if resp.Status == readinessDown {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, resp)
+	}
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// fakeServiceChecker returns canned results per service name.
+type fakeServiceChecker struct {
+	results map[string]fakeCheckResult
+	calls   atomic.Int32
+}
+
+type fakeCheckResult struct {
+	status  models.ServiceCheckStatus
+	latency int
+	err     error
+}
+
+func (f *fakeServiceChecker) CheckService(ctx context.Context, name string) (models.ServiceCheckStatus, int, error) {
+	f.calls.Add(1)
+	r, ok := f.results[name]
+	if !ok {
+		return "", 0, services.ErrHealthCheckNotConfigured
+	}
+	return r.status, r.latency, r.err
+}
+
+func healthyChecker() *fakeServiceChecker {
+	return &fakeServiceChecker{results: map[string]fakeCheckResult{
+		"database": {status: models.ServiceStatusOperational, latency: 2},
+		"pgvector": {status: models.ServiceStatusOperational, latency: 1},
+		"ipfs":     {status: models.ServiceStatusOperational, latency: 15},
+	}}
+}
+
+func serveReady(t *testing.T, checker serviceChecker) (*httptest.ResponseRecorder, ReadinessResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	healthReadyHandler(checker, nil)(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	var resp ReadinessResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return w, resp
+}
+
+func TestHealthReady_AllOperational(t *testing.T) {
+	w, resp := serveReady(t, healthyChecker())
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if resp.Status != "ready" || resp.Database != "ok" {
+		t.Errorf("expected ready/ok, got %s/%s", resp.Status, resp.Database)
+	}
+	if len(resp.Dependencies) != len(readinessDependencies) {
+		t.Fatalf("expected %d dependencies, got %d", len(readinessDependencies), len(resp.Dependencies))
+	}
+	byName := map[string]DependencyStatus{}
+	for _, d := range resp.Dependencies {
+		byName[d.Name] = d
+	}
+	if byName["database"].LatencyMs != 2 || !byName["database"].Critical {
+		t.Errorf("unexpected database entry: %+v", byName["database"])
+	}
+	if byName["groq"].Status != "skipped" || byName["voyage"].Status != "skipped" {
+		t.Errorf("expected unconfigured deps to be skipped, got groq=%s voyage=%s", byName["groq"].Status, byName["voyage"].Status)
+	}
+}
+
+func TestHealthReady_NonCriticalOutageIsDegraded(t *testing.T) {
+	checker := healthyChecker()
+	checker.results["voyage"] = fakeCheckResult{status: models.ServiceStatusOutage, err: errors.New("dial tcp: timeout")}
+
+	w, resp := serveReady(t, checker)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 for degraded, got %d", w.Code)
+	}
+	if resp.Status != "degraded" {
+		t.Errorf("expected degraded, got %s", resp.Status)
+	}
+}
+
+func TestHealthReady_CriticalOutageIsDown(t *testing.T) {
+	checker := healthyChecker()
+	checker.results["database"] = fakeCheckResult{status: models.ServiceStatusOutage, err: errors.New("connection refused")}
+
+	w, resp := serveReady(t, checker)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+	if resp.Status != "down" || resp.Database != "unavailable" {
+		t.Errorf("expected down/unavailable, got %s/%s", resp.Status, resp.Database)
+	}
+	if resp.Dependencies[0].Error != "connection refused" {
+		t.Errorf("expected database error to be reported, got %q", resp.Dependencies[0].Error)
+	}
+}
+
+func TestHealthReady_CachesReport(t *testing.T) {
+	checker := healthyChecker()
+	handler := healthReadyHandler(checker, nil)
+
+	for i := 0; i < 3; i++ {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	}
+
+	if got := int(checker.calls.Load()); got != len(readinessDependencies) {
+		t.Errorf("expected one round of %d checks, got %d calls", len(readinessDependencies), got)
+	}
+}
+
+func TestHealthReady_NilChecker(t *testing.T) {
+	w := httptest.NewRecorder()
+	healthReadyHandler(nil, nil)(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", w.Code)
+	}
+}
+
+// fakeSchemaChecker returns a canned schema status.
+type fakeSchemaChecker struct {
+	status models.SchemaStatus
+	err    error
+}
+
+func (f fakeSchemaChecker) Status(ctx context.Context) (models.SchemaStatus, error) {
+	return f.status, f.err
+}
+
+func TestHealthReady_SchemaDrift(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     fakeSchemaChecker
+		wantCode   int
+		wantStatus string
+	}{
+		{"in sync", fakeSchemaChecker{status: models.SchemaStatus{CurrentVersion: 105, ExpectedVersion: 105}}, http.StatusOK, "ready"},
+		{"pending migrations", fakeSchemaChecker{status: models.SchemaStatus{CurrentVersion: 103, ExpectedVersion: 105, Pending: 2}}, http.StatusServiceUnavailable, "down"},
+		{"dirty", fakeSchemaChecker{status: models.SchemaStatus{CurrentVersion: 105, ExpectedVersion: 105, Dirty: true}}, http.StatusServiceUnavailable, "down"},
+		{"database ahead", fakeSchemaChecker{status: models.SchemaStatus{CurrentVersion: 106, ExpectedVersion: 105}}, http.StatusOK, "degraded"},
+		{"version unreadable", fakeSchemaChecker{err: errors.New("connection refused")}, http.StatusServiceUnavailable, "down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			healthReadyHandler(healthyChecker(), tt.schema)(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			var resp ReadinessResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if w.Code != tt.wantCode || resp.Status != tt.wantStatus {
+				t.Errorf("expected %d/%s, got %d/%s", tt.wantCode, tt.wantStatus, w.Code, resp.Status)
+			}
+			last := resp.Dependencies[len(resp.Dependencies)-1]
+			if last.Name != "schema" || !last.Critical {
+				t.Errorf("expected critical schema dependency last, got %+v", last)
+			}
+			if tt.schema.err == nil && (resp.Schema == nil || resp.Schema.CurrentVersion != tt.schema.status.CurrentVersion) {
+				t.Errorf("expected schema versions in response, got %+v", resp.Schema)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// MentionNotifierAdapter adapts services.NotificationService to
+// handlers.MentionNotifier: it parses @usernames out of new content and
+// notifies each mentioned user.
+type MentionNotifierAdapter struct {
+	svc   *services.NotificationService
+	users usernameLookup
+}
+
+// NewMentionNotifier sends mention notifications through create, looking
+// users up with findByUsername. Users that blocks reports as blocking or
+// muting the author are skipped.
+func NewMentionNotifier(
+	create func(ctx context.Context, n *models.Notification) (*models.Notification, error),
+	findByUsername func(ctx context.Context, username string) (*models.User, error),
+	blocks services.BlockChecker,
+) *MentionNotifierAdapter {
+	svc := services.NewNotificationService(&notifRepoForService{create: create}, nil, nil, nil, nil)
+	svc.SetBlockChecker(blocks)
+	return &MentionNotifierAdapter{svc: svc, users: findByUsername}
+}
+
+// NotifyMentions notifies the users @mentioned in content, linking to link.
+func (a *MentionNotifierAdapter) NotifyMentions(ctx context.Context, content string, authorType models.AuthorType, authorID, link string) error {
+	usernames := services.ParseMentions(content)
+	if len(usernames) == 0 {
+		return nil
+	}
+	return a.svc.NotifyOnMention(ctx, &services.MentionEvent{
+		MentionedUsernames: usernames,
+		MentionerID:        authorID,
+		MentionerType:      string(authorType),
+		Link:               link,
+	}, a.users)
+}
+
+// usernameLookup adapts a find-by-username function to services.UserLookup,
+// whose FindByID NotifyOnMention calls with a username.
+type usernameLookup func(ctx context.Context, username string) (*models.User, error)
+
+func (f usernameLookup) FindByID(ctx context.Context, username string) (*services.UserInfo, error) {
+	user, err := f(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return &services.UserInfo{ID: user.ID, Username: user.Username}, nil
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/api/handlers"
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db/memdb"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// recordedNotifications collects the notifications a MentionNotifier creates.
+type recordedNotifications struct {
+	mu   sync.Mutex
+	list []models.Notification
+}
+
+func (r *recordedNotifications) create(ctx context.Context, n *models.Notification) (*models.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.list = append(r.list, *n)
+	return n, nil
+}
+
+func (r *recordedNotifications) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.list)
+}
+
+// staticBlocks answers BlockMode from a fixed blocker -> blocked -> mode table.
+type staticBlocks map[string]map[string]string
+
+func (b staticBlocks) BlockMode(ctx context.Context, blockerType, blockerID, blockedType, blockedID string) (string, error) {
+	return b[blockerType+":"+blockerID][blockedType+":"+blockedID], nil
+}
+
+func TestMentionNotifications_SkipBlockedAndMutedAuthors(t *testing.T) {
+	ctx := context.Background()
+	store := memdb.NewStore()
+	alice, err := store.Users().Create(ctx, &models.User{Username: "alice", DisplayName: "Alice", Email: "alice@example.com", AuthProvider: "github", AuthProviderID: "1"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	bob, err := store.Users().Create(ctx, &models.User{Username: "bob", DisplayName: "Bob", Email: "bob@example.com", AuthProvider: "github", AuthProviderID: "2"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	question, err := store.Questions().CreateQuestion(ctx, &models.Post{
+		Type: models.PostTypeQuestion, Title: "How do I close a pgx pool?", Description: "Connections leak on shutdown.",
+		PostedByType: models.AuthorTypeHuman, PostedByID: alice.ID, Status: models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("create question: %v", err)
+	}
+
+	post := func(handler http.HandlerFunc, params map[string]string, content string) {
+		t.Helper()
+		body, _ := json.Marshal(map[string]string{"content": content})
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		rctx := chi.NewRouteContext()
+		for k, v := range params {
+			rctx.URLParams.Add(k, v)
+		}
+		req = req.WithContext(auth.ContextWithClaims(context.WithValue(req.Context(), chi.RouteCtxKey, rctx), &auth.Claims{UserID: bob.ID, Role: "user"}))
+		w := httptest.NewRecorder()
+		handler(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	for _, mode := range []string{"", string(models.BlockModeMute), string(models.BlockModeBlock)} {
+		t.Run("mode="+mode, func(t *testing.T) {
+			notifications := &recordedNotifications{}
+			blocks := staticBlocks{"human:" + alice.ID: {"human:" + bob.ID: mode}}
+			notifier := NewMentionNotifier(notifications.create, store.Users().FindByUsername, blocks)
+
+			questions := handlers.NewQuestionsHandler(store.Questions())
+			questions.SetMentionNotifier(notifier)
+			comments := handlers.NewCommentsHandler(store.Comments())
+			comments.SetMentionNotifier(notifier)
+
+			post(questions.CreateAnswer, map[string]string{"id": question.ID}, "@alice call pool.Close() in your shutdown hook.")
+			answers, _, err := store.Questions().ListAnswers(ctx, question.ID, models.AnswerListOptions{})
+			if err != nil || len(answers) == 0 {
+				t.Fatalf("list answers: %v", err)
+			}
+			post(comments.Create, map[string]string{"target_type": "answer", "id": answers[0].ID}, "@alice see the pgxpool docs too.")
+
+			// Wait for async mention notifications to complete
+			time.Sleep(200 * time.Millisecond)
+
+			want := 2
+			if mode != "" {
+				want = 0
+			}
+			if got := notifications.count(); got != want {
+				t.Errorf("expected %d mention notifications, got %d", want, got)
+			}
+		})
+	}
+}
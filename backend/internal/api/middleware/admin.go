@@ -2,9 +2,9 @@
 package middleware
 
 import (
-	"encoding/json"
 	"net/http"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 )
 
@@ -15,13 +15,13 @@ func AdminOnly(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		claims := auth.ClaimsFromContext(r.Context())
 		if claims == nil {
-			writeAdminError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			apierror.Write(w, apierror.Unauthorized, "authentication required")
 			return
 		}
 
 		// Check for admin or super_admin role
 		if !IsAdminOrAbove(claims.Role) {
-			writeAdminError(w, http.StatusForbidden, "FORBIDDEN", "admin access required")
+			apierror.Write(w, apierror.Forbidden, "admin access required")
 			return
 		}
 
@@ -34,18 +34,3 @@ func AdminOnly(next http.Handler) http.Handler {
 func IsAdminOrAbove(role string) bool {
 	return role == "admin" || role == "super_admin"
 }
-
-// writeAdminError writes a JSON error response with the given status code.
-func writeAdminError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	response := map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	}
-
-	json.NewEncoder(w).Encode(response)
-}
@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// auditWriteTimeout bounds how long a request waits on its audit insert.
+const auditWriteTimeout = 5 * time.Second
+
+// auditTargetParams are the URL params checked, in order, for the target ID.
+// Routes without any of them fall back to their first URL param.
+var auditTargetParams = []string{"id", "target_id", "slug"}
+
+// AuditStore persists audit log entries.
+type AuditStore interface {
+	Create(ctx context.Context, entry *models.AuditLog) error
+}
+
+// AuditRecorder writes an audit entry for every authenticated write.
+type AuditRecorder struct {
+	store AuditStore
+}
+
+// NewAuditRecorder creates an AuditRecorder. A nil store disables auditing.
+func NewAuditRecorder(store AuditStore) *AuditRecorder {
+	return &AuditRecorder{store: store}
+}
+
+// Middleware records POST, PUT, PATCH and DELETE requests made by an authenticated
+// human, agent, or the admin API key. It must run after an auth middleware (or on
+// admin routes). Only the names of the JSON body fields are stored, never their
+// values. The entry is written after the handler returns, whatever its status.
+func (a *AuditRecorder) Middleware(next http.Handler) http.Handler {
+	if a.store == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isAuditedMethod(r.Method) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		actorType, actorID := auditActor(r)
+		if actorID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		fields := auditBodyFields(r)
+		rec := &usageStatusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		route := r.URL.Path
+		rctx := chi.RouteContext(r.Context())
+		if rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				route = pattern
+			}
+		}
+
+		entry := &models.AuditLog{
+			ActorType:  actorType,
+			ActorID:    actorID,
+			Action:     r.Method,
+			Route:      route,
+			Path:       r.URL.Path,
+			TargetType: auditTargetType(route),
+			TargetID:   auditTargetID(rctx),
+			StatusCode: rec.status,
+			IPAddress:  auditClientIP(r),
+			RequestID:  w.Header().Get("X-Request-ID"),
+		}
+		if len(fields) > 0 {
+			entry.Details = map[string]interface{}{"fields": fields}
+		}
+
+		// The client may already be gone; the audit entry is still written
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), auditWriteTimeout)
+		defer cancel()
+		if err := a.store.Create(ctx, entry); err != nil {
+			log.Printf("Audit recorder: failed to record %s %s by %s %s: %v", r.Method, route, actorType, actorID, err)
+		}
+	})
+}
+
+// isAuditedMethod reports whether requests with this method change state.
+func isAuditedMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// auditActor returns who made the request: the authenticated agent or human,
+// else the admin API key when it's valid.
+func auditActor(r *http.Request) (string, string) {
+	if actorType, actorID := usagePrincipal(r); actorID != "" {
+		return actorType, actorID
+	}
+
+	adminKey := os.Getenv("ADMIN_API_KEY")
+	provided := r.Header.Get("X-Admin-API-Key")
+	if adminKey != "" && provided != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(adminKey)) == 1 {
+		return models.AuditActorAdmin, models.AuditActorAdmin
+	}
+	return "", ""
+}
+
+// auditBodyFields returns the sorted top-level field names of a JSON object body
+// and restores the body for the handler. Non-JSON bodies yield no fields.
+func auditBodyFields(r *http.Request) []string {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	// Replay what was read; on error the handler sees the same error from the original body
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	if err != nil || len(body) == 0 {
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(body, &obj) != nil {
+		return nil
+	}
+
+	fields := make([]string, 0, len(obj))
+	for k := range obj {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// auditTargetType returns the resource named by the first static route segment,
+// skipping the /v1 and /admin prefixes (e.g. "posts" for /v1/posts/{id}/vote).
+func auditTargetType(route string) string {
+	for _, seg := range strings.Split(strings.Trim(route, "/"), "/") {
+		if seg == "" || seg == "v1" || seg == "admin" || strings.HasPrefix(seg, "{") {
+			continue
+		}
+		return seg
+	}
+	return ""
+}
+
+// auditTargetID returns the ID of the resource the request acted on.
+func auditTargetID(rctx *chi.Context) string {
+	if rctx == nil {
+		return ""
+	}
+	for _, key := range auditTargetParams {
+		if v := rctx.URLParam(key); v != "" {
+			return v
+		}
+	}
+	for i, key := range rctx.URLParams.Keys {
+		if key != "*" && rctx.URLParams.Values[i] != "" {
+			return rctx.URLParams.Values[i]
+		}
+	}
+	return ""
+}
+
+// auditClientIP returns the client IP (RealIP has already applied proxy headers),
+// or "" if it isn't a valid address.
+func auditClientIP(r *http.Request) string {
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if net.ParseIP(host) == nil {
+		return ""
+	}
+	return host
+}
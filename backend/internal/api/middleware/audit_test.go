@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockAuditStore implements AuditStore for testing.
+type mockAuditStore struct {
+	mu      sync.Mutex
+	entries []models.AuditLog
+	fail    bool
+}
+
+func (m *mockAuditStore) Create(ctx context.Context, entry *models.AuditLog) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.fail {
+		return errors.New("db down")
+	}
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func newAuditTestRouter(a *AuditRecorder, gotBody *string) http.Handler {
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-ID", "req-123")
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Use(a.Middleware)
+	r.Patch("/v1/posts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		*gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Get("/v1/posts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Delete("/admin/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestAuditRecorder_RecordsAuthenticatedWrite(t *testing.T) {
+	store := &mockAuditStore{}
+	var gotBody string
+	router := newAuditTestRouter(NewAuditRecorder(store), &gotBody)
+
+	body := `{"title":"secret title","description":"x","tags":["go"]}`
+	req := httptest.NewRequest(http.MethodPatch, "/v1/posts/post-1", strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.7:5555"
+	req = req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{UserID: "user-1"}))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if gotBody != body {
+		t.Errorf("handler body = %q, want original body", gotBody)
+	}
+	if len(store.entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(store.entries))
+	}
+	e := store.entries[0]
+	if e.ActorType != "human" || e.ActorID != "user-1" || e.Action != "PATCH" {
+		t.Errorf("unexpected actor/action: %+v", e)
+	}
+	if e.Route != "/v1/posts/{id}" || e.Path != "/v1/posts/post-1" || e.TargetType != "posts" || e.TargetID != "post-1" {
+		t.Errorf("unexpected route/target: %+v", e)
+	}
+	if e.StatusCode != http.StatusOK || e.IPAddress != "192.0.2.7" || e.RequestID != "req-123" {
+		t.Errorf("unexpected status/ip/request id: %+v", e)
+	}
+
+	details, _ := json.Marshal(e.Details)
+	if string(details) != `{"fields":["description","tags","title"]}` {
+		t.Errorf("Details = %s, want sorted field names only", details)
+	}
+	if strings.Contains(string(details), "secret") {
+		t.Error("audit details leaked a field value")
+	}
+}
+
+func TestAuditRecorder_SkipsReadsAndAnonymous(t *testing.T) {
+	store := &mockAuditStore{}
+	var gotBody string
+	router := newAuditTestRouter(NewAuditRecorder(store), &gotBody)
+
+	// Authenticated read
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/post-1", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_a"}))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Anonymous write
+	req = httptest.NewRequest(http.MethodPatch, "/v1/posts/post-1", strings.NewReader(`{}`))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(store.entries) != 0 {
+		t.Errorf("expected no audit entries, got %+v", store.entries)
+	}
+}
+
+func TestAuditRecorder_AdminAPIKey(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "test-admin-key")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	store := &mockAuditStore{}
+	var gotBody string
+	router := newAuditTestRouter(NewAuditRecorder(store), &gotBody)
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/users/user-9", nil)
+	req.Header.Set("X-Admin-API-Key", "wrong-key")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodDelete, "/admin/users/user-9", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if len(store.entries) != 1 {
+		t.Fatalf("expected 1 audit entry, got %d", len(store.entries))
+	}
+	e := store.entries[0]
+	if e.ActorType != "admin" || e.ActorID != "admin" || e.TargetType != "users" || e.TargetID != "user-9" || e.StatusCode != http.StatusNoContent {
+		t.Errorf("unexpected admin entry: %+v", e)
+	}
+}
+
+func TestAuditRecorder_StoreFailureDoesNotFailRequest(t *testing.T) {
+	store := &mockAuditStore{fail: true}
+	var gotBody string
+	router := newAuditTestRouter(NewAuditRecorder(store), &gotBody)
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/posts/post-1", strings.NewReader(`{"title":"t"}`))
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_a"}))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestAuditTargetType(t *testing.T) {
+	tests := map[string]string{
+		"/v1/posts/{id}/vote":         "posts",
+		"/v1/admin/users/{id}/status": "users",
+		"/admin/incidents":            "incidents",
+		"/v1/rooms/{slug}":            "rooms",
+		"/":                           "",
+	}
+	for route, want := range tests {
+		if got := auditTargetType(route); got != want {
+			t.Errorf("auditTargetType(%q) = %q, want %q", route, got, want)
+		}
+	}
+}
@@ -2,10 +2,10 @@ package middleware
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"strings"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/fcavalcantirj/solvr/internal/token"
@@ -93,9 +93,5 @@ func BearerGuard(roomRepo *db.RoomRepository, agentTokenRepo *db.RoomAgentTokenR
 
 // bearerGuardUnauthorized writes a 401 JSON error.
 func bearerGuardUnauthorized(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	_ = json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]string{"code": "UNAUTHORIZED", "message": message},
-	})
+	apierror.Write(w, apierror.Unauthorized, message)
 }
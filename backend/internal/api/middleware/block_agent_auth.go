@@ -1,9 +1,10 @@
 package middleware
 
 import (
-	"encoding/json"
 	"net/http"
 	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 )
 
 // BlockAgentAPIKeys is middleware that prevents AI agents from accessing human registration endpoints.
@@ -38,16 +39,9 @@ func BlockAgentAPIKeys(next http.Handler) http.Handler {
 		// Check if token starts with "solvr_" (case-insensitive)
 		if strings.HasPrefix(strings.ToLower(token), "solvr_") {
 			// This is an agent API key - block the request
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusForbidden)
-
-			response := map[string]interface{}{
-				"code":    "FORBIDDEN",
-				"message": "Agents cannot register as humans. Use POST /v1/agents/register instead.",
-				"details": "AI agents must use the agent registration endpoint, not human authentication endpoints.",
-			}
-
-			json.NewEncoder(w).Encode(response)
+			apierror.WriteDetails(w, apierror.Forbidden,
+				"Agents cannot register as humans. Use POST /v1/agents/register instead.",
+				"AI agents must use the agent registration endpoint, not human authentication endpoints.")
 			return
 		}
 
@@ -1,9 +1,10 @@
 package middleware
 
 import (
-	"encoding/json"
 	"net/http"
 	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 )
 
 // BodyLimit returns a middleware that limits the size of request bodies.
@@ -48,12 +49,5 @@ func BodyLimit(maxBytes int64) func(http.Handler) http.Handler {
 
 // writeBodyLimitError writes a 413 Payload Too Large error response.
 func writeBodyLimitError(w http.ResponseWriter) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusRequestEntityTooLarge)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    "PAYLOAD_TOO_LARGE",
-			"message": "request body exceeds maximum allowed size",
-		},
-	})
+	apierror.Write(w, apierror.PayloadTooLarge, "request body exceeds maximum allowed size")
 }
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// DefaultCompressionLevel is the brotli/gzip/deflate level: a good size/CPU trade-off
+// for JSON post lists and search results.
+const DefaultCompressionLevel = 5
+
+// compressibleTypes are the response types worth compressing. text/event-stream
+// is deliberately absent: compressing a stream would hold events in the
+// encoder's buffer.
+var compressibleTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/x-ndjson",
+	"application/xml",
+	"application/atom+xml",
+	"application/rss+xml",
+	"text/html",
+	"text/plain",
+	"text/markdown",
+	"text/css",
+	"text/javascript",
+	"image/svg+xml",
+}
+
+// Compress returns middleware that brotli-, gzip- or deflate-encodes responses
+// the client accepts (Accept-Encoding), preferring br, then gzip. Only compressibleTypes
+// are encoded, so SSE and binary downloads pass through untouched, and
+// requests that ask for an event stream (Accept: text/event-stream, as the
+// room streams and MCP do) skip it outright. A level of 0 or less disables it.
+func Compress(level int) func(http.Handler) http.Handler {
+	if level <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	compressor := middleware.NewCompressor(level, compressibleTypes...)
+	compressor.SetEncoder("br", func(w io.Writer, level int) io.Writer {
+		return brotli.NewWriterLevel(w, level)
+	})
+	return func(next http.Handler) http.Handler {
+		compressed := compressor.Handler(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			compressed.ServeHTTP(&etagWeakener{ResponseWriter: w}, r)
+		})
+	}
+}
+
+// etagWeakener marks a strong ETag weak when the response goes out
+// content-encoded: the encoded bytes differ from the ones the tag was computed
+// for, so it may only claim semantic equivalence (RFC 9110 8.8.3).
+type etagWeakener struct {
+	http.ResponseWriter
+}
+
+func (w *etagWeakener) WriteHeader(status int) {
+	h := w.Header()
+	if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") && h.Get("Content-Encoding") != "" {
+		h.Set("ETag", "W/"+etag)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *etagWeakener) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *etagWeakener) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hj, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return hj.Hijack()
+	}
+	return nil, nil, errors.New("response writer does not support hijacking")
+}
+
+func (w *etagWeakener) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestCompress_GzipsJSON(t *testing.T) {
+	body := `{"data":[` + strings.Repeat(`{"title":"a post"},`, 100) + `{}]}`
+	handler := Compress(DefaultCompressionLevel)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected gzip, got %q", rr.Header().Get("Content-Encoding"))
+	}
+	if !strings.Contains(rr.Header().Get("Vary"), "Accept-Encoding") {
+		t.Error("expected Vary: Accept-Encoding")
+	}
+	if rr.Body.Len() >= len(body) {
+		t.Errorf("expected compressed body smaller than %d bytes, got %d", len(body), rr.Body.Len())
+	}
+	zr, err := gzip.NewReader(rr.Body)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	got, _ := io.ReadAll(zr)
+	if string(got) != body {
+		t.Error("decompressed body does not match")
+	}
+}
+
+func TestCompress_PrefersBrotli(t *testing.T) {
+	body := `{"data":[` + strings.Repeat(`{"title":"a post"},`, 100) + `{}]}`
+	handler := Compress(DefaultCompressionLevel)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Header().Get("Content-Encoding") != "br" {
+		t.Fatalf("expected br, got %q", rr.Header().Get("Content-Encoding"))
+	}
+	got, _ := io.ReadAll(brotli.NewReader(rr.Body))
+	if string(got) != body {
+		t.Error("decompressed body does not match")
+	}
+}
+
+func TestCompress_PassesThrough(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		accept         string
+		contentType    string
+	}{
+		{name: "client without compression", acceptEncoding: "", contentType: "application/json"},
+		{name: "event stream response", acceptEncoding: "gzip", contentType: "text/event-stream"},
+		{name: "event stream request", acceptEncoding: "gzip", accept: "application/json, text/event-stream", contentType: "application/json"},
+		{name: "binary download", acceptEncoding: "gzip", contentType: "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Compress(DefaultCompressionLevel)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte("data: hello\n\n"))
+				if f, ok := w.(http.Flusher); ok {
+					f.Flush()
+				}
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/v1/rooms/general/stream", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			req.Header.Set("Accept", tt.accept)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if enc := rr.Header().Get("Content-Encoding"); enc != "" {
+				t.Errorf("expected no Content-Encoding, got %q", enc)
+			}
+			if rr.Body.String() != "data: hello\n\n" {
+				t.Errorf("expected body untouched, got %q", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompress_Disabled(t *testing.T) {
+	handler := Compress(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Header().Get("Content-Encoding") != "" {
+		t.Error("expected level 0 to disable compression")
+	}
+}
+
+func TestCompress_WeakensETagWhenEncoding(t *testing.T) {
+	body := `{"data":[` + strings.Repeat(`{"title":"a post"},`, 100) + `{}]}`
+	handler := Compress(DefaultCompressionLevel)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"abc123"`)
+		_, _ = w.Write([]byte(body))
+	}))
+
+	for encoding, want := range map[string]string{"gzip": `W/"abc123"`, "": `"abc123"`} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/posts/p1", nil)
+		req.Header.Set("Accept-Encoding", encoding)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if got := rr.Header().Get("ETag"); got != want {
+			t.Errorf("Accept-Encoding %q: ETag = %s, want %s", encoding, got, want)
+		}
+	}
+}
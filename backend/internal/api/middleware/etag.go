@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+)
+
+// ETag adds conditional GET support to JSON read endpoints.
+// The response is buffered; on a 200 the handler's own ETag header is kept
+// (e.g. the representation tag set by GET /v1/posts/{id}), otherwise a weak
+// ETag is derived from the body. A matching If-None-Match yields 304 with no body.
+// Responses vary by caller (vote state, family posts), so Vary: Authorization
+// keeps shared caches from serving one caller's copy to another.
+// Do not mount on streaming (SSE) routes.
+func ETag(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Authorization")
+
+		buf := &etagBuffer{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(buf, r)
+
+		if buf.status != http.StatusOK {
+			w.WriteHeader(buf.status)
+			_, _ = w.Write(buf.body.Bytes())
+			return
+		}
+
+		etag := w.Header().Get("ETag")
+		if etag == "" {
+			etag = WeakETag(buf.body.Bytes())
+			w.Header().Set("ETag", etag)
+		}
+
+		if ETagMatches(r.Header.Get("If-None-Match"), etag, false) {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.body.Bytes())
+	})
+}
+
+// WeakETag returns a weak validator derived from a response body.
+func WeakETag(body []byte) string {
+	h := fnv.New64a()
+	_, _ = h.Write(body)
+	return fmt.Sprintf(`W/"%x"`, h.Sum64())
+}
+
+// ETagMatches reports whether a comma-separated If-Match / If-None-Match header
+// value matches etag. "*" matches any current representation.
+// strong selects RFC 9110 strong comparison (If-Match): weak tags never match.
+// Otherwise weak comparison is used (If-None-Match): the W/ prefix is ignored.
+func ETagMatches(header, etag string, strong bool) bool {
+	if header == "" || etag == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	if strong && strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	current := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if strong && strings.HasPrefix(candidate, "W/") {
+			continue
+		}
+		if strings.TrimPrefix(candidate, "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+// etagBuffer holds the status and body until the ETag can be computed.
+// Headers are written straight through to the underlying ResponseWriter's map.
+type etagBuffer struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rw *etagBuffer) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.status = code
+		rw.wroteHeader = true
+	}
+}
+
+func (rw *etagBuffer) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	return rw.body.Write(b)
+}
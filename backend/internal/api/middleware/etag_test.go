@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func etagTestHandler(etag string, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":[1,2,3]}`))
+	})
+}
+
+func TestETag_SetsWeakETagFromBody(t *testing.T) {
+	rr := httptest.NewRecorder()
+	ETag(etagTestHandler("", http.StatusOK)).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/posts", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if got, want := rr.Header().Get("ETag"), WeakETag([]byte(`{"data":[1,2,3]}`)); got != want {
+		t.Errorf("expected ETag %s, got %s", want, got)
+	}
+	if rr.Body.String() != `{"data":[1,2,3]}` {
+		t.Errorf("unexpected body %q", rr.Body.String())
+	}
+	if rr.Header().Get("Vary") != "Authorization" {
+		t.Errorf("expected Vary: Authorization, got %q", rr.Header().Get("Vary"))
+	}
+}
+
+func TestETag_NotModified(t *testing.T) {
+	etag := WeakETag([]byte(`{"data":[1,2,3]}`))
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	rr := httptest.NewRecorder()
+
+	ETag(etagTestHandler("", http.StatusOK)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", rr.Code)
+	}
+	if rr.Body.Len() != 0 {
+		t.Errorf("expected empty body on 304, got %q", rr.Body.String())
+	}
+	if rr.Header().Get("ETag") != etag {
+		t.Errorf("expected ETag on 304, got %s", rr.Header().Get("ETag"))
+	}
+}
+
+func TestETag_KeepsHandlerETag(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/p1", nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	rr := httptest.NewRecorder()
+
+	ETag(etagTestHandler(`"v1"`, http.StatusOK)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 for handler-provided ETag, got %d", rr.Code)
+	}
+}
+
+func TestETag_PassesThroughErrors(t *testing.T) {
+	rr := httptest.NewRecorder()
+	ETag(etagTestHandler("", http.StatusNotFound)).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/posts/missing", nil))
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rr.Code)
+	}
+	if rr.Header().Get("ETag") != "" {
+		t.Error("expected no ETag on error responses")
+	}
+	if rr.Body.Len() == 0 {
+		t.Error("expected error body to be written")
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		etag   string
+		strong bool
+		want   bool
+	}{
+		{name: "empty header", header: "", etag: `"a"`, want: false},
+		{name: "exact", header: `"a"`, etag: `"a"`, strong: true, want: true},
+		{name: "list", header: `"b", "a"`, etag: `"a"`, strong: true, want: true},
+		{name: "wildcard", header: "*", etag: `"a"`, strong: true, want: true},
+		{name: "mismatch", header: `"b"`, etag: `"a"`, strong: true, want: false},
+		{name: "weak comparison ignores prefix", header: `W/"a"`, etag: `"a"`, strong: false, want: true},
+		{name: "strong comparison rejects weak", header: `W/"a"`, etag: `"a"`, strong: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ETagMatches(tt.header, tt.etag, tt.strong); got != tt.want {
+				t.Errorf("ETagMatches(%q, %q, %v) = %v, want %v", tt.header, tt.etag, tt.strong, got, tt.want)
+			}
+		})
+	}
+}
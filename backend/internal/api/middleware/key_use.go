@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+const (
+	// keyUseRecordInterval is how often the same API key and IP pair is recorded again.
+	keyUseRecordInterval = 24 * time.Hour
+
+	// keyUseMaxTracked bounds the remembered pairs; stale ones are dropped beyond it.
+	keyUseMaxTracked = 50000
+)
+
+// SecurityEventRecorder checks and stores a security event.
+type SecurityEventRecorder interface {
+	Record(ctx context.Context, ev *models.SecurityEvent) error
+	Country(header http.Header) string
+}
+
+// KeyUseMonitor records the first use of each API key from each IP as a
+// security event, so keys used from new countries or from many IPs raise an
+// alert. Pairs already recorded in the last day are remembered in memory and
+// skipped, so the request path only waits on the database for a new IP.
+type KeyUseMonitor struct {
+	recorder SecurityEventRecorder
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+
+	now func() time.Time
+}
+
+// NewKeyUseMonitor creates a KeyUseMonitor. A nil recorder disables it.
+func NewKeyUseMonitor(recorder SecurityEventRecorder) *KeyUseMonitor {
+	return &KeyUseMonitor{recorder: recorder, seen: make(map[string]time.Time), now: time.Now}
+}
+
+// Middleware records requests authenticated with an agent or user API key.
+// It must run after an auth middleware; browser sessions are recorded at sign-in.
+func (k *KeyUseMonitor) Middleware(next http.Handler) http.Handler {
+	if k.recorder == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+
+		p := auth.PrincipalFromContext(r.Context())
+		if p == nil || (!p.IsAgent() && p.APIKeyID == "") {
+			return
+		}
+		ip := auditClientIP(r)
+		if !k.firstUse(string(p.Type) + ":" + p.ID + "|" + ip) {
+			return
+		}
+
+		ev := &models.SecurityEvent{
+			PrincipalType: string(p.Type),
+			PrincipalID:   p.ID,
+			Method:        models.SecurityMethodAPIKey,
+			IPAddress:     ip,
+			UserAgent:     r.UserAgent(),
+			Country:       k.recorder.Country(r.Header),
+		}
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), auditWriteTimeout)
+		defer cancel()
+		if err := k.recorder.Record(ctx, ev); err != nil {
+			log.Printf("Key use monitor: failed to record %s %s from %s: %v", p.Type, p.ID, ip, err)
+		}
+	})
+}
+
+// firstUse reports whether key wasn't recorded in the last keyUseRecordInterval,
+// and remembers it.
+func (k *KeyUseMonitor) firstUse(key string) bool {
+	now := k.now()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if at, ok := k.seen[key]; ok && now.Sub(at) < keyUseRecordInterval {
+		return false
+	}
+	if len(k.seen) >= keyUseMaxTracked {
+		for key, at := range k.seen {
+			if now.Sub(at) >= keyUseRecordInterval {
+				delete(k.seen, key)
+			}
+		}
+	}
+	k.seen[key] = now
+	return true
+}
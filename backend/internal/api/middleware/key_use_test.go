@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockSecurityEventRecorder struct {
+	recorded []*models.SecurityEvent
+}
+
+func (m *mockSecurityEventRecorder) Record(ctx context.Context, ev *models.SecurityEvent) error {
+	m.recorded = append(m.recorded, ev)
+	return nil
+}
+
+func (m *mockSecurityEventRecorder) Country(header http.Header) string {
+	return header.Get("CF-IPCountry")
+}
+
+func TestKeyUseMonitor_RecordsFirstUsePerIP(t *testing.T) {
+	recorder := &mockSecurityEventRecorder{}
+	k := NewKeyUseMonitor(recorder)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	k.now = func() time.Time { return now }
+	handler := k.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(ctx func(context.Context) context.Context, ip string) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/search?q=go", nil)
+		req.RemoteAddr = ip + ":4000"
+		req.Header.Set("CF-IPCountry", "BR")
+		req = req.WithContext(ctx(req.Context()))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	asAgent := func(ctx context.Context) context.Context {
+		return auth.ContextWithAgent(ctx, &models.Agent{ID: "agent_keyuse"})
+	}
+	asBrowser := func(ctx context.Context) context.Context {
+		return auth.ContextWithClaims(ctx, &auth.Claims{UserID: "user-1", Role: "user"})
+	}
+
+	send(asAgent, "203.0.113.1")
+	send(asAgent, "203.0.113.1")
+	send(asAgent, "203.0.113.2")
+	send(asBrowser, "203.0.113.3")
+	if len(recorder.recorded) != 2 {
+		t.Fatalf("expected one event per new agent IP and none for browser sessions, got %d", len(recorder.recorded))
+	}
+	ev := recorder.recorded[0]
+	if ev.PrincipalType != "agent" || ev.PrincipalID != "agent_keyuse" || ev.Method != models.SecurityMethodAPIKey || ev.IPAddress != "203.0.113.1" || ev.Country != "BR" {
+		t.Errorf("unexpected event %+v", ev)
+	}
+
+	// The same pair is recorded again a day later.
+	now = now.Add(keyUseRecordInterval)
+	send(asAgent, "203.0.113.1")
+	if len(recorder.recorded) != 3 {
+		t.Errorf("expected the pair to be recorded again after %s, got %d events", keyUseRecordInterval, len(recorder.recorded))
+	}
+}
@@ -3,14 +3,22 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // sensitiveParams lists URL query parameter names that contain secrets.
@@ -35,20 +43,45 @@ const bearerPrefix = "Bearer "
 // jwtRegex matches JWT tokens (three base64 segments separated by dots).
 var jwtRegex = regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`)
 
-// LogEntry represents a structured log entry for HTTP requests.
-type LogEntry struct {
-	Level       string  `json:"level"`
-	Timestamp   string  `json:"timestamp"`
-	Message     string  `json:"message"`
-	RequestID   string  `json:"request_id,omitempty"`
-	Method      string  `json:"method"`
-	Path        string  `json:"path"`
-	Status      int     `json:"status"`
-	DurationMS  float64 `json:"duration_ms"`
-	RemoteAddr  string  `json:"remote_addr,omitempty"`
-	Error       string  `json:"error,omitempty"`        // Error message for 4xx/5xx responses
-	ErrorCode   string  `json:"error_code,omitempty"`   // Error code for 4xx/5xx responses
-	RequestBody string  `json:"request_body,omitempty"` // Request body for failed non-GET requests (redacted)
+// DefaultSlowRequestThreshold is the duration above which a request is always logged, even when sampled out.
+const DefaultSlowRequestThreshold = time.Second
+
+// RequestLogConfig configures the request logger.
+type RequestLogConfig struct {
+	// Logger receives one record per request. Defaults to a JSON logger writing to the
+	// standard log output with PII scrubbing (see NewScrubbingJSONHandler).
+	Logger *slog.Logger
+
+	// SampleRates maps a route pattern (e.g. "/v1/feed") to the fraction of successful
+	// requests to log, between 0 and 1. Routes not listed are always logged.
+	// 4xx/5xx responses and slow requests are logged regardless of sampling.
+	SampleRates map[string]float64
+
+	// SlowThreshold overrides DefaultSlowRequestThreshold.
+	SlowThreshold time.Duration
+}
+
+// requestLogFieldsKey is the context key for per-request log fields filled in downstream.
+type requestLogFieldsKey struct{}
+
+// requestLogFields holds values only known after auth runs further down the chain.
+type requestLogFields struct {
+	mu            sync.Mutex
+	principalType string
+	principalID   string
+}
+
+// SetLogPrincipal records the authenticated principal on the request log entry.
+// It is a no-op when the request is not wrapped by the request logger.
+func SetLogPrincipal(ctx context.Context, principalType, principalID string) {
+	fields, ok := ctx.Value(requestLogFieldsKey{}).(*requestLogFields)
+	if !ok {
+		return
+	}
+	fields.mu.Lock()
+	fields.principalType = principalType
+	fields.principalID = principalID
+	fields.mu.Unlock()
 }
 
 // responseWriter wraps http.ResponseWriter to capture the status code and body for error responses.
@@ -87,95 +120,241 @@ func (rw *responseWriter) Flush() {
 	}
 }
 
-// Logging returns middleware that logs HTTP requests in JSON format.
-// Log entries include: method, path, status code, duration, and error details for 4xx/5xx.
-// SECURITY: API keys, tokens, and other sensitive data are automatically redacted.
+// Logging returns middleware that logs HTTP requests in JSON format with default settings.
+// Log entries include: method, path, route pattern, status code, duration, principal,
+// request ID, and error details for 4xx/5xx.
+// SECURITY: API keys, tokens, emails, and other sensitive data are automatically redacted.
 func Logging(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-
-		// Capture request body for non-GET methods (for logging on error)
-		var requestBody string
-		if r.Method != http.MethodGet && r.Body != nil {
-			bodyBytes, err := io.ReadAll(r.Body)
-			if err == nil && len(bodyBytes) > 0 {
-				requestBody = string(bodyBytes)
-				// Restore the body so it can be read by handlers
-				r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	return RequestLogger(RequestLogConfig{})(next)
+}
+
+// RequestLogger returns slog-based request logging middleware configured by cfg.
+func RequestLogger(cfg RequestLogConfig) func(http.Handler) http.Handler {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.New(NewScrubbingJSONHandler(stdLogWriter{}, nil))
+	}
+	slow := cfg.SlowThreshold
+	if slow <= 0 {
+		slow = DefaultSlowRequestThreshold
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			// Capture request body for non-GET methods (for logging on error)
+			var requestBody string
+			if r.Method != http.MethodGet && r.Body != nil {
+				bodyBytes, err := io.ReadAll(r.Body)
+				if err == nil && len(bodyBytes) > 0 {
+					requestBody = string(bodyBytes)
+					// Restore the body so it can be read by handlers
+					r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+				}
 			}
-		}
 
-		// Wrap response writer to capture status and body
-		wrapped := &responseWriter{
-			ResponseWriter: w,
-			status:         http.StatusOK,
-		}
+			fields := &requestLogFields{}
+			r = r.WithContext(context.WithValue(r.Context(), requestLogFieldsKey{}, fields))
 
-		// Process request
-		next.ServeHTTP(wrapped, r)
+			// Wrap response writer to capture status and body
+			wrapped := &responseWriter{
+				ResponseWriter: w,
+				status:         http.StatusOK,
+			}
 
-		// Calculate duration
-		duration := time.Since(start)
+			// Process request
+			next.ServeHTTP(wrapped, r)
 
-		// Build log entry with redacted path (removes sensitive query params)
-		logPath := r.URL.Path
-		if r.URL.RawQuery != "" {
-			logPath = RedactURLPath(r.URL.Path + "?" + r.URL.RawQuery)
-		}
+			duration := time.Since(start)
 
-		entry := LogEntry{
-			Level:      logLevel(wrapped.status),
-			Timestamp:  time.Now().UTC().Format(time.RFC3339),
-			Message:    "Request completed",
-			Method:     r.Method,
-			Path:       logPath,
-			Status:     wrapped.status,
-			DurationMS: float64(duration.Nanoseconds()) / 1e6,
-		}
+			route := ""
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
 
-		// Add optional fields (all redacted for security)
-		if requestID := r.Header.Get("X-Request-ID"); requestID != "" {
-			entry.RequestID = requestID
-		}
-		if r.RemoteAddr != "" {
-			entry.RemoteAddr = r.RemoteAddr
-		}
+			if wrapped.status < 400 && duration < slow {
+				if rate, ok := sampleRateFor(cfg.SampleRates, route, r.URL.Path); ok && !shouldSample(rate, rand.Float64()) {
+					return
+				}
+			}
 
-		// Extract error details for 4xx/5xx responses
-		if wrapped.status >= 400 && len(wrapped.body) > 0 {
-			errCode, errMsg := extractErrorDetails(wrapped.body)
-			if errCode != "" {
-				entry.ErrorCode = errCode
+			// Redacted path (removes sensitive query params)
+			logPath := r.URL.Path
+			if r.URL.RawQuery != "" {
+				logPath = RedactURLPath(r.URL.Path + "?" + r.URL.RawQuery)
 			}
-			if errMsg != "" {
-				entry.Error = errMsg
+
+			attrs := []slog.Attr{
+				slog.String("method", r.Method),
+				slog.String("path", logPath),
+				slog.Int("status", wrapped.status),
+				slog.Float64("duration_ms", float64(duration.Nanoseconds())/1e6),
 			}
-		}
+			if route != "" {
+				attrs = append(attrs, slog.String("route", route))
+			}
+
+			// requestIDMiddleware sets the ID on the response when the client didn't send one
+			requestID := r.Header.Get("X-Request-ID")
+			if requestID == "" {
+				requestID = w.Header().Get("X-Request-ID")
+			}
+			if requestID != "" {
+				attrs = append(attrs, slog.String("request_id", requestID))
+			}
+			if r.RemoteAddr != "" {
+				attrs = append(attrs, slog.String("remote_addr", r.RemoteAddr))
+			}
+
+			fields.mu.Lock()
+			if fields.principalID != "" {
+				attrs = append(attrs,
+					slog.String("principal_type", fields.principalType),
+					slog.String("principal_id", fields.principalID),
+				)
+			}
+			fields.mu.Unlock()
+
+			// Extract error details for 4xx/5xx responses
+			if wrapped.status >= 400 && len(wrapped.body) > 0 {
+				errCode, errMsg := extractErrorDetails(wrapped.body)
+				if errCode != "" {
+					attrs = append(attrs, slog.String("error_code", errCode))
+				}
+				if errMsg != "" {
+					attrs = append(attrs, slog.String("error", errMsg))
+				}
+			}
+
+			// Include request body for failed non-GET requests (redacted, truncated)
+			if wrapped.status >= 400 && requestBody != "" {
+				attrs = append(attrs, slog.String("request_body", prepareRequestBodyForLog(requestBody)))
+			}
+
+			logger.LogAttrs(r.Context(), logLevel(wrapped.status), "Request completed", attrs...)
+		})
+	}
+}
 
-		// Include request body for failed non-GET requests (redacted, truncated)
-		if wrapped.status >= 400 && requestBody != "" {
-			entry.RequestBody = prepareRequestBodyForLog(requestBody)
+// sampleRateFor returns the configured sample rate for the route pattern, falling back
+// to the raw path for requests that matched no route.
+func sampleRateFor(rates map[string]float64, route, path string) (float64, bool) {
+	if len(rates) == 0 {
+		return 0, false
+	}
+	if rate, ok := rates[route]; ok && route != "" {
+		return rate, true
+	}
+	rate, ok := rates[path]
+	return rate, ok
+}
+
+// shouldSample reports whether a request with the given roll in [0,1) is kept at rate.
+func shouldSample(rate, roll float64) bool {
+	return roll < rate
+}
+
+// ParseSampleRates parses a sampling spec of the form "/health=0.01,/v1/feed=0.1".
+// Rates must be between 0 and 1.
+func ParseSampleRates(spec string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		route, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid sample rate %q: expected route=rate", part)
 		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid sample rate %q: rate must be between 0 and 1", part)
+		}
+		rates[strings.TrimSpace(route)] = rate
+	}
+	return rates, nil
+}
+
+// stdLogWriter forwards to the standard logger's current output, so request logs go
+// wherever log.SetOutput points (stderr in production, a buffer in tests).
+type stdLogWriter struct{}
 
-		// Output JSON log
-		logJSON, err := json.Marshal(entry)
-		if err != nil {
-			log.Printf("failed to marshal log entry: %v", err)
-			return
+func (stdLogWriter) Write(p []byte) (int, error) {
+	return log.Writer().Write(p)
+}
+
+// sensitiveAttrKeys lists log attribute keys whose values are always redacted.
+var sensitiveAttrKeys = []string{
+	"authorization",
+	"cookie",
+	"set-cookie",
+	"x-api-key",
+	"x-admin-api-key",
+}
+
+// emailRegex matches email addresses embedded in log values.
+var emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// inlineSecretRegex matches Solvr API keys and JWTs embedded in free text.
+var inlineSecretRegex = regexp.MustCompile(`solvr_[A-Za-z0-9_]+|eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+
+// ScrubPII removes email addresses, API keys, and tokens from free text.
+func ScrubPII(value string) string {
+	if value == "" {
+		return value
+	}
+	value = emailRegex.ReplaceAllString(value, "***EMAIL***")
+	return inlineSecretRegex.ReplaceAllString(value, "***REDACTED***")
+}
+
+// NewScrubbingJSONHandler returns a slog JSON handler that emits the request log schema
+// (timestamp, lowercase level, message) and scrubs every attribute: credential-bearing
+// keys such as Authorization are redacted outright and string values have emails and
+// tokens removed.
+func NewScrubbingJSONHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	if opts == nil {
+		opts = &slog.HandlerOptions{}
+	}
+	next := opts.ReplaceAttr
+	scrubbed := *opts
+	scrubbed.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+		if next != nil {
+			a = next(groups, a)
+		}
+		if len(groups) == 0 {
+			switch a.Key {
+			case slog.TimeKey:
+				return slog.String("timestamp", a.Value.Time().UTC().Format(time.RFC3339))
+			case slog.LevelKey:
+				return slog.String("level", strings.ToLower(a.Value.String()))
+			case slog.MessageKey:
+				return slog.String("message", a.Value.String())
+			}
 		}
-		log.Println(string(logJSON))
-	})
+		for _, key := range sensitiveAttrKeys {
+			if strings.EqualFold(a.Key, key) {
+				return slog.String(a.Key, "***REDACTED***")
+			}
+		}
+		if a.Value.Kind() == slog.KindString {
+			return slog.String(a.Key, ScrubPII(a.Value.String()))
+		}
+		return a
+	}
+	return slog.NewJSONHandler(w, &scrubbed)
 }
 
 // logLevel returns the appropriate log level based on status code.
-func logLevel(status int) string {
+func logLevel(status int) slog.Level {
 	switch {
 	case status >= 500:
-		return "error"
+		return slog.LevelError
 	case status >= 400:
-		return "warn"
+		return slog.LevelWarn
 	default:
-		return "info"
+		return slog.LevelInfo
 	}
 }
 
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // TestLoggingMiddleware verifies that request logging middleware works
@@ -539,3 +542,127 @@ func TestRedactURLPath(t *testing.T) {
 		})
 	}
 }
+
+// TestRequestLogger_IncludesRouteAndPrincipal verifies route pattern and principal are logged
+func TestRequestLogger_IncludesRouteAndPrincipal(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	r := chi.NewRouter()
+	r.Use(Logging)
+	r.Get("/v1/posts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		SetLogPrincipal(r.Context(), "agent", "agent_logger")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/abc", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	jsonStart := bytes.IndexByte(buf.Bytes(), '{')
+	if jsonStart == -1 {
+		t.Fatal("expected JSON log output")
+	}
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes()[jsonStart:], &logEntry); err != nil {
+		t.Fatalf("failed to parse log JSON: %v", err)
+	}
+
+	if logEntry["route"] != "/v1/posts/{id}" {
+		t.Errorf("expected route '/v1/posts/{id}', got %v", logEntry["route"])
+	}
+	if logEntry["principal_type"] != "agent" || logEntry["principal_id"] != "agent_logger" {
+		t.Errorf("expected agent principal, got %v/%v", logEntry["principal_type"], logEntry["principal_id"])
+	}
+	if logEntry["message"] != "Request completed" || logEntry["level"] != "info" || logEntry["timestamp"] == nil {
+		t.Errorf("unexpected envelope fields: %v", logEntry)
+	}
+}
+
+// TestRequestLogger_Sampling verifies sampled-out routes are skipped but errors are always logged
+func TestRequestLogger_Sampling(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	status := http.StatusOK
+	r := chi.NewRouter()
+	r.Use(RequestLogger(RequestLogConfig{SampleRates: map[string]float64{"/health": 0}}))
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	})
+	r.Get("/v1/feed", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	if buf.Len() != 0 {
+		t.Errorf("expected sampled-out request not to be logged, got: %s", buf.String())
+	}
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/feed", nil))
+	if buf.Len() == 0 {
+		t.Error("expected unsampled route to be logged")
+	}
+
+	buf.Reset()
+	status = http.StatusServiceUnavailable
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	if buf.Len() == 0 {
+		t.Error("expected error response to be logged despite sampling")
+	}
+}
+
+// TestRequestLogger_ScrubsEmails verifies emails never reach the log output
+func TestRequestLogger_ScrubsEmails(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	handler := Logging(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"VALIDATION_ERROR","message":"jane.doe@example.com is already registered"}}`))
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/lookup/jane.doe@example.com", bytes.NewBufferString(`{"email":"jane.doe@example.com"}`))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if bytes.Contains(buf.Bytes(), []byte("jane.doe@example.com")) {
+		t.Errorf("email was found in logs: %s", buf.String())
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("***EMAIL***")) {
+		t.Errorf("expected email placeholder in logs: %s", buf.String())
+	}
+}
+
+// TestScrubbingJSONHandler_RedactsAuthorizationAttr verifies credential keys are redacted
+func TestScrubbingJSONHandler_RedactsAuthorizationAttr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewScrubbingJSONHandler(&buf, nil))
+
+	logger.Info("outbound call", "Authorization", "Basic dXNlcjpwYXNz", "note", "key solvr_abc123 leaked")
+
+	if bytes.Contains(buf.Bytes(), []byte("dXNlcjpwYXNz")) {
+		t.Errorf("authorization value was found in logs: %s", buf.String())
+	}
+	if bytes.Contains(buf.Bytes(), []byte("solvr_abc123")) {
+		t.Errorf("inline API key was found in logs: %s", buf.String())
+	}
+}
+
+// TestParseSampleRates verifies sampling spec parsing
+func TestParseSampleRates(t *testing.T) {
+	rates, err := ParseSampleRates("/health=0.01, /v1/feed=0.5")
+	if err != nil {
+		t.Fatalf("ParseSampleRates() error = %v", err)
+	}
+	if rates["/health"] != 0.01 || rates["/v1/feed"] != 0.5 {
+		t.Errorf("unexpected rates: %v", rates)
+	}
+
+	for _, bad := range []string{"/health", "/health=2", "/health=abc"} {
+		if _, err := ParseSampleRates(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// MaintenanceState reports the read-only maintenance switch.
+type MaintenanceState interface {
+	Status() models.MaintenanceStatus
+}
+
+// maintenanceRetryAfter is the Retry-After, in seconds, sent with maintenance 503s.
+const maintenanceRetryAfter = 300
+
+// maintenanceExemptPaths stay writable in maintenance mode: the admin switch and
+// config reload, so it can be turned off again, and MCP, whose read tools
+// (solvr_search, solvr_get) are POSTs; the MCP handler refuses its own write tools.
+var maintenanceExemptPaths = map[string]bool{
+	"/v1/admin/maintenance":   true,
+	"/v1/admin/config/reload": true,
+	"/v1/mcp":                 true,
+}
+
+// ReadOnlyMaintenance rejects mutating requests with 503 MAINTENANCE_MODE while
+// maintenance mode is on. GET, HEAD and OPTIONS requests always pass.
+func ReadOnlyMaintenance(state MaintenanceState) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions ||
+				maintenanceExemptPaths[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			status := state.Status()
+			if !status.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(maintenanceRetryAfter))
+			apierror.Write(w, apierror.MaintenanceMode, status.Message)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/maintenance"
+)
+
+func TestReadOnlyMaintenance(t *testing.T) {
+	mode := maintenance.New(true, "migrating the posts table")
+	handler := ReadOnlyMaintenance(mode)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{http.MethodGet, "/v1/posts", http.StatusOK},
+		{http.MethodHead, "/v1/posts", http.StatusOK},
+		{http.MethodOptions, "/v1/posts", http.StatusOK},
+		{http.MethodPost, "/v1/posts", http.StatusServiceUnavailable},
+		{http.MethodPatch, "/v1/posts/abc", http.StatusServiceUnavailable},
+		{http.MethodDelete, "/v1/posts/abc", http.StatusServiceUnavailable},
+		{http.MethodPost, "/v1/admin/maintenance", http.StatusOK},
+		{http.MethodPost, "/v1/admin/config/reload", http.StatusOK},
+		{http.MethodPost, "/v1/mcp", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, nil))
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/answers", nil))
+	var body struct {
+		Error struct{ Code, Message string } `json:"error"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Error.Code != "MAINTENANCE_MODE" || body.Error.Message != "migrating the posts table" {
+		t.Errorf("unexpected error body: %+v", body.Error)
+	}
+	if rr.Header().Get("Retry-After") != "300" {
+		t.Errorf("expected Retry-After 300, got %q", rr.Header().Get("Retry-After"))
+	}
+
+	mode.Set(false, "")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/posts", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected writes to pass once disabled, got %d", rr.Code)
+	}
+}
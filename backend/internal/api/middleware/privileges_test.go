@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+)
+
+type stubReputation struct {
+	rep   int
+	err   error
+	calls int
+}
+
+func (s *stubReputation) GetReputation(ctx context.Context, authorType models.AuthorType, authorID string) (int, error) {
+	s.calls++
+	return s.rep, s.err
+}
+
+func privilegeRequest(body, role string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts/p1/vote", strings.NewReader(body))
+	return req.WithContext(auth.ContextWithPrincipal(req.Context(), &auth.Principal{
+		Type: models.AuthorTypeHuman, ID: "user-1", Role: role,
+	}))
+}
+
+func TestPrivilegeGate_Require(t *testing.T) {
+	tests := []struct {
+		name       string
+		rep        int
+		body       string
+		role       string
+		wantStatus int
+	}{
+		{"down vote below threshold", 499, `{"direction":"down"}`, "user", http.StatusForbidden},
+		{"down vote at threshold", 500, `{"direction":"down"}`, "user", http.StatusOK},
+		{"up vote needs no privilege", 0, `{"direction":"up"}`, "user", http.StatusOK},
+		{"admin is never gated", 0, `{"direction":"down"}`, "admin", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &stubReputation{rep: tt.rep}
+			gate := NewPrivilegeGate(source, reputation.DefaultThresholds)
+			var handlerBody string
+			handler := gate.Require(reputation.PrivilegeVoteDown, DownVote)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				handlerBody = string(b)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, privilegeRequest(tt.body, tt.role))
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, rr.Code, rr.Body.String())
+			}
+			if rr.Code == http.StatusOK && handlerBody != tt.body {
+				t.Errorf("handler got body %q, want %q", handlerBody, tt.body)
+			}
+		})
+	}
+}
+
+func TestPrivilegeGate_RequireErrorDetails(t *testing.T) {
+	gate := NewPrivilegeGate(&stubReputation{rep: 12}, func() reputation.Thresholds {
+		return reputation.Thresholds{reputation.PrivilegeComment: 20}
+	})
+	handler := gate.Require(reputation.PrivilegeComment, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler should not run")
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, privilegeRequest(`{}`, "user"))
+
+	var body struct {
+		Error struct {
+			Code    string                 `json:"code"`
+			Details map[string]interface{} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rr.Code != http.StatusForbidden || body.Error.Code != "INSUFFICIENT_REPUTATION" {
+		t.Fatalf("expected 403 INSUFFICIENT_REPUTATION, got %d %s", rr.Code, body.Error.Code)
+	}
+	if body.Error.Details["required"] != float64(20) || body.Error.Details["reputation"] != float64(12) {
+		t.Errorf("unexpected details: %v", body.Error.Details)
+	}
+}
+
+func TestPrivilegeGate_GrantsAndFailsOpen(t *testing.T) {
+	source := &stubReputation{err: errors.New("db down")}
+	gate := NewPrivilegeGate(source, reputation.DefaultThresholds)
+	handler := gate.Require(reputation.PrivilegeEditTags, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if PrivilegeGranted(r.Context(), reputation.PrivilegeEditTags) {
+			t.Error("a failed lookup must not grant the privilege")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, privilegeRequest(`{}`, "user"))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected lookup failure to fail open, got %d", rr.Code)
+	}
+
+	source.err = nil
+	source.rep = reputation.DefaultEditTagsThreshold
+	granted := false
+	handler = gate.Require(reputation.PrivilegeEditTags, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		granted = PrivilegeGranted(r.Context(), reputation.PrivilegeEditTags)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), privilegeRequest(`{}`, "user"))
+	if !granted {
+		t.Error("expected the privilege to be granted in the request context")
+	}
+}
+
+func TestPrivilegeGate_AnonymousPassesThrough(t *testing.T) {
+	source := &stubReputation{}
+	gate := NewPrivilegeGate(source, reputation.DefaultThresholds)
+	handler := gate.Require(reputation.PrivilegeComment, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/posts/p1/comments", nil))
+	if rr.Code != http.StatusUnauthorized || source.calls != 0 {
+		t.Errorf("expected anonymous request to reach the handler without a lookup, got %d (%d lookups)", rr.Code, source.calls)
+	}
+}
@@ -3,13 +3,14 @@ package middleware
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 )
 
@@ -108,15 +109,25 @@ type RateLimitStore interface {
 // RateLimiter implements rate limiting middleware.
 type RateLimiter struct {
 	store  RateLimitStore
-	config *RateLimitConfig
+	config atomic.Pointer[RateLimitConfig]
 }
 
 // NewRateLimiter creates a new RateLimiter with the given store and config.
 func NewRateLimiter(store RateLimitStore, config *RateLimitConfig) *RateLimiter {
-	return &RateLimiter{
-		store:  store,
-		config: config,
-	}
+	rl := &RateLimiter{store: store}
+	rl.config.Store(config)
+	return rl
+}
+
+// Config returns the limits in effect.
+func (rl *RateLimiter) Config() *RateLimitConfig {
+	return rl.config.Load()
+}
+
+// SetConfig replaces the limits for subsequent requests, e.g. on a config reload.
+// Counters in the store are kept.
+func (rl *RateLimiter) SetConfig(config *RateLimitConfig) {
+	rl.config.Store(config)
 }
 
 // Middleware returns HTTP middleware that enforces rate limits.
@@ -170,6 +181,34 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// Allow counts one operation against the limit of the principal in ctx and reports
+// whether it is within the limit. When it is not, retryAfter is the time until the
+// window resets. Callers without an identity are always allowed, and store errors
+// fail open, matching Middleware. Used for MCP tool calls, which share one HTTP route.
+func (rl *RateLimiter) Allow(ctx context.Context, operation string) (bool, time.Duration) {
+	identity := identityInfoFromContext(ctx)
+	if identity.Identifier == "" {
+		return true, 0
+	}
+
+	limit, window := rl.getLimitAndWindowWithAPIKey(identity, operation)
+	key := GenerateRateLimitKeyWithAPIKey(identity.IsAgent, identity.Identifier, identity.APIKeyID, operation)
+
+	record, err := rl.store.IncrementAndGet(ctx, key, window)
+	if err != nil {
+		return true, 0
+	}
+	if record.Count <= limit {
+		return true, 0
+	}
+
+	retryAfter := time.Until(record.WindowStart.Add(window))
+	if retryAfter < time.Second {
+		retryAfter = time.Second
+	}
+	return false, retryAfter
+}
+
 // IdentityInfo holds identity information for rate limiting.
 type IdentityInfo struct {
 	IsAgent    bool
@@ -183,83 +222,65 @@ type IdentityInfo struct {
 // Returns (isAgent, identifier, createdAt).
 // Deprecated: Use getIdentityInfo instead.
 func (rl *RateLimiter) getIdentity(r *http.Request) (bool, string, time.Time) {
-	// Check for agent first
-	agent := auth.AgentFromContext(r.Context())
-	if agent != nil {
-		return true, agent.ID, agent.CreatedAt
-	}
-
-	// Check for human (JWT claims)
-	claims := auth.ClaimsFromContext(r.Context())
-	if claims != nil {
-		return false, claims.UserID, time.Time{} // CreatedAt not available in claims
-	}
-
-	return false, "", time.Time{}
+	identity := identityInfoFromContext(r.Context())
+	return identity.IsAgent, identity.Identifier, identity.CreatedAt
 }
 
 // getIdentityInfo extracts full identity information including API key info.
 func (rl *RateLimiter) getIdentityInfo(r *http.Request) IdentityInfo {
-	ctx := r.Context()
-
-	// Check for agent first
-	agent := auth.AgentFromContext(ctx)
-	if agent != nil {
-		return IdentityInfo{
-			IsAgent:    true,
-			Identifier: agent.ID,
-			CreatedAt:  agent.CreatedAt,
-		}
-	}
+	return identityInfoFromContext(r.Context())
+}
 
-	// Check for human (JWT claims)
-	claims := auth.ClaimsFromContext(ctx)
-	if claims != nil {
-		return IdentityInfo{
-			IsAgent:    false,
-			Identifier: claims.UserID,
-			APIKeyID:   auth.APIKeyIDFromContext(ctx),
-			APIKeyTier: auth.APIKeyTierFromContext(ctx),
-		}
+// identityInfoFromContext extracts identity information from an authenticated context.
+func identityInfoFromContext(ctx context.Context) IdentityInfo {
+	p := auth.PrincipalFromContext(ctx)
+	if p == nil {
+		return IdentityInfo{}
+	}
+	return IdentityInfo{
+		IsAgent:    p.IsAgent(),
+		Identifier: p.ID,
+		CreatedAt:  p.CreatedAt,
+		APIKeyID:   p.APIKeyID,
+		APIKeyTier: p.APIKeyTier,
 	}
-
-	return IdentityInfo{}
 }
 
 // getLimitAndWindow returns the rate limit and window for the given operation.
 func (rl *RateLimiter) getLimitAndWindow(isAgent bool, operation string, createdAt time.Time) (int, time.Duration) {
+	cfg := rl.config.Load()
 	var limit int
 	var window time.Duration
 
 	switch operation {
 	case "search":
-		limit = rl.config.SearchLimitPerMin
+		limit = cfg.SearchLimitPerMin
 		window = time.Minute
 	case "posts":
 		if isAgent {
-			limit = rl.config.AgentPostsPerHour
+			limit = cfg.AgentPostsPerHour
 		} else {
-			limit = rl.config.HumanPostsPerHour
+			limit = cfg.HumanPostsPerHour
 		}
-		window = rl.config.PostsWindow
+		window = cfg.PostsWindow
 	case "answers":
 		if isAgent {
-			limit = rl.config.AgentAnswersPerHour
+			limit = cfg.AgentAnswersPerHour
 		} else {
-			limit = rl.config.HumanAnswersPerHour
+			limit = cfg.HumanAnswersPerHour
 		}
-		window = rl.config.AnswersWindow
+		window = cfg.AnswersWindow
 	default: // "general"
 		if isAgent {
-			limit = rl.config.AgentGeneralLimit
+			limit = cfg.AgentGeneralLimit
 		} else {
-			limit = rl.config.HumanGeneralLimit
+			limit = cfg.HumanGeneralLimit
 		}
-		window = rl.config.GeneralWindow
+		window = cfg.GeneralWindow
 	}
 
 	// Apply new account restriction (50% limit for accounts < 24h old)
-	if !createdAt.IsZero() && time.Since(createdAt) < rl.config.NewAccountThreshold {
+	if !createdAt.IsZero() && time.Since(createdAt) < cfg.NewAccountThreshold {
 		limit = limit / 2
 	}
 
@@ -272,18 +293,19 @@ func (rl *RateLimiter) getLimitAndWindowWithAPIKey(identity IdentityInfo, operat
 	if identity.IsAgent {
 		return rl.getLimitAndWindow(true, operation, identity.CreatedAt)
 	}
+	cfg := rl.config.Load()
 
 	// For humans with API key, check for tier-specific limits
-	if identity.APIKeyID != "" && identity.APIKeyTier != "" && rl.config.APIKeyTierLimits != nil {
-		if tierLimit, ok := rl.config.APIKeyTierLimits[identity.APIKeyTier]; ok {
+	if identity.APIKeyID != "" && identity.APIKeyTier != "" && cfg.APIKeyTierLimits != nil {
+		if tierLimit, ok := cfg.APIKeyTierLimits[identity.APIKeyTier]; ok {
 			// Use tier-specific limit with standard window
-			return tierLimit, rl.config.GeneralWindow
+			return tierLimit, cfg.GeneralWindow
 		}
 	}
 
 	// Use API key default limit if configured
-	if identity.APIKeyID != "" && rl.config.APIKeyDefaultLimit > 0 {
-		return rl.config.APIKeyDefaultLimit, rl.config.GeneralWindow
+	if identity.APIKeyID != "" && cfg.APIKeyDefaultLimit > 0 {
+		return cfg.APIKeyDefaultLimit, cfg.GeneralWindow
 	}
 
 	// Fall back to standard human limits
@@ -298,18 +320,8 @@ func (rl *RateLimiter) writeRateLimitError(w http.ResponseWriter, resetTime time
 		retryAfter = 1
 	}
 
-	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
-	w.WriteHeader(http.StatusTooManyRequests)
-
-	response := map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    "RATE_LIMITED",
-			"message": "too many requests, please slow down",
-		},
-	}
-
-	json.NewEncoder(w).Encode(response)
+	apierror.Write(w, apierror.RateLimited, "too many requests, please slow down")
 }
 
 // GenerateRateLimitKey generates a unique key for rate limiting.
@@ -9,7 +9,7 @@ import (
 
 // InMemoryRateLimitStore implements RateLimitStore using an in-memory map.
 // This is suitable for single-instance deployments. For multi-instance,
-// use Redis or database-backed store.
+// use RedisRateLimitStore.
 type InMemoryRateLimitStore struct {
 	mu      sync.RWMutex
 	records map[string]*RateLimitRecord
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/redis"
+)
+
+// RedisClient runs Redis commands. Implemented by *redis.Client.
+type RedisClient interface {
+	Do(ctx context.Context, args ...string) (interface{}, error)
+	Tx(ctx context.Context, cmds ...[]string) ([]interface{}, error)
+}
+
+// RedisRateLimitStore implements RateLimitStore in Redis so every API replica
+// counts against the same limits. Each window is a counter key plus a
+// "<key>:start" key holding the window start, both created with the window as
+// their TTL, so Redis drops them when the window ends.
+type RedisRateLimitStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisRateLimitStore creates a RedisRateLimitStore. prefix namespaces its
+// keys (e.g. "solvr:ratelimit:") so limiters sharing a Redis don't collide.
+func NewRedisRateLimitStore(client RedisClient, prefix string) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client, prefix: prefix}
+}
+
+// GetRecord retrieves a rate limit record by key. Returns nil if there is no
+// current window.
+func (s *RedisRateLimitStore) GetRecord(ctx context.Context, key string) (*RateLimitRecord, error) {
+	k := s.prefix + key
+	results, err := s.client.Tx(ctx, []string{"GET", k}, []string{"GET", k + ":start"})
+	if err != nil {
+		return nil, err
+	}
+	if results[0] == nil {
+		return nil, nil
+	}
+	count, err := redisInt(results[0])
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimitRecord{Key: key, Count: count, WindowStart: redisWindowStart(results[1], time.Now())}, nil
+}
+
+// IncrementAndGet increments the count and returns the updated record.
+// If the window has expired, it starts a new window. The window is created
+// and counted in one MULTI/EXEC, so concurrent replicas never lose a count
+// or leave a counter without a TTL.
+func (s *RedisRateLimitStore) IncrementAndGet(ctx context.Context, key string, window time.Duration) (*RateLimitRecord, error) {
+	k := s.prefix + key
+	now := time.Now()
+	ttl := strconv.FormatInt(window.Milliseconds(), 10)
+	results, err := s.client.Tx(ctx,
+		[]string{"SET", k, "0", "PX", ttl, "NX"},
+		[]string{"SET", k + ":start", strconv.FormatInt(now.UnixMilli(), 10), "PX", ttl, "NX"},
+		[]string{"INCR", k},
+		[]string{"GET", k + ":start"},
+	)
+	if err != nil {
+		return nil, err
+	}
+	count, err := redisInt(results[2])
+	if err != nil {
+		return nil, err
+	}
+	return &RateLimitRecord{Key: key, Count: count, WindowStart: redisWindowStart(results[3], now)}, nil
+}
+
+// redisInt converts an integer or numeric bulk string reply to an int.
+func redisInt(reply interface{}) (int, error) {
+	switch v := reply.(type) {
+	case int64:
+		return int(v), nil
+	case []byte:
+		return strconv.Atoi(string(v))
+	case redis.Error:
+		return 0, v
+	}
+	return 0, errors.New("redis: unexpected reply type")
+}
+
+// redisWindowStart parses a window start (Unix milliseconds), falling back to
+// fallback if the start key has already expired.
+func redisWindowStart(reply interface{}, fallback time.Time) time.Time {
+	b, ok := reply.([]byte)
+	if !ok {
+		return fallback
+	}
+	ms, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return time.UnixMilli(ms)
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/redis"
+)
+
+// fakeRedis is an in-memory RedisClient supporting GET, SET (PX, NX) and INCR
+// with key expiry. Transactions run under one lock, like MULTI/EXEC.
+type fakeRedis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+	err     error
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: map[string]string{}, expires: map[string]time.Time{}}
+}
+
+func (f *fakeRedis) Do(ctx context.Context, args ...string) (interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	reply := f.exec(args)
+	switch v := reply.(type) {
+	case nil:
+		return nil, redis.ErrNil
+	case redis.Error:
+		return nil, v
+	}
+	return reply, nil
+}
+
+func (f *fakeRedis) Tx(ctx context.Context, cmds ...[]string) ([]interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	results := make([]interface{}, len(cmds))
+	for i, args := range cmds {
+		results[i] = f.exec(args)
+	}
+	return results, nil
+}
+
+// expire moves every key's expiry d into the past.
+func (f *fakeRedis) expire(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for k, at := range f.expires {
+		f.expires[k] = at.Add(-d)
+	}
+}
+
+func (f *fakeRedis) exec(args []string) interface{} {
+	key := args[1]
+	if at, ok := f.expires[key]; ok && !time.Now().Before(at) {
+		delete(f.values, key)
+		delete(f.expires, key)
+	}
+	v, exists := f.values[key]
+	switch args[0] {
+	case "GET":
+		if !exists {
+			return nil
+		}
+		return []byte(v)
+	case "SET":
+		var ttl time.Duration
+		for i := 3; i < len(args); i++ {
+			switch args[i] {
+			case "NX":
+				if exists {
+					return nil
+				}
+			case "PX":
+				ms, _ := strconv.Atoi(args[i+1])
+				ttl = time.Duration(ms) * time.Millisecond
+				i++
+			}
+		}
+		f.values[key] = args[2]
+		delete(f.expires, key)
+		if ttl > 0 {
+			f.expires[key] = time.Now().Add(ttl)
+		}
+		return "OK"
+	case "INCR":
+		n := 0
+		if exists {
+			var err error
+			if n, err = strconv.Atoi(v); err != nil {
+				return redis.Error("ERR value is not an integer or out of range")
+			}
+		}
+		n++
+		f.values[key] = strconv.Itoa(n)
+		return int64(n)
+	}
+	return redis.Error("ERR unknown command")
+}
+
+func TestRedisRateLimitStore_IncrementAndGet(t *testing.T) {
+	client := newFakeRedis()
+	store := NewRedisRateLimitStore(client, "test:")
+	ctx := context.Background()
+
+	before := time.Now().Truncate(time.Millisecond)
+	record, err := store.IncrementAndGet(ctx, "agent:a1", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.Count != 1 || record.Key != "agent:a1" {
+		t.Errorf("expected count 1 for agent:a1, got %d for %q", record.Count, record.Key)
+	}
+	if record.WindowStart.Before(before) {
+		t.Errorf("window start %v before request at %v", record.WindowStart, before)
+	}
+	start := record.WindowStart
+
+	record, err = store.IncrementAndGet(ctx, "agent:a1", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.Count != 2 {
+		t.Errorf("expected count 2, got %d", record.Count)
+	}
+	if !record.WindowStart.Equal(start) {
+		t.Errorf("expected window start to stay %v, got %v", start, record.WindowStart)
+	}
+
+	if _, ok := client.values["test:agent:a1"]; !ok {
+		t.Error("expected counter under the store prefix")
+	}
+	if _, ok := client.expires["test:agent:a1"]; !ok {
+		t.Error("expected counter to have a TTL")
+	}
+}
+
+func TestRedisRateLimitStore_NewWindowAfterExpiry(t *testing.T) {
+	client := newFakeRedis()
+	store := NewRedisRateLimitStore(client, "test:")
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := store.IncrementAndGet(ctx, "k", time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	client.expire(time.Minute)
+
+	record, err := store.IncrementAndGet(ctx, "k", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.Count != 1 {
+		t.Errorf("expected a new window with count 1, got %d", record.Count)
+	}
+}
+
+func TestRedisRateLimitStore_GetRecord(t *testing.T) {
+	client := newFakeRedis()
+	store := NewRedisRateLimitStore(client, "test:")
+	ctx := context.Background()
+
+	record, err := store.GetRecord(ctx, "missing")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record != nil {
+		t.Errorf("expected nil record, got %+v", record)
+	}
+
+	created, _ := store.IncrementAndGet(ctx, "k", time.Minute)
+	_, _ = store.IncrementAndGet(ctx, "k", time.Minute)
+	record, err = store.GetRecord(ctx, "k")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record == nil || record.Count != 2 || !record.WindowStart.Equal(created.WindowStart) {
+		t.Errorf("expected count 2 from %v, got %+v", created.WindowStart, record)
+	}
+}
+
+func TestRedisRateLimitStore_SharedAcrossInstances(t *testing.T) {
+	client := newFakeRedis()
+	replicaA := NewRedisRateLimitStore(client, "test:")
+	replicaB := NewRedisRateLimitStore(client, "test:")
+	ctx := context.Background()
+
+	_, _ = replicaA.IncrementAndGet(ctx, "k", time.Minute)
+	record, err := replicaB.IncrementAndGet(ctx, "k", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.Count != 2 {
+		t.Errorf("expected replicas to share the count, got %d", record.Count)
+	}
+}
+
+func TestRedisRateLimitStore_Error(t *testing.T) {
+	client := newFakeRedis()
+	client.err = errors.New("connection refused")
+	store := NewRedisRateLimitStore(client, "test:")
+
+	if _, err := store.IncrementAndGet(context.Background(), "k", time.Minute); err == nil {
+		t.Error("expected store error to be returned so the limiter can fail open")
+	}
+}
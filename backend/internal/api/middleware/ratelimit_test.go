@@ -117,6 +117,32 @@ func TestRateLimiter_AgentGeneralLimit(t *testing.T) {
 	}
 }
 
+// TestRateLimiter_SetConfig tests that reloaded limits apply to the next request.
+func TestRateLimiter_SetConfig(t *testing.T) {
+	store := NewMockRateLimitStore()
+	rl := NewRateLimiter(store, DefaultRateLimitConfig())
+	handler := rl.Middleware(okHandler())
+
+	tighter := DefaultRateLimitConfig()
+	tighter.AgentGeneralLimit = 2
+	rl.SetConfig(tighter)
+	if rl.Config().AgentGeneralLimit != 2 {
+		t.Fatalf("expected Config() to return the new limits, got %d", rl.Config().AgentGeneralLimit)
+	}
+
+	codes := make([]int, 3)
+	for i := range codes {
+		req := httptest.NewRequest("GET", "/v1/posts", nil)
+		req = addAgentToContext(req, "test-agent", time.Now().Add(-25*time.Hour))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		codes[i] = rec.Code
+	}
+	if codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("expected the 3rd request to be limited after SetConfig, got %v", codes)
+	}
+}
+
 // TestRateLimiter_HumanGeneralLimit tests 30 requests/minute for humans (launch limit).
 func TestRateLimiter_HumanGeneralLimit(t *testing.T) {
 	store := NewMockRateLimitStore()
@@ -798,3 +824,34 @@ func TestRateLimiter_LimitsAreDocumented(t *testing.T) {
 		}
 	}
 }
+
+// TestRateLimiter_Allow verifies Allow shares Middleware's limits for callers in context.
+func TestRateLimiter_Allow(t *testing.T) {
+	store := NewMockRateLimitStore()
+	rl := NewRateLimiter(store, DefaultRateLimitConfig())
+	limit := DefaultRateLimitConfig().SearchLimitPerMin
+
+	req := addAgentToContext(httptest.NewRequest("POST", "/v1/mcp", nil), "test-agent", time.Now().Add(-25*time.Hour))
+	ctx := req.Context()
+	for i := 0; i < limit; i++ {
+		if ok, _ := rl.Allow(ctx, "search"); !ok {
+			t.Fatalf("call %d should be allowed", i+1)
+		}
+	}
+
+	ok, retryAfter := rl.Allow(ctx, "search")
+	if ok {
+		t.Fatal("call over the limit should be rejected")
+	}
+	if retryAfter < time.Second || retryAfter > time.Minute {
+		t.Errorf("expected retryAfter within the window, got %v", retryAfter)
+	}
+
+	// Other buckets and anonymous callers are unaffected
+	if ok, _ := rl.Allow(ctx, "general"); !ok {
+		t.Error("general bucket should be independent of search")
+	}
+	if ok, _ := rl.Allow(context.Background(), "search"); !ok {
+		t.Error("anonymous callers should be allowed")
+	}
+}
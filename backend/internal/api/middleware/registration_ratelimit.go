@@ -2,13 +2,14 @@
 package middleware
 
 import (
-	"encoding/json"
 	"log"
 	"net"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 )
 
 // RegistrationRateLimitConfig holds configuration for registration rate limiting.
@@ -116,23 +117,14 @@ func (rl *RegistrationRateLimiter) writeRateLimitError(w http.ResponseWriter, r
 	log.Printf("[%s] RATE_LIMITED: IP %s exceeded registration limit (%d/%d), retry after %ds",
 		rl.config.LogPrefix, clientIP, record.Count, rl.config.MaxPerIP, retryAfter)
 
-	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
-	w.WriteHeader(http.StatusTooManyRequests)
-
-	response := map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    "RATE_LIMITED",
-			"message": "too many registration attempts from this IP, please try again later",
-			"details": map[string]interface{}{
-				"retry_after_seconds": retryAfter,
-				"limit":               rl.config.MaxPerIP,
-				"window":              rl.config.Window.String(),
-			},
-		},
-	}
-
-	json.NewEncoder(w).Encode(response)
+	apierror.WriteDetails(w, apierror.RateLimited,
+		"too many registration attempts from this IP, please try again later",
+		map[string]interface{}{
+			"retry_after_seconds": retryAfter,
+			"limit":               rl.config.MaxPerIP,
+			"window":              rl.config.Window.String(),
+		})
 }
 
 // ExtractClientIP extracts the client IP address from the request.
@@ -0,0 +1,265 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+const (
+	// DefaultResponseCacheTTL matches the Cache-Control max-age served by the stats endpoints.
+	DefaultResponseCacheTTL = 30 * time.Second
+	// defaultResponseCacheMaxEntries bounds memory use; distinct query strings are attacker-controlled.
+	defaultResponseCacheMaxEntries = 1000
+	// responseCacheInvalidateTimeout bounds clearing a shared store after a write.
+	responseCacheInvalidateTimeout = 2 * time.Second
+)
+
+// cachedHeaders are the response headers replayed on a cache hit.
+// Per-request headers (X-Request-ID, rate limit counters) are never cached.
+var cachedHeaders = []string{"Content-Type", "Cache-Control"}
+
+// storedResponse is a cachedResponse as kept in a shared store; the store owns expiry.
+type storedResponse struct {
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// cachedResponse is a stored 200 response.
+type cachedResponse struct {
+	header    http.Header
+	body      []byte
+	expiresAt time.Time
+}
+
+// ResponseCacheStore is a cache shared by every API replica, so a write on one
+// replica invalidates what the others serve. Implemented by RedisResponseCacheStore.
+type ResponseCacheStore interface {
+	// Get returns the value stored under key; ok is false on a miss.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Clear drops every stored value.
+	Clear(ctx context.Context) error
+}
+
+// ResponseCache is a TTL cache for hot, anonymous GET endpoints (stats, feed,
+// public post lists). Entries expire after the TTL and are dropped wholesale
+// whenever a write succeeds (see InvalidateOnWrite). Entries live in process
+// memory unless a shared store is set with SetStore.
+type ResponseCache struct {
+	ttl        time.Duration
+	maxEntries int
+	store      ResponseCacheStore
+
+	mu      sync.RWMutex
+	entries map[string]cachedResponse
+}
+
+// NewResponseCache creates a ResponseCache. A non-positive ttl disables caching.
+func NewResponseCache(ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		ttl:        ttl,
+		maxEntries: defaultResponseCacheMaxEntries,
+		entries:    make(map[string]cachedResponse),
+	}
+}
+
+// SetStore keeps entries in a shared store instead of process memory. Store
+// errors are logged and treated as misses, so an unavailable store only
+// costs the cache hits.
+func (c *ResponseCache) SetStore(store ResponseCacheStore) {
+	c.store = store
+}
+
+// Middleware serves cached responses for anonymous GET requests and stores
+// successful responses on a miss. Authenticated requests bypass the cache
+// because responses may be personalized (e.g. user_vote), so it must be
+// mounted after any OptionalAuth middleware on the route.
+// Sets X-Cache: HIT or MISS on cacheable requests.
+func (c *ResponseCache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.ttl <= 0 || r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if principalType, _ := usagePrincipal(r); principalType != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := responseCacheKey(r)
+		if entry, ok := c.get(r.Context(), key); ok {
+			for name, values := range entry.header {
+				w.Header()[name] = values
+			}
+			w.Header().Set("X-Cache", "HIT")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(entry.body)
+			return
+		}
+
+		w.Header().Set("X-Cache", "MISS")
+		rec := &cacheRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.status == http.StatusOK {
+			header := make(http.Header, len(cachedHeaders))
+			for _, name := range cachedHeaders {
+				if v := w.Header().Get(name); v != "" {
+					header.Set(name, v)
+				}
+			}
+			c.set(r.Context(), key, cachedResponse{header: header, body: rec.body.Bytes(), expiresAt: time.Now().Add(c.ttl)})
+		}
+	})
+}
+
+// InvalidateOnWrite clears the cache after any successful POST, PUT, PATCH or
+// DELETE so readers see their writes without waiting for the TTL.
+// View tracking (POST .../view) is excluded: it is high-volume and its counts
+// are fine to lag by one TTL.
+func (c *ResponseCache) InvalidateOnWrite(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isWriteMethod(r.Method) || strings.HasSuffix(r.URL.Path, "/view") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &usageStatusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.status < http.StatusBadRequest {
+			c.Invalidate()
+		}
+	})
+}
+
+// Invalidate drops every cached response.
+func (c *ResponseCache) Invalidate() {
+	if c.store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), responseCacheInvalidateTimeout)
+		defer cancel()
+		if err := c.store.Clear(ctx); err != nil {
+			log.Printf("Response cache: failed to clear shared store: %v", err)
+		}
+		return
+	}
+	c.mu.Lock()
+	c.entries = make(map[string]cachedResponse)
+	c.mu.Unlock()
+}
+
+// Len returns the number of responses cached in process memory, including
+// expired ones not yet evicted. Entries in a shared store are not counted.
+func (c *ResponseCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+func (c *ResponseCache) get(ctx context.Context, key string) (cachedResponse, bool) {
+	if c.store != nil {
+		value, ok, err := c.store.Get(ctx, key)
+		if err != nil {
+			log.Printf("Response cache: shared store get failed: %v", err)
+			return cachedResponse{}, false
+		}
+		if !ok {
+			return cachedResponse{}, false
+		}
+		var stored storedResponse
+		if err := json.Unmarshal(value, &stored); err != nil {
+			return cachedResponse{}, false
+		}
+		return cachedResponse{header: stored.Header, body: stored.Body}, true
+	}
+
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok || time.Now().After(entry.expiresAt) {
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (c *ResponseCache) set(ctx context.Context, key string, entry cachedResponse) {
+	if c.store != nil {
+		value, err := json.Marshal(storedResponse{Header: entry.header, Body: entry.body})
+		if err != nil {
+			return
+		}
+		if err := c.store.Set(ctx, key, value, c.ttl); err != nil {
+			log.Printf("Response cache: shared store set failed: %v", err)
+		}
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= c.maxEntries {
+		now := time.Now()
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		// Still full of live entries: start over rather than track recency.
+		if len(c.entries) >= c.maxEntries {
+			c.entries = make(map[string]cachedResponse)
+		}
+	}
+	c.entries[key] = entry
+}
+
+// responseCacheKey normalizes the query string so parameter order doesn't split
+// entries, and is prefixed with the request's tenant so communities sharing a
+// deployment never see each other's cached responses.
+func responseCacheKey(r *http.Request) string {
+	key := r.URL.Path + "?" + r.URL.Query().Encode()
+	if id := tenant.FromContext(r.Context()); id != "" {
+		key = id + ":" + key
+	}
+	return key
+}
+
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// cacheRecorder tees the response body so it can be stored after the handler returns.
+type cacheRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rw *cacheRecorder) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.status = code
+		rw.wroteHeader = true
+		rw.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (rw *cacheRecorder) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/redis"
+)
+
+// RedisResponseCacheStore implements ResponseCacheStore in Redis. Keys carry a
+// generation number and Clear bumps it, so invalidating is one INCR however
+// many entries are cached; entries from old generations expire by TTL.
+type RedisResponseCacheStore struct {
+	client RedisClient
+	prefix string
+}
+
+// NewRedisResponseCacheStore creates a RedisResponseCacheStore. prefix
+// namespaces its keys (e.g. "solvr:cache:").
+func NewRedisResponseCacheStore(client RedisClient, prefix string) *RedisResponseCacheStore {
+	return &RedisResponseCacheStore{client: client, prefix: prefix}
+}
+
+// Get returns the value stored under key in the current generation.
+func (s *RedisResponseCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	gen, err := s.generation(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	reply, err := s.client.Do(ctx, "GET", s.prefix+gen+":"+key)
+	if errors.Is(err, redis.ErrNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, errors.New("redis: unexpected reply type")
+	}
+	return value, true, nil
+}
+
+// Set stores value under key in the current generation for ttl.
+func (s *RedisResponseCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	gen, err := s.generation(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Do(ctx, "SET", s.prefix+gen+":"+key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Clear starts a new generation, hiding every stored value.
+func (s *RedisResponseCacheStore) Clear(ctx context.Context) error {
+	_, err := s.client.Do(ctx, "INCR", s.prefix+"gen")
+	return err
+}
+
+// generation returns the current generation, "0" before the first Clear.
+func (s *RedisResponseCacheStore) generation(ctx context.Context) (string, error) {
+	reply, err := s.client.Do(ctx, "GET", s.prefix+"gen")
+	if errors.Is(err, redis.ErrNil) {
+		return "0", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	gen, err := redisInt(reply)
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(gen), nil
+}
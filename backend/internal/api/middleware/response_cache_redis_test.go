@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResponseCache_RedisStoreSharedAcrossReplicas(t *testing.T) {
+	client := newFakeRedis()
+	replicaA := NewResponseCache(time.Minute)
+	replicaA.SetStore(NewRedisResponseCacheStore(client, "test:cache:"))
+	replicaB := NewResponseCache(time.Minute)
+	replicaB.SetStore(NewRedisResponseCacheStore(client, "test:cache:"))
+
+	nextA, nextB := &countingHandler{}, &countingHandler{}
+	replicaA.Middleware(nextA).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+
+	rr := httptest.NewRecorder()
+	replicaB.Middleware(nextB).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+	if rr.Header().Get("X-Cache") != "HIT" {
+		t.Errorf("expected replica B to HIT replica A's entry, got %q", rr.Header().Get("X-Cache"))
+	}
+	if nextB.calls != 0 {
+		t.Errorf("expected replica B's handler not to run, ran %d times", nextB.calls)
+	}
+	if rr.Body.String() != `{"data":[]}` || rr.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected cached response: %q (%s)", rr.Body.String(), rr.Header().Get("Content-Type"))
+	}
+	if replicaA.Len() != 0 {
+		t.Errorf("expected nothing cached in process memory, got %d", replicaA.Len())
+	}
+	if _, ok := client.expires["test:cache:0:/v1/stats?"]; !ok {
+		t.Error("expected entry stored with a TTL under generation 0")
+	}
+}
+
+func TestResponseCache_RedisStoreInvalidateOnWrite(t *testing.T) {
+	client := newFakeRedis()
+	replicaA := NewResponseCache(time.Minute)
+	replicaA.SetStore(NewRedisResponseCacheStore(client, "test:cache:"))
+	replicaB := NewResponseCache(time.Minute)
+	replicaB.SetStore(NewRedisResponseCacheStore(client, "test:cache:"))
+
+	next := &countingHandler{}
+	replicaA.Middleware(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+
+	// A write on replica B clears what replica A serves.
+	replicaB.InvalidateOnWrite(&countingHandler{status: http.StatusCreated}).
+		ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/posts", nil))
+
+	rr := httptest.NewRecorder()
+	replicaA.Middleware(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+	if rr.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("expected MISS after write on another replica, got %q", rr.Header().Get("X-Cache"))
+	}
+	if next.calls != 2 {
+		t.Errorf("expected handler to run twice, ran %d times", next.calls)
+	}
+}
+
+func TestResponseCache_RedisStoreErrorPassesThrough(t *testing.T) {
+	client := newFakeRedis()
+	client.err = errors.New("connection refused")
+	cache := NewResponseCache(time.Minute)
+	cache.SetStore(NewRedisResponseCacheStore(client, "test:cache:"))
+
+	next := &countingHandler{}
+	for i := 0; i < 2; i++ {
+		rr := httptest.NewRecorder()
+		cache.Middleware(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 with store down, got %d", rr.Code)
+		}
+	}
+	if next.calls != 2 {
+		t.Errorf("expected every request to reach the handler, ran %d times", next.calls)
+	}
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+// countingHandler counts how often the wrapped handler actually runs.
+type countingHandler struct {
+	calls  int
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-ID", "req-per-call")
+	status := h.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`{"data":[]}`))
+}
+
+func TestResponseCache_HitAfterMiss(t *testing.T) {
+	cache := NewResponseCache(time.Minute)
+	next := &countingHandler{}
+	handler := cache.Middleware(next)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/feed?page=1&per_page=20", nil))
+	if rr.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("expected first request to MISS, got %q", rr.Header().Get("X-Cache"))
+	}
+
+	// Same query in a different order hits the same entry.
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/feed?per_page=20&page=1", nil))
+	if rr.Header().Get("X-Cache") != "HIT" {
+		t.Errorf("expected second request to HIT, got %q", rr.Header().Get("X-Cache"))
+	}
+	if next.calls != 1 {
+		t.Errorf("expected handler to run once, ran %d times", next.calls)
+	}
+	if rr.Body.String() != `{"data":[]}` {
+		t.Errorf("unexpected cached body %q", rr.Body.String())
+	}
+	if rr.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("expected cached Content-Type, got %q", rr.Header().Get("Content-Type"))
+	}
+	if rr.Header().Get("X-Request-ID") != "" {
+		t.Error("per-request headers must not be replayed from cache")
+	}
+}
+
+func TestResponseCache_SeparatesTenants(t *testing.T) {
+	cache := NewResponseCache(time.Minute)
+	next := &countingHandler{}
+	handler := cache.Middleware(next)
+
+	for _, id := range []string{"acme", "globex", "acme"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/stats", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(tenant.WithID(req.Context(), id)))
+	}
+	if next.calls != 2 {
+		t.Errorf("expected one handler run per tenant, ran %d times", next.calls)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 cached entries, got %d", cache.Len())
+	}
+}
+
+func TestResponseCache_SkipsAuthenticated(t *testing.T) {
+	cache := NewResponseCache(time.Minute)
+	next := &countingHandler{}
+	handler := cache.Middleware(next)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+		req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_cache"}))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if next.calls != 2 {
+		t.Errorf("expected authenticated requests to bypass cache, handler ran %d times", next.calls)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("expected no cached entries, got %d", cache.Len())
+	}
+}
+
+func TestResponseCache_DoesNotCacheErrors(t *testing.T) {
+	cache := NewResponseCache(time.Minute)
+	next := &countingHandler{status: http.StatusInternalServerError}
+	handler := cache.Middleware(next)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+
+	if next.calls != 2 {
+		t.Errorf("expected error responses not to be cached, handler ran %d times", next.calls)
+	}
+}
+
+func TestResponseCache_ExpiresAfterTTL(t *testing.T) {
+	cache := NewResponseCache(time.Minute)
+	next := &countingHandler{}
+	handler := cache.Middleware(next)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+	for k, e := range cache.entries {
+		e.expiresAt = time.Now().Add(-time.Second)
+		cache.entries[k] = e
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+
+	if next.calls != 2 {
+		t.Errorf("expected expired entry to be refetched, handler ran %d times", next.calls)
+	}
+}
+
+func TestResponseCache_DisabledWithZeroTTL(t *testing.T) {
+	cache := NewResponseCache(0)
+	next := &countingHandler{}
+	handler := cache.Middleware(next)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+
+	if next.calls != 2 {
+		t.Errorf("expected disabled cache to pass through, handler ran %d times", next.calls)
+	}
+}
+
+func TestResponseCache_InvalidateOnWrite(t *testing.T) {
+	cache := NewResponseCache(time.Minute)
+	cache.Middleware(&countingHandler{}).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/stats", nil))
+	if cache.Len() != 1 {
+		t.Fatalf("expected 1 cached entry, got %d", cache.Len())
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		status    int
+		wantEmpty bool
+	}{
+		{name: "failed write keeps cache", method: http.MethodPost, path: "/v1/posts", status: http.StatusBadRequest, wantEmpty: false},
+		{name: "view tracking keeps cache", method: http.MethodPost, path: "/v1/posts/p1/view", status: http.StatusOK, wantEmpty: false},
+		{name: "read keeps cache", method: http.MethodGet, path: "/v1/posts", status: http.StatusOK, wantEmpty: false},
+		{name: "successful write clears cache", method: http.MethodPost, path: "/v1/posts", status: http.StatusCreated, wantEmpty: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := cache.InvalidateOnWrite(&countingHandler{status: tt.status})
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+			if empty := cache.Len() == 0; empty != tt.wantEmpty {
+				t.Errorf("expected empty=%v, got %d entries", tt.wantEmpty, cache.Len())
+			}
+		})
+	}
+}
@@ -2,12 +2,12 @@ package middleware
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -35,18 +35,18 @@ func RoomAccessGuard(roomRepo *db.RoomRepository, memberRepo *db.RoomMemberRepos
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			slug := chi.URLParam(r, "slug")
 			if slug == "" {
-				roomGuardError(w, http.StatusBadRequest, "VALIDATION_ERROR", "slug is required")
+				apierror.Write(w, apierror.ValidationError, "slug is required")
 				return
 			}
 
 			room, err := roomRepo.GetBySlug(r.Context(), slug)
 			if err != nil {
 				if errors.Is(err, db.ErrRoomNotFound) {
-					roomGuardError(w, http.StatusNotFound, "NOT_FOUND", "room not found")
+					apierror.Write(w, apierror.NotFound, "room not found")
 					return
 				}
 				slog.Error("room access guard: failed to load room", "error", err, "slug", slug)
-				roomGuardError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to load room")
+				apierror.Write(w, apierror.InternalError, "failed to load room")
 				return
 			}
 
@@ -59,11 +59,11 @@ func RoomAccessGuard(roomRepo *db.RoomRepository, memberRepo *db.RoomMemberRepos
 			allowed, err := roomMemberAccessAllowed(r, room, memberRepo, agentTokenRepo)
 			if err != nil {
 				slog.Error("room access guard: membership check failed", "error", err, "slug", slug)
-				roomGuardError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "failed to check room access")
+				apierror.Write(w, apierror.InternalError, "failed to check room access")
 				return
 			}
 			if !allowed {
-				roomGuardError(w, http.StatusForbidden, "FORBIDDEN", "this room is closed to non-members")
+				apierror.Write(w, apierror.Forbidden, "this room is closed to non-members")
 				return
 			}
 
@@ -131,12 +131,3 @@ func roomBearerToken(r *http.Request) string {
 	}
 	return r.URL.Query().Get("token")
 }
-
-// roomGuardError writes a JSON error response mirroring the room handlers' shape.
-func roomGuardError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(map[string]any{
-		"error": map[string]string{"code": code, "message": message},
-	})
-}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+)
+
+// NoRouteTimeout disables the request timeout for a route (event streams).
+const NoRouteTimeout time.Duration = -1
+
+// RouteLimit is the time and body size a request may use.
+type RouteLimit struct {
+	// Timeout bounds reading the body and running the handler. Zero inherits
+	// the default; NoRouteTimeout disables it.
+	Timeout time.Duration
+	// MaxBodyBytes caps the request body. Zero inherits the default.
+	MaxBodyBytes int64
+}
+
+// RouteLimitRule overrides the default limit for matching requests.
+// Pattern is a path such as "/v1/posts/{id}/vote": a {name} segment matches
+// any one segment and a trailing "*" matches the rest of the path. An empty
+// Method matches every method.
+type RouteLimitRule struct {
+	Method  string
+	Pattern string
+	Limit   RouteLimit
+}
+
+// RouteLimits enforces per-route request timeouts and body size limits.
+// Slow requests get 408 REQUEST_TIMEOUT and oversized bodies get 413
+// PAYLOAD_TOO_LARGE, so long imports and uploads can be given room without
+// loosening the limits on every other route.
+type RouteLimits struct {
+	defaults RouteLimit
+	rules    []RouteLimitRule
+}
+
+// NewRouteLimits creates RouteLimits. Rules are checked in order; the first
+// match wins and its zero fields fall back to defaults.
+func NewRouteLimits(defaults RouteLimit, rules []RouteLimitRule) *RouteLimits {
+	return &RouteLimits{defaults: defaults, rules: rules}
+}
+
+// Lookup returns the limit for a request.
+func (l *RouteLimits) Lookup(method, path string) RouteLimit {
+	limit := l.defaults
+	for _, rule := range l.rules {
+		if (rule.Method == "" || rule.Method == method) && matchRoutePattern(rule.Pattern, path) {
+			if rule.Limit.Timeout != 0 {
+				limit.Timeout = rule.Limit.Timeout
+			}
+			if rule.Limit.MaxBodyBytes != 0 {
+				limit.MaxBodyBytes = rule.Limit.MaxBodyBytes
+			}
+			break
+		}
+	}
+	return limit
+}
+
+// Middleware applies the request's limit. The body limit works like BodyLimit
+// but also covers multipart uploads, which get their own rule. The timeout
+// sets the connection read deadline and the request context deadline; if the
+// handler hasn't written anything when it expires, the client gets 408.
+func (l *RouteLimits) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := l.Lookup(r.Method, r.URL.Path)
+
+		if limit.MaxBodyBytes > 0 && r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions {
+			if r.ContentLength > limit.MaxBodyBytes {
+				writeBodyLimitError(w)
+				return
+			}
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, limit.MaxBodyBytes)
+			}
+		}
+
+		if limit.Timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		serveWithTimeout(w, r, next, limit.Timeout)
+	})
+}
+
+// serveWithTimeout runs next in its own goroutine so a handler that ignores
+// its context can't hold the response past the deadline. Unlike
+// http.TimeoutHandler it doesn't buffer the response, and it answers with a
+// JSON error instead of an HTML 503.
+func serveWithTimeout(w http.ResponseWriter, r *http.Request, next http.Handler, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	// Not every ResponseWriter (e.g. httptest.ResponseRecorder) supports deadlines.
+	_ = http.NewResponseController(w).SetReadDeadline(deadline)
+
+	ctx, cancel := context.WithDeadline(r.Context(), deadline)
+	defer cancel()
+
+	tw := &timeoutWriter{ResponseWriter: w, header: w.Header().Clone()}
+	done := make(chan struct{})
+	panicked := make(chan any, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				panicked <- p
+			}
+		}()
+		next.ServeHTTP(tw, r.WithContext(ctx))
+		close(done)
+	}()
+
+	select {
+	case p := <-panicked:
+		panic(p)
+	case <-done:
+	case <-ctx.Done():
+		tw.mu.Lock()
+		defer tw.mu.Unlock()
+		tw.timedOut = true
+		if !tw.wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			apierror.Write(w, apierror.RequestTimeout, fmt.Sprintf("request did not complete within %s", timeout))
+		}
+	}
+}
+
+// timeoutWriter drops writes made after the deadline, when the middleware has
+// already answered or returned. The handler sets headers on its own copy,
+// which is copied to the real response when it starts, so a late handler
+// never touches the header map the timeout response is written with.
+type timeoutWriter struct {
+	http.ResponseWriter
+	header http.Header
+
+	mu          sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.writeHeaderLocked(code)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.writeHeaderLocked(http.StatusOK)
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+func (tw *timeoutWriter) writeHeaderLocked(code int) {
+	dst := tw.ResponseWriter.Header()
+	for name, values := range tw.header {
+		dst[name] = values
+	}
+	tw.wroteHeader = true
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if f, ok := tw.ResponseWriter.(http.Flusher); ok && !tw.timedOut {
+		if !tw.wroteHeader {
+			tw.writeHeaderLocked(http.StatusOK)
+		}
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
+
+// matchRoutePattern reports whether path matches a RouteLimitRule pattern.
+func matchRoutePattern(pattern, path string) bool {
+	patternSegs := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegs := strings.Split(strings.Trim(path, "/"), "/")
+	for i, seg := range patternSegs {
+		if seg == "*" && i == len(patternSegs)-1 {
+			return true
+		}
+		if i >= len(pathSegs) {
+			return false
+		}
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if pathSegs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != pathSegs[i] {
+			return false
+		}
+	}
+	return len(patternSegs) == len(pathSegs)
+}
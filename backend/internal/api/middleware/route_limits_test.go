@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRouteLimits() *RouteLimits {
+	return NewRouteLimits(RouteLimit{Timeout: time.Second, MaxBodyBytes: 64}, []RouteLimitRule{
+		{Method: http.MethodGet, Pattern: "/v1/rooms/{slug}/stream", Limit: RouteLimit{Timeout: NoRouteTimeout}},
+		{Method: http.MethodPost, Pattern: "/v1/add", Limit: RouteLimit{Timeout: time.Minute, MaxBodyBytes: 1024}},
+		{Method: http.MethodPost, Pattern: "/v1/posts/{id}/vote", Limit: RouteLimit{MaxBodyBytes: 8}},
+		{Pattern: "/v1/admin/*", Limit: RouteLimit{Timeout: 5 * time.Second}},
+	})
+}
+
+func TestRouteLimits_Lookup(t *testing.T) {
+	limits := testRouteLimits()
+	tests := []struct {
+		method string
+		path   string
+		want   RouteLimit
+	}{
+		{http.MethodGet, "/v1/feed", RouteLimit{Timeout: time.Second, MaxBodyBytes: 64}},
+		{http.MethodGet, "/v1/rooms/general/stream", RouteLimit{Timeout: NoRouteTimeout, MaxBodyBytes: 64}},
+		{http.MethodPost, "/v1/add", RouteLimit{Timeout: time.Minute, MaxBodyBytes: 1024}},
+		{http.MethodGet, "/v1/add", RouteLimit{Timeout: time.Second, MaxBodyBytes: 64}},
+		{http.MethodPost, "/v1/posts/p1/vote", RouteLimit{Timeout: time.Second, MaxBodyBytes: 8}},
+		{http.MethodPost, "/v1/posts/p1/vote/extra", RouteLimit{Timeout: time.Second, MaxBodyBytes: 64}},
+		{http.MethodPost, "/v1/posts//vote", RouteLimit{Timeout: time.Second, MaxBodyBytes: 64}},
+		{http.MethodDelete, "/v1/admin/users/u1", RouteLimit{Timeout: 5 * time.Second, MaxBodyBytes: 64}},
+	}
+	for _, tt := range tests {
+		if got := limits.Lookup(tt.method, tt.path); got != tt.want {
+			t.Errorf("Lookup(%s %s) = %+v, want %+v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRouteLimits_BodyTooLarge(t *testing.T) {
+	handler := testRouteLimits().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			writeBodyLimitError(w)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		chunked    bool
+		wantStatus int
+	}{
+		{name: "vote within limit", path: "/v1/posts/p1/vote", body: `{"d":1}`, wantStatus: http.StatusOK},
+		{name: "vote over limit", path: "/v1/posts/p1/vote", body: `{"direction":"up"}`, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "upload gets its own limit", path: "/v1/add", body: strings.Repeat("x", 512), wantStatus: http.StatusOK},
+		{name: "default limit without Content-Length", path: "/v1/posts", body: strings.Repeat("x", 100), chunked: true, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d", tt.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestRouteLimits_Timeout(t *testing.T) {
+	limits := NewRouteLimits(RouteLimit{Timeout: 20 * time.Millisecond}, nil)
+	handler := limits.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Late", "1")
+		// A handler that ignores its context still gets cut off.
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("too late"))
+	}))
+
+	rr := httptest.NewRecorder()
+	start := time.Now()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/search?q=slow", nil))
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("expected response at the deadline, took %v", elapsed)
+	}
+	if rr.Code != http.StatusRequestTimeout {
+		t.Fatalf("expected 408, got %d", rr.Code)
+	}
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("expected JSON error body: %v", err)
+	}
+	if body.Error.Code != "REQUEST_TIMEOUT" {
+		t.Errorf("expected REQUEST_TIMEOUT, got %q", body.Error.Code)
+	}
+	if rr.Header().Get("X-Late") != "" {
+		t.Error("headers set by the timed-out handler must not leak into the 408")
+	}
+}
+
+func TestRouteLimits_ContextDeadline(t *testing.T) {
+	limits := NewRouteLimits(RouteLimit{Timeout: 20 * time.Millisecond}, nil)
+	var sawDeadline bool
+	handler := limits.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, sawDeadline = r.Context().Deadline()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/feed", nil))
+	if rr.Code != http.StatusNoContent {
+		t.Errorf("expected handler's status, got %d", rr.Code)
+	}
+	if !sawDeadline {
+		t.Error("expected the request context to carry the route deadline")
+	}
+}
+
+func TestRouteLimits_NoTimeoutForStreams(t *testing.T) {
+	handler := testRouteLimits().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("stream route must not get a deadline")
+		}
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("stream route must keep the Flusher")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/rooms/general/stream", nil))
+}
+
+func TestRouteLimits_PanicPropagates(t *testing.T) {
+	handler := testRouteLimits().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	defer func() {
+		if recover() == nil {
+			t.Error("expected the handler's panic to reach the caller's Recoverer")
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/feed", nil))
+}
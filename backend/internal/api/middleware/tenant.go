@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+// DefaultTenantCacheTTL is how long the resolver trusts its tenant list before
+// reloading it, so hostname changes made on another replica apply within a minute.
+const DefaultTenantCacheTTL = time.Minute
+
+// TenantLister loads the tenants a deployment serves.
+type TenantLister interface {
+	List(ctx context.Context) ([]models.Tenant, error)
+}
+
+// TenantResolver maps requests to tenants and scopes their context to one.
+type TenantResolver struct {
+	lister TenantLister
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	byHost   map[string]string
+	ids      map[string]bool
+	loadedAt time.Time
+}
+
+// NewTenantResolver creates a TenantResolver that caches the tenant list for ttl.
+func NewTenantResolver(lister TenantLister, ttl time.Duration) *TenantResolver {
+	return &TenantResolver{lister: lister, ttl: ttl}
+}
+
+// Invalidate drops the cached tenant list so the next request reloads it.
+func (t *TenantResolver) Invalidate() {
+	t.mu.Lock()
+	t.loadedAt = time.Time{}
+	t.mu.Unlock()
+}
+
+// Middleware resolves the request's tenant and stores it in the context: the
+// tenant owning the request hostname, else the tenant named in the
+// X-Solvr-Tenant header, else the default tenant. A header naming an unknown
+// tenant is rejected with 404 TENANT_NOT_FOUND. The resolved tenant is echoed in
+// the X-Solvr-Tenant response header.
+func (t *TenantResolver) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		byHost, ids, ok := t.tenants(r.Context())
+		if !ok {
+			apierror.Write(w, apierror.ServiceUnavailable, "tenants could not be loaded")
+			return
+		}
+
+		id, ok := byHost[requestHostname(r)]
+		if !ok {
+			id = tenant.DefaultID
+			if name := strings.ToLower(strings.TrimSpace(r.Header.Get(tenant.Header))); name != "" {
+				if !ids[name] {
+					apierror.Write(w, apierror.TenantNotFound, "tenant not found")
+					return
+				}
+				id = name
+			}
+		}
+
+		w.Header().Set(tenant.Header, id)
+		next.ServeHTTP(w, r.WithContext(tenant.WithID(r.Context(), id)))
+	})
+}
+
+// tenants returns the cached hostname and ID indexes, reloading them once the
+// TTL has passed. A failed reload keeps serving the previous list; ok is false
+// only when no list has loaded yet.
+func (t *TenantResolver) tenants(ctx context.Context) (byHost map[string]string, ids map[string]bool, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.ids != nil && time.Since(t.loadedAt) < t.ttl {
+		return t.byHost, t.ids, true
+	}
+
+	list, err := t.lister.List(ctx)
+	if err != nil {
+		slog.Error("failed to load tenants", "error", err)
+		if t.ids == nil {
+			return nil, nil, false
+		}
+		t.loadedAt = time.Now() // retry after another TTL rather than on every request
+		return t.byHost, t.ids, true
+	}
+	t.byHost = make(map[string]string)
+	t.ids = make(map[string]bool, len(list))
+	for _, tn := range list {
+		t.ids[tn.ID] = true
+		for _, host := range tn.Hostnames {
+			t.byHost[strings.ToLower(host)] = tn.ID
+		}
+	}
+	t.loadedAt = time.Now()
+	return t.byHost, t.ids, true
+}
+
+// requestHostname returns the request's Host without port, lowercased.
+func requestHostname(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+type stubTenantLister struct {
+	tenants []models.Tenant
+	err     error
+	calls   int
+}
+
+func (s *stubTenantLister) List(ctx context.Context) ([]models.Tenant, error) {
+	s.calls++
+	return s.tenants, s.err
+}
+
+func TestTenantResolver_Middleware(t *testing.T) {
+	lister := &stubTenantLister{tenants: []models.Tenant{
+		{ID: tenant.DefaultID, Hostnames: []string{"solvr.dev"}},
+		{ID: "acme", Hostnames: []string{"acme.solvr.dev"}},
+		{ID: "globex"},
+	}}
+	resolver := NewTenantResolver(lister, time.Minute)
+	var got string
+	handler := resolver.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = tenant.FromContext(r.Context())
+	}))
+
+	tests := []struct {
+		name       string
+		host       string
+		header     string
+		wantStatus int
+		wantTenant string
+	}{
+		{name: "mapped hostname", host: "acme.solvr.dev", wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "hostname with port and case", host: "ACME.solvr.dev:8080", wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "hostname wins over header", host: "acme.solvr.dev", header: "globex", wantStatus: http.StatusOK, wantTenant: "acme"},
+		{name: "header on shared host", host: "api.example.com", header: "globex", wantStatus: http.StatusOK, wantTenant: "globex"},
+		{name: "unknown header tenant", host: "api.example.com", header: "initech", wantStatus: http.StatusNotFound},
+		{name: "default tenant", host: "api.example.com", wantStatus: http.StatusOK, wantTenant: tenant.DefaultID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+			req.Host = tt.host
+			if tt.header != "" {
+				req.Header.Set(tenant.Header, tt.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, rr.Code)
+			}
+			if got != tt.wantTenant {
+				t.Errorf("expected tenant %q, got %q", tt.wantTenant, got)
+			}
+			if tt.wantTenant != "" && rr.Header().Get(tenant.Header) != tt.wantTenant {
+				t.Errorf("expected %s response header %q, got %q", tenant.Header, tt.wantTenant, rr.Header().Get(tenant.Header))
+			}
+		})
+	}
+
+	if lister.calls != 1 {
+		t.Errorf("expected tenants to load once, loaded %d times", lister.calls)
+	}
+	resolver.Invalidate()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/posts", nil))
+	if lister.calls != 2 {
+		t.Errorf("expected Invalidate to force a reload, loaded %d times", lister.calls)
+	}
+}
+
+func TestTenantResolver_LoadFailure(t *testing.T) {
+	lister := &stubTenantLister{err: errors.New("connection refused")}
+	resolver := NewTenantResolver(lister, time.Minute)
+	handler := resolver.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/posts", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 before any tenant list loads, got %d", rr.Code)
+	}
+
+	// Once loaded, a failing reload keeps serving the previous list.
+	lister.tenants, lister.err = []models.Tenant{{ID: tenant.DefaultID}}, nil
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/posts", nil))
+	lister.err = errors.New("connection refused")
+	resolver.Invalidate()
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/posts", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected stale tenant list to keep serving, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,321 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+const (
+	// DefaultUsageFlushInterval is how often buffered usage counters are written to the store.
+	DefaultUsageFlushInterval = 30 * time.Second
+
+	// DefaultUsageRetention is how long daily usage rows are kept before pruning.
+	DefaultUsageRetention = 90 * 24 * time.Hour
+
+	// DefaultUsageMaxPendingKeys caps the buckets kept for retry while the store
+	// is failing; counters for new buckets beyond it are dropped.
+	DefaultUsageMaxPendingKeys = 50000
+)
+
+// UsageStore persists aggregated API usage counters.
+type UsageStore interface {
+	RecordUsage(ctx context.Context, increments []models.APIUsageIncrement) error
+	PruneOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// usageKey identifies one api_usage_daily bucket.
+type usageKey struct {
+	principalType string
+	principalID   string
+	day           string
+	method        string
+	route         string
+}
+
+// UsageRecorder counts authenticated requests per principal, day, and route pattern.
+// Counters are buffered in memory and flushed to the store in batches so the
+// request path never waits on the database. While maintenance mode is on, counting
+// goes on but nothing is written; the counters are flushed once it is off again.
+type UsageRecorder struct {
+	store       UsageStore
+	retention   time.Duration
+	maintenance MaintenanceState
+	maxPending  int
+
+	mu      sync.Mutex
+	pending map[usageKey]*models.APIUsageIncrement
+	dropped int64 // requests dropped by requeue since start
+
+	now func() time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewUsageRecorder creates a UsageRecorder and starts its background flush loop.
+// Close stops the loop and writes the last batch.
+func NewUsageRecorder(store UsageStore) *UsageRecorder {
+	u := newUsageRecorder(store)
+	ctx, cancel := context.WithCancel(context.Background())
+	u.cancel = cancel
+	u.done = make(chan struct{})
+	go u.run(ctx, DefaultUsageFlushInterval)
+	return u
+}
+
+// newUsageRecorder creates a UsageRecorder without starting the flush loop.
+func newUsageRecorder(store UsageStore) *UsageRecorder {
+	return &UsageRecorder{
+		store:      store,
+		retention:  DefaultUsageRetention,
+		maxPending: DefaultUsageMaxPendingKeys,
+		pending:    make(map[usageKey]*models.APIUsageIncrement),
+		now:        time.Now,
+	}
+}
+
+// SetMaintenanceState pauses flushing and pruning while state reports maintenance mode.
+func (u *UsageRecorder) SetMaintenanceState(state MaintenanceState) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.maintenance = state
+}
+
+// paused reports whether maintenance mode is on.
+func (u *UsageRecorder) paused() bool {
+	u.mu.Lock()
+	state := u.maintenance
+	u.mu.Unlock()
+	return state != nil && state.Status().Enabled
+}
+
+// Middleware records one request against the authenticated principal and tags the
+// request log entry with it. It must run after an auth middleware; anonymous requests
+// are not counted.
+func (u *UsageRecorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := u.now()
+		rec := &usageStatusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		principalType, principalID := usagePrincipal(r)
+		if principalID == "" {
+			return
+		}
+		SetLogPrincipal(r.Context(), principalType, principalID)
+
+		route := r.URL.Path
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			if pattern := rctx.RoutePattern(); pattern != "" {
+				route = pattern
+			}
+		}
+
+		u.add(principalType, principalID, r.Method, route, rec.status, u.now().Sub(start))
+	})
+}
+
+// add accumulates a single request into the pending buffer.
+func (u *UsageRecorder) add(principalType, principalID, method, route string, status int, duration time.Duration) {
+	day := u.now().UTC().Truncate(24 * time.Hour)
+	key := usageKey{
+		principalType: principalType,
+		principalID:   principalID,
+		day:           day.Format("2006-01-02"),
+		method:        method,
+		route:         route,
+	}
+	ms := int(duration.Milliseconds())
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	inc, ok := u.pending[key]
+	if !ok {
+		inc = &models.APIUsageIncrement{
+			PrincipalType: principalType,
+			PrincipalID:   principalID,
+			Day:           day,
+			Method:        method,
+			Route:         route,
+		}
+		u.pending[key] = inc
+	}
+	inc.Requests++
+	if status >= 400 {
+		inc.Errors++
+	}
+	inc.TotalDurationMs += int64(ms)
+	if ms > inc.MaxDurationMs {
+		inc.MaxDurationMs = ms
+	}
+}
+
+// Flush writes all buffered counters to the store.
+// On failure the counters are merged back so the next flush retries them.
+func (u *UsageRecorder) Flush(ctx context.Context) error {
+	u.mu.Lock()
+	if len(u.pending) == 0 {
+		u.mu.Unlock()
+		return nil
+	}
+	batch := u.pending
+	u.pending = make(map[usageKey]*models.APIUsageIncrement)
+	u.mu.Unlock()
+
+	increments := make([]models.APIUsageIncrement, 0, len(batch))
+	for _, inc := range batch {
+		increments = append(increments, *inc)
+	}
+
+	if err := u.store.RecordUsage(ctx, increments); err != nil {
+		u.requeue(batch)
+		return err
+	}
+	return nil
+}
+
+// requeue merges a failed batch back into the pending buffer. Buckets that
+// would grow the buffer past maxPending keys are dropped and logged, so a store
+// that keeps failing cannot exhaust memory.
+func (u *UsageRecorder) requeue(batch map[usageKey]*models.APIUsageIncrement) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var droppedKeys int
+	var droppedRequests int64
+	for key, inc := range batch {
+		existing, ok := u.pending[key]
+		if !ok {
+			if len(u.pending) >= u.maxPending {
+				droppedKeys++
+				droppedRequests += inc.Requests
+				continue
+			}
+			u.pending[key] = inc
+			continue
+		}
+		existing.Requests += inc.Requests
+		existing.Errors += inc.Errors
+		existing.TotalDurationMs += inc.TotalDurationMs
+		if inc.MaxDurationMs > existing.MaxDurationMs {
+			existing.MaxDurationMs = inc.MaxDurationMs
+		}
+	}
+	if droppedKeys > 0 {
+		u.dropped += droppedRequests
+		log.Printf("Usage recorder: retry buffer full (%d keys), dropped %d counters covering %d requests (%d requests dropped since start)",
+			u.maxPending, droppedKeys, droppedRequests, u.dropped)
+	}
+}
+
+// Close stops the flush loop and writes the counters still buffered. Call it once
+// the HTTP server has shut down, so no request is counted after the last flush.
+// While maintenance mode is on the counters are dropped instead, like any other
+// write held during maintenance.
+func (u *UsageRecorder) Close(ctx context.Context) error {
+	if u.cancel != nil {
+		u.cancel()
+		select {
+		case <-u.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if u.paused() {
+		u.mu.Lock()
+		dropped := len(u.pending)
+		u.mu.Unlock()
+		if dropped > 0 {
+			log.Printf("Usage recorder: maintenance mode on at shutdown, dropping %d counters", dropped)
+		}
+		return nil
+	}
+	return u.Flush(ctx)
+}
+
+// run flushes on every tick and prunes expired rows once a day, until ctx is done.
+func (u *UsageRecorder) run(ctx context.Context, interval time.Duration) {
+	defer close(u.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u.tick(&lastPrune)
+		}
+	}
+}
+
+// tick flushes the buffered counters and prunes expired rows when the last prune
+// is a day old. It writes nothing while maintenance mode is on.
+func (u *UsageRecorder) tick(lastPrune *time.Time) {
+	if u.paused() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := u.Flush(ctx); err != nil {
+		log.Printf("Usage recorder: flush failed: %v", err)
+	}
+	if u.now().Sub(*lastPrune) >= 24*time.Hour {
+		cutoff := u.now().Add(-u.retention)
+		if pruned, err := u.store.PruneOlderThan(ctx, cutoff); err != nil {
+			log.Printf("Usage recorder: prune failed: %v", err)
+		} else if pruned > 0 {
+			log.Printf("Usage recorder: pruned %d rows older than %s", pruned, cutoff.Format("2006-01-02"))
+		}
+		*lastPrune = u.now()
+	}
+}
+
+// usagePrincipal returns the principal type and ID for the request, agent first.
+func usagePrincipal(r *http.Request) (string, string) {
+	if p := auth.PrincipalFromContext(r.Context()); p != nil {
+		return string(p.Type), p.ID
+	}
+	return "", ""
+}
+
+// usageStatusRecorder captures the response status code.
+type usageStatusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (rw *usageStatusRecorder) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.status = code
+		rw.wroteHeader = true
+		rw.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (rw *usageStatusRecorder) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher so SSE handlers behind this middleware keep streaming.
+func (rw *usageStatusRecorder) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/maintenance"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockUsageStore implements UsageStore for testing.
+type mockUsageStore struct {
+	mu       sync.Mutex
+	recorded []models.APIUsageIncrement
+	failNext bool
+}
+
+func (m *mockUsageStore) RecordUsage(ctx context.Context, increments []models.APIUsageIncrement) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failNext {
+		m.failNext = false
+		return errors.New("db down")
+	}
+	m.recorded = append(m.recorded, increments...)
+	return nil
+}
+
+func (m *mockUsageStore) PruneOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newUsageTestRouter(u *UsageRecorder) http.Handler {
+	r := chi.NewRouter()
+	r.Use(u.Middleware)
+	r.Get("/v1/posts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if chi.URLParam(r, "id") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	return r
+}
+
+func TestUsageRecorder_AggregatesByRoutePattern(t *testing.T) {
+	store := &mockUsageStore{}
+	u := newUsageRecorder(store)
+	router := newUsageTestRouter(u)
+
+	for _, id := range []string{"a", "b", "missing"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/posts/"+id, nil)
+		req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_usage"}))
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if err := u.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if len(store.recorded) != 1 {
+		t.Fatalf("expected 1 aggregated row, got %d", len(store.recorded))
+	}
+	got := store.recorded[0]
+	if got.PrincipalType != "agent" || got.PrincipalID != "agent_usage" {
+		t.Errorf("unexpected principal %s/%s", got.PrincipalType, got.PrincipalID)
+	}
+	if got.Route != "/v1/posts/{id}" {
+		t.Errorf("expected route pattern /v1/posts/{id}, got %s", got.Route)
+	}
+	if got.Requests != 3 {
+		t.Errorf("expected 3 requests, got %d", got.Requests)
+	}
+	if got.Errors != 1 {
+		t.Errorf("expected 1 error, got %d", got.Errors)
+	}
+}
+
+func TestUsageRecorder_SeparatesPrincipals(t *testing.T) {
+	store := &mockUsageStore{}
+	u := newUsageRecorder(store)
+	router := newUsageTestRouter(u)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/a", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_usage"}))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/posts/a", nil)
+	req = req.WithContext(auth.ContextWithClaims(req.Context(), &auth.Claims{UserID: "user-usage"}))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if err := u.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(store.recorded) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(store.recorded))
+	}
+}
+
+func TestUsageRecorder_SkipsAnonymous(t *testing.T) {
+	store := &mockUsageStore{}
+	u := newUsageRecorder(store)
+	router := newUsageTestRouter(u)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/posts/a", nil))
+
+	if err := u.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(store.recorded) != 0 {
+		t.Errorf("expected anonymous request not to be recorded, got %d rows", len(store.recorded))
+	}
+}
+
+func TestUsageRecorder_RequeuesOnFlushFailure(t *testing.T) {
+	store := &mockUsageStore{failNext: true}
+	u := newUsageRecorder(store)
+	router := newUsageTestRouter(u)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/a", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_usage"}))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if err := u.Flush(context.Background()); err == nil {
+		t.Fatal("expected first flush to fail")
+	}
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if err := u.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(store.recorded) != 1 || store.recorded[0].Requests != 2 {
+		t.Fatalf("expected requeued counters to merge into 2 requests, got %+v", store.recorded)
+	}
+}
+
+func TestUsageRecorder_RequeueCapsPendingKeys(t *testing.T) {
+	store := &mockUsageStore{failNext: true}
+	u := newUsageRecorder(store)
+	u.maxPending = 2
+	router := newUsageTestRouter(u)
+
+	for _, id := range []string{"a", "b", "c"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/posts/"+id, nil)
+		req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_" + id}))
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if err := u.Flush(context.Background()); err == nil {
+		t.Fatal("expected first flush to fail")
+	}
+	if len(u.pending) != 2 || u.dropped != 1 {
+		t.Fatalf("expected 2 requeued keys and 1 dropped request, got %d keys, %d dropped", len(u.pending), u.dropped)
+	}
+
+	if err := u.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(store.recorded) != 2 {
+		t.Fatalf("expected the 2 requeued counters to be written, got %+v", store.recorded)
+	}
+}
+
+func TestUsageRecorder_HoldsWritesDuringMaintenance(t *testing.T) {
+	store := &mockUsageStore{}
+	u := newUsageRecorder(store)
+	mode := maintenance.New(true, "")
+	u.SetMaintenanceState(mode)
+	router := newUsageTestRouter(u)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/a", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_usage"}))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	var lastPrune time.Time
+	u.tick(&lastPrune)
+	if len(store.recorded) != 0 || !lastPrune.IsZero() {
+		t.Fatalf("expected no writes during maintenance, got %+v", store.recorded)
+	}
+
+	mode.Set(false, "")
+	u.tick(&lastPrune)
+	if len(store.recorded) != 1 || store.recorded[0].Requests != 1 {
+		t.Fatalf("expected the held counters to flush after maintenance, got %+v", store.recorded)
+	}
+}
+
+func TestUsageRecorder_CloseFlushesLastBatch(t *testing.T) {
+	store := &mockUsageStore{}
+	u := NewUsageRecorder(store)
+	router := newUsageTestRouter(u)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts/a", nil)
+	req = req.WithContext(auth.ContextWithAgent(req.Context(), &models.Agent{ID: "agent_usage"}))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if err := u.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.recorded) != 1 || store.recorded[0].Requests != 1 {
+		t.Fatalf("expected Close to flush the buffered request, got %+v", store.recorded)
+	}
+	select {
+	case <-u.done:
+	default:
+		t.Error("expected the flush loop to have stopped")
+	}
+}
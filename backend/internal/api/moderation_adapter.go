@@ -5,7 +5,9 @@ import (
 	"fmt"
 
 	"github.com/fcavalcantirj/solvr/internal/api/handlers"
+	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
 	"github.com/fcavalcantirj/solvr/internal/services"
 )
 
@@ -90,3 +92,12 @@ func NewModerationNotificationService(createFunc func(ctx context.Context, n *mo
 	repo := &notifRepoForService{create: createFunc}
 	return services.NewNotificationService(repo, nil, nil, nil, nil)
 }
+
+// NewModerationCommentRenderer renders moderation system comments from the
+// admin-edited templates in pool, or the built-in ones when pool is nil.
+func NewModerationCommentRenderer(pool *db.Pool) *modtemplate.Renderer {
+	if pool == nil {
+		return modtemplate.NewRenderer(nil, modtemplate.AppealURLFromEnv())
+	}
+	return modtemplate.NewRenderer(db.NewModerationTemplateRepository(pool), modtemplate.AppealURLFromEnv())
+}
@@ -0,0 +1,151 @@
+// Package api provides HTTP routing and handlers for the Solvr API.
+// This file generates OpenAPI schemas from Go types so the spec follows the
+// models and request structs instead of drifting from them.
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaEnums lists the allowed values of named string types used in schemas.
+var schemaEnums = map[reflect.Type][]string{
+	reflect.TypeOf(models.PostType("")):       {string(models.PostTypeProblem), string(models.PostTypeQuestion), string(models.PostTypeIdea)},
+	reflect.TypeOf(models.AuthorType("")):     {string(models.AuthorTypeHuman), string(models.AuthorTypeAgent)},
+	reflect.TypeOf(models.ApproachStatus("")): {"starting", "working", "stuck", "failed", "succeeded", "abandoned"},
+	reflect.TypeOf(models.ResponseType("")):   responseTypeEnum(),
+
+	reflect.TypeOf(models.ApproachOutcomeType("")):   enumStrings(models.ApproachOutcomeTypes),
+	reflect.TypeOf(models.ApproachFailureReason("")): enumStrings(models.ApproachFailureReasons),
+	reflect.TypeOf(models.TranscriptEntryType("")):   enumStrings(models.TranscriptEntryTypes),
+}
+
+// enumStrings returns the values of a named string type as strings.
+func enumStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// responseTypeEnum returns the idea response types as strings.
+func responseTypeEnum() []string {
+	var values []string
+	for _, t := range models.ValidResponseTypes() {
+		values = append(values, string(t))
+	}
+	return values
+}
+
+// schemaOf returns the OpenAPI schema for v's type, following its json tags.
+// Fields tagged json:"-" are skipped, embedded structs are flattened, pointers
+// are nullable, and time.Time is a date-time string.
+func schemaOf(v interface{}) map[string]interface{} {
+	return typeSchema(reflect.TypeOf(v), map[reflect.Type]bool{})
+}
+
+// typeSchema builds the schema for t. seen guards against recursive types.
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	if t.Kind() == reflect.Ptr {
+		s := typeSchema(t.Elem(), seen)
+		s["nullable"] = true
+		return s
+	}
+
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]interface{}{}
+	}
+
+	if enum, ok := schemaEnums[t]; ok {
+		return map[string]interface{}{"type": "string", "enum": enum}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		props := map[string]interface{}{}
+		addStructProperties(t, props, seen)
+		return map[string]interface{}{"type": "object", "properties": props}
+	}
+	// interface{} and anything else: any value
+	return map[string]interface{}{}
+}
+
+// addStructProperties adds t's JSON fields to props, flattening embedded structs.
+func addStructProperties(t reflect.Type, props map[string]interface{}, seen map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructProperties(ft, props, seen)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = typeSchema(f.Type, seen)
+	}
+}
+
+// withRequired marks fields of an object schema as required.
+func withRequired(schema map[string]interface{}, fields ...string) map[string]interface{} {
+	schema["required"] = fields
+	return schema
+}
+
+// withConstraint sets a validation keyword (minLength, maxItems, ...) on one property.
+func withConstraint(schema map[string]interface{}, field, keyword string, value interface{}) map[string]interface{} {
+	props, _ := schema["properties"].(map[string]interface{})
+	if prop, ok := props[field].(map[string]interface{}); ok {
+		prop[keyword] = value
+	}
+	return schema
+}
@@ -2,6 +2,14 @@
 // This file contains OpenAPI schema definitions for the Solvr API specification.
 package api
 
+import (
+	"reflect"
+
+	"github.com/fcavalcantirj/solvr/internal/api/handlers"
+	"github.com/fcavalcantirj/solvr/internal/config"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
 // buildComponents returns the OpenAPI components section
 func buildComponents() map[string]interface{} {
 	return map[string]interface{}{
@@ -18,6 +26,12 @@ func buildSecuritySchemes() map[string]interface{} {
 			"description":  "JWT token or API key",
 			"bearerFormat": "JWT or API Key",
 		},
+		"adminKey": map[string]interface{}{
+			"type":        "apiKey",
+			"in":          "header",
+			"name":        "X-Admin-API-Key",
+			"description": "Admin API key (ADMIN_API_KEY)",
+		},
 	}
 }
 
@@ -29,12 +43,17 @@ func buildSchemas() map[string]interface{} {
 		"PaginationMeta":            paginationMetaSchema(),
 		"PostsResponse":             postsResponseSchema(),
 		"PostResponse":              postResponseSchema(),
+		"CreatedPostResponse":       createdPostResponseSchema(),
 		"Post":                      postSchema(),
 		"CreatePostRequest":         createPostRequestSchema(),
 		"UpdatePostRequest":         updatePostRequestSchema(),
 		"VoteRequest":               voteRequestSchema(),
 		"VoteResponse":              voteResponseSchema(),
 		"ViewCountResponse":         viewCountResponseSchema(),
+		"PostAnalyticsResponse":     postAnalyticsResponseSchema(),
+		"PostMetaResponse":          postMetaResponseSchema(),
+		"SuggestQuestionsResponse":  suggestQuestionsResponseSchema(),
+		"QuestionContextResponse":   questionContextResponseSchema(),
 		"CommentsResponse":          commentsResponseSchema(),
 		"CommentResponse":           commentResponseSchema(),
 		"Comment":                   commentSchema(),
@@ -45,11 +64,23 @@ func buildSchemas() map[string]interface{} {
 		"CreateApproachRequest":     createApproachRequestSchema(),
 		"UpdateApproachRequest":     updateApproachRequestSchema(),
 		"ProgressNoteRequest":       progressNoteRequestSchema(),
+		"MarkStuckRequest":          markStuckRequestSchema(),
+		"StuckEscalationResponse":   stuckEscalationResponseSchema(),
+		"UpdateBountyRequest":       updateBountyRequestSchema(),
+		"BountyResponse":            bountyResponseSchema(),
+		"VerifyApproachRequest":     schemaOf(handlers.VerifyApproachRequest{}),
+		"ProblemInsightsResponse":   problemInsightsResponseSchema(),
+		"ApproachTimelineResponse":  approachTimelineResponseSchema(),
+		"AttachTranscriptRequest":   withRequired(schemaOf(handlers.AttachTranscriptRequest{}), "entries"),
+		"TranscriptResponse":        transcriptResponseSchema(),
 		"AnswersResponse":           answersResponseSchema(),
 		"AnswerResponse":            answerResponseSchema(),
+		"CreatedAnswerResponse":     createdAnswerResponseSchema(),
 		"Answer":                    answerSchema(),
 		"CreateAnswerRequest":       createAnswerRequestSchema(),
 		"UpdateAnswerRequest":       updateAnswerRequestSchema(),
+		"AnswerVersionsResponse":    answerVersionsResponseSchema(),
+		"PostFreshnessResponse":     postFreshnessResponseSchema(),
 		"IdeaResponsesResponse":     ideaResponsesResponseSchema(),
 		"IdeaResponseResponse":      ideaResponseResponseSchema(),
 		"IdeaResponse":              ideaResponseSchema(),
@@ -62,11 +93,22 @@ func buildSchemas() map[string]interface{} {
 		"ClaimURLResponse":          claimURLResponseSchema(),
 		"ClaimInfoResponse":         claimInfoResponseSchema(),
 		"ClaimConfirmResponse":      claimConfirmResponseSchema(),
+		"ClaimAgentRequest":         claimAgentRequestSchema(),
+		"AgentVerifyEmailRequest":   agentVerifyEmailRequestSchema(),
+		"ResendVerificationRequest": resendVerificationRequestSchema(),
+		"CreateGuestProblemRequest": createGuestProblemRequestSchema(),
+		"GuestClaimRequest":         guestClaimRequestSchema(),
+		"RetagPostRequest":          retagPostRequestSchema(),
 		"UserResponse":              userResponseSchema(),
 		"User":                      userSchema(),
 		"MeResponse":                meResponseSchema(),
 		"UpdateProfileRequest":      updateProfileRequestSchema(),
 		"ContributionsResponse":     contributionsResponseSchema(),
+		"UserPublicProfileResponse": userPublicProfileResponseSchema(),
+		"AvatarResponse":            avatarResponseSchema(),
+		"BlockRequest":              schemaOf(handlers.BlockRequest{}),
+		"BlockResponse":             blockResponseSchema(),
+		"BlockListResponse":         blockListResponseSchema(),
 		"APIKeysResponse":           apiKeysResponseSchema(),
 		"APIKeyResponse":            apiKeyResponseSchema(),
 		"APIKey":                    apiKeySchema(),
@@ -82,9 +124,12 @@ func buildSchemas() map[string]interface{} {
 		"ReportResponse":            reportResponseSchema(),
 		"CreateReportRequest":       createReportRequestSchema(),
 		"ReportCheckResponse":       reportCheckResponseSchema(),
+		"ReportContentRequest":      reportContentRequestSchema(),
 		"FeedResponse":              feedResponseSchema(),
+		"FeedBriefingResponse":      feedBriefingResponseSchema(),
 		"StatsResponse":             statsResponseSchema(),
 		"TrendingResponse":          trendingResponseSchema(),
+		"StatsHistoryResponse":      statsHistoryResponseSchema(),
 		"IdeasStatsResponse":        ideasStatsResponseSchema(),
 		"AuthResponse":              authResponseSchema(),
 		"MoltbookAuthRequest":       moltbookAuthRequestSchema(),
@@ -98,6 +143,64 @@ func buildSchemas() map[string]interface{} {
 		"CheckpointsResponse":        checkpointsResponseSchema(),
 		"ResurrectionBundleResponse": resurrectionBundleResponseSchema(),
 		"UpdateIdentityRequest":      updateIdentityRequestSchema(),
+		// MCP and admin
+		"JSONRPCRequest":             jsonRPCRequestSchema(),
+		"JSONRPCResponse":            jsonRPCResponseSchema(),
+		"UpdateUserStatusRequest":    updateUserStatusRequestSchema(),
+		"AuditLog":                   auditLogSchema(),
+		"AuditLogResponse":           auditLogResponseSchema(),
+		// Tags
+		"Tag":                        tagSchema(),
+		"TagListResponse":            tagListResponseSchema(),
+		"TagDetailResponse":          tagDetailResponseSchema(),
+		"RenameTagRequest":           withRequired(schemaOf(handlers.RenameTagRequest{}), "new_name"),
+		"MergeTagsRequest":           withRequired(schemaOf(handlers.MergeTagsRequest{}), "sources", "target"),
+		"BlacklistTagRequest":        schemaOf(handlers.BlacklistTagRequest{}),
+		// Post templates
+		"PostTemplateListResponse": postTemplateListResponseSchema(),
+		// Entities
+		"EntityDetailResponse": entityDetailResponseSchema(),
+		// Success criteria
+		"UpdateSuccessCriterionRequest": withRequired(schemaOf(handlers.UpdateSuccessCriterionRequest{}), "met"),
+		"SuccessCriterionResponse":      successCriterionResponseSchema(),
+		"SuccessCriteriaResponse":       successCriteriaResponseSchema(),
+		// Email
+		"EmailPreferencesResponse":      emailPreferencesResponseSchema(),
+		"UpdateEmailPreferencesRequest": withRequired(schemaOf(handlers.UpdateEmailPreferencesRequest{}), "events"),
+		// Web Push
+		"CreatePushSubscriptionRequest": withRequired(schemaOf(handlers.CreatePushSubscriptionRequest{}), "endpoint", "keys"),
+		"PushSubscriptionResponse":      pushSubscriptionResponseSchema(),
+		"PushSubscriptionListResponse":  pushSubscriptionListResponseSchema(),
+		"VAPIDPublicKeyResponse":        vapidPublicKeyResponseSchema(),
+		// Security
+		"SecurityEventsResponse": securityEventsResponseSchema(),
+		// Usage
+		"MeUsageResponse": meUsageResponseSchema(),
+		// Admin integrations
+		"CreateChatIntegrationRequest": withRequired(schemaOf(handlers.CreateChatIntegrationRequest{}), "name", "provider", "webhook_url", "tags"),
+		"UpdateChatIntegrationRequest": schemaOf(handlers.UpdateChatIntegrationRequest{}),
+		// Admin maintenance
+		"UpdateMaintenanceRequest": withRequired(schemaOf(handlers.UpdateMaintenanceRequest{}), "enabled"),
+		// Admin legal holds
+		"SetPostRetentionRequest": withRequired(schemaOf(handlers.SetPostRetentionRequest{}), "legal_hold"),
+		// Admin moderation templates
+		"PutModerationTemplateRequest": withRequired(schemaOf(handlers.PutModerationTemplateRequest{}), "body"),
+		// Admin tenants
+		"PutTenantRequest": withConstraint(withRequired(schemaOf(handlers.PutTenantRequest{}), "name"), "hostnames", "maxItems", models.MaxTenantHostnames),
+		// GitHub
+		"ImportGitHubIssueRequest": withRequired(schemaOf(handlers.ImportGitHubIssueRequest{}), "issue_url"),
+		"GitHubIssueLinkRequest":   withRequired(schemaOf(handlers.GitHubIssueLinkRequest{}), "issue_url"),
+		// Freshness
+		"FlagOutdatedRequest": withConstraint(schemaOf(handlers.FlagOutdatedRequest{}), "reason", "maxLength", models.MaxOutdatedFlagReasonLength),
+		// Collaborators
+		"AddCollaboratorRequest":    schemaOf(handlers.AddCollaboratorRequest{}),
+		"PostCollaboratorResponse":  postCollaboratorResponseSchema(),
+		"PostCollaboratorsResponse": postCollaboratorsResponseSchema(),
+		// Reactions
+		"ReactionRequest":  reactionRequestSchema(),
+		"ReactionResponse": reactionResponseSchema(),
+		// Content limits
+		"ContentLimitsResponse": contentLimitsResponseSchema(),
 	}
 }
 
@@ -135,6 +238,8 @@ func searchResultSchema() map[string]interface{} {
 			"id": map[string]interface{}{"type": "string"}, "type": map[string]interface{}{"type": "string"},
 			"title": map[string]interface{}{"type": "string"}, "snippet": map[string]interface{}{"type": "string"},
 			"score": map[string]interface{}{"type": "number"}, "status": map[string]interface{}{"type": "string"},
+			"freshness_score": map[string]interface{}{"type": "integer", "description": "0-100, higher is fresher; post results that have been scored"},
+			"outdated":        map[string]interface{}{"type": "boolean", "description": "Set when the post is outdated"},
 		},
 	}
 }
@@ -166,102 +271,228 @@ func postResponseSchema() map[string]interface{} {
 	}
 }
 
-func postSchema() map[string]interface{} {
+// createdPostResponseSchema lists posts with the same stack trace as the new post
+// under possible_duplicates (omitted when there are none).
+func createdPostResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"id": map[string]interface{}{"type": "string"}, "type": map[string]interface{}{"type": "string", "enum": []string{"problem", "question", "idea"}},
-			"title": map[string]interface{}{"type": "string"}, "description": map[string]interface{}{"type": "string"},
-			"status": map[string]interface{}{"type": "string"}, "tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
-			"posted_by_id": map[string]interface{}{"type": "string"}, "posted_by_type": map[string]interface{}{"type": "string"},
-			"upvotes": map[string]interface{}{"type": "integer"}, "downvotes": map[string]interface{}{"type": "integer"},
-			"created_at": map[string]interface{}{"type": "string", "format": "date-time"}, "updated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"data":                map[string]interface{}{"$ref": "#/components/schemas/Post"},
+			"possible_duplicates": map[string]interface{}{"type": "array", "items": schemaOf(models.CrashDuplicate{})},
 		},
 	}
 }
 
+func postSchema() map[string]interface{} {
+	return schemaOf(models.PostWithAuthor{})
+}
+
+// createPostRequestSchema requires only type and title: description may be sent as content.
 func createPostRequestSchema() map[string]interface{} {
+	s := withRequired(schemaOf(handlers.CreatePostRequest{}), "type", "title")
+	withConstraint(s, "type", "enum", []string{string(models.PostTypeProblem), string(models.PostTypeQuestion), string(models.PostTypeIdea)})
+	withPostLengthConstraints(s, "description", "content")
+	withConstraint(s, "tags", "maxItems", models.MaxTagsPerPost)
+	withConstraint(s, "success_criteria", "maxItems", models.MaxSuccessCriteria)
+	withConstraint(s, "weight", "minimum", 1)
+	withConstraint(s, "weight", "maximum", 5)
+	withConstraint(s, "publish_at", "description", "POST /v1/posts only: keep the post a draft until this time (at most 365 days ahead); moderation and embedding run when it is published")
+	return withConstraint(s, "visibility", "enum", []string{models.VisibilityPublic, models.VisibilityFamily})
+}
+
+func updatePostRequestSchema() map[string]interface{} {
+	s := schemaOf(handlers.UpdatePostRequest{})
+	withPostLengthConstraints(s, "description")
+	return withConstraint(s, "tags", "maxItems", models.MaxTagsPerPost)
+}
+
+// withPostLengthConstraints sets the title and description length keywords to
+// the loosest configured limits across post types, so spec validation never
+// rejects a valid post. Per-type limits are served at GET /v1/config/limits.
+func withPostLengthConstraints(s map[string]interface{}, descriptionFields ...string) {
+	limits := config.DefaultReloader().Current().ContentLimits.Loosest()
+	withLengthConstraint(s, "title", limits.Title)
+	for _, field := range descriptionFields {
+		withLengthConstraint(s, field, limits.Description)
+	}
+}
+
+// withLengthConstraint sets minLength and, when there is one, maxLength.
+func withLengthConstraint(s map[string]interface{}, field string, limit models.LengthLimit) {
+	withConstraint(s, field, "minLength", limit.Min)
+	if limit.Max > 0 {
+		withConstraint(s, field, "maxLength", limit.Max)
+	}
+}
+
+func voteRequestSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type":     "object",
-		"required": []string{"type", "title", "description"},
+		"required": []string{"direction"},
 		"properties": map[string]interface{}{
-			"type": map[string]interface{}{"type": "string", "enum": []string{"problem", "question", "idea"}},
-			"title": map[string]interface{}{"type": "string"}, "description": map[string]interface{}{"type": "string"},
-			"tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"direction": map[string]interface{}{"type": "string", "enum": []string{"up", "down", "none"}, "description": "none retracts your vote"},
 		},
 	}
 }
 
-func updatePostRequestSchema() map[string]interface{} {
+func voteResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"title": map[string]interface{}{"type": "string"}, "description": map[string]interface{}{"type": "string"},
-			"status": map[string]interface{}{"type": "string"}, "tags": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			"upvotes": map[string]interface{}{"type": "integer"}, "downvotes": map[string]interface{}{"type": "integer"},
 		},
 	}
 }
 
-func voteRequestSchema() map[string]interface{} {
+func viewCountResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type":     "object",
-		"required": []string{"direction"},
+		"type": "object",
 		"properties": map[string]interface{}{
-			"direction": map[string]interface{}{"type": "string", "enum": []string{"up", "down"}},
+			"data": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"view_count": map[string]interface{}{"type": "integer"},
+					"series": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"date":  map[string]interface{}{"type": "string", "format": "date"},
+								"views": map[string]interface{}{"type": "integer"},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func voteResponseSchema() map[string]interface{} {
+func questionContextResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"upvotes": map[string]interface{}{"type": "integer"}, "downvotes": map[string]interface{}{"type": "integer"},
+			"data": schemaOf(models.QuestionContext{}),
 		},
 	}
 }
 
-func viewCountResponseSchema() map[string]interface{} {
+func suggestQuestionsResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type":       "object",
-		"properties": map[string]interface{}{"views": map[string]interface{}{"type": "integer"}},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{"type": "array", "items": schemaOf(models.QuestionSuggestion{})},
+			"meta": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"method": map[string]interface{}{"type": "string"}},
+			},
+		},
 	}
 }
 
-func commentsResponseSchema() map[string]interface{} {
+func postMetaResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Comment"}},
+			"data": schemaOf(models.PostMeta{}),
 		},
 	}
 }
 
-func commentResponseSchema() map[string]interface{} {
+func emailPreferencesResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type":       "object",
-		"properties": map[string]interface{}{"data": map[string]interface{}{"$ref": "#/components/schemas/Comment"}},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(models.EmailPreferences{}),
+		},
 	}
 }
 
-func commentSchema() map[string]interface{} {
+func pushSubscriptionResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"id": map[string]interface{}{"type": "string"}, "content": map[string]interface{}{"type": "string"},
-			"posted_by_id": map[string]interface{}{"type": "string"}, "posted_by_type": map[string]interface{}{"type": "string"},
-			"created_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"data": schemaOf(models.PushSubscription{}),
 		},
 	}
 }
 
-func createCommentRequestSchema() map[string]interface{} {
+func pushSubscriptionListResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{"type": "array", "items": schemaOf(models.PushSubscription{})},
+		},
+	}
+}
+
+func securityEventsResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{"type": "array", "items": schemaOf(models.SecurityEvent{})},
+			"meta": map[string]interface{}{"$ref": "#/components/schemas/PaginationMeta"},
+		},
+	}
+}
+
+func meUsageResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(handlers.MeUsageResponse{}),
+		},
+	}
+}
+
+func vapidPublicKeyResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"public_key": map[string]interface{}{"type": "string", "description": "VAPID public key (base64url), for PushManager.subscribe() applicationServerKey"},
+				},
+			},
+		},
+	}
+}
+
+func postAnalyticsResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(models.PostAnalytics{}),
+		},
+	}
+}
+
+func commentsResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Comment"}},
+		},
+	}
+}
+
+func commentResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object", "required": []string{"content"},
-		"properties": map[string]interface{}{"content": map[string]interface{}{"type": "string"}},
+		"type":       "object",
+		"properties": map[string]interface{}{"data": map[string]interface{}{"$ref": "#/components/schemas/Comment"}},
 	}
 }
 
+func commentSchema() map[string]interface{} {
+	return schemaOf(models.CommentWithAuthor{})
+}
+
+func createCommentRequestSchema() map[string]interface{} {
+	s := withRequired(schemaOf(models.CreateCommentRequest{}), "content")
+	return withConstraint(s, "content", "maxLength", models.MaxCommentContentLength)
+}
+
 func approachesResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
@@ -279,204 +510,385 @@ func approachResponseSchema() map[string]interface{} {
 }
 
 func approachSchema() map[string]interface{} {
+	return schemaOf(models.ApproachWithAuthor{})
+}
+
+func createApproachRequestSchema() map[string]interface{} {
+	s := withRequired(schemaOf(models.CreateApproachRequest{}), "angle")
+	withConstraint(s, "angle", "maxLength", models.MaxApproachAngleLength)
+	withConstraint(s, "method", "maxLength", models.MaxApproachMethodLength)
+	return withConstraint(s, "assumptions", "maxItems", models.MaxApproachAssumptions)
+}
+
+func updateApproachRequestSchema() map[string]interface{} {
+	s := schemaOf(models.UpdateApproachRequest{})
+	withConstraint(s, "status", "enum", schemaEnums[reflect.TypeOf(models.ApproachStatus(""))])
+	withConstraint(s, "outcome", "maxLength", models.MaxApproachOutcomeLength)
+	withConstraint(s, "outcome_type", "enum", schemaEnums[reflect.TypeOf(models.ApproachOutcomeType(""))])
+	withConstraint(s, "failure_reason", "enum", append([]string{""}, schemaEnums[reflect.TypeOf(models.ApproachFailureReason(""))]...))
+	return withConstraint(s, "method", "maxLength", models.MaxApproachMethodLength)
+}
+
+func progressNoteRequestSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object",
+		"type": "object", "required": []string{"note"},
+		"properties": map[string]interface{}{"note": map[string]interface{}{"type": "string"}},
+	}
+}
+
+func markStuckRequestSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object", "required": []string{"summary"},
 		"properties": map[string]interface{}{
-			"id": map[string]interface{}{"type": "string"}, "problem_id": map[string]interface{}{"type": "string"},
-			"angle": map[string]interface{}{"type": "string"}, "status": map[string]interface{}{"type": "string"},
-			"posted_by_id": map[string]interface{}{"type": "string"}, "posted_by_type": map[string]interface{}{"type": "string"},
-			"progress_notes": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "object"}},
-			"created_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"summary": map[string]interface{}{"type": "string", "minLength": 50, "maxLength": 5000, "description": "What was tried and why it did not work"},
 		},
 	}
 }
 
-func createApproachRequestSchema() map[string]interface{} {
+func stuckEscalationResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object", "required": []string{"angle"},
-		"properties": map[string]interface{}{"angle": map[string]interface{}{"type": "string"}},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"escalation":     schemaOf(models.ProblemEscalation{}),
+					"notified_count": map[string]interface{}{"type": "integer"},
+				},
+			},
+		},
 	}
 }
 
-func updateApproachRequestSchema() map[string]interface{} {
+func updateBountyRequestSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object",
+		"type": "object", "required": []string{"weight"},
 		"properties": map[string]interface{}{
-			"angle": map[string]interface{}{"type": "string"}, "status": map[string]interface{}{"type": "string"},
+			"weight": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 5, "description": "New weight; must be higher than the current one"},
 		},
 	}
 }
 
-func progressNoteRequestSchema() map[string]interface{} {
+func successCriterionResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object", "required": []string{"note"},
-		"properties": map[string]interface{}{"note": map[string]interface{}{"type": "string"}},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(models.SuccessCriterion{}),
+		},
 	}
 }
 
-func answersResponseSchema() map[string]interface{} {
+func successCriteriaResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Answer"}},
+			"data": schemaOf(handlers.SuccessCriteriaResponse{}),
 		},
 	}
 }
 
-func answerResponseSchema() map[string]interface{} {
+func bountyResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type":       "object",
-		"properties": map[string]interface{}{"data": map[string]interface{}{"$ref": "#/components/schemas/Answer"}},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"problem_id":        map[string]interface{}{"type": "string"},
+					"weight":            map[string]interface{}{"type": "integer"},
+					"bounty_points":     map[string]interface{}{"type": "integer", "description": "Reputation paid to the solver on solve"},
+					"weight_updated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+				},
+			},
+		},
 	}
 }
 
-func answerSchema() map[string]interface{} {
+func problemInsightsResponseSchema() map[string]interface{} {
+	approach := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":     map[string]interface{}{"type": "string"},
+			"angle":  map[string]interface{}{"type": "string"},
+			"status": map[string]interface{}{"type": "string"},
+		},
+	}
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"id": map[string]interface{}{"type": "string"}, "question_id": map[string]interface{}{"type": "string"},
-			"content": map[string]interface{}{"type": "string"}, "accepted": map[string]interface{}{"type": "boolean"},
-			"posted_by_id": map[string]interface{}{"type": "string"}, "posted_by_type": map[string]interface{}{"type": "string"},
-			"upvotes": map[string]interface{}{"type": "integer"}, "downvotes": map[string]interface{}{"type": "integer"},
-			"created_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"data": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"problem_id":     map[string]interface{}{"type": "string"},
+					"approach_count": map[string]interface{}{"type": "integer"},
+					"strategy_count": map[string]interface{}{"type": "integer", "description": "Distinct strategy clusters"},
+					"clusters": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"index":          map[string]interface{}{"type": "integer", "description": "0 is the largest cluster"},
+								"size":           map[string]interface{}{"type": "integer"},
+								"representative": approach,
+								"approaches":     map[string]interface{}{"type": "array", "items": approach},
+								"status_counts":  map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "integer"}},
+							},
+						},
+					},
+					"unclustered_count":    map[string]interface{}{"type": "integer", "description": "Approaches added since the last clustering or without an embedding"},
+					"similarity_threshold": map[string]interface{}{"type": "number"},
+					"computed_at":          map[string]interface{}{"type": "string", "format": "date-time", "nullable": true},
+				},
+			},
 		},
 	}
 }
 
-func createAnswerRequestSchema() map[string]interface{} {
+func transcriptResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object", "required": []string{"content"},
-		"properties": map[string]interface{}{"content": map[string]interface{}{"type": "string"}},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(models.ApproachTranscript{}),
+		},
 	}
 }
 
-func updateAnswerRequestSchema() map[string]interface{} {
+func approachTimelineResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type":       "object",
-		"properties": map[string]interface{}{"content": map[string]interface{}{"type": "string"}},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"id":          map[string]interface{}{"type": "string"},
+						"approach_id": map[string]interface{}{"type": "string"},
+						"type": map[string]interface{}{"type": "string", "enum": []string{
+							"created", "status_changed", "progress_note", "outcome_recorded", "verified", "abandoned",
+							"transcript_attached",
+						}},
+						"actor_type":         map[string]interface{}{"type": "string", "enum": []string{"human", "agent", "system"}},
+						"actor_id":           map[string]interface{}{"type": "string"},
+						"actor_display_name": map[string]interface{}{"type": "string"},
+						"data":               map[string]interface{}{"type": "object", "description": "Event details, e.g. from/to for status changes"},
+						"created_at":         map[string]interface{}{"type": "string", "format": "date-time"},
+					},
+				},
+			},
+		},
 	}
 }
 
-func ideaResponsesResponseSchema() map[string]interface{} {
+func answersResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/IdeaResponse"}},
+			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Answer"}},
 		},
 	}
 }
 
-func ideaResponseResponseSchema() map[string]interface{} {
+func answerResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type":       "object",
-		"properties": map[string]interface{}{"data": map[string]interface{}{"$ref": "#/components/schemas/IdeaResponse"}},
+		"properties": map[string]interface{}{"data": map[string]interface{}{"$ref": "#/components/schemas/Answer"}},
 	}
 }
 
-func ideaResponseSchema() map[string]interface{} {
+// createdAnswerResponseSchema lists close existing answers on the same question
+// under possible_duplicates (omitted when there are none).
+func createdAnswerResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"id": map[string]interface{}{"type": "string"}, "idea_id": map[string]interface{}{"type": "string"},
-			"type": map[string]interface{}{"type": "string", "enum": []string{"support", "concern", "extension", "question"}},
-			"content": map[string]interface{}{"type": "string"},
-			"posted_by_id": map[string]interface{}{"type": "string"}, "posted_by_type": map[string]interface{}{"type": "string"},
-			"created_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"data":                map[string]interface{}{"$ref": "#/components/schemas/Answer"},
+			"possible_duplicates": map[string]interface{}{"type": "array", "items": schemaOf(models.AnswerDuplicate{})},
 		},
 	}
 }
 
-func createIdeaResponseRequestSchema() map[string]interface{} {
+func answerSchema() map[string]interface{} {
+	return schemaOf(models.AnswerWithAuthor{})
+}
+
+func createAnswerRequestSchema() map[string]interface{} {
+	s := withRequired(schemaOf(models.CreateAnswerRequest{}), "content")
+	s = withConstraint(s, "supersedes_answer_id", "description",
+		"Older answer on the same question that this answer improves on. Each answer can be superseded once (409 otherwise).")
+	return withConstraint(s, "content", "maxLength", models.MaxAnswerContentLength)
+}
+
+func answerVersionsResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object", "required": []string{"type", "content"},
+		"type": "object",
 		"properties": map[string]interface{}{
-			"type":    map[string]interface{}{"type": "string", "enum": []string{"support", "concern", "extension", "question"}},
-			"content": map[string]interface{}{"type": "string"},
+			"data": schemaOf(models.AnswerVersionChain{}),
 		},
 	}
 }
 
-func evolveIdeaRequestSchema() map[string]interface{} {
+func postFreshnessResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object", "required": []string{"description"},
+		"type": "object",
 		"properties": map[string]interface{}{
-			"description": map[string]interface{}{"type": "string"},
-			"changelog":   map[string]interface{}{"type": "string"},
+			"data": schemaOf(models.PostFreshness{}),
 		},
 	}
 }
 
-func agentResponseSchema() map[string]interface{} {
+func postCollaboratorResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type":       "object",
-		"properties": map[string]interface{}{"data": map[string]interface{}{"$ref": "#/components/schemas/Agent"}},
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(models.PostCollaborator{}),
+		},
 	}
 }
 
-func agentSchema() map[string]interface{} {
+func postCollaboratorsResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"id": map[string]interface{}{"type": "string"}, "name": map[string]interface{}{"type": "string"},
-			"display_name": map[string]interface{}{"type": "string"}, "model_id": map[string]interface{}{"type": "string"},
-			"human_backed": map[string]interface{}{"type": "boolean"}, "reputation": map[string]interface{}{"type": "integer"},
-			"created_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"data": map[string]interface{}{"type": "array", "items": schemaOf(models.PostCollaborator{})},
 		},
 	}
 }
 
-func registerAgentRequestSchema() map[string]interface{} {
+func reactionRequestSchema() map[string]interface{} {
 	return map[string]interface{}{
-		"type": "object", "required": []string{"name", "model_id"},
+		"type":     "object",
+		"required": []string{"emoji"},
 		"properties": map[string]interface{}{
-			"name": map[string]interface{}{"type": "string"}, "display_name": map[string]interface{}{"type": "string"},
-			"model_id": map[string]interface{}{"type": "string"},
+			"emoji": map[string]interface{}{"type": "string", "enum": models.Reactions},
 		},
 	}
 }
 
-func agentRegistrationResponseSchema() map[string]interface{} {
+func reactionResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"data": map[string]interface{}{
-				"type": "object",
-				"properties": map[string]interface{}{
-					"id": map[string]interface{}{"type": "string"}, "api_key": map[string]interface{}{"type": "string"},
-				},
-			},
+			"data": schemaOf(handlers.ReactionResponse{}),
 		},
 	}
 }
 
-func claimURLResponseSchema() map[string]interface{} {
+func contentLimitsResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"claim_url": map[string]interface{}{"type": "string"}, "expires_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"data": schemaOf(handlers.ContentLimitsResponse{}),
 		},
 	}
 }
 
-func claimInfoResponseSchema() map[string]interface{} {
+func updateAnswerRequestSchema() map[string]interface{} {
+	return withConstraint(schemaOf(models.UpdateAnswerRequest{}), "content", "maxLength", models.MaxAnswerContentLength)
+}
+
+func ideaResponsesResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"agent_id": map[string]interface{}{"type": "string"}, "agent_name": map[string]interface{}{"type": "string"},
-			"expires_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/IdeaResponse"}},
 		},
 	}
 }
 
-func claimConfirmResponseSchema() map[string]interface{} {
+func ideaResponseResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"data": map[string]interface{}{"$ref": "#/components/schemas/IdeaResponse"}},
+	}
+}
+
+func ideaResponseSchema() map[string]interface{} {
+	return schemaOf(models.ResponseWithAuthor{})
+}
+
+func createIdeaResponseRequestSchema() map[string]interface{} {
+	s := withRequired(schemaOf(models.CreateResponseRequest{}), "content", "response_type")
+	return withConstraint(s, "content", "maxLength", 10000)
+}
+
+func evolveIdeaRequestSchema() map[string]interface{} {
+	return withRequired(schemaOf(handlers.EvolveRequest{}), "evolved_post_id")
+}
+
+func agentResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"data": map[string]interface{}{"$ref": "#/components/schemas/Agent"}},
+	}
+}
+
+func agentSchema() map[string]interface{} {
+	return schemaOf(models.Agent{})
+}
+
+func registerAgentRequestSchema() map[string]interface{} {
+	s := withRequired(schemaOf(handlers.RegisterAgentRequest{}), "name")
+	withConstraint(s, "name", "minLength", 3)
+	withConstraint(s, "name", "maxLength", 30)
+	withConstraint(s, "description", "maxLength", 500)
+	withConstraint(s, "model", "maxLength", 100)
+	withConstraint(s, "email", "maxLength", 255)
+	withConstraint(s, "external_links", "maxItems", 10)
+	withConstraint(s, "amcp_aid", "maxLength", 255)
+	return withConstraint(s, "keri_public_key", "maxLength", 512)
+}
+
+func agentRegistrationResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"success": map[string]interface{}{"type": "boolean"}, "message": map[string]interface{}{"type": "string"},
+			"data": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{"type": "string"}, "api_key": map[string]interface{}{"type": "string"},
+				},
+			},
 		},
 	}
 }
 
+func claimURLResponseSchema() map[string]interface{} {
+	return schemaOf(handlers.GenerateClaimResponse{})
+}
+
+func claimInfoResponseSchema() map[string]interface{} {
+	return schemaOf(handlers.ClaimInfoResponse{})
+}
+
+func claimConfirmResponseSchema() map[string]interface{} {
+	return schemaOf(handlers.ClaimAgentResponse{})
+}
+
+func claimAgentRequestSchema() map[string]interface{} {
+	return withRequired(schemaOf(handlers.ClaimAgentRequest{}), "token")
+}
+
+func agentVerifyEmailRequestSchema() map[string]interface{} {
+	return withRequired(schemaOf(handlers.AgentEmailVerificationRequest{}), "token")
+}
+
+func resendVerificationRequestSchema() map[string]interface{} {
+	return withConstraint(schemaOf(handlers.ResendAgentVerificationRequest{}), "email", "maxLength", 255)
+}
+
+func createGuestProblemRequestSchema() map[string]interface{} {
+	return withRequired(schemaOf(handlers.CreateGuestProblemRequest{}), "title", "description", "email")
+}
+
+func guestClaimRequestSchema() map[string]interface{} {
+	return withRequired(schemaOf(handlers.GuestClaimRequest{}), "token")
+}
+
+func retagPostRequestSchema() map[string]interface{} {
+	return withRequired(schemaOf(handlers.RetagPostRequest{}), "tags")
+}
+
 func userResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type":       "object",
@@ -512,6 +924,7 @@ func updateProfileRequestSchema() map[string]interface{} {
 		"properties": map[string]interface{}{
 			"display_name": map[string]interface{}{"type": "string"}, "bio": map[string]interface{}{"type": "string"},
 			"avatar_url": map[string]interface{}{"type": "string"},
+			"username":   map[string]interface{}{"type": "string", "description": "3-30 letters, digits or underscores; the old username is held and redirects"},
 		},
 	}
 }
@@ -526,6 +939,44 @@ func contributionsResponseSchema() map[string]interface{} {
 	}
 }
 
+func userPublicProfileResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(handlers.UserPublicProfileResponse{}),
+			"meta": map[string]interface{}{"$ref": "#/components/schemas/PaginationMeta"},
+		},
+	}
+}
+
+func avatarResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(handlers.AvatarResponse{}),
+		},
+	}
+}
+
+func blockResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(models.PrincipalBlock{}),
+		},
+	}
+}
+
+func blockListResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{"type": "array", "items": schemaOf(models.PrincipalBlock{})},
+			"meta": map[string]interface{}{"$ref": "#/components/schemas/PaginationMeta"},
+		},
+	}
+}
+
 func apiKeysResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
@@ -549,6 +1000,7 @@ func apiKeySchema() map[string]interface{} {
 			"id": map[string]interface{}{"type": "string"}, "name": map[string]interface{}{"type": "string"},
 			"prefix": map[string]interface{}{"type": "string"}, "created_at": map[string]interface{}{"type": "string", "format": "date-time"},
 			"last_used_at": map[string]interface{}{"type": "string", "format": "date-time"},
+			"scope":        map[string]interface{}{"type": "string", "enum": []string{"read", "write"}},
 		},
 	}
 }
@@ -556,7 +1008,10 @@ func apiKeySchema() map[string]interface{} {
 func createAPIKeyRequestSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object", "required": []string{"name"},
-		"properties": map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+		"properties": map[string]interface{}{
+			"name":  map[string]interface{}{"type": "string"},
+			"scope": map[string]interface{}{"type": "string", "enum": []string{"read", "write"}, "default": "write", "description": "read-only keys can't create or change content"},
+		},
 	}
 }
 
@@ -666,6 +1121,16 @@ func createReportRequestSchema() map[string]interface{} {
 	}
 }
 
+func reportContentRequestSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object", "required": []string{"reason"},
+		"properties": map[string]interface{}{
+			"reason":  map[string]interface{}{"type": "string", "enum": []string{"spam", "offensive", "off_topic", "misleading", "other"}},
+			"details": map[string]interface{}{"type": "string"},
+		},
+	}
+}
+
 func reportCheckResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type":       "object",
@@ -673,6 +1138,15 @@ func reportCheckResponseSchema() map[string]interface{} {
 	}
 }
 
+func feedBriefingResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(models.FeedBriefing{}),
+		},
+	}
+}
+
 func feedResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
@@ -687,9 +1161,16 @@ func statsResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"total_posts": map[string]interface{}{"type": "integer"}, "total_problems": map[string]interface{}{"type": "integer"},
-			"total_questions": map[string]interface{}{"type": "integer"}, "total_ideas": map[string]interface{}{"type": "integer"},
-			"total_agents": map[string]interface{}{"type": "integer"}, "total_users": map[string]interface{}{"type": "integer"},
+			"data": schemaOf(handlers.StatsResponse{}),
+		},
+	}
+}
+
+func statsHistoryResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(handlers.StatsHistoryResponse{}),
 		},
 	}
 }
@@ -698,7 +1179,7 @@ func trendingResponseSchema() map[string]interface{} {
 	return map[string]interface{}{
 		"type": "object",
 		"properties": map[string]interface{}{
-			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Post"}},
+			"data": schemaOf(handlers.TrendingResponse{}),
 		},
 	}
 }
@@ -865,3 +1346,92 @@ func updateIdentityRequestSchema() map[string]interface{} {
 		},
 	}
 }
+
+// jsonRPCRequestSchema describes a JSON-RPC 2.0 call to the MCP endpoint.
+func jsonRPCRequestSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object", "required": []string{"jsonrpc", "method"},
+		"properties": map[string]interface{}{
+			"jsonrpc": map[string]interface{}{"type": "string", "enum": []string{"2.0"}},
+			"id":      map[string]interface{}{"description": "Request ID, echoed in the response"},
+			"method":  map[string]interface{}{"type": "string", "description": "initialize, initialized, tools/list, tools/call or shutdown"},
+			"params":  map[string]interface{}{"type": "object"},
+		},
+	}
+}
+
+func jsonRPCResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"jsonrpc": map[string]interface{}{"type": "string"}, "id": map[string]interface{}{},
+			"result": map[string]interface{}{"type": "object"},
+			"error": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"code": map[string]interface{}{"type": "integer"}, "message": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+}
+
+func updateUserStatusRequestSchema() map[string]interface{} {
+	s := withRequired(schemaOf(handlers.UpdateUserStatusRequest{}), "status")
+	return withConstraint(s, "status", "enum", []string{string(models.UserStatusActive), string(models.UserStatusSuspended), string(models.UserStatusBanned)})
+}
+
+func auditLogSchema() map[string]interface{} {
+	return schemaOf(models.AuditLog{})
+}
+
+func auditLogResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/AuditLog"}},
+			"meta": map[string]interface{}{"$ref": "#/components/schemas/PaginationMeta"},
+		},
+	}
+}
+
+func tagSchema() map[string]interface{} {
+	return schemaOf(models.TagSummary{})
+}
+
+func tagListResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/Tag"}},
+			"meta": map[string]interface{}{"$ref": "#/components/schemas/PaginationMeta"},
+		},
+	}
+}
+
+func postTemplateListResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": map[string]interface{}{"type": "array", "items": schemaOf(handlers.PostTemplateResponse{})},
+		},
+	}
+}
+
+func entityDetailResponseSchema() map[string]interface{} {
+	s := schemaOf(handlers.EntityDetailResponse{})
+	withConstraint(s, "kind", "enum", []string{"library", "tool", "error_code", "cve"})
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"data": s},
+	}
+}
+
+func tagDetailResponseSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data": schemaOf(handlers.TagDetailResponse{}),
+		},
+	}
+}
@@ -0,0 +1,284 @@
+// Package api provides HTTP routing and handlers for the Solvr API.
+// This file contains optional middleware that validates JSON request bodies
+// against the OpenAPI spec served at /v1/openapi.json.
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/api/handlers"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
+)
+
+// maxValidatedBodySize caps how much of a request body the validator buffers.
+// Larger bodies pass through unvalidated and are left to the handler's own limits.
+const maxValidatedBodySize = 1 << 20
+
+// specOperation is one path template from the spec with the request body
+// schema of each method that declares one.
+type specOperation struct {
+	segments []string
+	static   int
+	bodies   map[string]map[string]interface{}
+}
+
+// openAPIRequestValidator returns middleware that checks JSON request bodies
+// against the spec's requestBody schemas and rejects mismatches with 400
+// VALIDATION_ERROR and error.details.fields. Paths are matched after trimming
+// prefix. Requests without a matching operation, empty bodies and malformed
+// JSON pass through so handlers keep reporting those themselves.
+func openAPIRequestValidator(spec map[string]interface{}, prefix string) func(http.Handler) http.Handler {
+	ops := specOperations(spec)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			schema := matchBodySchema(ops, r.Method, strings.TrimPrefix(r.URL.Path, prefix))
+			if schema == nil || r.Body == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxValidatedBodySize+1))
+			if err != nil || len(body) > maxValidatedBodySize {
+				r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			var value interface{}
+			if len(bytes.TrimSpace(body)) == 0 || json.Unmarshal(body, &value) != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var v handlers.Validator
+			validateSchemaValue(&v, "", schema, value)
+			if !v.Valid() {
+				writeSpecValidationError(w, v.Errors())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// specOperations indexes the spec's paths by template, resolving each JSON
+// requestBody $ref against components.schemas.
+func specOperations(spec map[string]interface{}) []specOperation {
+	paths, _ := spec["paths"].(map[string]interface{})
+	components, _ := spec["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+
+	var ops []specOperation
+	for template, item := range paths {
+		methods, _ := item.(map[string]interface{})
+		op := specOperation{bodies: map[string]map[string]interface{}{}}
+		for method, raw := range methods {
+			operation, _ := raw.(map[string]interface{})
+			if schema := requestBodySchema(operation, schemas); schema != nil {
+				op.bodies[strings.ToUpper(method)] = schema
+			}
+		}
+		if len(op.bodies) == 0 {
+			continue
+		}
+		op.segments = strings.Split(strings.Trim(template, "/"), "/")
+		for _, seg := range op.segments {
+			if !strings.HasPrefix(seg, "{") {
+				op.static++
+			}
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// requestBodySchema returns the application/json schema of an operation's requestBody.
+func requestBodySchema(operation map[string]interface{}, schemas map[string]interface{}) map[string]interface{} {
+	body, _ := operation["requestBody"].(map[string]interface{})
+	content, _ := body["content"].(map[string]interface{})
+	media, _ := content["application/json"].(map[string]interface{})
+	schema, _ := media["schema"].(map[string]interface{})
+	return resolveSchemaRef(schema, schemas)
+}
+
+// resolveSchemaRef follows a #/components/schemas/ reference.
+func resolveSchemaRef(schema map[string]interface{}, schemas map[string]interface{}) map[string]interface{} {
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return schema
+	}
+	resolved, _ := schemas[strings.TrimPrefix(ref, "#/components/schemas/")].(map[string]interface{})
+	return resolved
+}
+
+// matchBodySchema finds the body schema for method and path. When several
+// templates match (e.g. /agents/claim and /agents/{id}) the one with the most
+// static segments wins, as in the router.
+func matchBodySchema(ops []specOperation, method, path string) map[string]interface{} {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var best map[string]interface{}
+	bestStatic := -1
+	for _, op := range ops {
+		schema, ok := op.bodies[method]
+		if !ok || op.static <= bestStatic || !matchSegments(op.segments, segments) {
+			continue
+		}
+		best, bestStatic = schema, op.static
+	}
+	return best
+}
+
+func matchSegments(template, path []string) bool {
+	if len(template) != len(path) {
+		return false
+	}
+	for i, seg := range template {
+		if strings.HasPrefix(seg, "{") {
+			if path[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// validateSchemaValue checks value against the subset of OpenAPI keywords the
+// spec uses: type, required, enum, minLength/maxLength, maxItems and
+// minimum/maximum. null is accepted anywhere and extra properties are allowed.
+func validateSchemaValue(v *handlers.Validator, field string, schema map[string]interface{}, value interface{}) {
+	if value == nil {
+		return
+	}
+
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			addTypeError(v, field, "an object")
+			return
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		for _, name := range schemaStrings(schema["required"]) {
+			if _, present := obj[name]; !present {
+				key := joinField(field, name)
+				v.Add(handlers.FieldError{Field: key, Code: handlers.FieldRequired, Message: key + " is required"})
+			}
+		}
+		for name, propValue := range obj {
+			if prop, ok := props[name].(map[string]interface{}); ok {
+				validateSchemaValue(v, joinField(field, name), prop, propValue)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			addTypeError(v, field, "an array")
+			return
+		}
+		if max, ok := schemaInt(schema["maxItems"]); ok {
+			v.MaxItems(field, len(items), max)
+		}
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range items {
+				validateSchemaValue(v, fmt.Sprintf("%s[%d]", field, i), itemSchema, item)
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			addTypeError(v, field, "a string")
+			return
+		}
+		if enum := schemaStrings(schema["enum"]); len(enum) > 0 {
+			v.OneOf(field, s, enum...)
+		}
+		min, _ := schemaInt(schema["minLength"])
+		max, _ := schemaInt(schema["maxLength"])
+		v.Length(field, s, min, max)
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok || (schema["type"] == "integer" && n != math.Trunc(n)) {
+			addTypeError(v, field, "a "+schema["type"].(string))
+			return
+		}
+		min, hasMin := schemaInt(schema["minimum"])
+		max, hasMax := schemaInt(schema["maximum"])
+		switch {
+		case hasMin && hasMax:
+			if n < float64(min) || n > float64(max) {
+				v.Range(field, int(n), min, max)
+			}
+		case hasMin && n < float64(min):
+			v.Add(handlers.FieldError{Field: field, Code: handlers.FieldOutOfRange, Min: min,
+				Message: fmt.Sprintf("%s must be at least %d", field, min)})
+		case hasMax && n > float64(max):
+			v.Add(handlers.FieldError{Field: field, Code: handlers.FieldOutOfRange, Max: max,
+				Message: fmt.Sprintf("%s must be at most %d", field, max)})
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			addTypeError(v, field, "a boolean")
+		}
+	}
+}
+
+func addTypeError(v *handlers.Validator, field, want string) {
+	name := field
+	if name == "" {
+		name = "body"
+	}
+	v.Add(handlers.FieldError{Field: field, Code: handlers.FieldInvalidFormat, Message: name + " must be " + want})
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// schemaStrings reads a string list keyword, which is []string when built in
+// Go and []interface{} when decoded from JSON.
+func schemaStrings(raw interface{}) []string {
+	switch list := raw.(type) {
+	case []string:
+		return list
+	case []interface{}:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// schemaInt reads a numeric keyword such as maxLength or minimum.
+func schemaInt(raw interface{}) (int, bool) {
+	switch n := raw.(type) {
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// writeSpecValidationError writes field errors in the standard error envelope.
+func writeSpecValidationError(w http.ResponseWriter, errs []handlers.FieldError) {
+	apierror.WriteDetails(w, apierror.ValidationError, errs[0].Message, map[string]interface{}{"fields": errs})
+}
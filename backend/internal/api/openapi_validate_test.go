@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/api/handlers"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestSchemaOf_FollowsJSONTags(t *testing.T) {
+	type inner struct {
+		Note string `json:"note"`
+	}
+	type sample struct {
+		inner
+		ID      string            `json:"id"`
+		Type    models.PostType   `json:"type"`
+		Hidden  string            `json:"-"`
+		When    time.Time         `json:"when"`
+		Maybe   *int              `json:"maybe,omitempty"`
+		Tags    []string          `json:"tags"`
+		Meta    map[string]string `json:"meta"`
+		Payload json.RawMessage   `json:"payload"`
+	}
+
+	props := schemaOf(sample{})["properties"].(map[string]interface{})
+	if _, ok := props["Hidden"]; ok {
+		t.Error("json:\"-\" field should be skipped")
+	}
+	if props["note"] == nil {
+		t.Error("embedded struct fields should be flattened")
+	}
+	if got := props["when"].(map[string]interface{})["format"]; got != "date-time" {
+		t.Errorf("time.Time format = %v, want date-time", got)
+	}
+	if got := props["maybe"].(map[string]interface{}); got["type"] != "integer" || got["nullable"] != true {
+		t.Errorf("pointer field = %v, want nullable integer", got)
+	}
+	if got := props["type"].(map[string]interface{})["enum"]; len(got.([]string)) != 3 {
+		t.Errorf("PostType enum = %v", got)
+	}
+	if got := props["tags"].(map[string]interface{})["type"]; got != "array" {
+		t.Errorf("slice type = %v, want array", got)
+	}
+	if len(props["payload"].(map[string]interface{})) != 0 {
+		t.Error("json.RawMessage should accept any value")
+	}
+}
+
+// TestOpenAPISpec_RegisterAgentMatchesHandler guards against the spec drifting
+// from the request struct the handler decodes.
+func TestOpenAPISpec_RegisterAgentMatchesHandler(t *testing.T) {
+	schema := registerAgentRequestSchema()
+	props := schema["properties"].(map[string]interface{})
+	for _, field := range []string{"name", "description", "model", "email", "external_links", "amcp_aid", "keri_public_key"} {
+		if props[field] == nil {
+			t.Errorf("RegisterAgentRequest schema missing %q", field)
+		}
+	}
+	if props["model_id"] != nil {
+		t.Error("RegisterAgentRequest schema should not list model_id")
+	}
+	if req := schema["required"].([]string); len(req) != 1 || req[0] != "name" {
+		t.Errorf("required = %v, want [name]", req)
+	}
+}
+
+// TestOpenAPISpec_PathsAreRouted checks every documented operation is a real /v1 route.
+func TestOpenAPISpec_PathsAreRouted(t *testing.T) {
+	router := setupTestRouter(t)
+
+	routes := map[string]bool{}
+	chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routes[method+" "+strings.TrimSuffix(route, "/")] = true
+		return nil
+	})
+
+	paths := getOpenAPISpec()["paths"].(map[string]interface{})
+	for path, item := range paths {
+		for method := range item.(map[string]interface{}) {
+			key := strings.ToUpper(method) + " /v1" + path
+			if !routes[key] {
+				t.Errorf("spec documents %s but no route is registered", key)
+			}
+		}
+	}
+}
+
+func newSpecValidatedHandler() (http.Handler, *bool) {
+	reached := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return openAPIRequestValidator(getOpenAPISpec(), "/v1")(next), &reached
+}
+
+func TestOpenAPIRequestValidator_RejectsInvalidBody(t *testing.T) {
+	handler, reached := newSpecValidatedHandler()
+
+	body := `{"type":"poem","title":"short","tags":["a","b","c","d","e","f","g","h","i","j","k"],"weight":9}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/posts", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if *reached {
+		t.Fatal("invalid body should not reach the handler")
+	}
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	var resp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Details struct {
+				Fields []handlers.FieldError `json:"fields"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Error.Code != "VALIDATION_ERROR" {
+		t.Errorf("code = %q, want VALIDATION_ERROR", resp.Error.Code)
+	}
+	codes := map[string]string{}
+	for _, f := range resp.Error.Details.Fields {
+		codes[f.Field] = f.Code
+	}
+	want := map[string]string{
+		"type":   handlers.FieldInvalidValue,
+		"title":  handlers.FieldTooShort,
+		"tags":   handlers.FieldTooMany,
+		"weight": handlers.FieldOutOfRange,
+	}
+	for field, code := range want {
+		if codes[field] != code {
+			t.Errorf("%s: code = %q, want %q (all: %v)", field, codes[field], code, codes)
+		}
+	}
+}
+
+func TestOpenAPIRequestValidator_RequiredAndTypes(t *testing.T) {
+	handler, _ := newSpecValidatedHandler()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/agents/register", strings.NewReader(`{"description":42}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, `"field":"name","code":"REQUIRED"`) ||
+		!strings.Contains(body, `"field":"description","code":"INVALID_FORMAT"`) {
+		t.Errorf("unexpected errors: %s", body)
+	}
+}
+
+func TestOpenAPIRequestValidator_PassesThrough(t *testing.T) {
+	tests := []struct {
+		name, method, path, body string
+	}{
+		{"valid body", http.MethodPost, "/v1/agents/claim", `{"token":"abc"}`},
+		{"null optional field", http.MethodPatch, "/v1/posts/p1", `{"title":null}`},
+		{"undocumented route", http.MethodPost, "/v1/unknown", `{"x":1}`},
+		{"no request body in spec", http.MethodGet, "/v1/posts", ``},
+		{"malformed JSON left to handler", http.MethodPost, "/v1/posts", `{"type":`},
+		{"empty body left to handler", http.MethodPost, "/v1/posts", ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, reached := newSpecValidatedHandler()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if !*reached {
+				t.Errorf("expected request to reach handler, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestOpenAPIRequestValidator_PreservesBody(t *testing.T) {
+	var got string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req handlers.ClaimAgentRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		got = req.Token
+	})
+	handler := openAPIRequestValidator(getOpenAPISpec(), "/v1")(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/agents/claim", strings.NewReader(`{"token":"tok_123"}`))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "tok_123" {
+		t.Errorf("handler read token %q, want tok_123", got)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 )
 
 // SuccessResponse wraps successful responses per SPEC.md Part 5.3.
@@ -69,35 +71,15 @@ func WriteJSONWithMeta(w http.ResponseWriter, status int, data interface{}, meta
 
 // WriteError writes an error JSON response.
 // Format: {"error": {"code": "...", "message": "..."}}
+// New code should call apierror.Write, which picks the status from the code.
 func WriteError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	response := ErrorResponse{
-		Error: ErrorDetail{
-			Code:    code,
-			Message: message,
-		},
-	}
-
-	json.NewEncoder(w).Encode(response)
+	apierror.WriteStatus(w, status, apierror.Code(code), message)
 }
 
 // WriteErrorWithDetails writes an error JSON response with additional details.
 // Format: {"error": {"code": "...", "message": "...", "details": {...}}}
 func WriteErrorWithDetails(w http.ResponseWriter, status int, code, message string, details interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	response := ErrorResponse{
-		Error: ErrorDetail{
-			Code:    code,
-			Message: message,
-			Details: details,
-		},
-	}
-
-	json.NewEncoder(w).Encode(response)
+	apierror.WriteErr(w, apierror.New(apierror.Code(code), message).WithStatus(status).WithDetails(details))
 }
 
 // WriteCreated writes a 201 Created response with data.
@@ -110,17 +92,17 @@ func WriteNoContent(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// Common error codes per SPEC.md Part 5.4.
+// Common error codes per SPEC.md Part 5.4. The full list lives in package apierror.
 const (
-	ErrCodeUnauthorized     = "UNAUTHORIZED"
-	ErrCodeForbidden        = "FORBIDDEN"
-	ErrCodeNotFound         = "NOT_FOUND"
-	ErrCodeValidation       = "VALIDATION_ERROR"
-	ErrCodeRateLimited      = "RATE_LIMITED"
-	ErrCodeDuplicateContent = "DUPLICATE_CONTENT"
-	ErrCodeContentTooShort  = "CONTENT_TOO_SHORT"
-	ErrCodeInternalError    = "INTERNAL_ERROR"
-	ErrCodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	ErrCodeUnauthorized     = string(apierror.Unauthorized)
+	ErrCodeForbidden        = string(apierror.Forbidden)
+	ErrCodeNotFound         = string(apierror.NotFound)
+	ErrCodeValidation       = string(apierror.ValidationError)
+	ErrCodeRateLimited      = string(apierror.RateLimited)
+	ErrCodeDuplicateContent = string(apierror.DuplicateContent)
+	ErrCodeContentTooShort  = string(apierror.ContentTooShort)
+	ErrCodeInternalError    = string(apierror.InternalError)
+	ErrCodeMethodNotAllowed = string(apierror.MethodNotAllowed)
 )
 
 // Convenience error writers for common cases.
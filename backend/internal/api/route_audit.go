@@ -0,0 +1,148 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// RouteAudit compares the mounted routes with the OpenAPI spec. It guards
+// against regressions like FIX-001, where placeholder handlers registered
+// after router.go silently replaced the real ones.
+type RouteAudit struct {
+	// Missing are documented operations ("GET /v1/posts") with no route.
+	Missing []string
+	// Placeholders are routes served by a stub (not-found, not-implemented or
+	// placeholder handlers) instead of a real one.
+	Placeholders []string
+	// Undocumented are /v1 routes the spec doesn't describe. Reported, not failed.
+	Undocumented []string
+}
+
+// AuditRoutes walks the router and checks it against getOpenAPISpec.
+func AuditRoutes(r chi.Routes) RouteAudit {
+	return auditRoutes(r, getOpenAPISpec())
+}
+
+func auditRoutes(r chi.Routes, spec map[string]interface{}) RouteAudit {
+	var audit RouteAudit
+
+	routes := map[string]bool{}
+	_ = chi.Walk(r, func(method, route string, handler http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := method + " " + strings.TrimSuffix(route, "/")
+		routes[key] = true
+		if isPlaceholderHandler(handler) {
+			audit.Placeholders = append(audit.Placeholders, key+" ("+routeHandlerName(handler)+")")
+		}
+		return nil
+	})
+
+	documented := map[string]bool{}
+	paths, _ := spec["paths"].(map[string]interface{})
+	for path, item := range paths {
+		ops, _ := item.(map[string]interface{})
+		for method := range ops {
+			if !isHTTPMethod(method) {
+				continue
+			}
+			key := strings.ToUpper(method) + " /v1" + path
+			documented[key] = true
+			if !routes[key] {
+				audit.Missing = append(audit.Missing, key)
+			}
+		}
+	}
+
+	for key := range routes {
+		if _, route, _ := strings.Cut(key, " "); strings.HasPrefix(route, "/v1/") && !documented[key] {
+			audit.Undocumented = append(audit.Undocumented, key)
+		}
+	}
+
+	sort.Strings(audit.Missing)
+	sort.Strings(audit.Placeholders)
+	sort.Strings(audit.Undocumented)
+	return audit
+}
+
+// Err reports missing and placeholder routes; nil if there are none.
+func (a RouteAudit) Err() error {
+	var errs []error
+	if len(a.Missing) > 0 {
+		errs = append(errs, fmt.Errorf("%d documented operations have no route: %s", len(a.Missing), strings.Join(a.Missing, ", ")))
+	}
+	if len(a.Placeholders) > 0 {
+		errs = append(errs, fmt.Errorf("%d routes are served by placeholder handlers: %s", len(a.Placeholders), strings.Join(a.Placeholders, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// Log writes the audit result: one warning per mismatch and a summary line.
+func (a RouteAudit) Log() {
+	for _, key := range a.Missing {
+		log.Printf("WARNING: route audit: %s is documented but not routed", key)
+	}
+	for _, key := range a.Placeholders {
+		log.Printf("WARNING: route audit: %s is served by a placeholder handler", key)
+	}
+	log.Printf("Route audit: %d missing, %d placeholders, %d undocumented /v1 routes",
+		len(a.Missing), len(a.Placeholders), len(a.Undocumented))
+}
+
+// placeholderHandlerNames are the stubs a real route must never resolve to.
+var placeholderHandlerNames = map[string]bool{
+	runtimeFuncName(notFoundHandler):         true,
+	runtimeFuncName(methodNotAllowedHandler): true,
+	runtimeFuncName(http.NotFound):           true,
+}
+
+// isPlaceholderHandler reports whether a route's endpoint is a stub: one of
+// placeholderHandlerNames (http.NotFoundHandler included), or a function
+// whose name says it is a placeholder or not implemented.
+func isPlaceholderHandler(h http.Handler) bool {
+	name := routeHandlerName(h)
+	if placeholderHandlerNames[name] {
+		return true
+	}
+	lower := strings.ToLower(name)
+	return strings.Contains(lower, "placeholder") || strings.Contains(lower, "notimplemented") || strings.Contains(lower, "comingsoon")
+}
+
+// routeHandlerName names the function behind a route, looking through
+// middleware chains added with r.With.
+func routeHandlerName(h http.Handler) string {
+	for {
+		chain, ok := h.(*chi.ChainHandler)
+		if !ok {
+			break
+		}
+		h = chain.Endpoint
+	}
+	if f, ok := h.(http.HandlerFunc); ok {
+		return runtimeFuncName(f)
+	}
+	if h == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%T", h)
+}
+
+func runtimeFuncName(f func(http.ResponseWriter, *http.Request)) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
+
+func isHTTPMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
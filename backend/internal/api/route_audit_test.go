@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func realListPosts(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+func comingSoonPlaceholder(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func routeAuditSpec() map[string]interface{} {
+	return map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/posts": map[string]interface{}{
+				"get":        map[string]interface{}{},
+				"parameters": []interface{}{},
+			},
+			"/posts/{id}": map[string]interface{}{"get": map[string]interface{}{}},
+			"/agents":     map[string]interface{}{"post": map[string]interface{}{}},
+			"/feed":       map[string]interface{}{"get": map[string]interface{}{}},
+		},
+	}
+}
+
+func TestAuditRoutes_Clean(t *testing.T) {
+	r := chi.NewRouter()
+	r.Route("/v1", func(r chi.Router) {
+		r.Get("/posts", realListPosts)
+		r.With(middleware.NoCache).Get("/posts/{id}", realListPosts)
+		r.Post("/agents", realListPosts)
+		r.Get("/feed", realListPosts)
+	})
+
+	audit := auditRoutes(r, routeAuditSpec())
+	if err := audit.Err(); err != nil {
+		t.Errorf("expected a clean audit, got %v", err)
+	}
+}
+
+func TestAuditRoutes_ReportsMismatches(t *testing.T) {
+	r := chi.NewRouter()
+	r.Route("/v1", func(r chi.Router) {
+		r.Get("/posts", realListPosts)
+		r.With(middleware.NoCache).Get("/posts/{id}", comingSoonPlaceholder)
+		r.Get("/feed", http.NotFound)
+		r.Get("/internal/debug", realListPosts)
+	})
+	r.Get("/health", realListPosts)
+
+	audit := auditRoutes(r, routeAuditSpec())
+
+	if want := []string{"POST /v1/agents"}; !reflect.DeepEqual(audit.Missing, want) {
+		t.Errorf("Missing = %v, want %v", audit.Missing, want)
+	}
+	if len(audit.Placeholders) != 2 ||
+		!strings.HasPrefix(audit.Placeholders[0], "GET /v1/feed ") ||
+		!strings.HasPrefix(audit.Placeholders[1], "GET /v1/posts/{id} ") {
+		t.Errorf("Placeholders = %v, want GET /v1/feed and GET /v1/posts/{id}", audit.Placeholders)
+	}
+	if want := []string{"GET /v1/internal/debug"}; !reflect.DeepEqual(audit.Undocumented, want) {
+		t.Errorf("Undocumented = %v, want %v", audit.Undocumented, want)
+	}
+
+	err := audit.Err()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "POST /v1/agents") || !strings.Contains(err.Error(), "comingSoonPlaceholder") {
+		t.Errorf("error should name the missing route and placeholder handler: %v", err)
+	}
+}
+
+// TestAuditRoutes_RouterMatchesSpec audits the real router, built without a
+// database so it runs in CI.
+func TestAuditRoutes_RouterMatchesSpec(t *testing.T) {
+	router := setupUnconnectedTestRouter(t)
+	if err := AuditRoutes(router).Err(); err != nil {
+		t.Error(err)
+	}
+}
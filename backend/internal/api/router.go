@@ -4,12 +4,12 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -19,23 +19,57 @@ import (
 
 	"github.com/fcavalcantirj/solvr/internal/api/handlers"
 	apimiddleware "github.com/fcavalcantirj/solvr/internal/api/middleware"
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/config"
 	"github.com/fcavalcantirj/solvr/internal/db"
 	"github.com/fcavalcantirj/solvr/internal/hub"
 	"github.com/fcavalcantirj/solvr/internal/jobs"
+	"github.com/fcavalcantirj/solvr/internal/maintenance"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/redis"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
 	"github.com/fcavalcantirj/solvr/internal/services"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+	"github.com/fcavalcantirj/solvr/migrations"
 )
 
 // Version is the API version string
 const Version = "0.2.0"
 
+// Router is the API's chi router plus the background work and config reload
+// listeners it started, which Close stops.
+type Router struct {
+	*chi.Mux
+	usageRecorder *apimiddleware.UsageRecorder
+	unregister    []func()
+}
+
+// onReload registers fn with the default config reloader until Close.
+func (rt *Router) onReload(fn config.ReloadFunc) {
+	rt.unregister = append(rt.unregister, config.DefaultReloader().OnReload(fn))
+}
+
+// Close removes the router's config reload listeners and writes the buffered
+// API usage counters. Call it after the HTTP server has shut down.
+func (rt *Router) Close(ctx context.Context) error {
+	for _, unregister := range rt.unregister {
+		unregister()
+	}
+	rt.unregister = nil
+	if rt.usageRecorder == nil {
+		return nil
+	}
+	return rt.usageRecorder.Close(ctx)
+}
+
 // NewRouter creates and configures a new chi router with all middleware.
 // The pool parameter is optional - if nil, /health/ready will return 503.
 // hubMgr and registry are optional - if nil, room routes are not mounted.
 // The embeddingService parameter is optional - if nil, post creation won't generate embeddings.
-func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegistry, embeddingService ...services.EmbeddingService) *chi.Mux {
+func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegistry, embeddingService ...services.EmbeddingService) *Router {
 	r := chi.NewRouter()
+	rt := &Router{Mux: r}
 
 	// Middleware stack
 	r.Use(requestIDMiddleware)
@@ -43,51 +77,92 @@ func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegi
 	r.Use(middleware.Recoverer)
 
 	// CORS configuration - MUST be early in the chain so error responses include CORS headers
-	// Read from ALLOWED_ORIGINS env var or use defaults
-	allowedOrigins := []string{"http://localhost:3000", "https://solvr.dev", "https://www.solvr.dev"}
-	if envOrigins := os.Getenv("ALLOWED_ORIGINS"); envOrigins != "" {
-		allowedOrigins = strings.Split(envOrigins, ",")
-		// Trim whitespace from each origin
-		for i, origin := range allowedOrigins {
-			allowedOrigins[i] = strings.TrimSpace(origin)
-		}
+	// Read from ALLOWED_ORIGINS env var or use defaults; cmd/api refuses to start
+	// on an invalid list, so the fallback only matters for other callers.
+	allowedOrigins, err := config.AllowedOrigins()
+	if err != nil {
+		log.Printf("WARNING: %v, using default CORS origins", err)
+		allowedOrigins = config.DefaultAllowedOrigins
 	}
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   allowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "X-Session-ID"},
-		ExposedHeaders:   []string{"X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "X-Session-ID", "If-Match", "If-None-Match", "Mcp-Session-Id", "Last-Event-ID", tenant.Header},
+		ExposedHeaders:   []string{"X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "ETag", "X-Cache", "Mcp-Session-Id", tenant.Header},
 		AllowCredentials: true,
 		MaxAge:           int(12 * time.Hour / time.Second),
 	}))
 
 	// Other middleware after CORS
-	r.Use(apimiddleware.Logging)
-	r.Use(apimiddleware.BodyLimit(64 * 1024)) // FIX-028: 64KB request body limit
+	r.Use(apimiddleware.RequestLogger(apimiddleware.RequestLogConfig{
+		SampleRates: requestLogSampleRates(),
+	}))
+	// gzip/deflate for JSON and other text responses; event streams are never compressed
+	r.Use(apimiddleware.Compress(compressionLevel()))
+	// Per-route request timeouts and body limits (FIX-028: 64KB by default)
+	r.Use(apimiddleware.NewRouteLimits(apimiddleware.RouteLimit{
+		Timeout:      requestTimeout(),
+		MaxBodyBytes: 64 * 1024,
+	}, routeLimitRules()).Middleware)
 	r.Use(securityHeadersMiddleware)
 	r.Use(jsonContentTypeMiddleware)
 
+	// Multi-tenancy (MULTI_TENANT): scope each request to the tenant owning its
+	// hostname or named in X-Solvr-Tenant; row-level security does the rest.
+	var tenantResolver *apimiddleware.TenantResolver
+	if pool != nil && config.MultiTenantEnabled() {
+		tenantResolver = apimiddleware.NewTenantResolver(db.NewTenantRepository(pool), apimiddleware.DefaultTenantCacheTTL)
+		r.Use(tenantResolver.Middleware)
+	}
+
+	// Read-only maintenance mode: mutating requests get 503 while it is on
+	// (MAINTENANCE_MODE at startup, POST /v1/admin/maintenance at runtime)
+	r.Use(apimiddleware.ReadOnlyMaintenance(maintenance.Default()))
+
+	// Shared cache (CACHE_DRIVER/REDIS_URL): with Redis, rate limit counters and
+	// cached responses are shared by every API replica; nil keeps them in memory.
+	cacheCfg := config.CacheConfig()
+	redisClient := newCacheRedisClient(cacheCfg)
+
 	// Rate limiting - load config from database with fallback to defaults
 	rateLimitConfig := loadRateLimitConfig(pool)
-	rateLimitStore := apimiddleware.NewInMemoryRateLimitStore()
+	rateLimitStore := newRateLimitStore(redisClient, cacheCfg.KeyPrefix+"ratelimit:")
 	rateLimiter := apimiddleware.NewRateLimiter(rateLimitStore, rateLimitConfig)
 	r.Use(rateLimiter.Middleware)
 
+	// Config reloads (SIGHUP or POST /v1/admin/config/reload) re-read the rate limits
+	// from the database and re-seed maintenance mode when its settings changed.
+	// Registered before mountV1Routes so handlers reading rateLimiter.Config() on
+	// reload see the new limits.
+	rt.onReload(func(_, _ config.Runtime) []models.ConfigChange {
+		next := loadRateLimitConfig(pool)
+		changes := rateLimitChanges(rateLimiter.Config(), next)
+		rateLimiter.SetConfig(next)
+		return changes
+	})
+	rt.onReload(func(old, next config.Runtime) []models.ConfigChange {
+		if old.MaintenanceMode != next.MaintenanceMode || old.MaintenanceMessage != next.MaintenanceMessage {
+			if _, err := maintenance.Default().Update(context.Background(), next.MaintenanceMode, next.MaintenanceMessage); err != nil {
+				slog.Error("failed to save maintenance mode", "error", err)
+			}
+		}
+		return nil
+	})
+
 	// Custom 404 and 405 handlers for JSON responses
 	r.NotFound(notFoundHandler)
 	r.MethodNotAllowed(methodNotAllowedHandler)
 
-	// Robots.txt — tell crawlers not to index the API
+	// Robots.txt — tell crawlers not to index the API, except the sitemap
 	r.Get("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("User-agent: *\nDisallow: /\n"))
+		w.Write([]byte("User-agent: *\nAllow: /sitemap.xml\nDisallow: /\n"))
 	})
 
 	// Health endpoints
 	r.Get("/health", healthHandler)
 	r.Get("/health/live", healthLiveHandler)
-	r.Get("/health/ready", healthReadyHandler(pool))
 
 	// IPFS configuration (shared by health check and pinning service)
 	ipfsAPIURL := os.Getenv("IPFS_API_URL")
@@ -102,19 +177,53 @@ func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegi
 		MaxRetries: 0,
 		RetryDelay: 0,
 	})
+	// GET /health/ready - per-dependency readiness (database, pgvector, IPFS, Groq, Voyage)
+	// plus schema version drift against the embedded migrations
+	var readyChecker serviceChecker
+	var readySchema schemaChecker
+	if pool != nil {
+		readyChecker = newReadinessChecker(pool, ipfsHealthSvc)
+		if migrator, err := db.NewMigrator(pool, migrations.FS); err == nil {
+			readySchema = migrator
+		} else {
+			log.Printf("Warning: embedded migrations unreadable, schema drift not reported: %v", err)
+		}
+	}
+	r.Get("/health/ready", healthReadyHandler(readyChecker, readySchema))
+
 	ipfsHealthAdapter := &ipfsHealthAdapter{ipfs: ipfsHealthSvc}
 	ipfsHealthHandler := handlers.NewIPFSHealthHandler(ipfsHealthAdapter)
 	r.Get("/v1/health/ipfs", ipfsHealthHandler.Check)
 
+	// Audit log: every authenticated POST/PUT/PATCH/DELETE (including admin API key
+	// calls) is appended to audit_log. Served by GET /v1/admin/audit.
+	var auditStore apimiddleware.AuditStore
+	if pool != nil {
+		auditStore = db.NewAuditLogRepository(pool)
+	}
+	auditRecorder := apimiddleware.NewAuditRecorder(auditStore)
+	adminWrites := r.With(auditRecorder.Middleware)
+
 	// Admin endpoints (requires X-Admin-API-Key header)
 	adminHandler := handlers.NewAdminHandler(pool)
-	r.Post("/admin/query", adminHandler.ExecuteQuery)
+	adminWrites.Post("/admin/query", adminHandler.ExecuteQuery)
 
 	// Admin hard-delete and list deleted (Task 17)
-	r.Delete("/admin/users/{id}", adminHandler.HardDeleteUser)
-	r.Delete("/admin/agents/{id}", adminHandler.HardDeleteAgent)
+	adminWrites.Delete("/admin/users/{id}", adminHandler.HardDeleteUser)
+	adminWrites.Delete("/admin/agents/{id}", adminHandler.HardDeleteAgent)
 	r.Get("/admin/users/deleted", adminHandler.ListDeletedUsers)
 	r.Get("/admin/agents/deleted", adminHandler.ListDeletedAgents)
+	r.Get("/admin/db/query-stats", adminHandler.GetQueryStats)
+	if pool != nil {
+		adminHandler.SetAbuseReportRepo(db.NewAbuseReportRepository(pool))
+	}
+	r.Get("/admin/abuse-reports", adminHandler.ListAbuseReports)
+	adminWrites.Patch("/admin/abuse-reports/{id}", adminHandler.ReviewAbuseReport)
+	if pool != nil {
+		adminHandler.SetReportQueueRepo(db.NewReportsRepository(pool))
+	}
+	r.Get("/admin/reports", adminHandler.ListReportQueue)
+	adminWrites.Patch("/admin/reports/{target_type}/{target_id}", adminHandler.ResolveReports)
 
 	// Admin manual translation trigger — wire the job if GROQ and DB are available
 	if groqKey := os.Getenv("GROQ_API_KEY"); groqKey != "" && pool != nil {
@@ -126,11 +235,13 @@ func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegi
 			adminPostRepo,
 			slog.Default(),
 		)
+		adminTrigger.SetPostPublishedNotifier(services.NewChatIntegrationNotifier(db.NewChatIntegrationRepository(pool)))
+		adminTrigger.SetModerationCommentRenderer(NewModerationCommentRenderer(pool))
 		translationJob := jobs.NewTranslationJob(adminPostRepo, adminPostRepo, translationSvc, adminTrigger,
 			jobs.DefaultTranslationBatchSize, 0)
 		adminHandler.SetTranslationJobRunner(translationJob)
 	}
-	r.Post("/admin/jobs/translation/run", adminHandler.RunTranslationJob)
+	adminWrites.Post("/admin/jobs/translation/run", adminHandler.RunTranslationJob)
 
 	// Wire Resend email client and broadcast endpoint if API key is available
 	if resendKey := os.Getenv("RESEND_API_KEY"); resendKey != "" {
@@ -148,7 +259,7 @@ func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegi
 		adminHandler.SetEmailBroadcastRepo(db.NewEmailBroadcastRepository(pool))
 		adminHandler.SetUserEmailRepo(db.NewUserRepository(pool))
 	}
-	r.Post("/admin/email/broadcast", adminHandler.BroadcastEmail)
+	adminWrites.Post("/admin/email/broadcast", adminHandler.BroadcastEmail)
 	r.Get("/admin/email/history", adminHandler.ListBroadcasts)
 
 	// Admin search analytics endpoints
@@ -163,9 +274,9 @@ func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegi
 	if pool != nil {
 		incidentRepo := db.NewIncidentRepository(pool)
 		incidentAdminHandler := handlers.NewIncidentAdminHandler(incidentRepo)
-		r.Post("/admin/incidents", incidentAdminHandler.CreateIncident)
-		r.Patch("/admin/incidents/{id}", incidentAdminHandler.UpdateIncidentStatus)
-		r.Post("/admin/incidents/{id}/updates", incidentAdminHandler.AddIncidentUpdate)
+		adminWrites.Post("/admin/incidents", incidentAdminHandler.CreateIncident)
+		adminWrites.Patch("/admin/incidents/{id}", incidentAdminHandler.UpdateIncidentStatus)
+		adminWrites.Post("/admin/incidents/{id}/updates", incidentAdminHandler.AddIncidentUpdate)
 	}
 
 	// Discovery endpoints (SPEC.md Part 18.3)
@@ -178,7 +289,13 @@ func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegi
 	if len(embeddingService) > 0 {
 		embedSvc = embeddingService[0]
 	}
-	mountV1Routes(r, pool, ipfsAPIURL, embedSvc)
+	// Per-principal API usage: counted in each auth group (after the caller is known)
+	// and flushed to api_usage_daily in batches. Served by GET /v1/me/usage.
+	if pool != nil {
+		rt.usageRecorder = apimiddleware.NewUsageRecorder(db.NewAPIUsageRepository(pool))
+		rt.usageRecorder.SetMaintenanceState(maintenance.Default())
+	}
+	mountV1Routes(r, pool, ipfsAPIURL, embedSvc, rateLimiter, rateLimitConfig, auditRecorder, tenantResolver, redisClient, cacheCfg.KeyPrefix, rt)
 
 	// Room routes (extracted per D-13 to keep router.go under 900 lines)
 	if pool != nil && hubMgr != nil {
@@ -190,7 +307,10 @@ func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegi
 		apiKeyValidator := auth.NewAPIKeyValidator(agentRepo)
 		userAPIKeyRepo := db.NewUserAPIKeyRepository(pool)
 		userAPIKeyValidator := auth.NewUserAPIKeyValidator(userAPIKeyRepo)
-		authMW := auth.UnifiedAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator)
+		unifiedAuthMW := auth.UnifiedAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator)
+		authMW := func(next http.Handler) http.Handler {
+			return unifiedAuthMW(auditRecorder.Middleware(next))
+		}
 		// Optional auth for public read routes: identifies the caller (agent/human)
 		// without rejecting anonymous requests, so the RoomAccessGuard can enforce
 		// closed-room membership while public rooms stay open.
@@ -198,16 +318,17 @@ func NewRouter(pool *db.Pool, hubMgr *hub.HubManager, registry *hub.PresenceRegi
 		mountRoomRoutes(r, pool, hubMgr, registry, authMW, optionalAuthMW)
 	}
 
-	return r
+	return rt
 }
 
 // mountV1Routes mounts all v1 API routes.
-func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingService services.EmbeddingService) {
+func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingService services.EmbeddingService, rateLimiter *apimiddleware.RateLimiter, rateLimitConfig *apimiddleware.RateLimitConfig, auditRecorder *apimiddleware.AuditRecorder, tenantResolver *apimiddleware.TenantResolver, redisClient *redis.Client, cachePrefix string, rt *Router) {
+	usageRecorder := rt.usageRecorder
 	// Create repositories and handlers
 	var agentRepo handlers.AgentRepositoryInterface
 	var claimTokenRepo handlers.ClaimTokenRepositoryInterface
 	var postsRepo handlers.PostsRepositoryInterface
-	var searchRepo handlers.SearchRepositoryInterface
+	var searchRepo handlers.SearchBackend
 	var feedRepo handlers.FeedRepositoryInterface
 	var userRepo handlers.MeUserRepositoryInterface
 	var problemsRepo handlers.ProblemsRepositoryInterface
@@ -229,7 +350,21 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 	agentRepo = agentRepoConcrete
 	claimTokenRepo = db.NewClaimTokenRepository(pool)
 	postsRepo = db.NewPostRepository(pool)
-	searchRepo = db.NewSearchRepository(pool)
+	// PostgreSQL search backs embeddings-based features even when SEARCH_BACKEND
+	// sends keyword search to OpenSearch.
+	pgSearchRepo := db.NewSearchRepository(pool)
+	searchRepo = pgSearchRepo
+	switch searchCfg := config.SearchBackendConfig(); searchCfg.Backend {
+	case models.SearchBackendPostgres:
+	case models.SearchBackendOpenSearch:
+		if backend, err := services.NewOpenSearchBackend(searchCfg); err != nil {
+			log.Printf("WARNING: SEARCH_BACKEND=opensearch unusable (%v), using PostgreSQL search", err)
+		} else {
+			searchRepo = backend
+		}
+	default:
+		log.Printf("WARNING: unknown SEARCH_BACKEND %q, using PostgreSQL search", searchCfg.Backend)
+	}
 	feedRepo = db.NewFeedRepository(pool)
 	userRepo = db.NewUserRepository(pool)
 	userAPIKeysRepo = db.NewUserAPIKeyRepository(pool)
@@ -249,12 +384,73 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 	referralRepo := db.NewReferralRepository(pool)
 	roomRepo := db.NewRoomRepository(pool)
 
+	usageRepo := db.NewAPIUsageRepository(pool)
+
+	// Sign-in anomaly alerts: password and OAuth logins, and the first use of an
+	// API key from each IP, are compared with the principal's history; new countries,
+	// impossible travel and keys used from many IPs create security.alert
+	// notifications. Served by GET /v1/me/security/events.
+	securityEventRepo := db.NewSecurityEventRepository(pool)
+	securityMonitor := services.NewSecurityMonitor(securityEventRepo, notificationsRepoConcrete, config.SecurityConfig())
+	keyUseMonitor := apimiddleware.NewKeyUseMonitor(securityMonitor)
+
+	// Reputation-gated privileges (commenting on others' content, down votes,
+	// retagging others' posts). Thresholds come from PRIVILEGE_THRESHOLDS and
+	// follow config reloads.
+	privilegeThresholds := func() reputation.Thresholds {
+		return config.DefaultReloader().Current().PrivilegeThresholds
+	}
+	privilegeGate := apimiddleware.NewPrivilegeGate(db.NewReputationRepository(pool), privilegeThresholds)
+
+	// Post title, description and success criterion length limits per post type.
+	// They come from CONTENT_LIMITS, follow config reloads and are served at
+	// GET /v1/config/limits so clients validate the same way.
+	contentLimits := func() models.ContentLimits {
+		return config.DefaultReloader().Current().ContentLimits
+	}
+	commentTargets := db.NewCommentsRepository(pool)
+
+	// Notification emails (answer accepted, claim link) are queued here and delivered
+	// by the email queue job in cmd/api when an email driver is configured.
+	var emailNotifier *services.EmailNotifier
+	if config.MailerConfig().Driver != "" {
+		emailQueueRepo := db.NewEmailQueueRepository(pool)
+		emailNotifier = services.NewEmailNotifier(emailQueueRepo, emailQueueRepo, db.NewPostRepository(pool))
+	}
+
+	// Web Push: browsers subscribe via /v1/me/push-subscriptions with the VAPID
+	// public key, and the web push job in cmd/api pushes their new notifications.
+	pushPublicKey := ""
+	if pushCfg := config.WebPushConfig(); pushCfg.Enabled() {
+		if sender, err := services.NewWebPushSender(pushCfg); err != nil {
+			log.Printf("WARNING: web push disabled: %v", err)
+		} else {
+			pushPublicKey = sender.PublicKey()
+		}
+	}
+	pushSubscriptionsHandler := handlers.NewPushSubscriptionsHandler(db.NewPushSubscriptionRepository(pool), pushPublicKey)
+
+	// Slack/Discord integrations (managed via /v1/admin/integrations) are told about
+	// public problems and questions when they are created or approved by moderation.
+	chatIntegrationRepo := db.NewChatIntegrationRepository(pool)
+	chatNotifier := services.NewChatIntegrationNotifier(chatIntegrationRepo)
+
 	agentsHandler := handlers.NewAgentsHandler(agentRepo, "")
 	agentsHandler.SetClaimTokenRepository(claimTokenRepo)
 	agentsHandler.SetBaseURL("https://solvr.dev")
 	// Room repo lets ClaimAgentWithToken backfill owner_id on rooms an agent created
 	// while unclaimed (family scope). The full room routes live in mountRoomRoutes.
 	agentsHandler.SetRoomRepository(roomRepo)
+	if emailNotifier != nil {
+		agentsHandler.SetEmailNotifier(emailNotifier)
+	}
+	// Self-registration friction: proof of work and operator email verification.
+	// Email verification needs the email queue to deliver the activation link.
+	agentRegistration := config.AgentRegistrationConfig()
+	agentsHandler.SetRegistrationPolicy(agentRegistration)
+	if emailNotifier != nil {
+		agentsHandler.SetEmailVerification(db.NewAgentVerificationRepository(pool), emailNotifier)
+	}
 
 	// Family-scoped room discovery handler for GET /v1/me/rooms. ListMyRooms only needs
 	// the room repo; the other room routes are served by mountRoomRoutes.
@@ -262,22 +458,47 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 
 	// Create posts handler
 	postsHandler := handlers.NewPostsHandler(postsRepo)
+	postsHandler.SetContentLimits(contentLimits)
 	postsHandler.SetApproachChecker(db.NewApproachesRepository(pool))
 	if embeddingService != nil {
 		postsHandler.SetEmbeddingService(embeddingService)
+		// Posts whose embedding can't be generated inline are queued for the embedding
+		// queue job, which only runs with an embedding service.
+		postsHandler.SetEmbeddingQueue(db.NewEmbeddingQueueRepository(pool))
+	}
+	postsHandler.SetPostPublishedNotifier(chatNotifier)
+	// Stack traces in descriptions are fingerprinted on create; matching posts are
+	// returned as possible_duplicates.
+	crashDuplicates := db.NewPostRepository(pool)
+	postsHandler.SetCrashDuplicateFinder(crashDuplicates)
+	// POST /v1/posts/import/github and /v1/posts/{id}/github-link. GITHUB_TOKEN is optional
+	// and only raises GitHub's rate limit; status sync runs in cmd/api.
+	postsHandler.SetGitHubImport(services.NewGitHubIssueClient(os.Getenv("GITHUB_TOKEN")), db.NewPostGitHubLinkRepository(pool))
+	// GET /v1/posts/{id}/freshness and outdated flags; scores are refreshed in cmd/api.
+	postsHandler.SetFreshnessRepository(db.NewPostFreshnessRepository(pool))
+	// Problem authors can let collaborators edit their post (PATCH /v1/posts/{id}).
+	postsHandler.SetCollaboratorRepository(db.NewPostCollaboratorRepository(pool))
+	// POST /v1/guest/problems needs the mailer: the claim link is only sent by email.
+	if emailNotifier != nil {
+		postsHandler.SetGuestPosting(db.NewClaimTokenRepository(pool), emailNotifier)
 	}
 	// Wire content moderation service if GROQ_API_KEY is configured
+	var contentModeration handlers.ContentModerationServiceInterface // also re-moderates answer edits
 	if groqAPIKey := os.Getenv("GROQ_API_KEY"); groqAPIKey != "" {
 		var modOpts []services.Option
-		if groqModel := os.Getenv("GROQ_MODEL"); groqModel != "" {
+		if groqModel := config.DefaultReloader().Current().ModerationModel; groqModel != "" {
 			modOpts = append(modOpts, services.WithGroqModel(groqModel))
 		}
 		modSvc := services.NewContentModerationService(groqAPIKey, modOpts...)
-		postsHandler.SetContentModerationService(NewContentModerationAdapter(modSvc))
+		moderationServices := []*services.ContentModerationService{modSvc}
+		contentModeration = NewContentModerationAdapter(modSvc)
+		postsHandler.SetContentModerationService(contentModeration)
 		if pr, ok := postsRepo.(*db.PostRepository); ok {
 			postsHandler.SetPostStatusUpdater(pr)
 		}
 		postsHandler.SetCommentRepo(commentsRepo)
+		commentTemplates := NewModerationCommentRenderer(pool)
+		postsHandler.SetModerationCommentRenderer(commentTemplates)
 		notifSvc := NewModerationNotificationService(notificationsRepoConcrete.Create)
 		postsHandler.SetNotificationService(notifSvc)
 
@@ -289,16 +510,29 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 				translationSvc = services.NewTranslationService(groqAPIKey, services.WithTranslationModel(translationModel))
 			}
 			reModSvc := services.NewContentModerationService(groqAPIKey, modOpts...)
+			moderationServices = append(moderationServices, reModSvc)
 			reModTrigger := handlers.NewModerationTrigger(
 				NewContentModerationAdapter(reModSvc),
 				pr,
 				slog.Default(),
 			)
 			reModTrigger.SetCommentRepo(commentsRepo)
+			reModTrigger.SetModerationCommentRenderer(commentTemplates)
 			reModTrigger.SetNotificationService(notifSvc)
+			reModTrigger.SetPostPublishedNotifier(chatNotifier)
 			translationTrigger := NewTranslationTriggerAdapter(translationSvc, pr, reModTrigger, slog.Default())
 			postsHandler.SetTranslationTrigger(translationTrigger)
 		}
+
+		// GROQ_MODEL is reloadable: switch the model without rebuilding the services
+		rt.onReload(func(old, next config.Runtime) []models.ConfigChange {
+			if old.ModerationModel != next.ModerationModel {
+				for _, svc := range moderationServices {
+					svc.SetGroqModel(next.ModerationModel)
+				}
+			}
+			return nil
+		})
 	} else {
 		slog.Warn("GROQ_API_KEY not set - content moderation disabled, posts created as pending_review without auto-moderation")
 	}
@@ -306,9 +540,7 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 	// Create search handler (per SPEC.md Part 5.5)
 	// Wire embedding service for hybrid RRF search (full-text + vector similarity)
 	if embeddingService != nil {
-		if sr, ok := searchRepo.(*db.SearchRepository); ok {
-			sr.SetEmbeddingService(embeddingService)
-		}
+		pgSearchRepo.SetEmbeddingService(embeddingService)
 	}
 	searchHandler := handlers.NewSearchHandler(searchRepo)
 
@@ -322,26 +554,81 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 
 	// Create feed handler (per SPEC.md Part 5.6: GET /feed endpoints)
 	feedHandler := handlers.NewFeedHandler(feedRepo)
+	// GET /v1/feed/briefing: daily summary of the caller's followed tags, summarized by Groq when configured
+	feedHandler.SetBriefingRepository(db.NewFeedBriefingRepository(pool))
+	if groqAPIKey := os.Getenv("GROQ_API_KEY"); groqAPIKey != "" {
+		var briefingOpts []services.FeedBriefingOption
+		if model := os.Getenv("FEED_BRIEFING_MODEL"); model != "" {
+			briefingOpts = append(briefingOpts, services.WithFeedBriefingModel(model))
+		}
+		feedHandler.SetBriefingSummarizer(services.NewFeedBriefingService(groqAPIKey, briefingOpts...))
+	}
 
 	// Create content handlers (API-CRITICAL per PRD-v2)
 	problemsHandler := handlers.NewProblemsHandler(problemsRepo)
+	problemsHandler.SetContentLimits(contentLimits)
 	if embeddingService != nil {
 		problemsHandler.SetEmbeddingService(embeddingService)
 	}
 	questionsHandler := handlers.NewQuestionsHandler(questionsRepo)
+	questionsHandler.SetContentLimits(contentLimits)
 	if embeddingService != nil {
 		questionsHandler.SetEmbeddingService(embeddingService)
 	}
 	ideasHandler := handlers.NewIdeasHandler(ideasRepo)
+	ideasHandler.SetContentLimits(contentLimits)
 	commentsHandler := handlers.NewCommentsHandler(commentsRepo)
 	commentsHandler.SetAgentRepository(agentRepo)
 
+	// @mentions in new answers and comments notify the mentioned user, unless
+	// they blocked or muted the author
+	mentionNotifier := NewMentionNotifier(notificationsRepoConcrete.Create, db.NewUserRepository(pool).FindByUsername, db.NewPrincipalBlockRepository(pool))
+	questionsHandler.SetMentionNotifier(mentionNotifier)
+	commentsHandler.SetMentionNotifier(mentionNotifier)
+
 	// Per FIX-020: Set posts repository on content handlers so type-specific list endpoints
 	// (GET /v1/problems, /v1/questions, /v1/ideas) return data consistent with /v1/posts
 	problemsHandler.SetPostsRepository(postsRepo)
 	approachRelRepo := db.NewApproachRelationshipsRepository(pool)
 	problemsHandler.SetApproachRelationshipsRepository(approachRelRepo)
+	// POST /v1/problems/{id}/stuck: escalations boost /v1/feed/stuck and notify
+	// agents whose specialties match the problem's tags
+	problemsHandler.SetStuckEscalationRepository(db.NewProblemEscalationsRepository(pool))
+	problemsHandler.SetNotificationCreator(notificationsRepoConcrete)
+	// PATCH /v1/problems/{id}/bounty: authors raise weight; solvers earn it on solve
+	problemsHandler.SetBountyRepository(db.NewBountyRepository(pool))
+	// GET /v1/problems/{id}/insights: strategy clusters from the strategy cluster job
+	problemsHandler.SetInsightsRepository(db.NewStrategyClustersRepository(pool))
+	// GET /v1/approaches/{id}/timeline: structured approach lifecycle events
+	problemsHandler.SetApproachEventsRepository(db.NewApproachEventsRepository(pool))
+	// /v1/approaches/{id}/transcript: compressed execution transcripts
+	problemsHandler.SetApproachTranscriptRepository(db.NewApproachTranscriptRepository(pool))
+	// /v1/problems/{id}/criteria: checkable success criteria; solving requires them met
+	criteriaRepo := db.NewSuccessCriteriaRepository(pool)
+	problemsHandler.SetSuccessCriteriaRepository(criteriaRepo)
+	postsHandler.SetSuccessCriteriaRepository(criteriaRepo)
+	problemsHandler.SetPostPublishedNotifier(chatNotifier)
+	problemsHandler.SetCrashDuplicateFinder(crashDuplicates)
 	questionsHandler.SetPostsRepository(postsRepo)
+	// GET /v1/questions/suggest: duplicate suggestions while composing a question
+	questionsHandler.SetSearchRepository(searchRepo)
+	questionsHandler.SetConfidenceThreshold(searchConfidenceThreshold)
+	// GET /v1/questions/{id}/context: answer-drafting context (also MCP solvr_context)
+	questionsHandler.SetContextRepository(db.NewQuestionContextRepository(pool))
+	// GET /v1/answers/{id}/versions: chains linked by supersedes_answer_id
+	questionsHandler.SetAnswerVersionsRepository(db.NewAnswersRepository(pool))
+	// POST /v1/questions/{id}/answers: answers close to an existing one on the same
+	// question (by embedding) are flagged as possible_duplicates or rejected
+	questionsHandler.SetAnswerDuplicateFinder(db.NewAnswersRepository(pool), config.AnswerDuplicateConfig())
+	// PATCH /v1/answers/{id}: large edits are re-moderated, drastic ones hidden until approved
+	if contentModeration != nil {
+		questionsHandler.SetAnswerRemoderation(contentModeration, db.NewAnswersRepository(pool), config.RemoderationConfig())
+	}
+	questionsHandler.SetPostPublishedNotifier(chatNotifier)
+	questionsHandler.SetCrashDuplicateFinder(crashDuplicates)
+	if emailNotifier != nil {
+		questionsHandler.SetEmailNotifier(emailNotifier)
+	}
 	ideasHandler.SetPostsRepository(postsRepo)
 
 	// Create user-related handlers (API-CRITICAL per PRD-v2)
@@ -349,7 +636,13 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 	userAPIKeysHandler := handlers.NewUserAPIKeysHandler(userAPIKeysRepo)
 	bookmarksHandler := handlers.NewBookmarksHandler(bookmarksRepo)
 	viewsHandler := handlers.NewViewsHandler(viewsRepo)
+	reactionsHandler := handlers.NewReactionsHandler(db.NewReactionRepository(pool))
 	reportsHandler := handlers.NewReportsHandler(reportsRepo)
+	if thresholdStr := os.Getenv("REPORT_AUTO_HIDE_THRESHOLD"); thresholdStr != "" {
+		if parsed, err := strconv.Atoi(thresholdStr); err == nil && parsed >= 0 {
+			reportsHandler.SetAutoHideThreshold(parsed)
+		}
+	}
 	followsHandler := handlers.NewFollowsHandler(followsRepo)
 
 	// Create users handler (BE-003: User profile endpoints)
@@ -373,6 +666,19 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		db.NewApproachesRepository(pool),
 		db.NewResponsesRepository(pool),
 	)
+	// GET /v1/users/{username}: profile, badges, activity and reputation breakdown in one call
+	if pool != nil {
+		usersHandler.SetPublicProfileRepositories(db.NewUserRepository(pool), db.NewUserProfileRepository(pool), db.NewBadgeRepository(pool))
+		usersHandler.SetUsernameChanger(db.NewUserRepository(pool), config.UsernameChangeConfig())
+	}
+	// POST/DELETE /v1/me/avatar: uploaded avatars, when an avatar bucket is configured
+	if avatarCfg := config.AvatarStorageConfig(); avatarCfg.Bucket != "" {
+		if store, err := services.NewS3Store(avatarCfg); err != nil {
+			log.Printf("Warning: avatar uploads disabled: %v", err)
+		} else {
+			usersHandler.SetAvatarStore(store)
+		}
+	}
 
 	// Create IPFS pinning handler (uses ipfsAPIURL passed from NewRouter)
 	ipfsService := services.NewKuboIPFSService(ipfsAPIURL)
@@ -397,14 +703,7 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 	resurrectionHandler.SetAgentRepo(agentRepoConcrete)
 
 	// Create IPFS upload handler
-	// Max upload size: configurable via env, defaults to 100MB
-	maxUploadSize := int64(handlers.DefaultMaxUploadSize)
-	if maxUploadSizeStr := os.Getenv("MAX_UPLOAD_SIZE_BYTES"); maxUploadSizeStr != "" {
-		if parsed, err := strconv.ParseInt(maxUploadSizeStr, 10, 64); err == nil && parsed > 0 {
-			maxUploadSize = parsed
-		}
-	}
-	uploadHandler := handlers.NewUploadHandler(ipfsService, maxUploadSize)
+	uploadHandler := handlers.NewUploadHandler(ipfsService, maxUploadSize())
 	uploadHandler.SetPinRepo(pinsRepo)
 	uploadHandler.SetStorageRepo(storageRepo)
 
@@ -453,6 +752,7 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		oauthUserService := services.NewOAuthUserService(userRepoForOAuth, authMethodRepoForOAuth)
 		oauthUserAdapter := services.NewOAuthUserServiceAdapter(oauthUserService)
 		oauthHandlers = handlers.NewOAuthHandlersWithUserService(oauthConfig, pool, nil, oauthUserAdapter)
+		oauthHandlers.SetSecurityMonitor(securityMonitor)
 		authUserRepo = db.NewUserRepository(pool)
 		authMethodRepo = authMethodRepoForOAuth
 		authReferralRepo = db.NewReferralRepository(pool)
@@ -474,24 +774,69 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		userAPIKeyValidator = auth.NewUserAPIKeyValidator(userAPIKeyDB)
 	}
 
+	// GDPR account deletion pipeline (DELETE /v1/me + receipts)
+	accountDeletionRepo := db.NewAccountDeletionRepository(pool)
+	accountDeletionHandler := handlers.NewAccountDeletionHandler(accountDeletionRepo)
+
+	// SEO: GET /sitemap.xml at the API root and GET /v1/posts/{id}/meta
+	// (OG/Twitter-card data and JSON-LD), both pointing crawlers at the frontend.
+	sitemapHandler := handlers.NewSitemapHandler(db.NewSitemapRepository(pool))
+	sitemapHandler.SetSiteURL(frontendURL)
+	r.Get("/sitemap.xml", sitemapHandler.GetSitemapXML)
+	postMetaHandler := handlers.NewPostMetaHandler(db.NewPostRepository(pool), frontendURL)
+	postMetaHandler.SetAnswersRepo(db.NewAnswersRepository(pool))
+	postMetaHandler.SetApproachesRepo(db.NewApproachesRepository(pool))
+
+	// Response cache for hot anonymous reads (stats, feed, post lists).
+	// Cleared on every successful v1 write; admin writes rely on the TTL.
+	responseCache := apimiddleware.NewResponseCache(responseCacheTTL())
+	if redisClient != nil {
+		responseCache.SetStore(apimiddleware.NewRedisResponseCacheStore(redisClient, cachePrefix+"cache:"))
+	}
+
 	// v1 API routes
 	r.Route("/v1", func(r chi.Router) {
+		r.Use(responseCache.InvalidateOnWrite)
+		if openAPIValidationEnabled() {
+			r.Use(openAPIRequestValidator(getOpenAPISpec(), "/v1"))
+		}
+
 		// Agent self-registration (no auth required)
 		// Per AGENT-ONBOARDING requirement: POST /v1/agents/register
-		r.Post("/agents/register", agentsHandler.RegisterAgent)
+		// Capped per IP (AGENT_REGISTRATION_MAX_PER_IP per AGENT_REGISTRATION_WINDOW)
+		if agentRegistration.MaxPerIP > 0 {
+			agentRegisterLimiter := apimiddleware.NewRegistrationRateLimiter(newRateLimitStore(redisClient, cachePrefix+"ratelimit:agent_register:"), &apimiddleware.RegistrationRateLimitConfig{
+				MaxPerIP:            agentRegistration.MaxPerIP,
+				Window:              agentRegistration.Window,
+				LogPrefix:           "agent_register",
+				SuspiciousThreshold: 2 * agentRegistration.MaxPerIP,
+			})
+			r.With(agentRegisterLimiter.Middleware).Post("/agents/register", agentsHandler.RegisterAgent)
+		} else {
+			r.Post("/agents/register", agentsHandler.RegisterAgent)
+		}
+
+		// Operator email verification for self-registered agents
+		// POST /v1/agents/verify-email - public, the emailed token activates the agent
+		// POST /v1/agents/me/verify-email - pending agent resends the email (API key auth)
+		r.Post("/agents/verify-email", agentsHandler.VerifyAgentEmail)
 
 		// Agent claim endpoints (API-CRITICAL requirement)
 		// POST /v1/agents/me/claim - agent generates claim URL (requires API key auth)
 		// Per FIX-002: Add API key auth middleware
 		r.Group(func(r chi.Router) {
 			r.Use(auth.APIKeyMiddleware(apiKeyValidator))
+			r.Use(keyUseMonitor.Middleware)
+			r.Use(auditRecorder.Middleware)
 			r.Post("/agents/me/claim", agentsHandler.GenerateClaim)
+			r.Post("/agents/me/verify-email", agentsHandler.ResendAgentVerification)
 		})
 
 		// SECURE agent claiming endpoint (requires JWT auth - humans only)
 		// POST /v1/agents/claim - claim agent with token from request body
 		r.Group(func(r chi.Router) {
 			r.Use(auth.JWTMiddleware(jwtSecret))
+			r.Use(auditRecorder.Middleware)
 			r.Post("/agents/claim", agentsHandler.ClaimAgentWithToken)
 		})
 
@@ -499,6 +844,24 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		// GET /v1/claim/{token} - get claim token info for confirmation page
 		r.Get("/claim/{token}", agentsHandler.GetClaimInfo)
 
+		// Guest posting: submit a problem without an account, claim it later.
+		// POST /v1/guest/problems - public, rate limited per IP
+		// GET /v1/guest/claim/{token} - public claim info for the confirmation page
+		// POST /v1/guest/claim - claim with the emailed token (requires JWT auth - humans only)
+		guestPostLimiter := apimiddleware.NewRegistrationRateLimiter(newRateLimitStore(redisClient, cachePrefix+"ratelimit:guest_post:"), &apimiddleware.RegistrationRateLimitConfig{
+			MaxPerIP:            5,
+			Window:              time.Hour,
+			LogPrefix:           "guest_post",
+			SuspiciousThreshold: 10,
+		})
+		r.With(guestPostLimiter.Middleware).Post("/guest/problems", postsHandler.CreateGuestProblem)
+		r.Get("/guest/claim/{token}", postsHandler.GetGuestClaimInfo)
+		r.Group(func(r chi.Router) {
+			r.Use(auth.JWTMiddleware(jwtSecret))
+			r.Use(auditRecorder.Middleware)
+			r.Post("/guest/claim", postsHandler.ClaimGuestPost)
+		})
+
 		// OAuth endpoints (API-CRITICAL requirement)
 		// SECURITY: Wrapped with BlockAgentAPIKeys middleware to prevent agents from
 		// registering as humans (see SPEC.md Part 21: Security)
@@ -514,6 +877,7 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		// SECURITY: Wrapped with BlockAgentAPIKeys middleware to prevent agents from
 		// registering as humans (see SPEC.md Part 21: Security)
 		authHandler := handlers.NewAuthHandlers(oauthConfig, authUserRepo, authMethodRepo, authReferralRepo)
+		authHandler.SetSecurityMonitor(securityMonitor)
 		r.With(apimiddleware.BlockAgentAPIKeys).Post("/auth/register", authHandler.Register)
 		r.With(apimiddleware.BlockAgentAPIKeys).Post("/auth/login", authHandler.Login)
 		r.Post("/auth/claim-referral", authHandler.ClaimReferral) // OAuth referral attribution
@@ -526,11 +890,109 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		moltbookHandler := handlers.NewMoltbookHandler(moltbookConfig, nil)
 		r.Post("/auth/moltbook", moltbookHandler.Authenticate)
 
+		// Admin user management (X-Admin-API-Key, like the root /admin routes)
+		// Listing with filters, suspend/ban with reason, restore soft-deleted users,
+		// and a read-only support view (no impersonation).
+		adminUsersHandler := handlers.NewAdminHandler(pool)
+		adminUsersHandler.SetAdminUserRepo(db.NewUserRepository(pool))
+		adminUsersHandler.SetAdminUserAgentsRepo(agentRepoConcrete)
+		adminUsersHandler.SetAdminUserAuthMethodsRepo(db.NewAuthMethodRepository(pool))
+		adminUsersHandler.SetAdminUserAPIKeysRepo(db.NewUserAPIKeyRepository(pool))
+		r.Get("/admin/users", adminUsersHandler.ListUsers)
+		r.Get("/admin/users/{id}", adminUsersHandler.GetUserSupportView)
+		r.With(auditRecorder.Middleware).Patch("/admin/users/{id}/status", adminUsersHandler.UpdateUserStatus)
+		r.With(auditRecorder.Middleware).Post("/admin/users/{id}/restore", adminUsersHandler.RestoreUser)
+		if pool != nil {
+			adminUsersHandler.SetAuditLogRepo(db.NewAuditLogRepository(pool))
+		}
+		r.Get("/admin/audit", adminUsersHandler.ListAuditLog)
+
+		// Ops overview: queue depths, 24h activity, error rates and service health
+		if pool != nil {
+			adminUsersHandler.SetDashboardRepo(db.NewAdminDashboardRepository(pool))
+		}
+		r.Get("/admin/dashboard", adminUsersHandler.GetDashboard)
+
+		// Admin tag moderation: rename/merge/blacklist update affected posts transactionally
+		if pool != nil {
+			adminUsersHandler.SetAdminTagRepo(db.NewTagsRepository(pool))
+		}
+		r.Get("/admin/tags/blacklist", adminUsersHandler.ListBlacklistedTags)
+		r.With(auditRecorder.Middleware).Post("/admin/tags/merge", adminUsersHandler.MergeTags)
+		r.With(auditRecorder.Middleware).Post("/admin/tags/{name}/rename", adminUsersHandler.RenameTag)
+		r.With(auditRecorder.Middleware).Post("/admin/tags/{name}/blacklist", adminUsersHandler.BlacklistTag)
+		r.With(auditRecorder.Middleware).Delete("/admin/tags/{name}/blacklist", adminUsersHandler.UnblacklistTag)
+
+		// Admin Slack/Discord integrations: new public problems/questions matching the
+		// integration's tags are posted to its incoming webhook
+		adminUsersHandler.SetChatIntegrationRepo(chatIntegrationRepo)
+		adminUsersHandler.SetChatIntegrationSender(chatNotifier)
+		r.Get("/admin/integrations", adminUsersHandler.ListChatIntegrations)
+		r.With(auditRecorder.Middleware).Post("/admin/integrations", adminUsersHandler.CreateChatIntegration)
+		r.With(auditRecorder.Middleware).Patch("/admin/integrations/{id}", adminUsersHandler.UpdateChatIntegration)
+		r.With(auditRecorder.Middleware).Delete("/admin/integrations/{id}", adminUsersHandler.DeleteChatIntegration)
+		r.With(auditRecorder.Middleware).Post("/admin/integrations/{id}/test", adminUsersHandler.TestChatIntegration)
+
+		// Admin read-only maintenance switch: rejects writes and pauses background jobs
+		adminUsersHandler.SetMaintenanceSwitch(maintenance.Default())
+		r.Get("/admin/maintenance", adminUsersHandler.GetMaintenance)
+		r.With(auditRecorder.Middleware).Post("/admin/maintenance", adminUsersHandler.UpdateMaintenance)
+
+		// Admin legal holds and retention: held posts are skipped by the stale content
+		// job, GDPR anonymization and account purges
+		if pool != nil {
+			adminUsersHandler.SetPostRetentionRepo(db.NewPostRetentionRepository(pool))
+		}
+		r.Get("/admin/legal-holds", adminUsersHandler.ListLegalHolds)
+		r.Get("/admin/posts/{id}/retention", adminUsersHandler.GetPostRetention)
+		r.With(auditRecorder.Middleware).Put("/admin/posts/{id}/retention", adminUsersHandler.SetPostRetention)
+
+		// Admin moderation comment templates: per-language overrides of the system
+		// comments left on approved, rejected and translating posts
+		if pool != nil {
+			adminUsersHandler.SetModerationTemplateRepo(db.NewModerationTemplateRepository(pool))
+		}
+		r.Get("/admin/moderation-templates", adminUsersHandler.ListModerationTemplates)
+		r.With(auditRecorder.Middleware).Put("/admin/moderation-templates/{key}/{language}", adminUsersHandler.PutModerationTemplate)
+		r.With(auditRecorder.Middleware).Delete("/admin/moderation-templates/{key}/{language}", adminUsersHandler.DeleteModerationTemplate)
+
+		// Admin freshness review queue: outdated accepted solutions, least fresh first
+		if pool != nil {
+			adminUsersHandler.SetFreshnessReviewRepo(db.NewPostFreshnessRepository(pool))
+		}
+		r.Get("/admin/freshness/review-queue", adminUsersHandler.ListFreshnessReviewQueue)
+		r.With(auditRecorder.Middleware).Post("/admin/freshness/{id}/review", adminUsersHandler.ReviewPostFreshness)
+
+		// Admin tenants: communities served by this deployment and their hostnames
+		if pool != nil {
+			adminUsersHandler.SetTenantRepo(db.NewTenantRepository(pool))
+		}
+		if tenantResolver != nil {
+			adminUsersHandler.SetTenantCache(tenantResolver)
+		}
+		r.Get("/admin/tenants", adminUsersHandler.ListTenants)
+		r.With(auditRecorder.Middleware).Put("/admin/tenants/{id}", adminUsersHandler.PutTenant)
+
+		// Change data capture: content mutations in commit order for external consumers (admin key)
+		if pool != nil {
+			adminUsersHandler.SetChangeEventRepo(db.NewChangeEventRepository(pool))
+		}
+		r.Get("/events/changes", adminUsersHandler.ListChangeEvents)
+
+		// Admin runtime config reload (same as SIGHUP)
+		adminUsersHandler.SetConfigReloader(config.DefaultReloader())
+		r.With(auditRecorder.Middleware).Post("/admin/config/reload", adminUsersHandler.ReloadConfig)
+
+		// GDPR deletion receipts (no auth: the account can no longer sign in)
+		r.Get("/account-deletions/{id}", accountDeletionHandler.GetReceipt)
+
 		// Search endpoint (API-CRITICAL per SPEC.md Part 5.5)
 		// GET /v1/search - search the knowledge base (public access per SPEC.md Part 5.6)
 		// OptionalAuth: never returns 401, but populates context for analytics identity
 		r.Group(func(r chi.Router) {
 			r.Use(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator))
+			r.Use(usageRecorder.Middleware)
+			r.Use(keyUseMonitor.Middleware)
 			r.Get("/search", searchHandler.Search)
 		})
 
@@ -538,7 +1000,28 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		// POST /v1/mcp - Model Context Protocol over HTTP (no auth required for tools/list)
 		mcpHandler := handlers.NewMCPHandler(searchRepo, postsRepo)
 		mcpHandler.SetConfidenceThreshold(searchConfidenceThreshold)
-		r.Post("/mcp", mcpHandler.Handle)
+		mcpHandler.SetMaintenanceState(maintenance.Default())
+		mcpHandler.SetContentLimits(contentLimits)
+		// solvr_related needs query embeddings; without them the tool reports it is unavailable.
+		if embeddingService != nil {
+			mcpHandler.SetRelatedFinder(pgSearchRepo)
+		}
+		// Workflow tools (solvr_approach/progress/verify) reuse the REST approach handlers;
+		// OptionalAuth populates the caller so they can authenticate with a Bearer token.
+		mcpHandler.SetApproachWorkflow(problemsHandler)
+		// solvr_context shares GET /v1/questions/{id}/context's builder.
+		mcpHandler.SetQuestionContextBuilder(questionsHandler)
+		mcpHandler.SetCrashDuplicateFinder(crashDuplicates)
+		// The global rate limiter runs before OptionalAuth and sees /mcp as anonymous, so
+		// each tools/call is charged to the caller's agent or API key here instead.
+		mcpHandler.SetRateLimiter(rateLimiter)
+		// Streamable HTTP transport: POST messages, GET session event stream, DELETE session.
+		r.Group(func(r chi.Router) {
+			r.Use(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator))
+			r.Post("/mcp", mcpHandler.Handle)
+			r.Get("/mcp", mcpHandler.Handle)
+			r.Delete("/mcp", mcpHandler.Handle)
+		})
 
 		// Agents list endpoint (API-001)
 		// GET /v1/agents - list registered agents (no auth required)
@@ -575,6 +1058,7 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 
 		// User profile endpoint (BE-003)
 		// GET /v1/users/{id} - get user profile (no auth required)
+		// GET /v1/users/{username} - full public profile with activity (no auth required)
 		r.Get("/users/{id}", usersHandler.GetUserProfile)
 
 		// Per prd-v4: GET /v1/users/{id}/agents - list agents claimed by user (no auth required)
@@ -598,20 +1082,33 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			})
 		}
 
+		// GET /v1/config/limits - post length limits per type, for client-side validation (no auth required)
+		r.Get("/config/limits", handlers.NewContentLimitsHandler(contentLimits).Get)
+
 		// Posts endpoints (API-CRITICAL requirement)
 		// Per SPEC.md Part 5.6: GET /v1/posts - list posts (no auth required, optional auth for user_vote)
 		// OptionalAuthMiddleware parses auth if present (for user_vote in response) but never returns 401
 		r.Group(func(r chi.Router) {
 			r.Use(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator))
-			r.Get("/posts", postsHandler.List)
+			r.Use(usageRecorder.Middleware)
+			r.Use(keyUseMonitor.Middleware)
+			r.With(apimiddleware.ETag, responseCache.Middleware).Get("/posts", postsHandler.List)
 			// Per SPEC.md Part 5.6: GET /v1/posts/:id - single post (no auth required, optional auth for user_vote)
-			r.Get("/posts/{id}", postsHandler.Get)
+			r.With(apimiddleware.ETag).Get("/posts/{id}", postsHandler.Get)
+			// GET /v1/posts/:id/github-link - linked GitHub issue (optional auth for family posts)
+			r.Get("/posts/{id}/github-link", postsHandler.GetGitHubLink)
+			// GET /v1/posts/:id/freshness - freshness score, outdated flags and pinned library versions
+			r.Get("/posts/{id}/freshness", postsHandler.GetFreshness)
+			// GET /v1/posts/:id/collaborators - who besides the author may edit the problem
+			r.Get("/posts/{id}/collaborators", postsHandler.ListCollaborators)
 		})
 		// FE-013: View tracking endpoints
 		// POST /v1/posts/:id/view - record a view (optional auth)
 		r.Post("/posts/{id}/view", viewsHandler.RecordView)
 		// GET /v1/posts/:id/views - get view count (no auth required)
 		r.Get("/posts/{id}/views", viewsHandler.GetViewCount)
+		// GET /v1/posts/:id/meta - SEO metadata and JSON-LD (no auth required)
+		r.Get("/posts/{id}/meta", postMetaHandler.GetPostMeta)
 
 		// Email unsubscribe — public endpoint, HMAC-signed token validates identity
 		if pool != nil {
@@ -620,8 +1117,10 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		}
 
 		// Feed endpoints (per SPEC.md Part 5.6 and FIX-004)
-		// GET /v1/feed - recent activity (no auth required)
-		r.Get("/feed", feedHandler.Feed)
+		// GET /v1/feed - recent activity (no auth required; scope=following needs auth,
+		// which also bypasses the anonymous response cache)
+		r.With(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator),
+			apimiddleware.ETag, responseCache.Middleware).Get("/feed", feedHandler.Feed)
 		// GET /v1/feed/stuck - problems needing help (no auth required)
 		r.Get("/feed/stuck", feedHandler.Stuck)
 		// GET /v1/feed/unanswered - unanswered questions (no auth required)
@@ -629,13 +1128,20 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 
 		// Stats endpoints (for frontend dashboard)
 		var statsRepo handlers.StatsRepositoryInterface
+		var statsHistoryRepo handlers.StatsHistoryRepositoryInterface
 		if pool != nil {
-			statsRepo = db.NewStatsRepository(pool)
+			statsRepoConcrete := db.NewStatsRepository(pool)
+			statsRepo = statsRepoConcrete
+			statsHistoryRepo = statsRepoConcrete
 		}
 		if statsRepo != nil {
 			statsHandler := handlers.NewStatsHandler(statsRepo)
-			r.Get("/stats", statsHandler.GetStats)
-			r.Get("/stats/trending", statsHandler.GetTrending)
+			statsHandler.SetTrendingConfig(config.TrendingConfig())
+			statsHandler.SetHistoryRepo(statsHistoryRepo)
+			r.With(responseCache.Middleware).Get("/stats", statsHandler.GetStats)
+			r.With(responseCache.Middleware).Get("/stats/trending", statsHandler.GetTrending)
+			// GET /v1/stats/history?metric=posts&window=90d - daily snapshots for growth charts
+			r.With(responseCache.Middleware).Get("/stats/history", statsHandler.GetStatsHistory)
 			r.Get("/stats/ideas", statsHandler.GetIdeasStats)
 			r.Get("/stats/problems", statsHandler.GetProblemsStats)
 			r.Get("/stats/questions", statsHandler.GetQuestionsStats)
@@ -657,8 +1163,6 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		// Sitemap endpoint (SEO-URGENT, no auth required)
 		// GET /v1/sitemap/urls - returns all indexable content for sitemap generation
 		if pool != nil {
-			sitemapRepo := db.NewSitemapRepository(pool)
-			sitemapHandler := handlers.NewSitemapHandler(sitemapRepo)
 			r.Get("/sitemap/urls", sitemapHandler.GetSitemapURLs)
 			r.Get("/sitemap/counts", sitemapHandler.GetSitemapCounts)
 		}
@@ -675,6 +1179,14 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			r.Get("/data/categories", dataHandler.GetCategories)
 		}
 
+		// GET /v1/export/qa-pairs - accepted Q→A and problem→verified-solution pairs as
+		// JSONL for fine-tuning (API key or X-Admin-API-Key; OptionalAuth identifies the key)
+		if pool != nil {
+			qaExportHandler := handlers.NewQAExportHandler(db.NewQAExportRepository(pool), frontendURL)
+			r.With(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator),
+				usageRecorder.Middleware, keyUseMonitor.Middleware).Get("/export/qa-pairs", qaExportHandler.ExportQAPairs)
+		}
+
 		// Leaderboard endpoints (PRD-v5)
 		// GET /v1/leaderboard - global leaderboard (no auth required)
 		// GET /v1/leaderboard/tags/{tag} - tag-specific leaderboard (no auth required)
@@ -685,15 +1197,40 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			r.Get("/leaderboard/tags/{tag}", leaderboardHandler.GetLeaderboardByTag)
 		}
 
+		// Tag endpoints (no auth required)
+		// GET /v1/tags - tags with usage counts; GET /v1/tags/{name} - tag detail with top posts
+		if pool != nil {
+			tagsHandler := handlers.NewTagsHandler(db.NewTagsRepository(pool))
+			r.Get("/tags", tagsHandler.ListTags)
+			r.Get("/tags/{name}", tagsHandler.GetTag)
+		}
+
+		// GET /v1/entities/{name} - knowledge graph entity page (no auth required);
+		// entities are extracted from posts by a job in cmd/api
+		if pool != nil {
+			entitiesHandler := handlers.NewEntitiesHandler(db.NewEntityRepository(pool))
+			r.Get("/entities/{name}", entitiesHandler.GetEntity)
+		}
+
+		// GET /v1/templates - structured post templates (no auth required)
+		r.Get("/templates", handlers.ListPostTemplates)
+
+		// GET /v1/push/vapid-public-key - applicationServerKey for Web Push (no auth required)
+		r.Get("/push/vapid-public-key", pushSubscriptionsHandler.GetVAPIDPublicKey)
+
 		// Blog endpoints (PRD-v5: public reads with optional auth for user_vote)
 		r.Group(func(r chi.Router) {
 			r.Use(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator))
+			r.Use(usageRecorder.Middleware)
+			r.Use(keyUseMonitor.Middleware)
 			r.Get("/blog", blogHandler.List)
 		})
 		r.Get("/blog/featured", blogHandler.GetFeatured)
 		r.Get("/blog/tags", blogHandler.ListTags)
 		r.Group(func(r chi.Router) {
 			r.Use(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator))
+			r.Use(usageRecorder.Middleware)
+			r.Use(keyUseMonitor.Middleware)
 			r.Get("/blog/{slug}", blogHandler.GetBySlug)
 		})
 		r.Post("/blog/{slug}/view", blogHandler.RecordView)
@@ -703,10 +1240,12 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		// private posts here too (anonymous callers still see public-only). Never 401s.
 		r.Group(func(r chi.Router) {
 			r.Use(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator))
+			r.Use(usageRecorder.Middleware)
+			r.Use(keyUseMonitor.Middleware)
 
 			// Problems endpoints (API-CRITICAL per PRD-v2)
 			// GET /v1/problems - list problems (no auth required)
-			r.Get("/problems", problemsHandler.List)
+			r.With(apimiddleware.ETag).Get("/problems", problemsHandler.List)
 			// GET /v1/problems/:id - single problem (no auth required)
 			r.Get("/problems/{id}", problemsHandler.Get)
 			// GET /v1/problems/:id/approaches - list approaches (no auth required)
@@ -715,19 +1254,33 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			r.Get("/problems/{id}/approaches/{approachId}/history", problemsHandler.GetApproachHistory)
 			// GET /v1/problems/:id/export - export problem as markdown (no auth required)
 			r.Get("/problems/{id}/export", problemsHandler.Export)
+			// GET /v1/problems/:id/insights - strategy clusters of approaches (no auth required)
+			r.Get("/problems/{id}/insights", problemsHandler.GetInsights)
+			// GET /v1/approaches/:id/timeline - approach lifecycle events (no auth required)
+			r.Get("/approaches/{id}/timeline", problemsHandler.GetApproachTimeline)
+			// GET /v1/approaches/:id/transcript - execution transcript (no auth required)
+			r.Get("/approaches/{id}/transcript", problemsHandler.GetTranscript)
+			// GET /v1/problems/:id/criteria - success criteria and their met state (no auth required)
+			r.Get("/problems/{id}/criteria", problemsHandler.ListSuccessCriteria)
 
 			// Questions endpoints (API-CRITICAL per PRD-v2)
 			// GET /v1/questions - list questions (no auth required)
-			r.Get("/questions", questionsHandler.List)
+			r.With(apimiddleware.ETag).Get("/questions", questionsHandler.List)
+			// GET /v1/questions/suggest?title=... - likely duplicates for a draft title (no auth required)
+			r.Get("/questions/suggest", questionsHandler.Suggest)
 			// GET /v1/questions/:id - single question (no auth required)
 			r.Get("/questions/{id}", questionsHandler.Get)
 			// GET /v1/questions/:id/answers - list answers (no auth required)
 			// Per FIX-022: Allow viewing answers before answering
 			r.Get("/questions/{id}/answers", questionsHandler.ListAnswers)
+			// GET /v1/questions/:id/context - question + answers + related solutions, sized by max_tokens (no auth required)
+			r.Get("/questions/{id}/context", questionsHandler.GetContext)
+			// GET /v1/answers/:id/versions - the answer's chain of improved versions (no auth required)
+			r.Get("/answers/{id}/versions", questionsHandler.GetAnswerVersions)
 
 			// Ideas endpoints (API-CRITICAL per PRD-v2)
 			// GET /v1/ideas - list ideas (no auth required)
-			r.Get("/ideas", ideasHandler.List)
+			r.With(apimiddleware.ETag).Get("/ideas", ideasHandler.List)
 			// GET /v1/ideas/:id - single idea (no auth required)
 			r.Get("/ideas/{id}", ideasHandler.Get)
 			// GET /v1/ideas/:id/responses - list responses (no auth required)
@@ -738,26 +1291,48 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 		// Comments endpoints (API-CRITICAL per PRD-v2)
 		// GET /v1/{target_type}/{id}/comments - list comments (no auth required)
 		// Note: Routes use singular form (approach, answer, response) to match handler expectations
-		r.Get("/approaches/{id}/comments", wrapCommentsListWithType(commentsHandler, "approach"))
-		r.Get("/answers/{id}/comments", wrapCommentsListWithType(commentsHandler, "answer"))
-		r.Get("/responses/{id}/comments", wrapCommentsListWithType(commentsHandler, "response"))
-		// FIX-019: GET /v1/posts/{id}/comments - list comments on posts (no auth required)
-		r.Get("/posts/{id}/comments", wrapCommentsListWithType(commentsHandler, "post"))
+		// OptionalAuth identifies the caller so comments by principals they blocked are left out
+		r.Group(func(r chi.Router) {
+			r.Use(auth.OptionalAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator))
+			r.Get("/approaches/{id}/comments", wrapCommentsListWithType(commentsHandler, "approach"))
+			r.Get("/answers/{id}/comments", wrapCommentsListWithType(commentsHandler, "answer"))
+			r.Get("/responses/{id}/comments", wrapCommentsListWithType(commentsHandler, "response"))
+			// FIX-019: GET /v1/posts/{id}/comments - list comments on posts (no auth required)
+			r.Get("/posts/{id}/comments", wrapCommentsListWithType(commentsHandler, "post"))
+		})
 
 		// Protected posts routes (require authentication)
 		// Per FIX-003: Use UnifiedAuthMiddleware so JWT (humans), agent API keys, and user API keys all work
 		r.Group(func(r chi.Router) {
 			// Use unified auth middleware that accepts JWT, agent API keys, and user API keys
 			r.Use(auth.UnifiedAuthMiddleware(jwtSecret, apiKeyValidator, userAPIKeyValidator))
+			r.Use(usageRecorder.Middleware)
+			r.Use(keyUseMonitor.Middleware)
+			r.Use(auditRecorder.Middleware)
 
 			// Per SPEC.md Part 5.6: POST /v1/posts - create post (requires auth)
 			r.Post("/posts", postsHandler.Create)
+			// POST /v1/posts/import/github - create a problem from a GitHub issue
+			r.Post("/posts/import/github", postsHandler.ImportGitHubIssue)
+			// POST/DELETE /v1/posts/:id/github-link - link own post to a GitHub issue (status sync)
+			r.Post("/posts/{id}/github-link", postsHandler.LinkGitHubIssue)
+			r.Delete("/posts/{id}/github-link", postsHandler.UnlinkGitHubIssue)
+			// POST/DELETE /v1/posts/:id/outdated - flag a post outdated, or withdraw the flag
+			r.Post("/posts/{id}/outdated", postsHandler.FlagOutdated)
+			r.Delete("/posts/{id}/outdated", postsHandler.UnflagOutdated)
+			// POST/DELETE /v1/posts/:id/collaborators/:principal - let others edit an own problem
+			r.Post("/posts/{id}/collaborators/{principal}", postsHandler.AddCollaborator)
+			r.Delete("/posts/{id}/collaborators/{principal}", postsHandler.RemoveCollaborator)
 			// Per SPEC.md Part 5.6: PATCH /v1/posts/:id - update post (requires auth)
 			r.Patch("/posts/{id}", postsHandler.Update)
+			// PATCH /v1/posts/:id/tags - retag a post (own posts, or others' with edit_tags)
+			r.With(privilegeGate.Require(reputation.PrivilegeEditTags, retagPrivilegeNeeded(postsRepo))).Patch("/posts/{id}/tags", postsHandler.RetagPost)
 			// Per SPEC.md Part 5.6: DELETE /v1/posts/:id - delete post (requires auth)
 			r.Delete("/posts/{id}", postsHandler.Delete)
 			// Per SPEC.md Part 5.6: POST /v1/posts/:id/vote - vote on post (requires auth)
-			r.Post("/posts/{id}/vote", postsHandler.Vote)
+			r.With(privilegeGate.Require(reputation.PrivilegeVoteDown, apimiddleware.DownVote)).Post("/posts/{id}/vote", postsHandler.Vote)
+			// DELETE /v1/posts/:id/vote - retract the caller's vote (requires auth)
+			r.Delete("/posts/{id}/vote", postsHandler.RetractVote)
 			// GET /v1/posts/:id/my-vote - get current user's vote on a post (requires auth)
 			r.Get("/posts/{id}/my-vote", postsHandler.GetMyVote)
 
@@ -765,7 +1340,7 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			r.Post("/blog", blogHandler.Create)
 			r.Patch("/blog/{slug}", blogHandler.Update)
 			r.Delete("/blog/{slug}", blogHandler.Delete)
-			r.Post("/blog/{slug}/vote", blogHandler.Vote)
+			r.With(privilegeGate.Require(reputation.PrivilegeVoteDown, apimiddleware.DownVote)).Post("/blog/{slug}/vote", blogHandler.Vote)
 
 			// Per prd-v4: PATCH /v1/agents/{id} - update agent profile (requires auth)
 			// Works with JWT (human owner) or API key (agent updating itself)
@@ -815,6 +1390,8 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			meHandler.SetBriefingService(briefingSvc)
 			meHandler.SetAgentFinderRepo(agentRepoConcrete)
 			meHandler.SetBadgeRepo(db.NewBadgeRepository(pool))
+			meHandler.SetPrivilegeThresholds(privilegeThresholds)
+			meHandler.SetAccountDeletionRepo(accountDeletionRepo, accountDeletionGracePeriod())
 			r.Get("/me", meHandler.Me)
 			r.Get("/me/auth-methods", meHandler.GetMyAuthMethods)
 
@@ -842,6 +1419,16 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			storageHandler.SetAgentFinderRepo(agentRepoConcrete)
 			r.Get("/me/storage", storageHandler.GetStorage)
 
+			// GET /v1/me/usage - daily per-endpoint request counts and latencies
+			meUsageHandler := handlers.NewMeUsageHandler(usageRepo)
+			meUsageHandler.SetRateLimits(usageRateLimits(rateLimitConfig, true), usageRateLimits(rateLimitConfig, false))
+			rt.onReload(func(_, _ config.Runtime) []models.ConfigChange {
+				limits := rateLimiter.Config()
+				meUsageHandler.SetRateLimits(usageRateLimits(limits, true), usageRateLimits(limits, false))
+				return nil
+			})
+			r.Get("/me/usage", meUsageHandler.GetUsage)
+
 			// GET /v1/agents/{id}/pins - agent pins for human owners or agent self
 			r.Get("/agents/{id}/pins", func(w http.ResponseWriter, req *http.Request) {
 				agentID := chi.URLParam(req, "id")
@@ -870,19 +1457,52 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			// BE-003: User profile endpoints
 			// PATCH /v1/me - update own profile
 			r.Patch("/me", usersHandler.UpdateProfile)
+			// POST/DELETE /v1/me/avatar - upload (resized, stored in S3) or remove own avatar
+			r.Post("/me/avatar", usersHandler.UploadAvatar)
+			r.Delete("/me/avatar", usersHandler.DeleteAvatar)
 			// GET /v1/me/posts - list own posts
 			r.Get("/me/posts", usersHandler.GetMyPosts)
+			// GET /v1/me/posts/{id}/analytics - views, search impressions, votes and referrers for own post
+			postAnalyticsHandler := handlers.NewPostAnalyticsHandler(db.NewPostAnalyticsRepository(pool))
+			r.Get("/me/posts/{id}/analytics", postAnalyticsHandler.GetPostAnalytics)
 			// GET /v1/me/rooms - family-scoped room discovery (rooms owned by the caller's
 			// human, INCLUDING private rooms) so agents can find sibling rooms.
 			r.Get("/me/rooms", roomDiscoveryHandler.ListMyRooms)
 			// GET /v1/me/contributions - list own contributions
 			r.Get("/me/contributions", usersHandler.GetMyContributions)
+			// Tag following: followed tags rank matching posts higher in GET /v1/feed?scope=following
+			tagFollowsHandler := handlers.NewTagFollowsHandler(db.NewTagsRepository(pool))
+			r.Get("/me/tags", tagFollowsHandler.List)
+			r.Post("/me/tags/{tag}/follow", tagFollowsHandler.Follow)
+			r.Delete("/me/tags/{tag}/follow", tagFollowsHandler.Unfollow)
+			// Block and mute lists: blocked principals' answers and comments are hidden
+			// from the caller, and blocked or muted principals' mentions don't notify them
+			blocksHandler := handlers.NewBlocksHandler(db.NewPrincipalBlockRepository(pool))
+			r.Get("/me/blocks", blocksHandler.List)
+			r.Post("/me/blocks/{principal}", blocksHandler.Block)
+			r.Delete("/me/blocks/{principal}", blocksHandler.Unblock)
+			// GET /v1/feed/briefing - daily summary of the followed tags, cached per caller per day
+			r.Get("/feed/briefing", feedHandler.Briefing)
+			// Per-event opt-outs for notification emails (humans only)
+			emailPrefsHandler := handlers.NewEmailPreferencesHandler(db.NewUserRepository(pool))
+			r.Get("/me/email-preferences", emailPrefsHandler.Get)
+			r.Patch("/me/email-preferences", emailPrefsHandler.Update)
+			// Web Push subscriptions for the caller's browsers (humans only)
+			r.Get("/me/push-subscriptions", pushSubscriptionsHandler.List)
+			r.Post("/me/push-subscriptions", pushSubscriptionsHandler.Create)
+			r.Delete("/me/push-subscriptions", pushSubscriptionsHandler.Delete)
+			// GET /v1/me/security/events - sign-in history with anomaly flags
+			r.Get("/me/security/events", handlers.NewSecurityEventsHandler(securityEventRepo).List)
 
 			// Protected problems endpoints (API-CRITICAL per PRD-v2)
 			r.Post("/problems", problemsHandler.Create)
 			r.Post("/problems/{id}/approaches", problemsHandler.CreateApproach)
+			r.Post("/problems/{id}/stuck", problemsHandler.MarkStuck)
+			r.Patch("/problems/{id}/bounty", problemsHandler.UpdateBounty)
+			r.Patch("/problems/{id}/criteria/{criterionId}", problemsHandler.UpdateSuccessCriterion)
 			r.Patch("/approaches/{id}", problemsHandler.UpdateApproach)
 			r.Post("/approaches/{id}/progress", problemsHandler.AddProgressNote)
+			r.Post("/approaches/{id}/transcript", problemsHandler.AttachTranscript)
 			r.Post("/approaches/{id}/verify", problemsHandler.VerifyApproach)
 
 			// Protected questions endpoints (API-CRITICAL per PRD-v2)
@@ -890,7 +1510,10 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			r.Post("/questions/{id}/answers", questionsHandler.CreateAnswer)
 			r.Patch("/answers/{id}", questionsHandler.UpdateAnswer)
 			r.Delete("/answers/{id}", questionsHandler.DeleteAnswer)
-			r.Post("/answers/{id}/vote", questionsHandler.VoteOnAnswer)
+			r.With(privilegeGate.Require(reputation.PrivilegeVoteDown, apimiddleware.DownVote)).Post("/answers/{id}/vote", questionsHandler.VoteOnAnswer)
+			r.Delete("/answers/{id}/vote", questionsHandler.RetractAnswerVote)
+			// POST /v1/answers/:id/reactions - toggle an emoji reaction (no reputation effect)
+			r.Post("/answers/{id}/reactions", reactionsHandler.ToggleAnswer)
 			r.Post("/questions/{id}/accept/{aid}", questionsHandler.AcceptAnswer)
 
 			// Protected ideas endpoints (API-CRITICAL per PRD-v2)
@@ -899,12 +1522,19 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			r.Post("/ideas/{id}/evolve", ideasHandler.Evolve)
 
 			// Protected comments endpoints (API-CRITICAL per PRD-v2)
-			r.Post("/approaches/{id}/comments", wrapCommentsCreateWithType(commentsHandler, "approach"))
-			r.Post("/answers/{id}/comments", wrapCommentsCreateWithType(commentsHandler, "answer"))
-			r.Post("/responses/{id}/comments", wrapCommentsCreateWithType(commentsHandler, "response"))
+			// Commenting on others' content needs the comment privilege
+			r.With(privilegeGate.Require(reputation.PrivilegeComment, commentPrivilegeNeeded(commentTargets, models.CommentTargetApproach))).
+				Post("/approaches/{id}/comments", wrapCommentsCreateWithType(commentsHandler, "approach"))
+			r.With(privilegeGate.Require(reputation.PrivilegeComment, commentPrivilegeNeeded(commentTargets, models.CommentTargetAnswer))).
+				Post("/answers/{id}/comments", wrapCommentsCreateWithType(commentsHandler, "answer"))
+			r.With(privilegeGate.Require(reputation.PrivilegeComment, commentPrivilegeNeeded(commentTargets, models.CommentTargetResponse))).
+				Post("/responses/{id}/comments", wrapCommentsCreateWithType(commentsHandler, "response"))
 			// FIX-019: POST /v1/posts/{id}/comments - create comment on posts (requires auth)
-			r.Post("/posts/{id}/comments", wrapCommentsCreateWithType(commentsHandler, "post"))
+			r.With(privilegeGate.Require(reputation.PrivilegeComment, commentPrivilegeNeeded(commentTargets, models.CommentTargetPost))).
+				Post("/posts/{id}/comments", wrapCommentsCreateWithType(commentsHandler, "post"))
 			r.Delete("/comments/{id}", commentsHandler.Delete)
+			// POST /v1/comments/:id/reactions - toggle an emoji reaction (no reputation effect)
+			r.Post("/comments/{id}/reactions", reactionsHandler.ToggleComment)
 
 			// Notifications endpoints (API-CRITICAL per PRD-v2)
 			// Per SPEC.md Part 5.6: GET /notifications - list notifications
@@ -957,6 +1587,10 @@ func mountV1Routes(r *chi.Mux, pool *db.Pool, ipfsAPIURL string, embeddingServic
 			r.Post("/reports", reportsHandler.Create)
 			// GET /reports/check - check if user has reported content (requires auth)
 			r.Get("/reports/check", reportsHandler.Check)
+			// POST /{posts,answers,comments}/{id}/report - report specific content (requires auth)
+			r.Post("/posts/{id}/report", reportsHandler.ReportPost)
+			r.Post("/answers/{id}/report", reportsHandler.ReportAnswer)
+			r.Post("/comments/{id}/report", reportsHandler.ReportComment)
 
 			// Follows endpoints (PRD-v5: social graph)
 			// POST /follow - follow an entity (requires auth)
@@ -1009,6 +1643,221 @@ func loadRateLimitConfig(pool *db.Pool) *apimiddleware.RateLimitConfig {
 	)
 }
 
+// rateLimitChanges lists the rate limits that differ between two configs, keyed by
+// their rate_limit_config row names.
+func rateLimitChanges(old, next *apimiddleware.RateLimitConfig) []models.ConfigChange {
+	var changes []models.ConfigChange
+	add := func(key string, o, n int) {
+		if o != n {
+			changes = append(changes, models.ConfigChange{Key: "rate_limit_config." + key, Old: strconv.Itoa(o), New: strconv.Itoa(n)})
+		}
+	}
+	add("agent_general_limit", old.AgentGeneralLimit, next.AgentGeneralLimit)
+	add("human_general_limit", old.HumanGeneralLimit, next.HumanGeneralLimit)
+	add("search_limit_per_min", old.SearchLimitPerMin, next.SearchLimitPerMin)
+	add("agent_posts_per_hour", old.AgentPostsPerHour, next.AgentPostsPerHour)
+	add("human_posts_per_hour", old.HumanPostsPerHour, next.HumanPostsPerHour)
+	add("agent_answers_per_hour", old.AgentAnswersPerHour, next.AgentAnswersPerHour)
+	add("human_answers_per_hour", old.HumanAnswersPerHour, next.HumanAnswersPerHour)
+	add("new_account_threshold_hours", int(old.NewAccountThreshold.Hours()), int(next.NewAccountThreshold.Hours()))
+	return changes
+}
+
+// defaultRequestLogSampling keeps health probe noise out of the request log.
+const defaultRequestLogSampling = "/health=0.01,/health/live=0.01,/health/ready=0.01"
+
+// requestLogSampleRates reads REQUEST_LOG_SAMPLING ("route=rate,...") with a fallback to
+// defaultRequestLogSampling. Set it to "none" to log every request.
+func requestLogSampleRates() map[string]float64 {
+	spec := os.Getenv("REQUEST_LOG_SAMPLING")
+	if spec == "none" {
+		return nil
+	}
+	if spec == "" {
+		spec = defaultRequestLogSampling
+	}
+	rates, err := apimiddleware.ParseSampleRates(spec)
+	if err != nil {
+		log.Printf("Warning: ignoring REQUEST_LOG_SAMPLING: %v", err)
+		rates, _ = apimiddleware.ParseSampleRates(defaultRequestLogSampling)
+	}
+	return rates
+}
+
+// compressionLevel reads COMPRESSION_LEVEL (1-9; 0 disables response
+// compression) with a fallback to apimiddleware.DefaultCompressionLevel.
+func compressionLevel() int {
+	v := os.Getenv("COMPRESSION_LEVEL")
+	if v == "" {
+		return apimiddleware.DefaultCompressionLevel
+	}
+	level, err := strconv.Atoi(v)
+	if err != nil || level < 0 || level > 9 {
+		log.Printf("Warning: ignoring invalid COMPRESSION_LEVEL %q", v)
+		return apimiddleware.DefaultCompressionLevel
+	}
+	return level
+}
+
+// maxUploadSize reads MAX_UPLOAD_SIZE_BYTES with a fallback to
+// handlers.DefaultMaxUploadSize (100MB).
+func maxUploadSize() int64 {
+	if v := os.Getenv("MAX_UPLOAD_SIZE_BYTES"); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return handlers.DefaultMaxUploadSize
+}
+
+// requestTimeout reads REQUEST_TIMEOUT (a Go duration) with a fallback to
+// defaultRequestTimeout. Routes in routeLimitRules may override it.
+func requestTimeout() time.Duration {
+	v := os.Getenv("REQUEST_TIMEOUT")
+	if v == "" {
+		return defaultRequestTimeout
+	}
+	timeout, err := time.ParseDuration(v)
+	if err != nil || timeout <= 0 {
+		log.Printf("Warning: ignoring invalid REQUEST_TIMEOUT %q", v)
+		return defaultRequestTimeout
+	}
+	return timeout
+}
+
+// defaultRequestTimeout bounds reading the body and handling most requests.
+const defaultRequestTimeout = 30 * time.Second
+
+// routeLimitRules are the routes whose timeout or body size differs from the
+// defaults: uploads and imports get more room, votes and comments less, and
+// event streams have no timeout.
+func routeLimitRules() []apimiddleware.RouteLimitRule {
+	return []apimiddleware.RouteLimitRule{
+		// Event streams stay open for as long as the client listens.
+		{Method: http.MethodGet, Pattern: "/v1/rooms/{slug}/stream", Limit: apimiddleware.RouteLimit{Timeout: apimiddleware.NoRouteTimeout}},
+		{Method: http.MethodGet, Pattern: "/r/{slug}/stream", Limit: apimiddleware.RouteLimit{Timeout: apimiddleware.NoRouteTimeout}},
+		// MCP answers tool calls as SSE streams; tools bound their own work.
+		{Pattern: "/v1/mcp", Limit: apimiddleware.RouteLimit{Timeout: apimiddleware.NoRouteTimeout}},
+
+		// IPFS upload (multipart): the handler re-checks the file size.
+		{Method: http.MethodPost, Pattern: "/v1/add", Limit: apimiddleware.RouteLimit{Timeout: 10 * time.Minute, MaxBodyBytes: maxUploadSize()}},
+		// Avatar upload (multipart): resized and stored before the response.
+		{Method: http.MethodPost, Pattern: "/v1/me/avatar", Limit: apimiddleware.RouteLimit{Timeout: 2 * time.Minute, MaxBodyBytes: handlers.MaxAvatarUploadSize + 64*1024}},
+		// GitHub import waits on the GitHub API.
+		{Method: http.MethodPost, Pattern: "/v1/posts/import/github", Limit: apimiddleware.RouteLimit{Timeout: 2 * time.Minute}},
+		// QA pair export streams up to models.MaxQAExportLimit records.
+		{Method: http.MethodGet, Pattern: "/v1/export/qa-pairs", Limit: apimiddleware.RouteLimit{Timeout: 10 * time.Minute}},
+
+		// Votes carry a direction; comments are at most models.MaxCommentContentLength characters.
+		{Method: http.MethodPost, Pattern: "/v1/posts/{id}/vote", Limit: apimiddleware.RouteLimit{MaxBodyBytes: 1024}},
+		{Method: http.MethodPost, Pattern: "/v1/answers/{id}/vote", Limit: apimiddleware.RouteLimit{MaxBodyBytes: 1024}},
+		{Method: http.MethodPost, Pattern: "/v1/blog/{slug}/vote", Limit: apimiddleware.RouteLimit{MaxBodyBytes: 1024}},
+		{Method: http.MethodPost, Pattern: "/v1/{target}/{id}/comments", Limit: apimiddleware.RouteLimit{MaxBodyBytes: 16 * 1024}},
+		// Transcripts are at most models.MaxTranscriptBytes, plus room for the request envelope.
+		{Method: http.MethodPost, Pattern: "/v1/approaches/{id}/transcript", Limit: apimiddleware.RouteLimit{MaxBodyBytes: models.MaxTranscriptBytes + 64*1024}},
+	}
+}
+
+// responseCacheTTL reads RESPONSE_CACHE_TTL (a Go duration, e.g. "30s") with a fallback to
+// apimiddleware.DefaultResponseCacheTTL. Set it to "0" to disable the response cache.
+func responseCacheTTL() time.Duration {
+	v := os.Getenv("RESPONSE_CACHE_TTL")
+	if v == "" {
+		return apimiddleware.DefaultResponseCacheTTL
+	}
+	ttl, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Warning: ignoring invalid RESPONSE_CACHE_TTL %q: %v", v, err)
+		return apimiddleware.DefaultResponseCacheTTL
+	}
+	return ttl
+}
+
+// newCacheRedisClient connects to Redis when the cache driver is redis. It
+// returns nil (in-memory rate limits and response cache) for the memory driver
+// or when Redis is unusable at startup, so a Redis outage never stops the API.
+func newCacheRedisClient(cfg models.CacheConfig) *redis.Client {
+	switch cfg.Driver {
+	case models.CacheDriverMemory:
+		return nil
+	case models.CacheDriverRedis:
+	default:
+		log.Printf("WARNING: unknown CACHE_DRIVER %q, using in-memory rate limits and response cache", cfg.Driver)
+		return nil
+	}
+
+	client, err := redis.New(cfg.RedisURL)
+	if err != nil {
+		log.Printf("WARNING: CACHE_DRIVER=redis unusable (%v), using in-memory rate limits and response cache", err)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx); err != nil {
+		log.Printf("WARNING: Redis unreachable (%v), using in-memory rate limits and response cache", err)
+		return nil
+	}
+	log.Println("Rate limits and response cache shared via Redis")
+	return client
+}
+
+// newRateLimitStore returns a Redis rate limit store under prefix, or an
+// in-memory store when client is nil.
+func newRateLimitStore(client *redis.Client, prefix string) apimiddleware.RateLimitStore {
+	if client == nil {
+		return apimiddleware.NewInMemoryRateLimitStore()
+	}
+	return apimiddleware.NewRedisRateLimitStore(client, prefix)
+}
+
+// openAPIValidationEnabled reports whether OPENAPI_VALIDATE_REQUESTS turns on
+// spec validation of /v1 request bodies. Off by default.
+func openAPIValidationEnabled() bool {
+	v := os.Getenv("OPENAPI_VALIDATE_REQUESTS")
+	if v == "" {
+		return false
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Printf("Warning: ignoring invalid OPENAPI_VALIDATE_REQUESTS %q", v)
+		return false
+	}
+	return enabled
+}
+
+// accountDeletionGracePeriod reads ACCOUNT_DELETION_GRACE_DAYS, falling back to
+// handlers.DefaultAccountDeletionGracePeriod.
+func accountDeletionGracePeriod() time.Duration {
+	v := os.Getenv("ACCOUNT_DELETION_GRACE_DAYS")
+	if v == "" {
+		return handlers.DefaultAccountDeletionGracePeriod
+	}
+	days, err := strconv.Atoi(v)
+	if err != nil || days <= 0 {
+		log.Printf("Warning: ignoring invalid ACCOUNT_DELETION_GRACE_DAYS %q", v)
+		return handlers.DefaultAccountDeletionGracePeriod
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// usageRateLimits converts the active rate limit config into the limits reported by GET /v1/me/usage.
+func usageRateLimits(cfg *apimiddleware.RateLimitConfig, isAgent bool) handlers.UsageRateLimits {
+	if isAgent {
+		return handlers.UsageRateLimits{
+			GeneralPerMinute: cfg.AgentGeneralLimit,
+			SearchPerMinute:  cfg.SearchLimitPerMin,
+			PostsPerHour:     cfg.AgentPostsPerHour,
+			AnswersPerHour:   cfg.AgentAnswersPerHour,
+		}
+	}
+	return handlers.UsageRateLimits{
+		GeneralPerMinute: cfg.HumanGeneralLimit,
+		SearchPerMinute:  cfg.SearchLimitPerMin,
+		PostsPerHour:     cfg.HumanPostsPerHour,
+		AnswersPerHour:   cfg.HumanAnswersPerHour,
+	}
+}
+
 // requestIDMiddleware adds a unique request ID to each request
 func requestIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1061,6 +1910,32 @@ func wrapCommentsCreateWithType(h *handlers.CommentsHandler, targetType string)
 	}
 }
 
+// commentPrivilegeNeeded is the comment privilege condition: commenting on your own
+// content, or on replies to your own post, needs no reputation.
+func commentPrivilegeNeeded(targets *db.CommentsRepository, targetType models.CommentTargetType) apimiddleware.PrivilegeCondition {
+	return func(r *http.Request) (bool, error) {
+		p := auth.PrincipalFromContext(r.Context())
+		owner, err := targets.IsTargetOwner(r.Context(), targetType, chi.URLParam(r, "id"), p.Type, p.ID)
+		return !owner, err
+	}
+}
+
+// retagPrivilegeNeeded is the edit_tags privilege condition: authors retag their
+// own posts freely. Unknown posts are left to the handler's 404.
+func retagPrivilegeNeeded(posts handlers.PostsRepositoryInterface) apimiddleware.PrivilegeCondition {
+	return func(r *http.Request) (bool, error) {
+		p := auth.PrincipalFromContext(r.Context())
+		post, err := posts.FindByID(r.Context(), chi.URLParam(r, "id"))
+		if errors.Is(err, db.ErrPostNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return post.PostedByType != p.Type || post.PostedByID != p.ID, nil
+	}
+}
+
 // ipfsHealthAdapter wraps KuboIPFSService to satisfy handlers.IPFSHealthChecker.
 type ipfsHealthAdapter struct {
 	ipfs *services.KuboIPFSService
@@ -1086,17 +1961,6 @@ type HealthResponse struct {
 	Database  string `json:"database,omitempty"`
 }
 
-// ErrorResponse is the standard error response structure
-type ErrorResponse struct {
-	Error ErrorDetail `json:"error"`
-}
-
-// ErrorDetail contains error details
-type ErrorDetail struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
-
 // healthHandler handles GET /health
 func healthHandler(w http.ResponseWriter, r *http.Request) {
 	response := HealthResponse{
@@ -1115,36 +1979,14 @@ func healthLiveHandler(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, response)
 }
 
-// healthReadyHandler handles GET /health/ready
-func healthReadyHandler(pool *db.Pool) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if pool == nil {
-			writeError(w, http.StatusServiceUnavailable, "SERVICE_UNAVAILABLE", "database not configured")
-			return
-		}
-
-		// Ping the database
-		if err := pool.Ping(r.Context()); err != nil {
-			writeError(w, http.StatusServiceUnavailable, "DATABASE_UNAVAILABLE", "database ping failed")
-			return
-		}
-
-		response := HealthResponse{
-			Status:   "ready",
-			Database: "ok",
-		}
-		writeJSON(w, http.StatusOK, response)
-	}
-}
-
 // notFoundHandler handles 404 responses
 func notFoundHandler(w http.ResponseWriter, r *http.Request) {
-	writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+	apierror.Write(w, apierror.NotFound, "resource not found")
 }
 
 // methodNotAllowedHandler handles 405 responses
 func methodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
-	writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+	apierror.Write(w, apierror.MethodNotAllowed, "method not allowed")
 }
 
 // writeJSON writes a JSON response with the given status code
@@ -1155,15 +1997,3 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 		http.Error(w, `{"error":{"code":"INTERNAL_ERROR","message":"failed to encode response"}}`, http.StatusInternalServerError)
 	}
 }
-
-// writeError writes a JSON error response
-func writeError(w http.ResponseWriter, status int, code, message string) {
-	response := ErrorResponse{
-		Error: ErrorDetail{
-			Code:    code,
-			Message: message,
-		},
-	}
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(response)
-}
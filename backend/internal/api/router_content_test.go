@@ -493,5 +493,5 @@ func TestPostCommentsEndpoints(t *testing.T) {
 // Uses the same secret as in router.go ("test-jwt-secret").
 func createTestJWTToken(userID, username, role string) (string, error) {
 	secret := "test-jwt-secret-32-chars-long!!"
-	return auth.GenerateJWT(secret, userID, username+"@example.com", role, time.Hour)
+	return auth.GenerateJWT(secret, userID, username+"@example.com", role, "", time.Hour)
 }
@@ -222,7 +222,7 @@ func TestRoomRoutes_UpdateRoom_Admin(t *testing.T) {
 	_, jwt := createRoomTestUser(t, pool)
 	slug, _ := createTestRoomWithToken(t, ts, jwt)
 
-	adminJWT, err := auth.GenerateJWT(roomTestJWTSecret, uuid.New().String(), "admin@test.solvr.dev", "admin", time.Hour)
+	adminJWT, err := auth.GenerateJWT(roomTestJWTSecret, uuid.New().String(), "admin@test.solvr.dev", "admin", "", time.Hour)
 	require.NoError(t, err)
 
 	resp := doRoomRequest(t, "PATCH", ts.URL+"/v1/rooms/"+slug, `{"display_name":"Renamed by Admin"}`, adminJWT)
@@ -376,7 +376,7 @@ func TestRoomRoutes_RotateToken_Admin(t *testing.T) {
 	_, jwt := createRoomTestUser(t, pool)
 	slug, _ := createTestRoomWithToken(t, ts, jwt)
 
-	adminJWT, err := auth.GenerateJWT(roomTestJWTSecret, uuid.New().String(), "admin@test.solvr.dev", "admin", time.Hour)
+	adminJWT, err := auth.GenerateJWT(roomTestJWTSecret, uuid.New().String(), "admin@test.solvr.dev", "admin", "", time.Hour)
 	require.NoError(t, err)
 
 	resp := doRoomRequest(t, "POST", ts.URL+"/v1/rooms/"+slug+"/rotate-token", "", adminJWT)
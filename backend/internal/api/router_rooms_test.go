@@ -43,6 +43,7 @@ func setupRoomTestServer(t *testing.T) (*httptest.Server, *db.Pool, func()) {
 	cleanup := func() {
 		ts.Close()
 		ctx := context.Background()
+		_ = router.Close(ctx)
 		// Clean up test data in correct FK order
 		pool.Exec(ctx, "DELETE FROM messages WHERE room_id IN (SELECT id FROM rooms WHERE slug LIKE 'test-%')")
 		pool.Exec(ctx, "DELETE FROM agent_presence WHERE room_id IN (SELECT id FROM rooms WHERE slug LIKE 'test-%')")
@@ -69,7 +70,7 @@ func createRoomTestUser(t *testing.T, pool *db.Pool) (string, string) {
 	)
 	require.NoError(t, err, "failed to create test user")
 
-	token, err := auth.GenerateJWT(roomTestJWTSecret, userID, email, "user", time.Hour)
+	token, err := auth.GenerateJWT(roomTestJWTSecret, userID, email, "user", "", time.Hour)
 	require.NoError(t, err, "failed to generate test JWT")
 	return userID, token
 }
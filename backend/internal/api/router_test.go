@@ -1259,3 +1259,28 @@ func TestSearchEndpointWithMalformedAuth(t *testing.T) {
 		t.Errorf("expected status 200 (public access), got %d: %s", w.Code, w.Body.String())
 	}
 }
+
+// TestAdminDashboardEndpointUnderV1 verifies the ops dashboard is served at the
+// documented /v1/admin/dashboard and not at the unversioned path.
+func TestAdminDashboardEndpointUnderV1(t *testing.T) {
+	t.Setenv("ADMIN_API_KEY", "test-admin-key")
+	router := setupUnconnectedTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/admin/dashboard", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// The handler is reached; its query fails without a database
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 from the dashboard handler, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/dashboard", nil)
+	req.Header.Set("X-Admin-API-Key", "test-admin-key")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for the unversioned path, got %d", w.Code)
+	}
+}
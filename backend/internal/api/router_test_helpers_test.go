@@ -11,7 +11,6 @@ import (
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/db"
-	"github.com/go-chi/chi/v5"
 )
 
 // groqThrottle sleeps 2 seconds to respect GROQ's 30 RPM rate limit.
@@ -24,7 +23,7 @@ func groqThrottle(t *testing.T) {
 
 // setupTestRouter creates a router with a real database connection.
 // Skips the test if DATABASE_URL is not set.
-func setupTestRouter(t *testing.T) *chi.Mux {
+func setupTestRouter(t *testing.T) *Router {
 	t.Helper()
 	dbURL := os.Getenv("DATABASE_URL")
 	if dbURL == "" {
@@ -35,7 +34,31 @@ func setupTestRouter(t *testing.T) *chi.Mux {
 		t.Fatalf("failed to connect to database: %v", err)
 	}
 	t.Cleanup(func() { pool.Close() })
-	return NewRouter(pool, nil, nil)
+	return newClosingRouter(t, pool)
+}
+
+// setupUnconnectedTestRouter creates a router whose pool never connects, so
+// the v1 routes are mounted without a database. Handlers that query fail.
+func setupUnconnectedTestRouter(t *testing.T) *Router {
+	t.Helper()
+	pool, err := db.NewUnconnectedPool("postgres://solvr@127.0.0.1:1/solvr")
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return newClosingRouter(t, pool)
+}
+
+// newClosingRouter creates a router that is closed, before pool, when the test ends.
+func newClosingRouter(t *testing.T, pool *db.Pool) *Router {
+	t.Helper()
+	router := NewRouter(pool, nil, nil)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = router.Close(ctx)
+	})
+	return router
 }
 
 // waitForPostOpen polls GET /v1/posts/:id until the post status is "open" (moderation approved).
@@ -273,7 +273,7 @@ func createUserEndpointsTestJWT(userID string) string {
 	secret := "test-jwt-secret-32-chars-long!!"
 
 	// Use the auth package's GenerateJWT function
-	token, err := auth.GenerateJWT(secret, userID, "test@example.com", "user", time.Hour)
+	token, err := auth.GenerateJWT(secret, userID, "test@example.com", "user", "", time.Hour)
 	if err != nil {
 		panic("failed to generate test JWT: " + err.Error())
 	}
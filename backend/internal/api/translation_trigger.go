@@ -7,6 +7,7 @@ import (
 
 	"github.com/fcavalcantirj/solvr/internal/api/handlers"
 	"github.com/fcavalcantirj/solvr/internal/services"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 )
 
 // TranslationApplier applies a translation result to a post in the database.
@@ -43,11 +44,14 @@ func NewTranslationTriggerAdapter(
 
 // TranslateAndModerateAsync translates a post and triggers re-moderation in a goroutine.
 // Uses context.Background() with its own 30s timeout — the calling goroutine's
-// HTTP request context may be cancelled before translation completes.
+// HTTP request context may be cancelled before translation completes — scoped
+// to ctx's tenant.
 func (a *TranslationTriggerAdapter) TranslateAndModerateAsync(
+	ctx context.Context,
 	postID, title, description string, tags []string,
 	language, postType, authorType, authorID string,
 ) {
+	tenantID := tenant.FromContext(ctx)
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
@@ -55,7 +59,7 @@ func (a *TranslationTriggerAdapter) TranslateAndModerateAsync(
 			}
 		}()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), tenantID), 30*time.Second)
 		defer cancel()
 
 		input := services.TranslationInput{
@@ -81,7 +85,7 @@ func (a *TranslationTriggerAdapter) TranslateAndModerateAsync(
 		}
 
 		// Trigger re-moderation on the translated English content
-		a.moderator.TriggerAsync(postID, result.Title, result.Description, tags, postType, authorType, authorID)
+		a.moderator.TriggerAsync(ctx, postID, result.Title, result.Description, tags, postType, authorType, authorID)
 
 		a.logger.Info("inline translation complete", "postID", postID, "language", language)
 	}()
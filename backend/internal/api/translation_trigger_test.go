@@ -158,7 +158,7 @@ func TestTranslationTriggerAdapter_AsyncDoesNotPanic(t *testing.T) {
 	adapter := NewTranslationTriggerAdapter(nil, applier, nil, slog.Default())
 
 	// This should not panic — the goroutine has a recover()
-	adapter.TranslateAndModerateAsync("post-1", "title", "desc", nil, "Chinese", "problem", "human", "user-1")
+	adapter.TranslateAndModerateAsync(context.Background(), "post-1", "title", "desc", nil, "Chinese", "problem", "human", "user-1")
 
 	// Give the goroutine time to execute
 	time.Sleep(100 * time.Millisecond)
@@ -0,0 +1,144 @@
+// Package apierror defines the API's error codes and writes error responses.
+// Response format follows SPEC.md Part 5.3:
+//
+//	{"error": {"code": "NOT_FOUND", "message": "post not found", "details": {...}}}
+//
+// Every code is listed in the catalog (codes.go) with its default HTTP status,
+// so the status a handler sends follows from the code. See SPEC.md Part 5.4.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error is an error that carries an API error code. Services and repositories
+// can return one to choose the code a handler responds with.
+type Error struct {
+	Code    Code
+	Message string
+	// Details is sent as error.details when set.
+	Details any
+	// status overrides Code.Status() when non-zero.
+	status int
+	err    error
+}
+
+// New returns an Error with the given code and client-facing message.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Newf is New with a formatted message.
+func Newf(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap returns an Error that responds with code and message and unwraps to err.
+// err is for logs and errors.Is; it is never sent to the client.
+func Wrap(err error, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, err: err}
+}
+
+// NotFoundf returns a NOT_FOUND error with a formatted message.
+func NotFoundf(format string, args ...any) *Error {
+	return Newf(NotFound, format, args...)
+}
+
+// Validation returns a VALIDATION_ERROR with the given message.
+func Validation(message string) *Error {
+	return New(ValidationError, message)
+}
+
+// Internal wraps err as an INTERNAL_ERROR with the given message.
+func Internal(err error, message string) *Error {
+	return Wrap(err, InternalError, message)
+}
+
+// WithDetails sets the error's details.
+func (e *Error) WithDetails(details any) *Error {
+	e.Details = details
+	return e
+}
+
+// WithStatus overrides the code's default HTTP status.
+func (e *Error) WithStatus(status int) *Error {
+	e.status = status
+	return e
+}
+
+// Status returns the HTTP status the error is sent with.
+func (e *Error) Status() int {
+	if e.status != 0 {
+		return e.status
+	}
+	return e.Code.Status()
+}
+
+func (e *Error) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// CodeOf returns the code of the first *Error in err's chain, or InternalError.
+func CodeOf(err error) Code {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return InternalError
+}
+
+// response mirrors SPEC.md Part 5.3's error envelope.
+type response struct {
+	Error detail `json:"error"`
+}
+
+type detail struct {
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+// Write sends an error response with the code's default status.
+func Write(w http.ResponseWriter, code Code, message string) {
+	WriteStatus(w, code.Status(), code, message)
+}
+
+// WriteStatus sends an error response with an explicit status, for the few
+// places where the code's default does not fit.
+func WriteStatus(w http.ResponseWriter, status int, code Code, message string) {
+	write(w, status, detail{Code: code, Message: message})
+}
+
+// WriteDetails sends an error response with details and the code's default
+// status.
+func WriteDetails(w http.ResponseWriter, code Code, message string, details any) {
+	write(w, code.Status(), detail{Code: code, Message: message, Details: details})
+}
+
+// WriteErr sends err as an error response. An *Error in err's chain is sent
+// as is; anything else becomes a 500 INTERNAL_ERROR with a generic message, so
+// internal error text never reaches the client.
+func WriteErr(w http.ResponseWriter, err error) {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		Write(w, InternalError, "internal server error")
+		return
+	}
+	write(w, apiErr.Status(), detail{Code: apiErr.Code, Message: apiErr.Message, Details: apiErr.Details})
+}
+
+func write(w http.ResponseWriter, status int, d detail) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response{Error: d})
+}
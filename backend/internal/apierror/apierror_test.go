@@ -0,0 +1,160 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestCatalog_Complete(t *testing.T) {
+	seen := make(map[Code]bool)
+	for _, info := range Catalog() {
+		if seen[info.Code] {
+			t.Errorf("%s listed twice", info.Code)
+		}
+		seen[info.Code] = true
+		if info.Status < 400 || info.Status > 599 {
+			t.Errorf("%s has status %d, want 4xx or 5xx", info.Code, info.Status)
+		}
+		if info.Description == "" {
+			t.Errorf("%s has no description", info.Code)
+		}
+	}
+
+	// Every declared constant must be in the catalog, or it would be sent as 500.
+	src, err := os.ReadFile("codes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range regexp.MustCompile(`(?m)^\s+\w+\s+Code = "(\w+)"`).FindAllStringSubmatch(string(src), -1) {
+		if !Code(m[1]).Known() {
+			t.Errorf("%s is declared but missing from the catalog", m[1])
+		}
+	}
+}
+
+func TestCode_Status(t *testing.T) {
+	tests := []struct {
+		code Code
+		want int
+	}{
+		{NotFound, http.StatusNotFound},
+		{ValidationError, http.StatusBadRequest},
+		{Unauthorized, http.StatusUnauthorized},
+		{RateLimited, http.StatusTooManyRequests},
+		{MaintenanceMode, http.StatusServiceUnavailable},
+		{Code("NOT_A_CODE"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := tt.code.Status(); got != tt.want {
+			t.Errorf("%s.Status() = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
+
+type envelope struct {
+	Error struct {
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	} `json:"error"`
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) envelope {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var body envelope
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	return body
+}
+
+func TestWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+	Write(w, NotFound, "post not found")
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", w.Code)
+	}
+	body := decode(t, w)
+	if body.Error.Code != "NOT_FOUND" || body.Error.Message != "post not found" {
+		t.Errorf("body = %+v", body.Error)
+	}
+	if body.Error.Details != nil {
+		t.Errorf("details = %s, want omitted", body.Error.Details)
+	}
+}
+
+func TestWriteStatus_OverridesDefault(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteStatus(w, http.StatusGone, TokenExpired, "claim token expired")
+
+	if w.Code != http.StatusGone {
+		t.Errorf("status = %d, want 410", w.Code)
+	}
+	if body := decode(t, w); body.Error.Code != "TOKEN_EXPIRED" {
+		t.Errorf("code = %s", body.Error.Code)
+	}
+}
+
+func TestWriteDetails(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteDetails(w, AlreadyImported, "already imported", map[string]string{"post_id": "p1"})
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409", w.Code)
+	}
+	if body := decode(t, w); string(body.Error.Details) != `{"post_id":"p1"}` {
+		t.Errorf("details = %s", body.Error.Details)
+	}
+}
+
+func TestWriteErr(t *testing.T) {
+	t.Run("api error in chain", func(t *testing.T) {
+		err := fmt.Errorf("create post: %w", Validation("title is required").WithStatus(http.StatusUnprocessableEntity))
+		w := httptest.NewRecorder()
+		WriteErr(w, err)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("status = %d, want 422", w.Code)
+		}
+		if body := decode(t, w); body.Error.Code != "VALIDATION_ERROR" || body.Error.Message != "title is required" {
+			t.Errorf("body = %+v", body.Error)
+		}
+	})
+
+	t.Run("plain error is hidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		WriteErr(w, errors.New("pq: connection refused"))
+
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("status = %d, want 500", w.Code)
+		}
+		if body := decode(t, w); body.Error.Code != "INTERNAL_ERROR" || body.Error.Message != "internal server error" {
+			t.Errorf("body = %+v", body.Error)
+		}
+	})
+}
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("no rows")
+	err := Wrap(cause, NotFound, "agent not found")
+
+	if !errors.Is(err, cause) {
+		t.Error("Wrap() does not unwrap to its cause")
+	}
+	if got := CodeOf(fmt.Errorf("lookup: %w", err)); got != NotFound {
+		t.Errorf("CodeOf() = %s, want NOT_FOUND", got)
+	}
+	if got := CodeOf(cause); got != InternalError {
+		t.Errorf("CodeOf(plain error) = %s, want INTERNAL_ERROR", got)
+	}
+}
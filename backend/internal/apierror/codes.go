@@ -0,0 +1,279 @@
+package apierror
+
+import "net/http"
+
+// Code is a machine-readable error code, sent as error.code in every error
+// response. Clients branch on codes, so existing codes must never change
+// meaning or be renamed.
+type Code string
+
+// Authentication and authorization.
+const (
+	Unauthorized             Code = "UNAUTHORIZED"
+	InvalidToken             Code = "INVALID_TOKEN"
+	TokenExpired             Code = "TOKEN_EXPIRED"
+	InvalidAPIKey            Code = "INVALID_API_KEY"
+	MissingAPIKey            Code = "MISSING_API_KEY"
+	InvalidCredentials       Code = "INVALID_CREDENTIALS"
+	OAuthOnlyUser            Code = "OAUTH_ONLY_USER"
+	InvalidMoltbookToken     Code = "INVALID_MOLTBOOK_TOKEN"
+	Forbidden                Code = "FORBIDDEN"
+	InsufficientScope        Code = "INSUFFICIENT_SCOPE"
+	InsufficientReputation   Code = "INSUFFICIENT_REPUTATION"
+	AccountSuspended         Code = "ACCOUNT_SUSPENDED"
+	EmailVerificationPending Code = "EMAIL_VERIFICATION_PENDING"
+	DestructiveQuery         Code = "DESTRUCTIVE_QUERY_BLOCKED"
+)
+
+// Malformed or invalid requests.
+const (
+	ValidationError      Code = "VALIDATION_ERROR"
+	BadRequest           Code = "BAD_REQUEST"
+	InvalidJSON          Code = "INVALID_JSON"
+	InvalidParam         Code = "INVALID_PARAM"
+	InvalidRequest       Code = "INVALID_REQUEST"
+	InvalidID            Code = "INVALID_ID"
+	InvalidUserID        Code = "INVALID_USER_ID"
+	InvalidAgent         Code = "INVALID_AGENT"
+	InvalidConfig        Code = "INVALID_CONFIG"
+	InvalidEventType     Code = "INVALID_EVENT_TYPE"
+	InvalidIssueURL      Code = "INVALID_ISSUE_URL"
+	InvalidTag           Code = "INVALID_TAG"
+	InvalidType          Code = "INVALID_TYPE"
+	InvalidEmail         Code = "INVALID_EMAIL"
+	InvalidPassword      Code = "INVALID_PASSWORD"
+	InvalidUsername      Code = "INVALID_USERNAME"
+	MissingID            Code = "MISSING_ID"
+	MissingFields        Code = "MISSING_FIELDS"
+	MissingRequiredField Code = "MISSING_REQUIRED_FIELD"
+	MissingStatus        Code = "MISSING_STATUS"
+	MissingToken         Code = "MISSING_TOKEN"
+	ProofOfWorkRequired  Code = "PROOF_OF_WORK_REQUIRED"
+	EmptyQuery           Code = "EMPTY_QUERY"
+	ContentTooShort      Code = "CONTENT_TOO_SHORT"
+	NotAnIssue           Code = "NOT_AN_ISSUE"
+	TagFollowLimit       Code = "TAG_FOLLOW_LIMIT"
+	UnclaimedAgent       Code = "UNCLAIMED_AGENT"
+	OAuthError           Code = "OAUTH_ERROR"
+)
+
+// Missing resources.
+const (
+	NotFound          Code = "NOT_FOUND"
+	AgentNotFound     Code = "AGENT_NOT_FOUND"
+	IssueNotFound     Code = "ISSUE_NOT_FOUND"
+	RecipientNotFound Code = "RECIPIENT_NOT_FOUND"
+	TokenNotFound     Code = "TOKEN_NOT_FOUND"
+	TenantNotFound    Code = "TENANT_NOT_FOUND"
+	MethodNotAllowed  Code = "METHOD_NOT_ALLOWED"
+)
+
+// Conflicts with the current state of a resource.
+const (
+	Conflict           Code = "CONFLICT"
+	DuplicateContent   Code = "DUPLICATE_CONTENT"
+	DuplicateAID       Code = "DUPLICATE_AID"
+	DuplicateEmail     Code = "DUPLICATE_EMAIL"
+	DuplicateFlag      Code = "DUPLICATE_FLAG"
+	DuplicateID        Code = "DUPLICATE_ID"
+	DuplicateName      Code = "DUPLICATE_NAME"
+	DuplicateRoom      Code = "DUPLICATE_ROOM"
+	DuplicateUsername  Code = "DUPLICATE_USERNAME"
+	DuplicateVote      Code = "DUPLICATE_VOTE"
+	AlreadyBlacklisted Code = "ALREADY_BLACKLISTED"
+	AlreadyClaimed     Code = "ALREADY_CLAIMED"
+	AlreadyImported    Code = "ALREADY_IMPORTED"
+	AlreadyLinked      Code = "ALREADY_LINKED"
+	AlreadyReported    Code = "ALREADY_REPORTED"
+	AlreadyStuck       Code = "ALREADY_STUCK"
+	BookmarkExists     Code = "BOOKMARK_EXISTS"
+	BountyNotRaised    Code = "BOUNTY_NOT_RAISED"
+	ClaimNotHeld       Code = "CLAIM_NOT_HELD"
+	CriteriaUnmet      Code = "CRITERIA_UNMET"
+	InvalidStatus      Code = "INVALID_STATUS"
+	LegalHold          Code = "LEGAL_HOLD"
+	ModerationRejected Code = "MODERATION_REJECTED"
+	TagExists          Code = "TAG_EXISTS"
+	TokenUsed          Code = "TOKEN_USED"
+	PreconditionFailed Code = "PRECONDITION_FAILED"
+)
+
+// Limits.
+const (
+	RateLimited     Code = "RATE_LIMITED"
+	QuotaExceeded   Code = "QUOTA_EXCEEDED"
+	PayloadTooLarge Code = "PAYLOAD_TOO_LARGE"
+	FileTooLarge    Code = "FILE_TOO_LARGE"
+	RequestTimeout  Code = "REQUEST_TIMEOUT"
+)
+
+// Server-side failures and unavailable dependencies.
+const (
+	InternalError            Code = "INTERNAL_ERROR"
+	DedupCheckFailed         Code = "DEDUP_CHECK_FAILED"
+	LinkFailed               Code = "LINK_FAILED"
+	RegistrationFailed       Code = "REGISTRATION_FAILED"
+	NotImplemented           Code = "NOT_IMPLEMENTED"
+	BadGateway               Code = "BAD_GATEWAY"
+	DeliveryFailed           Code = "DELIVERY_FAILED"
+	GitHubError              Code = "GITHUB_ERROR"
+	ServiceUnavailable       Code = "SERVICE_UNAVAILABLE"
+	MaintenanceMode          Code = "MAINTENANCE_MODE"
+	DatabaseUnavailable      Code = "DATABASE_UNAVAILABLE"
+	EmbeddingUnavailable     Code = "EMBEDDING_UNAVAILABLE"
+	GitHubRateLimited        Code = "GITHUB_RATE_LIMITED"
+	NotConfigured            Code = "NOT_CONFIGURED"
+	RepoNotConfigured        Code = "REPO_NOT_CONFIGURED"
+	AdminNotConfigured       Code = "ADMIN_NOT_CONFIGURED"
+	GitHubNotConfigured      Code = "GITHUB_NOT_CONFIGURED"
+	SenderNotConfigured      Code = "SENDER_NOT_CONFIGURED"
+	TranslationNotConfigured Code = "TRANSLATION_NOT_CONFIGURED"
+)
+
+// Info describes a code in the catalog.
+type Info struct {
+	Code        Code   `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+// catalog lists every code with its default HTTP status. A handler may send a
+// code with a different status where the context calls for it (for example
+// TOKEN_EXPIRED is 410 Gone for a one-time claim token), but never the other
+// way round: a status never implies a code.
+var catalog = []Info{
+	{Unauthorized, http.StatusUnauthorized, "Not authenticated"},
+	{InvalidToken, http.StatusUnauthorized, "Token is malformed or its signature is invalid"},
+	{TokenExpired, http.StatusUnauthorized, "Token has expired"},
+	{InvalidAPIKey, http.StatusUnauthorized, "API key is malformed, unknown or revoked"},
+	{MissingAPIKey, http.StatusUnauthorized, "API key is required"},
+	{InvalidCredentials, http.StatusUnauthorized, "Email or password is wrong"},
+	{OAuthOnlyUser, http.StatusUnauthorized, "Account signs in with OAuth only"},
+	{InvalidMoltbookToken, http.StatusUnauthorized, "Moltbook identity token is invalid"},
+	{Forbidden, http.StatusForbidden, "No permission"},
+	{InsufficientScope, http.StatusForbidden, "API key scope does not allow this request"},
+	{InsufficientReputation, http.StatusForbidden, "Not enough reputation for this action"},
+	{AccountSuspended, http.StatusForbidden, "Account is suspended or banned"},
+	{EmailVerificationPending, http.StatusForbidden, "Agent is read-only until its operator email is verified"},
+	{DestructiveQuery, http.StatusForbidden, "Query would modify data"},
+
+	{ValidationError, http.StatusBadRequest, "Invalid input; details.fields lists each failing field"},
+	{BadRequest, http.StatusBadRequest, "Request cannot be processed"},
+	{InvalidJSON, http.StatusBadRequest, "Request body is not valid JSON"},
+	{InvalidParam, http.StatusBadRequest, "Query parameter is invalid"},
+	{InvalidRequest, http.StatusBadRequest, "Request is invalid"},
+	{InvalidID, http.StatusBadRequest, "ID is malformed"},
+	{InvalidUserID, http.StatusBadRequest, "User ID is malformed"},
+	{InvalidAgent, http.StatusBadRequest, "Agent is invalid for this request"},
+	{InvalidConfig, http.StatusBadRequest, "Configuration is invalid"},
+	{InvalidEventType, http.StatusBadRequest, "Event type is unknown"},
+	{InvalidIssueURL, http.StatusBadRequest, "GitHub issue URL is invalid"},
+	{InvalidTag, http.StatusBadRequest, "Tag is invalid"},
+	{InvalidType, http.StatusBadRequest, "Post type is unknown"},
+	{InvalidEmail, http.StatusBadRequest, "Email address is invalid"},
+	{InvalidPassword, http.StatusBadRequest, "Password does not meet requirements"},
+	{InvalidUsername, http.StatusBadRequest, "Username is invalid"},
+	{MissingID, http.StatusBadRequest, "ID is required"},
+	{MissingFields, http.StatusBadRequest, "Required fields are missing"},
+	{MissingRequiredField, http.StatusBadRequest, "A required field is missing"},
+	{MissingStatus, http.StatusBadRequest, "Status is required"},
+	{MissingToken, http.StatusBadRequest, "Token is required"},
+	{ProofOfWorkRequired, http.StatusBadRequest, "Registration needs a valid hashcash proof-of-work stamp"},
+	{EmptyQuery, http.StatusBadRequest, "Search query is empty"},
+	{ContentTooShort, http.StatusBadRequest, "Minimum length not met"},
+	{NotAnIssue, http.StatusBadRequest, "URL points to a pull request, not an issue"},
+	{TagFollowLimit, http.StatusBadRequest, "Too many followed tags"},
+	{UnclaimedAgent, http.StatusBadRequest, "Agent has not been claimed by a human"},
+	{OAuthError, http.StatusBadRequest, "OAuth provider returned an error"},
+
+	{NotFound, http.StatusNotFound, "Resource doesn't exist"},
+	{AgentNotFound, http.StatusNotFound, "Agent doesn't exist"},
+	{IssueNotFound, http.StatusNotFound, "GitHub issue doesn't exist or is not public"},
+	{RecipientNotFound, http.StatusNotFound, "Recipient doesn't exist"},
+	{TokenNotFound, http.StatusNotFound, "Token doesn't exist"},
+	{TenantNotFound, http.StatusNotFound, "Tenant doesn't exist"},
+	{MethodNotAllowed, http.StatusMethodNotAllowed, "Method not supported on this route"},
+
+	{Conflict, http.StatusConflict, "Resource changed; reload and retry"},
+	{DuplicateContent, http.StatusConflict, "Spam detection or content already exists"},
+	{DuplicateAID, http.StatusConflict, "AID is already registered"},
+	{DuplicateEmail, http.StatusConflict, "Email is already registered"},
+	{DuplicateFlag, http.StatusConflict, "Content is already flagged by this caller"},
+	{DuplicateID, http.StatusConflict, "ID is already in use"},
+	{DuplicateName, http.StatusConflict, "Name is already taken"},
+	{DuplicateRoom, http.StatusConflict, "Room already exists"},
+	{DuplicateUsername, http.StatusConflict, "Username is already taken"},
+	{DuplicateVote, http.StatusConflict, "Vote already recorded"},
+	{AlreadyBlacklisted, http.StatusConflict, "Tag is already blacklisted"},
+	{AlreadyClaimed, http.StatusConflict, "Already claimed"},
+	{AlreadyImported, http.StatusConflict, "GitHub issue was already imported"},
+	{AlreadyLinked, http.StatusConflict, "Already linked"},
+	{AlreadyReported, http.StatusConflict, "Already reported by this caller"},
+	{AlreadyStuck, http.StatusConflict, "Approach is already marked stuck"},
+	{BookmarkExists, http.StatusConflict, "Bookmark already exists"},
+	{BountyNotRaised, http.StatusConflict, "Bounty cannot be lowered"},
+	{ClaimNotHeld, http.StatusConflict, "Caller does not hold the claim"},
+	{CriteriaUnmet, http.StatusConflict, "Problem has unmet success criteria"},
+	{InvalidStatus, http.StatusConflict, "Operation not allowed in the current status"},
+	{LegalHold, http.StatusConflict, "Content is under legal hold"},
+	{ModerationRejected, http.StatusConflict, "Content was rejected by moderation"},
+	{TagExists, http.StatusConflict, "Tag already exists"},
+	{TokenUsed, http.StatusConflict, "Token was already used"},
+	{PreconditionFailed, http.StatusPreconditionFailed, "If-Match does not match the current version"},
+
+	{RateLimited, http.StatusTooManyRequests, "Too many requests"},
+	{QuotaExceeded, http.StatusPaymentRequired, "Storage quota exceeded"},
+	{PayloadTooLarge, http.StatusRequestEntityTooLarge, "Request body too large"},
+	{FileTooLarge, http.StatusRequestEntityTooLarge, "Uploaded file too large"},
+	{RequestTimeout, http.StatusRequestTimeout, "Request took too long"},
+
+	{InternalError, http.StatusInternalServerError, "Server error"},
+	{DedupCheckFailed, http.StatusInternalServerError, "Duplicate check failed"},
+	{LinkFailed, http.StatusInternalServerError, "Linking failed"},
+	{RegistrationFailed, http.StatusInternalServerError, "Registration failed"},
+	{NotImplemented, http.StatusNotImplemented, "Not implemented"},
+	{BadGateway, http.StatusBadGateway, "Upstream service failed"},
+	{DeliveryFailed, http.StatusBadGateway, "Message delivery failed"},
+	{GitHubError, http.StatusBadGateway, "GitHub request failed"},
+	{ServiceUnavailable, http.StatusServiceUnavailable, "Service temporarily unavailable"},
+	{MaintenanceMode, http.StatusServiceUnavailable, "Writes are paused for maintenance"},
+	{DatabaseUnavailable, http.StatusServiceUnavailable, "Database not connected"},
+	{EmbeddingUnavailable, http.StatusServiceUnavailable, "Semantic search is unavailable for this request"},
+	{GitHubRateLimited, http.StatusServiceUnavailable, "GitHub rate limit reached"},
+	{NotConfigured, http.StatusServiceUnavailable, "Feature not configured"},
+	{RepoNotConfigured, http.StatusServiceUnavailable, "Repository not configured"},
+	{AdminNotConfigured, http.StatusServiceUnavailable, "Admin API key not configured"},
+	{GitHubNotConfigured, http.StatusServiceUnavailable, "GitHub integration not configured"},
+	{SenderNotConfigured, http.StatusServiceUnavailable, "Chat integration sender not configured"},
+	{TranslationNotConfigured, http.StatusServiceUnavailable, "Translation not configured"},
+}
+
+var byCode = func() map[Code]Info {
+	m := make(map[Code]Info, len(catalog))
+	for _, info := range catalog {
+		m[info.Code] = info
+	}
+	return m
+}()
+
+// Status returns the code's default HTTP status, or 500 for a code that is not
+// in the catalog.
+func (c Code) Status() int {
+	if info, ok := byCode[c]; ok {
+		return info.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// Known reports whether the code is in the catalog.
+func (c Code) Known() bool {
+	_, ok := byCode[c]
+	return ok
+}
+
+// Catalog returns every code in catalog order.
+func Catalog() []Info {
+	out := make([]Info, len(catalog))
+	copy(out, catalog)
+	return out
+}
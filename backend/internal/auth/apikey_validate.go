@@ -26,7 +26,8 @@ func NewAPIKeyValidator(db AgentDB) *APIKeyValidator {
 }
 
 // ValidateAPIKey validates an API key and returns the associated agent.
-// Returns an AuthError if the key is invalid, malformed, or not found.
+// Returns an AuthError if the key is invalid, malformed, not found, or belongs to
+// another tenant than the request's.
 func (v *APIKeyValidator) ValidateAPIKey(ctx context.Context, key string) (*models.Agent, error) {
 	// Check for empty key
 	if key == "" {
@@ -49,6 +50,11 @@ func (v *APIKeyValidator) ValidateAPIKey(ctx context.Context, key string) (*mode
 		return nil, NewAuthError(ErrCodeInvalidAPIKey, "invalid API key")
 	}
 
+	// Agents are tenant-owned: a key is only valid on its agent's tenant
+	if err := checkTenant(ctx, agent.TenantID); err != nil {
+		return nil, err
+	}
+
 	return agent, nil
 }
 
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 )
 
 // Claims represents the JWT claims for authenticated users.
@@ -16,13 +18,18 @@ type Claims struct {
 	Role      string    `json:"role"`
 	ExpiresAt time.Time `json:"exp"`
 	IssuedAt  time.Time `json:"iat"`
+
+	// TenantID is the tenant the token was issued on; empty for tokens issued
+	// without multi-tenancy, which belong to the default tenant.
+	TenantID string `json:"tenant_id,omitempty"`
 }
 
 // jwtClaims is the internal JWT claims structure that includes standard claims.
 type jwtClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID   string `json:"user_id"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	TenantID string `json:"tenant_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -43,15 +50,18 @@ func NewAuthError(code, message string) *AuthError {
 
 // Common error codes per SPEC.md Part 5.4
 const (
-	ErrCodeUnauthorized  = "UNAUTHORIZED"
-	ErrCodeInvalidToken  = "INVALID_TOKEN"
-	ErrCodeTokenExpired  = "TOKEN_EXPIRED"
-	ErrCodeInvalidAPIKey = "INVALID_API_KEY"
+	ErrCodeUnauthorized  = string(apierror.Unauthorized)
+	ErrCodeInvalidToken  = string(apierror.InvalidToken)
+	ErrCodeTokenExpired  = string(apierror.TokenExpired)
+	ErrCodeInvalidAPIKey = string(apierror.InvalidAPIKey)
+
+	ErrCodeInsufficientScope = string(apierror.InsufficientScope)
 )
 
-// GenerateJWT creates a new JWT token for a user.
+// GenerateJWT creates a new JWT token for a user, bound to tenantID (the
+// request's tenant; "" when multi-tenancy is off).
 // Returns the signed token string or an error.
-func GenerateJWT(secret, userID, email, role string, expiry time.Duration) (string, error) {
+func GenerateJWT(secret, userID, email, role, tenantID string, expiry time.Duration) (string, error) {
 	if userID == "" {
 		return "", NewAuthError(ErrCodeUnauthorized, "userID is required")
 	}
@@ -61,9 +71,10 @@ func GenerateJWT(secret, userID, email, role string, expiry time.Duration) (stri
 
 	now := time.Now()
 	claims := jwtClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:   userID,
+		Email:    email,
+		Role:     role,
+		TenantID: tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -124,6 +135,7 @@ func ValidateJWT(secret, tokenString string) (*Claims, error) {
 		UserID:   jwtClaims.UserID,
 		Email:    jwtClaims.Email,
 		Role:     jwtClaims.Role,
+		TenantID: jwtClaims.TenantID,
 		IssuedAt: jwtClaims.IssuedAt.Time,
 	}
 
@@ -50,7 +50,7 @@ func TestGenerateJWT(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			token, err := GenerateJWT(secret, tt.userID, tt.email, tt.role, 15*time.Minute)
+			token, err := GenerateJWT(secret, tt.userID, tt.email, tt.role, "", 15*time.Minute)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GenerateJWT() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -73,13 +73,13 @@ func TestValidateJWT(t *testing.T) {
 	secret := "test-secret-key-for-testing-purposes-only"
 
 	// Generate a valid token first
-	validToken, err := GenerateJWT(secret, "user-123", "test@example.com", "user", 15*time.Minute)
+	validToken, err := GenerateJWT(secret, "user-123", "test@example.com", "user", "", 15*time.Minute)
 	if err != nil {
 		t.Fatalf("Failed to generate test token: %v", err)
 	}
 
 	// Generate an expired token
-	expiredToken, err := GenerateJWT(secret, "user-123", "test@example.com", "user", -1*time.Minute)
+	expiredToken, err := GenerateJWT(secret, "user-123", "test@example.com", "user", "", -1*time.Minute)
 	if err != nil {
 		t.Fatalf("Failed to generate expired test token: %v", err)
 	}
@@ -179,7 +179,7 @@ func TestGenerateJWTExpiry(t *testing.T) {
 	secret := "test-secret-key-for-testing-purposes-only"
 
 	// Generate with 15 minute expiry
-	token, err := GenerateJWT(secret, "user-123", "test@example.com", "user", 15*time.Minute)
+	token, err := GenerateJWT(secret, "user-123", "test@example.com", "user", "", 15*time.Minute)
 	if err != nil {
 		t.Fatalf("GenerateJWT() error = %v", err)
 	}
@@ -197,3 +197,20 @@ func TestGenerateJWTExpiry(t *testing.T) {
 			claims.ExpiresAt, expectedExpiry)
 	}
 }
+
+func TestGenerateJWTTenant(t *testing.T) {
+	secret := "test-secret-key-for-testing-purposes-only"
+
+	token, err := GenerateJWT(secret, "user-123", "test@example.com", "user", "acme", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+
+	claims, err := ValidateJWT(secret, token)
+	if err != nil {
+		t.Fatalf("ValidateJWT() error = %v", err)
+	}
+	if claims.TenantID != "acme" {
+		t.Errorf("TenantID = %q, want acme", claims.TenantID)
+	}
+}
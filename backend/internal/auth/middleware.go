@@ -2,11 +2,12 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"strings"
 
+	"github.com/fcavalcantirj/solvr/internal/apierror"
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 )
 
 // contextKey is the type for context keys to avoid collisions.
@@ -24,6 +25,9 @@ const (
 
 	// APIKeyTierContextKey is the context key for the API key tier (for tiered rate limits).
 	APIKeyTierContextKey contextKey = "apiKeyTier"
+
+	// APIKeyScopeContextKey is the context key for the API key scope ("read" or "write").
+	APIKeyScopeContextKey contextKey = "apiKeyScope"
 )
 
 // JWTMiddleware creates middleware that validates JWT tokens from Authorization header.
@@ -120,42 +124,62 @@ func extractAndValidateJWT(secret string, r *http.Request) (*Claims, error) {
 		return nil, NewAuthError(ErrCodeUnauthorized, "token is empty")
 	}
 
-	return ValidateJWT(secret, token)
+	return validateRequestJWT(r.Context(), secret, token)
+}
+
+// validateRequestJWT validates a JWT and checks it was issued on the request's tenant.
+func validateRequestJWT(ctx context.Context, secret, token string) (*Claims, error) {
+	claims, err := ValidateJWT(secret, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTenant(ctx, claims.TenantID); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// checkTenant rejects a credential that belongs to a tenant other than the
+// request's. Unscoped requests (multi-tenancy off) accept any credential, and a
+// credential without a tenant belongs to the default tenant.
+func checkTenant(ctx context.Context, credentialTenant string) error {
+	requestTenant := tenant.FromContext(ctx)
+	if requestTenant == "" {
+		return nil
+	}
+	if credentialTenant == "" {
+		credentialTenant = tenant.DefaultID
+	}
+	if credentialTenant != requestTenant {
+		return NewAuthError(ErrCodeUnauthorized, "credentials belong to another tenant")
+	}
+	return nil
 }
 
 // writeAuthError writes an authentication error response as JSON.
 func writeAuthError(w http.ResponseWriter, err error) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-
 	authErr, ok := err.(*AuthError)
 	if !ok {
 		authErr = NewAuthError(ErrCodeUnauthorized, err.Error())
 	}
+	apierror.WriteStatus(w, http.StatusUnauthorized, apierror.Code(authErr.Code), authErr.Message)
+}
 
-	response := map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    authErr.Code,
-			"message": authErr.Message,
-		},
-	}
+// writeInsufficientScopeError writes a 403 response for a read-only API key used on a write route.
+func writeInsufficientScopeError(w http.ResponseWriter) {
+	apierror.Write(w, apierror.InsufficientScope, "this API key is read-only")
+}
 
-	json.NewEncoder(w).Encode(response)
+// writeEmailVerificationPendingError writes a 403 response for an agent whose
+// operator email is not yet verified making a write request.
+func writeEmailVerificationPendingError(w http.ResponseWriter) {
+	apierror.Write(w, apierror.EmailVerificationPending,
+		"verify your operator email before making changes; see GET /v1/me")
 }
 
 // writeForbiddenError writes a 403 Forbidden error response as JSON.
 func writeForbiddenError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusForbidden)
-
-	response := map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    "FORBIDDEN",
-			"message": message,
-		},
-	}
-
-	json.NewEncoder(w).Encode(response)
+	apierror.Write(w, apierror.Forbidden, message)
 }
 
 // APIKeyMiddleware creates middleware that validates API keys from Authorization header.
@@ -181,9 +205,13 @@ func APIKeyMiddleware(validator *APIKeyValidator) func(http.Handler) http.Handle
 				writeAuthError(w, err)
 				return
 			}
+			if isPendingAgentWrite(r, agent) {
+				writeEmailVerificationPendingError(w)
+				return
+			}
 
 			// Add agent to context
-			ctx := ContextWithAgent(r.Context(), agent)
+			ctx := agentContext(r.Context(), agent)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
@@ -204,7 +232,11 @@ func CombinedAuthMiddleware(jwtSecret string, apiKeyValidator *APIKeyValidator)
 			if IsAPIKey(token) {
 				agent, err := apiKeyValidator.ValidateAPIKey(r.Context(), token)
 				if err == nil && agent != nil {
-					ctx := ContextWithAgent(r.Context(), agent)
+					if isPendingAgentWrite(r, agent) {
+						writeEmailVerificationPendingError(w)
+						return
+					}
+					ctx := agentContext(r.Context(), agent)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
@@ -212,7 +244,7 @@ func CombinedAuthMiddleware(jwtSecret string, apiKeyValidator *APIKeyValidator)
 			}
 
 			// Try JWT
-			claims, err := ValidateJWT(jwtSecret, token)
+			claims, err := validateRequestJWT(r.Context(), jwtSecret, token)
 			if err == nil && claims != nil {
 				ctx := ContextWithClaims(r.Context(), claims)
 				next.ServeHTTP(w, r.WithContext(ctx))
@@ -291,6 +323,56 @@ func APIKeyTierFromContext(ctx context.Context) string {
 	return tier
 }
 
+// ContextWithAPIKeyScope adds an API key scope to the context.
+func ContextWithAPIKeyScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, APIKeyScopeContextKey, scope)
+}
+
+// APIKeyScopeFromContext retrieves the API key scope from the context.
+// Returns empty string if the request was not authenticated with a scoped key.
+func APIKeyScopeFromContext(ctx context.Context) string {
+	scope, ok := ctx.Value(APIKeyScopeContextKey).(string)
+	if !ok {
+		return ""
+	}
+	return scope
+}
+
+// IsReadOnlyFromContext reports whether the request was authenticated with a read-only API key.
+func IsReadOnlyFromContext(ctx context.Context) bool {
+	return APIKeyScopeFromContext(ctx) == models.APIKeyScopeRead
+}
+
+// isReadOnlyMethod reports whether an HTTP method never modifies state.
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// pendingVerificationPaths stay writable for agents whose operator email is not
+// yet verified: resending the verification email, and MCP, whose read tools are
+// POSTs; the MCP handler refuses its own write tools to read-only callers.
+var pendingVerificationPaths = map[string]bool{
+	"/v1/agents/me/verify-email": true,
+	"/v1/mcp":                    true,
+}
+
+// agentContext adds the agent to the context. An agent pending email
+// verification gets a read-only scope, like a read-only user API key.
+func agentContext(ctx context.Context, agent *models.Agent) context.Context {
+	ctx = ContextWithAgent(ctx, agent)
+	if agent.Status == string(models.AgentStatusPendingVerification) {
+		ctx = ContextWithAPIKeyScope(ctx, models.APIKeyScopeRead)
+	}
+	return ctx
+}
+
+// isPendingAgentWrite reports whether r modifies state on behalf of an agent
+// pending email verification, outside pendingVerificationPaths.
+func isPendingAgentWrite(r *http.Request, agent *models.Agent) bool {
+	return agent.Status == string(models.AgentStatusPendingVerification) &&
+		!isReadOnlyMethod(r.Method) && !pendingVerificationPaths[r.URL.Path]
+}
+
 // OptionalAuthMiddleware creates middleware that tries all three authentication types
 // (user API key, agent API key, JWT) but NEVER returns 401.
 // If any auth method succeeds, the context is populated with the identity.
@@ -317,6 +399,7 @@ func OptionalAuthMiddleware(jwtSecret string, agentValidator *APIKeyValidator, u
 					}
 					ctx := ContextWithClaims(r.Context(), claims)
 					ctx = ContextWithAPIKeyID(ctx, apiKey.ID)
+					ctx = ContextWithAPIKeyScope(ctx, apiKey.EffectiveScope())
 					_ = userValidator.db.UpdateLastUsed(r.Context(), apiKey.ID)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
@@ -330,7 +413,7 @@ func OptionalAuthMiddleware(jwtSecret string, agentValidator *APIKeyValidator, u
 			if IsAPIKey(token) {
 				agent, err := agentValidator.ValidateAPIKey(r.Context(), token)
 				if err == nil && agent != nil {
-					ctx := ContextWithAgent(r.Context(), agent)
+					ctx := agentContext(r.Context(), agent)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
@@ -340,7 +423,7 @@ func OptionalAuthMiddleware(jwtSecret string, agentValidator *APIKeyValidator, u
 			}
 
 			// Try JWT
-			claims, err := ValidateJWT(jwtSecret, token)
+			claims, err := validateRequestJWT(r.Context(), jwtSecret, token)
 			if err == nil && claims != nil {
 				ctx := ContextWithClaims(r.Context(), claims)
 				next.ServeHTTP(w, r.WithContext(ctx))
@@ -377,8 +460,14 @@ func UnifiedAuthMiddleware(jwtSecret string, agentValidator *APIKeyValidator, us
 						Email:  user.Email,
 						Role:   "user",
 					}
+					// Read-only keys may not modify state
+					if apiKey.EffectiveScope() == models.APIKeyScopeRead && !isReadOnlyMethod(r.Method) {
+						writeInsufficientScopeError(w)
+						return
+					}
 					ctx := ContextWithClaims(r.Context(), claims)
 					ctx = ContextWithAPIKeyID(ctx, apiKey.ID)
+					ctx = ContextWithAPIKeyScope(ctx, apiKey.EffectiveScope())
 					// Update last_used_at (fire and forget)
 					_ = userValidator.db.UpdateLastUsed(r.Context(), apiKey.ID)
 					next.ServeHTTP(w, r.WithContext(ctx))
@@ -397,7 +486,11 @@ func UnifiedAuthMiddleware(jwtSecret string, agentValidator *APIKeyValidator, us
 			if IsAPIKey(token) {
 				agent, err := agentValidator.ValidateAPIKey(r.Context(), token)
 				if err == nil && agent != nil {
-					ctx := ContextWithAgent(r.Context(), agent)
+					if isPendingAgentWrite(r, agent) {
+						writeEmailVerificationPendingError(w)
+						return
+					}
+					ctx := agentContext(r.Context(), agent)
 					next.ServeHTTP(w, r.WithContext(ctx))
 					return
 				}
@@ -407,7 +500,7 @@ func UnifiedAuthMiddleware(jwtSecret string, agentValidator *APIKeyValidator, us
 			}
 
 			// Try JWT
-			claims, err := ValidateJWT(jwtSecret, token)
+			claims, err := validateRequestJWT(r.Context(), jwtSecret, token)
 			if err == nil && claims != nil {
 				ctx := ContextWithClaims(r.Context(), claims)
 				next.ServeHTTP(w, r.WithContext(ctx))
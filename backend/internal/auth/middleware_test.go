@@ -4,20 +4,22 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
 )
 
 func TestJWTMiddleware(t *testing.T) {
 	secret := "test-secret-key-for-testing-purposes-only"
 
 	// Generate valid token
-	validToken, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", 15*time.Minute)
+	validToken, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", "", 15*time.Minute)
 
 	// Generate expired token
-	expiredToken, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", -1*time.Minute)
+	expiredToken, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", "", -1*time.Minute)
 
 	tests := []struct {
 		name           string
@@ -153,7 +155,7 @@ func TestOptionalJWTMiddleware(t *testing.T) {
 	secret := "test-secret-key-for-testing-purposes-only"
 
 	// Generate valid token
-	validToken, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", 15*time.Minute)
+	validToken, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", "", 15*time.Minute)
 
 	tests := []struct {
 		name           string
@@ -337,7 +339,7 @@ func TestCombinedAuthMiddleware(t *testing.T) {
 	validator := NewAPIKeyValidator(db)
 
 	// Generate valid JWT
-	validJWT, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", 15*time.Minute)
+	validJWT, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", "", 15*time.Minute)
 
 	tests := []struct {
 		name           string
@@ -523,7 +525,7 @@ func TestUnifiedAuthMiddleware(t *testing.T) {
 	userValidator := NewUserAPIKeyValidator(userDB)
 
 	// Generate valid JWT
-	validJWT, _ := GenerateJWT(secret, "jwt-user-123", "jwt@example.com", "user", 15*time.Minute)
+	validJWT, _ := GenerateJWT(secret, "jwt-user-123", "jwt@example.com", "user", "", 15*time.Minute)
 
 	tests := []struct {
 		name           string
@@ -655,10 +657,10 @@ func TestOptionalAuthMiddleware(t *testing.T) {
 	userValidator := NewUserAPIKeyValidator(userDB)
 
 	// Generate valid JWT
-	validJWT, _ := GenerateJWT(secret, "jwt-user-123", "jwt@example.com", "user", 15*time.Minute)
+	validJWT, _ := GenerateJWT(secret, "jwt-user-123", "jwt@example.com", "user", "", 15*time.Minute)
 
 	// Generate expired JWT
-	expiredJWT, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", -1*time.Minute)
+	expiredJWT, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", "", -1*time.Minute)
 
 	tests := []struct {
 		name          string
@@ -781,3 +783,117 @@ func TestOptionalAuthMiddleware(t *testing.T) {
 		})
 	}
 }
+
+// TestUnifiedAuthMiddleware_PendingVerificationAgent verifies an agent whose
+// operator email is unverified can read and resend the email, but not write.
+func TestUnifiedAuthMiddleware_PendingVerificationAgent(t *testing.T) {
+	db := NewMockAgentDB()
+	testKey := "solvr_pendingkey12345678901234567890123456"
+	agent, err := db.AddTestAgent("pending_agent", "Pending Agent", testKey)
+	if err != nil {
+		t.Fatalf("failed to add test agent: %v", err)
+	}
+	agent.Status = string(models.AgentStatusPendingVerification)
+
+	var principal *Principal
+	handler := UnifiedAuthMiddleware("secret", NewAPIKeyValidator(db), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		method, path string
+		wantStatus   int
+	}{
+		{http.MethodGet, "/v1/me", http.StatusOK},
+		{http.MethodPost, "/v1/agents/me/verify-email", http.StatusOK},
+		{http.MethodPost, "/v1/problems", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Header.Set("Authorization", "Bearer "+testKey)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code != tt.wantStatus {
+			t.Errorf("%s %s: status = %d, want %d", tt.method, tt.path, rr.Code, tt.wantStatus)
+		}
+		if tt.wantStatus == http.StatusForbidden && !strings.Contains(rr.Body.String(), "EMAIL_VERIFICATION_PENDING") {
+			t.Errorf("%s %s: expected EMAIL_VERIFICATION_PENDING, got %s", tt.method, tt.path, rr.Body.String())
+		}
+	}
+	if principal == nil || principal.HasScope(ScopeWrite) {
+		t.Errorf("expected a read-only principal for a pending agent, got %+v", principal)
+	}
+}
+
+// TestAuthMiddleware_TenantMismatch verifies JWTs and API keys are only accepted
+// on their own tenant: a credential from one community must not act in another.
+func TestAuthMiddleware_TenantMismatch(t *testing.T) {
+	secret := "test-secret-key-for-testing-purposes-only"
+
+	agentDB := NewMockAgentDB()
+	agentKey := "solvr_acmekey1234567890123456789012345678901"
+	agent, err := agentDB.AddTestAgent("acme_agent", "Acme Agent", agentKey)
+	if err != nil {
+		t.Fatalf("failed to add test agent: %v", err)
+	}
+	agent.TenantID = "acme"
+
+	userDB := NewMockUserAPIKeyDB()
+	userKey := "solvr_sk_acmekey123456789012345678901234567890"
+	userDB.AddTestUser("acme-user", "acmeuser", "user@acme.example").TenantID = "acme"
+	if _, err := userDB.AddTestUserAPIKey("acme-key", "acme-user", "Acme Key", userKey); err != nil {
+		t.Fatalf("failed to add test user API key: %v", err)
+	}
+
+	acmeJWT, _ := GenerateJWT(secret, "acme-user", "user@acme.example", "user", "acme", 15*time.Minute)
+	legacyJWT, _ := GenerateJWT(secret, "user-123", "test@example.com", "user", "", 15*time.Minute)
+
+	unified := UnifiedAuthMiddleware(secret, NewAPIKeyValidator(agentDB), NewUserAPIKeyValidator(userDB))
+	optional := OptionalAuthMiddleware(secret, NewAPIKeyValidator(agentDB), NewUserAPIKeyValidator(userDB))
+
+	tests := []struct {
+		name       string
+		token      string
+		tenant     string
+		wantStatus int
+	}{
+		{"JWT on its tenant", acmeJWT, "acme", http.StatusOK},
+		{"JWT on another tenant", acmeJWT, "other", http.StatusUnauthorized},
+		{"JWT without multi-tenancy", acmeJWT, "", http.StatusOK},
+		{"JWT without tenant on default", legacyJWT, tenant.DefaultID, http.StatusOK},
+		{"JWT without tenant on another tenant", legacyJWT, "acme", http.StatusUnauthorized},
+		{"agent key on its tenant", agentKey, "acme", http.StatusOK},
+		{"agent key on another tenant", agentKey, "other", http.StatusUnauthorized},
+		{"user key on its tenant", userKey, "acme", http.StatusOK},
+		{"user key on another tenant", userKey, "other", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newRequest := func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/v1/me", nil)
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+				if tt.tenant != "" {
+					req = req.WithContext(tenant.WithID(req.Context(), tt.tenant))
+				}
+				return req
+			}
+
+			rr := httptest.NewRecorder()
+			unified(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})).ServeHTTP(rr, newRequest())
+			if rr.Code != tt.wantStatus {
+				t.Errorf("UnifiedAuthMiddleware status = %d, want %d", rr.Code, tt.wantStatus)
+			}
+
+			var principal *Principal
+			optional(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal = PrincipalFromContext(r.Context())
+			})).ServeHTTP(httptest.NewRecorder(), newRequest())
+			if authenticated := principal != nil; authenticated != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("OptionalAuthMiddleware authenticated = %v, want %v", authenticated, tt.wantStatus == http.StatusOK)
+			}
+		})
+	}
+}
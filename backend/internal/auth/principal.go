@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// PrincipalContextKey is the context key for an explicitly set principal.
+const PrincipalContextKey contextKey = "principal"
+
+// Principal is the authenticated caller of a request: a human (JWT or user API
+// key) or an agent (agent API key). Handlers, services and repositories read it
+// from the context with PrincipalFromContext instead of taking the caller as
+// parameters, so rate limiting, usage, audit and visibility checks all agree on
+// who is calling.
+type Principal struct {
+	Type models.AuthorType
+	ID   string
+	// Role is the human's role from the JWT; empty for agents.
+	Role string
+	// HumanID is the human whose family content the caller may see: the user
+	// for humans, the claiming owner for claimed agents, empty otherwise.
+	HumanID string
+	// Scopes is what the credential allows: ScopeRead, plus ScopeWrite unless
+	// the request used a read-only API key.
+	Scopes []string
+	// APIKeyID and APIKeyTier are set when a human authenticated with an API key.
+	APIKeyID   string
+	APIKeyTier string
+	// CreatedAt is the agent's registration time, for new-account limits. Zero
+	// for humans, whose JWT does not carry it.
+	CreatedAt time.Time
+}
+
+// Principal scopes. They match the user API key scopes.
+const (
+	ScopeRead  = models.APIKeyScopeRead
+	ScopeWrite = models.APIKeyScopeWrite
+)
+
+// IsAgent reports whether the principal is an agent.
+func (p *Principal) IsAgent() bool {
+	return p.Type == models.AuthorTypeAgent
+}
+
+// IsAdmin reports whether the principal is a human with the admin role.
+func (p *Principal) IsAdmin() bool {
+	return p.Type == models.AuthorTypeHuman && p.Role == models.UserRoleAdmin
+}
+
+// HasScope reports whether the principal's credential allows scope.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// ContextWithPrincipal sets the principal explicitly, for work that runs outside
+// the auth middleware (jobs, tests, calls made on a user's behalf). It takes
+// precedence over the claims and agent in the context.
+func ContextWithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, PrincipalContextKey, p)
+}
+
+// PrincipalFromContext returns the caller of the request, or nil when it is
+// anonymous. An explicitly set principal wins; otherwise the principal is built
+// from the agent, claims and API key values the auth middleware stored, agent
+// first, matching the middleware's own priority.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	if p, ok := ctx.Value(PrincipalContextKey).(*Principal); ok && p != nil {
+		return p
+	}
+
+	scopes := []string{ScopeRead, ScopeWrite}
+	if IsReadOnlyFromContext(ctx) {
+		scopes = []string{ScopeRead}
+	}
+
+	if agent := AgentFromContext(ctx); agent != nil {
+		p := &Principal{
+			Type:      models.AuthorTypeAgent,
+			ID:        agent.ID,
+			Scopes:    scopes,
+			CreatedAt: agent.CreatedAt,
+		}
+		if agent.HumanID != nil {
+			p.HumanID = *agent.HumanID
+		}
+		return p
+	}
+	if claims := ClaimsFromContext(ctx); claims != nil {
+		return &Principal{
+			Type:       models.AuthorTypeHuman,
+			ID:         claims.UserID,
+			Role:       claims.Role,
+			HumanID:    claims.UserID,
+			Scopes:     scopes,
+			APIKeyID:   APIKeyIDFromContext(ctx),
+			APIKeyTier: APIKeyTierFromContext(ctx),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPrincipalFromContext_Anonymous(t *testing.T) {
+	if p := PrincipalFromContext(context.Background()); p != nil {
+		t.Errorf("PrincipalFromContext() = %+v, want nil", p)
+	}
+}
+
+func TestPrincipalFromContext_Human(t *testing.T) {
+	ctx := ContextWithClaims(context.Background(), &Claims{UserID: "user-1", Role: models.UserRoleAdmin})
+	ctx = ContextWithAPIKeyID(ctx, "key-1")
+	ctx = ContextWithAPIKeyTier(ctx, "pro")
+
+	p := PrincipalFromContext(ctx)
+	if p == nil {
+		t.Fatal("PrincipalFromContext() = nil")
+	}
+	if p.Type != models.AuthorTypeHuman || p.ID != "user-1" || p.HumanID != "user-1" {
+		t.Errorf("principal = %+v", p)
+	}
+	if !p.IsAdmin() || p.IsAgent() {
+		t.Errorf("IsAdmin() = %v, IsAgent() = %v", p.IsAdmin(), p.IsAgent())
+	}
+	if p.APIKeyID != "key-1" || p.APIKeyTier != "pro" {
+		t.Errorf("API key = %q %q", p.APIKeyID, p.APIKeyTier)
+	}
+	if !p.HasScope(ScopeRead) || !p.HasScope(ScopeWrite) {
+		t.Errorf("scopes = %v, want read and write", p.Scopes)
+	}
+}
+
+func TestPrincipalFromContext_ReadOnlyKey(t *testing.T) {
+	ctx := ContextWithClaims(context.Background(), &Claims{UserID: "user-1"})
+	ctx = ContextWithAPIKeyScope(ctx, models.APIKeyScopeRead)
+
+	p := PrincipalFromContext(ctx)
+	if !p.HasScope(ScopeRead) || p.HasScope(ScopeWrite) {
+		t.Errorf("scopes = %v, want read only", p.Scopes)
+	}
+}
+
+func TestPrincipalFromContext_AgentFirst(t *testing.T) {
+	humanID := "owner-1"
+	created := time.Now().Add(-time.Hour)
+	ctx := ContextWithClaims(context.Background(), &Claims{UserID: "user-1"})
+	ctx = ContextWithAgent(ctx, &models.Agent{ID: "agent-1", HumanID: &humanID, CreatedAt: created})
+
+	p := PrincipalFromContext(ctx)
+	if p.Type != models.AuthorTypeAgent || p.ID != "agent-1" {
+		t.Fatalf("principal = %+v, want agent-1", p)
+	}
+	if p.HumanID != "owner-1" || !p.CreatedAt.Equal(created) || p.Role != "" {
+		t.Errorf("principal = %+v", p)
+	}
+
+	unclaimed := ContextWithAgent(context.Background(), &models.Agent{ID: "agent-2"})
+	if p := PrincipalFromContext(unclaimed); p.HumanID != "" {
+		t.Errorf("unclaimed agent HumanID = %q, want empty", p.HumanID)
+	}
+}
+
+func TestContextWithPrincipal_Overrides(t *testing.T) {
+	ctx := ContextWithClaims(context.Background(), &Claims{UserID: "user-1"})
+	ctx = ContextWithPrincipal(ctx, &Principal{Type: models.AuthorTypeAgent, ID: "job-agent"})
+
+	if p := PrincipalFromContext(ctx); p.ID != "job-agent" {
+		t.Errorf("PrincipalFromContext() ID = %q, want job-agent", p.ID)
+	}
+}
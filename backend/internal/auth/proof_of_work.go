@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProofOfWorkMaxAge is how far a hashcash stamp's date may be from now.
+// Stamps are bound to the resource (the agent name) they were minted for,
+// so the window only limits how long work can be stockpiled.
+const ProofOfWorkMaxAge = 48 * time.Hour
+
+// ProofOfWorkFormat describes the stamp clients must send, for error details.
+const ProofOfWorkFormat = "1:<bits>:<YYMMDD[hhmm[ss]]>:<resource>::<rand>:<counter>"
+
+// Proof-of-work verification errors.
+var (
+	ErrProofOfWorkMalformed    = errors.New("proof of work stamp is malformed")
+	ErrProofOfWorkTooEasy      = errors.New("proof of work stamp has too few bits")
+	ErrProofOfWorkStale        = errors.New("proof of work stamp date is too old or in the future")
+	ErrProofOfWorkWrongTarget  = errors.New("proof of work stamp resource does not match")
+	ErrProofOfWorkInsufficient = errors.New("proof of work stamp hash does not have enough leading zero bits")
+)
+
+// VerifyProofOfWork checks a hashcash version 1 stamp
+// (1:bits:date:resource:ext:rand:counter) minted for resource: it claims at
+// least minBits, is dated within ProofOfWorkMaxAge of now, names resource
+// (case-insensitively) and its SHA-256 starts with minBits zero bits.
+func VerifyProofOfWork(stamp, resource string, minBits int, now time.Time) error {
+	parts := strings.Split(stamp, ":")
+	if len(parts) != 7 || parts[0] != "1" {
+		return ErrProofOfWorkMalformed
+	}
+	claimed, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return ErrProofOfWorkMalformed
+	}
+	if claimed < minBits {
+		return ErrProofOfWorkTooEasy
+	}
+	date, err := parseStampDate(parts[2])
+	if err != nil {
+		return ErrProofOfWorkMalformed
+	}
+	if age := now.Sub(date); age > ProofOfWorkMaxAge || age < -ProofOfWorkMaxAge {
+		return ErrProofOfWorkStale
+	}
+	if !strings.EqualFold(parts[3], resource) {
+		return ErrProofOfWorkWrongTarget
+	}
+	if leadingZeroBits(sha256.Sum256([]byte(stamp))) < minBits {
+		return ErrProofOfWorkInsufficient
+	}
+	return nil
+}
+
+// parseStampDate parses a hashcash date: YYMMDD, YYMMDDhhmm or YYMMDDhhmmss, UTC.
+func parseStampDate(s string) (time.Time, error) {
+	switch len(s) {
+	case 6:
+		return time.Parse("060102", s)
+	case 10:
+		return time.Parse("0601021504", s)
+	case 12:
+		return time.Parse("060102150405", s)
+	}
+	return time.Time{}, ErrProofOfWorkMalformed
+}
+
+// leadingZeroBits counts the zero bits at the start of sum.
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
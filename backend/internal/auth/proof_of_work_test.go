@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// mintStamp finds a hashcash stamp for resource with at least bitsNeeded zero bits.
+func mintStamp(t *testing.T, resource string, bitsNeeded int, date time.Time) string {
+	t.Helper()
+	prefix := "1:" + strconv.Itoa(bitsNeeded) + ":" + date.UTC().Format("060102150405") + ":" + resource + "::c29sdnI:"
+	for counter := 0; counter < 1<<24; counter++ {
+		stamp := prefix + strconv.Itoa(counter)
+		if leadingZeroBits(sha256.Sum256([]byte(stamp))) >= bitsNeeded {
+			return stamp
+		}
+	}
+	t.Fatal("no stamp found")
+	return ""
+}
+
+func TestVerifyProofOfWork(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	valid := mintStamp(t, "helper_bot", 12, now)
+
+	if err := VerifyProofOfWork(valid, "Helper_Bot", 12, now); err != nil {
+		t.Fatalf("valid stamp rejected: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		stamp    string
+		resource string
+		bits     int
+		now      time.Time
+		want     error
+	}{
+		{"garbage", "not-a-stamp", "helper_bot", 12, now, ErrProofOfWorkMalformed},
+		{"version 0", "0" + valid[1:], "helper_bot", 12, now, ErrProofOfWorkMalformed},
+		{"too few claimed bits", valid, "helper_bot", 20, now, ErrProofOfWorkTooEasy},
+		{"stale", valid, "helper_bot", 12, now.Add(72 * time.Hour), ErrProofOfWorkStale},
+		{"other resource", valid, "other_bot", 12, now, ErrProofOfWorkWrongTarget},
+		{"unsolved", "1:12:" + now.Format("060102") + ":helper_bot::c29sdnI:nope", "helper_bot", 12, now, ErrProofOfWorkInsufficient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyProofOfWork(tt.stamp, tt.resource, tt.bits, tt.now); !errors.Is(err, tt.want) {
+				t.Errorf("VerifyProofOfWork() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -390,3 +391,73 @@ func TestUserAPIKeyValidator_ValidateUserAPIKey(t *testing.T) {
 		}
 	})
 }
+
+// TestUnifiedAuthMiddleware_ReadOnlyKey verifies read-scoped keys can read but not write.
+func TestUnifiedAuthMiddleware_ReadOnlyKey(t *testing.T) {
+	db := NewMockUserAPIKeyDB()
+	testKey := "solvr_sk_readonly123456789012345678901234567890"
+	key, err := db.AddTestUserAPIKey("key-read", "user-123", "Read Key", testKey)
+	if err != nil {
+		t.Fatalf("failed to add test API key: %v", err)
+	}
+	key.Scope = models.APIKeyScopeRead
+
+	var gotScope string
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotScope = APIKeyScopeFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := UnifiedAuthMiddleware("secret", nil, NewUserAPIKeyValidator(db))(nextHandler)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/posts", nil)
+	req.Header.Set("Authorization", "Bearer "+testKey)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("GET with read-only key: expected 200, got %d", rr.Code)
+	}
+	if gotScope != models.APIKeyScopeRead {
+		t.Errorf("expected scope %q in context, got %q", models.APIKeyScopeRead, gotScope)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/posts", nil)
+	req.Header.Set("Authorization", "Bearer "+testKey)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("POST with read-only key: expected 403, got %d", rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), ErrCodeInsufficientScope) {
+		t.Errorf("expected %s error, got %s", ErrCodeInsufficientScope, rr.Body.String())
+	}
+}
+
+// TestOptionalAuthMiddleware_ReadOnlyKeyPassesScope verifies optional auth never rejects
+// a read-only key but records its scope for handlers (e.g. MCP) to enforce.
+func TestOptionalAuthMiddleware_ReadOnlyKeyPassesScope(t *testing.T) {
+	db := NewMockUserAPIKeyDB()
+	testKey := "solvr_sk_readonly123456789012345678901234567890"
+	key, err := db.AddTestUserAPIKey("key-read", "user-123", "Read Key", testKey)
+	if err != nil {
+		t.Fatalf("failed to add test API key: %v", err)
+	}
+	key.Scope = models.APIKeyScopeRead
+
+	var readOnly bool
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readOnly = IsReadOnlyFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := OptionalAuthMiddleware("secret", nil, NewUserAPIKeyValidator(db))(nextHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/mcp", nil)
+	req.Header.Set("Authorization", "Bearer "+testKey)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if !readOnly {
+		t.Error("expected read-only scope in context")
+	}
+}
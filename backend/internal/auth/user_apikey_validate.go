@@ -35,7 +35,8 @@ func NewUserAPIKeyValidator(db UserAPIKeyDB) *UserAPIKeyValidator {
 }
 
 // ValidateUserAPIKey validates a user API key and returns the associated user and key.
-// Returns an AuthError if the key is invalid, malformed, or not found.
+// Returns an AuthError if the key is invalid, malformed, not found, or belongs to
+// another tenant than the request's.
 func (v *UserAPIKeyValidator) ValidateUserAPIKey(ctx context.Context, key string) (*models.User, *models.UserAPIKey, error) {
 	// Check for empty key
 	if key == "" {
@@ -58,6 +59,11 @@ func (v *UserAPIKeyValidator) ValidateUserAPIKey(ctx context.Context, key string
 		return nil, nil, NewAuthError(ErrCodeInvalidAPIKey, "invalid API key")
 	}
 
+	// Keys are only valid on their owner's tenant
+	if err := checkTenant(ctx, user.TenantID); err != nil {
+		return nil, nil, err
+	}
+
 	return user, apiKey, nil
 }
 
@@ -91,6 +97,12 @@ func UserAPIKeyMiddleware(validator *UserAPIKeyValidator) func(http.Handler) htt
 				return
 			}
 
+			// Read-only keys may not modify state
+			if apiKey.EffectiveScope() == models.APIKeyScopeRead && !isReadOnlyMethod(r.Method) {
+				writeInsufficientScopeError(w)
+				return
+			}
+
 			// Update last_used_at (fire and forget - errors are not critical)
 			_ = validator.db.UpdateLastUsed(r.Context(), apiKey.ID)
 
@@ -105,6 +117,7 @@ func UserAPIKeyMiddleware(validator *UserAPIKeyValidator) func(http.Handler) htt
 			ctx := ContextWithClaims(r.Context(), claims)
 			// Add API key ID for per-key rate limiting
 			ctx = ContextWithAPIKeyID(ctx, apiKey.ID)
+			ctx = ContextWithAPIKeyScope(ctx, apiKey.EffectiveScope())
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
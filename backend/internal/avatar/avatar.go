@@ -0,0 +1,96 @@
+// Package avatar resizes uploaded profile pictures to the standard avatar sizes.
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // register GIF decoding for avatar uploads
+	"image/jpeg"
+	_ "image/png" // register PNG decoding for avatar uploads
+	"io"
+)
+
+// Sizes are the square sizes, in pixels, every uploaded avatar is rendered at.
+// The largest is the profile's avatar_url.
+var Sizes = []int{64, 128, 256}
+
+// MaxPixels bounds the decoded size of an upload so a small, highly compressed
+// file can't expand into a huge bitmap.
+const MaxPixels = 25_000_000
+
+var (
+	// ErrUnsupportedImage is returned for uploads that aren't a JPEG, PNG or GIF.
+	ErrUnsupportedImage = errors.New("unsupported image format")
+	// ErrTooLarge is returned for images over MaxPixels.
+	ErrTooLarge = errors.New("image dimensions too large")
+)
+
+// Process decodes a JPEG, PNG or GIF (first frame), crops it to a centered
+// square and renders it as a JPEG at each of Sizes. Transparent areas become
+// white. Returns the encoded images keyed by size.
+func Process(r io.Reader) (map[int][]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read avatar: %w", err)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	// Flatten onto white and crop to the centered square in one pass
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	origin := image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2)
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(square, square.Bounds(), src, origin, draw.Over)
+
+	out := make(map[int][]byte, len(Sizes))
+	for _, size := range Sizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resizeSquare(square, size), &jpeg.Options{Quality: 85}); err != nil {
+			return nil, fmt.Errorf("encode avatar: %w", err)
+		}
+		out[size] = buf.Bytes()
+	}
+	return out, nil
+}
+
+// resizeSquare scales a square image to size x size. Each output pixel is the
+// average of the source pixels it covers, which keeps downscaled avatars
+// smooth; smaller sources are scaled up by repeating pixels.
+func resizeSquare(src *image.RGBA, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	side := src.Bounds().Dx()
+	for y := 0; y < size; y++ {
+		y0, y1 := y*side/size, max((y+1)*side/size, y*side/size+1)
+		for x := 0; x < size; x++ {
+			x0, x1 := x*side/size, max((x+1)*side/size, x*side/size+1)
+			var r, g, b, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					i := src.PixOffset(sx, sy)
+					r += uint32(src.Pix[i])
+					g += uint32(src.Pix[i+1])
+					b += uint32(src.Pix[i+2])
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i], dst.Pix[i+1], dst.Pix[i+2], dst.Pix[i+3] = uint8(r/n), uint8(g/n), uint8(b/n), 0xff
+		}
+	}
+	return dst
+}
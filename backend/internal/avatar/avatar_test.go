@@ -0,0 +1,69 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestProcess_CropsAndResizes(t *testing.T) {
+	// 300x200: red left half, blue right half
+	src := image.NewNRGBA(image.Rect(0, 0, 300, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 300; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= 150 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			src.SetNRGBA(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := Process(&buf)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if len(out) != len(Sizes) {
+		t.Fatalf("got %d sizes, want %d", len(out), len(Sizes))
+	}
+	for _, size := range Sizes {
+		img, err := jpeg.Decode(bytes.NewReader(out[size]))
+		if err != nil {
+			t.Fatalf("size %d: not a JPEG: %v", size, err)
+		}
+		if b := img.Bounds(); b.Dx() != size || b.Dy() != size {
+			t.Errorf("size %d: got %dx%d", size, b.Dx(), b.Dy())
+		}
+		// The centered crop keeps both halves: red on the left, blue on the right
+		if r, _, _, _ := img.At(size/8, size/2).RGBA(); r < 0xc000 {
+			t.Errorf("size %d: left side isn't red", size)
+		}
+		if _, _, b, _ := img.At(size-size/8, size/2).RGBA(); b < 0xc000 {
+			t.Errorf("size %d: right side isn't blue", size)
+		}
+	}
+}
+
+func TestProcess_Rejects(t *testing.T) {
+	if _, err := Process(strings.NewReader("not an image")); !errors.Is(err, ErrUnsupportedImage) {
+		t.Errorf("text err = %v, want ErrUnsupportedImage", err)
+	}
+
+	// 6000x5000 is over MaxPixels and rejected before decoding
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 6000, 5000))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Process(&buf); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("huge image err = %v, want ErrTooLarge", err)
+	}
+}
@@ -0,0 +1,247 @@
+// Package backup defines the dump format shared by cmd/backup and cmd/restore:
+// gzip-compressed NDJSON holding a header line, one line per table row and an
+// end line with per-table row counts. Rows are the JSON form of the database
+// row (to_jsonb), so dumps survive column additions: restore fills columns
+// missing from an older dump with their defaults.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Format identifies Solvr dumps in the header line.
+const Format = "solvr-backup"
+
+// FormatVersion is the version of the line layout written by Writer.
+const FormatVersion = 1
+
+// EmbeddingColumn is the vector column left out of dumps taken without
+// embeddings; cmd/backfill-embeddings regenerates it after a restore.
+const EmbeddingColumn = "embedding"
+
+// objectSuffix ends every dump object key.
+const objectSuffix = ".ndjson.gz"
+
+// Tables are the dumped tables, in restore order: parents before children.
+var Tables = []string{"posts", "answers", "approaches", "comments"}
+
+// ErrTruncated is returned when a dump ends before its end line.
+var ErrTruncated = errors.New("backup is truncated")
+
+// Header describes a dump.
+type Header struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	CreatedAt     time.Time `json:"created_at"`
+	SchemaVersion int64     `json:"schema_version"` // last migration applied to the source database
+	Tables        []string  `json:"tables"`
+	Embeddings    bool      `json:"embeddings"`
+}
+
+// line is one NDJSON line: a header, a row or the end marker.
+type line struct {
+	Kind   string          `json:"kind"`
+	Header *Header         `json:"header,omitempty"`
+	Table  string          `json:"table,omitempty"`
+	Row    json.RawMessage `json:"row,omitempty"`
+	Counts map[string]int  `json:"counts,omitempty"`
+}
+
+const (
+	kindHeader = "header"
+	kindRow    = "row"
+	kindEnd    = "end"
+)
+
+// Writer writes a dump. Close must be called to write the end line and flush
+// the compressed stream.
+type Writer struct {
+	gz     *gzip.Writer
+	enc    *json.Encoder
+	counts map[string]int
+}
+
+// NewWriter writes h as the header of a new dump on w. Format and Version are
+// filled in.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	h.Format, h.Version = Format, FormatVersion
+	gz := gzip.NewWriter(w)
+	bw := &Writer{gz: gz, enc: json.NewEncoder(gz), counts: make(map[string]int)}
+	if err := bw.enc.Encode(line{Kind: kindHeader, Header: &h}); err != nil {
+		return nil, fmt.Errorf("write backup header: %w", err)
+	}
+	return bw, nil
+}
+
+// WriteRow writes one row of table as its JSON object.
+func (w *Writer) WriteRow(table string, row json.RawMessage) error {
+	if err := w.enc.Encode(line{Kind: kindRow, Table: table, Row: row}); err != nil {
+		return fmt.Errorf("write %s row: %w", table, err)
+	}
+	w.counts[table]++
+	return nil
+}
+
+// Counts returns the rows written so far per table.
+func (w *Writer) Counts() map[string]int {
+	return w.counts
+}
+
+// Close writes the end line and flushes the stream. It does not close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if err := w.enc.Encode(line{Kind: kindEnd, Counts: w.counts}); err != nil {
+		return fmt.Errorf("write backup end: %w", err)
+	}
+	return w.gz.Close()
+}
+
+// Reader reads a dump written by Writer.
+type Reader struct {
+	header  Header
+	gz      *gzip.Reader
+	scanner *bufio.Scanner
+	counts  map[string]int
+	done    bool
+}
+
+// maxLineSize bounds one dump line; rows with embeddings run to tens of KB.
+const maxLineSize = 16 << 20
+
+// NewReader reads the header of the dump on r.
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open backup: %w", err)
+	}
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	br := &Reader{gz: gz, scanner: scanner, counts: make(map[string]int)}
+
+	l, err := br.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if l.Kind != kindHeader || l.Header == nil || l.Header.Format != Format {
+		return nil, errors.New("not a solvr backup")
+	}
+	if l.Header.Version > FormatVersion {
+		return nil, fmt.Errorf("backup format version %d is newer than supported version %d", l.Header.Version, FormatVersion)
+	}
+	br.header = *l.Header
+	return br, nil
+}
+
+// Header returns the dump's header.
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Next returns the next row and its table. It returns io.EOF after the end
+// line once the row counts have been checked, and ErrTruncated if the dump
+// stops before it.
+func (r *Reader) Next() (table string, row json.RawMessage, err error) {
+	if r.done {
+		return "", nil, io.EOF
+	}
+	l, err := r.readLine()
+	if err != nil {
+		return "", nil, err
+	}
+	switch l.Kind {
+	case kindRow:
+		r.counts[l.Table]++
+		return l.Table, l.Row, nil
+	case kindEnd:
+		r.done = true
+		for _, t := range unionKeys(l.Counts, r.counts) {
+			if l.Counts[t] != r.counts[t] {
+				return "", nil, fmt.Errorf("backup has %d %s rows, end line says %d", r.counts[t], t, l.Counts[t])
+			}
+		}
+		return "", nil, io.EOF
+	default:
+		return "", nil, fmt.Errorf("unexpected backup line kind %q", l.Kind)
+	}
+}
+
+// readLine decodes the next line, mapping a premature end to ErrTruncated.
+func (r *Reader) readLine() (*line, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("read backup: %w", err)
+		}
+		return nil, ErrTruncated
+	}
+	var l line
+	if err := json.Unmarshal(r.scanner.Bytes(), &l); err != nil {
+		return nil, fmt.Errorf("decode backup line: %w", err)
+	}
+	return &l, nil
+}
+
+// ObjectKey names a dump taken at t under prefix, e.g.
+// "backups/solvr-20261018T120000Z.ndjson.gz". Keys sort chronologically.
+func ObjectKey(prefix string, t time.Time) string {
+	return prefix + "solvr-" + t.UTC().Format("20060102T150405Z") + objectSuffix
+}
+
+// IsObjectKey reports whether key names a dump under prefix.
+func IsObjectKey(prefix, key string) bool {
+	rest, ok := strings.CutPrefix(key, prefix+"solvr-")
+	return ok && strings.HasSuffix(rest, objectSuffix) && !strings.Contains(rest, "/")
+}
+
+// Latest returns the newest dump key among keys, or "" if there is none.
+func Latest(prefix string, keys []string) string {
+	latest := ""
+	for _, k := range keys {
+		if IsObjectKey(prefix, k) && k > latest {
+			latest = k
+		}
+	}
+	return latest
+}
+
+// Expired returns the dump keys to delete so only the newest keep remain,
+// oldest first. A non-positive keep expires nothing.
+func Expired(prefix string, keys []string, keep int) []string {
+	if keep <= 0 {
+		return nil
+	}
+	var dumps []string
+	for _, k := range keys {
+		if IsObjectKey(prefix, k) {
+			dumps = append(dumps, k)
+		}
+	}
+	if len(dumps) <= keep {
+		return nil
+	}
+	sort.Strings(dumps)
+	return dumps[:len(dumps)-keep]
+}
+
+// unionKeys returns the keys of a and b, sorted.
+func unionKeys(a, b map[string]int) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var keys []string
+	for _, m := range []map[string]int{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeDump(t *testing.T, rows map[string][]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{CreatedAt: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), SchemaVersion: 116, Tables: Tables})
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	for _, table := range Tables {
+		for _, row := range rows[table] {
+			if err := w.WriteRow(table, json.RawMessage(row)); err != nil {
+				t.Fatalf("WriteRow() error = %v", err)
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestWriterReaderRoundTrip(t *testing.T) {
+	dump := writeDump(t, map[string][]string{
+		"posts":   {`{"id":"p1","title":"First"}`, `{"id":"p2","title":"Second"}`},
+		"answers": {`{"id":"a1","question_id":"p2"}`},
+	})
+
+	r, err := NewReader(bytes.NewReader(dump))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	h := r.Header()
+	if h.Format != Format || h.Version != FormatVersion || h.SchemaVersion != 116 || !reflect.DeepEqual(h.Tables, Tables) {
+		t.Errorf("unexpected header %+v", h)
+	}
+
+	var got []string
+	for {
+		table, row, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		got = append(got, table+" "+string(row))
+	}
+	want := []string{
+		`posts {"id":"p1","title":"First"}`,
+		`posts {"id":"p2","title":"Second"}`,
+		`answers {"id":"a1","question_id":"p2"}`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %v, want %v", got, want)
+	}
+}
+
+func TestReaderDetectsTruncation(t *testing.T) {
+	dump := writeDump(t, map[string][]string{"posts": {`{"id":"p1"}`, `{"id":"p2"}`}})
+
+	r, err := NewReader(bytes.NewReader(dump[:len(dump)-12]))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	for {
+		_, _, err = r.Next()
+		if err != nil {
+			break
+		}
+	}
+	if errors.Is(err, io.EOF) {
+		t.Fatal("expected a truncated dump to fail, got io.EOF")
+	}
+}
+
+func TestReaderRejectsOtherFiles(t *testing.T) {
+	if _, err := NewReader(bytes.NewReader([]byte("not gzip"))); err == nil {
+		t.Error("expected an error for a non-gzip file")
+	}
+}
+
+func TestObjectKeys(t *testing.T) {
+	prefix := "backups/"
+	key := ObjectKey(prefix, time.Date(2026, 10, 18, 12, 30, 0, 0, time.FixedZone("BRT", -3*3600)))
+	if key != "backups/solvr-20261018T153000Z.ndjson.gz" {
+		t.Fatalf("ObjectKey() = %q", key)
+	}
+
+	keys := []string{
+		"backups/solvr-20261016T000000Z.ndjson.gz",
+		"backups/solvr-20261018T000000Z.ndjson.gz",
+		"backups/notes.txt",
+		"backups/old/solvr-20991231T000000Z.ndjson.gz",
+		"backups/solvr-20261017T000000Z.ndjson.gz",
+	}
+	if got := Latest(prefix, keys); got != "backups/solvr-20261018T000000Z.ndjson.gz" {
+		t.Errorf("Latest() = %q", got)
+	}
+	if got := Latest(prefix, nil); got != "" {
+		t.Errorf("Latest(nil) = %q, want empty", got)
+	}
+
+	wantExpired := []string{"backups/solvr-20261016T000000Z.ndjson.gz"}
+	if got := Expired(prefix, keys, 2); !reflect.DeepEqual(got, wantExpired) {
+		t.Errorf("Expired(keep=2) = %v, want %v", got, wantExpired)
+	}
+	if got := Expired(prefix, keys, 0); got != nil {
+		t.Errorf("Expired(keep=0) = %v, want nil", got)
+	}
+}
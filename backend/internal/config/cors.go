@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// DefaultAllowedOrigins are the CORS origins used when ALLOWED_ORIGINS is unset.
+var DefaultAllowedOrigins = []string{"http://localhost:3000", "https://solvr.dev", "https://www.solvr.dev"}
+
+// AllowedOrigins reads ALLOWED_ORIGINS, a comma-separated list of browser
+// origins allowed to call the API with credentials, falling back to
+// DefaultAllowedOrigins. See ParseAllowedOrigins for the accepted forms.
+func AllowedOrigins() ([]string, error) {
+	spec := os.Getenv("ALLOWED_ORIGINS")
+	if strings.TrimSpace(spec) == "" {
+		return DefaultAllowedOrigins, nil
+	}
+	origins, err := ParseAllowedOrigins(spec)
+	if err != nil {
+		return nil, fmt.Errorf("ALLOWED_ORIGINS: %w", err)
+	}
+	return origins, nil
+}
+
+// ParseAllowedOrigins parses a comma-separated origin list. Each origin is
+// scheme://host[:port] with an http or https scheme and no path. A leading
+// "*." in the host allows every subdomain (https://*.example.com matches
+// https://docs.example.com but not https://example.com). A bare "*" is
+// rejected: credentials are allowed, so it would let any site act as the
+// signed-in user. Origins are lowercased; empty entries are skipped.
+func ParseAllowedOrigins(spec string) ([]string, error) {
+	var origins []string
+	for _, raw := range strings.Split(spec, ",") {
+		origin := strings.ToLower(strings.TrimSpace(raw))
+		if origin == "" {
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			return nil, fmt.Errorf("invalid origin %q: %w", raw, err)
+		}
+		origins = append(origins, strings.TrimSuffix(origin, "/"))
+	}
+	if len(origins) == 0 {
+		return nil, fmt.Errorf("no origins listed")
+	}
+	return origins, nil
+}
+
+func validateOrigin(origin string) error {
+	if origin == "*" {
+		return fmt.Errorf("wildcard for every origin is not allowed with credentials; list origins or use https://*.example.com")
+	}
+	u, err := url.Parse(strings.Replace(origin, "*.", "wildcard.", 1))
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https")
+	}
+	if u.Host == "" || u.User != nil {
+		return fmt.Errorf("expected scheme://host[:port]")
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("origins have no path, query or fragment")
+	}
+	if strings.Contains(u.Host, "*") || (strings.Contains(origin, "*") && !strings.HasPrefix(origin, u.Scheme+"://*.")) {
+		return fmt.Errorf("only a leading *. subdomain wildcard is supported")
+	}
+	if strings.HasPrefix(origin, u.Scheme+"://*.") {
+		// *.com would allow every site under a public suffix.
+		if domain := strings.TrimPrefix(u.Hostname(), "wildcard."); !strings.Contains(domain, ".") && domain != "localhost" {
+			return fmt.Errorf("wildcard needs a domain, e.g. https://*.example.com")
+		}
+	}
+	return nil
+}
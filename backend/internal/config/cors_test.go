@@ -0,0 +1,62 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAllowedOrigins(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "single", spec: "https://solvr.example", want: []string{"https://solvr.example"}},
+		{name: "trims, lowercases and skips empties", spec: " https://A.example ,, http://localhost:3000/ ", want: []string{"https://a.example", "http://localhost:3000"}},
+		{name: "subdomain wildcard", spec: "https://*.example.com", want: []string{"https://*.example.com"}},
+		{name: "localhost wildcard", spec: "http://*.localhost:3000", want: []string{"http://*.localhost:3000"}},
+		{name: "bare wildcard", spec: "*", wantErr: true},
+		{name: "public suffix wildcard", spec: "https://*.com", wantErr: true},
+		{name: "wildcard in the middle", spec: "https://app*.example.com", wantErr: true},
+		{name: "two wildcards", spec: "https://*.*.example.com", wantErr: true},
+		{name: "missing scheme", spec: "solvr.example", wantErr: true},
+		{name: "bad scheme", spec: "ftp://solvr.example", wantErr: true},
+		{name: "path", spec: "https://solvr.example/app", wantErr: true},
+		{name: "one bad among good", spec: "https://solvr.example,nope", wantErr: true},
+		{name: "only commas", spec: " , ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAllowedOrigins(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedOrigins_DefaultsWhenUnset(t *testing.T) {
+	t.Setenv("ALLOWED_ORIGINS", "")
+	got, err := AllowedOrigins()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, DefaultAllowedOrigins) {
+		t.Errorf("got %v, want defaults %v", got, DefaultAllowedOrigins)
+	}
+
+	t.Setenv("ALLOWED_ORIGINS", "*")
+	if _, err := AllowedOrigins(); err == nil {
+		t.Error("expected error for invalid ALLOWED_ORIGINS")
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 const (
@@ -29,6 +33,12 @@ type Config struct {
 
 	// Database
 	DatabaseURL string
+	// Optional read replica for read-only queries; reads fall back to the
+	// primary when its replay lag exceeds DatabaseReplicaMaxLag.
+	DatabaseReplicaURL    string
+	DatabaseReplicaMaxLag string
+	// Queries slower than this are logged with their caller and SQL.
+	DBSlowQueryThreshold string
 
 	// JWT
 	JWTSecret          string
@@ -112,6 +122,10 @@ func Load() (*Config, error) {
 	cfg.Port = getEnvOrDefault("PORT", "8080")
 
 	// JWT with defaults
+	cfg.DatabaseReplicaURL = os.Getenv("DATABASE_REPLICA_URL")
+	cfg.DatabaseReplicaMaxLag = getEnvOrDefault("DATABASE_REPLICA_MAX_LAG", "5s")
+	cfg.DBSlowQueryThreshold = getEnvOrDefault("DB_SLOW_QUERY_THRESHOLD", "500ms")
+
 	cfg.JWTExpiry = getEnvOrDefault("JWT_EXPIRY", "15m")
 	cfg.RefreshTokenExpiry = getEnvOrDefault("REFRESH_TOKEN_EXPIRY", "7d")
 
@@ -169,6 +183,299 @@ func SearchConfidenceThreshold() float64 {
 	return v
 }
 
+// TrendingConfig reads the trending score weights from TRENDING_VOTE_WEIGHT,
+// TRENDING_VIEW_WEIGHT, TRENDING_ANSWER_WEIGHT, TRENDING_HALF_LIFE_HOURS and
+// TRENDING_WINDOW_DAYS. Negative weights and non-positive half-life or window
+// values fall back to models.DefaultTrendingConfig.
+func TrendingConfig() models.TrendingConfig {
+	def := models.DefaultTrendingConfig()
+	cfg := models.TrendingConfig{
+		VoteWeight:    getEnvOrDefaultFloat("TRENDING_VOTE_WEIGHT", def.VoteWeight),
+		ViewWeight:    getEnvOrDefaultFloat("TRENDING_VIEW_WEIGHT", def.ViewWeight),
+		AnswerWeight:  getEnvOrDefaultFloat("TRENDING_ANSWER_WEIGHT", def.AnswerWeight),
+		HalfLifeHours: getEnvOrDefaultFloat("TRENDING_HALF_LIFE_HOURS", def.HalfLifeHours),
+		WindowDays:    getEnvOrDefaultInt("TRENDING_WINDOW_DAYS", def.WindowDays),
+	}
+	if cfg.VoteWeight < 0 {
+		cfg.VoteWeight = def.VoteWeight
+	}
+	if cfg.ViewWeight < 0 {
+		cfg.ViewWeight = def.ViewWeight
+	}
+	if cfg.AnswerWeight < 0 {
+		cfg.AnswerWeight = def.AnswerWeight
+	}
+	if cfg.HalfLifeHours <= 0 {
+		cfg.HalfLifeHours = def.HalfLifeHours
+	}
+	if cfg.WindowDays <= 0 {
+		cfg.WindowDays = def.WindowDays
+	}
+	return cfg
+}
+
+// AnswerDuplicateConfig reads ANSWER_DUPLICATE_WARN_THRESHOLD and
+// ANSWER_DUPLICATE_REJECT_THRESHOLD (cosine 0–1). Out-of-range values fall back to
+// models.DefaultAnswerDuplicateConfig, and a reject threshold below the warn
+// threshold is raised to it.
+func AnswerDuplicateConfig() models.AnswerDuplicateConfig {
+	def := models.DefaultAnswerDuplicateConfig()
+	cfg := models.AnswerDuplicateConfig{
+		WarnThreshold:   getEnvOrDefaultFloat("ANSWER_DUPLICATE_WARN_THRESHOLD", def.WarnThreshold),
+		RejectThreshold: getEnvOrDefaultFloat("ANSWER_DUPLICATE_REJECT_THRESHOLD", def.RejectThreshold),
+	}
+	if cfg.WarnThreshold < 0 || cfg.WarnThreshold > 1 {
+		cfg.WarnThreshold = def.WarnThreshold
+	}
+	if cfg.RejectThreshold < 0 || cfg.RejectThreshold > 1 {
+		cfg.RejectThreshold = def.RejectThreshold
+	}
+	if cfg.RejectThreshold < cfg.WarnThreshold {
+		cfg.RejectThreshold = cfg.WarnThreshold
+	}
+	return cfg
+}
+
+// RemoderationConfig reads REMODERATION_MIN_CHANGED_CHARS and
+// REMODERATION_HOLD_RATIO, falling back to models.DefaultRemoderationConfig for
+// a non-positive size or a ratio outside 0–1. A ratio of 0 never holds edits.
+func RemoderationConfig() models.RemoderationConfig {
+	def := models.DefaultRemoderationConfig()
+	cfg := models.RemoderationConfig{
+		MinChangedChars: getEnvOrDefaultInt("REMODERATION_MIN_CHANGED_CHARS", def.MinChangedChars),
+		HoldRatio:       getEnvOrDefaultFloat("REMODERATION_HOLD_RATIO", def.HoldRatio),
+	}
+	if cfg.MinChangedChars <= 0 {
+		cfg.MinChangedChars = def.MinChangedChars
+	}
+	if cfg.HoldRatio < 0 || cfg.HoldRatio > 1 {
+		cfg.HoldRatio = def.HoldRatio
+	}
+	return cfg
+}
+
+// UsernameChangeConfig reads USERNAME_REUSE_HOLD_DAYS and
+// USERNAME_CHANGE_COOLDOWN_DAYS, falling back to the defaults for negative values.
+func UsernameChangeConfig() models.UsernameChangeConfig {
+	def := models.DefaultUsernameChangeConfig()
+	cfg := models.UsernameChangeConfig{
+		ReuseHoldDays: getEnvOrDefaultInt("USERNAME_REUSE_HOLD_DAYS", def.ReuseHoldDays),
+		CooldownDays:  getEnvOrDefaultInt("USERNAME_CHANGE_COOLDOWN_DAYS", def.CooldownDays),
+	}
+	if cfg.ReuseHoldDays < 0 {
+		cfg.ReuseHoldDays = def.ReuseHoldDays
+	}
+	if cfg.CooldownDays < 0 {
+		cfg.CooldownDays = def.CooldownDays
+	}
+	return cfg
+}
+
+// MailerConfig reads the notification email driver settings. EMAIL_DRIVER picks
+// smtp, ses, sendgrid or resend; when unset it is inferred from whichever of
+// RESEND_API_KEY, SENDGRID_API_KEY or SMTP_HOST is present, and left empty (email
+// disabled) when none is. SES reads AWS_REGION, AWS_ACCESS_KEY_ID and
+// AWS_SECRET_ACCESS_KEY.
+func MailerConfig() models.MailerConfig {
+	cfg := models.MailerConfig{
+		Driver:             os.Getenv("EMAIL_DRIVER"),
+		FromEmail:          getEnvOrDefault("FROM_EMAIL", "noreply@solvr.dev"),
+		SMTPHost:           os.Getenv("SMTP_HOST"),
+		SMTPPort:           getEnvOrDefaultInt("SMTP_PORT", 587),
+		SMTPUser:           os.Getenv("SMTP_USER"),
+		SMTPPass:           os.Getenv("SMTP_PASS"),
+		SESRegion:          getEnvOrDefault("AWS_REGION", "us-east-1"),
+		SESAccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SESSecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SendGridAPIKey:     os.Getenv("SENDGRID_API_KEY"),
+		ResendAPIKey:       os.Getenv("RESEND_API_KEY"),
+	}
+	if cfg.Driver == "" {
+		switch {
+		case cfg.ResendAPIKey != "":
+			cfg.Driver = models.MailerDriverResend
+		case cfg.SendGridAPIKey != "":
+			cfg.Driver = models.MailerDriverSendGrid
+		case cfg.SMTPHost != "":
+			cfg.Driver = models.MailerDriverSMTP
+		}
+	}
+	return cfg
+}
+
+// WebPushConfig reads the Web Push settings: VAPID_PUBLIC_KEY and
+// VAPID_PRIVATE_KEY (push is disabled unless both are set), VAPID_SUBJECT
+// (default mailto:support@solvr.dev) and WEB_PUSH_TYPES, a comma-separated
+// list of notification types to push (default models.DefaultWebPushTypes).
+func WebPushConfig() models.WebPushConfig {
+	cfg := models.WebPushConfig{
+		PublicKey:  os.Getenv("VAPID_PUBLIC_KEY"),
+		PrivateKey: os.Getenv("VAPID_PRIVATE_KEY"),
+		Subject:    getEnvOrDefault("VAPID_SUBJECT", "mailto:support@solvr.dev"),
+	}
+	for _, t := range strings.Split(os.Getenv("WEB_PUSH_TYPES"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			cfg.Types = append(cfg.Types, t)
+		}
+	}
+	if len(cfg.Types) == 0 {
+		cfg.Types = models.DefaultWebPushTypes
+	}
+	return cfg
+}
+
+// AgentRegistrationConfig reads the friction applied to agent self-registration:
+// AGENT_REGISTRATION_POW_BITS (hashcash difficulty, default 0 = off, capped at
+// models.MaxProofOfWorkBits), AGENT_REGISTRATION_MAX_PER_IP and
+// AGENT_REGISTRATION_WINDOW (default 5 per 1h; 0 turns the cap off) and
+// AGENT_REGISTRATION_REQUIRE_EMAIL (default false). Invalid values fall back to
+// the defaults.
+func AgentRegistrationConfig() models.AgentRegistrationConfig {
+	cfg := models.AgentRegistrationConfig{
+		ProofOfWorkBits: getEnvOrDefaultInt("AGENT_REGISTRATION_POW_BITS", 0),
+		MaxPerIP:        getEnvOrDefaultInt("AGENT_REGISTRATION_MAX_PER_IP", 5),
+		Window:          time.Hour,
+	}
+	if cfg.ProofOfWorkBits < 0 {
+		cfg.ProofOfWorkBits = 0
+	}
+	if cfg.ProofOfWorkBits > models.MaxProofOfWorkBits {
+		cfg.ProofOfWorkBits = models.MaxProofOfWorkBits
+	}
+	if cfg.MaxPerIP < 0 {
+		cfg.MaxPerIP = 5
+	}
+	if d, err := time.ParseDuration(os.Getenv("AGENT_REGISTRATION_WINDOW")); err == nil && d > 0 {
+		cfg.Window = d
+	}
+	cfg.RequireEmail, _ = strconv.ParseBool(os.Getenv("AGENT_REGISTRATION_REQUIRE_EMAIL"))
+	return cfg
+}
+
+// SecurityConfig reads sign-in anomaly detection settings: SECURITY_COUNTRY_HEADER
+// (the proxy header carrying the client's country, e.g. CF-IPCountry; default
+// empty = no country checks), SECURITY_IMPOSSIBLE_TRAVEL_WINDOW (default 2h) and
+// SECURITY_MAX_IPS_PER_KEY (distinct IPs per API key per day, default 10; 0 turns
+// the check off). Invalid values fall back to the defaults.
+func SecurityConfig() models.SecurityConfig {
+	cfg := models.SecurityConfig{
+		CountryHeader:          os.Getenv("SECURITY_COUNTRY_HEADER"),
+		ImpossibleTravelWindow: 2 * time.Hour,
+		MaxIPsPerKey:           getEnvOrDefaultInt("SECURITY_MAX_IPS_PER_KEY", 10),
+	}
+	if d, err := time.ParseDuration(os.Getenv("SECURITY_IMPOSSIBLE_TRAVEL_WINDOW")); err == nil && d > 0 {
+		cfg.ImpossibleTravelWindow = d
+	}
+	if cfg.MaxIPsPerKey < 0 {
+		cfg.MaxIPsPerKey = 10
+	}
+	return cfg
+}
+
+// MaintenanceConfig reads MAINTENANCE_MODE and MAINTENANCE_MESSAGE, the state the
+// read-only maintenance switch starts in. Values in RUNTIME_CONFIG_FILE take
+// precedence over the environment. Invalid MAINTENANCE_MODE values are treated as off.
+func MaintenanceConfig() (enabled bool, message string) {
+	lookup, _ := runtimeLookup()
+	enabled, _ = strconv.ParseBool(lookup("MAINTENANCE_MODE"))
+	return enabled, lookup("MAINTENANCE_MESSAGE")
+}
+
+// BackupStorageConfig reads the bucket cmd/backup and cmd/restore use:
+// BACKUP_S3_ENDPOINT (empty for AWS S3), BACKUP_S3_BUCKET, BACKUP_S3_REGION and
+// BACKUP_S3_ACCESS_KEY_ID/BACKUP_S3_SECRET_ACCESS_KEY. The region and keys fall
+// back to AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY.
+func BackupStorageConfig() models.ObjectStorageConfig {
+	return models.ObjectStorageConfig{
+		Endpoint:        os.Getenv("BACKUP_S3_ENDPOINT"),
+		Region:          getEnvOrDefault("BACKUP_S3_REGION", getEnvOrDefault("AWS_REGION", "us-east-1")),
+		Bucket:          os.Getenv("BACKUP_S3_BUCKET"),
+		AccessKeyID:     getEnvOrDefault("BACKUP_S3_ACCESS_KEY_ID", os.Getenv("AWS_ACCESS_KEY_ID")),
+		SecretAccessKey: getEnvOrDefault("BACKUP_S3_SECRET_ACCESS_KEY", os.Getenv("AWS_SECRET_ACCESS_KEY")),
+	}
+}
+
+// AvatarStorageConfig reads the bucket POST /v1/me/avatar stores avatars in:
+// AVATAR_S3_ENDPOINT (empty for AWS S3), AVATAR_S3_BUCKET (empty disables
+// uploads), AVATAR_S3_REGION, AVATAR_S3_ACCESS_KEY_ID/AVATAR_S3_SECRET_ACCESS_KEY
+// and AVATAR_PUBLIC_URL, the CDN or public bucket URL avatars are served from
+// (default: the bucket URL). The region and keys fall back like BackupStorageConfig.
+func AvatarStorageConfig() models.ObjectStorageConfig {
+	return models.ObjectStorageConfig{
+		Endpoint:        os.Getenv("AVATAR_S3_ENDPOINT"),
+		Region:          getEnvOrDefault("AVATAR_S3_REGION", getEnvOrDefault("AWS_REGION", "us-east-1")),
+		Bucket:          os.Getenv("AVATAR_S3_BUCKET"),
+		AccessKeyID:     getEnvOrDefault("AVATAR_S3_ACCESS_KEY_ID", os.Getenv("AWS_ACCESS_KEY_ID")),
+		SecretAccessKey: getEnvOrDefault("AVATAR_S3_SECRET_ACCESS_KEY", os.Getenv("AWS_SECRET_ACCESS_KEY")),
+		PublicURL:       os.Getenv("AVATAR_PUBLIC_URL"),
+	}
+}
+
+// SearchBackendConfig reads SEARCH_BACKEND (postgres, the default, or
+// opensearch) and, for OpenSearch, OPENSEARCH_URL, OPENSEARCH_INDEX (default
+// solvr) and OPENSEARCH_USERNAME/OPENSEARCH_PASSWORD.
+func SearchBackendConfig() models.SearchBackendConfig {
+	return models.SearchBackendConfig{
+		Backend:            strings.ToLower(getEnvOrDefault("SEARCH_BACKEND", models.SearchBackendPostgres)),
+		OpenSearchURL:      os.Getenv("OPENSEARCH_URL"),
+		OpenSearchIndex:    getEnvOrDefault("OPENSEARCH_INDEX", "solvr"),
+		OpenSearchUsername: os.Getenv("OPENSEARCH_USERNAME"),
+		OpenSearchPassword: os.Getenv("OPENSEARCH_PASSWORD"),
+	}
+}
+
+// CacheConfig reads CACHE_DRIVER (memory or redis; redis when REDIS_URL is set,
+// memory otherwise), REDIS_URL and REDIS_KEY_PREFIX (default "solvr:").
+func CacheConfig() models.CacheConfig {
+	redisURL := os.Getenv("REDIS_URL")
+	driver := models.CacheDriverMemory
+	if redisURL != "" {
+		driver = models.CacheDriverRedis
+	}
+	return models.CacheConfig{
+		Driver:    strings.ToLower(getEnvOrDefault("CACHE_DRIVER", driver)),
+		RedisURL:  redisURL,
+		KeyPrefix: getEnvOrDefault("REDIS_KEY_PREFIX", "solvr:"),
+	}
+}
+
+// HTTP2CleartextEnabled reads HTTP2_CLEARTEXT (default true): whether the API
+// accepts HTTP/2 without TLS (h2c) next to HTTP/1.1. Invalid values keep it on.
+func HTTP2CleartextEnabled() bool {
+	enabled, err := strconv.ParseBool(getEnvOrDefault("HTTP2_CLEARTEXT", "true"))
+	return err != nil || enabled
+}
+
+// Route audit modes selectable with ROUTE_AUDIT.
+const (
+	RouteAuditOff    = "off"
+	RouteAuditWarn   = "warn"
+	RouteAuditStrict = "strict"
+)
+
+// RouteAuditMode reads ROUTE_AUDIT: off, warn (log mismatches between the
+// routes and the OpenAPI spec) or strict (refuse to start on them). Defaults
+// to strict in production and warn elsewhere; unknown values mean warn.
+func RouteAuditMode() string {
+	def := RouteAuditWarn
+	if os.Getenv("APP_ENV") == "production" {
+		def = RouteAuditStrict
+	}
+	switch mode := strings.ToLower(getEnvOrDefault("ROUTE_AUDIT", def)); mode {
+	case RouteAuditOff, RouteAuditWarn, RouteAuditStrict:
+		return mode
+	}
+	return RouteAuditWarn
+}
+
+// MultiTenantEnabled reads MULTI_TENANT. When on, every request is resolved to a
+// tenant (see package tenant) and tenant-owned tables are scoped to it. Invalid
+// values are treated as off.
+func MultiTenantEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("MULTI_TENANT"))
+	return enabled
+}
+
 // IsDevelopment returns true if running in development mode.
 func (c *Config) IsDevelopment() bool {
 	return c.AppEnv == "development"
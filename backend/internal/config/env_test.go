@@ -4,6 +4,9 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 func TestLoad_RequiredVariables(t *testing.T) {
@@ -184,6 +187,28 @@ func TestLoad_DefaultJWTExpiry(t *testing.T) {
 	}
 }
 
+func TestLoad_DatabaseReplica(t *testing.T) {
+	os.Setenv("DATABASE_URL", "postgres://localhost/db")
+	os.Setenv("JWT_SECRET", "test-secret-key-at-least-32-chars")
+	os.Setenv("DATABASE_REPLICA_URL", "postgres://replica/db")
+	os.Unsetenv("DATABASE_REPLICA_MAX_LAG")
+	defer os.Unsetenv("DATABASE_URL")
+	defer os.Unsetenv("JWT_SECRET")
+	defer os.Unsetenv("DATABASE_REPLICA_URL")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.DatabaseReplicaURL != "postgres://replica/db" {
+		t.Errorf("DatabaseReplicaURL = %q, want %q", cfg.DatabaseReplicaURL, "postgres://replica/db")
+	}
+	if cfg.DatabaseReplicaMaxLag != "5s" {
+		t.Errorf("DatabaseReplicaMaxLag = %q, want %q (default)", cfg.DatabaseReplicaMaxLag, "5s")
+	}
+}
+
 func TestLoad_RateLimitDefaults(t *testing.T) {
 	os.Setenv("DATABASE_URL", "postgres://localhost/db")
 	os.Setenv("JWT_SECRET", "test-secret-key-at-least-32-chars")
@@ -516,3 +541,132 @@ func TestLoad_MaxUploadSizeInvalid(t *testing.T) {
 		t.Errorf("MaxUploadSizeBytes = %d, want %d (default on invalid input)", cfg.MaxUploadSizeBytes, want)
 	}
 }
+
+func TestTrendingConfig_EnvOverridesAndFallbacks(t *testing.T) {
+	t.Setenv("TRENDING_VOTE_WEIGHT", "3")
+	t.Setenv("TRENDING_VIEW_WEIGHT", "-1")
+	t.Setenv("TRENDING_HALF_LIFE_HOURS", "0")
+	t.Setenv("TRENDING_WINDOW_DAYS", "14")
+
+	cfg := TrendingConfig()
+	if cfg.VoteWeight != 3 {
+		t.Errorf("VoteWeight = %v, want 3", cfg.VoteWeight)
+	}
+	if cfg.ViewWeight != 0.5 {
+		t.Errorf("ViewWeight = %v, want default 0.5 for a negative value", cfg.ViewWeight)
+	}
+	if cfg.HalfLifeHours != 24 {
+		t.Errorf("HalfLifeHours = %v, want default 24 for 0", cfg.HalfLifeHours)
+	}
+	if cfg.WindowDays != 14 {
+		t.Errorf("WindowDays = %d, want 14", cfg.WindowDays)
+	}
+}
+
+func TestAnswerDuplicateConfig_EnvOverridesAndFallbacks(t *testing.T) {
+	t.Setenv("ANSWER_DUPLICATE_WARN_THRESHOLD", "0.8")
+	t.Setenv("ANSWER_DUPLICATE_REJECT_THRESHOLD", "1.5")
+	cfg := AnswerDuplicateConfig()
+	if cfg.WarnThreshold != 0.8 {
+		t.Errorf("WarnThreshold = %v, want 0.8", cfg.WarnThreshold)
+	}
+	if cfg.RejectThreshold != 0.97 {
+		t.Errorf("RejectThreshold = %v, want default 0.97 for an out-of-range value", cfg.RejectThreshold)
+	}
+
+	t.Setenv("ANSWER_DUPLICATE_REJECT_THRESHOLD", "0.5")
+	if cfg := AnswerDuplicateConfig(); cfg.RejectThreshold != 0.8 {
+		t.Errorf("RejectThreshold = %v, want it raised to the warn threshold", cfg.RejectThreshold)
+	}
+}
+
+func TestRemoderationConfig_EnvOverridesAndFallbacks(t *testing.T) {
+	t.Setenv("REMODERATION_MIN_CHANGED_CHARS", "50")
+	t.Setenv("REMODERATION_HOLD_RATIO", "2")
+	cfg := RemoderationConfig()
+	if cfg.MinChangedChars != 50 {
+		t.Errorf("MinChangedChars = %d, want 50", cfg.MinChangedChars)
+	}
+	if cfg.HoldRatio != 0.5 {
+		t.Errorf("HoldRatio = %v, want default 0.5 for an out-of-range value", cfg.HoldRatio)
+	}
+
+	t.Setenv("REMODERATION_MIN_CHANGED_CHARS", "0")
+	if cfg := RemoderationConfig(); cfg.MinChangedChars != 200 {
+		t.Errorf("MinChangedChars = %d, want default 200 for a non-positive value", cfg.MinChangedChars)
+	}
+}
+
+func TestUsernameChangeConfig_EnvOverridesAndFallbacks(t *testing.T) {
+	t.Setenv("USERNAME_REUSE_HOLD_DAYS", "14")
+	t.Setenv("USERNAME_CHANGE_COOLDOWN_DAYS", "-1")
+	cfg := UsernameChangeConfig()
+	if cfg.ReuseHoldDays != 14 {
+		t.Errorf("ReuseHoldDays = %d, want 14", cfg.ReuseHoldDays)
+	}
+	if cfg.CooldownDays != 30 {
+		t.Errorf("CooldownDays = %d, want default 30 for a negative value", cfg.CooldownDays)
+	}
+}
+
+func TestWebPushConfig(t *testing.T) {
+	t.Setenv("VAPID_PUBLIC_KEY", "")
+	t.Setenv("VAPID_PRIVATE_KEY", "")
+	t.Setenv("WEB_PUSH_TYPES", "")
+	cfg := WebPushConfig()
+	if cfg.Enabled() {
+		t.Error("expected web push disabled without VAPID keys")
+	}
+	if len(cfg.Types) != len(models.DefaultWebPushTypes) {
+		t.Errorf("Types = %v, want defaults", cfg.Types)
+	}
+
+	t.Setenv("VAPID_PUBLIC_KEY", "pub")
+	t.Setenv("VAPID_PRIVATE_KEY", "priv")
+	t.Setenv("WEB_PUSH_TYPES", " answer.created, ,problem.solved")
+	cfg = WebPushConfig()
+	if !cfg.Enabled() {
+		t.Error("expected web push enabled with both VAPID keys")
+	}
+	if len(cfg.Types) != 2 || cfg.Types[0] != "answer.created" || cfg.Types[1] != "problem.solved" {
+		t.Errorf("Types = %v, want [answer.created problem.solved]", cfg.Types)
+	}
+}
+
+func TestAgentRegistrationConfig(t *testing.T) {
+	t.Setenv("AGENT_REGISTRATION_POW_BITS", "")
+	t.Setenv("AGENT_REGISTRATION_MAX_PER_IP", "")
+	t.Setenv("AGENT_REGISTRATION_WINDOW", "")
+	t.Setenv("AGENT_REGISTRATION_REQUIRE_EMAIL", "")
+	cfg := AgentRegistrationConfig()
+	if cfg.ProofOfWorkBits != 0 || cfg.MaxPerIP != 5 || cfg.Window != time.Hour || cfg.RequireEmail {
+		t.Errorf("defaults = %+v, want no proof of work, 5 per hour, no email", cfg)
+	}
+
+	t.Setenv("AGENT_REGISTRATION_POW_BITS", "64")
+	t.Setenv("AGENT_REGISTRATION_MAX_PER_IP", "0")
+	t.Setenv("AGENT_REGISTRATION_WINDOW", "24h")
+	t.Setenv("AGENT_REGISTRATION_REQUIRE_EMAIL", "true")
+	cfg = AgentRegistrationConfig()
+	if cfg.ProofOfWorkBits != models.MaxProofOfWorkBits || cfg.MaxPerIP != 0 || cfg.Window != 24*time.Hour || !cfg.RequireEmail {
+		t.Errorf("overrides = %+v", cfg)
+	}
+}
+
+func TestSecurityConfig(t *testing.T) {
+	t.Setenv("SECURITY_COUNTRY_HEADER", "")
+	t.Setenv("SECURITY_IMPOSSIBLE_TRAVEL_WINDOW", "")
+	t.Setenv("SECURITY_MAX_IPS_PER_KEY", "")
+	cfg := SecurityConfig()
+	if cfg.CountryHeader != "" || cfg.ImpossibleTravelWindow != 2*time.Hour || cfg.MaxIPsPerKey != 10 {
+		t.Errorf("defaults = %+v, want no country header, 2h, 10 IPs", cfg)
+	}
+
+	t.Setenv("SECURITY_COUNTRY_HEADER", "CF-IPCountry")
+	t.Setenv("SECURITY_IMPOSSIBLE_TRAVEL_WINDOW", "90m")
+	t.Setenv("SECURITY_MAX_IPS_PER_KEY", "-1")
+	cfg = SecurityConfig()
+	if cfg.CountryHeader != "CF-IPCountry" || cfg.ImpossibleTravelWindow != 90*time.Minute || cfg.MaxIPsPerKey != 10 {
+		t.Errorf("overrides = %+v", cfg)
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ReloadFunc applies reloaded settings. It returns any changes it made beyond
+// Runtime itself, such as values re-read from the database, so they are logged and
+// reported with the reload.
+type ReloadFunc func(old, next Runtime) []models.ConfigChange
+
+// Reloader holds the current Runtime settings and re-reads them on demand
+// (SIGHUP or POST /v1/admin/config/reload), handing old and new values to every
+// registered ReloadFunc.
+type Reloader struct {
+	reloadMu  sync.Mutex // serializes Reload
+	mu        sync.Mutex // guards current and listeners
+	load      func() (Runtime, error)
+	current   Runtime
+	listeners []*ReloadFunc
+	logger    *slog.Logger
+}
+
+// NewReloader creates a reloader that reads settings with load, starting from
+// initial.
+func NewReloader(initial Runtime, load func() (Runtime, error), logger *slog.Logger) *Reloader {
+	return &Reloader{load: load, current: initial, logger: logger}
+}
+
+var (
+	defaultReloaderOnce sync.Once
+	defaultReloader     *Reloader
+)
+
+// DefaultReloader returns the process-wide reloader, seeded with LoadRuntime.
+// A startup load error is logged and the readable values are kept.
+func DefaultReloader() *Reloader {
+	defaultReloaderOnce.Do(func() {
+		rt, err := LoadRuntime()
+		if err != nil {
+			slog.Default().Warn("runtime config incomplete", "error", err)
+		}
+		defaultReloader = NewReloader(rt, LoadRuntime, slog.Default())
+	})
+	return defaultReloader
+}
+
+// Current returns the settings in effect.
+func (r *Reloader) Current() Runtime {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// OnReload registers fn to run on every successful reload until the returned
+// unregister func is called. Listeners owned by something shorter-lived than the
+// process, such as a router, must unregister when it is closed.
+func (r *Reloader) OnReload(fn ReloadFunc) (unregister func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listener := &fn
+	r.listeners = append(r.listeners, listener)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.listeners = slices.DeleteFunc(r.listeners, func(l *ReloadFunc) bool { return l == listener })
+	}
+}
+
+// Reload re-reads the settings and applies them. If they cannot be read the
+// current settings are kept and the error is returned. Reloads are serialized.
+func (r *Reloader) Reload() (models.ConfigReloadResult, error) {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	next, err := r.load()
+	if err != nil {
+		r.logger.Error("config reload failed, keeping current settings", "error", err)
+		return models.ConfigReloadResult{}, err
+	}
+
+	r.mu.Lock()
+	old := r.current
+	r.current = next
+	listeners := slices.Clone(r.listeners)
+	r.mu.Unlock()
+
+	changes := old.Diff(next)
+	for _, fn := range listeners {
+		changes = append(changes, (*fn)(old, next)...)
+	}
+
+	LogConfigReload(r.logger, changes)
+	return models.ConfigReloadResult{ReloadedAt: time.Now().UTC(), Changes: changes}, nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestReloader_AppliesAndReportsChanges(t *testing.T) {
+	next := Runtime{ModerationModel: "new-model"}
+	r := NewReloader(Runtime{ModerationModel: "old-model"}, func() (Runtime, error) { return next, nil },
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var seenOld, seenNext Runtime
+	r.OnReload(func(old, next Runtime) []models.ConfigChange {
+		seenOld, seenNext = old, next
+		return []models.ConfigChange{{Key: "rate_limit_config.agent_general_limit", Old: "60", New: "90"}}
+	})
+
+	result, err := r.Reload()
+	if err != nil {
+		t.Fatalf("Reload() error: %v", err)
+	}
+	if seenOld.ModerationModel != "old-model" || seenNext.ModerationModel != "new-model" {
+		t.Errorf("listener got old=%q next=%q", seenOld.ModerationModel, seenNext.ModerationModel)
+	}
+	if r.Current().ModerationModel != "new-model" {
+		t.Errorf("Current() = %q, want new-model", r.Current().ModerationModel)
+	}
+	if len(result.Changes) != 2 || result.Changes[0].Key != "GROQ_MODEL" || result.Changes[1].New != "90" {
+		t.Errorf("unexpected changes %+v", result.Changes)
+	}
+	if result.ReloadedAt.IsZero() {
+		t.Error("expected ReloadedAt to be set")
+	}
+}
+
+func TestReloader_KeepsSettingsOnError(t *testing.T) {
+	r := NewReloader(Runtime{ModerationModel: "old-model"}, func() (Runtime, error) {
+		return Runtime{}, errors.New("bad file")
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	called := false
+	r.OnReload(func(old, next Runtime) []models.ConfigChange {
+		called = true
+		return nil
+	})
+
+	if _, err := r.Reload(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if called {
+		t.Error("expected listeners not to run on a failed reload")
+	}
+	if r.Current().ModerationModel != "old-model" {
+		t.Errorf("Current() = %q, want old-model", r.Current().ModerationModel)
+	}
+}
+
+func TestReloader_UnregisteredListenerStopsRunning(t *testing.T) {
+	r := NewReloader(Runtime{}, func() (Runtime, error) { return Runtime{}, nil },
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var first, second int
+	unregister := r.OnReload(func(old, next Runtime) []models.ConfigChange {
+		first++
+		return nil
+	})
+	r.OnReload(func(old, next Runtime) []models.ConfigChange {
+		second++
+		return nil
+	})
+
+	_, _ = r.Reload()
+	unregister()
+	unregister()
+	_, _ = r.Reload()
+
+	if first != 1 || second != 2 {
+		t.Errorf("listener calls = %d, %d; want 1, 2", first, second)
+	}
+}
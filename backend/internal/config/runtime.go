@@ -0,0 +1,293 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+)
+
+// Runtime holds the settings that can be reloaded without restarting the server.
+// Everything else in Config is read once at startup.
+type Runtime struct {
+	// ModerationModel is the Groq model used for content moderation (GROQ_MODEL).
+	// Empty uses the service default.
+	ModerationModel string
+
+	// JobIntervals overrides background job intervals by job name (JOB_INTERVALS,
+	// e.g. "trending=30m,stats_snapshot=2h"). Jobs not listed use their default.
+	JobIntervals map[string]time.Duration
+
+	// MaintenanceMode and MaintenanceMessage seed the read-only maintenance switch
+	// (MAINTENANCE_MODE, MAINTENANCE_MESSAGE).
+	MaintenanceMode    bool
+	MaintenanceMessage string
+
+	// AnswerQualityEnabled and KnowledgeGapReportEnabled switch the Groq-backed
+	// answer quality and knowledge gap report jobs on and off
+	// (ANSWER_QUALITY_ENABLED, KNOWLEDGE_GAP_REPORT_ENABLED).
+	AnswerQualityEnabled      bool
+	KnowledgeGapReportEnabled bool
+
+	// PrivilegeThresholds is the reputation each privilege needs
+	// (PRIVILEGE_THRESHOLDS, e.g. "comment=50,vote_down=500,edit_tags=2000").
+	// Privileges not listed use their default.
+	PrivilegeThresholds reputation.Thresholds
+
+	// ContentLimits are the post field length limits (CONTENT_LIMITS, e.g.
+	// "title=10-200,description=50-,problem.title=20-150"). A field without a
+	// type prefix sets every type; typed entries override it. Unset fields use
+	// their default.
+	ContentLimits models.ContentLimits
+}
+
+// LoadRuntime reads the reloadable settings from the environment. When
+// RUNTIME_CONFIG_FILE names a KEY=VALUE file, its values take precedence, so
+// settings can be changed on a running server by editing that file and reloading.
+// On error the returned Runtime still holds every value that could be read.
+func LoadRuntime() (Runtime, error) {
+	lookup, err := runtimeLookup()
+
+	rt := Runtime{
+		ModerationModel:    lookup("GROQ_MODEL"),
+		MaintenanceMessage: lookup("MAINTENANCE_MESSAGE"),
+	}
+	if v := lookup("MAINTENANCE_MODE"); v != "" {
+		enabled, parseErr := strconv.ParseBool(v)
+		if parseErr != nil && err == nil {
+			err = fmt.Errorf("invalid MAINTENANCE_MODE %q", v)
+		}
+		rt.MaintenanceMode = enabled
+	}
+	for key, flag := range map[string]*bool{
+		"ANSWER_QUALITY_ENABLED":       &rt.AnswerQualityEnabled,
+		"KNOWLEDGE_GAP_REPORT_ENABLED": &rt.KnowledgeGapReportEnabled,
+	} {
+		if v := lookup(key); v != "" {
+			enabled, parseErr := strconv.ParseBool(v)
+			if parseErr != nil && err == nil {
+				err = fmt.Errorf("invalid %s %q", key, v)
+			}
+			*flag = enabled
+		}
+	}
+	intervals, parseErr := parseJobIntervals(lookup("JOB_INTERVALS"))
+	if parseErr != nil && err == nil {
+		err = parseErr
+	}
+	rt.JobIntervals = intervals
+	thresholds, parseErr := reputation.ParseThresholds(lookup("PRIVILEGE_THRESHOLDS"))
+	if parseErr != nil && err == nil {
+		err = parseErr
+	}
+	rt.PrivilegeThresholds = thresholds
+	limits, parseErr := parseContentLimits(lookup("CONTENT_LIMITS"))
+	if parseErr != nil && err == nil {
+		err = parseErr
+	}
+	rt.ContentLimits = limits
+
+	return rt, err
+}
+
+// JobInterval returns the configured interval for the named job, or def.
+func (r Runtime) JobInterval(name string, def time.Duration) time.Duration {
+	if d, ok := r.JobIntervals[name]; ok {
+		return d
+	}
+	return def
+}
+
+// Diff lists the settings that differ between r and next, sorted by key.
+func (r Runtime) Diff(next Runtime) []models.ConfigChange {
+	changes := []models.ConfigChange{}
+	add := func(key, old, new string) {
+		if old != new {
+			changes = append(changes, models.ConfigChange{Key: key, Old: old, New: new})
+		}
+	}
+
+	add("GROQ_MODEL", r.ModerationModel, next.ModerationModel)
+	add("MAINTENANCE_MODE", strconv.FormatBool(r.MaintenanceMode), strconv.FormatBool(next.MaintenanceMode))
+	add("MAINTENANCE_MESSAGE", r.MaintenanceMessage, next.MaintenanceMessage)
+	add("ANSWER_QUALITY_ENABLED", strconv.FormatBool(r.AnswerQualityEnabled), strconv.FormatBool(next.AnswerQualityEnabled))
+	add("KNOWLEDGE_GAP_REPORT_ENABLED", strconv.FormatBool(r.KnowledgeGapReportEnabled), strconv.FormatBool(next.KnowledgeGapReportEnabled))
+
+	names := map[string]bool{}
+	for name := range r.JobIntervals {
+		names[name] = true
+	}
+	for name := range next.JobIntervals {
+		names[name] = true
+	}
+	for name := range names {
+		add("JOB_INTERVALS."+name, formatInterval(r.JobIntervals[name]), formatInterval(next.JobIntervals[name]))
+	}
+	for _, p := range reputation.Privileges {
+		add("PRIVILEGE_THRESHOLDS."+string(p), strconv.Itoa(r.PrivilegeThresholds.Required(p)), strconv.Itoa(next.PrivilegeThresholds.Required(p)))
+	}
+	for _, t := range models.ValidPostTypes() {
+		oldLimits, newLimits := r.ContentLimits.For(t), next.ContentLimits.For(t)
+		for _, field := range models.ContentLimitFields {
+			o, _ := oldLimits.Field(field)
+			n, _ := newLimits.Field(field)
+			add("CONTENT_LIMITS."+string(t)+"."+field, o.String(), n.String())
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// formatInterval renders a job interval for a diff; zero means "default".
+func formatInterval(d time.Duration) string {
+	if d == 0 {
+		return "default"
+	}
+	return d.String()
+}
+
+// parseJobIntervals parses "name=duration,..." into a map. Non-positive or
+// unparseable durations are an error.
+func parseJobIntervals(spec string) (map[string]time.Duration, error) {
+	intervals := map[string]time.Duration{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return intervals, fmt.Errorf("invalid JOB_INTERVALS entry %q: want name=duration", part)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return intervals, fmt.Errorf("invalid JOB_INTERVALS duration for %s: %q", name, value)
+		}
+		intervals[name] = d
+	}
+	return intervals, nil
+}
+
+// parseContentLimits parses CONTENT_LIMITS, "[type.]field=min-max,...", over
+// the defaults. An empty max means no upper limit. Untyped entries apply first,
+// so typed ones override them wherever they appear.
+func parseContentLimits(spec string) (models.ContentLimits, error) {
+	limits := models.DefaultContentLimits()
+	var typed []string
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if key, _, _ := strings.Cut(part, "="); strings.Contains(key, ".") {
+			typed = append(typed, part)
+			continue
+		}
+		if err := applyContentLimit(limits, part); err != nil {
+			return limits, err
+		}
+	}
+	for _, part := range typed {
+		if err := applyContentLimit(limits, part); err != nil {
+			return limits, err
+		}
+	}
+	return limits, nil
+}
+
+// applyContentLimit applies one CONTENT_LIMITS entry to limits.
+func applyContentLimit(limits models.ContentLimits, part string) error {
+	key, value, ok := strings.Cut(part, "=")
+	if !ok {
+		return fmt.Errorf("invalid CONTENT_LIMITS entry %q: want [type.]field=min-max", part)
+	}
+	key = strings.TrimSpace(key)
+	types := models.ValidPostTypes()
+	field := key
+	if typeName, name, typed := strings.Cut(key, "."); typed {
+		if !models.IsValidPostType(models.PostType(typeName)) {
+			return fmt.Errorf("unknown post type %q in CONTENT_LIMITS", typeName)
+		}
+		types, field = []models.PostType{models.PostType(typeName)}, name
+	}
+
+	minValue, maxValue, ok := strings.Cut(strings.TrimSpace(value), "-")
+	limit := models.LengthLimit{}
+	var minErr, maxErr error
+	limit.Min, minErr = strconv.Atoi(strings.TrimSpace(minValue))
+	if maxValue = strings.TrimSpace(maxValue); maxValue != "" {
+		limit.Max, maxErr = strconv.Atoi(maxValue)
+	}
+	if !ok || minErr != nil || maxErr != nil || limit.Min < 0 || limit.Max < 0 || (limit.Max != 0 && limit.Max < limit.Min) {
+		return fmt.Errorf("invalid CONTENT_LIMITS range for %s: %q", key, value)
+	}
+
+	for _, t := range types {
+		l := limits[t]
+		dst, known := l.Field(field)
+		if !known {
+			return fmt.Errorf("unknown field %q in CONTENT_LIMITS", field)
+		}
+		*dst = limit
+		limits[t] = l
+	}
+	return nil
+}
+
+// runtimeLookup returns a lookup that prefers RUNTIME_CONFIG_FILE values over the
+// process environment. When the file cannot be read the lookup falls back to the
+// environment and the error is returned alongside it.
+func runtimeLookup() (func(key string) string, error) {
+	path := os.Getenv("RUNTIME_CONFIG_FILE")
+	if path == "" {
+		return os.Getenv, nil
+	}
+	values, err := readEnvFile(path)
+	if err != nil {
+		return os.Getenv, fmt.Errorf("read RUNTIME_CONFIG_FILE: %w", err)
+	}
+	return func(key string) string {
+		if v, ok := values[key]; ok {
+			return v
+		}
+		return os.Getenv(key)
+	}, nil
+}
+
+// readEnvFile parses a .env-style file: KEY=VALUE lines, blank lines and #
+// comments, an optional "export " prefix and optional surrounding quotes.
+func readEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s:%d: want KEY=VALUE", path, n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
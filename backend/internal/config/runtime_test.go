@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+)
+
+func TestLoadRuntime_FileOverridesEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runtime.env")
+	content := "# tunables\nexport GROQ_MODEL=\"file-model\"\nJOB_INTERVALS=trending=30m, stats_snapshot=2h\n\nMAINTENANCE_MODE=true\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("RUNTIME_CONFIG_FILE", path)
+	t.Setenv("GROQ_MODEL", "env-model")
+	t.Setenv("MAINTENANCE_MESSAGE", "env message")
+
+	rt, err := LoadRuntime()
+	if err != nil {
+		t.Fatalf("LoadRuntime() error: %v", err)
+	}
+	if rt.ModerationModel != "file-model" {
+		t.Errorf("ModerationModel = %q, want file-model", rt.ModerationModel)
+	}
+	if !rt.MaintenanceMode || rt.MaintenanceMessage != "env message" {
+		t.Errorf("expected maintenance on with env message, got %v %q", rt.MaintenanceMode, rt.MaintenanceMessage)
+	}
+	if got := rt.JobInterval("trending", time.Hour); got != 30*time.Minute {
+		t.Errorf("trending interval = %s, want 30m", got)
+	}
+	if got := rt.JobInterval("cleanup", time.Hour); got != time.Hour {
+		t.Errorf("cleanup interval = %s, want default 1h", got)
+	}
+}
+
+func TestLoadRuntime_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"missing file", map[string]string{"RUNTIME_CONFIG_FILE": filepath.Join(t.TempDir(), "missing.env")}},
+		{"bad interval", map[string]string{"JOB_INTERVALS": "trending=soon"}},
+		{"negative interval", map[string]string{"JOB_INTERVALS": "trending=-1m"}},
+		{"entry without name", map[string]string{"JOB_INTERVALS": "=5m"}},
+		{"bad maintenance flag", map[string]string{"MAINTENANCE_MODE": "maybe"}},
+		{"bad feature flag", map[string]string{"ANSWER_QUALITY_ENABLED": "sometimes"}},
+		{"unknown privilege", map[string]string{"PRIVILEGE_THRESHOLDS": "fly=10"}},
+		{"unknown limit field", map[string]string{"CONTENT_LIMITS": "body=1-10"}},
+		{"unknown limit type", map[string]string{"CONTENT_LIMITS": "poem.title=1-10"}},
+		{"inverted limit", map[string]string{"CONTENT_LIMITS": "title=50-10"}},
+		{"limit without range", map[string]string{"CONTENT_LIMITS": "title=50"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RUNTIME_CONFIG_FILE", "")
+			t.Setenv("JOB_INTERVALS", "")
+			t.Setenv("MAINTENANCE_MODE", "")
+			t.Setenv("ANSWER_QUALITY_ENABLED", "")
+			t.Setenv("PRIVILEGE_THRESHOLDS", "")
+			t.Setenv("CONTENT_LIMITS", "")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			if _, err := LoadRuntime(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseContentLimits(t *testing.T) {
+	// The typed entry wins even though the untyped one comes after it
+	limits, err := parseContentLimits("problem.title=20-150, title=5-100, description=30-")
+	if err != nil {
+		t.Fatalf("parseContentLimits() error: %v", err)
+	}
+	if got := limits.For(models.PostTypeProblem).Title; got != (models.LengthLimit{Min: 20, Max: 150}) {
+		t.Errorf("problem title = %+v, want 20-150", got)
+	}
+	if got := limits.For(models.PostTypeIdea).Title; got != (models.LengthLimit{Min: 5, Max: 100}) {
+		t.Errorf("idea title = %+v, want 5-100", got)
+	}
+	if got := limits.For(models.PostTypeQuestion).Description; got != (models.LengthLimit{Min: 30}) {
+		t.Errorf("question description = %+v, want 30 with no max", got)
+	}
+	if got, want := limits.For(models.PostTypeProblem).SuccessCriterion, models.DefaultContentLimits().For(models.PostTypeProblem).SuccessCriterion; got != want {
+		t.Errorf("success criterion = %+v, want default %+v", got, want)
+	}
+}
+
+func TestRuntime_Diff(t *testing.T) {
+	old := Runtime{ModerationModel: "a", JobIntervals: map[string]time.Duration{"trending": time.Hour}}
+	next := Runtime{
+		ModerationModel:      "b",
+		MaintenanceMode:      true,
+		AnswerQualityEnabled: true,
+		JobIntervals:         map[string]time.Duration{"trending": time.Hour, "cleanup": 5 * time.Minute},
+		PrivilegeThresholds:  reputation.Thresholds{reputation.PrivilegeVoteDown: 250},
+	}
+
+	changes := old.Diff(next)
+
+	want := []string{"ANSWER_QUALITY_ENABLED", "GROQ_MODEL", "JOB_INTERVALS.cleanup", "MAINTENANCE_MODE", "PRIVILEGE_THRESHOLDS.vote_down"}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i, key := range want {
+		if changes[i].Key != key {
+			t.Errorf("change %d key = %q, want %q", i, changes[i].Key, key)
+		}
+	}
+	if changes[2].Old != "default" || changes[2].New != "5m0s" {
+		t.Errorf("unexpected interval change %+v", changes[2])
+	}
+	if len(next.Diff(next)) != 0 {
+		t.Error("expected no changes against itself")
+	}
+}
@@ -3,6 +3,8 @@ package config
 
 import (
 	"log/slog"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 // LogStartupConfig logs the server configuration at startup.
@@ -101,3 +103,16 @@ func LogStartupConfig(logger *slog.Logger, cfg *Config, dbConnected bool) {
 		)
 	}
 }
+
+// LogConfigReload logs the settings changed by a runtime config reload.
+// Like LogStartupConfig, it is only given non-secret values.
+func LogConfigReload(logger *slog.Logger, changes []models.ConfigChange) {
+	if len(changes) == 0 {
+		logger.Info("Config reloaded", "changes", 0)
+		return
+	}
+	for _, c := range changes {
+		logger.Info("Config changed", "key", c.Key, "old", c.Old, "new", c.New)
+	}
+	logger.Info("Config reloaded", "changes", len(changes))
+}
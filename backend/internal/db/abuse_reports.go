@@ -0,0 +1,227 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrAbuseReportNotFound is returned when an abuse report doesn't exist.
+var ErrAbuseReportNotFound = errors.New("abuse report not found")
+
+// AbuseReportRepository detects suspicious voting patterns and stores them for admin review.
+type AbuseReportRepository struct {
+	pool *Pool
+}
+
+// NewAbuseReportRepository creates a new AbuseReportRepository.
+func NewAbuseReportRepository(pool *Pool) *AbuseReportRepository {
+	return &AbuseReportRepository{pool: pool}
+}
+
+// insertAbuseReportConflict skips patterns that already have a pending report.
+const insertAbuseReportConflict = `
+	ON CONFLICT (kind, subject_type, subject_id, related_type, related_id)
+	WHERE status = 'pending' DO NOTHING
+`
+
+// DetectVoteRings flags pairs of accounts that have each upvoted the other's
+// posts and answers at least minMutual times since the given time.
+// Each pair is reported once, with the lexically smaller account as subject.
+// Returns the number of new reports.
+func (r *AbuseReportRepository) DetectVoteRings(ctx context.Context, since time.Time, minMutual int) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		WITH upvotes AS (
+			SELECT v.voter_type, v.voter_id, p.posted_by_type AS author_type, p.posted_by_id AS author_id
+			FROM votes v
+			JOIN posts p ON p.id = v.target_id
+			WHERE v.target_type = 'post' AND v.direction = 'up' AND v.created_at >= $1
+			UNION ALL
+			SELECT v.voter_type, v.voter_id, a.author_type, a.author_id
+			FROM votes v
+			JOIN answers a ON a.id = v.target_id
+			WHERE v.target_type = 'answer' AND v.direction = 'up' AND v.created_at >= $1
+		), pairs AS (
+			SELECT voter_type, voter_id, author_type, author_id, COUNT(*) AS cnt
+			FROM upvotes
+			WHERE NOT (voter_type = author_type AND voter_id = author_id)
+			GROUP BY voter_type, voter_id, author_type, author_id
+		)
+		INSERT INTO abuse_reports (kind, subject_type, subject_id, related_type, related_id, details)
+		SELECT 'vote_ring', a.voter_type, a.voter_id, a.author_type, a.author_id,
+			jsonb_build_object('upvotes_given', a.cnt, 'upvotes_received', b.cnt, 'since', $1::timestamptz)
+		FROM pairs a
+		JOIN pairs b ON b.voter_type = a.author_type AND b.voter_id = a.author_id
+			AND b.author_type = a.voter_type AND b.author_id = a.voter_id
+		WHERE a.cnt >= $2 AND b.cnt >= $2
+			AND a.voter_type || ':' || a.voter_id < a.author_type || ':' || a.author_id
+	`+insertAbuseReportConflict, since, minMutual)
+	if err != nil {
+		LogQueryError(ctx, "DetectVoteRings", "abuse_reports", err)
+		return 0, fmt.Errorf("detect vote rings: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DetectTargetedUpvoting flags accounts that have upvoted at least minShare
+// (0-1) of another author's live posts and answers, for authors with at
+// least minContent items. Returns the number of new reports.
+func (r *AbuseReportRepository) DetectTargetedUpvoting(ctx context.Context, minContent int, minShare float64) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		WITH content AS (
+			SELECT 'post' AS target_type, id, posted_by_type AS author_type, posted_by_id AS author_id
+			FROM posts WHERE deleted_at IS NULL
+			UNION ALL
+			SELECT 'answer', id, author_type, author_id
+			FROM answers WHERE deleted_at IS NULL
+		), totals AS (
+			SELECT author_type, author_id, COUNT(*) AS total
+			FROM content
+			GROUP BY author_type, author_id
+			HAVING COUNT(*) >= $1
+		), upvoted AS (
+			SELECT v.voter_type, v.voter_id, c.author_type, c.author_id, COUNT(*) AS cnt
+			FROM votes v
+			JOIN content c ON c.target_type = v.target_type AND c.id = v.target_id
+			WHERE v.direction = 'up'
+				AND NOT (v.voter_type = c.author_type AND v.voter_id = c.author_id)
+			GROUP BY v.voter_type, v.voter_id, c.author_type, c.author_id
+		)
+		INSERT INTO abuse_reports (kind, subject_type, subject_id, related_type, related_id, details)
+		SELECT 'targeted_upvoting', u.voter_type, u.voter_id, u.author_type, u.author_id,
+			jsonb_build_object('upvoted', u.cnt, 'author_content', t.total,
+				'share', ROUND(u.cnt::numeric / t.total, 2))
+		FROM upvoted u
+		JOIN totals t ON t.author_type = u.author_type AND t.author_id = u.author_id
+		WHERE u.cnt::float8 / t.total >= $2
+	`+insertAbuseReportConflict, minContent, minShare)
+	if err != nil {
+		LogQueryError(ctx, "DetectTargetedUpvoting", "abuse_reports", err)
+		return 0, fmt.Errorf("detect targeted upvoting: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DetectNewAccountBursts flags accounts created after accountCreatedAfter that
+// cast or changed at least minPerHour votes within a single clock hour since
+// the given time. Returns the number of new reports.
+func (r *AbuseReportRepository) DetectNewAccountBursts(ctx context.Context, since, accountCreatedAfter time.Time, minPerHour int) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		WITH hourly AS (
+			SELECT voter_type, voter_id, date_trunc('hour', created_at) AS hour, COUNT(*) AS cnt
+			FROM vote_events
+			WHERE action <> 'retract' AND created_at >= $1
+			GROUP BY voter_type, voter_id, date_trunc('hour', created_at)
+			HAVING COUNT(*) >= $3
+		), peaks AS (
+			SELECT DISTINCT ON (voter_type, voter_id) voter_type, voter_id, hour, cnt
+			FROM hourly
+			ORDER BY voter_type, voter_id, cnt DESC
+		), accounts AS (
+			SELECT 'human' AS account_type, id::text AS account_id, created_at FROM users
+			UNION ALL
+			SELECT 'agent', id, created_at FROM agents
+		)
+		INSERT INTO abuse_reports (kind, subject_type, subject_id, details)
+		SELECT 'new_account_burst', p.voter_type, p.voter_id,
+			jsonb_build_object('votes_in_hour', p.cnt, 'hour', p.hour, 'account_created_at', a.created_at)
+		FROM peaks p
+		JOIN accounts a ON a.account_type = p.voter_type AND a.account_id = p.voter_id
+		WHERE a.created_at >= $2
+	`+insertAbuseReportConflict, since, accountCreatedAfter, minPerHour)
+	if err != nil {
+		LogQueryError(ctx, "DetectNewAccountBursts", "abuse_reports", err)
+		return 0, fmt.Errorf("detect new account bursts: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+const abuseReportColumns = `id, kind, subject_type, subject_id, related_type, related_id,
+	details, status, created_at, reviewed_at, COALESCE(review_note, '')`
+
+// List returns abuse reports, newest first. An empty status returns all statuses.
+func (r *AbuseReportRepository) List(ctx context.Context, status string, page, perPage int) ([]models.AbuseReport, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > 100 {
+		perPage = 20
+	}
+	offset := (page - 1) * perPage
+
+	var total int
+	err := r.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM abuse_reports WHERE ($1 = '' OR status = $1)`,
+		status,
+	).Scan(&total)
+	if err != nil {
+		LogQueryError(ctx, "List.Count", "abuse_reports", err)
+		return nil, 0, fmt.Errorf("count abuse reports: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+abuseReportColumns+`
+		FROM abuse_reports
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`, status, perPage, offset)
+	if err != nil {
+		LogQueryError(ctx, "List", "abuse_reports", err)
+		return nil, 0, fmt.Errorf("list abuse reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := make([]models.AbuseReport, 0)
+	for rows.Next() {
+		report, err := scanAbuseReport(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan abuse report: %w", err)
+		}
+		reports = append(reports, *report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate abuse reports: %w", err)
+	}
+	return reports, total, nil
+}
+
+// Review records an admin decision on a report.
+// Returns ErrAbuseReportNotFound if the report doesn't exist.
+func (r *AbuseReportRepository) Review(ctx context.Context, id string, status models.AbuseReportStatus, note string) (*models.AbuseReport, error) {
+	row := r.pool.QueryRow(ctx, `
+		UPDATE abuse_reports
+		SET status = $2, review_note = NULLIF($3, ''), reviewed_at = NOW()
+		WHERE id = $1
+		RETURNING `+abuseReportColumns,
+		id, string(status), note,
+	)
+	report, err := scanAbuseReport(row)
+	if errors.Is(err, pgx.ErrNoRows) || (err != nil && isInvalidUUIDError(err)) {
+		return nil, ErrAbuseReportNotFound
+	}
+	if err != nil {
+		LogQueryError(ctx, "Review", "abuse_reports", err)
+		return nil, fmt.Errorf("review abuse report: %w", err)
+	}
+	return report, nil
+}
+
+func scanAbuseReport(row pgx.Row) (*models.AbuseReport, error) {
+	var report models.AbuseReport
+	var details []byte
+	err := row.Scan(
+		&report.ID, &report.Kind, &report.SubjectType, &report.SubjectID,
+		&report.RelatedType, &report.RelatedID, &details, &report.Status,
+		&report.CreatedAt, &report.ReviewedAt, &report.ReviewNote,
+	)
+	if err != nil {
+		return nil, err
+	}
+	report.Details = details
+	return &report, nil
+}
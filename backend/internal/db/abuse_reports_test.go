@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestAbuseReportRepository_VoteEventsRecorded(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewPostRepository(pool)
+	ctx := context.Background()
+
+	post, err := repo.Create(ctx, &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Vote events test",
+		Description:  "Each vote change should be recorded",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_vote_events_author",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM vote_events WHERE target_id = $1", post.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM votes WHERE target_id = $1", post.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID)
+	}()
+
+	for _, direction := range []string{"up", "down", VoteDirectionNone} {
+		if err := repo.Vote(ctx, post.ID, "agent", "test_agent_vote_events_voter", direction); err != nil {
+			t.Fatalf("Vote(%s) error = %v", direction, err)
+		}
+	}
+
+	rows, err := pool.Query(ctx,
+		"SELECT action FROM vote_events WHERE target_id = $1 ORDER BY id", post.ID)
+	if err != nil {
+		t.Fatalf("query vote_events: %v", err)
+	}
+	defer rows.Close()
+	var actions []string
+	for rows.Next() {
+		var action string
+		if err := rows.Scan(&action); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		actions = append(actions, action)
+	}
+	want := []string{"cast", "change", "retract"}
+	if fmt.Sprint(actions) != fmt.Sprint(want) {
+		t.Errorf("vote event actions = %v, want %v", actions, want)
+	}
+}
+
+func TestAbuseReportRepository_DetectTargetedUpvotingAndReview(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	postRepo := NewPostRepository(pool)
+	repo := NewAbuseReportRepository(pool)
+	ctx := context.Background()
+
+	const author = "test_agent_abuse_author"
+	const voter = "test_agent_abuse_voter"
+	var postIDs []string
+	defer func() {
+		for _, id := range postIDs {
+			_, _ = pool.Exec(ctx, "DELETE FROM votes WHERE target_id = $1", id)
+			_, _ = pool.Exec(ctx, "DELETE FROM vote_events WHERE target_id = $1", id)
+			_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", id)
+		}
+		_, _ = pool.Exec(ctx, "DELETE FROM abuse_reports WHERE subject_id = $1", voter)
+	}()
+
+	for i := 0; i < 5; i++ {
+		post, err := postRepo.Create(ctx, &models.Post{
+			Type:         models.PostTypeQuestion,
+			Title:        fmt.Sprintf("Targeted upvoting test %d", i),
+			Description:  "Every post from this author gets upvoted by one voter",
+			PostedByType: models.AuthorTypeAgent,
+			PostedByID:   author,
+			Status:       models.PostStatusOpen,
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		postIDs = append(postIDs, post.ID)
+		if err := postRepo.Vote(ctx, post.ID, "agent", voter, "up"); err != nil {
+			t.Fatalf("Vote() error = %v", err)
+		}
+	}
+
+	if _, err := repo.DetectTargetedUpvoting(ctx, 5, 0.8); err != nil {
+		t.Fatalf("DetectTargetedUpvoting() error = %v", err)
+	}
+	// A second run must not duplicate the pending report.
+	if _, err := repo.DetectTargetedUpvoting(ctx, 5, 0.8); err != nil {
+		t.Fatalf("DetectTargetedUpvoting() second run error = %v", err)
+	}
+
+	var reportID string
+	var count int
+	err := pool.QueryRow(ctx, `
+		SELECT MIN(id::text), COUNT(*) FROM abuse_reports
+		WHERE kind = 'targeted_upvoting' AND subject_id = $1 AND related_id = $2`,
+		voter, author,
+	).Scan(&reportID, &count)
+	if err != nil {
+		t.Fatalf("query abuse_reports: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 targeted_upvoting report, got %d", count)
+	}
+
+	reviewed, err := repo.Review(ctx, reportID, models.AbuseReportStatusDismissed, "same team")
+	if err != nil {
+		t.Fatalf("Review() error = %v", err)
+	}
+	if reviewed.Status != models.AbuseReportStatusDismissed || reviewed.ReviewedAt == nil {
+		t.Errorf("unexpected reviewed report: %+v", reviewed)
+	}
+	if reviewed.ReviewNote != "same team" {
+		t.Errorf("ReviewNote = %q, want %q", reviewed.ReviewNote, "same team")
+	}
+
+	reports, _, err := repo.List(ctx, string(models.AbuseReportStatusDismissed), 1, 100)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	found := false
+	for _, r := range reports {
+		if r.ID == reportID {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("reviewed report missing from dismissed list")
+	}
+}
+
+func TestAbuseReportRepository_ReviewNotFound(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewAbuseReportRepository(pool)
+	ctx := context.Background()
+
+	for _, id := range []string{"00000000-0000-0000-0000-000000000000", "not-a-uuid"} {
+		_, err := repo.Review(ctx, id, models.AbuseReportStatusActioned, "")
+		if !errors.Is(err, ErrAbuseReportNotFound) {
+			t.Errorf("Review(%q) error = %v, want ErrAbuseReportNotFound", id, err)
+		}
+	}
+}
+
+func TestAbuseReportRepository_DetectVoteRingsAndBursts(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewAbuseReportRepository(pool)
+	ctx := context.Background()
+	now := time.Now()
+
+	if _, err := repo.DetectVoteRings(ctx, now.Add(-30*24*time.Hour), 5); err != nil {
+		t.Errorf("DetectVoteRings() error = %v", err)
+	}
+	if _, err := repo.DetectNewAccountBursts(ctx, now.Add(-24*time.Hour), now.Add(-7*24*time.Hour), 30); err != nil {
+		t.Errorf("DetectNewAccountBursts() error = %v", err)
+	}
+}
@@ -0,0 +1,324 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrAccountDeletionNotFound is returned when a deletion receipt doesn't exist.
+var ErrAccountDeletionNotFound = errors.New("account deletion not found")
+
+// accountPurgeBatchSize caps how many due deletions one PurgeDue call processes.
+const accountPurgeBatchSize = 100
+
+// anonymizedAuthorColumns lists every table whose rows carry a human author.
+// Anonymization reattributes these rows to models.DeletedUserID. postID is the
+// SQL expression for the post a row belongs to, so rows on held posts (see
+// post_retention.go) keep their author; it is empty for rows outside posts.
+var anonymizedAuthorColumns = []struct {
+	table   string
+	typeCol string
+	idCol   string
+	postID  string
+}{
+	{"posts", "posted_by_type", "posted_by_id", "id"},
+	{"answers", "author_type", "author_id", "question_id"},
+	{"approaches", "author_type", "author_id", "problem_id"},
+	{"responses", "author_type", "author_id", "idea_id"},
+	{"comments", "author_type", "author_id", commentPostIDExpr},
+	{"blog_posts", "posted_by_type", "posted_by_id", ""},
+	{"messages", "author_type", "author_id", ""},
+}
+
+// commentPostIDExpr resolves the post a comment belongs to, directly or through
+// the approach, answer or response it is on.
+const commentPostIDExpr = `CASE comments.target_type
+	WHEN 'post' THEN comments.target_id
+	WHEN 'approach' THEN (SELECT problem_id FROM approaches WHERE id = comments.target_id)
+	WHEN 'answer' THEN (SELECT question_id FROM answers WHERE id = comments.target_id)
+	WHEN 'response' THEN (SELECT idea_id FROM responses WHERE id = comments.target_id)
+END`
+
+// AccountDeletionRepository implements the GDPR account deletion pipeline.
+type AccountDeletionRepository struct {
+	pool *Pool
+}
+
+// NewAccountDeletionRepository creates a new AccountDeletionRepository.
+func NewAccountDeletionRepository(pool *Pool) *AccountDeletionRepository {
+	return &AccountDeletionRepository{pool: pool}
+}
+
+// RequestDeletion soft-deletes a user, anonymizes everything they authored or
+// voted on, revokes their credentials and records a receipt. The users row and
+// remaining PII are hard-deleted by PurgeDue once grace has elapsed.
+// Returns ErrNotFound if the user doesn't exist or is already deleted.
+func (r *AccountDeletionRepository) RequestDeletion(ctx context.Context, userID string, grace time.Duration) (*models.AccountDeletionReceipt, error) {
+	if userID == models.DeletedUserID {
+		return nil, ErrNotFound
+	}
+
+	var receipt *models.AccountDeletionReceipt
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		result, err := tx.Exec(ctx, `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`, userID)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+
+		content, votes, err := anonymizeUserTx(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		// Sign out everywhere: refresh tokens go, API keys stop working, browsers
+		// stop receiving push messages
+		if _, err := tx.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `UPDATE user_api_keys SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL`, userID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM push_subscriptions WHERE user_id = $1`, userID); err != nil {
+			return err
+		}
+
+		receipt, err = scanAccountDeletion(tx.QueryRow(ctx, `
+			INSERT INTO account_deletions (user_id, purge_after, content_anonymized, votes_anonymized)
+			VALUES ($1, NOW() + $2::interval, $3, $4)
+			RETURNING id, user_id, requested_at, purge_after, content_anonymized, votes_anonymized, purged_at, cancelled_at
+		`, userID, fmt.Sprintf("%d seconds", int64(grace.Seconds())), content, votes))
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || isInvalidUUIDError(err) {
+			return nil, ErrNotFound
+		}
+		LogQueryError(ctx, "RequestDeletion", "account_deletions", err)
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// FindReceipt returns a deletion receipt by ID.
+// Returns ErrAccountDeletionNotFound if it doesn't exist.
+func (r *AccountDeletionRepository) FindReceipt(ctx context.Context, id string) (*models.AccountDeletionReceipt, error) {
+	receipt, err := scanAccountDeletion(r.pool.QueryRow(ctx, `
+		SELECT id, user_id, requested_at, purge_after, content_anonymized, votes_anonymized, purged_at, cancelled_at
+		FROM account_deletions
+		WHERE id = $1
+	`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+			return nil, ErrAccountDeletionNotFound
+		}
+		LogQueryError(ctx, "FindReceipt", "account_deletions", err)
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// PurgeDue hard-deletes users whose deletion grace period has elapsed and marks
+// their receipts purged. Each user is purged in its own transaction so one
+// failure doesn't block the rest. Users who still author held content are
+// skipped and retried on later runs, once the holds are lifted.
+// Returns the number of users purged.
+func (r *AccountDeletionRepository) PurgeDue(ctx context.Context) (int, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, user_id
+		FROM account_deletions
+		WHERE purged_at IS NULL AND cancelled_at IS NULL AND purge_after <= NOW()
+		ORDER BY purge_after
+		LIMIT $1
+	`, accountPurgeBatchSize)
+	if err != nil {
+		LogQueryError(ctx, "PurgeDue", "account_deletions", err)
+		return 0, err
+	}
+
+	type dueDeletion struct{ id, userID string }
+	var due []dueDeletion
+	for rows.Next() {
+		var d dueDeletion
+		if err := rows.Scan(&d.id, &d.userID); err != nil {
+			rows.Close()
+			LogQueryError(ctx, "PurgeDue.Scan", "account_deletions", err)
+			return 0, err
+		}
+		due = append(due, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		LogQueryError(ctx, "PurgeDue.Rows", "account_deletions", err)
+		return 0, err
+	}
+
+	purged := 0
+	var errs []error
+	for _, d := range due {
+		err := r.pool.WithTx(ctx, func(tx Tx) error {
+			if err := purgeUserTx(ctx, tx, d.userID); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `UPDATE account_deletions SET purged_at = NOW() WHERE id = $1`, d.id)
+			return err
+		})
+		if errors.Is(err, ErrLegalHold) {
+			continue
+		}
+		if err != nil {
+			LogQueryError(ctx, "PurgeDue.Purge", "users", err)
+			errs = append(errs, fmt.Errorf("purge user %s: %w", d.userID, err))
+			continue
+		}
+		purged++
+	}
+
+	return purged, errors.Join(errs...)
+}
+
+// anonymizeUserTx reattributes a user's authored content to the deleted-user
+// sentinel and detaches their remaining activity from their identity.
+// Votes, vote history and reports keep counting but move to a random
+// pseudonymous voter so they can't be traced back. Content on held posts is
+// left as is. Returns the number of content rows and votes anonymized.
+func anonymizeUserTx(ctx context.Context, tx Tx, userID string) (content, votes int, err error) {
+	for _, c := range anonymizedAuthorColumns {
+		query := fmt.Sprintf(`UPDATE %s SET %s = $2 WHERE %s = 'human' AND %s = $1`, c.table, c.idCol, c.typeCol, c.idCol)
+		if c.postID != "" {
+			query += " AND NOT " + heldPostCondition(c.postID)
+		}
+		result, err := tx.Exec(ctx, query, userID, models.DeletedUserID)
+		if err != nil {
+			return 0, 0, fmt.Errorf("anonymize %s: %w", c.table, err)
+		}
+		content += int(result.RowsAffected())
+	}
+
+	pseudonym := "deleted-" + uuid.NewString()
+	result, err := tx.Exec(ctx, `UPDATE votes SET voter_id = $2 WHERE voter_type = 'human' AND voter_id = $1`, userID, pseudonym)
+	if err != nil {
+		return 0, 0, fmt.Errorf("anonymize votes: %w", err)
+	}
+	votes = int(result.RowsAffected())
+
+	statements := []string{
+		`UPDATE vote_events SET voter_id = $2 WHERE voter_type = 'human' AND voter_id = $1`,
+		`UPDATE reports SET reporter_id = $2 WHERE reporter_type = 'human' AND reporter_id = $1`,
+		`UPDATE flags SET reporter_id = $2 WHERE reporter_type = 'human' AND reporter_id = $1`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt, userID, pseudonym); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	statements = []string{
+		`UPDATE post_views SET viewer_type = 'anonymous', viewer_id = NULL, viewer_hash = NULL WHERE viewer_type = 'human' AND viewer_id = $1`,
+		`UPDATE search_queries SET searcher_type = 'anonymous', searcher_id = NULL WHERE searcher_type = 'human' AND searcher_id = $1`,
+		`DELETE FROM follows WHERE (follower_type = 'human' AND follower_id = $1) OR (followed_type = 'human' AND followed_id = $1)`,
+		`DELETE FROM bookmarks WHERE user_type = 'human' AND user_id = $1`,
+		`DELETE FROM badges WHERE owner_type = 'human' AND owner_id = $1`,
+		`DELETE FROM api_usage_daily WHERE principal_type = 'human' AND principal_id = $1`,
+		`DELETE FROM security_events WHERE principal_type = 'human' AND principal_id = $1`,
+		`DELETE FROM feed_briefings WHERE follower_type = 'human' AND follower_id = $1`,
+		`DELETE FROM username_history WHERE user_id = $1`,
+		`DELETE FROM principal_blocks WHERE (blocker_type = 'human' AND blocker_id = $1) OR (blocked_type = 'human' AND blocked_id = $1)`,
+		`DELETE FROM post_collaborators WHERE collaborator_type = 'human' AND collaborator_id = $1`,
+		`DELETE FROM reactions WHERE reactor_type = 'human' AND reactor_id = $1`,
+		`UPDATE agents SET human_id = NULL WHERE human_id = $1`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt, userID); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return content, votes, nil
+}
+
+// purgeUserTx hard-deletes a user and the rows that still reference them.
+// Content is anonymized again first in case anything was written between the
+// deletion request and the purge. Returns ErrNotFound if the user doesn't exist
+// and ErrLegalHold if they still author content on a held post.
+func purgeUserTx(ctx context.Context, tx Tx, userID string) error {
+	if userID == models.DeletedUserID {
+		return ErrNotFound
+	}
+
+	if _, _, err := anonymizeUserTx(ctx, tx, userID); err != nil {
+		return err
+	}
+	for _, c := range anonymizedAuthorColumns {
+		if c.postID == "" {
+			continue
+		}
+		var held bool
+		query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE %s = 'human' AND %s = $1 AND %s)`,
+			c.table, c.typeCol, c.idCol, heldPostCondition(c.postID))
+		if err := tx.QueryRow(ctx, query, userID).Scan(&held); err != nil {
+			return err
+		}
+		if held {
+			return ErrLegalHold
+		}
+	}
+
+	statements := []string{
+		`DELETE FROM referrals WHERE referrer_id = $1 OR referred_id = $1`,
+		`UPDATE claim_tokens SET used_by_human_id = NULL WHERE used_by_human_id = $1`,
+		`DELETE FROM pins WHERE owner_type = 'human' AND owner_id = $1`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt, userID); err != nil {
+			return err
+		}
+	}
+
+	// auth_methods, refresh_tokens, user_api_keys and notifications cascade
+	result, err := tx.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// scanAccountDeletion scans an account_deletions row and derives its status.
+func scanAccountDeletion(row pgx.Row) (*models.AccountDeletionReceipt, error) {
+	receipt := &models.AccountDeletionReceipt{}
+	err := row.Scan(
+		&receipt.ID,
+		&receipt.UserID,
+		&receipt.RequestedAt,
+		&receipt.PurgeAfter,
+		&receipt.ContentAnonymized,
+		&receipt.VotesAnonymized,
+		&receipt.PurgedAt,
+		&receipt.CancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case receipt.PurgedAt != nil:
+		receipt.Status = models.AccountDeletionPurged
+	case receipt.CancelledAt != nil:
+		receipt.Status = models.AccountDeletionCancelled
+	default:
+		receipt.Status = models.AccountDeletionPending
+	}
+	return receipt, nil
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestAccountDeletion_RequestAndPurge_Integration(t *testing.T) {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+
+	ctx := context.Background()
+	pool, err := NewPool(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	userRepo := NewUserRepository(pool)
+	postRepo := NewPostRepository(pool)
+	repo := NewAccountDeletionRepository(pool)
+
+	now := time.Now()
+	ts := now.Format("150405.000000")
+	created, err := userRepo.Create(ctx, &models.User{
+		Username:       "gd" + now.Format("0405") + fmt.Sprintf("%06d", now.Nanosecond()/1000)[:4],
+		DisplayName:    "GDPR Test User",
+		Email:          "gdprtest" + ts + "@example.com",
+		AuthProvider:   models.AuthProviderGitHub,
+		AuthProviderID: "github_gdpr_" + ts,
+		Role:           models.UserRoleUser,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test user: %v", err)
+	}
+
+	post, err := postRepo.Create(ctx, &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Question that outlives its author",
+		Description:  "This question should be reattributed to the deleted-user sentinel.",
+		PostedByType: models.AuthorTypeHuman,
+		PostedByID:   created.ID,
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test post: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID)
+		pool.Exec(ctx, "DELETE FROM account_deletions WHERE user_id = $1", created.ID)
+		pool.Exec(ctx, "DELETE FROM users WHERE id = $1", created.ID)
+	})
+
+	receipt, err := repo.RequestDeletion(ctx, created.ID, 0)
+	if err != nil {
+		t.Fatalf("RequestDeletion() error = %v", err)
+	}
+	if receipt.Status != models.AccountDeletionPending || receipt.ContentAnonymized < 1 {
+		t.Errorf("unexpected receipt: %+v", receipt)
+	}
+
+	var postedBy string
+	if err := pool.QueryRow(ctx, "SELECT posted_by_id FROM posts WHERE id = $1", post.ID).Scan(&postedBy); err != nil {
+		t.Fatalf("failed to read post: %v", err)
+	}
+	if postedBy != models.DeletedUserID {
+		t.Errorf("post author = %s, want deleted-user sentinel", postedBy)
+	}
+
+	if _, err := repo.RequestDeletion(ctx, created.ID, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second RequestDeletion() error = %v, want ErrNotFound", err)
+	}
+
+	if _, err := repo.PurgeDue(ctx); err != nil {
+		t.Fatalf("PurgeDue() error = %v", err)
+	}
+	if _, err := userRepo.FindByIDForAdmin(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("user still present after purge, err = %v", err)
+	}
+
+	purged, err := repo.FindReceipt(ctx, receipt.ID)
+	if err != nil {
+		t.Fatalf("FindReceipt() error = %v", err)
+	}
+	if purged.Status != models.AccountDeletionPurged {
+		t.Errorf("receipt status = %s, want purged", purged.Status)
+	}
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// dashboardTopRoutes caps the routes listed in the dashboard's error rates.
+const dashboardTopRoutes = 5
+
+// AdminDashboardRepository aggregates the ops overview for GET /v1/admin/dashboard.
+type AdminDashboardRepository struct {
+	pool *Pool
+}
+
+// NewAdminDashboardRepository creates a new AdminDashboardRepository.
+func NewAdminDashboardRepository(pool *Pool) *AdminDashboardRepository {
+	return &AdminDashboardRepository{pool: pool}
+}
+
+// GetDashboard returns queue depths, 24h activity, error rates and service health.
+func (r *AdminDashboardRepository) GetDashboard(ctx context.Context) (*models.AdminDashboard, error) {
+	dashboard := &models.AdminDashboard{
+		Services:    []models.DashboardService{},
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	q := &dashboard.Queues
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM posts WHERE status = 'pending_review' AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM flags WHERE status = 'pending'),
+			(SELECT COUNT(*) FROM reports WHERE status = 'pending'),
+			(SELECT COUNT(*) FROM abuse_reports WHERE status = 'pending'),
+			(SELECT COUNT(*) FROM embedding_queue),
+			(SELECT COUNT(*) FROM embedding_queue WHERE attempts > 0),
+			(SELECT COUNT(*) FROM email_queue),
+			(SELECT COUNT(*) FROM email_queue WHERE attempts > 0)
+	`).Scan(&q.ModerationPending, &q.FlagsPending, &q.ReportsPending, &q.AbuseReportsPending,
+		&q.EmbeddingsPending, &q.EmbeddingsFailing, &q.EmailsPending, &q.EmailsFailing)
+	if err != nil {
+		LogQueryError(ctx, "GetDashboard.Queues", "admin_dashboard", err)
+		return nil, err
+	}
+
+	a := &dashboard.Activity24h
+	err = r.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM posts WHERE created_at > NOW() - INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM answers WHERE created_at > NOW() - INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM approaches WHERE created_at > NOW() - INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM comments WHERE created_at > NOW() - INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM votes WHERE created_at > NOW() - INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM users WHERE created_at > NOW() - INTERVAL '24 hours'),
+			(SELECT COUNT(*) FROM agents WHERE created_at > NOW() - INTERVAL '24 hours')
+	`).Scan(&a.Posts, &a.Answers, &a.Approaches, &a.Comments, &a.Votes, &a.Users, &a.Agents)
+	if err != nil {
+		LogQueryError(ctx, "GetDashboard.Activity", "admin_dashboard", err)
+		return nil, err
+	}
+
+	if err := r.loadErrorRates(ctx, &dashboard.ErrorRates); err != nil {
+		return nil, err
+	}
+
+	services, err := r.loadServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.Services = services
+
+	return dashboard, nil
+}
+
+// loadErrorRates sums api_usage_daily since yesterday (UTC), the closest the daily
+// rollup gets to a 24 hour window, and lists the routes with the most errors.
+func (r *AdminDashboardRepository) loadErrorRates(ctx context.Context, rates *models.DashboardErrorRates) error {
+	since := time.Now().UTC().AddDate(0, 0, -1).Truncate(24 * time.Hour)
+	rates.Since = since.Format("2006-01-02")
+	rates.TopRoutes = []models.DashboardRouteErrors{}
+
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(request_count), 0), COALESCE(SUM(error_count), 0)
+		FROM api_usage_daily
+		WHERE day >= $1
+	`, since).Scan(&rates.Requests, &rates.Errors)
+	if err != nil {
+		LogQueryError(ctx, "GetDashboard.ErrorRates", "api_usage_daily", err)
+		return err
+	}
+	rates.ErrorRate = errorRate(rates.Errors, rates.Requests)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT method, route, SUM(request_count), SUM(error_count)
+		FROM api_usage_daily
+		WHERE day >= $1
+		GROUP BY method, route
+		HAVING SUM(error_count) > 0
+		ORDER BY SUM(error_count) DESC, method, route
+		LIMIT $2
+	`, since, dashboardTopRoutes)
+	if err != nil {
+		LogQueryError(ctx, "GetDashboard.TopRoutes", "api_usage_daily", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var route models.DashboardRouteErrors
+		if err := rows.Scan(&route.Method, &route.Route, &route.Requests, &route.Errors); err != nil {
+			return err
+		}
+		route.ErrorRate = errorRate(route.Errors, route.Requests)
+		rates.TopRoutes = append(rates.TopRoutes, route)
+	}
+	return rows.Err()
+}
+
+// loadServices returns each service's latest check and its 24h uptime.
+func (r *AdminDashboardRepository) loadServices(ctx context.Context) ([]models.DashboardService, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT latest.service_name, latest.status, latest.response_time_ms, latest.error_message, latest.checked_at,
+			COALESCE((
+				SELECT ROUND(100.0 * COUNT(*) FILTER (WHERE sc.status = 'operational') / NULLIF(COUNT(*), 0), 2)
+				FROM service_checks sc
+				WHERE sc.service_name = latest.service_name AND sc.checked_at > NOW() - INTERVAL '24 hours'
+			), 0)::float8
+		FROM (
+			SELECT DISTINCT ON (service_name) service_name, status, response_time_ms, error_message, checked_at
+			FROM service_checks
+			ORDER BY service_name, checked_at DESC
+		) latest
+		ORDER BY latest.service_name
+	`)
+	if err != nil {
+		LogQueryError(ctx, "GetDashboard.Services", "service_checks", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	services := []models.DashboardService{}
+	for rows.Next() {
+		var s models.DashboardService
+		if err := rows.Scan(&s.ServiceName, &s.Status, &s.ResponseTimeMs, &s.ErrorMessage, &s.CheckedAt, &s.Uptime24h); err != nil {
+			return nil, err
+		}
+		services = append(services, s)
+	}
+	return services, rows.Err()
+}
+
+// errorRate returns errors/requests rounded to four places, 0 when there were no requests.
+func errorRate(errs, requests int64) float64 {
+	if requests == 0 {
+		return 0
+	}
+	return math.Round(float64(errs)/float64(requests)*10000) / 10000
+}
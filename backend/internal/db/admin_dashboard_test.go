@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestAdminDashboardRepository_GetDashboard(t *testing.T) {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+
+	ctx := context.Background()
+	pool, err := NewPool(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	checks := NewServiceCheckRepository(pool)
+	_, _ = pool.Exec(ctx, "DELETE FROM service_checks WHERE service_name = 'test_dashboard'")
+	defer pool.Exec(ctx, "DELETE FROM service_checks WHERE service_name = 'test_dashboard'")
+	for _, status := range []models.ServiceCheckStatus{models.ServiceStatusOperational, models.ServiceStatusOutage} {
+		if err := checks.Insert(ctx, models.ServiceCheck{ServiceName: "test_dashboard", Status: status, CheckedAt: time.Now()}); err != nil {
+			t.Fatalf("Insert: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	dashboard, err := NewAdminDashboardRepository(pool).GetDashboard(ctx)
+	if err != nil {
+		t.Fatalf("GetDashboard: %v", err)
+	}
+
+	var found *models.DashboardService
+	for i := range dashboard.Services {
+		if dashboard.Services[i].ServiceName == "test_dashboard" {
+			found = &dashboard.Services[i]
+		}
+	}
+	if found == nil {
+		t.Fatal("expected test_dashboard in services")
+	}
+	if found.Status != models.ServiceStatusOutage {
+		t.Errorf("expected latest status outage, got %s", found.Status)
+	}
+	if found.Uptime24h != 50 {
+		t.Errorf("expected 50%% uptime, got %v", found.Uptime24h)
+	}
+	if dashboard.ErrorRates.Errors > dashboard.ErrorRates.Requests {
+		t.Errorf("errors %d exceed requests %d", dashboard.ErrorRates.Errors, dashboard.ErrorRates.Requests)
+	}
+	if dashboard.Queues.EmbeddingsFailing > dashboard.Queues.EmbeddingsPending {
+		t.Errorf("failing embeddings %d exceed pending %d", dashboard.Queues.EmbeddingsFailing, dashboard.Queues.EmbeddingsPending)
+	}
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrAgentVerificationNotFound is returned when no verification matches a token.
+var ErrAgentVerificationNotFound = errors.New("agent email verification not found")
+
+// ErrAgentVerificationExpired is returned when a verification token has expired.
+var ErrAgentVerificationExpired = errors.New("agent email verification expired")
+
+// AgentVerificationRepository stores the email verification tokens of agents
+// registered while AGENT_REGISTRATION_REQUIRE_EMAIL is on.
+type AgentVerificationRepository struct {
+	pool *Pool
+}
+
+// NewAgentVerificationRepository creates a new AgentVerificationRepository.
+func NewAgentVerificationRepository(pool *Pool) *AgentVerificationRepository {
+	return &AgentVerificationRepository{pool: pool}
+}
+
+// Issue stores the verification token for agentID, replacing any earlier one,
+// so only the most recently emailed link works.
+func (r *AgentVerificationRepository) Issue(ctx context.Context, agentID, email, tokenHash string, expiresAt time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO agent_email_verifications (agent_id, email, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (agent_id)
+		DO UPDATE SET email = EXCLUDED.email, token_hash = EXCLUDED.token_hash,
+		              expires_at = EXCLUDED.expires_at, created_at = NOW()
+	`, agentID, email, tokenHash, expiresAt)
+	if err != nil {
+		LogQueryError(ctx, "Issue", "agent_email_verifications", err)
+	}
+	return err
+}
+
+// Verify consumes the token with tokenHash and activates its agent, recording
+// the verified address as the agent's email. It returns the agent ID.
+func (r *AgentVerificationRepository) Verify(ctx context.Context, tokenHash string) (string, error) {
+	var agentID string
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var email string
+		var expiresAt time.Time
+		err := tx.QueryRow(ctx, `
+			SELECT agent_id, email, expires_at
+			FROM agent_email_verifications
+			WHERE token_hash = $1
+			FOR UPDATE
+		`, tokenHash).Scan(&agentID, &email, &expiresAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAgentVerificationNotFound
+		}
+		if err != nil {
+			LogQueryError(ctx, "Verify", "agent_email_verifications", err)
+			return err
+		}
+		if time.Now().After(expiresAt) {
+			return ErrAgentVerificationExpired
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM agent_email_verifications WHERE agent_id = $1`, agentID); err != nil {
+			LogQueryError(ctx, "Verify", "agent_email_verifications", err)
+			return err
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE agents
+			SET status = 'active', email = $2, updated_at = NOW()
+			WHERE id = $1 AND status = 'pending_verification'
+		`, agentID, email); err != nil {
+			LogQueryError(ctx, "Verify", "agents", err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return agentID, nil
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAgentVerificationRepository_IssueAndVerify(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewAgentVerificationRepository(pool)
+
+	agentID := "agent_verify_test"
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO agents (id, display_name, api_key_hash, status, email)
+		VALUES ($1, 'Verify Test', 'hash', 'pending_verification', 'old@example.com')
+		ON CONFLICT (id) DO UPDATE SET status = 'pending_verification'
+	`, agentID); err != nil {
+		t.Fatalf("insert agent: %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM agents WHERE id = $1", agentID)
+	}()
+
+	if err := repo.Issue(ctx, agentID, "old@example.com", "hash-first", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	// Resending replaces the first token.
+	if err := repo.Issue(ctx, agentID, "ops@example.com", "hash-second", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Issue() resend error = %v", err)
+	}
+	if _, err := repo.Verify(ctx, "hash-first"); !errors.Is(err, ErrAgentVerificationNotFound) {
+		t.Errorf("Verify(replaced token) error = %v, want ErrAgentVerificationNotFound", err)
+	}
+
+	got, err := repo.Verify(ctx, "hash-second")
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got != agentID {
+		t.Errorf("Verify() = %q, want %q", got, agentID)
+	}
+	var status, email string
+	if err := pool.QueryRow(ctx, "SELECT status, email FROM agents WHERE id = $1", agentID).Scan(&status, &email); err != nil {
+		t.Fatalf("read agent: %v", err)
+	}
+	if status != "active" || email != "ops@example.com" {
+		t.Errorf("agent status/email = %s/%s, want active/ops@example.com", status, email)
+	}
+	if _, err := repo.Verify(ctx, "hash-second"); !errors.Is(err, ErrAgentVerificationNotFound) {
+		t.Errorf("Verify(used token) error = %v, want ErrAgentVerificationNotFound", err)
+	}
+
+	if err := repo.Issue(ctx, agentID, "ops@example.com", "hash-expired", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("Issue() expired error = %v", err)
+	}
+	if _, err := repo.Verify(ctx, "hash-expired"); !errors.Is(err, ErrAgentVerificationExpired) {
+		t.Errorf("Verify(expired token) error = %v, want ErrAgentVerificationExpired", err)
+	}
+}
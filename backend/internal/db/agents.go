@@ -32,8 +32,8 @@ type AgentRepository struct {
 // Used to keep queries consistent and DRY.
 // Note: COALESCE handles NULL values for nullable columns scanned into non-pointer Go types.
 // Without COALESCE, pgx fails when scanning NULL into string/[]string.
-// 25 columns total (added keri_public_key for KERI identity management)
-const agentColumns = `id, display_name, human_id, COALESCE(bio, '') as bio, COALESCE(specialties, '{}') as specialties, COALESCE(avatar_url, '') as avatar_url, COALESCE(api_key_hash, '') as api_key_hash, COALESCE(moltbook_id, '') as moltbook_id, COALESCE(model, '') as model, COALESCE(email, '') as email, COALESCE(external_links, '{}') as external_links, status, reputation, human_claimed_at, has_human_backed_badge, has_amcp_identity, COALESCE(amcp_aid, '') as amcp_aid, COALESCE(keri_public_key, '') as keri_public_key, pinning_quota_bytes, storage_used_bytes, last_seen_at, last_briefing_at, created_at, updated_at, deleted_at`
+// 26 columns total (added tenant_id so API keys can be checked against the request's tenant)
+const agentColumns = `id, display_name, human_id, COALESCE(bio, '') as bio, COALESCE(specialties, '{}') as specialties, COALESCE(avatar_url, '') as avatar_url, COALESCE(api_key_hash, '') as api_key_hash, COALESCE(moltbook_id, '') as moltbook_id, COALESCE(model, '') as model, COALESCE(email, '') as email, COALESCE(external_links, '{}') as external_links, status, reputation, human_claimed_at, has_human_backed_badge, has_amcp_identity, COALESCE(amcp_aid, '') as amcp_aid, COALESCE(keri_public_key, '') as keri_public_key, pinning_quota_bytes, storage_used_bytes, last_seen_at, last_briefing_at, created_at, updated_at, deleted_at, tenant_id`
 
 // NewAgentRepository creates a new AgentRepository.
 func NewAgentRepository(pool *Pool) *AgentRepository {
@@ -109,6 +109,7 @@ func (r *AgentRepository) Create(ctx context.Context, agent *models.Agent) error
 		&agent.CreatedAt,
 		&agent.UpdatedAt,
 		&agent.DeletedAt,
+		&agent.TenantID,
 	)
 
 	if err != nil {
@@ -214,6 +215,7 @@ func (r *AgentRepository) Update(ctx context.Context, agent *models.Agent) error
 		&agent.CreatedAt,
 		&agent.UpdatedAt,
 		&agent.DeletedAt,
+		&agent.TenantID,
 	)
 
 	if err != nil {
@@ -373,6 +375,7 @@ func (r *AgentRepository) GetAgentStats(ctx context.Context, agentID string) (*m
 	//            + responses_given * 5
 	//            + upvotes_received * 2
 	//            - downvotes_received * 1
+	//            + bounty_weight * 25 (sum of weight - 1 over bounties earned)
 	query := `
 		WITH agent_bonus AS (
 			SELECT COALESCE(reputation, 0) as bonus FROM agents WHERE id = $1 AND deleted_at IS NULL
@@ -414,6 +417,11 @@ func (r *AgentRepository) GetAgentStats(ctx context.Context, agentID string) (*m
 					SELECT 1 FROM responses r WHERE r.id = v.target_id AND r.author_type = 'agent' AND r.author_id = $1
 				))
 			)
+		),
+		agent_bounties AS (
+			SELECT COALESCE(SUM(weight - 1), 0) as bounty_weight
+			FROM bounty_awards
+			WHERE recipient_type = 'agent' AND recipient_id = $1
 		)
 		SELECT
 			COALESCE(ap.problems_solved, 0)::int,
@@ -432,8 +440,9 @@ func (r *AgentRepository) GetAgentStats(ctx context.Context, agentID string) (*m
 			 COALESCE(ap.ideas_posted, 0) * 15 +
 			 COALESCE(ar.responses_given, 0) * 5 +
 			 COALESCE(av.upvotes, 0) * 2 -
-			 COALESCE(av.downvotes, 0))::int as reputation
-		FROM agent_bonus ab, agent_posts ap, agent_answers aa, agent_responses ar, agent_votes_received av
+			 COALESCE(av.downvotes, 0) +
+			 abw.bounty_weight * 25)::int as reputation
+		FROM agent_bonus ab, agent_posts ap, agent_answers aa, agent_responses ar, agent_votes_received av, agent_bounties abw
 	`
 
 	row := r.pool.QueryRow(ctx, query, agentID)
@@ -463,7 +472,7 @@ func (r *AgentRepository) GetAgentStats(ctx context.Context, agentID string) (*m
 }
 
 // scanAgent scans an agent row into an Agent struct.
-// Expects columns in order defined by agentColumns constant (25 columns).
+// Expects columns in order defined by agentColumns constant (26 columns).
 func (r *AgentRepository) scanAgent(row pgx.Row) (*models.Agent, error) {
 	agent := &models.Agent{}
 	err := row.Scan(
@@ -492,6 +501,7 @@ func (r *AgentRepository) scanAgent(row pgx.Row) (*models.Agent, error) {
 		&agent.CreatedAt,
 		&agent.UpdatedAt,
 		&agent.DeletedAt,
+		&agent.TenantID,
 	)
 
 	if err != nil {
@@ -505,7 +515,7 @@ func (r *AgentRepository) scanAgent(row pgx.Row) (*models.Agent, error) {
 }
 
 // scanAgentRows scans a rows result into an Agent struct.
-// Used for queries that return multiple rows (25 columns).
+// Used for queries that return multiple rows (26 columns).
 func (r *AgentRepository) scanAgentRows(rows pgx.Rows) (*models.Agent, error) {
 	agent := &models.Agent{}
 	err := rows.Scan(
@@ -534,6 +544,7 @@ func (r *AgentRepository) scanAgentRows(rows pgx.Rows) (*models.Agent, error) {
 		&agent.CreatedAt,
 		&agent.UpdatedAt,
 		&agent.DeletedAt,
+		&agent.TenantID,
 	)
 	if err != nil {
 		return nil, err
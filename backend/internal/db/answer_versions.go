@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// maxAnswerChainDepth bounds how far version chains are walked.
+const maxAnswerChainDepth = 50
+
+// GetAnswerVersionChain returns the chain of versions the answer belongs to,
+// oldest first: the answers it supersedes, itself and the answers superseding it.
+// Deleted and hidden versions are walked through but not returned.
+// Returns ErrAnswerNotFound if the answer is deleted or its question isn't public.
+func (r *AnswersRepository) GetAnswerVersionChain(ctx context.Context, answerID string) (*models.AnswerVersionChain, error) {
+	if _, err := r.FindAnswerByID(ctx, answerID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH RECURSIVE back AS (
+			SELECT id, supersedes_answer_id, 0 AS depth FROM answers WHERE id = $1
+			UNION ALL
+			SELECT a.id, a.supersedes_answer_id, back.depth + 1
+			FROM answers a
+			JOIN back ON a.id = back.supersedes_answer_id
+			WHERE back.depth < $2
+		),
+		root AS (
+			SELECT id FROM back ORDER BY depth DESC LIMIT 1
+		),
+		fwd AS (
+			SELECT id, 0 AS depth FROM root
+			UNION ALL
+			SELECT a.id, fwd.depth + 1
+			FROM answers a
+			JOIN fwd ON a.supersedes_answer_id = fwd.id
+			WHERE fwd.depth < $2
+		)
+		SELECT
+			ans.id,
+			ans.question_id,
+			ans.author_type,
+			ans.author_id,
+			ans.content,
+			ans.is_accepted,
+			ans.upvotes,
+			ans.downvotes,
+			ans.created_at,
+			ans.updated_at,
+			ans.supersedes_answer_id,
+			`+supersededBySelect+`,
+			COALESCE(
+				CASE WHEN ans.author_type = 'agent' THEN a.display_name
+				     WHEN ans.author_type = 'human' THEN u.display_name
+				     ELSE ans.author_id
+				END,
+				ans.author_id
+			) as display_name,
+			COALESCE(
+				CASE WHEN ans.author_type = 'human' THEN u.avatar_url
+				     ELSE ''
+				END,
+				''
+			) as avatar_url
+		FROM fwd
+		JOIN answers ans ON ans.id = fwd.id
+		LEFT JOIN agents a ON ans.author_type = 'agent' AND ans.author_id = a.id
+		LEFT JOIN users u ON ans.author_type = 'human' AND ans.author_id = u.id::text
+		WHERE ans.deleted_at IS NULL AND ans.hidden_at IS NULL
+		ORDER BY fwd.depth ASC, ans.created_at ASC
+	`, answerID, maxAnswerChainDepth)
+	if err != nil {
+		LogQueryError(ctx, "GetAnswerVersionChain", "answers", err)
+		return nil, fmt.Errorf("query answer versions: %w", err)
+	}
+	defer rows.Close()
+
+	chain := &models.AnswerVersionChain{AnswerID: answerID, Versions: make([]models.AnswerWithAuthor, 0)}
+	for rows.Next() {
+		var ans models.AnswerWithAuthor
+		var displayName, avatarURL string
+		if err := rows.Scan(
+			&ans.ID,
+			&ans.QuestionID,
+			&ans.AuthorType,
+			&ans.AuthorID,
+			&ans.Content,
+			&ans.IsAccepted,
+			&ans.Upvotes,
+			&ans.Downvotes,
+			&ans.CreatedAt,
+			&ans.UpdatedAt,
+			&ans.SupersedesAnswerID,
+			&ans.SupersededByID,
+			&displayName,
+			&avatarURL,
+		); err != nil {
+			return nil, fmt.Errorf("scan answer version: %w", err)
+		}
+		ans.Author = models.AnswerAuthor{
+			Type:        ans.AuthorType,
+			ID:          ans.AuthorID,
+			DisplayName: displayName,
+			AvatarURL:   avatarURL,
+		}
+		ans.VoteScore = ans.Upvotes - ans.Downvotes
+		chain.Versions = append(chain.Versions, ans)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate answer versions: %w", err)
+	}
+
+	chain.LatestVerifiedID = models.LatestVerifiedAnswerID(chain.Versions)
+	return chain, nil
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestAnswersRepository_VersionChain_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewAnswersRepository(pool)
+	agent := createStaleTestAgent(t, pool, "answer_versions")
+
+	var questionID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'How do I rotate Postgres credentials?', 'Description', 'agent', $1, 'open')
+		RETURNING id::text
+	`, agent.ID).Scan(&questionID); err != nil {
+		t.Fatalf("failed to insert question: %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM answers WHERE question_id = $1", questionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", questionID)
+	}()
+
+	create := func(content string, supersedes *string) (*models.Answer, error) {
+		return repo.CreateAnswer(ctx, &models.Answer{
+			QuestionID:         questionID,
+			AuthorType:         models.AuthorTypeAgent,
+			AuthorID:           agent.ID,
+			Content:            content,
+			SupersedesAnswerID: supersedes,
+		})
+	}
+	v1, err := create("Use ALTER ROLE and restart the pool.", nil)
+	if err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+	v2, err := create("Rotate through a second role to avoid downtime.", &v1.ID)
+	if err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+	if v2.SupersedesAnswerID == nil || *v2.SupersedesAnswerID != v1.ID {
+		t.Errorf("expected v2 to supersede v1, got %v", v2.SupersedesAnswerID)
+	}
+	if _, err := create("A competing v2.", &v1.ID); !errors.Is(err, ErrAnswerAlreadySuperseded) {
+		t.Errorf("expected ErrAnswerAlreadySuperseded, got %v", err)
+	}
+
+	found, err := repo.FindAnswerByID(ctx, v1.ID)
+	if err != nil {
+		t.Fatalf("FindAnswerByID() error = %v", err)
+	}
+	if found.SupersededByID == nil || *found.SupersededByID != v2.ID {
+		t.Errorf("expected v1 superseded by v2, got %v", found.SupersededByID)
+	}
+
+	// Nothing newer is verified yet: v1 is its own latest verified version
+	var latest string
+	if err := pool.QueryRow(ctx, "SELECT latest_verified_answer_id($1)::text", v1.ID).Scan(&latest); err != nil {
+		t.Fatalf("latest_verified_answer_id: %v", err)
+	}
+	if latest != v1.ID {
+		t.Errorf("expected v1 before v2 is verified, got %s", latest)
+	}
+
+	if _, err := pool.Exec(ctx, "UPDATE answers SET upvotes = 2 WHERE id = $1", v2.ID); err != nil {
+		t.Fatalf("failed to upvote v2: %v", err)
+	}
+	if err := pool.QueryRow(ctx, "SELECT latest_verified_answer_id($1)::text", v1.ID).Scan(&latest); err != nil {
+		t.Fatalf("latest_verified_answer_id: %v", err)
+	}
+	if latest != v2.ID {
+		t.Errorf("expected upvoted v2 as latest verified version, got %s", latest)
+	}
+
+	chain, err := repo.GetAnswerVersionChain(ctx, v2.ID)
+	if err != nil {
+		t.Fatalf("GetAnswerVersionChain() error = %v", err)
+	}
+	if len(chain.Versions) != 2 || chain.Versions[0].ID != v1.ID || chain.Versions[1].ID != v2.ID {
+		t.Fatalf("expected [v1, v2], got %+v", chain.Versions)
+	}
+	if chain.LatestVerifiedID == nil || *chain.LatestVerifiedID != v2.ID {
+		t.Errorf("expected v2 as latest verified, got %v", chain.LatestVerifiedID)
+	}
+}
@@ -5,16 +5,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 // Answer-related errors.
 var (
-	ErrAnswerNotFound   = errors.New("answer not found")
-	ErrQuestionNotExist = errors.New("question does not exist")
+	ErrAnswerNotFound          = errors.New("answer not found")
+	ErrQuestionNotExist        = errors.New("question does not exist")
+	ErrAnswerAlreadySuperseded = errors.New("answer already superseded")
 )
 
 // AnswersRepository handles database operations for answers.
@@ -28,8 +31,16 @@ func NewAnswersRepository(pool *Pool) *AnswersRepository {
 	return &AnswersRepository{pool: pool}
 }
 
+// supersededBySelect selects the newest visible answer superseding ans.
+const supersededBySelect = `(
+				SELECT n.id FROM answers n
+				WHERE n.supersedes_answer_id = ans.id AND n.deleted_at IS NULL AND n.hidden_at IS NULL
+				ORDER BY n.created_at DESC LIMIT 1
+			) AS superseded_by_id`
+
 // ListAnswers returns answers for a question with pagination.
-// Returns answers ordered by created_at descending (newest first).
+// Returns answers ordered by created_at descending (newest first), or with
+// opts.Sort "quality" by quality_score (unscored last), then vote score.
 // Includes author display_name from agents/users tables.
 func (r *AnswersRepository) ListAnswers(ctx context.Context, questionID string, opts models.AnswerListOptions) ([]models.AnswerWithAuthor, int, error) {
 	// Calculate pagination
@@ -46,11 +57,28 @@ func (r *AnswersRepository) ListAnswers(ctx context.Context, questionID string,
 	}
 	offset := (page - 1) * perPage
 
+	orderBy := "ans.created_at DESC"
+	switch opts.Sort {
+	case "oldest":
+		orderBy = "ans.created_at ASC"
+	case "top":
+		orderBy = "(ans.upvotes - ans.downvotes) DESC, ans.created_at DESC"
+	case "verified-first":
+		orderBy = "ans.is_accepted DESC, (ans.upvotes - ans.downvotes) DESC, ans.created_at DESC"
+	case "quality":
+		orderBy = "ans.quality_score DESC NULLS LAST, (ans.upvotes - ans.downvotes) DESC, ans.created_at DESC"
+	}
+
+	// The caller's blocked principals' answers are left out.
+	viewerType, viewerID, _ := viewerFromContext(ctx)
+
 	// Get total count
 	var total int
 	err := r.pool.QueryRow(ctx, `
-		SELECT COUNT(*) FROM answers WHERE question_id = $1 AND deleted_at IS NULL
-	`, questionID).Scan(&total)
+		SELECT COUNT(*) FROM answers ans
+		WHERE ans.question_id = $1 AND ans.deleted_at IS NULL AND ans.hidden_at IS NULL
+		AND `+blockedAuthorCondition("ans.author_type", "ans.author_id", 2)+`
+	`, questionID, string(viewerType), viewerID).Scan(&total)
 	if err != nil {
 		// If table doesn't exist, return empty array (graceful degradation)
 		if isTableNotFoundError(err) {
@@ -72,7 +100,12 @@ func (r *AnswersRepository) ListAnswers(ctx context.Context, questionID string,
 			ans.is_accepted,
 			ans.upvotes,
 			ans.downvotes,
+			ans.quality_score,
+			COALESCE(ans.code_languages, '{}'),
 			ans.created_at,
+			ans.updated_at,
+			ans.supersedes_answer_id,
+			`+supersededBySelect+`,
 			COALESCE(
 				CASE WHEN ans.author_type = 'agent' THEN a.display_name
 				     WHEN ans.author_type = 'human' THEN u.display_name
@@ -85,15 +118,17 @@ func (r *AnswersRepository) ListAnswers(ctx context.Context, questionID string,
 				     ELSE ''
 				END,
 				''
-			) as avatar_url
+			) as avatar_url,
+			`+reactionsSelect(models.ReactionTargetAnswer, "ans.id", 4)+` AS reactions
 		FROM answers ans
 		LEFT JOIN agents a ON ans.author_type = 'agent' AND ans.author_id = a.id
 		LEFT JOIN users u ON ans.author_type = 'human' AND ans.author_id = u.id::text
-		WHERE ans.question_id = $1 AND ans.deleted_at IS NULL
+		WHERE ans.question_id = $1 AND ans.deleted_at IS NULL AND ans.hidden_at IS NULL
 		AND EXISTS (SELECT 1 FROM posts WHERE id = ans.question_id AND visibility = 'public') -- BART-151: answers inherit the question's visibility
-		ORDER BY ans.created_at DESC
+		AND `+blockedAuthorCondition("ans.author_type", "ans.author_id", 4)+`
+		ORDER BY `+orderBy+`
 		LIMIT $2 OFFSET $3
-	`, questionID, perPage, offset)
+	`, questionID, perPage, offset, string(viewerType), viewerID)
 	if err != nil {
 		// If table doesn't exist, return empty array (graceful degradation)
 		if isTableNotFoundError(err) {
@@ -117,9 +152,15 @@ func (r *AnswersRepository) ListAnswers(ctx context.Context, questionID string,
 			&ans.IsAccepted,
 			&ans.Upvotes,
 			&ans.Downvotes,
+			&ans.QualityScore,
+			&ans.CodeLanguages,
 			&ans.CreatedAt,
+			&ans.UpdatedAt,
+			&ans.SupersedesAnswerID,
+			&ans.SupersededByID,
 			&displayName,
 			&avatarURL,
+			&ans.Reactions,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("scan answer: %w", err)
@@ -152,11 +193,13 @@ func (r *AnswersRepository) CreateAnswer(ctx context.Context, answer *models.Ans
 		id = uuid.New().String()
 	}
 
+	answer.CodeLanguages = models.ExtractCodeLanguages(answer.Content)
+
 	// Insert answer with optional embedding for semantic search
 	err := r.pool.QueryRow(ctx, `
-		INSERT INTO answers (id, question_id, author_type, author_id, content, embedding)
-		VALUES ($1, $2, $3, $4, $5, $6::vector)
-		RETURNING id, question_id, author_type, author_id, content, is_accepted, upvotes, downvotes, created_at
+		INSERT INTO answers (id, question_id, author_type, author_id, content, embedding, code_languages, supersedes_answer_id)
+		VALUES ($1, $2, $3, $4, $5, $6::vector, $7, $8)
+		RETURNING id, question_id, author_type, author_id, content, is_accepted, upvotes, downvotes, supersedes_answer_id, created_at, updated_at
 	`,
 		id,
 		answer.QuestionID,
@@ -164,6 +207,8 @@ func (r *AnswersRepository) CreateAnswer(ctx context.Context, answer *models.Ans
 		answer.AuthorID,
 		answer.Content,
 		answer.EmbeddingStr,
+		answer.CodeLanguages,
+		answer.SupersedesAnswerID,
 	).Scan(
 		&answer.ID,
 		&answer.QuestionID,
@@ -173,9 +218,15 @@ func (r *AnswersRepository) CreateAnswer(ctx context.Context, answer *models.Ans
 		&answer.IsAccepted,
 		&answer.Upvotes,
 		&answer.Downvotes,
+		&answer.SupersedesAnswerID,
 		&answer.CreatedAt,
+		&answer.UpdatedAt,
 	)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_answers_supersedes_answer_id" {
+			return nil, ErrAnswerAlreadySuperseded
+		}
 		return nil, fmt.Errorf("insert answer: %w", err)
 	}
 
@@ -208,6 +259,9 @@ func (r *AnswersRepository) FindAnswerByID(ctx context.Context, id string) (*mod
 			ans.upvotes,
 			ans.downvotes,
 			ans.created_at,
+			ans.updated_at,
+			ans.supersedes_answer_id,
+			`+supersededBySelect+`,
 			COALESCE(
 				CASE WHEN ans.author_type = 'agent' THEN a.display_name
 				     WHEN ans.author_type = 'human' THEN u.display_name
@@ -236,6 +290,9 @@ func (r *AnswersRepository) FindAnswerByID(ctx context.Context, id string) (*mod
 		&ans.Upvotes,
 		&ans.Downvotes,
 		&ans.CreatedAt,
+		&ans.UpdatedAt,
+		&ans.SupersedesAnswerID,
+		&ans.SupersededByID,
 		&displayName,
 		&avatarURL,
 	)
@@ -258,16 +315,30 @@ func (r *AnswersRepository) FindAnswerByID(ctx context.Context, id string) (*mod
 }
 
 // UpdateAnswer updates an existing answer.
+// When answer.UpdatedAt is set it is treated as the expected version: the update only
+// applies if the row still has that updated_at, otherwise ErrVersionConflict is returned.
+// A zero UpdatedAt updates unconditionally.
 func (r *AnswersRepository) UpdateAnswer(ctx context.Context, answer *models.Answer) (*models.Answer, error) {
+	var expectedUpdatedAt *time.Time
+	if !answer.UpdatedAt.IsZero() {
+		expectedUpdatedAt = &answer.UpdatedAt
+	}
+
+	answer.CodeLanguages = models.ExtractCodeLanguages(answer.Content)
+
 	err := r.pool.QueryRow(ctx, `
 		UPDATE answers
-		SET content = $2, embedding = COALESCE($3::vector, embedding)
+		SET content = $2, embedding = COALESCE($3::vector, embedding), code_languages = $5, updated_at = NOW(),
+			quality_score = NULL, quality_scored_at = NULL, quality_attempts = 0
 		WHERE id = $1 AND deleted_at IS NULL
-		RETURNING id, question_id, author_type, author_id, content, is_accepted, upvotes, downvotes, created_at
+		AND ($4::timestamptz IS NULL OR updated_at = $4)
+		RETURNING id, question_id, author_type, author_id, content, is_accepted, upvotes, downvotes, created_at, updated_at
 	`,
 		answer.ID,
 		answer.Content,
 		answer.EmbeddingStr,
+		expectedUpdatedAt,
+		answer.CodeLanguages,
 	).Scan(
 		&answer.ID,
 		&answer.QuestionID,
@@ -278,9 +349,13 @@ func (r *AnswersRepository) UpdateAnswer(ctx context.Context, answer *models.Ans
 		&answer.Upvotes,
 		&answer.Downvotes,
 		&answer.CreatedAt,
+		&answer.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
+			if expectedUpdatedAt != nil && r.answerExists(ctx, answer.ID) {
+				return nil, ErrVersionConflict
+			}
 			return nil, ErrAnswerNotFound
 		}
 		return nil, fmt.Errorf("update answer: %w", err)
@@ -289,6 +364,15 @@ func (r *AnswersRepository) UpdateAnswer(ctx context.Context, answer *models.Ans
 	return answer, nil
 }
 
+// answerExists reports whether a non-deleted answer with the given ID exists.
+func (r *AnswersRepository) answerExists(ctx context.Context, id string) bool {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM answers WHERE id = $1 AND deleted_at IS NULL)
+	`, id).Scan(&exists)
+	return err == nil && exists
+}
+
 // DeleteAnswer soft-deletes an answer by ID.
 func (r *AnswersRepository) DeleteAnswer(ctx context.Context, id string) error {
 	result, err := r.pool.Exec(ctx, `
@@ -343,29 +427,83 @@ func (r *AnswersRepository) AcceptAnswer(ctx context.Context, questionID, answer
 	})
 }
 
-// VoteOnAnswer records a vote on an answer.
+// VoteOnAnswer records, changes or retracts a vote on an answer.
+// Votes are tracked per voter in the votes table (one vote per voter per answer),
+// so repeating the same direction is a no-op and direction "none" removes the
+// voter's vote, decrementing the answer's counts in the same transaction.
 func (r *AnswersRepository) VoteOnAnswer(ctx context.Context, answerID, voterType, voterID, direction string) error {
-	// For now, just update the vote counts directly
-	// A more sophisticated implementation would track individual votes
-	var query string
-	if direction == "up" {
-		query = `UPDATE answers SET upvotes = upvotes + 1 WHERE id = $1 AND deleted_at IS NULL`
-	} else if direction == "down" {
-		query = `UPDATE answers SET downvotes = downvotes + 1 WHERE id = $1 AND deleted_at IS NULL`
-	} else {
+	if direction != "up" && direction != "down" && direction != VoteDirectionNone {
 		return fmt.Errorf("invalid vote direction: %s", direction)
 	}
 
-	result, err := r.pool.Exec(ctx, query, answerID)
+	var exists bool
+	err := r.pool.QueryRow(ctx,
+		"SELECT EXISTS(SELECT 1 FROM answers WHERE id = $1 AND deleted_at IS NULL)",
+		answerID,
+	).Scan(&exists)
 	if err != nil {
+		if isInvalidUUIDError(err) {
+			return ErrAnswerNotFound
+		}
 		return fmt.Errorf("vote on answer: %w", err)
 	}
-
-	if result.RowsAffected() == 0 {
+	if !exists {
 		return ErrAnswerNotFound
 	}
 
-	return nil
+	if direction == VoteDirectionNone {
+		return retractVote(ctx, r.pool, "answer", "answers", answerID, voterType, voterID)
+	}
+
+	return r.pool.WithTx(ctx, func(tx Tx) error {
+		var existingDirection string
+		err := tx.QueryRow(ctx,
+			`SELECT direction FROM votes
+			 WHERE target_type = 'answer' AND target_id = $1
+			 AND voter_type = $2 AND voter_id = $3
+			 FOR UPDATE`,
+			answerID, voterType, voterID,
+		).Scan(&existingDirection)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("vote on answer: check existing vote: %w", err)
+		}
+
+		if existingDirection == direction {
+			return nil
+		}
+
+		var countsQuery string
+		if existingDirection == "" {
+			_, err = tx.Exec(ctx,
+				`INSERT INTO votes (target_type, target_id, voter_type, voter_id, direction, confirmed)
+				 VALUES ('answer', $1, $2, $3, $4, true)`,
+				answerID, voterType, voterID, direction,
+			)
+			countsQuery = "UPDATE answers SET upvotes = upvotes + 1 WHERE id = $1"
+			if direction == "down" {
+				countsQuery = "UPDATE answers SET downvotes = downvotes + 1 WHERE id = $1"
+			}
+		} else {
+			_, err = tx.Exec(ctx,
+				`UPDATE votes SET direction = $4
+				 WHERE target_type = 'answer' AND target_id = $1
+				 AND voter_type = $2 AND voter_id = $3`,
+				answerID, voterType, voterID, direction,
+			)
+			countsQuery = "UPDATE answers SET upvotes = upvotes + 1, downvotes = GREATEST(downvotes - 1, 0) WHERE id = $1"
+			if direction == "down" {
+				countsQuery = "UPDATE answers SET upvotes = GREATEST(upvotes - 1, 0), downvotes = downvotes + 1 WHERE id = $1"
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("vote on answer: record vote: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, countsQuery, answerID); err != nil {
+			return fmt.Errorf("vote on answer: update counts: %w", err)
+		}
+		return nil
+	})
 }
 
 // ListByAuthor returns answers by a specific author with question title context.
@@ -398,7 +536,7 @@ func (r *AnswersRepository) ListByAuthor(ctx context.Context, authorType, author
 	rows, err := r.pool.Query(ctx, `
 		SELECT
 			ans.id, ans.question_id, ans.author_type, ans.author_id,
-			ans.content, ans.is_accepted, ans.upvotes, ans.downvotes, ans.created_at,
+			ans.content, ans.is_accepted, ans.upvotes, ans.downvotes, ans.created_at, ans.updated_at,
 			COALESCE(
 				CASE WHEN ans.author_type = 'agent' THEN a.display_name
 				     WHEN ans.author_type = 'human' THEN u.display_name
@@ -432,7 +570,7 @@ func (r *AnswersRepository) ListByAuthor(ctx context.Context, authorType, author
 
 		err := rows.Scan(
 			&item.ID, &item.QuestionID, &item.AuthorType, &item.AuthorID,
-			&item.Content, &item.IsAccepted, &item.Upvotes, &item.Downvotes, &item.CreatedAt,
+			&item.Content, &item.IsAccepted, &item.Upvotes, &item.Downvotes, &item.CreatedAt, &item.UpdatedAt,
 			&displayName, &avatarURL, &item.QuestionTitle,
 		)
 		if err != nil {
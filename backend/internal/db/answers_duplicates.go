@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// FindAnswerDuplicates returns visible answers on a question whose embedding has
+// at least minSimilarity cosine similarity to embedding, most similar first.
+// Answers without an embedding are skipped.
+func (r *AnswersRepository) FindAnswerDuplicates(ctx context.Context, questionID, embedding string, minSimilarity float64, limit int) ([]models.AnswerDuplicate, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT ans.id, ans.author_type, ans.author_id, ans.is_accepted,
+		       ans.upvotes - ans.downvotes, 1 - (ans.embedding <=> $2::vector), ans.created_at
+		FROM answers ans
+		WHERE ans.question_id = $1
+		  AND ans.deleted_at IS NULL
+		  AND ans.hidden_at IS NULL
+		  AND ans.embedding IS NOT NULL
+		  AND 1 - (ans.embedding <=> $2::vector) >= $3
+		ORDER BY ans.embedding <=> $2::vector
+		LIMIT $4
+	`, questionID, embedding, minSimilarity, limit)
+	if err != nil {
+		LogQueryError(ctx, "FindAnswerDuplicates", "answers", err)
+		return nil, fmt.Errorf("find answer duplicates: %w", err)
+	}
+	defer rows.Close()
+
+	duplicates := []models.AnswerDuplicate{}
+	for rows.Next() {
+		var d models.AnswerDuplicate
+		if err := rows.Scan(&d.ID, &d.AuthorType, &d.AuthorID, &d.IsAccepted, &d.VoteScore, &d.Similarity, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan answer duplicate: %w", err)
+		}
+		duplicates = append(duplicates, d)
+	}
+	return duplicates, rows.Err()
+}
@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+// TestAnswersRepository_FindAnswerDuplicates tests that only answers on the same
+// question above the similarity threshold are returned, closest first.
+func TestAnswersRepository_FindAnswerDuplicates(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewAnswersRepository(pool)
+	ctx := context.Background()
+
+	var questionID string
+	err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Duplicate answers question', 'How do I close a pgx pool?', 'human', 'dup-test-user', 'open')
+		RETURNING id::text
+	`).Scan(&questionID)
+	if err != nil {
+		t.Fatalf("failed to insert question: %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM answers WHERE question_id = $1", questionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", questionID)
+	}()
+
+	// vector points mostly along the first axis, tilted towards the second by tilt.
+	vector := func(tilt float32) string {
+		v := make([]float32, 1024)
+		v[0], v[1] = 1, tilt
+		return formatVectorLiteral(v)
+	}
+	insertAnswer := func(content, embedding string) string {
+		var id string
+		if err := pool.QueryRow(ctx, `
+			INSERT INTO answers (question_id, author_type, author_id, content, embedding)
+			VALUES ($1, 'agent', 'dup-test-agent', $2, $3::vector)
+			RETURNING id::text
+		`, questionID, content, embedding).Scan(&id); err != nil {
+			t.Fatalf("failed to insert answer: %v", err)
+		}
+		return id
+	}
+	same := insertAnswer("Call pool.Close() on shutdown.", vector(0))
+	nearby := insertAnswer("Close the pool when the server stops.", vector(0.2))
+	insertAnswer("Use a bigger pool.", vector(5))
+
+	duplicates, err := repo.FindAnswerDuplicates(ctx, questionID, vector(0), 0.9, 5)
+	if err != nil {
+		t.Fatalf("FindAnswerDuplicates failed: %v", err)
+	}
+	if len(duplicates) != 2 || duplicates[0].ID != same || duplicates[1].ID != nearby {
+		t.Fatalf("duplicates = %+v, want %s then %s", duplicates, same, nearby)
+	}
+	if duplicates[0].Similarity < 0.999 {
+		t.Errorf("identical embedding similarity = %v, want 1", duplicates[0].Similarity)
+	}
+}
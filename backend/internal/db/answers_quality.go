@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// maxAnswerQualityAttempts is how many failed scoring attempts an answer gets
+// before the answer quality job stops picking it up.
+const maxAnswerQualityAttempts = 3
+
+// ListAnswersNeedingQualityScore returns visible, unscored answers with their
+// question's title and description. Ordered by creation time (oldest first).
+func (r *AnswersRepository) ListAnswersNeedingQualityScore(ctx context.Context, limit int) ([]models.AnswerForScoring, error) {
+	query := `
+		SELECT ans.id, ans.content, p.title, p.description
+		FROM answers ans
+		JOIN posts p ON p.id = ans.question_id
+		WHERE ans.quality_scored_at IS NULL
+		  AND ans.quality_attempts < $2
+		  AND ans.deleted_at IS NULL
+		  AND ans.hidden_at IS NULL
+		  AND p.deleted_at IS NULL
+		ORDER BY ans.created_at ASC
+		LIMIT $1
+	`
+
+	rows, err := r.pool.Query(ctx, query, limit, maxAnswerQualityAttempts)
+	if err != nil {
+		LogQueryError(ctx, "ListAnswersNeedingQualityScore", "answers", err)
+		return nil, fmt.Errorf("list answers needing quality score failed: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []models.AnswerForScoring
+	for rows.Next() {
+		var a models.AnswerForScoring
+		if err := rows.Scan(&a.AnswerID, &a.Content, &a.QuestionTitle, &a.QuestionDescription); err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		answers = append(answers, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return answers, nil
+}
+
+// SetQualityScore stores an answer's quality score. updated_at is left alone
+// because it is the answer's edit version.
+func (r *AnswersRepository) SetQualityScore(ctx context.Context, answerID string, score int) error {
+	query := `
+		UPDATE answers
+		SET quality_score = $2, quality_scored_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.pool.Exec(ctx, query, answerID, score)
+	if err != nil {
+		if isInvalidUUIDError(err) {
+			return ErrAnswerNotFound
+		}
+		LogQueryError(ctx, "SetQualityScore", "answers", err)
+		return fmt.Errorf("set quality score failed: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAnswerNotFound
+	}
+
+	return nil
+}
+
+// IncrementQualityAttempts records a failed scoring attempt for an answer.
+func (r *AnswersRepository) IncrementQualityAttempts(ctx context.Context, answerID string) error {
+	query := `
+		UPDATE answers
+		SET quality_attempts = quality_attempts + 1
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	result, err := r.pool.Exec(ctx, query, answerID)
+	if err != nil {
+		if isInvalidUUIDError(err) {
+			return ErrAnswerNotFound
+		}
+		LogQueryError(ctx, "IncrementQualityAttempts", "answers", err)
+		return fmt.Errorf("increment quality attempts failed: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrAnswerNotFound
+	}
+
+	return nil
+}
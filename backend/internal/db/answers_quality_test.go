@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// TestAnswersRepository_QualityScore_SortAndReset tests that scored answers are
+// no longer candidates, sort=quality puts the best answer first, and editing
+// an answer clears its score.
+func TestAnswersRepository_QualityScore_SortAndReset(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewAnswersRepository(pool)
+	ctx := context.Background()
+
+	var questionID string
+	err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Quality sort question', 'How do I close a pgx pool?', 'human', 'quality-test-user', 'open')
+		RETURNING id::text
+	`).Scan(&questionID)
+	if err != nil {
+		t.Fatalf("failed to insert question: %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM answers WHERE question_id = $1", questionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", questionID)
+	}()
+
+	insertAnswer := func(content string) string {
+		var id string
+		if err := pool.QueryRow(ctx, `
+			INSERT INTO answers (question_id, author_type, author_id, content)
+			VALUES ($1, 'human', 'quality-test-user', $2)
+			RETURNING id::text
+		`, questionID, content).Scan(&id); err != nil {
+			t.Fatalf("failed to insert answer: %v", err)
+		}
+		return id
+	}
+	good := insertAnswer("Call pool.Close() on shutdown; see the pgxpool docs.")
+	meToo := insertAnswer("me too")
+
+	if err := repo.SetQualityScore(ctx, good, 90); err != nil {
+		t.Fatalf("SetQualityScore failed: %v", err)
+	}
+	if err := repo.SetQualityScore(ctx, meToo, 5); err != nil {
+		t.Fatalf("SetQualityScore failed: %v", err)
+	}
+
+	candidates, err := repo.ListAnswersNeedingQualityScore(ctx, 1000)
+	if err != nil {
+		t.Fatalf("ListAnswersNeedingQualityScore failed: %v", err)
+	}
+	for _, c := range candidates {
+		if c.AnswerID == good || c.AnswerID == meToo {
+			t.Errorf("scored answer %s should not be a candidate", c.AnswerID)
+		}
+	}
+
+	answers, _, err := repo.ListAnswers(ctx, questionID, models.AnswerListOptions{Sort: "quality"})
+	if err != nil {
+		t.Fatalf("ListAnswers failed: %v", err)
+	}
+	if len(answers) != 2 || answers[0].ID != good {
+		t.Fatalf("expected highest quality answer first, got %+v", answers)
+	}
+	if answers[0].QualityScore == nil || *answers[0].QualityScore != 90 {
+		t.Errorf("expected quality_score 90, got %v", answers[0].QualityScore)
+	}
+
+	if _, err := repo.UpdateAnswer(ctx, &models.Answer{ID: good, Content: "Edited answer"}); err != nil {
+		t.Fatalf("UpdateAnswer failed: %v", err)
+	}
+	candidates, err = repo.ListAnswersNeedingQualityScore(ctx, 1000)
+	if err != nil {
+		t.Fatalf("ListAnswersNeedingQualityScore failed: %v", err)
+	}
+	found := false
+	for _, c := range candidates {
+		if c.AnswerID == good {
+			found = true
+			if c.QuestionTitle != "Quality sort question" {
+				t.Errorf("expected question title on candidate, got %q", c.QuestionTitle)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected edited answer to need rescoring")
+	}
+}
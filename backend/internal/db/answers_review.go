@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// HoldAnswerForReview hides an answer while an edit to it is re-moderated.
+// Answers already hidden by reports are left alone. Returns true when the
+// answer is held after the call.
+func (r *AnswersRepository) HoldAnswerForReview(ctx context.Context, answerID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE answers
+		SET review_held_at = NOW(), hidden_at = COALESCE(hidden_at, NOW())
+		WHERE id = $1 AND deleted_at IS NULL
+		AND (hidden_at IS NULL OR review_held_at IS NOT NULL)
+	`, answerID)
+	if err != nil {
+		if isInvalidUUIDError(err) {
+			return false, ErrAnswerNotFound
+		}
+		LogQueryError(ctx, "HoldAnswerForReview", "answers", err)
+		return false, fmt.Errorf("hold answer for review failed: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ReleaseAnswerReview ends an answer's review hold once moderation approves the
+// edit. The answer stays hidden if a moderator hid it on reports meanwhile.
+func (r *AnswersRepository) ReleaseAnswerReview(ctx context.Context, answerID string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE answers
+		SET review_held_at = NULL,
+		    hidden_at = CASE WHEN EXISTS (
+		        SELECT 1 FROM reports
+		        WHERE target_type = 'answer' AND target_id = answers.id AND status = 'actioned'
+		    ) THEN hidden_at END
+		WHERE id = $1 AND review_held_at IS NOT NULL
+	`, answerID)
+	if err != nil {
+		if isInvalidUUIDError(err) {
+			return ErrAnswerNotFound
+		}
+		LogQueryError(ctx, "ReleaseAnswerReview", "answers", err)
+		return fmt.Errorf("release answer review failed: %w", err)
+	}
+	return nil
+}
+
+// IsAnswerHeldForReview reports whether an answer is waiting on re-moderation.
+func (r *AnswersRepository) IsAnswerHeldForReview(ctx context.Context, answerID string) (bool, error) {
+	var held bool
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM answers WHERE id = $1 AND review_held_at IS NOT NULL)`,
+		answerID,
+	).Scan(&held)
+	if err != nil {
+		if isInvalidUUIDError(err) {
+			return false, ErrAnswerNotFound
+		}
+		LogQueryError(ctx, "IsAnswerHeldForReview", "answers", err)
+		return false, fmt.Errorf("check answer review hold failed: %w", err)
+	}
+	return held, nil
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// TestAnswersRepository_ReviewHold tests that a held answer drops out of the
+// question's answers until released, and that answers hidden on reports are
+// not taken over by a hold.
+func TestAnswersRepository_ReviewHold(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewAnswersRepository(pool)
+	ctx := context.Background()
+
+	var questionID string
+	err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Review hold question', 'How do I close a pgx pool?', 'human', 'review-hold-user', 'open')
+		RETURNING id::text
+	`).Scan(&questionID)
+	if err != nil {
+		t.Fatalf("failed to insert question: %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM answers WHERE question_id = $1", questionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", questionID)
+	}()
+
+	insertAnswer := func(content string) string {
+		var id string
+		if err := pool.QueryRow(ctx, `
+			INSERT INTO answers (question_id, author_type, author_id, content)
+			VALUES ($1, 'human', 'review-hold-user', $2)
+			RETURNING id::text
+		`, questionID, content).Scan(&id); err != nil {
+			t.Fatalf("failed to insert answer: %v", err)
+		}
+		return id
+	}
+	edited := insertAnswer("Call pool.Close() on shutdown.")
+	reported := insertAnswer("Buy cheap pills here")
+	if _, err := pool.Exec(ctx, "UPDATE answers SET hidden_at = NOW() WHERE id = $1", reported); err != nil {
+		t.Fatalf("failed to hide answer: %v", err)
+	}
+
+	visible := func() int {
+		answers, _, err := repo.ListAnswers(ctx, questionID, models.AnswerListOptions{})
+		if err != nil {
+			t.Fatalf("ListAnswers failed: %v", err)
+		}
+		return len(answers)
+	}
+
+	held, err := repo.HoldAnswerForReview(ctx, edited)
+	if err != nil || !held {
+		t.Fatalf("HoldAnswerForReview = %v, %v; want held", held, err)
+	}
+	if n := visible(); n != 0 {
+		t.Errorf("expected the held answer to be hidden, got %d visible", n)
+	}
+	if held, err := repo.IsAnswerHeldForReview(ctx, edited); err != nil || !held {
+		t.Errorf("IsAnswerHeldForReview = %v, %v; want true", held, err)
+	}
+
+	if held, err := repo.HoldAnswerForReview(ctx, reported); err != nil || held {
+		t.Errorf("HoldAnswerForReview on a reported answer = %v, %v; want not held", held, err)
+	}
+
+	if err := repo.ReleaseAnswerReview(ctx, edited); err != nil {
+		t.Fatalf("ReleaseAnswerReview failed: %v", err)
+	}
+	if err := repo.ReleaseAnswerReview(ctx, reported); err != nil {
+		t.Fatalf("ReleaseAnswerReview failed: %v", err)
+	}
+	if n := visible(); n != 1 {
+		t.Errorf("expected only the released answer to be visible, got %d", n)
+	}
+	if held, err := repo.IsAnswerHeldForReview(ctx, edited); err != nil || held {
+		t.Errorf("IsAnswerHeldForReview after release = %v, %v; want false", held, err)
+	}
+}
@@ -451,3 +451,66 @@ func TestCreateAnswer_DoesNotOverwriteSolvedStatus(t *testing.T) {
 		t.Errorf("expected question status = 'solved', got '%s'", status)
 	}
 }
+
+func TestAnswersRepository_VoteOnAnswer_PerVoterAndRetract(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(pool)
+	answersRepo := NewAnswersRepository(pool)
+
+	question, err := postRepo.Create(ctx, &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Answer vote retraction test",
+		Description:  "Votes on answers are tracked per voter",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_answer_vote",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	answer, err := answersRepo.CreateAnswer(ctx, &models.Answer{
+		QuestionID: question.ID,
+		AuthorType: models.AuthorTypeAgent,
+		AuthorID:   "test_agent_answer_vote",
+		Content:    "An answer to vote on",
+	})
+	if err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM votes WHERE target_id = $1", answer.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM answers WHERE id = $1", answer.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", question.ID)
+	}()
+
+	// Repeating the same vote no longer double counts
+	for i := 0; i < 2; i++ {
+		if err := answersRepo.VoteOnAnswer(ctx, answer.ID, "agent", "voter_answer_1", "up"); err != nil {
+			t.Fatalf("VoteOnAnswer(up) error = %v", err)
+		}
+	}
+	got, err := answersRepo.FindAnswerByID(ctx, answer.ID)
+	if err != nil {
+		t.Fatalf("FindAnswerByID() error = %v", err)
+	}
+	if got.Upvotes != 1 {
+		t.Errorf("expected 1 upvote, got %d", got.Upvotes)
+	}
+
+	if err := answersRepo.VoteOnAnswer(ctx, answer.ID, "agent", "voter_answer_1", VoteDirectionNone); err != nil {
+		t.Fatalf("VoteOnAnswer(none) error = %v", err)
+	}
+	got, err = answersRepo.FindAnswerByID(ctx, answer.ID)
+	if err != nil {
+		t.Fatalf("FindAnswerByID() error = %v", err)
+	}
+	if got.Upvotes != 0 || got.Downvotes != 0 {
+		t.Errorf("expected 0/0 votes after retraction, got %d/%d", got.Upvotes, got.Downvotes)
+	}
+}
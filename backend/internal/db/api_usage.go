@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// APIUsageRepository handles persistence of per-principal API usage counters.
+type APIUsageRepository struct {
+	pool *Pool
+}
+
+// NewAPIUsageRepository creates a new APIUsageRepository.
+func NewAPIUsageRepository(pool *Pool) *APIUsageRepository {
+	return &APIUsageRepository{pool: pool}
+}
+
+// RecordUsage upserts a batch of usage increments into api_usage_daily.
+// Counters are added to any existing row for the same principal, day, and route.
+func (r *APIUsageRepository) RecordUsage(ctx context.Context, increments []models.APIUsageIncrement) error {
+	if len(increments) == 0 {
+		return nil
+	}
+
+	return r.pool.WithTx(ctx, func(tx Tx) error {
+		for _, inc := range increments {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO api_usage_daily (
+					principal_type, principal_id, day, method, route,
+					request_count, error_count, total_duration_ms, max_duration_ms
+				) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+				ON CONFLICT (principal_type, principal_id, day, method, route) DO UPDATE SET
+					request_count = api_usage_daily.request_count + EXCLUDED.request_count,
+					error_count = api_usage_daily.error_count + EXCLUDED.error_count,
+					total_duration_ms = api_usage_daily.total_duration_ms + EXCLUDED.total_duration_ms,
+					max_duration_ms = GREATEST(api_usage_daily.max_duration_ms, EXCLUDED.max_duration_ms),
+					updated_at = NOW()
+			`,
+				inc.PrincipalType, inc.PrincipalID, inc.Day.UTC().Format("2006-01-02"), inc.Method, inc.Route,
+				inc.Requests, inc.Errors, inc.TotalDurationMs, inc.MaxDurationMs,
+			)
+			if err != nil {
+				LogQueryError(ctx, "RecordUsage", "api_usage_daily", err)
+				return fmt.Errorf("record api usage: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListDailyUsage returns usage rows for a principal on or after since (UTC day),
+// ordered by day descending, then by request count descending.
+func (r *APIUsageRepository) ListDailyUsage(ctx context.Context, principalType, principalID string, since time.Time) ([]models.APIUsageRow, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT day, method, route, request_count, error_count, total_duration_ms, max_duration_ms
+		FROM api_usage_daily
+		WHERE principal_type = $1 AND principal_id = $2 AND day >= $3
+		ORDER BY day DESC, request_count DESC, route ASC
+	`, principalType, principalID, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		LogQueryError(ctx, "ListDailyUsage", "api_usage_daily", err)
+		return nil, fmt.Errorf("list api usage: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.APIUsageRow{}
+	for rows.Next() {
+		var row models.APIUsageRow
+		if err := rows.Scan(&row.Day, &row.Method, &row.Route, &row.Requests, &row.Errors, &row.TotalDurationMs, &row.MaxDurationMs); err != nil {
+			return nil, fmt.Errorf("scan api usage: %w", err)
+		}
+		results = append(results, row)
+	}
+	return results, rows.Err()
+}
+
+// PruneOlderThan deletes usage rows for days before cutoff and returns the count removed.
+func (r *APIUsageRepository) PruneOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM api_usage_daily WHERE day < $1`, cutoff.UTC().Format("2006-01-02"))
+	if err != nil {
+		LogQueryError(ctx, "PruneOlderThan", "api_usage_daily", err)
+		return 0, fmt.Errorf("prune api usage: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func setupAPIUsageTest(t *testing.T) (*Pool, *APIUsageRepository) {
+	t.Helper()
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+
+	ctx := context.Background()
+	pool, err := NewPool(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+
+	// Clean up test data
+	_, _ = pool.Exec(ctx, "DELETE FROM api_usage_daily WHERE principal_id LIKE 'test_usage_%'")
+
+	return pool, NewAPIUsageRepository(pool)
+}
+
+func TestAPIUsageRepository_RecordUsageAccumulates(t *testing.T) {
+	pool, repo := setupAPIUsageTest(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	inc := models.APIUsageIncrement{
+		PrincipalType:   "agent",
+		PrincipalID:     "test_usage_agent",
+		Day:             today,
+		Method:          "GET",
+		Route:           "/v1/search",
+		Requests:        3,
+		Errors:          1,
+		TotalDurationMs: 90,
+		MaxDurationMs:   50,
+	}
+
+	if err := repo.RecordUsage(ctx, []models.APIUsageIncrement{inc}); err != nil {
+		t.Fatalf("RecordUsage() error = %v", err)
+	}
+	inc.Requests = 2
+	inc.Errors = 0
+	inc.TotalDurationMs = 200
+	inc.MaxDurationMs = 150
+	if err := repo.RecordUsage(ctx, []models.APIUsageIncrement{inc}); err != nil {
+		t.Fatalf("RecordUsage() second call error = %v", err)
+	}
+
+	rows, err := repo.ListDailyUsage(ctx, "agent", "test_usage_agent", today)
+	if err != nil {
+		t.Fatalf("ListDailyUsage() error = %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	got := rows[0]
+	if got.Requests != 5 || got.Errors != 1 || got.TotalDurationMs != 290 || got.MaxDurationMs != 150 {
+		t.Errorf("unexpected accumulated row: %+v", got)
+	}
+}
+
+func TestAPIUsageRepository_ListDailyUsageWindow(t *testing.T) {
+	pool, repo := setupAPIUsageTest(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	old := today.AddDate(0, 0, -10)
+	err := repo.RecordUsage(ctx, []models.APIUsageIncrement{
+		{PrincipalType: "human", PrincipalID: "test_usage_human", Day: today, Method: "GET", Route: "/v1/feed", Requests: 1},
+		{PrincipalType: "human", PrincipalID: "test_usage_human", Day: old, Method: "GET", Route: "/v1/feed", Requests: 1},
+	})
+	if err != nil {
+		t.Fatalf("RecordUsage() error = %v", err)
+	}
+
+	rows, err := repo.ListDailyUsage(ctx, "human", "test_usage_human", today.AddDate(0, 0, -6))
+	if err != nil {
+		t.Fatalf("ListDailyUsage() error = %v", err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("expected only the in-window row, got %d", len(rows))
+	}
+}
+
+func TestAPIUsageRepository_PruneOlderThan(t *testing.T) {
+	pool, repo := setupAPIUsageTest(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	old := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -200)
+	err := repo.RecordUsage(ctx, []models.APIUsageIncrement{
+		{PrincipalType: "agent", PrincipalID: "test_usage_prune", Day: old, Method: "GET", Route: "/v1/feed", Requests: 1},
+	})
+	if err != nil {
+		t.Fatalf("RecordUsage() error = %v", err)
+	}
+
+	pruned, err := repo.PruneOlderThan(ctx, time.Now().AddDate(0, 0, -90))
+	if err != nil {
+		t.Fatalf("PruneOlderThan() error = %v", err)
+	}
+	if pruned < 1 {
+		t.Errorf("expected at least 1 pruned row, got %d", pruned)
+	}
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ApproachEventsRepository stores approach lifecycle events (migration 000108)
+// for GET /v1/approaches/{id}/timeline.
+type ApproachEventsRepository struct {
+	pool *Pool
+}
+
+// NewApproachEventsRepository creates a new ApproachEventsRepository.
+func NewApproachEventsRepository(pool *Pool) *ApproachEventsRepository {
+	return &ApproachEventsRepository{pool: pool}
+}
+
+// RecordApproachEvent inserts an event and sets its ID and CreatedAt.
+func (r *ApproachEventsRepository) RecordApproachEvent(ctx context.Context, event *models.ApproachEvent) error {
+	data := event.Data
+	if data == nil {
+		data = map[string]any{}
+	}
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO approach_events (approach_id, event_type, actor_type, actor_id, data)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, event.ApproachID, event.Type, event.ActorType, event.ActorID, data).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		LogQueryError(ctx, "RecordApproachEvent", "approach_events", err)
+		return fmt.Errorf("record approach event: %w", err)
+	}
+	return nil
+}
+
+// ListApproachEvents returns an approach's events, oldest first, with the actor's
+// display name.
+func (r *ApproachEventsRepository) ListApproachEvents(ctx context.Context, approachID string) ([]models.ApproachEvent, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+			e.id, e.approach_id, e.event_type, e.actor_type, e.actor_id, e.data, e.created_at,
+			COALESCE(
+				CASE WHEN e.actor_type = 'agent' THEN ag.display_name
+				     WHEN e.actor_type = 'human' THEN u.display_name
+				END,
+				''
+			) AS display_name
+		FROM approach_events e
+		LEFT JOIN agents ag ON e.actor_type = 'agent' AND e.actor_id = ag.id
+		LEFT JOIN users u ON e.actor_type = 'human' AND e.actor_id = u.id::text
+		WHERE e.approach_id = $1
+		ORDER BY e.created_at, e.id
+	`, approachID)
+	if err != nil {
+		LogQueryError(ctx, "ListApproachEvents", "approach_events", err)
+		return nil, fmt.Errorf("list approach events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]models.ApproachEvent, 0)
+	for rows.Next() {
+		var e models.ApproachEvent
+		if err := rows.Scan(&e.ID, &e.ApproachID, &e.Type, &e.ActorType, &e.ActorID, &e.Data, &e.CreatedAt, &e.ActorDisplayName); err != nil {
+			return nil, fmt.Errorf("scan approach event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate approach events: %w", err)
+	}
+	return events, nil
+}
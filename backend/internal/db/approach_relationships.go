@@ -229,6 +229,7 @@ func (r *ApproachRelationshipsRepository) getApproachWithAuthor(ctx context.Cont
 			a.id, a.problem_id, a.author_type, a.author_id,
 			a.angle, a.method, a.status, a.is_latest,
 			a.outcome, a.solution,
+			COALESCE(a.outcome_type, ''), COALESCE(a.failure_reason, ''),
 			a.created_at, a.updated_at, a.deleted_at,
 			a.forget_after, a.archived_at, a.archived_cid,
 			COALESCE(ag.display_name, u.display_name, a.author_id) as author_display_name,
@@ -241,6 +242,7 @@ func (r *ApproachRelationshipsRepository) getApproachWithAuthor(ctx context.Cont
 		&a.ID, &a.ProblemID, &a.AuthorType, &a.AuthorID,
 		&a.Angle, &a.Method, &a.Status, &a.IsLatest,
 		&a.Outcome, &a.Solution,
+		&a.OutcomeType, &a.FailureReason,
 		&createdAt, &updatedAt, &deletedAt,
 		&forgetAfter, &archivedAt, &archivedCID,
 		&authorDisplayName, &authorAvatarURL,
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// ApproachTranscriptRepository stores execution transcripts attached to
+// approaches (migration 000125), gzip-compressed.
+type ApproachTranscriptRepository struct {
+	pool *Pool
+}
+
+// NewApproachTranscriptRepository creates a new ApproachTranscriptRepository.
+func NewApproachTranscriptRepository(pool *Pool) *ApproachTranscriptRepository {
+	return &ApproachTranscriptRepository{pool: pool}
+}
+
+// SaveTranscript stores t.Entries as the approach's transcript, replacing any
+// earlier one, and sets the sizes and timestamps on t.
+func (r *ApproachTranscriptRepository) SaveTranscript(ctx context.Context, t *models.ApproachTranscript) error {
+	raw, err := json.Marshal(t.Entries)
+	if err != nil {
+		return fmt.Errorf("encode transcript: %w", err)
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return fmt.Errorf("compress transcript: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compress transcript: %w", err)
+	}
+
+	t.EntryCount = len(t.Entries)
+	t.SizeBytes = len(raw)
+	t.CompressedBytes = buf.Len()
+	err = r.pool.QueryRow(ctx, `
+		INSERT INTO approach_transcripts (approach_id, content, entry_count, size_bytes, compressed_bytes)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (approach_id) DO UPDATE SET
+			content = EXCLUDED.content, entry_count = EXCLUDED.entry_count,
+			size_bytes = EXCLUDED.size_bytes, compressed_bytes = EXCLUDED.compressed_bytes,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`, t.ApproachID, buf.Bytes(), t.EntryCount, t.SizeBytes, t.CompressedBytes).Scan(&t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		LogQueryError(ctx, "SaveTranscript", "approach_transcripts", err)
+		return fmt.Errorf("save approach transcript: %w", err)
+	}
+	return nil
+}
+
+// FindTranscript returns an approach's transcript with its entries
+// decompressed, or nil if the approach has none.
+func (r *ApproachTranscriptRepository) FindTranscript(ctx context.Context, approachID string) (*models.ApproachTranscript, error) {
+	t := models.ApproachTranscript{ApproachID: approachID}
+	var content []byte
+	err := r.pool.QueryRow(ctx, `
+		SELECT content, entry_count, size_bytes, compressed_bytes, created_at, updated_at
+		FROM approach_transcripts
+		WHERE approach_id = $1
+	`, approachID).Scan(&content, &t.EntryCount, &t.SizeBytes, &t.CompressedBytes, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+			return nil, nil
+		}
+		LogQueryError(ctx, "FindTranscript", "approach_transcripts", err)
+		return nil, fmt.Errorf("find approach transcript: %w", err)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decompress transcript: %w", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompress transcript: %w", err)
+	}
+	if err := json.Unmarshal(raw, &t.Entries); err != nil {
+		return nil, fmt.Errorf("decode transcript: %w", err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestApproachTranscriptRepository_SaveAndFind(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewApproachTranscriptRepository(pool)
+	agent := createInferTestAgent(t, pool, "transcript")
+	defer func() { _, _ = pool.Exec(ctx, "DELETE FROM agents WHERE id = $1", agent.ID) }()
+	problemID := createInferTestPost(t, pool, "agent", agent.ID, []string{"go"})
+	defer func() { _, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", problemID) }()
+	approachID := createInferTestApproach(t, pool, problemID, agent.ID)
+
+	if got, err := repo.FindTranscript(ctx, approachID); err != nil || got != nil {
+		t.Fatalf("FindTranscript() before saving = %+v, %v; want nil, nil", got, err)
+	}
+
+	exitCode := 1
+	output := strings.Repeat("FAIL TestParse\n", 200)
+	transcript := &models.ApproachTranscript{ApproachID: approachID, Entries: []models.TranscriptEntry{
+		{Type: models.TranscriptEntryCommand, Command: "go test ./...", Content: output, ExitCode: &exitCode},
+		{Type: models.TranscriptEntryDiff, Path: "parse.go", Content: "--- a/parse.go\n+++ b/parse.go\n"},
+	}}
+	if err := repo.SaveTranscript(ctx, transcript); err != nil {
+		t.Fatalf("SaveTranscript() error = %v", err)
+	}
+	if transcript.EntryCount != 2 || transcript.CompressedBytes >= transcript.SizeBytes {
+		t.Errorf("SaveTranscript() set %d entries, %d of %d bytes; want 2 entries, compressed", transcript.EntryCount, transcript.CompressedBytes, transcript.SizeBytes)
+	}
+
+	// Attaching again replaces the transcript.
+	transcript.Entries = transcript.Entries[1:]
+	if err := repo.SaveTranscript(ctx, transcript); err != nil {
+		t.Fatalf("SaveTranscript() again error = %v", err)
+	}
+	got, err := repo.FindTranscript(ctx, approachID)
+	if err != nil {
+		t.Fatalf("FindTranscript() error = %v", err)
+	}
+	if got == nil || got.EntryCount != 1 || len(got.Entries) != 1 || got.Entries[0].Path != "parse.go" {
+		t.Errorf("FindTranscript() = %+v, want the replacing diff", got)
+	}
+}
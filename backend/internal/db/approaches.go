@@ -84,11 +84,13 @@ func (r *ApproachesRepository) FindApproachByID(ctx context.Context, id string)
 			a.id, a.problem_id, a.author_type, a.author_id,
 			COALESCE(a.angle, '') as angle, COALESCE(a.method, '') as method, a.assumptions, a.differs_from,
 			a.status, COALESCE(a.outcome, '') as outcome, COALESCE(a.solution, '') as solution,
+			COALESCE(a.outcome_type, '') as outcome_type, COALESCE(a.failure_reason, '') as failure_reason,
 			a.created_at, a.updated_at, a.deleted_at,
 			a.is_latest,
 			a.forget_after,
 			a.archived_at,
 			COALESCE(a.archived_cid, '') as archived_cid,
+			a.long_running,
 			COALESCE(
 				CASE WHEN a.author_type = 'agent' THEN ag.display_name
 				     WHEN a.author_type = 'human' THEN u.display_name
@@ -129,6 +131,8 @@ func (r *ApproachesRepository) FindApproachByID(ctx context.Context, id string)
 		&approach.Status,
 		&approach.Outcome,
 		&approach.Solution,
+		&approach.OutcomeType,
+		&approach.FailureReason,
 		&createdAt,
 		&updatedAt,
 		&deletedAt,
@@ -136,6 +140,7 @@ func (r *ApproachesRepository) FindApproachByID(ctx context.Context, id string)
 		&forgetAfter,
 		&archivedAt,
 		&archivedCID,
+		&approach.LongRunning,
 		&displayName,
 		&avatarURL,
 	)
@@ -176,7 +181,19 @@ func (r *ApproachesRepository) FindApproachByID(ctx context.Context, id string)
 	return &approach, nil
 }
 
-// ListApproaches returns approaches for a problem with pagination.
+// approachVoteScoreSQL is an approach's net vote score. Approaches keep no vote
+// counters, so it is summed from the votes table.
+const approachVoteScoreSQL = `(SELECT COALESCE(SUM(CASE WHEN v.direction = 'up' THEN 1 ELSE -1 END), 0)
+		FROM votes v WHERE v.target_type = 'approach' AND v.target_id = a.id)`
+
+// approachVerifiedSQL is whether the problem owner's latest verification of an
+// approach marked it verified.
+const approachVerifiedSQL = `COALESCE((SELECT (e.data->>'verified')::boolean FROM approach_events e
+		WHERE e.approach_id = a.id AND e.event_type = 'verified'
+		ORDER BY e.created_at DESC LIMIT 1), FALSE)`
+
+// ListApproaches returns approaches for a problem with pagination, ordered by
+// opts.Sort.
 func (r *ApproachesRepository) ListApproaches(ctx context.Context, problemID string, opts models.ApproachListOptions) ([]models.ApproachWithAuthor, int, error) {
 	// Calculate pagination
 	page := opts.Page
@@ -192,12 +209,23 @@ func (r *ApproachesRepository) ListApproaches(ctx context.Context, problemID str
 	}
 	offset := (page - 1) * perPage
 
+	orderBy := "a.created_at DESC"
+	switch opts.Sort {
+	case "oldest":
+		orderBy = "a.created_at ASC"
+	case "top":
+		orderBy = approachVoteScoreSQL + " DESC, a.created_at DESC"
+	case "verified-first":
+		orderBy = approachVerifiedSQL + " DESC, (a.status = 'succeeded') DESC, a.created_at DESC"
+	}
+
 	// Get total count
 	var total int
 	err := r.pool.QueryRow(ctx, `
 		SELECT COUNT(*) FROM approaches
 		WHERE problem_id = $1 AND deleted_at IS NULL
-	`, problemID).Scan(&total)
+		AND ($2 = '' OR outcome_type = $2)
+	`, problemID, string(opts.OutcomeType)).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("count approaches: %w", err)
 	}
@@ -208,11 +236,13 @@ func (r *ApproachesRepository) ListApproaches(ctx context.Context, problemID str
 			a.id, a.problem_id, a.author_type, a.author_id,
 			COALESCE(a.angle, '') as angle, COALESCE(a.method, '') as method, a.assumptions, a.differs_from,
 			a.status, COALESCE(a.outcome, '') as outcome, COALESCE(a.solution, '') as solution,
+			COALESCE(a.outcome_type, '') as outcome_type, COALESCE(a.failure_reason, '') as failure_reason,
 			a.created_at, a.updated_at,
 			a.is_latest,
 			a.forget_after,
 			a.archived_at,
 			COALESCE(a.archived_cid, '') as archived_cid,
+			a.long_running,
 			COALESCE(
 				CASE WHEN a.author_type = 'agent' THEN ag.display_name
 				     WHEN a.author_type = 'human' THEN u.display_name
@@ -231,9 +261,10 @@ func (r *ApproachesRepository) ListApproaches(ctx context.Context, problemID str
 		LEFT JOIN users u ON a.author_type = 'human' AND a.author_id = u.id::text
 		WHERE a.problem_id = $1 AND a.deleted_at IS NULL
 		AND EXISTS (SELECT 1 FROM posts WHERE id = a.problem_id AND visibility = 'public') -- BART-151: approaches inherit the problem's visibility
-		ORDER BY a.created_at DESC
+		AND ($4 = '' OR a.outcome_type = $4)
+		ORDER BY `+orderBy+`
 		LIMIT $2 OFFSET $3
-	`, problemID, perPage, offset)
+	`, problemID, perPage, offset, string(opts.OutcomeType))
 	if err != nil {
 		return nil, 0, fmt.Errorf("query approaches: %w", err)
 	}
@@ -261,12 +292,15 @@ func (r *ApproachesRepository) ListApproaches(ctx context.Context, problemID str
 			&approach.Status,
 			&approach.Outcome,
 			&approach.Solution,
+			&approach.OutcomeType,
+			&approach.FailureReason,
 			&createdAt,
 			&updatedAt,
 			&isLatest,
 			&forgetAfter,
 			&archivedAt,
 			&archivedCID,
+			&approach.LongRunning,
 			&displayName,
 			&avatarURL,
 		)
@@ -304,7 +338,8 @@ func (r *ApproachesRepository) ListApproaches(ctx context.Context, problemID str
 	return approaches, total, nil
 }
 
-// UpdateApproach updates an existing approach and returns it.
+// UpdateApproach updates an existing approach and returns it. An empty
+// OutcomeType keeps the stored one; FailureReason is always written.
 func (r *ApproachesRepository) UpdateApproach(ctx context.Context, approach *models.Approach) (*models.Approach, error) {
 	var updatedAt pgtype.Timestamptz
 
@@ -315,9 +350,13 @@ func (r *ApproachesRepository) UpdateApproach(ctx context.Context, approach *mod
 		    solution = COALESCE($4, solution),
 		    method = COALESCE($5, method),
 		    embedding = COALESCE($6::vector, embedding),
+		    long_running = $7,
+		    outcome_type = COALESCE($8, outcome_type),
+		    failure_reason = $9,
 		    updated_at = NOW()
 		WHERE id = $1 AND deleted_at IS NULL
-		RETURNING status, COALESCE(outcome, '') as outcome, COALESCE(solution, '') as solution, COALESCE(method, '') as method, updated_at
+		RETURNING status, COALESCE(outcome, '') as outcome, COALESCE(solution, '') as solution, COALESCE(method, '') as method, long_running,
+		          COALESCE(outcome_type, '') as outcome_type, COALESCE(failure_reason, '') as failure_reason, updated_at
 	`,
 		approach.ID,
 		approach.Status,
@@ -325,11 +364,17 @@ func (r *ApproachesRepository) UpdateApproach(ctx context.Context, approach *mod
 		nullIfEmpty(approach.Solution),
 		nullIfEmpty(approach.Method),
 		approach.EmbeddingStr,
+		approach.LongRunning,
+		nullIfEmpty(string(approach.OutcomeType)),
+		nullIfEmpty(string(approach.FailureReason)),
 	).Scan(
 		&approach.Status,
 		&approach.Outcome,
 		&approach.Solution,
 		&approach.Method,
+		&approach.LongRunning,
+		&approach.OutcomeType,
+		&approach.FailureReason,
 		&updatedAt,
 	)
 
@@ -392,11 +437,13 @@ func (r *ApproachesRepository) ListByAuthor(ctx context.Context, authorType, aut
 			a.id, a.problem_id, a.author_type, a.author_id,
 			COALESCE(a.angle, '') as angle, COALESCE(a.method, '') as method, a.assumptions, a.differs_from,
 			a.status, COALESCE(a.outcome, '') as outcome, COALESCE(a.solution, '') as solution,
+			COALESCE(a.outcome_type, '') as outcome_type, COALESCE(a.failure_reason, '') as failure_reason,
 			a.created_at, a.updated_at,
 			a.is_latest,
 			a.forget_after,
 			a.archived_at,
 			COALESCE(a.archived_cid, '') as archived_cid,
+			a.long_running,
 			COALESCE(
 				CASE WHEN a.author_type = 'agent' THEN ag.display_name
 				     WHEN a.author_type = 'human' THEN u.display_name
@@ -433,9 +480,9 @@ func (r *ApproachesRepository) ListByAuthor(ctx context.Context, authorType, aut
 		err := rows.Scan(
 			&item.ID, &item.ProblemID, &item.AuthorType, &item.AuthorID,
 			&item.Angle, &item.Method, &assumptions, &differsFrom,
-			&item.Status, &item.Outcome, &item.Solution,
+			&item.Status, &item.Outcome, &item.Solution, &item.OutcomeType, &item.FailureReason,
 			&createdAt, &updatedAt,
-			&isLatest, &forgetAfter, &archivedAt, &archivedCID,
+			&isLatest, &forgetAfter, &archivedAt, &archivedCID, &item.LongRunning,
 			&displayName, &avatarURL, &item.ProblemTitle,
 		)
 		if err != nil {
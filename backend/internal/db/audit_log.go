@@ -0,0 +1,149 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// AuditLogRepository handles persistence of append-only audit entries.
+type AuditLogRepository struct {
+	pool *Pool
+}
+
+// NewAuditLogRepository creates a new AuditLogRepository.
+func NewAuditLogRepository(pool *Pool) *AuditLogRepository {
+	return &AuditLogRepository{pool: pool}
+}
+
+// Create appends an entry to audit_log and fills in its ID and CreatedAt.
+// Entries can never be updated or deleted (enforced by a trigger).
+func (r *AuditLogRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	var details []byte
+	if len(entry.Details) > 0 {
+		var err error
+		details, err = json.Marshal(entry.Details)
+		if err != nil {
+			return fmt.Errorf("marshal audit details: %w", err)
+		}
+	}
+
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO audit_log (
+			actor_type, actor_id, action, route, path, target_type, target_id,
+			status_code, details, ip_address, request_id
+		) VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8, $9, NULLIF($10, '')::inet, NULLIF($11, ''))
+		RETURNING id, created_at
+	`,
+		entry.ActorType, entry.ActorID, entry.Action, entry.Route, entry.Path, entry.TargetType, entry.TargetID,
+		entry.StatusCode, details, entry.IPAddress, entry.RequestID,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		LogQueryError(ctx, "Create", "audit_log", err)
+		return fmt.Errorf("create audit entry: %w", err)
+	}
+	return nil
+}
+
+// List returns audit entries matching the filters, newest first, with the total count.
+// FromDate is inclusive and ToDate exclusive.
+func (r *AuditLogRepository) List(ctx context.Context, opts models.AuditListOptions) ([]models.AuditLog, int, error) {
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := opts.PerPage
+	if perPage < 1 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	// Only rows written by the audit middleware; legacy admin rows have no actor
+	conditions := []string{"actor_type IS NOT NULL"}
+	var args []any
+	argNum := 1
+
+	addFilter := func(column, value string) {
+		if value == "" {
+			return
+		}
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, argNum))
+		args = append(args, value)
+		argNum++
+	}
+	addFilter("actor_type", opts.ActorType)
+	addFilter("actor_id", opts.ActorID)
+	addFilter("action", opts.Action)
+	addFilter("route", opts.Route)
+	addFilter("target_type", opts.TargetType)
+	addFilter("target_id", opts.TargetID)
+
+	if opts.FromDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argNum))
+		args = append(args, *opts.FromDate)
+		argNum++
+	}
+	if opts.ToDate != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argNum))
+		args = append(args, *opts.ToDate)
+		argNum++
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.pool.QueryRow(ctx, "SELECT COUNT(*) FROM audit_log "+where, args...).Scan(&total); err != nil {
+		LogQueryError(ctx, "List.Count", "audit_log", err)
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, actor_type, actor_id, action, COALESCE(route, ''), COALESCE(path, ''),
+		       COALESCE(target_type, ''), COALESCE(target_id, ''), COALESCE(status_code, 0),
+		       details, COALESCE(host(ip_address), ''), COALESCE(request_id, ''), created_at
+		FROM audit_log
+		%s
+		ORDER BY created_at DESC, id
+		LIMIT $%d OFFSET $%d
+	`, where, argNum, argNum+1)
+	args = append(args, perPage, (page-1)*perPage)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		LogQueryError(ctx, "List", "audit_log", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []models.AuditLog{}
+	for rows.Next() {
+		var e models.AuditLog
+		var details []byte
+		if err := rows.Scan(
+			&e.ID, &e.ActorType, &e.ActorID, &e.Action, &e.Route, &e.Path,
+			&e.TargetType, &e.TargetID, &e.StatusCode,
+			&details, &e.IPAddress, &e.RequestID, &e.CreatedAt,
+		); err != nil {
+			LogQueryError(ctx, "List.Scan", "audit_log", err)
+			return nil, 0, err
+		}
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &e.Details); err != nil {
+				return nil, 0, fmt.Errorf("unmarshal audit details: %w", err)
+			}
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		LogQueryError(ctx, "List.Rows", "audit_log", err)
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func setupAuditLogTest(t *testing.T) (*Pool, *AuditLogRepository) {
+	t.Helper()
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+
+	pool, err := NewPool(context.Background(), databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	return pool, NewAuditLogRepository(pool)
+}
+
+func TestAuditLogRepository_CreateAndList(t *testing.T) {
+	pool, repo := setupAuditLogTest(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	// Entries can't be deleted, so each run uses its own actor
+	actorID := fmt.Sprintf("test_audit_%d", time.Now().UnixNano())
+
+	entry := &models.AuditLog{
+		ActorType:  "agent",
+		ActorID:    actorID,
+		Action:     "PATCH",
+		Route:      "/v1/posts/{id}",
+		Path:       "/v1/posts/post-1",
+		TargetType: "posts",
+		TargetID:   "post-1",
+		StatusCode: 200,
+		Details:    map[string]interface{}{"fields": []interface{}{"title"}},
+		IPAddress:  "192.0.2.10",
+		RequestID:  "req-1",
+	}
+	if err := repo.Create(ctx, entry); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if entry.ID == "" || entry.CreatedAt.IsZero() {
+		t.Fatalf("Create() did not fill ID/CreatedAt: %+v", entry)
+	}
+	if err := repo.Create(ctx, &models.AuditLog{
+		ActorType: "agent", ActorID: actorID, Action: "DELETE", Route: "/v1/posts/{id}", StatusCode: 204,
+	}); err != nil {
+		t.Fatalf("Create() second entry error = %v", err)
+	}
+
+	entries, total, err := repo.List(ctx, models.AuditListOptions{ActorID: actorID, Action: "PATCH"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if total != 1 || len(entries) != 1 {
+		t.Fatalf("List() = %d entries (total %d), want 1", len(entries), total)
+	}
+	got := entries[0]
+	if got.TargetID != "post-1" || got.IPAddress != "192.0.2.10" || got.RequestID != "req-1" || got.StatusCode != 200 {
+		t.Errorf("unexpected entry: %+v", got)
+	}
+	if fields, _ := got.Details["fields"].([]interface{}); len(fields) != 1 || fields[0] != "title" {
+		t.Errorf("Details = %v, want fields [title]", got.Details)
+	}
+
+	_, total, err = repo.List(ctx, models.AuditListOptions{ActorType: "agent", ActorID: actorID})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if total != 2 {
+		t.Errorf("List() total = %d, want 2", total)
+	}
+}
+
+func TestAuditLogRepository_AppendOnly(t *testing.T) {
+	pool, repo := setupAuditLogTest(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	entry := &models.AuditLog{
+		ActorType: "human", ActorID: fmt.Sprintf("test_audit_%d", time.Now().UnixNano()),
+		Action: "POST", Route: "/v1/posts", StatusCode: 201,
+	}
+	if err := repo.Create(ctx, entry); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := pool.Exec(ctx, `UPDATE audit_log SET action = 'GET' WHERE id = $1`, entry.ID); err == nil {
+		t.Error("expected UPDATE on audit_log to fail")
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM audit_log WHERE id = $1`, entry.ID); err == nil {
+		t.Error("expected DELETE on audit_log to fail")
+	}
+}
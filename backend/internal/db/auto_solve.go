@@ -98,7 +98,7 @@ func (r *AutoSolveRepository) WarnProblemsApproachingAutoSolve(ctx context.Conte
 }
 
 // AutoSolveProblems finds problems with succeeded approaches older than
-// olderThan and transitions them to "solved" status. Returns the number
+// olderThan and all success criteria met, and transitions them to "solved" status. Returns the number
 // of problems auto-solved.
 func (r *AutoSolveRepository) AutoSolveProblems(ctx context.Context, olderThan time.Duration) (int64, error) {
 	cutoff := time.Now().Add(-olderThan)
@@ -114,6 +114,7 @@ func (r *AutoSolveRepository) AutoSolveProblems(ctx context.Context, olderThan t
 		  AND a.status = 'succeeded'
 		  AND a.deleted_at IS NULL
 		  AND a.updated_at < $1
+		  AND NOT EXISTS (SELECT 1 FROM success_criteria sc WHERE sc.post_id = p.id AND NOT sc.met)
 		ORDER BY p.id
 	`, cutoff)
 	if err != nil {
@@ -185,8 +185,9 @@ func (r *BlogPostRepository) FindBySlug(ctx context.Context, slug string) (*mode
 	return r.findBySlugInternal(ctx, slug, "", "")
 }
 
-// FindBySlugForViewer returns a blog post by slug with the viewer's vote included.
-func (r *BlogPostRepository) FindBySlugForViewer(ctx context.Context, slug string, viewerType models.AuthorType, viewerID string) (*models.BlogPostWithAuthor, error) {
+// FindBySlugForViewer returns a blog post by slug with the vote of the caller in ctx included.
+func (r *BlogPostRepository) FindBySlugForViewer(ctx context.Context, slug string) (*models.BlogPostWithAuthor, error) {
+	viewerType, viewerID, _ := viewerFromContext(ctx)
 	return r.findBySlugInternal(ctx, slug, viewerType, viewerID)
 }
 
@@ -390,13 +391,13 @@ func (r *BlogPostRepository) List(ctx context.Context, opts models.BlogPostListO
 
 	// Viewer vote column and JOIN
 	var viewerVoteColumn, viewerVoteJoin string
-	if opts.ViewerType != "" && opts.ViewerID != "" {
+	if viewerType, viewerID, _ := viewerFromContext(ctx); viewerType != "" && viewerID != "" {
 		viewerVoteColumn = "v.direction as user_vote_direction"
 		viewerVoteJoin = fmt.Sprintf(
 			`LEFT JOIN votes v ON v.target_type = 'blog_post' AND v.target_id = bp.id AND v.voter_type = $%d AND v.voter_id = $%d`,
 			argNum, argNum+1,
 		)
-		args = append(args, string(opts.ViewerType), opts.ViewerID)
+		args = append(args, string(viewerType), viewerID)
 		argNum += 2
 	} else {
 		viewerVoteColumn = "NULL::text as user_vote_direction"
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -235,7 +236,8 @@ func TestBlogPostRepository_FindBySlugForViewer(t *testing.T) {
 	}
 
 	// Find with viewer context
-	found, err := repo.FindBySlugForViewer(ctx, slug, models.AuthorTypeAgent, agentID)
+	viewerCtx := auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeAgent, ID: agentID})
+	found, err := repo.FindBySlugForViewer(viewerCtx, slug)
 	if err != nil {
 		t.Fatalf("FindBySlugForViewer failed: %v", err)
 	}
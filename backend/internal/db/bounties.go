@@ -0,0 +1,78 @@
+// Package db provides database access for Solvr.
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrBountyNotRaised is returned when a bounty update would not increase the
+// problem's weight (it is already at least that high, or the problem is gone).
+var ErrBountyNotRaised = errors.New("bounty can only be increased")
+
+// BountyRepository handles problem bounties, which are stored as posts.weight.
+// It implements the jobs.BountyDecayer interface.
+type BountyRepository struct {
+	pool *Pool
+}
+
+// NewBountyRepository creates a new BountyRepository.
+func NewBountyRepository(pool *Pool) *BountyRepository {
+	return &BountyRepository{pool: pool}
+}
+
+// RaiseWeight sets a problem's weight if it is higher than the current one.
+// The comparison happens in the UPDATE so concurrent raises cannot lower it.
+// Returns ErrBountyNotRaised if nothing was updated.
+func (r *BountyRepository) RaiseWeight(ctx context.Context, problemID string, weight int) (time.Time, error) {
+	var updatedAt time.Time
+	err := r.pool.QueryRow(ctx, `
+		UPDATE posts
+		SET weight = $2, weight_updated_at = NOW()
+		WHERE id = $1
+		  AND type = 'problem'
+		  AND deleted_at IS NULL
+		  AND COALESCE(weight, 0) < $2
+		RETURNING weight_updated_at
+	`, problemID, weight).Scan(&updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, ErrBountyNotRaised
+		}
+		LogQueryError(ctx, "RaiseWeight", "posts", err)
+		return time.Time{}, fmt.Errorf("failed to raise weight: %w", err)
+	}
+	return updatedAt, nil
+}
+
+// DecayInactiveWeights lowers by one the weight of unsolved problems that had
+// no approach activity and no weight change for inactiveFor. Weight never goes
+// below 1. Returns the number of problems decayed.
+func (r *BountyRepository) DecayInactiveWeights(ctx context.Context, inactiveFor time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-inactiveFor)
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE posts p
+		SET weight = p.weight - 1, weight_updated_at = NOW()
+		WHERE p.type = 'problem'
+		  AND p.weight > 1
+		  AND p.status IN ('open', 'in_progress', 'dormant')
+		  AND p.deleted_at IS NULL
+		  AND COALESCE(p.weight_updated_at, p.created_at) < $1
+		  AND NOT EXISTS (
+		    SELECT 1 FROM approaches a
+		    WHERE a.problem_id = p.id
+		      AND a.deleted_at IS NULL
+		      AND a.updated_at >= $1
+		  )
+	`, cutoff)
+	if err != nil {
+		LogQueryError(ctx, "DecayInactiveWeights", "posts", err)
+		return 0, fmt.Errorf("failed to decay weights: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// changeEventSequencerLockID serializes sequence assignment so seq order is commit order.
+const changeEventSequencerLockID = 7_117_000_117
+
+// changeEventSequenceBatch caps the events sequenced per statement.
+const changeEventSequenceBatch = 5000
+
+// ChangeEventRepository reads the append-only change event log written by the
+// trigger in migration 000117.
+type ChangeEventRepository struct {
+	pool *Pool
+}
+
+// NewChangeEventRepository creates a new ChangeEventRepository.
+func NewChangeEventRepository(pool *Pool) *ChangeEventRepository {
+	return &ChangeEventRepository{pool: pool}
+}
+
+// AssignSequence gives every committed event without a seq the next sequence
+// numbers, in the order the events were written. Events of transactions still
+// in flight are invisible here and get a higher seq once they commit, so a
+// reader never sees a seq appear below one it has already read.
+// Returns the number of events sequenced.
+func (r *ChangeEventRepository) AssignSequence(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		var n int64
+		err := r.pool.WithTx(ctx, func(tx Tx) error {
+			if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", changeEventSequencerLockID); err != nil {
+				return fmt.Errorf("lock change event sequencer: %w", err)
+			}
+			// A fresh statement after the lock sees events sequenced by the previous holder.
+			tag, err := tx.Exec(ctx, `
+				UPDATE change_events c
+				SET seq = s.seq
+				FROM (
+					SELECT id, nextval('change_events_seq') AS seq
+					FROM (
+						SELECT id FROM change_events
+						WHERE seq IS NULL
+						ORDER BY id
+						LIMIT $1
+					) pending
+				) s
+				WHERE c.id = s.id`, changeEventSequenceBatch)
+			if err != nil {
+				return fmt.Errorf("assign change event seq: %w", err)
+			}
+			n = tag.RowsAffected()
+			return nil
+		})
+		if err != nil {
+			LogQueryError(ctx, "AssignSequence", "change_events", err)
+			return total, err
+		}
+		total += int(n)
+		if n < changeEventSequenceBatch {
+			return total, nil
+		}
+	}
+}
+
+// ListSince returns up to limit events with seq greater than since, oldest
+// first, optionally restricted to entities. Pending events are sequenced first.
+func (r *ChangeEventRepository) ListSince(ctx context.Context, since int64, entities []string, limit int) ([]models.ChangeEvent, error) {
+	if _, err := r.AssignSequence(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT seq, entity, entity_id, op, payload, created_at
+		FROM change_events
+		WHERE seq > $1
+		  AND (cardinality($2::text[]) = 0 OR entity = ANY($2))
+		ORDER BY seq
+		LIMIT $3`, since, entities, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListSince", "change_events", err)
+		return nil, fmt.Errorf("list change events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.ChangeEvent{}
+	for rows.Next() {
+		var e models.ChangeEvent
+		var payload []byte
+		if err := rows.Scan(&e.Seq, &e.Entity, &e.EntityID, &e.Op, &payload, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan change event: %w", err)
+		}
+		e.Payload = payload
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// LatestSeq returns the highest assigned seq, or 0 if the log is empty.
+// Pending events are sequenced first.
+func (r *ChangeEventRepository) LatestSeq(ctx context.Context) (int64, error) {
+	if _, err := r.AssignSequence(ctx); err != nil {
+		return 0, err
+	}
+	var seq int64
+	if err := r.pool.QueryRow(ctx, `SELECT COALESCE(MAX(seq), 0) FROM change_events`).Scan(&seq); err != nil {
+		LogQueryError(ctx, "LatestSeq", "change_events", err)
+		return 0, fmt.Errorf("latest change event seq: %w", err)
+	}
+	return seq, nil
+}
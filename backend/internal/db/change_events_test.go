@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestChangeEventRepository_ListSince(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+	ctx := context.Background()
+	repo := NewChangeEventRepository(pool)
+
+	// Start after everything already logged.
+	existing, err := repo.ListSince(ctx, 0, nil, 1_000_000)
+	if err != nil {
+		t.Fatalf("ListSince() error = %v", err)
+	}
+	var since int64
+	if len(existing) > 0 {
+		since = existing[len(existing)-1].Seq
+	}
+
+	var postID string
+	err = pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Change event question title', 'Change event question body long enough', 'human', 'test-user', 'open')
+		RETURNING id::text`).Scan(&postID)
+	if err != nil {
+		t.Fatalf("insert post error = %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM posts WHERE id = $1`, postID)
+	})
+	// Counter-only updates are not recorded.
+	if _, err := pool.Exec(ctx, `UPDATE posts SET view_count = view_count + 1 WHERE id = $1`, postID); err != nil {
+		t.Fatalf("update view_count error = %v", err)
+	}
+	if _, err := pool.Exec(ctx, `UPDATE posts SET title = 'Change event question title edited' WHERE id = $1`, postID); err != nil {
+		t.Fatalf("update title error = %v", err)
+	}
+	if _, err := pool.Exec(ctx, `DELETE FROM posts WHERE id = $1`, postID); err != nil {
+		t.Fatalf("delete post error = %v", err)
+	}
+
+	events, err := repo.ListSince(ctx, since, []string{"post"}, 100)
+	if err != nil {
+		t.Fatalf("ListSince() error = %v", err)
+	}
+	var ops []string
+	for i, e := range events {
+		if i > 0 && e.Seq <= events[i-1].Seq {
+			t.Errorf("seq %d follows %d, want increasing", e.Seq, events[i-1].Seq)
+		}
+		if e.EntityID != postID {
+			continue
+		}
+		ops = append(ops, e.Op)
+		var payload map[string]any
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			t.Fatalf("payload unmarshal error = %v", err)
+		}
+		if _, ok := payload["view_count"]; ok {
+			t.Error("payload includes view_count, want it dropped")
+		}
+	}
+	if len(ops) != 3 || ops[0] != "insert" || ops[1] != "update" || ops[2] != "delete" {
+		t.Errorf("ops = %v, want [insert update delete]", ops)
+	}
+
+	// Events are append-only.
+	if len(events) > 0 {
+		if _, err := pool.Exec(ctx, `DELETE FROM change_events WHERE seq = $1`, events[0].Seq); err == nil {
+			t.Error("deleting a change event succeeded, want error")
+		}
+	}
+}
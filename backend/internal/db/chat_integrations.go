@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrChatIntegrationNotFound is returned when a chat integration doesn't exist.
+var ErrChatIntegrationNotFound = errors.New("chat integration not found")
+
+const chatIntegrationColumns = `id, name, provider, webhook_url, tags, post_types, enabled,
+	failure_count, last_error, last_delivered_at, created_at, updated_at`
+
+// ChatIntegrationRepository handles persistence of Slack/Discord integrations.
+type ChatIntegrationRepository struct {
+	pool *Pool
+}
+
+// NewChatIntegrationRepository creates a new ChatIntegrationRepository.
+func NewChatIntegrationRepository(pool *Pool) *ChatIntegrationRepository {
+	return &ChatIntegrationRepository{pool: pool}
+}
+
+// Create inserts a new integration and returns it with generated fields set.
+func (r *ChatIntegrationRepository) Create(ctx context.Context, integration *models.ChatIntegration) (*models.ChatIntegration, error) {
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO chat_integrations (name, provider, webhook_url, tags, post_types, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+chatIntegrationColumns,
+		integration.Name, integration.Provider, integration.WebhookURL,
+		integration.Tags, integration.PostTypes, integration.Enabled)
+	created, err := scanChatIntegration(row)
+	if err != nil {
+		return nil, fmt.Errorf("create chat integration: %w", err)
+	}
+	return created, nil
+}
+
+// FindByID returns one integration.
+func (r *ChatIntegrationRepository) FindByID(ctx context.Context, id string) (*models.ChatIntegration, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+chatIntegrationColumns+` FROM chat_integrations WHERE id = $1`, id)
+	integration, err := scanChatIntegration(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrChatIntegrationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find chat integration: %w", err)
+	}
+	return integration, nil
+}
+
+// List returns every integration, newest first.
+func (r *ChatIntegrationRepository) List(ctx context.Context) ([]models.ChatIntegration, error) {
+	return r.query(ctx, `SELECT `+chatIntegrationColumns+` FROM chat_integrations ORDER BY created_at DESC`)
+}
+
+// ListMatching returns enabled integrations watching postType that share at least
+// one tag with tags.
+func (r *ChatIntegrationRepository) ListMatching(ctx context.Context, postType string, tags []string) ([]models.ChatIntegration, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return r.query(ctx, `
+		SELECT `+chatIntegrationColumns+`
+		FROM chat_integrations
+		WHERE enabled AND tags && $1 AND $2 = ANY(post_types)
+		ORDER BY created_at`, tags, postType)
+}
+
+// Update applies the non-nil fields of update and returns the updated integration.
+func (r *ChatIntegrationRepository) Update(ctx context.Context, id string, update models.ChatIntegrationUpdate) (*models.ChatIntegration, error) {
+	row := r.pool.QueryRow(ctx, `
+		UPDATE chat_integrations SET
+			name        = COALESCE($2, name),
+			webhook_url = COALESCE($3, webhook_url),
+			tags        = COALESCE($4, tags),
+			post_types  = COALESCE($5, post_types),
+			enabled     = COALESCE($6, enabled),
+			-- Re-enabling or pointing at a new URL starts the failure count over.
+			failure_count = CASE WHEN $3::text IS NOT NULL OR $6::boolean THEN 0 ELSE failure_count END,
+			updated_at  = NOW()
+		WHERE id = $1
+		RETURNING `+chatIntegrationColumns,
+		id, update.Name, update.WebhookURL, update.Tags, update.PostTypes, update.Enabled)
+	integration, err := scanChatIntegration(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrChatIntegrationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update chat integration: %w", err)
+	}
+	return integration, nil
+}
+
+// Delete removes an integration.
+func (r *ChatIntegrationRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM chat_integrations WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete chat integration: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrChatIntegrationNotFound
+	}
+	return nil
+}
+
+// RecordDelivery stores the outcome of a webhook post. A success clears the error
+// and failure count; after maxFailures consecutive failures the integration is disabled.
+func (r *ChatIntegrationRepository) RecordDelivery(ctx context.Context, id string, deliveryErr error, maxFailures int) error {
+	var err error
+	if deliveryErr == nil {
+		_, err = r.pool.Exec(ctx, `
+			UPDATE chat_integrations
+			SET failure_count = 0, last_error = NULL, last_delivered_at = $2
+			WHERE id = $1`, id, time.Now())
+	} else {
+		_, err = r.pool.Exec(ctx, `
+			UPDATE chat_integrations
+			SET failure_count = failure_count + 1,
+			    last_error = $2,
+			    enabled = enabled AND failure_count + 1 < $3
+			WHERE id = $1`, id, deliveryErr.Error(), maxFailures)
+	}
+	if err != nil {
+		return fmt.Errorf("record chat integration delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *ChatIntegrationRepository) query(ctx context.Context, sql string, args ...any) ([]models.ChatIntegration, error) {
+	rows, err := r.pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list chat integrations: %w", err)
+	}
+	defer rows.Close()
+
+	integrations := []models.ChatIntegration{}
+	for rows.Next() {
+		integration, err := scanChatIntegration(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan chat integration: %w", err)
+		}
+		integrations = append(integrations, *integration)
+	}
+	return integrations, rows.Err()
+}
+
+func scanChatIntegration(row pgx.Row) (*models.ChatIntegration, error) {
+	var c models.ChatIntegration
+	err := row.Scan(&c.ID, &c.Name, &c.Provider, &c.WebhookURL, &c.Tags, &c.PostTypes, &c.Enabled,
+		&c.FailureCount, &c.LastError, &c.LastDeliveredAt, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestChatIntegrationRepository_Lifecycle(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewChatIntegrationRepository(pool)
+	tag := "chatint" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	created, err := repo.Create(ctx, &models.ChatIntegration{
+		Name:       "test",
+		Provider:   models.ChatProviderSlack,
+		WebhookURL: "https://hooks.slack.com/services/T/B/x",
+		Tags:       []string{tag},
+		PostTypes:  []string{"question"},
+		Enabled:    true,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer repo.Delete(ctx, created.ID)
+
+	matches := func(postType string) bool {
+		t.Helper()
+		list, err := repo.ListMatching(ctx, postType, []string{"unrelated", tag})
+		if err != nil {
+			t.Fatalf("ListMatching() error = %v", err)
+		}
+		for _, c := range list {
+			if c.ID == created.ID {
+				return true
+			}
+		}
+		return false
+	}
+	if !matches("question") {
+		t.Error("expected integration to match a question with its tag")
+	}
+	if matches("problem") {
+		t.Error("expected integration not to match a problem")
+	}
+
+	// Failures accumulate and disable the integration at the limit.
+	for i := 0; i < 2; i++ {
+		if err := repo.RecordDelivery(ctx, created.ID, errors.New("boom"), 2); err != nil {
+			t.Fatalf("RecordDelivery() error = %v", err)
+		}
+	}
+	got, err := repo.FindByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if got.Enabled || got.FailureCount != 2 || got.LastError == nil {
+		t.Errorf("expected disabled after 2 failures, got %+v", got)
+	}
+
+	enabled := true
+	updated, err := repo.Update(ctx, created.ID, models.ChatIntegrationUpdate{Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !updated.Enabled || updated.FailureCount != 0 || updated.Name != "test" {
+		t.Errorf("expected re-enabled with reset failures, got %+v", updated)
+	}
+
+	if err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.FindByID(ctx, created.ID); !errors.Is(err, ErrChatIntegrationNotFound) {
+		t.Errorf("expected ErrChatIntegrationNotFound, got %v", err)
+	}
+}
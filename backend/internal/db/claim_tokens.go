@@ -27,17 +27,21 @@ var ErrDuplicateClaimToken = errors.New("claim token already exists")
 // ErrClaimTokenNotFound is returned when a claim token is not found.
 var ErrClaimTokenNotFound = errors.New("claim token not found")
 
-// Create inserts a new claim token into the database.
+// ErrClaimTokenUsed is returned when a claim token has already been used.
+var ErrClaimTokenUsed = errors.New("claim token already used")
+
+// Create inserts a new claim token into the database. The token targets either
+// token.AgentID or, for guest posts, token.PostID.
 // The token's ID and CreatedAt fields are populated from the database after insertion.
 // Returns ErrDuplicateClaimToken if the token value already exists.
 func (r *ClaimTokenRepository) Create(ctx context.Context, token *models.ClaimToken) error {
 	query := `
-		INSERT INTO claim_tokens (token, agent_id, expires_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO claim_tokens (token, agent_id, post_id, email, expires_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, '')::uuid, NULLIF($4, ''), $5)
 		RETURNING id, created_at
 	`
 
-	err := r.pool.QueryRow(ctx, query, token.Token, token.AgentID, token.ExpiresAt).
+	err := r.pool.QueryRow(ctx, query, token.Token, token.AgentID, token.PostID, token.Email, token.ExpiresAt).
 		Scan(&token.ID, &token.CreatedAt)
 
 	if err != nil {
@@ -59,7 +63,8 @@ func (r *ClaimTokenRepository) Create(ctx context.Context, token *models.ClaimTo
 // Per prd-v2.json: SELECT * FROM claim_tokens WHERE token = $1
 func (r *ClaimTokenRepository) FindByToken(ctx context.Context, tokenValue string) (*models.ClaimToken, error) {
 	query := `
-		SELECT id, token, agent_id, expires_at, used_at, used_by_human_id, created_at
+		SELECT id, token, COALESCE(agent_id, ''), COALESCE(post_id::text, ''), COALESCE(email, ''),
+		       expires_at, used_at, used_by_human_id, created_at
 		FROM claim_tokens
 		WHERE token = $1
 	`
@@ -69,6 +74,8 @@ func (r *ClaimTokenRepository) FindByToken(ctx context.Context, tokenValue strin
 		&token.ID,
 		&token.Token,
 		&token.AgentID,
+		&token.PostID,
+		&token.Email,
 		&token.ExpiresAt,
 		&token.UsedAt,
 		&token.UsedByHumanID,
@@ -91,7 +98,8 @@ func (r *ClaimTokenRepository) FindByToken(ctx context.Context, tokenValue strin
 // Per prd-v2.json: Query for unexpired, unused tokens.
 func (r *ClaimTokenRepository) FindActiveByAgentID(ctx context.Context, agentID string) (*models.ClaimToken, error) {
 	query := `
-		SELECT id, token, agent_id, expires_at, used_at, used_by_human_id, created_at
+		SELECT id, token, COALESCE(agent_id, ''), COALESCE(post_id::text, ''), COALESCE(email, ''),
+		       expires_at, used_at, used_by_human_id, created_at
 		FROM claim_tokens
 		WHERE agent_id = $1 AND used_at IS NULL AND expires_at > NOW()
 		ORDER BY created_at DESC
@@ -103,6 +111,8 @@ func (r *ClaimTokenRepository) FindActiveByAgentID(ctx context.Context, agentID
 		&token.ID,
 		&token.Token,
 		&token.AgentID,
+		&token.PostID,
+		&token.Email,
 		&token.ExpiresAt,
 		&token.UsedAt,
 		&token.UsedByHumanID,
@@ -144,6 +154,42 @@ func (r *ClaimTokenRepository) MarkUsed(ctx context.Context, tokenID, humanID st
 	return nil
 }
 
+// ClaimGuestPost moves the guest post of a post claim token to a human, in one
+// transaction: the token is marked used by humanID and the post becomes the
+// human's, which lifts its guest quarantine.
+// Returns ErrClaimTokenUsed if the token was used concurrently, and ErrPostNotFound
+// if the post is gone or no longer a guest post.
+func (r *ClaimTokenRepository) ClaimGuestPost(ctx context.Context, token *models.ClaimToken, humanID string) error {
+	return r.pool.WithTx(ctx, func(tx Tx) error {
+		result, err := tx.Exec(ctx, `
+			UPDATE claim_tokens
+			SET used_at = NOW(), used_by_human_id = $2
+			WHERE id = $1 AND used_at IS NULL
+		`, token.ID, humanID)
+		if err != nil {
+			LogQueryError(ctx, "ClaimGuestPost", "claim_tokens", err)
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return ErrClaimTokenUsed
+		}
+
+		result, err = tx.Exec(ctx, `
+			UPDATE posts
+			SET posted_by_type = 'human', posted_by_id = $2, owner_human_id = $2::uuid, updated_at = NOW()
+			WHERE id = $1 AND posted_by_type = 'guest' AND deleted_at IS NULL
+		`, token.PostID, humanID)
+		if err != nil {
+			LogQueryError(ctx, "ClaimGuestPost", "posts", err)
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return ErrPostNotFound
+		}
+		return nil
+	})
+}
+
 // DeleteExpiredByAgentID deletes expired unused claim tokens for a specific agent.
 // This unblocks the partial unique index (one active token per agent) after expiry.
 func (r *ClaimTokenRepository) DeleteExpiredByAgentID(ctx context.Context, agentID string) (int64, error) {
@@ -592,3 +592,67 @@ func TestClaimTokenRepository_DeleteExpiredTokens_NoExpired(t *testing.T) {
 		t.Errorf("expected 0 deleted tokens when no expired tokens exist, got %d", deleted)
 	}
 }
+
+func TestClaimTokenRepository_ClaimGuestPost(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewClaimTokenRepository(pool)
+	ctx := context.Background()
+
+	humanID := "00000000-0000-0000-0000-000000000002"
+	_, _ = pool.Exec(ctx, `
+		INSERT INTO users (id, username, display_name, email, auth_provider, auth_provider_id)
+		VALUES ($1, 'guestclaim_test_user', 'Guest Claim Test', 'guestclaim@test.com', 'github', 'gh_guestclaim')
+		ON CONFLICT (id) DO NOTHING
+	`, humanID)
+	defer pool.Exec(ctx, "DELETE FROM users WHERE id = $1", humanID)
+
+	var postID string
+	err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('problem', 'Guest claim test problem', 'A problem posted by a guest for the claim token test.', 'guest', 'guest_claimtest', 'pending_review')
+		RETURNING id
+	`).Scan(&postID)
+	if err != nil {
+		t.Fatalf("failed to create guest post: %v", err)
+	}
+	defer pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", postID)
+
+	token := &models.ClaimToken{
+		Token:     "guestclaim_token_" + time.Now().Format("150405"),
+		PostID:    postID,
+		Email:     "guest@example.com",
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+	if err := repo.Create(ctx, token); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	found, err := repo.FindByToken(ctx, token.Token)
+	if err != nil || found == nil {
+		t.Fatalf("FindByToken failed: %v", err)
+	}
+	if found.PostID != postID || found.AgentID != "" || found.Email != "guest@example.com" {
+		t.Errorf("unexpected guest token: %+v", found)
+	}
+
+	if err := repo.ClaimGuestPost(ctx, found, humanID); err != nil {
+		t.Fatalf("ClaimGuestPost failed: %v", err)
+	}
+
+	var postedByType, postedByID string
+	if err := pool.QueryRow(ctx, "SELECT posted_by_type, posted_by_id FROM posts WHERE id = $1", postID).Scan(&postedByType, &postedByID); err != nil {
+		t.Fatalf("failed to read post: %v", err)
+	}
+	if postedByType != "human" || postedByID != humanID {
+		t.Errorf("expected post owned by human %s, got %s %s", humanID, postedByType, postedByID)
+	}
+
+	if err := repo.ClaimGuestPost(ctx, found, humanID); err != ErrClaimTokenUsed {
+		t.Errorf("expected ErrClaimTokenUsed on second claim, got %v", err)
+	}
+}
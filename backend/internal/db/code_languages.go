@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// BackfillCodeLanguages scans up to limit posts and limit answers whose
+// code_languages have not been extracted yet (rows written before migration
+// 000105) and stores the languages of their fenced code blocks. Soft-deleted
+// rows are included so a restore never surfaces an unscanned row. updated_at is
+// left alone: the content did not change. Returns the number of rows scanned.
+func (r *PostRepository) BackfillCodeLanguages(ctx context.Context, limit int) (int64, error) {
+	posts, err := r.backfillCodeLanguages(ctx, "posts", "description", limit)
+	if err != nil {
+		return posts, err
+	}
+	answers, err := r.backfillCodeLanguages(ctx, "answers", "content", limit)
+	return posts + answers, err
+}
+
+// backfillCodeLanguages fills code_languages for one table from its markdown column.
+func (r *PostRepository) backfillCodeLanguages(ctx context.Context, table, column string, limit int) (int64, error) {
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT id::text, %s FROM %s WHERE code_languages IS NULL LIMIT $1`, column, table), limit)
+	if err != nil {
+		LogQueryError(ctx, "BackfillCodeLanguages", table, err)
+		return 0, fmt.Errorf("list unscanned %s: %w", table, err)
+	}
+	type pending struct{ id, markdown string }
+	var batch []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.markdown); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan unscanned %s: %w", table, err)
+		}
+		batch = append(batch, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate unscanned %s: %w", table, err)
+	}
+
+	var scanned int64
+	for _, p := range batch {
+		_, err := r.pool.Exec(ctx, fmt.Sprintf(`
+			UPDATE %s SET code_languages = $2 WHERE id = $1 AND code_languages IS NULL`, table),
+			p.id, models.ExtractCodeLanguages(p.markdown))
+		if err != nil {
+			LogQueryError(ctx, "BackfillCodeLanguages", table, err)
+			return scanned, fmt.Errorf("set %s code languages: %w", table, err)
+		}
+		scanned++
+	}
+	return scanned, nil
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+const codeLanguagesTestDescription = "Reading the config panics when the file is empty:\n\n" +
+	"```golang\ncfg, err := config.Load(path)\n```\n\nThe fixture is:\n\n```yml\nname: demo\n```\n"
+
+func TestPostRepository_CodeLanguages(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewPostRepository(pool)
+	ctx := context.Background()
+
+	post, err := repo.Create(ctx, &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        "Config loader panics on empty file",
+		Description:  codeLanguagesTestDescription,
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_code_languages",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() { _, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID) }()
+
+	want := []string{"go", "yaml"}
+	if !reflect.DeepEqual(post.CodeLanguages, want) {
+		t.Errorf("Create() CodeLanguages = %v, want %v", post.CodeLanguages, want)
+	}
+	found, err := repo.FindByID(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if !reflect.DeepEqual(found.CodeLanguages, want) {
+		t.Errorf("FindByID() CodeLanguages = %v, want %v", found.CodeLanguages, want)
+	}
+
+	// Rows written before the column existed are filled in by the backfill.
+	if _, err := pool.Exec(ctx, "UPDATE posts SET code_languages = NULL WHERE id = $1", post.ID); err != nil {
+		t.Fatalf("reset code_languages: %v", err)
+	}
+	for {
+		n, err := repo.BackfillCodeLanguages(ctx, 500)
+		if err != nil {
+			t.Fatalf("BackfillCodeLanguages() error = %v", err)
+		}
+		if n == 0 {
+			break
+		}
+	}
+	var stored []string
+	if err := pool.QueryRow(ctx, "SELECT code_languages FROM posts WHERE id = $1", post.ID).Scan(&stored); err != nil {
+		t.Fatalf("read code_languages: %v", err)
+	}
+	if !reflect.DeepEqual(stored, want) {
+		t.Errorf("backfilled code_languages = %v, want %v", stored, want)
+	}
+}
@@ -121,13 +121,17 @@ func (r *CommentsRepository) List(ctx context.Context, opts models.CommentListOp
 	}
 	offset := (opts.Page - 1) * opts.PerPage
 
+	// The caller's blocked principals' comments are left out.
+	viewerType, viewerID, _ := viewerFromContext(ctx)
+
 	// Count total
 	countQuery := `
-		SELECT COUNT(*) FROM comments
-		WHERE target_type = $1 AND target_id = $2 AND deleted_at IS NULL
+		SELECT COUNT(*) FROM comments c
+		WHERE c.target_type = $1 AND c.target_id = $2 AND c.deleted_at IS NULL AND c.hidden_at IS NULL
+		AND ` + blockedAuthorCondition("c.author_type", "c.author_id", 3) + `
 	`
 	var total int
-	err := r.pool.QueryRow(ctx, countQuery, opts.TargetType, opts.TargetID).Scan(&total)
+	err := r.pool.QueryRow(ctx, countQuery, opts.TargetType, opts.TargetID, string(viewerType), viewerID).Scan(&total)
 	if err != nil {
 		LogQueryError(ctx, "List.Count", "comments", err)
 		return nil, 0, err
@@ -147,18 +151,20 @@ func (r *CommentsRepository) List(ctx context.Context, opts models.CommentListOp
 			CASE c.author_type
 				WHEN 'human' THEN u.avatar_url
 				WHEN 'agent' THEN a.avatar_url
-			END as author_avatar_url
+			END as author_avatar_url,
+			` + reactionsSelect(models.ReactionTargetComment, "c.id", 5) + ` AS reactions
 		FROM comments c
 		LEFT JOIN users u ON c.author_type = 'human' AND c.author_id = u.id::text
 		LEFT JOIN agents a ON c.author_type = 'agent' AND c.author_id = a.id
-		WHERE c.target_type = $1 AND c.target_id = $2 AND c.deleted_at IS NULL
+		WHERE c.target_type = $1 AND c.target_id = $2 AND c.deleted_at IS NULL AND c.hidden_at IS NULL
 		-- BART-151: comments on a private post inherit its visibility (public-only here)
 		AND (c.target_type <> 'post' OR EXISTS (SELECT 1 FROM posts WHERE id = c.target_id AND visibility = 'public'))
+		AND ` + blockedAuthorCondition("c.author_type", "c.author_id", 5) + `
 		ORDER BY c.created_at ASC
 		LIMIT $3 OFFSET $4
 	`
 
-	rows, err := r.pool.Query(ctx, query, opts.TargetType, opts.TargetID, opts.PerPage, offset)
+	rows, err := r.pool.Query(ctx, query, opts.TargetType, opts.TargetID, opts.PerPage, offset, string(viewerType), viewerID)
 	if err != nil {
 		LogQueryError(ctx, "List.Query", "comments", err)
 		return nil, 0, err
@@ -180,6 +186,7 @@ func (r *CommentsRepository) List(ctx context.Context, opts models.CommentListOp
 			&cwa.DeletedAt,
 			&cwa.Author.DisplayName,
 			&avatarURL,
+			&cwa.Reactions,
 		)
 		if err != nil {
 			LogQueryError(ctx, "List.Scan", "comments", err)
@@ -251,3 +258,44 @@ func (r *CommentsRepository) TargetExists(ctx context.Context, targetType models
 
 	return exists, nil
 }
+
+// IsTargetOwner reports whether the author wrote the comment target or the post it
+// belongs to, e.g. an answer on their own question. Commenting there needs no
+// reputation privilege.
+func (r *CommentsRepository) IsTargetOwner(ctx context.Context, targetType models.CommentTargetType, targetID string, authorType models.AuthorType, authorID string) (bool, error) {
+	var query string
+	switch targetType {
+	case models.CommentTargetPost:
+		query = `SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1 AND posted_by_type = $2 AND posted_by_id = $3)`
+	case models.CommentTargetApproach:
+		query = `
+			SELECT EXISTS(
+				SELECT 1 FROM approaches t JOIN posts p ON p.id = t.problem_id
+				WHERE t.id = $1
+					AND ((t.author_type = $2 AND t.author_id = $3) OR (p.posted_by_type = $2 AND p.posted_by_id = $3))
+			)`
+	case models.CommentTargetAnswer:
+		query = `
+			SELECT EXISTS(
+				SELECT 1 FROM answers t JOIN posts p ON p.id = t.question_id
+				WHERE t.id = $1
+					AND ((t.author_type = $2 AND t.author_id = $3) OR (p.posted_by_type = $2 AND p.posted_by_id = $3))
+			)`
+	case models.CommentTargetResponse:
+		query = `
+			SELECT EXISTS(
+				SELECT 1 FROM responses t JOIN posts p ON p.id = t.idea_id
+				WHERE t.id = $1
+					AND ((t.author_type = $2 AND t.author_id = $3) OR (p.posted_by_type = $2 AND p.posted_by_id = $3))
+			)`
+	default:
+		return false, fmt.Errorf("unknown target type: %s", targetType)
+	}
+
+	var owner bool
+	if err := r.pool.QueryRow(ctx, query, targetID, string(authorType), authorID).Scan(&owner); err != nil {
+		LogQueryError(ctx, "IsTargetOwner", "comments", err)
+		return false, err
+	}
+	return owner, nil
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetEmailPreferences returns which notification emails the user receives.
+// Returns ErrNotFound if the user doesn't exist or is deleted.
+func (r *UserRepository) GetEmailPreferences(ctx context.Context, userID string) (*models.EmailPreferences, error) {
+	var optOuts []string
+	var unsubscribed bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT email_opt_outs, email_unsubscribed_at IS NOT NULL
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+	`, userID).Scan(&optOuts, &unsubscribed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		LogQueryError(ctx, "GetEmailPreferences", "users", err)
+		return nil, fmt.Errorf("get email preferences: %w", err)
+	}
+	return buildEmailPreferences(optOuts, unsubscribed), nil
+}
+
+// UpdateEmailPreferences turns notification emails on or off per event and returns
+// the resulting preferences. Events missing from events are left unchanged.
+// Turning any event on also clears a previous unsubscribe-from-all.
+func (r *UserRepository) UpdateEmailPreferences(ctx context.Context, userID string, events map[string]bool) (*models.EmailPreferences, error) {
+	var enable, disable []string
+	for event, on := range events {
+		if on {
+			enable = append(enable, event)
+		} else {
+			disable = append(disable, event)
+		}
+	}
+
+	var optOuts []string
+	var unsubscribed bool
+	err := r.pool.QueryRow(ctx, `
+		UPDATE users
+		SET email_opt_outs = ARRAY(
+				SELECT DISTINCT e FROM unnest(email_opt_outs || $3::text[]) AS e
+				WHERE e <> ALL($2::text[])
+				ORDER BY e
+			),
+			email_unsubscribed_at = CASE WHEN cardinality($2::text[]) > 0 THEN NULL ELSE email_unsubscribed_at END
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING email_opt_outs, email_unsubscribed_at IS NOT NULL
+	`, userID, enable, disable).Scan(&optOuts, &unsubscribed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		LogQueryError(ctx, "UpdateEmailPreferences", "users", err)
+		return nil, fmt.Errorf("update email preferences: %w", err)
+	}
+	return buildEmailPreferences(optOuts, unsubscribed), nil
+}
+
+func buildEmailPreferences(optOuts []string, unsubscribed bool) *models.EmailPreferences {
+	prefs := &models.EmailPreferences{Events: map[string]bool{}, Unsubscribed: unsubscribed}
+	for _, event := range models.OptOutEmailEvents {
+		prefs.Events[event] = true
+	}
+	for _, event := range optOuts {
+		if _, ok := prefs.Events[event]; ok {
+			prefs.Events[event] = false
+		}
+	}
+	return prefs
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// EmailQueueRepository persists notification emails awaiting delivery.
+type EmailQueueRepository struct {
+	pool *Pool
+}
+
+// NewEmailQueueRepository creates a new EmailQueueRepository.
+func NewEmailQueueRepository(pool *Pool) *EmailQueueRepository {
+	return &EmailQueueRepository{pool: pool}
+}
+
+// Enqueue stores a rendered email for delivery by the email queue job.
+func (r *EmailQueueRepository) Enqueue(ctx context.Context, item *models.EmailQueueItem) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO email_queue (event, to_email, subject, html_body, text_body)
+		VALUES ($1, $2, $3, $4, $5)
+	`, item.Event, item.To, item.Subject, item.HTML, item.Text)
+	if err != nil {
+		LogQueryError(ctx, "Enqueue", "email_queue", err)
+		return fmt.Errorf("enqueue email: %w", err)
+	}
+	return nil
+}
+
+// ListDue returns up to limit queued emails whose next attempt is due, oldest first.
+// Emails that already failed maxAttempts times are left in the table for inspection.
+func (r *EmailQueueRepository) ListDue(ctx context.Context, limit, maxAttempts int) ([]models.EmailQueueItem, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, event, to_email, subject, html_body, text_body, attempts
+		FROM email_queue
+		WHERE next_attempt_at <= NOW() AND attempts < $2
+		ORDER BY next_attempt_at ASC
+		LIMIT $1
+	`, limit, maxAttempts)
+	if err != nil {
+		LogQueryError(ctx, "ListDue", "email_queue", err)
+		return nil, fmt.Errorf("list due emails: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.EmailQueueItem{}
+	for rows.Next() {
+		var item models.EmailQueueItem
+		if err := rows.Scan(&item.ID, &item.Event, &item.To, &item.Subject, &item.HTML, &item.Text, &item.Attempts); err != nil {
+			return nil, fmt.Errorf("scan email queue item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Complete removes a delivered email from the queue.
+func (r *EmailQueueRepository) Complete(ctx context.Context, id string) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM email_queue WHERE id = $1`, id); err != nil {
+		LogQueryError(ctx, "Complete", "email_queue", err)
+		return fmt.Errorf("dequeue email: %w", err)
+	}
+	return nil
+}
+
+// Fail records a failed delivery attempt and schedules the next one.
+func (r *EmailQueueRepository) Fail(ctx context.Context, id, errMsg string, nextAttempt time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE email_queue
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`, id, errMsg, nextAttempt)
+	if err != nil {
+		LogQueryError(ctx, "Fail", "email_queue", err)
+		return fmt.Errorf("record email failure: %w", err)
+	}
+	return nil
+}
+
+// FindAuthorRecipient returns the human who should be emailed about event for
+// content by the given author: the user themself, or the human who claimed the
+// agent. Returns nil without error when there is no such human, or when they
+// unsubscribed from all emails or opted out of this event.
+func (r *EmailQueueRepository) FindAuthorRecipient(ctx context.Context, authorType, authorID, event string) (*models.EmailRecipient, error) {
+	userIDQuery := `SELECT $1::uuid`
+	if authorType == string(models.AuthorTypeAgent) {
+		userIDQuery = `SELECT human_id FROM agents WHERE id = $1`
+	} else if authorType != string(models.AuthorTypeHuman) {
+		return nil, nil
+	}
+
+	var rec models.EmailRecipient
+	err := r.pool.QueryRow(ctx, `
+		SELECT u.id, u.email, u.display_name, COALESCE(u.referral_code, '')
+		FROM users u
+		WHERE u.id = (`+userIDQuery+`)
+		AND u.deleted_at IS NULL
+		AND u.email_unsubscribed_at IS NULL
+		AND NOT ($2 = ANY(u.email_opt_outs))
+	`, authorID, event).Scan(&rec.ID, &rec.Email, &rec.DisplayName, &rec.ReferralCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "FindAuthorRecipient", "users", err)
+		return nil, fmt.Errorf("find email recipient: %w", err)
+	}
+	return &rec, nil
+}
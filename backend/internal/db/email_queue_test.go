@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestEmailQueueRepository_Lifecycle(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewEmailQueueRepository(pool)
+	to := "emailqueue_" + time.Now().Format("150405.000000") + "@example.com"
+
+	if err := repo.Enqueue(ctx, &models.EmailQueueItem{
+		Event: models.EmailEventClaimLink, To: to, Subject: "s", HTML: "<p>h</p>", Text: "t",
+	}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	findQueued := func() *models.EmailQueueItem {
+		t.Helper()
+		items, err := repo.ListDue(ctx, 1000, 8)
+		if err != nil {
+			t.Fatalf("ListDue() error = %v", err)
+		}
+		for i := range items {
+			if items[i].To == to {
+				return &items[i]
+			}
+		}
+		return nil
+	}
+
+	item := findQueued()
+	if item == nil {
+		t.Fatal("expected queued email to be due")
+	}
+	defer pool.Exec(ctx, `DELETE FROM email_queue WHERE id = $1`, item.ID)
+
+	if err := repo.Fail(ctx, item.ID, "mailbox unavailable", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+	if findQueued() != nil {
+		t.Error("expected failed email to wait for its next attempt")
+	}
+
+	if err := repo.Complete(ctx, item.ID); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	var count int
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM email_queue WHERE id = $1`, item.ID).Scan(&count)
+	if count != 0 {
+		t.Error("expected completed email removed from the queue")
+	}
+}
+
+func TestEmailQueueRepository_FindAuthorRecipient_RespectsOptOut(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	userRepo := NewUserRepository(pool)
+	repo := NewEmailQueueRepository(pool)
+	user := createViewsTestUser(t, userRepo)
+
+	rec, err := repo.FindAuthorRecipient(ctx, string(models.AuthorTypeHuman), user.ID, models.EmailEventAnswerAccepted)
+	if err != nil {
+		t.Fatalf("FindAuthorRecipient() error = %v", err)
+	}
+	if rec == nil || rec.Email != user.Email {
+		t.Fatalf("expected recipient %s, got %+v", user.Email, rec)
+	}
+
+	prefs, err := userRepo.UpdateEmailPreferences(ctx, user.ID, map[string]bool{models.EmailEventAnswerAccepted: false})
+	if err != nil {
+		t.Fatalf("UpdateEmailPreferences() error = %v", err)
+	}
+	if prefs.Events[models.EmailEventAnswerAccepted] || !prefs.Events[models.EmailEventPostCrystallized] {
+		t.Errorf("unexpected preferences %+v", prefs)
+	}
+
+	rec, err = repo.FindAuthorRecipient(ctx, string(models.AuthorTypeHuman), user.ID, models.EmailEventAnswerAccepted)
+	if err != nil {
+		t.Fatalf("FindAuthorRecipient() error = %v", err)
+	}
+	if rec != nil {
+		t.Errorf("expected no recipient after opting out, got %+v", rec)
+	}
+
+	rec, err = repo.FindAuthorRecipient(ctx, string(models.AuthorTypeHuman), user.ID, models.EmailEventPostCrystallized)
+	if err != nil || rec == nil {
+		t.Errorf("expected recipient for other events, got %+v, %v", rec, err)
+	}
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	pgvector "github.com/pgvector/pgvector-go"
+)
+
+// EmbeddingQueueRepository persists posts awaiting embedding generation.
+type EmbeddingQueueRepository struct {
+	pool *Pool
+}
+
+// NewEmbeddingQueueRepository creates a new EmbeddingQueueRepository.
+func NewEmbeddingQueueRepository(pool *Pool) *EmbeddingQueueRepository {
+	return &EmbeddingQueueRepository{pool: pool}
+}
+
+// Enqueue adds a post to the queue, or makes an already-queued post due immediately.
+func (r *EmbeddingQueueRepository) Enqueue(ctx context.Context, postID, reason string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO embedding_queue (post_id, reason)
+		VALUES ($1, $2)
+		ON CONFLICT (post_id) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			next_attempt_at = NOW()
+	`, postID, reason)
+	if err != nil {
+		LogQueryError(ctx, "Enqueue", "embedding_queue", err)
+		return fmt.Errorf("enqueue embedding: %w", err)
+	}
+	return nil
+}
+
+// ListDue returns up to limit queued posts whose next attempt is due, oldest first.
+// Posts deleted since they were queued are skipped.
+func (r *EmbeddingQueueRepository) ListDue(ctx context.Context, limit int) ([]models.EmbeddingQueueItem, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT q.post_id, p.title, p.description, q.attempts
+		FROM embedding_queue q
+		JOIN posts p ON p.id = q.post_id
+		WHERE q.next_attempt_at <= NOW() AND p.deleted_at IS NULL
+		ORDER BY q.next_attempt_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListDue", "embedding_queue", err)
+		return nil, fmt.Errorf("list due embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.EmbeddingQueueItem{}
+	for rows.Next() {
+		var item models.EmbeddingQueueItem
+		if err := rows.Scan(&item.PostID, &item.Title, &item.Description, &item.Attempts); err != nil {
+			return nil, fmt.Errorf("scan embedding queue item: %w", err)
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Complete stores the generated embedding on the post and removes it from the queue.
+func (r *EmbeddingQueueRepository) Complete(ctx context.Context, postID string, embedding []float32) error {
+	vec := pgvector.NewVector(embedding)
+	return r.pool.WithTx(ctx, func(tx Tx) error {
+		if _, err := tx.Exec(ctx, `UPDATE posts SET embedding = $2 WHERE id = $1`, postID, vec); err != nil {
+			LogQueryError(ctx, "Complete", "posts", err)
+			return fmt.Errorf("store post embedding: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM embedding_queue WHERE post_id = $1`, postID); err != nil {
+			LogQueryError(ctx, "Complete", "embedding_queue", err)
+			return fmt.Errorf("dequeue embedding: %w", err)
+		}
+		return nil
+	})
+}
+
+// Fail records a failed attempt and schedules the next one.
+func (r *EmbeddingQueueRepository) Fail(ctx context.Context, postID, errMsg string, nextAttempt time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE embedding_queue
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE post_id = $1
+	`, postID, errMsg, nextAttempt)
+	if err != nil {
+		LogQueryError(ctx, "Fail", "embedding_queue", err)
+		return fmt.Errorf("record embedding failure: %w", err)
+	}
+	return nil
+}
+
+// Count returns the number of posts waiting in the queue.
+func (r *EmbeddingQueueRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM embedding_queue`).Scan(&count); err != nil {
+		LogQueryError(ctx, "Count", "embedding_queue", err)
+		return 0, fmt.Errorf("count embedding queue: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func setupEmbeddingQueueTest(t *testing.T) (*Pool, *EmbeddingQueueRepository, string) {
+	t.Helper()
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+
+	ctx := context.Background()
+	pool, err := NewPool(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+
+	var postID string
+	err = pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Embedding queue test question', 'Queued while the provider was down', 'agent', 'test_embedding_queue', 'open')
+		RETURNING id::text
+	`).Scan(&postID)
+	if err != nil {
+		pool.Close()
+		t.Fatalf("failed to insert post: %v", err)
+	}
+	t.Cleanup(func() {
+		// Cascades to embedding_queue.
+		_, _ = pool.Exec(context.Background(), "DELETE FROM posts WHERE id = $1", postID)
+		pool.Close()
+	})
+
+	return pool, NewEmbeddingQueueRepository(pool), postID
+}
+
+func TestEmbeddingQueueRepository_EnqueueAndComplete(t *testing.T) {
+	pool, repo, postID := setupEmbeddingQueueTest(t)
+	ctx := context.Background()
+
+	if err := repo.Enqueue(ctx, postID, models.EmbeddingQueueReasonScheduled); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	// Re-enqueueing the same post must not create a duplicate.
+	if err := repo.Enqueue(ctx, postID, models.EmbeddingQueueReasonFailed); err != nil {
+		t.Fatalf("Enqueue() second call error = %v", err)
+	}
+
+	items, err := repo.ListDue(ctx, 100)
+	if err != nil {
+		t.Fatalf("ListDue() error = %v", err)
+	}
+	found := 0
+	for _, item := range items {
+		if item.PostID == postID {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Fatalf("expected post to be due exactly once, found %d", found)
+	}
+
+	embedding := make([]float32, 1024)
+	embedding[0] = 0.5
+	if err := repo.Complete(ctx, postID, embedding); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	var queued int
+	if err := pool.QueryRow(ctx, "SELECT COUNT(*) FROM embedding_queue WHERE post_id = $1", postID).Scan(&queued); err != nil {
+		t.Fatalf("failed to count queue: %v", err)
+	}
+	if queued != 0 {
+		t.Errorf("expected post to be removed from queue, got %d rows", queued)
+	}
+	var hasEmbedding bool
+	if err := pool.QueryRow(ctx, "SELECT embedding IS NOT NULL FROM posts WHERE id = $1", postID).Scan(&hasEmbedding); err != nil {
+		t.Fatalf("failed to read embedding: %v", err)
+	}
+	if !hasEmbedding {
+		t.Error("expected post embedding to be stored")
+	}
+}
+
+func TestEmbeddingQueueRepository_FailDefersRetry(t *testing.T) {
+	_, repo, postID := setupEmbeddingQueueTest(t)
+	ctx := context.Background()
+
+	if err := repo.Enqueue(ctx, postID, models.EmbeddingQueueReasonFailed); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	if err := repo.Fail(ctx, postID, "voyage unavailable", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Fail() error = %v", err)
+	}
+
+	items, err := repo.ListDue(ctx, 100)
+	if err != nil {
+		t.Fatalf("ListDue() error = %v", err)
+	}
+	for _, item := range items {
+		if item.PostID == postID {
+			t.Error("expected failed post not to be due before its next attempt")
+		}
+	}
+}
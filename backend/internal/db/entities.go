@@ -0,0 +1,192 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrEntityNotFound is returned when no visible post mentions an entity.
+var ErrEntityNotFound = errors.New("entity not found")
+
+// EntityRepository stores the knowledge graph entities extracted from posts
+// and serves entity pages.
+type EntityRepository struct {
+	pool *Pool
+}
+
+// NewEntityRepository creates a new EntityRepository.
+func NewEntityRepository(pool *Pool) *EntityRepository {
+	return &EntityRepository{pool: pool}
+}
+
+// ExtractPendingEntities extracts the entities of up to limit posts that were
+// never extracted or were edited since, oldest edit first, replacing their
+// post_entities. Soft-deleted posts are included so a restore never surfaces
+// an unextracted post. Returns the number of posts extracted.
+func (r *EntityRepository) ExtractPendingEntities(ctx context.Context, limit int) (int, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id::text, title, description, updated_at
+		FROM posts
+		WHERE entities_extracted_at IS NULL OR entities_extracted_at < updated_at
+		ORDER BY updated_at
+		LIMIT $1`, limit)
+	if err != nil {
+		LogQueryError(ctx, "ExtractPendingEntities", "posts", err)
+		return 0, fmt.Errorf("list posts pending entity extraction: %w", err)
+	}
+	type pending struct {
+		id, title, description string
+		updatedAt              time.Time
+	}
+	var batch []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.title, &p.description, &p.updatedAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan post pending entity extraction: %w", err)
+		}
+		batch = append(batch, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate posts pending entity extraction: %w", err)
+	}
+
+	extracted := 0
+	for _, p := range batch {
+		if err := r.setPostEntities(ctx, p.id, p.updatedAt, models.ExtractEntities(p.title, p.description)); err != nil {
+			LogQueryError(ctx, "ExtractPendingEntities", "post_entities", err)
+			return extracted, fmt.Errorf("set entities of post %s: %w", p.id, err)
+		}
+		extracted++
+	}
+	return extracted, nil
+}
+
+// setPostEntities replaces a post's entities and records the updated_at they
+// were extracted from. An entity keeps the kind it was first seen with.
+func (r *EntityRepository) setPostEntities(ctx context.Context, postID string, updatedAt time.Time, entities []models.ExtractedEntity) error {
+	names := make([]string, len(entities))
+	kinds := make([]string, len(entities))
+	for i, e := range entities {
+		names[i], kinds[i] = e.Name, string(e.Kind)
+	}
+	return r.pool.WithTx(ctx, func(tx Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM post_entities WHERE post_id = $1`, postID); err != nil {
+			return err
+		}
+		if len(entities) > 0 {
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO entities (name, kind)
+				SELECT * FROM unnest($1::text[], $2::text[])
+				ON CONFLICT (name) DO NOTHING`, names, kinds); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO post_entities (post_id, entity_id)
+				SELECT $1::uuid, id FROM entities WHERE name = ANY($2)
+				ON CONFLICT DO NOTHING`, postID, names); err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec(ctx, `UPDATE posts SET entities_extracted_at = $2 WHERE id = $1`, postID, updatedAt)
+		return err
+	})
+}
+
+// visibleEntityPostsFilter restricts entity pages to posts anyone can see, the
+// same posts tag pages count.
+const visibleEntityPostsFilter = visibleTagPostsFilter
+
+// GetEntity returns what is known about an entity across visible posts.
+// Returns ErrEntityNotFound if it doesn't exist or no visible post mentions it.
+func (r *EntityRepository) GetEntity(ctx context.Context, name string) (*models.Entity, error) {
+	e := models.Entity{Name: name}
+	err := r.pool.QueryRow(ctx, `
+		SELECT e.kind, COUNT(p.id),
+			COUNT(p.id) FILTER (WHERE p.type = 'problem'),
+			COUNT(p.id) FILTER (WHERE p.type = 'question'),
+			COUNT(p.id) FILTER (WHERE p.type = 'idea'),
+			COUNT(p.id) FILTER (WHERE p.status IN ('solved', 'answered')),
+			COALESCE(MIN(p.created_at), NOW()), COALESCE(MAX(p.created_at), NOW())
+		FROM entities e
+		JOIN post_entities pe ON pe.entity_id = e.id
+		JOIN posts p ON p.id = pe.post_id AND `+visibleEntityPostsFilter+`
+		WHERE e.name = $1
+		GROUP BY e.kind
+	`, name).Scan(&e.Kind, &e.PostCount, &e.ProblemCount, &e.QuestionCount, &e.IdeaCount,
+		&e.ResolvedCount, &e.FirstSeenAt, &e.LastSeenAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEntityNotFound
+	}
+	if err != nil {
+		LogQueryError(ctx, "GetEntity", "entities", err)
+		return nil, fmt.Errorf("get entity: %w", err)
+	}
+	return &e, nil
+}
+
+// ListEntityPosts returns the highest voted visible posts mentioning an entity.
+func (r *EntityRepository) ListEntityPosts(ctx context.Context, name string, limit int) ([]models.EntityPost, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT p.id::text, p.type, p.title, p.status, p.upvotes - p.downvotes AS vote_score, p.created_at
+		FROM entities e
+		JOIN post_entities pe ON pe.entity_id = e.id
+		JOIN posts p ON p.id = pe.post_id
+		WHERE e.name = $1 AND `+visibleEntityPostsFilter+`
+		ORDER BY vote_score DESC, p.created_at DESC
+		LIMIT $2
+	`, name, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListEntityPosts", "entities", err)
+		return nil, fmt.Errorf("list entity posts: %w", err)
+	}
+	defer rows.Close()
+
+	posts := make([]models.EntityPost, 0)
+	for rows.Next() {
+		var p models.EntityPost
+		if err := rows.Scan(&p.ID, &p.Type, &p.Title, &p.Status, &p.VoteScore, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan entity post: %w", err)
+		}
+		posts = append(posts, p)
+	}
+	return posts, rows.Err()
+}
+
+// ListRelatedEntities returns the entities most often mentioned in the same
+// visible posts as name.
+func (r *EntityRepository) ListRelatedEntities(ctx context.Context, name string, limit int) ([]models.RelatedEntity, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT o.name, o.kind, COUNT(*) AS shared_posts
+		FROM entities e
+		JOIN post_entities pe ON pe.entity_id = e.id
+		JOIN posts p ON p.id = pe.post_id
+		JOIN post_entities ope ON ope.post_id = pe.post_id AND ope.entity_id <> e.id
+		JOIN entities o ON o.id = ope.entity_id
+		WHERE e.name = $1 AND `+visibleEntityPostsFilter+`
+		GROUP BY o.name, o.kind
+		ORDER BY shared_posts DESC, o.name
+		LIMIT $2
+	`, name, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListRelatedEntities", "entities", err)
+		return nil, fmt.Errorf("list related entities: %w", err)
+	}
+	defer rows.Close()
+
+	related := make([]models.RelatedEntity, 0)
+	for rows.Next() {
+		var e models.RelatedEntity
+		if err := rows.Scan(&e.Name, &e.Kind, &e.SharedPosts); err != nil {
+			return nil, fmt.Errorf("scan related entity: %w", err)
+		}
+		related = append(related, e)
+	}
+	return related, rows.Err()
+}
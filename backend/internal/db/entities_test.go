@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestEntityRepository_ExtractAndGet(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	ctx := context.Background()
+	posts := NewPostRepository(pool)
+	repo := NewEntityRepository(pool)
+
+	post, err := posts.Create(ctx, &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        "pgx v5 pool returns ECONNREFUSED",
+		Description:  "Connecting to postgres with github.com/jackc/pgx/v5 fails with ECONNREFUSED after a restart.",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_entities",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() { _, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID) }()
+
+	for {
+		n, err := repo.ExtractPendingEntities(ctx, 500)
+		if err != nil {
+			t.Fatalf("ExtractPendingEntities() error = %v", err)
+		}
+		if n == 0 {
+			break
+		}
+	}
+
+	entity, err := repo.GetEntity(ctx, "pgx v5")
+	if err != nil {
+		t.Fatalf("GetEntity() error = %v", err)
+	}
+	if entity.Kind != models.EntityKindLibrary || entity.ProblemCount < 1 {
+		t.Errorf("GetEntity() = %+v, want a library mentioned by a problem", entity)
+	}
+
+	postsForEntity, err := repo.ListEntityPosts(ctx, "pgx v5", 10)
+	if err != nil {
+		t.Fatalf("ListEntityPosts() error = %v", err)
+	}
+	found := false
+	for _, p := range postsForEntity {
+		found = found || p.ID == post.ID
+	}
+	if !found {
+		t.Errorf("ListEntityPosts() = %+v, want post %s", postsForEntity, post.ID)
+	}
+
+	related, err := repo.ListRelatedEntities(ctx, "pgx v5", 10)
+	if err != nil {
+		t.Fatalf("ListRelatedEntities() error = %v", err)
+	}
+	names := map[string]bool{}
+	for _, e := range related {
+		names[e.Name] = true
+	}
+	if !names["econnrefused"] || !names["postgresql"] {
+		t.Errorf("ListRelatedEntities() = %+v, want econnrefused and postgresql", related)
+	}
+
+	// An edit makes the post pending again; the old entities are replaced.
+	if _, err := pool.Exec(ctx, "UPDATE posts SET title = 'Redis cache misses', description = 'Now it is a redis problem.', updated_at = NOW() WHERE id = $1", post.ID); err != nil {
+		t.Fatalf("edit post: %v", err)
+	}
+	if _, err := repo.ExtractPendingEntities(ctx, 500); err != nil {
+		t.Fatalf("ExtractPendingEntities() after edit error = %v", err)
+	}
+	var stillMentioned bool
+	if err := pool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM post_entities pe JOIN entities e ON e.id = pe.entity_id
+		WHERE pe.post_id = $1 AND e.name = 'econnrefused')`, post.ID).Scan(&stillMentioned); err != nil {
+		t.Fatalf("read post_entities: %v", err)
+	}
+	if stillMentioned {
+		t.Error("expected econnrefused to be dropped after the edit")
+	}
+
+	if _, err := repo.GetEntity(ctx, "no-such-entity"); err != ErrEntityNotFound {
+		t.Errorf("GetEntity(unknown) error = %v, want ErrEntityNotFound", err)
+	}
+}
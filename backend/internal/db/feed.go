@@ -46,48 +46,31 @@ func (r *FeedRepository) GetRecentActivity(ctx context.Context, page, perPage in
 	}
 
 	// Query for recent activity with author info
-	// Uses LEFT JOIN subqueries instead of correlated subqueries for better performance
+	// Answer/approach/comment counts come from the trigger-maintained counter columns;
+	// idea responses are still aggregated with a LEFT JOIN subquery.
 	query := `
 		SELECT
 			p.id, p.type, p.title, p.description, p.tags,
 			p.status, p.posted_by_type, p.posted_by_id,
 			p.upvotes - p.downvotes as vote_score,
 			CASE
-				WHEN p.type = 'question' THEN COALESCE(ans_cnt.cnt, 0)
+				WHEN p.type = 'question' THEN p.answers_count
 				WHEN p.type = 'idea' THEN COALESCE(resp_cnt.cnt, 0)
 				ELSE 0
 			END as answer_count,
-			COALESCE(app_cnt.cnt, 0) as approach_count,
-			COALESCE(cmt_cnt.cnt, 0) as comment_count,
+			p.approaches_count as approach_count,
+			p.comments_count as comment_count,
 			p.created_at,
 			COALESCE(u.display_name, a.display_name, '') as author_display_name,
 			COALESCE(u.avatar_url, a.avatar_url, '') as author_avatar_url
 		FROM posts p
 		LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
 		LEFT JOIN agents a ON p.posted_by_type = 'agent' AND p.posted_by_id = a.id
-		LEFT JOIN (
-			SELECT question_id, COUNT(*) as cnt
-			FROM answers
-			WHERE deleted_at IS NULL
-			GROUP BY question_id
-		) ans_cnt ON ans_cnt.question_id = p.id
-		LEFT JOIN (
-			SELECT problem_id, COUNT(*) as cnt
-			FROM approaches
-			WHERE deleted_at IS NULL
-			GROUP BY problem_id
-		) app_cnt ON app_cnt.problem_id = p.id
 		LEFT JOIN (
 			SELECT idea_id, COUNT(*) as cnt
 			FROM responses
 			GROUP BY idea_id
 		) resp_cnt ON resp_cnt.idea_id = p.id
-		LEFT JOIN (
-			SELECT target_id, COUNT(*) as cnt
-			FROM comments
-			WHERE target_type = 'post' AND deleted_at IS NULL
-			GROUP BY target_id
-		) cmt_cnt ON cmt_cnt.target_id = p.id
 		WHERE p.deleted_at IS NULL
 		AND p.visibility = 'public' -- BART-151: no private posts in the public feed
 		ORDER BY p.created_at DESC
@@ -135,7 +118,8 @@ func (r *FeedRepository) GetStuckProblems(ctx context.Context, page, perPage int
 	}
 	offset := (page - 1) * perPage
 
-	// Count total - problems that are stuck (have stuck approaches or are in_progress)
+	// Count total - problems that are stuck (have stuck approaches, are in_progress
+	// or were escalated by their author via POST /problems/{id}/stuck)
 	countQuery := `
 		SELECT COUNT(DISTINCT p.id)
 		FROM posts p
@@ -150,6 +134,11 @@ func (r *FeedRepository) GetStuckProblems(ctx context.Context, page, perPage int
 				AND ap.status = 'stuck'
 				AND ap.deleted_at IS NULL
 			)
+			OR EXISTS (
+				SELECT 1 FROM problem_escalations pe
+				WHERE pe.problem_id = p.id
+				AND pe.resolved_at IS NULL
+			)
 		)
 	`
 	var total int
@@ -161,7 +150,7 @@ func (r *FeedRepository) GetStuckProblems(ctx context.Context, page, perPage int
 
 	// Query for stuck problems
 	query := `
-		SELECT DISTINCT
+		SELECT
 			p.id, p.type, p.title, p.description, p.tags,
 			p.status, p.posted_by_type, p.posted_by_id,
 			p.upvotes - p.downvotes as vote_score,
@@ -174,6 +163,7 @@ func (r *FeedRepository) GetStuckProblems(ctx context.Context, page, perPage int
 		FROM posts p
 		LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
 		LEFT JOIN agents a ON p.posted_by_type = 'agent' AND p.posted_by_id = a.id
+		LEFT JOIN problem_escalations esc ON esc.problem_id = p.id AND esc.resolved_at IS NULL
 		WHERE p.type = 'problem'
 		AND p.deleted_at IS NULL
 		AND p.visibility = 'public' -- BART-151
@@ -185,8 +175,14 @@ func (r *FeedRepository) GetStuckProblems(ctx context.Context, page, perPage int
 				AND ap.status = 'stuck'
 				AND ap.deleted_at IS NULL
 			)
+			OR EXISTS (
+				SELECT 1 FROM problem_escalations pe
+				WHERE pe.problem_id = p.id
+				AND pe.resolved_at IS NULL
+			)
 		)
-		ORDER BY p.created_at DESC
+		-- Escalated problems first, most recently escalated on top
+		ORDER BY esc.created_at DESC NULLS LAST, p.created_at DESC
 		LIMIT $1 OFFSET $2
 	`
 
@@ -302,6 +298,100 @@ func (r *FeedRepository) GetUnansweredQuestions(ctx context.Context, page, perPa
 	return items, total, nil
 }
 
+// followingBoost is how much newer, per point of relevance, a post ranks in the
+// following feed. One followed tag match ranks a post as if it were a day newer.
+const followingBoost = "24 hours"
+
+// GetFollowingFeed returns public posts ranked for a follower: posts matching
+// followed tags (up to 3 matches count) and bookmarked posts (worth 2) rank as
+// if they were posted followingBoost newer per point, so fresh unrelated posts
+// still surface below recent matches.
+// Per SPEC.md Part 5.6: GET /feed?scope=following
+func (r *FeedRepository) GetFollowingFeed(ctx context.Context, followerType, followerID string, page, perPage int) ([]models.FeedItem, int, error) {
+	// Validate pagination
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 20
+	}
+	if perPage > 50 {
+		perPage = 50
+	}
+	offset := (page - 1) * perPage
+
+	countQuery := `SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL AND visibility = 'public'`
+	var total int
+	err := r.pool.QueryRow(ctx, countQuery).Scan(&total)
+	if err != nil {
+		LogQueryError(ctx, "GetFollowingFeed.Count", "posts", err)
+		return nil, 0, fmt.Errorf("count query failed: %w", err)
+	}
+
+	query := `
+		WITH followed AS (
+			SELECT COALESCE(array_agg(tag), '{}') AS tags
+			FROM tag_follows
+			WHERE follower_type = $1 AND follower_id = $2
+		)
+		SELECT
+			p.id, p.type, p.title, p.description, p.tags,
+			p.status, p.posted_by_type, p.posted_by_id,
+			p.upvotes - p.downvotes as vote_score,
+			CASE
+				WHEN p.type = 'question' THEN p.answers_count
+				WHEN p.type = 'idea' THEN COALESCE(resp_cnt.cnt, 0)
+				ELSE 0
+			END as answer_count,
+			p.approaches_count as approach_count,
+			p.comments_count as comment_count,
+			p.created_at,
+			COALESCE(u.display_name, a.display_name, '') as author_display_name,
+			COALESCE(u.avatar_url, a.avatar_url, '') as author_avatar_url
+		FROM posts p
+		CROSS JOIN followed f
+		LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
+		LEFT JOIN agents a ON p.posted_by_type = 'agent' AND p.posted_by_id = a.id
+		LEFT JOIN (
+			SELECT idea_id, COUNT(*) as cnt
+			FROM responses
+			GROUP BY idea_id
+		) resp_cnt ON resp_cnt.idea_id = p.id
+		LEFT JOIN bookmarks b ON b.post_id = p.id AND b.user_type = $1 AND b.user_id = $2
+		WHERE p.deleted_at IS NULL
+		AND p.visibility = 'public'
+		ORDER BY p.created_at + (
+			LEAST(cardinality(ARRAY(SELECT unnest(p.tags) INTERSECT SELECT unnest(f.tags))), 3)
+			+ CASE WHEN b.post_id IS NOT NULL THEN 2 ELSE 0 END
+		) * interval '` + followingBoost + `' DESC, p.id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, followerType, followerID, perPage, offset)
+	if err != nil {
+		LogQueryError(ctx, "GetFollowingFeed", "posts", err)
+		return nil, 0, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]models.FeedItem, 0)
+	for rows.Next() {
+		item, err := r.scanFeedItem(rows)
+		if err != nil {
+			LogQueryError(ctx, "GetFollowingFeed.Scan", "posts", err)
+			return nil, 0, fmt.Errorf("scan failed: %w", err)
+		}
+		items = append(items, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		LogQueryError(ctx, "GetFollowingFeed.Rows", "posts", err)
+		return nil, 0, fmt.Errorf("rows iteration failed: %w", err)
+	}
+
+	return items, total, nil
+}
+
 // scanFeedItem scans a row into a FeedItem.
 func (r *FeedRepository) scanFeedItem(rows interface{ Scan(dest ...any) error }) (*models.FeedItem, error) {
 	var item models.FeedItem
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// feedBriefingRetention is how many days of briefings are kept per follower.
+const feedBriefingRetention = 7
+
+// FeedBriefingRepository gathers the posts for GET /v1/feed/briefing and caches
+// the generated briefings (migration 000126).
+type FeedBriefingRepository struct {
+	pool *Pool
+	feed *FeedRepository // for scanFeedItem
+}
+
+// NewFeedBriefingRepository creates a new FeedBriefingRepository.
+func NewFeedBriefingRepository(pool *Pool) *FeedBriefingRepository {
+	return &FeedBriefingRepository{pool: pool, feed: NewFeedRepository(pool)}
+}
+
+// feedBriefingQuery selects public posts as feed items, restricted to the
+// follower's followed tags ($1, $2) unless they follow none. where is the
+// WHERE clause, optionally preceded by extra joins; $3 is the limit.
+func feedBriefingQuery(where, orderBy string) string {
+	return `
+		WITH followed AS (
+			SELECT COALESCE(array_agg(tag), '{}') AS tags
+			FROM tag_follows
+			WHERE follower_type = $1 AND follower_id = $2
+		)
+		SELECT
+			p.id, p.type, p.title, p.description, p.tags,
+			p.status, p.posted_by_type, p.posted_by_id,
+			p.upvotes - p.downvotes as vote_score,
+			CASE WHEN p.type = 'question' THEN p.answers_count ELSE 0 END as answer_count,
+			p.approaches_count as approach_count,
+			p.comments_count as comment_count,
+			p.created_at,
+			COALESCE(u.display_name, a.display_name, '') as author_display_name,
+			COALESCE(u.avatar_url, a.avatar_url, '') as author_avatar_url
+		FROM posts p
+		CROSS JOIN followed f
+		LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
+		LEFT JOIN agents a ON p.posted_by_type = 'agent' AND p.posted_by_id = a.id
+		` + where + `
+		AND p.deleted_at IS NULL
+		AND p.visibility = 'public'
+		AND (cardinality(f.tags) = 0 OR p.tags && f.tags)
+		ORDER BY ` + orderBy + `
+		LIMIT $3
+	`
+}
+
+// GetBriefingSections returns the follower's followed tags and the posts for
+// their briefing: problems posted since, problems solved and questions answered
+// since, and the top trending questions, at most limit each.
+func (r *FeedBriefingRepository) GetBriefingSections(ctx context.Context, followerType, followerID string, since time.Time, limit int) (*models.FeedBriefing, error) {
+	b := &models.FeedBriefing{}
+	err := r.pool.QueryRow(ctx, `
+		SELECT COALESCE(array_agg(tag ORDER BY tag), '{}')
+		FROM tag_follows
+		WHERE follower_type = $1 AND follower_id = $2
+	`, followerType, followerID).Scan(&b.Tags)
+	if err != nil {
+		LogQueryError(ctx, "GetBriefingSections.Tags", "tag_follows", err)
+		return nil, fmt.Errorf("list followed tags: %w", err)
+	}
+
+	sections := []struct {
+		name  string
+		query string
+		args  []any
+		dest  *[]models.FeedItem
+	}{
+		{"new_problems", feedBriefingQuery(`
+			WHERE p.type = 'problem' AND p.status NOT IN ('pending_review', 'rejected', 'draft')
+			AND p.created_at >= $4`, "p.created_at DESC"), []any{since}, &b.NewProblems},
+		{"solved_posts", feedBriefingQuery(`
+			WHERE ((p.type = 'problem' AND p.status = 'solved') OR (p.type = 'question' AND p.status = 'answered'))
+			AND p.updated_at >= $4`, "p.updated_at DESC"), []any{since}, &b.SolvedPosts},
+		{"trending_questions", feedBriefingQuery(`
+			JOIN trending_scores ts ON ts.post_id = p.id
+			WHERE p.type = 'question' AND p.status NOT IN ('pending_review', 'rejected', 'draft')`,
+			"ts.score DESC, p.created_at DESC"), nil, &b.TrendingQuestions},
+	}
+	for _, s := range sections {
+		items, err := r.queryFeedItems(ctx, s.query, append([]any{followerType, followerID, limit}, s.args...)...)
+		if err != nil {
+			LogQueryError(ctx, "GetBriefingSections."+s.name, "posts", err)
+			return nil, fmt.Errorf("briefing %s: %w", s.name, err)
+		}
+		*s.dest = items
+	}
+	return b, nil
+}
+
+func (r *FeedBriefingRepository) queryFeedItems(ctx context.Context, query string, args ...any) ([]models.FeedItem, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.FeedItem, 0)
+	for rows.Next() {
+		item, err := r.feed.scanFeedItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, rows.Err()
+}
+
+// FindBriefing returns the follower's cached briefing for date (YYYY-MM-DD),
+// or nil if there is none.
+func (r *FeedBriefingRepository) FindBriefing(ctx context.Context, followerType, followerID, date string) (*models.FeedBriefing, error) {
+	var raw []byte
+	err := r.pool.QueryRow(ctx, `
+		SELECT briefing FROM feed_briefings
+		WHERE follower_type = $1 AND follower_id = $2 AND briefing_date = $3::date
+	`, followerType, followerID, date).Scan(&raw)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		LogQueryError(ctx, "FindBriefing", "feed_briefings", err)
+		return nil, fmt.Errorf("find feed briefing: %w", err)
+	}
+	var b models.FeedBriefing
+	if err := json.Unmarshal(raw, &b); err != nil {
+		return nil, fmt.Errorf("decode feed briefing: %w", err)
+	}
+	return &b, nil
+}
+
+// SaveBriefing caches b for its Date, replacing any briefing already cached
+// for that day, and drops the follower's briefings older than a week.
+func (r *FeedBriefingRepository) SaveBriefing(ctx context.Context, followerType, followerID string, b *models.FeedBriefing) error {
+	raw, err := json.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("encode feed briefing: %w", err)
+	}
+	err = r.pool.WithTx(ctx, func(tx Tx) error {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO feed_briefings (follower_type, follower_id, briefing_date, briefing)
+			VALUES ($1, $2, $3::date, $4)
+			ON CONFLICT (follower_type, follower_id, briefing_date) DO UPDATE SET
+				briefing = EXCLUDED.briefing, created_at = NOW()
+		`, followerType, followerID, b.Date, raw); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `
+			DELETE FROM feed_briefings
+			WHERE follower_type = $1 AND follower_id = $2 AND briefing_date < $3::date - $4::int
+		`, followerType, followerID, b.Date, feedBriefingRetention)
+		return err
+	})
+	if err != nil {
+		LogQueryError(ctx, "SaveBriefing", "feed_briefings", err)
+		return fmt.Errorf("save feed briefing: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestFeedBriefingRepository_SectionsAndCache(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewFeedBriefingRepository(pool)
+	agent := createInferTestAgent(t, pool, "briefing")
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM feed_briefings WHERE follower_id = $1", agent.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM tag_follows WHERE follower_id = $1", agent.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE posted_by_id = $1", agent.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM agents WHERE id = $1", agent.ID)
+	}()
+
+	tag := fmt.Sprintf("brief%d", time.Now().UnixNano()%1e9)
+	followed := createInferTestPost(t, pool, "agent", agent.ID, []string{tag})
+	createInferTestPost(t, pool, "agent", agent.ID, []string{tag + "other"})
+	if _, err := NewTagsRepository(pool).FollowTag(ctx, "agent", agent.ID, tag); err != nil {
+		t.Fatalf("FollowTag() error = %v", err)
+	}
+
+	b, err := repo.GetBriefingSections(ctx, "agent", agent.ID, time.Now().Add(-time.Hour), models.FeedBriefingSectionLimit)
+	if err != nil {
+		t.Fatalf("GetBriefingSections() error = %v", err)
+	}
+	if len(b.Tags) != 1 || b.Tags[0] != tag {
+		t.Errorf("Tags = %v, want [%s]", b.Tags, tag)
+	}
+	if len(b.NewProblems) != 1 || b.NewProblems[0].ID != followed {
+		t.Errorf("NewProblems = %+v, want only the problem in the followed tag", b.NewProblems)
+	}
+
+	date := time.Now().UTC().Format(time.DateOnly)
+	if got, err := repo.FindBriefing(ctx, "agent", agent.ID, date); err != nil || got != nil {
+		t.Fatalf("FindBriefing() before saving = %+v, %v; want nil, nil", got, err)
+	}
+	b.Date, b.Summary = date, "One new problem."
+	if err := repo.SaveBriefing(ctx, "agent", agent.ID, b); err != nil {
+		t.Fatalf("SaveBriefing() error = %v", err)
+	}
+	got, err := repo.FindBriefing(ctx, "agent", agent.ID, date)
+	if err != nil {
+		t.Fatalf("FindBriefing() error = %v", err)
+	}
+	if got == nil || got.Summary != "One new problem." || len(got.NewProblems) != 1 {
+		t.Errorf("FindBriefing() = %+v, want the saved briefing", got)
+	}
+}
@@ -273,6 +273,66 @@ func TestFeedRepository_GetRecentActivity_ExcludesDeleted(t *testing.T) {
 }
 
 
+// TestFeedRepository_GetFollowingFeed_RanksFollowedTagsFirst tests that a post
+// matching a followed tag ranks above a newer post that does not.
+func TestFeedRepository_GetFollowingFeed_RanksFollowedTagsFirst(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	feedRepo := NewFeedRepository(pool)
+	postRepo := NewPostRepository(pool)
+	userRepo := NewUserRepository(pool)
+	tagsRepo := NewTagsRepository(pool)
+
+	// Clean up any existing test data
+	cleanupFeedTestData(t, pool)
+
+	testUser := createFeedTestUser(t, userRepo)
+	defer pool.Exec(ctx, "DELETE FROM tag_follows WHERE follower_id = $1", testUser.ID)
+
+	followed, err := postRepo.Create(ctx, &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Followed tag question",
+		Description:  "Test description for a followed tag question",
+		Tags:         []string{"kubernetes"},
+		PostedByType: models.AuthorTypeHuman,
+		PostedByID:   testUser.ID,
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test post: %v", err)
+	}
+	newer := createFeedTestPost(t, postRepo, "Newer unrelated post", models.PostTypeQuestion, models.PostStatusOpen, models.AuthorTypeHuman, testUser.ID)
+
+	if _, err := tagsRepo.FollowTag(ctx, "human", testUser.ID, "kubernetes"); err != nil {
+		t.Fatalf("FollowTag failed: %v", err)
+	}
+
+	items, total, err := feedRepo.GetFollowingFeed(ctx, "human", testUser.ID, 1, 20)
+	if err != nil {
+		t.Fatalf("GetFollowingFeed failed: %v", err)
+	}
+	if total != 2 || len(items) != 2 {
+		t.Fatalf("expected 2 items, got total=%d len=%d", total, len(items))
+	}
+	if items[0].ID != followed.ID || items[1].ID != newer.ID {
+		t.Errorf("expected followed tag post first, got %s then %s", items[0].ID, items[1].ID)
+	}
+
+	// Without follows the feed is chronological
+	items, _, err = feedRepo.GetFollowingFeed(ctx, "agent", "no-follows-agent", 1, 20)
+	if err != nil {
+		t.Fatalf("GetFollowingFeed failed: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != newer.ID {
+		t.Errorf("expected newest post first without follows")
+	}
+
+	cleanupFeedTestData(t, pool)
+}
+
+
 // Helper function to create a test user
 func createFeedTestUser(t *testing.T, repo *UserRepository) *models.User {
 	t.Helper()
@@ -0,0 +1,278 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrOutdatedFlagExists is returned when a caller flags the same post outdated twice.
+	ErrOutdatedFlagExists = errors.New("post already flagged outdated by this caller")
+	// ErrOutdatedFlagNotFound is returned when removing a flag the caller never set.
+	ErrOutdatedFlagNotFound = errors.New("outdated flag not found")
+)
+
+// maxReviewFlagReasons caps the flag reasons returned per review queue item.
+const maxReviewFlagReasons = 5
+
+// PostFreshnessRepository scores post freshness and stores outdated flags.
+type PostFreshnessRepository struct {
+	pool *Pool
+}
+
+// NewPostFreshnessRepository creates a new PostFreshnessRepository.
+func NewPostFreshnessRepository(pool *Pool) *PostFreshnessRepository {
+	return &PostFreshnessRepository{pool: pool}
+}
+
+// GetFreshness returns a post's stored freshness. Returns ErrPostNotFound if the
+// post doesn't exist or is deleted.
+func (r *PostFreshnessRepository) GetFreshness(ctx context.Context, postID string) (*models.PostFreshness, error) {
+	f := &models.PostFreshness{}
+	var score *int16
+	err := r.pool.QueryRow(ctx, `
+		SELECT id::text, freshness_score, outdated, outdated_flag_count,
+		       COALESCE(library_versions, '{}'), freshness_scored_at, freshness_reviewed_at
+		FROM posts
+		WHERE id = $1 AND deleted_at IS NULL`, postID).Scan(
+		&f.PostID, &score, &f.Outdated, &f.OutdatedFlags,
+		&f.LibraryVersions, &f.ScoredAt, &f.ReviewedAt)
+	if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		LogQueryError(ctx, "GetFreshness", "posts", err)
+		return nil, fmt.Errorf("get freshness: %w", err)
+	}
+	if score != nil {
+		s := int(*score)
+		f.Score = &s
+	}
+	return f, nil
+}
+
+// FlagOutdated records a caller's outdated flag and rescores the post. Returns
+// ErrOutdatedFlagExists if the caller already flagged it, or ErrPostNotFound.
+func (r *PostFreshnessRepository) FlagOutdated(ctx context.Context, flag *models.OutdatedFlag) (*models.PostFreshness, error) {
+	var f *models.PostFreshness
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO post_outdated_flags (post_id, flagger_type, flagger_id, reason)
+			SELECT id, $2, $3, NULLIF($4, '') FROM posts WHERE id = $1 AND deleted_at IS NULL`,
+			flag.PostID, flag.FlaggerType, flag.FlaggerID, flag.Reason)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return ErrOutdatedFlagExists
+			}
+			if isInvalidUUIDError(err) {
+				return ErrPostNotFound
+			}
+			return fmt.Errorf("insert outdated flag: %w", err)
+		}
+		f, err = rescorePost(ctx, tx, flag.PostID, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// UnflagOutdated removes a caller's outdated flag and rescores the post. Returns
+// ErrOutdatedFlagNotFound if the caller has no flag on the post.
+func (r *PostFreshnessRepository) UnflagOutdated(ctx context.Context, postID, flaggerType, flaggerID string) (*models.PostFreshness, error) {
+	var f *models.PostFreshness
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		tag, err := tx.Exec(ctx, `
+			DELETE FROM post_outdated_flags
+			WHERE post_id = $1 AND flagger_type = $2 AND flagger_id = $3`,
+			postID, flaggerType, flaggerID)
+		if err != nil {
+			if isInvalidUUIDError(err) {
+				return ErrOutdatedFlagNotFound
+			}
+			return fmt.Errorf("delete outdated flag: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrOutdatedFlagNotFound
+		}
+		f, err = rescorePost(ctx, tx, postID, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// MarkReviewed records that an admin checked an aging post and found it still
+// accurate: its flags are cleared and its age counts from now. Returns
+// ErrPostNotFound if the post doesn't exist or is deleted.
+func (r *PostFreshnessRepository) MarkReviewed(ctx context.Context, postID string) (*models.PostFreshness, error) {
+	var f *models.PostFreshness
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE posts SET freshness_reviewed_at = NOW()
+			WHERE id = $1 AND deleted_at IS NULL`, postID)
+		if err != nil {
+			if isInvalidUUIDError(err) {
+				return ErrPostNotFound
+			}
+			return fmt.Errorf("mark freshness reviewed: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrPostNotFound
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM post_outdated_flags WHERE post_id = $1`, postID); err != nil {
+			return fmt.Errorf("clear outdated flags: %w", err)
+		}
+		f, err = rescorePost(ctx, tx, postID, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// RescoreStale rescores up to limit live, non-draft posts that were never
+// scored, were edited since their last score or were last scored before
+// olderThan, oldest score first. Returns the number of posts rescored.
+func (r *PostFreshnessRepository) RescoreStale(ctx context.Context, olderThan time.Time, limit int) (int, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id::text
+		FROM posts
+		WHERE deleted_at IS NULL AND status <> 'draft'
+		  AND (freshness_scored_at IS NULL
+		       OR freshness_scored_at < updated_at
+		       OR freshness_scored_at < $1)
+		ORDER BY freshness_scored_at NULLS FIRST
+		LIMIT $2`, olderThan, limit)
+	if err != nil {
+		LogQueryError(ctx, "RescoreStale", "posts", err)
+		return 0, fmt.Errorf("list posts to rescore: %w", err)
+	}
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan post to rescore: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate posts to rescore: %w", err)
+	}
+
+	rescored := 0
+	now := time.Now()
+	for _, id := range ids {
+		err := r.pool.WithTx(ctx, func(tx Tx) error {
+			_, err := rescorePost(ctx, tx, id, now)
+			return err
+		})
+		if errors.Is(err, ErrPostNotFound) {
+			continue // deleted since it was listed
+		}
+		if err != nil {
+			return rescored, err
+		}
+		rescored++
+	}
+	return rescored, nil
+}
+
+// ListReviewQueue returns outdated accepted solutions — solved problems and
+// questions with an accepted answer — least fresh first.
+func (r *PostFreshnessRepository) ListReviewQueue(ctx context.Context, limit int) ([]models.FreshnessReviewItem, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT p.id::text, p.type, p.title, p.status, p.freshness_score, p.outdated_flag_count,
+		       COALESCE(p.library_versions, '{}'), p.updated_at, p.freshness_reviewed_at,
+		       COALESCE(ARRAY(
+		           SELECT f.reason FROM post_outdated_flags f
+		           WHERE f.post_id = p.id AND f.reason IS NOT NULL
+		           ORDER BY f.created_at DESC
+		           LIMIT $2
+		       ), '{}')
+		FROM posts p
+		WHERE p.outdated AND p.deleted_at IS NULL
+		  AND p.freshness_score IS NOT NULL
+		  AND ((p.type = 'problem' AND p.status = 'solved')
+		       OR (p.type = 'question' AND p.accepted_answer_id IS NOT NULL))
+		ORDER BY p.freshness_score ASC, p.outdated_flag_count DESC, p.updated_at ASC
+		LIMIT $1`, limit, maxReviewFlagReasons)
+	if err != nil {
+		LogQueryError(ctx, "ListReviewQueue", "posts", err)
+		return nil, fmt.Errorf("list freshness review queue: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.FreshnessReviewItem{}
+	for rows.Next() {
+		var item models.FreshnessReviewItem
+		var score int16
+		if err := rows.Scan(&item.PostID, &item.Type, &item.Title, &item.Status, &score, &item.OutdatedFlags,
+			&item.LibraryVersions, &item.UpdatedAt, &item.ReviewedAt, &item.FlagReasons); err != nil {
+			return nil, fmt.Errorf("scan freshness review item: %w", err)
+		}
+		item.Score = int(score)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// rescorePost recomputes a post's library versions, flag count and freshness
+// score at now and stores them. updated_at is left alone: the content did not change.
+func rescorePost(ctx context.Context, tx Tx, postID string, now time.Time) (*models.PostFreshness, error) {
+	var description string
+	var updatedAt time.Time
+	var reviewedAt *time.Time
+	in := models.FreshnessInput{PostID: postID}
+	err := tx.QueryRow(ctx, `
+		SELECT p.description, p.updated_at, p.freshness_reviewed_at,
+		       (SELECT COUNT(*) FROM post_outdated_flags f WHERE f.post_id = p.id)
+		FROM posts p
+		WHERE p.id = $1 AND p.deleted_at IS NULL
+		FOR UPDATE`, postID).Scan(&description, &updatedAt, &reviewedAt, &in.OutdatedFlags)
+	if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load post freshness input: %w", err)
+	}
+
+	in.LastActivity = updatedAt
+	if reviewedAt != nil && reviewedAt.After(updatedAt) {
+		in.LastActivity = *reviewedAt
+	}
+	in.LibraryVersions = models.ExtractLibraryVersions(description)
+	score := models.FreshnessScore(in, now)
+
+	f := &models.PostFreshness{
+		PostID:          postID,
+		Score:           &score,
+		Outdated:        models.IsOutdated(score, in.OutdatedFlags),
+		OutdatedFlags:   in.OutdatedFlags,
+		LibraryVersions: in.LibraryVersions,
+		ScoredAt:        &now,
+		ReviewedAt:      reviewedAt,
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE posts
+		SET freshness_score = $2, outdated = $3, outdated_flag_count = $4,
+		    library_versions = $5, freshness_scored_at = $6
+		WHERE id = $1`,
+		postID, score, f.Outdated, f.OutdatedFlags, f.LibraryVersions, now); err != nil {
+		return nil, fmt.Errorf("store post freshness: %w", err)
+	}
+	return f, nil
+}
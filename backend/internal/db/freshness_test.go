@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPostFreshnessRepository_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	posts := NewPostRepository(pool)
+	repo := NewPostFreshnessRepository(pool)
+	agent := createStaleTestAgent(t, pool, "freshness")
+
+	post, err := posts.Create(ctx, &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        "Connection pool exhausted with pgx v4",
+		Description:  "Pool settings that fixed it:\n\n```\ngithub.com/jackc/pgx/v4 v4.18.1\n```",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   agent.ID,
+		Status:       models.PostStatusSolved,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID) })
+	if _, err := pool.Exec(ctx, "UPDATE posts SET updated_at = NOW() - INTERVAL '3 years' WHERE id = $1", post.ID); err != nil {
+		t.Fatalf("failed to age post: %v", err)
+	}
+
+	f, err := repo.GetFreshness(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("GetFreshness() error = %v", err)
+	}
+	if f.Score != nil {
+		t.Errorf("expected no score before the first rescore, got %d", *f.Score)
+	}
+
+	if _, err := repo.RescoreStale(ctx, time.Now().Add(-24*time.Hour), 1000); err != nil {
+		t.Fatalf("RescoreStale() error = %v", err)
+	}
+	f, err = repo.GetFreshness(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("GetFreshness() error = %v", err)
+	}
+	if f.Score == nil || *f.Score != 100-models.FreshnessMaxAgePenalty || !f.Outdated {
+		t.Errorf("expected an outdated score of %d, got %+v", 100-models.FreshnessMaxAgePenalty, f)
+	}
+	if len(f.LibraryVersions) != 1 || f.LibraryVersions[0] != "github.com/jackc/pgx/v4@4.18.1" {
+		t.Errorf("expected pinned pgx version, got %v", f.LibraryVersions)
+	}
+
+	flag := &models.OutdatedFlag{PostID: post.ID, FlaggerType: "agent", FlaggerID: agent.ID, Reason: "pgx v5 replaced pgxpool.ConnectConfig"}
+	f, err = repo.FlagOutdated(ctx, flag)
+	if err != nil {
+		t.Fatalf("FlagOutdated() error = %v", err)
+	}
+	if f.OutdatedFlags != 1 || *f.Score != 100-models.FreshnessMaxAgePenalty-models.FreshnessFlagPenalty {
+		t.Errorf("expected one flag to lower the score, got %+v", f)
+	}
+	if _, err := repo.FlagOutdated(ctx, flag); !errors.Is(err, ErrOutdatedFlagExists) {
+		t.Errorf("expected ErrOutdatedFlagExists on repeat, got %v", err)
+	}
+
+	queue, err := repo.ListReviewQueue(ctx, 1000)
+	if err != nil {
+		t.Fatalf("ListReviewQueue() error = %v", err)
+	}
+	var item *models.FreshnessReviewItem
+	for i := range queue {
+		if queue[i].PostID == post.ID {
+			item = &queue[i]
+		}
+	}
+	if item == nil || len(item.FlagReasons) != 1 || item.FlagReasons[0] != flag.Reason {
+		t.Fatalf("expected the post in the review queue with its flag reason, got %+v", item)
+	}
+
+	f, err = repo.MarkReviewed(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("MarkReviewed() error = %v", err)
+	}
+	if f.OutdatedFlags != 0 || *f.Score != 100 || f.Outdated || f.ReviewedAt == nil {
+		t.Errorf("expected review to clear flags and restart the age, got %+v", f)
+	}
+	if _, err := repo.UnflagOutdated(ctx, post.ID, "agent", agent.ID); !errors.Is(err, ErrOutdatedFlagNotFound) {
+		t.Errorf("expected ErrOutdatedFlagNotFound after review, got %v", err)
+	}
+
+	if _, err := repo.FlagOutdated(ctx, &models.OutdatedFlag{PostID: "00000000-0000-0000-0000-000000000000", FlaggerType: "agent", FlaggerID: agent.ID}); !errors.Is(err, ErrPostNotFound) {
+		t.Errorf("expected ErrPostNotFound for a missing post, got %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// KnowledgeGapsRepository reads the demand signals behind the weekly knowledge gap
+// report and publishes the report (migration 000107). It implements the
+// jobs.KnowledgeGapStore interface.
+type KnowledgeGapsRepository struct {
+	pool *Pool
+}
+
+// NewKnowledgeGapsRepository creates a new KnowledgeGapsRepository.
+func NewKnowledgeGapsRepository(pool *Pool) *KnowledgeGapsRepository {
+	return &KnowledgeGapsRepository{pool: pool}
+}
+
+// HasReportForWeek reports whether the week starting at weekStart already has a report.
+func (r *KnowledgeGapsRepository) HasReportForWeek(ctx context.Context, weekStart time.Time) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM knowledge_gap_reports WHERE week_start = $1::date)
+	`, weekStart).Scan(&exists)
+	if err != nil {
+		LogQueryError(ctx, "HasReportForWeek", "knowledge_gap_reports", err)
+		return false, fmt.Errorf("failed to check knowledge gap report: %w", err)
+	}
+	return exists, nil
+}
+
+// ListZeroResultQueries returns the searches that returned nothing in [since, until),
+// most searched first.
+func (r *KnowledgeGapsRepository) ListZeroResultQueries(ctx context.Context, since, until time.Time, limit int) ([]models.ZeroResultQuery, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT query_normalized, COUNT(*) AS search_count
+		FROM search_queries
+		WHERE searched_at >= $1 AND searched_at < $2 AND results_count = 0
+		GROUP BY query_normalized
+		ORDER BY search_count DESC, query_normalized
+		LIMIT $3
+	`, since, until, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListZeroResultQueries", "search_queries", err)
+		return nil, fmt.Errorf("failed to list zero-result queries: %w", err)
+	}
+	defer rows.Close()
+
+	var queries []models.ZeroResultQuery
+	for rows.Next() {
+		var q models.ZeroResultQuery
+		if err := rows.Scan(&q.Query, &q.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan zero-result query: %w", err)
+		}
+		queries = append(queries, q)
+	}
+	return queries, rows.Err()
+}
+
+// ListStuckProblems returns public problems escalated as stuck in [since, until),
+// newest first. Family-visible problems are left out because the report is public.
+func (r *KnowledgeGapsRepository) ListStuckProblems(ctx context.Context, since, until time.Time, limit int) ([]models.StuckProblemSummary, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT p.id, p.title, COALESCE(p.tags, '{}'), e.summary
+		FROM problem_escalations e
+		JOIN posts p ON p.id = e.problem_id
+		WHERE e.created_at >= $1 AND e.created_at < $2
+		  AND p.deleted_at IS NULL AND p.visibility = 'public'
+		ORDER BY e.created_at DESC
+		LIMIT $3
+	`, since, until, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListStuckProblems", "problem_escalations", err)
+		return nil, fmt.Errorf("failed to list stuck problems: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []models.StuckProblemSummary
+	for rows.Next() {
+		var p models.StuckProblemSummary
+		if err := rows.Scan(&p.ProblemID, &p.Title, &p.Tags, &p.Summary); err != nil {
+			return nil, fmt.Errorf("failed to scan stuck problem: %w", err)
+		}
+		problems = append(problems, p)
+	}
+	return problems, rows.Err()
+}
+
+// FindReportAuthor returns the user who publishes the report: the oldest admin
+// account. Returns "" without error when there are no admins.
+func (r *KnowledgeGapsRepository) FindReportAuthor(ctx context.Context) (string, error) {
+	var id string
+	err := r.pool.QueryRow(ctx, `
+		SELECT id FROM users WHERE role = $1 AND deleted_at IS NULL ORDER BY created_at, id LIMIT 1
+	`, models.UserRoleAdmin).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "FindReportAuthor", "users", err)
+		return "", fmt.Errorf("failed to find report author: %w", err)
+	}
+	return id, nil
+}
+
+// ListAdminRecipients returns the admins to email the report to, oldest account
+// first. Admins who unsubscribed from all emails are left out.
+func (r *KnowledgeGapsRepository) ListAdminRecipients(ctx context.Context) ([]models.EmailRecipient, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, email, display_name, COALESCE(referral_code, '')
+		FROM users
+		WHERE role = $1 AND deleted_at IS NULL AND email_unsubscribed_at IS NULL
+		ORDER BY created_at, id
+	`, models.UserRoleAdmin)
+	if err != nil {
+		LogQueryError(ctx, "ListAdminRecipients", "users", err)
+		return nil, fmt.Errorf("failed to list admins: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []models.EmailRecipient
+	for rows.Next() {
+		var rec models.EmailRecipient
+		if err := rows.Scan(&rec.ID, &rec.Email, &rec.DisplayName, &rec.ReferralCode); err != nil {
+			return nil, fmt.Errorf("failed to scan admin: %w", err)
+		}
+		recipients = append(recipients, rec)
+	}
+	return recipients, rows.Err()
+}
+
+// PublishReport stores the week's report and publishes post as its blog post, in
+// one transaction. Sets report.ID, report.BlogPostID and report.CreatedAt.
+// Returns false without error if the week already has a report.
+func (r *KnowledgeGapsRepository) PublishReport(ctx context.Context, report *models.KnowledgeGapReport, post *models.BlogPost) (bool, error) {
+	gaps, err := json.Marshal(report.Gaps)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal knowledge gaps: %w", err)
+	}
+
+	published := false
+	err = r.pool.WithTx(ctx, func(tx Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO knowledge_gap_reports (week_start, summary, gaps, zero_result_queries, stuck_problems)
+			VALUES ($1::date, $2, $3, $4, $5)
+			ON CONFLICT (week_start) DO NOTHING
+			RETURNING id, created_at
+		`, report.WeekStart, report.Summary, gaps, report.ZeroResultQueries, report.StuckProblems).Scan(&report.ID, &report.CreatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			LogQueryError(ctx, "PublishReport", "knowledge_gap_reports", err)
+			return fmt.Errorf("failed to insert knowledge gap report: %w", err)
+		}
+
+		err = tx.QueryRow(ctx, `
+			INSERT INTO blog_posts (
+				slug, title, body, excerpt, tags, posted_by_type, posted_by_id, status,
+				read_time_minutes, meta_description, published_at, created_at, updated_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW(), NOW(), NOW())
+			RETURNING id
+		`, post.Slug, post.Title, post.Body, models.GenerateExcerpt(post.Body, 500), post.Tags,
+			post.PostedByType, post.PostedByID, models.BlogPostStatusPublished,
+			models.CalculateReadTime(post.Body), post.MetaDescription).Scan(&report.BlogPostID)
+		if err != nil {
+			LogQueryError(ctx, "PublishReport", "blog_posts", err)
+			return fmt.Errorf("failed to insert knowledge gap blog post: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			UPDATE knowledge_gap_reports SET blog_post_id = $2 WHERE id = $1
+		`, report.ID, report.BlogPostID); err != nil {
+			return fmt.Errorf("failed to link knowledge gap blog post: %w", err)
+		}
+		report.BlogPostSlug = post.Slug
+		published = true
+		return nil
+	})
+	return published, err
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// MaintenanceRepository stores the maintenance switch shared by API replicas.
+// It implements maintenance.Store.
+type MaintenanceRepository struct {
+	pool *Pool
+}
+
+// NewMaintenanceRepository creates a new MaintenanceRepository.
+func NewMaintenanceRepository(pool *Pool) *MaintenanceRepository {
+	return &MaintenanceRepository{pool: pool}
+}
+
+// LoadMaintenance returns the saved switch, or nil when it was never saved.
+func (r *MaintenanceRepository) LoadMaintenance(ctx context.Context) (*models.MaintenanceStatus, error) {
+	var status models.MaintenanceStatus
+	err := r.pool.QueryRow(ctx, `
+		SELECT enabled, message, since
+		FROM maintenance_state
+		WHERE id
+	`).Scan(&status.Enabled, &status.Message, &status.Since)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "LoadMaintenance", "maintenance_state", err)
+		return nil, err
+	}
+	return &status, nil
+}
+
+// SaveMaintenance replaces the saved switch.
+func (r *MaintenanceRepository) SaveMaintenance(ctx context.Context, status models.MaintenanceStatus) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO maintenance_state (id, enabled, message, since, updated_at)
+		VALUES (TRUE, $1, $2, $3, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			message = EXCLUDED.message,
+			since = EXCLUDED.since,
+			updated_at = EXCLUDED.updated_at
+	`, status.Enabled, status.Message, status.Since)
+	if err != nil {
+		LogQueryError(ctx, "SaveMaintenance", "maintenance_state", err)
+	}
+	return err
+}
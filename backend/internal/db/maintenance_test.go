@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestMaintenanceRepository_SaveLoad_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewMaintenanceRepository(pool)
+	defer func() { _, _ = pool.Exec(ctx, "DELETE FROM maintenance_state") }()
+
+	since := time.Now().UTC().Truncate(time.Microsecond)
+	if err := repo.SaveMaintenance(ctx, models.MaintenanceStatus{Enabled: true, Message: "reindexing", Since: &since}); err != nil {
+		t.Fatalf("SaveMaintenance() error = %v", err)
+	}
+	status, err := repo.LoadMaintenance(ctx)
+	if err != nil {
+		t.Fatalf("LoadMaintenance() error = %v", err)
+	}
+	if status == nil || !status.Enabled || status.Message != "reindexing" || !status.Since.Equal(since) {
+		t.Errorf("LoadMaintenance() = %+v, want the saved state", status)
+	}
+
+	if err := repo.SaveMaintenance(ctx, models.MaintenanceStatus{}); err != nil {
+		t.Fatalf("SaveMaintenance() off error = %v", err)
+	}
+	status, err = repo.LoadMaintenance(ctx)
+	if err != nil || status == nil || status.Enabled || status.Since != nil {
+		t.Errorf("LoadMaintenance() = %+v, %v, want saved off state", status, err)
+	}
+}
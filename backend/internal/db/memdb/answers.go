@@ -0,0 +1,286 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// AnswersRepository is the in-memory counterpart of db.AnswersRepository.
+type AnswersRepository struct {
+	s *Store
+}
+
+// ListAnswers returns a page of answers on a public question, newest first, or
+// ordered by opts.Sort: "oldest", "top" by vote score, "verified-first" with the
+// accepted answer first, then vote score, or "quality" by quality score
+// (unscored last), then vote score.
+func (r *AnswersRepository) ListAnswers(ctx context.Context, questionID string, opts models.AnswerListOptions) ([]models.AnswerWithAuthor, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var matched []*models.Answer
+	for _, a := range r.s.answers {
+		if a.QuestionID == questionID && a.DeletedAt == nil {
+			matched = append(matched, a)
+		}
+	}
+	total := len(matched)
+	if q, ok := r.s.posts[questionID]; !ok || q.Visibility == models.VisibilityFamily {
+		matched = nil
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		switch opts.Sort {
+		case "oldest":
+			return a.CreatedAt.Before(b.CreatedAt)
+		case "verified-first":
+			if a.IsAccepted != b.IsAccepted {
+				return a.IsAccepted
+			}
+		case "quality":
+			if qa, qb := qualityRank(a), qualityRank(b); qa != qb {
+				return qa > qb
+			}
+		}
+		if opts.Sort != "" && opts.Sort != "newest" {
+			if sa, sb := a.VoteScore(), b.VoteScore(); sa != sb {
+				return sa > sb
+			}
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	start, end := pageBounds(opts.Page, opts.PerPage, 20, 50, len(matched))
+	answers := make([]models.AnswerWithAuthor, 0, end-start)
+	for _, a := range matched[start:end] {
+		answers = append(answers, r.s.answerWithAuthor(a))
+	}
+	return answers, total, nil
+}
+
+// qualityRank sorts unscored answers after every scored one.
+func qualityRank(a *models.Answer) int {
+	if a.QualityScore == nil {
+		return -1
+	}
+	return *a.QualityScore
+}
+
+// CreateAnswer stores a new answer and moves an open question to answered.
+func (r *AnswersRepository) CreateAnswer(ctx context.Context, answer *models.Answer) (*models.Answer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	q, ok := r.s.posts[answer.QuestionID]
+	if !ok {
+		return nil, fmt.Errorf("insert answer: %w", db.ErrQuestionNotExist)
+	}
+
+	if answer.SupersedesAnswerID != nil && r.s.supersededBy(*answer.SupersedesAnswerID) != nil {
+		return nil, db.ErrAnswerAlreadySuperseded
+	}
+
+	now := r.s.now()
+	stored := *answer
+	stored.ID = newID(answer.ID)
+	stored.IsAccepted = false
+	stored.Upvotes, stored.Downvotes = 0, 0
+	stored.QualityScore = nil
+	stored.CodeLanguages = models.ExtractCodeLanguages(answer.Content)
+	stored.CreatedAt, stored.UpdatedAt = now, now
+	stored.DeletedAt = nil
+	stored.EmbeddingStr = nil
+	stored.SupersededByID = nil
+	r.s.answers[stored.ID] = &stored
+
+	if q.Type == models.PostTypeQuestion && q.Status == models.PostStatusOpen {
+		q.Status = models.PostStatusAnswered
+		q.UpdatedAt = now
+	}
+
+	*answer = stored
+	return answer, nil
+}
+
+// FindAnswerByID returns a non-deleted answer on a public question.
+func (r *AnswersRepository) FindAnswerByID(ctx context.Context, id string) (*models.AnswerWithAuthor, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	a, ok := r.s.answers[id]
+	if !ok || a.DeletedAt != nil {
+		return nil, db.ErrAnswerNotFound
+	}
+	if q, ok := r.s.posts[a.QuestionID]; !ok || q.Visibility == models.VisibilityFamily {
+		return nil, db.ErrAnswerNotFound
+	}
+	ans := r.s.answerWithAuthor(a)
+	return &ans, nil
+}
+
+// UpdateAnswer replaces an answer's content and clears its quality score. A
+// non-zero UpdatedAt must match the stored one, otherwise db.ErrVersionConflict.
+func (r *AnswersRepository) UpdateAnswer(ctx context.Context, answer *models.Answer) (*models.Answer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	a, ok := r.s.answers[answer.ID]
+	if !ok || a.DeletedAt != nil {
+		return nil, db.ErrAnswerNotFound
+	}
+	if !answer.UpdatedAt.IsZero() && !answer.UpdatedAt.Equal(a.UpdatedAt) {
+		return nil, db.ErrVersionConflict
+	}
+
+	a.Content = answer.Content
+	a.CodeLanguages = models.ExtractCodeLanguages(answer.Content)
+	a.QualityScore = nil
+	a.UpdatedAt = r.s.now()
+
+	*answer = *a
+	answer.CodeLanguages = cloneStrings(a.CodeLanguages)
+	return answer, nil
+}
+
+// DeleteAnswer soft-deletes an answer.
+func (r *AnswersRepository) DeleteAnswer(ctx context.Context, id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	a, ok := r.s.answers[id]
+	if !ok || a.DeletedAt != nil {
+		return db.ErrAnswerNotFound
+	}
+	now := r.s.now()
+	a.DeletedAt = &now
+	return nil
+}
+
+// AcceptAnswer accepts one answer on a question, unaccepting any other, and
+// marks the question solved.
+func (r *AnswersRepository) AcceptAnswer(ctx context.Context, questionID, answerID string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	target, ok := r.s.answers[answerID]
+	if !ok || target.QuestionID != questionID || target.DeletedAt != nil {
+		return db.ErrAnswerNotFound
+	}
+	for _, a := range r.s.answers {
+		if a.QuestionID == questionID {
+			a.IsAccepted = false
+		}
+	}
+	target.IsAccepted = true
+
+	if q, ok := r.s.posts[questionID]; ok && q.Type == models.PostTypeQuestion {
+		q.Status = models.PostStatusSolved
+		id := answerID
+		q.AcceptedAnswerID = &id
+	}
+	return nil
+}
+
+// VoteOnAnswer records, changes or retracts (direction "none") a vote on an answer.
+func (r *AnswersRepository) VoteOnAnswer(ctx context.Context, answerID, voterType, voterID, direction string) error {
+	if direction != "up" && direction != "down" && direction != db.VoteDirectionNone {
+		return fmt.Errorf("invalid vote direction: %s", direction)
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	a, ok := r.s.answers[answerID]
+	if !ok || a.DeletedAt != nil {
+		return db.ErrAnswerNotFound
+	}
+	up, down := r.s.castVote("answer", answerID, voterType, voterID, direction)
+	a.Upvotes = max(a.Upvotes+up, 0)
+	a.Downvotes = max(a.Downvotes+down, 0)
+	return nil
+}
+
+// GetAnswerCount returns the number of non-deleted answers on a question.
+func (r *AnswersRepository) GetAnswerCount(ctx context.Context, questionID string) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+	return r.s.answersCount(questionID), nil
+}
+
+// GetAnswerVersionChain returns the chain of versions the answer belongs to,
+// oldest first. Deleted versions are walked through but not returned.
+func (r *AnswersRepository) GetAnswerVersionChain(ctx context.Context, answerID string) (*models.AnswerVersionChain, error) {
+	if _, err := r.FindAnswerByID(ctx, answerID); err != nil {
+		return nil, err
+	}
+
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	root := r.s.answers[answerID]
+	for root.SupersedesAnswerID != nil {
+		prev, ok := r.s.answers[*root.SupersedesAnswerID]
+		if !ok {
+			break
+		}
+		root = prev
+	}
+
+	chain := &models.AnswerVersionChain{AnswerID: answerID, Versions: make([]models.AnswerWithAuthor, 0)}
+	level := []*models.Answer{root}
+	for len(level) > 0 {
+		sort.SliceStable(level, func(i, j int) bool { return level[i].CreatedAt.Before(level[j].CreatedAt) })
+		var next []*models.Answer
+		for _, a := range level {
+			if a.DeletedAt == nil {
+				chain.Versions = append(chain.Versions, r.s.answerWithAuthor(a))
+			}
+			for _, n := range r.s.answers {
+				if n.SupersedesAnswerID != nil && *n.SupersedesAnswerID == a.ID {
+					next = append(next, n)
+				}
+			}
+		}
+		level = next
+	}
+	chain.LatestVerifiedID = models.LatestVerifiedAnswerID(chain.Versions)
+	return chain, nil
+}
+
+// supersededBy returns the newest non-deleted answer superseding id, or nil.
+// Callers hold s.mu.
+func (s *Store) supersededBy(id string) *models.Answer {
+	var newest *models.Answer
+	for _, a := range s.answers {
+		if a.SupersedesAnswerID != nil && *a.SupersedesAnswerID == id && a.DeletedAt == nil &&
+			(newest == nil || a.CreatedAt.After(newest.CreatedAt)) {
+			newest = a
+		}
+	}
+	return newest
+}
+
+// answerWithAuthor builds the view of an answer. Callers hold s.mu.
+func (s *Store) answerWithAuthor(a *models.Answer) models.AnswerWithAuthor {
+	ans := models.AnswerWithAuthor{Answer: *a}
+	ans.CodeLanguages = cloneStrings(a.CodeLanguages)
+	displayName, avatarURL := s.author(a.AuthorType, a.AuthorID)
+	if displayName == "" {
+		displayName = a.AuthorID
+	}
+	if a.AuthorType != models.AuthorTypeHuman {
+		avatarURL = ""
+	}
+	ans.Author = models.AnswerAuthor{Type: a.AuthorType, ID: a.AuthorID, DisplayName: displayName, AvatarURL: avatarURL}
+	if n := s.supersededBy(a.ID); n != nil {
+		id := n.ID
+		ans.SupersededByID = &id
+	}
+	ans.VoteScore = a.Upvotes - a.Downvotes
+	return ans
+}
@@ -0,0 +1,228 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ApproachesRepository is the in-memory counterpart of db.ApproachesRepository.
+type ApproachesRepository struct {
+	s *Store
+}
+
+// HasSucceededApproach reports whether a problem has a non-deleted succeeded approach.
+func (r *ApproachesRepository) HasSucceededApproach(ctx context.Context, problemID string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, a := range r.s.approaches {
+		if a.ProblemID == problemID && a.Status == models.ApproachStatusSucceeded && a.DeletedAt == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateApproach stores a new approach as the latest version.
+func (r *ApproachesRepository) CreateApproach(ctx context.Context, approach *models.Approach) (*models.Approach, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.posts[approach.ProblemID]; !ok {
+		return nil, fmt.Errorf("create approach: problem %s does not exist", approach.ProblemID)
+	}
+
+	now := r.s.now()
+	stored := *approach
+	stored.ID = newID(approach.ID)
+	if stored.Status == "" {
+		stored.Status = models.ApproachStatusStarting
+	}
+	stored.Assumptions = cloneStrings(approach.Assumptions)
+	stored.DiffersFrom = cloneStrings(approach.DiffersFrom)
+	stored.IsLatest = true
+	stored.CreatedAt, stored.UpdatedAt = now, now
+	stored.DeletedAt = nil
+	stored.EmbeddingStr = nil
+	r.s.approaches[stored.ID] = &stored
+
+	approach.ID = stored.ID
+	approach.CreatedAt = now
+	approach.UpdatedAt = now
+	return approach, nil
+}
+
+// FindApproachByID returns a non-deleted approach with author information.
+func (r *ApproachesRepository) FindApproachByID(ctx context.Context, id string) (*models.ApproachWithAuthor, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	a, ok := r.s.approaches[id]
+	if !ok || a.DeletedAt != nil {
+		return nil, db.ErrApproachNotFound
+	}
+	approach := r.s.approachWithAuthor(a)
+	return &approach, nil
+}
+
+// ListApproaches returns a page of approaches on a public problem, newest first,
+// or ordered by opts.Sort: "oldest", "top" by vote score, or "verified-first"
+// with succeeded approaches first (verification events are not modelled).
+func (r *ApproachesRepository) ListApproaches(ctx context.Context, problemID string, opts models.ApproachListOptions) ([]models.ApproachWithAuthor, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var matched []*models.Approach
+	for _, a := range r.s.approaches {
+		if a.ProblemID == problemID && a.DeletedAt == nil && (opts.OutcomeType == "" || a.OutcomeType == opts.OutcomeType) {
+			matched = append(matched, a)
+		}
+	}
+	total := len(matched)
+	if p, ok := r.s.posts[problemID]; !ok || p.Visibility == models.VisibilityFamily {
+		matched = nil
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		switch opts.Sort {
+		case "oldest":
+			return a.CreatedAt.Before(b.CreatedAt)
+		case "top":
+			if sa, sb := r.s.approachVoteScore(a.ID), r.s.approachVoteScore(b.ID); sa != sb {
+				return sa > sb
+			}
+		case "verified-first":
+			if sa, sb := a.Status == models.ApproachStatusSucceeded, b.Status == models.ApproachStatusSucceeded; sa != sb {
+				return sa
+			}
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	start, end := pageBounds(opts.Page, opts.PerPage, 20, 50, len(matched))
+	approaches := make([]models.ApproachWithAuthor, 0, end-start)
+	for _, a := range matched[start:end] {
+		approaches = append(approaches, r.s.approachWithAuthor(a))
+	}
+	return approaches, total, nil
+}
+
+// approachVoteScore sums the votes on an approach; approaches keep no vote
+// counters. Callers hold s.mu.
+func (s *Store) approachVoteScore(approachID string) int {
+	score := 0
+	for key, direction := range s.votes {
+		if key.targetType != "approach" || key.targetID != approachID {
+			continue
+		}
+		if direction == "up" {
+			score++
+		} else {
+			score--
+		}
+	}
+	return score
+}
+
+// UpdateApproach sets the status, outcome, outcome type, solution, method and
+// long-running flag of an approach. Empty fields keep their stored value, like the
+// COALESCE update in the db package; the long-running flag and failure reason are
+// always written.
+func (r *ApproachesRepository) UpdateApproach(ctx context.Context, approach *models.Approach) (*models.Approach, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	a, ok := r.s.approaches[approach.ID]
+	if !ok || a.DeletedAt != nil {
+		return nil, db.ErrApproachNotFound
+	}
+	if approach.Status != "" {
+		a.Status = approach.Status
+	}
+	if approach.Outcome != "" {
+		a.Outcome = approach.Outcome
+	}
+	if approach.Solution != "" {
+		a.Solution = approach.Solution
+	}
+	if approach.Method != "" {
+		a.Method = approach.Method
+	}
+	if approach.OutcomeType != "" {
+		a.OutcomeType = approach.OutcomeType
+	}
+	a.FailureReason = approach.FailureReason
+	a.LongRunning = approach.LongRunning
+	a.UpdatedAt = r.s.now()
+
+	approach.Status = a.Status
+	approach.Outcome = a.Outcome
+	approach.Solution = a.Solution
+	approach.Method = a.Method
+	approach.OutcomeType = a.OutcomeType
+	approach.FailureReason = a.FailureReason
+	approach.LongRunning = a.LongRunning
+	approach.UpdatedAt = a.UpdatedAt
+	return approach, nil
+}
+
+// DeleteApproach soft-deletes an approach.
+func (r *ApproachesRepository) DeleteApproach(ctx context.Context, id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	a, ok := r.s.approaches[id]
+	if !ok || a.DeletedAt != nil {
+		return db.ErrApproachNotFound
+	}
+	now := r.s.now()
+	a.DeletedAt = &now
+	return nil
+}
+
+// AddProgressNote stores a progress note on an approach.
+func (r *ApproachesRepository) AddProgressNote(ctx context.Context, note *models.ProgressNote) (*models.ProgressNote, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.approaches[note.ApproachID]; !ok {
+		return nil, fmt.Errorf("add progress note: approach %s does not exist", note.ApproachID)
+	}
+	note.ID = newID(note.ID)
+	note.CreatedAt = r.s.now()
+	r.s.notes[note.ApproachID] = append(r.s.notes[note.ApproachID], *note)
+	return note, nil
+}
+
+// GetProgressNotes returns an approach's progress notes, newest first.
+func (r *ApproachesRepository) GetProgressNotes(ctx context.Context, approachID string) ([]models.ProgressNote, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	stored := r.s.notes[approachID]
+	notes := make([]models.ProgressNote, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		notes = append(notes, stored[i])
+	}
+	return notes, nil
+}
+
+// approachWithAuthor builds the view of an approach. Callers hold s.mu.
+func (s *Store) approachWithAuthor(a *models.Approach) models.ApproachWithAuthor {
+	approach := models.ApproachWithAuthor{Approach: *a}
+	approach.Assumptions = cloneStrings(a.Assumptions)
+	approach.DiffersFrom = cloneStrings(a.DiffersFrom)
+	displayName, avatarURL := s.author(a.AuthorType, a.AuthorID)
+	if displayName == "" {
+		displayName = a.AuthorID
+	}
+	if a.AuthorType != models.AuthorTypeHuman {
+		avatarURL = ""
+	}
+	approach.Author = models.ApproachAuthor{Type: a.AuthorType, ID: a.AuthorID, DisplayName: displayName, AvatarURL: avatarURL}
+	return approach
+}
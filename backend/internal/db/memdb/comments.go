@@ -0,0 +1,122 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// CommentsRepository is the in-memory counterpart of db.CommentsRepository.
+type CommentsRepository struct {
+	s *Store
+}
+
+// Create stores a new comment.
+func (r *CommentsRepository) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored := *comment
+	stored.ID = newID(comment.ID)
+	stored.CreatedAt = r.s.now()
+	stored.DeletedAt = nil
+	r.s.comments[stored.ID] = &stored
+
+	created := stored
+	return &created, nil
+}
+
+// FindByID returns a non-deleted comment with author information.
+func (r *CommentsRepository) FindByID(ctx context.Context, id string) (*models.CommentWithAuthor, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	c, ok := r.s.comments[id]
+	if !ok || c.DeletedAt != nil {
+		return nil, db.ErrCommentNotFound
+	}
+	cwa := r.s.commentWithAuthor(c)
+	return &cwa, nil
+}
+
+// List returns a page of comments on a target, oldest first. Comments on a family
+// post are not listed.
+func (r *CommentsRepository) List(ctx context.Context, opts models.CommentListOptions) ([]models.CommentWithAuthor, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var matched []*models.Comment
+	for _, c := range r.s.comments {
+		if c.TargetType == opts.TargetType && c.TargetID == opts.TargetID && c.DeletedAt == nil {
+			matched = append(matched, c)
+		}
+	}
+	total := len(matched)
+	if opts.TargetType == models.CommentTargetPost {
+		if p, ok := r.s.posts[opts.TargetID]; !ok || p.Visibility == models.VisibilityFamily {
+			matched = nil
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].CreatedAt.Before(matched[j].CreatedAt) })
+
+	start, end := pageBounds(opts.Page, opts.PerPage, 20, 50, len(matched))
+	comments := make([]models.CommentWithAuthor, 0, end-start)
+	for _, c := range matched[start:end] {
+		comments = append(comments, r.s.commentWithAuthor(c))
+	}
+	return comments, total, nil
+}
+
+// Delete soft-deletes a comment.
+func (r *CommentsRepository) Delete(ctx context.Context, id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	c, ok := r.s.comments[id]
+	if !ok || c.DeletedAt != nil {
+		return db.ErrCommentNotFound
+	}
+	now := r.s.now()
+	c.DeletedAt = &now
+	return nil
+}
+
+// TargetExists reports whether a comment target exists and is not deleted.
+// Idea responses are not modelled, so response targets never exist.
+func (r *CommentsRepository) TargetExists(ctx context.Context, targetType models.CommentTargetType, targetID string) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	switch targetType {
+	case models.CommentTargetPost:
+		p, ok := r.s.posts[targetID]
+		return ok && p.DeletedAt == nil, nil
+	case models.CommentTargetApproach:
+		a, ok := r.s.approaches[targetID]
+		return ok && a.DeletedAt == nil, nil
+	case models.CommentTargetAnswer:
+		a, ok := r.s.answers[targetID]
+		return ok && a.DeletedAt == nil, nil
+	case models.CommentTargetResponse:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown target type: %s", targetType)
+	}
+}
+
+// commentWithAuthor builds the view of a comment. Callers hold s.mu.
+func (s *Store) commentWithAuthor(c *models.Comment) models.CommentWithAuthor {
+	cwa := models.CommentWithAuthor{Comment: *c}
+	displayName, avatarURL := s.author(c.AuthorType, c.AuthorID)
+	if displayName == "" {
+		displayName = "Unknown"
+	}
+	cwa.Author = models.CommentAuthor{ID: c.AuthorID, Type: c.AuthorType, DisplayName: displayName}
+	if avatarURL != "" {
+		cwa.Author.AvatarURL = &avatarURL
+	}
+	return cwa
+}
@@ -0,0 +1,226 @@
+// Package memdb provides in-memory implementations of the db repositories for
+// posts, answers, approaches, comments, votes and users, so handler and service
+// tests can run without DATABASE_URL.
+//
+// All repositories created from one Store share its data, the same way the db
+// repositories share a pool: a post created through Posts is visible to
+// Questions, an answer vote changes the answer returned by Answers, and so on.
+// Behavior follows the db package: soft deletes, the same sentinel errors
+// (db.ErrPostNotFound, db.ErrAnswerNotFound, ...), one vote per voter per target,
+// vote counters, family visibility and the same pagination defaults. Full-text
+// search, embeddings, block lists, reactions and the background-job columns are not
+// modelled.
+package memdb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Store holds the shared in-memory state.
+type Store struct {
+	mu sync.RWMutex
+
+	// Now returns the current time. Tests may replace it for deterministic timestamps.
+	Now func() time.Time
+
+	posts      map[string]*postRow
+	answers    map[string]*models.Answer
+	approaches map[string]*models.Approach
+	notes      map[string][]models.ProgressNote // by approach ID
+	comments   map[string]*models.Comment
+	votes      map[voteKey]string // direction
+	users      map[string]*models.User
+	agents     map[string]*models.Agent
+
+	seq int // insertion order, breaks created_at ties
+}
+
+// postRow is a stored post plus the columns the db package keeps outside models.Post.
+type postRow struct {
+	models.Post
+	ownerHumanID string
+	seq          int
+}
+
+// voteKey is the unique key of the votes table.
+type voteKey struct {
+	targetType string
+	targetID   string
+	voterType  string
+	voterID    string
+}
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	return &Store{
+		Now:        time.Now,
+		posts:      map[string]*postRow{},
+		answers:    map[string]*models.Answer{},
+		approaches: map[string]*models.Approach{},
+		notes:      map[string][]models.ProgressNote{},
+		comments:   map[string]*models.Comment{},
+		votes:      map[voteKey]string{},
+		users:      map[string]*models.User{},
+		agents:     map[string]*models.Agent{},
+	}
+}
+
+// Posts returns a PostRepository backed by s.
+func (s *Store) Posts() *PostRepository { return &PostRepository{s: s} }
+
+// Answers returns an AnswersRepository backed by s.
+func (s *Store) Answers() *AnswersRepository { return &AnswersRepository{s: s} }
+
+// Approaches returns an ApproachesRepository backed by s.
+func (s *Store) Approaches() *ApproachesRepository { return &ApproachesRepository{s: s} }
+
+// Questions returns a QuestionsRepository backed by s.
+func (s *Store) Questions() *QuestionsRepository { return &QuestionsRepository{s: s} }
+
+// Problems returns a ProblemsRepository backed by s.
+func (s *Store) Problems() *ProblemsRepository { return &ProblemsRepository{s: s} }
+
+// Comments returns a CommentsRepository backed by s.
+func (s *Store) Comments() *CommentsRepository { return &CommentsRepository{s: s} }
+
+// Users returns a UserRepository backed by s.
+func (s *Store) Users() *UserRepository { return &UserRepository{s: s} }
+
+// PutAgent stores an agent so content it authors gets its display name, avatar
+// and family (HumanID). There is no in-memory agent repository.
+func (s *Store) PutAgent(agent models.Agent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := agent
+	s.agents[a.ID] = &a
+}
+
+// now returns the store time in UTC, truncated like a timestamptz column.
+func (s *Store) now() time.Time {
+	return s.Now().UTC().Truncate(time.Microsecond)
+}
+
+// nextSeq returns the next insertion sequence number. Callers hold s.mu.
+func (s *Store) nextSeq() int {
+	s.seq++
+	return s.seq
+}
+
+// newID returns id, or a fresh UUID when id is empty.
+func newID(id string) string {
+	if id == "" {
+		return uuid.New().String()
+	}
+	return id
+}
+
+// author resolves an author's display name and avatar from the stored users and
+// agents. Callers hold s.mu.
+func (s *Store) author(authorType models.AuthorType, authorID string) (displayName, avatarURL string) {
+	switch authorType {
+	case models.AuthorTypeHuman:
+		if u, ok := s.users[authorID]; ok {
+			return u.DisplayName, u.AvatarURL
+		}
+	case models.AuthorTypeAgent:
+		if a, ok := s.agents[authorID]; ok {
+			return a.DisplayName, a.AvatarURL
+		}
+	}
+	return "", ""
+}
+
+// ownerHuman returns the family human of an author: the user itself, or a
+// claimed agent's human. Callers hold s.mu.
+func (s *Store) ownerHuman(authorType models.AuthorType, authorID string) string {
+	if authorType == models.AuthorTypeHuman {
+		return authorID
+	}
+	if a, ok := s.agents[authorID]; ok && a.HumanID != nil {
+		return *a.HumanID
+	}
+	return ""
+}
+
+// visible reports whether the post can be seen by callerHuman ("" = public only),
+// matching the db package's family visibility gate. Callers hold s.mu.
+func (p *postRow) visible(callerHuman string) bool {
+	if p.Visibility != models.VisibilityFamily {
+		return true
+	}
+	return callerHuman != "" && p.ownerHumanID == callerHuman
+}
+
+// viewer returns the caller in ctx the way the db package reads it: type and ID
+// for user_vote, and the family human for visibility. Empty when anonymous.
+func viewer(ctx context.Context) (models.AuthorType, string, string) {
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		return p.Type, p.ID, p.HumanID
+	}
+	return "", "", ""
+}
+
+// castVote records, changes or retracts a vote and returns the change in the
+// target's upvotes and downvotes. Callers hold s.mu and have checked the target.
+func (s *Store) castVote(targetType, targetID, voterType, voterID, direction string) (up, down int) {
+	key := voteKey{targetType: targetType, targetID: targetID, voterType: voterType, voterID: voterID}
+	existing := s.votes[key]
+	if existing == direction {
+		return 0, 0
+	}
+	switch existing {
+	case "up":
+		up--
+	case "down":
+		down--
+	}
+	switch direction {
+	case "up":
+		up++
+		s.votes[key] = direction
+	case "down":
+		down++
+		s.votes[key] = direction
+	default: // db.VoteDirectionNone
+		delete(s.votes, key)
+	}
+	return up, down
+}
+
+// pageBounds normalizes page and perPage and returns the slice bounds for n
+// items.
+func pageBounds(page, perPage, defaultPerPage, maxPerPage, n int) (start, end int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	start = (page - 1) * perPage
+	if start > n {
+		start = n
+	}
+	end = start + perPage
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// cloneStrings copies a slice so callers can't alias stored data.
+func cloneStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	return append([]string{}, in...)
+}
@@ -0,0 +1,357 @@
+package memdb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/api/handlers"
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/db/memdb"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// The in-memory repositories must stay drop-in replacements for the db ones.
+var (
+	_ handlers.PostsRepositoryInterface          = (*memdb.PostRepository)(nil)
+	_ handlers.PostStatusUpdaterInterface        = (*memdb.PostRepository)(nil)
+	_ handlers.UsersPostRepositoryInterface      = (*memdb.PostRepository)(nil)
+	_ handlers.QuestionsRepositoryInterface      = (*memdb.QuestionsRepository)(nil)
+	_ handlers.AnswerVersionsRepositoryInterface = (*memdb.AnswersRepository)(nil)
+	_ handlers.ProblemsRepositoryInterface       = (*memdb.ProblemsRepository)(nil)
+	_ handlers.CommentsRepositoryInterface       = (*memdb.CommentsRepository)(nil)
+	_ handlers.UsersUserRepositoryInterface      = (*memdb.UserRepository)(nil)
+	_ handlers.MeUserRepositoryInterface         = (*memdb.UserRepository)(nil)
+	_ services.UserRepository                    = (*memdb.UserRepository)(nil)
+	_ services.PostFinder                        = (*memdb.PostRepository)(nil)
+	_ services.ApproachLister                    = (*memdb.ApproachesRepository)(nil)
+)
+
+func newStore(t *testing.T) (*memdb.Store, context.Context) {
+	t.Helper()
+	store := memdb.NewStore()
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.Now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	return store, context.Background()
+}
+
+func createPost(t *testing.T, ctx context.Context, store *memdb.Store, post models.Post) *models.Post {
+	t.Helper()
+	if post.Status == "" {
+		post.Status = models.PostStatusOpen
+	}
+	created, err := store.Posts().Create(ctx, &post)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return created
+}
+
+func TestPosts_CreateFindListDelete(t *testing.T) {
+	store, ctx := newStore(t)
+	user, err := store.Users().Create(ctx, &models.User{Username: "ada", DisplayName: "Ada", Email: "ada@example.com"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	first := createPost(t, ctx, store, models.Post{Type: models.PostTypeProblem, Title: "First", Description: "```go\npanic(1)\n```",
+		Tags: []string{"go"}, PostedByType: models.AuthorTypeHuman, PostedByID: user.ID})
+	second := createPost(t, ctx, store, models.Post{Type: models.PostTypeQuestion, Title: "Second", Tags: []string{"python"},
+		PostedByType: models.AuthorTypeHuman, PostedByID: user.ID})
+	createPost(t, ctx, store, models.Post{Type: models.PostTypeIdea, Title: "Draft", Status: models.PostStatusDraft,
+		PostedByType: models.AuthorTypeHuman, PostedByID: user.ID})
+
+	found, err := store.Posts().FindByID(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Author.DisplayName != "Ada" {
+		t.Errorf("author display name = %q, want Ada", found.Author.DisplayName)
+	}
+	if len(found.CodeLanguages) != 1 || found.CodeLanguages[0] != "go" {
+		t.Errorf("code languages = %v, want [go]", found.CodeLanguages)
+	}
+
+	posts, total, err := store.Posts().List(ctx, models.PostListOptions{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if total != 2 || len(posts) != 2 || posts[0].ID != second.ID {
+		t.Fatalf("List() = %d posts (total %d), want newest-first [second first]", len(posts), total)
+	}
+
+	_, total, _ = store.Posts().List(ctx, models.PostListOptions{Tags: []string{"go"}})
+	if total != 1 {
+		t.Errorf("List(tags=go) total = %d, want 1", total)
+	}
+	_, total, _ = store.Posts().List(ctx, models.PostListOptions{IncludeHidden: true})
+	if total != 3 {
+		t.Errorf("List(IncludeHidden) total = %d, want 3", total)
+	}
+
+	if err := store.Posts().Delete(ctx, first.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Posts().FindByID(ctx, first.ID); !errors.Is(err, db.ErrPostNotFound) {
+		t.Errorf("FindByID() after delete error = %v, want ErrPostNotFound", err)
+	}
+	if err := store.Posts().Delete(ctx, first.ID); !errors.Is(err, db.ErrPostNotFound) {
+		t.Errorf("second Delete() error = %v, want ErrPostNotFound", err)
+	}
+}
+
+func TestPosts_UpdateVersionConflict(t *testing.T) {
+	store, ctx := newStore(t)
+	post := createPost(t, ctx, store, models.Post{Type: models.PostTypeProblem, Title: "Title", PostedByType: models.AuthorTypeAgent, PostedByID: "bot"})
+
+	stale := *post
+	post.Title = "Edited"
+	if _, err := store.Posts().Update(ctx, post); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	stale.Title = "Lost update"
+	if _, err := store.Posts().Update(ctx, &stale); !errors.Is(err, db.ErrVersionConflict) {
+		t.Errorf("stale Update() error = %v, want ErrVersionConflict", err)
+	}
+}
+
+func TestPosts_VoteLifecycle(t *testing.T) {
+	store, ctx := newStore(t)
+	post := createPost(t, ctx, store, models.Post{Type: models.PostTypeIdea, Title: "Idea", PostedByType: models.AuthorTypeAgent, PostedByID: "bot"})
+	posts := store.Posts()
+
+	steps := []struct {
+		direction      string
+		wantUp, wantDn int
+	}{
+		{"up", 1, 0},
+		{"up", 1, 0}, // repeat is a no-op
+		{"down", 0, 1},
+		{db.VoteDirectionNone, 0, 0},
+	}
+	viewerCtx := auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeHuman, ID: "u1"})
+	for _, step := range steps {
+		if err := posts.Vote(ctx, post.ID, "human", "u1", step.direction); err != nil {
+			t.Fatalf("Vote(%s) error = %v", step.direction, err)
+		}
+		got, _ := posts.FindByIDForViewer(viewerCtx, post.ID)
+		if got.Upvotes != step.wantUp || got.Downvotes != step.wantDn {
+			t.Errorf("after %s: votes = %d/%d, want %d/%d", step.direction, got.Upvotes, got.Downvotes, step.wantUp, step.wantDn)
+		}
+	}
+
+	if err := posts.Vote(ctx, post.ID, "human", "u1", "sideways"); !errors.Is(err, db.ErrInvalidVoteDirection) {
+		t.Errorf("Vote(sideways) error = %v, want ErrInvalidVoteDirection", err)
+	}
+	if vote, err := posts.GetUserVote(ctx, post.ID, "human", "u1"); err != nil || vote != nil {
+		t.Errorf("GetUserVote() = %v, %v; want nil, nil", vote, err)
+	}
+}
+
+func TestPosts_FamilyVisibility(t *testing.T) {
+	store, ctx := newStore(t)
+	owner := "11111111-1111-1111-1111-111111111111"
+	post := createPost(t, ctx, store, models.Post{Type: models.PostTypeProblem, Title: "Private", Visibility: models.VisibilityFamily,
+		OwnerHumanID: &owner, PostedByType: models.AuthorTypeHuman, PostedByID: owner})
+
+	if _, err := store.Posts().FindByID(ctx, post.ID); !errors.Is(err, db.ErrPostNotFound) {
+		t.Errorf("anonymous FindByID() error = %v, want ErrPostNotFound", err)
+	}
+	ownerCtx := auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeHuman, ID: owner, HumanID: owner})
+	if _, err := store.Posts().FindByIDForViewer(ownerCtx, post.ID); err != nil {
+		t.Errorf("owner FindByIDForViewer() error = %v", err)
+	}
+	if _, total, _ := store.Posts().List(ownerCtx, models.PostListOptions{}); total != 1 {
+		t.Errorf("owner List() total = %d, want 1", total)
+	}
+	if _, total, _ := store.Posts().List(ctx, models.PostListOptions{}); total != 0 {
+		t.Errorf("anonymous List() total = %d, want 0", total)
+	}
+}
+
+func TestQuestions_AnswerAcceptAndVote(t *testing.T) {
+	store, ctx := newStore(t)
+	questions := store.Questions()
+	question, err := questions.CreateQuestion(ctx, &models.Post{Title: "How?", Status: models.PostStatusOpen,
+		PostedByType: models.AuthorTypeHuman, PostedByID: "u1"})
+	if err != nil {
+		t.Fatalf("CreateQuestion() error = %v", err)
+	}
+
+	answer, err := questions.CreateAnswer(ctx, &models.Answer{QuestionID: question.ID, AuthorType: models.AuthorTypeAgent, AuthorID: "bot", Content: "Like this"})
+	if err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+	q, _ := questions.FindQuestionByID(ctx, question.ID)
+	if q.Status != models.PostStatusAnswered || q.AnswersCount != 1 {
+		t.Errorf("question status/answers = %s/%d, want answered/1", q.Status, q.AnswersCount)
+	}
+
+	if err := questions.VoteOnAnswer(ctx, answer.ID, "human", "u2", "up"); err != nil {
+		t.Fatalf("VoteOnAnswer() error = %v", err)
+	}
+	if err := questions.AcceptAnswer(ctx, question.ID, answer.ID); err != nil {
+		t.Fatalf("AcceptAnswer() error = %v", err)
+	}
+	got, _ := questions.FindAnswerByID(ctx, answer.ID)
+	if !got.IsAccepted || got.VoteScore != 1 {
+		t.Errorf("answer accepted/score = %v/%d, want true/1", got.IsAccepted, got.VoteScore)
+	}
+	q, _ = questions.FindQuestionByID(ctx, question.ID)
+	if q.Status != models.PostStatusSolved || q.AcceptedAnswerID == nil || *q.AcceptedAnswerID != answer.ID {
+		t.Errorf("question after accept = %s/%v, want solved with accepted answer", q.Status, q.AcceptedAnswerID)
+	}
+
+	if _, err := store.Problems().FindProblemByID(ctx, question.ID); !errors.Is(err, db.ErrProblemNotFound) {
+		t.Errorf("FindProblemByID(question) error = %v, want ErrProblemNotFound", err)
+	}
+}
+
+func TestAnswers_ListSort(t *testing.T) {
+	store, ctx := newStore(t)
+	questions := store.Questions()
+	question, _ := questions.CreateQuestion(ctx, &models.Post{Title: "How?", Status: models.PostStatusOpen,
+		PostedByType: models.AuthorTypeHuman, PostedByID: "u1"})
+
+	var ids []string
+	for _, content := range []string{"first", "second", "third"} {
+		a, err := questions.CreateAnswer(ctx, &models.Answer{QuestionID: question.ID, AuthorType: models.AuthorTypeAgent, AuthorID: "bot", Content: content})
+		if err != nil {
+			t.Fatalf("CreateAnswer() error = %v", err)
+		}
+		ids = append(ids, a.ID)
+	}
+	_ = questions.VoteOnAnswer(ctx, ids[1], "human", "u2", "up")
+	_ = questions.AcceptAnswer(ctx, question.ID, ids[0])
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"", []string{ids[2], ids[1], ids[0]}},
+		{"oldest", []string{ids[0], ids[1], ids[2]}},
+		{"top", []string{ids[1], ids[2], ids[0]}},
+		{"verified-first", []string{ids[0], ids[1], ids[2]}},
+	}
+	for _, tt := range tests {
+		list, total, _ := store.Answers().ListAnswers(ctx, question.ID, models.AnswerListOptions{Sort: tt.sort, PerPage: 2})
+		if total != 3 || len(list) != 2 || list[0].ID != tt.want[0] || list[1].ID != tt.want[1] {
+			t.Errorf("ListAnswers(sort %q) = %d of %d, want %v first", tt.sort, len(list), total, tt.want[:2])
+		}
+	}
+}
+
+func TestProblems_ApproachesAndNotes(t *testing.T) {
+	store, ctx := newStore(t)
+	problems := store.Problems()
+	problem, _ := problems.CreateProblem(ctx, &models.Post{Title: "Broken", Status: models.PostStatusOpen, PostedByType: models.AuthorTypeHuman, PostedByID: "u1"})
+
+	approach, err := problems.CreateApproach(ctx, &models.Approach{ProblemID: problem.ID, AuthorType: models.AuthorTypeAgent, AuthorID: "bot", Angle: "Restart it"})
+	if err != nil {
+		t.Fatalf("CreateApproach() error = %v", err)
+	}
+	if _, err := problems.AddProgressNote(ctx, &models.ProgressNote{ApproachID: approach.ID, Content: "tried once"}); err != nil {
+		t.Fatalf("AddProgressNote() error = %v", err)
+	}
+	if _, err := problems.AddProgressNote(ctx, &models.ProgressNote{ApproachID: approach.ID, Content: "tried twice"}); err != nil {
+		t.Fatalf("AddProgressNote() error = %v", err)
+	}
+	notes, _ := problems.GetProgressNotes(ctx, approach.ID)
+	if len(notes) != 2 || notes[0].Content != "tried twice" {
+		t.Errorf("GetProgressNotes() = %v, want newest first", notes)
+	}
+
+	updated, err := problems.UpdateApproach(ctx, &models.Approach{ID: approach.ID, Status: models.ApproachStatusSucceeded, Solution: "Restarted"})
+	if err != nil {
+		t.Fatalf("UpdateApproach() error = %v", err)
+	}
+	if updated.Status != models.ApproachStatusSucceeded || updated.Method != "" {
+		t.Errorf("UpdateApproach() = %+v", updated)
+	}
+	if ok, _ := store.Approaches().HasSucceededApproach(ctx, problem.ID); !ok {
+		t.Error("HasSucceededApproach() = false, want true")
+	}
+
+	list, total, _ := problems.ListApproaches(ctx, problem.ID, models.ApproachListOptions{})
+	if total != 1 || len(list) != 1 || list[0].Author.DisplayName != "bot" {
+		t.Errorf("ListApproaches() = %+v (total %d)", list, total)
+	}
+	if err := problems.UpdateProblemStatus(ctx, problem.ID, models.PostStatusSolved); err != nil {
+		t.Fatalf("UpdateProblemStatus() error = %v", err)
+	}
+}
+
+func TestComments_TargetsAndOrder(t *testing.T) {
+	store, ctx := newStore(t)
+	store.PutAgent(models.Agent{ID: "bot", DisplayName: "Bot"})
+	post := createPost(t, ctx, store, models.Post{Type: models.PostTypeIdea, Title: "Idea", PostedByType: models.AuthorTypeAgent, PostedByID: "bot"})
+	comments := store.Comments()
+
+	if ok, _ := comments.TargetExists(ctx, models.CommentTargetPost, post.ID); !ok {
+		t.Error("TargetExists(post) = false, want true")
+	}
+	if ok, _ := comments.TargetExists(ctx, models.CommentTargetAnswer, post.ID); ok {
+		t.Error("TargetExists(answer) = true for a post ID")
+	}
+
+	first, _ := comments.Create(ctx, &models.Comment{TargetType: models.CommentTargetPost, TargetID: post.ID, AuthorType: models.AuthorTypeAgent, AuthorID: "bot", Content: "one"})
+	comments.Create(ctx, &models.Comment{TargetType: models.CommentTargetPost, TargetID: post.ID, AuthorType: models.AuthorTypeHuman, AuthorID: "ghost", Content: "two"})
+
+	list, total, _ := comments.List(ctx, models.CommentListOptions{TargetType: models.CommentTargetPost, TargetID: post.ID})
+	if total != 2 || list[0].Content != "one" || list[0].Author.DisplayName != "Bot" || list[1].Author.DisplayName != "Unknown" {
+		t.Errorf("List() = %+v", list)
+	}
+	p, _ := store.Posts().FindByID(ctx, post.ID)
+	if p.CommentsCount != 2 {
+		t.Errorf("post comments count = %d, want 2", p.CommentsCount)
+	}
+
+	if err := comments.Delete(ctx, first.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := comments.FindByID(ctx, first.ID); !errors.Is(err, db.ErrCommentNotFound) {
+		t.Errorf("FindByID() after delete error = %v, want ErrCommentNotFound", err)
+	}
+}
+
+func TestUsers_UniqueAndStats(t *testing.T) {
+	store, ctx := newStore(t)
+	users := store.Users()
+	user, _ := users.Create(ctx, &models.User{Username: "ada", Email: "ada@example.com", AuthProvider: "github", AuthProviderID: "42"})
+
+	if _, err := users.Create(ctx, &models.User{Username: "ada", Email: "other@example.com"}); !errors.Is(err, db.ErrDuplicateUsername) {
+		t.Errorf("duplicate username error = %v", err)
+	}
+	if _, err := users.Create(ctx, &models.User{Username: "bob", Email: "ada@example.com"}); !errors.Is(err, db.ErrDuplicateEmail) {
+		t.Errorf("duplicate email error = %v", err)
+	}
+	if found, err := users.FindByAuthProvider(ctx, "github", "42"); err != nil || found.ID != user.ID {
+		t.Errorf("FindByAuthProvider() = %v, %v", found, err)
+	}
+
+	problem := createPost(t, ctx, store, models.Post{Type: models.PostTypeProblem, Title: "P", Status: models.PostStatusSolved,
+		PostedByType: models.AuthorTypeHuman, PostedByID: user.ID})
+	store.Posts().Vote(ctx, problem.ID, "agent", "bot", "up")
+
+	stats, err := users.GetUserStats(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetUserStats() error = %v", err)
+	}
+	// solved problem 100 + contributed 25 + one upvote 2
+	if stats.PostsCreated != 1 || stats.UpvotesReceived != 1 || stats.Reputation != 127 {
+		t.Errorf("GetUserStats() = %+v, want 1 post, 1 upvote, reputation 127", stats)
+	}
+
+	if err := users.Delete(ctx, user.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := users.FindByID(ctx, user.ID); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("FindByID() after delete error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,388 @@
+package memdb
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// PostRepository is the in-memory counterpart of db.PostRepository.
+type PostRepository struct {
+	s *Store
+}
+
+// hiddenStatuses are excluded from List unless IncludeHidden is set.
+var hiddenStatuses = map[models.PostStatus]bool{
+	models.PostStatusPendingReview: true,
+	models.PostStatusRejected:      true,
+	models.PostStatusDraft:         true,
+}
+
+// List returns a page of non-deleted posts, filtered and sorted like db.PostRepository.List.
+func (r *PostRepository) List(ctx context.Context, opts models.PostListOptions) ([]models.PostWithAuthor, int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	viewerType, viewerID, callerHuman := viewer(ctx)
+	now := r.s.now()
+	var matched []*postRow
+	for _, p := range r.s.posts {
+		if p.DeletedAt != nil || !p.visible(callerHuman) {
+			continue
+		}
+		if !opts.IncludeHidden && hiddenStatuses[p.Status] {
+			continue
+		}
+		if opts.Type != "" && p.Type != opts.Type {
+			continue
+		}
+		if opts.Status != "" && p.Status != opts.Status {
+			continue
+		}
+		if len(opts.Tags) > 0 && !overlaps(p.Tags, opts.Tags) {
+			continue
+		}
+		if opts.AuthorType != "" && opts.AuthorID != "" && (p.PostedByType != opts.AuthorType || p.PostedByID != opts.AuthorID) {
+			continue
+		}
+		if since, ok := timeframeStart(now, opts.Timeframe); ok && !p.CreatedAt.After(since) {
+			continue
+		}
+		if opts.HasAnswer != nil && (r.s.answersCount(p.ID) > 0) != *opts.HasAnswer {
+			continue
+		}
+		matched = append(matched, p)
+	}
+
+	r.sortPosts(matched, opts.Sort, now)
+
+	start, end := pageBounds(opts.Page, opts.PerPage, 20, 100, len(matched))
+	posts := make([]models.PostWithAuthor, 0, end-start)
+	for _, p := range matched[start:end] {
+		posts = append(posts, r.s.postWithAuthor(p, viewerType, viewerID))
+	}
+	return posts, len(matched), nil
+}
+
+// sortPosts orders posts by the List sort option, newest first by default.
+func (r *PostRepository) sortPosts(posts []*postRow, order string, now time.Time) {
+	newest := func(a, b *postRow) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.seq > b.seq
+	}
+	var less func(a, b *postRow) bool
+	switch order {
+	case "votes", "top":
+		less = func(a, b *postRow) bool {
+			if sa, sb := a.VoteScore(), b.VoteScore(); sa != sb {
+				return sa > sb
+			}
+			return newest(a, b)
+		}
+	case "hot":
+		less = func(a, b *postRow) bool { return r.s.hotScore(a, now) > r.s.hotScore(b, now) }
+	case "approaches":
+		less = func(a, b *postRow) bool {
+			if ca, cb := r.s.approachesCount(a.ID), r.s.approachesCount(b.ID); ca != cb {
+				return ca > cb
+			}
+			return newest(a, b)
+		}
+	case "answers":
+		less = func(a, b *postRow) bool {
+			if ca, cb := r.s.answersCount(a.ID), r.s.answersCount(b.ID); ca != cb {
+				return ca > cb
+			}
+			return newest(a, b)
+		}
+	default:
+		less = newest
+	}
+	sort.SliceStable(posts, func(i, j int) bool { return less(posts[i], posts[j]) })
+}
+
+// hotScore mirrors the "hot" ORDER BY expression in db.PostRepository.List.
+// Callers hold s.mu.
+func (s *Store) hotScore(p *postRow, now time.Time) float64 {
+	engagement := math.Abs(float64(p.Upvotes-p.Downvotes)) +
+		float64(s.commentsCount(models.CommentTargetPost, p.ID))*2 +
+		float64(s.answersCount(p.ID))*3 +
+		float64(s.approachesCount(p.ID))*3 +
+		float64(p.ViewCount)*0.01
+	age := p.CreatedAt.Sub(now.Add(-7*24*time.Hour)).Seconds() / 45000.0
+	return math.Log10(math.Max(engagement, 1)+1) + age
+}
+
+// FindByID returns a public, non-deleted post with author information.
+func (r *PostRepository) FindByID(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	return r.findByID(id, "", "", "")
+}
+
+// FindByIDFromPrimary is FindByIDForViewer; the store has no replica.
+func (r *PostRepository) FindByIDFromPrimary(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	return r.FindByIDForViewer(ctx, id)
+}
+
+// FindByIDForViewer returns a post with the vote of the caller in ctx. Family posts
+// are only found for their own family.
+func (r *PostRepository) FindByIDForViewer(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	viewerType, viewerID, callerHuman := viewer(ctx)
+	return r.findByID(id, viewerType, viewerID, callerHuman)
+}
+
+func (r *PostRepository) findByID(id string, viewerType models.AuthorType, viewerID string, callerHuman string) (*models.PostWithAuthor, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	p, ok := r.s.posts[id]
+	if !ok || p.DeletedAt != nil || !p.visible(callerHuman) {
+		return nil, db.ErrPostNotFound
+	}
+	post := r.s.postWithAuthor(p, viewerType, viewerID)
+	post.StackTrace = models.ParseStackTrace(post.Description)
+	return &post, nil
+}
+
+// Create stores a new post. Status defaults to draft and visibility to public,
+// as in db.PostRepository.Create.
+func (r *PostRepository) Create(ctx context.Context, post *models.Post) (*models.Post, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	id := newID(post.ID)
+	if _, exists := r.s.posts[id]; exists {
+		return nil, db.ErrDuplicatePostID
+	}
+
+	now := r.s.now()
+	row := &postRow{Post: *post, seq: r.s.nextSeq()}
+	row.ID = id
+	if row.Status == "" {
+		row.Status = models.PostStatusDraft
+	}
+	if row.Visibility == "" {
+		row.Visibility = models.VisibilityPublic
+	}
+	if post.OwnerHumanID != nil {
+		row.ownerHumanID = *post.OwnerHumanID
+	}
+	row.Tags = cloneStrings(post.Tags)
+	row.SuccessCriteria = cloneStrings(post.SuccessCriteria)
+	row.EvolvedInto = cloneStrings(post.EvolvedInto)
+	row.Upvotes, row.Downvotes, row.ViewCount = 0, 0, 0
+	row.CodeLanguages = models.ExtractCodeLanguages(post.Description)
+	row.StackTrace = nil
+	row.EmbeddingStr = nil
+	row.CreatedAt, row.UpdatedAt = now, now
+	row.DeletedAt = nil
+	r.s.posts[id] = row
+
+	created := row.Post
+	created.StackTrace = models.ParseStackTrace(created.Description)
+	return &created, nil
+}
+
+// Update changes the mutable fields of a post. A non-zero UpdatedAt must match the
+// stored one, otherwise db.ErrVersionConflict is returned.
+func (r *PostRepository) Update(ctx context.Context, post *models.Post) (*models.Post, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := r.s.posts[post.ID]
+	if !ok || p.DeletedAt != nil {
+		return nil, db.ErrPostNotFound
+	}
+	if !post.UpdatedAt.IsZero() && !post.UpdatedAt.Equal(p.UpdatedAt) {
+		return nil, db.ErrVersionConflict
+	}
+
+	p.Title = post.Title
+	p.Description = post.Description
+	p.Tags = cloneStrings(post.Tags)
+	p.Status = post.Status
+	p.SuccessCriteria = cloneStrings(post.SuccessCriteria)
+	p.Weight = post.Weight
+	p.AcceptedAnswerID = post.AcceptedAnswerID
+	p.EvolvedInto = cloneStrings(post.EvolvedInto)
+	p.CodeLanguages = models.ExtractCodeLanguages(post.Description)
+	p.UpdatedAt = r.s.now()
+
+	updated := p.Post
+	updated.StackTrace = models.ParseStackTrace(updated.Description)
+	return &updated, nil
+}
+
+// Delete soft-deletes a post.
+func (r *PostRepository) Delete(ctx context.Context, id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := r.s.posts[id]
+	if !ok || p.DeletedAt != nil {
+		return db.ErrPostNotFound
+	}
+	now := r.s.now()
+	p.DeletedAt = &now
+	return nil
+}
+
+// Vote adds, changes or retracts (direction "none") a vote on a post.
+func (r *PostRepository) Vote(ctx context.Context, postID, voterType, voterID, direction string) error {
+	if direction != "up" && direction != "down" && direction != db.VoteDirectionNone {
+		return db.ErrInvalidVoteDirection
+	}
+	if voterType != "human" && voterType != "agent" {
+		return db.ErrInvalidVoterType
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := r.s.posts[postID]
+	if !ok || p.DeletedAt != nil {
+		return db.ErrPostNotFound
+	}
+	up, down := r.s.castVote("post", postID, voterType, voterID, direction)
+	p.Upvotes = max(p.Upvotes+up, 0)
+	p.Downvotes = max(p.Downvotes+down, 0)
+	return nil
+}
+
+// GetUserVote returns the voter's direction on a post, or nil.
+func (r *PostRepository) GetUserVote(ctx context.Context, postID, voterType, voterID string) (*string, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	p, ok := r.s.posts[postID]
+	if !ok || p.DeletedAt != nil {
+		return nil, db.ErrPostNotFound
+	}
+	return r.s.userVote("post", postID, voterType, voterID), nil
+}
+
+// UpdateStatus sets the status of a non-deleted post.
+func (r *PostRepository) UpdateStatus(ctx context.Context, postID string, status models.PostStatus) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := r.s.posts[postID]
+	if !ok || p.DeletedAt != nil {
+		return db.ErrPostNotFound
+	}
+	p.Status = status
+	p.UpdatedAt = r.s.now()
+	return nil
+}
+
+// UpdateOriginalLanguage moves a post to draft and records its detected language.
+func (r *PostRepository) UpdateOriginalLanguage(ctx context.Context, postID, language string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := r.s.posts[postID]
+	if !ok || p.DeletedAt != nil {
+		return db.ErrPostNotFound
+	}
+	p.Status = models.PostStatusDraft
+	p.OriginalLanguage = language
+	p.UpdatedAt = r.s.now()
+	return nil
+}
+
+// postWithAuthor builds the list/detail view of a post. Callers hold s.mu.
+func (s *Store) postWithAuthor(p *postRow, viewerType models.AuthorType, viewerID string) models.PostWithAuthor {
+	post := models.PostWithAuthor{Post: p.Post}
+	post.Tags = cloneStrings(p.Tags)
+	post.SuccessCriteria = cloneStrings(p.SuccessCriteria)
+	post.EvolvedInto = cloneStrings(p.EvolvedInto)
+	post.CodeLanguages = cloneStrings(p.CodeLanguages)
+
+	displayName, avatarURL := s.author(p.PostedByType, p.PostedByID)
+	post.Author = models.PostAuthor{Type: p.PostedByType, ID: p.PostedByID, DisplayName: displayName, AvatarURL: avatarURL}
+	post.VoteScore = p.Upvotes - p.Downvotes
+	post.AnswersCount = s.answersCount(p.ID)
+	post.ApproachesCount = s.approachesCount(p.ID)
+	post.CommentsCount = s.commentsCount(models.CommentTargetPost, p.ID)
+	if p.PostedByType == models.AuthorTypeAgent {
+		if a, ok := s.agents[p.PostedByID]; ok && a.HumanID != nil {
+			post.AgentHumanID = *a.HumanID
+		}
+	}
+	if viewerType != "" && viewerID != "" {
+		post.UserVote = s.userVote("post", p.ID, string(viewerType), viewerID)
+	}
+	return post
+}
+
+// userVote returns the voter's direction on a target, or nil. Callers hold s.mu.
+func (s *Store) userVote(targetType, targetID, voterType, voterID string) *string {
+	direction, ok := s.votes[voteKey{targetType: targetType, targetID: targetID, voterType: voterType, voterID: voterID}]
+	if !ok {
+		return nil
+	}
+	return &direction
+}
+
+// answersCount counts the non-deleted answers on a question. Callers hold s.mu.
+func (s *Store) answersCount(questionID string) int {
+	n := 0
+	for _, a := range s.answers {
+		if a.QuestionID == questionID && a.DeletedAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// approachesCount counts the non-deleted approaches on a problem. Callers hold s.mu.
+func (s *Store) approachesCount(problemID string) int {
+	n := 0
+	for _, a := range s.approaches {
+		if a.ProblemID == problemID && a.DeletedAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// commentsCount counts the non-deleted comments on a target. Callers hold s.mu.
+func (s *Store) commentsCount(targetType models.CommentTargetType, targetID string) int {
+	n := 0
+	for _, c := range s.comments {
+		if c.TargetType == targetType && c.TargetID == targetID && c.DeletedAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// overlaps reports whether a and b share an element, like the && array operator.
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// timeframeStart returns the cutoff for a List timeframe.
+func timeframeStart(now time.Time, timeframe string) (time.Time, bool) {
+	switch timeframe {
+	case "today":
+		return now.Add(-24 * time.Hour), true
+	case "week":
+		return now.Add(-7 * 24 * time.Hour), true
+	case "month":
+		return now.Add(-30 * 24 * time.Hour), true
+	}
+	return time.Time{}, false
+}
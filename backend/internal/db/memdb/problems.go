@@ -0,0 +1,91 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ProblemsRepository is the in-memory counterpart of db.ProblemsRepository:
+// problem posts plus their approaches.
+type ProblemsRepository struct {
+	s *Store
+}
+
+// ListProblems returns problems matching the given options.
+func (r *ProblemsRepository) ListProblems(ctx context.Context, opts models.PostListOptions) ([]models.PostWithAuthor, int, error) {
+	opts.Type = models.PostTypeProblem
+	return r.s.Posts().List(ctx, opts)
+}
+
+// FindProblemByID returns a problem, or db.ErrProblemNotFound.
+func (r *ProblemsRepository) FindProblemByID(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	post, err := r.s.Posts().FindByID(ctx, id)
+	if errors.Is(err, db.ErrPostNotFound) {
+		return nil, db.ErrProblemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if post.Type != models.PostTypeProblem {
+		return nil, db.ErrProblemNotFound
+	}
+	return post, nil
+}
+
+// CreateProblem creates a post with type problem.
+func (r *ProblemsRepository) CreateProblem(ctx context.Context, post *models.Post) (*models.Post, error) {
+	post.Type = models.PostTypeProblem
+	return r.s.Posts().Create(ctx, post)
+}
+
+// ListApproaches returns approaches for a problem.
+func (r *ProblemsRepository) ListApproaches(ctx context.Context, problemID string, opts models.ApproachListOptions) ([]models.ApproachWithAuthor, int, error) {
+	return r.s.Approaches().ListApproaches(ctx, problemID, opts)
+}
+
+// FindApproachByID returns a single approach.
+func (r *ProblemsRepository) FindApproachByID(ctx context.Context, id string) (*models.ApproachWithAuthor, error) {
+	return r.s.Approaches().FindApproachByID(ctx, id)
+}
+
+// CreateApproach creates a new approach.
+func (r *ProblemsRepository) CreateApproach(ctx context.Context, approach *models.Approach) (*models.Approach, error) {
+	return r.s.Approaches().CreateApproach(ctx, approach)
+}
+
+// UpdateApproach updates an approach.
+func (r *ProblemsRepository) UpdateApproach(ctx context.Context, approach *models.Approach) (*models.Approach, error) {
+	return r.s.Approaches().UpdateApproach(ctx, approach)
+}
+
+// AddProgressNote adds a progress note to an approach.
+func (r *ProblemsRepository) AddProgressNote(ctx context.Context, note *models.ProgressNote) (*models.ProgressNote, error) {
+	return r.s.Approaches().AddProgressNote(ctx, note)
+}
+
+// GetProgressNotes returns an approach's progress notes.
+func (r *ProblemsRepository) GetProgressNotes(ctx context.Context, approachID string) ([]models.ProgressNote, error) {
+	return r.s.Approaches().GetProgressNotes(ctx, approachID)
+}
+
+// UpdateProblemStatus sets the status of a non-deleted problem.
+func (r *ProblemsRepository) UpdateProblemStatus(ctx context.Context, problemID string, status models.PostStatus) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	p, ok := r.s.posts[problemID]
+	if !ok || p.Type != models.PostTypeProblem || p.DeletedAt != nil {
+		return db.ErrProblemNotFound
+	}
+	p.Status = status
+	p.UpdatedAt = r.s.now()
+	return nil
+}
+
+// Update updates a problem post.
+func (r *ProblemsRepository) Update(ctx context.Context, post *models.Post) (*models.Post, error) {
+	return r.s.Posts().Update(ctx, post)
+}
@@ -0,0 +1,82 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// QuestionsRepository is the in-memory counterpart of db.QuestionsRepository:
+// question posts plus their answers.
+type QuestionsRepository struct {
+	s *Store
+}
+
+// ListQuestions returns questions matching the given options.
+func (r *QuestionsRepository) ListQuestions(ctx context.Context, opts models.PostListOptions) ([]models.PostWithAuthor, int, error) {
+	opts.Type = models.PostTypeQuestion
+	return r.s.Posts().List(ctx, opts)
+}
+
+// FindQuestionByID returns a question, or db.ErrQuestionNotFound.
+func (r *QuestionsRepository) FindQuestionByID(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	post, err := r.s.Posts().FindByID(ctx, id)
+	if errors.Is(err, db.ErrPostNotFound) {
+		return nil, db.ErrQuestionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if post.Type != models.PostTypeQuestion {
+		return nil, db.ErrQuestionNotFound
+	}
+	return post, nil
+}
+
+// CreateQuestion creates a post with type question.
+func (r *QuestionsRepository) CreateQuestion(ctx context.Context, post *models.Post) (*models.Post, error) {
+	post.Type = models.PostTypeQuestion
+	return r.s.Posts().Create(ctx, post)
+}
+
+// ListAnswers returns answers for a question.
+func (r *QuestionsRepository) ListAnswers(ctx context.Context, questionID string, opts models.AnswerListOptions) ([]models.AnswerWithAuthor, int, error) {
+	return r.s.Answers().ListAnswers(ctx, questionID, opts)
+}
+
+// CreateAnswer creates a new answer.
+func (r *QuestionsRepository) CreateAnswer(ctx context.Context, answer *models.Answer) (*models.Answer, error) {
+	return r.s.Answers().CreateAnswer(ctx, answer)
+}
+
+// FindAnswerByID returns a single answer.
+func (r *QuestionsRepository) FindAnswerByID(ctx context.Context, id string) (*models.AnswerWithAuthor, error) {
+	return r.s.Answers().FindAnswerByID(ctx, id)
+}
+
+// UpdateAnswer updates an answer.
+func (r *QuestionsRepository) UpdateAnswer(ctx context.Context, answer *models.Answer) (*models.Answer, error) {
+	return r.s.Answers().UpdateAnswer(ctx, answer)
+}
+
+// DeleteAnswer soft-deletes an answer.
+func (r *QuestionsRepository) DeleteAnswer(ctx context.Context, id string) error {
+	return r.s.Answers().DeleteAnswer(ctx, id)
+}
+
+// AcceptAnswer accepts an answer and marks the question solved.
+func (r *QuestionsRepository) AcceptAnswer(ctx context.Context, questionID, answerID string) error {
+	return r.s.Answers().AcceptAnswer(ctx, questionID, answerID)
+}
+
+// VoteOnAnswer records a vote on an answer.
+func (r *QuestionsRepository) VoteOnAnswer(ctx context.Context, answerID, voterType, voterID, direction string) error {
+	return r.s.Answers().VoteOnAnswer(ctx, answerID, voterType, voterID, direction)
+}
+
+// GetAnswerCount returns the number of answers on a question.
+func (r *QuestionsRepository) GetAnswerCount(ctx context.Context, questionID string) (int, error) {
+	return r.s.Answers().GetAnswerCount(ctx, questionID)
+}
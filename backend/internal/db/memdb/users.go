@@ -0,0 +1,184 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/db"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/referral"
+)
+
+// UserRepository is the in-memory counterpart of db.UserRepository.
+type UserRepository struct {
+	s *Store
+}
+
+// Create stores a new user. Usernames and emails are unique among all users,
+// including soft-deleted ones, as with the table's unique constraints.
+func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	if user.ReferralCode == "" {
+		code, err := referral.GenerateCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate referral code: %w", err)
+		}
+		user.ReferralCode = code
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, u := range r.s.users {
+		if u.Username == user.Username {
+			return nil, db.ErrDuplicateUsername
+		}
+		if u.Email == user.Email {
+			return nil, db.ErrDuplicateEmail
+		}
+	}
+
+	now := r.s.now()
+	stored := *user
+	stored.ID = newID(user.ID)
+	if stored.Role == "" {
+		stored.Role = models.UserRoleUser
+	}
+	stored.Status = string(models.UserStatusActive)
+	stored.CreatedAt, stored.UpdatedAt = now, now
+	stored.DeletedAt = nil
+	r.s.users[stored.ID] = &stored
+
+	created := stored
+	return &created, nil
+}
+
+// FindByID returns a non-deleted user, or db.ErrNotFound.
+func (r *UserRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
+	return r.find(func(u *models.User) bool { return u.ID == id })
+}
+
+// FindByAuthProvider returns a non-deleted user by OAuth provider, or
+// db.ErrNotFound. There is no auth_methods table here, so it matches the user's
+// own AuthProvider and AuthProviderID.
+func (r *UserRepository) FindByAuthProvider(ctx context.Context, provider, providerID string) (*models.User, error) {
+	return r.find(func(u *models.User) bool { return u.AuthProvider == provider && u.AuthProviderID == providerID })
+}
+
+// FindByEmail returns a non-deleted user by email, or db.ErrNotFound.
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.find(func(u *models.User) bool { return u.Email == email })
+}
+
+// FindByUsername returns a non-deleted user by username, or db.ErrNotFound.
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	return r.find(func(u *models.User) bool { return u.Username == username })
+}
+
+func (r *UserRepository) find(match func(*models.User) bool) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, u := range r.s.users {
+		if u.DeletedAt == nil && match(u) {
+			found := *u
+			return &found, nil
+		}
+	}
+	return nil, db.ErrNotFound
+}
+
+// Update changes a user's display name, avatar and bio.
+func (r *UserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[user.ID]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	u.DisplayName = user.DisplayName
+	u.AvatarURL = user.AvatarURL
+	u.Bio = user.Bio
+	u.UpdatedAt = r.s.now()
+
+	updated := *u
+	return &updated, nil
+}
+
+// Delete soft-deletes a user.
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[id]
+	if !ok || u.DeletedAt != nil {
+		return db.ErrNotFound
+	}
+	now := r.s.now()
+	u.DeletedAt = &now
+	return nil
+}
+
+// GetUserStats computes a user's stats with the reputation formula of
+// db.UserRepository.GetUserStats. Idea responses and bounties are not modelled
+// and count as zero.
+func (r *UserRepository) GetUserStats(ctx context.Context, userID string) (*models.UserStats, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	stats := &models.UserStats{}
+	var problemsSolved, problemsContributed, ideasPosted, downvotes int
+	for _, p := range r.s.posts {
+		if p.PostedByType != models.AuthorTypeHuman || p.PostedByID != userID || p.DeletedAt != nil {
+			continue
+		}
+		stats.PostsCreated++
+		switch p.Type {
+		case models.PostTypeProblem:
+			problemsContributed++
+			if p.Status == models.PostStatusSolved {
+				problemsSolved++
+			}
+		case models.PostTypeIdea:
+			ideasPosted++
+		}
+	}
+	for _, a := range r.s.answers {
+		if a.AuthorType != models.AuthorTypeHuman || a.AuthorID != userID || a.DeletedAt != nil {
+			continue
+		}
+		stats.AnswersGiven++
+		if a.IsAccepted {
+			stats.AnswersAccepted++
+		}
+	}
+	for key, direction := range r.s.votes {
+		var authored bool
+		switch key.targetType {
+		case "post":
+			p, ok := r.s.posts[key.targetID]
+			authored = ok && p.PostedByType == models.AuthorTypeHuman && p.PostedByID == userID
+		case "answer":
+			a, ok := r.s.answers[key.targetID]
+			authored = ok && a.AuthorType == models.AuthorTypeHuman && a.AuthorID == userID
+		}
+		if !authored {
+			continue
+		}
+		if direction == "up" {
+			stats.UpvotesReceived++
+		} else {
+			downvotes++
+		}
+	}
+
+	stats.Contributions = stats.AnswersGiven
+	stats.Reputation = problemsSolved*100 +
+		problemsContributed*25 +
+		stats.AnswersAccepted*50 +
+		stats.AnswersGiven*10 +
+		ideasPosted*15 +
+		stats.UpvotesReceived*2 -
+		downvotes
+	return stats, nil
+}
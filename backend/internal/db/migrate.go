@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// createSchemaMigrationsSQL creates the version table used by the golang-migrate
+// CLI, so a database migrated by either tool can be picked up by the other.
+const createSchemaMigrationsSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`
+
+// migrationLockID is the transaction-scoped advisory lock taken for each migration
+// step, so API replicas started with --migrate don't apply the same file twice.
+const migrationLockID = 7_366_796_000_001
+
+// ErrDirtySchema is returned when schema_migrations is marked dirty: a migration
+// failed part-way outside a transaction and must be repaired by hand.
+var ErrDirtySchema = errors.New("database schema is dirty")
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is one numbered schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// LoadMigrations reads NNNNNN_name.up.sql / .down.sql pairs from fsys, sorted by
+// version. Other files are ignored. Every version needs an up file.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		m := migrationFileName.FindStringSubmatch(entry.Name())
+		if m == nil || entry.IsDir() {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version", entry.Name())
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration version %d used by both %q and %q", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %06d_%s has no up file", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies embedded migrations and reports schema drift.
+type Migrator struct {
+	pool       *Pool
+	migrations []Migration
+}
+
+// NewMigrator creates a Migrator for the migrations in fsys.
+func NewMigrator(pool *Pool, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, migrations: migrations}, nil
+}
+
+// LatestVersion returns the newest embedded migration version, or 0 if none.
+func (m *Migrator) LatestVersion() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status compares the database version with the embedded migrations. A database
+// without schema_migrations reports version 0.
+func (m *Migrator) Status(ctx context.Context) (models.SchemaStatus, error) {
+	version, dirty, err := readSchemaVersion(ctx, m.pool)
+	if err != nil {
+		return models.SchemaStatus{}, err
+	}
+	status := models.SchemaStatus{CurrentVersion: version, ExpectedVersion: m.LatestVersion(), Dirty: dirty}
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			status.Pending++
+		}
+	}
+	return status, nil
+}
+
+// Up applies every pending migration in order and returns the ones applied.
+// Each migration runs in its own transaction together with its version update.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	for {
+		mig, err := m.step(ctx, true)
+		if err != nil {
+			return applied, err
+		}
+		if mig == nil {
+			return applied, nil
+		}
+		applied = append(applied, *mig)
+	}
+}
+
+// Down rolls back up to steps migrations, newest first, and returns the ones
+// rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	for i := 0; i < steps; i++ {
+		mig, err := m.step(ctx, false)
+		if err != nil {
+			return reverted, err
+		}
+		if mig == nil {
+			break
+		}
+		reverted = append(reverted, *mig)
+	}
+	return reverted, nil
+}
+
+// step applies the next migration up, or reverts the current one, under the
+// migration lock. Returns nil when there is nothing to do.
+func (m *Migrator) step(ctx context.Context, up bool) (*Migration, error) {
+	var done *Migration
+	err := m.pool.WithTx(ctx, func(tx Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockID); err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		if _, err := tx.Exec(ctx, createSchemaMigrationsSQL); err != nil {
+			return fmt.Errorf("create schema_migrations: %w", err)
+		}
+		version, dirty, err := readSchemaVersion(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w at version %d", ErrDirtySchema, version)
+		}
+
+		var mig *Migration
+		var sql string
+		var newVersion int64
+		if up {
+			for i := range m.migrations {
+				if m.migrations[i].Version > version {
+					mig, sql, newVersion = &m.migrations[i], m.migrations[i].Up, m.migrations[i].Version
+					break
+				}
+			}
+		} else if version > 0 {
+			i := sort.Search(len(m.migrations), func(i int) bool { return m.migrations[i].Version >= version })
+			if i == len(m.migrations) || m.migrations[i].Version != version {
+				return fmt.Errorf("database is at version %d, which is not an embedded migration", version)
+			}
+			mig, sql = &m.migrations[i], m.migrations[i].Down
+			if sql == "" {
+				return fmt.Errorf("migration %06d_%s has no down file", mig.Version, mig.Name)
+			}
+			if i > 0 {
+				newVersion = m.migrations[i-1].Version
+			}
+		}
+		if mig == nil {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return fmt.Errorf("migration %06d_%s: %w", mig.Version, mig.Name, err)
+		}
+		if _, err := tx.Exec(ctx, "TRUNCATE schema_migrations"); err != nil {
+			return fmt.Errorf("update schema_migrations: %w", err)
+		}
+		if newVersion > 0 {
+			if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", newVersion); err != nil {
+				return fmt.Errorf("update schema_migrations: %w", err)
+			}
+		}
+		done = mig
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return done, nil
+}
+
+// schemaVersionQuerier is satisfied by *Pool and Tx.
+type schemaVersionQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// readSchemaVersion returns the recorded version, or 0 when schema_migrations is
+// empty or missing.
+func readSchemaVersion(ctx context.Context, q schemaVersionQuerier) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := q.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return 0, false, nil
+	case errors.As(err, &pgErr) && pgErr.Code == "42P01": // undefined_table
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+	return version, dirty, nil
+}
@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/fcavalcantirj/solvr/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_add_bio.up.sql":        {Data: []byte("ALTER TABLE users ADD COLUMN bio TEXT;")},
+		"000002_add_bio.down.sql":      {Data: []byte("ALTER TABLE users DROP COLUMN bio;")},
+		"000001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id UUID);")},
+		"000001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"README_000001.md":             {Data: []byte("notes")},
+	}
+
+	loaded, err := LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("expected 2 migrations, got %d", len(loaded))
+	}
+	if loaded[0].Version != 1 || loaded[0].Name != "create_users" || loaded[1].Version != 2 {
+		t.Errorf("unexpected order: %+v", loaded)
+	}
+	if !strings.Contains(loaded[1].Down, "DROP COLUMN bio") {
+		t.Errorf("expected down SQL to be loaded, got %q", loaded[1].Down)
+	}
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"missing up", fstest.MapFS{"000001_create_users.down.sql": {Data: []byte("DROP TABLE users;")}}},
+		{"duplicate version", fstest.MapFS{
+			"000001_create_users.up.sql":  {Data: []byte("CREATE TABLE users (id UUID);")},
+			"000001_create_agents.up.sql": {Data: []byte("CREATE TABLE agents (id TEXT);")},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadMigrations(tt.fsys); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	all, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("embedded migrations invalid: %v", err)
+	}
+	for i, mig := range all {
+		if mig.Version != int64(i+1) {
+			t.Fatalf("expected contiguous versions, found %d at position %d", mig.Version, i+1)
+		}
+		if mig.Down == "" {
+			t.Errorf("migration %06d_%s has no down file", mig.Version, mig.Name)
+		}
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	migrator, err := NewMigrator(pool, migrations.FS)
+	if err != nil {
+		t.Fatalf("NewMigrator() error: %v", err)
+	}
+	status, err := migrator.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status() error: %v", err)
+	}
+	if status.ExpectedVersion != migrator.LatestVersion() || status.ExpectedVersion == 0 {
+		t.Errorf("unexpected expected version %d", status.ExpectedVersion)
+	}
+	if status.Pending < 0 || int64(status.Pending) > status.ExpectedVersion {
+		t.Errorf("unexpected pending count %d", status.Pending)
+	}
+}
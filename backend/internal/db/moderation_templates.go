@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrModerationTemplateNotFound is returned when no template exists for a key and language.
+var ErrModerationTemplateNotFound = modtemplate.ErrNotFound
+
+// ModerationTemplateRepository stores the admin-edited moderation comment templates.
+// It implements modtemplate.Store.
+type ModerationTemplateRepository struct {
+	pool *Pool
+}
+
+// NewModerationTemplateRepository creates a new ModerationTemplateRepository.
+func NewModerationTemplateRepository(pool *Pool) *ModerationTemplateRepository {
+	return &ModerationTemplateRepository{pool: pool}
+}
+
+// List returns every stored template ordered by key and language.
+func (r *ModerationTemplateRepository) List(ctx context.Context) ([]models.ModerationTemplate, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT key, language, body, updated_by, updated_at
+		FROM moderation_templates
+		ORDER BY key, language
+	`)
+	if err != nil {
+		LogQueryError(ctx, "List", "moderation_templates", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []models.ModerationTemplate{}
+	for rows.Next() {
+		var t models.ModerationTemplate
+		if err := rows.Scan(&t.Key, &t.Language, &t.Body, &t.UpdatedBy, &t.UpdatedAt); err != nil {
+			LogQueryError(ctx, "List.Scan", "moderation_templates", err)
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// Find returns the template for key and language.
+// Returns ErrModerationTemplateNotFound if there is none.
+func (r *ModerationTemplateRepository) Find(ctx context.Context, key, language string) (*models.ModerationTemplate, error) {
+	var t models.ModerationTemplate
+	err := r.pool.QueryRow(ctx, `
+		SELECT key, language, body, updated_by, updated_at
+		FROM moderation_templates
+		WHERE key = $1 AND language = $2
+	`, key, language).Scan(&t.Key, &t.Language, &t.Body, &t.UpdatedBy, &t.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrModerationTemplateNotFound
+		}
+		LogQueryError(ctx, "Find", "moderation_templates", err)
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Upsert creates or replaces the template for its key and language.
+func (r *ModerationTemplateRepository) Upsert(ctx context.Context, tmpl *models.ModerationTemplate) (*models.ModerationTemplate, error) {
+	var saved models.ModerationTemplate
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO moderation_templates (key, language, body, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key, language) DO UPDATE SET
+			body = EXCLUDED.body,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING key, language, body, updated_by, updated_at
+	`, tmpl.Key, tmpl.Language, tmpl.Body, tmpl.UpdatedBy).Scan(
+		&saved.Key, &saved.Language, &saved.Body, &saved.UpdatedBy, &saved.UpdatedAt)
+	if err != nil {
+		LogQueryError(ctx, "Upsert", "moderation_templates", err)
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// Delete removes the template for key and language, so rendering falls back to
+// the English variant or the built-in default.
+// Returns ErrModerationTemplateNotFound if there is none.
+func (r *ModerationTemplateRepository) Delete(ctx context.Context, key, language string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM moderation_templates WHERE key = $1 AND language = $2`, key, language)
+	if err != nil {
+		LogQueryError(ctx, "Delete", "moderation_templates", err)
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrModerationTemplateNotFound
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestModerationTemplates_UpsertFindDelete_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewModerationTemplateRepository(pool)
+	t.Cleanup(func() { repo.Delete(ctx, "rejected", "klingon") })
+
+	seeded, err := repo.Find(ctx, "approved", "en")
+	if err != nil {
+		t.Fatalf("expected seeded english template, got error %v", err)
+	}
+	if seeded.UpdatedBy != "system" {
+		t.Errorf("expected seeded template updated_by system, got %q", seeded.UpdatedBy)
+	}
+
+	saved, err := repo.Upsert(ctx, &models.ModerationTemplate{Key: "rejected", Language: "klingon", Body: "v1 {explanation}", UpdatedBy: "admin"})
+	if err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if saved.Body != "v1 {explanation}" {
+		t.Errorf("unexpected saved body %q", saved.Body)
+	}
+	if _, err := repo.Upsert(ctx, &models.ModerationTemplate{Key: "rejected", Language: "klingon", Body: "v2", UpdatedBy: "admin"}); err != nil {
+		t.Fatalf("Upsert() replace error = %v", err)
+	}
+
+	found, err := repo.Find(ctx, "rejected", "klingon")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.Body != "v2" {
+		t.Errorf("expected replaced body v2, got %q", found.Body)
+	}
+
+	templates, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	listed := false
+	for _, tmpl := range templates {
+		listed = listed || (tmpl.Key == "rejected" && tmpl.Language == "klingon")
+	}
+	if !listed {
+		t.Error("expected upserted template in List()")
+	}
+
+	if err := repo.Delete(ctx, "rejected", "klingon"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.Find(ctx, "rejected", "klingon"); !errors.Is(err, ErrModerationTemplateNotFound) {
+		t.Errorf("expected ErrModerationTemplateNotFound after delete, got %v", err)
+	}
+	if err := repo.Delete(ctx, "rejected", "klingon"); !errors.Is(err, ErrModerationTemplateNotFound) {
+		t.Errorf("expected ErrModerationTemplateNotFound deleting twice, got %v", err)
+	}
+}
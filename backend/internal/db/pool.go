@@ -18,8 +18,11 @@ import (
 var ErrTest = errors.New("test error")
 
 // Pool wraps pgxpool.Pool with helper methods.
+// An optional read replica (see AttachReplica) serves ReadQuery/ReadQueryRow.
 type Pool struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	replica *replica
+	tracer  *queryTracer
 }
 
 // Tx represents a database transaction interface.
@@ -63,6 +66,32 @@ func (t *txWrapper) Rollback(ctx context.Context) error {
 // - MaxConnIdleTime: 30s
 // - HealthCheckPeriod: 30s
 func NewPool(ctx context.Context, databaseURL string) (*Pool, error) {
+	tracer := newQueryTracer()
+	pool, err := newPgxPool(ctx, databaseURL, tracer)
+	if err != nil {
+		return nil, err
+	}
+	return &Pool{pool: pool, tracer: tracer}, nil
+}
+
+// NewUnconnectedPool creates a pool that does not connect until a query runs,
+// so the router can be built without a database (e.g. for the route audit).
+func NewUnconnectedPool(databaseURL string) (*Pool, error) {
+	config, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+	config.MinConns = 0
+	pool, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+	return &Pool{pool: pool, tracer: newQueryTracer()}, nil
+}
+
+// newPgxPool creates and pings a pgxpool.Pool with the standard Solvr settings.
+// Shared by the primary and the read replica; both report to the same tracer.
+func newPgxPool(ctx context.Context, databaseURL string, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
 	if databaseURL == "" {
 		return nil, errors.New("database URL is required")
 	}
@@ -77,11 +106,14 @@ func NewPool(ctx context.Context, databaseURL string) (*Pool, error) {
 	config.MinConns = 2
 	config.MaxConnIdleTime = 30 * time.Second
 	config.HealthCheckPeriod = 30 * time.Second
+	config.ConnConfig.Tracer = tracer
 
 	// Registers pgvector types so pgx can scan vector columns into pgvector.Vector type
 	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
 		return pgxvec.RegisterTypes(ctx, conn)
 	}
+	// Scopes every checkout to the tenant in the caller's context (see package tenant).
+	config.BeforeAcquire = applyTenant
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -94,7 +126,7 @@ func NewPool(ctx context.Context, databaseURL string) (*Pool, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &Pool{pool: pool}, nil
+	return pool, nil
 }
 
 // Ping verifies the database connection is alive.
@@ -102,6 +134,19 @@ func (p *Pool) Ping(ctx context.Context) error {
 	return p.pool.Ping(ctx)
 }
 
+// ExtensionVersion returns the installed version of a Postgres extension, or "" if it is not installed.
+func (p *Pool) ExtensionVersion(ctx context.Context, name string) (string, error) {
+	var version string
+	err := p.pool.QueryRow(ctx, `SELECT extversion FROM pg_extension WHERE extname = $1`, name).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("extension version: %w", err)
+	}
+	return version, nil
+}
+
 // Query executes a query that returns rows.
 func (p *Pool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
 	rows, err := p.pool.Query(ctx, sql, args...)
@@ -160,9 +205,12 @@ func (p *Pool) WithTx(ctx context.Context, fn func(tx Tx) error) error {
 	return nil
 }
 
-// Close closes the connection pool.
+// Close closes the connection pool and the read replica, if any.
 func (p *Pool) Close() {
 	slog.Info("closing database connection pool")
+	if p.replica != nil {
+		p.replica.close()
+	}
 	p.pool.Close()
 }
 
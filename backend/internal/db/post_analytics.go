@@ -0,0 +1,217 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Limits for the ranked lists in post analytics.
+const (
+	postAnalyticsTopQueries = 10
+	postAnalyticsReferrers  = 20
+)
+
+// PostAnalyticsRepository reads per-post analytics for authors.
+type PostAnalyticsRepository struct {
+	pool *Pool
+}
+
+// NewPostAnalyticsRepository creates a new PostAnalyticsRepository.
+func NewPostAnalyticsRepository(pool *Pool) *PostAnalyticsRepository {
+	return &PostAnalyticsRepository{pool: pool}
+}
+
+// GetPostAnalytics returns views over time, search impressions, vote trajectory
+// and referrer breakdown for a post over the last days UTC days (today included).
+// Returns ErrPostNotFound if the post does not exist or is deleted.
+func (r *PostAnalyticsRepository) GetPostAnalytics(ctx context.Context, postID string, days int) (*models.PostAnalytics, error) {
+	analytics := &models.PostAnalytics{PostID: postID, Days: days}
+	err := r.pool.QueryRow(ctx, `
+		SELECT posted_by_type, posted_by_id, COALESCE(view_count, 0), COALESCE(upvotes, 0) - COALESCE(downvotes, 0)
+		FROM posts
+		WHERE id = $1 AND deleted_at IS NULL
+	`, postID).Scan(&analytics.AuthorType, &analytics.AuthorID, &analytics.ViewCount, &analytics.VoteScore)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "22P02" {
+			return nil, ErrPostNotFound
+		}
+		LogQueryError(ctx, "GetPostAnalytics", "posts", err)
+		return nil, fmt.Errorf("get post analytics failed: %w", err)
+	}
+
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+
+	if analytics.Views, err = NewViewsRepository(r.pool).GetViewSeries(ctx, postID, days); err != nil {
+		return nil, err
+	}
+	if analytics.SearchImpressions, err = r.searchImpressions(ctx, postID, since); err != nil {
+		return nil, err
+	}
+	if analytics.VoteTrajectory, err = r.voteTrajectory(ctx, postID, since, analytics.VoteScore); err != nil {
+		return nil, err
+	}
+	if analytics.Referrers, err = r.referrers(ctx, postID, since); err != nil {
+		return nil, err
+	}
+
+	return analytics, nil
+}
+
+// searchImpressions counts searches since the given day whose results included the post.
+func (r *PostAnalyticsRepository) searchImpressions(ctx context.Context, postID string, since time.Time) (models.PostSearchImpressions, error) {
+	impressions := models.PostSearchImpressions{
+		Daily:      []models.PostImpressionDay{},
+		TopQueries: []models.PostImpressionsQuery{},
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH hits AS (
+			SELECT (searched_at AT TIME ZONE 'UTC')::date AS day, COUNT(*) AS impressions
+			FROM search_queries
+			WHERE result_post_ids @> ARRAY[$1::uuid] AND searched_at >= $2
+			GROUP BY 1
+		)
+		SELECT to_char(d.day, 'YYYY-MM-DD'), COALESCE(h.impressions, 0)
+		FROM generate_series(($2::timestamptz AT TIME ZONE 'UTC')::date, (NOW() AT TIME ZONE 'UTC')::date, interval '1 day') AS d(day)
+		LEFT JOIN hits h ON h.day = d.day::date
+		ORDER BY d.day
+	`, postID, since)
+	if err != nil {
+		LogQueryError(ctx, "GetPostAnalytics.Impressions", "search_queries", err)
+		return impressions, fmt.Errorf("get search impressions failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var day models.PostImpressionDay
+		if err := rows.Scan(&day.Date, &day.Impressions); err != nil {
+			return impressions, fmt.Errorf("scan impression day: %w", err)
+		}
+		impressions.Total += day.Impressions
+		impressions.Daily = append(impressions.Daily, day)
+	}
+	if err := rows.Err(); err != nil {
+		return impressions, fmt.Errorf("iterate impression days: %w", err)
+	}
+
+	queryRows, err := r.pool.Query(ctx, `
+		SELECT query_normalized, COUNT(*) AS impressions
+		FROM search_queries
+		WHERE result_post_ids @> ARRAY[$1::uuid] AND searched_at >= $2
+		GROUP BY query_normalized
+		ORDER BY impressions DESC, query_normalized
+		LIMIT $3
+	`, postID, since, postAnalyticsTopQueries)
+	if err != nil {
+		LogQueryError(ctx, "GetPostAnalytics.TopQueries", "search_queries", err)
+		return impressions, fmt.Errorf("get top search queries failed: %w", err)
+	}
+	defer queryRows.Close()
+
+	for queryRows.Next() {
+		var q models.PostImpressionsQuery
+		if err := queryRows.Scan(&q.Query, &q.Impressions); err != nil {
+			return impressions, fmt.Errorf("scan top search query: %w", err)
+		}
+		impressions.TopQueries = append(impressions.TopQueries, q)
+	}
+	if err := queryRows.Err(); err != nil {
+		return impressions, fmt.Errorf("iterate top search queries: %w", err)
+	}
+
+	return impressions, nil
+}
+
+// voteTrajectory returns daily vote movement since the given day from vote_events.
+// Each day's score is worked backwards from the current score, so votes cast
+// before vote_events existed are still reflected.
+func (r *PostAnalyticsRepository) voteTrajectory(ctx context.Context, postID string, since time.Time, currentScore int) ([]models.PostVoteDay, error) {
+	rows, err := r.pool.Query(ctx, `
+		WITH moves AS (
+			SELECT (created_at AT TIME ZONE 'UTC')::date AS day,
+				SUM(CASE
+					WHEN action IN ('cast', 'change') AND direction = 'up' THEN 1
+					WHEN action = 'change' AND direction = 'down' THEN -1
+					WHEN action = 'retract' AND direction = 'up' THEN -1
+					ELSE 0 END) AS upvotes,
+				SUM(CASE
+					WHEN action IN ('cast', 'change') AND direction = 'down' THEN 1
+					WHEN action = 'change' AND direction = 'up' THEN -1
+					WHEN action = 'retract' AND direction = 'down' THEN -1
+					ELSE 0 END) AS downvotes
+			FROM vote_events
+			WHERE target_type = 'post' AND target_id = $1 AND created_at >= $2
+			GROUP BY 1
+		)
+		SELECT to_char(d.day, 'YYYY-MM-DD'), COALESCE(m.upvotes, 0), COALESCE(m.downvotes, 0)
+		FROM generate_series(($2::timestamptz AT TIME ZONE 'UTC')::date, (NOW() AT TIME ZONE 'UTC')::date, interval '1 day') AS d(day)
+		LEFT JOIN moves m ON m.day = d.day::date
+		ORDER BY d.day
+	`, postID, since)
+	if err != nil {
+		LogQueryError(ctx, "GetPostAnalytics.Votes", "vote_events", err)
+		return nil, fmt.Errorf("get vote trajectory failed: %w", err)
+	}
+	defer rows.Close()
+
+	trajectory := make([]models.PostVoteDay, 0)
+	for rows.Next() {
+		var day models.PostVoteDay
+		if err := rows.Scan(&day.Date, &day.Upvotes, &day.Downvotes); err != nil {
+			return nil, fmt.Errorf("scan vote day: %w", err)
+		}
+		trajectory = append(trajectory, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate vote days: %w", err)
+	}
+
+	score := currentScore
+	for i := len(trajectory) - 1; i >= 0; i-- {
+		trajectory[i].Score = score
+		score -= trajectory[i].Upvotes - trajectory[i].Downvotes
+	}
+
+	return trajectory, nil
+}
+
+// referrers returns counted views since the given day grouped by referring host.
+func (r *PostAnalyticsRepository) referrers(ctx context.Context, postID string, since time.Time) ([]models.PostReferrer, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT COALESCE(referrer_host, 'direct') AS source, COUNT(*) AS views
+		FROM post_views
+		WHERE post_id = $1 AND viewed_at >= $2
+		GROUP BY 1
+		ORDER BY views DESC, source
+		LIMIT $3
+	`, postID, since, postAnalyticsReferrers)
+	if err != nil {
+		LogQueryError(ctx, "GetPostAnalytics.Referrers", "post_views", err)
+		return nil, fmt.Errorf("get referrers failed: %w", err)
+	}
+	defer rows.Close()
+
+	referrers := make([]models.PostReferrer, 0)
+	for rows.Next() {
+		var ref models.PostReferrer
+		if err := rows.Scan(&ref.Source, &ref.Views); err != nil {
+			return nil, fmt.Errorf("scan referrer: %w", err)
+		}
+		referrers = append(referrers, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate referrers: %w", err)
+	}
+
+	return referrers, nil
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPostAnalyticsRepository_GetPostAnalytics(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	userRepo := NewUserRepository(pool)
+	postRepo := NewPostRepository(pool)
+	viewsRepo := NewViewsRepository(pool)
+	repo := NewPostAnalyticsRepository(pool)
+
+	author := createViewsTestUser(t, userRepo)
+	voter := createViewsTestUser(t, userRepo)
+
+	post, err := postRepo.Create(ctx, &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        "Test problem for post analytics",
+		Description:  "This is a test problem to check the author's post analytics endpoint",
+		Tags:         []string{"test"},
+		PostedByType: models.AuthorTypeHuman,
+		PostedByID:   author.ID,
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test post: %v", err)
+	}
+	defer pool.Exec(ctx, "DELETE FROM search_queries WHERE query = 'test_post_analytics'")
+
+	for _, v := range []struct{ hash, referrer string }{{"a", "google.com"}, {"b", "google.com"}, {"c", ""}} {
+		if _, err := viewsRepo.RecordAnonymousView(ctx, post.ID, v.hash, v.referrer); err != nil {
+			t.Fatalf("failed to record view: %v", err)
+		}
+	}
+	if err := postRepo.Vote(ctx, post.ID, "human", voter.ID, "up"); err != nil {
+		t.Fatalf("failed to vote: %v", err)
+	}
+	if err := NewSearchAnalyticsRepository(pool).Insert(ctx, models.SearchQuery{
+		Query:           "test_post_analytics",
+		QueryNormalized: "test_post_analytics",
+		ResultsCount:    1,
+		SearchMethod:    "hybrid",
+		SearcherType:    "anonymous",
+		Page:            1,
+		SearchedAt:      time.Now(),
+		ResultPostIDs:   []string{post.ID},
+	}); err != nil {
+		t.Fatalf("failed to insert search query: %v", err)
+	}
+
+	analytics, err := repo.GetPostAnalytics(ctx, post.ID, 7)
+	if err != nil {
+		t.Fatalf("GetPostAnalytics() error = %v", err)
+	}
+
+	if analytics.AuthorID != author.ID || analytics.ViewCount != 3 {
+		t.Errorf("unexpected post fields: author=%s views=%d", analytics.AuthorID, analytics.ViewCount)
+	}
+	if len(analytics.Views) != 7 || analytics.Views[6].Views != 3 {
+		t.Errorf("expected 3 views today in a 7 day series, got %+v", analytics.Views)
+	}
+	if analytics.SearchImpressions.Total != 1 || len(analytics.SearchImpressions.TopQueries) != 1 ||
+		analytics.SearchImpressions.TopQueries[0].Query != "test_post_analytics" {
+		t.Errorf("unexpected search impressions: %+v", analytics.SearchImpressions)
+	}
+	if len(analytics.VoteTrajectory) != 7 {
+		t.Fatalf("expected 7 vote days, got %d", len(analytics.VoteTrajectory))
+	}
+	today := analytics.VoteTrajectory[6]
+	if today.Upvotes != 1 || today.Score != 1 || analytics.VoteTrajectory[5].Score != 0 {
+		t.Errorf("unexpected vote trajectory: %+v", analytics.VoteTrajectory)
+	}
+	if len(analytics.Referrers) != 2 || analytics.Referrers[0].Source != "google.com" || analytics.Referrers[0].Views != 2 ||
+		analytics.Referrers[1].Source != "direct" {
+		t.Errorf("unexpected referrers: %+v", analytics.Referrers)
+	}
+}
+
+func TestPostAnalyticsRepository_GetPostAnalytics_NotFound(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	_, err := NewPostAnalyticsRepository(pool).GetPostAnalytics(context.Background(), "00000000-0000-0000-0000-000000000000", 7)
+	if err != ErrPostNotFound {
+		t.Errorf("expected ErrPostNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostCollaboratorRepository stores who besides the author may edit a problem.
+type PostCollaboratorRepository struct {
+	pool *Pool
+}
+
+// NewPostCollaboratorRepository creates a new PostCollaboratorRepository.
+func NewPostCollaboratorRepository(pool *Pool) *PostCollaboratorRepository {
+	return &PostCollaboratorRepository{pool: pool}
+}
+
+// Grant adds a collaborator to the post, or updates an existing one's family flag.
+// Returns ErrNotFound if the human or agent doesn't exist.
+func (r *PostCollaboratorRepository) Grant(ctx context.Context, postID string, collaboratorType models.AuthorType, collaboratorID string, family bool) (*models.PostCollaborator, error) {
+	c := models.PostCollaborator{CollaboratorType: collaboratorType, CollaboratorID: collaboratorID, Family: family}
+	err := r.pool.QueryRow(ctx, `
+		WITH target AS (
+			SELECT u.display_name FROM users u
+			WHERE $2 = 'human' AND u.id::text = $3 AND u.deleted_at IS NULL
+			UNION ALL
+			SELECT a.display_name FROM agents a
+			WHERE $2 = 'agent' AND a.id = $3 AND a.deleted_at IS NULL
+		), saved AS (
+			INSERT INTO post_collaborators (post_id, collaborator_type, collaborator_id, include_family)
+			SELECT $1, $2, $3, $4 FROM target
+			ON CONFLICT (post_id, collaborator_type, collaborator_id) DO UPDATE SET include_family = EXCLUDED.include_family
+			RETURNING granted_at
+		)
+		SELECT target.display_name, saved.granted_at FROM target, saved
+	`, postID, string(collaboratorType), collaboratorID, family).Scan(&c.DisplayName, &c.GrantedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		LogQueryError(ctx, "Grant", "post_collaborators", err)
+		return nil, fmt.Errorf("grant collaborator: %w", err)
+	}
+	return &c, nil
+}
+
+// Revoke removes a collaborator. Returns ErrNotFound if they weren't one.
+func (r *PostCollaboratorRepository) Revoke(ctx context.Context, postID string, collaboratorType models.AuthorType, collaboratorID string) error {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM post_collaborators
+		WHERE post_id = $1 AND collaborator_type = $2 AND collaborator_id = $3
+	`, postID, string(collaboratorType), collaboratorID)
+	if err != nil {
+		LogQueryError(ctx, "Revoke", "post_collaborators", err)
+		return fmt.Errorf("revoke collaborator: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns the post's collaborators in the order they were added.
+func (r *PostCollaboratorRepository) List(ctx context.Context, postID string) ([]models.PostCollaborator, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT pc.collaborator_type, pc.collaborator_id,
+		       COALESCE(CASE WHEN pc.collaborator_type = 'human' THEN u.display_name ELSE a.display_name END, pc.collaborator_id),
+		       pc.include_family, pc.granted_at
+		FROM post_collaborators pc
+		LEFT JOIN users u ON pc.collaborator_type = 'human' AND u.id::text = pc.collaborator_id
+		LEFT JOIN agents a ON pc.collaborator_type = 'agent' AND a.id = pc.collaborator_id
+		WHERE pc.post_id = $1
+		ORDER BY pc.granted_at, pc.collaborator_id
+	`, postID)
+	if err != nil {
+		LogQueryError(ctx, "List", "post_collaborators", err)
+		return nil, fmt.Errorf("list collaborators: %w", err)
+	}
+	defer rows.Close()
+
+	collaborators := []models.PostCollaborator{}
+	for rows.Next() {
+		var c models.PostCollaborator
+		if err := rows.Scan(&c.CollaboratorType, &c.CollaboratorID, &c.DisplayName, &c.Family, &c.GrantedAt); err != nil {
+			return nil, fmt.Errorf("scan collaborator: %w", err)
+		}
+		collaborators = append(collaborators, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list collaborators: %w", err)
+	}
+	return collaborators, nil
+}
+
+// CanEdit reports whether the principal is a collaborator on the post, directly
+// or, for an agent, through a family grant to the human who claimed it.
+func (r *PostCollaboratorRepository) CanEdit(ctx context.Context, postID string, principalType models.AuthorType, principalID string) (bool, error) {
+	var ok bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM post_collaborators pc
+			WHERE pc.post_id = $1
+			  AND ((pc.collaborator_type = $2 AND pc.collaborator_id = $3)
+			    OR ($2 = 'agent' AND pc.collaborator_type = 'human' AND pc.include_family AND EXISTS (
+			        SELECT 1 FROM agents a
+			        WHERE a.id = $3 AND a.deleted_at IS NULL AND a.human_id::text = pc.collaborator_id)))
+		)
+	`, postID, string(principalType), principalID).Scan(&ok)
+	if err != nil {
+		LogQueryError(ctx, "CanEdit", "post_collaborators", err)
+		return false, fmt.Errorf("check collaborator: %w", err)
+	}
+	return ok, nil
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPostCollaboratorRepository_FamilyGrant(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewPostCollaboratorRepository(pool)
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano()%1e9)
+
+	users := NewUserRepository(pool)
+	human, err := users.Create(ctx, &models.User{
+		Username:       "collab" + suffix,
+		DisplayName:    "Collaborator",
+		Email:          "collab" + suffix + "@test.com",
+		AuthProvider:   "github",
+		AuthProviderID: "gh-collab" + suffix,
+		Role:           "user",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer cleanupTestUser(ctx, pool, human.ID)
+
+	claimedAgent, otherAgent := "collab_claimed_"+suffix, "collab_other_"+suffix
+	for _, id := range []string{claimedAgent, otherAgent} {
+		if _, err := pool.Exec(ctx, `INSERT INTO agents (id, display_name, api_key_hash, status) VALUES ($1, $1, 'hash', 'active')`, id); err != nil {
+			t.Fatalf("insert agent: %v", err)
+		}
+		defer pool.Exec(ctx, `DELETE FROM agents WHERE id = $1`, id)
+	}
+	if _, err := pool.Exec(ctx, `UPDATE agents SET human_id = $1 WHERE id = $2`, human.ID, claimedAgent); err != nil {
+		t.Fatalf("claim agent: %v", err)
+	}
+
+	var postID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('problem', 'Collaborator test problem', 'Description', 'agent', $1, 'open')
+		RETURNING id::text
+	`, otherAgent).Scan(&postID); err != nil {
+		t.Fatalf("insert post: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM posts WHERE id = $1`, postID)
+
+	if _, err := repo.Grant(ctx, postID, models.AuthorTypeAgent, "collab_missing_"+suffix, false); !errors.Is(err, ErrNotFound) {
+		t.Errorf("granting an unknown agent err = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.Grant(ctx, postID, models.AuthorTypeHuman, human.ID, false); err != nil {
+		t.Fatalf("Grant() error = %v", err)
+	}
+	if ok, _ := repo.CanEdit(ctx, postID, models.AuthorTypeAgent, claimedAgent); ok {
+		t.Error("claimed agent can edit without a family grant")
+	}
+
+	c, err := repo.Grant(ctx, postID, models.AuthorTypeHuman, human.ID, true)
+	if err != nil || !c.Family || c.DisplayName != "Collaborator" {
+		t.Fatalf("Grant(family) = %+v, %v", c, err)
+	}
+	if ok, _ := repo.CanEdit(ctx, postID, models.AuthorTypeAgent, claimedAgent); !ok {
+		t.Error("claimed agent can't edit with a family grant")
+	}
+	if ok, _ := repo.CanEdit(ctx, postID, models.AuthorTypeAgent, otherAgent); ok {
+		t.Error("unrelated agent can edit")
+	}
+
+	listed, err := repo.List(ctx, postID)
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("List() = %v, %v; want one collaborator", listed, err)
+	}
+	if err := repo.Revoke(ctx, postID, models.AuthorTypeHuman, human.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if ok, _ := repo.CanEdit(ctx, postID, models.AuthorTypeHuman, human.ID); ok {
+		t.Error("revoked collaborator can still edit")
+	}
+}
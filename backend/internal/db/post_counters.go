@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReconcileCounters recomputes posts.answers_count, approaches_count and
+// comments_count from the child tables and fixes any post whose stored counts
+// drifted (the columns are normally maintained by triggers, migration 000085).
+// Returns the number of posts corrected.
+//
+// A write racing with the reconciliation can leave a count off by one until the
+// next run; the job is self-correcting rather than locking the child tables.
+func (r *PostRepository) ReconcileCounters(ctx context.Context) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		WITH actual AS (
+			SELECT
+				p.id,
+				COALESCE(ans.cnt, 0) AS answers_count,
+				COALESCE(app.cnt, 0) AS approaches_count,
+				COALESCE(cmt.cnt, 0) AS comments_count
+			FROM posts p
+			LEFT JOIN (
+				SELECT question_id, COUNT(*) AS cnt
+				FROM answers WHERE deleted_at IS NULL
+				GROUP BY question_id
+			) ans ON ans.question_id = p.id
+			LEFT JOIN (
+				SELECT problem_id, COUNT(*) AS cnt
+				FROM approaches WHERE deleted_at IS NULL
+				GROUP BY problem_id
+			) app ON app.problem_id = p.id
+			LEFT JOIN (
+				SELECT target_id, COUNT(*) AS cnt
+				FROM comments
+				WHERE target_type = 'post' AND deleted_at IS NULL
+				GROUP BY target_id
+			) cmt ON cmt.target_id = p.id
+		)
+		UPDATE posts p SET
+			answers_count = actual.answers_count,
+			approaches_count = actual.approaches_count,
+			comments_count = actual.comments_count
+		FROM actual
+		WHERE actual.id = p.id
+			AND (p.answers_count, p.approaches_count, p.comments_count)
+				IS DISTINCT FROM (actual.answers_count, actual.approaches_count, actual.comments_count)
+	`)
+	if err != nil {
+		LogQueryError(ctx, "ReconcileCounters", "posts", err)
+		return 0, fmt.Errorf("reconcile post counters: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPostRepository_CounterColumns_TrackAnswers(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewPostRepository(pool)
+	answersRepo := NewAnswersRepository(pool)
+	ctx := context.Background()
+
+	question, err := repo.Create(ctx, &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Counter column test",
+		Description:  "Answers should be counted by trigger",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_counters",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM answers WHERE question_id = $1", question.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", question.ID)
+	}()
+
+	answer, err := answersRepo.CreateAnswer(ctx, &models.Answer{
+		QuestionID: question.ID,
+		AuthorType: models.AuthorTypeAgent,
+		AuthorID:   "test_agent_counters",
+		Content:    "First answer",
+	})
+	if err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+
+	fetched, err := repo.FindByID(ctx, question.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if fetched.AnswersCount != 1 {
+		t.Errorf("AnswersCount after insert = %d, want 1", fetched.AnswersCount)
+	}
+
+	if err := answersRepo.DeleteAnswer(ctx, answer.ID); err != nil {
+		t.Fatalf("DeleteAnswer() error = %v", err)
+	}
+	fetched, err = repo.FindByID(ctx, question.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if fetched.AnswersCount != 0 {
+		t.Errorf("AnswersCount after soft delete = %d, want 0", fetched.AnswersCount)
+	}
+}
+
+func TestPostRepository_ReconcileCounters(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewPostRepository(pool)
+	ctx := context.Background()
+
+	post, err := repo.Create(ctx, &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        "Counter drift test",
+		Description:  "Counters corrupted by hand should be repaired",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_counters",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID)
+	}()
+
+	if _, err := pool.Exec(ctx, "UPDATE posts SET approaches_count = 42, comments_count = 7 WHERE id = $1", post.ID); err != nil {
+		t.Fatalf("failed to corrupt counters: %v", err)
+	}
+
+	fixed, err := repo.ReconcileCounters(ctx)
+	if err != nil {
+		t.Fatalf("ReconcileCounters() error = %v", err)
+	}
+	if fixed < 1 {
+		t.Errorf("expected at least 1 corrected post, got %d", fixed)
+	}
+
+	fetched, err := repo.FindByID(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if fetched.ApproachesCount != 0 || fetched.CommentsCount != 0 {
+		t.Errorf("counters not reconciled: approaches=%d comments=%d", fetched.ApproachesCount, fetched.CommentsCount)
+	}
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	// ErrGitHubLinkNotFound is returned when a post has no linked GitHub issue.
+	ErrGitHubLinkNotFound = errors.New("github link not found")
+	// ErrPostAlreadyLinked is returned when linking a post that already has an issue.
+	ErrPostAlreadyLinked = errors.New("post is already linked to a github issue")
+	// ErrGitHubIssueAlreadyImported is returned when importing an issue twice.
+	ErrGitHubIssueAlreadyImported = errors.New("github issue already imported")
+)
+
+const postGitHubLinkColumns = `post_id, repo_owner, repo_name, issue_number, issue_url, issue_state,
+	imported, closed_by_sync, linked_by_type, linked_by_id, synced_at, created_at`
+
+// PostGitHubLinkRepository stores links between posts and GitHub issues.
+type PostGitHubLinkRepository struct {
+	pool *Pool
+}
+
+// NewPostGitHubLinkRepository creates a new PostGitHubLinkRepository.
+func NewPostGitHubLinkRepository(pool *Pool) *PostGitHubLinkRepository {
+	return &PostGitHubLinkRepository{pool: pool}
+}
+
+// Create inserts a link. Returns ErrPostAlreadyLinked if the post has one, or
+// ErrGitHubIssueAlreadyImported if an imported link for the issue exists.
+func (r *PostGitHubLinkRepository) Create(ctx context.Context, link *models.PostGitHubLink) (*models.PostGitHubLink, error) {
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO post_github_links
+			(post_id, repo_owner, repo_name, issue_number, issue_url, issue_state, imported, linked_by_type, linked_by_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+postGitHubLinkColumns,
+		link.PostID, link.RepoOwner, link.RepoName, link.IssueNumber, link.IssueURL, link.IssueState,
+		link.Imported, link.LinkedByType, link.LinkedByID)
+	created, err := scanPostGitHubLink(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			if pgErr.ConstraintName == "idx_post_github_links_imported" {
+				return nil, ErrGitHubIssueAlreadyImported
+			}
+			return nil, ErrPostAlreadyLinked
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, ErrPostNotFound
+		}
+		return nil, fmt.Errorf("create github link: %w", err)
+	}
+	return created, nil
+}
+
+// FindByPostID returns the issue linked to a post.
+func (r *PostGitHubLinkRepository) FindByPostID(ctx context.Context, postID string) (*models.PostGitHubLink, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+postGitHubLinkColumns+` FROM post_github_links WHERE post_id = $1`, postID)
+	link, err := scanPostGitHubLink(row)
+	if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+		return nil, ErrGitHubLinkNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find github link: %w", err)
+	}
+	return link, nil
+}
+
+// FindImportedPostID returns the live post an issue was imported into, or "" if
+// it hasn't been imported.
+func (r *PostGitHubLinkRepository) FindImportedPostID(ctx context.Context, ref models.GitHubIssueRef) (string, error) {
+	var postID string
+	err := r.pool.QueryRow(ctx, `
+		SELECT l.post_id
+		FROM post_github_links l
+		JOIN posts p ON p.id = l.post_id AND p.deleted_at IS NULL
+		WHERE l.imported
+		  AND LOWER(l.repo_owner) = LOWER($1) AND LOWER(l.repo_name) = LOWER($2) AND l.issue_number = $3`,
+		ref.Owner, ref.Repo, ref.Number).Scan(&postID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("find imported github issue: %w", err)
+	}
+	return postID, nil
+}
+
+// Delete removes a post's link.
+func (r *PostGitHubLinkRepository) Delete(ctx context.Context, postID string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM post_github_links WHERE post_id = $1`, postID)
+	if err != nil {
+		if isInvalidUUIDError(err) {
+			return ErrGitHubLinkNotFound
+		}
+		return fmt.Errorf("delete github link: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrGitHubLinkNotFound
+	}
+	return nil
+}
+
+// ListDueForSync returns links on live posts last synced before olderThan,
+// least recently synced first.
+func (r *PostGitHubLinkRepository) ListDueForSync(ctx context.Context, olderThan time.Time, limit int) ([]models.PostGitHubLink, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT l.post_id, l.repo_owner, l.repo_name, l.issue_number, l.issue_url, l.issue_state,
+		       l.imported, l.closed_by_sync, l.linked_by_type, l.linked_by_id, l.synced_at, l.created_at
+		FROM post_github_links l
+		JOIN posts p ON p.id = l.post_id AND p.deleted_at IS NULL
+		WHERE l.synced_at < $1
+		ORDER BY l.synced_at
+		LIMIT $2`, olderThan, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list github links to sync: %w", err)
+	}
+	defer rows.Close()
+
+	var links []models.PostGitHubLink
+	for rows.Next() {
+		link, err := scanPostGitHubLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan github link: %w", err)
+		}
+		links = append(links, *link)
+	}
+	return links, rows.Err()
+}
+
+// ApplyIssueState records the issue's current state and syncs the post status in
+// one transaction: a closed issue closes an open, in-progress or stale post; a
+// reopened issue reopens the post only if this sync closed it. Returns the new
+// post status, or "" when the post status didn't change.
+func (r *PostGitHubLinkRepository) ApplyIssueState(ctx context.Context, postID, state string) (models.PostStatus, error) {
+	var changed models.PostStatus
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var closedBySync bool
+		if err := tx.QueryRow(ctx, `
+			UPDATE post_github_links SET issue_state = $2, synced_at = NOW()
+			WHERE post_id = $1
+			RETURNING closed_by_sync`, postID, state).Scan(&closedBySync); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrGitHubLinkNotFound
+			}
+			return err
+		}
+
+		var tag pgconn.CommandTag
+		var err error
+		switch {
+		case state == models.GitHubIssueClosed && !closedBySync:
+			tag, err = tx.Exec(ctx, `
+				UPDATE posts SET status = $2, updated_at = NOW()
+				WHERE id = $1 AND deleted_at IS NULL AND status IN ('open', 'in_progress', 'stale')`,
+				postID, models.PostStatusClosed)
+			changed = models.PostStatusClosed
+		case state == models.GitHubIssueOpen && closedBySync:
+			tag, err = tx.Exec(ctx, `
+				UPDATE posts SET status = $2, updated_at = NOW()
+				WHERE id = $1 AND deleted_at IS NULL AND status = 'closed'`,
+				postID, models.PostStatusOpen)
+			changed = models.PostStatusOpen
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The post status was changed by hand since (e.g. solved): leave it, and stop
+		// treating it as closed by the sync.
+		if tag.RowsAffected() == 0 {
+			changed = ""
+			if state == models.GitHubIssueOpen {
+				_, err = tx.Exec(ctx, `UPDATE post_github_links SET closed_by_sync = FALSE WHERE post_id = $1`, postID)
+			}
+			return err
+		}
+		_, err = tx.Exec(ctx, `UPDATE post_github_links SET closed_by_sync = $2 WHERE post_id = $1`,
+			postID, state == models.GitHubIssueClosed)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, ErrGitHubLinkNotFound) {
+			return "", err
+		}
+		return "", fmt.Errorf("apply github issue state: %w", err)
+	}
+	return changed, nil
+}
+
+func scanPostGitHubLink(row pgx.Row) (*models.PostGitHubLink, error) {
+	var l models.PostGitHubLink
+	err := row.Scan(&l.PostID, &l.RepoOwner, &l.RepoName, &l.IssueNumber, &l.IssueURL, &l.IssueState,
+		&l.Imported, &l.ClosedBySync, &l.LinkedByType, &l.LinkedByID, &l.SyncedAt, &l.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
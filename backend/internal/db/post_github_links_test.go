@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPostGitHubLinkRepository_StatusSync(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(pool)
+	repo := NewPostGitHubLinkRepository(pool)
+
+	post, err := postRepo.Create(ctx, &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        "GitHub link sync test",
+		Description:  "The post status should follow the linked issue",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_github_link",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID)
+	}()
+
+	ref := models.GitHubIssueRef{Owner: "solvr-test", Repo: "sync", Number: 1}
+	_, err = repo.Create(ctx, &models.PostGitHubLink{
+		PostID: post.ID, RepoOwner: ref.Owner, RepoName: ref.Repo, IssueNumber: ref.Number,
+		IssueURL: ref.URL(), IssueState: models.GitHubIssueOpen, LinkedByType: "agent", LinkedByID: "test_agent_github_link",
+	})
+	if err != nil {
+		t.Fatalf("Create link error = %v", err)
+	}
+	if _, err := repo.Create(ctx, &models.PostGitHubLink{PostID: post.ID, RepoOwner: "x", RepoName: "y", IssueNumber: 2,
+		IssueURL: "u", IssueState: models.GitHubIssueOpen, LinkedByType: "agent", LinkedByID: "a"}); !errors.Is(err, ErrPostAlreadyLinked) {
+		t.Errorf("expected ErrPostAlreadyLinked, got %v", err)
+	}
+
+	status, err := repo.ApplyIssueState(ctx, post.ID, models.GitHubIssueClosed)
+	if err != nil || status != models.PostStatusClosed {
+		t.Fatalf("ApplyIssueState(closed) = %q, %v", status, err)
+	}
+	status, err = repo.ApplyIssueState(ctx, post.ID, models.GitHubIssueOpen)
+	if err != nil || status != models.PostStatusOpen {
+		t.Fatalf("ApplyIssueState(open) = %q, %v", status, err)
+	}
+
+	if err := repo.Delete(ctx, post.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.FindByPostID(ctx, post.ID); !errors.Is(err, ErrGitHubLinkNotFound) {
+		t.Errorf("expected ErrGitHubLinkNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrLegalHold is returned when an operation would destroy or anonymize content
+// under a legal hold or retention period.
+var ErrLegalHold = errors.New("content is under legal hold")
+
+// heldPostCondition returns a SQL condition that is true when the post whose ID
+// postIDExpr evaluates to is held: legal_hold is set or retain_until is ahead.
+func heldPostCondition(postIDExpr string) string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM post_retention held
+		WHERE held.post_id = (%s) AND (held.legal_hold OR held.retain_until > NOW())
+	)`, postIDExpr)
+}
+
+// PostRetentionRepository stores per-post retention and legal hold metadata.
+type PostRetentionRepository struct {
+	pool *Pool
+}
+
+// NewPostRetentionRepository creates a new PostRetentionRepository.
+func NewPostRetentionRepository(pool *Pool) *PostRetentionRepository {
+	return &PostRetentionRepository{pool: pool}
+}
+
+// Get returns a post's retention metadata. Posts without any are returned with
+// no hold. Returns ErrPostNotFound if the post doesn't exist.
+func (r *PostRetentionRepository) Get(ctx context.Context, postID string) (*models.PostRetention, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT p.id, COALESCE(pr.legal_hold, FALSE), COALESCE(pr.hold_reason, ''), pr.retain_until,
+			COALESCE(pr.updated_by, ''), pr.updated_at
+		FROM posts p
+		LEFT JOIN post_retention pr ON pr.post_id = p.id
+		WHERE p.id = $1
+	`, postID)
+	retention, err := scanPostRetention(row)
+	if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		LogQueryError(ctx, "Get", "post_retention", err)
+		return nil, err
+	}
+	return retention, nil
+}
+
+// Set replaces a post's retention metadata. Returns ErrPostNotFound if the post
+// doesn't exist.
+func (r *PostRetentionRepository) Set(ctx context.Context, retention *models.PostRetention) (*models.PostRetention, error) {
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO post_retention (post_id, legal_hold, hold_reason, retain_until, updated_by)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+		ON CONFLICT (post_id) DO UPDATE SET
+			legal_hold = EXCLUDED.legal_hold,
+			hold_reason = EXCLUDED.hold_reason,
+			retain_until = EXCLUDED.retain_until,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING post_id, legal_hold, COALESCE(hold_reason, ''), retain_until, updated_by, updated_at
+	`, retention.PostID, retention.LegalHold, retention.HoldReason, retention.RetainUntil, retention.UpdatedBy)
+	saved, err := scanPostRetention(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if isInvalidUUIDError(err) || (errors.As(err, &pgErr) && pgErr.Code == "23503") {
+			return nil, ErrPostNotFound
+		}
+		LogQueryError(ctx, "Set", "post_retention", err)
+		return nil, err
+	}
+	return saved, nil
+}
+
+// ListHeld returns every post currently under legal hold or within its retention
+// period, most recently updated first.
+func (r *PostRetentionRepository) ListHeld(ctx context.Context) ([]models.PostRetention, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT post_id, legal_hold, COALESCE(hold_reason, ''), retain_until, updated_by, updated_at
+		FROM post_retention
+		WHERE legal_hold OR retain_until > NOW()
+		ORDER BY updated_at DESC
+	`)
+	if err != nil {
+		LogQueryError(ctx, "ListHeld", "post_retention", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	held := []models.PostRetention{}
+	for rows.Next() {
+		retention, err := scanPostRetention(rows)
+		if err != nil {
+			LogQueryError(ctx, "ListHeld.Scan", "post_retention", err)
+			return nil, err
+		}
+		held = append(held, *retention)
+	}
+	return held, rows.Err()
+}
+
+// scanPostRetention scans a retention row and derives whether it is held.
+func scanPostRetention(row pgx.Row) (*models.PostRetention, error) {
+	retention := &models.PostRetention{}
+	if err := row.Scan(
+		&retention.PostID,
+		&retention.LegalHold,
+		&retention.HoldReason,
+		&retention.RetainUntil,
+		&retention.UpdatedBy,
+		&retention.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	retention.Held = retention.IsHeld(time.Now())
+	return retention, nil
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPostRetention_SetGetAndListHeld_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewPostRetentionRepository(pool)
+	agent := createStaleTestAgent(t, pool, "retention")
+	postID := createStaleTestProblem(t, pool, agent.ID, time.Now())
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", postID) })
+
+	retention, err := repo.Get(ctx, postID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if retention.Held || retention.LegalHold {
+		t.Errorf("expected no hold on a new post, got %+v", retention)
+	}
+
+	retention, err = repo.Set(ctx, &models.PostRetention{PostID: postID, LegalHold: true, HoldReason: "Incident 42", UpdatedBy: "admin"})
+	if err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if !retention.Held || retention.HoldReason != "Incident 42" {
+		t.Errorf("expected held post with reason, got %+v", retention)
+	}
+
+	held, err := repo.ListHeld(ctx)
+	if err != nil {
+		t.Fatalf("ListHeld() error = %v", err)
+	}
+	found := false
+	for _, h := range held {
+		found = found || h.PostID == postID
+	}
+	if !found {
+		t.Error("expected held post in ListHeld()")
+	}
+
+	past := time.Now().Add(-time.Hour)
+	retention, err = repo.Set(ctx, &models.PostRetention{PostID: postID, RetainUntil: &past, UpdatedBy: "admin"})
+	if err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if retention.Held {
+		t.Error("expected an expired retention period not to hold the post")
+	}
+
+	if _, err := repo.Set(ctx, &models.PostRetention{PostID: "00000000-0000-0000-0000-000000000001", LegalHold: true, UpdatedBy: "admin"}); !errors.Is(err, ErrPostNotFound) {
+		t.Errorf("Set() on missing post error = %v, want ErrPostNotFound", err)
+	}
+}
+
+func TestPostRetention_StaleContentSkipsHeldPosts_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	staleRepo := NewStaleContentRepository(pool, NewNotificationsRepository(pool))
+	agent := createStaleTestAgent(t, pool, "held")
+	now := time.Now()
+
+	heldProblemID := createStaleTestProblem(t, pool, agent.ID, now.Add(-65*24*time.Hour))
+	staleProblemID := createStaleTestProblem(t, pool, agent.ID, now.Add(-65*24*time.Hour))
+	approachID := createStaleTestApproach(t, pool, staleProblemID, agent.ID, "working", now.Add(-35*24*time.Hour))
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM posts WHERE id IN ($1, $2)", heldProblemID, staleProblemID) })
+
+	future := now.Add(24 * time.Hour)
+	retention := NewPostRetentionRepository(pool)
+	for _, id := range []string{heldProblemID, staleProblemID} {
+		if _, err := retention.Set(ctx, &models.PostRetention{PostID: id, RetainUntil: &future, UpdatedBy: "admin"}); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+
+	if _, err := staleRepo.MarkDormantPosts(ctx, 60*24*time.Hour); err != nil {
+		t.Fatalf("MarkDormantPosts failed: %v", err)
+	}
+	if _, err := staleRepo.AbandonStaleApproaches(ctx, testStalePolicy); err != nil {
+		t.Fatalf("AbandonStaleApproaches failed: %v", err)
+	}
+
+	var postStatus, approachStatus string
+	if err := pool.QueryRow(ctx, `SELECT status FROM posts WHERE id = $1`, heldProblemID).Scan(&postStatus); err != nil {
+		t.Fatalf("failed to query post status: %v", err)
+	}
+	if postStatus != "open" {
+		t.Errorf("held post status = %s, want open", postStatus)
+	}
+	if err := pool.QueryRow(ctx, `SELECT status FROM approaches WHERE id = $1`, approachID).Scan(&approachStatus); err != nil {
+		t.Fatalf("failed to query approach status: %v", err)
+	}
+	if approachStatus != "working" {
+		t.Errorf("approach on held problem status = %s, want working", approachStatus)
+	}
+}
+
+func TestPostRetention_AccountPurgeWaitsForHolds_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+	ts := now.Format("150405.000000")
+	user, err := NewUserRepository(pool).Create(ctx, &models.User{
+		Username:       "lh" + now.Format("0405") + fmt.Sprintf("%06d", now.Nanosecond()/1000)[:4],
+		DisplayName:    "Legal Hold Test User",
+		Email:          "legalhold" + ts + "@example.com",
+		AuthProvider:   models.AuthProviderGitHub,
+		AuthProviderID: "github_legalhold_" + ts,
+		Role:           models.UserRoleUser,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test user: %v", err)
+	}
+	post, err := NewPostRepository(pool).Create(ctx, &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Question preserved for an incident review",
+		Description:  "This question is under legal hold and must keep its author.",
+		PostedByType: models.AuthorTypeHuman,
+		PostedByID:   user.ID,
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test post: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID)
+		pool.Exec(ctx, "DELETE FROM account_deletions WHERE user_id = $1", user.ID)
+		pool.Exec(ctx, "DELETE FROM users WHERE id = $1", user.ID)
+	})
+
+	retention := NewPostRetentionRepository(pool)
+	if _, err := retention.Set(ctx, &models.PostRetention{PostID: post.ID, LegalHold: true, HoldReason: "Incident review", UpdatedBy: "admin"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	deletions := NewAccountDeletionRepository(pool)
+	if _, err := deletions.RequestDeletion(ctx, user.ID, 0); err != nil {
+		t.Fatalf("RequestDeletion() error = %v", err)
+	}
+
+	var postedBy string
+	if err := pool.QueryRow(ctx, "SELECT posted_by_id FROM posts WHERE id = $1", post.ID).Scan(&postedBy); err != nil {
+		t.Fatalf("failed to read post: %v", err)
+	}
+	if postedBy != user.ID {
+		t.Errorf("held post author = %s, want unchanged %s", postedBy, user.ID)
+	}
+
+	if _, err := deletions.PurgeDue(ctx); err != nil {
+		t.Fatalf("PurgeDue() error = %v", err)
+	}
+	var exists bool
+	if err := pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", user.ID).Scan(&exists); err != nil {
+		t.Fatalf("failed to check user: %v", err)
+	}
+	if !exists {
+		t.Fatal("expected the purge to wait while the user authors held content")
+	}
+	if err := NewUserRepository(pool).HardDelete(ctx, user.ID); !errors.Is(err, ErrLegalHold) {
+		t.Errorf("HardDelete() error = %v, want ErrLegalHold", err)
+	}
+
+	if _, err := retention.Set(ctx, &models.PostRetention{PostID: post.ID, UpdatedBy: "admin"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, err := deletions.PurgeDue(ctx); err != nil {
+		t.Fatalf("PurgeDue() error = %v", err)
+	}
+	if err := pool.QueryRow(ctx, "SELECT posted_by_id FROM posts WHERE id = $1", post.ID).Scan(&postedBy); err != nil {
+		t.Fatalf("failed to read post: %v", err)
+	}
+	if postedBy != models.DeletedUserID {
+		t.Errorf("post author after lifting the hold = %s, want deleted-user sentinel", postedBy)
+	}
+}
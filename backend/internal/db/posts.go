@@ -20,8 +20,11 @@ var (
 	ErrDuplicatePostID      = errors.New("post ID already exists")
 	ErrInvalidPostType      = errors.New("invalid post type")
 	ErrInvalidPostStatus    = errors.New("invalid post status")
-	ErrInvalidVoteDirection = errors.New("invalid vote direction: must be 'up' or 'down'")
+	ErrInvalidVoteDirection = errors.New("invalid vote direction: must be 'up', 'down' or 'none'")
 	ErrInvalidVoterType     = errors.New("invalid voter type: must be 'human' or 'agent'")
+	// ErrVersionConflict is returned by conditional updates when the row changed
+	// since the caller read it (its updated_at no longer matches).
+	ErrVersionConflict = errors.New("resource was modified concurrently")
 )
 
 // isInvalidUUIDError checks if an error is a PostgreSQL invalid UUID syntax error.
@@ -74,8 +77,8 @@ func (r *PostRepository) List(ctx context.Context, opts models.PostListOptions)
 	var args []any
 	argNum := 1
 
-	// Always exclude deleted posts
-	conditions = append(conditions, "p.deleted_at IS NULL")
+	// Always exclude deleted posts and posts hidden by community reports
+	conditions = append(conditions, "p.deleted_at IS NULL", "p.hidden_at IS NULL")
 
 	// Exclude hidden statuses (pending_review, rejected, draft) unless IncludeHidden is set
 	if !opts.IncludeHidden {
@@ -83,8 +86,9 @@ func (r *PostRepository) List(ctx context.Context, opts models.PostListOptions)
 	}
 
 	// BART-151: family-scoped visibility — public posts, plus the caller's own family
-	// (ViewerHuman == "" for anonymous/cross-family → public-only).
-	appendVisibilityFilter(&conditions, &args, &argNum, "p", opts.ViewerHuman)
+	// (callerHuman == "" for anonymous/cross-family → public-only).
+	viewerType, viewerID, callerHuman := viewerFromContext(ctx)
+	appendVisibilityFilter(&conditions, &args, &argNum, "p", callerHuman)
 
 	// Filter by type
 	if opts.Type != "" {
@@ -126,19 +130,17 @@ func (r *PostRepository) List(ctx context.Context, opts models.PostListOptions)
 		}
 	}
 
-	whereClause := strings.Join(conditions, " AND ")
-
-	// Build answer count filter condition for main query
-	// This will be added after the LEFT JOIN so ans_cnt.cnt is available
-	var answerCountFilter string
+	// Filter by answer count (trigger-maintained counter column)
 	if opts.HasAnswer != nil {
 		if *opts.HasAnswer {
-			answerCountFilter = " AND COALESCE(ans_cnt.cnt, 0) > 0"
+			conditions = append(conditions, "p.answers_count > 0")
 		} else {
-			answerCountFilter = " AND COALESCE(ans_cnt.cnt, 0) = 0"
+			conditions = append(conditions, "p.answers_count = 0")
 		}
 	}
 
+	whereClause := strings.Join(conditions, " AND ")
+
 	// Calculate pagination
 	page := opts.Page
 	if page < 1 {
@@ -153,27 +155,10 @@ func (r *PostRepository) List(ctx context.Context, opts models.PostListOptions)
 	}
 	offset := (page - 1) * perPage
 
-	// Query for total count (with answer count filter if needed)
-	var countQuery string
-	if answerCountFilter != "" {
-		// When filtering by answer count, we need to include the LEFT JOIN in the count query
-		countQuery = fmt.Sprintf(`
-			SELECT COUNT(*) FROM (
-				SELECT p.id
-				FROM posts p
-				LEFT JOIN (
-					SELECT question_id, COUNT(*) as cnt
-					FROM answers WHERE deleted_at IS NULL
-					GROUP BY question_id
-				) ans_cnt ON ans_cnt.question_id = p.id
-				WHERE %s%s
-			) counted
-		`, whereClause, answerCountFilter)
-	} else {
-		countQuery = fmt.Sprintf(`SELECT COUNT(*) FROM posts p WHERE %s`, whereClause)
-	}
+	// Query for total count
+	countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM posts p WHERE %s`, whereClause)
 	var total int
-	err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total)
+	err := r.pool.ReadQueryRow(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		LogQueryError(ctx, "List.Count", "posts", err)
 		return nil, 0, fmt.Errorf("count query failed: %w", err)
@@ -185,29 +170,29 @@ func (r *PostRepository) List(ctx context.Context, opts models.PostListOptions)
 	case "votes", "top": // "top" is frontend alias for vote-based sorting
 		orderClause = "(p.upvotes - p.downvotes) DESC, p.created_at DESC"
 	case "hot": // trending: engagement-weighted score + recency decay
-		orderClause = "(LOG(GREATEST(ABS(COALESCE(p.upvotes,0) - COALESCE(p.downvotes,0)) + COALESCE(cmt_cnt.cnt,0) * 2 + COALESCE(ans_cnt.cnt,0) * 3 + COALESCE(app_cnt.cnt,0) * 3 + COALESCE(p.view_count,0) * 0.01, 1) + 1) + EXTRACT(EPOCH FROM (p.created_at - (NOW() - INTERVAL '7 days'))) / 45000.0) DESC"
+		orderClause = "(LOG(GREATEST(ABS(COALESCE(p.upvotes,0) - COALESCE(p.downvotes,0)) + p.comments_count * 2 + p.answers_count * 3 + p.approaches_count * 3 + COALESCE(p.view_count,0) * 0.01, 1) + 1) + EXTRACT(EPOCH FROM (p.created_at - (NOW() - INTERVAL '7 days'))) / 45000.0) DESC"
 	case "new": // frontend alias for newest
 		orderClause = "p.created_at DESC"
 	case "approaches":
-		orderClause = "COALESCE(app_cnt.cnt, 0) DESC, p.created_at DESC"
+		orderClause = "p.approaches_count DESC, p.created_at DESC"
 	case "answers":
-		orderClause = "COALESCE(ans_cnt.cnt, 0) DESC, p.created_at DESC"
+		orderClause = "p.answers_count DESC, p.created_at DESC"
 	}
 
 	// Build viewer vote column and JOIN
 	var viewerVoteColumn, viewerVoteJoin string
-	if opts.ViewerType != "" && opts.ViewerID != "" {
+	if viewerType != "" && viewerID != "" {
 		viewerVoteColumn = "v.direction as user_vote_direction"
 		viewerVoteJoin = fmt.Sprintf(`LEFT JOIN votes v ON v.target_type = 'post' AND v.target_id = p.id AND v.voter_type = $%d AND v.voter_id = $%d`, argNum, argNum+1)
-		args = append(args, string(opts.ViewerType), opts.ViewerID)
+		args = append(args, string(viewerType), viewerID)
 		argNum += 2
 	} else {
 		viewerVoteColumn = "NULL::text as user_vote_direction"
 		viewerVoteJoin = ""
 	}
 
-	// Main query with LEFT JOINs for author information and pre-aggregated counts.
-	// Uses LEFT JOIN subqueries instead of correlated subqueries to avoid per-row execution.
+	// Main query with LEFT JOINs for author information.
+	// Child counts come from the trigger-maintained counter columns on posts.
 	query := fmt.Sprintf(`
 		SELECT
 			p.id, p.type, p.title, p.description, p.tags,
@@ -221,40 +206,24 @@ func (r *PostRepository) List(ctx context.Context, opts models.PostListOptions)
 			COALESCE(p.original_description, '') as original_description,
 			COALESCE(u.display_name, ag.display_name, '') as author_display_name,
 			COALESCE(u.avatar_url, ag.avatar_url, '') as author_avatar_url,
-			COALESCE(ans_cnt.cnt, 0) as answers_count,
-			COALESCE(app_cnt.cnt, 0) as approaches_count,
-			COALESCE(cmt_cnt.cnt, 0) as comments_count,
+			p.answers_count,
+			p.approaches_count,
+			p.comments_count,
 			COALESCE(ag.human_id::text, '') as agent_human_id,
 			%s,
 			p.visibility
 		FROM posts p
 		LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
 		LEFT JOIN agents ag ON p.posted_by_type = 'agent' AND p.posted_by_id = ag.id
-		LEFT JOIN (
-			SELECT question_id, COUNT(*) as cnt
-			FROM answers WHERE deleted_at IS NULL
-			GROUP BY question_id
-		) ans_cnt ON ans_cnt.question_id = p.id
-		LEFT JOIN (
-			SELECT problem_id, COUNT(*) as cnt
-			FROM approaches WHERE deleted_at IS NULL
-			GROUP BY problem_id
-		) app_cnt ON app_cnt.problem_id = p.id
-		LEFT JOIN (
-			SELECT target_id, COUNT(*) as cnt
-			FROM comments
-			WHERE target_type = 'post' AND deleted_at IS NULL
-			GROUP BY target_id
-		) cmt_cnt ON cmt_cnt.target_id = p.id
 		%s
-		WHERE %s%s
+		WHERE %s
 		ORDER BY %s
 		LIMIT $%d OFFSET $%d
-	`, viewerVoteColumn, viewerVoteJoin, whereClause, answerCountFilter, orderClause, argNum, argNum+1)
+	`, viewerVoteColumn, viewerVoteJoin, whereClause, orderClause, argNum, argNum+1)
 
 	args = append(args, perPage, offset)
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := r.pool.ReadQuery(ctx, query, args...)
 	if err != nil {
 		LogQueryError(ctx, "List", "posts", err)
 		return nil, 0, fmt.Errorf("list query failed: %w", err)
@@ -430,9 +399,11 @@ func (r *PostRepository) Create(ctx context.Context, post *models.Post) (*models
 			accepted_answer_id, evolved_into,
 			embedding,
 			visibility, owner_human_id,
+			stack_language, stack_exception_type, stack_frames, stack_fingerprint,
+			code_languages, publish_at,
 			created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14::vector, $15, $16, NOW(), NOW())
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14::vector, $15, $16, $17, $18, $19, $20, $21, $22, NOW(), NOW())
 		RETURNING id, type, title, description, tags,
 			posted_by_type, posted_by_id, status,
 			upvotes, downvotes, view_count, success_criteria, weight,
@@ -447,6 +418,12 @@ func (r *PostRepository) Create(ctx context.Context, post *models.Post) (*models
 		status = models.PostStatusDraft
 	}
 
+	// Parse any stack trace and code blocks here so every create path (posts,
+	// problems, questions, ideas, imports) records its crash signature and languages.
+	post.StackTrace = models.ParseStackTrace(post.Description)
+	stack := newStackTraceColumns(post.StackTrace)
+	post.CodeLanguages = models.ExtractCodeLanguages(post.Description)
+
 	row := r.pool.QueryRow(ctx, query,
 		post.Type,
 		post.Title,
@@ -464,28 +441,53 @@ func (r *PostRepository) Create(ctx context.Context, post *models.Post) (*models
 		post.EmbeddingStr,
 		visibilityOrDefault(post.Visibility),
 		post.OwnerHumanID,
+		stack.Language,
+		stack.ExceptionType,
+		stack.Frames,
+		stack.Fingerprint,
+		post.CodeLanguages,
+		post.PublishAt,
 	)
 
-	return r.scanPost(row)
+	created, err := r.scanPost(row)
+	if err != nil {
+		return nil, err
+	}
+	created.StackTrace = post.StackTrace
+	created.CodeLanguages = post.CodeLanguages
+	created.PublishAt = post.PublishAt
+	return created, nil
 }
 
 // FindByID returns a single post by ID with author information.
 // Returns ErrPostNotFound if the post doesn't exist or is soft-deleted.
 // UserVote is always nil (no viewer context). Use FindByIDForViewer for authenticated lookups.
+// May be served by the read replica, so it can lag recent writes; use FindByIDFromPrimary
+// before updates.
 func (r *PostRepository) FindByID(ctx context.Context, id string) (*models.PostWithAuthor, error) {
-	return r.findByIDInternal(ctx, id, "", "", "")
+	return r.findByIDInternal(ctx, id, "", "", "", false)
+}
+
+// FindByIDForViewer returns a single post by ID as seen by the caller in ctx: with
+// their vote included and their family's posts visible. For an anonymous caller it
+// behaves identically to FindByID. Like FindByID it may be served by the read replica.
+func (r *PostRepository) FindByIDForViewer(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	viewerType, viewerID, callerHuman := viewerFromContext(ctx)
+	return r.findByIDInternal(ctx, id, viewerType, viewerID, callerHuman, false)
 }
 
-// FindByIDForViewer returns a single post by ID with the viewer's vote included.
-// If viewerType/viewerID are empty, behaves identically to FindByID.
-func (r *PostRepository) FindByIDForViewer(ctx context.Context, id string, viewerType models.AuthorType, viewerID string, callerHuman string) (*models.PostWithAuthor, error) {
-	return r.findByIDInternal(ctx, id, viewerType, viewerID, callerHuman)
+// FindByIDFromPrimary is FindByIDForViewer read from the primary. Write paths use it
+// so ownership checks, If-Match preconditions and post-write refetches see the latest row.
+func (r *PostRepository) FindByIDFromPrimary(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	viewerType, viewerID, callerHuman := viewerFromContext(ctx)
+	return r.findByIDInternal(ctx, id, viewerType, viewerID, callerHuman, true)
 }
 
-// findByIDInternal is the shared implementation for FindByID and FindByIDForViewer.
-// callerHuman is the caller's family human UUID for visibility scoping ("" = public-only);
-// a family post the caller may not see yields no row → ErrPostNotFound (404, existence hidden).
-func (r *PostRepository) findByIDInternal(ctx context.Context, id string, viewerType models.AuthorType, viewerID string, callerHuman string) (*models.PostWithAuthor, error) {
+// findByIDInternal is the shared implementation for FindByID, FindByIDForViewer and
+// FindByIDFromPrimary. callerHuman is the caller's family human UUID for visibility
+// scoping ("" = public-only); a family post the caller may not see yields no row →
+// ErrPostNotFound (404, existence hidden). primary skips the read replica.
+func (r *PostRepository) findByIDInternal(ctx context.Context, id string, viewerType models.AuthorType, viewerID string, callerHuman string, primary bool) (*models.PostWithAuthor, error) {
 	var viewerVoteColumn, viewerVoteJoin string
 	var args []any
 
@@ -519,39 +521,31 @@ func (r *PostRepository) findByIDInternal(ctx context.Context, id string, viewer
 			COALESCE(p.original_description, '') as original_description,
 			COALESCE(u.display_name, ag.display_name, '') as author_display_name,
 			COALESCE(u.avatar_url, ag.avatar_url, '') as author_avatar_url,
-			COALESCE(ans_cnt.cnt, 0) as answers_count,
-			COALESCE(app_cnt.cnt, 0) as approaches_count,
-			COALESCE(cmt_cnt.cnt, 0) as comments_count,
+			p.answers_count,
+			p.approaches_count,
+			p.comments_count,
 			COALESCE(ag.human_id::text, '') as agent_human_id,
 			%s,
-			p.visibility
+			p.visibility,
+			p.stack_language, p.stack_exception_type, p.stack_frames, p.stack_fingerprint,
+			COALESCE(p.code_languages, '{}')
 		FROM posts p
 		LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
 		LEFT JOIN agents ag ON p.posted_by_type = 'agent' AND p.posted_by_id = ag.id
-		LEFT JOIN (
-			SELECT question_id, COUNT(*) as cnt
-			FROM answers WHERE deleted_at IS NULL
-			GROUP BY question_id
-		) ans_cnt ON ans_cnt.question_id = p.id
-		LEFT JOIN (
-			SELECT problem_id, COUNT(*) as cnt
-			FROM approaches WHERE deleted_at IS NULL
-			GROUP BY problem_id
-		) app_cnt ON app_cnt.problem_id = p.id
-		LEFT JOIN (
-			SELECT target_id, COUNT(*) as cnt
-			FROM comments
-			WHERE target_type = 'post' AND deleted_at IS NULL
-			GROUP BY target_id
-		) cmt_cnt ON cmt_cnt.target_id = p.id
 		%s
 		WHERE p.id = $1 AND p.deleted_at IS NULL AND %s
 	`, viewerVoteColumn, viewerVoteJoin, visClause)
 
-	row := r.pool.QueryRow(ctx, query, args...)
+	var row pgx.Row
+	if primary {
+		row = r.pool.QueryRow(ctx, query, args...)
+	} else {
+		row = r.pool.ReadQueryRow(ctx, query, args...)
+	}
 
 	var post models.PostWithAuthor
 	var authorDisplayName, authorAvatarURL string
+	var stack stackTraceColumns
 
 	err := row.Scan(
 		&post.ID,
@@ -585,6 +579,11 @@ func (r *PostRepository) findByIDInternal(ctx context.Context, id string, viewer
 		&post.AgentHumanID,
 		&post.UserVote,
 		&post.Visibility,
+		&stack.Language,
+		&stack.ExceptionType,
+		&stack.Frames,
+		&stack.Fingerprint,
+		&post.CodeLanguages,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -610,6 +609,7 @@ func (r *PostRepository) findByIDInternal(ctx context.Context, id string, viewer
 
 	// Compute vote score
 	post.VoteScore = post.Upvotes - post.Downvotes
+	post.StackTrace = stack.toModel()
 
 	return &post, nil
 }
@@ -618,7 +618,19 @@ func (r *PostRepository) findByIDInternal(ctx context.Context, id string, viewer
 // Only mutable fields are updated: title, description, tags, status,
 // success_criteria, weight, accepted_answer_id, evolved_into.
 // Returns ErrPostNotFound if the post doesn't exist or is soft-deleted.
+// When post.UpdatedAt is set it is treated as the expected version: the update only
+// applies if the row still has that updated_at, otherwise ErrVersionConflict is returned.
+// A zero UpdatedAt updates unconditionally.
 func (r *PostRepository) Update(ctx context.Context, post *models.Post) (*models.Post, error) {
+	var expectedUpdatedAt *time.Time
+	if !post.UpdatedAt.IsZero() {
+		expectedUpdatedAt = &post.UpdatedAt
+	}
+
+	post.StackTrace = models.ParseStackTrace(post.Description)
+	stack := newStackTraceColumns(post.StackTrace)
+	post.CodeLanguages = models.ExtractCodeLanguages(post.Description)
+
 	query := `
 		UPDATE posts
 		SET
@@ -631,8 +643,14 @@ func (r *PostRepository) Update(ctx context.Context, post *models.Post) (*models
 			accepted_answer_id = $8,
 			evolved_into = $9,
 			embedding = COALESCE($10::vector, embedding),
+			stack_language = $12,
+			stack_exception_type = $13,
+			stack_frames = $14,
+			stack_fingerprint = $15,
+			code_languages = $16,
 			updated_at = NOW()
 		WHERE id = $1 AND deleted_at IS NULL
+		AND ($11::timestamptz IS NULL OR updated_at = $11)
 		RETURNING id, type, title, description, tags,
 			posted_by_type, posted_by_id, status,
 			upvotes, downvotes, view_count, success_criteria, weight,
@@ -652,9 +670,32 @@ func (r *PostRepository) Update(ctx context.Context, post *models.Post) (*models
 		post.AcceptedAnswerID,
 		post.EvolvedInto,
 		post.EmbeddingStr,
+		expectedUpdatedAt,
+		stack.Language,
+		stack.ExceptionType,
+		stack.Frames,
+		stack.Fingerprint,
+		post.CodeLanguages,
 	)
 
-	return r.scanPost(row)
+	updated, err := r.scanPost(row)
+	if errors.Is(err, ErrPostNotFound) && expectedUpdatedAt != nil && r.postExists(ctx, post.ID) {
+		return nil, ErrVersionConflict
+	}
+	if updated != nil {
+		updated.StackTrace = post.StackTrace
+		updated.CodeLanguages = post.CodeLanguages
+	}
+	return updated, err
+}
+
+// postExists reports whether a non-deleted post with the given ID exists.
+func (r *PostRepository) postExists(ctx context.Context, id string) bool {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL)
+	`, id).Scan(&exists)
+	return err == nil && exists
 }
 
 // Delete performs a soft delete on a post by setting deleted_at.
@@ -684,14 +725,15 @@ func (r *PostRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// Vote adds or updates a vote on a post.
+// Vote adds, updates or retracts a vote on a post.
 // If the voter hasn't voted, it inserts a new vote.
 // If the voter has voted with a different direction, it updates the vote and adjusts counts.
 // If the voter has voted with the same direction, it's a no-op.
+// Direction "none" removes the voter's vote and decrements the counts (no-op if not voted).
 // Per SPEC.md Part 2.9: One vote per entity per target.
 func (r *PostRepository) Vote(ctx context.Context, postID, voterType, voterID, direction string) error {
 	// Validate direction
-	if direction != "up" && direction != "down" {
+	if direction != "up" && direction != "down" && direction != VoteDirectionNone {
 		return ErrInvalidVoteDirection
 	}
 
@@ -719,6 +761,10 @@ func (r *PostRepository) Vote(ctx context.Context, postID, voterType, voterID, d
 		return ErrPostNotFound
 	}
 
+	if direction == VoteDirectionNone {
+		return retractVote(ctx, r.pool, "post", "posts", postID, voterType, voterID)
+	}
+
 	// Check for existing vote
 	var existingDirection string
 	err = r.pool.QueryRow(ctx,
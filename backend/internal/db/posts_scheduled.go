@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ListDueScheduledPosts returns scheduled drafts whose publish_at has passed,
+// earliest first.
+func (r *PostRepository) ListDueScheduledPosts(ctx context.Context, limit int) ([]*models.Post, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, type, title, description, tags, posted_by_type, posted_by_id,
+		       status, visibility, publish_at
+		FROM posts
+		WHERE status = 'draft'
+		  AND publish_at IS NOT NULL
+		  AND publish_at <= NOW()
+		  AND deleted_at IS NULL
+		ORDER BY publish_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListDueScheduledPosts", "posts", err)
+		return nil, fmt.Errorf("list due scheduled posts failed: %w", err)
+	}
+	defer rows.Close()
+
+	var posts []*models.Post
+	for rows.Next() {
+		post := &models.Post{}
+		if err := rows.Scan(
+			&post.ID,
+			&post.Type,
+			&post.Title,
+			&post.Description,
+			&post.Tags,
+			&post.PostedByType,
+			&post.PostedByID,
+			&post.Status,
+			&post.Visibility,
+			&post.PublishAt,
+		); err != nil {
+			LogQueryError(ctx, "ListDueScheduledPosts.Scan", "posts", err)
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+// PublishScheduledPost moves a due scheduled draft to status and clears its
+// publish_at. created_at is reset to the publish time so the post is listed as new.
+// Returns false if the post is no longer a due scheduled draft (it was deleted,
+// already published or its status was changed by its author).
+func (r *PostRepository) PublishScheduledPost(ctx context.Context, postID string, status models.PostStatus) (bool, error) {
+	result, err := r.pool.Exec(ctx, `
+		UPDATE posts
+		SET status = $2, publish_at = NULL, created_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+		  AND status = 'draft'
+		  AND publish_at IS NOT NULL
+		  AND publish_at <= NOW()
+		  AND deleted_at IS NULL
+	`, postID, status)
+	if err != nil {
+		LogQueryError(ctx, "PublishScheduledPost", "posts", err)
+		return false, fmt.Errorf("publish scheduled post failed: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
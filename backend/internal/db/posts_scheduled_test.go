@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPostRepository_ScheduledPublish_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewPostRepository(pool)
+	agent := createStaleTestAgent(t, pool, "scheduled")
+
+	create := func(publishAt time.Time) string {
+		t.Helper()
+		post, err := repo.Create(ctx, &models.Post{
+			Type:         models.PostTypeProblem,
+			Title:        "Scheduled postmortem write-up",
+			Description:  "A postmortem prepared ahead of the incident review, published on a schedule.",
+			PostedByType: models.AuthorTypeAgent,
+			PostedByID:   agent.ID,
+			Status:       models.PostStatusDraft,
+			PublishAt:    &publishAt,
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID) })
+		return post.ID
+	}
+	futureID := create(time.Now().Add(time.Hour))
+	dueID := create(time.Now().Add(time.Hour))
+	if _, err := pool.Exec(ctx, "UPDATE posts SET publish_at = NOW() - INTERVAL '1 minute' WHERE id = $1", dueID); err != nil {
+		t.Fatalf("failed to make post due: %v", err)
+	}
+
+	due, err := repo.ListDueScheduledPosts(ctx, 100)
+	if err != nil {
+		t.Fatalf("ListDueScheduledPosts() error = %v", err)
+	}
+	foundDue, foundFuture := false, false
+	for _, p := range due {
+		foundDue = foundDue || p.ID == dueID
+		foundFuture = foundFuture || p.ID == futureID
+	}
+	if !foundDue || foundFuture {
+		t.Errorf("expected only the due post listed (due=%v future=%v)", foundDue, foundFuture)
+	}
+
+	if ok, err := repo.PublishScheduledPost(ctx, futureID, models.PostStatusPendingReview); err != nil || ok {
+		t.Errorf("expected future post not to publish, got ok=%v err=%v", ok, err)
+	}
+	if ok, err := repo.PublishScheduledPost(ctx, dueID, models.PostStatusPendingReview); err != nil || !ok {
+		t.Fatalf("expected due post to publish, got ok=%v err=%v", ok, err)
+	}
+	if ok, _ := repo.PublishScheduledPost(ctx, dueID, models.PostStatusPendingReview); ok {
+		t.Error("expected a published post not to publish twice")
+	}
+
+	var status string
+	var publishAt *time.Time
+	if err := pool.QueryRow(ctx, "SELECT status, publish_at FROM posts WHERE id = $1", dueID).Scan(&status, &publishAt); err != nil {
+		t.Fatalf("failed to read post: %v", err)
+	}
+	if status != string(models.PostStatusPendingReview) || publishAt != nil {
+		t.Errorf("expected pending_review with publish_at cleared, got %s %v", status, publishAt)
+	}
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// stackTraceColumns holds the nullable stack_* columns of a post.
+type stackTraceColumns struct {
+	Language      *string
+	ExceptionType *string
+	Frames        []string
+	Fingerprint   *string
+}
+
+// newStackTraceColumns maps a parsed stack trace to column values; nil clears them.
+func newStackTraceColumns(st *models.StackTrace) stackTraceColumns {
+	if st == nil {
+		return stackTraceColumns{}
+	}
+	cols := stackTraceColumns{
+		Language:    &st.Language,
+		Frames:      st.Frames,
+		Fingerprint: &st.Fingerprint,
+	}
+	if st.ExceptionType != "" {
+		cols.ExceptionType = &st.ExceptionType
+	}
+	return cols
+}
+
+// toModel returns the stack trace, or nil when the post has none.
+func (c stackTraceColumns) toModel() *models.StackTrace {
+	if c.Fingerprint == nil || c.Language == nil {
+		return nil
+	}
+	st := &models.StackTrace{Language: *c.Language, Frames: c.Frames, Fingerprint: *c.Fingerprint}
+	if c.ExceptionType != nil {
+		st.ExceptionType = *c.ExceptionType
+	}
+	if st.Frames == nil {
+		st.Frames = []string{}
+	}
+	return st
+}
+
+// FindCrashDuplicates returns visible posts with the given crash fingerprint,
+// excluding excludeID. Solved and answered posts come first, then oldest first.
+// The caller in ctx scopes family visibility (anonymous = public only).
+func (r *PostRepository) FindCrashDuplicates(ctx context.Context, fingerprint, excludeID string, limit int) ([]models.CrashDuplicate, error) {
+	_, _, callerHuman := viewerFromContext(ctx)
+	args := []any{fingerprint, excludeID, limit}
+	argNum := 4
+	visClause := searchVisibilityClause("p", callerHuman, &args, &argNum)
+
+	rows, err := r.pool.Query(ctx, fmt.Sprintf(`
+		SELECT p.id, p.type, p.title, p.status, p.created_at
+		FROM posts p
+		WHERE p.stack_fingerprint = $1
+		  AND p.id::text <> $2
+		  AND p.deleted_at IS NULL
+		  AND p.status NOT IN ('draft', 'pending_review', 'rejected')
+		  AND %s
+		ORDER BY (p.status IN ('solved', 'answered')) DESC, p.created_at
+		LIMIT $3`, visClause), args...)
+	if err != nil {
+		LogQueryError(ctx, "FindCrashDuplicates", "posts", err)
+		return nil, fmt.Errorf("find crash duplicates: %w", err)
+	}
+	defer rows.Close()
+
+	duplicates := []models.CrashDuplicate{}
+	for rows.Next() {
+		var d models.CrashDuplicate
+		if err := rows.Scan(&d.ID, &d.Type, &d.Title, &d.Status, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan crash duplicate: %w", err)
+		}
+		duplicates = append(duplicates, d)
+	}
+	return duplicates, rows.Err()
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPostRepository_StackTraceDuplicates(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewPostRepository(pool)
+
+	// A unique function name keeps the fingerprint from matching other test data.
+	fn := "main.crash" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	description := "Crash on start:\npanic: boom\n\ngoroutine 1 [running]:\n" +
+		fn + "()\n\t/app/main.go:5 +0x1d\nmain.main()\n\t/app/main.go:9 +0x25\n"
+
+	var ids []string
+	defer func() {
+		for _, id := range ids {
+			_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", id)
+		}
+	}()
+	create := func(status models.PostStatus) *models.Post {
+		t.Helper()
+		post, err := repo.Create(ctx, &models.Post{
+			Type:         models.PostTypeProblem,
+			Title:        "Stack trace duplicate test",
+			Description:  description,
+			PostedByType: models.AuthorTypeAgent,
+			PostedByID:   "test_agent_stack_trace",
+			Status:       status,
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		ids = append(ids, post.ID)
+		return post
+	}
+
+	first := create(models.PostStatusOpen)
+	if first.StackTrace == nil || first.StackTrace.Frames[0] != fn {
+		t.Fatalf("expected parsed stack trace on create, got %+v", first.StackTrace)
+	}
+	create(models.PostStatusPendingReview) // not visible yet, never a duplicate
+	second := create(models.PostStatusOpen)
+
+	found, err := repo.FindByID(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.StackTrace == nil || found.StackTrace.Fingerprint != first.StackTrace.Fingerprint {
+		t.Errorf("expected stack trace to round-trip, got %+v", found.StackTrace)
+	}
+
+	duplicates, err := repo.FindCrashDuplicates(ctx, second.StackTrace.Fingerprint, second.ID, 5)
+	if err != nil {
+		t.Fatalf("FindCrashDuplicates() error = %v", err)
+	}
+	if len(duplicates) != 1 || duplicates[0].ID != first.ID {
+		t.Errorf("expected only the first post as a duplicate, got %+v", duplicates)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/jackc/pgx/v5/pgconn"
 )
@@ -1830,6 +1831,61 @@ func TestPostRepository_Vote_SameVoteTwice(t *testing.T) {
 	}
 }
 
+func TestPostRepository_Vote_Retract(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewPostRepository(pool)
+	ctx := context.Background()
+
+	createdPost, err := repo.Create(ctx, &models.Post{
+		Type:         models.PostTypeProblem,
+		Title:        "Post for Retract Vote Test",
+		Description:  "Testing vote retraction",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_vote",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM votes WHERE target_id = $1", createdPost.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", createdPost.ID)
+	}()
+
+	if err := repo.Vote(ctx, createdPost.ID, "agent", "voter_agent_retract", "down"); err != nil {
+		t.Fatalf("Vote() error = %v", err)
+	}
+	if err := repo.Vote(ctx, createdPost.ID, "agent", "voter_agent_retract", VoteDirectionNone); err != nil {
+		t.Fatalf("Vote(none) error = %v", err)
+	}
+	// Retracting again is a no-op
+	if err := repo.Vote(ctx, createdPost.ID, "agent", "voter_agent_retract", VoteDirectionNone); err != nil {
+		t.Fatalf("second Vote(none) error = %v", err)
+	}
+
+	updatedPost, err := repo.FindByID(ctx, createdPost.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if updatedPost.Upvotes != 0 || updatedPost.Downvotes != 0 {
+		t.Errorf("expected 0/0 votes after retraction, got %d/%d", updatedPost.Upvotes, updatedPost.Downvotes)
+	}
+
+	vote, err := repo.GetUserVote(ctx, createdPost.ID, "agent", "voter_agent_retract")
+	if err != nil {
+		t.Fatalf("GetUserVote() error = %v", err)
+	}
+	if vote != nil {
+		t.Errorf("expected no vote after retraction, got %q", *vote)
+	}
+}
+
 func TestPostRepository_Vote_PostNotFound(t *testing.T) {
 	pool := getTestPool(t)
 	if pool == nil {
@@ -3577,11 +3633,10 @@ func TestPostRepository_List_WithViewerVote(t *testing.T) {
 	}
 
 	// List with viewer info — should show user_vote = "up"
-	posts, _, err := repo.List(ctx, models.PostListOptions{
-		Page:       1,
-		PerPage:    100,
-		ViewerType: models.AuthorTypeAgent,
-		ViewerID:   "viewer_vote_agent_a",
+	viewerCtx := auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeAgent, ID: "viewer_vote_agent_a"})
+	posts, _, err := repo.List(viewerCtx, models.PostListOptions{
+		Page:    1,
+		PerPage: 100,
 	})
 	if err != nil {
 		t.Fatalf("List() with viewer error = %v", err)
@@ -3628,11 +3683,10 @@ func TestPostRepository_List_WithViewerVote(t *testing.T) {
 	}
 
 	// List with a different viewer — should have nil UserVote
-	postsOther, _, err := repo.List(ctx, models.PostListOptions{
-		Page:       1,
-		PerPage:    100,
-		ViewerType: models.AuthorTypeAgent,
-		ViewerID:   "viewer_vote_agent_b",
+	otherCtx := auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeAgent, ID: "viewer_vote_agent_b"})
+	postsOther, _, err := repo.List(otherCtx, models.PostListOptions{
+		Page:    1,
+		PerPage: 100,
 	})
 	if err != nil {
 		t.Fatalf("List() other viewer error = %v", err)
@@ -3690,7 +3744,8 @@ func TestPostRepository_FindByID_WithViewerVote(t *testing.T) {
 	}
 
 	// FindByID with viewer info — should show user_vote = "down"
-	found, err := repo.FindByIDForViewer(ctx, createdPost.ID, models.AuthorTypeHuman, "findbyid_voter_human", "")
+	viewerCtx := auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeHuman, ID: "findbyid_voter_human"})
+	found, err := repo.FindByIDForViewer(viewerCtx, createdPost.ID)
 	if err != nil {
 		t.Fatalf("FindByIDForViewer() error = %v", err)
 	}
@@ -3711,7 +3766,8 @@ func TestPostRepository_FindByID_WithViewerVote(t *testing.T) {
 	}
 
 	// FindByID with non-voter — should have nil UserVote
-	foundOther, err := repo.FindByIDForViewer(ctx, createdPost.ID, models.AuthorTypeAgent, "some_other_agent", "")
+	otherCtx := auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeAgent, ID: "some_other_agent"})
+	foundOther, err := repo.FindByIDForViewer(otherCtx, createdPost.ID)
 	if err != nil {
 		t.Fatalf("FindByIDForViewer() other error = %v", err)
 	}
@@ -3758,3 +3814,51 @@ func TestPostRepository_FindByID_OriginalLanguage(t *testing.T) {
 		t.Errorf("expected original_language 'pt', got %q", post.OriginalLanguage)
 	}
 }
+
+func TestPostRepository_Update_VersionConflict(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewPostRepository(pool)
+	ctx := context.Background()
+
+	createdPost, err := repo.Create(ctx, &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Concurrent edit test",
+		Description:  "Two editors load the same version",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "test_agent_conflict",
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", createdPost.ID)
+	}()
+
+	// Both editors start from the same version.
+	first := *createdPost
+	second := *createdPost
+
+	first.Title = "First editor wins"
+	if _, err := repo.Update(ctx, &first); err != nil {
+		t.Fatalf("first Update() error = %v", err)
+	}
+
+	second.Title = "Second editor is stale"
+	if _, err := repo.Update(ctx, &second); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+
+	fetched, err := repo.FindByID(ctx, createdPost.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if fetched.Title != "First editor wins" {
+		t.Errorf("expected first edit to survive, got %q", fetched.Title)
+	}
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// PrincipalBlockRepository stores the humans and agents each principal has
+// blocked or muted.
+type PrincipalBlockRepository struct {
+	pool *Pool
+}
+
+// NewPrincipalBlockRepository creates a new PrincipalBlockRepository.
+func NewPrincipalBlockRepository(pool *Pool) *PrincipalBlockRepository {
+	return &PrincipalBlockRepository{pool: pool}
+}
+
+// blockedAuthorCondition is a WHERE condition that drops rows whose author
+// (typeCol, idCol) the viewer bound as $viewerArg and $viewerArg+1 has blocked.
+// An empty viewer matches no block, so anonymous callers see everything.
+func blockedAuthorCondition(typeCol, idCol string, viewerArg int) string {
+	return fmt.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM principal_blocks pb
+		WHERE pb.blocker_type = $%d AND pb.blocker_id = $%d AND pb.mode = 'block'
+		  AND pb.blocked_type = %s AND pb.blocked_id = %s)`, viewerArg, viewerArg+1, typeCol, idCol)
+}
+
+// Block blocks or mutes the principal, or changes the mode of an existing
+// block. Returns ErrNotFound if the blocked human or agent doesn't exist.
+func (r *PrincipalBlockRepository) Block(ctx context.Context, blockerType, blockerID string, blockedType models.AuthorType, blockedID string, mode models.BlockMode) (*models.PrincipalBlock, error) {
+	block := models.PrincipalBlock{BlockedType: blockedType, BlockedID: blockedID, Mode: mode}
+	err := r.pool.QueryRow(ctx, `
+		WITH target AS (
+			SELECT u.display_name FROM users u
+			WHERE $3 = 'human' AND u.id::text = $4 AND u.deleted_at IS NULL
+			UNION ALL
+			SELECT a.display_name FROM agents a
+			WHERE $3 = 'agent' AND a.id = $4 AND a.deleted_at IS NULL
+		), saved AS (
+			INSERT INTO principal_blocks (blocker_type, blocker_id, blocked_type, blocked_id, mode)
+			SELECT $1, $2, $3, $4, $5 FROM target
+			ON CONFLICT (blocker_type, blocker_id, blocked_type, blocked_id) DO UPDATE SET mode = EXCLUDED.mode
+			RETURNING created_at
+		)
+		SELECT target.display_name, saved.created_at FROM target, saved
+	`, blockerType, blockerID, string(blockedType), blockedID, string(mode)).Scan(&block.DisplayName, &block.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		LogQueryError(ctx, "Block", "principal_blocks", err)
+		return nil, fmt.Errorf("block principal: %w", err)
+	}
+	return &block, nil
+}
+
+// Unblock removes a block or mute. Returns ErrNotFound if there was none.
+func (r *PrincipalBlockRepository) Unblock(ctx context.Context, blockerType, blockerID string, blockedType models.AuthorType, blockedID string) error {
+	result, err := r.pool.Exec(ctx, `
+		DELETE FROM principal_blocks
+		WHERE blocker_type = $1 AND blocker_id = $2 AND blocked_type = $3 AND blocked_id = $4
+	`, blockerType, blockerID, string(blockedType), blockedID)
+	if err != nil {
+		LogQueryError(ctx, "Unblock", "principal_blocks", err)
+		return fmt.Errorf("unblock principal: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns a page of the blocker's blocks and mutes, newest first, with the total.
+func (r *PrincipalBlockRepository) List(ctx context.Context, blockerType, blockerID string, page, perPage int) ([]models.PrincipalBlock, int, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT pb.blocked_type, pb.blocked_id,
+		       COALESCE(CASE WHEN pb.blocked_type = 'human' THEN u.display_name ELSE a.display_name END, pb.blocked_id),
+		       pb.mode, pb.created_at, COUNT(*) OVER ()
+		FROM principal_blocks pb
+		LEFT JOIN users u ON pb.blocked_type = 'human' AND u.id::text = pb.blocked_id
+		LEFT JOIN agents a ON pb.blocked_type = 'agent' AND a.id = pb.blocked_id
+		WHERE pb.blocker_type = $1 AND pb.blocker_id = $2
+		ORDER BY pb.created_at DESC, pb.blocked_id
+		LIMIT $3 OFFSET $4
+	`, blockerType, blockerID, perPage, (page-1)*perPage)
+	if err != nil {
+		LogQueryError(ctx, "List", "principal_blocks", err)
+		return nil, 0, fmt.Errorf("list blocks: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := []models.PrincipalBlock{}
+	total := 0
+	for rows.Next() {
+		var b models.PrincipalBlock
+		if err := rows.Scan(&b.BlockedType, &b.BlockedID, &b.DisplayName, &b.Mode, &b.CreatedAt, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan block: %w", err)
+		}
+		blocks = append(blocks, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list blocks: %w", err)
+	}
+	if len(blocks) == 0 && page > 1 {
+		if err := r.pool.QueryRow(ctx, `
+			SELECT COUNT(*) FROM principal_blocks WHERE blocker_type = $1 AND blocker_id = $2
+		`, blockerType, blockerID).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("count blocks: %w", err)
+		}
+	}
+	return blocks, total, nil
+}
+
+// BlockMode returns how the blocker has blocked the principal: "block",
+// "mute", or "" when they haven't.
+func (r *PrincipalBlockRepository) BlockMode(ctx context.Context, blockerType, blockerID, blockedType, blockedID string) (string, error) {
+	var mode string
+	err := r.pool.QueryRow(ctx, `
+		SELECT mode FROM principal_blocks
+		WHERE blocker_type = $1 AND blocker_id = $2 AND blocked_type = $3 AND blocked_id = $4
+	`, blockerType, blockerID, blockedType, blockedID).Scan(&mode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		LogQueryError(ctx, "BlockMode", "principal_blocks", err)
+		return "", fmt.Errorf("get block mode: %w", err)
+	}
+	return mode, nil
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPrincipalBlockRepository_HidesBlockedAnswers(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewPrincipalBlockRepository(pool)
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano()%1e9)
+	viewerID := "blk_viewer_" + suffix
+	agentIDs := []string{"blk_loud_" + suffix, "blk_calm_" + suffix}
+	for _, id := range append([]string{viewerID}, agentIDs...) {
+		if _, err := pool.Exec(ctx, `INSERT INTO agents (id, display_name, api_key_hash, status) VALUES ($1, $1, 'hash', 'active')`, id); err != nil {
+			t.Fatalf("insert agent: %v", err)
+		}
+		defer pool.Exec(ctx, `DELETE FROM agents WHERE id = $1`, id)
+	}
+	defer pool.Exec(ctx, `DELETE FROM principal_blocks WHERE blocker_id = $1`, viewerID)
+
+	var questionID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Block test question', 'Description', 'agent', $1, 'open')
+		RETURNING id::text
+	`, viewerID).Scan(&questionID); err != nil {
+		t.Fatalf("insert question: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM posts WHERE id = $1`, questionID)
+	for _, id := range agentIDs {
+		if _, err := pool.Exec(ctx, `INSERT INTO answers (question_id, author_type, author_id, content) VALUES ($1, 'agent', $2, 'An answer')`, questionID, id); err != nil {
+			t.Fatalf("insert answer: %v", err)
+		}
+	}
+	defer pool.Exec(ctx, `DELETE FROM answers WHERE question_id = $1`, questionID)
+
+	if _, err := repo.Block(ctx, "agent", viewerID, models.AuthorTypeAgent, "blk_missing_"+suffix, models.BlockModeBlock); !errors.Is(err, ErrNotFound) {
+		t.Errorf("blocking an unknown agent err = %v, want ErrNotFound", err)
+	}
+	block, err := repo.Block(ctx, "agent", viewerID, models.AuthorTypeAgent, agentIDs[0], models.BlockModeBlock)
+	if err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	if block.DisplayName != agentIDs[0] {
+		t.Errorf("DisplayName = %q, want %q", block.DisplayName, agentIDs[0])
+	}
+
+	answers := NewAnswersRepository(pool)
+	viewerCtx := auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeAgent, ID: viewerID})
+	listed, total, err := answers.ListAnswers(viewerCtx, questionID, models.AnswerListOptions{Page: 1, PerPage: 20})
+	if err != nil {
+		t.Fatalf("ListAnswers() error = %v", err)
+	}
+	if total != 1 || len(listed) != 1 || listed[0].AuthorID != agentIDs[1] {
+		t.Errorf("ListAnswers() as blocker = %d answers (total %d), want only %s", len(listed), total, agentIDs[1])
+	}
+	if _, total, _ := answers.ListAnswers(ctx, questionID, models.AnswerListOptions{Page: 1, PerPage: 20}); total != 2 {
+		t.Errorf("ListAnswers() anonymously total = %d, want 2", total)
+	}
+
+	// Muting keeps answers visible
+	if _, err := repo.Block(ctx, "agent", viewerID, models.AuthorTypeAgent, agentIDs[0], models.BlockModeMute); err != nil {
+		t.Fatalf("Block(mute) error = %v", err)
+	}
+	if mode, _ := repo.BlockMode(ctx, "agent", viewerID, "agent", agentIDs[0]); mode != "mute" {
+		t.Errorf("BlockMode() = %q, want mute", mode)
+	}
+	if _, total, _ := answers.ListAnswers(viewerCtx, questionID, models.AnswerListOptions{Page: 1, PerPage: 20}); total != 2 {
+		t.Errorf("ListAnswers() as muter total = %d, want 2", total)
+	}
+
+	blocks, total, err := repo.List(ctx, "agent", viewerID, 1, 20)
+	if err != nil || total != 1 || blocks[0].Mode != models.BlockModeMute {
+		t.Fatalf("List() = %v, %d, %v; want one mute", blocks, total, err)
+	}
+	if err := repo.Unblock(ctx, "agent", viewerID, models.AuthorTypeAgent, agentIDs[0]); err != nil {
+		t.Fatalf("Unblock() error = %v", err)
+	}
+	if err := repo.Unblock(ctx, "agent", viewerID, models.AuthorTypeAgent, agentIDs[0]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Unblock() err = %v, want ErrNotFound", err)
+	}
+}
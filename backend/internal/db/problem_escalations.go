@@ -0,0 +1,92 @@
+// Package db provides database access for Solvr.
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrProblemAlreadyStuck is returned when a problem already has an open escalation.
+var ErrProblemAlreadyStuck = errors.New("problem is already marked as stuck")
+
+// ProblemEscalationsRepository handles database operations for stuck-problem escalations.
+type ProblemEscalationsRepository struct {
+	pool *Pool
+}
+
+// NewProblemEscalationsRepository creates a new ProblemEscalationsRepository.
+func NewProblemEscalationsRepository(pool *Pool) *ProblemEscalationsRepository {
+	return &ProblemEscalationsRepository{pool: pool}
+}
+
+// CreateEscalation opens an escalation for a problem.
+// Returns ErrProblemAlreadyStuck if the problem already has an open escalation.
+func (r *ProblemEscalationsRepository) CreateEscalation(ctx context.Context, e *models.ProblemEscalation) (*models.ProblemEscalation, error) {
+	query := `
+		INSERT INTO problem_escalations (problem_id, summary, escalated_by_type, escalated_by_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, problem_id, summary, escalated_by_type, escalated_by_id, created_at, resolved_at
+	`
+
+	var created models.ProblemEscalation
+	err := r.pool.QueryRow(ctx, query, e.ProblemID, e.Summary, e.EscalatedByType, e.EscalatedByID).Scan(
+		&created.ID,
+		&created.ProblemID,
+		&created.Summary,
+		&created.EscalatedByType,
+		&created.EscalatedByID,
+		&created.CreatedAt,
+		&created.ResolvedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrProblemAlreadyStuck
+		}
+		LogQueryError(ctx, "CreateEscalation", "problem_escalations", err)
+		return nil, fmt.Errorf("failed to create escalation: %w", err)
+	}
+
+	return &created, nil
+}
+
+// FindTagSubscribers returns IDs of active agents whose specialties overlap tags,
+// most reputable first. excludeAgentID (the escalating agent) is left out.
+func (r *ProblemEscalationsRepository) FindTagSubscribers(ctx context.Context, tags []string, excludeAgentID string, limit int) ([]string, error) {
+	if len(tags) == 0 {
+		return []string{}, nil
+	}
+
+	query := `
+		SELECT id
+		FROM agents
+		WHERE specialties && $1
+		AND status = 'active'
+		AND deleted_at IS NULL
+		AND id <> $2
+		ORDER BY reputation DESC, id
+		LIMIT $3
+	`
+
+	rows, err := r.pool.Query(ctx, query, tags, excludeAgentID, limit)
+	if err != nil {
+		LogQueryError(ctx, "FindTagSubscribers", "agents", err)
+		return nil, fmt.Errorf("failed to find tag subscribers: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan agent id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
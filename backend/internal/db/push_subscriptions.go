@@ -0,0 +1,172 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// PushSubscriptionRepository stores browsers' Web Push subscriptions and
+// tracks which notifications were pushed to them.
+type PushSubscriptionRepository struct {
+	pool *Pool
+}
+
+// NewPushSubscriptionRepository creates a new PushSubscriptionRepository.
+func NewPushSubscriptionRepository(pool *Pool) *PushSubscriptionRepository {
+	return &PushSubscriptionRepository{pool: pool}
+}
+
+const pushSubscriptionColumns = `id::text, user_id::text, endpoint, p256dh, auth, COALESCE(user_agent, ''), created_at, last_pushed_at`
+
+func scanPushSubscription(row interface{ Scan(dest ...any) error }) (*models.PushSubscription, error) {
+	var s models.PushSubscription
+	err := row.Scan(&s.ID, &s.UserID, &s.Endpoint, &s.P256dh, &s.Auth, &s.UserAgent, &s.CreatedAt, &s.LastPushedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SaveSubscription stores a subscription for sub.UserID. Subscribing an
+// endpoint again (the browser refreshed its keys, or another user signed in
+// on it) replaces the old row. Beyond models.MaxPushSubscriptionsPerUser the
+// user's oldest subscriptions are dropped.
+func (r *PushSubscriptionRepository) SaveSubscription(ctx context.Context, sub *models.PushSubscription) (*models.PushSubscription, error) {
+	var saved *models.PushSubscription
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var err error
+		saved, err = scanPushSubscription(tx.QueryRow(ctx, `
+			INSERT INTO push_subscriptions (user_id, endpoint, p256dh, auth, user_agent)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+			ON CONFLICT (endpoint) DO UPDATE SET
+				user_id = EXCLUDED.user_id, p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth,
+				user_agent = EXCLUDED.user_agent, created_at = NOW(), last_pushed_at = NULL
+			RETURNING `+pushSubscriptionColumns,
+			sub.UserID, sub.Endpoint, sub.P256dh, sub.Auth, sub.UserAgent))
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			DELETE FROM push_subscriptions
+			WHERE user_id = $1 AND id NOT IN (
+				SELECT id FROM push_subscriptions WHERE user_id = $1
+				ORDER BY created_at DESC LIMIT $2
+			)`, sub.UserID, models.MaxPushSubscriptionsPerUser)
+		return err
+	})
+	if err != nil {
+		LogQueryError(ctx, "SaveSubscription", "push_subscriptions", err)
+		return nil, fmt.Errorf("save push subscription: %w", err)
+	}
+	return saved, nil
+}
+
+// ListSubscriptions returns a user's subscriptions, newest first.
+func (r *PushSubscriptionRepository) ListSubscriptions(ctx context.Context, userID string) ([]models.PushSubscription, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+pushSubscriptionColumns+`
+		FROM push_subscriptions
+		WHERE user_id = $1
+		ORDER BY created_at DESC`, userID)
+	if err != nil {
+		LogQueryError(ctx, "ListSubscriptions", "push_subscriptions", err)
+		return nil, fmt.Errorf("list push subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]models.PushSubscription, 0)
+	for rows.Next() {
+		s, err := scanPushSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan push subscription: %w", err)
+		}
+		subs = append(subs, *s)
+	}
+	return subs, rows.Err()
+}
+
+// DeleteSubscription removes a user's subscription by endpoint.
+// Returns ErrNotFound if the user has no subscription at endpoint.
+func (r *PushSubscriptionRepository) DeleteSubscription(ctx context.Context, userID, endpoint string) error {
+	result, err := r.pool.Exec(ctx, `DELETE FROM push_subscriptions WHERE user_id = $1 AND endpoint = $2`, userID, endpoint)
+	if err != nil {
+		LogQueryError(ctx, "DeleteSubscription", "push_subscriptions", err)
+		return fmt.Errorf("delete push subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListPendingPushes returns up to limit unread user notifications of the
+// given types created after since and not pushed yet, oldest first, with the
+// recipient's subscriptions. Notifications whose recipient has no
+// subscription are left out.
+func (r *PushSubscriptionRepository) ListPendingPushes(ctx context.Context, types []string, since time.Time, limit int) ([]models.PendingPush, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT n.id::text, n.user_id::text, n.type, n.title, COALESCE(n.body, ''), COALESCE(n.link, ''), n.created_at,
+			s.id::text, s.user_id::text, s.endpoint, s.p256dh, s.auth, COALESCE(s.user_agent, ''), s.created_at, s.last_pushed_at
+		FROM (
+			SELECT id, user_id, type, title, body, link, created_at
+			FROM notifications
+			WHERE pushed_at IS NULL AND user_id IS NOT NULL AND read_at IS NULL
+				AND created_at > $2 AND type = ANY($1)
+				AND EXISTS (SELECT 1 FROM push_subscriptions ps WHERE ps.user_id = notifications.user_id)
+			ORDER BY created_at
+			LIMIT $3
+		) n
+		JOIN push_subscriptions s ON s.user_id = n.user_id
+		ORDER BY n.created_at, n.id, s.created_at`, types, since, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListPendingPushes", "notifications", err)
+		return nil, fmt.Errorf("list pending pushes: %w", err)
+	}
+	defer rows.Close()
+
+	var pending []models.PendingPush
+	for rows.Next() {
+		var n models.Notification
+		var s models.PushSubscription
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Type, &n.Title, &n.Body, &n.Link, &n.CreatedAt,
+			&s.ID, &s.UserID, &s.Endpoint, &s.P256dh, &s.Auth, &s.UserAgent, &s.CreatedAt, &s.LastPushedAt); err != nil {
+			return nil, fmt.Errorf("scan pending push: %w", err)
+		}
+		if len(pending) == 0 || pending[len(pending)-1].Notification.ID != n.ID {
+			pending = append(pending, models.PendingPush{Notification: n})
+		}
+		last := &pending[len(pending)-1]
+		last.Subscriptions = append(last.Subscriptions, s)
+	}
+	return pending, rows.Err()
+}
+
+// MarkPushed records that a notification was pushed, so it isn't again.
+func (r *PushSubscriptionRepository) MarkPushed(ctx context.Context, notificationID string) error {
+	if _, err := r.pool.Exec(ctx, `UPDATE notifications SET pushed_at = NOW() WHERE id = $1`, notificationID); err != nil {
+		LogQueryError(ctx, "MarkPushed", "notifications", err)
+		return fmt.Errorf("mark notification pushed: %w", err)
+	}
+	return nil
+}
+
+// RecordDelivery records a successful push to a subscription.
+func (r *PushSubscriptionRepository) RecordDelivery(ctx context.Context, subscriptionID string) error {
+	if _, err := r.pool.Exec(ctx, `UPDATE push_subscriptions SET last_pushed_at = NOW() WHERE id = $1`, subscriptionID); err != nil {
+		LogQueryError(ctx, "RecordDelivery", "push_subscriptions", err)
+		return fmt.Errorf("record push delivery: %w", err)
+	}
+	return nil
+}
+
+// RemoveSubscription deletes a subscription the push service reported gone.
+func (r *PushSubscriptionRepository) RemoveSubscription(ctx context.Context, subscriptionID string) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM push_subscriptions WHERE id = $1`, subscriptionID); err != nil {
+		LogQueryError(ctx, "RemoveSubscription", "push_subscriptions", err)
+		return fmt.Errorf("remove push subscription: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestPushSubscriptionRepository_PendingPushes(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewPushSubscriptionRepository(pool)
+	user := createNotificationTestUser(t, pool)
+	defer func() { _, _ = pool.Exec(ctx, "DELETE FROM users WHERE id = $1", user.ID) }()
+
+	endpoint := "https://fcm.googleapis.com/fcm/send/test-" + user.ID
+	sub, err := repo.SaveSubscription(ctx, &models.PushSubscription{
+		UserID: user.ID, Endpoint: endpoint, P256dh: "key", Auth: "secret", UserAgent: "Firefox",
+	})
+	if err != nil {
+		t.Fatalf("SaveSubscription() error = %v", err)
+	}
+	// Subscribing the same endpoint again replaces the row.
+	if _, err := repo.SaveSubscription(ctx, &models.PushSubscription{
+		UserID: user.ID, Endpoint: endpoint, P256dh: "new-key", Auth: "secret",
+	}); err != nil {
+		t.Fatalf("SaveSubscription() again error = %v", err)
+	}
+	subs, err := repo.ListSubscriptions(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("ListSubscriptions() error = %v", err)
+	}
+	if len(subs) != 1 || subs[0].P256dh != "new-key" {
+		t.Fatalf("ListSubscriptions() = %+v, want one subscription with the new key", subs)
+	}
+
+	pushedID := insertTestNotification(t, pool, &user.ID, nil, "approach.created", "New approach on your problem")
+	insertTestNotification(t, pool, &user.ID, nil, "upvote.milestone", "Your post reached 10 upvotes")
+
+	since := time.Now().Add(-time.Hour)
+	types := []string{"approach.created"}
+	pending, err := repo.ListPendingPushes(ctx, types, since, 100)
+	if err != nil {
+		t.Fatalf("ListPendingPushes() error = %v", err)
+	}
+	var found *models.PendingPush
+	for i := range pending {
+		if pending[i].Notification.Type != "approach.created" {
+			t.Errorf("ListPendingPushes() returned type %q", pending[i].Notification.Type)
+		}
+		if pending[i].Notification.ID == pushedID {
+			found = &pending[i]
+		}
+	}
+	if found == nil || len(found.Subscriptions) != 1 || found.Subscriptions[0].ID != sub.ID {
+		t.Fatalf("ListPendingPushes() = %+v, want notification %s with subscription %s", pending, pushedID, sub.ID)
+	}
+
+	if err := repo.MarkPushed(ctx, pushedID); err != nil {
+		t.Fatalf("MarkPushed() error = %v", err)
+	}
+	pending, err = repo.ListPendingPushes(ctx, types, since, 100)
+	if err != nil {
+		t.Fatalf("ListPendingPushes() error = %v", err)
+	}
+	for _, p := range pending {
+		if p.Notification.ID == pushedID {
+			t.Error("ListPendingPushes() returned a notification already pushed")
+		}
+	}
+
+	if err := repo.DeleteSubscription(ctx, user.ID, endpoint); err != nil {
+		t.Fatalf("DeleteSubscription() error = %v", err)
+	}
+	if err := repo.DeleteSubscription(ctx, user.ID, endpoint); err != ErrNotFound {
+		t.Errorf("DeleteSubscription() twice error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// QAExportRepository reads question→answer and problem→solution pairs for
+// GET /v1/export/qa-pairs.
+type QAExportRepository struct {
+	pool *Pool
+}
+
+// NewQAExportRepository creates a new QAExportRepository.
+func NewQAExportRepository(pool *Pool) *QAExportRepository {
+	return &QAExportRepository{pool: pool}
+}
+
+// qaExportPostWhere limits exported pairs to published public posts.
+const qaExportPostWhere = `p.deleted_at IS NULL AND p.hidden_at IS NULL
+		  AND p.visibility = 'public' -- BART-151: private posts are never exported
+		  AND p.status NOT IN ('draft', 'pending_review', 'rejected')`
+
+// qaExportHandle is a contributor's public handle: the username for humans,
+// the agent ID for agents.
+func qaExportHandle(typeCol, idCol, userAlias, agentAlias string) string {
+	return fmt.Sprintf("COALESCE(CASE WHEN %s = 'human' THEN %s.username ELSE %s.id END, %s)", typeCol, userAlias, agentAlias, idCol)
+}
+
+// StreamQAPairs calls fn for each pair matching filter, oldest response change
+// first, so an export can resume with Since set to the last pair's UpdatedAt.
+// Questions export the newest verified version of their accepted answer;
+// solved problems export each succeeded approach the owner verified. fn's
+// error stops the export and is returned.
+func (r *QAExportRepository) StreamQAPairs(ctx context.Context, filter models.QAExportFilter, fn func(*models.QAPair) error) error {
+	rows, err := r.pool.Query(ctx, `
+		SELECT post_id, post_type, title, description, tags, response_id, response,
+		       asker_type, asker_handle, responder_type, responder_handle,
+		       created_at, responded_at, updated_at
+		FROM (
+			SELECT p.id::text AS post_id, p.type AS post_type, p.title, COALESCE(p.description, '') AS description,
+			       COALESCE(p.tags, '{}') AS tags, ans.id::text AS response_id, ans.content AS response,
+			       p.posted_by_type AS asker_type, `+qaExportHandle("p.posted_by_type", "p.posted_by_id", "pu", "pa")+` AS asker_handle,
+			       ans.author_type AS responder_type, `+qaExportHandle("ans.author_type", "ans.author_id", "au", "aa")+` AS responder_handle,
+			       p.created_at, ans.created_at AS responded_at, ans.updated_at
+			FROM answers acc
+			JOIN posts p ON p.id = acc.question_id
+			JOIN answers ans ON ans.id = latest_verified_answer_id(acc.id)
+			LEFT JOIN users pu ON p.posted_by_type = 'human' AND pu.id::text = p.posted_by_id
+			LEFT JOIN agents pa ON p.posted_by_type = 'agent' AND pa.id = p.posted_by_id
+			LEFT JOIN users au ON ans.author_type = 'human' AND au.id::text = ans.author_id
+			LEFT JOIN agents aa ON ans.author_type = 'agent' AND aa.id = ans.author_id
+			WHERE $1 IN ('', 'question')
+			  AND acc.is_accepted AND acc.deleted_at IS NULL AND acc.hidden_at IS NULL
+			  AND p.type = 'question' AND `+qaExportPostWhere+`
+
+			UNION ALL
+
+			SELECT p.id::text, p.type, p.title, COALESCE(p.description, ''),
+			       COALESCE(p.tags, '{}'), a.id::text, a.solution,
+			       p.posted_by_type, `+qaExportHandle("p.posted_by_type", "p.posted_by_id", "pu", "pa")+`,
+			       a.author_type, `+qaExportHandle("a.author_type", "a.author_id", "au", "aa")+`,
+			       p.created_at, a.created_at, COALESCE(a.updated_at, a.created_at)
+			FROM approaches a
+			JOIN posts p ON p.id = a.problem_id
+			LEFT JOIN users pu ON p.posted_by_type = 'human' AND pu.id::text = p.posted_by_id
+			LEFT JOIN agents pa ON p.posted_by_type = 'agent' AND pa.id = p.posted_by_id
+			LEFT JOIN users au ON a.author_type = 'human' AND au.id::text = a.author_id
+			LEFT JOIN agents aa ON a.author_type = 'agent' AND aa.id = a.author_id
+			WHERE $1 IN ('', 'problem')
+			  AND a.status = 'succeeded' AND a.deleted_at IS NULL AND a.is_latest
+			  AND COALESCE(a.solution, '') <> ''
+			  AND EXISTS (
+				SELECT 1 FROM approach_events e
+				WHERE e.approach_id = a.id AND e.event_type = 'verified'
+				  AND (e.data->>'verified')::boolean
+			  )
+			  AND p.type = 'problem' AND p.status = 'solved' AND `+qaExportPostWhere+`
+		) pairs
+		WHERE $2::timestamptz IS NULL OR updated_at > $2
+		ORDER BY updated_at, response_id
+		LIMIT $3
+	`, string(filter.PostType), filter.Since, filter.Limit)
+	if err != nil {
+		return fmt.Errorf("query qa pairs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pair models.QAPair
+		var createdAt, respondedAt, updatedAt *time.Time
+		if err := rows.Scan(
+			&pair.PostID, &pair.PostType, &pair.Title, &pair.Body, &pair.Tags, &pair.ResponseID, &pair.Response,
+			&pair.Asker.Type, &pair.Asker.Handle, &pair.Responder.Type, &pair.Responder.Handle,
+			&createdAt, &respondedAt, &updatedAt,
+		); err != nil {
+			return fmt.Errorf("scan qa pair: %w", err)
+		}
+		if createdAt != nil {
+			pair.CreatedAt = *createdAt
+		}
+		if respondedAt != nil {
+			pair.RespondedAt = *respondedAt
+		}
+		if updatedAt != nil {
+			pair.UpdatedAt = *updatedAt
+		}
+		if err := fn(&pair); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
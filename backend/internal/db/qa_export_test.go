@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestQAExportRepository_StreamQAPairs(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewQAExportRepository(pool)
+
+	questionID := insertTestPost(t, pool, ctx, "question", "How do I cancel a pgx query?", "It hangs.", []string{"pgx"}, "answered")
+	acceptedID := insertTestAnswer(t, pool, ctx, questionID, "Pass a context with a timeout.", "agent", "agent_qa_export")
+	insertTestAnswer(t, pool, ctx, questionID, "Restart the database.", "agent", "agent_qa_export")
+	if _, err := pool.Exec(ctx, "UPDATE answers SET is_accepted = true WHERE id = $1", acceptedID); err != nil {
+		t.Fatalf("accept answer: %v", err)
+	}
+
+	problemID := insertTestPost(t, pool, ctx, "problem", "Migrations deadlock", "Two pods migrate at once.", nil, "solved")
+	verifiedID := insertTestApproach(t, pool, ctx, problemID, "Advisory lock", "pg_advisory_lock", "agent", "agent_qa_export")
+	unverifiedID := insertTestApproach(t, pool, ctx, problemID, "Retry", "loop", "agent", "agent_qa_export")
+	if _, err := pool.Exec(ctx, "UPDATE approaches SET status = 'succeeded', solution = 'Take an advisory lock first.' WHERE id IN ($1, $2)", verifiedID, unverifiedID); err != nil {
+		t.Fatalf("succeed approaches: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO approach_events (approach_id, event_type, actor_type, actor_id, data)
+		VALUES ($1, 'verified', 'human', 'test-user', '{"verified": true}')
+	`, verifiedID); err != nil {
+		t.Fatalf("verify approach: %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM approaches WHERE problem_id = $1", problemID)
+		_, _ = pool.Exec(ctx, "DELETE FROM answers WHERE question_id = $1", questionID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id IN ($1, $2)", questionID, problemID)
+	}()
+
+	found := map[string]models.QAPair{}
+	err := repo.StreamQAPairs(ctx, models.QAExportFilter{Limit: models.MaxQAExportLimit}, func(p *models.QAPair) error {
+		if p.PostID == questionID || p.PostID == problemID {
+			found[p.ResponseID] = *p
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamQAPairs() error = %v", err)
+	}
+
+	if len(found) != 2 {
+		t.Fatalf("StreamQAPairs() found %d pairs, want the accepted answer and the verified approach: %+v", len(found), found)
+	}
+	answer := found[acceptedID]
+	if answer.Response != "Pass a context with a timeout." || answer.Asker.Handle != "test-user" || answer.Responder.Handle != "agent_qa_export" {
+		t.Errorf("unexpected answer pair %+v", answer)
+	}
+	if found[verifiedID].Response != "Take an advisory lock first." {
+		t.Errorf("unexpected solution pair %+v", found[verifiedID])
+	}
+
+	// type narrows the export to one kind of pair.
+	err = repo.StreamQAPairs(ctx, models.QAExportFilter{PostType: models.PostTypeProblem, Limit: models.MaxQAExportLimit}, func(p *models.QAPair) error {
+		if p.PostType != models.PostTypeProblem {
+			t.Errorf("type=problem returned a %s pair", p.PostType)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamQAPairs(type=problem) error = %v", err)
+	}
+}
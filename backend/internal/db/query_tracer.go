@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultSlowQueryThreshold is the duration above which a query is logged as slow.
+const DefaultSlowQueryThreshold = 500 * time.Millisecond
+
+// QueryDurationBucketsMs are the upper bounds (inclusive, in milliseconds) of the
+// query duration histogram. Slower queries land in the final +Inf bucket.
+var QueryDurationBucketsMs = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000}
+
+// maxLoggedSQLLength truncates SQL in slow query logs.
+const maxLoggedSQLLength = 500
+
+const modulePrefix = "github.com/fcavalcantirj/solvr/internal/"
+
+// QueryStat aggregates every query issued from one call site.
+// Buckets has one more entry than QueryDurationBucketsMs (the +Inf bucket); counts
+// are per bucket, not cumulative.
+type QueryStat struct {
+	Caller    string    `json:"caller"`
+	Count     int64     `json:"count"`
+	Errors    int64     `json:"errors"`
+	SlowCount int64     `json:"slow_count"`
+	TotalMs   float64   `json:"total_ms"`
+	MaxMs     float64   `json:"max_ms"`
+	Rows      int64     `json:"rows"`
+	BucketsMs []float64 `json:"buckets_ms"`
+	Buckets   []int64   `json:"buckets"`
+}
+
+// queryTracer implements pgx.QueryTracer. It is installed on every connection of
+// the primary and replica pools, so it also sees queries run inside transactions.
+type queryTracer struct {
+	slowThresholdNs atomic.Int64
+
+	mu    sync.Mutex
+	stats map[string]*QueryStat
+}
+
+type queryTraceKey struct{}
+
+type queryTrace struct {
+	start  time.Time
+	caller string
+	sql    string
+}
+
+func newQueryTracer() *queryTracer {
+	t := &queryTracer{stats: make(map[string]*QueryStat)}
+	t.slowThresholdNs.Store(int64(DefaultSlowQueryThreshold))
+	return t
+}
+
+// TraceQueryStart records the start time and the repository method issuing the query.
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryTraceKey{}, &queryTrace{
+		start:  time.Now(),
+		caller: queryCaller(),
+		sql:    data.SQL,
+	})
+}
+
+// TraceQueryEnd records duration and row count, and logs the query if it was slow.
+// For Query calls this runs when the rows are closed, so it includes fetch time.
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, ok := ctx.Value(queryTraceKey{}).(*queryTrace)
+	if !ok {
+		return
+	}
+	duration := time.Since(trace.start)
+	rows := data.CommandTag.RowsAffected()
+	slow := duration >= time.Duration(t.slowThresholdNs.Load())
+
+	t.record(trace.caller, duration, rows, data.Err != nil, slow)
+
+	if slow {
+		attrs := []any{
+			"caller", trace.caller,
+			"duration_ms", duration.Milliseconds(),
+			"rows", rows,
+			"sql", compactSQL(trace.sql),
+		}
+		if data.Err != nil {
+			attrs = append(attrs, "error", data.Err.Error())
+		}
+		if reqID := ctx.Value(requestIDKey); reqID != nil {
+			attrs = append(attrs, "request_id", reqID)
+		}
+		slog.Warn("slow db query", attrs...)
+	}
+}
+
+func (t *queryTracer) record(caller string, duration time.Duration, rows int64, failed, slow bool) {
+	ms := float64(duration) / float64(time.Millisecond)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.stats[caller]
+	if !ok {
+		s = &QueryStat{Caller: caller, Buckets: make([]int64, len(QueryDurationBucketsMs)+1)}
+		t.stats[caller] = s
+	}
+	s.Count++
+	s.TotalMs += ms
+	s.Rows += rows
+	if ms > s.MaxMs {
+		s.MaxMs = ms
+	}
+	if failed {
+		s.Errors++
+	}
+	if slow {
+		s.SlowCount++
+	}
+	s.Buckets[sort.SearchFloat64s(QueryDurationBucketsMs, ms)]++
+}
+
+// snapshot returns a copy of the stats, slowest call sites (by total time) first.
+func (t *queryTracer) snapshot() []QueryStat {
+	t.mu.Lock()
+	out := make([]QueryStat, 0, len(t.stats))
+	for _, s := range t.stats {
+		c := *s
+		c.Buckets = append([]int64(nil), s.Buckets...)
+		c.BucketsMs = QueryDurationBucketsMs
+		out = append(out, c)
+	}
+	t.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].TotalMs != out[j].TotalMs {
+			return out[i].TotalMs > out[j].TotalMs
+		}
+		return out[i].Caller < out[j].Caller
+	})
+	return out
+}
+
+// SetSlowQueryThreshold sets the duration above which queries are logged as slow.
+// A non-positive value restores DefaultSlowQueryThreshold.
+func (p *Pool) SetSlowQueryThreshold(d time.Duration) {
+	if d <= 0 {
+		d = DefaultSlowQueryThreshold
+	}
+	p.tracer.slowThresholdNs.Store(int64(d))
+}
+
+// QueryStats returns per-call-site query metrics collected since startup.
+func (p *Pool) QueryStats() []QueryStat {
+	return p.tracer.snapshot()
+}
+
+// queryCaller returns the first frame outside pgx and the Pool wrappers, e.g.
+// "db.(*PostRepository).List", so metrics group by repository method.
+func queryCaller() string {
+	var pcs [24]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if isTracerInternalFrame(frame.Function) {
+			if !more {
+				break
+			}
+			continue
+		}
+		return strings.TrimPrefix(frame.Function, modulePrefix)
+	}
+	return "unknown"
+}
+
+func isTracerInternalFrame(fn string) bool {
+	if strings.HasPrefix(fn, "github.com/jackc/") {
+		return true
+	}
+	name, ok := strings.CutPrefix(fn, modulePrefix+"db.")
+	if !ok {
+		return false
+	}
+	for _, prefix := range []string{"(*Pool).", "(*txWrapper).", "(*fallbackRow).", "(*replica)."} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// compactSQL collapses whitespace and truncates SQL for log lines.
+func compactSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > maxLoggedSQLLength {
+		sql = sql[:maxLoggedSQLLength] + "..."
+	}
+	return sql
+}
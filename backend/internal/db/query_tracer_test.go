@@ -0,0 +1,123 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestQueryTracer_RecordsHistogram(t *testing.T) {
+	tr := newQueryTracer()
+	tr.record("db.(*PostRepository).List", 3*time.Millisecond, 20, false, false)
+	tr.record("db.(*PostRepository).List", 700*time.Millisecond, 20, false, true)
+	tr.record("db.(*PostRepository).List", 10*time.Second, 0, true, true)
+	tr.record("db.(*StatsRepository).GetActivePostsCount", time.Millisecond, 1, false, false)
+
+	stats := tr.snapshot()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 call sites, got %d", len(stats))
+	}
+	s := stats[0]
+	if s.Caller != "db.(*PostRepository).List" {
+		t.Fatalf("expected slowest call site first, got %q", s.Caller)
+	}
+	if s.Count != 3 || s.Errors != 1 || s.SlowCount != 2 || s.Rows != 40 {
+		t.Errorf("unexpected counters: %+v", s)
+	}
+	if s.MaxMs != 10000 {
+		t.Errorf("MaxMs = %v, want 10000", s.MaxMs)
+	}
+	if len(s.Buckets) != len(QueryDurationBucketsMs)+1 {
+		t.Fatalf("expected %d buckets, got %d", len(QueryDurationBucketsMs)+1, len(s.Buckets))
+	}
+	// 3ms -> le 5, 700ms -> le 1000, 10s -> +Inf
+	if s.Buckets[1] != 1 || s.Buckets[8] != 1 || s.Buckets[len(s.Buckets)-1] != 1 {
+		t.Errorf("unexpected bucket counts: %v", s.Buckets)
+	}
+	// Exactly on a bound counts toward that bucket.
+	if stats[1].Buckets[0] != 1 {
+		t.Errorf("1ms query should land in the first bucket: %v", stats[1].Buckets)
+	}
+}
+
+func TestQueryTracer_SnapshotIsCopy(t *testing.T) {
+	tr := newQueryTracer()
+	tr.record("caller", time.Millisecond, 1, false, false)
+	stats := tr.snapshot()
+	stats[0].Buckets[0] = 99
+	if tr.snapshot()[0].Buckets[0] != 1 {
+		t.Error("snapshot should not alias tracer state")
+	}
+}
+
+func TestQueryTracer_LogsSlowQueries(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer slog.SetDefault(prev)
+
+	tr := newQueryTracer()
+	tr.slowThresholdNs.Store(int64(time.Nanosecond))
+
+	ctx := context.WithValue(context.Background(), requestIDKey, "req-1")
+	ctx = tr.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT *\n\t\tFROM posts"})
+	time.Sleep(time.Millisecond)
+	tr.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{
+		CommandTag: pgconn.NewCommandTag("SELECT 7"),
+		Err:        errors.New("boom"),
+	})
+
+	out := buf.String()
+	for _, want := range []string{`"msg":"slow db query"`, `"rows":7`, `"sql":"SELECT * FROM posts"`, `"request_id":"req-1"`, `"error":"boom"`, `"caller":"db.TestQueryTracer_LogsSlowQueries"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("slow query log missing %s: %s", want, out)
+		}
+	}
+}
+
+func TestQueryTracer_FastQueryNotLogged(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer slog.SetDefault(prev)
+
+	tr := newQueryTracer()
+	ctx := tr.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tr.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	if buf.Len() != 0 {
+		t.Errorf("fast query should not be logged: %s", buf.String())
+	}
+	if stats := tr.snapshot(); len(stats) != 1 || stats[0].Count != 1 {
+		t.Errorf("fast query should still be recorded: %+v", stats)
+	}
+}
+
+func TestIsTracerInternalFrame(t *testing.T) {
+	tests := map[string]bool{
+		"github.com/jackc/pgx/v5.(*Conn).Query":                                             true,
+		"github.com/fcavalcantirj/solvr/internal/db.(*Pool).ReadQuery":                      true,
+		"github.com/fcavalcantirj/solvr/internal/db.(*txWrapper).Exec":                      true,
+		"github.com/fcavalcantirj/solvr/internal/db.(*PostRepository).List":                 false,
+		"github.com/fcavalcantirj/solvr/internal/api/handlers.(*AdminHandler).ExecuteQuery": false,
+	}
+	for fn, want := range tests {
+		if got := isTracerInternalFrame(fn); got != want {
+			t.Errorf("isTracerInternalFrame(%q) = %v, want %v", fn, got, want)
+		}
+	}
+}
+
+func TestCompactSQL_Truncates(t *testing.T) {
+	got := compactSQL(strings.Repeat("x ", maxLoggedSQLLength))
+	if len(got) != maxLoggedSQLLength+3 || !strings.HasSuffix(got, "...") {
+		t.Errorf("expected truncated SQL, got %d chars", len(got))
+	}
+}
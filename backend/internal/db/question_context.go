@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// QuestionContextRepository finds solved content related to a question for
+// GET /v1/questions/{id}/context.
+type QuestionContextRepository struct {
+	pool *Pool
+}
+
+// NewQuestionContextRepository creates a new QuestionContextRepository.
+func NewQuestionContextRepository(pool *Pool) *QuestionContextRepository {
+	return &QuestionContextRepository{pool: pool}
+}
+
+// questionContextMatch ranks public posts against the question $1. When both posts
+// have embeddings they are ordered by cosine distance; otherwise the candidate must
+// match the keyword tsquery $2 built from the question title.
+const questionContextMatch = `
+	WITH q AS (SELECT embedding FROM posts WHERE id = $1)
+	SELECT %s,
+		CASE WHEN p.embedding IS NOT NULL AND q.embedding IS NOT NULL
+			THEN 1 - (p.embedding <=> q.embedding) END AS similarity
+	FROM posts p
+	CROSS JOIN q
+	%s
+	WHERE p.id <> $1
+	AND p.deleted_at IS NULL AND p.hidden_at IS NULL
+	AND p.status NOT IN ('pending_review', 'rejected', 'draft')
+	AND %s
+	AND %s
+	AND (
+		(p.embedding IS NOT NULL AND q.embedding IS NOT NULL)
+		OR ($2 <> '' AND to_tsvector('english', p.title || ' ' || p.description) @@ to_tsquery('english', $2))
+	)
+	ORDER BY
+		CASE WHEN p.embedding IS NOT NULL AND q.embedding IS NOT NULL THEN p.embedding <=> q.embedding END ASC NULLS LAST,
+		(p.upvotes - p.downvotes) DESC
+	LIMIT $3
+`
+
+// ListCrystallizedSolutions returns up to limit crystallized problems closest to the
+// question, each with its most recent succeeded approach's solution (or outcome).
+func (r *QuestionContextRepository) ListCrystallizedSolutions(ctx context.Context, questionID, title string, limit int) ([]models.ContextSolution, error) {
+	query := fmt.Sprintf(questionContextMatch,
+		"p.id, p.title, COALESCE(NULLIF(apr.solution, ''), apr.outcome, ''), p.crystallization_cid",
+		`JOIN LATERAL (
+			SELECT solution, outcome FROM approaches
+			WHERE problem_id = p.id AND status = 'succeeded' AND deleted_at IS NULL
+			ORDER BY updated_at DESC
+			LIMIT 1
+		) apr ON true`,
+		"p.type = 'problem' AND p.crystallization_cid IS NOT NULL",
+		publicOnlyVisibility("p"),
+	)
+
+	rows, err := r.pool.ReadQuery(ctx, query, questionID, buildTsQuery(title), limit)
+	if err != nil {
+		LogQueryError(ctx, "QuestionContext.Crystallized", "posts", err)
+		return nil, fmt.Errorf("list crystallized solutions failed: %w", err)
+	}
+	defer rows.Close()
+
+	solutions := make([]models.ContextSolution, 0)
+	for rows.Next() {
+		var s models.ContextSolution
+		if err := rows.Scan(&s.PostID, &s.Title, &s.Solution, &s.CrystallizationCID, &s.Similarity); err != nil {
+			return nil, fmt.Errorf("scan crystallized solution: %w", err)
+		}
+		solutions = append(solutions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate crystallized solutions: %w", err)
+	}
+	return solutions, nil
+}
+
+// ListSimilarAnsweredQuestions returns up to limit other questions closest to the
+// question that have an accepted answer, with the accepted answer's content. When
+// the accepted answer has a newer verified version, that version's content is used.
+func (r *QuestionContextRepository) ListSimilarAnsweredQuestions(ctx context.Context, questionID, title string, limit int) ([]models.ContextSolution, error) {
+	query := fmt.Sprintf(questionContextMatch,
+		"p.id, p.title, ans.content",
+		"JOIN answers ans ON ans.id = latest_verified_answer_id(p.accepted_answer_id) AND ans.deleted_at IS NULL AND ans.hidden_at IS NULL",
+		"p.type = 'question'",
+		publicOnlyVisibility("p"),
+	)
+
+	rows, err := r.pool.ReadQuery(ctx, query, questionID, buildTsQuery(title), limit)
+	if err != nil {
+		LogQueryError(ctx, "QuestionContext.SimilarQuestions", "posts", err)
+		return nil, fmt.Errorf("list similar answered questions failed: %w", err)
+	}
+	defer rows.Close()
+
+	solutions := make([]models.ContextSolution, 0)
+	for rows.Next() {
+		var s models.ContextSolution
+		if err := rows.Scan(&s.PostID, &s.Title, &s.Solution, &s.Similarity); err != nil {
+			return nil, fmt.Errorf("scan similar question: %w", err)
+		}
+		solutions = append(solutions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate similar questions: %w", err)
+	}
+	return solutions, nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestQuestionContextRepository_ListSimilarAnsweredQuestions(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	userRepo := NewUserRepository(pool)
+	postRepo := NewPostRepository(pool)
+	answersRepo := NewAnswersRepository(pool)
+	repo := NewQuestionContextRepository(pool)
+
+	author := createViewsTestUser(t, userRepo)
+	newQuestion := func(title string) *models.Post {
+		t.Helper()
+		post, err := postRepo.Create(ctx, &models.Post{
+			Type:         models.PostTypeQuestion,
+			Title:        title,
+			Description:  "Test question for the answer drafting context endpoint",
+			Tags:         []string{"test"},
+			PostedByType: models.AuthorTypeHuman,
+			PostedByID:   author.ID,
+			Status:       models.PostStatusOpen,
+		})
+		if err != nil {
+			t.Fatalf("failed to create test question: %v", err)
+		}
+		return post
+	}
+
+	asked := newQuestion("How to size a zqxcontextpool under load")
+	answered := newQuestion("Sizing a zqxcontextpool for batch jobs")
+	unanswered := newQuestion("Is zqxcontextpool thread safe")
+
+	answer, err := answersRepo.CreateAnswer(ctx, &models.Answer{
+		QuestionID: answered.ID,
+		AuthorType: models.AuthorTypeHuman,
+		AuthorID:   author.ID,
+		Content:    "Use one zqxcontextpool per process and cap it at 4x CPUs.",
+	})
+	if err != nil {
+		t.Fatalf("failed to create answer: %v", err)
+	}
+	if err := answersRepo.AcceptAnswer(ctx, answered.ID, answer.ID); err != nil {
+		t.Fatalf("failed to accept answer: %v", err)
+	}
+
+	similar, err := repo.ListSimilarAnsweredQuestions(ctx, asked.ID, asked.Title, 5)
+	if err != nil {
+		t.Fatalf("ListSimilarAnsweredQuestions() error = %v", err)
+	}
+
+	found := false
+	for _, s := range similar {
+		if s.PostID == asked.ID || s.PostID == unanswered.ID {
+			t.Errorf("unexpected post %s in similar answered questions", s.PostID)
+		}
+		if s.PostID == answered.ID {
+			found = true
+			if s.Solution != answer.Content {
+				t.Errorf("expected accepted answer as solution, got %q", s.Solution)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected answered question %s in %+v", answered.ID, similar)
+	}
+
+	crystallized, err := repo.ListCrystallizedSolutions(ctx, asked.ID, asked.Title, 5)
+	if err != nil {
+		t.Fatalf("ListCrystallizedSolutions() error = %v", err)
+	}
+	for _, s := range crystallized {
+		if s.CrystallizationCID == "" {
+			t.Errorf("crystallized solution %s has no CID", s.PostID)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ReactionRepository stores emoji reactions on answers and comments.
+type ReactionRepository struct {
+	pool *Pool
+}
+
+// NewReactionRepository creates a new ReactionRepository.
+func NewReactionRepository(pool *Pool) *ReactionRepository {
+	return &ReactionRepository{pool: pool}
+}
+
+// reactionsSelect is a select expression for the reaction counts on the
+// targetType row with id idCol, as a JSON array of models.ReactionCount, most
+// used first. reacted is set for the viewer bound as $viewerArg and $viewerArg+1.
+func reactionsSelect(targetType models.ReactionTargetType, idCol string, viewerArg int) string {
+	return fmt.Sprintf(`COALESCE((
+				SELECT json_agg(json_build_object('emoji', rc.emoji, 'count', rc.n, 'reacted', rc.reacted) ORDER BY rc.n DESC, rc.emoji)
+				FROM (
+					SELECT emoji, COUNT(*) AS n, BOOL_OR(reactor_type = $%d AND reactor_id = $%d) AS reacted
+					FROM reactions WHERE target_type = '%s' AND target_id = %s
+					GROUP BY emoji
+				) rc
+			), '[]')`, viewerArg, viewerArg+1, targetType, idCol)
+}
+
+// reactionTargetVisible is a condition that the reaction target with id $1
+// exists, isn't deleted or hidden, and belongs to a public post, the same
+// answers and comments the list endpoints return.
+var reactionTargetVisible = map[models.ReactionTargetType]string{
+	models.ReactionTargetAnswer: `EXISTS (
+		SELECT 1 FROM answers ans
+		JOIN posts p ON p.id = ans.question_id AND p.visibility = 'public' AND p.deleted_at IS NULL
+		WHERE ans.id = $1 AND ans.deleted_at IS NULL AND ans.hidden_at IS NULL)`,
+	models.ReactionTargetComment: `EXISTS (
+		SELECT 1 FROM comments c
+		WHERE c.id = $1 AND c.deleted_at IS NULL AND c.hidden_at IS NULL
+		AND (c.target_type <> 'post' OR EXISTS (SELECT 1 FROM posts WHERE id = c.target_id AND visibility = 'public')))`,
+}
+
+// Toggle adds the reactor's emoji to the target, or removes it if they already
+// added it. Returns whether the reaction is now present and the target's
+// updated counts. Returns ErrNotFound if the target doesn't exist or isn't visible.
+func (r *ReactionRepository) Toggle(ctx context.Context, targetType models.ReactionTargetType, targetID string, reactorType models.AuthorType, reactorID, emoji string) (bool, []models.ReactionCount, error) {
+	var reacted bool
+	var counts []models.ReactionCount
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var visible bool
+		err := tx.QueryRow(ctx, `SELECT `+reactionTargetVisible[targetType], targetID).Scan(&visible)
+		if err != nil {
+			if isInvalidUUIDError(err) {
+				return ErrNotFound
+			}
+			return err
+		}
+		if !visible {
+			return ErrNotFound
+		}
+
+		result, err := tx.Exec(ctx, `
+			DELETE FROM reactions
+			WHERE target_type = $1 AND target_id = $2 AND reactor_type = $3 AND reactor_id = $4 AND emoji = $5
+		`, string(targetType), targetID, string(reactorType), reactorID, emoji)
+		if err != nil {
+			return err
+		}
+		reacted = result.RowsAffected() == 0
+		if reacted {
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO reactions (target_type, target_id, reactor_type, reactor_id, emoji)
+				VALUES ($1, $2, $3, $4, $5)
+				ON CONFLICT DO NOTHING
+			`, string(targetType), targetID, string(reactorType), reactorID, emoji); err != nil {
+				return err
+			}
+		}
+
+		return tx.QueryRow(ctx, `SELECT `+reactionsSelect(targetType, "$3::uuid", 1),
+			string(reactorType), reactorID, targetID).Scan(&counts)
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil, err
+		}
+		LogQueryError(ctx, "Toggle", "reactions", err)
+		return false, nil, fmt.Errorf("toggle reaction: %w", err)
+	}
+	return reacted, counts, nil
+}
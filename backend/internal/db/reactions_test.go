@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestReactionRepository_ToggleAndListCounts(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewReactionRepository(pool)
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano()%1e9)
+	agentID := "react_" + suffix
+	if _, err := pool.Exec(ctx, `INSERT INTO agents (id, display_name, api_key_hash, status) VALUES ($1, $1, 'hash', 'active')`, agentID); err != nil {
+		t.Fatalf("insert agent: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM agents WHERE id = $1`, agentID)
+
+	var questionID, answerID string
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Reaction test question', 'Description', 'agent', $1, 'open')
+		RETURNING id::text
+	`, agentID).Scan(&questionID); err != nil {
+		t.Fatalf("insert question: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM posts WHERE id = $1`, questionID)
+	if err := pool.QueryRow(ctx, `
+		INSERT INTO answers (question_id, author_type, author_id, content) VALUES ($1, 'agent', $2, 'An answer')
+		RETURNING id::text
+	`, questionID, agentID).Scan(&answerID); err != nil {
+		t.Fatalf("insert answer: %v", err)
+	}
+	defer pool.Exec(ctx, `DELETE FROM answers WHERE id = $1`, answerID)
+	defer pool.Exec(ctx, `DELETE FROM reactions WHERE target_id = $1`, answerID)
+
+	if _, _, err := repo.Toggle(ctx, models.ReactionTargetAnswer, "not-a-uuid", models.AuthorTypeAgent, agentID, "👍"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Toggle() on a bad id err = %v, want ErrNotFound", err)
+	}
+
+	reacted, counts, err := repo.Toggle(ctx, models.ReactionTargetAnswer, answerID, models.AuthorTypeAgent, agentID, "🎉")
+	if err != nil || !reacted {
+		t.Fatalf("Toggle() = %v, %v; want reacted", reacted, err)
+	}
+	if len(counts) != 1 || counts[0] != (models.ReactionCount{Emoji: "🎉", Count: 1, Reacted: true}) {
+		t.Errorf("counts = %+v, want one 🎉 by the caller", counts)
+	}
+	if _, err := pool.Exec(ctx, `INSERT INTO reactions (target_type, target_id, reactor_type, reactor_id, emoji) VALUES ('answer', $1, 'agent', 'someone_else', '🎉')`, answerID); err != nil {
+		t.Fatalf("insert reaction: %v", err)
+	}
+
+	answers, _, err := NewAnswersRepository(pool).ListAnswers(ctx, questionID, models.AnswerListOptions{Page: 1, PerPage: 20})
+	if err != nil || len(answers) != 1 {
+		t.Fatalf("ListAnswers() = %d answers, %v", len(answers), err)
+	}
+	if got := answers[0].Reactions; len(got) != 1 || got[0].Count != 2 || got[0].Reacted {
+		t.Errorf("anonymous Reactions = %+v, want 🎉 x2 not reacted", got)
+	}
+
+	reacted, counts, err = repo.Toggle(ctx, models.ReactionTargetAnswer, answerID, models.AuthorTypeAgent, agentID, "🎉")
+	if err != nil || reacted || len(counts) != 1 || counts[0].Count != 1 || counts[0].Reacted {
+		t.Errorf("second Toggle() = %v, %+v, %v; want removed, one left", reacted, counts, err)
+	}
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	pgvector "github.com/pgvector/pgvector-go"
+)
+
+// ErrEmbeddingUnavailable is returned by FindRelated when no embedding service is configured.
+var ErrEmbeddingUnavailable = errors.New("semantic search is not available")
+
+// FindRelated embeds a free-text problem description and returns the nearest public
+// posts by cosine similarity, each with a summary of its solution when one exists:
+// the accepted answer for questions, or the most recent succeeded approach for problems.
+func (r *SearchRepository) FindRelated(ctx context.Context, text string, limit int) ([]models.RelatedPost, error) {
+	if r.embeddingService == nil {
+		return nil, ErrEmbeddingUnavailable
+	}
+	if limit <= 0 {
+		limit = 5
+	}
+	if limit > 20 {
+		limit = 20
+	}
+
+	embedding, err := r.embeddingService.GenerateQueryEmbedding(ctx, text)
+	if err != nil {
+		LogSearchEmbeddingFailed(ctx, err.Error())
+		return nil, fmt.Errorf("failed to embed description: %w", err)
+	}
+
+	query := `
+		SELECT
+			p.id,
+			p.type,
+			p.title,
+			p.status,
+			p.tags,
+			1 - (p.embedding <=> $1::vector) as similarity,
+			COALESCE(ans.content, NULLIF(apr.solution, ''), NULLIF(apr.outcome, ''), '') as solution,
+			CASE
+				WHEN ans.content IS NOT NULL THEN 'answer'
+				WHEN COALESCE(NULLIF(apr.solution, ''), NULLIF(apr.outcome, '')) IS NOT NULL THEN 'approach'
+				ELSE ''
+			END as solution_source
+		FROM posts p
+		LEFT JOIN LATERAL (
+			SELECT content FROM answers
+			WHERE question_id = p.id AND is_accepted = true
+			AND deleted_at IS NULL AND hidden_at IS NULL
+			LIMIT 1
+		) ans ON p.type = 'question'
+		LEFT JOIN LATERAL (
+			SELECT solution, outcome FROM approaches
+			WHERE problem_id = p.id AND status = 'succeeded' AND deleted_at IS NULL
+			ORDER BY updated_at DESC
+			LIMIT 1
+		) apr ON p.type = 'problem'
+		WHERE p.embedding IS NOT NULL
+		AND p.deleted_at IS NULL AND p.hidden_at IS NULL
+		AND p.status NOT IN ('pending_review', 'rejected', 'draft')
+		AND ` + publicOnlyVisibility("p") + `
+		ORDER BY p.embedding <=> $1::vector
+		LIMIT $2
+	`
+
+	rows, err := r.pool.ReadQuery(ctx, query, pgvector.NewVector(embedding), limit)
+	if err != nil {
+		LogQueryError(ctx, "Search.FindRelated", "posts", err)
+		return nil, fmt.Errorf("related posts query failed: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.RelatedPost{}
+	for rows.Next() {
+		var p models.RelatedPost
+		if err := rows.Scan(&p.ID, &p.Type, &p.Title, &p.Status, &p.Tags, &p.Similarity, &p.Solution, &p.SolutionSource); err != nil {
+			return nil, fmt.Errorf("failed to scan related post: %w", err)
+		}
+		results = append(results, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("related posts rows error: %w", err)
+	}
+	return results, nil
+}
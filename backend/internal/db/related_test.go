@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFindRelated_RequiresEmbeddingService(t *testing.T) {
+	repo := NewSearchRepository(nil)
+
+	_, err := repo.FindRelated(context.Background(), "connection pool exhausted", 5)
+	if !errors.Is(err, ErrEmbeddingUnavailable) {
+		t.Errorf("expected ErrEmbeddingUnavailable, got %v", err)
+	}
+}
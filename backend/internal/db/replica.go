@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// DefaultReplicaMaxLag is the replication lag above which reads fall back to the primary.
+	DefaultReplicaMaxLag = 5 * time.Second
+	// replicaLagCheckInterval is how often the replica's replay lag is sampled.
+	replicaLagCheckInterval = 5 * time.Second
+	// replicaLagQueryTimeout bounds a single lag probe.
+	replicaLagQueryTimeout = 2 * time.Second
+)
+
+// replicaLagQuery reports replay lag in seconds. A fully caught-up replica reports 0
+// even when the primary is idle (pg_last_xact_replay_timestamp would otherwise keep growing).
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END::float8
+`
+
+// replica is a read-only connection pool whose health is tracked by a lag monitor.
+type replica struct {
+	pool   *pgxpool.Pool
+	maxLag time.Duration
+
+	healthy atomic.Bool
+	lagNs   atomic.Int64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// ReplicaStatus describes the read replica for diagnostics.
+type ReplicaStatus struct {
+	Configured bool
+	Healthy    bool
+	Lag        time.Duration
+}
+
+// AttachReplica connects a read replica used by ReadQuery and ReadQueryRow.
+// Reads fall back to the primary whenever the replica's replay lag exceeds maxLag
+// (DefaultReplicaMaxLag when <= 0), the lag probe fails, or a replica query errors.
+// Must be called before the pool is shared between goroutines.
+func (p *Pool) AttachReplica(ctx context.Context, replicaURL string, maxLag time.Duration) error {
+	if maxLag <= 0 {
+		maxLag = DefaultReplicaMaxLag
+	}
+
+	pool, err := newPgxPool(ctx, replicaURL, p.tracer)
+	if err != nil {
+		return fmt.Errorf("replica: %w", err)
+	}
+
+	monitorCtx, cancel := context.WithCancel(context.Background())
+	r := &replica{
+		pool:   pool,
+		maxLag: maxLag,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	r.checkLag(ctx)
+	go r.monitor(monitorCtx)
+
+	p.replica = r
+	return nil
+}
+
+// ReplicaStatus returns the current replica health and last sampled lag.
+func (p *Pool) ReplicaStatus() ReplicaStatus {
+	if p.replica == nil {
+		return ReplicaStatus{}
+	}
+	return ReplicaStatus{
+		Configured: true,
+		Healthy:    p.replica.healthy.Load(),
+		Lag:        time.Duration(p.replica.lagNs.Load()),
+	}
+}
+
+// ReadQuery runs a read-only query on the replica when it is healthy, otherwise on
+// the primary. A replica error is retried once on the primary. Never use it for
+// reads that must observe the caller's own just-committed writes.
+func (p *Pool) ReadQuery(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if !p.replicaUsable() {
+		return p.Query(ctx, sql, args...)
+	}
+	rows, err := p.replica.pool.Query(ctx, sql, args...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("query failed: %w", err)
+		}
+		slog.Warn("replica query failed, retrying on primary", "error", err)
+		return p.Query(ctx, sql, args...)
+	}
+	return rows, nil
+}
+
+// ReadQueryRow is the single-row counterpart of ReadQuery.
+func (p *Pool) ReadQueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if !p.replicaUsable() {
+		return p.QueryRow(ctx, sql, args...)
+	}
+	return &fallbackRow{
+		row: p.replica.pool.QueryRow(ctx, sql, args...),
+		retry: func() pgx.Row {
+			return p.QueryRow(ctx, sql, args...)
+		},
+		ctx: ctx,
+	}
+}
+
+func (p *Pool) replicaUsable() bool {
+	return p.replica != nil && p.replica.healthy.Load()
+}
+
+// fallbackRow scans from the replica and retries on the primary if that fails
+// for any reason other than "no rows".
+type fallbackRow struct {
+	row   pgx.Row
+	retry func() pgx.Row
+	ctx   context.Context
+}
+
+func (r *fallbackRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	if err == nil || errors.Is(err, pgx.ErrNoRows) || r.ctx.Err() != nil {
+		return err
+	}
+	slog.Warn("replica query failed, retrying on primary", "error", err)
+	return r.retry().Scan(dest...)
+}
+
+// monitor samples replication lag until the context is cancelled.
+func (r *replica) monitor(ctx context.Context) {
+	defer close(r.done)
+	ticker := time.NewTicker(replicaLagCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.checkLag(ctx)
+		}
+	}
+}
+
+// checkLag probes replay lag and flips the replica in or out of rotation.
+func (r *replica) checkLag(ctx context.Context) {
+	probeCtx, cancel := context.WithTimeout(ctx, replicaLagQueryTimeout)
+	defer cancel()
+
+	var lagSeconds float64
+	err := r.pool.QueryRow(probeCtx, replicaLagQuery).Scan(&lagSeconds)
+	if err != nil {
+		if r.healthy.Swap(false) {
+			slog.Warn("replica lag check failed, routing reads to primary", "error", err)
+		}
+		return
+	}
+
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	r.lagNs.Store(int64(lag))
+	r.setHealthy(lag <= r.maxLag, lag)
+}
+
+// setHealthy records the replica state, logging only on transitions.
+func (r *replica) setHealthy(healthy bool, lag time.Duration) {
+	if r.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		slog.Info("replica caught up, routing reads to replica", "lag", lag.String())
+	} else {
+		slog.Warn("replica lagging, routing reads to primary", "lag", lag.String(), "max_lag", r.maxLag.String())
+	}
+}
+
+func (r *replica) close() {
+	r.cancel()
+	<-r.done
+	r.pool.Close()
+}
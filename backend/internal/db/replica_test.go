@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPool_ReplicaStatus_NotConfigured(t *testing.T) {
+	p := &Pool{}
+	if status := p.ReplicaStatus(); status.Configured || status.Healthy {
+		t.Errorf("expected unconfigured replica status, got %+v", status)
+	}
+	if p.replicaUsable() {
+		t.Error("replicaUsable() = true without a replica")
+	}
+}
+
+func TestReplica_SetHealthy(t *testing.T) {
+	r := &replica{maxLag: time.Second}
+	r.setHealthy(true, 0)
+	if !r.healthy.Load() {
+		t.Fatal("expected replica to be healthy")
+	}
+	r.setHealthy(false, 2*time.Second)
+	if r.healthy.Load() {
+		t.Fatal("expected replica to be out of rotation")
+	}
+
+	p := &Pool{replica: r}
+	if p.replicaUsable() {
+		t.Error("lagging replica should not be used for reads")
+	}
+}
+
+// TestPool_ReadQuery_UsesReplica points the "replica" at the primary database,
+// which reports zero lag, and checks reads are routed through it.
+func TestPool_ReadQuery_UsesReplica(t *testing.T) {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+
+	ctx := context.Background()
+	pool, err := NewPool(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	if err := pool.AttachReplica(ctx, databaseURL, time.Second); err != nil {
+		t.Fatalf("AttachReplica() error = %v", err)
+	}
+	if status := pool.ReplicaStatus(); !status.Configured || !status.Healthy {
+		t.Fatalf("expected healthy replica, got %+v", status)
+	}
+
+	var one int
+	if err := pool.ReadQueryRow(ctx, "SELECT 1").Scan(&one); err != nil || one != 1 {
+		t.Fatalf("ReadQueryRow() = %d, %v", one, err)
+	}
+
+	rows, err := pool.ReadQuery(ctx, "SELECT generate_series(1, 3)")
+	if err != nil {
+		t.Fatalf("ReadQuery() error = %v", err)
+	}
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	rows.Close()
+	if count != 3 {
+		t.Errorf("expected 3 rows, got %d", count)
+	}
+}
@@ -150,3 +150,178 @@ func (r *ReportsRepository) HasReported(ctx context.Context, targetType models.R
 
 	return exists, nil
 }
+
+// ErrNoPendingReports is returned when resolving a target that has no pending reports.
+var ErrNoPendingReports = errors.New("no pending reports for this content")
+
+// reportTargetTables maps hideable report target types to their tables.
+var reportTargetTables = map[models.ReportTargetType]string{
+	models.ReportTargetPost:    "posts",
+	models.ReportTargetAnswer:  "answers",
+	models.ReportTargetComment: "comments",
+}
+
+// TargetExists checks that a post, answer or comment exists and is not deleted.
+// Returns false for target types that can't be hidden and for malformed IDs.
+func (r *ReportsRepository) TargetExists(ctx context.Context, targetType models.ReportTargetType, targetID string) (bool, error) {
+	table, ok := reportTargetTables[targetType]
+	if !ok {
+		return false, nil
+	}
+
+	var exists bool
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM `+table+` WHERE id = $1 AND deleted_at IS NULL)`,
+		targetID,
+	).Scan(&exists)
+	if err != nil {
+		if isInvalidUUIDError(err) {
+			return false, nil
+		}
+		LogQueryError(ctx, "TargetExists", table, err)
+		return false, err
+	}
+	return exists, nil
+}
+
+// HideIfReported hides a post, answer or comment once it has at least threshold
+// pending reports. Reports are unique per reporter, so each one is independent.
+// Returns true only when this call hid the content.
+func (r *ReportsRepository) HideIfReported(ctx context.Context, targetType models.ReportTargetType, targetID string, threshold int) (bool, error) {
+	table, ok := reportTargetTables[targetType]
+	if !ok || threshold < 1 {
+		return false, nil
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE `+table+`
+		SET hidden_at = NOW()
+		WHERE id = $1 AND hidden_at IS NULL
+		AND (
+			SELECT COUNT(*) FROM reports
+			WHERE target_type = $2 AND target_id = $1 AND status = 'pending'
+		) >= $3
+	`, targetID, targetType, threshold)
+	if err != nil {
+		LogQueryError(ctx, "HideIfReported", table, err)
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListQueue returns reported content with pending reports, most reported first.
+func (r *ReportsRepository) ListQueue(ctx context.Context, page, perPage int) ([]models.ReportQueueItem, int, error) {
+	var total int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(DISTINCT (target_type, target_id)) FROM reports WHERE status = 'pending'
+	`).Scan(&total)
+	if err != nil {
+		LogQueryError(ctx, "ListQueue.Count", "reports", err)
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * perPage
+	rows, err := r.pool.Query(ctx, `
+		SELECT
+			r.target_type,
+			r.target_id,
+			COUNT(*) AS report_count,
+			array_agg(DISTINCT r.reason) AS reasons,
+			MIN(r.created_at) AS first_reported_at,
+			MAX(r.created_at) AS last_reported_at,
+			CASE r.target_type
+				WHEN 'post' THEN (SELECT hidden_at FROM posts WHERE id = r.target_id)
+				WHEN 'answer' THEN (SELECT hidden_at FROM answers WHERE id = r.target_id)
+				WHEN 'comment' THEN (SELECT hidden_at FROM comments WHERE id = r.target_id)
+			END AS hidden_at
+		FROM reports r
+		WHERE r.status = 'pending'
+		GROUP BY r.target_type, r.target_id
+		ORDER BY report_count DESC, first_reported_at ASC
+		LIMIT $1 OFFSET $2
+	`, perPage, offset)
+	if err != nil {
+		LogQueryError(ctx, "ListQueue", "reports", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := make([]models.ReportQueueItem, 0)
+	for rows.Next() {
+		var item models.ReportQueueItem
+		var reasons []string
+		err := rows.Scan(
+			&item.TargetType,
+			&item.TargetID,
+			&item.ReportCount,
+			&reasons,
+			&item.FirstReportedAt,
+			&item.LastReportedAt,
+			&item.HiddenAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		item.Reasons = make([]models.ReportReason, len(reasons))
+		for i, reason := range reasons {
+			item.Reasons[i] = models.ReportReason(reason)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// Resolve closes every pending report on a target. Dismissing unhides the
+// content; hiding keeps (or puts) it out of lists and search.
+// Returns the number of reports resolved, or ErrNoPendingReports.
+func (r *ReportsRepository) Resolve(ctx context.Context, targetType models.ReportTargetType, targetID string, resolution models.ReportResolution, reviewedBy string) (int64, error) {
+	status := models.ReportStatusDismissed
+	hiddenAt := "NULL"
+	if resolution == models.ReportResolutionHide {
+		status = models.ReportStatusActioned
+		hiddenAt = "COALESCE(hidden_at, NOW())"
+	} else if targetType == models.ReportTargetAnswer {
+		// An answer held for re-moderation stays hidden until it is released.
+		hiddenAt = "CASE WHEN review_held_at IS NOT NULL THEN hidden_at END"
+	}
+
+	var resolved int64
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE reports
+			SET status = $3, reviewed_at = NOW(), reviewed_by = $4
+			WHERE target_type = $1 AND target_id = $2 AND status = 'pending'
+		`, targetType, targetID, status, reviewedBy)
+		if err != nil {
+			if isInvalidUUIDError(err) {
+				return ErrNoPendingReports
+			}
+			return err
+		}
+		resolved = tag.RowsAffected()
+		if resolved == 0 {
+			return ErrNoPendingReports
+		}
+
+		if table, ok := reportTargetTables[targetType]; ok {
+			if _, err := tx.Exec(ctx,
+				`UPDATE `+table+` SET hidden_at = `+hiddenAt+` WHERE id = $1`,
+				targetID,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if !errors.Is(err, ErrNoPendingReports) {
+			LogQueryError(ctx, "Resolve", "reports", err)
+		}
+		return 0, err
+	}
+	return resolved, nil
+}
@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
@@ -211,3 +213,94 @@ func TestReportsRepository_HasReported(t *testing.T) {
 func randomSuffix() string {
 	return string(models.AuthorTypeHuman) + "_test"
 }
+
+func TestReportsRepository_AutoHideAndResolve(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	reportsRepo := NewReportsRepository(pool)
+	postRepo := NewPostRepository(pool)
+
+	post, err := postRepo.Create(ctx, &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Reported question for auto-hide",
+		Description:  "This question collects reports until it is hidden",
+		PostedByType: models.AuthorTypeAgent,
+		PostedByID:   "report_author_" + randomSuffix(),
+		Status:       models.PostStatusOpen,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test post: %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM reports WHERE target_id = $1", post.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID)
+	}()
+
+	exists, err := reportsRepo.TargetExists(ctx, models.ReportTargetPost, post.ID)
+	if err != nil || !exists {
+		t.Fatalf("TargetExists() = %v, %v; want true", exists, err)
+	}
+	if exists, _ := reportsRepo.TargetExists(ctx, models.ReportTargetPost, "not-a-uuid"); exists {
+		t.Error("TargetExists() should be false for a malformed ID")
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := reportsRepo.Create(ctx, &models.Report{
+			TargetType:   models.ReportTargetPost,
+			TargetID:     post.ID,
+			ReporterType: models.AuthorTypeAgent,
+			ReporterID:   "reporter_" + randomSuffix(),
+			Reason:       models.ReportReasonSpam,
+		})
+		if err != nil {
+			t.Fatalf("failed to create report: %v", err)
+		}
+
+		hidden, err := reportsRepo.HideIfReported(ctx, models.ReportTargetPost, post.ID, 3)
+		if err != nil {
+			t.Fatalf("HideIfReported() error = %v", err)
+		}
+		if wantHidden := i == 2; hidden != wantHidden {
+			t.Errorf("after %d reports hidden = %v, want %v", i+1, hidden, wantHidden)
+		}
+	}
+
+	items, _, err := reportsRepo.ListQueue(ctx, 1, 100)
+	if err != nil {
+		t.Fatalf("ListQueue() error = %v", err)
+	}
+	var found *models.ReportQueueItem
+	for i := range items {
+		if items[i].TargetID == post.ID {
+			found = &items[i]
+		}
+	}
+	if found == nil {
+		t.Fatal("reported post missing from queue")
+	}
+	if found.ReportCount != 3 || found.HiddenAt == nil {
+		t.Errorf("unexpected queue item: %+v", found)
+	}
+
+	resolved, err := reportsRepo.Resolve(ctx, models.ReportTargetPost, post.ID, models.ReportResolutionDismiss, "admin")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if resolved != 3 {
+		t.Errorf("Resolve() resolved %d reports, want 3", resolved)
+	}
+
+	var hiddenAt *time.Time
+	if err := pool.QueryRow(ctx, "SELECT hidden_at FROM posts WHERE id = $1", post.ID).Scan(&hiddenAt); err != nil {
+		t.Fatalf("query hidden_at: %v", err)
+	}
+	if hiddenAt != nil {
+		t.Error("expected dismissed post to be unhidden")
+	}
+
+	if _, err := reportsRepo.Resolve(ctx, models.ReportTargetPost, post.ID, models.ReportResolutionHide, "admin"); !errors.Is(err, ErrNoPendingReports) {
+		t.Errorf("second Resolve() error = %v, want ErrNoPendingReports", err)
+	}
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+	"github.com/jackc/pgx/v5"
+)
+
+// ReputationRepository reads a single human's or agent's current reputation,
+// computed like the leaderboard with reputation.BuildReputationSQL.
+type ReputationRepository struct {
+	pool *Pool
+}
+
+// NewReputationRepository creates a new ReputationRepository.
+func NewReputationRepository(pool *Pool) *ReputationRepository {
+	return &ReputationRepository{pool: pool}
+}
+
+var (
+	humanReputationQuery = `SELECT ` + reputation.BuildReputationSQL(reputation.SQLBuilderOptions{
+		EntityType:     "user",
+		EntityIDColumn: "u.id::text",
+		AuthorType:     "human",
+	}) + ` FROM users u WHERE u.id::text = $1`
+
+	agentReputationQuery = `SELECT ` + reputation.BuildReputationSQL(reputation.SQLBuilderOptions{
+		EntityType:     "agent",
+		EntityIDColumn: "a.id",
+		AuthorType:     "agent",
+		IncludeBonus:   true,
+		BonusColumn:    "a.reputation",
+	}) + ` FROM agents a WHERE a.id = $1`
+)
+
+// GetReputation returns the reputation of a human or agent. Unknown authors have 0.
+func (r *ReputationRepository) GetReputation(ctx context.Context, authorType models.AuthorType, authorID string) (int, error) {
+	query := humanReputationQuery
+	if authorType == models.AuthorTypeAgent {
+		query = agentReputationQuery
+	}
+
+	var rep int
+	err := r.pool.QueryRow(ctx, query, authorID).Scan(&rep)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "GetReputation", "reputation", err)
+		return 0, err
+	}
+	return rep, nil
+}
@@ -102,6 +102,15 @@ func TestReputation_CrossEndpointConsistency(t *testing.T) {
 		}
 	}
 
+	// 4. Get reputation from ReputationRepository (privilege checks)
+	reputationFromPrivileges, err := NewReputationRepository(pool).GetReputation(ctx, models.AuthorTypeHuman, created.ID)
+	if err != nil {
+		t.Fatalf("GetReputation error = %v", err)
+	}
+	if reputationFromStats != reputationFromPrivileges {
+		t.Errorf("❌ GetUserStats (%d) != GetReputation (%d)", reputationFromStats, reputationFromPrivileges)
+	}
+
 	// ASSERT: All three must match
 	t.Logf("Reputation from GetUserStats: %d", reputationFromStats)
 	t.Logf("Reputation from List: %d", reputationFromList)
@@ -20,7 +20,7 @@ type QueryEmbedder interface {
 	GenerateQueryEmbedding(ctx context.Context, text string) ([]float32, error)
 }
 
-// SearchRepository implements SearchRepositoryInterface for PostgreSQL.
+// SearchRepository implements handlers.SearchBackend for PostgreSQL.
 type SearchRepository struct {
 	pool             *Pool
 	embeddingService QueryEmbedder
@@ -41,7 +41,8 @@ func (r *SearchRepository) SetEmbeddingService(svc QueryEmbedder) {
 // Search performs a search across posts, answers, and approaches.
 // When an embedding service is configured, uses hybrid RRF search
 // (combining full-text keyword matching with vector semantic similarity).
-// Falls back to full-text only search if embedding service is nil or fails.
+// Falls back to full-text only search if embedding service is nil ("fulltext_only")
+// or fails ("fulltext_fallback").
 // Supports ContentTypes filter to search specific content sources.
 // When ContentTypes is empty, searches only posts (backwards compatible).
 func (r *SearchRepository) Search(ctx context.Context, query string, opts models.SearchOptions) ([]models.SearchResult, int, string, *float64, error) {
@@ -61,6 +62,7 @@ func (r *SearchRepository) Search(ctx context.Context, query string, opts models
 			// Hybrid search combines exact keyword matching (full-text) with semantic similarity (vector)
 			// If embedding generation fails, fall back to full-text only search
 			LogSearchEmbeddingFailed(ctx, err.Error())
+			searchMethod = "fulltext_fallback"
 		} else {
 			embDuration := time.Since(embStart).Milliseconds()
 			LogSearchEmbeddingGenerated(ctx, embDuration)
@@ -98,8 +100,9 @@ func (r *SearchRepository) Search(ctx context.Context, query string, opts models
 		allResults = append(allResults, answers...)
 	}
 
-	// Search approaches if explicitly requested
-	if containsContentType(contentTypes, "approaches") {
+	// Search approaches if explicitly requested. Approaches carry no code
+	// languages, so a language filter excludes them.
+	if containsContentType(contentTypes, "approaches") && len(opts.Languages) == 0 {
 		approaches, err := r.searchApproaches(ctx, tsquery, opts)
 		if err != nil {
 			return nil, 0, "", nil, err
@@ -157,10 +160,66 @@ func (r *SearchRepository) Search(ctx context.Context, query string, opts models
 		end = total
 	}
 
+	page := allResults[offset:end]
+	if err := r.attachFreshness(ctx, page); err != nil {
+		return nil, 0, "", nil, err
+	}
+
 	duration := time.Since(start).Milliseconds()
-	LogSearchCompleted(ctx, query, duration, len(allResults[offset:end]), searchMethod)
+	LogSearchCompleted(ctx, query, duration, len(page), searchMethod)
 
-	return allResults[offset:end], total, searchMethod, topSimilarity, nil
+	return page, total, searchMethod, topSimilarity, nil
+}
+
+// attachFreshness sets the stored freshness score and outdated flag on the post
+// results of a page. Answer and approach results are left alone.
+func (r *SearchRepository) attachFreshness(ctx context.Context, page []models.SearchResult) error {
+	var ids []string
+	for _, res := range page {
+		if res.Source == "post" {
+			ids = append(ids, res.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id::text, freshness_score, outdated
+		FROM posts
+		WHERE id = ANY($1::uuid[]) AND freshness_score IS NOT NULL`, ids)
+	if err != nil {
+		LogQueryError(ctx, "Search.attachFreshness", "posts", err)
+		return fmt.Errorf("load search result freshness: %w", err)
+	}
+	defer rows.Close()
+
+	type freshness struct {
+		score    int
+		outdated bool
+	}
+	byID := make(map[string]freshness, len(ids))
+	for rows.Next() {
+		var id string
+		var score int16
+		var outdated bool
+		if err := rows.Scan(&id, &score, &outdated); err != nil {
+			return fmt.Errorf("scan search result freshness: %w", err)
+		}
+		byID[id] = freshness{score: int(score), outdated: outdated}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate search result freshness: %w", err)
+	}
+
+	for i := range page {
+		if f, ok := byID[page[i].ID]; ok && page[i].Source == "post" {
+			score := f.score
+			page[i].FreshnessScore = &score
+			page[i].Outdated = f.outdated
+		}
+	}
+	return nil
 }
 
 // maxSimilarity returns a pointer to the highest non-nil Similarity across results,
@@ -203,9 +262,9 @@ func (r *SearchRepository) searchPosts(ctx context.Context, tsquery string, opts
 			) as author_name,
 			ts_rank(to_tsvector('english', p.title || ' ' || p.description), to_tsquery('english', $1)) as score,
 			(p.upvotes - p.downvotes) as vote_score,
-			COALESCE((SELECT COUNT(*) FROM answers WHERE question_id = p.id AND deleted_at IS NULL), 0) as answers_count,
-			COALESCE((SELECT COUNT(*) FROM approaches WHERE problem_id = p.id AND deleted_at IS NULL), 0) as approaches_count,
-			COALESCE((SELECT COUNT(*) FROM comments WHERE target_id = p.id AND target_type = 'post' AND deleted_at IS NULL), 0) as comments_count,
+			p.answers_count,
+			p.approaches_count,
+			p.comments_count,
 			COALESCE(p.view_count, 0) as view_count,
 			p.created_at,
 			CASE WHEN p.status = 'solved' THEN p.updated_at ELSE NULL END as solved_at,
@@ -213,7 +272,7 @@ func (r *SearchRepository) searchPosts(ctx context.Context, tsquery string, opts
 		FROM posts p
 		LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
 		LEFT JOIN agents a ON p.posted_by_type = 'agent' AND p.posted_by_id = a.id
-		WHERE p.deleted_at IS NULL
+		WHERE p.deleted_at IS NULL AND p.hidden_at IS NULL
 		AND p.status NOT IN ('pending_review', 'rejected', 'draft')
 		AND to_tsvector('english', p.title || ' ' || p.description) @@ to_tsquery('english', $1)
 	`
@@ -222,7 +281,8 @@ func (r *SearchRepository) searchPosts(ctx context.Context, tsquery string, opts
 	argNum := 2
 
 	// BART-151/152: family-scoped visibility (public, or the caller's own family).
-	baseQuery += " AND " + searchVisibilityClause("p", opts.ViewerHuman, &args, &argNum)
+	_, _, callerHuman := viewerFromContext(ctx)
+	baseQuery += " AND " + searchVisibilityClause("p", callerHuman, &args, &argNum)
 
 	filters, args, _ := buildSearchFilters(opts, args, argNum)
 	if filters != "" {
@@ -232,7 +292,7 @@ func (r *SearchRepository) searchPosts(ctx context.Context, tsquery string, opts
 	orderBy := getSearchOrderBy(opts.Sort)
 	baseQuery += " ORDER BY " + orderBy
 
-	rows, err := r.pool.Query(ctx, baseQuery, args...)
+	rows, err := r.pool.ReadQuery(ctx, baseQuery, args...)
 	if err != nil {
 		LogQueryError(ctx, "Search.Posts", "posts", err)
 		return nil, fmt.Errorf("search posts query failed: %w", err)
@@ -296,9 +356,9 @@ func (r *SearchRepository) searchPostsHybrid(ctx context.Context, embedding []fl
 			) as author_name,
 			hs.rrf_score as score,
 			(p.upvotes - p.downvotes) as vote_score,
-			COALESCE((SELECT COUNT(*) FROM answers WHERE question_id = p.id AND deleted_at IS NULL), 0) as answers_count,
-			COALESCE((SELECT COUNT(*) FROM approaches WHERE problem_id = p.id AND deleted_at IS NULL), 0) as approaches_count,
-			COALESCE((SELECT COUNT(*) FROM comments WHERE target_id = p.id AND target_type = 'post' AND deleted_at IS NULL), 0) as comments_count,
+			p.answers_count,
+			p.approaches_count,
+			p.comments_count,
 			COALESCE(p.view_count, 0) as view_count,
 			p.created_at,
 			CASE WHEN p.status = 'solved' THEN p.updated_at ELSE NULL END as solved_at,
@@ -311,11 +371,13 @@ func (r *SearchRepository) searchPostsHybrid(ctx context.Context, embedding []fl
 		LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
 		LEFT JOIN agents a ON p.posted_by_type = 'agent' AND p.posted_by_id = a.id
 		WHERE p.status NOT IN ('pending_review', 'rejected', 'draft')
+		AND p.hidden_at IS NULL
 	`
 
 	// BART-151: hybrid_search filters visibility inside both CTEs via viewer_human ($5,
 	// NULL for anonymous/cross-family → public-only), so joined post_ids are family-scoped.
-	args := []any{tsquery, queryVec, matchCount, tsquery, nullableViewer(opts.ViewerHuman)}
+	_, _, callerHuman := viewerFromContext(ctx)
+	args := []any{tsquery, queryVec, matchCount, tsquery, nullableViewer(callerHuman)}
 	argNum := 6
 
 	// Apply filters (reuse the same filter builder, but need to adjust field references)
@@ -327,7 +389,7 @@ func (r *SearchRepository) searchPostsHybrid(ctx context.Context, embedding []fl
 	// Order by real RRF score from hybrid_search (deterministic, survives JOINs)
 	baseQuery += " ORDER BY hs.rrf_score DESC"
 
-	rows, err := r.pool.Query(ctx, baseQuery, args...)
+	rows, err := r.pool.ReadQuery(ctx, baseQuery, args...)
 	if err != nil {
 		// If hybrid search fails (e.g., missing function), fall back to full-text
 		LogSearchEmbeddingFailed(ctx, fmt.Sprintf("hybrid_search query failed: %v", err))
@@ -349,6 +411,7 @@ func (r *SearchRepository) searchPostsHybrid(ctx context.Context, embedding []fl
 }
 
 // searchAnswers searches answers using full-text search on content.
+// Superseded answers whose chain has a newer verified version are down-weighted.
 // TODO: Wire up hybrid_search_answers() SQL function (migration 000045) for semantic search.
 // Currently only full-text; the SQL function exists but is not called from Go code.
 func (r *SearchRepository) searchAnswers(ctx context.Context, tsquery string, opts models.SearchOptions) ([]models.SearchResult, error) {
@@ -356,7 +419,13 @@ func (r *SearchRepository) searchAnswers(ctx context.Context, tsquery string, op
 	argNum := 2
 	// BART-152: family-scoped visibility (public, or the caller's own family) on the
 	// parent question — mirrors post search so an owner can find their own private answers.
-	visibility := searchVisibilityClause("p", opts.ViewerHuman, &args, &argNum)
+	_, _, callerHuman := viewerFromContext(ctx)
+	visibility := searchVisibilityClause("p", callerHuman, &args, &argNum)
+	langFilter := ""
+	if len(opts.Languages) > 0 {
+		langFilter = fmt.Sprintf("AND a.code_languages && $%d", argNum)
+		args = append(args, opts.Languages)
+	}
 	query := `
 		SELECT
 			a.id::text,
@@ -376,7 +445,9 @@ func (r *SearchRepository) searchAnswers(ctx context.Context, tsquery string, op
 				END,
 				a.author_id
 			) as author_name,
-			ts_rank(to_tsvector('english', a.content), to_tsquery('english', $1)) as score,
+			-- Answers with a newer verified version rank at half weight
+			ts_rank(to_tsvector('english', a.content), to_tsquery('english', $1))
+				* CASE WHEN latest_verified_answer_id(a.id) <> a.id THEN 0.5 ELSE 1 END as score,
 			(a.upvotes - a.downvotes) as vote_score,
 			0 as answers_count,
 			0 as approaches_count,
@@ -389,13 +460,14 @@ func (r *SearchRepository) searchAnswers(ctx context.Context, tsquery string, op
 		LEFT JOIN posts p ON a.question_id = p.id
 		LEFT JOIN users u ON a.author_type = 'human' AND a.author_id = u.id::text
 		LEFT JOIN agents ag ON a.author_type = 'agent' AND a.author_id = ag.id
-		WHERE a.deleted_at IS NULL
+		WHERE a.deleted_at IS NULL AND a.hidden_at IS NULL
 		AND ` + visibility + `
+		` + langFilter + `
 		AND to_tsvector('english', a.content) @@ to_tsquery('english', $1)
 		ORDER BY score DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := r.pool.ReadQuery(ctx, query, args...)
 	if err != nil {
 		LogQueryError(ctx, "Search.Answers", "answers", err)
 		return nil, fmt.Errorf("search answers query failed: %w", err)
@@ -422,7 +494,8 @@ func (r *SearchRepository) searchApproaches(ctx context.Context, tsquery string,
 	args := []any{tsquery}
 	argNum := 2
 	// BART-152: family-scoped visibility on the parent problem — mirrors post search.
-	visibility := searchVisibilityClause("p", opts.ViewerHuman, &args, &argNum)
+	_, _, callerHuman := viewerFromContext(ctx)
+	visibility := searchVisibilityClause("p", callerHuman, &args, &argNum)
 	query := `
 		SELECT
 			a.id::text,
@@ -463,7 +536,7 @@ func (r *SearchRepository) searchApproaches(ctx context.Context, tsquery string,
 		LEFT JOIN posts p ON a.problem_id = p.id
 		LEFT JOIN users u ON a.author_type = 'human' AND a.author_id = u.id::text
 		LEFT JOIN agents ag ON a.author_type = 'agent' AND a.author_id = ag.id
-		WHERE a.deleted_at IS NULL
+		WHERE a.deleted_at IS NULL AND a.hidden_at IS NULL
 		AND ` + visibility + `
 		AND to_tsvector('english',
 			COALESCE(a.angle, '') || ' ' || COALESCE(a.method, '') || ' ' ||
@@ -472,7 +545,7 @@ func (r *SearchRepository) searchApproaches(ctx context.Context, tsquery string,
 		ORDER BY score DESC
 	`
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := r.pool.ReadQuery(ctx, query, args...)
 	if err != nil {
 		LogQueryError(ctx, "Search.Approaches", "approaches", err)
 		return nil, fmt.Errorf("search approaches query failed: %w", err)
@@ -564,6 +637,12 @@ func buildSearchFilters(opts models.SearchOptions, args []any, argNum int) (stri
 		argNum++
 	}
 
+	if len(opts.Languages) > 0 {
+		filters = append(filters, fmt.Sprintf("AND p.code_languages && $%d", argNum))
+		args = append(args, opts.Languages)
+		argNum++
+	}
+
 	if opts.Author != "" {
 		filters = append(filters, fmt.Sprintf("AND p.posted_by_id = $%d", argNum))
 		args = append(args, opts.Author)
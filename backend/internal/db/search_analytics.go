@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -18,18 +19,27 @@ func NewSearchAnalyticsRepository(pool *Pool) *SearchAnalyticsRepository {
 	return &SearchAnalyticsRepository{pool: pool}
 }
 
-// Insert stores a single search query record.
+// Insert stores a single search query record. When sq.SearcherType is empty the
+// searcher is taken from the principal in ctx, or recorded as anonymous.
 func (r *SearchAnalyticsRepository) Insert(ctx context.Context, sq models.SearchQuery) error {
+	if sq.SearcherType == "" {
+		sq.SearcherType = "anonymous"
+		if p := auth.PrincipalFromContext(ctx); p != nil {
+			sq.SearcherType = string(p.Type)
+			sq.SearcherID = &p.ID
+		}
+	}
 	_, err := r.pool.Exec(ctx, `
 		INSERT INTO search_queries (
 			query, query_normalized, type_filter, results_count,
 			search_method, duration_ms, searcher_type, searcher_id,
-			ip_address, user_agent, page, searched_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			ip_address, user_agent, page, searched_at, result_post_ids
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, COALESCE($13::uuid[], '{}'))
 	`,
 		sq.Query, sq.QueryNormalized, sq.TypeFilter, sq.ResultsCount,
 		sq.SearchMethod, sq.DurationMs, sq.SearcherType, sq.SearcherID,
 		nilIfEmpty(sq.IPAddress), nilIfEmpty(sq.UserAgent), sq.Page, sq.SearchedAt,
+		sq.ResultPostIDs,
 	)
 	if err != nil {
 		return fmt.Errorf("insert search query: %w", err)
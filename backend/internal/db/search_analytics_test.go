@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
@@ -109,6 +110,42 @@ func TestSearchAnalyticsRepository_Insert_Anonymous(t *testing.T) {
 	_, _ = pool.Exec(ctx, "DELETE FROM search_queries WHERE query LIKE 'test_%'")
 }
 
+func TestSearchAnalyticsRepository_Insert_SearcherFromPrincipal(t *testing.T) {
+	pool, repo := setupSearchAnalyticsTest(t)
+	defer pool.Close()
+
+	ctx := auth.ContextWithPrincipal(context.Background(), &auth.Principal{
+		Type: models.AuthorTypeAgent,
+		ID:   "agent-from-context",
+	})
+	sq := models.SearchQuery{
+		Query:           "test_principal search",
+		QueryNormalized: "test_principal search",
+		SearchMethod:    "fulltext",
+		Page:            1,
+		SearchedAt:      time.Now(),
+	}
+
+	if err := repo.Insert(ctx, sq); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+
+	var searcherType string
+	var searcherID *string
+	err := pool.QueryRow(ctx,
+		"SELECT searcher_type, searcher_id FROM search_queries WHERE query = 'test_principal search'",
+	).Scan(&searcherType, &searcherID)
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if searcherType != "agent" || searcherID == nil || *searcherID != "agent-from-context" {
+		t.Errorf("searcher = %s %v, want agent agent-from-context", searcherType, searcherID)
+	}
+
+	// Cleanup
+	_, _ = pool.Exec(ctx, "DELETE FROM search_queries WHERE query LIKE 'test_%'")
+}
+
 func TestSearchAnalyticsRepository_GetTrending(t *testing.T) {
 	pool, repo := setupSearchAnalyticsTest(t)
 	defer pool.Close()
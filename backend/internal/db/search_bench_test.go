@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Benchmarks for the other hot paths: full-text search, filtered list counts and
+// post creation. With only the 100 posts created here the numbers mostly reflect
+// per-query overhead; populate the database with cmd/loadgen first to measure
+// realistic volumes.
+//
+// Run: DATABASE_URL=... go test ./internal/db/... -bench='BenchmarkSearchRepository|BenchmarkPostRepository' -benchmem -count=3
+
+func BenchmarkSearchRepository_Search_Fulltext(b *testing.B) {
+	pool := getBenchPool(b)
+	defer pool.Close()
+
+	cleanup := setupBenchmarkData(b, pool, 100, 5, 3)
+	defer cleanup()
+
+	repo := NewSearchRepository(pool)
+	ctx := context.Background()
+	opts := models.SearchOptions{Page: 1, PerPage: 20}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _, _, err := repo.Search(ctx, "benchmark perf", opts)
+		if err != nil {
+			b.Fatalf("Search() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkPostRepository_List_FilteredCount(b *testing.B) {
+	pool := getBenchPool(b)
+	defer pool.Close()
+
+	cleanup := setupBenchmarkData(b, pool, 100, 5, 3)
+	defer cleanup()
+
+	repo := NewPostRepository(pool)
+	ctx := context.Background()
+	opts := models.PostListOptions{Page: 5, PerPage: 20, Type: models.PostTypeQuestion, Status: models.PostStatusOpen, Tags: []string{"bench"}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, err := repo.List(ctx, opts)
+		if err != nil {
+			b.Fatalf("List() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkPostRepository_Create(b *testing.B) {
+	pool := getBenchPool(b)
+	defer pool.Close()
+
+	repo := NewPostRepository(pool)
+	ctx := context.Background()
+	postIDs := make([]string, 0, b.N)
+	defer func() {
+		for _, id := range postIDs {
+			_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", id)
+		}
+	}()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		post, err := repo.Create(ctx, &models.Post{
+			Type:         models.PostTypeProblem,
+			Title:        fmt.Sprintf("Bench Create %d", i),
+			Description:  fmt.Sprintf("Benchmark post %d created to measure insert cost", i),
+			Tags:         []string{"bench", "perf"},
+			PostedByType: models.AuthorTypeAgent,
+			PostedByID:   "bench_agent",
+			Status:       models.PostStatusOpen,
+		})
+		if err != nil {
+			b.Fatalf("Create() error = %v", err)
+		}
+		postIDs = append(postIDs, post.ID)
+	}
+}
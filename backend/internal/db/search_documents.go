@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// SearchDocumentRepository loads posts, answers and approaches in the shape an
+// external search index stores them, and tracks each indexer's position in the
+// change event log.
+type SearchDocumentRepository struct {
+	pool *Pool
+}
+
+// NewSearchDocumentRepository creates a new SearchDocumentRepository.
+func NewSearchDocumentRepository(pool *Pool) *SearchDocumentRepository {
+	return &SearchDocumentRepository{pool: pool}
+}
+
+// searchAuthorName is the author display name, falling back to the author ID.
+const searchAuthorName = `COALESCE(
+			CASE WHEN %[1]s = 'human' THEN u.display_name ELSE ag.display_name END,
+			%[2]s)`
+
+// LoadDocuments returns the searchable documents among the given posts,
+// answers and approaches, plus every answer and approach under the given posts
+// (they inherit the post's tags and visibility). Rows that are deleted, hidden
+// or not yet public (drafts, pending review, rejected) are left out, so the
+// caller removes them from the index.
+func (r *SearchDocumentRepository) LoadDocuments(ctx context.Context, postIDs, answerIDs, approachIDs []string) ([]models.SearchDocument, error) {
+	docs := []models.SearchDocument{}
+	if len(postIDs) > 0 {
+		rows, err := r.pool.Query(ctx, `
+			SELECT 'post', p.id::text, p.id::text, p.type, p.title, p.description,
+			       COALESCE(p.tags, '{}'), COALESCE(p.code_languages, '{}'), p.status,
+			       p.posted_by_type, p.posted_by_id, `+fmt.Sprintf(searchAuthorName, "p.posted_by_type", "p.posted_by_id")+`,
+			       p.upvotes - p.downvotes, p.answers_count, p.approaches_count, p.comments_count,
+			       COALESCE(p.view_count, 0), p.visibility, COALESCE(p.owner_human_id::text, ''), p.tenant_id,
+			       p.freshness_score, p.outdated, p.created_at, p.updated_at,
+			       CASE WHEN p.status = 'solved' THEN p.updated_at END
+			FROM posts p
+			LEFT JOIN users u ON p.posted_by_type = 'human' AND p.posted_by_id = u.id::text
+			LEFT JOIN agents ag ON p.posted_by_type = 'agent' AND p.posted_by_id = ag.id
+			WHERE p.id = ANY($1::uuid[])
+			  AND p.deleted_at IS NULL AND p.hidden_at IS NULL
+			  AND p.status NOT IN ('pending_review', 'rejected', 'draft')`, postIDs)
+		if err != nil {
+			LogQueryError(ctx, "LoadDocuments.Posts", "posts", err)
+			return nil, fmt.Errorf("load post search documents: %w", err)
+		}
+		if docs, err = scanSearchDocuments(rows, docs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(postIDs) > 0 || len(answerIDs) > 0 {
+		rows, err := r.pool.Query(ctx, `
+			SELECT 'answer', a.id::text, p.id::text, 'answer', '', a.content,
+			       COALESCE(p.tags, '{}'), COALESCE(a.code_languages, '{}'),
+			       CASE WHEN a.is_accepted THEN 'accepted' ELSE '' END,
+			       a.author_type, a.author_id, `+fmt.Sprintf(searchAuthorName, "a.author_type", "a.author_id")+`,
+			       COALESCE(a.upvotes, 0) - COALESCE(a.downvotes, 0), 0, 0, 0,
+			       0, p.visibility, COALESCE(p.owner_human_id::text, ''), a.tenant_id,
+			       NULL::smallint, false, a.created_at, COALESCE(a.updated_at, a.created_at),
+			       NULL::timestamptz
+			FROM answers a
+			JOIN posts p ON a.question_id = p.id
+			LEFT JOIN users u ON a.author_type = 'human' AND a.author_id = u.id::text
+			LEFT JOIN agents ag ON a.author_type = 'agent' AND a.author_id = ag.id
+			WHERE (a.id = ANY($1::uuid[]) OR a.question_id = ANY($2::uuid[]))
+			  AND a.deleted_at IS NULL AND a.hidden_at IS NULL`, answerIDs, postIDs)
+		if err != nil {
+			LogQueryError(ctx, "LoadDocuments.Answers", "answers", err)
+			return nil, fmt.Errorf("load answer search documents: %w", err)
+		}
+		if docs, err = scanSearchDocuments(rows, docs); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(postIDs) > 0 || len(approachIDs) > 0 {
+		rows, err := r.pool.Query(ctx, `
+			SELECT 'approach', a.id::text, p.id::text, 'approach',
+			       COALESCE(a.angle, '') || ' ' || COALESCE(a.method, ''),
+			       COALESCE(a.angle, '') || ' ' || COALESCE(a.method, '') || ' ' ||
+			       COALESCE(a.outcome, '') || ' ' || COALESCE(a.solution, ''),
+			       COALESCE(p.tags, '{}'), '{}'::text[], a.status::text,
+			       a.author_type, a.author_id, `+fmt.Sprintf(searchAuthorName, "a.author_type", "a.author_id")+`,
+			       0, 0, 0, 0,
+			       0, p.visibility, COALESCE(p.owner_human_id::text, ''), a.tenant_id,
+			       NULL::smallint, false, a.created_at, COALESCE(a.updated_at, a.created_at),
+			       NULL::timestamptz
+			FROM approaches a
+			JOIN posts p ON a.problem_id = p.id
+			LEFT JOIN users u ON a.author_type = 'human' AND a.author_id = u.id::text
+			LEFT JOIN agents ag ON a.author_type = 'agent' AND a.author_id = ag.id
+			WHERE (a.id = ANY($1::uuid[]) OR a.problem_id = ANY($2::uuid[]))
+			  AND a.deleted_at IS NULL AND a.hidden_at IS NULL`, approachIDs, postIDs)
+		if err != nil {
+			LogQueryError(ctx, "LoadDocuments.Approaches", "approaches", err)
+			return nil, fmt.Errorf("load approach search documents: %w", err)
+		}
+		if docs, err = scanSearchDocuments(rows, docs); err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// scanSearchDocuments appends the rows of a LoadDocuments query to docs and closes rows.
+func scanSearchDocuments(rows pgx.Rows, docs []models.SearchDocument) ([]models.SearchDocument, error) {
+	defer rows.Close()
+	for rows.Next() {
+		var d models.SearchDocument
+		var freshness *int16
+		if err := rows.Scan(
+			&d.Source, &d.ID, &d.PostID, &d.Type, &d.Title, &d.Description,
+			&d.Tags, &d.CodeLanguages, &d.Status,
+			&d.AuthorType, &d.AuthorID, &d.AuthorName,
+			&d.VoteScore, &d.AnswersCount, &d.ApproachesCount, &d.CommentsCount,
+			&d.ViewCount, &d.Visibility, &d.OwnerHumanID, &d.TenantID,
+			&freshness, &d.Outdated, &d.CreatedAt, &d.UpdatedAt,
+			&d.SolvedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan search document: %w", err)
+		}
+		if freshness != nil {
+			score := int(*freshness)
+			d.FreshnessScore = &score
+		}
+		docs = append(docs, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate search documents: %w", err)
+	}
+	return docs, nil
+}
+
+// ListPostIDs returns up to limit post IDs greater than afterID, in ID order,
+// for rebuilding an index. Loading a post also loads its answers and approaches.
+func (r *SearchDocumentRepository) ListPostIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	var after any // NULL starts from the first post
+	if afterID != "" {
+		after = afterID
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT id::text FROM posts
+		WHERE $1::uuid IS NULL OR id > $1::uuid
+		ORDER BY id
+		LIMIT $2`, after, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListPostIDs", "posts", err)
+		return nil, fmt.Errorf("list post ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan post id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetCursor returns the last change event seq the named indexer applied. ok
+// is false if it has never completed a run.
+func (r *SearchDocumentRepository) GetCursor(ctx context.Context, name string) (seq int64, ok bool, err error) {
+	err = r.pool.QueryRow(ctx, `SELECT last_seq FROM search_index_cursors WHERE name = $1`, name).Scan(&seq)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "GetCursor", "search_index_cursors", err)
+		return 0, false, fmt.Errorf("get search index cursor: %w", err)
+	}
+	return seq, true, nil
+}
+
+// SetCursor records the last change event seq the named indexer applied.
+func (r *SearchDocumentRepository) SetCursor(ctx context.Context, name string, seq int64) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO search_index_cursors (name, last_seq) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET last_seq = EXCLUDED.last_seq, updated_at = NOW()`, name, seq)
+	if err != nil {
+		LogQueryError(ctx, "SetCursor", "search_index_cursors", err)
+		return fmt.Errorf("set search index cursor: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSearchDocumentRepository_LoadDocuments(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+	ctx := context.Background()
+	repo := NewSearchDocumentRepository(pool)
+
+	var openID, draftID string
+	for _, p := range []struct {
+		id     *string
+		status string
+	}{{&openID, "open"}, {&draftID, "draft"}} {
+		err := pool.QueryRow(ctx, `
+			INSERT INTO posts (type, title, description, tags, posted_by_type, posted_by_id, status)
+			VALUES ('question', 'Search document question title', 'Search document question body long enough', ARRAY['go'], 'human', 'test-user', $1)
+			RETURNING id::text`, p.status).Scan(p.id)
+		if err != nil {
+			t.Fatalf("insert post error = %v", err)
+		}
+	}
+	var answerID string
+	err := pool.QueryRow(ctx, `
+		INSERT INTO answers (question_id, author_type, author_id, content)
+		VALUES ($1, 'agent', 'test-agent', 'Search document answer content')
+		RETURNING id::text`, openID).Scan(&answerID)
+	if err != nil {
+		t.Fatalf("insert answer error = %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM answers WHERE id = $1`, answerID)
+		_, _ = pool.Exec(ctx, `DELETE FROM posts WHERE id IN ($1, $2)`, openID, draftID)
+	})
+
+	// Loading a post loads its answers; drafts are not searchable.
+	docs, err := repo.LoadDocuments(ctx, []string{openID, draftID}, nil, nil)
+	if err != nil {
+		t.Fatalf("LoadDocuments() error = %v", err)
+	}
+	got := map[string]bool{}
+	for _, d := range docs {
+		got[d.DocumentID()] = true
+		if d.Source == "answer" && (len(d.Tags) != 1 || d.Tags[0] != "go" || d.PostID != openID) {
+			t.Errorf("answer document should carry its question's tags: %+v", d)
+		}
+	}
+	if !got["post:"+openID] || !got["answer:"+answerID] || got["post:"+draftID] {
+		t.Errorf("LoadDocuments() = %v", got)
+	}
+
+	if err := repo.SetCursor(ctx, "test-index", 12); err != nil {
+		t.Fatalf("SetCursor() error = %v", err)
+	}
+	t.Cleanup(func() { _, _ = pool.Exec(ctx, `DELETE FROM search_index_cursors WHERE name = 'test-index'`) })
+	if seq, ok, err := repo.GetCursor(ctx, "test-index"); err != nil || !ok || seq != 12 {
+		t.Errorf("GetCursor() = %d, %v, %v; want 12, true", seq, ok, err)
+	}
+}
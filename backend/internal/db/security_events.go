@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// SecurityEventRepository stores principals' sign-in history.
+type SecurityEventRepository struct {
+	pool *Pool
+}
+
+// NewSecurityEventRepository creates a new SecurityEventRepository.
+func NewSecurityEventRepository(pool *Pool) *SecurityEventRepository {
+	return &SecurityEventRepository{pool: pool}
+}
+
+const securityEventColumns = `id::text, principal_type, principal_id, method, ip_address, user_agent, COALESCE(country, ''), anomalies, created_at`
+
+func scanSecurityEvent(row interface{ Scan(dest ...any) error }) (*models.SecurityEvent, error) {
+	var e models.SecurityEvent
+	err := row.Scan(&e.ID, &e.PrincipalType, &e.PrincipalID, &e.Method, &e.IPAddress, &e.UserAgent, &e.Country, &e.Anomalies, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if e.Anomalies == nil {
+		e.Anomalies = []string{}
+	}
+	return &e, nil
+}
+
+// Create stores ev and fills in its ID and creation time.
+func (r *SecurityEventRepository) Create(ctx context.Context, ev *models.SecurityEvent) error {
+	anomalies := ev.Anomalies
+	if anomalies == nil {
+		anomalies = []string{}
+	}
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO security_events (principal_type, principal_id, method, ip_address, user_agent, country, anomalies)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+		RETURNING id::text, created_at`,
+		ev.PrincipalType, ev.PrincipalID, ev.Method, ev.IPAddress, ev.UserAgent, ev.Country, anomalies,
+	).Scan(&ev.ID, &ev.CreatedAt)
+	if err != nil {
+		LogQueryError(ctx, "Create", "security_events", err)
+		return fmt.Errorf("create security event: %w", err)
+	}
+	return nil
+}
+
+// History returns up to limit of a principal's events created after since,
+// newest first.
+func (r *SecurityEventRepository) History(ctx context.Context, principalType, principalID string, since time.Time, limit int) ([]models.SecurityEvent, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+securityEventColumns+`
+		FROM security_events
+		WHERE principal_type = $1 AND principal_id = $2 AND created_at > $3
+		ORDER BY created_at DESC
+		LIMIT $4`, principalType, principalID, since, limit)
+	if err != nil {
+		LogQueryError(ctx, "History", "security_events", err)
+		return nil, fmt.Errorf("list security history: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]models.SecurityEvent, 0)
+	for rows.Next() {
+		e, err := scanSecurityEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan security event: %w", err)
+		}
+		events = append(events, *e)
+	}
+	return events, rows.Err()
+}
+
+// List returns a page of a principal's events, newest first, and their total.
+// With anomalousOnly, only events that raised an alert are returned.
+func (r *SecurityEventRepository) List(ctx context.Context, principalType, principalID string, anomalousOnly bool, page, perPage int) ([]models.SecurityEvent, int, error) {
+	where := `principal_type = $1 AND principal_id = $2`
+	if anomalousOnly {
+		where += ` AND cardinality(anomalies) > 0`
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM security_events WHERE `+where, principalType, principalID).Scan(&total); err != nil {
+		LogQueryError(ctx, "List", "security_events", err)
+		return nil, 0, fmt.Errorf("count security events: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+securityEventColumns+`
+		FROM security_events
+		WHERE `+where+`
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4`, principalType, principalID, perPage, (page-1)*perPage)
+	if err != nil {
+		LogQueryError(ctx, "List", "security_events", err)
+		return nil, 0, fmt.Errorf("list security events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]models.SecurityEvent, 0)
+	for rows.Next() {
+		e, err := scanSecurityEvent(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan security event: %w", err)
+		}
+		events = append(events, *e)
+	}
+	return events, total, rows.Err()
+}
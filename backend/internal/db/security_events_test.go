@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestSecurityEventRepository_CreateAndList(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewSecurityEventRepository(pool)
+	principalID := "agent_security_events_test_" + time.Now().Format("150405.000000")
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM security_events WHERE principal_id = $1", principalID)
+	}()
+
+	first := &models.SecurityEvent{
+		PrincipalType: "agent", PrincipalID: principalID, Method: models.SecurityMethodAPIKey,
+		IPAddress: "203.0.113.1", UserAgent: "curl/8.0", Country: "BR",
+	}
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if first.ID == "" || first.CreatedAt.IsZero() {
+		t.Fatalf("Create() did not fill ID and CreatedAt: %+v", first)
+	}
+	second := &models.SecurityEvent{
+		PrincipalType: "agent", PrincipalID: principalID, Method: models.SecurityMethodAPIKey,
+		IPAddress: "198.51.100.7", Anomalies: []string{models.SecurityAnomalyManyIPs},
+	}
+	if err := repo.Create(ctx, second); err != nil {
+		t.Fatalf("Create() second error = %v", err)
+	}
+
+	history, err := repo.History(ctx, "agent", principalID, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 || history[0].ID != second.ID || history[1].Country != "BR" {
+		t.Fatalf("History() = %+v, want both events newest first", history)
+	}
+
+	events, total, err := repo.List(ctx, "agent", principalID, true, 1, 20)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if total != 1 || len(events) != 1 || events[0].Anomalies[0] != models.SecurityAnomalyManyIPs {
+		t.Errorf("List(anomalous) = %+v (total %d), want only the flagged event", events, total)
+	}
+}
@@ -36,6 +36,7 @@ func (r *SitemapRepository) GetSitemapURLs(ctx context.Context) (*models.Sitemap
 		WHERE deleted_at IS NULL
 		AND visibility = 'public' -- BART-151: private posts never in the public sitemap
 		AND status NOT IN ('draft', 'pending_review', 'rejected')
+		AND hidden_at IS NULL
 		AND (
 			(type = 'problem' AND (status = 'solved' OR upvotes - downvotes >= 1))
 			OR (type = 'idea' AND (upvotes - downvotes >= 2))
@@ -155,6 +156,7 @@ func (r *SitemapRepository) GetSitemapCounts(ctx context.Context) (*models.Sitem
 		WHERE deleted_at IS NULL
 		AND visibility = 'public' -- BART-151: private posts never in the public sitemap
 		AND status NOT IN ('draft', 'pending_review', 'rejected')
+		AND hidden_at IS NULL
 		AND (
 			(type = 'problem' AND (status = 'solved' OR upvotes - downvotes >= 1))
 			OR (type = 'idea' AND (upvotes - downvotes >= 2))
@@ -223,6 +225,7 @@ func (r *SitemapRepository) GetPaginatedSitemapURLs(ctx context.Context, opts mo
 			WHERE deleted_at IS NULL
 			AND visibility = 'public' -- BART-151: private posts never in the public sitemap
 			AND status NOT IN ('draft', 'pending_review', 'rejected')
+			AND hidden_at IS NULL
 			AND (
 				(type = 'problem' AND (status = 'solved' OR upvotes - downvotes >= 1))
 				OR (type = 'idea' AND (upvotes - downvotes >= 2))
@@ -4,6 +4,7 @@ package db
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -22,19 +23,80 @@ func NewStaleContentRepository(pool *Pool, notifRepo *NotificationsRepository) *
 	return &StaleContentRepository{pool: pool, notifRepo: notifRepo}
 }
 
+// StaleContentAuthorID is the author ID of the system comment left on approaches
+// the stale content job abandons, and the actor ID of their 'abandoned' events.
+const StaleContentAuthorID = "stale_content"
+
+// Notification types for the two abandonment warning tiers.
+const (
+	notificationAbandonmentWarning  = "approach_abandonment_warning"
+	notificationAbandonmentReminder = "approach_abandonment_reminder"
+)
+
+// staleThresholdsCTE resolves the thresholds of each problem weight in policy. It
+// expects the arguments returned by staleThresholdArgs as $1 to $4; approaches
+// join it on their problem's weight and fall back to staleDefaultSecs.
+const staleThresholdsCTE = `
+	thresholds AS (
+		SELECT * FROM unnest($1::int[], $2::float8[], $3::float8[], $4::float8[])
+			AS t(weight, warning_secs, reminder_secs, abandon_secs)
+	)`
+
+// staleThresholdArgs flattens policy into the per-weight arrays of
+// staleThresholdsCTE followed by the default warning, reminder and abandon ages
+// in seconds ($5 to $7).
+func staleThresholdArgs(policy models.StaleApproachPolicy) []any {
+	var weights []int32
+	var warning, reminder, abandon []float64
+	for weight, t := range policy.ByWeight {
+		weights = append(weights, int32(weight))
+		warning = append(warning, t.Warning.Seconds())
+		reminder = append(reminder, t.Reminder.Seconds())
+		abandon = append(abandon, t.Abandon.Seconds())
+	}
+	return []any{
+		weights, warning, reminder, abandon,
+		policy.Default.Warning.Seconds(), policy.Default.Reminder.Seconds(), policy.Default.Abandon.Seconds(),
+	}
+}
+
 // AbandonStaleApproaches updates approaches in 'working' or 'starting' status
-// that haven't been updated for longer than olderThan to 'abandoned' status.
+// that have been inactive longer than their problem weight's abandon threshold to
+// 'abandoned' status. Approaches marked long-running or on held problems (see
+// post_retention.go) are skipped. Each abandoned approach gets a system comment
+// explaining why and an 'abandoned' event by the system on its timeline.
 // Returns the number of approaches abandoned.
-func (r *StaleContentRepository) AbandonStaleApproaches(ctx context.Context, olderThan time.Duration) (int64, error) {
-	cutoff := time.Now().Add(-olderThan)
+func (r *StaleContentRepository) AbandonStaleApproaches(ctx context.Context, policy models.StaleApproachPolicy) (int64, error) {
+	args := append(staleThresholdArgs(policy), StaleContentAuthorID)
 
 	result, err := r.pool.Exec(ctx, `
-		UPDATE approaches
-		SET status = 'abandoned', updated_at = NOW()
-		WHERE status IN ('working', 'starting')
-		  AND updated_at < $1
-		  AND deleted_at IS NULL
-	`, cutoff)
+		WITH`+staleThresholdsCTE+`,
+		abandoned AS (
+			UPDATE approaches a
+			SET status = 'abandoned', updated_at = NOW()
+			FROM posts p
+			LEFT JOIN thresholds t ON t.weight = p.weight
+			WHERE p.id = a.problem_id
+			  AND a.status IN ('working', 'starting')
+			  AND a.updated_at < NOW() - make_interval(secs => COALESCE(t.abandon_secs, $7))
+			  AND a.deleted_at IS NULL
+			  AND NOT a.long_running
+			  AND NOT `+heldPostCondition("p.id")+`
+			RETURNING a.id, COALESCE(t.abandon_secs, $7) AS abandon_secs
+		),
+		events AS (
+			INSERT INTO approach_events (approach_id, event_type, actor_type, actor_id, data)
+			SELECT id, 'abandoned', 'system', $8, jsonb_build_object('reason', 'inactive')
+			FROM abandoned
+		)
+		INSERT INTO comments (target_type, target_id, author_type, author_id, content)
+		SELECT 'approach', id, 'system', $8, format(
+			'This approach was automatically abandoned after %s days without activity. '
+			|| 'Its author can set it back to working; approaches marked long-running are never auto-abandoned.',
+			round(abandon_secs / 86400)
+		)
+		FROM abandoned
+	`, args...)
 	if err != nil {
 		LogQueryError(ctx, "AbandonStaleApproaches", "approaches", err)
 		return 0, fmt.Errorf("failed to abandon stale approaches: %w", err)
@@ -43,30 +105,54 @@ func (r *StaleContentRepository) AbandonStaleApproaches(ctx context.Context, old
 	return result.RowsAffected(), nil
 }
 
-// WarnApproachesApproachingAbandonment finds approaches in 'working' or 'starting'
-// status that are between warningThreshold and abandonThreshold old, and creates
-// a warning notification for each approach author.
+// WarnApproachesApproachingAbandonment sends the first abandonment warning for
+// approaches in 'working' or 'starting' status that have been inactive between
+// their problem weight's warning and reminder thresholds. Approaches marked
+// long-running or on held problems are skipped, and each approach is warned once
+// per inactive stretch.
 // Returns the number of warnings sent.
-func (r *StaleContentRepository) WarnApproachesApproachingAbandonment(ctx context.Context, warningThreshold, abandonThreshold time.Duration) (int64, error) {
-	warningCutoff := time.Now().Add(-warningThreshold)
-	abandonCutoff := time.Now().Add(-abandonThreshold)
+func (r *StaleContentRepository) WarnApproachesApproachingAbandonment(ctx context.Context, policy models.StaleApproachPolicy) (int64, error) {
+	return r.warnStaleApproaches(ctx, policy, notificationAbandonmentWarning)
+}
+
+// RemindApproachesApproachingAbandonment sends the abandonment reminder for
+// approaches that have been inactive between their problem weight's reminder and
+// abandon thresholds, like WarnApproachesApproachingAbandonment.
+// Returns the number of reminders sent.
+func (r *StaleContentRepository) RemindApproachesApproachingAbandonment(ctx context.Context, policy models.StaleApproachPolicy) (int64, error) {
+	return r.warnStaleApproaches(ctx, policy, notificationAbandonmentReminder)
+}
+
+// warnStaleApproaches notifies the authors of approaches in the warning window of
+// notifType: from the warning to the reminder threshold for warnings, and from
+// the reminder to the abandon threshold for reminders.
+func (r *StaleContentRepository) warnStaleApproaches(ctx context.Context, policy models.StaleApproachPolicy, notifType string) (int64, error) {
+	windowStart, windowEnd := "COALESCE(t.warning_secs, $5)", "COALESCE(t.reminder_secs, $6)"
+	if notifType == notificationAbandonmentReminder {
+		windowStart, windowEnd = "COALESCE(t.reminder_secs, $6)", "COALESCE(t.abandon_secs, $7)"
+	}
+	args := append(staleThresholdArgs(policy), notifType)
 
-	// Find approaches in the warning window (between warningThreshold and abandonThreshold old)
 	rows, err := r.pool.Query(ctx, `
-		SELECT a.id, a.angle, a.author_type, a.author_id, a.problem_id, p.title
+		WITH`+staleThresholdsCTE+`
+		SELECT a.id, a.angle, a.author_type, a.author_id, a.problem_id, p.title,
+		       a.updated_at, COALESCE(t.abandon_secs, $7)
 		FROM approaches a
 		JOIN posts p ON p.id = a.problem_id
+		LEFT JOIN thresholds t ON t.weight = p.weight
 		WHERE a.status IN ('working', 'starting')
-		  AND a.updated_at < $1
-		  AND a.updated_at >= $2
+		  AND a.updated_at < NOW() - make_interval(secs => `+windowStart+`)
+		  AND a.updated_at >= NOW() - make_interval(secs => `+windowEnd+`)
 		  AND a.deleted_at IS NULL
+		  AND NOT a.long_running
+		  AND NOT `+heldPostCondition("p.id")+`
 		  AND NOT EXISTS (
 		    SELECT 1 FROM notifications n
-		    WHERE n.type = 'approach_abandonment_warning'
+		    WHERE n.type = $8
 		      AND n.body LIKE '%' || a.id::text || '%'
-		      AND n.created_at > $2
+		      AND n.created_at > a.updated_at
 		  )
-	`, warningCutoff, abandonCutoff)
+	`, args...)
 	if err != nil {
 		LogQueryError(ctx, "WarnApproachesApproachingAbandonment.Query", "approaches", err)
 		return 0, fmt.Errorf("failed to query approaches for warning: %w", err)
@@ -76,16 +162,26 @@ func (r *StaleContentRepository) WarnApproachesApproachingAbandonment(ctx contex
 	var warned int64
 	for rows.Next() {
 		var approachID, angle, authorType, authorID, problemID, problemTitle string
-		if err := rows.Scan(&approachID, &angle, &authorType, &authorID, &problemID, &problemTitle); err != nil {
+		var updatedAt time.Time
+		var abandonSecs float64
+		if err := rows.Scan(&approachID, &angle, &authorType, &authorID, &problemID, &problemTitle, &updatedAt, &abandonSecs); err != nil {
 			LogQueryError(ctx, "WarnApproachesApproachingAbandonment.Scan", "approaches", err)
 			continue
 		}
 
+		// Whole days left before the abandon threshold, at least one
+		abandonAt := updatedAt.Add(time.Duration(abandonSecs * float64(time.Second)))
+		daysLeft := max(int(math.Ceil(time.Until(abandonAt).Hours()/24)), 1)
+
 		// Create warning notification for the approach author
+		title := fmt.Sprintf("Your approach on \"%s\" will be auto-abandoned in %d days", problemTitle, daysLeft)
+		if notifType == notificationAbandonmentReminder {
+			title = "Reminder: " + title
+		}
 		notif := &models.Notification{
-			Type:  "approach_abandonment_warning",
-			Title: fmt.Sprintf("Your approach on \"%s\" will be auto-abandoned in 7 days", problemTitle),
-			Body:  fmt.Sprintf("Approach %s has been inactive. Update it to prevent auto-abandonment.", approachID),
+			Type:  notifType,
+			Title: title,
+			Body:  fmt.Sprintf("Approach %s has been inactive. Update it, or mark it long-running, to prevent auto-abandonment.", approachID),
 			Link:  fmt.Sprintf("/problems/%s", problemID),
 		}
 
@@ -111,7 +207,7 @@ func (r *StaleContentRepository) WarnApproachesApproachingAbandonment(ctx contex
 }
 
 // MarkDormantPosts updates open problem posts that have no approaches
-// and are older than olderThan to 'dormant' status.
+// and are older than olderThan to 'dormant' status. Held posts are skipped.
 // Returns the number of posts marked dormant.
 func (r *StaleContentRepository) MarkDormantPosts(ctx context.Context, olderThan time.Duration) (int64, error) {
 	cutoff := time.Now().Add(-olderThan)
@@ -123,6 +219,7 @@ func (r *StaleContentRepository) MarkDormantPosts(ctx context.Context, olderThan
 		  AND status = 'open'
 		  AND created_at < $1
 		  AND deleted_at IS NULL
+		  AND NOT `+heldPostCondition("posts.id")+`
 		  AND NOT EXISTS (
 		    SELECT 1 FROM approaches
 		    WHERE approaches.problem_id = posts.id
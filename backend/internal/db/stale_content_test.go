@@ -30,6 +30,11 @@ func createStaleTestAgent(t *testing.T, pool *Pool, suffix string) *models.Agent
 	return agent
 }
 
+// testStalePolicy warns at 23 days, reminds at 27 days and abandons at 30 days.
+var testStalePolicy = models.StaleApproachPolicy{
+	Default: models.StaleApproachThresholds{Warning: 23 * 24 * time.Hour, Reminder: 27 * 24 * time.Hour, Abandon: 30 * 24 * time.Hour},
+}
+
 // createStaleTestProblem creates a problem post with a specific created_at time.
 func createStaleTestProblem(t *testing.T, pool *Pool, authorID string, createdAt time.Time) string {
 	t.Helper()
@@ -81,7 +86,7 @@ func TestStaleContent_AbandonApproaches_Integration(t *testing.T) {
 	staleApproachID := createStaleTestApproach(t, pool, problemID, agent.ID, "working", now.Add(-35*24*time.Hour))
 
 	// Abandon approaches older than 30 days
-	count, err := repo.AbandonStaleApproaches(ctx, 30*24*time.Hour)
+	count, err := repo.AbandonStaleApproaches(ctx, testStalePolicy)
 	if err != nil {
 		t.Fatalf("AbandonStaleApproaches failed: %v", err)
 	}
@@ -98,6 +103,110 @@ func TestStaleContent_AbandonApproaches_Integration(t *testing.T) {
 	if status != "abandoned" {
 		t.Errorf("expected approach status 'abandoned', got '%s'", status)
 	}
+
+	// Verify the system recorded an 'abandoned' event on the timeline
+	events, err := NewApproachEventsRepository(pool).ListApproachEvents(ctx, staleApproachID)
+	if err != nil {
+		t.Fatalf("ListApproachEvents failed: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != models.ApproachEventAbandoned || events[0].ActorType != models.AuthorTypeSystem {
+		t.Errorf("expected one system 'abandoned' event, got %+v", events)
+	}
+
+	// Verify the system left a comment explaining the abandonment
+	var commentCount int
+	err = pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM comments
+		WHERE target_type = 'approach' AND target_id = $1 AND author_type = 'system' AND content LIKE '%30 days%'
+	`, staleApproachID).Scan(&commentCount)
+	if err != nil {
+		t.Fatalf("failed to query system comment: %v", err)
+	}
+	if commentCount != 1 {
+		t.Errorf("expected 1 system comment, got %d", commentCount)
+	}
+}
+
+func TestStaleContent_RemindApproaches_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	notifRepo := NewNotificationsRepository(pool)
+	repo := NewStaleContentRepository(pool, notifRepo)
+
+	agent := createStaleTestAgent(t, pool, "remind")
+	now := time.Now()
+	problemID := createStaleTestProblem(t, pool, agent.ID, now.Add(-90*24*time.Hour))
+
+	// 28 days old — past the warning tier, inside the 27-30 day reminder window
+	createStaleTestApproach(t, pool, problemID, agent.ID, "working", now.Add(-28*24*time.Hour))
+
+	warned, err := repo.WarnApproachesApproachingAbandonment(ctx, testStalePolicy)
+	if err != nil {
+		t.Fatalf("WarnApproachesApproachingAbandonment failed: %v", err)
+	}
+	if warned != 0 {
+		t.Errorf("expected no first-tier warnings, got %d", warned)
+	}
+
+	reminded, err := repo.RemindApproachesApproachingAbandonment(ctx, testStalePolicy)
+	if err != nil {
+		t.Fatalf("RemindApproachesApproachingAbandonment failed: %v", err)
+	}
+	if reminded != 1 {
+		t.Errorf("expected 1 reminder, got %d", reminded)
+	}
+
+	// A second run does not remind again
+	reminded, err = repo.RemindApproachesApproachingAbandonment(ctx, testStalePolicy)
+	if err != nil {
+		t.Fatalf("RemindApproachesApproachingAbandonment failed: %v", err)
+	}
+	if reminded != 0 {
+		t.Errorf("expected no repeat reminder, got %d", reminded)
+	}
+}
+
+func TestStaleContent_LongRunningAndWeightThresholds_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewStaleContentRepository(pool, NewNotificationsRepository(pool))
+
+	agent := createStaleTestAgent(t, pool, "longrun")
+	now := time.Now()
+	problemID := createStaleTestProblem(t, pool, agent.ID, now.Add(-90*24*time.Hour))
+	heavyProblemID := createStaleTestProblem(t, pool, agent.ID, now.Add(-90*24*time.Hour))
+	if _, err := pool.Exec(ctx, `UPDATE posts SET weight = 5 WHERE id = $1`, heavyProblemID); err != nil {
+		t.Fatalf("failed to set problem weight: %v", err)
+	}
+
+	longRunningID := createStaleTestApproach(t, pool, problemID, agent.ID, "working", now.Add(-35*24*time.Hour))
+	if _, err := pool.Exec(ctx, `UPDATE approaches SET long_running = TRUE WHERE id = $1`, longRunningID); err != nil {
+		t.Fatalf("failed to mark approach long-running: %v", err)
+	}
+	heavyID := createStaleTestApproach(t, pool, heavyProblemID, agent.ID, "working", now.Add(-35*24*time.Hour))
+
+	// Weight-5 problems abandon after 60 days instead of 30
+	policy := testStalePolicy
+	policy.ByWeight = map[int]models.StaleApproachThresholds{
+		5: {Warning: 45 * 24 * time.Hour, Reminder: 55 * 24 * time.Hour, Abandon: 60 * 24 * time.Hour},
+	}
+	if _, err := repo.AbandonStaleApproaches(ctx, policy); err != nil {
+		t.Fatalf("AbandonStaleApproaches failed: %v", err)
+	}
+
+	for _, id := range []string{longRunningID, heavyID} {
+		var status string
+		if err := pool.QueryRow(ctx, `SELECT status FROM approaches WHERE id = $1`, id).Scan(&status); err != nil {
+			t.Fatalf("failed to query approach status: %v", err)
+		}
+		if status != "working" {
+			t.Errorf("approach %s: expected status 'working', got '%s'", id, status)
+		}
+	}
 }
 
 func TestStaleContent_WarnApproaches_Integration(t *testing.T) {
@@ -112,11 +221,11 @@ func TestStaleContent_WarnApproaches_Integration(t *testing.T) {
 	now := time.Now()
 	problemID := createStaleTestProblem(t, pool, agent.ID, now.Add(-90*24*time.Hour))
 
-	// Create a working approach that is 25 days old — within the 23-30 day warning window
+	// Create a working approach that is 25 days old — within the 23-27 day warning window
 	createStaleTestApproach(t, pool, problemID, agent.ID, "working", now.Add(-25*24*time.Hour))
 
-	// Warn approaches between 23 and 30 days old
-	count, err := repo.WarnApproachesApproachingAbandonment(ctx, 23*24*time.Hour, 30*24*time.Hour)
+	// Warn approaches between 23 and 27 days old
+	count, err := repo.WarnApproachesApproachingAbandonment(ctx, testStalePolicy)
 	if err != nil {
 		t.Fatalf("WarnApproachesApproachingAbandonment failed: %v", err)
 	}
@@ -231,7 +340,7 @@ func TestStaleContent_SkipsRecentContent(t *testing.T) {
 	recentProblemID := createStaleTestProblem(t, pool, agent.ID, now.Add(-10*24*time.Hour))
 
 	// Try to abandon — the 10-day approach should survive
-	_, err := repo.AbandonStaleApproaches(ctx, 30*24*time.Hour)
+	_, err := repo.AbandonStaleApproaches(ctx, testStalePolicy)
 	if err != nil {
 		t.Fatalf("AbandonStaleApproaches failed: %v", err)
 	}
@@ -4,6 +4,8 @@ package db
 import (
 	"context"
 	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 // StatsRepository provides stats data from the database.
@@ -19,7 +21,7 @@ func NewStatsRepository(pool *Pool) *StatsRepository {
 // GetActivePostsCount returns the count of posts with status 'open' or 'active'.
 func (r *StatsRepository) GetActivePostsCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT COUNT(*) FROM posts
 		WHERE status IN ('open', 'active', 'in_progress')
 		AND deleted_at IS NULL
@@ -33,7 +35,7 @@ func (r *StatsRepository) GetActivePostsCount(ctx context.Context) (int, error)
 // GetAgentsCount returns the total count of registered agents.
 func (r *StatsRepository) GetAgentsCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT COUNT(*) FROM agents 
 		WHERE status = 'active'
 	`).Scan(&count)
@@ -47,7 +49,7 @@ func (r *StatsRepository) GetAgentsCount(ctx context.Context) (int, error) {
 func (r *StatsRepository) GetSolvedTodayCount(ctx context.Context) (int, error) {
 	var count int
 	today := time.Now().Truncate(24 * time.Hour)
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT COUNT(*) FROM posts
 		WHERE status = 'solved'
 		AND deleted_at IS NULL
@@ -63,7 +65,7 @@ func (r *StatsRepository) GetSolvedTodayCount(ctx context.Context) (int, error)
 func (r *StatsRepository) GetPostedTodayCount(ctx context.Context) (int, error) {
 	var count int
 	today := time.Now().Truncate(24 * time.Hour)
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT COUNT(*) FROM posts
 		WHERE deleted_at IS NULL
 		AND created_at >= $1
@@ -77,7 +79,7 @@ func (r *StatsRepository) GetPostedTodayCount(ctx context.Context) (int, error)
 // GetProblemsSolvedCount returns the total count of solved problems.
 func (r *StatsRepository) GetProblemsSolvedCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT COUNT(*) FROM posts
 		WHERE type = 'problem' AND status = 'solved'
 		AND deleted_at IS NULL
@@ -91,7 +93,7 @@ func (r *StatsRepository) GetProblemsSolvedCount(ctx context.Context) (int, erro
 // GetQuestionsAnsweredCount returns the count of questions with accepted answers.
 func (r *StatsRepository) GetQuestionsAnsweredCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT COUNT(*) FROM posts
 		WHERE type = 'question' AND accepted_answer_id IS NOT NULL
 		AND deleted_at IS NULL
@@ -105,7 +107,7 @@ func (r *StatsRepository) GetQuestionsAnsweredCount(ctx context.Context) (int, e
 // GetHumansCount returns the total count of human users.
 func (r *StatsRepository) GetHumansCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT COUNT(*) FROM users
 	`).Scan(&count)
 	if err != nil {
@@ -117,7 +119,7 @@ func (r *StatsRepository) GetHumansCount(ctx context.Context) (int, error) {
 // GetTotalPostsCount returns the total count of all posts.
 func (r *StatsRepository) GetTotalPostsCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT COUNT(*) FROM posts
 		WHERE deleted_at IS NULL
 	`).Scan(&count)
@@ -130,7 +132,7 @@ func (r *StatsRepository) GetTotalPostsCount(ctx context.Context) (int, error) {
 // GetTotalContributionsCount returns the total count of all contributions (answers + approaches + responses).
 func (r *StatsRepository) GetTotalContributionsCount(ctx context.Context) (int, error) {
 	var count int
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT
 			COALESCE((SELECT COUNT(*) FROM answers WHERE deleted_at IS NULL), 0) +
 			COALESCE((SELECT COUNT(*) FROM approaches WHERE deleted_at IS NULL), 0) +
@@ -160,7 +162,7 @@ type AllStatsResult struct {
 func (r *StatsRepository) GetAllStats(ctx context.Context) (*AllStatsResult, error) {
 	today := time.Now().Truncate(24 * time.Hour)
 	var s AllStatsResult
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT
 			(SELECT COUNT(*) FROM posts WHERE status IN ('open', 'active', 'in_progress') AND deleted_at IS NULL),
 			(SELECT COUNT(*) FROM agents WHERE status = 'active'),
@@ -185,6 +187,48 @@ func (r *StatsRepository) GetAllStats(ctx context.Context) (*AllStatsResult, err
 	return &s, nil
 }
 
+// GetCodeLanguageStats returns the most common code block languages across
+// public posts and their answers, ordered by total usage. Rows not yet scanned
+// for code blocks (NULL code_languages) are skipped.
+func (r *StatsRepository) GetCodeLanguageStats(ctx context.Context, limit int) ([]models.CodeLanguageCount, error) {
+	rows, err := r.pool.ReadQuery(ctx, `
+		WITH usage AS (
+			SELECT lang, 1 AS posts, 0 AS answers
+			FROM posts p, unnest(p.code_languages) AS lang
+			WHERE p.deleted_at IS NULL
+				AND p.visibility = 'public' -- BART-151
+				AND p.status NOT IN ('pending_review', 'rejected', 'draft')
+			UNION ALL
+			SELECT lang, 0, 1
+			FROM answers a
+			JOIN posts p ON p.id = a.question_id
+			CROSS JOIN unnest(a.code_languages) AS lang
+			WHERE a.deleted_at IS NULL AND a.hidden_at IS NULL
+				AND p.deleted_at IS NULL
+				AND p.visibility = 'public' -- BART-151
+		)
+		SELECT lang, SUM(posts)::int, SUM(answers)::int
+		FROM usage
+		GROUP BY lang
+		ORDER BY SUM(posts) + SUM(answers) DESC, lang
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	languages := []models.CodeLanguageCount{}
+	for rows.Next() {
+		var l models.CodeLanguageCount
+		if err := rows.Scan(&l.Language, &l.Posts, &l.Answers); err != nil {
+			return nil, err
+		}
+		languages = append(languages, l)
+	}
+	return languages, rows.Err()
+}
+
 // TrendingPostDB represents a trending post from the database.
 type TrendingPostDB struct {
 	ID            string
@@ -202,36 +246,30 @@ type TrendingTagDB struct {
 	Growth int
 }
 
-// GetTrendingPosts returns the hottest posts using a ranking that combines
-// net votes (logarithmic) with recency weighting. Includes real response counts.
+// GetTrendingPosts returns the hottest posts by the score the trending job stores
+// in trending_scores, with the score breakdown for tuning. Posts hidden from the
+// public since the last recompute are skipped. Includes real response counts.
 func (r *StatsRepository) GetTrendingPosts(ctx context.Context, limit int) ([]any, error) {
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		SELECT
 			p.id,
 			p.title,
 			p.type,
 			COALESCE(p.upvotes - p.downvotes, 0) as vote_score,
-			COALESCE(ans_cnt.cnt, 0) + COALESCE(app_cnt.cnt, 0) as response_count,
-			p.created_at
-		FROM posts p
-		LEFT JOIN (
-			SELECT question_id, COUNT(*) as cnt
-			FROM answers WHERE deleted_at IS NULL
-			GROUP BY question_id
-		) ans_cnt ON ans_cnt.question_id = p.id
-		LEFT JOIN (
-			SELECT problem_id, COUNT(*) as cnt
-			FROM approaches WHERE deleted_at IS NULL
-			GROUP BY problem_id
-		) app_cnt ON app_cnt.problem_id = p.id
-		WHERE p.created_at > NOW() - INTERVAL '7 days'
-			AND p.deleted_at IS NULL
+			p.answers_count + p.approaches_count as response_count,
+			p.created_at,
+			ts.score,
+			ts.votes_score,
+			ts.views_score,
+			ts.answers_score,
+			ts.decay,
+			ts.computed_at
+		FROM trending_scores ts
+		JOIN posts p ON p.id = ts.post_id
+		WHERE p.deleted_at IS NULL
 			AND p.visibility = 'public' -- BART-151
 			AND p.status NOT IN ('pending_review', 'rejected', 'draft')
-		ORDER BY
-			LOG(GREATEST(ABS(COALESCE(p.upvotes, 0) - COALESCE(p.downvotes, 0)), 1) + 1)
-			+ EXTRACT(EPOCH FROM (p.created_at - (NOW() - INTERVAL '7 days'))) / 45000.0
-			DESC
+		ORDER BY ts.score DESC, p.created_at DESC
 		LIMIT $1
 	`, limit)
 	if err != nil {
@@ -242,16 +280,21 @@ func (r *StatsRepository) GetTrendingPosts(ctx context.Context, limit int) ([]an
 	var posts []any
 	for rows.Next() {
 		var post TrendingPostDB
-		if err := rows.Scan(&post.ID, &post.Title, &post.Type, &post.VoteScore, &post.ResponseCount, &post.CreatedAt); err != nil {
+		var score float64
+		var breakdown models.TrendingScoreBreakdown
+		if err := rows.Scan(&post.ID, &post.Title, &post.Type, &post.VoteScore, &post.ResponseCount, &post.CreatedAt,
+			&score, &breakdown.Votes, &breakdown.Views, &breakdown.Answers, &breakdown.Decay, &breakdown.ComputedAt); err != nil {
 			return nil, err
 		}
 		posts = append(posts, map[string]any{
-			"id":             post.ID,
-			"title":          post.Title,
-			"type":           post.Type,
-			"vote_score":     post.VoteScore,
-			"response_count": post.ResponseCount,
-			"created_at":     post.CreatedAt,
+			"id":              post.ID,
+			"title":           post.Title,
+			"type":            post.Type,
+			"vote_score":      post.VoteScore,
+			"response_count":  post.ResponseCount,
+			"created_at":      post.CreatedAt,
+			"score":           score,
+			"score_breakdown": breakdown,
 		})
 	}
 
@@ -269,7 +312,7 @@ func (r *StatsRepository) GetTrendingTags(ctx context.Context, limit int) ([]any
 		limit = 10
 	}
 
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		WITH recent AS (
 			SELECT tag, COUNT(*) as count
 			FROM posts, unnest(tags) as tag
@@ -334,9 +377,9 @@ func (r *StatsRepository) GetTrendingTags(ctx context.Context, limit int) ([]any
 
 // GetProblemsStats returns aggregate statistics for the problems page sidebar.
 func (r *StatsRepository) GetProblemsStats(ctx context.Context) (map[string]any, error) {
-	var totalProblems, solvedCount, activeApproaches, avgSolveTimeDays int
+	var totalProblems, solvedCount, activeApproaches, avgSolveTimeDays, stuckCount, avgTimeInStuckHours int
 
-	err := r.pool.QueryRow(ctx, `
+	err := r.pool.ReadQueryRow(ctx, `
 		SELECT
 			(SELECT COUNT(*) FROM posts WHERE type = 'problem' AND deleted_at IS NULL),
 			(SELECT COUNT(*) FROM posts WHERE type = 'problem' AND status = 'solved' AND deleted_at IS NULL),
@@ -347,8 +390,12 @@ func (r *StatsRepository) GetProblemsStats(ctx context.Context) (map[string]any,
 				AND p.deleted_at IS NULL),
 			COALESCE((SELECT AVG(EXTRACT(EPOCH FROM (p.updated_at - p.created_at)) / 86400)::INT
 				FROM posts p
-				WHERE p.type = 'problem' AND p.status = 'solved' AND p.deleted_at IS NULL), 0)
-	`).Scan(&totalProblems, &solvedCount, &activeApproaches, &avgSolveTimeDays)
+				WHERE p.type = 'problem' AND p.status = 'solved' AND p.deleted_at IS NULL), 0),
+			(SELECT COUNT(*) FROM problem_escalations WHERE resolved_at IS NULL),
+			COALESCE((SELECT AVG(EXTRACT(EPOCH FROM (resolved_at - created_at)) / 3600)::INT
+				FROM problem_escalations
+				WHERE resolved_at IS NOT NULL), 0)
+	`).Scan(&totalProblems, &solvedCount, &activeApproaches, &avgSolveTimeDays, &stuckCount, &avgTimeInStuckHours)
 	if err != nil {
 		return nil, err
 	}
@@ -358,6 +405,10 @@ func (r *StatsRepository) GetProblemsStats(ctx context.Context) (map[string]any,
 		"solved_count":        solvedCount,
 		"active_approaches":   activeApproaches,
 		"avg_solve_time_days": avgSolveTimeDays,
+		// Escalations via POST /problems/{id}/stuck: open now, and mean hours
+		// from escalation until the problem was solved, closed or went stale
+		"stuck_count":             stuckCount,
+		"avg_time_in_stuck_hours": avgTimeInStuckHours,
 	}, nil
 }
 
@@ -367,7 +418,7 @@ func (r *StatsRepository) GetRecentlySolvedProblems(ctx context.Context, limit i
 		limit = 3
 	}
 
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		SELECT
 			p.id,
 			p.title,
@@ -444,7 +495,7 @@ func (r *StatsRepository) GetTopProblemSolvers(ctx context.Context, limit int) (
 		limit = 5
 	}
 
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		SELECT
 			a.author_id,
 			a.author_type,
@@ -506,7 +557,7 @@ type IdeaStatusCount struct {
 
 // GetIdeasCountByStatus returns count of ideas grouped by status.
 func (r *StatsRepository) GetIdeasCountByStatus(ctx context.Context) (map[string]int, error) {
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		SELECT status, COUNT(*) as count
 		FROM posts
 		WHERE type = 'idea' AND deleted_at IS NULL
@@ -548,7 +599,7 @@ func (r *StatsRepository) GetFreshSparks(ctx context.Context, limit int) ([]map[
 		limit = 5
 	}
 
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		SELECT id, title, COALESCE(upvotes, 0) as support, created_at
 		FROM posts
 		WHERE type = 'idea'
@@ -591,7 +642,7 @@ func (r *StatsRepository) GetReadyToDevelop(ctx context.Context, limit int) ([]m
 		limit = 5
 	}
 
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		SELECT
 			id,
 			title,
@@ -651,7 +702,7 @@ func (r *StatsRepository) GetTopSparklers(ctx context.Context, limit int) ([]map
 		limit = 5
 	}
 
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		SELECT
 			p.posted_by_id,
 			p.posted_by_type,
@@ -740,7 +791,7 @@ func (r *StatsRepository) GetIdeaPipelineStats(ctx context.Context) (map[string]
 
 	// Average days to realization (simplified: avg age of evolved ideas)
 	var avgDays int
-	err = r.pool.QueryRow(ctx, `
+	err = r.pool.ReadQueryRow(ctx, `
 		SELECT COALESCE(AVG(EXTRACT(EPOCH FROM (updated_at - created_at)) / 86400)::INT, 0)
 		FROM posts
 		WHERE type = 'idea' AND status = 'evolved' AND deleted_at IS NULL
@@ -763,7 +814,7 @@ func (r *StatsRepository) GetRecentlyRealized(ctx context.Context, limit int) ([
 		limit = 5
 	}
 
-	rows, err := r.pool.Query(ctx, `
+	rows, err := r.pool.ReadQuery(ctx, `
 		SELECT
 			id,
 			title,
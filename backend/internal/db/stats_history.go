@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// SnapshotDailyStats records today's (UTC) value of every models.StatsMetrics
+// metric into stats_daily, overwriting any earlier snapshot from today.
+// Returns the number of metrics written.
+func (r *StatsRepository) SnapshotDailyStats(ctx context.Context) (int64, error) {
+	values := make(map[string]int64, len(models.StatsMetrics))
+	var posts, problems, questions, ideas, answers, approaches, responses,
+		problemsSolved, questionsAnswered, agents, humans, crystallized int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM posts WHERE type = 'problem' AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM posts WHERE type = 'question' AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM posts WHERE type = 'idea' AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM answers WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM approaches WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM responses),
+			(SELECT COUNT(*) FROM posts WHERE type = 'problem' AND status = 'solved' AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM posts WHERE type = 'question' AND accepted_answer_id IS NOT NULL AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM agents WHERE status = 'active'),
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM posts WHERE crystallization_cid IS NOT NULL AND deleted_at IS NULL)
+	`).Scan(&posts, &problems, &questions, &ideas, &answers, &approaches, &responses,
+		&problemsSolved, &questionsAnswered, &agents, &humans, &crystallized)
+	if err != nil {
+		LogQueryError(ctx, "SnapshotDailyStats.Count", "posts", err)
+		return 0, fmt.Errorf("count daily stats failed: %w", err)
+	}
+
+	values[models.StatsMetricPosts] = posts
+	values[models.StatsMetricProblems] = problems
+	values[models.StatsMetricQuestions] = questions
+	values[models.StatsMetricIdeas] = ideas
+	values[models.StatsMetricAnswers] = answers
+	values[models.StatsMetricApproaches] = approaches
+	values[models.StatsMetricContributions] = answers + approaches + responses
+	values[models.StatsMetricProblemsSolved] = problemsSolved
+	values[models.StatsMetricQuestionsAnswered] = questionsAnswered
+	values[models.StatsMetricAgents] = agents
+	values[models.StatsMetricHumans] = humans
+	values[models.StatsMetricCrystallized] = crystallized
+
+	metrics := make([]string, 0, len(models.StatsMetrics))
+	counts := make([]int64, 0, len(models.StatsMetrics))
+	for _, metric := range models.StatsMetrics {
+		metrics = append(metrics, metric)
+		counts = append(counts, values[metric])
+	}
+
+	result, err := r.pool.Exec(ctx, `
+		INSERT INTO stats_daily (day, metric, value, recorded_at)
+		SELECT (NOW() AT TIME ZONE 'UTC')::date, m.metric, m.value, NOW()
+		FROM unnest($1::text[], $2::bigint[]) AS m(metric, value)
+		ON CONFLICT (metric, day) DO UPDATE
+		SET value = EXCLUDED.value, recorded_at = EXCLUDED.recorded_at
+	`, metrics, counts)
+	if err != nil {
+		LogQueryError(ctx, "SnapshotDailyStats.Insert", "stats_daily", err)
+		return 0, fmt.Errorf("snapshot daily stats failed: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetStatsHistory returns the daily snapshots of metric over the last days UTC
+// days (today included), oldest first. Days without a snapshot are omitted.
+func (r *StatsRepository) GetStatsHistory(ctx context.Context, metric string, days int) ([]models.StatsHistoryPoint, error) {
+	rows, err := r.pool.ReadQuery(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), value
+		FROM stats_daily
+		WHERE metric = $1 AND day > (NOW() AT TIME ZONE 'UTC')::date - $2::int
+		ORDER BY day ASC
+	`, metric, days)
+	if err != nil {
+		LogQueryError(ctx, "GetStatsHistory", "stats_daily", err)
+		return nil, fmt.Errorf("get stats history failed: %w", err)
+	}
+	defer rows.Close()
+
+	points := make([]models.StatsHistoryPoint, 0)
+	for rows.Next() {
+		var p models.StatsHistoryPoint
+		if err := rows.Scan(&p.Date, &p.Value); err != nil {
+			return nil, fmt.Errorf("scan stats history: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stats history: %w", err)
+	}
+
+	return points, nil
+}
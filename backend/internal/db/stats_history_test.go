@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// TestSnapshotDailyStats_WritesEveryMetric verifies a snapshot writes one row per
+// metric for today and that re-running overwrites instead of duplicating.
+func TestSnapshotDailyStats_WritesEveryMetric(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewStatsRepository(pool)
+
+	for i := 0; i < 2; i++ {
+		written, err := repo.SnapshotDailyStats(ctx)
+		if err != nil {
+			t.Fatalf("SnapshotDailyStats() error = %v", err)
+		}
+		if written != int64(len(models.StatsMetrics)) {
+			t.Errorf("expected %d metrics written, got %d", len(models.StatsMetrics), written)
+		}
+	}
+
+	var rows int
+	if err := pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM stats_daily WHERE day = (NOW() AT TIME ZONE 'UTC')::date
+	`).Scan(&rows); err != nil {
+		t.Fatalf("failed to count snapshots: %v", err)
+	}
+	if rows != len(models.StatsMetrics) {
+		t.Errorf("expected %d snapshot rows today, got %d", len(models.StatsMetrics), rows)
+	}
+
+	var total int64
+	if err := pool.QueryRow(ctx, `SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL`).Scan(&total); err != nil {
+		t.Fatalf("failed to count posts: %v", err)
+	}
+	history, err := repo.GetStatsHistory(ctx, models.StatsMetricPosts, 1)
+	if err != nil {
+		t.Fatalf("GetStatsHistory() error = %v", err)
+	}
+	if len(history) != 1 || history[0].Value != total || history[0].Date != time.Now().UTC().Format("2006-01-02") {
+		t.Errorf("expected today's posts snapshot of %d, got %+v", total, history)
+	}
+}
+
+func TestGetStatsHistory_WindowAndOrder(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	const metric = "test_history_metric"
+	defer pool.Exec(ctx, `DELETE FROM stats_daily WHERE metric = $1`, metric)
+
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO stats_daily (day, metric, value)
+		SELECT (NOW() AT TIME ZONE 'UTC')::date - d, $1, 100 - d
+		FROM generate_series(0, 10) AS d
+		ON CONFLICT (metric, day) DO UPDATE SET value = EXCLUDED.value
+	`, metric); err != nil {
+		t.Fatalf("failed to seed stats_daily: %v", err)
+	}
+
+	history, err := NewStatsRepository(pool).GetStatsHistory(ctx, metric, 7)
+	if err != nil {
+		t.Fatalf("GetStatsHistory() error = %v", err)
+	}
+	if len(history) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(history))
+	}
+	if history[0].Value != 94 || history[6].Value != 100 {
+		t.Errorf("expected oldest first from 94 to 100, got %+v", history)
+	}
+}
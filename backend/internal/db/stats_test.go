@@ -9,6 +9,14 @@ import (
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
+// recomputeTrendingScores runs the trending job's recompute so GetTrendingPosts sees new posts.
+func recomputeTrendingScores(t *testing.T, pool *Pool, ctx context.Context) {
+	t.Helper()
+	if _, err := NewTrendingRepository(pool).RecomputeTrendingScores(ctx, models.DefaultTrendingConfig()); err != nil {
+		t.Fatalf("RecomputeTrendingScores() error = %v", err)
+	}
+}
+
 // TestGetTrendingPosts_ExcludesDraft verifies that draft posts are excluded from trending results.
 func TestGetTrendingPosts_ExcludesDraft(t *testing.T) {
 	pool := setupTestDB(t)
@@ -25,6 +33,7 @@ func TestGetTrendingPosts_ExcludesDraft(t *testing.T) {
 	openID := insertTestPost(t, pool, ctx, "problem", "Open trending problem about Go performance",
 		"This is open and should appear in trending.", []string{"go"}, "open")
 
+	recomputeTrendingScores(t, pool, ctx)
 	posts, err := statsRepo.GetTrendingPosts(ctx, 10)
 	if err != nil {
 		t.Fatalf("GetTrendingPosts() error = %v", err)
@@ -71,6 +80,7 @@ func TestGetTrendingPosts_ExcludesPendingReview(t *testing.T) {
 	openID := insertTestPost(t, pool, ctx, "question", "Open trending question about databases",
 		"This is open and should appear in trending.", []string{"databases"}, "open")
 
+	recomputeTrendingScores(t, pool, ctx)
 	posts, err := statsRepo.GetTrendingPosts(ctx, 10)
 	if err != nil {
 		t.Fatalf("GetTrendingPosts() error = %v", err)
@@ -117,6 +127,7 @@ func TestGetTrendingPosts_ExcludesRejected(t *testing.T) {
 	openID := insertTestPost(t, pool, ctx, "idea", "Open trending idea about AI agents",
 		"This idea is open and should appear in trending.", []string{"ai"}, "open")
 
+	recomputeTrendingScores(t, pool, ctx)
 	posts, err := statsRepo.GetTrendingPosts(ctx, 10)
 	if err != nil {
 		t.Fatalf("GetTrendingPosts() error = %v", err)
@@ -0,0 +1,203 @@
+// Package db provides database access for Solvr.
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+	pgvector "github.com/pgvector/pgvector-go"
+)
+
+// StrategyClustersRepository stores the strategy clusters of each problem's
+// approaches (migration 000106). It implements the jobs.StrategyClusterStore
+// interface and backs GET /v1/problems/{id}/insights.
+type StrategyClustersRepository struct {
+	pool *Pool
+}
+
+// NewStrategyClustersRepository creates a new StrategyClustersRepository.
+func NewStrategyClustersRepository(pool *Pool) *StrategyClustersRepository {
+	return &StrategyClustersRepository{pool: pool}
+}
+
+// ListProblemsNeedingClustering returns problems whose embedded approaches changed
+// since they were last clustered, or that were never clustered, most recently
+// active first.
+func (r *StrategyClustersRepository) ListProblemsNeedingClustering(ctx context.Context, limit int) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT a.problem_id
+		FROM approaches a
+		LEFT JOIN problem_strategy_runs sr ON sr.problem_id = a.problem_id
+		WHERE a.deleted_at IS NULL AND a.embedding IS NOT NULL
+		GROUP BY a.problem_id, sr.computed_at, sr.approach_count
+		HAVING sr.computed_at IS NULL
+		    OR MAX(a.updated_at) > sr.computed_at
+		    OR COUNT(*) <> sr.approach_count
+		ORDER BY MAX(a.updated_at) DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListProblemsNeedingClustering", "approaches", err)
+		return nil, fmt.Errorf("failed to list problems needing clustering: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan problem id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ListApproachEmbeddings returns the embeddings of a problem's approaches, oldest
+// first. Approaches without an embedding are skipped.
+func (r *StrategyClustersRepository) ListApproachEmbeddings(ctx context.Context, problemID string) ([]models.ApproachEmbedding, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, embedding
+		FROM approaches
+		WHERE problem_id = $1 AND deleted_at IS NULL AND embedding IS NOT NULL
+		ORDER BY created_at, id
+	`, problemID)
+	if err != nil {
+		LogQueryError(ctx, "ListApproachEmbeddings", "approaches", err)
+		return nil, fmt.Errorf("failed to list approach embeddings: %w", err)
+	}
+	defer rows.Close()
+
+	var out []models.ApproachEmbedding
+	for rows.Next() {
+		var e models.ApproachEmbedding
+		var vec pgvector.Vector
+		if err := rows.Scan(&e.ApproachID, &vec); err != nil {
+			return nil, fmt.Errorf("failed to scan approach embedding: %w", err)
+		}
+		e.Embedding = vec.Slice()
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// SaveStrategyClusters replaces a problem's clusters with assignments and records
+// the run, in one transaction.
+func (r *StrategyClustersRepository) SaveStrategyClusters(ctx context.Context, problemID string, assignments []models.StrategyAssignment, threshold float64) error {
+	clusters := 0
+	for _, a := range assignments {
+		clusters = max(clusters, a.ClusterIndex+1)
+	}
+
+	return r.pool.WithTx(ctx, func(tx Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM problem_strategy_clusters WHERE problem_id = $1`, problemID); err != nil {
+			return fmt.Errorf("failed to clear strategy clusters: %w", err)
+		}
+		for _, a := range assignments {
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO problem_strategy_clusters (problem_id, approach_id, cluster_index, is_representative)
+				VALUES ($1, $2, $3, $4)
+			`, problemID, a.ApproachID, a.ClusterIndex, a.Representative); err != nil {
+				return fmt.Errorf("failed to insert strategy cluster: %w", err)
+			}
+		}
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO problem_strategy_runs (problem_id, approach_count, cluster_count, similarity_threshold, computed_at)
+			VALUES ($1, $2, $3, $4, NOW())
+			ON CONFLICT (problem_id) DO UPDATE SET
+				approach_count = EXCLUDED.approach_count,
+				cluster_count = EXCLUDED.cluster_count,
+				similarity_threshold = EXCLUDED.similarity_threshold,
+				computed_at = EXCLUDED.computed_at
+		`, problemID, len(assignments), clusters, threshold); err != nil {
+			return fmt.Errorf("failed to record strategy run: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetProblemInsights returns the strategy clusters of a problem's approaches.
+// A problem that was never clustered has no clusters and a nil ComputedAt.
+// Approaches deleted since the last run are left out of their cluster.
+func (r *StrategyClustersRepository) GetProblemInsights(ctx context.Context, problemID string) (*models.ProblemInsights, error) {
+	insights := &models.ProblemInsights{ProblemID: problemID, Clusters: []models.StrategyCluster{}}
+
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM approaches WHERE problem_id = $1 AND deleted_at IS NULL
+	`, problemID).Scan(&insights.ApproachCount)
+	if err != nil {
+		LogQueryError(ctx, "GetProblemInsights", "approaches", err)
+		return nil, fmt.Errorf("failed to count approaches: %w", err)
+	}
+
+	var computedAt time.Time
+	err = r.pool.QueryRow(ctx, `
+		SELECT similarity_threshold, computed_at FROM problem_strategy_runs WHERE problem_id = $1
+	`, problemID).Scan(&insights.SimilarityThreshold, &computedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		insights.UnclusteredCount = insights.ApproachCount
+		return insights, nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "GetProblemInsights", "problem_strategy_runs", err)
+		return nil, fmt.Errorf("failed to get strategy run: %w", err)
+	}
+	insights.ComputedAt = &computedAt
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT c.cluster_index, c.is_representative, a.id, a.angle, a.status,
+		       COALESCE(a.outcome_type, ''), COALESCE(a.failure_reason, '')
+		FROM problem_strategy_clusters c
+		JOIN approaches a ON a.id = c.approach_id
+		WHERE c.problem_id = $1 AND a.deleted_at IS NULL
+		ORDER BY c.cluster_index, c.is_representative DESC, a.created_at, a.id
+	`, problemID)
+	if err != nil {
+		LogQueryError(ctx, "GetProblemInsights", "problem_strategy_clusters", err)
+		return nil, fmt.Errorf("failed to list strategy clusters: %w", err)
+	}
+	defer rows.Close()
+
+	clustered := 0
+	for rows.Next() {
+		var index int
+		var representative bool
+		var a models.StrategyClusterApproach
+		if err := rows.Scan(&index, &representative, &a.ID, &a.Angle, &a.Status, &a.OutcomeType, &a.FailureReason); err != nil {
+			return nil, fmt.Errorf("failed to scan strategy cluster: %w", err)
+		}
+		n := len(insights.Clusters)
+		if n == 0 || insights.Clusters[n-1].Index != index {
+			insights.Clusters = append(insights.Clusters, models.StrategyCluster{
+				Index:               index,
+				Representative:      a,
+				StatusCounts:        map[models.ApproachStatus]int{},
+				OutcomeCounts:       map[models.ApproachOutcomeType]int{},
+				FailureReasonCounts: map[models.ApproachFailureReason]int{},
+			})
+			n++
+		}
+		c := &insights.Clusters[n-1]
+		c.Approaches = append(c.Approaches, a)
+		c.Size++
+		c.StatusCounts[a.Status]++
+		if a.OutcomeType != "" {
+			c.OutcomeCounts[a.OutcomeType]++
+		}
+		if a.FailureReason != "" {
+			c.FailureReasonCounts[a.FailureReason]++
+		}
+		clustered++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read strategy clusters: %w", err)
+	}
+
+	insights.StrategyCount = len(insights.Clusters)
+	insights.UnclusteredCount = max(0, insights.ApproachCount-clustered)
+	return insights, nil
+}
@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrSuccessCriterionNotFound is returned when a criterion doesn't exist on the given problem.
+var ErrSuccessCriterionNotFound = errors.New("success criterion not found")
+
+// SuccessCriteriaRepository stores the checkable success criteria of problems.
+// Rows are created by the trigger that mirrors posts.success_criteria.
+type SuccessCriteriaRepository struct {
+	pool *Pool
+}
+
+// NewSuccessCriteriaRepository creates a new SuccessCriteriaRepository.
+func NewSuccessCriteriaRepository(pool *Pool) *SuccessCriteriaRepository {
+	return &SuccessCriteriaRepository{pool: pool}
+}
+
+const successCriterionColumns = `id::text, post_id::text, position, text, met,
+	COALESCE(verified_by_type, ''), COALESCE(verified_by_id, ''), COALESCE(evidence_url, ''), verified_at`
+
+func scanSuccessCriterion(row pgx.Row) (*models.SuccessCriterion, error) {
+	c := &models.SuccessCriterion{}
+	err := row.Scan(&c.ID, &c.PostID, &c.Position, &c.Text, &c.Met,
+		&c.VerifiedByType, &c.VerifiedByID, &c.EvidenceURL, &c.VerifiedAt)
+	return c, err
+}
+
+// ListCriteria returns a problem's success criteria in order.
+func (r *SuccessCriteriaRepository) ListCriteria(ctx context.Context, problemID string) ([]models.SuccessCriterion, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+successCriterionColumns+`
+		FROM success_criteria
+		WHERE post_id = $1
+		ORDER BY position`, problemID)
+	if isInvalidUUIDError(err) {
+		return []models.SuccessCriterion{}, nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "ListCriteria", "success_criteria", err)
+		return nil, fmt.Errorf("list success criteria: %w", err)
+	}
+	defer rows.Close()
+
+	criteria := []models.SuccessCriterion{}
+	for rows.Next() {
+		c, err := scanSuccessCriterion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan success criterion: %w", err)
+		}
+		criteria = append(criteria, *c)
+	}
+	return criteria, rows.Err()
+}
+
+// SetMet marks a criterion met, recording who verified it and the optional
+// evidence link, or unmet, clearing them. Returns ErrSuccessCriterionNotFound
+// if the criterion doesn't belong to the problem.
+func (r *SuccessCriteriaRepository) SetMet(ctx context.Context, problemID, criterionID string, met bool, verifierType, verifierID, evidenceURL string) (*models.SuccessCriterion, error) {
+	c, err := scanSuccessCriterion(r.pool.QueryRow(ctx, `
+		UPDATE success_criteria
+		SET met = $3,
+		    verified_by_type = CASE WHEN $3 THEN $4 END,
+		    verified_by_id = CASE WHEN $3 THEN $5 END,
+		    evidence_url = CASE WHEN $3 THEN NULLIF($6, '') END,
+		    verified_at = CASE WHEN $3 THEN NOW() END
+		WHERE id = $2 AND post_id = $1
+		RETURNING `+successCriterionColumns,
+		problemID, criterionID, met, verifierType, verifierID, evidenceURL))
+	if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+		return nil, ErrSuccessCriterionNotFound
+	}
+	if err != nil {
+		LogQueryError(ctx, "SetMet", "success_criteria", err)
+		return nil, fmt.Errorf("update success criterion: %w", err)
+	}
+	return c, nil
+}
+
+// CountUnmet returns how many of a problem's success criteria are not met.
+func (r *SuccessCriteriaRepository) CountUnmet(ctx context.Context, problemID string) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM success_criteria WHERE post_id = $1 AND NOT met`, problemID).Scan(&n)
+	if isInvalidUUIDError(err) {
+		return 0, nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "CountUnmet", "success_criteria", err)
+		return 0, fmt.Errorf("count unmet success criteria: %w", err)
+	}
+	return n, nil
+}
+
+// RecordOverride stores why a problem is being solved with unmet criteria,
+// replacing any earlier override.
+func (r *SuccessCriteriaRepository) RecordOverride(ctx context.Context, o models.SuccessCriteriaOverride) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO success_criteria_overrides (post_id, reason, actor_type, actor_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (post_id) DO UPDATE
+		SET reason = EXCLUDED.reason, actor_type = EXCLUDED.actor_type,
+		    actor_id = EXCLUDED.actor_id, created_at = NOW()`,
+		o.PostID, o.Reason, o.ActorType, o.ActorID)
+	if err != nil {
+		LogQueryError(ctx, "RecordOverride", "success_criteria_overrides", err)
+		return fmt.Errorf("record success criteria override: %w", err)
+	}
+	return nil
+}
+
+// FindOverride returns a problem's criteria override, or nil if it has none.
+func (r *SuccessCriteriaRepository) FindOverride(ctx context.Context, problemID string) (*models.SuccessCriteriaOverride, error) {
+	o := &models.SuccessCriteriaOverride{}
+	err := r.pool.QueryRow(ctx, `
+		SELECT post_id::text, reason, actor_type, actor_id, created_at
+		FROM success_criteria_overrides
+		WHERE post_id = $1`, problemID).Scan(&o.PostID, &o.Reason, &o.ActorType, &o.ActorID, &o.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		LogQueryError(ctx, "FindOverride", "success_criteria_overrides", err)
+		return nil, fmt.Errorf("find success criteria override: %w", err)
+	}
+	return o, nil
+}
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestSuccessCriteriaRepository_Integration(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	posts := NewPostRepository(pool)
+	repo := NewSuccessCriteriaRepository(pool)
+	agent := createStaleTestAgent(t, pool, "criteria")
+
+	post, err := posts.Create(ctx, &models.Post{
+		Type:            models.PostTypeProblem,
+		Title:           "Flaky integration tests on CI",
+		Description:     "The suite fails about one run in five with connection resets against the test database.",
+		PostedByType:    models.AuthorTypeAgent,
+		PostedByID:      agent.ID,
+		Status:          models.PostStatusOpen,
+		SuccessCriteria: []string{"Suite passes 20 runs in a row", "No retries added"},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	t.Cleanup(func() { pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", post.ID) })
+
+	criteria, err := repo.ListCriteria(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("ListCriteria() error = %v", err)
+	}
+	if len(criteria) != 2 || criteria[0].Text != "Suite passes 20 runs in a row" || criteria[1].Met {
+		t.Fatalf("expected the two criteria mirrored unmet, got %+v", criteria)
+	}
+
+	c, err := repo.SetMet(ctx, post.ID, criteria[0].ID, true, "agent", agent.ID, "https://ci.example.com/runs/1")
+	if err != nil {
+		t.Fatalf("SetMet() error = %v", err)
+	}
+	if !c.Met || c.VerifiedByID != agent.ID || c.VerifiedAt == nil {
+		t.Errorf("expected criterion met by the agent, got %+v", c)
+	}
+	if n, err := repo.CountUnmet(ctx, post.ID); err != nil || n != 1 {
+		t.Errorf("CountUnmet() = %d, %v; want 1", n, err)
+	}
+	if _, err := repo.SetMet(ctx, "00000000-0000-0000-0000-000000000000", criteria[1].ID, true, "agent", agent.ID, ""); !errors.Is(err, ErrSuccessCriterionNotFound) {
+		t.Errorf("expected ErrSuccessCriterionNotFound for another problem, got %v", err)
+	}
+
+	// Editing a criterion's text resets it; dropping one removes it.
+	if _, err := pool.Exec(ctx, "UPDATE posts SET success_criteria = ARRAY['Suite passes 50 runs in a row'] WHERE id = $1", post.ID); err != nil {
+		t.Fatalf("failed to edit criteria: %v", err)
+	}
+	criteria, _ = repo.ListCriteria(ctx, post.ID)
+	if len(criteria) != 1 || criteria[0].Met {
+		t.Errorf("expected one reset criterion, got %+v", criteria)
+	}
+
+	override := models.SuccessCriteriaOverride{PostID: post.ID, Reason: "Verified manually", ActorType: "agent", ActorID: agent.ID}
+	if err := repo.RecordOverride(ctx, override); err != nil {
+		t.Fatalf("RecordOverride() error = %v", err)
+	}
+	o, err := repo.FindOverride(ctx, post.ID)
+	if err != nil || o == nil || o.Reason != "Verified manually" {
+		t.Errorf("FindOverride() = %+v, %v", o, err)
+	}
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ErrTagFollowLimit is returned when the caller already follows models.MaxFollowedTags tags.
+var ErrTagFollowLimit = errors.New("tag follow limit reached")
+
+// FollowTag makes the follower follow tag. Following a tag twice is a no-op
+// that returns the existing follow. Returns ErrTagFollowLimit if the follower
+// already follows models.MaxFollowedTags other tags.
+func (r *TagsRepository) FollowTag(ctx context.Context, followerType, followerID, tag string) (*models.TagFollow, error) {
+	follow := models.TagFollow{Tag: tag}
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var count int
+		var exists bool
+		if err := tx.QueryRow(ctx, `
+			SELECT COUNT(*), COALESCE(bool_or(tag = $3), false)
+			FROM tag_follows
+			WHERE follower_type = $1 AND follower_id = $2
+		`, followerType, followerID, tag).Scan(&count, &exists); err != nil {
+			return err
+		}
+		if !exists && count >= models.MaxFollowedTags {
+			return ErrTagFollowLimit
+		}
+
+		return tx.QueryRow(ctx, `
+			INSERT INTO tag_follows (follower_type, follower_id, tag)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (follower_type, follower_id, tag) DO UPDATE SET tag = EXCLUDED.tag
+			RETURNING created_at
+		`, followerType, followerID, tag).Scan(&follow.CreatedAt)
+	})
+	if err != nil {
+		if errors.Is(err, ErrTagFollowLimit) {
+			return nil, err
+		}
+		LogQueryError(ctx, "FollowTag", "tag_follows", err)
+		return nil, fmt.Errorf("follow tag failed: %w", err)
+	}
+	return &follow, nil
+}
+
+// UnfollowTag removes a tag follow. Unfollowing a tag that is not followed is a no-op.
+func (r *TagsRepository) UnfollowTag(ctx context.Context, followerType, followerID, tag string) error {
+	_, err := r.pool.Exec(ctx, `
+		DELETE FROM tag_follows
+		WHERE follower_type = $1 AND follower_id = $2 AND tag = $3
+	`, followerType, followerID, tag)
+	if err != nil {
+		LogQueryError(ctx, "UnfollowTag", "tag_follows", err)
+		return fmt.Errorf("unfollow tag failed: %w", err)
+	}
+	return nil
+}
+
+// ListFollowedTags returns the tags the follower follows, alphabetically.
+func (r *TagsRepository) ListFollowedTags(ctx context.Context, followerType, followerID string) ([]models.TagFollow, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT tag, created_at
+		FROM tag_follows
+		WHERE follower_type = $1 AND follower_id = $2
+		ORDER BY tag ASC
+	`, followerType, followerID)
+	if err != nil {
+		LogQueryError(ctx, "ListFollowedTags", "tag_follows", err)
+		return nil, fmt.Errorf("list followed tags failed: %w", err)
+	}
+	defer rows.Close()
+
+	follows := make([]models.TagFollow, 0)
+	for rows.Next() {
+		var f models.TagFollow
+		if err := rows.Scan(&f.Tag, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan tag follow: %w", err)
+		}
+		follows = append(follows, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tag follows: %w", err)
+	}
+
+	return follows, nil
+}
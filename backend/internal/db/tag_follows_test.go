@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestTagsRepository_FollowTag_IdempotentAndSurvivesMerge tests that following
+// twice keeps one follow and that merging tags moves follows to the target.
+func TestTagsRepository_FollowTag_IdempotentAndSurvivesMerge(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewTagsRepository(pool)
+	ctx := context.Background()
+	suffix := time.Now().Format("150405.000000")
+	follower, source, target := "tag-follower-"+suffix, "k8s-"+suffix, "kubernetes-"+suffix
+	defer pool.Exec(ctx, "DELETE FROM tag_follows WHERE follower_id = $1", follower)
+
+	first, err := repo.FollowTag(ctx, "agent", follower, source)
+	if err != nil {
+		t.Fatalf("FollowTag() error = %v", err)
+	}
+	second, err := repo.FollowTag(ctx, "agent", follower, source)
+	if err != nil {
+		t.Fatalf("second FollowTag() error = %v", err)
+	}
+	if !second.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("second FollowTag() created_at = %v, want %v", second.CreatedAt, first.CreatedAt)
+	}
+
+	insertTaggedPost(t, pool, ctx, []string{source})
+	if _, err := repo.MergeTags(ctx, []string{source}, target); err != nil {
+		t.Fatalf("MergeTags() error = %v", err)
+	}
+
+	follows, err := repo.ListFollowedTags(ctx, "agent", follower)
+	if err != nil {
+		t.Fatalf("ListFollowedTags() error = %v", err)
+	}
+	if len(follows) != 1 || follows[0].Tag != target {
+		t.Errorf("ListFollowedTags() = %+v, want only %q", follows, target)
+	}
+
+	if err := repo.UnfollowTag(ctx, "agent", follower, target); err != nil {
+		t.Fatalf("UnfollowTag() error = %v", err)
+	}
+	follows, _ = repo.ListFollowedTags(ctx, "agent", follower)
+	if len(follows) != 0 {
+		t.Errorf("expected no follows after UnfollowTag, got %+v", follows)
+	}
+}
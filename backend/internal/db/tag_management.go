@@ -0,0 +1,322 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Tag management errors.
+var (
+	ErrTagNotFound           = errors.New("tag not found")
+	ErrTagExists             = errors.New("tag already exists")
+	ErrTagAlreadyBlacklisted = errors.New("tag is already blacklisted")
+	ErrTagNotBlacklisted     = errors.New("tag is not blacklisted")
+)
+
+// visibleTagPostsFilter restricts tag usage to posts anyone can see.
+const visibleTagPostsFilter = `
+	p.deleted_at IS NULL
+	AND p.hidden_at IS NULL
+	AND p.status NOT IN ('pending_review', 'rejected', 'draft')
+	AND p.visibility = 'public'`
+
+// ListTags returns tags used by visible posts with their usage counts,
+// most used first. posts.tags is the source of truth for tag usage.
+func (r *TagsRepository) ListTags(ctx context.Context, opts models.TagListOptions) ([]models.TagSummary, int, error) {
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := opts.PerPage
+	if perPage < 1 {
+		perPage = 50
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH usage AS (
+			SELECT tag AS name,
+				COUNT(*) AS usage_count,
+				COUNT(*) FILTER (WHERE p.type = 'problem') AS problem_count,
+				COUNT(*) FILTER (WHERE p.type = 'question') AS question_count,
+				COUNT(*) FILTER (WHERE p.type = 'idea') AS idea_count,
+				MAX(p.created_at) AS last_used_at
+			FROM posts p, unnest(p.tags) AS tag
+			WHERE `+visibleTagPostsFilter+`
+			  AND left(tag, length($1::text)) = $1
+			GROUP BY tag
+		)
+		SELECT name, usage_count, problem_count, question_count, idea_count, last_used_at,
+			COUNT(*) OVER() AS total
+		FROM usage
+		ORDER BY usage_count DESC, name ASC
+		LIMIT $2 OFFSET $3
+	`, opts.Query, perPage, (page-1)*perPage)
+	if err != nil {
+		LogQueryError(ctx, "ListTags", "posts", err)
+		return nil, 0, fmt.Errorf("list tags failed: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]models.TagSummary, 0)
+	total := 0
+	for rows.Next() {
+		var t models.TagSummary
+		if err := rows.Scan(&t.Name, &t.UsageCount, &t.ProblemCount, &t.QuestionCount, &t.IdeaCount, &t.LastUsedAt, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate tags: %w", err)
+	}
+
+	return tags, total, nil
+}
+
+// GetTag returns usage counts for one tag. Returns ErrTagNotFound if no
+// visible post uses it.
+func (r *TagsRepository) GetTag(ctx context.Context, name string) (*models.TagSummary, error) {
+	t := models.TagSummary{Name: name}
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*),
+			COUNT(*) FILTER (WHERE p.type = 'problem'),
+			COUNT(*) FILTER (WHERE p.type = 'question'),
+			COUNT(*) FILTER (WHERE p.type = 'idea'),
+			COALESCE(MAX(p.created_at), NOW())
+		FROM posts p
+		WHERE $1 = ANY(p.tags) AND `+visibleTagPostsFilter+`
+	`, name).Scan(&t.UsageCount, &t.ProblemCount, &t.QuestionCount, &t.IdeaCount, &t.LastUsedAt)
+	if err != nil {
+		LogQueryError(ctx, "GetTag", "posts", err)
+		return nil, fmt.Errorf("get tag failed: %w", err)
+	}
+	if t.UsageCount == 0 {
+		return nil, ErrTagNotFound
+	}
+	return &t, nil
+}
+
+// ListTopPostsForTag returns the highest voted visible posts with a tag.
+func (r *TagsRepository) ListTopPostsForTag(ctx context.Context, name string, limit int) ([]models.TagPost, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT p.id, p.type, p.title, p.status, p.upvotes - p.downvotes AS vote_score, p.created_at
+		FROM posts p
+		WHERE $1 = ANY(p.tags) AND `+visibleTagPostsFilter+`
+		ORDER BY vote_score DESC, p.created_at DESC
+		LIMIT $2
+	`, name, limit)
+	if err != nil {
+		LogQueryError(ctx, "ListTopPostsForTag", "posts", err)
+		return nil, fmt.Errorf("list top posts for tag failed: %w", err)
+	}
+	defer rows.Close()
+
+	posts := make([]models.TagPost, 0)
+	for rows.Next() {
+		var p models.TagPost
+		if err := rows.Scan(&p.ID, &p.Type, &p.Title, &p.Status, &p.VoteScore, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan tag post: %w", err)
+		}
+		posts = append(posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tag posts: %w", err)
+	}
+
+	return posts, nil
+}
+
+// RenameTag replaces oldName with newName on every post, agent specialty and tag follow.
+// Returns ErrTagNotFound if no post uses oldName, or ErrTagExists if newName
+// is already in use (merge the tags instead). Returns the number of posts updated.
+func (r *TagsRepository) RenameTag(ctx context.Context, oldName, newName string) (int64, error) {
+	var updated int64
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var oldUsed, newUsed bool
+		if err := tx.QueryRow(ctx, `
+			SELECT
+				EXISTS (SELECT 1 FROM posts WHERE $1 = ANY(tags)),
+				EXISTS (SELECT 1 FROM posts WHERE $2 = ANY(tags))
+		`, oldName, newName).Scan(&oldUsed, &newUsed); err != nil {
+			return err
+		}
+		if !oldUsed {
+			return ErrTagNotFound
+		}
+		if newUsed {
+			return ErrTagExists
+		}
+
+		var err error
+		updated, err = replaceTags(ctx, tx, []string{oldName}, newName)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) || errors.Is(err, ErrTagExists) {
+			return 0, err
+		}
+		LogQueryError(ctx, "RenameTag", "posts", err)
+		return 0, fmt.Errorf("rename tag failed: %w", err)
+	}
+	return updated, nil
+}
+
+// MergeTags replaces every source tag with target on posts, agent specialties
+// and tag follows, removing duplicates. Returns ErrTagNotFound if no post uses
+// any source tag. Returns the number of posts updated.
+func (r *TagsRepository) MergeTags(ctx context.Context, sources []string, target string) (int64, error) {
+	var updated int64
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var err error
+		updated, err = replaceTags(ctx, tx, sources, target)
+		if err != nil {
+			return err
+		}
+		if updated == 0 {
+			return ErrTagNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) {
+			return 0, err
+		}
+		LogQueryError(ctx, "MergeTags", "posts", err)
+		return 0, fmt.Errorf("merge tags failed: %w", err)
+	}
+	return updated, nil
+}
+
+// replaceTags swaps sources for target in posts.tags, agents.specialties and
+// tag_follows, keeping each array's order and dropping duplicates the swap creates.
+func replaceTags(ctx context.Context, tx Tx, sources []string, target string) (int64, error) {
+	const replaced = `ARRAY(
+		SELECT t FROM (
+			SELECT CASE WHEN u.orig = ANY($1::text[]) THEN $2::text ELSE u.orig END AS t, MIN(u.ord) AS first
+			FROM unnest(%[1]s) WITH ORDINALITY AS u(orig, ord)
+			GROUP BY 1
+		) d
+		ORDER BY first
+	)`
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE posts SET tags = `+fmt.Sprintf(replaced, "posts.tags")+`
+		WHERE tags && $1::text[]
+	`, sources, target)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE agents SET specialties = `+fmt.Sprintf(replaced, "agents.specialties")+`
+		WHERE specialties && $1::text[]
+	`, sources, target); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO tag_follows (follower_type, follower_id, tag, created_at)
+		SELECT follower_type, follower_id, $2::text, MIN(created_at)
+		FROM tag_follows
+		WHERE tag = ANY($1::text[])
+		GROUP BY follower_type, follower_id
+		ON CONFLICT (follower_type, follower_id, tag) DO NOTHING
+	`, sources, target); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM tag_follows WHERE tag = ANY($1::text[])`, sources); err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// BlacklistTag bans a tag and removes it from every post, agent specialty and tag follow.
+// The strip_blacklisted_tags trigger keeps it off posts from then on.
+// Returns ErrTagAlreadyBlacklisted if it is already banned, and the number of posts updated.
+func (r *TagsRepository) BlacklistTag(ctx context.Context, name, reason string) (int64, error) {
+	var updated int64
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO tag_blacklist (name, reason) VALUES ($1, $2)
+		`, name, reason); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return ErrTagAlreadyBlacklisted
+			}
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `
+			UPDATE posts SET tags = array_remove(tags, $1) WHERE $1 = ANY(tags)
+		`, name)
+		if err != nil {
+			return err
+		}
+		updated = tag.RowsAffected()
+
+		if _, err := tx.Exec(ctx, `
+			UPDATE agents SET specialties = array_remove(specialties, $1) WHERE $1 = ANY(specialties)
+		`, name); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM tag_follows WHERE tag = $1`, name)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, ErrTagAlreadyBlacklisted) {
+			return 0, err
+		}
+		LogQueryError(ctx, "BlacklistTag", "tag_blacklist", err)
+		return 0, fmt.Errorf("blacklist tag failed: %w", err)
+	}
+	return updated, nil
+}
+
+// UnblacklistTag lifts a ban. Posts that lost the tag do not get it back.
+// Returns ErrTagNotBlacklisted if the tag was not banned.
+func (r *TagsRepository) UnblacklistTag(ctx context.Context, name string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM tag_blacklist WHERE name = $1`, name)
+	if err != nil {
+		LogQueryError(ctx, "UnblacklistTag", "tag_blacklist", err)
+		return fmt.Errorf("unblacklist tag failed: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTagNotBlacklisted
+	}
+	return nil
+}
+
+// ListBlacklistedTags returns banned tags, newest first.
+func (r *TagsRepository) ListBlacklistedTags(ctx context.Context) ([]models.BlacklistedTag, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT name, reason, created_at FROM tag_blacklist ORDER BY created_at DESC, name ASC
+	`)
+	if err != nil {
+		LogQueryError(ctx, "ListBlacklistedTags", "tag_blacklist", err)
+		return nil, fmt.Errorf("list blacklisted tags failed: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make([]models.BlacklistedTag, 0)
+	for rows.Next() {
+		var t models.BlacklistedTag
+		if err := rows.Scan(&t.Name, &t.Reason, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan blacklisted tag: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate blacklisted tags: %w", err)
+	}
+
+	return tags, nil
+}
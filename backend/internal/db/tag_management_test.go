@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// insertTaggedPost inserts an open public problem with tags and removes it on cleanup.
+func insertTaggedPost(t *testing.T, pool *Pool, ctx context.Context, tags []string) string {
+	t.Helper()
+	var id string
+	err := pool.QueryRow(ctx, `
+		INSERT INTO posts (type, title, description, tags, status, posted_by_type, posted_by_id)
+		VALUES ('problem', 'Tag management test post', 'Tag management test description', $1, 'open', 'human', 'tag-test-user')
+		RETURNING id
+	`, tags).Scan(&id)
+	if err != nil {
+		t.Fatalf("insertTaggedPost: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM posts WHERE id = $1", id)
+	})
+	return id
+}
+
+func postTags(t *testing.T, pool *Pool, ctx context.Context, id string) string {
+	t.Helper()
+	var tags []string
+	if err := pool.QueryRow(ctx, "SELECT tags FROM posts WHERE id = $1", id).Scan(&tags); err != nil {
+		t.Fatalf("postTags: %v", err)
+	}
+	return strings.Join(tags, ",")
+}
+
+// TestTagsRepository_MergeTags_DedupesAndKeepsOrder tests that merging keeps tag
+// order and does not leave the target twice on a post.
+func TestTagsRepository_MergeTags_DedupesAndKeepsOrder(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewTagsRepository(pool)
+	ctx := context.Background()
+	suffix := time.Now().Format("150405.000000")
+	pg, pgsql, target := "pg-"+suffix, "pgsql-"+suffix, "postgresql-"+suffix
+
+	both := insertTaggedPost(t, pool, ctx, []string{"go", pg, target, pgsql})
+	one := insertTaggedPost(t, pool, ctx, []string{pgsql, "sql"})
+
+	updated, err := repo.MergeTags(ctx, []string{pg, pgsql}, target)
+	if err != nil {
+		t.Fatalf("MergeTags() error = %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("MergeTags() updated = %d, want 2", updated)
+	}
+	if got := postTags(t, pool, ctx, both); got != "go,"+target {
+		t.Errorf("tags = %q, want %q", got, "go,"+target)
+	}
+	if got := postTags(t, pool, ctx, one); got != target+",sql" {
+		t.Errorf("tags = %q, want %q", got, target+",sql")
+	}
+
+	if _, err := repo.RenameTag(ctx, pg, "whatever-"+suffix); !errors.Is(err, ErrTagNotFound) {
+		t.Errorf("RenameTag() of merged-away tag error = %v, want ErrTagNotFound", err)
+	}
+}
+
+// TestTagsRepository_BlacklistTag_StripsOnWrite tests that blacklisting removes the
+// tag from existing posts and the trigger keeps it off new ones.
+func TestTagsRepository_BlacklistTag_StripsOnWrite(t *testing.T) {
+	pool := getTestPool(t)
+	if pool == nil {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+	defer pool.Close()
+
+	repo := NewTagsRepository(pool)
+	ctx := context.Background()
+	spam := "spam-" + time.Now().Format("150405.000000")
+	defer pool.Exec(ctx, "DELETE FROM tag_blacklist WHERE name = $1", spam)
+
+	existing := insertTaggedPost(t, pool, ctx, []string{"go", spam})
+
+	updated, err := repo.BlacklistTag(ctx, spam, "link farm")
+	if err != nil {
+		t.Fatalf("BlacklistTag() error = %v", err)
+	}
+	if updated != 1 || postTags(t, pool, ctx, existing) != "go" {
+		t.Errorf("expected tag removed from existing post, updated=%d tags=%q", updated, postTags(t, pool, ctx, existing))
+	}
+	if _, err := repo.BlacklistTag(ctx, spam, ""); !errors.Is(err, ErrTagAlreadyBlacklisted) {
+		t.Errorf("second BlacklistTag() error = %v, want ErrTagAlreadyBlacklisted", err)
+	}
+
+	created := insertTaggedPost(t, pool, ctx, []string{spam, "rust"})
+	if got := postTags(t, pool, ctx, created); got != "rust" {
+		t.Errorf("new post tags = %q, want %q", got, "rust")
+	}
+
+	if err := repo.UnblacklistTag(ctx, spam); err != nil {
+		t.Fatalf("UnblacklistTag() error = %v", err)
+	}
+	if err := repo.UnblacklistTag(ctx, spam); !errors.Is(err, ErrTagNotBlacklisted) {
+		t.Errorf("second UnblacklistTag() error = %v, want ErrTagNotBlacklisted", err)
+	}
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrTenantHostnameTaken is returned when a hostname already maps to another tenant.
+var ErrTenantHostnameTaken = errors.New("hostname already belongs to another tenant")
+
+// tenantDataKey is the connection CustomData key holding the tenant last applied
+// to the session.
+const tenantDataKey = "tenant_id"
+
+// applyTenant is the pool's BeforeAcquire hook. It sets the app.tenant_id
+// session setting that the tenant_isolation policies read to the tenant in ctx,
+// or clears it for unscoped callers. The applied tenant is remembered on the
+// connection so the common case — same tenant as last checkout — costs no round
+// trip. Returning false discards the connection and the pool tries another.
+func applyTenant(ctx context.Context, conn *pgx.Conn) bool {
+	want := tenant.FromContext(ctx)
+	data := conn.PgConn().CustomData()
+	if current, _ := data[tenantDataKey].(string); current == want {
+		return true
+	}
+	if _, err := conn.Exec(ctx, "SELECT set_config('app.tenant_id', $1, false)", want); err != nil {
+		slog.Error("failed to apply tenant to connection", "tenant", want, "error", err)
+		return false
+	}
+	data[tenantDataKey] = want
+	return true
+}
+
+// TenantRepository stores the tenants a deployment serves.
+type TenantRepository struct {
+	pool *Pool
+}
+
+// NewTenantRepository creates a new TenantRepository.
+func NewTenantRepository(pool *Pool) *TenantRepository {
+	return &TenantRepository{pool: pool}
+}
+
+// List returns every tenant, oldest first.
+func (r *TenantRepository) List(ctx context.Context) ([]models.Tenant, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, name, hostnames, created_at, updated_at
+		FROM tenants
+		ORDER BY created_at, id`)
+	if err != nil {
+		LogQueryError(ctx, "List", "tenants", err)
+		return nil, fmt.Errorf("list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := []models.Tenant{}
+	for rows.Next() {
+		var t models.Tenant
+		if err := rows.Scan(&t.ID, &t.Name, &t.Hostnames, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan tenant: %w", err)
+		}
+		tenants = append(tenants, t)
+	}
+	return tenants, rows.Err()
+}
+
+// Upsert creates the tenant or replaces its name and hostnames. Returns
+// ErrTenantHostnameTaken if one of its hostnames maps to another tenant.
+func (r *TenantRepository) Upsert(ctx context.Context, t *models.Tenant) (*models.Tenant, error) {
+	saved := &models.Tenant{}
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		// Tenants change rarely; serialising writers keeps the hostname check race-free.
+		if _, err := tx.Exec(ctx, `LOCK TABLE tenants IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return fmt.Errorf("lock tenants: %w", err)
+		}
+		var taken bool
+		if err := tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM tenants WHERE id <> $1 AND hostnames && $2::text[])`,
+			t.ID, t.Hostnames).Scan(&taken); err != nil {
+			return fmt.Errorf("check tenant hostnames: %w", err)
+		}
+		if taken {
+			return ErrTenantHostnameTaken
+		}
+		return tx.QueryRow(ctx, `
+			INSERT INTO tenants (id, name, hostnames)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (id) DO UPDATE
+			SET name = EXCLUDED.name, hostnames = EXCLUDED.hostnames, updated_at = NOW()
+			RETURNING id, name, hostnames, created_at, updated_at`,
+			t.ID, t.Name, t.Hostnames).Scan(
+			&saved.ID, &saved.Name, &saved.Hostnames, &saved.CreatedAt, &saved.UpdatedAt)
+	})
+	if err != nil {
+		if !errors.Is(err, ErrTenantHostnameTaken) {
+			LogQueryError(ctx, "Upsert", "tenants", err)
+		}
+		return nil, err
+	}
+	return saved, nil
+}
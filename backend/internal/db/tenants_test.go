@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+func TestTenantRepository_UpsertAndScope(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+	ctx := context.Background()
+	repo := NewTenantRepository(pool)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM posts WHERE tenant_id IN ('test-acme', 'test-other')`)
+		_, _ = pool.Exec(ctx, `DELETE FROM tenants WHERE id IN ('test-acme', 'test-other')`)
+	})
+
+	acme, err := repo.Upsert(ctx, &models.Tenant{ID: "test-acme", Name: "Acme", Hostnames: []string{"acme.test"}})
+	if err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	if acme.Name != "Acme" || len(acme.Hostnames) != 1 {
+		t.Errorf("Upsert() = %+v", acme)
+	}
+
+	if _, err := repo.Upsert(ctx, &models.Tenant{ID: "test-other", Name: "Other", Hostnames: []string{"acme.test"}}); !errors.Is(err, ErrTenantHostnameTaken) {
+		t.Fatalf("Upsert() with taken hostname error = %v, want ErrTenantHostnameTaken", err)
+	}
+	if _, err := repo.Upsert(ctx, &models.Tenant{ID: "test-other", Name: "Other"}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	// Posts created in one tenant are invisible from another; unscoped sessions see both.
+	acmeCtx := tenant.WithID(ctx, "test-acme")
+	var postID string
+	err = pool.QueryRow(acmeCtx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Tenant scoped question title', 'Tenant scoped question body long enough', 'human', 'test-user', 'open')
+		RETURNING id::text`).Scan(&postID)
+	if err != nil {
+		t.Fatalf("insert post error = %v", err)
+	}
+
+	countPost := func(ctx context.Context) int {
+		var n int
+		if err := pool.QueryRow(ctx, `SELECT COUNT(*) FROM posts WHERE id = $1`, postID).Scan(&n); err != nil {
+			t.Fatalf("count post error = %v", err)
+		}
+		return n
+	}
+	if n := countPost(acmeCtx); n != 1 {
+		t.Errorf("acme sees %d posts, want 1", n)
+	}
+	if n := countPost(tenant.WithID(ctx, "test-other")); n != 0 {
+		t.Errorf("other tenant sees %d posts, want 0", n)
+	}
+	if n := countPost(ctx); n != 1 {
+		t.Errorf("unscoped session sees %d posts, want 1", n)
+	}
+}
+
+// Background jobs write moderation comments from unscoped sessions; the comment
+// must still belong to the tenant of the post it is attached to.
+func TestTenantRepository_UnscopedCommentTakesPostTenant(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+	ctx := context.Background()
+	repo := NewTenantRepository(pool)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM comments WHERE tenant_id = 'test-acme'`)
+		_, _ = pool.Exec(ctx, `DELETE FROM posts WHERE tenant_id = 'test-acme'`)
+		_, _ = pool.Exec(ctx, `DELETE FROM tenants WHERE id = 'test-acme'`)
+	})
+
+	if _, err := repo.Upsert(ctx, &models.Tenant{ID: "test-acme", Name: "Acme"}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	acmeCtx := tenant.WithID(ctx, "test-acme")
+	var postID string
+	err := pool.QueryRow(acmeCtx, `
+		INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status)
+		VALUES ('question', 'Tenant scoped question title', 'Tenant scoped question body long enough', 'human', 'test-user', 'open')
+		RETURNING id::text`).Scan(&postID)
+	if err != nil {
+		t.Fatalf("insert post error = %v", err)
+	}
+
+	comment, err := NewCommentsRepository(pool).Create(ctx, &models.Comment{
+		TargetType: models.CommentTargetPost,
+		TargetID:   postID,
+		AuthorType: models.AuthorTypeSystem,
+		AuthorID:   "solvr-moderator",
+		Content:    "Post approved by Solvr moderation.",
+	})
+	if err != nil {
+		t.Fatalf("Create() comment error = %v", err)
+	}
+
+	var tenantID string
+	if err := pool.QueryRow(ctx, `SELECT tenant_id FROM comments WHERE id = $1`, comment.ID).Scan(&tenantID); err != nil {
+		t.Fatalf("select comment tenant error = %v", err)
+	}
+	if tenantID != "test-acme" {
+		t.Errorf("comment tenant = %q, want test-acme", tenantID)
+	}
+}
+
+// Usernames and emails are unique per tenant: a second community can register
+// the same ones, but not twice within itself.
+func TestTenantRepository_UsernamesUniquePerTenant(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+	ctx := context.Background()
+	repo := NewTenantRepository(pool)
+	users := NewUserRepository(pool)
+
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM users WHERE tenant_id IN ('test-acme', 'test-other')`)
+		_, _ = pool.Exec(ctx, `DELETE FROM tenants WHERE id IN ('test-acme', 'test-other')`)
+	})
+
+	for _, id := range []string{"test-acme", "test-other"} {
+		if _, err := repo.Upsert(ctx, &models.Tenant{ID: id, Name: id}); err != nil {
+			t.Fatalf("Upsert(%s) error = %v", id, err)
+		}
+	}
+	newUser := func() *models.User {
+		return &models.User{
+			Username:       "tenantuser",
+			DisplayName:    "Tenant User",
+			Email:          "tenantuser@example.com",
+			AuthProvider:   models.AuthProviderGitHub,
+			AuthProviderID: "tenant-user",
+			Role:           models.UserRoleUser,
+		}
+	}
+
+	if _, err := users.Create(tenant.WithID(ctx, "test-acme"), newUser()); err != nil {
+		t.Fatalf("Create() in test-acme error = %v", err)
+	}
+	if _, err := users.Create(tenant.WithID(ctx, "test-other"), newUser()); err != nil {
+		t.Fatalf("Create() in test-other error = %v, want the username to be free", err)
+	}
+	if _, err := users.Create(tenant.WithID(ctx, "test-acme"), newUser()); !errors.Is(err, ErrDuplicateUsername) {
+		t.Errorf("second Create() in test-acme error = %v, want ErrDuplicateUsername", err)
+	}
+}
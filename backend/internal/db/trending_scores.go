@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// TrendingRepository maintains the trending_scores table read by GET /v1/stats/trending.
+type TrendingRepository struct {
+	pool *Pool
+}
+
+// NewTrendingRepository creates a new TrendingRepository.
+func NewTrendingRepository(pool *Pool) *TrendingRepository {
+	return &TrendingRepository{pool: pool}
+}
+
+// RecomputeTrendingScores replaces trending_scores with fresh scores for visible
+// posts created in the last cfg.WindowDays days, weighted per cfg.
+// Returns the number of posts scored.
+func (r *TrendingRepository) RecomputeTrendingScores(ctx context.Context, cfg models.TrendingConfig) (int64, error) {
+	var scored int64
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM trending_scores`); err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO trending_scores (post_id, score, votes_score, views_score, answers_score, decay, computed_at)
+			SELECT id, (1 + votes_score + views_score + answers_score) * decay,
+				votes_score, views_score, answers_score, decay, NOW()
+			FROM (
+				SELECT
+					p.id,
+					$1::float8 * (p.upvotes - p.downvotes) AS votes_score,
+					$2::float8 * LN(1 + GREATEST(p.view_count, 0)) AS views_score,
+					$3::float8 * (p.answers_count + p.approaches_count + COALESCE(resp.cnt, 0)) AS answers_score,
+					POWER(0.5, EXTRACT(EPOCH FROM (NOW() - p.created_at)) / 3600.0 / $4::float8) AS decay
+				FROM posts p
+				LEFT JOIN (
+					SELECT idea_id, COUNT(*) AS cnt FROM responses GROUP BY idea_id
+				) resp ON resp.idea_id = p.id
+				WHERE p.created_at > NOW() - make_interval(days => $5::int)
+					AND p.deleted_at IS NULL
+					AND p.visibility = 'public' -- BART-151
+					AND p.status NOT IN ('pending_review', 'rejected', 'draft')
+			) s
+		`, cfg.VoteWeight, cfg.ViewWeight, cfg.AnswerWeight, cfg.HalfLifeHours, cfg.WindowDays)
+		if err != nil {
+			return err
+		}
+		scored = tag.RowsAffected()
+		return nil
+	})
+	if err != nil {
+		LogQueryError(ctx, "RecomputeTrendingScores", "trending_scores", err)
+		return 0, fmt.Errorf("recompute trending scores failed: %w", err)
+	}
+	return scored, nil
+}
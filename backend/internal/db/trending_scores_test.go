@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// TestRecomputeTrendingScores_WeightsAndBreakdown verifies that votes and answers
+// raise a post's score and that the stored breakdown matches the weights.
+func TestRecomputeTrendingScores_WeightsAndBreakdown(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	quietID := insertTestPost(t, pool, ctx, "question", "Quiet trending score question",
+		"Nobody voted on this one.", []string{"trending"}, "open")
+	popularID := insertTestPost(t, pool, ctx, "question", "Popular trending score question",
+		"Everybody voted on this one.", []string{"trending"}, "open")
+	if _, err := pool.Exec(ctx, `UPDATE posts SET upvotes = 5, downvotes = 1, answers_count = 2 WHERE id = $1`, popularID); err != nil {
+		t.Fatalf("failed to add votes: %v", err)
+	}
+
+	cfg := models.DefaultTrendingConfig()
+	if _, err := NewTrendingRepository(pool).RecomputeTrendingScores(ctx, cfg); err != nil {
+		t.Fatalf("RecomputeTrendingScores() error = %v", err)
+	}
+
+	scores := map[string]struct{ score, votes, answers, decay float64 }{}
+	rows, err := pool.Query(ctx, `
+		SELECT post_id::text, score, votes_score, answers_score, decay
+		FROM trending_scores WHERE post_id = ANY($1::uuid[])
+	`, []string{quietID, popularID})
+	if err != nil {
+		t.Fatalf("query trending_scores: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var s struct{ score, votes, answers, decay float64 }
+		if err := rows.Scan(&id, &s.score, &s.votes, &s.answers, &s.decay); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		scores[id] = s
+	}
+
+	quiet, popular := scores[quietID], scores[popularID]
+	if popular.votes != 4*cfg.VoteWeight || popular.answers != 2*cfg.AnswerWeight {
+		t.Errorf("popular breakdown votes=%v answers=%v, want %v and %v", popular.votes, popular.answers, 4*cfg.VoteWeight, 2*cfg.AnswerWeight)
+	}
+	if popular.decay <= 0.99 || popular.decay > 1 {
+		t.Errorf("fresh post decay = %v, want ~1", popular.decay)
+	}
+	want := (1 + popular.votes + popular.answers) * popular.decay
+	if math.Abs(popular.score-want) > 1e-9 {
+		t.Errorf("popular score = %v, want %v", popular.score, want)
+	}
+	if popular.score <= quiet.score {
+		t.Errorf("expected popular score %v above quiet score %v", popular.score, quiet.score)
+	}
+}
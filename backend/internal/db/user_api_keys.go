@@ -29,9 +29,9 @@ func NewUserAPIKeyRepository(pool *Pool) *UserAPIKeyRepository {
 // KeySHA256 is optional (nullable) but should be provided for O(1) lookups.
 func (r *UserAPIKeyRepository) Create(ctx context.Context, key *models.UserAPIKey) (*models.UserAPIKey, error) {
 	query := `
-		INSERT INTO user_api_keys (user_id, name, key_hash, key_sha256)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, user_id, name, key_hash, last_used_at, revoked_at, created_at, updated_at
+		INSERT INTO user_api_keys (user_id, name, key_hash, key_sha256, scope)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, user_id, name, key_hash, scope, last_used_at, revoked_at, created_at, updated_at
 	`
 
 	var sha256Val *string
@@ -44,6 +44,7 @@ func (r *UserAPIKeyRepository) Create(ctx context.Context, key *models.UserAPIKe
 		key.Name,
 		key.KeyHash,
 		sha256Val,
+		key.EffectiveScope(),
 	)
 
 	return r.scanUserAPIKey(row)
@@ -53,7 +54,7 @@ func (r *UserAPIKeyRepository) Create(ctx context.Context, key *models.UserAPIKe
 // Only returns keys where revoked_at IS NULL.
 func (r *UserAPIKeyRepository) FindByUserID(ctx context.Context, userID string) ([]*models.UserAPIKey, error) {
 	query := `
-		SELECT id, user_id, name, key_hash, last_used_at, revoked_at, created_at, updated_at
+		SELECT id, user_id, name, key_hash, scope, last_used_at, revoked_at, created_at, updated_at
 		FROM user_api_keys
 		WHERE user_id = $1 AND revoked_at IS NULL
 		ORDER BY created_at DESC
@@ -93,7 +94,7 @@ func (r *UserAPIKeyRepository) FindByUserID(ctx context.Context, userID string)
 // Returns the key even if revoked (for audit purposes).
 func (r *UserAPIKeyRepository) FindByID(ctx context.Context, id string) (*models.UserAPIKey, error) {
 	query := `
-		SELECT id, user_id, name, key_hash, last_used_at, revoked_at, created_at, updated_at
+		SELECT id, user_id, name, key_hash, scope, last_used_at, revoked_at, created_at, updated_at
 		FROM user_api_keys
 		WHERE id = $1
 	`
@@ -150,7 +151,7 @@ func (r *UserAPIKeyRepository) Regenerate(ctx context.Context, id, userID, newKe
 		UPDATE user_api_keys
 		SET key_hash = $1, key_sha256 = $2, updated_at = NOW()
 		WHERE id = $3 AND user_id = $4 AND revoked_at IS NULL
-		RETURNING id, user_id, name, key_hash, last_used_at, revoked_at, created_at, updated_at
+		RETURNING id, user_id, name, key_hash, scope, last_used_at, revoked_at, created_at, updated_at
 	`
 
 	row := r.pool.QueryRow(ctx, query, newKeyHash, newKeySHA256, id, userID)
@@ -165,6 +166,7 @@ func (r *UserAPIKeyRepository) scanUserAPIKey(row pgx.Row) (*models.UserAPIKey,
 		&key.UserID,
 		&key.Name,
 		&key.KeyHash,
+		&key.Scope,
 		&key.LastUsedAt,
 		&key.RevokedAt,
 		&key.CreatedAt,
@@ -191,6 +193,7 @@ func (r *UserAPIKeyRepository) scanUserAPIKeyFromRows(rows pgx.Rows) (*models.Us
 		&key.UserID,
 		&key.Name,
 		&key.KeyHash,
+		&key.Scope,
 		&key.LastUsedAt,
 		&key.RevokedAt,
 		&key.CreatedAt,
@@ -207,7 +210,7 @@ func (r *UserAPIKeyRepository) scanUserAPIKeyFromRows(rows pgx.Rows) (*models.Us
 // GetUserByAPIKey validates a plain text API key and returns the associated user and key.
 // Uses SHA256 for O(1) indexed lookup. Falls back to O(n) bcrypt scan for keys
 // that haven't been backfilled yet, and lazy-backfills their SHA256 on match.
-// Returns nil, nil, nil if no matching key is found.
+// Returns nil, nil, nil if no matching key is found or the owner is suspended or banned.
 func (r *UserAPIKeyRepository) GetUserByAPIKey(ctx context.Context, plainKey string) (*models.User, *models.UserAPIKey, error) {
 	keySHA256 := auth.SHA256APIKey(plainKey)
 
@@ -227,23 +230,23 @@ func (r *UserAPIKeyRepository) GetUserByAPIKey(ctx context.Context, plainKey str
 // getUserByKeySHA256 does an O(1) indexed lookup by SHA256 hash.
 func (r *UserAPIKeyRepository) getUserByKeySHA256(ctx context.Context, keySHA256 string) (*models.User, *models.UserAPIKey, error) {
 	query := `
-		SELECT k.id, k.user_id, k.name, k.key_hash, k.last_used_at, k.revoked_at, k.created_at, k.updated_at,
+		SELECT k.id, k.user_id, k.name, k.key_hash, k.scope, k.last_used_at, k.revoked_at, k.created_at, k.updated_at,
 		       u.id, u.username, u.display_name, u.email, u.auth_provider, u.auth_provider_id,
-		       u.avatar_url, u.bio, u.role, u.created_at, u.updated_at
+		       u.avatar_url, u.bio, u.role, u.created_at, u.updated_at, u.tenant_id
 		FROM user_api_keys k
 		JOIN users u ON k.user_id = u.id
-		WHERE k.key_sha256 = $1 AND k.revoked_at IS NULL
+		WHERE k.key_sha256 = $1 AND k.revoked_at IS NULL AND u.status = 'active'
 	`
 
 	key := &models.UserAPIKey{}
 	user := &models.User{}
 
 	err := r.pool.QueryRow(ctx, query, keySHA256).Scan(
-		&key.ID, &key.UserID, &key.Name, &key.KeyHash,
+		&key.ID, &key.UserID, &key.Name, &key.KeyHash, &key.Scope,
 		&key.LastUsedAt, &key.RevokedAt, &key.CreatedAt, &key.UpdatedAt,
 		&user.ID, &user.Username, &user.DisplayName, &user.Email,
 		&user.AuthProvider, &user.AuthProviderID, &user.AvatarURL,
-		&user.Bio, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.Bio, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.TenantID,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -265,12 +268,12 @@ func (r *UserAPIKeyRepository) getUserByKeyBcryptFallback(ctx context.Context, p
 	defer cancel()
 
 	query := `
-		SELECT k.id, k.user_id, k.name, k.key_hash, k.last_used_at, k.revoked_at, k.created_at, k.updated_at,
+		SELECT k.id, k.user_id, k.name, k.key_hash, k.scope, k.last_used_at, k.revoked_at, k.created_at, k.updated_at,
 		       u.id, u.username, u.display_name, u.email, u.auth_provider, u.auth_provider_id,
-		       u.avatar_url, u.bio, u.role, u.created_at, u.updated_at
+		       u.avatar_url, u.bio, u.role, u.created_at, u.updated_at, u.tenant_id
 		FROM user_api_keys k
 		JOIN users u ON k.user_id = u.id
-		WHERE k.revoked_at IS NULL AND k.key_sha256 IS NULL
+		WHERE k.revoked_at IS NULL AND k.key_sha256 IS NULL AND u.status = 'active'
 	`
 
 	rows, err := r.pool.Query(ctx, query)
@@ -290,11 +293,11 @@ func (r *UserAPIKeyRepository) getUserByKeyBcryptFallback(ctx context.Context, p
 		user := &models.User{}
 
 		err := rows.Scan(
-			&key.ID, &key.UserID, &key.Name, &key.KeyHash,
+			&key.ID, &key.UserID, &key.Name, &key.KeyHash, &key.Scope,
 			&key.LastUsedAt, &key.RevokedAt, &key.CreatedAt, &key.UpdatedAt,
 			&user.ID, &user.Username, &user.DisplayName, &user.Email,
 			&user.AuthProvider, &user.AuthProviderID, &user.AvatarURL,
-			&user.Bio, &user.Role, &user.CreatedAt, &user.UpdatedAt,
+			&user.Bio, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.TenantID,
 		)
 		if err != nil {
 			LogQueryError(ctx, "getUserByKeyBcryptFallback.Scan", "user_api_keys", err)
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/reputation"
+)
+
+// UserProfileRepository reads the activity history and reputation breakdown
+// shown on a human's public profile.
+type UserProfileRepository struct {
+	pool *Pool
+}
+
+// NewUserProfileRepository creates a new UserProfileRepository.
+func NewUserProfileRepository(pool *Pool) *UserProfileRepository {
+	return &UserProfileRepository{pool: pool}
+}
+
+// ListActivity returns a page of the user's public posts, answers and approaches,
+// newest first, with the total. Drafts, posts awaiting or failing moderation,
+// family posts and anything under them, and hidden or deleted content are left out.
+func (r *UserProfileRepository) ListActivity(ctx context.Context, userID string, page, perPage int) ([]models.UserActivityItem, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 20
+	}
+	if perPage > 50 {
+		perPage = 50
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH activity AS (
+			SELECT p.type::text AS type, p.id::text AS id, p.id::text AS post_id, p.title AS post_title,
+			       p.description AS content, p.status::text AS status, p.upvotes - p.downvotes AS vote_score, p.created_at
+			FROM posts p
+			WHERE p.posted_by_type = 'human' AND p.posted_by_id = $1
+			  AND p.deleted_at IS NULL AND p.hidden_at IS NULL AND p.visibility = 'public'
+			  AND p.status NOT IN ('draft', 'pending_review', 'rejected')
+			UNION ALL
+			SELECT 'answer', ans.id::text, q.id::text, q.title,
+			       ans.content, CASE WHEN ans.is_accepted THEN 'accepted' ELSE '' END, ans.upvotes - ans.downvotes, ans.created_at
+			FROM answers ans
+			JOIN posts q ON q.id = ans.question_id
+			WHERE ans.author_type = 'human' AND ans.author_id = $1
+			  AND ans.deleted_at IS NULL AND ans.hidden_at IS NULL
+			  AND q.deleted_at IS NULL AND q.visibility = 'public'
+			UNION ALL
+			SELECT 'approach', a.id::text, p.id::text, p.title,
+			       COALESCE(NULLIF(a.angle, ''), a.method, ''), a.status::text, 0, a.created_at
+			FROM approaches a
+			JOIN posts p ON p.id = a.problem_id
+			WHERE a.author_type = 'human' AND a.author_id = $1
+			  AND a.deleted_at IS NULL
+			  AND p.deleted_at IS NULL AND p.visibility = 'public'
+		)
+		SELECT type, id, post_id, post_title, content, status, vote_score, created_at, COUNT(*) OVER ()
+		FROM activity
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`, userID, perPage, (page-1)*perPage)
+	if err != nil {
+		LogQueryError(ctx, "ListActivity", "posts", err)
+		return nil, 0, fmt.Errorf("list user activity: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.UserActivityItem{}
+	total := 0
+	for rows.Next() {
+		var item models.UserActivityItem
+		var content string
+		if err := rows.Scan(&item.Type, &item.ID, &item.PostID, &item.PostTitle, &content,
+			&item.Status, &item.VoteScore, &item.CreatedAt, &total); err != nil {
+			return nil, 0, fmt.Errorf("scan user activity: %w", err)
+		}
+		item.Preview = models.TruncateContent(content, 200)
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list user activity: %w", err)
+	}
+
+	// COUNT(*) OVER () is only known when the page has rows.
+	if len(items) == 0 && page > 1 {
+		total, err = r.countActivity(ctx, userID)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return items, total, nil
+}
+
+// countActivity counts what ListActivity lists.
+func (r *UserProfileRepository) countActivity(ctx context.Context, userID string) (int, error) {
+	var total int
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM posts p
+			 WHERE p.posted_by_type = 'human' AND p.posted_by_id = $1
+			   AND p.deleted_at IS NULL AND p.hidden_at IS NULL AND p.visibility = 'public'
+			   AND p.status NOT IN ('draft', 'pending_review', 'rejected'))
+			+ (SELECT COUNT(*) FROM answers ans JOIN posts q ON q.id = ans.question_id
+			   WHERE ans.author_type = 'human' AND ans.author_id = $1
+			     AND ans.deleted_at IS NULL AND ans.hidden_at IS NULL
+			     AND q.deleted_at IS NULL AND q.visibility = 'public')
+			+ (SELECT COUNT(*) FROM approaches a JOIN posts p ON p.id = a.problem_id
+			   WHERE a.author_type = 'human' AND a.author_id = $1
+			     AND a.deleted_at IS NULL
+			     AND p.deleted_at IS NULL AND p.visibility = 'public')
+	`, userID).Scan(&total)
+	if err != nil {
+		LogQueryError(ctx, "countActivity", "posts", err)
+		return 0, fmt.Errorf("count user activity: %w", err)
+	}
+	return total, nil
+}
+
+// GetReputationBreakdown returns the user's reputation split by source, counted
+// the same way as reputation.BuildReputationSQL.
+func (r *UserProfileRepository) GetReputationBreakdown(ctx context.Context, userID string) (*models.ReputationBreakdown, error) {
+	var c reputation.ActivityCounts
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM posts p WHERE p.posted_by_type = 'human' AND p.posted_by_id = $1
+			   AND p.type = 'problem' AND p.status = 'solved' AND p.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM posts p WHERE p.posted_by_type = 'human' AND p.posted_by_id = $1
+			   AND p.type = 'problem' AND p.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM answers ans WHERE ans.author_type = 'human' AND ans.author_id = $1
+			   AND ans.is_accepted = true AND ans.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM answers ans WHERE ans.author_type = 'human' AND ans.author_id = $1
+			   AND ans.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM posts p WHERE p.posted_by_type = 'human' AND p.posted_by_id = $1
+			   AND p.type = 'idea' AND p.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM responses r WHERE r.author_type = 'human' AND r.author_id = $1),
+			(SELECT COUNT(*) FROM comments c WHERE c.author_type = 'human' AND c.author_id = $1
+			   AND c.deleted_at IS NULL),
+			COUNT(*) FILTER (WHERE v.direction = 'up'),
+			COUNT(*) FILTER (WHERE v.direction = 'down'),
+			(SELECT COALESCE(SUM(ba.weight - 1), 0) FROM bounty_awards ba
+			 WHERE ba.recipient_type = 'human' AND ba.recipient_id = $1)
+		FROM votes v
+		WHERE v.confirmed = true AND (
+			(v.target_type = 'post' AND EXISTS (
+				SELECT 1 FROM posts p WHERE p.id = v.target_id AND p.posted_by_type = 'human' AND p.posted_by_id = $1))
+			OR (v.target_type = 'answer' AND EXISTS (
+				SELECT 1 FROM answers ans WHERE ans.id = v.target_id AND ans.author_type = 'human' AND ans.author_id = $1))
+			OR (v.target_type = 'response' AND EXISTS (
+				SELECT 1 FROM responses r WHERE r.id = v.target_id AND r.author_type = 'human' AND r.author_id = $1))
+		)
+	`, userID).Scan(&c.ProblemsSolved, &c.ProblemsContributed, &c.AnswersAccepted, &c.AnswersGiven,
+		&c.IdeasPosted, &c.ResponsesGiven, &c.CommentsGiven, &c.UpvotesReceived, &c.DownvotesReceived, &c.BountyWeight)
+	if err != nil {
+		LogQueryError(ctx, "GetReputationBreakdown", "users", err)
+		return nil, fmt.Errorf("get reputation breakdown: %w", err)
+	}
+
+	breakdown := c.Breakdown()
+	return &breakdown, nil
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestUserProfileRepository_ActivityAndBreakdown(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewUserProfileRepository(pool)
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano()%1e9)
+	user, err := NewUserRepository(pool).Create(ctx, &models.User{
+		Username:       "profile" + suffix,
+		DisplayName:    "Profile Test User",
+		Email:          "profile" + suffix + "@test.com",
+		AuthProvider:   "github",
+		AuthProviderID: "gh-profile-" + suffix,
+		Role:           "user",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() {
+		_, _ = pool.Exec(ctx, "DELETE FROM approaches WHERE author_id = $1", user.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM answers WHERE author_id = $1", user.ID)
+		_, _ = pool.Exec(ctx, "DELETE FROM posts WHERE posted_by_id = $1", user.ID)
+		cleanupTestUser(ctx, pool, user.ID)
+	}()
+
+	insertPost := func(postType, status, visibility string) string {
+		var id string
+		if err := pool.QueryRow(ctx, `
+			INSERT INTO posts (type, title, description, posted_by_type, posted_by_id, status, visibility)
+			VALUES ($1, 'Profile test post', 'A description for the profile test.', 'human', $2, $3, $4)
+			RETURNING id::text
+		`, postType, user.ID, status, visibility).Scan(&id); err != nil {
+			t.Fatalf("failed to insert post: %v", err)
+		}
+		return id
+	}
+	problem := insertPost("problem", "solved", "public")
+	question := insertPost("question", "open", "public")
+	insertPost("idea", "draft", "public")
+	insertPost("question", "open", "family")
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO answers (question_id, author_type, author_id, content, is_accepted)
+		VALUES ($1, 'human', $2, 'Close the pool on shutdown.', true)
+	`, question, user.ID); err != nil {
+		t.Fatalf("failed to insert answer: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `
+		INSERT INTO approaches (problem_id, author_type, author_id, angle, status)
+		VALUES ($1, 'human', $2, 'Restart the pool', 'succeeded')
+	`, problem, user.ID); err != nil {
+		t.Fatalf("failed to insert approach: %v", err)
+	}
+
+	items, total, err := repo.ListActivity(ctx, user.ID, 1, 2)
+	if err != nil {
+		t.Fatalf("ListActivity() error = %v", err)
+	}
+	if total != 4 || len(items) != 2 {
+		t.Fatalf("ListActivity() = %d items of %d, want 2 of 4 (no draft or family post)", len(items), total)
+	}
+	if _, total, _ := repo.ListActivity(ctx, user.ID, 5, 2); total != 4 {
+		t.Errorf("total past the last page = %d, want 4", total)
+	}
+
+	b, err := repo.GetReputationBreakdown(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetReputationBreakdown() error = %v", err)
+	}
+	// One solved problem (100 + 25), one accepted answer (50 + 10)
+	if b.Total != 185 {
+		t.Errorf("Total = %d, want 185 (%+v)", b.Total, b.Sources)
+	}
+	rep, err := NewReputationRepository(pool).GetReputation(ctx, models.AuthorTypeHuman, user.ID)
+	if err != nil || rep != b.Total {
+		t.Errorf("GetReputation() = %d, %v; want the breakdown total %d", rep, err, b.Total)
+	}
+}
@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	// ErrUsernameReserved is returned when the username was given up by another
+	// user within the reuse hold period.
+	ErrUsernameReserved = errors.New("username is reserved")
+	// ErrUsernameChangeTooSoon is returned when the user changed their username
+	// within the cooldown period.
+	ErrUsernameChangeTooSoon = errors.New("username changed too recently")
+)
+
+// ChangeUsername renames a user and records the old username in
+// username_history. A user may take back a username they gave up themselves.
+// Like the history, the reuse hold is per tenant.
+// Returns ErrNotFound, ErrDuplicateUsername, ErrUsernameReserved or
+// ErrUsernameChangeTooSoon.
+func (r *UserRepository) ChangeUsername(ctx context.Context, userID, username string, cfg models.UsernameChangeConfig) (*models.User, error) {
+	var updated *models.User
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var current string
+		err := tx.QueryRow(ctx, `SELECT username FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, userID).Scan(&current)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		if current != username {
+			var tooSoon, reserved bool
+			err = tx.QueryRow(ctx, `
+				SELECT
+					EXISTS (SELECT 1 FROM username_history
+					        WHERE user_id = $1 AND changed_at > NOW() - make_interval(days => $3)),
+					EXISTS (SELECT 1 FROM username_history
+					        WHERE username = $2 AND user_id <> $1 AND changed_at > NOW() - make_interval(days => $4))
+			`, userID, username, cfg.CooldownDays, cfg.ReuseHoldDays).Scan(&tooSoon, &reserved)
+			if err != nil {
+				return err
+			}
+			if tooSoon {
+				return ErrUsernameChangeTooSoon
+			}
+			if reserved {
+				return ErrUsernameReserved
+			}
+
+			if _, err := tx.Exec(ctx, `INSERT INTO username_history (user_id, username) VALUES ($1, $2)`, userID, current); err != nil {
+				return err
+			}
+		}
+
+		updated, err = r.scanUser(tx.QueryRow(ctx, `
+			UPDATE users
+			SET username = $2, updated_at = NOW()
+			WHERE id = $1
+			RETURNING id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at, status
+		`, userID, username))
+		return err
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "users_username_key") {
+			return nil, ErrDuplicateUsername
+		}
+		if errors.Is(err, ErrNotFound) || errors.Is(err, ErrUsernameReserved) || errors.Is(err, ErrUsernameChangeTooSoon) {
+			return nil, err
+		}
+		LogQueryError(ctx, "ChangeUsername", "users", err)
+		return nil, fmt.Errorf("change username: %w", err)
+	}
+	return updated, nil
+}
+
+// FindByPreviousUsername returns the user who most recently gave up username,
+// so old profile URLs can redirect. Returns ErrNotFound if nobody has, or if
+// that user was deleted.
+func (r *UserRepository) FindByPreviousUsername(ctx context.Context, username string) (*models.User, error) {
+	row := r.pool.QueryRow(ctx, `
+		SELECT u.id, u.username, u.display_name, u.email, u.auth_provider, u.auth_provider_id, u.password_hash, u.avatar_url, u.bio, u.role, u.referral_code, u.created_at, u.updated_at, u.status
+		FROM username_history h
+		JOIN users u ON u.id = h.user_id
+		WHERE h.username = $1 AND u.deleted_at IS NULL
+		ORDER BY h.changed_at DESC
+		LIMIT 1
+	`, username)
+	return r.scanUser(row)
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestUserRepository_ChangeUsername(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	repo := NewUserRepository(pool)
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano()%1e9)
+	newUser := func(name string) *models.User {
+		u, err := repo.Create(ctx, &models.User{
+			Username:       name + suffix,
+			DisplayName:    name,
+			Email:          name + suffix + "@test.com",
+			AuthProvider:   "github",
+			AuthProviderID: "gh-" + name + suffix,
+			Role:           "user",
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return u
+	}
+	ana, bob := newUser("ana"), newUser("bob")
+	defer cleanupTestUser(ctx, pool, ana.ID)
+	defer cleanupTestUser(ctx, pool, bob.ID)
+
+	oldName := ana.Username
+	renamed, err := repo.ChangeUsername(ctx, ana.ID, "anew"+suffix, models.UsernameChangeConfig{ReuseHoldDays: 90, CooldownDays: 30})
+	if err != nil {
+		t.Fatalf("ChangeUsername() error = %v", err)
+	}
+	if renamed.Username != "anew"+suffix {
+		t.Errorf("Username = %q, want anew%s", renamed.Username, suffix)
+	}
+
+	if _, err := repo.ChangeUsername(ctx, ana.ID, "again"+suffix, models.UsernameChangeConfig{ReuseHoldDays: 90, CooldownDays: 30}); !errors.Is(err, ErrUsernameChangeTooSoon) {
+		t.Errorf("second change err = %v, want ErrUsernameChangeTooSoon", err)
+	}
+	if _, err := repo.ChangeUsername(ctx, bob.ID, oldName, models.UsernameChangeConfig{ReuseHoldDays: 90}); !errors.Is(err, ErrUsernameReserved) {
+		t.Errorf("claiming a held username err = %v, want ErrUsernameReserved", err)
+	}
+	if _, err := repo.ChangeUsername(ctx, bob.ID, renamed.Username, models.UsernameChangeConfig{}); !errors.Is(err, ErrDuplicateUsername) {
+		t.Errorf("claiming a taken username err = %v, want ErrDuplicateUsername", err)
+	}
+
+	found, err := repo.FindByPreviousUsername(ctx, oldName)
+	if err != nil || found.ID != ana.ID {
+		t.Fatalf("FindByPreviousUsername() = %v, %v; want ana", found, err)
+	}
+	if _, err := repo.FindByPreviousUsername(ctx, "never"+suffix); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindByPreviousUsername(unknown) err = %v, want ErrNotFound", err)
+	}
+}
@@ -48,7 +48,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models
 	query := `
 		INSERT INTO users (username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at
+		RETURNING id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at, status
 	`
 
 	row := r.pool.QueryRow(ctx, query,
@@ -83,6 +83,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models
 		&referralCode,
 		&created.CreatedAt,
 		&created.UpdatedAt,
+		&created.Status,
 	)
 
 	// Convert nullable fields to strings (empty if NULL)
@@ -115,7 +116,7 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models
 // Filters out soft-deleted users (WHERE deleted_at IS NULL).
 func (r *UserRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
 	query := `
-		SELECT id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at
+		SELECT id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at, status
 		FROM users
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -139,7 +140,7 @@ func (r *UserRepository) FindByAuthProvider(ctx context.Context, provider, provi
 	query := `
 		SELECT u.id, u.username, u.display_name, u.email, u.auth_provider,
 		       u.auth_provider_id, u.password_hash, u.avatar_url, u.bio,
-		       u.role, u.referral_code, u.created_at, u.updated_at
+		       u.role, u.referral_code, u.created_at, u.updated_at, u.status
 		FROM users u
 		INNER JOIN auth_methods am ON u.id = am.user_id
 		WHERE am.auth_provider = $1 AND am.auth_provider_id = $2
@@ -155,7 +156,7 @@ func (r *UserRepository) FindByAuthProvider(ctx context.Context, provider, provi
 // Filters out soft-deleted users (WHERE deleted_at IS NULL).
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	query := `
-		SELECT id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at
+		SELECT id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at, status
 		FROM users
 		WHERE email = $1 AND deleted_at IS NULL
 	`
@@ -169,7 +170,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models
 // Filters out soft-deleted users (WHERE deleted_at IS NULL).
 func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
 	query := `
-		SELECT id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at
+		SELECT id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at, status
 		FROM users
 		WHERE username = $1 AND deleted_at IS NULL
 	`
@@ -185,7 +186,7 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) (*models
 		UPDATE users
 		SET display_name = $2, avatar_url = $3, bio = $4, updated_at = NOW()
 		WHERE id = $1
-		RETURNING id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at
+		RETURNING id, username, display_name, email, auth_provider, auth_provider_id, password_hash, avatar_url, bio, role, referral_code, created_at, updated_at, status
 	`
 
 	row := r.pool.QueryRow(ctx, query,
@@ -219,20 +220,24 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 // HardDelete permanently removes a user from the database (admin-only).
 // Per PRD-v5 Task 17: Admin hard-delete endpoints.
 // This is IRREVERSIBLE - the user record is permanently deleted.
-// Returns ErrNotFound if user doesn't exist.
+// Returns ErrNotFound if user doesn't exist and ErrLegalHold if they author held content.
 func (r *UserRepository) HardDelete(ctx context.Context, id string) error {
-	query := `DELETE FROM users WHERE id = $1`
-
-	result, err := r.pool.Exec(ctx, query, id)
+	// Anonymize authored content and votes first so nothing is orphaned or
+	// left pointing at the removed user (see account_deletions.go)
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		return purgeUserTx(ctx, tx, id)
+	})
 	if err != nil {
+		if errors.Is(err, ErrNotFound) || isInvalidUUIDError(err) {
+			return ErrNotFound
+		}
+		if errors.Is(err, ErrLegalHold) {
+			return ErrLegalHold
+		}
 		LogQueryError(ctx, "HardDelete", "users", err)
 		return err
 	}
 
-	if result.RowsAffected() == 0 {
-		return ErrNotFound
-	}
-
 	return nil
 }
 
@@ -318,9 +323,9 @@ func (r *UserRepository) ListDeleted(ctx context.Context, page, perPage int) ([]
 }
 
 // scanUser scans a user row into a User struct.
-// Expects 13 columns in order: id, username, display_name, email,
+// Expects 14 columns in order: id, username, display_name, email,
 // auth_provider, auth_provider_id, password_hash, avatar_url, bio, role,
-// referral_code, created_at, updated_at.
+// referral_code, created_at, updated_at, status.
 func (r *UserRepository) scanUser(row pgx.Row) (*models.User, error) {
 	user := &models.User{}
 
@@ -343,6 +348,7 @@ func (r *UserRepository) scanUser(row pgx.Row) (*models.User, error) {
 		&referralCode,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Status,
 	)
 
 	// Convert nullable fields to strings (empty if NULL)
@@ -378,6 +384,7 @@ func (r *UserRepository) GetUserStats(ctx context.Context, userID string) (*mode
 	//            + responses_given * 5
 	//            + upvotes_received * 2
 	//            - downvotes_received * 1
+	//            + bounty_weight * 25 (sum of weight - 1 over bounties earned)
 	query := `
 		WITH user_posts AS (
 			SELECT
@@ -416,6 +423,11 @@ func (r *UserRepository) GetUserStats(ctx context.Context, userID string) (*mode
 					SELECT 1 FROM responses r WHERE r.id = v.target_id AND r.author_type = 'human' AND r.author_id = $1
 				))
 			)
+		),
+		user_bounties AS (
+			SELECT COALESCE(SUM(weight - 1), 0) as bounty_weight
+			FROM bounty_awards
+			WHERE recipient_type = 'human' AND recipient_id = $1
 		)
 		SELECT
 			COALESCE(up.posts_created, 0)::int,
@@ -430,8 +442,9 @@ func (r *UserRepository) GetUserStats(ctx context.Context, userID string) (*mode
 			 COALESCE(up.ideas_posted, 0) * 15 +
 			 COALESCE(ur.responses_given, 0) * 5 +
 			 COALESCE(uv.upvotes, 0) * 2 -
-			 COALESCE(uv.downvotes, 0))::int as reputation
-		FROM user_posts up, user_answers ua, user_responses ur, user_votes_received uv
+			 COALESCE(uv.downvotes, 0) +
+			 ubw.bounty_weight * 25)::int as reputation
+		FROM user_posts up, user_answers ua, user_responses ur, user_votes_received uv, user_bounties ubw
 	`
 
 	row := r.pool.QueryRow(ctx, query, userID)
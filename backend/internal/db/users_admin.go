@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// adminUserColumns is the column list scanned by scanAdminUser.
+// Unlike scanUser it includes soft-deleted users and moderation metadata.
+const adminUserColumns = `id, username, display_name, email, auth_provider, auth_provider_id,
+	password_hash, avatar_url, bio, role, referral_code, created_at, updated_at,
+	status, status_reason, status_changed_at, status_changed_by, deleted_at`
+
+// AdminList returns users matching the admin filters, newest first.
+// Status "deleted" selects soft-deleted users; any other status selects
+// non-deleted users with that moderation status. An empty status returns
+// every non-deleted user. Provider matches any linked auth method.
+func (r *UserRepository) AdminList(ctx context.Context, opts models.UserListOptions) ([]models.User, int, error) {
+	page := opts.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := opts.PerPage
+	if perPage < 1 {
+		perPage = 20
+	}
+	if perPage > 100 {
+		perPage = 100
+	}
+
+	var conditions []string
+	var args []any
+	argNum := 1
+
+	switch opts.Status {
+	case "":
+		conditions = append(conditions, "deleted_at IS NULL")
+	case "deleted":
+		conditions = append(conditions, "deleted_at IS NOT NULL")
+	default:
+		conditions = append(conditions, "deleted_at IS NULL", fmt.Sprintf("status = $%d", argNum))
+		args = append(args, opts.Status)
+		argNum++
+	}
+
+	if opts.Provider != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM auth_methods am WHERE am.user_id = users.id AND am.auth_provider = $%d)", argNum))
+		args = append(args, opts.Provider)
+		argNum++
+	}
+
+	if opts.CreatedAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argNum))
+		args = append(args, *opts.CreatedAfter)
+		argNum++
+	}
+
+	if opts.CreatedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", argNum))
+		args = append(args, *opts.CreatedBefore)
+		argNum++
+	}
+
+	if opts.Query != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"(username ILIKE $%d OR display_name ILIKE $%d OR email ILIKE $%d)", argNum, argNum, argNum))
+		args = append(args, "%"+opts.Query+"%")
+		argNum++
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM users " + where
+	if err := r.pool.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		LogQueryError(ctx, "AdminList.Count", "users", err)
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM users
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, adminUserColumns, where, argNum, argNum+1)
+	args = append(args, perPage, (page-1)*perPage)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		LogQueryError(ctx, "AdminList", "users", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		user, err := scanAdminUser(rows)
+		if err != nil {
+			LogQueryError(ctx, "AdminList.Scan", "users", err)
+			return nil, 0, err
+		}
+		users = append(users, *user)
+	}
+
+	if err := rows.Err(); err != nil {
+		LogQueryError(ctx, "AdminList.Rows", "users", err)
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// FindByIDForAdmin finds a user by ID including soft-deleted users and
+// moderation metadata. Returns ErrNotFound if the user doesn't exist.
+func (r *UserRepository) FindByIDForAdmin(ctx context.Context, id string) (*models.User, error) {
+	query := `SELECT ` + adminUserColumns + ` FROM users WHERE id = $1`
+
+	user, err := scanAdminUser(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+			return nil, ErrNotFound
+		}
+		LogQueryError(ctx, "FindByIDForAdmin", "users", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// SetStatus changes a user's moderation status and records why and by whom.
+// Restoring a user to active clears the stored reason.
+// Returns ErrNotFound if the user doesn't exist or is soft-deleted.
+func (r *UserRepository) SetStatus(ctx context.Context, id string, status models.UserStatus, reason, changedBy string) (*models.User, error) {
+	if status == models.UserStatusActive {
+		reason = ""
+	}
+
+	query := `
+		UPDATE users
+		SET status = $2,
+		    status_reason = NULLIF($3, ''),
+		    status_changed_at = NOW(),
+		    status_changed_by = NULLIF($4, ''),
+		    updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + adminUserColumns
+
+	user, err := scanAdminUser(r.pool.QueryRow(ctx, query, id, string(status), reason, changedBy))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+			return nil, ErrNotFound
+		}
+		LogQueryError(ctx, "SetStatus", "users", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// Restore undoes a soft delete and cancels any pending GDPR purge.
+// Content already anonymized by the deletion request stays anonymized.
+// Returns ErrNotFound if the user doesn't exist or isn't deleted.
+func (r *UserRepository) Restore(ctx context.Context, id string) (*models.User, error) {
+	query := `
+		UPDATE users
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL AND id <> $2
+		RETURNING ` + adminUserColumns
+
+	var user *models.User
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		var err error
+		user, err = scanAdminUser(tx.QueryRow(ctx, query, id, models.DeletedUserID))
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE account_deletions SET cancelled_at = NOW()
+			WHERE user_id = $1 AND purged_at IS NULL AND cancelled_at IS NULL
+		`, id)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) || isInvalidUUIDError(err) {
+			return nil, ErrNotFound
+		}
+		LogQueryError(ctx, "Restore", "users", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// scanAdminUser scans a row selected with adminUserColumns.
+func scanAdminUser(row pgx.Row) (*models.User, error) {
+	user := &models.User{}
+	var passwordHash, avatarURL, bio, authProvider, authProviderID, role, referralCode sql.NullString
+	var statusReason, statusChangedBy sql.NullString
+	var statusChangedAt, deletedAt sql.NullTime
+
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.DisplayName,
+		&user.Email,
+		&authProvider,
+		&authProviderID,
+		&passwordHash,
+		&avatarURL,
+		&bio,
+		&role,
+		&referralCode,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.Status,
+		&statusReason,
+		&statusChangedAt,
+		&statusChangedBy,
+		&deletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.AuthProvider = authProvider.String
+	user.AuthProviderID = authProviderID.String
+	user.PasswordHash = passwordHash.String
+	user.AvatarURL = avatarURL.String
+	user.Bio = bio.String
+	user.Role = role.String
+	user.ReferralCode = referralCode.String
+	user.StatusReason = statusReason.String
+	user.StatusChangedBy = statusChangedBy.String
+	if statusChangedAt.Valid {
+		user.StatusChangedAt = &statusChangedAt.Time
+	}
+	if deletedAt.Valid {
+		user.DeletedAt = &deletedAt.Time
+	}
+
+	return user, nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestUserAdmin_StatusAndRestore_Integration(t *testing.T) {
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("DATABASE_URL not set, skipping integration test")
+	}
+
+	ctx := context.Background()
+	pool, err := NewPool(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	repo := NewUserRepository(pool)
+
+	now := time.Now()
+	ts := now.Format("150405.000000")
+	username := "ua" + now.Format("0405") + fmt.Sprintf("%06d", now.Nanosecond()/1000)[:4]
+	created, err := repo.Create(ctx, &models.User{
+		Username:       username,
+		DisplayName:    "Admin Test User",
+		Email:          "admintest" + ts + "@example.com",
+		AuthProvider:   models.AuthProviderGitHub,
+		AuthProviderID: "github_admin_" + ts,
+		Role:           models.UserRoleUser,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test user: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(ctx, "DELETE FROM users WHERE id = $1", created.ID)
+	})
+
+	if created.Status != string(models.UserStatusActive) {
+		t.Errorf("new user status = %q, want active", created.Status)
+	}
+
+	suspended, err := repo.SetStatus(ctx, created.ID, models.UserStatusSuspended, "spam", "admin")
+	if err != nil {
+		t.Fatalf("SetStatus() error = %v", err)
+	}
+	if suspended.Status != string(models.UserStatusSuspended) || suspended.StatusReason != "spam" {
+		t.Errorf("SetStatus() = %q/%q, want suspended/spam", suspended.Status, suspended.StatusReason)
+	}
+	if suspended.StatusChangedAt == nil || suspended.StatusChangedBy != "admin" {
+		t.Error("SetStatus() did not record who changed the status and when")
+	}
+
+	users, total, err := repo.AdminList(ctx, models.UserListOptions{Status: "suspended", Query: username, Page: 1, PerPage: 20})
+	if err != nil {
+		t.Fatalf("AdminList() error = %v", err)
+	}
+	if total != 1 || len(users) != 1 || users[0].ID != created.ID {
+		t.Errorf("AdminList(suspended) = %d users (total %d), want the suspended user", len(users), total)
+	}
+
+	reactivated, err := repo.SetStatus(ctx, created.ID, models.UserStatusActive, "ignored", "admin")
+	if err != nil {
+		t.Fatalf("SetStatus(active) error = %v", err)
+	}
+	if reactivated.StatusReason != "" {
+		t.Errorf("SetStatus(active) kept reason %q", reactivated.StatusReason)
+	}
+
+	if _, err := repo.Restore(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Restore() on non-deleted user error = %v, want ErrNotFound", err)
+	}
+
+	if err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	users, _, err = repo.AdminList(ctx, models.UserListOptions{Status: "deleted", Query: username, Page: 1, PerPage: 20})
+	if err != nil {
+		t.Fatalf("AdminList(deleted) error = %v", err)
+	}
+	if len(users) != 1 || users[0].DeletedAt == nil {
+		t.Errorf("AdminList(deleted) = %d users, want the deleted user", len(users))
+	}
+
+	restored, err := repo.Restore(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if restored.DeletedAt != nil {
+		t.Error("Restore() did not clear deleted_at")
+	}
+	if _, err := repo.FindByID(ctx, created.ID); err != nil {
+		t.Errorf("FindByID() after restore error = %v", err)
+	}
+}
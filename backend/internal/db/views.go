@@ -4,7 +4,9 @@ package db
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/fcavalcantirj/solvr/internal/models"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -19,39 +21,63 @@ func NewViewsRepository(pool *Pool) *ViewsRepository {
 }
 
 // RecordView records a view for a post and returns the updated view count.
-// If the user has already viewed the post, it returns the current count without incrementing.
-func (r *ViewsRepository) RecordView(ctx context.Context, postID, viewerType, viewerID string) (int, error) {
-	// Use ON CONFLICT DO NOTHING to handle duplicate views
-	insertQuery := `
-		INSERT INTO post_views (post_id, viewer_type, viewer_id)
-		VALUES ($1, $2, $3)
-		ON CONFLICT (post_id, viewer_type, viewer_id) DO NOTHING
-	`
-
-	result, err := r.pool.Exec(ctx, insertQuery, postID, viewerType, viewerID)
+// viewerHash identifies the viewer (principal or IP, plus user agent); a viewer
+// counts once per post per models.ViewDedupWindowHours, and repeat views return
+// the current count without incrementing. Counted views also bump the
+// post_views_daily rollup. viewerID may be empty for anonymous viewers, and
+// referrerHost is empty for direct views.
+func (r *ViewsRepository) RecordView(ctx context.Context, postID, viewerType, viewerID, viewerHash, referrerHost string) (int, error) {
+	var viewCount int
+	counted := false
+	err := r.pool.WithTx(ctx, func(tx Tx) error {
+		// Serialize concurrent views by the same viewer so each window counts once
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1::text || $2::text, 0))`, postID, viewerHash); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(ctx, `
+			INSERT INTO post_views (post_id, viewer_type, viewer_id, viewer_hash, referrer_host)
+			SELECT $1, $2, NULLIF($3, ''), $4, NULLIF($6, '')
+			WHERE NOT EXISTS (
+				SELECT 1 FROM post_views
+				WHERE post_id = $1 AND viewer_hash = $4
+				  AND viewed_at > NOW() - make_interval(hours => $5)
+			)
+		`, postID, viewerType, viewerID, viewerHash, models.ViewDedupWindowHours, referrerHost)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return nil
+		}
+		counted = true
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO post_views_daily (post_id, day, views)
+			VALUES ($1, (NOW() AT TIME ZONE 'UTC')::date, 1)
+			ON CONFLICT (post_id, day) DO UPDATE SET views = post_views_daily.views + 1
+		`, postID); err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx, `
+			UPDATE posts SET view_count = view_count + 1
+			WHERE id = $1
+			RETURNING view_count
+		`, postID).Scan(&viewCount)
+	})
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
-			// Handle invalid UUID
-			if pgErr.Code == "22P02" {
+			// Handle invalid UUID or unknown post
+			if pgErr.Code == "22P02" || pgErr.Code == "23503" {
 				return 0, ErrPostNotFound
 			}
 		}
 		return 0, err
 	}
 
-	// If a row was inserted, update the view_count
-	if result.RowsAffected() > 0 {
-		updateQuery := `
-			UPDATE posts SET view_count = view_count + 1
-			WHERE id = $1
-			RETURNING view_count
-		`
-		var viewCount int
-		err = r.pool.QueryRow(ctx, updateQuery, postID).Scan(&viewCount)
-		if err != nil {
-			return 0, err
-		}
+	if counted {
 		return viewCount, nil
 	}
 
@@ -59,10 +85,9 @@ func (r *ViewsRepository) RecordView(ctx context.Context, postID, viewerType, vi
 	return r.GetViewCount(ctx, postID)
 }
 
-// RecordAnonymousView records a view from an anonymous user.
-// Anonymous views are tracked by a session identifier.
-func (r *ViewsRepository) RecordAnonymousView(ctx context.Context, postID, sessionID string) (int, error) {
-	return r.RecordView(ctx, postID, "anonymous", sessionID)
+// RecordAnonymousView records a view from an anonymous viewer identified only by viewerHash.
+func (r *ViewsRepository) RecordAnonymousView(ctx context.Context, postID, viewerHash, referrerHost string) (int, error) {
+	return r.RecordView(ctx, postID, "anonymous", "", viewerHash, referrerHost)
 }
 
 // GetViewCount returns the view count for a post.
@@ -77,3 +102,37 @@ func (r *ViewsRepository) GetViewCount(ctx context.Context, postID string) (int,
 
 	return viewCount, nil
 }
+
+// GetViewSeries returns daily views for the last days UTC days, oldest first,
+// including days with no views.
+func (r *ViewsRepository) GetViewSeries(ctx context.Context, postID string, days int) ([]models.PostViewDay, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT to_char(d.day, 'YYYY-MM-DD'), COALESCE(v.views, 0)
+		FROM generate_series(
+			(NOW() AT TIME ZONE 'UTC')::date - ($2::int - 1),
+			(NOW() AT TIME ZONE 'UTC')::date,
+			interval '1 day'
+		) AS d(day)
+		LEFT JOIN post_views_daily v ON v.post_id = $1 AND v.day = d.day::date
+		ORDER BY d.day
+	`, postID, days)
+	if err != nil {
+		LogQueryError(ctx, "GetViewSeries", "post_views_daily", err)
+		return nil, fmt.Errorf("get view series failed: %w", err)
+	}
+	defer rows.Close()
+
+	series := make([]models.PostViewDay, 0, days)
+	for rows.Next() {
+		var day models.PostViewDay
+		if err := rows.Scan(&day.Date, &day.Views); err != nil {
+			return nil, fmt.Errorf("scan view day: %w", err)
+		}
+		series = append(series, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate view days: %w", err)
+	}
+
+	return series, nil
+}
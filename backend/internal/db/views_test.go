@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -60,7 +61,7 @@ func TestViewsRepository_RecordView(t *testing.T) {
 	}
 
 	// Record a view
-	viewCount, err := viewsRepo.RecordView(ctx, createdPost.ID, "human", testUser.ID)
+	viewCount, err := viewsRepo.RecordView(ctx, createdPost.ID, "human", testUser.ID, "hash-"+testUser.ID, "")
 	if err != nil {
 		t.Fatalf("failed to record view: %v", err)
 	}
@@ -98,13 +99,13 @@ func TestViewsRepository_RecordView_Duplicate(t *testing.T) {
 	}
 
 	// Record first view
-	count1, err := viewsRepo.RecordView(ctx, createdPost.ID, "human", testUser.ID)
+	count1, err := viewsRepo.RecordView(ctx, createdPost.ID, "human", testUser.ID, "hash-"+testUser.ID, "")
 	if err != nil {
 		t.Fatalf("failed to record first view: %v", err)
 	}
 
 	// Record duplicate view - should not increase count
-	count2, err := viewsRepo.RecordView(ctx, createdPost.ID, "human", testUser.ID)
+	count2, err := viewsRepo.RecordView(ctx, createdPost.ID, "human", testUser.ID, "hash-"+testUser.ID, "")
 	if err != nil {
 		t.Fatalf("failed to record duplicate view: %v", err)
 	}
@@ -143,12 +144,12 @@ func TestViewsRepository_RecordView_MultipleUsers(t *testing.T) {
 	}
 
 	// Record views from different users
-	_, err = viewsRepo.RecordView(ctx, createdPost.ID, "human", user1.ID)
+	_, err = viewsRepo.RecordView(ctx, createdPost.ID, "human", user1.ID, "hash-"+user1.ID, "")
 	if err != nil {
 		t.Fatalf("failed to record first user view: %v", err)
 	}
 
-	count, err := viewsRepo.RecordView(ctx, createdPost.ID, "human", user2.ID)
+	count, err := viewsRepo.RecordView(ctx, createdPost.ID, "human", user2.ID, "hash-"+user2.ID, "")
 	if err != nil {
 		t.Fatalf("failed to record second user view: %v", err)
 	}
@@ -195,7 +196,7 @@ func TestViewsRepository_GetViewCount(t *testing.T) {
 	}
 
 	// Record a view
-	_, err = viewsRepo.RecordView(ctx, createdPost.ID, "human", testUser.ID)
+	_, err = viewsRepo.RecordView(ctx, createdPost.ID, "human", testUser.ID, "hash-"+testUser.ID, "")
 	if err != nil {
 		t.Fatalf("failed to record view: %v", err)
 	}
@@ -209,3 +210,69 @@ func TestViewsRepository_GetViewCount(t *testing.T) {
 		t.Errorf("expected view count 1, got %d", count)
 	}
 }
+
+func TestViewsRepository_AnonymousDedupAndSeries(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	ctx := context.Background()
+	viewsRepo := NewViewsRepository(pool)
+	postRepo := NewPostRepository(pool)
+	userRepo := NewUserRepository(pool)
+
+	testUser := createViewsTestUser(t, userRepo)
+
+	post := &models.Post{
+		Type:         models.PostTypeQuestion,
+		Title:        "Test question for anonymous view dedup",
+		Description:  "This is a test question to dedup anonymous views by hash",
+		Tags:         []string{"test"},
+		PostedByType: models.AuthorTypeHuman,
+		PostedByID:   testUser.ID,
+		Status:       models.PostStatusOpen,
+	}
+	createdPost, err := postRepo.Create(ctx, post)
+	if err != nil {
+		t.Fatalf("failed to create test post: %v", err)
+	}
+
+	// Same viewer hash twice within the dedup window counts once
+	if _, err := viewsRepo.RecordAnonymousView(ctx, createdPost.ID, "anon-a", ""); err != nil {
+		t.Fatalf("failed to record anonymous view: %v", err)
+	}
+	if _, err := viewsRepo.RecordAnonymousView(ctx, createdPost.ID, "anon-a", ""); err != nil {
+		t.Fatalf("failed to record anonymous view: %v", err)
+	}
+	count, err := viewsRepo.RecordAnonymousView(ctx, createdPost.ID, "anon-b", "")
+	if err != nil {
+		t.Fatalf("failed to record anonymous view: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected view count 2, got %d", count)
+	}
+
+	series, err := viewsRepo.GetViewSeries(ctx, createdPost.ID, 7)
+	if err != nil {
+		t.Fatalf("failed to get view series: %v", err)
+	}
+	if len(series) != 7 {
+		t.Fatalf("expected 7 days in series, got %d", len(series))
+	}
+	if series[6].Views != 2 {
+		t.Errorf("expected 2 views today, got %d", series[6].Views)
+	}
+	if series[0].Views != 0 {
+		t.Errorf("expected 0 views six days ago, got %d", series[0].Views)
+	}
+}
+
+func TestViewsRepository_RecordView_PostNotFound(t *testing.T) {
+	pool := setupTestDB(t)
+	defer pool.Close()
+
+	viewsRepo := NewViewsRepository(pool)
+	_, err := viewsRepo.RecordAnonymousView(context.Background(), "00000000-0000-0000-0000-000000000000", "anon", "")
+	if !errors.Is(err, ErrPostNotFound) {
+		t.Errorf("expected ErrPostNotFound, got %v", err)
+	}
+}
@@ -1,11 +1,23 @@
 package db
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/fcavalcantirj/solvr/internal/auth"
 	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
+// viewerFromContext returns the caller of the request from its principal: the
+// type and ID used for user_vote and blocked principals, and the family human
+// UUID used for visibility. All three are empty for an anonymous caller.
+func viewerFromContext(ctx context.Context) (viewerType models.AuthorType, viewerID, callerHuman string) {
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		return p.Type, p.ID, p.HumanID
+	}
+	return "", "", ""
+}
+
 // visibilityOrDefault coerces an empty visibility to "public" so a Post created without an
 // explicit visibility (e.g. legacy callers, ideas.CreateIdea, tests) still satisfies the
 // NOT NULL / CHECK column.
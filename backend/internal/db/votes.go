@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// VoteDirectionNone retracts the voter's existing vote instead of casting one.
+const VoteDirectionNone = "none"
+
+// retractVote removes a voter's vote on a target and decrements the matching
+// upvotes/downvotes counter on counterTable in the same transaction.
+// Retracting when there is no vote is a no-op, so DELETE .../vote is idempotent.
+// counterTable is always a package constant ("posts", "answers"), never user input.
+func retractVote(ctx context.Context, pool *Pool, targetType, counterTable, targetID, voterType, voterID string) error {
+	return pool.WithTx(ctx, func(tx Tx) error {
+		var direction string
+		err := tx.QueryRow(ctx,
+			`DELETE FROM votes
+			 WHERE target_type = $1 AND target_id = $2
+			 AND voter_type = $3 AND voter_id = $4
+			 RETURNING direction`,
+			targetType, targetID, voterType, voterID,
+		).Scan(&direction)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			LogQueryError(ctx, "RetractVote.Delete", "votes", err)
+			return fmt.Errorf("failed to delete vote: %w", err)
+		}
+
+		column := "upvotes"
+		if direction == "down" {
+			column = "downvotes"
+		}
+		_, err = tx.Exec(ctx,
+			fmt.Sprintf("UPDATE %s SET %s = GREATEST(%s - 1, 0) WHERE id = $1", counterTable, column, column),
+			targetID,
+		)
+		if err != nil {
+			LogQueryError(ctx, "RetractVote.UpdateCounts", counterTable, err)
+			return fmt.Errorf("failed to decrement vote counts: %w", err)
+		}
+		return nil
+	})
+}
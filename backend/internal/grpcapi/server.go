@@ -0,0 +1,344 @@
+// Package grpcapi serves the gRPC API defined in proto/solvr/v1/solvr.proto.
+//
+// Each RPC is answered by the REST handler for the same operation, called in
+// process through the API router: a gRPC request becomes an http.Request with
+// the caller's credentials, tenant and address, and the JSON response is
+// decoded into the proto message. Authentication, rate limits, validation,
+// moderation, maintenance mode and auditing are therefore exactly those of the
+// REST API; gRPC only changes the wire format and the connection handling.
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/grpcapi/solvrv1"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// forwardedMetadata lists the metadata keys copied to the REST request as headers.
+var forwardedMetadata = []string{"authorization", strings.ToLower(tenant.Header), "x-request-id", "accept-language"}
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Server implements solvrv1.SolvrServiceServer on top of the REST API.
+type Server struct {
+	solvrv1.UnimplementedSolvrServiceServer
+	rest http.Handler
+}
+
+// NewServer creates a Server answering RPCs with rest, the API router.
+func NewServer(rest http.Handler) *Server {
+	return &Server{rest: rest}
+}
+
+// NewGRPCServer creates a gRPC server with the Solvr service registered on it.
+func NewGRPCServer(rest http.Handler, opts ...grpc.ServerOption) *grpc.Server {
+	g := grpc.NewServer(opts...)
+	solvrv1.RegisterSolvrServiceServer(g, NewServer(rest))
+	return g
+}
+
+// Search implements GET /v1/search.
+func (s *Server) Search(ctx context.Context, req *solvrv1.SearchRequest) (*solvrv1.SearchResponse, error) {
+	query := url.Values{}
+	query.Set("q", req.GetQ())
+	setIfNotEmpty(query, "type", req.GetType())
+	setIfNotEmpty(query, "tags", strings.Join(req.GetTags(), ","))
+	setIfNotEmpty(query, "status", req.GetStatus())
+	setIfNotEmpty(query, "author", req.GetAuthor())
+	setIfNotEmpty(query, "sort", req.GetSort())
+	if req.GetPage() > 0 {
+		query.Set("page", strconv.Itoa(int(req.GetPage())))
+	}
+	if req.GetPerPage() > 0 {
+		query.Set("per_page", strconv.Itoa(int(req.GetPerPage())))
+	}
+
+	body, err := s.call(ctx, http.MethodGet, "/v1/search?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var envelope struct {
+		Data []json.RawMessage `json:"data"`
+		Meta struct {
+			Total   int   `json:"total"`
+			Page    int   `json:"page"`
+			PerPage int   `json:"per_page"`
+			HasMore bool  `json:"has_more"`
+			TookMs  int64 `json:"took_ms"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, status.Errorf(codes.Internal, "INTERNAL_ERROR: decode search response: %v", err)
+	}
+	resp := &solvrv1.SearchResponse{
+		Data:    make([]*solvrv1.SearchResult, 0, len(envelope.Data)),
+		Total:   int32(envelope.Meta.Total),
+		Page:    int32(envelope.Meta.Page),
+		PerPage: int32(envelope.Meta.PerPage),
+		HasMore: envelope.Meta.HasMore,
+		TookMs:  envelope.Meta.TookMs,
+	}
+	for _, raw := range envelope.Data {
+		result := &solvrv1.SearchResult{}
+		if err := decode(raw, result); err != nil {
+			return nil, err
+		}
+		resp.Data = append(resp.Data, result)
+	}
+	return resp, nil
+}
+
+// GetPost implements GET /v1/posts/{id}.
+func (s *Server) GetPost(ctx context.Context, req *solvrv1.GetPostRequest) (*solvrv1.Post, error) {
+	post := &solvrv1.Post{}
+	if err := s.callData(ctx, http.MethodGet, "/v1/posts/"+url.PathEscape(req.GetId()), nil, post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// CreatePost implements POST /v1/posts.
+func (s *Server) CreatePost(ctx context.Context, req *solvrv1.CreatePostRequest) (*solvrv1.Post, error) {
+	post := &solvrv1.Post{}
+	if err := s.callData(ctx, http.MethodPost, "/v1/posts", req, post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// CreateAnswer implements POST /v1/questions/{id}/answers.
+func (s *Server) CreateAnswer(ctx context.Context, req *solvrv1.CreateAnswerRequest) (*solvrv1.Answer, error) {
+	path := "/v1/questions/" + url.PathEscape(req.GetQuestionId()) + "/answers"
+	body := &solvrv1.CreateAnswerRequest{Content: req.GetContent()}
+	answer := &solvrv1.Answer{}
+	if err := s.callData(ctx, http.MethodPost, path, body, answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// CreateApproach implements POST /v1/problems/{id}/approaches.
+func (s *Server) CreateApproach(ctx context.Context, req *solvrv1.CreateApproachRequest) (*solvrv1.Approach, error) {
+	path := "/v1/problems/" + url.PathEscape(req.GetProblemId()) + "/approaches"
+	body := proto.Clone(req).(*solvrv1.CreateApproachRequest)
+	body.ProblemId = ""
+	approach := &solvrv1.Approach{}
+	if err := s.callData(ctx, http.MethodPost, path, body, approach); err != nil {
+		return nil, err
+	}
+	return approach, nil
+}
+
+// callData calls the REST API and decodes the response's data field into out.
+// Responses that name their author with author_type/author_id (or
+// posted_by_type/posted_by_id) instead of an author object get one built from
+// those fields.
+func (s *Server) callData(ctx context.Context, method, path string, in, out proto.Message) error {
+	body, err := s.call(ctx, method, path, in)
+	if err != nil {
+		return err
+	}
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return status.Errorf(codes.Internal, "INTERNAL_ERROR: decode response: %v", err)
+	}
+	if err := decode(envelope.Data, out); err != nil {
+		return err
+	}
+
+	withAuthor, ok := out.(interface{ GetAuthor() *solvrv1.Author })
+	if !ok || withAuthor.GetAuthor() != nil {
+		return nil
+	}
+	var ids struct {
+		AuthorType   string `json:"author_type"`
+		AuthorID     string `json:"author_id"`
+		PostedByType string `json:"posted_by_type"`
+		PostedByID   string `json:"posted_by_id"`
+	}
+	_ = json.Unmarshal(envelope.Data, &ids)
+	author := &solvrv1.Author{Type: ids.AuthorType, Id: ids.AuthorID}
+	if author.Id == "" {
+		author = &solvrv1.Author{Type: ids.PostedByType, Id: ids.PostedByID}
+	}
+	switch m := out.(type) {
+	case *solvrv1.Post:
+		m.Author = author
+	case *solvrv1.Answer:
+		m.Author = author
+	case *solvrv1.Approach:
+		m.Author = author
+	}
+	return nil
+}
+
+// call serves one REST request through the router and returns the response
+// body, or the REST error converted to a gRPC status.
+func (s *Server) call(ctx context.Context, method, path string, in proto.Message) ([]byte, error) {
+	var reqBody []byte
+	if in != nil {
+		var err error
+		if reqBody, err = marshalOptions.Marshal(in); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range forwardedMetadata {
+			if values := md.Get(key); len(values) > 0 {
+				req.Header.Set(key, values[0])
+			}
+		}
+		if values := md.Get("user-agent"); len(values) > 0 {
+			req.Header.Set("User-Agent", values[0])
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		req.RemoteAddr = p.Addr.String()
+	}
+
+	rec := newRecorder()
+	s.rest.ServeHTTP(rec, req)
+	if rec.status >= http.StatusBadRequest {
+		return nil, restError(rec.status, rec.body.Bytes())
+	}
+	return rec.body.Bytes(), nil
+}
+
+// decode unmarshals REST JSON into a proto message, ignoring fields the proto
+// does not define.
+func decode(data []byte, out proto.Message) error {
+	if err := unmarshalOptions.Unmarshal(data, out); err != nil {
+		return status.Errorf(codes.Internal, "INTERNAL_ERROR: decode response: %v", err)
+	}
+	return nil
+}
+
+// restError converts a REST error response to a gRPC status. The message is
+// prefixed with the REST error code; validation field errors become
+// google.rpc.BadRequest details.
+func restError(httpStatus int, body []byte) error {
+	var envelope struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Details struct {
+				Fields []struct {
+					Field   string `json:"field"`
+					Message string `json:"message"`
+				} `json:"fields"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Error.Code == "" {
+		return status.Errorf(grpcCode(httpStatus), "HTTP_%d: %s", httpStatus, strings.TrimSpace(string(body)))
+	}
+
+	st := status.New(grpcCode(httpStatus), fmt.Sprintf("%s: %s", envelope.Error.Code, envelope.Error.Message))
+	if len(envelope.Error.Details.Fields) == 0 {
+		return st.Err()
+	}
+	badRequest := &errdetails.BadRequest{}
+	for _, f := range envelope.Error.Details.Fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Message,
+		})
+	}
+	if withDetails, err := st.WithDetails(badRequest); err == nil {
+		return withDetails.Err()
+	}
+	return st.Err()
+}
+
+// grpcCode maps an HTTP status to the closest gRPC status code.
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound, http.StatusGone:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+func setIfNotEmpty(values url.Values, key, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
+
+// recorder is the http.ResponseWriter REST handlers write to for an RPC. As
+// with net/http, a handler that writes nothing has answered 200 OK.
+type recorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{header: http.Header{}, status: http.StatusOK}
+}
+
+func (r *recorder) Header() http.Header { return r.header }
+
+func (r *recorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	if status == 0 {
+		status = http.StatusOK
+	}
+	r.status = status
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(p)
+}
@@ -0,0 +1,198 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/grpcapi/solvrv1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves rest over an in-memory gRPC connection.
+func newTestClient(t *testing.T, rest http.Handler) solvrv1.SolvrServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := NewGRPCServer(rest)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return solvrv1.NewSolvrServiceClient(conn)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestGetPost_DecodesRESTResponse(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/posts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{
+			"id":          r.PathValue("id"),
+			"type":        "question",
+			"title":       "How do goroutines work?",
+			"tags":        []string{"go"},
+			"author":      map[string]any{"id": "u1", "type": "human", "display_name": "Ada"},
+			"vote_score":  3,
+			"user_vote":   nil,
+			"view_count":  12,
+			"created_at":  "2026-01-02T03:04:05Z",
+			"updated_at":  "2026-01-02T03:04:05.123+02:00",
+			"is_outdated": true,
+		}})
+	})
+	client := newTestClient(t, mux)
+
+	post, err := client.GetPost(context.Background(), &solvrv1.GetPostRequest{Id: "p1"})
+	if err != nil {
+		t.Fatalf("GetPost() error = %v", err)
+	}
+	if post.GetId() != "p1" || post.GetTitle() != "How do goroutines work?" || post.GetVoteScore() != 3 {
+		t.Errorf("GetPost() = %+v", post)
+	}
+	if post.GetAuthor().GetDisplayName() != "Ada" {
+		t.Errorf("author = %+v, want Ada", post.GetAuthor())
+	}
+	if post.GetCreatedAt().AsTime().Year() != 2026 {
+		t.Errorf("created_at = %v", post.GetCreatedAt())
+	}
+}
+
+func TestCreateAnswer_ForwardsCredentialsAndBody(t *testing.T) {
+	var gotAuth, gotTenant string
+	var gotBody map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/questions/{id}/answers", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotTenant = r.Header.Get("X-Solvr-Tenant")
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &gotBody)
+		writeJSON(w, http.StatusCreated, map[string]any{"data": map[string]any{
+			"id":          "a1",
+			"question_id": r.PathValue("id"),
+			"content":     gotBody["content"],
+			"author_type": "agent",
+			"author_id":   "claude_bot",
+		}})
+	})
+	client := newTestClient(t, mux)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"authorization", "Bearer solvr_testkey", "x-solvr-tenant", "acme")
+	answer, err := client.CreateAnswer(ctx, &solvrv1.CreateAnswerRequest{QuestionId: "q1", Content: "Use channels."})
+	if err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+	if gotAuth != "Bearer solvr_testkey" || gotTenant != "acme" {
+		t.Errorf("forwarded headers = %q, %q", gotAuth, gotTenant)
+	}
+	if _, ok := gotBody["question_id"]; ok || gotBody["content"] != "Use channels." {
+		t.Errorf("REST body = %v, want only content", gotBody)
+	}
+	if answer.GetQuestionId() != "q1" || answer.GetAuthor().GetId() != "claude_bot" || answer.GetAuthor().GetType() != "agent" {
+		t.Errorf("CreateAnswer() = %+v", answer)
+	}
+}
+
+func TestSearch_PassesFiltersAndMeta(t *testing.T) {
+	var gotQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/search", func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		writeJSON(w, http.StatusOK, map[string]any{
+			"data": []map[string]any{{"id": "p1", "title": "Race in map", "score": 0.9, "source": "post"}},
+			"meta": map[string]any{"total": 1, "page": 1, "per_page": 5, "has_more": false, "took_ms": 7},
+		})
+	})
+	client := newTestClient(t, mux)
+
+	resp, err := client.Search(context.Background(), &solvrv1.SearchRequest{Q: "race", Tags: []string{"go", "sync"}, PerPage: 5})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if gotQuery != "per_page=5&q=race&tags=go%2Csync" {
+		t.Errorf("query = %q", gotQuery)
+	}
+	if len(resp.GetData()) != 1 || resp.GetData()[0].GetScore() != 0.9 || resp.GetTotal() != 1 || resp.GetTookMs() != 7 {
+		t.Errorf("Search() = %+v", resp)
+	}
+}
+
+func TestCreatePost_MapsValidationError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/posts", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"error": map[string]any{
+			"code":    "VALIDATION_ERROR",
+			"message": "title is too short",
+			"details": map[string]any{"fields": []map[string]any{{"field": "title", "code": "too_short", "message": "title is too short"}}},
+		}})
+	})
+	client := newTestClient(t, mux)
+
+	_, err := client.CreatePost(context.Background(), &solvrv1.CreatePostRequest{Type: "question", Title: "short"})
+	st, _ := status.FromError(err)
+	if st.Code() != codes.InvalidArgument || st.Message() != "VALIDATION_ERROR: title is too short" {
+		t.Fatalf("CreatePost() status = %v %q", st.Code(), st.Message())
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("details = %v, want one BadRequest", st.Details())
+	}
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok || badRequest.GetFieldViolations()[0].GetField() != "title" {
+		t.Errorf("details = %v", st.Details())
+	}
+}
+
+func TestGrpcCode(t *testing.T) {
+	tests := map[int]codes.Code{
+		http.StatusUnauthorized:        codes.Unauthenticated,
+		http.StatusForbidden:           codes.PermissionDenied,
+		http.StatusNotFound:            codes.NotFound,
+		http.StatusTooManyRequests:     codes.ResourceExhausted,
+		http.StatusServiceUnavailable:  codes.Unavailable,
+		http.StatusInternalServerError: codes.Internal,
+	}
+	for httpStatus, want := range tests {
+		if got := grpcCode(httpStatus); got != want {
+			t.Errorf("grpcCode(%d) = %v, want %v", httpStatus, got, want)
+		}
+	}
+}
+
+func TestRecorder_DefaultsToOK(t *testing.T) {
+	rec := newRecorder()
+	if rec.status != http.StatusOK {
+		t.Errorf("status of a silent handler = %d, want 200", rec.status)
+	}
+
+	rec = newRecorder()
+	rec.WriteHeader(0)
+	if rec.status != http.StatusOK {
+		t.Errorf("status after WriteHeader(0) = %d, want 200", rec.status)
+	}
+
+	rec = newRecorder()
+	rec.WriteHeader(http.StatusNotFound)
+	_, _ = rec.Write([]byte("{}"))
+	if rec.status != http.StatusNotFound {
+		t.Errorf("status = %d, want the first WriteHeader's 404", rec.status)
+	}
+}
@@ -0,0 +1,1303 @@
+// Solvr gRPC API (v1).
+//
+// Mirrors the core REST operations in /v1 for high-throughput agent
+// integrations. Field names match the REST JSON so the two surfaces share one
+// vocabulary. Authenticate with the same credentials as REST, sent as
+// metadata: "authorization: Bearer <jwt | solvr_ agent key | solvr_sk_ user key>".
+//
+// Errors use standard gRPC status codes; the REST error code (e.g.
+// VALIDATION_ERROR) is carried in the status message prefix and field errors in
+// google.rpc.BadRequest details.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: solvr/v1/solvr.proto
+
+package solvrv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Author struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // human | agent
+	DisplayName   string                 `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	AvatarUrl     string                 `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Author) Reset() {
+	*x = Author{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Author) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Author) ProtoMessage() {}
+
+func (x *Author) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Author.ProtoReflect.Descriptor instead.
+func (*Author) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Author) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Author) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Author) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Author) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Q             string                 `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // problem | question | idea | all
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Author        string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Sort          string                 `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"` // relevance | newest | votes | activity
+	Page          int32                  `protobuf:"varint,7,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,8,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"` // default 20, max 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Title           string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Snippet         string                 `protobuf:"bytes,4,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	Tags            []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Author          *Author                `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	Score           float64                `protobuf:"fixed64,8,opt,name=score,proto3" json:"score,omitempty"`
+	VoteScore       int32                  `protobuf:"varint,9,opt,name=vote_score,json=voteScore,proto3" json:"vote_score,omitempty"`
+	AnswersCount    int32                  `protobuf:"varint,10,opt,name=answers_count,json=answersCount,proto3" json:"answers_count,omitempty"`
+	ApproachesCount int32                  `protobuf:"varint,11,opt,name=approaches_count,json=approachesCount,proto3" json:"approaches_count,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SolvedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=solved_at,json=solvedAt,proto3" json:"solved_at,omitempty"`
+	Source          string                 `protobuf:"bytes,14,opt,name=source,proto3" json:"source,omitempty"` // post | answer | approach
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SearchResult) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SearchResult) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *SearchResult) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SearchResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SearchResult) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetVoteScore() int32 {
+	if x != nil {
+		return x.VoteScore
+	}
+	return 0
+}
+
+func (x *SearchResult) GetAnswersCount() int32 {
+	if x != nil {
+		return x.AnswersCount
+	}
+	return 0
+}
+
+func (x *SearchResult) GetApproachesCount() int32 {
+	if x != nil {
+		return x.ApproachesCount
+	}
+	return 0
+}
+
+func (x *SearchResult) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SearchResult) GetSolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SolvedAt
+	}
+	return nil
+}
+
+func (x *SearchResult) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*SearchResult        `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,4,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	TookMs        int64                  `protobuf:"varint,6,opt,name=took_ms,json=tookMs,proto3" json:"took_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchResponse) GetData() []*SearchResult {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *SearchResponse) GetTookMs() int64 {
+	if x != nil {
+		return x.TookMs
+	}
+	return 0
+}
+
+type GetPostRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPostRequest) Reset() {
+	*x = GetPostRequest{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPostRequest) ProtoMessage() {}
+
+func (x *GetPostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPostRequest.ProtoReflect.Descriptor instead.
+func (*GetPostRequest) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPostRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Post struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Title           string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Tags            []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Author          *Author                `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	Upvotes         int32                  `protobuf:"varint,8,opt,name=upvotes,proto3" json:"upvotes,omitempty"`
+	Downvotes       int32                  `protobuf:"varint,9,opt,name=downvotes,proto3" json:"downvotes,omitempty"`
+	VoteScore       int32                  `protobuf:"varint,10,opt,name=vote_score,json=voteScore,proto3" json:"vote_score,omitempty"`
+	AnswersCount    int32                  `protobuf:"varint,11,opt,name=answers_count,json=answersCount,proto3" json:"answers_count,omitempty"`
+	ApproachesCount int32                  `protobuf:"varint,12,opt,name=approaches_count,json=approachesCount,proto3" json:"approaches_count,omitempty"`
+	CommentsCount   int32                  `protobuf:"varint,13,opt,name=comments_count,json=commentsCount,proto3" json:"comments_count,omitempty"`
+	SuccessCriteria []string               `protobuf:"bytes,14,rep,name=success_criteria,json=successCriteria,proto3" json:"success_criteria,omitempty"`
+	Weight          int32                  `protobuf:"varint,15,opt,name=weight,proto3" json:"weight,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Post) Reset() {
+	*x = Post{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Post) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Post) ProtoMessage() {}
+
+func (x *Post) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Post.ProtoReflect.Descriptor instead.
+func (*Post) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Post) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Post) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Post) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Post) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Post) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Post) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Post) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Post) GetUpvotes() int32 {
+	if x != nil {
+		return x.Upvotes
+	}
+	return 0
+}
+
+func (x *Post) GetDownvotes() int32 {
+	if x != nil {
+		return x.Downvotes
+	}
+	return 0
+}
+
+func (x *Post) GetVoteScore() int32 {
+	if x != nil {
+		return x.VoteScore
+	}
+	return 0
+}
+
+func (x *Post) GetAnswersCount() int32 {
+	if x != nil {
+		return x.AnswersCount
+	}
+	return 0
+}
+
+func (x *Post) GetApproachesCount() int32 {
+	if x != nil {
+		return x.ApproachesCount
+	}
+	return 0
+}
+
+func (x *Post) GetCommentsCount() int32 {
+	if x != nil {
+		return x.CommentsCount
+	}
+	return 0
+}
+
+func (x *Post) GetSuccessCriteria() []string {
+	if x != nil {
+		return x.SuccessCriteria
+	}
+	return nil
+}
+
+func (x *Post) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Post) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Post) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreatePostRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                              // problem | question | idea
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                                            // 10-200 characters
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                                // at least 50 characters
+	Tags            []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`                                              // at most 10
+	SuccessCriteria []string               `protobuf:"bytes,5,rep,name=success_criteria,json=successCriteria,proto3" json:"success_criteria,omitempty"` // problems only, at most 10
+	Weight          int32                  `protobuf:"varint,6,opt,name=weight,proto3" json:"weight,omitempty"`                                         // problems only, 1-5
+	Visibility      string                 `protobuf:"bytes,7,opt,name=visibility,proto3" json:"visibility,omitempty"`                                  // public (default) | family
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreatePostRequest) Reset() {
+	*x = CreatePostRequest{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePostRequest) ProtoMessage() {}
+
+func (x *CreatePostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePostRequest.ProtoReflect.Descriptor instead.
+func (*CreatePostRequest) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreatePostRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreatePostRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreatePostRequest) GetSuccessCriteria() []string {
+	if x != nil {
+		return x.SuccessCriteria
+	}
+	return nil
+}
+
+func (x *CreatePostRequest) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *CreatePostRequest) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+type Answer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	QuestionId    string                 `protobuf:"bytes,2,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Author        *Author                `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	IsAccepted    bool                   `protobuf:"varint,5,opt,name=is_accepted,json=isAccepted,proto3" json:"is_accepted,omitempty"`
+	Upvotes       int32                  `protobuf:"varint,6,opt,name=upvotes,proto3" json:"upvotes,omitempty"`
+	Downvotes     int32                  `protobuf:"varint,7,opt,name=downvotes,proto3" json:"downvotes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Answer) Reset() {
+	*x = Answer{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Answer) ProtoMessage() {}
+
+func (x *Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Answer.ProtoReflect.Descriptor instead.
+func (*Answer) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Answer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Answer) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *Answer) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Answer) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Answer) GetIsAccepted() bool {
+	if x != nil {
+		return x.IsAccepted
+	}
+	return false
+}
+
+func (x *Answer) GetUpvotes() int32 {
+	if x != nil {
+		return x.Upvotes
+	}
+	return 0
+}
+
+func (x *Answer) GetDownvotes() int32 {
+	if x != nil {
+		return x.Downvotes
+	}
+	return 0
+}
+
+func (x *Answer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Answer) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"` // at most 30000 bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAnswerRequest) Reset() {
+	*x = CreateAnswerRequest{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAnswerRequest) ProtoMessage() {}
+
+func (x *CreateAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAnswerRequest.ProtoReflect.Descriptor instead.
+func (*CreateAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateAnswerRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *CreateAnswerRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type Approach struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProblemId     string                 `protobuf:"bytes,2,opt,name=problem_id,json=problemId,proto3" json:"problem_id,omitempty"`
+	Angle         string                 `protobuf:"bytes,3,opt,name=angle,proto3" json:"angle,omitempty"`
+	Method        string                 `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	Assumptions   []string               `protobuf:"bytes,5,rep,name=assumptions,proto3" json:"assumptions,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // starting | working | stuck | failed | succeeded | abandoned
+	Outcome       string                 `protobuf:"bytes,7,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Author        *Author                `protobuf:"bytes,8,opt,name=author,proto3" json:"author,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Approach) Reset() {
+	*x = Approach{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Approach) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Approach) ProtoMessage() {}
+
+func (x *Approach) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Approach.ProtoReflect.Descriptor instead.
+func (*Approach) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Approach) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Approach) GetProblemId() string {
+	if x != nil {
+		return x.ProblemId
+	}
+	return ""
+}
+
+func (x *Approach) GetAngle() string {
+	if x != nil {
+		return x.Angle
+	}
+	return ""
+}
+
+func (x *Approach) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Approach) GetAssumptions() []string {
+	if x != nil {
+		return x.Assumptions
+	}
+	return nil
+}
+
+func (x *Approach) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Approach) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *Approach) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Approach) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Approach) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateApproachRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProblemId     string                 `protobuf:"bytes,1,opt,name=problem_id,json=problemId,proto3" json:"problem_id,omitempty"`
+	Angle         string                 `protobuf:"bytes,2,opt,name=angle,proto3" json:"angle,omitempty"`                                // required, at most 500 characters
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`                              // at most 500 characters
+	Assumptions   []string               `protobuf:"bytes,4,rep,name=assumptions,proto3" json:"assumptions,omitempty"`                    // at most 10
+	DiffersFrom   []string               `protobuf:"bytes,5,rep,name=differs_from,json=differsFrom,proto3" json:"differs_from,omitempty"` // approach IDs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApproachRequest) Reset() {
+	*x = CreateApproachRequest{}
+	mi := &file_solvr_v1_solvr_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApproachRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApproachRequest) ProtoMessage() {}
+
+func (x *CreateApproachRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_solvr_v1_solvr_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApproachRequest.ProtoReflect.Descriptor instead.
+func (*CreateApproachRequest) Descriptor() ([]byte, []int) {
+	return file_solvr_v1_solvr_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateApproachRequest) GetProblemId() string {
+	if x != nil {
+		return x.ProblemId
+	}
+	return ""
+}
+
+func (x *CreateApproachRequest) GetAngle() string {
+	if x != nil {
+		return x.Angle
+	}
+	return ""
+}
+
+func (x *CreateApproachRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *CreateApproachRequest) GetAssumptions() []string {
+	if x != nil {
+		return x.Assumptions
+	}
+	return nil
+}
+
+func (x *CreateApproachRequest) GetDiffersFrom() []string {
+	if x != nil {
+		return x.DiffersFrom
+	}
+	return nil
+}
+
+var File_solvr_v1_solvr_proto protoreflect.FileDescriptor
+
+const file_solvr_v1_solvr_proto_rawDesc = "" +
+	"\n" +
+	"\x14solvr/v1/solvr.proto\x12\bsolvr.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"n\n" +
+	"\x06Author\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12!\n" +
+	"\fdisplay_name\x18\x03 \x01(\tR\vdisplayName\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x04 \x01(\tR\tavatarUrl\"\xb8\x01\n" +
+	"\rSearchRequest\x12\f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sort\x12\x12\n" +
+	"\x04page\x18\a \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\b \x01(\x05R\aperPage\"\xc9\x03\n" +
+	"\fSearchResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
+	"\asnippet\x18\x04 \x01(\tR\asnippet\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12(\n" +
+	"\x06author\x18\a \x01(\v2\x10.solvr.v1.AuthorR\x06author\x12\x14\n" +
+	"\x05score\x18\b \x01(\x01R\x05score\x12\x1d\n" +
+	"\n" +
+	"vote_score\x18\t \x01(\x05R\tvoteScore\x12#\n" +
+	"\ranswers_count\x18\n" +
+	" \x01(\x05R\fanswersCount\x12)\n" +
+	"\x10approaches_count\x18\v \x01(\x05R\x0fapproachesCount\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tsolved_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\bsolvedAt\x12\x16\n" +
+	"\x06source\x18\x0e \x01(\tR\x06source\"\xb5\x01\n" +
+	"\x0eSearchResponse\x12*\n" +
+	"\x04data\x18\x01 \x03(\v2\x16.solvr.v1.SearchResultR\x04data\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x04 \x01(\x05R\aperPage\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\x12\x17\n" +
+	"\atook_ms\x18\x06 \x01(\x03R\x06tookMs\" \n" +
+	"\x0eGetPostRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbf\x04\n" +
+	"\x04Post\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12(\n" +
+	"\x06author\x18\a \x01(\v2\x10.solvr.v1.AuthorR\x06author\x12\x18\n" +
+	"\aupvotes\x18\b \x01(\x05R\aupvotes\x12\x1c\n" +
+	"\tdownvotes\x18\t \x01(\x05R\tdownvotes\x12\x1d\n" +
+	"\n" +
+	"vote_score\x18\n" +
+	" \x01(\x05R\tvoteScore\x12#\n" +
+	"\ranswers_count\x18\v \x01(\x05R\fanswersCount\x12)\n" +
+	"\x10approaches_count\x18\f \x01(\x05R\x0fapproachesCount\x12%\n" +
+	"\x0ecomments_count\x18\r \x01(\x05R\rcommentsCount\x12)\n" +
+	"\x10success_criteria\x18\x0e \x03(\tR\x0fsuccessCriteria\x12\x16\n" +
+	"\x06weight\x18\x0f \x01(\x05R\x06weight\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd6\x01\n" +
+	"\x11CreatePostRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12)\n" +
+	"\x10success_criteria\x18\x05 \x03(\tR\x0fsuccessCriteria\x12\x16\n" +
+	"\x06weight\x18\x06 \x01(\x05R\x06weight\x12\x1e\n" +
+	"\n" +
+	"visibility\x18\a \x01(\tR\n" +
+	"visibility\"\xcc\x02\n" +
+	"\x06Answer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vquestion_id\x18\x02 \x01(\tR\n" +
+	"questionId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12(\n" +
+	"\x06author\x18\x04 \x01(\v2\x10.solvr.v1.AuthorR\x06author\x12\x1f\n" +
+	"\vis_accepted\x18\x05 \x01(\bR\n" +
+	"isAccepted\x12\x18\n" +
+	"\aupvotes\x18\x06 \x01(\x05R\aupvotes\x12\x1c\n" +
+	"\tdownvotes\x18\a \x01(\x05R\tdownvotes\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"P\n" +
+	"\x13CreateAnswerRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xdb\x02\n" +
+	"\bApproach\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"problem_id\x18\x02 \x01(\tR\tproblemId\x12\x14\n" +
+	"\x05angle\x18\x03 \x01(\tR\x05angle\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12 \n" +
+	"\vassumptions\x18\x05 \x03(\tR\vassumptions\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x18\n" +
+	"\aoutcome\x18\a \x01(\tR\aoutcome\x12(\n" +
+	"\x06author\x18\b \x01(\v2\x10.solvr.v1.AuthorR\x06author\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa9\x01\n" +
+	"\x15CreateApproachRequest\x12\x1d\n" +
+	"\n" +
+	"problem_id\x18\x01 \x01(\tR\tproblemId\x12\x14\n" +
+	"\x05angle\x18\x02 \x01(\tR\x05angle\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12 \n" +
+	"\vassumptions\x18\x04 \x03(\tR\vassumptions\x12!\n" +
+	"\fdiffers_from\x18\x05 \x03(\tR\vdiffersFrom2\xc3\x02\n" +
+	"\fSolvrService\x12;\n" +
+	"\x06Search\x12\x17.solvr.v1.SearchRequest\x1a\x18.solvr.v1.SearchResponse\x123\n" +
+	"\aGetPost\x12\x18.solvr.v1.GetPostRequest\x1a\x0e.solvr.v1.Post\x129\n" +
+	"\n" +
+	"CreatePost\x12\x1b.solvr.v1.CreatePostRequest\x1a\x0e.solvr.v1.Post\x12?\n" +
+	"\fCreateAnswer\x12\x1d.solvr.v1.CreateAnswerRequest\x1a\x10.solvr.v1.Answer\x12E\n" +
+	"\x0eCreateApproach\x12\x1f.solvr.v1.CreateApproachRequest\x1a\x12.solvr.v1.ApproachB9Z7github.com/fcavalcantirj/solvr/internal/grpcapi/solvrv1b\x06proto3"
+
+var (
+	file_solvr_v1_solvr_proto_rawDescOnce sync.Once
+	file_solvr_v1_solvr_proto_rawDescData []byte
+)
+
+func file_solvr_v1_solvr_proto_rawDescGZIP() []byte {
+	file_solvr_v1_solvr_proto_rawDescOnce.Do(func() {
+		file_solvr_v1_solvr_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_solvr_v1_solvr_proto_rawDesc), len(file_solvr_v1_solvr_proto_rawDesc)))
+	})
+	return file_solvr_v1_solvr_proto_rawDescData
+}
+
+var file_solvr_v1_solvr_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_solvr_v1_solvr_proto_goTypes = []any{
+	(*Author)(nil),                // 0: solvr.v1.Author
+	(*SearchRequest)(nil),         // 1: solvr.v1.SearchRequest
+	(*SearchResult)(nil),          // 2: solvr.v1.SearchResult
+	(*SearchResponse)(nil),        // 3: solvr.v1.SearchResponse
+	(*GetPostRequest)(nil),        // 4: solvr.v1.GetPostRequest
+	(*Post)(nil),                  // 5: solvr.v1.Post
+	(*CreatePostRequest)(nil),     // 6: solvr.v1.CreatePostRequest
+	(*Answer)(nil),                // 7: solvr.v1.Answer
+	(*CreateAnswerRequest)(nil),   // 8: solvr.v1.CreateAnswerRequest
+	(*Approach)(nil),              // 9: solvr.v1.Approach
+	(*CreateApproachRequest)(nil), // 10: solvr.v1.CreateApproachRequest
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+}
+var file_solvr_v1_solvr_proto_depIdxs = []int32{
+	0,  // 0: solvr.v1.SearchResult.author:type_name -> solvr.v1.Author
+	11, // 1: solvr.v1.SearchResult.created_at:type_name -> google.protobuf.Timestamp
+	11, // 2: solvr.v1.SearchResult.solved_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: solvr.v1.SearchResponse.data:type_name -> solvr.v1.SearchResult
+	0,  // 4: solvr.v1.Post.author:type_name -> solvr.v1.Author
+	11, // 5: solvr.v1.Post.created_at:type_name -> google.protobuf.Timestamp
+	11, // 6: solvr.v1.Post.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: solvr.v1.Answer.author:type_name -> solvr.v1.Author
+	11, // 8: solvr.v1.Answer.created_at:type_name -> google.protobuf.Timestamp
+	11, // 9: solvr.v1.Answer.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 10: solvr.v1.Approach.author:type_name -> solvr.v1.Author
+	11, // 11: solvr.v1.Approach.created_at:type_name -> google.protobuf.Timestamp
+	11, // 12: solvr.v1.Approach.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 13: solvr.v1.SolvrService.Search:input_type -> solvr.v1.SearchRequest
+	4,  // 14: solvr.v1.SolvrService.GetPost:input_type -> solvr.v1.GetPostRequest
+	6,  // 15: solvr.v1.SolvrService.CreatePost:input_type -> solvr.v1.CreatePostRequest
+	8,  // 16: solvr.v1.SolvrService.CreateAnswer:input_type -> solvr.v1.CreateAnswerRequest
+	10, // 17: solvr.v1.SolvrService.CreateApproach:input_type -> solvr.v1.CreateApproachRequest
+	3,  // 18: solvr.v1.SolvrService.Search:output_type -> solvr.v1.SearchResponse
+	5,  // 19: solvr.v1.SolvrService.GetPost:output_type -> solvr.v1.Post
+	5,  // 20: solvr.v1.SolvrService.CreatePost:output_type -> solvr.v1.Post
+	7,  // 21: solvr.v1.SolvrService.CreateAnswer:output_type -> solvr.v1.Answer
+	9,  // 22: solvr.v1.SolvrService.CreateApproach:output_type -> solvr.v1.Approach
+	18, // [18:23] is the sub-list for method output_type
+	13, // [13:18] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_solvr_v1_solvr_proto_init() }
+func file_solvr_v1_solvr_proto_init() {
+	if File_solvr_v1_solvr_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_solvr_v1_solvr_proto_rawDesc), len(file_solvr_v1_solvr_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_solvr_v1_solvr_proto_goTypes,
+		DependencyIndexes: file_solvr_v1_solvr_proto_depIdxs,
+		MessageInfos:      file_solvr_v1_solvr_proto_msgTypes,
+	}.Build()
+	File_solvr_v1_solvr_proto = out.File
+	file_solvr_v1_solvr_proto_goTypes = nil
+	file_solvr_v1_solvr_proto_depIdxs = nil
+}
@@ -0,0 +1,294 @@
+// Solvr gRPC API (v1).
+//
+// Mirrors the core REST operations in /v1 for high-throughput agent
+// integrations. Field names match the REST JSON so the two surfaces share one
+// vocabulary. Authenticate with the same credentials as REST, sent as
+// metadata: "authorization: Bearer <jwt | solvr_ agent key | solvr_sk_ user key>".
+//
+// Errors use standard gRPC status codes; the REST error code (e.g.
+// VALIDATION_ERROR) is carried in the status message prefix and field errors in
+// google.rpc.BadRequest details.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: solvr/v1/solvr.proto
+
+package solvrv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SolvrService_Search_FullMethodName         = "/solvr.v1.SolvrService/Search"
+	SolvrService_GetPost_FullMethodName        = "/solvr.v1.SolvrService/GetPost"
+	SolvrService_CreatePost_FullMethodName     = "/solvr.v1.SolvrService/CreatePost"
+	SolvrService_CreateAnswer_FullMethodName   = "/solvr.v1.SolvrService/CreateAnswer"
+	SolvrService_CreateApproach_FullMethodName = "/solvr.v1.SolvrService/CreateApproach"
+)
+
+// SolvrServiceClient is the client API for SolvrService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SolvrServiceClient interface {
+	// Search the knowledge base. Public; same ranking as GET /v1/search.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// Get a post by ID. Public; same visibility rules as GET /v1/posts/{id}.
+	GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*Post, error)
+	// Create a problem, question or idea. Same validation as POST /v1/posts.
+	CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*Post, error)
+	// Answer a question. Same validation as POST /v1/questions/{id}/answers.
+	CreateAnswer(ctx context.Context, in *CreateAnswerRequest, opts ...grpc.CallOption) (*Answer, error)
+	// Start an approach on a problem. Same validation as POST /v1/problems/{id}/approaches.
+	CreateApproach(ctx context.Context, in *CreateApproachRequest, opts ...grpc.CallOption) (*Approach, error)
+}
+
+type solvrServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSolvrServiceClient(cc grpc.ClientConnInterface) SolvrServiceClient {
+	return &solvrServiceClient{cc}
+}
+
+func (c *solvrServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, SolvrService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solvrServiceClient) GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, SolvrService_GetPost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solvrServiceClient) CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*Post, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Post)
+	err := c.cc.Invoke(ctx, SolvrService_CreatePost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solvrServiceClient) CreateAnswer(ctx context.Context, in *CreateAnswerRequest, opts ...grpc.CallOption) (*Answer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Answer)
+	err := c.cc.Invoke(ctx, SolvrService_CreateAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solvrServiceClient) CreateApproach(ctx context.Context, in *CreateApproachRequest, opts ...grpc.CallOption) (*Approach, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Approach)
+	err := c.cc.Invoke(ctx, SolvrService_CreateApproach_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SolvrServiceServer is the server API for SolvrService service.
+// All implementations must embed UnimplementedSolvrServiceServer
+// for forward compatibility.
+type SolvrServiceServer interface {
+	// Search the knowledge base. Public; same ranking as GET /v1/search.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// Get a post by ID. Public; same visibility rules as GET /v1/posts/{id}.
+	GetPost(context.Context, *GetPostRequest) (*Post, error)
+	// Create a problem, question or idea. Same validation as POST /v1/posts.
+	CreatePost(context.Context, *CreatePostRequest) (*Post, error)
+	// Answer a question. Same validation as POST /v1/questions/{id}/answers.
+	CreateAnswer(context.Context, *CreateAnswerRequest) (*Answer, error)
+	// Start an approach on a problem. Same validation as POST /v1/problems/{id}/approaches.
+	CreateApproach(context.Context, *CreateApproachRequest) (*Approach, error)
+	mustEmbedUnimplementedSolvrServiceServer()
+}
+
+// UnimplementedSolvrServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSolvrServiceServer struct{}
+
+func (UnimplementedSolvrServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedSolvrServiceServer) GetPost(context.Context, *GetPostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPost not implemented")
+}
+func (UnimplementedSolvrServiceServer) CreatePost(context.Context, *CreatePostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePost not implemented")
+}
+func (UnimplementedSolvrServiceServer) CreateAnswer(context.Context, *CreateAnswerRequest) (*Answer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAnswer not implemented")
+}
+func (UnimplementedSolvrServiceServer) CreateApproach(context.Context, *CreateApproachRequest) (*Approach, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateApproach not implemented")
+}
+func (UnimplementedSolvrServiceServer) mustEmbedUnimplementedSolvrServiceServer() {}
+func (UnimplementedSolvrServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeSolvrServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SolvrServiceServer will
+// result in compilation errors.
+type UnsafeSolvrServiceServer interface {
+	mustEmbedUnimplementedSolvrServiceServer()
+}
+
+func RegisterSolvrServiceServer(s grpc.ServiceRegistrar, srv SolvrServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSolvrServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SolvrService_ServiceDesc, srv)
+}
+
+func _SolvrService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolvrServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolvrService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolvrServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SolvrService_GetPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolvrServiceServer).GetPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolvrService_GetPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolvrServiceServer).GetPost(ctx, req.(*GetPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SolvrService_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolvrServiceServer).CreatePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolvrService_CreatePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolvrServiceServer).CreatePost(ctx, req.(*CreatePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SolvrService_CreateAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolvrServiceServer).CreateAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolvrService_CreateAnswer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolvrServiceServer).CreateAnswer(ctx, req.(*CreateAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SolvrService_CreateApproach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateApproachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolvrServiceServer).CreateApproach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SolvrService_CreateApproach_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolvrServiceServer).CreateApproach(ctx, req.(*CreateApproachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SolvrService_ServiceDesc is the grpc.ServiceDesc for SolvrService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SolvrService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "solvr.v1.SolvrService",
+	HandlerType: (*SolvrServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _SolvrService_Search_Handler,
+		},
+		{
+			MethodName: "GetPost",
+			Handler:    _SolvrService_GetPost_Handler,
+		},
+		{
+			MethodName: "CreatePost",
+			Handler:    _SolvrService_CreatePost_Handler,
+		},
+		{
+			MethodName: "CreateAnswer",
+			Handler:    _SolvrService_CreateAnswer_Handler,
+		},
+		{
+			MethodName: "CreateApproach",
+			Handler:    _SolvrService_CreateApproach_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "solvr/v1/solvr.proto",
+}
@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// DefaultAbuseDetectionInterval is how often voting patterns are scanned for abuse.
+const DefaultAbuseDetectionInterval = 24 * time.Hour
+
+// Thresholds for the abuse detectors. They are deliberately conservative:
+// a report is only a prompt for an admin to look, never an automatic penalty.
+const (
+	// VoteRingLookback bounds how far back mutual upvoting is counted.
+	VoteRingLookback = 30 * 24 * time.Hour
+	// VoteRingMinMutualUpvotes is how many upvotes each side of a pair must give the other.
+	VoteRingMinMutualUpvotes = 5
+	// TargetedUpvotingMinContent is the smallest author history worth checking.
+	TargetedUpvotingMinContent = 5
+	// TargetedUpvotingMinShare is the fraction of an author's content one voter must have upvoted.
+	TargetedUpvotingMinShare = 0.8
+	// NewAccountMaxAge is how young an account must be to count as new.
+	NewAccountMaxAge = 7 * 24 * time.Hour
+	// NewAccountBurstMinVotesPerHour is how many votes in one hour count as a burst.
+	NewAccountBurstMinVotesPerHour = 30
+)
+
+// AbuseDetector scans vote history for suspicious patterns and files abuse reports.
+// Each method returns the number of new reports.
+type AbuseDetector interface {
+	DetectVoteRings(ctx context.Context, since time.Time, minMutual int) (int64, error)
+	DetectTargetedUpvoting(ctx context.Context, minContent int, minShare float64) (int64, error)
+	DetectNewAccountBursts(ctx context.Context, since, accountCreatedAfter time.Time, minPerHour int) (int64, error)
+}
+
+// AbuseDetectionJob periodically flags vote rings, targeted upvoting and
+// vote bursts from new accounts into abuse_reports for admin review.
+type AbuseDetectionJob struct {
+	detector AbuseDetector
+	now      func() time.Time
+}
+
+// NewAbuseDetectionJob creates a new AbuseDetectionJob.
+func NewAbuseDetectionJob(detector AbuseDetector) *AbuseDetectionJob {
+	return &AbuseDetectionJob{detector: detector, now: time.Now}
+}
+
+// RunOnce runs every detector once. A failing detector doesn't stop the others.
+// Returns the total number of new reports and any errors joined together.
+func (j *AbuseDetectionJob) RunOnce(ctx context.Context) (int64, error) {
+	now := j.now()
+	var total int64
+	var errs []error
+
+	n, err := j.detector.DetectVoteRings(ctx, now.Add(-VoteRingLookback), VoteRingMinMutualUpvotes)
+	total += n
+	errs = append(errs, err)
+
+	n, err = j.detector.DetectTargetedUpvoting(ctx, TargetedUpvotingMinContent, TargetedUpvotingMinShare)
+	total += n
+	errs = append(errs, err)
+
+	// Bursts only look at the window since the previous daily run.
+	n, err = j.detector.DetectNewAccountBursts(ctx, now.Add(-DefaultAbuseDetectionInterval), now.Add(-NewAccountMaxAge), NewAccountBurstMinVotesPerHour)
+	total += n
+	errs = append(errs, err)
+
+	return total, errors.Join(errs...)
+}
+
+// RunScheduled runs abuse detection on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *AbuseDetectionJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Abuse detection job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs once and logs errors or new reports.
+func (j *AbuseDetectionJob) runAndLog(ctx context.Context) {
+	reports, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Abuse detection failed: %v", err)
+	}
+	if reports > 0 {
+		log.Printf("Abuse detection: filed %d new abuse reports", reports)
+	}
+}
@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockAbuseDetector struct {
+	ringSince      time.Time
+	ringMinMutual  int
+	burstSince     time.Time
+	burstCreatedAt time.Time
+	calls          int
+	ringErr        error
+	perDetector    int64
+}
+
+func (m *mockAbuseDetector) DetectVoteRings(ctx context.Context, since time.Time, minMutual int) (int64, error) {
+	m.calls++
+	m.ringSince = since
+	m.ringMinMutual = minMutual
+	if m.ringErr != nil {
+		return 0, m.ringErr
+	}
+	return m.perDetector, nil
+}
+
+func (m *mockAbuseDetector) DetectTargetedUpvoting(ctx context.Context, minContent int, minShare float64) (int64, error) {
+	m.calls++
+	return m.perDetector, nil
+}
+
+func (m *mockAbuseDetector) DetectNewAccountBursts(ctx context.Context, since, accountCreatedAfter time.Time, minPerHour int) (int64, error) {
+	m.calls++
+	m.burstSince = since
+	m.burstCreatedAt = accountCreatedAfter
+	return m.perDetector, nil
+}
+
+func TestAbuseDetectionJob_RunOnce(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock := &mockAbuseDetector{perDetector: 2}
+	job := NewAbuseDetectionJob(mock)
+	job.now = func() time.Time { return now }
+
+	reports, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reports != 6 {
+		t.Errorf("expected 6 reports, got %d", reports)
+	}
+	if mock.calls != 3 {
+		t.Errorf("expected 3 detector calls, got %d", mock.calls)
+	}
+	if !mock.ringSince.Equal(now.Add(-VoteRingLookback)) {
+		t.Errorf("unexpected vote ring window start: %v", mock.ringSince)
+	}
+	if mock.ringMinMutual != VoteRingMinMutualUpvotes {
+		t.Errorf("expected min mutual %d, got %d", VoteRingMinMutualUpvotes, mock.ringMinMutual)
+	}
+	if !mock.burstSince.Equal(now.Add(-DefaultAbuseDetectionInterval)) {
+		t.Errorf("unexpected burst window start: %v", mock.burstSince)
+	}
+	if !mock.burstCreatedAt.Equal(now.Add(-NewAccountMaxAge)) {
+		t.Errorf("unexpected new account cutoff: %v", mock.burstCreatedAt)
+	}
+}
+
+func TestAbuseDetectionJob_RunOnceContinuesAfterError(t *testing.T) {
+	mock := &mockAbuseDetector{ringErr: errors.New("db down"), perDetector: 1}
+	job := NewAbuseDetectionJob(mock)
+
+	reports, err := job.RunOnce(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if mock.calls != 3 {
+		t.Errorf("expected all 3 detectors to run, got %d", mock.calls)
+	}
+	if reports != 2 {
+		t.Errorf("expected 2 reports from the healthy detectors, got %d", reports)
+	}
+}
+
+func TestAbuseDetectionJob_RunScheduledStopsOnCancel(t *testing.T) {
+	job := NewAbuseDetectionJob(&mockAbuseDetector{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.RunScheduled(ctx, time.Hour)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunScheduled did not stop after cancel")
+	}
+}
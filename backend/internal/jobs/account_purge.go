@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultAccountPurgeInterval is how often deleted accounts past their grace period are purged.
+const DefaultAccountPurgeInterval = 1 * time.Hour
+
+// AccountPurger hard-deletes users whose deletion grace period has elapsed.
+type AccountPurger interface {
+	PurgeDue(ctx context.Context) (int, error)
+}
+
+// AccountPurgeJob periodically hard-deletes the PII of accounts deleted via
+// DELETE /v1/me once their grace period is over. Accounts that author content
+// under legal hold or retention wait until the holds are lifted.
+type AccountPurgeJob struct {
+	purger AccountPurger
+}
+
+// NewAccountPurgeJob creates a new AccountPurgeJob.
+func NewAccountPurgeJob(purger AccountPurger) *AccountPurgeJob {
+	return &AccountPurgeJob{purger: purger}
+}
+
+// RunOnce purges due accounts once. Returns the number of users purged.
+// Accounts that failed are retried on the next run.
+func (j *AccountPurgeJob) RunOnce(ctx context.Context) (int, error) {
+	return j.purger.PurgeDue(ctx)
+}
+
+// RunScheduled purges due accounts on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *AccountPurgeJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Account purge job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs once and logs the outcome. A partial failure still reports
+// the accounts that were purged.
+func (j *AccountPurgeJob) runAndLog(ctx context.Context) {
+	purged, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Account purge failed: %v", err)
+	}
+	if purged > 0 {
+		log.Printf("Account purge: hard-deleted %d accounts past their grace period", purged)
+	}
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockAccountPurger struct {
+	calls  int
+	result int
+	err    error
+}
+
+func (m *mockAccountPurger) PurgeDue(ctx context.Context) (int, error) {
+	m.calls++
+	return m.result, m.err
+}
+
+func TestAccountPurgeJob_RunOnce(t *testing.T) {
+	mock := &mockAccountPurger{result: 2}
+	job := NewAccountPurgeJob(mock)
+
+	purged, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purged != 2 {
+		t.Errorf("expected 2 purged accounts, got %d", purged)
+	}
+	if mock.calls != 1 {
+		t.Errorf("expected 1 purge call, got %d", mock.calls)
+	}
+}
+
+func TestAccountPurgeJob_RunOncePartialFailure(t *testing.T) {
+	mock := &mockAccountPurger{result: 1, err: errors.New("purge user x: fk violation")}
+	job := NewAccountPurgeJob(mock)
+
+	purged, err := job.RunOnce(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if purged != 1 {
+		t.Errorf("expected successful purges to still be counted, got %d", purged)
+	}
+}
+
+func TestAccountPurgeJob_RunScheduledStopsOnCancel(t *testing.T) {
+	mock := &mockAccountPurger{}
+	job := NewAccountPurgeJob(mock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.RunScheduled(ctx, time.Hour)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunScheduled did not stop after cancel")
+	}
+	if mock.calls != 1 {
+		t.Errorf("expected immediate run on start, got %d calls", mock.calls)
+	}
+}
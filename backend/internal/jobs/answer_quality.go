@@ -0,0 +1,128 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// Default answer quality job configuration.
+const (
+	// DefaultAnswerQualityInterval is how often new answers are picked up for scoring.
+	DefaultAnswerQualityInterval = 15 * time.Minute
+
+	// DefaultAnswerQualityBatchSize is the max answers scored per run.
+	DefaultAnswerQualityBatchSize = 20
+
+	// DefaultAnswerQualityDelayMs is the milliseconds to sleep between API calls,
+	// leaving Groq rate limit headroom for moderation and translation.
+	DefaultAnswerQualityDelayMs = 3_000 // 3 seconds
+)
+
+// AnswerQualityStore lists unscored answers and records scoring results.
+type AnswerQualityStore interface {
+	ListAnswersNeedingQualityScore(ctx context.Context, limit int) ([]models.AnswerForScoring, error)
+	SetQualityScore(ctx context.Context, answerID string, score int) error
+	IncrementQualityAttempts(ctx context.Context, answerID string) error
+}
+
+// AnswerScorer reviews an answer against its question.
+type AnswerScorer interface {
+	ScoreAnswer(ctx context.Context, input services.AnswerQualityInput) (*services.AnswerQualityResult, error)
+}
+
+// AnswerQualityJob scores new answers so GET /v1/questions/{id}/answers?sort=quality
+// can surface complete answers above low-effort "me too" replies.
+type AnswerQualityJob struct {
+	store     AnswerQualityStore
+	scorer    AnswerScorer
+	batchSize int
+	delayMs   int
+}
+
+// NewAnswerQualityJob creates a new AnswerQualityJob.
+func NewAnswerQualityJob(store AnswerQualityStore, scorer AnswerScorer, batchSize, delayMs int) *AnswerQualityJob {
+	return &AnswerQualityJob{
+		store:     store,
+		scorer:    scorer,
+		batchSize: batchSize,
+		delayMs:   delayMs,
+	}
+}
+
+// RunOnce scores the next batch of unscored answers.
+// Returns the number of scored and failed answers.
+func (j *AnswerQualityJob) RunOnce(ctx context.Context) (scored, failed int) {
+	answers, err := j.store.ListAnswersNeedingQualityScore(ctx, j.batchSize)
+	if err != nil {
+		log.Printf("Answer quality job: failed to list candidates: %v", err)
+		return 0, 0
+	}
+
+	for i, ans := range answers {
+		if i > 0 && j.delayMs > 0 {
+			time.Sleep(time.Duration(j.delayMs) * time.Millisecond)
+		}
+
+		result, err := j.scorer.ScoreAnswer(ctx, services.AnswerQualityInput{
+			QuestionTitle:       ans.QuestionTitle,
+			QuestionDescription: ans.QuestionDescription,
+			Answer:              ans.Content,
+		})
+		if err != nil {
+			var rlErr *services.AnswerQualityRateLimitError
+			if errors.As(err, &rlErr) {
+				// Rate limited: leave the answer for the next run and stop the batch
+				log.Printf("Answer quality job: rate limited on answer %s, retry after %v", ans.AnswerID, rlErr.RetryAfter)
+				break
+			}
+
+			log.Printf("Answer quality job: failed to score answer %s: %v", ans.AnswerID, err)
+			if incrErr := j.store.IncrementQualityAttempts(ctx, ans.AnswerID); incrErr != nil {
+				log.Printf("Answer quality job: failed to increment attempts for %s: %v", ans.AnswerID, incrErr)
+			}
+			failed++
+			continue
+		}
+
+		if err := j.store.SetQualityScore(ctx, ans.AnswerID, result.Score); err != nil {
+			log.Printf("Answer quality job: failed to store score for %s: %v", ans.AnswerID, err)
+			failed++
+			continue
+		}
+		scored++
+	}
+
+	return scored, failed
+}
+
+// RunScheduled runs the answer quality job on a schedule.
+// It runs immediately on start, then repeats at the given interval.
+// The job stops when the context is cancelled.
+func (j *AnswerQualityJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Answer quality job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+func (j *AnswerQualityJob) runAndLog(ctx context.Context) {
+	scored, failed := j.RunOnce(ctx)
+	if scored > 0 || failed > 0 {
+		log.Printf("Answer quality job: %d scored, %d failed", scored, failed)
+	}
+}
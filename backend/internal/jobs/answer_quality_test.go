@@ -0,0 +1,122 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+type mockAnswerQualityStore struct {
+	answers    []models.AnswerForScoring
+	listErr    error
+	scores     map[string]int
+	increments []string
+}
+
+func (m *mockAnswerQualityStore) ListAnswersNeedingQualityScore(ctx context.Context, limit int) ([]models.AnswerForScoring, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return m.answers, nil
+}
+
+func (m *mockAnswerQualityStore) SetQualityScore(ctx context.Context, answerID string, score int) error {
+	if m.scores == nil {
+		m.scores = map[string]int{}
+	}
+	m.scores[answerID] = score
+	return nil
+}
+
+func (m *mockAnswerQualityStore) IncrementQualityAttempts(ctx context.Context, answerID string) error {
+	m.increments = append(m.increments, answerID)
+	return nil
+}
+
+// mockAnswerScorer returns errs[content] for an answer if set, otherwise a score
+// of len(content) so tests can tell answers apart.
+type mockAnswerScorer struct {
+	errs  map[string]error
+	calls []services.AnswerQualityInput
+}
+
+func (m *mockAnswerScorer) ScoreAnswer(ctx context.Context, input services.AnswerQualityInput) (*services.AnswerQualityResult, error) {
+	m.calls = append(m.calls, input)
+	if err, ok := m.errs[input.Answer]; ok {
+		return nil, err
+	}
+	return &services.AnswerQualityResult{AddressesQuestion: true, Score: len(input.Answer)}, nil
+}
+
+func TestAnswerQualityJob_RunOnce_ScoresAnswers(t *testing.T) {
+	store := &mockAnswerQualityStore{answers: []models.AnswerForScoring{
+		{AnswerID: "a1", Content: "me too", QuestionTitle: "Pool leak"},
+		{AnswerID: "a2", Content: "Call pool.Close() on shutdown", QuestionTitle: "Pool leak"},
+	}}
+	scorer := &mockAnswerScorer{}
+
+	scored, failed := NewAnswerQualityJob(store, scorer, 10, 0).RunOnce(context.Background())
+
+	if scored != 2 || failed != 0 {
+		t.Fatalf("RunOnce() = (%d, %d), want (2, 0)", scored, failed)
+	}
+	if store.scores["a1"] != 6 || store.scores["a2"] != 29 {
+		t.Errorf("unexpected scores: %v", store.scores)
+	}
+	if scorer.calls[0].QuestionTitle != "Pool leak" {
+		t.Errorf("expected question passed to scorer, got %+v", scorer.calls[0])
+	}
+}
+
+func TestAnswerQualityJob_RunOnce_FailureIncrementsAttempts(t *testing.T) {
+	store := &mockAnswerQualityStore{answers: []models.AnswerForScoring{
+		{AnswerID: "a1", Content: "bad"},
+		{AnswerID: "a2", Content: "good"},
+	}}
+	scorer := &mockAnswerScorer{errs: map[string]error{"bad": errors.New("unparseable review")}}
+
+	scored, failed := NewAnswerQualityJob(store, scorer, 10, 0).RunOnce(context.Background())
+
+	if scored != 1 || failed != 1 {
+		t.Fatalf("RunOnce() = (%d, %d), want (1, 1)", scored, failed)
+	}
+	if len(store.increments) != 1 || store.increments[0] != "a1" {
+		t.Errorf("expected attempts incremented for a1, got %v", store.increments)
+	}
+}
+
+func TestAnswerQualityJob_RunOnce_RateLimitStopsBatch(t *testing.T) {
+	store := &mockAnswerQualityStore{answers: []models.AnswerForScoring{
+		{AnswerID: "a1", Content: "first"},
+		{AnswerID: "a2", Content: "second"},
+		{AnswerID: "a3", Content: "third"},
+	}}
+	scorer := &mockAnswerScorer{errs: map[string]error{
+		"second": &services.AnswerQualityRateLimitError{Message: "rate limited"},
+	}}
+
+	scored, failed := NewAnswerQualityJob(store, scorer, 10, 0).RunOnce(context.Background())
+
+	if scored != 1 || failed != 0 {
+		t.Fatalf("RunOnce() = (%d, %d), want (1, 0)", scored, failed)
+	}
+	if len(scorer.calls) != 2 {
+		t.Errorf("expected batch to stop after rate limit, got %d calls", len(scorer.calls))
+	}
+	if len(store.increments) != 0 {
+		t.Errorf("rate limited answers should not count as attempts, got %v", store.increments)
+	}
+}
+
+func TestAnswerQualityJob_RunOnce_ListError(t *testing.T) {
+	store := &mockAnswerQualityStore{listErr: errors.New("db down")}
+
+	scored, failed := NewAnswerQualityJob(store, &mockAnswerScorer{}, 10, 0).RunOnce(context.Background())
+
+	if scored != 0 || failed != 0 {
+		t.Errorf("RunOnce() = (%d, %d), want (0, 0)", scored, failed)
+	}
+}
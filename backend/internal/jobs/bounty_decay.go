@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Default bounty decay job configuration values.
+const (
+	// DefaultBountyDecayInactivity is how long a problem must go without approach
+	// activity or a bounty change before its weight drops by one (14 days).
+	DefaultBountyDecayInactivity = 14 * 24 * time.Hour
+
+	// DefaultBountyDecayInterval is how often the bounty decay scan runs.
+	DefaultBountyDecayInterval = 24 * time.Hour
+)
+
+// BountyDecayer lowers the weight of inactive unsolved problems.
+// Implemented by db.BountyRepository.
+type BountyDecayer interface {
+	DecayInactiveWeights(ctx context.Context, inactiveFor time.Duration) (int64, error)
+}
+
+// BountyDecayJob periodically decays the bounty (weight) of unsolved problems
+// nobody is working on, so raised bounties reflect current interest.
+type BountyDecayJob struct {
+	decayer     BountyDecayer
+	inactiveFor time.Duration
+}
+
+// NewBountyDecayJob creates a new BountyDecayJob.
+func NewBountyDecayJob(decayer BountyDecayer, inactiveFor time.Duration) *BountyDecayJob {
+	return &BountyDecayJob{decayer: decayer, inactiveFor: inactiveFor}
+}
+
+// RunOnce decays inactive problems once. Returns the number of problems decayed.
+// Each problem drops at most one step per inactivity period, since decaying
+// resets its clock.
+func (j *BountyDecayJob) RunOnce(ctx context.Context) (int64, error) {
+	return j.decayer.DecayInactiveWeights(ctx, j.inactiveFor)
+}
+
+// RunScheduled runs the bounty decay job on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *BountyDecayJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Bounty decay job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+func (j *BountyDecayJob) runAndLog(ctx context.Context) {
+	decayed, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Bounty decay failed: %v", err)
+		return
+	}
+	if decayed > 0 {
+		log.Printf("Bounty decay: lowered the weight of %d inactive problems", decayed)
+	}
+}
@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockBountyDecayer struct {
+	calls       int
+	inactiveFor time.Duration
+	result      int64
+	err         error
+}
+
+func (m *mockBountyDecayer) DecayInactiveWeights(ctx context.Context, inactiveFor time.Duration) (int64, error) {
+	m.calls++
+	m.inactiveFor = inactiveFor
+	return m.result, m.err
+}
+
+func TestBountyDecayJob_RunOnce(t *testing.T) {
+	mock := &mockBountyDecayer{result: 3}
+	job := NewBountyDecayJob(mock, DefaultBountyDecayInactivity)
+
+	decayed, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decayed != 3 {
+		t.Errorf("expected 3 decayed problems, got %d", decayed)
+	}
+	if mock.inactiveFor != DefaultBountyDecayInactivity {
+		t.Errorf("expected inactivity threshold %v, got %v", DefaultBountyDecayInactivity, mock.inactiveFor)
+	}
+}
+
+func TestBountyDecayJob_RunOnceError(t *testing.T) {
+	mock := &mockBountyDecayer{err: errors.New("db down")}
+	job := NewBountyDecayJob(mock, time.Hour)
+
+	if _, err := job.RunOnce(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestBountyDecayJob_RunScheduledStopsOnCancel(t *testing.T) {
+	mock := &mockBountyDecayer{}
+	job := NewBountyDecayJob(mock, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.RunScheduled(ctx, time.Hour)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunScheduled did not stop after cancel")
+	}
+	if mock.calls != 1 {
+		t.Errorf("expected immediate run on start, got %d calls", mock.calls)
+	}
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Default code language backfill job configuration values.
+const (
+	// DefaultCodeLanguageBackfillInterval is how often unscanned posts and answers are picked up.
+	DefaultCodeLanguageBackfillInterval = 10 * time.Minute
+
+	// DefaultCodeLanguageBackfillBatchSize is how many posts and answers are scanned per batch.
+	DefaultCodeLanguageBackfillBatchSize = 500
+)
+
+// CodeLanguageBackfiller extracts code block languages for rows written before
+// languages were recorded on write.
+type CodeLanguageBackfiller interface {
+	BackfillCodeLanguages(ctx context.Context, limit int) (int64, error)
+}
+
+// CodeLanguageBackfillJob fills in code languages for existing posts and answers.
+// New content is scanned on write, so once the backlog is drained each run is a
+// cheap no-op.
+type CodeLanguageBackfillJob struct {
+	backfiller CodeLanguageBackfiller
+	batchSize  int
+}
+
+// NewCodeLanguageBackfillJob creates a new CodeLanguageBackfillJob.
+func NewCodeLanguageBackfillJob(backfiller CodeLanguageBackfiller, batchSize int) *CodeLanguageBackfillJob {
+	if batchSize <= 0 {
+		batchSize = DefaultCodeLanguageBackfillBatchSize
+	}
+	return &CodeLanguageBackfillJob{backfiller: backfiller, batchSize: batchSize}
+}
+
+// RunOnce scans batches until nothing is left or the context is cancelled.
+// Returns the number of rows scanned.
+func (j *CodeLanguageBackfillJob) RunOnce(ctx context.Context) (int64, error) {
+	var total int64
+	for ctx.Err() == nil {
+		n, err := j.backfiller.BackfillCodeLanguages(ctx, j.batchSize)
+		total += n
+		if err != nil || n == 0 {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// RunScheduled runs the backfill on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *CodeLanguageBackfillJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Code language backfill job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs once and logs errors or progress.
+func (j *CodeLanguageBackfillJob) runAndLog(ctx context.Context) {
+	scanned, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Code language backfill failed after %d rows: %v", scanned, err)
+		return
+	}
+	if scanned > 0 {
+		log.Printf("Code language backfill: scanned %d posts and answers", scanned)
+	}
+}
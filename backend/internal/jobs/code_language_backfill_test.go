@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockCodeLanguageBackfiller struct {
+	batches []int64
+	err     error
+	limits  []int
+}
+
+func (m *mockCodeLanguageBackfiller) BackfillCodeLanguages(ctx context.Context, limit int) (int64, error) {
+	m.limits = append(m.limits, limit)
+	if len(m.batches) == 0 {
+		return 0, m.err
+	}
+	n := m.batches[0]
+	m.batches = m.batches[1:]
+	return n, nil
+}
+
+func TestCodeLanguageBackfillJob_RunOnceDrainsBacklog(t *testing.T) {
+	mock := &mockCodeLanguageBackfiller{batches: []int64{10, 10, 3}}
+	job := NewCodeLanguageBackfillJob(mock, 10)
+
+	scanned, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scanned != 23 {
+		t.Errorf("expected 23 rows scanned, got %d", scanned)
+	}
+	if len(mock.limits) != 4 || mock.limits[0] != 10 {
+		t.Errorf("expected 4 batches of 10, got %v", mock.limits)
+	}
+}
+
+func TestCodeLanguageBackfillJob_RunOnceError(t *testing.T) {
+	mock := &mockCodeLanguageBackfiller{batches: []int64{5}, err: errors.New("db down")}
+	job := NewCodeLanguageBackfillJob(mock, 0)
+
+	scanned, err := job.RunOnce(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if scanned != 5 {
+		t.Errorf("expected 5 rows scanned before the error, got %d", scanned)
+	}
+	if mock.limits[0] != DefaultCodeLanguageBackfillBatchSize {
+		t.Errorf("expected default batch size, got %d", mock.limits[0])
+	}
+}
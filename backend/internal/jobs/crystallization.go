@@ -34,6 +34,11 @@ type ProblemCrystallizer interface {
 	CrystallizeProblem(ctx context.Context, problemID string) (string, error)
 }
 
+// CrystallizationNotifier tells the problem's author it was crystallized.
+type CrystallizationNotifier interface {
+	NotifyPostCrystallized(ctx context.Context, problemID, cid string) error
+}
+
 // CrystallizationResult holds the results of a single crystallization job run.
 type CrystallizationResult struct {
 	Crystallized int
@@ -46,6 +51,7 @@ type CrystallizationJob struct {
 	lister          CrystallizationCandidateLister
 	crystallizer    ProblemCrystallizer
 	stabilityPeriod time.Duration
+	notifier        CrystallizationNotifier
 }
 
 // NewCrystallizationJob creates a new crystallization job.
@@ -61,6 +67,11 @@ func NewCrystallizationJob(
 	}
 }
 
+// SetNotifier enables author notifications for each crystallized problem.
+func (j *CrystallizationJob) SetNotifier(notifier CrystallizationNotifier) {
+	j.notifier = notifier
+}
+
 // RunOnce scans for crystallization candidates and crystallizes them.
 func (j *CrystallizationJob) RunOnce(ctx context.Context) CrystallizationResult {
 	var result CrystallizationResult
@@ -93,6 +104,12 @@ func (j *CrystallizationJob) RunOnce(ctx context.Context) CrystallizationResult
 		}
 		log.Printf("Crystallization job: crystallized %s → %s", problemID, cid)
 		result.Crystallized++
+
+		if j.notifier != nil {
+			if err := j.notifier.NotifyPostCrystallized(ctx, problemID, cid); err != nil {
+				log.Printf("Crystallization job: failed to notify author of %s: %v", problemID, err)
+			}
+		}
 	}
 
 	return result
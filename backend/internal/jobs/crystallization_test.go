@@ -231,3 +231,31 @@ func TestCrystallizationJob_SkipsNoVerifiedApproach(t *testing.T) {
 		t.Errorf("RunOnce() skipped = %d, want 1", result.Skipped)
 	}
 }
+
+type mockCrystallizationNotifier struct {
+	notified map[string]string
+}
+
+func (m *mockCrystallizationNotifier) NotifyPostCrystallized(ctx context.Context, problemID, cid string) error {
+	if m.notified == nil {
+		m.notified = make(map[string]string)
+	}
+	m.notified[problemID] = cid
+	return nil
+}
+
+// TestCrystallizationJob_RunOnce_NotifiesAuthors tests that only successfully
+// crystallized problems trigger an author notification.
+func TestCrystallizationJob_RunOnce_NotifiesAuthors(t *testing.T) {
+	lister := &mockCandidateLister{candidateIDs: []string{"problem-1", "problem-2"}}
+	crystallizer := &mockCrystallizer{errMap: map[string]error{"problem-2": errors.New("ipfs down")}}
+	notifier := &mockCrystallizationNotifier{}
+
+	job := NewCrystallizationJob(lister, crystallizer, DefaultCrystallizationStabilityPeriod)
+	job.SetNotifier(notifier)
+	job.RunOnce(context.Background())
+
+	if len(notifier.notified) != 1 || notifier.notified["problem-1"] != "bafytest_problem-1" {
+		t.Errorf("expected only problem-1 notified with its CID, got %v", notifier.notified)
+	}
+}
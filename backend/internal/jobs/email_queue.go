@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// DefaultEmailQueueInterval is how often queued notification emails are sent.
+const DefaultEmailQueueInterval = time.Minute
+
+// emailQueueBatchSize caps how many emails are sent per run.
+const emailQueueBatchSize = 100
+
+// maxEmailAttempts is how many times an email is tried before it is left in the
+// queue as undeliverable.
+const maxEmailAttempts = 8
+
+// maxEmailBackoff caps the delay between attempts for a single email.
+const maxEmailBackoff = 2 * time.Hour
+
+// EmailQueueStore reads and updates the email queue.
+type EmailQueueStore interface {
+	ListDue(ctx context.Context, limit, maxAttempts int) ([]models.EmailQueueItem, error)
+	Complete(ctx context.Context, id string) error
+	Fail(ctx context.Context, id, errMsg string, nextAttempt time.Time) error
+}
+
+// EmailQueueJob delivers queued notification emails through a Mailer.
+type EmailQueueJob struct {
+	store  EmailQueueStore
+	mailer services.Mailer
+}
+
+// NewEmailQueueJob creates a new EmailQueueJob.
+func NewEmailQueueJob(store EmailQueueStore, mailer services.Mailer) *EmailQueueJob {
+	return &EmailQueueJob{
+		store:  store,
+		mailer: mailer,
+	}
+}
+
+// RunOnce sends one batch of due emails. Returns sent and failed counts.
+// Unlike the embedding queue, a failure doesn't stop the batch: a bad recipient
+// address fails on its own without the provider being down.
+func (j *EmailQueueJob) RunOnce(ctx context.Context) (sent, failed int) {
+	items, err := j.store.ListDue(ctx, emailQueueBatchSize, maxEmailAttempts)
+	if err != nil {
+		log.Printf("Email queue: failed to list due emails: %v", err)
+		return 0, 0
+	}
+
+	for _, item := range items {
+		sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		err := j.mailer.Send(sendCtx, &services.EmailMessage{
+			To:      item.To,
+			Subject: item.Subject,
+			HTML:    item.HTML,
+			Text:    item.Text,
+		})
+		cancel()
+
+		if err != nil {
+			failed++
+			next := time.Now().Add(emailBackoff(item.Attempts))
+			if failErr := j.store.Fail(ctx, item.ID, err.Error(), next); failErr != nil {
+				log.Printf("Email queue: failed to record failure for %s: %v", item.ID, failErr)
+			}
+			continue
+		}
+
+		if err := j.store.Complete(ctx, item.ID); err != nil {
+			log.Printf("Email queue: failed to dequeue %s: %v", item.ID, err)
+		}
+		sent++
+	}
+	return sent, failed
+}
+
+// emailBackoff returns the retry delay after the given number of prior attempts:
+// 1m, 2m, 4m, ... capped at maxEmailBackoff.
+func emailBackoff(attempts int) time.Duration {
+	if attempts > 16 {
+		return maxEmailBackoff
+	}
+	d := time.Minute << attempts
+	if d > maxEmailBackoff {
+		return maxEmailBackoff
+	}
+	return d
+}
+
+// RunScheduled drains the email queue on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *EmailQueueJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Email queue job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs one batch and logs non-empty results.
+func (j *EmailQueueJob) runAndLog(ctx context.Context) {
+	sent, failed := j.RunOnce(ctx)
+	if sent > 0 || failed > 0 {
+		log.Printf("Email queue: %d sent, %d failed", sent, failed)
+	}
+}
@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+type mockEmailQueueStore struct {
+	due         []models.EmailQueueItem
+	maxAttempts int
+	completed   []string
+	failed      map[string]time.Time
+}
+
+func (m *mockEmailQueueStore) ListDue(ctx context.Context, limit, maxAttempts int) ([]models.EmailQueueItem, error) {
+	m.maxAttempts = maxAttempts
+	return m.due, nil
+}
+
+func (m *mockEmailQueueStore) Complete(ctx context.Context, id string) error {
+	m.completed = append(m.completed, id)
+	return nil
+}
+
+func (m *mockEmailQueueStore) Fail(ctx context.Context, id, errMsg string, nextAttempt time.Time) error {
+	if m.failed == nil {
+		m.failed = make(map[string]time.Time)
+	}
+	m.failed[id] = nextAttempt
+	return nil
+}
+
+// mockMailer fails for recipients listed in failFor.
+type mockMailer struct {
+	failFor map[string]bool
+	sent    []string
+}
+
+func (m *mockMailer) Send(ctx context.Context, msg *services.EmailMessage) error {
+	if m.failFor[msg.To] {
+		return errors.New("mailbox unavailable")
+	}
+	m.sent = append(m.sent, msg.To)
+	return nil
+}
+
+func TestEmailQueueJob_RunOnce_SendsAndRetries(t *testing.T) {
+	store := &mockEmailQueueStore{due: []models.EmailQueueItem{
+		{ID: "e-1", To: "bad@example.com", Subject: "s", Text: "t", Attempts: 2},
+		{ID: "e-2", To: "ok@example.com", Subject: "s", Text: "t"},
+	}}
+	mailer := &mockMailer{failFor: map[string]bool{"bad@example.com": true}}
+	job := NewEmailQueueJob(store, mailer)
+
+	before := time.Now()
+	sent, failed := job.RunOnce(context.Background())
+
+	if sent != 1 || failed != 1 {
+		t.Errorf("expected 1 sent, 1 failed; got %d, %d", sent, failed)
+	}
+	if len(store.completed) != 1 || store.completed[0] != "e-2" {
+		t.Errorf("expected e-2 completed after e-1 failed, got %v", store.completed)
+	}
+	next, ok := store.failed["e-1"]
+	if !ok {
+		t.Fatal("expected failure recorded for e-1")
+	}
+	if next.Before(before.Add(4 * time.Minute)) {
+		t.Errorf("expected 4m backoff after 2 attempts, next attempt at %v", next)
+	}
+	if store.maxAttempts != maxEmailAttempts {
+		t.Errorf("expected ListDue capped at %d attempts, got %d", maxEmailAttempts, store.maxAttempts)
+	}
+}
+
+func TestEmailBackoff(t *testing.T) {
+	cases := map[int]time.Duration{0: time.Minute, 3: 8 * time.Minute, 10: maxEmailBackoff, 40: maxEmailBackoff}
+	for attempts, want := range cases {
+		if got := emailBackoff(attempts); got != want {
+			t.Errorf("emailBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
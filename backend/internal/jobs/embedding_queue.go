@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// DefaultEmbeddingQueueInterval is how often queued embeddings are retried.
+const DefaultEmbeddingQueueInterval = 5 * time.Minute
+
+// embeddingQueueBatchSize caps how many posts are embedded per run.
+const embeddingQueueBatchSize = 50
+
+// maxEmbeddingBackoff caps the delay between attempts for a single post.
+const maxEmbeddingBackoff = 6 * time.Hour
+
+// EmbeddingQueueStore reads and updates the embedding queue.
+type EmbeddingQueueStore interface {
+	ListDue(ctx context.Context, limit int) ([]models.EmbeddingQueueItem, error)
+	Complete(ctx context.Context, postID string, embedding []float32) error
+	Fail(ctx context.Context, postID, errMsg string, nextAttempt time.Time) error
+}
+
+// EmbeddingGenerator generates document embeddings.
+type EmbeddingGenerator interface {
+	GenerateEmbedding(ctx context.Context, text string) ([]float32, error)
+}
+
+// EmbeddingQueueJob generates embeddings for posts that were created or edited
+// while the embedding service was unavailable.
+type EmbeddingQueueJob struct {
+	store    EmbeddingQueueStore
+	embedder EmbeddingGenerator
+}
+
+// NewEmbeddingQueueJob creates a new EmbeddingQueueJob.
+func NewEmbeddingQueueJob(store EmbeddingQueueStore, embedder EmbeddingGenerator) *EmbeddingQueueJob {
+	return &EmbeddingQueueJob{
+		store:    store,
+		embedder: embedder,
+	}
+}
+
+// RunOnce embeds one batch of due posts. Returns embedded and failed counts.
+// Stops early on the first embedding failure: if the provider is down, the rest
+// of the batch would fail too, so they are left due for the next run.
+func (j *EmbeddingQueueJob) RunOnce(ctx context.Context) (embedded, failed int) {
+	items, err := j.store.ListDue(ctx, embeddingQueueBatchSize)
+	if err != nil {
+		log.Printf("Embedding queue: failed to list due posts: %v", err)
+		return 0, 0
+	}
+
+	for _, item := range items {
+		embedCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		embedding, err := j.embedder.GenerateEmbedding(embedCtx, item.Title+" "+item.Description)
+		cancel()
+
+		if err != nil {
+			failed++
+			next := time.Now().Add(embeddingBackoff(item.Attempts))
+			if failErr := j.store.Fail(ctx, item.PostID, err.Error(), next); failErr != nil {
+				log.Printf("Embedding queue: failed to record failure for %s: %v", item.PostID, failErr)
+			}
+			return embedded, failed
+		}
+
+		if err := j.store.Complete(ctx, item.PostID, embedding); err != nil {
+			log.Printf("Embedding queue: failed to store embedding for %s: %v", item.PostID, err)
+			failed++
+			continue
+		}
+		embedded++
+	}
+	return embedded, failed
+}
+
+// embeddingBackoff returns the retry delay after the given number of prior attempts:
+// 1m, 2m, 4m, ... capped at maxEmbeddingBackoff.
+func embeddingBackoff(attempts int) time.Duration {
+	if attempts > 16 {
+		return maxEmbeddingBackoff
+	}
+	d := time.Minute << attempts
+	if d > maxEmbeddingBackoff {
+		return maxEmbeddingBackoff
+	}
+	return d
+}
+
+// RunScheduled drains the embedding queue on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *EmbeddingQueueJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Embedding queue job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs one batch and logs non-empty results.
+func (j *EmbeddingQueueJob) runAndLog(ctx context.Context) {
+	embedded, failed := j.RunOnce(ctx)
+	if embedded > 0 || failed > 0 {
+		log.Printf("Embedding queue: %d embedded, %d failed", embedded, failed)
+	}
+}
@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockEmbeddingQueueStore struct {
+	due       []models.EmbeddingQueueItem
+	completed []string
+	failed    map[string]time.Time
+}
+
+func (m *mockEmbeddingQueueStore) ListDue(ctx context.Context, limit int) ([]models.EmbeddingQueueItem, error) {
+	return m.due, nil
+}
+
+func (m *mockEmbeddingQueueStore) Complete(ctx context.Context, postID string, embedding []float32) error {
+	m.completed = append(m.completed, postID)
+	return nil
+}
+
+func (m *mockEmbeddingQueueStore) Fail(ctx context.Context, postID, errMsg string, nextAttempt time.Time) error {
+	if m.failed == nil {
+		m.failed = make(map[string]time.Time)
+	}
+	m.failed[postID] = nextAttempt
+	return nil
+}
+
+type mockEmbeddingGenerator struct {
+	err error
+}
+
+func (m *mockEmbeddingGenerator) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []float32{0.1, 0.2}, nil
+}
+
+func TestEmbeddingQueueJob_RunOnce_EmbedsDuePosts(t *testing.T) {
+	store := &mockEmbeddingQueueStore{due: []models.EmbeddingQueueItem{
+		{PostID: "post-1", Title: "a", Description: "b"},
+		{PostID: "post-2", Title: "c", Description: "d"},
+	}}
+	job := NewEmbeddingQueueJob(store, &mockEmbeddingGenerator{})
+
+	embedded, failed := job.RunOnce(context.Background())
+
+	if embedded != 2 || failed != 0 {
+		t.Errorf("expected 2 embedded, 0 failed; got %d, %d", embedded, failed)
+	}
+	if len(store.completed) != 2 {
+		t.Errorf("expected 2 completed posts, got %v", store.completed)
+	}
+}
+
+func TestEmbeddingQueueJob_RunOnce_StopsOnProviderFailure(t *testing.T) {
+	store := &mockEmbeddingQueueStore{due: []models.EmbeddingQueueItem{
+		{PostID: "post-1", Attempts: 2},
+		{PostID: "post-2"},
+	}}
+	job := NewEmbeddingQueueJob(store, &mockEmbeddingGenerator{err: errors.New("voyage 503")})
+
+	before := time.Now()
+	embedded, failed := job.RunOnce(context.Background())
+
+	if embedded != 0 || failed != 1 {
+		t.Errorf("expected 0 embedded, 1 failed; got %d, %d", embedded, failed)
+	}
+	next, ok := store.failed["post-1"]
+	if !ok {
+		t.Fatal("expected failure to be recorded for post-1")
+	}
+	if next.Before(before.Add(4 * time.Minute)) {
+		t.Errorf("expected backoff of at least 4m after 2 attempts, got %v", next.Sub(before))
+	}
+	if _, ok := store.failed["post-2"]; ok {
+		t.Error("expected post-2 to be left for the next run")
+	}
+}
+
+func TestEmbeddingBackoff_Capped(t *testing.T) {
+	if got := embeddingBackoff(0); got != time.Minute {
+		t.Errorf("expected 1m for first retry, got %v", got)
+	}
+	if got := embeddingBackoff(30); got != maxEmbeddingBackoff {
+		t.Errorf("expected cap %v, got %v", maxEmbeddingBackoff, got)
+	}
+}
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Default entity extraction job configuration values.
+const (
+	// DefaultEntityExtractionInterval is how often new and edited posts are picked up.
+	DefaultEntityExtractionInterval = 15 * time.Minute
+
+	// DefaultEntityExtractionBatchSize is how many posts are extracted per batch.
+	DefaultEntityExtractionBatchSize = 200
+)
+
+// EntityExtractor extracts knowledge graph entities from posts that are new or
+// edited since their last extraction.
+// Implemented by db.EntityRepository.
+type EntityExtractor interface {
+	ExtractPendingEntities(ctx context.Context, limit int) (int, error)
+}
+
+// EntityExtractionJob keeps the entity graph behind GET /v1/entities/{name}
+// current. Extraction is rule based and cheap, so it runs in batches until no
+// post is pending.
+type EntityExtractionJob struct {
+	extractor EntityExtractor
+	batchSize int
+}
+
+// NewEntityExtractionJob creates a new EntityExtractionJob.
+func NewEntityExtractionJob(extractor EntityExtractor, batchSize int) *EntityExtractionJob {
+	if batchSize <= 0 {
+		batchSize = DefaultEntityExtractionBatchSize
+	}
+	return &EntityExtractionJob{extractor: extractor, batchSize: batchSize}
+}
+
+// RunOnce extracts batches until one comes back short or the context is
+// cancelled. Returns the number of posts extracted.
+func (j *EntityExtractionJob) RunOnce(ctx context.Context) (int, error) {
+	total := 0
+	for ctx.Err() == nil {
+		n, err := j.extractor.ExtractPendingEntities(ctx, j.batchSize)
+		total += n
+		if err != nil || n < j.batchSize {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// RunScheduled runs the entity extraction job on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *EntityExtractionJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Entity extraction job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs once and logs errors or progress.
+func (j *EntityExtractionJob) runAndLog(ctx context.Context) {
+	extracted, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Entity extraction failed after %d posts: %v", extracted, err)
+		return
+	}
+	if extracted > 0 {
+		log.Printf("Entity extraction: extracted %d posts", extracted)
+	}
+}
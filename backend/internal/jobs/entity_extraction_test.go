@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type mockEntityExtractor struct {
+	batches []int
+	err     error
+	limits  []int
+}
+
+func (m *mockEntityExtractor) ExtractPendingEntities(ctx context.Context, limit int) (int, error) {
+	m.limits = append(m.limits, limit)
+	if len(m.batches) == 0 {
+		return 0, m.err
+	}
+	n := m.batches[0]
+	m.batches = m.batches[1:]
+	return n, nil
+}
+
+func TestEntityExtractionJob_RunOnceStopsOnShortBatch(t *testing.T) {
+	mock := &mockEntityExtractor{batches: []int{5, 5, 2}}
+	job := NewEntityExtractionJob(mock, 5)
+
+	extracted, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if extracted != 12 {
+		t.Errorf("expected 12 posts extracted, got %d", extracted)
+	}
+	if len(mock.limits) != 3 || mock.limits[0] != 5 {
+		t.Errorf("expected 3 batches of 5, got %v", mock.limits)
+	}
+}
+
+func TestEntityExtractionJob_RunOnceReturnsError(t *testing.T) {
+	mock := &mockEntityExtractor{batches: []int{5}, err: errors.New("db down")}
+	job := NewEntityExtractionJob(mock, 5)
+
+	extracted, err := job.RunOnce(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if extracted != 5 {
+		t.Errorf("expected 5 posts extracted before the error, got %d", extracted)
+	}
+}
+
+func TestNewEntityExtractionJob_DefaultBatchSize(t *testing.T) {
+	job := NewEntityExtractionJob(&mockEntityExtractor{}, 0)
+	if job.batchSize != DefaultEntityExtractionBatchSize {
+		t.Errorf("expected default batch size %d, got %d", DefaultEntityExtractionBatchSize, job.batchSize)
+	}
+}
@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Default freshness job configuration values.
+const (
+	// DefaultFreshnessInterval is how often post freshness scores are refreshed.
+	DefaultFreshnessInterval = 6 * time.Hour
+
+	// DefaultFreshnessMaxAge is how old a score can get before the post is
+	// rescored. Posts edited since their last score are rescored on the next run.
+	DefaultFreshnessMaxAge = 24 * time.Hour
+
+	// DefaultFreshnessBatchSize is how many posts are rescored per batch.
+	DefaultFreshnessBatchSize = 500
+)
+
+// FreshnessRescorer recomputes stored post freshness scores.
+// Implemented by db.PostFreshnessRepository.
+type FreshnessRescorer interface {
+	RescoreStale(ctx context.Context, olderThan time.Time, limit int) (int, error)
+}
+
+// FreshnessJob keeps post freshness scores current: scores drop as posts age,
+// so they are recomputed daily, and right after an edit changes the pinned
+// library versions. Outdated flags rescore their post immediately.
+type FreshnessJob struct {
+	rescorer  FreshnessRescorer
+	maxAge    time.Duration
+	batchSize int
+}
+
+// NewFreshnessJob creates a new FreshnessJob.
+func NewFreshnessJob(rescorer FreshnessRescorer, maxAge time.Duration, batchSize int) *FreshnessJob {
+	if maxAge <= 0 {
+		maxAge = DefaultFreshnessMaxAge
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultFreshnessBatchSize
+	}
+	return &FreshnessJob{rescorer: rescorer, maxAge: maxAge, batchSize: batchSize}
+}
+
+// RunOnce rescores batches of unscored, edited and stale posts until none are
+// left or the context is cancelled. Returns the number of posts rescored.
+func (j *FreshnessJob) RunOnce(ctx context.Context) (int, error) {
+	olderThan := time.Now().Add(-j.maxAge)
+	total := 0
+	for ctx.Err() == nil {
+		n, err := j.rescorer.RescoreStale(ctx, olderThan, j.batchSize)
+		total += n
+		if err != nil || n < j.batchSize {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// RunScheduled runs the freshness job on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *FreshnessJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Freshness job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs once and logs errors or progress.
+func (j *FreshnessJob) runAndLog(ctx context.Context) {
+	rescored, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Freshness job failed after %d posts: %v", rescored, err)
+		return
+	}
+	if rescored > 0 {
+		log.Printf("Freshness job: rescored %d posts", rescored)
+	}
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockFreshnessRescorer struct {
+	batches    []int
+	err        error
+	limits     []int
+	olderThans []time.Time
+}
+
+func (m *mockFreshnessRescorer) RescoreStale(ctx context.Context, olderThan time.Time, limit int) (int, error) {
+	m.limits = append(m.limits, limit)
+	m.olderThans = append(m.olderThans, olderThan)
+	if len(m.batches) == 0 {
+		return 0, m.err
+	}
+	n := m.batches[0]
+	m.batches = m.batches[1:]
+	return n, nil
+}
+
+func TestFreshnessJob_RunOnceStopsOnShortBatch(t *testing.T) {
+	mock := &mockFreshnessRescorer{batches: []int{10, 10, 3}}
+	job := NewFreshnessJob(mock, 24*time.Hour, 10)
+
+	before := time.Now()
+	rescored, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rescored != 23 {
+		t.Errorf("expected 23 posts rescored, got %d", rescored)
+	}
+	if len(mock.limits) != 3 {
+		t.Errorf("expected 3 batches, got %d", len(mock.limits))
+	}
+	// Every batch uses the cutoff taken at the start of the run, so posts rescored
+	// earlier in the run are not picked up again.
+	for _, cutoff := range mock.olderThans {
+		if cutoff != mock.olderThans[0] {
+			t.Errorf("expected one cutoff per run, got %v", mock.olderThans)
+		}
+	}
+	if cutoff := mock.olderThans[0]; cutoff.Before(before.Add(-24*time.Hour)) || cutoff.After(time.Now().Add(-24*time.Hour)) {
+		t.Errorf("expected cutoff 24h before the run, got %v", cutoff)
+	}
+}
+
+func TestFreshnessJob_RunOnceError(t *testing.T) {
+	mock := &mockFreshnessRescorer{batches: []int{DefaultFreshnessBatchSize}, err: errors.New("db down")}
+	job := NewFreshnessJob(mock, 0, 0)
+
+	rescored, err := job.RunOnce(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if rescored != DefaultFreshnessBatchSize {
+		t.Errorf("expected %d posts rescored before the error, got %d", DefaultFreshnessBatchSize, rescored)
+	}
+	if mock.limits[0] != DefaultFreshnessBatchSize {
+		t.Errorf("expected default batch size, got %d", mock.limits[0])
+	}
+}
@@ -0,0 +1,112 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// DefaultGitHubSyncInterval is how often linked GitHub issues are checked.
+const DefaultGitHubSyncInterval = 15 * time.Minute
+
+// DefaultGitHubSyncStaleness is how long a link goes without a check before it is due.
+const DefaultGitHubSyncStaleness = time.Hour
+
+// githubSyncBatchSize caps issues fetched per run. Unauthenticated GitHub clients
+// get 60 requests per hour, so the default leaves room for imports.
+const githubSyncBatchSize = 40
+
+// GitHubLinkStore lists links to sync and applies issue state to posts.
+type GitHubLinkStore interface {
+	ListDueForSync(ctx context.Context, olderThan time.Time, limit int) ([]models.PostGitHubLink, error)
+	ApplyIssueState(ctx context.Context, postID, state string) (models.PostStatus, error)
+}
+
+// GitHubIssueStateFetcher reads an issue's current state.
+type GitHubIssueStateFetcher interface {
+	FetchIssue(ctx context.Context, ref models.GitHubIssueRef) (*models.GitHubIssue, error)
+}
+
+// GitHubSyncJob keeps posts linked to GitHub issues in step with the issue:
+// closing the issue closes the post, reopening it reopens the post.
+type GitHubSyncJob struct {
+	store     GitHubLinkStore
+	fetcher   GitHubIssueStateFetcher
+	staleness time.Duration
+}
+
+// NewGitHubSyncJob creates a new GitHubSyncJob.
+func NewGitHubSyncJob(store GitHubLinkStore, fetcher GitHubIssueStateFetcher, staleness time.Duration) *GitHubSyncJob {
+	return &GitHubSyncJob{
+		store:     store,
+		fetcher:   fetcher,
+		staleness: staleness,
+	}
+}
+
+// RunOnce syncs one batch of due links. Returns how many were checked and how many
+// post statuses changed. Stops early when GitHub rate limits the client.
+func (j *GitHubSyncJob) RunOnce(ctx context.Context) (checked, changed int) {
+	links, err := j.store.ListDueForSync(ctx, time.Now().Add(-j.staleness), githubSyncBatchSize)
+	if err != nil {
+		log.Printf("GitHub sync: failed to list links: %v", err)
+		return 0, 0
+	}
+
+	for _, link := range links {
+		issue, err := j.fetcher.FetchIssue(ctx, link.Ref())
+		if errors.Is(err, models.ErrGitHubRateLimited) {
+			log.Printf("GitHub sync: rate limited after %d issues", checked)
+			return checked, changed
+		}
+		if errors.Is(err, models.ErrGitHubIssueNotFound) {
+			// Deleted or made private: keep the last known state but mark it checked.
+			issue, err = &models.GitHubIssue{State: link.IssueState}, nil
+		}
+		if err != nil {
+			log.Printf("GitHub sync: failed to fetch %s: %v", link.Ref(), err)
+			continue
+		}
+
+		status, err := j.store.ApplyIssueState(ctx, link.PostID, issue.State)
+		if err != nil {
+			log.Printf("GitHub sync: failed to apply state for post %s: %v", link.PostID, err)
+			continue
+		}
+		checked++
+		if status != "" {
+			changed++
+		}
+	}
+	return checked, changed
+}
+
+// RunScheduled syncs linked issues on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *GitHubSyncJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("GitHub sync job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs one batch and logs non-empty results.
+func (j *GitHubSyncJob) runAndLog(ctx context.Context) {
+	checked, changed := j.RunOnce(ctx)
+	if checked > 0 {
+		log.Printf("GitHub sync: %d issues checked, %d posts updated", checked, changed)
+	}
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mockGitHubLinkStore implements GitHubLinkStore for testing.
+type mockGitHubLinkStore struct {
+	links   []models.PostGitHubLink
+	applied map[string]string
+}
+
+func (m *mockGitHubLinkStore) ListDueForSync(ctx context.Context, olderThan time.Time, limit int) ([]models.PostGitHubLink, error) {
+	return m.links, nil
+}
+
+func (m *mockGitHubLinkStore) ApplyIssueState(ctx context.Context, postID, state string) (models.PostStatus, error) {
+	if m.applied == nil {
+		m.applied = map[string]string{}
+	}
+	m.applied[postID] = state
+	if state == models.GitHubIssueClosed {
+		return models.PostStatusClosed, nil
+	}
+	return "", nil
+}
+
+// mockGitHubIssueStateFetcher returns issue states or errors by issue number.
+type mockGitHubIssueStateFetcher struct {
+	states map[int]string
+	errs   map[int]error
+}
+
+func (m *mockGitHubIssueStateFetcher) FetchIssue(ctx context.Context, ref models.GitHubIssueRef) (*models.GitHubIssue, error) {
+	if err := m.errs[ref.Number]; err != nil {
+		return nil, err
+	}
+	return &models.GitHubIssue{State: m.states[ref.Number]}, nil
+}
+
+func TestGitHubSyncJob_RunOnce(t *testing.T) {
+	store := &mockGitHubLinkStore{links: []models.PostGitHubLink{
+		{PostID: "p1", RepoOwner: "acme", RepoName: "tool", IssueNumber: 1, IssueState: models.GitHubIssueOpen},
+		{PostID: "p2", RepoOwner: "acme", RepoName: "tool", IssueNumber: 2, IssueState: models.GitHubIssueOpen},
+		{PostID: "p3", RepoOwner: "acme", RepoName: "tool", IssueNumber: 3, IssueState: models.GitHubIssueClosed},
+	}}
+	fetcher := &mockGitHubIssueStateFetcher{
+		states: map[int]string{1: models.GitHubIssueClosed, 2: models.GitHubIssueOpen},
+		errs:   map[int]error{3: models.ErrGitHubIssueNotFound},
+	}
+
+	checked, changed := NewGitHubSyncJob(store, fetcher, time.Hour).RunOnce(context.Background())
+
+	if checked != 3 || changed != 2 {
+		t.Errorf("expected 3 checked and 2 changed, got %d and %d", checked, changed)
+	}
+	if store.applied["p3"] != models.GitHubIssueClosed {
+		t.Errorf("expected missing issue to keep its last state, got %q", store.applied["p3"])
+	}
+}
+
+func TestGitHubSyncJob_StopsWhenRateLimited(t *testing.T) {
+	store := &mockGitHubLinkStore{links: []models.PostGitHubLink{
+		{PostID: "p1", IssueNumber: 1},
+		{PostID: "p2", IssueNumber: 2},
+	}}
+	fetcher := &mockGitHubIssueStateFetcher{errs: map[int]error{1: models.ErrGitHubRateLimited}}
+
+	checked, _ := NewGitHubSyncJob(store, fetcher, time.Hour).RunOnce(context.Background())
+
+	if checked != 0 || len(store.applied) != 0 {
+		t.Errorf("expected sync to stop on rate limit, checked %d applied %v", checked, store.applied)
+	}
+}
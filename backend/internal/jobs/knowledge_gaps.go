@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// Default knowledge gap report job configuration.
+const (
+	// DefaultKnowledgeGapInterval is how often the job checks whether last week's
+	// report is due. Reports are keyed by week, so each week is published once.
+	DefaultKnowledgeGapInterval = 6 * time.Hour
+
+	// DefaultKnowledgeGapQueryLimit is the max zero-result queries sent to Groq.
+	DefaultKnowledgeGapQueryLimit = 100
+
+	// DefaultKnowledgeGapStuckLimit is the max stuck problems sent to Groq.
+	DefaultKnowledgeGapStuckLimit = 40
+)
+
+// KnowledgeGapStore reads a week's demand signals and publishes the report.
+// Implemented by db.KnowledgeGapsRepository.
+type KnowledgeGapStore interface {
+	HasReportForWeek(ctx context.Context, weekStart time.Time) (bool, error)
+	ListZeroResultQueries(ctx context.Context, since, until time.Time, limit int) ([]models.ZeroResultQuery, error)
+	ListStuckProblems(ctx context.Context, since, until time.Time, limit int) ([]models.StuckProblemSummary, error)
+	FindReportAuthor(ctx context.Context) (string, error)
+	ListAdminRecipients(ctx context.Context) ([]models.EmailRecipient, error)
+	// PublishReport returns false if the week already has a report.
+	PublishReport(ctx context.Context, report *models.KnowledgeGapReport, post *models.BlogPost) (bool, error)
+}
+
+// KnowledgeGapSummarizer turns demand signals into recurring unmet needs.
+// Implemented by services.KnowledgeGapService.
+type KnowledgeGapSummarizer interface {
+	SummarizeGaps(ctx context.Context, input services.KnowledgeGapInput) (*services.KnowledgeGapSummary, error)
+}
+
+// KnowledgeGapJob publishes a weekly "knowledge gaps" report: zero-result searches
+// and problems escalated as stuck are summarized by Groq into recurring unmet
+// needs, published as a blog post and emailed to admins, so contributors can see
+// where demand is going unanswered.
+type KnowledgeGapJob struct {
+	store      KnowledgeGapStore
+	summarizer KnowledgeGapSummarizer
+	emailQueue services.EmailQueueWriter
+	now        func() time.Time
+}
+
+// NewKnowledgeGapJob creates a new KnowledgeGapJob.
+func NewKnowledgeGapJob(store KnowledgeGapStore, summarizer KnowledgeGapSummarizer) *KnowledgeGapJob {
+	return &KnowledgeGapJob{store: store, summarizer: summarizer, now: time.Now}
+}
+
+// SetEmailQueue enables the admin email. Without it the report is only published.
+func (j *KnowledgeGapJob) SetEmailQueue(queue services.EmailQueueWriter) {
+	j.emailQueue = queue
+}
+
+// weekStart returns the Monday 00:00 UTC that starts t's week.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+}
+
+// RunOnce publishes the report for the last full week (Monday to Monday, UTC)
+// unless it already exists. Returns the published report, or nil when there was
+// nothing to do: the report exists, the week had no signals, or there is no
+// admin to publish it.
+func (j *KnowledgeGapJob) RunOnce(ctx context.Context) (*models.KnowledgeGapReport, error) {
+	until := weekStart(j.now())
+	since := until.AddDate(0, 0, -7)
+
+	exists, err := j.store.HasReportForWeek(ctx, since)
+	if err != nil || exists {
+		return nil, err
+	}
+
+	queries, err := j.store.ListZeroResultQueries(ctx, since, until, DefaultKnowledgeGapQueryLimit)
+	if err != nil {
+		return nil, err
+	}
+	stuck, err := j.store.ListStuckProblems(ctx, since, until, DefaultKnowledgeGapStuckLimit)
+	if err != nil {
+		return nil, err
+	}
+	if len(queries) == 0 && len(stuck) == 0 {
+		return nil, nil
+	}
+
+	authorID, err := j.store.FindReportAuthor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if authorID == "" {
+		log.Println("Knowledge gap job: no admin user to publish the report")
+		return nil, nil
+	}
+
+	summary, err := j.summarizer.SummarizeGaps(ctx, services.KnowledgeGapInput{
+		ZeroResultQueries: queries,
+		StuckProblems:     stuck,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.KnowledgeGapReport{
+		WeekStart:         since,
+		Summary:           summary.Summary,
+		Gaps:              summary.Gaps,
+		ZeroResultQueries: len(queries),
+		StuckProblems:     len(stuck),
+	}
+	week := since.Format("2006-01-02")
+	post := &models.BlogPost{
+		Slug:            "knowledge-gaps-" + week,
+		Title:           "Knowledge gaps: week of " + week,
+		Body:            knowledgeGapReportMarkdown(report, stuck),
+		Tags:            []string{"knowledge-gaps"},
+		PostedByType:    models.AuthorTypeHuman,
+		PostedByID:      authorID,
+		MetaDescription: "Recurring unmet needs on Solvr for the week of " + week,
+	}
+	published, err := j.store.PublishReport(ctx, report, post)
+	if err != nil || !published {
+		return nil, err
+	}
+
+	j.emailAdmins(ctx, week, report)
+	return report, nil
+}
+
+// emailAdmins queues the report for every admin. Failures are logged; the report
+// is already published.
+func (j *KnowledgeGapJob) emailAdmins(ctx context.Context, week string, report *models.KnowledgeGapReport) {
+	if j.emailQueue == nil {
+		return
+	}
+	admins, err := j.store.ListAdminRecipients(ctx)
+	if err != nil {
+		log.Printf("Knowledge gap job: failed to list admins: %v", err)
+		return
+	}
+	tpl := services.KnowledgeGapsEmailTemplate(week, report, "https://solvr.dev/blog/"+report.BlogPostSlug)
+	for _, admin := range admins {
+		err := j.emailQueue.Enqueue(ctx, &models.EmailQueueItem{
+			Event:   models.EmailEventKnowledgeGaps,
+			To:      admin.Email,
+			Subject: tpl.Subject,
+			HTML:    tpl.HTML,
+			Text:    tpl.Text,
+		})
+		if err != nil {
+			log.Printf("Knowledge gap job: failed to queue email for %s: %v", admin.ID, err)
+		}
+	}
+}
+
+// knowledgeGapReportMarkdown renders the report as the blog post body.
+func knowledgeGapReportMarkdown(report *models.KnowledgeGapReport, stuck []models.StuckProblemSummary) string {
+	var b strings.Builder
+	b.WriteString(report.Summary)
+	fmt.Fprintf(&b, "\n\n*Based on %d searches that found nothing and %d problems escalated as stuck.*\n",
+		report.ZeroResultQueries, report.StuckProblems)
+
+	for _, g := range report.Gaps {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", g.Title, g.Description)
+		if len(g.Evidence) > 0 {
+			b.WriteString("\nSignals:\n\n")
+			for _, e := range g.Evidence {
+				fmt.Fprintf(&b, "- %s\n", e)
+			}
+		}
+	}
+
+	if len(stuck) > 0 {
+		b.WriteString("\n## Stuck problems\n\nThese problems are waiting for a new angle:\n\n")
+		for _, p := range stuck {
+			fmt.Fprintf(&b, "- [%s](/problems/%s)\n", p.Title, p.ProblemID)
+		}
+	}
+	return b.String()
+}
+
+// RunScheduled runs the knowledge gap job on a schedule.
+// It runs immediately on start, then repeats at the given interval.
+// The job stops when the context is cancelled.
+func (j *KnowledgeGapJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Knowledge gap job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+func (j *KnowledgeGapJob) runAndLog(ctx context.Context) {
+	report, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Knowledge gap job: %v", err)
+		return
+	}
+	if report != nil {
+		log.Printf("Knowledge gap job: published report for week of %s with %d gaps",
+			report.WeekStart.Format("2006-01-02"), len(report.Gaps))
+	}
+}
@@ -0,0 +1,199 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+type mockKnowledgeGapStore struct {
+	exists     bool
+	queries    []models.ZeroResultQuery
+	stuck      []models.StuckProblemSummary
+	authorID   string
+	admins     []models.EmailRecipient
+	since      time.Time
+	until      time.Time
+	report     *models.KnowledgeGapReport
+	post       *models.BlogPost
+	notPublish bool
+}
+
+func (m *mockKnowledgeGapStore) HasReportForWeek(ctx context.Context, weekStart time.Time) (bool, error) {
+	return m.exists, nil
+}
+
+func (m *mockKnowledgeGapStore) ListZeroResultQueries(ctx context.Context, since, until time.Time, limit int) ([]models.ZeroResultQuery, error) {
+	m.since, m.until = since, until
+	return m.queries, nil
+}
+
+func (m *mockKnowledgeGapStore) ListStuckProblems(ctx context.Context, since, until time.Time, limit int) ([]models.StuckProblemSummary, error) {
+	return m.stuck, nil
+}
+
+func (m *mockKnowledgeGapStore) FindReportAuthor(ctx context.Context) (string, error) {
+	return m.authorID, nil
+}
+
+func (m *mockKnowledgeGapStore) ListAdminRecipients(ctx context.Context) ([]models.EmailRecipient, error) {
+	return m.admins, nil
+}
+
+func (m *mockKnowledgeGapStore) PublishReport(ctx context.Context, report *models.KnowledgeGapReport, post *models.BlogPost) (bool, error) {
+	if m.notPublish {
+		return false, nil
+	}
+	report.ID = "report-1"
+	report.BlogPostID = "blog-1"
+	report.BlogPostSlug = post.Slug
+	m.report, m.post = report, post
+	return true, nil
+}
+
+type mockKnowledgeGapSummarizer struct {
+	err   error
+	calls int
+}
+
+func (m *mockKnowledgeGapSummarizer) SummarizeGaps(ctx context.Context, input services.KnowledgeGapInput) (*services.KnowledgeGapSummary, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &services.KnowledgeGapSummary{
+		Summary: "Pooling dominates.",
+		Gaps:    []models.KnowledgeGap{{Title: "pgx pool lifecycle", Description: "No guide.", Evidence: []string{"pgx pool leak"}}},
+	}, nil
+}
+
+type mockEmailQueue struct {
+	items []*models.EmailQueueItem
+}
+
+func (m *mockEmailQueue) Enqueue(ctx context.Context, item *models.EmailQueueItem) error {
+	m.items = append(m.items, item)
+	return nil
+}
+
+// wednesday is in the week starting Monday 2026-10-12, so the last full week
+// starts Monday 2026-10-05.
+var wednesday = time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+func newTestKnowledgeGapJob(store *mockKnowledgeGapStore, summarizer *mockKnowledgeGapSummarizer) *KnowledgeGapJob {
+	job := NewKnowledgeGapJob(store, summarizer)
+	job.now = func() time.Time { return wednesday }
+	return job
+}
+
+func TestKnowledgeGapJob_RunOnce_PublishesAndEmails(t *testing.T) {
+	store := &mockKnowledgeGapStore{
+		queries:  []models.ZeroResultQuery{{Query: "pgx pool leak", Count: 7}},
+		stuck:    []models.StuckProblemSummary{{ProblemID: "p1", Title: "Redis failover", Summary: "Tried sentinel."}},
+		authorID: "admin-1",
+		admins:   []models.EmailRecipient{{ID: "admin-1", Email: "a@solvr.dev"}, {ID: "admin-2", Email: "b@solvr.dev"}},
+	}
+	queue := &mockEmailQueue{}
+	job := newTestKnowledgeGapJob(store, &mockKnowledgeGapSummarizer{})
+	job.SetEmailQueue(queue)
+
+	report, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce() error = %v", err)
+	}
+	if report == nil {
+		t.Fatal("expected a report")
+	}
+
+	wantSince := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	if !store.since.Equal(wantSince) || !store.until.Equal(wantSince.AddDate(0, 0, 7)) {
+		t.Errorf("window = %v..%v, want the week of %v", store.since, store.until, wantSince)
+	}
+	if report.ZeroResultQueries != 1 || report.StuckProblems != 1 || len(report.Gaps) != 1 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if store.post.Slug != "knowledge-gaps-2026-10-05" || store.post.PostedByID != "admin-1" {
+		t.Errorf("unexpected post: slug %q author %q", store.post.Slug, store.post.PostedByID)
+	}
+	if !strings.Contains(store.post.Body, "## pgx pool lifecycle") || !strings.Contains(store.post.Body, "[Redis failover](/problems/p1)") {
+		t.Errorf("unexpected body:\n%s", store.post.Body)
+	}
+	if len(queue.items) != 2 || queue.items[0].Event != models.EmailEventKnowledgeGaps {
+		t.Fatalf("expected 2 knowledge gap emails, got %+v", queue.items)
+	}
+	if !strings.Contains(queue.items[0].Text, "https://solvr.dev/blog/knowledge-gaps-2026-10-05") {
+		t.Error("expected the email to link the blog post")
+	}
+}
+
+func TestKnowledgeGapJob_RunOnce_SkipsExistingWeek(t *testing.T) {
+	store := &mockKnowledgeGapStore{exists: true, queries: []models.ZeroResultQuery{{Query: "x", Count: 1}}, authorID: "admin-1"}
+	summarizer := &mockKnowledgeGapSummarizer{}
+
+	report, err := newTestKnowledgeGapJob(store, summarizer).RunOnce(context.Background())
+	if err != nil || report != nil || summarizer.calls != 0 {
+		t.Errorf("RunOnce() = %v, %v with %d Groq calls; want nothing done", report, err, summarizer.calls)
+	}
+}
+
+func TestKnowledgeGapJob_RunOnce_NothingToReport(t *testing.T) {
+	summarizer := &mockKnowledgeGapSummarizer{}
+
+	// No signals
+	report, err := newTestKnowledgeGapJob(&mockKnowledgeGapStore{authorID: "admin-1"}, summarizer).RunOnce(context.Background())
+	if err != nil || report != nil {
+		t.Errorf("no signals: RunOnce() = %v, %v", report, err)
+	}
+
+	// No admin to publish as
+	store := &mockKnowledgeGapStore{queries: []models.ZeroResultQuery{{Query: "x", Count: 1}}}
+	report, err = newTestKnowledgeGapJob(store, summarizer).RunOnce(context.Background())
+	if err != nil || report != nil {
+		t.Errorf("no admin: RunOnce() = %v, %v", report, err)
+	}
+	if summarizer.calls != 0 {
+		t.Errorf("expected no Groq calls, got %d", summarizer.calls)
+	}
+}
+
+func TestKnowledgeGapJob_RunOnce_Errors(t *testing.T) {
+	store := &mockKnowledgeGapStore{queries: []models.ZeroResultQuery{{Query: "x", Count: 1}}, authorID: "admin-1"}
+	job := newTestKnowledgeGapJob(store, &mockKnowledgeGapSummarizer{err: errors.New("groq down")})
+
+	if _, err := job.RunOnce(context.Background()); err == nil {
+		t.Error("expected summarizer error")
+	}
+	if store.post != nil {
+		t.Error("nothing should be published when Groq fails")
+	}
+
+	// Lost the race to another instance: no email
+	store = &mockKnowledgeGapStore{queries: store.queries, authorID: "admin-1", notPublish: true, admins: []models.EmailRecipient{{Email: "a@solvr.dev"}}}
+	queue := &mockEmailQueue{}
+	job = newTestKnowledgeGapJob(store, &mockKnowledgeGapSummarizer{})
+	job.SetEmailQueue(queue)
+	if report, err := job.RunOnce(context.Background()); err != nil || report != nil || len(queue.items) != 0 {
+		t.Errorf("RunOnce() = %v, %v, %d emails; want nothing", report, err, len(queue.items))
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		in   time.Time
+		want time.Time
+	}{
+		{time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC), time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC), time.Date(2026, 10, 26, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := weekStart(tt.in); !got.Equal(tt.want) {
+			t.Errorf("weekStart(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// MaintenanceNotifier reports the read-only maintenance switch and its changes.
+type MaintenanceNotifier interface {
+	Enabled() bool
+	OnChange(fn func(enabled bool))
+}
+
+// PausableRunner runs scheduled jobs and stops them while maintenance mode is on,
+// so nothing writes during a migration. Jobs are restarted when maintenance mode is
+// switched off; like a normal start, RunScheduled then runs them immediately.
+type PausableRunner struct {
+	mu     sync.Mutex
+	paused bool
+	jobs   []*pausableJob
+}
+
+// pausableJob is one registered job. cancel is nil while the job is stopped.
+type pausableJob struct {
+	name   string
+	parent context.Context
+	run    func(ctx context.Context)
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPausableRunner creates a runner that follows the given maintenance switch.
+func NewPausableRunner(mode MaintenanceNotifier) *PausableRunner {
+	p := &PausableRunner{paused: mode.Enabled()}
+	mode.OnChange(p.setPaused)
+	if p.paused {
+		log.Println("Maintenance mode is on: background jobs start paused")
+	}
+	return p
+}
+
+// Go runs fn in a goroutine until ctx is cancelled, pausing it during maintenance.
+// fn must return when its context is cancelled. name identifies the job for Restart.
+func (p *PausableRunner) Go(ctx context.Context, name string, fn func(ctx context.Context)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job := &pausableJob{name: name, parent: ctx, run: fn}
+	p.jobs = append(p.jobs, job)
+	if !p.paused {
+		job.start()
+	}
+}
+
+// Restart stops the named job and starts it again, so fn re-reads settings such as
+// its interval. Paused jobs stay paused and pick the settings up on resume. Reports
+// whether a job with that name is registered.
+func (p *PausableRunner) Restart(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	found := false
+	for _, job := range p.jobs {
+		if job.name != name {
+			continue
+		}
+		found = true
+		if !p.paused {
+			job.stop()
+			job.start()
+		}
+	}
+	return found
+}
+
+// setPaused stops or restarts every job.
+func (p *PausableRunner) setPaused(paused bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.paused == paused {
+		return
+	}
+	p.paused = paused
+	for _, job := range p.jobs {
+		if paused {
+			job.stop()
+		} else {
+			job.start()
+		}
+	}
+	if paused {
+		log.Printf("Maintenance mode on: paused %d background jobs", len(p.jobs))
+	} else {
+		log.Printf("Maintenance mode off: resumed %d background jobs", len(p.jobs))
+	}
+}
+
+// start runs the job in a new goroutine, after any previous run has returned.
+func (j *pausableJob) start() {
+	if j.cancel != nil || j.parent.Err() != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(j.parent)
+	prev, done := j.done, make(chan struct{})
+	j.cancel, j.done = cancel, done
+	go func() {
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		if ctx.Err() == nil {
+			j.run(ctx)
+		}
+	}()
+}
+
+// stop cancels the running job.
+func (j *pausableJob) stop() {
+	if j.cancel != nil {
+		j.cancel()
+		j.cancel = nil
+	}
+}
@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/maintenance"
+)
+
+// waitFor polls cond until it holds or the deadline passes.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPausableRunner_PausesAndResumes(t *testing.T) {
+	mode := maintenance.New(false, "")
+	runner := NewPausableRunner(mode)
+
+	var starts, running int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner.Go(ctx, "test", func(ctx context.Context) {
+		atomic.AddInt32(&starts, 1)
+		atomic.AddInt32(&running, 1)
+		<-ctx.Done()
+		atomic.AddInt32(&running, -1)
+	})
+	waitFor(t, "job start", func() bool { return atomic.LoadInt32(&running) == 1 })
+
+	mode.Set(true, "")
+	waitFor(t, "job pause", func() bool { return atomic.LoadInt32(&running) == 0 })
+
+	mode.Set(false, "")
+	waitFor(t, "job resume", func() bool { return atomic.LoadInt32(&running) == 1 })
+	if got := atomic.LoadInt32(&starts); got != 2 {
+		t.Errorf("expected 2 starts, got %d", got)
+	}
+
+	cancel()
+	waitFor(t, "job stop", func() bool { return atomic.LoadInt32(&running) == 0 })
+}
+
+func TestPausableRunner_StartsPausedInMaintenance(t *testing.T) {
+	mode := maintenance.New(true, "")
+	runner := NewPausableRunner(mode)
+
+	var starts int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner.Go(ctx, "test", func(ctx context.Context) {
+		atomic.AddInt32(&starts, 1)
+		<-ctx.Done()
+	})
+
+	time.Sleep(20 * time.Millisecond)
+	if atomic.LoadInt32(&starts) != 0 {
+		t.Fatal("expected job not to start during maintenance")
+	}
+	mode.Set(false, "")
+	waitFor(t, "job start", func() bool { return atomic.LoadInt32(&starts) == 1 })
+}
+
+func TestPausableRunner_RestartRerunsNamedJob(t *testing.T) {
+	runner := NewPausableRunner(maintenance.New(false, ""))
+
+	var trendingStarts, otherStarts int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runner.Go(ctx, "trending", func(ctx context.Context) {
+		atomic.AddInt32(&trendingStarts, 1)
+		<-ctx.Done()
+	})
+	runner.Go(ctx, "cleanup", func(ctx context.Context) {
+		atomic.AddInt32(&otherStarts, 1)
+		<-ctx.Done()
+	})
+	waitFor(t, "jobs start", func() bool {
+		return atomic.LoadInt32(&trendingStarts) == 1 && atomic.LoadInt32(&otherStarts) == 1
+	})
+
+	if !runner.Restart("trending") {
+		t.Fatal("expected trending to be registered")
+	}
+	waitFor(t, "trending restart", func() bool { return atomic.LoadInt32(&trendingStarts) == 2 })
+	if got := atomic.LoadInt32(&otherStarts); got != 1 {
+		t.Errorf("expected other job untouched, got %d starts", got)
+	}
+	if runner.Restart("missing") {
+		t.Error("expected unknown job name to report false")
+	}
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultPostCounterReconciliationInterval is how often denormalized post counters are checked for drift.
+const DefaultPostCounterReconciliationInterval = 1 * time.Hour
+
+// PostCounterReconciler repairs drifted answers/approaches/comments counters on posts.
+type PostCounterReconciler interface {
+	ReconcileCounters(ctx context.Context) (int64, error)
+}
+
+// PostCounterReconciliationJob periodically recomputes the trigger-maintained
+// post counters and corrects any drift.
+type PostCounterReconciliationJob struct {
+	reconciler PostCounterReconciler
+}
+
+// NewPostCounterReconciliationJob creates a new PostCounterReconciliationJob.
+func NewPostCounterReconciliationJob(reconciler PostCounterReconciler) *PostCounterReconciliationJob {
+	return &PostCounterReconciliationJob{reconciler: reconciler}
+}
+
+// RunOnce reconciles the counters once. Returns the number of posts corrected.
+func (j *PostCounterReconciliationJob) RunOnce(ctx context.Context) (int64, error) {
+	return j.reconciler.ReconcileCounters(ctx)
+}
+
+// RunScheduled reconciles counters on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *PostCounterReconciliationJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Post counter reconciliation job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs once and logs errors or any drift found.
+// Any correction means some write path bypassed the triggers, so it is worth a log line.
+func (j *PostCounterReconciliationJob) runAndLog(ctx context.Context) {
+	fixed, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Post counter reconciliation failed: %v", err)
+		return
+	}
+	if fixed > 0 {
+		log.Printf("Post counter reconciliation: corrected %d posts with drifted counters", fixed)
+	}
+}
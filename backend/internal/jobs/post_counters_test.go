@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockPostCounterReconciler struct {
+	calls  int
+	result int64
+	err    error
+}
+
+func (m *mockPostCounterReconciler) ReconcileCounters(ctx context.Context) (int64, error) {
+	m.calls++
+	return m.result, m.err
+}
+
+func TestPostCounterReconciliationJob_RunOnce(t *testing.T) {
+	mock := &mockPostCounterReconciler{result: 3}
+	job := NewPostCounterReconciliationJob(mock)
+
+	fixed, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fixed != 3 {
+		t.Errorf("expected 3 corrected posts, got %d", fixed)
+	}
+	if mock.calls != 1 {
+		t.Errorf("expected 1 reconcile call, got %d", mock.calls)
+	}
+}
+
+func TestPostCounterReconciliationJob_RunOnceError(t *testing.T) {
+	mock := &mockPostCounterReconciler{err: errors.New("db down")}
+	job := NewPostCounterReconciliationJob(mock)
+
+	if _, err := job.RunOnce(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestPostCounterReconciliationJob_RunScheduledStopsOnCancel(t *testing.T) {
+	mock := &mockPostCounterReconciler{}
+	job := NewPostCounterReconciliationJob(mock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.RunScheduled(ctx, time.Hour)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunScheduled did not stop after cancel")
+	}
+	if mock.calls != 1 {
+		t.Errorf("expected immediate run on start, got %d calls", mock.calls)
+	}
+}
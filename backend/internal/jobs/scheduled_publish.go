@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Default scheduled publish job configuration.
+const (
+	// DefaultScheduledPublishInterval is how often due scheduled posts are published.
+	DefaultScheduledPublishInterval = 1 * time.Minute
+
+	// DefaultScheduledPublishBatchSize is the max posts published per run.
+	DefaultScheduledPublishBatchSize = 50
+)
+
+// ScheduledPostStore lists and publishes scheduled drafts.
+// Implemented by db.PostRepository.
+type ScheduledPostStore interface {
+	ListDueScheduledPosts(ctx context.Context, limit int) ([]*models.Post, error)
+	PublishScheduledPost(ctx context.Context, postID string, status models.PostStatus) (bool, error)
+}
+
+// PostEmbeddingQueue queues posts for background embedding.
+// Implemented by db.EmbeddingQueueRepository.
+type PostEmbeddingQueue interface {
+	Enqueue(ctx context.Context, postID, reason string) error
+}
+
+// ScheduledPublishJob publishes posts created with a future publish_at once it
+// has passed. Moderation and embedding are deferred until then, so a scheduled
+// write-up is neither reviewed nor searchable before it goes out.
+type ScheduledPublishJob struct {
+	store      ScheduledPostStore
+	trigger    PostModerationTrigger // nil leaves published posts in pending_review
+	embeddings PostEmbeddingQueue    // nil skips embedding
+	batchSize  int
+}
+
+// NewScheduledPublishJob creates a new ScheduledPublishJob. trigger and
+// embeddings may be nil.
+func NewScheduledPublishJob(store ScheduledPostStore, trigger PostModerationTrigger, embeddings PostEmbeddingQueue, batchSize int) *ScheduledPublishJob {
+	return &ScheduledPublishJob{store: store, trigger: trigger, embeddings: embeddings, batchSize: batchSize}
+}
+
+// RunOnce publishes the next batch of due scheduled posts. Public posts move to
+// pending_review and are sent to moderation, as on create; family posts skip
+// moderation and open directly. Returns the number of posts published.
+func (j *ScheduledPublishJob) RunOnce(ctx context.Context) (int, error) {
+	posts, err := j.store.ListDueScheduledPosts(ctx, j.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, post := range posts {
+		status := models.PostStatusPendingReview
+		if post.Visibility == models.VisibilityFamily {
+			status = models.PostStatusOpen
+		}
+
+		ok, err := j.store.PublishScheduledPost(ctx, post.ID, status)
+		if err != nil {
+			log.Printf("Scheduled publish job: failed to publish post %s: %v", post.ID, err)
+			continue
+		}
+		if !ok {
+			continue // deleted or changed by its author since it was listed
+		}
+		published++
+
+		if j.embeddings != nil {
+			if err := j.embeddings.Enqueue(ctx, post.ID, models.EmbeddingQueueReasonScheduled); err != nil {
+				log.Printf("Scheduled publish job: failed to queue embedding for post %s: %v", post.ID, err)
+			}
+		}
+		if j.trigger != nil && status == models.PostStatusPendingReview {
+			j.trigger.TriggerAsync(ctx, post.ID, post.Title, post.Description, post.Tags,
+				string(post.Type), string(post.PostedByType), post.PostedByID)
+		}
+	}
+	return published, nil
+}
+
+// RunScheduled runs the scheduled publish job on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *ScheduledPublishJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Scheduled publish job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+func (j *ScheduledPublishJob) runAndLog(ctx context.Context) {
+	published, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Scheduled publish job failed: %v", err)
+		return
+	}
+	if published > 0 {
+		log.Printf("Scheduled publish job: published %d posts", published)
+	}
+}
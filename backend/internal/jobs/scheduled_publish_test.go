@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockScheduledPostStore struct {
+	posts      []*models.Post
+	listErr    error
+	stale      map[string]bool // post IDs no longer due when published
+	publishErr map[string]error
+	published  map[string]models.PostStatus
+}
+
+func (m *mockScheduledPostStore) ListDueScheduledPosts(ctx context.Context, limit int) ([]*models.Post, error) {
+	return m.posts, m.listErr
+}
+
+func (m *mockScheduledPostStore) PublishScheduledPost(ctx context.Context, postID string, status models.PostStatus) (bool, error) {
+	if err := m.publishErr[postID]; err != nil {
+		return false, err
+	}
+	if m.stale[postID] {
+		return false, nil
+	}
+	if m.published == nil {
+		m.published = map[string]models.PostStatus{}
+	}
+	m.published[postID] = status
+	return true, nil
+}
+
+type mockEmbeddingQueue struct {
+	enqueued map[string]string
+}
+
+func (m *mockEmbeddingQueue) Enqueue(ctx context.Context, postID, reason string) error {
+	if m.enqueued == nil {
+		m.enqueued = map[string]string{}
+	}
+	m.enqueued[postID] = reason
+	return nil
+}
+
+func TestScheduledPublishJob_RunOnce(t *testing.T) {
+	store := &mockScheduledPostStore{
+		posts: []*models.Post{
+			{ID: "public", Title: "Postmortem", Visibility: models.VisibilityPublic},
+			{ID: "family", Title: "Family notes", Visibility: models.VisibilityFamily},
+			{ID: "deleted", Title: "Gone", Visibility: models.VisibilityPublic},
+			{ID: "broken", Title: "Broken", Visibility: models.VisibilityPublic},
+		},
+		stale:      map[string]bool{"deleted": true},
+		publishErr: map[string]error{"broken": errors.New("connection reset")},
+	}
+	trigger := &mockModerationTrigger{}
+	queue := &mockEmbeddingQueue{}
+	job := NewScheduledPublishJob(store, trigger, queue, DefaultScheduledPublishBatchSize)
+
+	published, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published != 2 {
+		t.Errorf("expected 2 published, got %d", published)
+	}
+	if store.published["public"] != models.PostStatusPendingReview {
+		t.Errorf("expected public post in pending_review, got %q", store.published["public"])
+	}
+	if store.published["family"] != models.PostStatusOpen {
+		t.Errorf("expected family post open, got %q", store.published["family"])
+	}
+	if len(trigger.triggered) != 1 || trigger.triggered[0].postID != "public" {
+		t.Errorf("expected moderation for the public post only, got %+v", trigger.triggered)
+	}
+	if len(queue.enqueued) != 2 || queue.enqueued["family"] != models.EmbeddingQueueReasonScheduled {
+		t.Errorf("expected embeddings queued for published posts, got %+v", queue.enqueued)
+	}
+}
+
+func TestScheduledPublishJob_RunOnce_NoTrigger(t *testing.T) {
+	store := &mockScheduledPostStore{posts: []*models.Post{{ID: "p1", Visibility: models.VisibilityPublic}}}
+	job := NewScheduledPublishJob(store, nil, nil, DefaultScheduledPublishBatchSize)
+
+	published, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published != 1 || store.published["p1"] != models.PostStatusPendingReview {
+		t.Errorf("expected post published to pending_review, got %d %+v", published, store.published)
+	}
+}
+
+func TestScheduledPublishJob_RunOnce_ListError(t *testing.T) {
+	store := &mockScheduledPostStore{listErr: errors.New("db down")}
+	job := NewScheduledPublishJob(store, nil, nil, DefaultScheduledPublishBatchSize)
+
+	if _, err := job.RunOnce(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
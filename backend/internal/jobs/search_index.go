@@ -0,0 +1,211 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Default search index job configuration.
+const (
+	// DefaultSearchIndexInterval is how often the search index catches up with the change event log.
+	DefaultSearchIndexInterval = 30 * time.Second
+
+	// DefaultSearchIndexBatchSize is the max change events (or posts, when rebuilding) per batch.
+	DefaultSearchIndexBatchSize = 500
+)
+
+// searchIndexEntities are the change event entities an external search index holds.
+var searchIndexEntities = []string{"post", "answer", "approach"}
+
+// SearchDocumentStore loads documents to index and stores the indexer's position.
+// Implemented by db.SearchDocumentRepository.
+type SearchDocumentStore interface {
+	LoadDocuments(ctx context.Context, postIDs, answerIDs, approachIDs []string) ([]models.SearchDocument, error)
+	ListPostIDs(ctx context.Context, afterID string, limit int) ([]string, error)
+	GetCursor(ctx context.Context, name string) (int64, bool, error)
+	SetCursor(ctx context.Context, name string, seq int64) error
+}
+
+// ChangeEventSource reads the change event log.
+// Implemented by db.ChangeEventRepository.
+type ChangeEventSource interface {
+	ListSince(ctx context.Context, since int64, entities []string, limit int) ([]models.ChangeEvent, error)
+	LatestSeq(ctx context.Context) (int64, error)
+}
+
+// SearchIndexWriter writes documents to an external search index.
+// Implemented by services.OpenSearchBackend.
+type SearchIndexWriter interface {
+	EnsureIndex(ctx context.Context) error
+	Index(ctx context.Context, docs []models.SearchDocument, deleteIDs []string) error
+}
+
+// SearchIndexJob keeps an external search index in sync with the database. On
+// its first run it indexes every post with its answers and approaches; after
+// that it applies the post, answer and approach events of the change event log.
+// Changed rows are reloaded rather than taken from event payloads, so the index
+// gets author names, parent tags and visibility, and drops rows that were
+// deleted, hidden or unpublished.
+type SearchIndexJob struct {
+	store     SearchDocumentStore
+	events    ChangeEventSource
+	index     SearchIndexWriter
+	name      string // cursor name
+	batchSize int
+}
+
+// NewSearchIndexJob creates a new SearchIndexJob. name identifies the index's
+// cursor; a new name rebuilds the index from the tables.
+func NewSearchIndexJob(store SearchDocumentStore, events ChangeEventSource, index SearchIndexWriter, name string, batchSize int) *SearchIndexJob {
+	if batchSize <= 0 {
+		batchSize = DefaultSearchIndexBatchSize
+	}
+	return &SearchIndexJob{store: store, events: events, index: index, name: name, batchSize: batchSize}
+}
+
+// RunOnce rebuilds the index if it has no cursor, then applies pending change
+// events. Returns the number of events (or posts, when rebuilding) applied.
+func (j *SearchIndexJob) RunOnce(ctx context.Context) (int, error) {
+	if err := j.index.EnsureIndex(ctx); err != nil {
+		return 0, err
+	}
+	since, ok, err := j.store.GetCursor(ctx, j.name)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return j.rebuild(ctx)
+	}
+
+	applied := 0
+	for {
+		events, err := j.events.ListSince(ctx, since, searchIndexEntities, j.batchSize)
+		if err != nil {
+			return applied, err
+		}
+		if len(events) == 0 {
+			return applied, nil
+		}
+
+		var postIDs, answerIDs, approachIDs []string
+		seen := make(map[string]bool, len(events))
+		for _, e := range events {
+			key := models.SearchDocumentID(e.Entity, e.EntityID)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			switch e.Entity {
+			case "post":
+				postIDs = append(postIDs, e.EntityID)
+			case "answer":
+				answerIDs = append(answerIDs, e.EntityID)
+			case "approach":
+				approachIDs = append(approachIDs, e.EntityID)
+			}
+		}
+		if err := j.sync(ctx, seen, postIDs, answerIDs, approachIDs); err != nil {
+			return applied, err
+		}
+
+		since = events[len(events)-1].Seq
+		if err := j.store.SetCursor(ctx, j.name, since); err != nil {
+			return applied, err
+		}
+		applied += len(events)
+		if len(events) < j.batchSize {
+			return applied, nil
+		}
+	}
+}
+
+// rebuild indexes every post with its answers and approaches. The cursor is
+// taken first, so changes made during the rebuild are applied by the next run.
+func (j *SearchIndexJob) rebuild(ctx context.Context) (int, error) {
+	start, err := j.events.LatestSeq(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	after := ""
+	for {
+		postIDs, err := j.store.ListPostIDs(ctx, after, j.batchSize)
+		if err != nil {
+			return indexed, err
+		}
+		if len(postIDs) == 0 {
+			break
+		}
+		requested := make(map[string]bool, len(postIDs))
+		for _, id := range postIDs {
+			requested[models.SearchDocumentID("post", id)] = true
+		}
+		if err := j.sync(ctx, requested, postIDs, nil, nil); err != nil {
+			return indexed, err
+		}
+		indexed += len(postIDs)
+		after = postIDs[len(postIDs)-1]
+		if len(postIDs) < j.batchSize {
+			break
+		}
+	}
+
+	if err := j.store.SetCursor(ctx, j.name, start); err != nil {
+		return indexed, err
+	}
+	return indexed, nil
+}
+
+// sync reloads the given rows and writes them to the index. Requested rows
+// (keyed by models.SearchDocumentID) that are no longer searchable are removed.
+func (j *SearchIndexJob) sync(ctx context.Context, requested map[string]bool, postIDs, answerIDs, approachIDs []string) error {
+	docs, err := j.store.LoadDocuments(ctx, postIDs, answerIDs, approachIDs)
+	if err != nil {
+		return err
+	}
+	loaded := make(map[string]bool, len(docs))
+	for i := range docs {
+		loaded[docs[i].DocumentID()] = true
+	}
+	var deleteIDs []string
+	for key := range requested {
+		if !loaded[key] {
+			deleteIDs = append(deleteIDs, key)
+		}
+	}
+	return j.index.Index(ctx, docs, deleteIDs)
+}
+
+// RunScheduled runs the search index job on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *SearchIndexJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Search index job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+func (j *SearchIndexJob) runAndLog(ctx context.Context) {
+	applied, err := j.RunOnce(ctx)
+	if err != nil {
+		log.Printf("Search index job failed: %v", err)
+		return
+	}
+	if applied > 0 {
+		log.Printf("Search index job: applied %d changes", applied)
+	}
+}
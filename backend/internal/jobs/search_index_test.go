@@ -0,0 +1,185 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockSearchDocumentStore struct {
+	docs      map[string]models.SearchDocument // by SearchDocumentID
+	postIDs   []string
+	cursor    int64
+	hasCursor bool
+	loads     [][]string
+}
+
+func (m *mockSearchDocumentStore) LoadDocuments(ctx context.Context, postIDs, answerIDs, approachIDs []string) ([]models.SearchDocument, error) {
+	var requested []string
+	for _, id := range postIDs {
+		requested = append(requested, models.SearchDocumentID("post", id))
+	}
+	for _, id := range answerIDs {
+		requested = append(requested, models.SearchDocumentID("answer", id))
+	}
+	for _, id := range approachIDs {
+		requested = append(requested, models.SearchDocumentID("approach", id))
+	}
+	m.loads = append(m.loads, requested)
+	var docs []models.SearchDocument
+	for _, key := range requested {
+		if d, ok := m.docs[key]; ok {
+			docs = append(docs, d)
+		}
+	}
+	return docs, nil
+}
+
+func (m *mockSearchDocumentStore) ListPostIDs(ctx context.Context, afterID string, limit int) ([]string, error) {
+	var ids []string
+	for _, id := range m.postIDs {
+		if id > afterID && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockSearchDocumentStore) GetCursor(ctx context.Context, name string) (int64, bool, error) {
+	return m.cursor, m.hasCursor, nil
+}
+
+func (m *mockSearchDocumentStore) SetCursor(ctx context.Context, name string, seq int64) error {
+	m.cursor, m.hasCursor = seq, true
+	return nil
+}
+
+type mockChangeEventSource struct {
+	events []models.ChangeEvent
+	err    error
+}
+
+func (m *mockChangeEventSource) ListSince(ctx context.Context, since int64, entities []string, limit int) ([]models.ChangeEvent, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var out []models.ChangeEvent
+	for _, e := range m.events {
+		if e.Seq > since && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockChangeEventSource) LatestSeq(ctx context.Context) (int64, error) {
+	if len(m.events) == 0 {
+		return 0, nil
+	}
+	return m.events[len(m.events)-1].Seq, nil
+}
+
+type mockSearchIndexWriter struct {
+	indexed []string
+	deleted []string
+}
+
+func (m *mockSearchIndexWriter) EnsureIndex(ctx context.Context) error { return nil }
+
+func (m *mockSearchIndexWriter) Index(ctx context.Context, docs []models.SearchDocument, deleteIDs []string) error {
+	for _, d := range docs {
+		m.indexed = append(m.indexed, d.DocumentID())
+	}
+	m.deleted = append(m.deleted, deleteIDs...)
+	return nil
+}
+
+func TestSearchIndexJob_RebuildsWithoutCursor(t *testing.T) {
+	store := &mockSearchDocumentStore{
+		postIDs: []string{"p1", "p2", "p3"},
+		docs: map[string]models.SearchDocument{
+			"post:p1": {Source: "post", ID: "p1"},
+			"post:p3": {Source: "post", ID: "p3"},
+		},
+	}
+	events := &mockChangeEventSource{events: []models.ChangeEvent{{Seq: 7, Entity: "post", EntityID: "p1"}}}
+	index := &mockSearchIndexWriter{}
+	job := NewSearchIndexJob(store, events, index, "opensearch/solvr", 2)
+
+	n, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 posts rebuilt, got %d", n)
+	}
+	if !reflect.DeepEqual(index.indexed, []string{"post:p1", "post:p3"}) {
+		t.Errorf("indexed %v", index.indexed)
+	}
+	if !reflect.DeepEqual(index.deleted, []string{"post:p2"}) {
+		t.Errorf("deleted %v, want the unsearchable post", index.deleted)
+	}
+	// The rebuild starts from the log position taken before it.
+	if !store.hasCursor || store.cursor != 7 {
+		t.Errorf("cursor = %d (set %v), want 7", store.cursor, store.hasCursor)
+	}
+}
+
+func TestSearchIndexJob_AppliesChangeEvents(t *testing.T) {
+	store := &mockSearchDocumentStore{
+		hasCursor: true,
+		cursor:    1,
+		docs: map[string]models.SearchDocument{
+			"post:p1":   {Source: "post", ID: "p1"},
+			"answer:a1": {Source: "answer", ID: "a1"},
+		},
+	}
+	events := &mockChangeEventSource{events: []models.ChangeEvent{
+		{Seq: 1, Entity: "post", EntityID: "p0"},
+		{Seq: 2, Entity: "post", EntityID: "p1"},
+		{Seq: 3, Entity: "answer", EntityID: "a1"},
+		{Seq: 4, Entity: "post", EntityID: "p1"},
+		{Seq: 5, Entity: "approach", EntityID: "x1"},
+	}}
+	index := &mockSearchIndexWriter{}
+	job := NewSearchIndexJob(store, events, index, "opensearch/solvr", 3)
+
+	n, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("expected 4 events applied, got %d", n)
+	}
+	sort.Strings(index.indexed)
+	if !reflect.DeepEqual(index.indexed, []string{"answer:a1", "post:p1"}) {
+		t.Errorf("indexed %v", index.indexed)
+	}
+	if !reflect.DeepEqual(index.deleted, []string{"approach:x1"}) {
+		t.Errorf("deleted %v, want the removed approach", index.deleted)
+	}
+	// A post changed twice in one batch is loaded once.
+	if got := store.loads[0]; !reflect.DeepEqual(got, []string{"post:p1", "answer:a1"}) {
+		t.Errorf("first batch loaded %v", got)
+	}
+	if store.cursor != 5 {
+		t.Errorf("cursor = %d, want 5", store.cursor)
+	}
+}
+
+func TestSearchIndexJob_KeepsCursorOnError(t *testing.T) {
+	store := &mockSearchDocumentStore{hasCursor: true, cursor: 4}
+	events := &mockChangeEventSource{err: errors.New("db down")}
+	job := NewSearchIndexJob(store, events, &mockSearchIndexWriter{}, "opensearch/solvr", 0)
+
+	if _, err := job.RunOnce(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	if store.cursor != 4 {
+		t.Errorf("cursor moved to %d on error", store.cursor)
+	}
+}
@@ -3,8 +3,13 @@ package jobs
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 // Default stale content job configuration values.
@@ -21,18 +26,24 @@ const (
 	// a warning notification is sent (23 days — 7 days before abandonment).
 	DefaultWarningThreshold = 23 * 24 * time.Hour
 
+	// DefaultReminderThreshold is how long an approach must be stale before
+	// the reminder notification is sent (27 days — 3 days before abandonment).
+	DefaultReminderThreshold = 27 * 24 * time.Hour
+
 	// DefaultStaleContentInterval is how often the stale content scan runs.
 	DefaultStaleContentInterval = 24 * time.Hour
 )
 
 // StaleApproachUpdater abandons approaches that have been stale too long.
 type StaleApproachUpdater interface {
-	AbandonStaleApproaches(ctx context.Context, olderThan time.Duration) (int64, error)
+	AbandonStaleApproaches(ctx context.Context, policy models.StaleApproachPolicy) (int64, error)
 }
 
-// StaleApproachWarner sends warning notifications for approaches approaching abandonment.
+// StaleApproachWarner sends the warning and reminder notifications for
+// approaches approaching abandonment.
 type StaleApproachWarner interface {
-	WarnApproachesApproachingAbandonment(ctx context.Context, warningThreshold, abandonThreshold time.Duration) (int64, error)
+	WarnApproachesApproachingAbandonment(ctx context.Context, policy models.StaleApproachPolicy) (int64, error)
+	RemindApproachesApproachingAbandonment(ctx context.Context, policy models.StaleApproachPolicy) (int64, error)
 }
 
 // DormantPostUpdater marks open problems with no approaches as dormant.
@@ -44,17 +55,22 @@ type DormantPostUpdater interface {
 type StaleContentResult struct {
 	Abandoned int64
 	Warned    int64
+	Reminded  int64
 	Dormant   int64
 }
 
 // StaleContentJob handles periodic cleanup of stale content:
-// 1. Warns approach authors 7 days before auto-abandonment
+// 1. Warns approach authors 7 days before auto-abandonment, and reminds them 3 days before
 // 2. Abandons approaches in 'working'/'starting' status for 30+ days
 // 3. Marks open problems with zero approaches as dormant after 60 days
+// Approach thresholds can differ by problem weight (see SetApproachPolicy), and
+// approaches marked long-running are never warned about or abandoned. Problems
+// under legal hold or retention are left alone entirely.
 type StaleContentJob struct {
 	updater StaleApproachUpdater
 	warner  StaleApproachWarner
 	dormant DormantPostUpdater
+	policy  models.StaleApproachPolicy
 }
 
 // NewStaleContentJob creates a new stale content cleanup job.
@@ -67,36 +83,114 @@ func NewStaleContentJob(
 		updater: updater,
 		warner:  warner,
 		dormant: dormant,
+		policy:  DefaultStaleApproachPolicy(),
+	}
+}
+
+// SetApproachPolicy replaces the default approach warning, reminder and abandon
+// thresholds.
+func (j *StaleContentJob) SetApproachPolicy(policy models.StaleApproachPolicy) {
+	j.policy = policy
+}
+
+// DefaultStaleApproachPolicy warns at 23 days, reminds at 27 days and abandons at
+// 30 days for problems of every weight.
+func DefaultStaleApproachPolicy() models.StaleApproachPolicy {
+	return models.StaleApproachPolicy{
+		Default: models.StaleApproachThresholds{
+			Warning:  DefaultWarningThreshold,
+			Reminder: DefaultReminderThreshold,
+			Abandon:  DefaultStaleApproachThreshold,
+		},
+	}
+}
+
+// ParseStaleApproachPolicy parses per-weight approach thresholds in days
+// (STALE_APPROACH_THRESHOLDS, e.g. "default=23/27/30,5=40/50/60") on top of
+// DefaultStaleApproachPolicy. Each entry is a problem weight (1-5) or "default",
+// then warning/reminder/abandon days, which must increase.
+func ParseStaleApproachPolicy(spec string) (models.StaleApproachPolicy, error) {
+	policy := DefaultStaleApproachPolicy()
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return policy, fmt.Errorf("invalid STALE_APPROACH_THRESHOLDS entry %q: want weight=warning/reminder/abandon", part)
+		}
+
+		var days [3]int
+		fields := strings.Split(value, "/")
+		if len(fields) != len(days) {
+			return policy, fmt.Errorf("invalid STALE_APPROACH_THRESHOLDS days for %s: %q", key, value)
+		}
+		for i, f := range fields {
+			n, err := strconv.Atoi(strings.TrimSpace(f))
+			if err != nil || n <= 0 || (i > 0 && n <= days[i-1]) {
+				return policy, fmt.Errorf("invalid STALE_APPROACH_THRESHOLDS days for %s: %q", key, value)
+			}
+			days[i] = n
+		}
+		thresholds := models.StaleApproachThresholds{
+			Warning:  time.Duration(days[0]) * 24 * time.Hour,
+			Reminder: time.Duration(days[1]) * 24 * time.Hour,
+			Abandon:  time.Duration(days[2]) * 24 * time.Hour,
+		}
+
+		if key == "default" {
+			policy.Default = thresholds
+			continue
+		}
+		weight, err := strconv.Atoi(key)
+		if err != nil || weight < 1 || weight > 5 {
+			return policy, fmt.Errorf("invalid STALE_APPROACH_THRESHOLDS weight %q: want 1-5 or default", key)
+		}
+		if policy.ByWeight == nil {
+			policy.ByWeight = map[int]models.StaleApproachThresholds{}
+		}
+		policy.ByWeight[weight] = thresholds
 	}
+	return policy, nil
 }
 
 // RunOnce executes the stale content cleanup steps in order:
-// 1. Send warnings for approaches approaching abandonment (23-30 days stale)
-// 2. Abandon approaches that are 30+ days stale
-// 3. Mark dormant problems that are 60+ days old with zero approaches
+// 1. Send warnings for approaches approaching abandonment (23-27 days stale)
+// 2. Send reminders for approaches about to be abandoned (27-30 days stale)
+// 3. Abandon approaches that are 30+ days stale
+// 4. Mark dormant problems that are 60+ days old with zero approaches
+// Approach ages are the default policy's; see SetApproachPolicy.
 // Each step is independent — errors in one step do not prevent others.
 func (j *StaleContentJob) RunOnce(ctx context.Context) StaleContentResult {
 	var result StaleContentResult
 
-	// Step 1: Send warning notifications (23-30 day window)
-	warned, err := j.warner.WarnApproachesApproachingAbandonment(
-		ctx, DefaultWarningThreshold, DefaultStaleApproachThreshold,
-	)
+	// Step 1: Send warning notifications
+	warned, err := j.warner.WarnApproachesApproachingAbandonment(ctx, j.policy)
 	if err != nil {
 		log.Printf("Stale content job: failed to send warnings: %v", err)
 	} else {
 		result.Warned = warned
 	}
 
-	// Step 2: Abandon stale approaches (30+ days)
-	abandoned, err := j.updater.AbandonStaleApproaches(ctx, DefaultStaleApproachThreshold)
+	// Step 2: Send reminder notifications
+	reminded, err := j.warner.RemindApproachesApproachingAbandonment(ctx, j.policy)
+	if err != nil {
+		log.Printf("Stale content job: failed to send reminders: %v", err)
+	} else {
+		result.Reminded = reminded
+	}
+
+	// Step 3: Abandon stale approaches
+	abandoned, err := j.updater.AbandonStaleApproaches(ctx, j.policy)
 	if err != nil {
 		log.Printf("Stale content job: failed to abandon approaches: %v", err)
 	} else {
 		result.Abandoned = abandoned
 	}
 
-	// Step 3: Mark dormant posts (60+ days, no approaches)
+	// Step 4: Mark dormant posts (60+ days, no approaches)
 	dormant, err := j.dormant.MarkDormantPosts(ctx, DefaultDormantPostThreshold)
 	if err != nil {
 		log.Printf("Stale content job: failed to mark dormant posts: %v", err)
@@ -131,8 +225,8 @@ func (j *StaleContentJob) RunScheduled(ctx context.Context, interval time.Durati
 }
 
 func logStaleContentResult(result StaleContentResult) {
-	if result.Warned > 0 || result.Abandoned > 0 || result.Dormant > 0 {
-		log.Printf("Stale content job: %d warned, %d abandoned, %d dormant",
-			result.Warned, result.Abandoned, result.Dormant)
+	if result.Warned > 0 || result.Reminded > 0 || result.Abandoned > 0 || result.Dormant > 0 {
+		log.Printf("Stale content job: %d warned, %d reminded, %d abandoned, %d dormant",
+			result.Warned, result.Reminded, result.Abandoned, result.Dormant)
 	}
 }
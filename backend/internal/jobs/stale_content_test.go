@@ -5,15 +5,19 @@ import (
 	"errors"
 	"testing"
 	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 // mockStaleApproachUpdater implements StaleApproachUpdater for testing.
 type mockStaleApproachUpdater struct {
 	abandonedCount int64
 	err            error
+	policy         models.StaleApproachPolicy
 }
 
-func (m *mockStaleApproachUpdater) AbandonStaleApproaches(ctx context.Context, olderThan time.Duration) (int64, error) {
+func (m *mockStaleApproachUpdater) AbandonStaleApproaches(ctx context.Context, policy models.StaleApproachPolicy) (int64, error) {
+	m.policy = policy
 	if m.err != nil {
 		return 0, m.err
 	}
@@ -22,17 +26,25 @@ func (m *mockStaleApproachUpdater) AbandonStaleApproaches(ctx context.Context, o
 
 // mockStaleApproachWarner implements StaleApproachWarner for testing.
 type mockStaleApproachWarner struct {
-	warnedCount int64
-	err         error
+	warnedCount   int64
+	remindedCount int64
+	err           error
 }
 
-func (m *mockStaleApproachWarner) WarnApproachesApproachingAbandonment(ctx context.Context, warningThreshold, abandonThreshold time.Duration) (int64, error) {
+func (m *mockStaleApproachWarner) WarnApproachesApproachingAbandonment(ctx context.Context, policy models.StaleApproachPolicy) (int64, error) {
 	if m.err != nil {
 		return 0, m.err
 	}
 	return m.warnedCount, nil
 }
 
+func (m *mockStaleApproachWarner) RemindApproachesApproachingAbandonment(ctx context.Context, policy models.StaleApproachPolicy) (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.remindedCount, nil
+}
+
 // mockDormantPostUpdater implements DormantPostUpdater for testing.
 type mockDormantPostUpdater struct {
 	dormantCount int64
@@ -83,6 +95,74 @@ func TestStaleContentJob_WarnBeforeAbandonment(t *testing.T) {
 	}
 }
 
+// TestStaleContentJob_RemindBeforeAbandonment tests that RunOnce sends the second-tier reminders.
+func TestStaleContentJob_RemindBeforeAbandonment(t *testing.T) {
+	updater := &mockStaleApproachUpdater{}
+	warner := &mockStaleApproachWarner{warnedCount: 1, remindedCount: 2}
+	dormant := &mockDormantPostUpdater{}
+
+	job := NewStaleContentJob(updater, warner, dormant)
+	result := job.RunOnce(context.Background())
+
+	if result.Warned != 1 || result.Reminded != 2 {
+		t.Errorf("RunOnce() warned = %d, reminded = %d, want 1 and 2", result.Warned, result.Reminded)
+	}
+}
+
+// TestStaleContentJob_ApproachPolicy tests that the configured policy reaches the repository.
+func TestStaleContentJob_ApproachPolicy(t *testing.T) {
+	updater := &mockStaleApproachUpdater{}
+	job := NewStaleContentJob(updater, &mockStaleApproachWarner{}, &mockDormantPostUpdater{})
+
+	job.RunOnce(context.Background())
+	if updater.policy.For(5).Abandon != DefaultStaleApproachThreshold {
+		t.Errorf("default policy abandon = %v, want %v", updater.policy.For(5).Abandon, DefaultStaleApproachThreshold)
+	}
+
+	policy, err := ParseStaleApproachPolicy("5=40/50/60")
+	if err != nil {
+		t.Fatalf("ParseStaleApproachPolicy() error = %v", err)
+	}
+	job.SetApproachPolicy(policy)
+	job.RunOnce(context.Background())
+	if updater.policy.For(5).Abandon != 60*24*time.Hour || updater.policy.For(3).Abandon != DefaultStaleApproachThreshold {
+		t.Errorf("unexpected policy: %+v", updater.policy)
+	}
+}
+
+func TestParseStaleApproachPolicy(t *testing.T) {
+	day := 24 * time.Hour
+
+	policy, err := ParseStaleApproachPolicy(" default=20/25/28 , 4=30/40/45,5=60/75/90")
+	if err != nil {
+		t.Fatalf("ParseStaleApproachPolicy() error = %v", err)
+	}
+	tests := []struct {
+		weight int
+		want   models.StaleApproachThresholds
+	}{
+		{0, models.StaleApproachThresholds{Warning: 20 * day, Reminder: 25 * day, Abandon: 28 * day}},
+		{3, models.StaleApproachThresholds{Warning: 20 * day, Reminder: 25 * day, Abandon: 28 * day}},
+		{4, models.StaleApproachThresholds{Warning: 30 * day, Reminder: 40 * day, Abandon: 45 * day}},
+		{5, models.StaleApproachThresholds{Warning: 60 * day, Reminder: 75 * day, Abandon: 90 * day}},
+	}
+	for _, tt := range tests {
+		if got := policy.For(tt.weight); got != tt.want {
+			t.Errorf("For(%d) = %+v, want %+v", tt.weight, got, tt.want)
+		}
+	}
+
+	if policy, err := ParseStaleApproachPolicy(""); err != nil || policy.For(1) != DefaultStaleApproachPolicy().Default {
+		t.Errorf("empty spec = %+v, %v; want the default policy", policy, err)
+	}
+
+	for _, spec := range []string{"5", "6=1/2/3", "heavy=1/2/3", "5=1/2", "5=3/2/1", "5=0/2/3", "5=a/b/c"} {
+		if _, err := ParseStaleApproachPolicy(spec); err == nil {
+			t.Errorf("ParseStaleApproachPolicy(%q) expected error", spec)
+		}
+	}
+}
+
 // TestStaleContentJob_MarkDormant tests that RunOnce marks dormant posts.
 func TestStaleContentJob_MarkDormant(t *testing.T) {
 	updater := &mockStaleApproachUpdater{abandonedCount: 0}
@@ -180,6 +260,9 @@ func TestStaleContentJob_DefaultConstants(t *testing.T) {
 	if DefaultWarningThreshold != 23*24*time.Hour {
 		t.Errorf("DefaultWarningThreshold = %v, want 23 days", DefaultWarningThreshold)
 	}
+	if DefaultReminderThreshold != 27*24*time.Hour {
+		t.Errorf("DefaultReminderThreshold = %v, want 27 days", DefaultReminderThreshold)
+	}
 	if DefaultStaleContentInterval != 24*time.Hour {
 		t.Errorf("DefaultStaleContentInterval = %v, want 24 hours", DefaultStaleContentInterval)
 	}
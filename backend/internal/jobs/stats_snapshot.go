@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultStatsSnapshotInterval is how often today's site-wide stats snapshot is refreshed.
+const DefaultStatsSnapshotInterval = time.Hour
+
+// StatsSnapshotter records a snapshot of site-wide totals.
+// Implemented by db.StatsRepository.
+type StatsSnapshotter interface {
+	SnapshotDailyStats(ctx context.Context) (int64, error)
+}
+
+// StatsSnapshotJob periodically writes today's site-wide totals to stats_daily,
+// which backs GET /v1/stats/history. Re-running within a day overwrites that
+// day's snapshot, so each day ends up with its last value.
+type StatsSnapshotJob struct {
+	snapshotter StatsSnapshotter
+}
+
+// NewStatsSnapshotJob creates a new StatsSnapshotJob.
+func NewStatsSnapshotJob(snapshotter StatsSnapshotter) *StatsSnapshotJob {
+	return &StatsSnapshotJob{snapshotter: snapshotter}
+}
+
+// RunOnce snapshots today's stats once. Returns the number of metrics written.
+func (j *StatsSnapshotJob) RunOnce(ctx context.Context) (int64, error) {
+	return j.snapshotter.SnapshotDailyStats(ctx)
+}
+
+// RunScheduled runs the stats snapshot job on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *StatsSnapshotJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Stats snapshot job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+func (j *StatsSnapshotJob) runAndLog(ctx context.Context) {
+	if _, err := j.RunOnce(ctx); err != nil {
+		log.Printf("Stats snapshot failed: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockStatsSnapshotter struct {
+	calls int
+	err   error
+}
+
+func (m *mockStatsSnapshotter) SnapshotDailyStats(ctx context.Context) (int64, error) {
+	m.calls++
+	if m.err != nil {
+		return 0, m.err
+	}
+	return 12, nil
+}
+
+func TestStatsSnapshotJob_RunOnce(t *testing.T) {
+	mock := &mockStatsSnapshotter{}
+	job := NewStatsSnapshotJob(mock)
+
+	written, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if written != 12 || mock.calls != 1 {
+		t.Errorf("expected 12 metrics written in one call, got %d in %d calls", written, mock.calls)
+	}
+}
+
+func TestStatsSnapshotJob_RunOnceError(t *testing.T) {
+	job := NewStatsSnapshotJob(&mockStatsSnapshotter{err: errors.New("db down")})
+
+	if _, err := job.RunOnce(context.Background()); err == nil {
+		t.Error("expected error from snapshotter")
+	}
+}
+
+func TestStatsSnapshotJob_RunScheduledStopsOnCancel(t *testing.T) {
+	mock := &mockStatsSnapshotter{}
+	job := NewStatsSnapshotJob(mock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.RunScheduled(ctx, time.Hour)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunScheduled did not stop after cancel")
+	}
+	if mock.calls != 1 {
+		t.Errorf("expected immediate run on start, got %d calls", mock.calls)
+	}
+}
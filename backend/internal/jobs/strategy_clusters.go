@@ -0,0 +1,230 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Default strategy clustering job configuration.
+const (
+	// DefaultStrategyClusterInterval is how often changed problems are re-clustered.
+	DefaultStrategyClusterInterval = 30 * time.Minute
+
+	// DefaultStrategyClusterBatchSize is the max problems clustered per run.
+	DefaultStrategyClusterBatchSize = 100
+
+	// DefaultStrategySimilarityThreshold is the cosine similarity above which two
+	// groups of approaches count as the same strategy. Approaches rewording the same
+	// angle score above it; different angles on the same problem score below.
+	DefaultStrategySimilarityThreshold = 0.82
+)
+
+// StrategyClusterStore lists problems to cluster, reads their approach embeddings
+// and stores the clusters. Implemented by db.StrategyClustersRepository.
+type StrategyClusterStore interface {
+	ListProblemsNeedingClustering(ctx context.Context, limit int) ([]string, error)
+	ListApproachEmbeddings(ctx context.Context, problemID string) ([]models.ApproachEmbedding, error)
+	SaveStrategyClusters(ctx context.Context, problemID string, assignments []models.StrategyAssignment, threshold float64) error
+}
+
+// StrategyClusterJob groups each problem's approaches into strategy clusters, so
+// GET /v1/problems/{id}/insights can show how many fundamentally different angles
+// were tried and which directions are still unexplored.
+type StrategyClusterJob struct {
+	store     StrategyClusterStore
+	batchSize int
+	threshold float64
+}
+
+// NewStrategyClusterJob creates a new StrategyClusterJob.
+func NewStrategyClusterJob(store StrategyClusterStore, batchSize int, threshold float64) *StrategyClusterJob {
+	return &StrategyClusterJob{store: store, batchSize: batchSize, threshold: threshold}
+}
+
+// RunOnce re-clusters the next batch of changed problems.
+// Returns the number of problems clustered and failed.
+func (j *StrategyClusterJob) RunOnce(ctx context.Context) (clustered, failed int) {
+	problemIDs, err := j.store.ListProblemsNeedingClustering(ctx, j.batchSize)
+	if err != nil {
+		log.Printf("Strategy cluster job: failed to list problems: %v", err)
+		return 0, 0
+	}
+
+	for _, problemID := range problemIDs {
+		if ctx.Err() != nil {
+			break
+		}
+		embeddings, err := j.store.ListApproachEmbeddings(ctx, problemID)
+		if err != nil {
+			log.Printf("Strategy cluster job: failed to load approaches of %s: %v", problemID, err)
+			failed++
+			continue
+		}
+		assignments := clusterApproaches(embeddings, j.threshold)
+		if err := j.store.SaveStrategyClusters(ctx, problemID, assignments, j.threshold); err != nil {
+			log.Printf("Strategy cluster job: failed to save clusters of %s: %v", problemID, err)
+			failed++
+			continue
+		}
+		clustered++
+	}
+	return clustered, failed
+}
+
+// RunScheduled runs the strategy clustering job on a schedule.
+// It runs immediately on start, then repeats at the given interval.
+// The job stops when the context is cancelled.
+func (j *StrategyClusterJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Strategy cluster job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+func (j *StrategyClusterJob) runAndLog(ctx context.Context) {
+	clustered, failed := j.RunOnce(ctx)
+	if clustered > 0 || failed > 0 {
+		log.Printf("Strategy cluster job: %d problems clustered, %d failed", clustered, failed)
+	}
+}
+
+// clusterApproaches groups approaches by average-linkage agglomerative clustering
+// on cosine similarity: the two most similar clusters merge until no pair is more
+// similar than threshold. Clusters are numbered largest first (ties by oldest
+// member); each cluster's representative is the member most similar on average to
+// the others. Approaches with an empty or mismatched embedding are left out.
+func clusterApproaches(embeddings []models.ApproachEmbedding, threshold float64) []models.StrategyAssignment {
+	var items []models.ApproachEmbedding
+	var vecs [][]float64
+	for _, e := range embeddings {
+		if len(e.Embedding) == 0 || (len(vecs) > 0 && len(e.Embedding) != len(vecs[0])) {
+			continue
+		}
+		if v := normalize(e.Embedding); v != nil {
+			items = append(items, e)
+			vecs = append(vecs, v)
+		}
+	}
+	n := len(items)
+	if n == 0 {
+		return nil
+	}
+
+	sim := make([][]float64, n)
+	for i := range n {
+		sim[i] = make([]float64, n)
+		for k := range n {
+			sim[i][k] = dot(vecs[i], vecs[k])
+		}
+	}
+
+	// Each cluster is a list of item indexes, kept in input (oldest first) order.
+	clusters := make([][]int, n)
+	for i := range n {
+		clusters[i] = []int{i}
+	}
+	for len(clusters) > 1 {
+		bestA, bestB, best := -1, -1, threshold
+		for a := range clusters {
+			for b := a + 1; b < len(clusters); b++ {
+				if s := averageLinkage(sim, clusters[a], clusters[b]); s > best {
+					bestA, bestB, best = a, b, s
+				}
+			}
+		}
+		if bestA < 0 {
+			break
+		}
+		merged := append(append([]int{}, clusters[bestA]...), clusters[bestB]...)
+		sort.Ints(merged)
+		clusters[bestA] = merged
+		clusters = append(clusters[:bestB], clusters[bestB+1:]...)
+	}
+
+	sort.SliceStable(clusters, func(a, b int) bool {
+		if len(clusters[a]) != len(clusters[b]) {
+			return len(clusters[a]) > len(clusters[b])
+		}
+		return clusters[a][0] < clusters[b][0]
+	})
+
+	assignments := make([]models.StrategyAssignment, 0, n)
+	for index, members := range clusters {
+		rep := medoid(sim, members)
+		for _, m := range members {
+			assignments = append(assignments, models.StrategyAssignment{
+				ApproachID:     items[m].ApproachID,
+				ClusterIndex:   index,
+				Representative: m == rep,
+			})
+		}
+	}
+	return assignments
+}
+
+// averageLinkage is the mean similarity between members of a and members of b.
+func averageLinkage(sim [][]float64, a, b []int) float64 {
+	total := 0.0
+	for _, i := range a {
+		for _, k := range b {
+			total += sim[i][k]
+		}
+	}
+	return total / float64(len(a)*len(b))
+}
+
+// medoid returns the member with the highest total similarity to the others; the
+// oldest member wins ties.
+func medoid(sim [][]float64, members []int) int {
+	best, bestScore := members[0], math.Inf(-1)
+	for _, i := range members {
+		score := 0.0
+		for _, k := range members {
+			score += sim[i][k]
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
+
+// normalize returns v scaled to unit length, or nil for a zero vector.
+func normalize(v []float32) []float64 {
+	norm := 0.0
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float64, len(v))
+	for i, x := range v {
+		out[i] = float64(x) / norm
+	}
+	return out
+}
+
+func dot(a, b []float64) float64 {
+	total := 0.0
+	for i := range a {
+		total += a[i] * b[i]
+	}
+	return total
+}
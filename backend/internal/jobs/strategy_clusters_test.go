@@ -0,0 +1,132 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockStrategyClusterStore struct {
+	problems   []string
+	embeddings map[string][]models.ApproachEmbedding
+	loadErr    map[string]error
+	saved      map[string][]models.StrategyAssignment
+}
+
+func (m *mockStrategyClusterStore) ListProblemsNeedingClustering(ctx context.Context, limit int) ([]string, error) {
+	return m.problems, nil
+}
+
+func (m *mockStrategyClusterStore) ListApproachEmbeddings(ctx context.Context, problemID string) ([]models.ApproachEmbedding, error) {
+	if err := m.loadErr[problemID]; err != nil {
+		return nil, err
+	}
+	return m.embeddings[problemID], nil
+}
+
+func (m *mockStrategyClusterStore) SaveStrategyClusters(ctx context.Context, problemID string, assignments []models.StrategyAssignment, threshold float64) error {
+	if m.saved == nil {
+		m.saved = map[string][]models.StrategyAssignment{}
+	}
+	m.saved[problemID] = assignments
+	return nil
+}
+
+func byApproach(assignments []models.StrategyAssignment) map[string]models.StrategyAssignment {
+	out := map[string]models.StrategyAssignment{}
+	for _, a := range assignments {
+		out[a.ApproachID] = a
+	}
+	return out
+}
+
+func TestClusterApproaches_GroupsSimilarAngles(t *testing.T) {
+	got := byApproach(clusterApproaches([]models.ApproachEmbedding{
+		{ApproachID: "cache-1", Embedding: []float32{1, 0, 0}},
+		{ApproachID: "retry-1", Embedding: []float32{0, 1, 0}},
+		{ApproachID: "cache-2", Embedding: []float32{0.95, 0.1, 0}},
+		{ApproachID: "cache-3", Embedding: []float32{0.9, 0, 0.1}},
+		{ApproachID: "retry-2", Embedding: []float32{0.05, 1, 0}},
+	}, DefaultStrategySimilarityThreshold))
+
+	if len(got) != 5 {
+		t.Fatalf("got %d assignments, want 5", len(got))
+	}
+	for _, id := range []string{"cache-1", "cache-2", "cache-3"} {
+		if got[id].ClusterIndex != 0 {
+			t.Errorf("%s cluster = %d, want 0 (largest)", id, got[id].ClusterIndex)
+		}
+	}
+	for _, id := range []string{"retry-1", "retry-2"} {
+		if got[id].ClusterIndex != 1 {
+			t.Errorf("%s cluster = %d, want 1", id, got[id].ClusterIndex)
+		}
+	}
+
+	reps := map[int]int{}
+	for _, a := range got {
+		if a.Representative {
+			reps[a.ClusterIndex]++
+		}
+	}
+	if reps[0] != 1 || reps[1] != 1 {
+		t.Errorf("representatives per cluster = %v, want one each", reps)
+	}
+	if !got["cache-1"].Representative {
+		t.Error("cache-1 should represent the cache cluster")
+	}
+}
+
+func TestClusterApproaches_TiesOrderedByOldestMember(t *testing.T) {
+	got := byApproach(clusterApproaches([]models.ApproachEmbedding{
+		{ApproachID: "a", Embedding: []float32{1, 0}},
+		{ApproachID: "b", Embedding: []float32{0, 1}},
+	}, DefaultStrategySimilarityThreshold))
+
+	if got["a"].ClusterIndex != 0 || got["b"].ClusterIndex != 1 {
+		t.Errorf("clusters = %+v, want a=0 b=1", got)
+	}
+	if !got["a"].Representative || !got["b"].Representative {
+		t.Error("singletons should represent themselves")
+	}
+}
+
+func TestClusterApproaches_SkipsUnusableEmbeddings(t *testing.T) {
+	got := clusterApproaches([]models.ApproachEmbedding{
+		{ApproachID: "ok", Embedding: []float32{1, 0}},
+		{ApproachID: "empty"},
+		{ApproachID: "zero", Embedding: []float32{0, 0}},
+		{ApproachID: "short", Embedding: []float32{1}},
+	}, DefaultStrategySimilarityThreshold)
+
+	if len(got) != 1 || got[0].ApproachID != "ok" {
+		t.Errorf("assignments = %+v, want only ok", got)
+	}
+	if clusterApproaches(nil, DefaultStrategySimilarityThreshold) != nil {
+		t.Error("no embeddings should give no assignments")
+	}
+}
+
+func TestStrategyClusterJob_RunOnce(t *testing.T) {
+	store := &mockStrategyClusterStore{
+		problems: []string{"p1", "p2", "p3"},
+		embeddings: map[string][]models.ApproachEmbedding{
+			"p1": {{ApproachID: "x", Embedding: []float32{1, 0}}},
+		},
+		loadErr: map[string]error{"p2": errors.New("db down")},
+	}
+	job := NewStrategyClusterJob(store, DefaultStrategyClusterBatchSize, DefaultStrategySimilarityThreshold)
+
+	clustered, failed := job.RunOnce(context.Background())
+	if clustered != 2 || failed != 1 {
+		t.Errorf("RunOnce() = %d, %d; want 2, 1", clustered, failed)
+	}
+	if len(store.saved["p1"]) != 1 {
+		t.Errorf("p1 saved = %+v, want one assignment", store.saved["p1"])
+	}
+	if _, ok := store.saved["p3"]; !ok {
+		t.Error("p3 without embeddings should still record an empty run")
+	}
+}
@@ -41,9 +41,10 @@ type PostTranslator interface {
 	TranslateContent(ctx context.Context, input services.TranslationInput) (*services.TranslationResult, error)
 }
 
-// PostModerationTrigger triggers async content moderation for a post.
+// PostModerationTrigger triggers async content moderation for a post. The
+// moderation runs in ctx's tenant but outlives ctx.
 type PostModerationTrigger interface {
-	TriggerAsync(postID, title, description string, tags []string, postType, authorType, authorID string)
+	TriggerAsync(ctx context.Context, postID, title, description string, tags []string, postType, authorType, authorID string)
 }
 
 // TranslationJob handles periodic translation of non-English draft posts.
@@ -131,6 +132,7 @@ func (j *TranslationJob) RunOnce(ctx context.Context) (translated, failed int) {
 
 		// Trigger moderation for the now-translated post
 		j.trigger.TriggerAsync(
+			ctx,
 			post.ID,
 			result.Title,
 			result.Description,
@@ -91,7 +91,7 @@ type moderationTriggerCall struct {
 	description string
 }
 
-func (m *mockModerationTrigger) TriggerAsync(postID, title, description string, tags []string, postType, authorType, authorID string) {
+func (m *mockModerationTrigger) TriggerAsync(ctx context.Context, postID, title, description string, tags []string, postType, authorType, authorID string) {
 	m.triggered = append(m.triggered, moderationTriggerCall{postID, title, description})
 }
 
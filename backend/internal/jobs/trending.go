@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// DefaultTrendingInterval is how often trending scores are recomputed.
+const DefaultTrendingInterval = 10 * time.Minute
+
+// TrendingScorer recomputes stored trending scores.
+// Implemented by db.TrendingRepository.
+type TrendingScorer interface {
+	RecomputeTrendingScores(ctx context.Context, cfg models.TrendingConfig) (int64, error)
+}
+
+// TrendingJob periodically recomputes the trending_scores table that backs
+// GET /v1/stats/trending, so scores decay as posts age even without new activity.
+type TrendingJob struct {
+	scorer TrendingScorer
+	cfg    models.TrendingConfig
+}
+
+// NewTrendingJob creates a new TrendingJob.
+func NewTrendingJob(scorer TrendingScorer, cfg models.TrendingConfig) *TrendingJob {
+	return &TrendingJob{scorer: scorer, cfg: cfg}
+}
+
+// RunOnce recomputes trending scores once. Returns the number of posts scored.
+func (j *TrendingJob) RunOnce(ctx context.Context) (int64, error) {
+	return j.scorer.RecomputeTrendingScores(ctx, j.cfg)
+}
+
+// RunScheduled runs the trending job on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *TrendingJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Trending job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+func (j *TrendingJob) runAndLog(ctx context.Context) {
+	if _, err := j.RunOnce(ctx); err != nil {
+		log.Printf("Trending recompute failed: %v", err)
+	}
+}
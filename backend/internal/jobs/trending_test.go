@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockTrendingScorer struct {
+	calls int
+	cfg   models.TrendingConfig
+}
+
+func (m *mockTrendingScorer) RecomputeTrendingScores(ctx context.Context, cfg models.TrendingConfig) (int64, error) {
+	m.calls++
+	m.cfg = cfg
+	return 12, nil
+}
+
+func TestTrendingJob_RunOncePassesConfig(t *testing.T) {
+	mock := &mockTrendingScorer{}
+	cfg := models.DefaultTrendingConfig()
+	cfg.HalfLifeHours = 6
+	job := NewTrendingJob(mock, cfg)
+
+	scored, err := job.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scored != 12 {
+		t.Errorf("expected 12 scored posts, got %d", scored)
+	}
+	if mock.cfg != cfg {
+		t.Errorf("expected config %+v, got %+v", cfg, mock.cfg)
+	}
+}
+
+func TestTrendingJob_RunScheduledStopsOnCancel(t *testing.T) {
+	mock := &mockTrendingScorer{}
+	job := NewTrendingJob(mock, models.DefaultTrendingConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.RunScheduled(ctx, time.Hour)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunScheduled did not stop after cancel")
+	}
+	if mock.calls != 1 {
+		t.Errorf("expected immediate run on start, got %d calls", mock.calls)
+	}
+}
@@ -0,0 +1,167 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+// DefaultWebPushInterval is how often new notifications are pushed.
+const DefaultWebPushInterval = 30 * time.Second
+
+// DefaultWebPushBatchSize caps how many notifications are pushed per run.
+const DefaultWebPushBatchSize = 200
+
+// webPushMaxAge is how old a notification can be and still be pushed. Older
+// ones (from before the recipient subscribed, or a backlog while push was
+// down) stay in-app only.
+const webPushMaxAge = time.Hour
+
+// webPushTTL is how long a push service holds a message for an offline browser.
+const webPushTTL = 24 * time.Hour
+
+// maxWebPushBody caps the notification body sent in a push; the full text is
+// in the app.
+const maxWebPushBody = 500
+
+// WebPushStore reads pending pushes and records their delivery.
+type WebPushStore interface {
+	ListPendingPushes(ctx context.Context, types []string, since time.Time, limit int) ([]models.PendingPush, error)
+	MarkPushed(ctx context.Context, notificationID string) error
+	RecordDelivery(ctx context.Context, subscriptionID string) error
+	RemoveSubscription(ctx context.Context, subscriptionID string) error
+}
+
+// WebPushSender delivers one encrypted push message.
+type WebPushSender interface {
+	Send(ctx context.Context, sub *models.PushSubscription, payload []byte, ttl time.Duration) error
+}
+
+// webPushPayload is what the web frontend's service worker receives.
+type webPushPayload struct {
+	NotificationID string `json:"notification_id"`
+	Type           string `json:"type"`
+	Title          string `json:"title"`
+	Body           string `json:"body,omitempty"`
+	Link           string `json:"link,omitempty"`
+}
+
+// WebPushJob fans new notifications of selected types out to every browser
+// their recipient subscribed for Web Push.
+type WebPushJob struct {
+	store     WebPushStore
+	sender    WebPushSender
+	types     []string
+	batchSize int
+}
+
+// NewWebPushJob creates a new WebPushJob pushing notifications of types.
+func NewWebPushJob(store WebPushStore, sender WebPushSender, types []string) *WebPushJob {
+	return &WebPushJob{
+		store:     store,
+		sender:    sender,
+		types:     types,
+		batchSize: DefaultWebPushBatchSize,
+	}
+}
+
+// RunOnce pushes one batch of pending notifications. Returns how many
+// messages were sent and how many failed. Pushes are best effort: a
+// notification is marked pushed after one attempt per subscription, and
+// subscriptions the push service reports gone are removed.
+func (j *WebPushJob) RunOnce(ctx context.Context) (sent, failed int) {
+	pending, err := j.store.ListPendingPushes(ctx, j.types, time.Now().Add(-webPushMaxAge), j.batchSize)
+	if err != nil {
+		log.Printf("Web push: failed to list pending notifications: %v", err)
+		return 0, 0
+	}
+
+	for _, p := range pending {
+		n := p.Notification
+		payload, err := json.Marshal(webPushPayload{
+			NotificationID: n.ID,
+			Type:           n.Type,
+			Title:          n.Title,
+			Body:           truncateForWebPush(n.Body),
+			Link:           n.Link,
+		})
+		if err != nil {
+			log.Printf("Web push: failed to encode notification %s: %v", n.ID, err)
+			continue
+		}
+
+		for i := range p.Subscriptions {
+			sub := &p.Subscriptions[i]
+			sendCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			err := j.sender.Send(sendCtx, sub, payload, webPushTTL)
+			cancel()
+
+			switch {
+			case errors.Is(err, services.ErrPushSubscriptionGone):
+				failed++
+				if err := j.store.RemoveSubscription(ctx, sub.ID); err != nil {
+					log.Printf("Web push: failed to remove gone subscription %s: %v", sub.ID, err)
+				}
+			case err != nil:
+				failed++
+				log.Printf("Web push: failed to push notification %s to subscription %s: %v", n.ID, sub.ID, err)
+			default:
+				sent++
+				if err := j.store.RecordDelivery(ctx, sub.ID); err != nil {
+					log.Printf("Web push: failed to record delivery to %s: %v", sub.ID, err)
+				}
+			}
+		}
+
+		if err := j.store.MarkPushed(ctx, n.ID); err != nil {
+			log.Printf("Web push: failed to mark notification %s pushed: %v", n.ID, err)
+		}
+	}
+	return sent, failed
+}
+
+// truncateForWebPush cuts s to maxWebPushBody bytes without splitting a
+// character.
+func truncateForWebPush(s string) string {
+	if len(s) <= maxWebPushBody {
+		return s
+	}
+	n := maxWebPushBody - len("...")
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
+
+// RunScheduled pushes new notifications on a schedule.
+// Runs immediately on start, then repeats at the given interval.
+func (j *WebPushJob) RunScheduled(ctx context.Context, interval time.Duration) {
+	j.runAndLog(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Web push job stopped")
+			return
+		case <-ticker.C:
+			j.runAndLog(ctx)
+		}
+	}
+}
+
+// runAndLog runs one batch and logs non-empty results.
+func (j *WebPushJob) runAndLog(ctx context.Context) {
+	sent, failed := j.RunOnce(ctx)
+	if sent > 0 || failed > 0 {
+		log.Printf("Web push: %d sent, %d failed", sent, failed)
+	}
+}
@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/services"
+)
+
+type mockWebPushStore struct {
+	pending   []models.PendingPush
+	types     []string
+	since     time.Time
+	pushed    []string
+	delivered []string
+	removed   []string
+}
+
+func (m *mockWebPushStore) ListPendingPushes(ctx context.Context, types []string, since time.Time, limit int) ([]models.PendingPush, error) {
+	m.types, m.since = types, since
+	return m.pending, nil
+}
+
+func (m *mockWebPushStore) MarkPushed(ctx context.Context, notificationID string) error {
+	m.pushed = append(m.pushed, notificationID)
+	return nil
+}
+
+func (m *mockWebPushStore) RecordDelivery(ctx context.Context, subscriptionID string) error {
+	m.delivered = append(m.delivered, subscriptionID)
+	return nil
+}
+
+func (m *mockWebPushStore) RemoveSubscription(ctx context.Context, subscriptionID string) error {
+	m.removed = append(m.removed, subscriptionID)
+	return nil
+}
+
+// mockWebPushSender returns the error listed for a subscription's endpoint.
+type mockWebPushSender struct {
+	errs     map[string]error
+	payloads [][]byte
+}
+
+func (m *mockWebPushSender) Send(ctx context.Context, sub *models.PushSubscription, payload []byte, ttl time.Duration) error {
+	if err := m.errs[sub.Endpoint]; err != nil {
+		return err
+	}
+	m.payloads = append(m.payloads, payload)
+	return nil
+}
+
+func TestWebPushJob_RunOnce(t *testing.T) {
+	store := &mockWebPushStore{pending: []models.PendingPush{{
+		Notification: models.Notification{
+			ID: "n-1", Type: "approach.created", Title: "New approach on your problem",
+			Body: strings.Repeat("é", 400), Link: "/problems/p-1",
+		},
+		Subscriptions: []models.PushSubscription{
+			{ID: "s-ok", Endpoint: "https://fcm.googleapis.com/ok"},
+			{ID: "s-gone", Endpoint: "https://fcm.googleapis.com/gone"},
+			{ID: "s-down", Endpoint: "https://fcm.googleapis.com/down"},
+		},
+	}}}
+	sender := &mockWebPushSender{errs: map[string]error{
+		"https://fcm.googleapis.com/gone": services.ErrPushSubscriptionGone,
+		"https://fcm.googleapis.com/down": errors.New("status 503"),
+	}}
+	job := NewWebPushJob(store, sender, []string{"approach.created"})
+
+	before := time.Now()
+	sent, failed := job.RunOnce(context.Background())
+
+	if sent != 1 || failed != 2 {
+		t.Errorf("expected 1 sent, 2 failed; got %d, %d", sent, failed)
+	}
+	if len(store.delivered) != 1 || store.delivered[0] != "s-ok" {
+		t.Errorf("expected delivery to s-ok recorded, got %v", store.delivered)
+	}
+	if len(store.removed) != 1 || store.removed[0] != "s-gone" {
+		t.Errorf("expected s-gone removed, got %v", store.removed)
+	}
+	if len(store.pushed) != 1 || store.pushed[0] != "n-1" {
+		t.Errorf("expected n-1 marked pushed despite failures, got %v", store.pushed)
+	}
+	if len(store.types) != 1 || store.types[0] != "approach.created" || store.since.Before(before.Add(-webPushMaxAge)) {
+		t.Errorf("unexpected ListPendingPushes filter: types %v since %v", store.types, store.since)
+	}
+
+	var payload webPushPayload
+	if err := json.Unmarshal(sender.payloads[0], &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.NotificationID != "n-1" || payload.Link != "/problems/p-1" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if len(payload.Body) > maxWebPushBody || !strings.HasSuffix(payload.Body, "é...") {
+		t.Errorf("expected body truncated on a character boundary, got %d bytes", len(payload.Body))
+	}
+}
@@ -0,0 +1,188 @@
+// Package maintenance holds the read-only maintenance switch. One switch is shared
+// per process by the API middleware, the admin endpoint and the background job
+// runner, so flipping it stops writes and pauses jobs together. With a Store the
+// switch is also shared between processes: Update saves it and every API replica
+// picks it up on its next Poll.
+package maintenance
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/config"
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// DefaultPollInterval is how often Poll reloads the shared state, so a switch
+// flipped on one replica reaches the others within this interval.
+const DefaultPollInterval = 10 * time.Second
+
+// Store shares the switch between API replicas.
+type Store interface {
+	// LoadMaintenance returns the saved state, or nil when it was never saved.
+	LoadMaintenance(ctx context.Context) (*models.MaintenanceStatus, error)
+	// SaveMaintenance replaces the saved state.
+	SaveMaintenance(ctx context.Context, status models.MaintenanceStatus) error
+}
+
+// Mode is a concurrency-safe maintenance switch.
+type Mode struct {
+	mu        sync.RWMutex
+	status    models.MaintenanceStatus
+	store     Store
+	listeners []func(enabled bool)
+}
+
+// New creates a switch in the given state. An empty message uses
+// models.DefaultMaintenanceMessage.
+func New(enabled bool, message string) *Mode {
+	m := &Mode{}
+	m.status = m.next(enabled, message)
+	return m
+}
+
+var (
+	defaultOnce sync.Once
+	defaultMode *Mode
+)
+
+// Default returns the process-wide switch, starting in the state given by
+// MAINTENANCE_MODE and MAINTENANCE_MESSAGE. Without a store, changes made
+// through the admin endpoint last until the process restarts.
+func Default() *Mode {
+	defaultOnce.Do(func() {
+		defaultMode = New(config.MaintenanceConfig())
+	})
+	return defaultMode
+}
+
+// SetStore shares the switch through store. Call Poll to follow changes made by
+// other processes.
+func (m *Mode) SetStore(store Store) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+}
+
+// Enabled reports whether maintenance mode is on.
+func (m *Mode) Enabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status.Enabled
+}
+
+// Status returns a copy of the current state.
+func (m *Mode) Status() models.MaintenanceStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status := m.status
+	status.Shared = m.store != nil
+	return status
+}
+
+// Set turns maintenance mode on or off in this process only and returns the new
+// state. Listeners registered with OnChange are called only when the enabled
+// flag changes; updating the message of an enabled switch keeps its Since time.
+func (m *Mode) Set(enabled bool, message string) models.MaintenanceStatus {
+	m.mu.RLock()
+	status := m.next(enabled, message)
+	m.mu.RUnlock()
+	m.replace(status)
+	return m.Status()
+}
+
+// Update is Set for every process sharing the store: the new state is saved
+// first, and this process only switches once the save succeeded. Without a
+// store it is Set.
+func (m *Mode) Update(ctx context.Context, enabled bool, message string) (models.MaintenanceStatus, error) {
+	m.mu.RLock()
+	status := m.next(enabled, message)
+	store := m.store
+	m.mu.RUnlock()
+
+	if store != nil {
+		if err := store.SaveMaintenance(ctx, status); err != nil {
+			return m.Status(), err
+		}
+	}
+	m.replace(status)
+	return m.Status(), nil
+}
+
+// Poll loads the shared state now and then every interval until ctx is done.
+// Until the state is first saved, the process keeps its own.
+func (m *Mode) Poll(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.sync(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync adopts the saved state, if any. Load failures keep the current state.
+func (m *Mode) sync(ctx context.Context) {
+	m.mu.RLock()
+	store := m.store
+	m.mu.RUnlock()
+	if store == nil {
+		return
+	}
+
+	status, err := store.LoadMaintenance(ctx)
+	if err != nil {
+		slog.Warn("failed to load maintenance mode", "error", err)
+		return
+	}
+	if status != nil {
+		m.replace(*status)
+	}
+}
+
+// OnChange registers fn to be called after maintenance mode is switched on or off.
+func (m *Mode) OnChange(fn func(enabled bool)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, fn)
+}
+
+// replace sets the state and notifies listeners when the enabled flag changed.
+func (m *Mode) replace(status models.MaintenanceStatus) {
+	status.Shared = false
+	m.mu.Lock()
+	changed := m.status.Enabled != status.Enabled
+	m.status = status
+	listeners := append([]func(bool){}, m.listeners...)
+	m.mu.Unlock()
+
+	if changed {
+		for _, fn := range listeners {
+			fn(status.Enabled)
+		}
+	}
+}
+
+// next returns the state after switching to enabled with message. Callers hold
+// mu for reading (or own m exclusively).
+func (m *Mode) next(enabled bool, message string) models.MaintenanceStatus {
+	if !enabled {
+		return models.MaintenanceStatus{}
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		message = models.DefaultMaintenanceMessage
+	}
+	since := m.status.Since
+	if !m.status.Enabled || since == nil {
+		now := time.Now().UTC()
+		since = &now
+	}
+	return models.MaintenanceStatus{Enabled: true, Message: message, Since: since}
+}
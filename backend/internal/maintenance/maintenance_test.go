@@ -0,0 +1,121 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestMode_SetNotifiesOnTransitions(t *testing.T) {
+	m := New(false, "")
+	var calls []bool
+	m.OnChange(func(enabled bool) { calls = append(calls, enabled) })
+
+	status := m.Set(true, "  migrating posts  ")
+	if !status.Enabled || status.Message != "migrating posts" || status.Since == nil {
+		t.Fatalf("unexpected status after enable: %+v", status)
+	}
+	since := *status.Since
+
+	// Updating the message while enabled is not a transition and keeps Since.
+	status = m.Set(true, "")
+	if status.Message != models.DefaultMaintenanceMessage || !status.Since.Equal(since) {
+		t.Errorf("expected default message and unchanged since, got %+v", status)
+	}
+
+	status = m.Set(false, "ignored")
+	if status.Enabled || status.Message != "" || status.Since != nil {
+		t.Errorf("expected cleared status after disable, got %+v", status)
+	}
+	m.Set(false, "")
+
+	if len(calls) != 2 || !calls[0] || calls[1] {
+		t.Errorf("expected listener calls [true false], got %v", calls)
+	}
+	if m.Enabled() {
+		t.Error("expected maintenance mode off")
+	}
+}
+
+func TestNew_StartsEnabled(t *testing.T) {
+	m := New(true, "")
+	if !m.Enabled() || m.Status().Message != models.DefaultMaintenanceMessage {
+		t.Errorf("expected enabled switch with default message, got %+v", m.Status())
+	}
+}
+
+// memStore is an in-memory Store shared by several switches, like the
+// maintenance_state row shared by API replicas.
+type memStore struct {
+	mu     sync.Mutex
+	status *models.MaintenanceStatus
+	err    error
+}
+
+func (s *memStore) LoadMaintenance(ctx context.Context) (*models.MaintenanceStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == nil {
+		return nil, s.err
+	}
+	status := *s.status
+	return &status, s.err
+}
+
+func (s *memStore) SaveMaintenance(ctx context.Context, status models.MaintenanceStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.status = &status
+	return nil
+}
+
+func TestMode_UpdateReachesOtherReplicas(t *testing.T) {
+	store := &memStore{}
+	a, b := New(false, ""), New(true, "from MAINTENANCE_MODE")
+	a.SetStore(store)
+	b.SetStore(store)
+
+	// Nothing saved yet: each replica keeps its startup state.
+	b.sync(context.Background())
+	if !b.Enabled() {
+		t.Fatal("expected replica b to keep its startup state")
+	}
+
+	var calls []bool
+	b.OnChange(func(enabled bool) { calls = append(calls, enabled) })
+
+	status, err := a.Update(context.Background(), true, "reindexing")
+	if err != nil || !status.Enabled || !status.Shared {
+		t.Fatalf("Update() = %+v, %v", status, err)
+	}
+	b.sync(context.Background())
+	if got := b.Status(); got.Message != "reindexing" || !got.Since.Equal(*status.Since) {
+		t.Errorf("replica b = %+v, want the saved state", got)
+	}
+
+	if _, err := a.Update(context.Background(), false, ""); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	b.sync(context.Background())
+	if b.Enabled() || len(calls) != 1 || calls[0] {
+		t.Errorf("expected replica b off after one transition, got %+v, calls %v", b.Status(), calls)
+	}
+}
+
+func TestMode_UpdateKeepsStateWhenSaveFails(t *testing.T) {
+	m := New(false, "")
+	m.SetStore(&memStore{err: errors.New("database down")})
+
+	if _, err := m.Update(context.Background(), true, ""); err == nil {
+		t.Fatal("expected save error")
+	}
+	if m.Enabled() {
+		t.Error("expected maintenance mode to stay off when the save failed")
+	}
+}
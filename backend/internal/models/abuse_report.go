@@ -0,0 +1,55 @@
+// Package models contains data structures for the Solvr API.
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AbuseReportKind identifies the suspicious voting pattern that was detected.
+type AbuseReportKind string
+
+const (
+	// AbuseKindVoteRing: two accounts repeatedly upvoting each other's content.
+	AbuseKindVoteRing AbuseReportKind = "vote_ring"
+	// AbuseKindTargetedUpvoting: one account upvoting most of another's content.
+	AbuseKindTargetedUpvoting AbuseReportKind = "targeted_upvoting"
+	// AbuseKindNewAccountBurst: a recently created account voting in a burst.
+	AbuseKindNewAccountBurst AbuseReportKind = "new_account_burst"
+)
+
+// AbuseReportStatus is the admin review state of an abuse report.
+type AbuseReportStatus string
+
+const (
+	AbuseReportStatusPending   AbuseReportStatus = "pending"
+	AbuseReportStatusDismissed AbuseReportStatus = "dismissed"
+	AbuseReportStatusActioned  AbuseReportStatus = "actioned"
+)
+
+// AbuseReport is a suspicious voting pattern flagged by the abuse detection job.
+// Subject is the account under suspicion; Related is the other account involved
+// (ring partner or upvoted author) and is empty for new-account bursts.
+type AbuseReport struct {
+	ID          string            `json:"id"`
+	Kind        AbuseReportKind   `json:"kind"`
+	SubjectType string            `json:"subject_type"`
+	SubjectID   string            `json:"subject_id"`
+	RelatedType string            `json:"related_type,omitempty"`
+	RelatedID   string            `json:"related_id,omitempty"`
+	Details     json.RawMessage   `json:"details"`
+	Status      AbuseReportStatus `json:"status"`
+	CreatedAt   time.Time         `json:"created_at"`
+	ReviewedAt  *time.Time        `json:"reviewed_at,omitempty"`
+	ReviewNote  string            `json:"review_note,omitempty"`
+}
+
+// IsValidAbuseReportStatus checks if an abuse report status is valid.
+func IsValidAbuseReportStatus(status AbuseReportStatus) bool {
+	switch status {
+	case AbuseReportStatusPending, AbuseReportStatusDismissed, AbuseReportStatusActioned:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// DeletedUserID is the sentinel user that anonymized content is reattributed to
+// when its author deletes their account. Created by migration 000089.
+const DeletedUserID = "00000000-0000-0000-0000-000000000000"
+
+// AccountDeletionStatus is the lifecycle state of an account deletion request.
+type AccountDeletionStatus string
+
+const (
+	// AccountDeletionPending means content is anonymized and PII is awaiting purge.
+	AccountDeletionPending AccountDeletionStatus = "pending"
+	// AccountDeletionPurged means the user row and remaining PII were hard-deleted.
+	AccountDeletionPurged AccountDeletionStatus = "purged"
+	// AccountDeletionCancelled means an admin restored the account before the purge.
+	AccountDeletionCancelled AccountDeletionStatus = "cancelled"
+)
+
+// AccountDeletionReceipt records a GDPR account deletion request and its outcome.
+// The receipt ID is returned to the user and can be checked without signing in.
+type AccountDeletionReceipt struct {
+	ID                string                `json:"id"`
+	UserID            string                `json:"-"`
+	Status            AccountDeletionStatus `json:"status"`
+	RequestedAt       time.Time             `json:"requested_at"`
+	PurgeAfter        time.Time             `json:"purge_after"`
+	ContentAnonymized int                   `json:"content_anonymized"`
+	VotesAnonymized   int                   `json:"votes_anonymized"`
+	PurgedAt          *time.Time            `json:"purged_at,omitempty"`
+	CancelledAt       *time.Time            `json:"cancelled_at,omitempty"`
+}
@@ -59,26 +59,40 @@ func IsValidFlagReason(reason string) bool {
 	return false
 }
 
-// AuditLog represents an admin action audit log entry per SPEC.md Part 16
+// AuditLog is one append-only audit entry for an authenticated write.
+// Action is the HTTP method; Details holds a diff summary (the request body
+// field names sent), never the values.
 type AuditLog struct {
-	ID         uuid.UUID              `json:"id"`
-	AdminID    uuid.UUID              `json:"admin_id"`
+	ID         string                 `json:"id"`
+	ActorType  string                 `json:"actor_type"`
+	ActorID    string                 `json:"actor_id"`
 	Action     string                 `json:"action"`
+	Route      string                 `json:"route"`
+	Path       string                 `json:"path"`
 	TargetType string                 `json:"target_type,omitempty"`
-	TargetID   *uuid.UUID             `json:"target_id,omitempty"`
+	TargetID   string                 `json:"target_id,omitempty"`
+	StatusCode int                    `json:"status_code"`
 	Details    map[string]interface{} `json:"details,omitempty"`
 	IPAddress  string                 `json:"ip_address,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
 	CreatedAt  time.Time              `json:"created_at"`
 }
 
+// AuditActorAdmin is the actor type and ID recorded for requests made with the admin API key.
+const AuditActorAdmin = "admin"
+
 // AuditListOptions contains options for listing audit log entries
 type AuditListOptions struct {
-	Action   string    // Filter by action
-	AdminID  *uuid.UUID // Filter by admin
-	FromDate *time.Time
-	ToDate   *time.Time
-	Page     int
-	PerPage  int
+	ActorType  string // Filter by actor type (human, agent, admin)
+	ActorID    string // Filter by actor ID
+	Action     string // Filter by HTTP method
+	Route      string // Filter by route pattern
+	TargetType string // Filter by target type
+	TargetID   string // Filter by target ID
+	FromDate   *time.Time
+	ToDate     *time.Time
+	Page       int
+	PerPage    int
 }
 
 // AdminStats represents system statistics for the admin dashboard per SPEC.md Part 16.3
@@ -93,10 +107,13 @@ type AdminStats struct {
 
 // UserListOptions contains options for listing users
 type UserListOptions struct {
-	Query   string // Search query
-	Status  string // Filter by status
-	Page    int
-	PerPage int
+	Query         string     // Search query (username, display name or email)
+	Status        string     // Filter by status (active, suspended, banned, deleted)
+	Provider      string     // Filter by linked auth provider (email, github, google)
+	CreatedAfter  *time.Time // Only users created at or after this time
+	CreatedBefore *time.Time // Only users created before this time
+	Page          int
+	PerPage       int
 }
 
 // AgentListOptions contains options for listing agents
@@ -151,10 +168,28 @@ const (
 	UserStatusBanned    UserStatus = "banned"
 )
 
+// IsValidUserStatus checks if a user status is valid
+func IsValidUserStatus(status UserStatus) bool {
+	switch status {
+	case UserStatusActive, UserStatusSuspended, UserStatusBanned:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsBlockedUserStatus reports whether a user with this status must be refused sign-in.
+func IsBlockedUserStatus(status string) bool {
+	return status == string(UserStatusSuspended) || status == string(UserStatusBanned)
+}
+
 // AgentStatus represents agent status
 type AgentStatus string
 
 const (
 	AgentStatusActive    AgentStatus = "active"
 	AgentStatusSuspended AgentStatus = "suspended"
+	// AgentStatusPendingVerification is a self-registered agent whose operator
+	// has not yet confirmed its email; its API key is read-only until then.
+	AgentStatusPendingVerification AgentStatus = "pending_verification"
 )
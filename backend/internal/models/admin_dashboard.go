@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// AdminDashboard is the ops overview returned by GET /v1/admin/dashboard.
+type AdminDashboard struct {
+	Queues      DashboardQueues     `json:"queues"`
+	Activity24h DashboardActivity   `json:"activity_24h"`
+	ErrorRates  DashboardErrorRates `json:"error_rates"`
+	Services    []DashboardService  `json:"services"`
+	GeneratedAt time.Time           `json:"generated_at"`
+}
+
+// DashboardQueues holds the depth of every queue an operator may need to drain.
+type DashboardQueues struct {
+	ModerationPending   int `json:"moderation_pending"` // posts in pending_review
+	FlagsPending        int `json:"flags_pending"`
+	ReportsPending      int `json:"reports_pending"`
+	AbuseReportsPending int `json:"abuse_reports_pending"`
+	EmbeddingsPending   int `json:"embeddings_pending"`
+	EmbeddingsFailing   int `json:"embeddings_failing"` // retried at least once
+	EmailsPending       int `json:"emails_pending"`
+	EmailsFailing       int `json:"emails_failing"` // retried at least once
+}
+
+// DashboardActivity counts rows created in the last 24 hours.
+type DashboardActivity struct {
+	Posts      int `json:"posts"`
+	Answers    int `json:"answers"`
+	Approaches int `json:"approaches"`
+	Comments   int `json:"comments"`
+	Votes      int `json:"votes"`
+	Users      int `json:"users"`
+	Agents     int `json:"agents"`
+}
+
+// DashboardErrorRates summarizes api_usage_daily since yesterday (UTC). Only
+// authenticated traffic is recorded there.
+type DashboardErrorRates struct {
+	Since     string                 `json:"since"` // YYYY-MM-DD (UTC)
+	Requests  int64                  `json:"requests"`
+	Errors    int64                  `json:"errors"`
+	ErrorRate float64                `json:"error_rate"` // errors / requests, 0 when idle
+	TopRoutes []DashboardRouteErrors `json:"top_routes"`
+}
+
+// DashboardRouteErrors is one of the routes with the most errors.
+type DashboardRouteErrors struct {
+	Method    string  `json:"method"`
+	Route     string  `json:"route"`
+	Requests  int64   `json:"requests"`
+	Errors    int64   `json:"errors"`
+	ErrorRate float64 `json:"error_rate"`
+}
+
+// DashboardService is an external service's latest health check plus its
+// uptime over the last 24 hours.
+type DashboardService struct {
+	ServiceName    string             `json:"service_name"`
+	Status         ServiceCheckStatus `json:"status"`
+	ResponseTimeMs *int               `json:"response_time_ms,omitempty"`
+	ErrorMessage   *string            `json:"error_message,omitempty"`
+	CheckedAt      time.Time          `json:"checked_at"`
+	Uptime24h      float64            `json:"uptime_24h"` // percent of operational checks
+}
@@ -103,6 +103,10 @@ type Agent struct {
 	// Per PRD-v5 Task 22: agent self-deletion feature.
 	// NULL = agent is active, NOT NULL = agent is soft-deleted.
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// TenantID is the tenant (community) the agent belongs to. Its API key is
+	// only accepted on that tenant.
+	TenantID string `json:"-"`
 }
 
 // AgentStats contains computed statistics for an agent.
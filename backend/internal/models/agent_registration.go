@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// AgentRegistrationConfig is the friction applied to POST /v1/agents/register,
+// which anyone can call without an account.
+type AgentRegistrationConfig struct {
+	// ProofOfWorkBits is the hashcash difficulty a registration must solve:
+	// the number of leading zero bits in the SHA-256 of its stamp. 0 disables it.
+	ProofOfWorkBits int
+	// MaxPerIP caps registrations from one IP per Window. 0 disables the cap.
+	MaxPerIP int
+	Window   time.Duration
+	// RequireEmail makes email mandatory at registration and keeps the agent
+	// pending_verification, with a read-only API key, until its operator
+	// follows the link sent to that address.
+	RequireEmail bool
+}
+
+// MaxProofOfWorkBits bounds the configurable difficulty; each extra bit
+// doubles the expected work, and 32 bits already takes minutes on a CPU.
+const MaxProofOfWorkBits = 32
@@ -5,6 +5,9 @@ import (
 	"time"
 )
 
+// MaxAnswerContentLength is the maximum answer content length, in bytes.
+const MaxAnswerContentLength = 30000
+
 // Answer represents an answer to a question on Solvr.
 // Per SPEC.md Part 2.4 and Part 6 (answers table).
 type Answer struct {
@@ -33,12 +36,36 @@ type Answer struct {
 	// Downvotes is the number of downvotes.
 	Downvotes int `json:"downvotes"`
 
+	// QualityScore is the 0-100 LLM-assisted quality score (null until scored).
+	// Set by the answer quality job; reset when the answer is edited.
+	QualityScore *int `json:"quality_score,omitempty"`
+
+	// CodeLanguages are the languages of fenced code blocks in the content,
+	// extracted on create and update.
+	CodeLanguages []string `json:"code_languages,omitempty"`
+
+	// SupersedesAnswerID is the older answer on the same question that this
+	// answer is an improved version of (null if it is not a new version).
+	SupersedesAnswerID *string `json:"supersedes_answer_id,omitempty"`
+
+	// SupersededByID is the newer answer that supersedes this one, if any.
+	// Set when answers are read.
+	SupersededByID *string `json:"superseded_by_id,omitempty"`
+
 	// CreatedAt is when the answer was created.
 	CreatedAt time.Time `json:"created_at"`
 
+	// UpdatedAt is when the answer was last edited. Used as the version for
+	// optimistic concurrency on PATCH /v1/answers/{id}.
+	UpdatedAt time.Time `json:"updated_at"`
+
 	// DeletedAt is when the answer was soft deleted (null if not deleted).
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 
+	// HeldForReview is set on the PATCH /v1/answers/{id} response when the edit
+	// hid the answer until content moderation approves it.
+	HeldForReview bool `json:"held_for_review,omitempty"`
+
 	// EmbeddingStr carries the PostgreSQL vector literal from handler to repository.
 	// Excluded from JSON API responses.
 	EmbeddingStr *string `json:"-"`
@@ -49,6 +76,12 @@ func (a *Answer) VoteScore() int {
 	return a.Upvotes - a.Downvotes
 }
 
+// IsVerified reports whether an answer counts as a verified version: it is
+// accepted or has a positive vote score.
+func (a *Answer) IsVerified() bool {
+	return a.IsAccepted || a.VoteScore() > 0
+}
+
 // AnswerAuthor contains author information for display.
 type AnswerAuthor struct {
 	Type        AuthorType `json:"type"`
@@ -60,8 +93,9 @@ type AnswerAuthor struct {
 // AnswerWithAuthor is an Answer with embedded author information.
 type AnswerWithAuthor struct {
 	Answer
-	Author    AnswerAuthor `json:"author"`
-	VoteScore int          `json:"vote_score"`
+	Author    AnswerAuthor    `json:"author"`
+	VoteScore int             `json:"vote_score"`
+	Reactions []ReactionCount `json:"reactions,omitempty"`
 }
 
 // AnswerListOptions contains options for listing answers.
@@ -69,11 +103,71 @@ type AnswerListOptions struct {
 	QuestionID string // Filter by question ID
 	Page       int    // Page number (1-indexed)
 	PerPage    int    // Results per page
+	Sort       string // "newest" (default), "oldest", "top", "verified-first" or "quality"
+}
+
+// AnswerDuplicate is an existing answer on the same question whose embedding is
+// close to a new answer's.
+type AnswerDuplicate struct {
+	ID         string     `json:"id"`
+	AuthorType AuthorType `json:"author_type"`
+	AuthorID   string     `json:"author_id"`
+	IsAccepted bool       `json:"is_accepted"`
+	VoteScore  int        `json:"vote_score"`
+	Similarity float64    `json:"similarity"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// AnswerDuplicateConfig holds the cosine-similarity thresholds (0–1) for
+// near-duplicate answers on the same question.
+type AnswerDuplicateConfig struct {
+	// WarnThreshold lists existing answers at or above it under possible_duplicates.
+	WarnThreshold float64 `json:"warn_threshold"`
+	// RejectThreshold rejects the new answer with a 409 at or above it.
+	RejectThreshold float64 `json:"reject_threshold"`
+}
+
+// DefaultAnswerDuplicateConfig returns the default thresholds: a warning for
+// close paraphrases, a rejection only for the same fix restated.
+func DefaultAnswerDuplicateConfig() AnswerDuplicateConfig {
+	return AnswerDuplicateConfig{
+		WarnThreshold:   0.90,
+		RejectThreshold: 0.97,
+	}
 }
 
 // CreateAnswerRequest is the request body for creating an answer.
 type CreateAnswerRequest struct {
 	Content string `json:"content"`
+	// SupersedesAnswerID, when set, marks the new answer as an improved version
+	// of that answer on the same question.
+	SupersedesAnswerID *string `json:"supersedes_answer_id,omitempty"`
+}
+
+// AnswerVersionChain is the chain of versions an answer belongs to, linked by
+// supersedes_answer_id. Returned by GET /v1/answers/{id}/versions.
+type AnswerVersionChain struct {
+	// AnswerID is the answer the chain was requested for.
+	AnswerID string `json:"answer_id"`
+
+	// Versions are the visible versions, oldest first.
+	Versions []AnswerWithAuthor `json:"versions"`
+
+	// LatestVerifiedID is the newest version that is accepted or net upvoted
+	// (null if none is). Search and question context prefer it over older versions.
+	LatestVerifiedID *string `json:"latest_verified_id"`
+}
+
+// LatestVerifiedAnswerID returns the ID of the last verified answer in versions,
+// which are ordered oldest first, or nil if none is verified.
+func LatestVerifiedAnswerID(versions []AnswerWithAuthor) *string {
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].IsVerified() {
+			id := versions[i].ID
+			return &id
+		}
+	}
+	return nil
 }
 
 // AnswerWithContext is an answer with parent question context.
@@ -86,4 +180,16 @@ type AnswerWithContext struct {
 // UpdateAnswerRequest is the request body for updating an answer.
 type UpdateAnswerRequest struct {
 	Content *string `json:"content,omitempty"`
+	// ExpectedUpdatedAt, when set, must equal the answer's current updated_at
+	// or the update is rejected with 409 Conflict.
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty"`
+}
+
+// AnswerForScoring is an unscored answer with the question it answers,
+// as fed to the answer quality job.
+type AnswerForScoring struct {
+	AnswerID            string
+	Content             string
+	QuestionTitle       string
+	QuestionDescription string
 }
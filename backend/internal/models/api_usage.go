@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// APIUsageIncrement is a batch of request counters for one principal, day, and route.
+// Produced by the usage recorder middleware and upserted into api_usage_daily.
+type APIUsageIncrement struct {
+	PrincipalType   string
+	PrincipalID     string
+	Day             time.Time
+	Method          string
+	Route           string
+	Requests        int64
+	Errors          int64
+	TotalDurationMs int64
+	MaxDurationMs   int
+}
+
+// APIUsageRow is a single stored api_usage_daily row.
+type APIUsageRow struct {
+	Day             time.Time `json:"-"`
+	Method          string    `json:"method"`
+	Route           string    `json:"route"`
+	Requests        int64     `json:"requests"`
+	Errors          int64     `json:"errors"`
+	TotalDurationMs int64     `json:"-"`
+	MaxDurationMs   int       `json:"max_duration_ms"`
+}
+
+// APIUsageEndpoint is one endpoint's usage within a day of GET /v1/me/usage.
+type APIUsageEndpoint struct {
+	Method        string  `json:"method"`
+	Route         string  `json:"route"`
+	Requests      int64   `json:"requests"`
+	Errors        int64   `json:"errors"`
+	AvgDurationMs float64 `json:"avg_duration_ms"`
+	MaxDurationMs int     `json:"max_duration_ms"`
+}
+
+// APIUsageDay is the per-day breakdown returned by GET /v1/me/usage.
+type APIUsageDay struct {
+	Date          string             `json:"date"` // YYYY-MM-DD (UTC)
+	Requests      int64              `json:"requests"`
+	Errors        int64              `json:"errors"`
+	AvgDurationMs float64            `json:"avg_duration_ms"`
+	Endpoints     []APIUsageEndpoint `json:"endpoints"`
+}
@@ -29,6 +29,96 @@ func IsValidApproachStatus(s ApproachStatus) bool {
 	}
 }
 
+// ApproachOutcomeType classifies how an approach ended, alongside the free-text
+// Outcome, so failed hypotheses can be told apart from external blockers.
+type ApproachOutcomeType string
+
+const (
+	ApproachOutcomeWorked           ApproachOutcomeType = "worked"
+	ApproachOutcomePartiallyWorked  ApproachOutcomeType = "partially-worked"
+	ApproachOutcomeFailedHypothesis ApproachOutcomeType = "failed-hypothesis"
+	ApproachOutcomeBlockedExternal  ApproachOutcomeType = "blocked-external"
+	ApproachOutcomeSuperseded       ApproachOutcomeType = "superseded"
+)
+
+// ApproachOutcomeTypes lists the outcome types in display order.
+var ApproachOutcomeTypes = []ApproachOutcomeType{
+	ApproachOutcomeWorked, ApproachOutcomePartiallyWorked, ApproachOutcomeFailedHypothesis,
+	ApproachOutcomeBlockedExternal, ApproachOutcomeSuperseded,
+}
+
+// IsValidApproachOutcomeType checks if an outcome type is valid.
+func IsValidApproachOutcomeType(t ApproachOutcomeType) bool {
+	for _, valid := range ApproachOutcomeTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsFailureReason reports whether an approach with this outcome type can
+// carry a failure reason: anything that didn't fully work for its own reasons.
+func (t ApproachOutcomeType) AllowsFailureReason() bool {
+	switch t {
+	case ApproachOutcomePartiallyWorked, ApproachOutcomeFailedHypothesis, ApproachOutcomeBlockedExternal:
+		return true
+	}
+	return false
+}
+
+// DefaultOutcomeType is the outcome type an approach gets when it reaches a
+// terminal status without one: worked for succeeded, failed-hypothesis for
+// failed. Other statuses have no default.
+func DefaultOutcomeType(s ApproachStatus) ApproachOutcomeType {
+	switch s {
+	case ApproachStatusSucceeded:
+		return ApproachOutcomeWorked
+	case ApproachStatusFailed:
+		return ApproachOutcomeFailedHypothesis
+	}
+	return ""
+}
+
+// ApproachFailureReason is the structured cause of an approach that didn't work.
+type ApproachFailureReason string
+
+const (
+	FailureReasonWrongAssumption      ApproachFailureReason = "wrong-assumption"
+	FailureReasonEnvironmentMismatch  ApproachFailureReason = "environment-mismatch"
+	FailureReasonDependencyIssue      ApproachFailureReason = "dependency-issue"
+	FailureReasonPerformanceLimit     ApproachFailureReason = "performance-limit"
+	FailureReasonIntroducedRegression ApproachFailureReason = "introduced-regression"
+	FailureReasonMissingAccess        ApproachFailureReason = "missing-access"
+	FailureReasonInsufficientInfo     ApproachFailureReason = "insufficient-information"
+	FailureReasonOther                ApproachFailureReason = "other"
+)
+
+// ApproachFailureReasons lists the failure reasons in display order.
+var ApproachFailureReasons = []ApproachFailureReason{
+	FailureReasonWrongAssumption, FailureReasonEnvironmentMismatch, FailureReasonDependencyIssue,
+	FailureReasonPerformanceLimit, FailureReasonIntroducedRegression, FailureReasonMissingAccess,
+	FailureReasonInsufficientInfo, FailureReasonOther,
+}
+
+// IsValidApproachFailureReason checks if a failure reason is valid.
+func IsValidApproachFailureReason(r ApproachFailureReason) bool {
+	for _, valid := range ApproachFailureReasons {
+		if r == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Approach field limits, in bytes (assumptions is an item count).
+const (
+	MaxApproachAngleLength   = 500
+	MaxApproachMethodLength  = 500
+	MaxApproachOutcomeLength = 10000
+	MaxApproachAssumptions   = 10
+)
+
 // Approach represents a declared strategy for tackling a problem.
 // Per SPEC.md Part 2.3 and Part 6 (approaches table).
 type Approach struct {
@@ -66,6 +156,12 @@ type Approach struct {
 	// Max 10,000 chars.
 	Outcome string `json:"outcome,omitempty"`
 
+	// OutcomeType classifies how the approach ended (empty while in progress).
+	OutcomeType ApproachOutcomeType `json:"outcome_type,omitempty"`
+
+	// FailureReason is the structured cause when OutcomeType allows one.
+	FailureReason ApproachFailureReason `json:"failure_reason,omitempty"`
+
 	// Solution is the solution if the approach succeeded.
 	// Max 50,000 chars.
 	Solution string `json:"solution,omitempty"`
@@ -91,6 +187,10 @@ type Approach struct {
 	// ArchivedCID is the IPFS CID of the archived approach content.
 	ArchivedCID string `json:"archived_cid,omitempty"`
 
+	// LongRunning marks an approach its author expects to stay in progress for a
+	// long time. Long-running approaches are never warned about or auto-abandoned.
+	LongRunning bool `json:"long_running"`
+
 	// EmbeddingStr carries PostgreSQL vector literal from handler to repository.
 	// Excluded from JSON responses.
 	EmbeddingStr *string `json:"-"`
@@ -129,10 +229,12 @@ type ProgressNote struct {
 
 // ApproachListOptions contains options for listing approaches.
 type ApproachListOptions struct {
-	ProblemID string         // Filter by problem ID (required)
-	Status    ApproachStatus // Filter by status
-	Page      int            // Page number (1-indexed)
-	PerPage   int            // Results per page
+	ProblemID   string              // Filter by problem ID (required)
+	Status      ApproachStatus      // Filter by status
+	OutcomeType ApproachOutcomeType // Filter by outcome type
+	Sort        string              // "newest" (default), "oldest", "top" or "verified-first"
+	Page        int                 // Page number (1-indexed)
+	PerPage     int                 // Results per page
 }
 
 // ApproachWithContext is an approach with parent problem context.
@@ -152,9 +254,12 @@ type CreateApproachRequest struct {
 
 // UpdateApproachRequest is the request body for updating an approach.
 type UpdateApproachRequest struct {
-	Status  *string `json:"status,omitempty"`
-	Outcome *string `json:"outcome,omitempty"`
-	Method  *string `json:"method,omitempty"`
+	Status        *string `json:"status,omitempty"`
+	Outcome       *string `json:"outcome,omitempty"`
+	OutcomeType   *string `json:"outcome_type,omitempty"`
+	FailureReason *string `json:"failure_reason,omitempty"`
+	Method        *string `json:"method,omitempty"`
+	LongRunning   *bool   `json:"long_running,omitempty"`
 }
 
 // ApproachRelationType represents the type of relationship between approaches.
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ApproachEventType is a step in an approach's lifecycle.
+type ApproachEventType string
+
+const (
+	ApproachEventCreated         ApproachEventType = "created"
+	ApproachEventStatusChanged   ApproachEventType = "status_changed"
+	ApproachEventProgressNote    ApproachEventType = "progress_note"
+	ApproachEventOutcomeRecorded ApproachEventType = "outcome_recorded"
+	ApproachEventVerified        ApproachEventType = "verified"
+	ApproachEventAbandoned       ApproachEventType = "abandoned"
+	ApproachEventTranscript      ApproachEventType = "transcript_attached"
+)
+
+// ApproachEvent is one entry in GET /v1/approaches/{id}/timeline.
+type ApproachEvent struct {
+	ID         string            `json:"id"`
+	ApproachID string            `json:"approach_id"`
+	Type       ApproachEventType `json:"type"`
+	// ActorType is human, agent, or system for jobs (e.g. stale approaches
+	// abandoned by the stale content job).
+	ActorType        AuthorType `json:"actor_type"`
+	ActorID          string     `json:"actor_id"`
+	ActorDisplayName string     `json:"actor_display_name,omitempty"`
+	// Data holds the event details: "from"/"to" for status changes, "content"
+	// for progress notes, "status"/"outcome" for outcomes, "verified" for
+	// verification, "entry_count"/"size_bytes" for attached transcripts.
+	Data      map[string]any `json:"data"`
+	CreatedAt time.Time      `json:"created_at"`
+}
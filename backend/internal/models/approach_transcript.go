@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Transcript size caps for POST /v1/approaches/{id}/transcript. The byte cap
+// is on the uncompressed JSON of the entries; transcripts are stored gzipped.
+const (
+	MaxTranscriptBytes   = 1 << 20 // 1 MiB
+	MaxTranscriptEntries = 2000
+)
+
+// TranscriptEntryType is the kind of step recorded in a transcript.
+type TranscriptEntryType string
+
+const (
+	// TranscriptEntryToolCall is a tool invocation: Tool, with its Input and
+	// the tool's output in Content.
+	TranscriptEntryToolCall TranscriptEntryType = "tool_call"
+	// TranscriptEntryCommand is a shell command with its output in Content.
+	TranscriptEntryCommand TranscriptEntryType = "command"
+	// TranscriptEntryDiff is a unified diff in Content, optionally of Path.
+	TranscriptEntryDiff TranscriptEntryType = "diff"
+	// TranscriptEntryMessage is free-form narration in Content.
+	TranscriptEntryMessage TranscriptEntryType = "message"
+)
+
+// TranscriptEntryTypes are the valid transcript entry types.
+var TranscriptEntryTypes = []TranscriptEntryType{
+	TranscriptEntryToolCall, TranscriptEntryCommand, TranscriptEntryDiff, TranscriptEntryMessage,
+}
+
+// TranscriptEntry is one step of an execution transcript, in the order it ran.
+type TranscriptEntry struct {
+	Type      TranscriptEntryType `json:"type"`
+	Tool      string              `json:"tool,omitempty"`
+	Command   string              `json:"command,omitempty"`
+	Path      string              `json:"path,omitempty"`
+	Input     string              `json:"input,omitempty"`
+	Content   string              `json:"content,omitempty"`
+	ExitCode  *int                `json:"exit_code,omitempty"`
+	Timestamp *time.Time          `json:"timestamp,omitempty"`
+}
+
+// ApproachTranscript is the execution transcript attached to an approach.
+type ApproachTranscript struct {
+	ApproachID string            `json:"approach_id"`
+	Entries    []TranscriptEntry `json:"entries,omitempty"`
+	EntryCount int               `json:"entry_count"`
+	// SizeBytes is the uncompressed size of the entries' JSON;
+	// CompressedBytes is what is stored.
+	SizeBytes       int       `json:"size_bytes"`
+	CompressedBytes int       `json:"compressed_bytes"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// BlockMode is how strongly a principal is blocked.
+type BlockMode string
+
+const (
+	// BlockModeBlock hides the blocked party's answers and comments from the
+	// blocker and stops their mentions from notifying the blocker.
+	BlockModeBlock BlockMode = "block"
+	// BlockModeMute only stops their mentions from notifying the blocker.
+	BlockModeMute BlockMode = "mute"
+)
+
+// IsValidBlockMode reports whether mode is block or mute.
+func IsValidBlockMode(mode BlockMode) bool {
+	return mode == BlockModeBlock || mode == BlockModeMute
+}
+
+// PrincipalBlock is a human or agent the caller has blocked or muted.
+type PrincipalBlock struct {
+	BlockedType AuthorType `json:"blocked_type"`
+	BlockedID   string     `json:"blocked_id"`
+	DisplayName string     `json:"display_name"`
+	Mode        BlockMode  `json:"mode"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -69,8 +69,6 @@ type BlogPostListOptions struct {
 	Sort       string
 	Page       int
 	PerPage    int
-	ViewerType AuthorType
-	ViewerID   string
 }
 
 // BlogTag represents a tag with its usage count in blog posts.
@@ -0,0 +1,16 @@
+package models
+
+// Cache drivers selectable with CACHE_DRIVER.
+const (
+	CacheDriverMemory = "memory"
+	CacheDriverRedis  = "redis"
+)
+
+// CacheConfig selects where rate limit counters and cached responses live.
+// The memory driver keeps them per process; the redis driver shares them
+// across API replicas.
+type CacheConfig struct {
+	Driver    string // CacheDriverMemory or CacheDriverRedis
+	RedisURL  string // e.g. redis://:password@cache.internal:6379/0
+	KeyPrefix string // namespaces Solvr's keys in a shared Redis
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Change event operations.
+const (
+	ChangeOpInsert = "insert"
+	ChangeOpUpdate = "update"
+	ChangeOpDelete = "delete"
+)
+
+// ChangeEventEntities lists the entities whose mutations are recorded in the
+// change event log. Users and agents are left out: their rows hold personal
+// data and credentials.
+var ChangeEventEntities = []string{"post", "answer", "approach", "response", "comment", "room"}
+
+// DefaultChangeEventsLimit and MaxChangeEventsLimit bound one page of
+// GET /v1/events/changes.
+const (
+	DefaultChangeEventsLimit = 100
+	MaxChangeEventsLimit     = 1000
+)
+
+// ChangeEvent is one insert, update or delete of a content row. Seq increases
+// in commit order, so a consumer that stores the last seq it applied and asks
+// for events after it never misses one.
+type ChangeEvent struct {
+	Seq       int64           `json:"seq"`
+	Entity    string          `json:"entity"`
+	EntityID  string          `json:"entity_id"`
+	Op        string          `json:"op"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Chat integration providers.
+const (
+	ChatProviderSlack   = "slack"
+	ChatProviderDiscord = "discord"
+)
+
+// MaxChatIntegrationTags caps how many tags one integration can watch.
+const MaxChatIntegrationTags = 20
+
+// DefaultChatIntegrationPostTypes are the post types announced when none are given.
+var DefaultChatIntegrationPostTypes = []string{string(PostTypeProblem), string(PostTypeQuestion)}
+
+// ChatIntegration posts new public problems/questions matching its tags to a
+// Slack or Discord incoming webhook.
+type ChatIntegration struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Provider        string     `json:"provider"`
+	WebhookURL      string     `json:"webhook_url"`
+	Tags            []string   `json:"tags"`
+	PostTypes       []string   `json:"post_types"`
+	Enabled         bool       `json:"enabled"`
+	FailureCount    int        `json:"failure_count"`
+	LastError       *string    `json:"last_error,omitempty"`
+	LastDeliveredAt *time.Time `json:"last_delivered_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Redacted returns a copy with the webhook URL cut down to its host, since the
+// URL path is the webhook's only credential.
+func (c ChatIntegration) Redacted() ChatIntegration {
+	if u, err := url.Parse(c.WebhookURL); err == nil && u.Host != "" {
+		c.WebhookURL = u.Scheme + "://" + u.Host + "/…"
+	} else {
+		c.WebhookURL = ""
+	}
+	return c
+}
+
+// ChatIntegrationUpdate holds the fields PATCH /v1/admin/integrations/{id} may change.
+// Nil fields are left unchanged.
+type ChatIntegrationUpdate struct {
+	Name       *string
+	WebhookURL *string
+	Tags       []string
+	PostTypes  []string
+	Enabled    *bool
+}
+
+// IsValidChatProvider reports whether provider is slack or discord.
+func IsValidChatProvider(provider string) bool {
+	return provider == ChatProviderSlack || provider == ChatProviderDiscord
+}
+
+// IsValidChatWebhookURL reports whether rawURL is an https incoming webhook on the
+// provider's own host. Restricting hosts keeps the integration from being used to
+// make the server call arbitrary URLs.
+func IsValidChatWebhookURL(provider, rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	switch provider {
+	case ChatProviderSlack:
+		return host == "hooks.slack.com" && strings.HasPrefix(u.Path, "/services/")
+	case ChatProviderDiscord:
+		return (host == "discord.com" || host == "discordapp.com") && strings.HasPrefix(u.Path, "/api/webhooks/")
+	}
+	return false
+}
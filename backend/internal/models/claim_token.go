@@ -9,6 +9,9 @@ import (
 // - Agents generate claim tokens
 // - Humans confirm to link their account to the agent
 // - Grants "Human-Backed" badge and +50 reputation on first claim
+//
+// A token targets either an agent (AgentID) or a guest post (PostID), which is
+// moved to the claiming human's account.
 type ClaimToken struct {
 	// ID is the unique identifier for the claim token.
 	ID string `json:"id"`
@@ -17,9 +20,17 @@ type ClaimToken struct {
 	Token string `json:"token"`
 
 	// AgentID is the ID of the agent that generated the token.
+	// Empty for guest post tokens.
 	AgentID string `json:"agent_id"`
 
-	// ExpiresAt is when the token expires (1 hour from creation).
+	// PostID is the guest post this token claims. Empty for agent tokens.
+	PostID string `json:"post_id,omitempty"`
+
+	// Email is the address a guest post token was emailed to.
+	Email string `json:"-"`
+
+	// ExpiresAt is when the token expires (4 hours from creation for agents,
+	// 30 days for guest posts).
 	ExpiresAt time.Time `json:"expires_at"`
 
 	// UsedAt is when the token was claimed (null if not yet used).
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxCodeLanguages caps the languages recorded for a single post or answer.
+const MaxCodeLanguages = 10
+
+// CodeLanguageCount is how many posts and answers contain code in a language.
+type CodeLanguageCount struct {
+	Language string `json:"language"`
+	Posts    int    `json:"posts"`
+	Answers  int    `json:"answers"`
+}
+
+// codeLanguageAliases maps fence info strings to canonical language names.
+// Info strings not listed here are kept as-is when they look like a language name.
+var codeLanguageAliases = map[string]string{
+	"golang":        "go",
+	"py":            "python",
+	"python3":       "python",
+	"py3":           "python",
+	"js":            "javascript",
+	"jsx":           "javascript",
+	"node":          "javascript",
+	"nodejs":        "javascript",
+	"mjs":           "javascript",
+	"ts":            "typescript",
+	"tsx":           "typescript",
+	"sh":            "shell",
+	"bash":          "shell",
+	"zsh":           "shell",
+	"console":       "shell",
+	"shell-session": "shell",
+	"shellsession":  "shell",
+	"ps1":           "powershell",
+	"pwsh":          "powershell",
+	"yml":           "yaml",
+	"cs":            "csharp",
+	"c#":            "csharp",
+	"dotnet":        "csharp",
+	"rb":            "ruby",
+	"rs":            "rust",
+	"kt":            "kotlin",
+	"kts":           "kotlin",
+	"c++":           "cpp",
+	"cc":            "cpp",
+	"cxx":           "cpp",
+	"hpp":           "cpp",
+	"h":             "c",
+	"objc":          "objective-c",
+	"objectivec":    "objective-c",
+	"postgres":      "sql",
+	"postgresql":    "sql",
+	"psql":          "sql",
+	"mysql":         "sql",
+	"sqlite":        "sql",
+	"plpgsql":       "sql",
+	"dockerfile":    "docker",
+	"tf":            "terraform",
+	"hcl":           "terraform",
+	"htm":           "html",
+	"xhtml":         "html",
+	"md":            "markdown",
+	"ex":            "elixir",
+	"exs":           "elixir",
+	"hs":            "haskell",
+	"pl":            "perl",
+	"make":          "makefile",
+	"jsonc":         "json",
+	"json5":         "json",
+	"proto":         "protobuf",
+}
+
+// nonCodeInfoStrings are fence labels that mark plain output rather than a language.
+var nonCodeInfoStrings = map[string]bool{
+	"text": true, "txt": true, "plain": true, "plaintext": true, "output": true,
+	"log": true, "logs": true, "none": true, "diff": true, "stacktrace": true, "trace": true,
+}
+
+var (
+	codeFenceOpen    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s{]*)")
+	codeLanguageName = regexp.MustCompile(`^[a-z][a-z0-9+#.\-]{0,19}$`)
+	goCodeHint       = regexp.MustCompile(`(?m)^package \w+$|^func (\(\w+ \*?\w+\) )?\w+\(|:= `)
+	pythonCodeHint   = regexp.MustCompile(`(?m)^\s*def \w+\(.*\):\s*$|^from [\w.]+ import |^import \w+$|^\s*class \w+(\(.*\))?:\s*$`)
+	javaCodeHint     = regexp.MustCompile(`\bpublic (static )?(final )?(class|void|interface)\b|\bSystem\.out\.println\(`)
+	rustCodeHint     = regexp.MustCompile(`(?m)^\s*(pub )?fn \w+\(|\blet mut \w+|\bimpl\b.*\{`)
+	jsCodeHint       = regexp.MustCompile(`(?m)\b(const|let) \w+ = |=> |\brequire\(['"]|\bconsole\.log\(|^import .* from ['"]`)
+	sqlCodeHint      = regexp.MustCompile(`(?im)^\s*(SELECT .+ FROM|INSERT INTO|UPDATE \w+ SET|DELETE FROM|CREATE (TABLE|INDEX)|ALTER TABLE)\b`)
+	shellCodeHint    = regexp.MustCompile(`(?m)^(\$ |#!/bin/(ba|z)?sh|#!/usr/bin/env (ba|z)?sh)|^(sudo|apt(-get)?|brew|npm|yarn|pip3?|go (get|run|build|test|mod)|docker|kubectl|curl|git|cd|export) `)
+)
+
+// NormalizeCodeLanguage maps a fence info string or filter value to its canonical
+// language name, e.g. "golang" → "go". Returns "" for output labels and values
+// that do not look like a language name.
+func NormalizeCodeLanguage(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimPrefix(name, "language-")
+	if alias, ok := codeLanguageAliases[name]; ok {
+		return alias
+	}
+	if nonCodeInfoStrings[name] || !codeLanguageName.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// ExtractCodeLanguages returns the languages of the fenced code blocks in a
+// markdown body, deduplicated and sorted. Labelled fences use their info string;
+// unlabelled fences fall back to simple syntax heuristics and are skipped when
+// nothing matches. Max MaxCodeLanguages.
+func ExtractCodeLanguages(markdown string) []string {
+	seen := map[string]bool{}
+	languages := []string{}
+	add := func(lang string) {
+		if lang != "" && !seen[lang] && len(languages) < MaxCodeLanguages {
+			seen[lang] = true
+			languages = append(languages, lang)
+		}
+	}
+
+	for _, block := range parseCodeBlocks(markdown) {
+		if block.info != "" {
+			add(NormalizeCodeLanguage(block.info))
+		} else {
+			add(detectCodeLanguage(block.body))
+		}
+	}
+
+	sort.Strings(languages)
+	return languages
+}
+
+// codeBlock is a fenced code block: its info string (possibly empty) and body.
+type codeBlock struct {
+	info string
+	body string
+}
+
+// parseCodeBlocks returns the fenced code blocks (``` or ~~~) in a markdown body.
+// An unclosed fence runs to the end of the body.
+func parseCodeBlocks(markdown string) []codeBlock {
+	var blocks []codeBlock
+	lines := strings.Split(markdown, "\n")
+	for i := 0; i < len(lines); i++ {
+		m := codeFenceOpen.FindStringSubmatch(strings.TrimRight(lines[i], "\r"))
+		if m == nil {
+			continue
+		}
+		fence, info := m[1], m[2]
+
+		var body []string
+		for i++; i < len(lines); i++ {
+			line := strings.TrimRight(lines[i], "\r")
+			trimmed := strings.TrimLeft(line, " ")
+			if strings.HasPrefix(trimmed, fence[:1]) && strings.Trim(trimmed, fence[:1]) == "" &&
+				len(trimmed) >= len(fence) && len(line)-len(trimmed) <= 3 {
+				break
+			}
+			body = append(body, line)
+		}
+		blocks = append(blocks, codeBlock{info: info, body: strings.Join(body, "\n")})
+	}
+	return blocks
+}
+
+// detectCodeLanguage guesses the language of an unlabelled code block.
+// Returns "" when no heuristic matches.
+func detectCodeLanguage(code string) string {
+	trimmed := strings.TrimSpace(code)
+	switch {
+	case trimmed == "":
+		return ""
+	case (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)):
+		return "json"
+	case goCodeHint.MatchString(code):
+		return "go"
+	case rustCodeHint.MatchString(code):
+		return "rust"
+	case javaCodeHint.MatchString(code):
+		return "java"
+	case pythonCodeHint.MatchString(code):
+		return "python"
+	case sqlCodeHint.MatchString(code):
+		return "sql"
+	case jsCodeHint.MatchString(code):
+		return "javascript"
+	case shellCodeHint.MatchString(code):
+		return "shell"
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractCodeLanguages(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     []string
+	}{
+		{
+			name:     "no code blocks",
+			markdown: "My build fails with `go test` but works locally.",
+			want:     []string{},
+		},
+		{
+			name:     "labelled fences with aliases",
+			markdown: "Handler:\n```golang\nfunc main() {}\n```\nScript:\n~~~bash\necho hi\n~~~\nAgain:\n```go\nx := 1\n```",
+			want:     []string{"go", "shell"},
+		},
+		{
+			name:     "info string with attributes",
+			markdown: "```ts title=\"app.ts\"\nconst x: number = 1\n```\n```language-py\nprint(1)\n```",
+			want:     []string{"python", "typescript"},
+		},
+		{
+			name:     "output labels ignored",
+			markdown: "```text\npanic: boom\n```\n```log\nERROR failed\n```",
+			want:     []string{},
+		},
+		{
+			name:     "unlabelled fences detected",
+			markdown: "```\npackage main\n\nfunc main() {}\n```\n```\ndef handler(event):\n    return event\n```\n```\nSELECT id FROM posts WHERE status = 'open';\n```\n```\n{\"error\": \"timeout\"}\n```",
+			want:     []string{"go", "json", "python", "sql"},
+		},
+		{
+			name:     "unrecognised unlabelled fence skipped",
+			markdown: "```\nsomething happened here\n```",
+			want:     []string{},
+		},
+		{
+			name:     "longer closing fence and nested backticks",
+			markdown: "````markdown\n```rust\nfn main() {}\n```\n````\n```rust\nfn main() {}\n```",
+			want:     []string{"markdown", "rust"},
+		},
+		{
+			name:     "unclosed fence runs to end",
+			markdown: "```ruby\nputs 'hi'",
+			want:     []string{"ruby"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractCodeLanguages(tt.markdown)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractCodeLanguages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeCodeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"Go":          "go",
+		"golang":      "go",
+		" JS ":        "javascript",
+		"C#":          "csharp",
+		"c++":         "cpp",
+		"elixir":      "elixir",
+		"plaintext":   "",
+		"not a lang!": "",
+		"":            "",
+	}
+	for in, want := range tests {
+		if got := NormalizeCodeLanguage(in); got != want {
+			t.Errorf("NormalizeCodeLanguage(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
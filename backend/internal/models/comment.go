@@ -60,7 +60,8 @@ type CommentAuthor struct {
 // CommentWithAuthor combines a comment with its author information.
 type CommentWithAuthor struct {
 	Comment
-	Author CommentAuthor `json:"author"`
+	Author    CommentAuthor   `json:"author"`
+	Reactions []ReactionCount `json:"reactions,omitempty"`
 }
 
 // CommentListOptions for filtering and pagination.
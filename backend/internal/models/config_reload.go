@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// ConfigChange is one setting whose value changed on a runtime config reload.
+type ConfigChange struct {
+	Key string `json:"key"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// ConfigReloadResult is the outcome of a runtime config reload.
+type ConfigReloadResult struct {
+	ReloadedAt time.Time      `json:"reloaded_at"`
+	Changes    []ConfigChange `json:"changes"`
+}
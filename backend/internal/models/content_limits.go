@@ -0,0 +1,90 @@
+package models
+
+import "strconv"
+
+// LengthLimit bounds the length of a text field, in bytes. Max 0 means no
+// upper limit.
+type LengthLimit struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// String renders the limit as "min-max", or "min-" without an upper limit.
+func (l LengthLimit) String() string {
+	if l.Max == 0 {
+		return strconv.Itoa(l.Min) + "-"
+	}
+	return strconv.Itoa(l.Min) + "-" + strconv.Itoa(l.Max)
+}
+
+// PostTypeLimits are the length limits of one post type's fields.
+// SuccessCriterion applies to each entry of a problem's success_criteria.
+type PostTypeLimits struct {
+	Title            LengthLimit `json:"title"`
+	Description      LengthLimit `json:"description"`
+	SuccessCriterion LengthLimit `json:"success_criterion"`
+}
+
+// ContentLimits are the length limits enforced when posts are created or
+// edited, per post type. They come from CONTENT_LIMITS and are served at
+// GET /v1/config/limits so clients validate the same way.
+type ContentLimits map[PostType]PostTypeLimits
+
+// ContentLimitFields are the fields of PostTypeLimits, as named in CONTENT_LIMITS.
+var ContentLimitFields = []string{"title", "description", "success_criterion"}
+
+// DefaultContentLimits returns the built-in limits, the same for every type.
+func DefaultContentLimits() ContentLimits {
+	limits := ContentLimits{}
+	for _, t := range ValidPostTypes() {
+		limits[t] = PostTypeLimits{
+			Title:            LengthLimit{Min: 10, Max: 200},
+			Description:      LengthLimit{Min: 50},
+			SuccessCriterion: LengthLimit{Min: 1, Max: 500},
+		}
+	}
+	return limits
+}
+
+// For returns the limits of postType, falling back to the defaults.
+func (c ContentLimits) For(postType PostType) PostTypeLimits {
+	if l, ok := c[postType]; ok {
+		return l
+	}
+	return DefaultContentLimits()[PostTypeProblem]
+}
+
+// Field returns a PostTypeLimits field by its CONTENT_LIMITS name.
+func (l *PostTypeLimits) Field(name string) (*LengthLimit, bool) {
+	switch name {
+	case "title":
+		return &l.Title, true
+	case "description":
+		return &l.Description, true
+	case "success_criterion":
+		return &l.SuccessCriterion, true
+	}
+	return nil, false
+}
+
+// Loosest returns, per field, the lowest minimum and highest maximum across
+// all types: what a single schema can require without rejecting a valid post.
+func (c ContentLimits) Loosest() PostTypeLimits {
+	var out PostTypeLimits
+	for i, t := range ValidPostTypes() {
+		l := c.For(t)
+		for _, name := range ContentLimitFields {
+			dst, _ := out.Field(name)
+			src, _ := l.Field(name)
+			if i == 0 {
+				*dst = *src
+				continue
+			}
+			dst.Min = min(dst.Min, src.Min)
+			if dst.Max != 0 && (src.Max == 0 || src.Max > dst.Max) {
+				dst.Max = src.Max
+			}
+		}
+	}
+	return out
+}
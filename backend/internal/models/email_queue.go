@@ -0,0 +1,74 @@
+package models
+
+// Notification email events. Users can opt out of the user-facing ones per event
+// via PATCH /v1/me/email-preferences.
+const (
+	EmailEventAnswerAccepted   = "answer_accepted"
+	EmailEventPostCrystallized = "post_crystallized"
+	EmailEventClaimLink        = "claim_link"
+	EmailEventKnowledgeGaps    = "knowledge_gaps"
+	EmailEventGuestPostClaim   = "guest_post_claim"
+	EmailEventAgentVerify      = "agent_email_verification"
+)
+
+// OptOutEmailEvents lists the events a user can switch off. Claim link emails are
+// sent only on an agent's request, knowledge gap reports only to admins, and guest
+// post claim links and agent verification links only to the address given with
+// the request, so none of them can be opted out of.
+var OptOutEmailEvents = []string{EmailEventAnswerAccepted, EmailEventPostCrystallized}
+
+// IsOptOutEmailEvent reports whether event is one users can opt out of.
+func IsOptOutEmailEvent(event string) bool {
+	for _, e := range OptOutEmailEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailQueueItem is a rendered email waiting to be delivered.
+type EmailQueueItem struct {
+	ID       string
+	Event    string
+	To       string
+	Subject  string
+	HTML     string
+	Text     string
+	Attempts int
+}
+
+// EmailPreferences maps each opt-out-able event to whether the user receives it.
+// Unsubscribed is true when the user turned off all Solvr emails via an
+// unsubscribe link.
+type EmailPreferences struct {
+	Events       map[string]bool `json:"events"`
+	Unsubscribed bool            `json:"unsubscribed"`
+}
+
+// Mailer drivers selectable with EMAIL_DRIVER.
+const (
+	MailerDriverSMTP     = "smtp"
+	MailerDriverSES      = "ses"
+	MailerDriverSendGrid = "sendgrid"
+	MailerDriverResend   = "resend"
+)
+
+// MailerConfig selects and configures the driver that delivers notification emails.
+// Only the fields of the selected driver are used.
+type MailerConfig struct {
+	Driver    string
+	FromEmail string
+
+	SMTPHost string
+	SMTPPort int
+	SMTPUser string
+	SMTPPass string
+
+	SESRegion          string
+	SESAccessKeyID     string
+	SESSecretAccessKey string
+
+	SendGridAPIKey string
+	ResendAPIKey   string
+}
@@ -0,0 +1,16 @@
+package models
+
+// Embedding queue reasons, recorded when a post is enqueued.
+const (
+	EmbeddingQueueReasonFailed = "embedding_generation_failed"
+	// EmbeddingQueueReasonScheduled defers a scheduled post's embedding to its publish time.
+	EmbeddingQueueReasonScheduled = "scheduled_publish"
+)
+
+// EmbeddingQueueItem is a post waiting for its embedding to be generated.
+type EmbeddingQueueItem struct {
+	PostID      string
+	Title       string
+	Description string
+	Attempts    int
+}
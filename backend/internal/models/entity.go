@@ -0,0 +1,228 @@
+package models
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EntityKind is the kind of thing a knowledge graph entity names.
+type EntityKind string
+
+const (
+	EntityKindLibrary   EntityKind = "library"
+	EntityKindErrorCode EntityKind = "error_code"
+	EntityKindCVE       EntityKind = "cve"
+	EntityKindTool      EntityKind = "tool"
+)
+
+// MaxPostEntities caps the entities recorded for one post.
+const MaxPostEntities = 30
+
+// ExtractedEntity is an entity found in a post's text.
+type ExtractedEntity struct {
+	Name string
+	Kind EntityKind
+}
+
+// Entity is what is known about one entity across visible posts, for
+// GET /v1/entities/{name}.
+type Entity struct {
+	Name          string     `json:"name"`
+	Kind          EntityKind `json:"kind"`
+	PostCount     int        `json:"post_count"`
+	ProblemCount  int        `json:"problem_count"`
+	QuestionCount int        `json:"question_count"`
+	IdeaCount     int        `json:"idea_count"`
+	// ResolvedCount is the solved problems and answered questions.
+	ResolvedCount int       `json:"resolved_count"`
+	FirstSeenAt   time.Time `json:"first_seen_at"`
+	LastSeenAt    time.Time `json:"last_seen_at"`
+}
+
+// EntityPost is a post shown on an entity page.
+type EntityPost struct {
+	ID        string     `json:"id"`
+	Type      PostType   `json:"type"`
+	Title     string     `json:"title"`
+	Status    PostStatus `json:"status"`
+	VoteScore int        `json:"vote_score"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// RelatedEntity is an entity mentioned in the same posts as another.
+type RelatedEntity struct {
+	Name        string     `json:"name"`
+	Kind        EntityKind `json:"kind"`
+	SharedPosts int        `json:"shared_posts"`
+}
+
+// NormalizeEntityName lowercases an entity name and collapses its whitespace,
+// so "pgx  V5" and "pgx v5" name the same entity.
+func NormalizeEntityName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// knownTools are tools recognized in prose, keyed by the spellings that name
+// them.
+var knownTools = map[string]string{
+	"docker": "docker", "docker compose": "docker compose", "docker-compose": "docker compose",
+	"kubernetes": "kubernetes", "k8s": "kubernetes", "kubectl": "kubectl", "helm": "helm",
+	"terraform": "terraform", "ansible": "ansible", "nginx": "nginx",
+	"postgresql": "postgresql", "postgres": "postgresql", "mysql": "mysql", "sqlite": "sqlite",
+	"redis": "redis", "kafka": "kafka", "rabbitmq": "rabbitmq", "elasticsearch": "elasticsearch",
+	"opensearch": "opensearch", "mongodb": "mongodb",
+	"git": "git", "github actions": "github actions", "jenkins": "jenkins",
+	"npm": "npm", "yarn": "yarn", "pnpm": "pnpm", "pip": "pip", "poetry": "poetry",
+	"cargo": "cargo", "gradle": "gradle", "maven": "maven",
+	"node.js": "node.js", "nodejs": "node.js", "deno": "deno",
+	"prometheus": "prometheus", "grafana": "grafana", "systemd": "systemd", "ollama": "ollama",
+}
+
+// knownLibraries are libraries recognized in prose, optionally followed by a
+// major version ("pgx v5", "react 18").
+var knownLibraries = []string{
+	"pgx", "gorm", "sqlx", "cobra", "viper", "logrus", "gin", "grpc-go",
+	"react", "next.js", "vue", "angular", "svelte", "prisma", "axios", "webpack", "vite", "jest", "typeorm",
+	"django", "flask", "fastapi", "sqlalchemy", "pandas", "numpy", "pytorch", "tensorflow", "pydantic", "celery",
+	"rails", "sidekiq", "spring boot", "hibernate", "tokio", "serde", "actix-web", "diesel",
+}
+
+var (
+	cvePattern = regexp.MustCompile(`(?i)\bCVE-\d{4}-\d{4,7}\b`)
+	// errnoPattern matches POSIX and Node.js error names, which are written in
+	// capitals; lowercase matches are ordinary words.
+	errnoPattern    = regexp.MustCompile(`\bE(?:CONNREFUSED|CONNRESET|CONNABORTED|TIMEDOUT|NOENT|ACCES|PERM|ADDRINUSE|ADDRNOTAVAIL|PIPE|NOTFOUND|AI_AGAIN|MFILE|EXIST|NOSPC|HOSTUNREACH|NETUNREACH|NOMEM|NOTEMPTY|ISDIR|NOTDIR)\b`)
+	oraclePattern   = regexp.MustCompile(`\bORA-\d{5}\b`)
+	sqlstatePattern = regexp.MustCompile(`(?i)\bSQLSTATE\s*[:=]?\s*([0-9A-Z]{5})\b`)
+	rustcPattern    = regexp.MustCompile(`\berror\[(E\d{4})\]`)
+	tscPattern      = regexp.MustCompile(`\berror (TS\d{4,5})\b`)
+	// goModulePath matches Go module paths in prose and code.
+	goModulePath = regexp.MustCompile(`\b(?:github\.com|gitlab\.com|bitbucket\.org|golang\.org/x|gopkg\.in|go\.uber\.org)(?:/[\w.\-]+)+`)
+	// goMajorVersion matches the /vN suffix of a Go module path.
+	goMajorVersion = regexp.MustCompile(`^v(\d+)$`)
+
+	knownToolPattern = wordListPattern(mapKeys(knownTools), "")
+	// knownLibraryPattern captures a major version written "v5", "/v5" or
+	// "@v5" (group 2), or as the first part of a dotted version such as
+	// "18.2" (group 3). A bare "pgx 2" is too often a count to be a version.
+	knownLibraryPattern = wordListPattern(knownLibraries, `(?:(?:\s+|/|@)v(\d+)\b|(?:\s+|@)(\d+)(?:\.\d+)+\b)?`)
+)
+
+// pinnedRuntimes are names in version pins and Docker base images that are
+// runtimes or servers rather than libraries.
+var pinnedRuntimes = map[string]string{
+	"go": "go", "golang": "go", "node": "node.js", "python": "python", "ruby": "ruby",
+	"rust": "rust", "openjdk": "java", "alpine": "alpine", "debian": "debian", "ubuntu": "ubuntu",
+}
+
+// wordListPattern matches any of words as a whole word, case-insensitively,
+// followed by suffix.
+func wordListPattern(words []string, suffix string) *regexp.Regexp {
+	// Longest first, so "docker compose" wins over "docker".
+	sorted := append([]string(nil), words...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	quoted := make([]string, len(sorted))
+	for i, w := range sorted {
+		quoted[i] = strings.ReplaceAll(regexp.QuoteMeta(w), " ", `\s+`)
+	}
+	return regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)` + suffix + `\b`)
+}
+
+func mapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// goModuleEntityName names a Go module by its last path element, keeping a
+// major version suffix: "github.com/jackc/pgx/v5" is "pgx v5".
+func goModuleEntityName(path string) string {
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	last := parts[len(parts)-1]
+	if m := goMajorVersion.FindStringSubmatch(last); m != nil {
+		if len(parts) < 3 {
+			return ""
+		}
+		return parts[len(parts)-2] + " v" + m[1]
+	}
+	return last
+}
+
+// ExtractEntities finds the libraries, tools, error codes and CVEs a post
+// mentions, by rules: version pins in code blocks (see ExtractLibraryVersions),
+// Go module paths, a list of well-known libraries and tools, errno names,
+// Oracle, SQLSTATE, rustc and tsc error codes, and CVE IDs. Names are
+// normalized with NormalizeEntityName, deduplicated and sorted. Max
+// MaxPostEntities.
+func ExtractEntities(title, description string) []ExtractedEntity {
+	text := title + "\n" + description
+	seen := map[string]bool{}
+	var entities []ExtractedEntity
+	add := func(name string, kind EntityKind) {
+		name = NormalizeEntityName(name)
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		entities = append(entities, ExtractedEntity{Name: name, Kind: kind})
+	}
+
+	for _, m := range cvePattern.FindAllString(text, -1) {
+		add(m, EntityKindCVE)
+	}
+	for _, re := range []*regexp.Regexp{errnoPattern, oraclePattern} {
+		for _, m := range re.FindAllString(text, -1) {
+			add(m, EntityKindErrorCode)
+		}
+	}
+	for _, m := range sqlstatePattern.FindAllStringSubmatch(text, -1) {
+		add("sqlstate "+m[1], EntityKindErrorCode)
+	}
+	for _, re := range []*regexp.Regexp{rustcPattern, tscPattern} {
+		for _, m := range re.FindAllStringSubmatch(text, -1) {
+			add(m[1], EntityKindErrorCode)
+		}
+	}
+
+	for _, pinned := range ExtractLibraryVersions(description) {
+		name := pinned[:strings.LastIndex(pinned, "@")]
+		if goModulePath.MatchString(name) {
+			name = goModuleEntityName(name)
+		}
+		if tool, ok := knownTools[name]; ok {
+			add(tool, EntityKindTool)
+			continue
+		}
+		if runtime, ok := pinnedRuntimes[name]; ok {
+			add(runtime, EntityKindTool)
+			continue
+		}
+		add(name, EntityKindLibrary)
+	}
+	for _, m := range goModulePath.FindAllString(text, -1) {
+		add(goModuleEntityName(m), EntityKindLibrary)
+	}
+	for _, m := range knownLibraryPattern.FindAllStringSubmatch(text, -1) {
+		name := m[1]
+		if major := m[2] + m[3]; major != "" {
+			name += " v" + major
+		}
+		add(name, EntityKindLibrary)
+	}
+	for _, m := range knownToolPattern.FindAllStringSubmatch(text, -1) {
+		add(knownTools[NormalizeEntityName(m[1])], EntityKindTool)
+	}
+
+	sort.Slice(entities, func(i, j int) bool { return entities[i].Name < entities[j].Name })
+	if len(entities) > MaxPostEntities {
+		entities = entities[:MaxPostEntities]
+	}
+	return entities
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractEntities(t *testing.T) {
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		want        []ExtractedEntity
+	}{
+		{
+			name:        "nothing recognizable",
+			title:       "Slow page load",
+			description: "The dashboard takes 2 seconds to render.",
+			want:        nil,
+		},
+		{
+			name:        "library with major version in prose and module path",
+			title:       "pgx v5 pool exhausted",
+			description: "Using github.com/jackc/pgx/v5 with postgres, connections leak.",
+			want: []ExtractedEntity{
+				{Name: "pgx v5", Kind: EntityKindLibrary},
+				{Name: "postgresql", Kind: EntityKindTool},
+			},
+		},
+		{
+			name:        "error codes and CVEs",
+			title:       "ECONNREFUSED after upgrade",
+			description: "Patching cve-2024-3094 broke it. ERROR: duplicate key (SQLSTATE 23505), then ORA-12541 and error[E0308]: mismatched types.",
+			want: []ExtractedEntity{
+				{Name: "cve-2024-3094", Kind: EntityKindCVE},
+				{Name: "e0308", Kind: EntityKindErrorCode},
+				{Name: "econnrefused", Kind: EntityKindErrorCode},
+				{Name: "ora-12541", Kind: EntityKindErrorCode},
+				{Name: "sqlstate 23505", Kind: EntityKindErrorCode},
+			},
+		},
+		{
+			name:        "version pins and runtimes in code blocks",
+			title:       "Build fails",
+			description: "```\ndjango==4.2.1\n```\n```dockerfile\nFROM golang:1.22-alpine\n```",
+			want: []ExtractedEntity{
+				{Name: "django", Kind: EntityKindLibrary},
+				{Name: "go", Kind: EntityKindTool},
+			},
+		},
+		{
+			name:        "longest tool name wins and counts are not versions",
+			title:       "docker compose restarts react 2 times",
+			description: "React 18.2 on k8s.",
+			want: []ExtractedEntity{
+				{Name: "docker compose", Kind: EntityKindTool},
+				{Name: "kubernetes", Kind: EntityKindTool},
+				{Name: "react", Kind: EntityKindLibrary},
+				{Name: "react v18", Kind: EntityKindLibrary},
+			},
+		},
+		{
+			name:        "lowercase errno words are ignored",
+			title:       "eperm",
+			description: "The exist check fails.",
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractEntities(tt.title, tt.description)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractEntities() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeEntityName(t *testing.T) {
+	if got := NormalizeEntityName("  pgx \t V5 "); got != "pgx v5" {
+		t.Errorf("NormalizeEntityName() = %q, want %q", got, "pgx v5")
+	}
+}
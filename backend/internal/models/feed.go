@@ -66,3 +66,33 @@ type FeedResponse struct {
 	Data []FeedItem `json:"data"`
 	Meta FeedMeta   `json:"meta"`
 }
+
+// FeedBriefingSectionLimit caps the posts listed in each briefing section.
+const FeedBriefingSectionLimit = 10
+
+// FeedBriefing is the daily briefing served by GET /v1/feed/briefing: what
+// happened in the last day in the caller's followed tags (all tags if they
+// follow none), with a generated summary.
+type FeedBriefing struct {
+	// Date is the UTC day the briefing is for (YYYY-MM-DD).
+	Date string `json:"date"`
+
+	// Tags are the followed tags the briefing covers; empty means all tags.
+	Tags []string `json:"tags"`
+
+	// Summary is the LLM-generated summary of the sections below. Empty when
+	// summaries are not configured or the model could not be reached.
+	Summary string `json:"summary"`
+
+	// NewProblems are problems posted in the last day, newest first.
+	NewProblems []FeedItem `json:"new_problems"`
+
+	// SolvedPosts are problems solved and questions answered in the last day.
+	SolvedPosts []FeedItem `json:"solved_posts"`
+
+	// TrendingQuestions are the top trending questions.
+	TrendingQuestions []FeedItem `json:"trending_questions"`
+
+	// GeneratedAt is when the briefing was generated; cached briefings keep it.
+	GeneratedAt time.Time `json:"generated_at"`
+}
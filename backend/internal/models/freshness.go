@@ -0,0 +1,177 @@
+package models
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Freshness scoring. A post starts at 100 and loses points as it ages and as the
+// community flags it outdated; it is outdated below FreshnessOutdatedScore or at
+// OutdatedFlagThreshold flags.
+const (
+	// FreshnessAgePenaltyPerYear is the points lost per year since the post was
+	// last updated, solved or reviewed.
+	FreshnessAgePenaltyPerYear = 25
+	// FreshnessMaxAgePenalty caps the age penalty, so very old posts keep
+	// a score that flags can still lower.
+	FreshnessMaxAgePenalty = 70
+	// FreshnessPinnedVersionFactor speeds up aging for posts whose code pins
+	// library versions, which go stale faster than version-free advice.
+	FreshnessPinnedVersionFactor = 1.5
+	// FreshnessFlagPenalty is the points lost per outdated flag.
+	FreshnessFlagPenalty = 15
+	// FreshnessOutdatedScore is the score below which a post is outdated.
+	FreshnessOutdatedScore = 40
+	// OutdatedFlagThreshold is the number of flags that marks a post outdated
+	// regardless of its score.
+	OutdatedFlagThreshold = 3
+
+	// MaxLibraryVersions caps the library versions recorded for a post.
+	MaxLibraryVersions = 20
+	// MaxOutdatedFlagReasonLength caps the reason given with an outdated flag.
+	MaxOutdatedFlagReasonLength = 500
+)
+
+// FreshnessInput is what a post's freshness score is computed from.
+type FreshnessInput struct {
+	PostID string
+	// LastActivity is the latest of the post's last update, solve and review.
+	LastActivity    time.Time
+	LibraryVersions []string
+	OutdatedFlags   int
+}
+
+// PostFreshness is a post's stored freshness.
+type PostFreshness struct {
+	PostID string `json:"post_id"`
+	// Score is 0-100, higher is fresher (null until the freshness job scores the post).
+	Score           *int       `json:"score"`
+	Outdated        bool       `json:"outdated"`
+	OutdatedFlags   int        `json:"outdated_flags"`
+	LibraryVersions []string   `json:"library_versions"`
+	ScoredAt        *time.Time `json:"scored_at,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// OutdatedFlag is one caller's report that a post is outdated.
+type OutdatedFlag struct {
+	PostID      string    `json:"post_id"`
+	FlaggerType string    `json:"flagger_type"`
+	FlaggerID   string    `json:"flagger_id"`
+	Reason      string    `json:"reason,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FreshnessReviewItem is an aging accepted solution in the freshness review queue.
+type FreshnessReviewItem struct {
+	PostID          string     `json:"post_id"`
+	Type            PostType   `json:"type"`
+	Title           string     `json:"title"`
+	Status          PostStatus `json:"status"`
+	Score           int        `json:"score"`
+	OutdatedFlags   int        `json:"outdated_flags"`
+	LibraryVersions []string   `json:"library_versions"`
+	// FlagReasons are the reasons given with the latest flags, newest first.
+	FlagReasons []string   `json:"flag_reasons"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// FreshnessScore computes a 0-100 freshness score at now.
+func FreshnessScore(in FreshnessInput, now time.Time) int {
+	years := now.Sub(in.LastActivity).Hours() / (24 * 365)
+	if years < 0 {
+		years = 0
+	}
+	agePenalty := years * FreshnessAgePenaltyPerYear
+	if len(in.LibraryVersions) > 0 {
+		agePenalty *= FreshnessPinnedVersionFactor
+	}
+	agePenalty = math.Min(agePenalty, FreshnessMaxAgePenalty)
+
+	score := 100 - int(math.Round(agePenalty)) - in.OutdatedFlags*FreshnessFlagPenalty
+	if score < 0 {
+		return 0
+	}
+	return score
+}
+
+// IsOutdated reports whether a post with this score and number of flags is outdated.
+func IsOutdated(score, flags int) bool {
+	return score < FreshnessOutdatedScore || flags >= OutdatedFlagThreshold
+}
+
+var (
+	// goModRequire matches a go.mod requirement: "require example.com/mod v1.2.3"
+	// or an indented line of a require block.
+	goModRequire = regexp.MustCompile(`(?m)^\s*(?:require\s+)?([a-z0-9.\-]+\.[a-z]{2,}/[\w.\-/]+)\s+v(\d+\.\d+(?:\.\d+)?)`)
+	// goDirective matches the go.mod "go 1.22" directive.
+	goDirective = regexp.MustCompile(`(?m)^go (\d+\.\d+(?:\.\d+)?)\s*$`)
+	// pinnedRequirement matches pip and Gemfile style pins: "django==4.2.1",
+	// "requests>=2.31", "gem 'rails', '~> 7.0'".
+	pinnedRequirement = regexp.MustCompile(`(?m)^\s*(?:gem\s+['"])?([A-Za-z][\w.\-]*)(?:\[[\w,]+\])?['"]?\s*(?:==|>=|~=|,\s*['"](?:~>|>=|=)?\s*)(\d+\.\d+(?:\.\d+)?)`)
+	// packageJSONDependency matches "name": "^1.2.3" entries in package.json.
+	packageJSONDependency = regexp.MustCompile(`"(@?[a-z0-9][\w.\-/]*)"\s*:\s*"[\^~>=v ]*(\d+\.\d+(?:\.\d+)?)`)
+	// cargoDependency matches Cargo.toml dependencies: serde = "1.0" or
+	// tokio = { version = "1.28", ... }.
+	cargoDependency = regexp.MustCompile(`(?m)^\s*([a-z][\w\-]*)\s*=\s*(?:\{[^}\n]*version\s*=\s*)?"[\^~=]?(\d+\.\d+(?:\.\d+)?)"`)
+	// installedVersion matches "pkg@1.2.3" in npm, yarn and go get commands.
+	installedVersion = regexp.MustCompile(`(?:^|\s)(@?[a-z0-9][\w.\-/]*)@v?(\d+\.\d+(?:\.\d+)?)\b`)
+	// dockerBaseImage matches "FROM golang:1.22-alpine".
+	dockerBaseImage = regexp.MustCompile(`(?mi)^FROM\s+(?:[\w.\-]+/)*([\w.\-]+):v?(\d+(?:\.\d+)+)`)
+)
+
+// versionKeys are JSON and TOML keys that look like dependencies but are not.
+var versionKeys = map[string]bool{
+	"version": true, "edition": true, "rust-version": true, "node": true, "npm": true, "engines": true,
+}
+
+// ExtractLibraryVersions returns the library versions pinned in the fenced code
+// blocks of a markdown body, as "name@version" (e.g. "django@4.2.1",
+// "github.com/jackc/pgx/v5@5.5.0", "golang@1.22"), deduplicated and sorted. It
+// reads go.mod, requirements.txt, Gemfile, package.json and Cargo.toml snippets,
+// install commands and Dockerfile base images. Max MaxLibraryVersions.
+func ExtractLibraryVersions(markdown string) []string {
+	seen := map[string]bool{}
+	versions := []string{}
+	add := func(name, version string) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || versionKeys[name] {
+			return
+		}
+		v := name + "@" + version
+		if !seen[v] && len(versions) < MaxLibraryVersions {
+			seen[v] = true
+			versions = append(versions, v)
+		}
+	}
+	addAll := func(re *regexp.Regexp, body string) {
+		for _, m := range re.FindAllStringSubmatch(body, -1) {
+			add(m[1], m[2])
+		}
+	}
+
+	for _, block := range parseCodeBlocks(markdown) {
+		body := block.body
+		addAll(goModRequire, body)
+		for _, m := range goDirective.FindAllStringSubmatch(body, -1) {
+			add("go", m[1])
+		}
+		addAll(installedVersion, body)
+		addAll(dockerBaseImage, body)
+		switch NormalizeCodeLanguage(block.info) {
+		case "json":
+			addAll(packageJSONDependency, body)
+		case "toml":
+			addAll(cargoDependency, body)
+		case "", "python", "ruby", "shell":
+			addAll(pinnedRequirement, body)
+		}
+	}
+
+	sort.Strings(versions)
+	return versions
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractLibraryVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     []string
+	}{
+		{
+			name:     "no code blocks",
+			markdown: "Upgrade django==4.2 and it works.",
+			want:     []string{},
+		},
+		{
+			name:     "go.mod require block and directive",
+			markdown: "```\nmodule example.com/app\n\ngo 1.22\n\nrequire (\n\tgithub.com/jackc/pgx/v5 v5.5.0\n\tgithub.com/go-chi/chi/v5 v5.0.12 // indirect\n)\n```",
+			want:     []string{"github.com/go-chi/chi/v5@5.0.12", "github.com/jackc/pgx/v5@5.5.0", "go@1.22"},
+		},
+		{
+			name:     "requirements and install commands",
+			markdown: "```\ndjango==4.2.1\nrequests[socks]>=2.31\n```\n```bash\nnpm install react@18.2.0\ngo get github.com/stretchr/testify@v1.9.0\n```",
+			want:     []string{"django@4.2.1", "github.com/stretchr/testify@1.9.0", "react@18.2.0", "requests@2.31"},
+		},
+		{
+			name:     "package.json skips version keys",
+			markdown: "```json\n{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\",\n  \"dependencies\": {\n    \"express\": \"^4.18.2\",\n    \"@types/node\": \"~20.1\"\n  }\n}\n```",
+			want:     []string{"@types/node@20.1", "express@4.18.2"},
+		},
+		{
+			name:     "cargo toml and dockerfile",
+			markdown: "```toml\n[package]\nedition = \"2021\"\n\n[dependencies]\nserde = \"1.0\"\ntokio = { version = \"1.28\", features = [\"full\"] }\n```\n```dockerfile\nFROM golang:1.22-alpine AS build\n```",
+			want:     []string{"golang@1.22", "serde@1.0", "tokio@1.28"},
+		},
+		{
+			name:     "gemfile",
+			markdown: "```ruby\ngem 'rails', '~> 7.0.4'\n```",
+			want:     []string{"rails@7.0.4"},
+		},
+		{
+			name:     "versions outside code blocks ignored",
+			markdown: "Use react@18.2.0.\n```\nnothing pinned here\n```",
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractLibraryVersions(tt.markdown)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractLibraryVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFreshnessScore(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	year := 365 * 24 * time.Hour
+
+	tests := []struct {
+		name         string
+		in           FreshnessInput
+		want         int
+		wantOutdated bool
+	}{
+		{
+			name: "new post",
+			in:   FreshnessInput{LastActivity: now},
+			want: 100,
+		},
+		{
+			name: "one year old",
+			in:   FreshnessInput{LastActivity: now.Add(-year)},
+			want: 75,
+		},
+		{
+			name: "pinned versions age faster",
+			in:   FreshnessInput{LastActivity: now.Add(-year), LibraryVersions: []string{"django@4.2"}},
+			want: 62,
+		},
+		{
+			name:         "age penalty capped",
+			in:           FreshnessInput{LastActivity: now.Add(-10 * year)},
+			want:         30,
+			wantOutdated: true,
+		},
+		{
+			name:         "flags lower the score",
+			in:           FreshnessInput{LastActivity: now, OutdatedFlags: 2},
+			want:         70,
+			wantOutdated: false,
+		},
+		{
+			name:         "flag threshold marks outdated",
+			in:           FreshnessInput{LastActivity: now, OutdatedFlags: OutdatedFlagThreshold},
+			want:         55,
+			wantOutdated: true,
+		},
+		{
+			name:         "clamped at zero",
+			in:           FreshnessInput{LastActivity: now.Add(-10 * year), OutdatedFlags: 5},
+			want:         0,
+			wantOutdated: true,
+		},
+		{
+			name: "future activity treated as now",
+			in:   FreshnessInput{LastActivity: now.Add(time.Hour)},
+			want: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FreshnessScore(tt.in, now)
+			if got != tt.want {
+				t.Errorf("FreshnessScore() = %d, want %d", got, tt.want)
+			}
+			if outdated := IsOutdated(got, tt.in.OutdatedFlags); outdated != tt.wantOutdated {
+				t.Errorf("IsOutdated(%d, %d) = %v, want %v", got, tt.in.OutdatedFlags, outdated, tt.wantOutdated)
+			}
+		})
+	}
+}
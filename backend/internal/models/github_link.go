@@ -0,0 +1,110 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitHub issue states.
+const (
+	GitHubIssueOpen   = "open"
+	GitHubIssueClosed = "closed"
+)
+
+// ErrInvalidGitHubIssueURL is returned for URLs that aren't https://github.com/{owner}/{repo}/issues/{number}.
+var ErrInvalidGitHubIssueURL = errors.New("issue_url must look like https://github.com/{owner}/{repo}/issues/{number}")
+
+// ErrGitHubIssueNotFound is returned when an issue doesn't exist or isn't public.
+var ErrGitHubIssueNotFound = errors.New("github issue not found")
+
+// ErrGitHubRateLimited is returned when GitHub rejects a request for rate limiting.
+var ErrGitHubRateLimited = errors.New("github API rate limit exceeded")
+
+// githubNamePattern matches GitHub user, organization and repository names.
+var githubNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,100}$`)
+
+// GitHubIssueRef identifies one GitHub issue.
+type GitHubIssueRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// String returns owner/repo#number.
+func (r GitHubIssueRef) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// URL returns the issue's web URL.
+func (r GitHubIssueRef) URL() string {
+	return fmt.Sprintf("https://github.com/%s/%s/issues/%d", r.Owner, r.Repo, r.Number)
+}
+
+// ParseGitHubIssueURL parses a github.com issue URL. Query strings and fragments
+// (e.g. #issuecomment-1) are ignored.
+func ParseGitHubIssueURL(raw string) (GitHubIssueRef, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return GitHubIssueRef{}, ErrInvalidGitHubIssueURL
+	}
+	if host := strings.ToLower(u.Host); host != "github.com" && host != "www.github.com" {
+		return GitHubIssueRef{}, ErrInvalidGitHubIssueURL
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 4 || parts[2] != "issues" {
+		return GitHubIssueRef{}, ErrInvalidGitHubIssueURL
+	}
+	if !githubNamePattern.MatchString(parts[0]) || !githubNamePattern.MatchString(parts[1]) {
+		return GitHubIssueRef{}, ErrInvalidGitHubIssueURL
+	}
+	number, err := strconv.Atoi(parts[3])
+	if err != nil || number < 1 {
+		return GitHubIssueRef{}, ErrInvalidGitHubIssueURL
+	}
+	return GitHubIssueRef{Owner: parts[0], Repo: parts[1], Number: number}, nil
+}
+
+// GitHubIssue is the part of a GitHub issue used for import and status sync.
+type GitHubIssue struct {
+	Title         string
+	Body          string
+	State         string
+	HTMLURL       string
+	Author        string
+	Labels        []string
+	IsPullRequest bool
+	CreatedAt     time.Time
+}
+
+// GitHubIssueComment is one comment on a GitHub issue.
+type GitHubIssueComment struct {
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
+
+// PostGitHubLink connects a post to a GitHub issue.
+type PostGitHubLink struct {
+	PostID       string    `json:"post_id"`
+	RepoOwner    string    `json:"repo_owner"`
+	RepoName     string    `json:"repo_name"`
+	IssueNumber  int       `json:"issue_number"`
+	IssueURL     string    `json:"issue_url"`
+	IssueState   string    `json:"issue_state"`
+	Imported     bool      `json:"imported"`
+	ClosedBySync bool      `json:"-"`
+	LinkedByType string    `json:"linked_by_type"`
+	LinkedByID   string    `json:"linked_by_id"`
+	SyncedAt     time.Time `json:"synced_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Ref returns the linked issue's reference.
+func (l *PostGitHubLink) Ref() GitHubIssueRef {
+	return GitHubIssueRef{Owner: l.RepoOwner, Repo: l.RepoName, Number: l.IssueNumber}
+}
@@ -0,0 +1,32 @@
+package models
+
+import "testing"
+
+func TestParseGitHubIssueURL(t *testing.T) {
+	ref, err := ParseGitHubIssueURL(" https://github.com/acme/tool/issues/42#issuecomment-7 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ref != (GitHubIssueRef{Owner: "acme", Repo: "tool", Number: 42}) {
+		t.Errorf("unexpected ref %+v", ref)
+	}
+	if ref.String() != "acme/tool#42" || ref.URL() != "https://github.com/acme/tool/issues/42" {
+		t.Errorf("unexpected String/URL: %s %s", ref.String(), ref.URL())
+	}
+
+	invalid := []string{
+		"",
+		"not a url",
+		"https://gitlab.com/acme/tool/issues/1",
+		"https://github.com/acme/tool/pull/1",
+		"https://github.com/acme/tool/issues/0",
+		"https://github.com/acme/tool/issues/abc",
+		"https://github.com/acme/tool/issues/1/extra",
+		"ftp://github.com/acme/tool/issues/1",
+	}
+	for _, raw := range invalid {
+		if _, err := ParseGitHubIssueURL(raw); err != ErrInvalidGitHubIssueURL {
+			t.Errorf("expected %q to be rejected, got %v", raw, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// ZeroResultQuery is a search that kept returning nothing.
+type ZeroResultQuery struct {
+	Query string `json:"query"`
+	Count int    `json:"count"`
+}
+
+// StuckProblemSummary is a problem escalated as stuck, with the author's account
+// of what was tried.
+type StuckProblemSummary struct {
+	ProblemID string   `json:"problem_id"`
+	Title     string   `json:"title"`
+	Tags      []string `json:"tags,omitempty"`
+	Summary   string   `json:"summary"`
+}
+
+// KnowledgeGap is one recurring unmet need found in a week's demand signals.
+type KnowledgeGap struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Evidence lists the queries and problem titles behind the gap.
+	Evidence []string `json:"evidence,omitempty"`
+}
+
+// KnowledgeGapReport is the weekly summary of recurring unmet needs.
+type KnowledgeGapReport struct {
+	ID                string         `json:"id"`
+	WeekStart         time.Time      `json:"week_start"`
+	Summary           string         `json:"summary"`
+	Gaps              []KnowledgeGap `json:"gaps"`
+	ZeroResultQueries int            `json:"zero_result_queries"`
+	StuckProblems     int            `json:"stuck_problems"`
+	BlogPostID        string         `json:"blog_post_id,omitempty"`
+	BlogPostSlug      string         `json:"-"`
+	CreatedAt         time.Time      `json:"created_at"`
+}
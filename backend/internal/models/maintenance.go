@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// DefaultMaintenanceMessage is shown when maintenance mode is enabled without a message.
+const DefaultMaintenanceMessage = "Solvr is in read-only maintenance mode. Reads still work; writes are paused. Please retry in a few minutes."
+
+// MaintenanceStatus is the state of the read-only maintenance switch.
+type MaintenanceStatus struct {
+	// Enabled is true while mutating requests are rejected and background jobs are paused.
+	Enabled bool `json:"enabled"`
+
+	// Message is returned with every rejected write. Empty when disabled.
+	Message string `json:"message,omitempty"`
+
+	// Since is when maintenance mode was last enabled. Nil when disabled.
+	Since *time.Time `json:"since,omitempty"`
+
+	// Shared is true when the switch is stored in the database and applies to
+	// every API replica; false when it applies to the answering process only.
+	Shared bool `json:"shared"`
+}
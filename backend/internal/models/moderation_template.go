@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ModerationTemplate is an admin-edited system comment template for a content
+// moderation outcome, in one language. See package modtemplate for the keys and
+// placeholders.
+type ModerationTemplate struct {
+	Key       string    `json:"key"`
+	Language  string    `json:"language"`
+	Body      string    `json:"body"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// ObjectStorageConfig points at an S3-compatible bucket (AWS S3, MinIO,
+// Cloudflare R2, Backblaze B2, ...).
+type ObjectStorageConfig struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicURL is the base URL objects are publicly served from (CDN or public
+	// bucket domain). Only needed for objects handed out as links, like avatars.
+	PublicURL string
+}
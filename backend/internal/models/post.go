@@ -17,6 +17,12 @@ const (
 // MaxTagsPerPost is the maximum number of tags allowed per post.
 const MaxTagsPerPost = 10
 
+// MaxSuccessCriteria is the maximum number of success criteria per problem.
+const MaxSuccessCriteria = 10
+
+// MaxPostPublishDelay is how far ahead a post's publish_at may be scheduled.
+const MaxPostPublishDelay = 365 * 24 * time.Hour
+
 // PostStatus represents the status of a post.
 type PostStatus string
 
@@ -52,6 +58,10 @@ const (
 	AuthorTypeHuman  AuthorType = "human"
 	AuthorTypeAgent  AuthorType = "agent"
 	AuthorTypeSystem AuthorType = "system"
+
+	// AuthorTypeGuest marks a problem submitted without an account. It stays
+	// quarantined until the submitter claims it (see POST /v1/guest/claim).
+	AuthorTypeGuest AuthorType = "guest"
 )
 
 // Post visibility tiers (BART-151). "public" = global KB index (default). "family" =
@@ -154,6 +164,27 @@ type Post struct {
 	// OwnerHumanID is the UUID of the human who owns this post, for family-scoping.
 	// Set on write (human author's id, or a claimed agent's human_id). Never serialized.
 	OwnerHumanID *string `json:"-"`
+
+	// StackTrace is the crash signature parsed from the description on create and
+	// update, or nil when the description has no stack trace.
+	StackTrace *StackTrace `json:"stack_trace,omitempty"`
+
+	// CodeLanguages are the languages of fenced code blocks in the description,
+	// extracted on create and update. See ExtractCodeLanguages.
+	CodeLanguages []string `json:"code_languages,omitempty"`
+
+	// PublishAt is when a scheduled draft will be published. Set on create and
+	// cleared by the scheduled publish job; read paths leave it nil.
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+}
+
+// CrashDuplicate is an existing post reporting the same crash signature.
+type CrashDuplicate struct {
+	ID        string     `json:"id"`
+	Type      PostType   `json:"type"`
+	Title     string     `json:"title"`
+	Status    PostStatus `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
 }
 
 // VoteScore returns the computed vote score (upvotes - downvotes).
@@ -194,9 +225,6 @@ type PostListOptions struct {
 	Timeframe     string     // Timeframe filter: "today", "week", "month"
 	Page          int        // Page number (1-indexed)
 	PerPage       int        // Results per page
-	ViewerType    AuthorType // Optional: authenticated viewer's type for user_vote lookup
-	ViewerID      string     // Optional: authenticated viewer's ID for user_vote lookup
-	ViewerHuman   string     // Optional: caller's family human UUID for visibility scoping ("" = public-only)
 }
 
 // ValidPostTypes returns all valid post types.
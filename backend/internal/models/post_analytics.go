@@ -0,0 +1,60 @@
+package models
+
+// Post analytics bounds for GET /v1/me/posts/{id}/analytics?days=N.
+const (
+	DefaultPostAnalyticsDays = 30
+	MaxPostAnalyticsDays     = 90
+)
+
+// PostAnalytics is an author's view of how a post is being found and received
+// over the last Days UTC days.
+type PostAnalytics struct {
+	PostID            string                `json:"post_id"`
+	Days              int                   `json:"days"`
+	ViewCount         int                   `json:"view_count"`
+	VoteScore         int                   `json:"vote_score"`
+	Views             []PostViewDay         `json:"views"`
+	SearchImpressions PostSearchImpressions `json:"search_impressions"`
+	VoteTrajectory    []PostVoteDay         `json:"vote_trajectory"`
+	Referrers         []PostReferrer        `json:"referrers"`
+
+	// AuthorType and AuthorID identify the post's author for the ownership check.
+	AuthorType AuthorType `json:"-"`
+	AuthorID   string     `json:"-"`
+}
+
+// PostSearchImpressions counts searches whose results included the post.
+type PostSearchImpressions struct {
+	Total      int                    `json:"total"`
+	Daily      []PostImpressionDay    `json:"daily"`
+	TopQueries []PostImpressionsQuery `json:"top_queries"`
+}
+
+// PostImpressionDay is one day of search impressions.
+type PostImpressionDay struct {
+	Date        string `json:"date"` // YYYY-MM-DD
+	Impressions int    `json:"impressions"`
+}
+
+// PostImpressionsQuery is a normalized search query that surfaced the post.
+type PostImpressionsQuery struct {
+	Query       string `json:"query"`
+	Impressions int    `json:"impressions"`
+}
+
+// PostVoteDay is one day of a post's vote trajectory. Upvotes and Downvotes
+// are net of changes and retractions that day; Score is the vote score at the
+// end of the day.
+type PostVoteDay struct {
+	Date      string `json:"date"` // YYYY-MM-DD
+	Upvotes   int    `json:"upvotes"`
+	Downvotes int    `json:"downvotes"`
+	Score     int    `json:"score"`
+}
+
+// PostReferrer is the number of counted views referred by one host.
+// Source is "direct" for views without a referrer.
+type PostReferrer struct {
+	Source string `json:"source"`
+	Views  int    `json:"views"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// MaxPostCollaborators is how many collaborators one problem can have.
+const MaxPostCollaborators = 20
+
+// PostCollaborator is a human or agent the author has allowed to edit their problem.
+type PostCollaborator struct {
+	CollaboratorType AuthorType `json:"collaborator_type"`
+	CollaboratorID   string     `json:"collaborator_id"`
+	DisplayName      string     `json:"display_name"`
+	// Family extends a human collaborator's grant to the agents they have claimed.
+	Family    bool      `json:"family"`
+	GrantedAt time.Time `json:"granted_at"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// MetaDescriptionLength is the maximum length of a post's meta description, in runes.
+const MetaDescriptionLength = 160
+
+// PostMeta is the SEO metadata for a post page: the page title and description,
+// Open Graph and Twitter card tags, and schema.org JSON-LD structured data.
+type PostMeta struct {
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	CanonicalURL string                 `json:"canonical_url"`
+	OpenGraph    OpenGraphMeta          `json:"open_graph"`
+	Twitter      TwitterCardMeta        `json:"twitter"`
+	JSONLD       map[string]interface{} `json:"json_ld"`
+}
+
+// OpenGraphMeta holds og:* tags.
+type OpenGraphMeta struct {
+	Type          string   `json:"type"` // always "article"
+	Title         string   `json:"title"`
+	Description   string   `json:"description"`
+	URL           string   `json:"url"`
+	SiteName      string   `json:"site_name"`
+	PublishedTime string   `json:"published_time"` // RFC 3339
+	ModifiedTime  string   `json:"modified_time"`  // RFC 3339
+	Tags          []string `json:"tags"`
+}
+
+// TwitterCardMeta holds twitter:* tags.
+type TwitterCardMeta struct {
+	Card        string `json:"card"` // always "summary"
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PostRetention is a post's retention and legal hold metadata, set by admins via
+// PUT /v1/admin/posts/{id}/retention. Posts without a row have no hold.
+type PostRetention struct {
+	PostID      string     `json:"post_id"`
+	LegalHold   bool       `json:"legal_hold"`
+	HoldReason  string     `json:"hold_reason,omitempty"`
+	RetainUntil *time.Time `json:"retain_until,omitempty"`
+	Held        bool       `json:"held"` // legal hold set or retain_until in the future
+	UpdatedBy   string     `json:"updated_by,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// IsHeld reports whether the post is held at now: under legal hold, or within its
+// retention period.
+func (r *PostRetention) IsHeld(now time.Time) bool {
+	return r.LegalHold || (r.RetainUntil != nil && r.RetainUntil.After(now))
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"strings"
+)
+
+// PostTemplateSection is one markdown section a template asks for. A post
+// written from the template has it as a heading ("## Steps to reproduce")
+// followed by its content.
+type PostTemplateSection struct {
+	Key      string `json:"key"`
+	Heading  string `json:"heading"`
+	Prompt   string `json:"prompt"`
+	Required bool   `json:"required"`
+}
+
+// PostTemplate is a structured starting point for a post of one type. Posts
+// created with a template_id must fill in its required sections, which keeps
+// the descriptions the crystallization pipeline reads consistent.
+type PostTemplate struct {
+	ID          string                `json:"id"`
+	Type        PostType              `json:"type"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	TitleHint   string                `json:"title_hint"`
+	Sections    []PostTemplateSection `json:"sections"`
+}
+
+// postTemplates is the template catalog, in display order.
+var postTemplates = []PostTemplate{
+	{
+		ID:          "bug-report",
+		Type:        PostTypeProblem,
+		Name:        "Bug report",
+		Description: "Something behaves differently from what it should.",
+		TitleHint:   "<component>: <what goes wrong> when <trigger>",
+		Sections: []PostTemplateSection{
+			{Key: "expected", Heading: "Expected behavior", Prompt: "What should happen?", Required: true},
+			{Key: "actual", Heading: "Actual behavior", Prompt: "What happens instead? Include the exact error or stack trace.", Required: true},
+			{Key: "reproduce", Heading: "Steps to reproduce", Prompt: "The smallest sequence of steps that triggers it.", Required: true},
+			{Key: "environment", Heading: "Environment", Prompt: "Versions of the language, libraries, OS and anything else relevant.", Required: true},
+			{Key: "tried", Heading: "What I tried", Prompt: "Fixes or workarounds already attempted, and how they failed."},
+		},
+	},
+	{
+		ID:          "performance-issue",
+		Type:        PostTypeProblem,
+		Name:        "Performance issue",
+		Description: "Something works but is too slow or uses too much memory.",
+		TitleHint:   "<operation> takes <time> with <workload>",
+		Sections: []PostTemplateSection{
+			{Key: "symptom", Heading: "Symptom", Prompt: "What is slow, and how slow is it?", Required: true},
+			{Key: "measurements", Heading: "Measurements", Prompt: "Timings, profiles or metrics, with how they were taken.", Required: true},
+			{Key: "workload", Heading: "Workload", Prompt: "Data sizes, request rates and concurrency.", Required: true},
+			{Key: "target", Heading: "Target", Prompt: "What would be fast enough?"},
+			{Key: "environment", Heading: "Environment", Prompt: "Hardware, versions and configuration."},
+		},
+	},
+	{
+		ID:          "architecture-question",
+		Type:        PostTypeQuestion,
+		Name:        "Architecture question",
+		Description: "Choosing between designs, or how to structure a system.",
+		TitleHint:   "How should I <design goal> given <constraint>?",
+		Sections: []PostTemplateSection{
+			{Key: "context", Heading: "Context", Prompt: "What the system does today and what needs to change.", Required: true},
+			{Key: "constraints", Heading: "Constraints", Prompt: "Scale, latency, budget, team or compatibility limits.", Required: true},
+			{Key: "options", Heading: "Options considered", Prompt: "The designs on the table and their trade-offs as you see them.", Required: true},
+			{Key: "question", Heading: "Question", Prompt: "The specific decision you need help with."},
+		},
+	},
+}
+
+// PostTemplates returns the template catalog, optionally only those for one
+// post type (an empty postType returns all).
+func PostTemplates(postType PostType) []PostTemplate {
+	templates := make([]PostTemplate, 0, len(postTemplates))
+	for _, t := range postTemplates {
+		if postType == "" || t.Type == postType {
+			templates = append(templates, t)
+		}
+	}
+	return templates
+}
+
+// FindPostTemplate returns the template with the given ID.
+func FindPostTemplate(id string) (PostTemplate, bool) {
+	for _, t := range postTemplates {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return PostTemplate{}, false
+}
+
+// Skeleton is a markdown description with every section heading and its
+// prompt as a placeholder, for clients to prefill an editor with.
+func (t PostTemplate) Skeleton() string {
+	var b strings.Builder
+	for i, s := range t.Sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("## " + s.Heading + "\n\n" + s.Prompt + "\n")
+	}
+	return b.String()
+}
+
+// MissingSections returns the required sections description doesn't fill
+// in: the heading is absent, or nothing but whitespace follows it before the
+// next heading. Headings match at any level, ignoring case and a trailing
+// colon.
+func (t PostTemplate) MissingSections(description string) []PostTemplateSection {
+	filled := markdownSections(description)
+	var missing []PostTemplateSection
+	for _, s := range t.Sections {
+		if s.Required && !filled[normalizeHeading(s.Heading)] {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// markdownSections reports which normalized ATX headings in markdown have
+// content under them. Lines inside code fences are never headings.
+func markdownSections(markdown string) map[string]bool {
+	sections := map[string]bool{}
+	current := ""
+	inFence := false
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(trimmed, "#") {
+			heading := strings.TrimLeft(trimmed, "#")
+			if level := len(trimmed) - len(heading); level <= 6 && (heading == "" || heading[0] == ' ') {
+				current = normalizeHeading(heading)
+				continue
+			}
+		}
+		if current != "" && trimmed != "" {
+			sections[current] = true
+		}
+	}
+	return sections
+}
+
+func normalizeHeading(heading string) string {
+	heading = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(heading), "#"))
+	return strings.ToLower(strings.TrimSuffix(heading, ":"))
+}
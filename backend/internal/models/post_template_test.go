@@ -0,0 +1,64 @@
+package models
+
+import "testing"
+
+func TestPostTemplate_MissingSections(t *testing.T) {
+	tmpl, ok := FindPostTemplate("bug-report")
+	if !ok {
+		t.Fatal("bug-report template not found")
+	}
+
+	tests := []struct {
+		name        string
+		description string
+		want        []string
+	}{
+		{
+			name: "all required sections filled",
+			description: "## Expected behavior\nThe job finishes.\n\n### actual behavior:\npanic: nil map\n\n" +
+				"## Steps to reproduce\n1. run it\n\n## Environment\nGo 1.24",
+		},
+		{
+			name:        "empty and absent sections",
+			description: "## Expected behavior\n\n## Actual behavior\npanic\n## Steps to reproduce\n   \n",
+			want:        []string{"expected", "reproduce", "environment"},
+		},
+		{
+			name:        "headings inside code fences don't count",
+			description: "## Expected behavior\nok\n## Actual behavior\n```\n## Steps to reproduce\nx\n## Environment\ny\n```",
+			want:        []string{"reproduce", "environment"},
+		},
+		{
+			name:        "skeleton fills every section",
+			description: tmpl.Skeleton(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing := tmpl.MissingSections(tt.description)
+			var got []string
+			for _, s := range missing {
+				got = append(got, s.Key)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("missing = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("missing = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPostTemplates_FilterByType(t *testing.T) {
+	for _, tmpl := range PostTemplates(PostTypeQuestion) {
+		if tmpl.Type != PostTypeQuestion {
+			t.Errorf("template %s has type %s", tmpl.ID, tmpl.Type)
+		}
+	}
+	if len(PostTemplates("")) != len(postTemplates) {
+		t.Error("expected an empty type to return every template")
+	}
+}
@@ -0,0 +1,18 @@
+// Package models contains data structures for the Solvr API.
+package models
+
+import (
+	"time"
+)
+
+// ProblemEscalation records a problem its author marked as stuck.
+// An escalation is open until the problem is solved, closed or goes stale.
+type ProblemEscalation struct {
+	ID              string     `json:"id"`
+	ProblemID       string     `json:"problem_id"`
+	Summary         string     `json:"summary"`           // what was tried so far
+	EscalatedByType string     `json:"escalated_by_type"` // "human" or "agent"
+	EscalatedByID   string     `json:"escalated_by_id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// MaxPushSubscriptionsPerUser caps the browsers one human can subscribe; the
+// oldest subscription is replaced beyond it.
+const MaxPushSubscriptionsPerUser = 10
+
+// DefaultWebPushTypes are the notification types pushed when WEB_PUSH_TYPES
+// is unset: activity on the recipient's own posts.
+var DefaultWebPushTypes = []string{
+	"approach.created",
+	"answer.created",
+	"answer.accepted",
+	"comment.created",
+	"problem.solved",
+}
+
+// WebPushConfig holds the VAPID key pair that identifies the server to push
+// services (both base64url, as generated by web-push tooling: the public key
+// an uncompressed P-256 point, the private key its 32-byte scalar), the
+// contact URI sent with every push and the notification types pushed.
+type WebPushConfig struct {
+	PublicKey  string
+	PrivateKey string
+	Subject    string // mailto: or https: contact for push services
+	Types      []string
+}
+
+// Enabled reports whether both VAPID keys are configured.
+func (c WebPushConfig) Enabled() bool {
+	return c.PublicKey != "" && c.PrivateKey != ""
+}
+
+// PushSubscription is a browser's Web Push subscription, as returned by
+// PushManager.subscribe() in the web frontend.
+type PushSubscription struct {
+	ID       string `json:"id"`
+	UserID   string `json:"-"`
+	Endpoint string `json:"endpoint"`
+	// P256dh and Auth are the browser's encryption keys (base64url).
+	P256dh       string     `json:"-"`
+	Auth         string     `json:"-"`
+	UserAgent    string     `json:"user_agent,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastPushedAt *time.Time `json:"last_pushed_at,omitempty"`
+}
+
+// PendingPush is a notification not pushed yet and the recipient's
+// subscriptions to push it to.
+type PendingPush struct {
+	Notification  Notification
+	Subscriptions []PushSubscription
+}
+
+// pushServiceHosts are the push services browsers subscribe with. Subdomains
+// are accepted for the services that shard by host.
+var pushServiceHosts = []string{
+	"fcm.googleapis.com",
+	"push.services.mozilla.com",
+	"notify.windows.com",
+	"push.apple.com",
+}
+
+// IsValidPushEndpoint reports whether rawURL is an https endpoint on a known
+// browser push service. Restricting hosts keeps subscriptions from being used
+// to make the server call arbitrary URLs.
+func IsValidPushEndpoint(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.User != nil || u.Port() != "" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, h := range pushServiceHosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
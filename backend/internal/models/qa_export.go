@@ -0,0 +1,93 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Fine-tuning formats for GET /v1/export/qa-pairs. Each line of the export is
+// one JSON record in the chosen format.
+const (
+	// QAExportFormatMessages is the chat format used by most fine-tuning APIs:
+	// {"messages": [{"role": "system"|"user"|"assistant", "content": ...}]}.
+	QAExportFormatMessages = "messages"
+	// QAExportFormatAlpaca is the instruction format: {"instruction", "input", "output"}.
+	QAExportFormatAlpaca = "alpaca"
+	// QAExportFormatPromptCompletion is the legacy completion format: {"prompt", "completion"}.
+	QAExportFormatPromptCompletion = "prompt_completion"
+)
+
+// ValidQAExportFormats lists the accepted format values.
+var ValidQAExportFormats = []string{QAExportFormatMessages, QAExportFormatAlpaca, QAExportFormatPromptCompletion}
+
+// Collective knowledge (solutions, approaches and answers) is licensed under
+// CC BY-SA 4.0 per the terms of service; exported pairs carry the license and
+// the attribution it requires.
+const (
+	ContentLicense    = "CC-BY-SA-4.0"
+	ContentLicenseURL = "https://creativecommons.org/licenses/by-sa/4.0/"
+)
+
+// DefaultQAExportLimit and MaxQAExportLimit bound one export request; page
+// through larger exports with the since parameter.
+const (
+	DefaultQAExportLimit = 10000
+	MaxQAExportLimit     = 50000
+)
+
+// QAExportFilter selects which pairs to export.
+type QAExportFilter struct {
+	// PostType is PostTypeQuestion, PostTypeProblem, or empty for both.
+	PostType PostType
+	// Since, when set, only exports pairs whose response changed after it.
+	Since *time.Time
+	// Limit caps the number of pairs.
+	Limit int
+}
+
+// QAContributor is an author credited in a pair's attribution.
+type QAContributor struct {
+	Type AuthorType `json:"type"`
+	// Handle is the username for humans and the agent ID for agents.
+	Handle string `json:"handle"`
+}
+
+// QAPair is a question and its accepted answer, or a problem and a verified
+// solution, ready for export.
+type QAPair struct {
+	PostID     string
+	PostType   PostType
+	Title      string
+	Body       string
+	Tags       []string
+	ResponseID string
+	// Response is the newest verified version of the accepted answer, or the
+	// solution of a succeeded approach the problem owner verified.
+	Response  string
+	Asker     QAContributor
+	Responder QAContributor
+	// CreatedAt is when the post was created, RespondedAt when the response
+	// was, and UpdatedAt when the response last changed (the export order).
+	CreatedAt   time.Time
+	RespondedAt time.Time
+	UpdatedAt   time.Time
+}
+
+// Prompt is the user turn of the pair: the title followed by the body.
+func (p *QAPair) Prompt() string {
+	body := strings.TrimSpace(p.Body)
+	if body == "" {
+		return p.Title
+	}
+	return p.Title + "\n\n" + body
+}
+
+// Attribution is the credit line the license requires, in the format the terms
+// of service ask for.
+func (p *QAPair) Attribution(sourceURL string) string {
+	contributors := "@" + p.Asker.Handle
+	if p.Responder.Handle != p.Asker.Handle {
+		contributors += ", @" + p.Responder.Handle
+	}
+	return "Source: Solvr (" + strings.TrimPrefix(strings.TrimPrefix(sourceURL, "https://"), "http://") + ") — Contributors: " + contributors
+}
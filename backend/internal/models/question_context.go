@@ -0,0 +1,61 @@
+package models
+
+// Token budget for GET /v1/questions/{id}/context. Sizes are estimated at
+// QuestionContextCharsPerToken characters per token.
+const (
+	DefaultQuestionContextTokens = 8000
+	MinQuestionContextTokens     = 1000
+	MaxQuestionContextTokens     = 32000
+	QuestionContextCharsPerToken = 4
+	// QuestionContextRelatedLimit caps crystallized solutions and similar questions each.
+	QuestionContextRelatedLimit = 5
+	// QuestionContextAnswersLimit caps the existing answers included.
+	QuestionContextAnswersLimit = 10
+)
+
+// QuestionContext is everything an agent needs to draft an answer to a question:
+// the question, its current answers, crystallized solutions to related problems
+// and similar questions that already have an accepted answer. Long texts are
+// truncated so the whole payload fits TokenBudget.
+type QuestionContext struct {
+	Question              QuestionContextPost     `json:"question"`
+	ExistingAnswers       []QuestionContextAnswer `json:"existing_answers"`
+	CrystallizedSolutions []ContextSolution       `json:"crystallized_solutions"`
+	SimilarQuestions      []ContextSolution       `json:"similar_questions"`
+	TokenBudget           int                     `json:"token_budget"`
+	EstimatedTokens       int                     `json:"estimated_tokens"`
+	// Truncated is true when any text was shortened or any item dropped to fit.
+	Truncated bool `json:"truncated"`
+}
+
+// QuestionContextPost is the question being answered.
+type QuestionContextPost struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	Tags         []string `json:"tags"`
+	Status       string   `json:"status"`
+	AnswersCount int      `json:"answers_count"`
+}
+
+// QuestionContextAnswer is an answer already posted to the question.
+type QuestionContextAnswer struct {
+	ID         string `json:"id"`
+	Content    string `json:"content"`
+	VoteScore  int    `json:"vote_score"`
+	IsAccepted bool   `json:"is_accepted"`
+	AuthorName string `json:"author_name"`
+}
+
+// ContextSolution is a solved post related to the question: a crystallized
+// problem with its succeeded approach, or an answered question with its
+// accepted answer.
+type ContextSolution struct {
+	PostID   string `json:"post_id"`
+	Title    string `json:"title"`
+	Solution string `json:"solution"`
+	// Similarity is the cosine similarity (0–1) to the question; nil when the
+	// match came from keyword search because either post has no embedding.
+	Similarity         *float64 `json:"similarity,omitempty"`
+	CrystallizationCID string   `json:"crystallization_cid,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Limits for GET /v1/questions/suggest.
+const (
+	// QuestionSuggestMinTitleLength is the shortest title (in characters) that is
+	// searched; shorter drafts get an empty list without hitting the database.
+	QuestionSuggestMinTitleLength = 10
+	// QuestionSuggestDefaultLimit is the number of suggestions when limit is not given.
+	QuestionSuggestDefaultLimit = 5
+	// QuestionSuggestMaxLimit caps the limit query param.
+	QuestionSuggestMaxLimit = 10
+)
+
+// QuestionSuggestion is an existing question that may duplicate a draft title.
+type QuestionSuggestion struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Status       string    `json:"status"`
+	AnswersCount int       `json:"answers_count"`
+	VoteScore    int       `json:"vote_score"`
+	CreatedAt    time.Time `json:"created_at"`
+	// Similarity is the cosine similarity (0–1) to the draft title; nil when
+	// the lookup fell back to keyword search.
+	Similarity *float64 `json:"similarity,omitempty"`
+	// LikelyDuplicate is true when Similarity clears the search confidence threshold.
+	LikelyDuplicate bool `json:"likely_duplicate"`
+}
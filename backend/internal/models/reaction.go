@@ -0,0 +1,31 @@
+package models
+
+// ReactionTargetType is what a reaction is on.
+type ReactionTargetType string
+
+const (
+	ReactionTargetAnswer  ReactionTargetType = "answer"
+	ReactionTargetComment ReactionTargetType = "comment"
+)
+
+// Reactions are the emoji that can be added to answers and comments. They
+// acknowledge without voting, so they don't change reputation.
+var Reactions = []string{"👍", "🎉", "❤️", "😄", "🚀", "👀"}
+
+// IsValidReaction reports whether emoji is one of Reactions.
+func IsValidReaction(emoji string) bool {
+	for _, r := range Reactions {
+		if r == emoji {
+			return true
+		}
+	}
+	return false
+}
+
+// ReactionCount is how many principals added one emoji, and whether the
+// caller is one of them.
+type ReactionCount struct {
+	Emoji   string `json:"emoji"`
+	Count   int    `json:"count"`
+	Reacted bool   `json:"reacted"`
+}
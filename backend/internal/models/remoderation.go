@@ -0,0 +1,60 @@
+package models
+
+// RemoderationConfig decides when an edit to content that already passed
+// moderation is moderated again.
+type RemoderationConfig struct {
+	// MinChangedChars is the edit size, in bytes, from which the new content
+	// is re-moderated in the background.
+	MinChangedChars int `json:"min_changed_chars"`
+	// HoldRatio is the edit size, as a fraction of the original length, from
+	// which the content is also held for review until moderation approves it.
+	HoldRatio float64 `json:"hold_ratio"`
+}
+
+// DefaultRemoderationConfig returns the default thresholds: typo fixes and
+// small additions pass, rewriting half of the content is held.
+func DefaultRemoderationConfig() RemoderationConfig {
+	return RemoderationConfig{
+		MinChangedChars: 200,
+		HoldRatio:       0.5,
+	}
+}
+
+// RemoderationAction is what an edit to approved content triggers.
+type RemoderationAction int
+
+const (
+	// RemoderationNone leaves the edit alone.
+	RemoderationNone RemoderationAction = iota
+	// RemoderationBackground re-moderates the edit while it stays visible.
+	RemoderationBackground
+	// RemoderationHold hides the edit until moderation approves it.
+	RemoderationHold
+)
+
+// Decide returns what editing before into after triggers.
+func (c RemoderationConfig) Decide(before, after string) RemoderationAction {
+	changed := EditSize(before, after)
+	if changed == 0 || changed < c.MinChangedChars {
+		return RemoderationNone
+	}
+	if c.HoldRatio > 0 && float64(changed) >= c.HoldRatio*float64(max(len(before), 1)) {
+		return RemoderationHold
+	}
+	return RemoderationBackground
+}
+
+// EditSize returns how many bytes an edit from before to after rewrote: the
+// longer of the two once their common prefix and suffix are removed.
+func EditSize(before, after string) int {
+	prefix := 0
+	for prefix < len(before) && prefix < len(after) && before[prefix] == after[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(before)-prefix && suffix < len(after)-prefix &&
+		before[len(before)-1-suffix] == after[len(after)-1-suffix] {
+		suffix++
+	}
+	return max(len(before), len(after)) - prefix - suffix
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditSize(t *testing.T) {
+	tests := []struct {
+		before, after string
+		want          int
+	}{
+		{"same text", "same text", 0},
+		{"fix the typo hre", "fix the typo here", 1},
+		{"abc", "", 3},
+		{"", "abcd", 4},
+		{"start middle end", "start MIDDLE end", 6},
+		{"aaaa", "aa", 2},
+	}
+	for _, tt := range tests {
+		if got := EditSize(tt.before, tt.after); got != tt.want {
+			t.Errorf("EditSize(%q, %q) = %d, want %d", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
+
+func TestRemoderationConfig_Decide(t *testing.T) {
+	cfg := RemoderationConfig{MinChangedChars: 20, HoldRatio: 0.5}
+	original := strings.Repeat("a", 100)
+
+	tests := []struct {
+		name  string
+		after string
+		want  RemoderationAction
+	}{
+		{"small edit", original + " typo", RemoderationNone},
+		{"large addition", original + strings.Repeat("b", 30), RemoderationBackground},
+		{"rewrite", strings.Repeat("b", 60) + original[60:], RemoderationHold},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.Decide(original, tt.after); got != tt.want {
+				t.Errorf("Decide = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	cfg.HoldRatio = 0
+	if got := cfg.Decide(original, strings.Repeat("b", 100)); got != RemoderationBackground {
+		t.Errorf("Decide with HoldRatio 0 = %d, want background", got)
+	}
+}
@@ -52,6 +52,39 @@ type Report struct {
 	ReviewedBy   string           `json:"reviewed_by,omitempty"`
 }
 
+// ReportQueueItem is one reported piece of content in the admin moderation
+// queue, with its pending reports rolled up.
+type ReportQueueItem struct {
+	TargetType      ReportTargetType `json:"target_type"`
+	TargetID        string           `json:"target_id"`
+	ReportCount     int              `json:"report_count"`
+	Reasons         []ReportReason   `json:"reasons"`
+	FirstReportedAt time.Time        `json:"first_reported_at"`
+	LastReportedAt  time.Time        `json:"last_reported_at"`
+	HiddenAt        *time.Time       `json:"hidden_at,omitempty"`
+}
+
+// ReportResolution is an admin decision on the pending reports for a target.
+type ReportResolution string
+
+const (
+	// ReportResolutionDismiss rejects the reports and unhides the content.
+	ReportResolutionDismiss ReportResolution = "dismiss"
+	// ReportResolutionHide upholds the reports and keeps the content hidden.
+	ReportResolutionHide ReportResolution = "hide"
+)
+
+// IsHideableReportTarget reports whether content of this type can be
+// auto-hidden by community reports.
+func IsHideableReportTarget(targetType ReportTargetType) bool {
+	switch targetType {
+	case ReportTargetPost, ReportTargetAnswer, ReportTargetComment:
+		return true
+	default:
+		return false
+	}
+}
+
 // IsValidReportReason checks if a report reason is valid.
 func IsValidReportReason(reason ReportReason) bool {
 	switch reason {
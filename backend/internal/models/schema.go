@@ -0,0 +1,22 @@
+package models
+
+// SchemaStatus compares the database schema version with the migrations built
+// into the binary.
+type SchemaStatus struct {
+	// CurrentVersion is the last applied migration (0 when none are recorded).
+	CurrentVersion int64 `json:"current_version"`
+
+	// ExpectedVersion is the newest embedded migration.
+	ExpectedVersion int64 `json:"expected_version"`
+
+	// Pending counts embedded migrations newer than CurrentVersion.
+	Pending int `json:"pending"`
+
+	// Dirty is true when a migration failed part-way and needs manual repair.
+	Dirty bool `json:"dirty"`
+}
+
+// InSync reports whether the database is at the expected version and clean.
+func (s SchemaStatus) InSync() bool {
+	return !s.Dirty && s.CurrentVersion == s.ExpectedVersion
+}
@@ -29,6 +29,10 @@ type SearchResult struct {
 	CreatedAt       time.Time  `json:"created_at"`
 	SolvedAt        *time.Time `json:"solved_at,omitempty"`
 	Source          string     `json:"source"` // "post", "answer", or "approach"
+	// FreshnessScore and Outdated are the post's stored freshness (see
+	// FreshnessScore); set on post results once the post has been scored.
+	FreshnessScore *int `json:"freshness_score,omitempty"`
+	Outdated       bool `json:"outdated,omitempty"`
 }
 
 // SearchResultResponse is the JSON response format for a search result.
@@ -51,7 +55,9 @@ type SearchResultResponse struct {
 	ViewCount       int          `json:"view_count"`
 	CreatedAt       time.Time    `json:"created_at"`
 	SolvedAt        *time.Time   `json:"solved_at,omitempty"`
-	Source          string       `json:"source"` // "post", "answer", or "approach"
+	Source          string       `json:"source"`                    // "post", "answer", or "approach"
+	FreshnessScore  *int         `json:"freshness_score,omitempty"` // 0-100; post results only, once scored
+	Outdated        bool         `json:"outdated,omitempty"`
 }
 
 // SearchAuthor represents the author info in search results.
@@ -65,6 +71,7 @@ type SearchAuthor struct {
 type SearchOptions struct {
 	Type         string    // Filter by post type (problem, question, idea)
 	Tags         []string  // Filter by tags
+	Languages    []string  // Filter by code block language (canonical names, see NormalizeCodeLanguage)
 	Status       string    // Filter by status
 	Author       string    // Filter by author_id
 	AuthorType   string    // Filter by author_type (human, agent)
@@ -74,7 +81,6 @@ type SearchOptions struct {
 	Page         int       // Page number (1-indexed)
 	PerPage      int       // Results per page
 	ContentTypes []string  // Filter by content source: "posts", "answers", "approaches" (default: all)
-	ViewerHuman  string    // Caller's family human UUID for visibility scoping ("" = public-only)
 	// MinSimilarity is an OPT-IN cosine-similarity floor (0–1). When > 0, results are
 	// filtered to those whose Similarity >= MinSimilarity (nil-similarity/keyword-only
 	// results are dropped), yielding an honest empty below the bar. 0 = no filter
@@ -107,6 +113,8 @@ func (r *SearchResult) ToResponse() SearchResultResponse {
 		CreatedAt:       r.CreatedAt,
 		SolvedAt:        r.SolvedAt,
 		Source:          r.Source,
+		FreshnessScore:  r.FreshnessScore,
+		Outdated:        r.Outdated,
 	}
 }
 
@@ -117,3 +125,19 @@ func (r *SearchResult) ToResponse() SearchResultResponse {
 func IsConfidentMatch(topSimilarity *float64, threshold float64) bool {
 	return topSimilarity != nil && *topSimilarity >= threshold
 }
+
+// RelatedPost is a post semantically close to a free-text problem description,
+// as returned by the MCP solvr_related tool.
+type RelatedPost struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Title      string   `json:"title"`
+	Status     string   `json:"status"`
+	Tags       []string `json:"tags"`
+	Similarity float64  `json:"similarity"` // cosine similarity 0–1
+	// Solution is the accepted answer (questions) or the latest succeeded
+	// approach's solution or outcome (problems); empty when none exists.
+	Solution string `json:"solution,omitempty"`
+	// SolutionSource is "answer" or "approach" when Solution is set.
+	SolutionSource string `json:"solution_source,omitempty"`
+}
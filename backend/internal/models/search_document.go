@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Search backends selectable with SEARCH_BACKEND.
+const (
+	SearchBackendPostgres   = "postgres"
+	SearchBackendOpenSearch = "opensearch"
+)
+
+// SearchBackendConfig selects the search backend and, for OpenSearch, where
+// its index lives.
+type SearchBackendConfig struct {
+	Backend            string // SearchBackendPostgres or SearchBackendOpenSearch
+	OpenSearchURL      string // e.g. https://search.internal:9200
+	OpenSearchIndex    string
+	OpenSearchUsername string // basic auth; empty for none
+	OpenSearchPassword string
+}
+
+// SearchDocument is a searchable post, answer or approach as stored in an
+// external search index. Answers and approaches carry their parent post's
+// tags, visibility and owner, so search filters them the same way.
+type SearchDocument struct {
+	Source          string     `json:"source"` // "post", "answer" or "approach"
+	ID              string     `json:"id"`
+	PostID          string     `json:"post_id"` // the post itself, or the answer's question / approach's problem
+	Type            string     `json:"type"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Tags            []string   `json:"tags"`
+	CodeLanguages   []string   `json:"code_languages"`
+	Status          string     `json:"status"`
+	AuthorType      string     `json:"author_type"`
+	AuthorID        string     `json:"author_id"`
+	AuthorName      string     `json:"author_name"`
+	VoteScore       int        `json:"vote_score"`
+	AnswersCount    int        `json:"answers_count"`
+	ApproachesCount int        `json:"approaches_count"`
+	CommentsCount   int        `json:"comments_count"`
+	ViewCount       int        `json:"view_count"`
+	Visibility      string     `json:"visibility"`
+	OwnerHumanID    string     `json:"owner_human_id,omitempty"`
+	TenantID        string     `json:"tenant_id"`
+	FreshnessScore  *int       `json:"freshness_score,omitempty"`
+	Outdated        bool       `json:"outdated"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	SolvedAt        *time.Time `json:"solved_at,omitempty"`
+}
+
+// DocumentID is the document's key in the index: sources share one index and
+// their IDs are not disjoint.
+func (d *SearchDocument) DocumentID() string {
+	return SearchDocumentID(d.Source, d.ID)
+}
+
+// SearchDocumentID builds the index key of the source row with the given ID.
+func SearchDocumentID(source, id string) string {
+	return source + ":" + id
+}
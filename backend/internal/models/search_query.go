@@ -17,6 +17,8 @@ type SearchQuery struct {
 	UserAgent       string    `json:"user_agent,omitempty"`
 	Page            int       `json:"page"`
 	SearchedAt      time.Time `json:"searched_at"`
+	// ResultPostIDs are the posts on the returned page, for per-post search impressions.
+	ResultPostIDs []string `json:"result_post_ids,omitempty"`
 }
 
 // TrendingSearch represents an aggregated trending search term.
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Security event methods: how the principal signed in.
+const (
+	SecurityMethodPassword = "password"
+	SecurityMethodGitHub   = "github"
+	SecurityMethodGoogle   = "google"
+	// SecurityMethodAPIKey is recorded the first time an API key is used from an IP.
+	SecurityMethodAPIKey = "api_key"
+)
+
+// Security anomalies detected by comparing an event with the principal's history.
+const (
+	// SecurityAnomalyNewCountry: the first sign-in from a country the principal
+	// has not signed in from before.
+	SecurityAnomalyNewCountry = "new_country"
+	// SecurityAnomalyImpossibleTravel: a sign-in from another country shortly
+	// after the previous one, sooner than anyone could have travelled.
+	SecurityAnomalyImpossibleTravel = "impossible_travel"
+	// SecurityAnomalyManyIPs: the API key was used from more IPs in a day than
+	// SecurityConfig.MaxIPsPerKey.
+	SecurityAnomalyManyIPs = "many_ips"
+)
+
+// SecurityEvent is one entry of a principal's sign-in history.
+type SecurityEvent struct {
+	ID            string    `json:"id"`
+	PrincipalType string    `json:"-"`
+	PrincipalID   string    `json:"-"`
+	Method        string    `json:"method"`
+	IPAddress     string    `json:"ip_address"`
+	UserAgent     string    `json:"user_agent,omitempty"`
+	Country       string    `json:"country,omitempty"` // ISO 3166-1 alpha-2, when known
+	Anomalies     []string  `json:"anomalies"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// SecurityConfig tunes sign-in anomaly detection.
+type SecurityConfig struct {
+	// CountryHeader is the request header a trusted proxy sets to the client's
+	// country (e.g. CF-IPCountry behind Cloudflare). Empty disables the
+	// country-based checks, since clients could otherwise set it themselves.
+	CountryHeader string
+	// ImpossibleTravelWindow is how soon after a sign-in from one country a
+	// sign-in from another is flagged as impossible travel.
+	ImpossibleTravelWindow time.Duration
+	// MaxIPsPerKey is how many distinct IPs may use one API key in 24 hours
+	// before it is flagged. 0 disables the check.
+	MaxIPsPerKey int
+}
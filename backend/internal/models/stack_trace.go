@@ -0,0 +1,333 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Stack trace languages detected by ParseStackTrace.
+const (
+	StackLanguageGo         = "go"
+	StackLanguagePython     = "python"
+	StackLanguageJava       = "java"
+	StackLanguageJavaScript = "javascript"
+	StackLanguageRuby       = "ruby"
+	StackLanguageCSharp     = "csharp"
+	StackLanguageRust       = "rust"
+)
+
+// MaxStackFrames caps the frames kept from a parsed stack trace.
+const MaxStackFrames = 10
+
+// stackFingerprintFrames is how many of the innermost frames make up a fingerprint.
+// Outer frames (main loops, test runners, framework dispatch) vary between callers
+// of the same crash site.
+const stackFingerprintFrames = 5
+
+// StackTrace is the crash signature extracted from a post's description.
+type StackTrace struct {
+	// Language is the detected runtime, e.g. "go" or "python".
+	Language string `json:"language"`
+
+	// ExceptionType is the exception class or normalized panic message, if present.
+	ExceptionType string `json:"exception_type,omitempty"`
+
+	// Frames are normalized function identifiers, innermost first, without line
+	// numbers, addresses or directories. Max MaxStackFrames.
+	Frames []string `json:"frames"`
+
+	// Fingerprint hashes the language, exception type and innermost frames, so the
+	// same crash reported twice shares a fingerprint.
+	Fingerprint string `json:"fingerprint"`
+}
+
+var (
+	pythonTracebackHeader = regexp.MustCompile(`^\s*Traceback \(most recent call last\):`)
+	pythonFrame           = regexp.MustCompile(`^\s*File "([^"]+)", line \d+, in (\S+)`)
+	pythonException       = regexp.MustCompile(`^([A-Za-z_][\w.]*(?:Error|Exception|Exit|Interrupt|Warning|Iteration))(?::|$)`)
+
+	goPanic     = regexp.MustCompile(`^(?:panic|fatal error): (.+)$`)
+	goFuncLine  = regexp.MustCompile(`^\s*([\w./*()\-\[\]{},]+?)\((?:[^()]*)\)$`)
+	goFileLine  = regexp.MustCompile(`^\s+\S+\.go:\d+`)
+	goNoiseFunc = regexp.MustCompile(`^(?:runtime\.|panic$|testing\.tRunner)`)
+
+	jvmFrame     = regexp.MustCompile(`^\s*at ([\w$.<>/]+)\((?:[\w$]+\.(?:java|kt|scala|groovy)(?::\d+)?|Native Method|Unknown Source)\)`)
+	jvmException = regexp.MustCompile(`(?:^|\s)((?:[a-z_$][\w$]*\.)+[A-Z][\w$]*(?:Exception|Error|Throwable)[\w$]*)(?::|\s|$)`)
+
+	jsFrame     = regexp.MustCompile(`^\s*at (?:async )?(?:(\S+) \((.+?):\d+:\d+\)|(.+?):\d+:\d+)$`)
+	jsException = regexp.MustCompile(`^\s*(?:Uncaught )?([A-Z]\w*(?:Error|Exception))(?::|$)`)
+
+	rubyFrame = regexp.MustCompile("^\\s*(?:from )?(\\S+\\.rb):\\d+:in [`']([^']+)'")
+	rubyError = regexp.MustCompile(`\(([A-Z]\w*(?:::[A-Z]\w*)*)\)\s*$`)
+
+	csharpFrame     = regexp.MustCompile("^\\s*at ([\\w.`<>\\[\\]|+]+)\\([^)]*\\)(?: in .+:line \\d+)?\\s*$")
+	csharpException = regexp.MustCompile(`(?:^|\s)((?:[A-Z]\w*\.)+\w*Exception)(?::|\s|$)`)
+
+	rustPanic = regexp.MustCompile(`^thread '[^']*' panicked at (?:'([^']*)'|[^:]+:\d+:\d+:?)`)
+	rustFrame = regexp.MustCompile(`^\s*\d+: ([\w:<>$ ]+?)(?:::h[0-9a-f]{16})?\s*$`)
+
+	stackHexPattern    = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+	stackNumberPattern = regexp.MustCompile(`\d+`)
+	stackQuotedPattern = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+)
+
+// ParseStackTrace finds the first stack trace in a post description and extracts
+// its crash signature. Returns nil when the text has no recognizable stack trace.
+func ParseStackTrace(text string) *StackTrace {
+	if !strings.ContainsAny(text, "\n") {
+		return nil
+	}
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	parsers := []func([]string) *StackTrace{
+		parsePythonTrace,
+		parseGoTrace,
+		parseJVMTrace,
+		parseCSharpTrace,
+		parseJSTrace,
+		parseRubyTrace,
+		parseRustTrace,
+	}
+	for _, parse := range parsers {
+		if st := parse(lines); st != nil {
+			st.Fingerprint = stackFingerprint(st)
+			return st
+		}
+	}
+	return nil
+}
+
+func parsePythonTrace(lines []string) *StackTrace {
+	start := -1
+	for i, line := range lines {
+		if pythonTracebackHeader.MatchString(line) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	var frames []string
+	exception := ""
+	for _, line := range lines[start+1:] {
+		if m := pythonFrame.FindStringSubmatch(line); m != nil {
+			frames = append(frames, strings.TrimSuffix(path.Base(m[1]), ".py")+"."+m[2])
+			continue
+		}
+		if len(frames) > 0 && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			if m := pythonException.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				exception = m[1]
+				break
+			}
+		}
+	}
+	if len(frames) == 0 {
+		return nil
+	}
+	// Python prints the innermost frame last.
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return newStackTrace(StackLanguagePython, exception, frames)
+}
+
+func parseGoTrace(lines []string) *StackTrace {
+	exception := ""
+	var frames []string
+	for i, line := range lines {
+		if exception == "" {
+			if m := goPanic.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				exception = normalizeStackMessage(m[1])
+			}
+		}
+		if i+1 >= len(lines) || !goFileLine.MatchString(lines[i+1]) {
+			continue
+		}
+		if m := goFuncLine.FindStringSubmatch(line); m != nil {
+			if fn := m[1]; !goNoiseFunc.MatchString(fn) {
+				frames = append(frames, fn)
+			}
+		}
+	}
+	if len(frames) == 0 || (exception == "" && len(frames) < 2) {
+		return nil
+	}
+	return newStackTrace(StackLanguageGo, exception, frames)
+}
+
+func parseJVMTrace(lines []string) *StackTrace {
+	exception := ""
+	var frames []string
+	for _, line := range lines {
+		if m := jvmFrame.FindStringSubmatch(line); m != nil {
+			frames = append(frames, stackNumberPattern.ReplaceAllString(m[1], ""))
+			continue
+		}
+		// Only the outermost exception's frames: "Caused by" traces repeat outer frames.
+		if len(frames) > 0 {
+			break
+		}
+		if m := jvmException.FindStringSubmatch(line); m != nil {
+			exception = m[1]
+		}
+	}
+	if len(frames) == 0 || (exception == "" && len(frames) < 2) {
+		return nil
+	}
+	return newStackTrace(StackLanguageJava, exception, frames)
+}
+
+func parseCSharpTrace(lines []string) *StackTrace {
+	exception := ""
+	var frames []string
+	for _, line := range lines {
+		if m := csharpFrame.FindStringSubmatch(line); m != nil {
+			frames = append(frames, m[1])
+			continue
+		}
+		if len(frames) > 0 {
+			if strings.TrimSpace(line) == "" || strings.Contains(line, "--- End of") {
+				break
+			}
+			continue
+		}
+		if m := csharpException.FindStringSubmatch(line); m != nil {
+			exception = m[1]
+		}
+	}
+	// Without an exception line, "at Foo.Bar()" frames can't be told apart from
+	// JVM or JS output, so require one.
+	if len(frames) == 0 || exception == "" {
+		return nil
+	}
+	return newStackTrace(StackLanguageCSharp, exception, frames)
+}
+
+func parseJSTrace(lines []string) *StackTrace {
+	exception := ""
+	var frames []string
+	for _, line := range lines {
+		if m := jsFrame.FindStringSubmatch(line); m != nil {
+			fn, file := m[1], m[2]
+			if file == "" {
+				file = m[3]
+			}
+			if strings.HasPrefix(file, "node:") || strings.Contains(file, "node_modules/") {
+				continue
+			}
+			if fn == "" || fn == "<anonymous>" || fn == "Object.<anonymous>" {
+				fn = path.Base(file)
+			}
+			frames = append(frames, fn)
+			continue
+		}
+		if len(frames) > 0 {
+			if strings.TrimSpace(line) == "" {
+				break
+			}
+			continue
+		}
+		if m := jsException.FindStringSubmatch(line); m != nil {
+			exception = m[1]
+		}
+	}
+	if len(frames) == 0 || (exception == "" && len(frames) < 2) {
+		return nil
+	}
+	return newStackTrace(StackLanguageJavaScript, exception, frames)
+}
+
+func parseRubyTrace(lines []string) *StackTrace {
+	exception := ""
+	var frames []string
+	for _, line := range lines {
+		m := rubyFrame.FindStringSubmatch(line)
+		if m == nil {
+			if len(frames) > 0 && strings.TrimSpace(line) == "" {
+				break
+			}
+			continue
+		}
+		if len(frames) == 0 {
+			if e := rubyError.FindStringSubmatch(line); e != nil {
+				exception = e[1]
+			}
+		}
+		method := stackNumberPattern.ReplaceAllString(m[2], "")
+		method = strings.TrimPrefix(method, "block in ")
+		frames = append(frames, strings.TrimSuffix(path.Base(m[1]), ".rb")+"#"+method)
+	}
+	if len(frames) == 0 || (exception == "" && len(frames) < 2) {
+		return nil
+	}
+	return newStackTrace(StackLanguageRuby, exception, frames)
+}
+
+func parseRustTrace(lines []string) *StackTrace {
+	start := -1
+	exception := ""
+	for i, line := range lines {
+		if m := rustPanic.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			start = i
+			exception = normalizeStackMessage(m[1])
+			if exception == "" && i+1 < len(lines) {
+				exception = normalizeStackMessage(strings.TrimSpace(lines[i+1]))
+			}
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	var frames []string
+	for _, line := range lines[start+1:] {
+		m := rustFrame.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		fn := strings.TrimSpace(m[1])
+		if strings.HasPrefix(fn, "std::") || strings.HasPrefix(fn, "core::") || strings.HasPrefix(fn, "rust_") || strings.HasPrefix(fn, "__") {
+			continue
+		}
+		frames = append(frames, fn)
+	}
+	// A Rust panic without RUST_BACKTRACE has no frames; the message still identifies it.
+	return newStackTrace(StackLanguageRust, exception, frames)
+}
+
+func newStackTrace(language, exception string, frames []string) *StackTrace {
+	if len(frames) > MaxStackFrames {
+		frames = frames[:MaxStackFrames]
+	}
+	if frames == nil {
+		frames = []string{}
+	}
+	if len(exception) > 200 {
+		exception = exception[:200]
+	}
+	return &StackTrace{Language: language, ExceptionType: exception, Frames: frames}
+}
+
+// normalizeStackMessage strips values that differ between occurrences of the same
+// crash (quoted strings, addresses, indexes) from a panic message.
+func normalizeStackMessage(msg string) string {
+	msg = stackQuotedPattern.ReplaceAllString(msg, `""`)
+	msg = stackHexPattern.ReplaceAllString(msg, "0x")
+	msg = stackNumberPattern.ReplaceAllString(msg, "N")
+	return strings.TrimSpace(msg)
+}
+
+func stackFingerprint(st *StackTrace) string {
+	frames := st.Frames
+	if len(frames) > stackFingerprintFrames {
+		frames = frames[:stackFingerprintFrames]
+	}
+	h := sha256.Sum256([]byte(st.Language + "\n" + st.ExceptionType + "\n" + strings.Join(frames, "\n")))
+	return hex.EncodeToString(h[:16])
+}
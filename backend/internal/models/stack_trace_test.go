@@ -0,0 +1,137 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStackTrace_Languages(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		language  string
+		exception string
+		topFrame  string
+	}{
+		{
+			name: "go",
+			text: "Server crashes on start:\n```\npanic: runtime error: index out of range [5] with length 3\n\ngoroutine 1 [running]:\n" +
+				"main.(*Server).route(0xc000010000, {0x1, 0x2})\n\t/home/me/app/server.go:42 +0x1d\n" +
+				"main.main()\n\t/home/me/app/main.go:10 +0x25\nexit status 2\n```",
+			language:  StackLanguageGo,
+			exception: "runtime error: index out of range [N] with length N",
+			topFrame:  "main.(*Server).route",
+		},
+		{
+			name: "python",
+			text: "Traceback (most recent call last):\n  File \"/srv/app/main.py\", line 12, in <module>\n    run()\n" +
+				"  File \"/srv/app/jobs.py\", line 40, in run\n    data[key]\nKeyError: 'user_id'\n",
+			language:  StackLanguagePython,
+			exception: "KeyError",
+			topFrame:  "jobs.run",
+		},
+		{
+			name: "java",
+			text: "Exception in thread \"main\" java.lang.IllegalStateException: closed\n" +
+				"\tat com.acme.db.Pool.acquire(Pool.java:88)\n\tat com.acme.App.main(App.java:12)\n" +
+				"Caused by: java.io.IOException: broken pipe\n\tat com.acme.net.Conn.write(Conn.java:5)\n",
+			language:  StackLanguageJava,
+			exception: "java.lang.IllegalStateException",
+			topFrame:  "com.acme.db.Pool.acquire",
+		},
+		{
+			name: "javascript",
+			text: "TypeError: Cannot read properties of undefined (reading 'id')\n" +
+				"    at getUser (/app/src/users.js:14:22)\n    at Object.<anonymous> (/app/src/index.js:3:1)\n" +
+				"    at Module._compile (node:internal/modules/cjs/loader:1105:14)\n",
+			language:  StackLanguageJavaScript,
+			exception: "TypeError",
+			topFrame:  "getUser",
+		},
+		{
+			name: "ruby",
+			text: "app/models/user.rb:12:in `full_name': undefined method `upcase' for nil:NilClass (NoMethodError)\n" +
+				"\tfrom app/controllers/users_controller.rb:5:in `show'\n",
+			language:  StackLanguageRuby,
+			exception: "NoMethodError",
+			topFrame:  "user#full_name",
+		},
+		{
+			name: "csharp",
+			text: "Unhandled exception. System.NullReferenceException: Object reference not set to an instance of an object.\n" +
+				"   at Acme.Orders.Checkout(Cart cart) in /src/Orders.cs:line 27\n   at Acme.Program.Main(String[] args) in /src/Program.cs:line 9\n",
+			language:  StackLanguageCSharp,
+			exception: "System.NullReferenceException",
+			topFrame:  "Acme.Orders.Checkout",
+		},
+		{
+			name:      "rust",
+			text:      "thread 'main' panicked at src/main.rs:4:5:\ncalled `Option::unwrap()` on a `None` value\nnote: run with `RUST_BACKTRACE=1`",
+			language:  StackLanguageRust,
+			exception: "called `Option::unwrap()` on a `None` value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := ParseStackTrace(tt.text)
+			if st == nil {
+				t.Fatal("expected a stack trace")
+			}
+			if st.Language != tt.language {
+				t.Errorf("Language = %q, want %q", st.Language, tt.language)
+			}
+			if st.ExceptionType != tt.exception {
+				t.Errorf("ExceptionType = %q, want %q", st.ExceptionType, tt.exception)
+			}
+			if tt.topFrame != "" && (len(st.Frames) == 0 || st.Frames[0] != tt.topFrame) {
+				t.Errorf("Frames = %v, want %q first", st.Frames, tt.topFrame)
+			}
+			if len(st.Fingerprint) != 32 {
+				t.Errorf("expected a 32-char fingerprint, got %q", st.Fingerprint)
+			}
+		})
+	}
+}
+
+func TestParseStackTrace_NoTrace(t *testing.T) {
+	texts := []string{
+		"",
+		"My build fails with an error about missing modules.",
+		"Steps:\n1. run the app\n2. click save\nNothing happens.",
+		"See the docs at https://example.com:8080/path for more.\nThanks",
+	}
+	for _, text := range texts {
+		if st := ParseStackTrace(text); st != nil {
+			t.Errorf("expected no stack trace in %q, got %+v", text, st)
+		}
+	}
+}
+
+func TestParseStackTrace_FingerprintIgnoresVolatileDetails(t *testing.T) {
+	trace := func(path string, line, index int) string {
+		return strings.Join([]string{
+			"panic: runtime error: index out of range [" + string(rune('0'+index)) + "] with length 3",
+			"",
+			"goroutine 7 [running]:",
+			"main.(*Server).route(0xc000010000)",
+			"\t" + path + "/server.go:" + string(rune('0'+line)) + " +0x1d",
+			"main.main()",
+			"\t" + path + "/main.go:10 +0x25",
+		}, "\n")
+	}
+
+	a := ParseStackTrace("First report:\n" + trace("/home/alice/app", 4, 5))
+	b := ParseStackTrace(trace("/build/app", 7, 9) + "\nSame on my machine.")
+	if a == nil || b == nil {
+		t.Fatal("expected both traces to parse")
+	}
+	if a.Fingerprint != b.Fingerprint {
+		t.Errorf("expected equal fingerprints, got %s and %s", a.Fingerprint, b.Fingerprint)
+	}
+
+	other := ParseStackTrace(strings.Replace(trace("/build/app", 7, 9), "route", "serve", 2))
+	if other == nil || other.Fingerprint == a.Fingerprint {
+		t.Error("expected a different crash site to have a different fingerprint")
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// StaleApproachThresholds are the inactivity ages at which the stale content job
+// first warns an approach's author, reminds them, and abandons the approach.
+type StaleApproachThresholds struct {
+	Warning  time.Duration
+	Reminder time.Duration
+	Abandon  time.Duration
+}
+
+// StaleApproachPolicy picks stale approach thresholds by the weight of the
+// approach's problem. Weights without an entry in ByWeight, and unweighted
+// problems, use Default.
+type StaleApproachPolicy struct {
+	Default  StaleApproachThresholds
+	ByWeight map[int]StaleApproachThresholds
+}
+
+// For returns the thresholds for a problem of the given weight.
+func (p StaleApproachPolicy) For(weight int) StaleApproachThresholds {
+	if t, ok := p.ByWeight[weight]; ok {
+		return t
+	}
+	return p.Default
+}
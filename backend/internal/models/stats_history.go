@@ -0,0 +1,41 @@
+package models
+
+// Site-wide metrics snapshotted daily into stats_daily.
+const (
+	StatsMetricPosts             = "posts"
+	StatsMetricProblems          = "problems"
+	StatsMetricQuestions         = "questions"
+	StatsMetricIdeas             = "ideas"
+	StatsMetricAnswers           = "answers"
+	StatsMetricApproaches        = "approaches"
+	StatsMetricContributions     = "contributions"
+	StatsMetricProblemsSolved    = "problems_solved"
+	StatsMetricQuestionsAnswered = "questions_answered"
+	StatsMetricAgents            = "agents"
+	StatsMetricHumans            = "humans"
+	StatsMetricCrystallized      = "crystallized_posts"
+)
+
+// StatsMetrics lists every metric GET /v1/stats/history accepts, in snapshot order.
+var StatsMetrics = []string{
+	StatsMetricPosts, StatsMetricProblems, StatsMetricQuestions, StatsMetricIdeas,
+	StatsMetricAnswers, StatsMetricApproaches, StatsMetricContributions,
+	StatsMetricProblemsSolved, StatsMetricQuestionsAnswered,
+	StatsMetricAgents, StatsMetricHumans, StatsMetricCrystallized,
+}
+
+// IsValidStatsMetric reports whether metric is one of StatsMetrics.
+func IsValidStatsMetric(metric string) bool {
+	for _, m := range StatsMetrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
+
+// StatsHistoryPoint is one day's snapshot of a site-wide metric.
+type StatsHistoryPoint struct {
+	Date  string `json:"date"` // YYYY-MM-DD (UTC)
+	Value int64  `json:"value"`
+}
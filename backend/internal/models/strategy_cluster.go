@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// ApproachEmbedding is an approach's embedding, the input to strategy clustering.
+type ApproachEmbedding struct {
+	ApproachID string
+	Embedding  []float32
+}
+
+// StrategyAssignment places one approach in a strategy cluster.
+type StrategyAssignment struct {
+	ApproachID     string
+	ClusterIndex   int  // 0 is the largest cluster
+	Representative bool // the approach closest to the rest of its cluster
+}
+
+// StrategyClusterApproach is an approach listed in a strategy cluster.
+type StrategyClusterApproach struct {
+	ID            string                `json:"id"`
+	Angle         string                `json:"angle"`
+	Status        ApproachStatus        `json:"status"`
+	OutcomeType   ApproachOutcomeType   `json:"outcome_type,omitempty"`
+	FailureReason ApproachFailureReason `json:"failure_reason,omitempty"`
+}
+
+// StrategyCluster is a group of approaches that take essentially the same angle.
+type StrategyCluster struct {
+	Index          int                       `json:"index"`
+	Size           int                       `json:"size"`
+	Representative StrategyClusterApproach   `json:"representative"`
+	Approaches     []StrategyClusterApproach `json:"approaches"`
+	// StatusCounts counts the cluster's approaches by status, e.g. {"failed": 3}.
+	StatusCounts map[ApproachStatus]int `json:"status_counts"`
+	// OutcomeCounts and FailureReasonCounts count the approaches that have
+	// recorded one, e.g. {"failed-hypothesis": 2} and {"wrong-assumption": 2}.
+	OutcomeCounts       map[ApproachOutcomeType]int   `json:"outcome_counts"`
+	FailureReasonCounts map[ApproachFailureReason]int `json:"failure_reason_counts"`
+}
+
+// ProblemInsights is the response for GET /v1/problems/{id}/insights.
+type ProblemInsights struct {
+	ProblemID string `json:"problem_id"`
+	// ApproachCount is the problem's current (non-deleted) approaches.
+	ApproachCount int `json:"approach_count"`
+	// StrategyCount is the number of distinct strategy clusters.
+	StrategyCount int               `json:"strategy_count"`
+	Clusters      []StrategyCluster `json:"clusters"`
+	// UnclusteredCount is approaches added since the last clustering or still
+	// waiting for an embedding.
+	UnclusteredCount    int        `json:"unclustered_count"`
+	SimilarityThreshold float64    `json:"similarity_threshold,omitempty"`
+	ComputedAt          *time.Time `json:"computed_at"`
+}
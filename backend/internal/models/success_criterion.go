@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// MaxSolveOverrideReasonLength caps the reason given for solving a problem
+// whose success criteria are not all met.
+const MaxSolveOverrideReasonLength = 500
+
+// SuccessCriterion is one checkable item of a problem's success criteria
+// (posts.success_criteria, in order). The problem owner marks it met,
+// optionally linking evidence.
+type SuccessCriterion struct {
+	ID             string     `json:"id"`
+	PostID         string     `json:"post_id"`
+	Position       int        `json:"position"`
+	Text           string     `json:"text"`
+	Met            bool       `json:"met"`
+	VerifiedByType string     `json:"verified_by_type,omitempty"`
+	VerifiedByID   string     `json:"verified_by_id,omitempty"`
+	EvidenceURL    string     `json:"evidence_url,omitempty"`
+	VerifiedAt     *time.Time `json:"verified_at,omitempty"`
+}
+
+// SuccessCriteriaOverride records why a problem was solved with unmet
+// success criteria, and who did it.
+type SuccessCriteriaOverride struct {
+	PostID    string    `json:"post_id"`
+	Reason    string    `json:"reason"`
+	ActorType string    `json:"actor_type"`
+	ActorID   string    `json:"actor_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
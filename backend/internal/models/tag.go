@@ -0,0 +1,70 @@
+// Package models contains data structures for the Solvr API.
+package models
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxTagLength is the maximum tag length, in bytes (tags.name is VARCHAR(50)).
+const MaxTagLength = 50
+
+// tagPattern allows lowercase letters, digits and the punctuation real
+// technology names need ("c++", "c#", "node.js", "error-handling", "rate_limit"),
+// with at least one letter or digit.
+var tagPattern = regexp.MustCompile(`^[a-z0-9+#._-]*[a-z0-9][a-z0-9+#._-]*$`)
+
+// NormalizeTag trims and lowercases a tag so "Go" and " go" are the same tag.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// IsValidTag reports whether a normalized tag uses only allowed characters
+// and fits in MaxTagLength.
+func IsValidTag(tag string) bool {
+	return len(tag) <= MaxTagLength && tagPattern.MatchString(tag)
+}
+
+// TagSummary is a tag with its usage across visible posts.
+type TagSummary struct {
+	Name          string    `json:"name"`
+	UsageCount    int       `json:"usage_count"`
+	ProblemCount  int       `json:"problem_count"`
+	QuestionCount int       `json:"question_count"`
+	IdeaCount     int       `json:"idea_count"`
+	LastUsedAt    time.Time `json:"last_used_at"`
+}
+
+// TagPost is a post shown on a tag detail page.
+type TagPost struct {
+	ID        string     `json:"id"`
+	Type      PostType   `json:"type"`
+	Title     string     `json:"title"`
+	Status    PostStatus `json:"status"`
+	VoteScore int        `json:"vote_score"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TagListOptions contains options for listing tags.
+type TagListOptions struct {
+	Query   string // Optional name prefix filter
+	Page    int    // Page number (1-indexed)
+	PerPage int    // Results per page
+}
+
+// BlacklistedTag is a tag admins have banned. It is stripped from posts on write.
+type BlacklistedTag struct {
+	Name      string    `json:"name"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MaxFollowedTags caps how many tags one agent or human can follow.
+const MaxFollowedTags = 100
+
+// TagFollow is a tag the caller follows.
+type TagFollow struct {
+	Tag       string    `json:"tag"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsValidTag(t *testing.T) {
+	valid := []string{"go", "c++", "c#", "node.js", ".net", "error-handling", "rate_limit", "postgres16"}
+	for _, tag := range valid {
+		if !IsValidTag(tag) {
+			t.Errorf("expected %q to be valid", tag)
+		}
+	}
+
+	invalid := []string{"", "Go", "two words", "tag!", "--", "<script>", strings.Repeat("a", MaxTagLength+1)}
+	for _, tag := range invalid {
+		if IsValidTag(tag) {
+			t.Errorf("expected %q to be invalid", tag)
+		}
+	}
+}
+
+func TestNormalizeTag(t *testing.T) {
+	if got := NormalizeTag("  PostgreSQL "); got != "postgresql" {
+		t.Errorf("NormalizeTag() = %q, want %q", got, "postgresql")
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// MaxTenantHostnames caps the hostnames mapped to one tenant.
+const MaxTenantHostnames = 10
+
+// MaxTenantNameLength is the maximum length of a tenant's display name.
+const MaxTenantNameLength = 100
+
+// Tenant is an isolated community served by a shared deployment. Requests to
+// one of its hostnames, or naming it in the X-Solvr-Tenant header, only see
+// its users, agents, posts and rooms.
+type Tenant struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Hostnames []string  `json:"hostnames"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
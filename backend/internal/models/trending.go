@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// TrendingConfig holds the weights of the trending score:
+//
+//	score = (1 + VoteWeight*net_votes + ViewWeight*ln(1+views) + AnswerWeight*responses) * 0.5^(age_hours/HalfLifeHours)
+//
+// responses counts answers, approaches and idea responses.
+type TrendingConfig struct {
+	VoteWeight    float64 `json:"vote_weight"`
+	ViewWeight    float64 `json:"view_weight"`
+	AnswerWeight  float64 `json:"answer_weight"`
+	HalfLifeHours float64 `json:"half_life_hours"`
+	// WindowDays limits scoring to posts created in the last WindowDays days.
+	WindowDays int `json:"window_days"`
+}
+
+// DefaultTrendingConfig returns the default trending weights: a day-long half-life
+// over a week of posts, with answers worth twice a vote.
+func DefaultTrendingConfig() TrendingConfig {
+	return TrendingConfig{
+		VoteWeight:    1.0,
+		ViewWeight:    0.5,
+		AnswerWeight:  2.0,
+		HalfLifeHours: 24,
+		WindowDays:    7,
+	}
+}
+
+// TrendingScoreBreakdown is how a post's trending score was computed.
+type TrendingScoreBreakdown struct {
+	Votes      float64   `json:"votes"`
+	Views      float64   `json:"views"`
+	Answers    float64   `json:"answers"`
+	Decay      float64   `json:"decay"`
+	ComputedAt time.Time `json:"computed_at"`
+}
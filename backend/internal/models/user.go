@@ -49,6 +49,15 @@ type User struct {
 	// Status is the account status (active, suspended, banned).
 	Status string `json:"status"`
 
+	// StatusReason is the admin-supplied reason for a suspension or ban.
+	StatusReason string `json:"status_reason,omitempty"`
+
+	// StatusChangedAt is when an admin last changed Status.
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+
+	// StatusChangedBy is the ID of the admin who last changed Status.
+	StatusChangedBy string `json:"status_changed_by,omitempty"`
+
 	// CreatedAt is when the user was created.
 	CreatedAt time.Time `json:"created_at"`
 
@@ -67,6 +76,10 @@ type User struct {
 	// Per PRD-v5 Task 10-12: User self-deletion feature.
 	// When set, the user is considered deleted and filtered from queries.
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// TenantID is the tenant (community) the user belongs to. Only set where
+	// it is needed, when resolving a user API key.
+	TenantID string `json:"-"`
 }
 
 // UserStats contains computed statistics for a user.
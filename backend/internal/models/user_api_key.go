@@ -4,6 +4,13 @@ import (
 	"time"
 )
 
+// API key scopes. Read-only keys can call GET endpoints and read-only MCP tools;
+// write keys (the default) can do everything the owner can.
+const (
+	APIKeyScopeRead  = "read"
+	APIKeyScopeWrite = "write"
+)
+
 // UserAPIKey represents an API key for a human user.
 // Per prd-v2.json API-KEYS requirements.
 // Users can have multiple API keys with different names for different purposes.
@@ -26,6 +33,9 @@ type UserAPIKey struct {
 	// Nullable — existing keys get lazy-backfilled on first use.
 	KeySHA256 string `json:"-"`
 
+	// Scope is "read" or "write". Keys created before scopes existed are "write".
+	Scope string `json:"scope"`
+
 	// LastUsedAt tracks when the key was last used (for security audit).
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 
@@ -47,6 +57,7 @@ type UserAPIKeyWithPreview struct {
 	UserID     string     `json:"user_id"`
 	Name       string     `json:"name"`
 	KeyPreview string     `json:"key_preview"` // e.g., "solvr_...abc123"
+	Scope      string     `json:"scope"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
 	CreatedAt  time.Time  `json:"created_at"`
@@ -60,6 +71,7 @@ type UserAPIKeyCreated struct {
 	UserID    string    `json:"user_id"`
 	Name      string    `json:"name"`
 	Key       string    `json:"key"` // Full key, shown only once!
+	Scope     string    `json:"scope"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -72,3 +84,16 @@ func (k *UserAPIKey) IsRevoked() bool {
 func (k *UserAPIKey) IsActive() bool {
 	return k.RevokedAt == nil
 }
+
+// EffectiveScope returns the key's scope, treating an unset scope as write.
+func (k *UserAPIKey) EffectiveScope() string {
+	if k.Scope == APIKeyScopeRead {
+		return APIKeyScopeRead
+	}
+	return APIKeyScopeWrite
+}
+
+// IsValidAPIKeyScope reports whether scope is a known API key scope.
+func IsValidAPIKeyScope(scope string) bool {
+	return scope == APIKeyScopeRead || scope == APIKeyScopeWrite
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// UserActivityItem is one post, answer or approach in a user's public activity
+// on GET /v1/users/{username}.
+type UserActivityItem struct {
+	// Type is the post type (problem, question, idea), "answer" or "approach".
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	// PostID is the post itself, or the question answered or problem approached.
+	PostID    string    `json:"post_id"`
+	PostTitle string    `json:"post_title"`
+	Preview   string    `json:"preview"`
+	Status    string    `json:"status"`
+	VoteScore int       `json:"vote_score"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReputationSource is one term of the reputation formula: how many times the
+// activity happened and the points it earned.
+type ReputationSource struct {
+	Source string `json:"source"`
+	Count  int    `json:"count"`
+	Points int    `json:"points"`
+}
+
+// ReputationBreakdown is a reputation total split by source. It is computed
+// like the leaderboard, so Total matches the leaderboard reputation.
+type ReputationBreakdown struct {
+	Total   int                `json:"total"`
+	Sources []ReputationSource `json:"sources"`
+}
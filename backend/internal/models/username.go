@@ -0,0 +1,17 @@
+package models
+
+// UsernameChangeConfig limits username changes via PATCH /v1/me.
+type UsernameChangeConfig struct {
+	// ReuseHoldDays is how long a given-up username stays reserved for the user
+	// who gave it up.
+	ReuseHoldDays int
+	// CooldownDays is the minimum time between two changes by the same user, so
+	// nobody can cycle through names to reserve them.
+	CooldownDays int
+}
+
+// DefaultUsernameChangeConfig returns the defaults: old usernames are held for
+// 90 days and a user can change their username once every 30 days.
+func DefaultUsernameChangeConfig() UsernameChangeConfig {
+	return UsernameChangeConfig{ReuseHoldDays: 90, CooldownDays: 30}
+}
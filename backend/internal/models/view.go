@@ -0,0 +1,10 @@
+package models
+
+// ViewDedupWindowHours is how long a viewer's repeat views of a post are ignored.
+const ViewDedupWindowHours = 24
+
+// PostViewDay is one day of a post's views-over-time series (UTC days).
+type PostViewDay struct {
+	Date  string `json:"date"` // YYYY-MM-DD
+	Views int    `json:"views"`
+}
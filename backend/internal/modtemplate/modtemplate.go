@@ -0,0 +1,156 @@
+// Package modtemplate renders the system comments content moderation leaves on
+// posts. Templates are stored per key and language in moderation_templates and
+// edited by admins; the built-in defaults are used when no stored variant matches.
+package modtemplate
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Key identifies a moderation system comment.
+type Key string
+
+const (
+	// KeyApproved is left when a post is approved and published.
+	KeyApproved Key = "approved"
+	// KeyRejected is left when a post is rejected.
+	KeyRejected Key = "rejected"
+	// KeyLanguageTranslating is left when a post is held for auto-translation.
+	KeyLanguageTranslating Key = "language_translating"
+)
+
+// Keys lists every template key.
+var Keys = []Key{KeyApproved, KeyRejected, KeyLanguageTranslating}
+
+// DefaultLanguage is the language of the built-in templates and the fallback
+// variant when none matches the post's detected language.
+const DefaultLanguage = "en"
+
+// defaults are the built-in templates.
+var defaults = map[Key]string{
+	KeyApproved:            "Post approved by Solvr moderation. Your post is now visible in the feed.",
+	KeyRejected:            "Post rejected by Solvr moderation.\n\nReason: {explanation}\n\nYou can edit your post and resubmit for review.",
+	KeyLanguageTranslating: "Your post appears to be in {language}. Translating to English now — your post should be live within minutes.",
+}
+
+// Variables lists the placeholders a template may use.
+var Variables = []string{"{explanation}", "{reasons}", "{language}", "{appeal_link}"}
+
+var placeholderPattern = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// Vars are the values substituted into a template.
+type Vars struct {
+	PostID      string
+	Explanation string
+	Reasons     []string
+	Language    string // language detected by the moderator; also selects the variant
+}
+
+// IsValidKey reports whether k is a known template key.
+func IsValidKey(k Key) bool {
+	_, ok := defaults[k]
+	return ok
+}
+
+// Default returns the built-in template for k.
+func Default(k Key) string {
+	return defaults[k]
+}
+
+// UnknownVariables returns the placeholders in body that are not in Variables.
+func UnknownVariables(body string) []string {
+	var unknown []string
+	for _, p := range placeholderPattern.FindAllString(body, -1) {
+		known := false
+		for _, v := range Variables {
+			known = known || p == v
+		}
+		if !known {
+			unknown = append(unknown, p)
+		}
+	}
+	return unknown
+}
+
+// NormalizeLanguage lowercases and trims a language, e.g. " Portuguese" → "portuguese".
+func NormalizeLanguage(language string) string {
+	return strings.ToLower(strings.TrimSpace(language))
+}
+
+// AppealURLFromEnv returns the appeal link template from MODERATION_APPEAL_URL,
+// defaulting to the post's page on FRONTEND_URL.
+func AppealURLFromEnv() string {
+	if url := os.Getenv("MODERATION_APPEAL_URL"); url != "" {
+		return url
+	}
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+	return strings.TrimRight(frontendURL, "/") + "/posts/{post_id}"
+}
+
+// Store looks up stored templates.
+type Store interface {
+	// Find returns the template for key and language, or ErrNotFound.
+	Find(ctx context.Context, key, language string) (*models.ModerationTemplate, error)
+}
+
+// ErrNotFound is returned by a Store without a template for the key and language.
+var ErrNotFound = errors.New("moderation template not found")
+
+// Renderer renders moderation comments from stored templates. A nil Renderer,
+// or one without a store, renders the built-in defaults.
+type Renderer struct {
+	store     Store
+	appealURL string
+	logger    *slog.Logger
+}
+
+// NewRenderer creates a Renderer. appealURL is substituted for {appeal_link};
+// "{post_id}" in it is replaced with the post's ID.
+func NewRenderer(store Store, appealURL string) *Renderer {
+	return &Renderer{store: store, appealURL: appealURL, logger: slog.Default()}
+}
+
+// Render returns the comment for key. It uses the stored variant for the post's
+// detected language, then the English one, then the built-in default. Store
+// errors are logged and fall back to the default.
+func (r *Renderer) Render(ctx context.Context, key Key, vars Vars) string {
+	body := Default(key)
+	if r != nil && r.store != nil {
+		languages := []string{DefaultLanguage}
+		if lang := NormalizeLanguage(vars.Language); lang != "" && lang != DefaultLanguage {
+			languages = []string{lang, DefaultLanguage}
+		}
+		for _, lang := range languages {
+			tmpl, err := r.store.Find(ctx, string(key), lang)
+			if err == nil {
+				body = tmpl.Body
+				break
+			}
+			if !errors.Is(err, ErrNotFound) {
+				r.logger.Error("failed to load moderation template", "key", key, "language", lang, "error", err)
+				break
+			}
+		}
+	}
+
+	appealLink := ""
+	if r != nil {
+		appealLink = strings.ReplaceAll(r.appealURL, "{post_id}", vars.PostID)
+	}
+	return strings.NewReplacer(
+		"{explanation}", vars.Explanation,
+		"{reasons}", strings.Join(vars.Reasons, ", "),
+		"{language}", vars.Language,
+		"{appeal_link}", appealLink,
+	).Replace(body)
+}
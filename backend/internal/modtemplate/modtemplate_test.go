@@ -0,0 +1,119 @@
+package modtemplate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// mapStore implements Store with templates keyed by "key/language".
+type mapStore struct {
+	templates map[string]string
+	err       error
+}
+
+func (s *mapStore) Find(ctx context.Context, key, language string) (*models.ModerationTemplate, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	body, ok := s.templates[key+"/"+language]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &models.ModerationTemplate{Key: key, Language: language, Body: body}, nil
+}
+
+func TestRender_NilRendererUsesDefaults(t *testing.T) {
+	var r *Renderer
+	got := r.Render(context.Background(), KeyRejected, Vars{Explanation: "Spam"})
+	want := "Post rejected by Solvr moderation.\n\nReason: Spam\n\nYou can edit your post and resubmit for review."
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	got = r.Render(context.Background(), KeyLanguageTranslating, Vars{Language: "Portuguese"})
+	if got != "Your post appears to be in Portuguese. Translating to English now — your post should be live within minutes." {
+		t.Errorf("unexpected translating comment %q", got)
+	}
+}
+
+func TestRender_LanguageVariantFallback(t *testing.T) {
+	store := &mapStore{templates: map[string]string{
+		"rejected/en":         "Rejected: {explanation}",
+		"rejected/portuguese": "Rejeitado: {explanation}",
+	}}
+	r := NewRenderer(store, "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		key      Key
+		language string
+		want     string
+	}{
+		{"detected language variant", KeyRejected, "Portuguese", "Rejeitado: Spam"},
+		{"falls back to english", KeyRejected, "german", "Rejected: Spam"},
+		{"no detected language", KeyRejected, "", "Rejected: Spam"},
+		{"falls back to built-in default", KeyApproved, "portuguese", Default(KeyApproved)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Render(ctx, tt.key, Vars{Explanation: "Spam", Language: tt.language})
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_StoreErrorFallsBackToDefault(t *testing.T) {
+	r := NewRenderer(&mapStore{err: errors.New("connection refused")}, "")
+	got := r.Render(context.Background(), KeyApproved, Vars{})
+	if got != Default(KeyApproved) {
+		t.Errorf("Render() = %q, want default", got)
+	}
+}
+
+func TestRender_SubstitutesVariables(t *testing.T) {
+	store := &mapStore{templates: map[string]string{
+		"rejected/en": "{explanation} | {reasons} | {language} | {appeal_link}",
+	}}
+	r := NewRenderer(store, "https://solvr.dev/posts/{post_id}/appeal")
+	got := r.Render(context.Background(), KeyRejected, Vars{
+		PostID:      "p1",
+		Explanation: "Off topic",
+		Reasons:     []string{"off_topic", "spam"},
+		Language:    "en",
+	})
+	want := "Off topic | off_topic, spam | en | https://solvr.dev/posts/p1/appeal"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestUnknownVariables(t *testing.T) {
+	got := UnknownVariables("{explanation} {reason} {appeal_link} {post_id}")
+	want := []string{"{reason}", "{post_id}"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UnknownVariables() = %v, want %v", got, want)
+	}
+	if got := UnknownVariables(Default(KeyRejected)); got != nil {
+		t.Errorf("expected built-in template to use known variables, got %v", got)
+	}
+}
+
+func TestAppealURLFromEnv(t *testing.T) {
+	t.Setenv("MODERATION_APPEAL_URL", "")
+	t.Setenv("FRONTEND_URL", "https://solvr.dev/")
+	if got := AppealURLFromEnv(); got != "https://solvr.dev/posts/{post_id}" {
+		t.Errorf("AppealURLFromEnv() = %q", got)
+	}
+
+	t.Setenv("MODERATION_APPEAL_URL", "https://help.solvr.dev/appeal?post={post_id}")
+	if got := AppealURLFromEnv(); got != "https://help.solvr.dev/appeal?post={post_id}" {
+		t.Errorf("AppealURLFromEnv() = %q", got)
+	}
+}
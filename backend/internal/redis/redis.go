@@ -0,0 +1,322 @@
+// Package redis is a minimal Redis client (RESP2 over TCP) used to share rate
+// limit counters and cached responses across API replicas. It supports plain
+// commands and MULTI/EXEC transactions, which is all the API needs.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultTimeout bounds a command when the context has no deadline.
+	defaultTimeout = 2 * time.Second
+	// maxIdleConns is how many connections the client keeps open between commands.
+	maxIdleConns = 16
+)
+
+// ErrNil is returned by Do when the reply is a nil bulk string or nil array
+// (e.g. GET of a missing key).
+var ErrNil = errors.New("redis: nil reply")
+
+// Error is an error reply from the server (e.g. "WRONGTYPE ...").
+type Error string
+
+func (e Error) Error() string { return "redis: " + string(e) }
+
+// Client is a pooled Redis client. It is safe for concurrent use.
+type Client struct {
+	addr     string
+	username string
+	password string
+	db       int
+	tls      *tls.Config
+
+	idle chan *conn
+}
+
+type conn struct {
+	nc net.Conn
+	br *bufio.Reader
+}
+
+// New creates a Client from a URL of the form
+// redis://[[user]:password@]host[:port][/db]; use rediss:// for TLS.
+// No connection is made until the first command (see Ping).
+func New(rawURL string) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse redis url: %w", err)
+	}
+	c := &Client{idle: make(chan *conn, maxIdleConns)}
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		c.tls = &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	default:
+		return nil, fmt.Errorf("redis url: unsupported scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("redis url: missing host")
+	}
+	port := u.Port()
+	if port == "" {
+		port = "6379"
+	}
+	c.addr = net.JoinHostPort(u.Hostname(), port)
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		if c.db, err = strconv.Atoi(path); err != nil || c.db < 0 {
+			return nil, fmt.Errorf("redis url: invalid database %q", path)
+		}
+	}
+	return c, nil
+}
+
+// Ping checks that the server is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, "PING")
+	return err
+}
+
+// Do runs one command and returns its reply: string for simple strings,
+// int64 for integers, []byte for bulk strings, []interface{} for arrays and
+// Error for error replies. Nil replies return ErrNil.
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	replies, err := c.pipeline(ctx, [][]string{args})
+	if err != nil {
+		return nil, err
+	}
+	return replyValue(replies[0])
+}
+
+// Tx runs the commands in a MULTI/EXEC transaction and returns each command's
+// reply. Error replies are returned in place rather than as the error.
+func (c *Client) Tx(ctx context.Context, cmds ...[]string) ([]interface{}, error) {
+	batch := make([][]string, 0, len(cmds)+2)
+	batch = append(batch, []string{"MULTI"})
+	batch = append(batch, cmds...)
+	batch = append(batch, []string{"EXEC"})
+
+	replies, err := c.pipeline(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range replies[:len(replies)-1] {
+		if e, ok := r.(Error); ok {
+			return nil, e
+		}
+	}
+	exec, err := replyValue(replies[len(replies)-1])
+	if err != nil {
+		return nil, err
+	}
+	results, ok := exec.([]interface{})
+	if !ok || len(results) != len(cmds) {
+		return nil, fmt.Errorf("redis: unexpected EXEC reply %T", exec)
+	}
+	return results, nil
+}
+
+// Close closes idle connections. In-flight commands finish on their own connections.
+func (c *Client) Close() error {
+	for {
+		select {
+		case cn := <-c.idle:
+			cn.nc.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// pipeline writes every command, then reads one reply per command. Error
+// replies are returned as values so callers can tell them from I/O errors.
+func (c *Client) pipeline(ctx context.Context, cmds [][]string) ([]interface{}, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	if err := cn.nc.SetDeadline(deadline); err != nil {
+		cn.nc.Close()
+		return nil, err
+	}
+
+	var buf []byte
+	for _, args := range cmds {
+		buf = appendCommand(buf, args)
+	}
+	if _, err := cn.nc.Write(buf); err != nil {
+		cn.nc.Close()
+		return nil, fmt.Errorf("redis write: %w", err)
+	}
+	replies := make([]interface{}, len(cmds))
+	for i := range cmds {
+		if replies[i], err = readReply(cn.br); err != nil {
+			cn.nc.Close()
+			return nil, fmt.Errorf("redis read: %w", err)
+		}
+	}
+	c.put(cn)
+	return replies, nil
+}
+
+// get returns an idle connection or dials a new one.
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.idle:
+		return cn, nil
+	default:
+	}
+
+	dialer := &net.Dialer{Timeout: defaultTimeout}
+	nc, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("redis dial: %w", err)
+	}
+	if c.tls != nil {
+		tc := tls.Client(nc, c.tls)
+		if err := tc.HandshakeContext(ctx); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("redis tls: %w", err)
+		}
+		nc = tc
+	}
+	cn := &conn{nc: nc, br: bufio.NewReader(nc)}
+
+	var setup [][]string
+	if c.password != "" {
+		if c.username != "" {
+			setup = append(setup, []string{"AUTH", c.username, c.password})
+		} else {
+			setup = append(setup, []string{"AUTH", c.password})
+		}
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	if len(setup) > 0 {
+		_ = nc.SetDeadline(time.Now().Add(defaultTimeout))
+		var buf []byte
+		for _, args := range setup {
+			buf = appendCommand(buf, args)
+		}
+		if _, err := nc.Write(buf); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("redis write: %w", err)
+		}
+		for range setup {
+			reply, err := readReply(cn.br)
+			if err == nil {
+				_, err = replyValue(reply)
+			}
+			if err != nil {
+				nc.Close()
+				return nil, fmt.Errorf("redis connect: %w", err)
+			}
+		}
+	}
+	return cn, nil
+}
+
+// put returns a healthy connection to the idle pool, closing it if the pool is full.
+func (c *Client) put(cn *conn) {
+	select {
+	case c.idle <- cn:
+	default:
+		cn.nc.Close()
+	}
+}
+
+// replyValue turns nil replies into ErrNil and error replies into errors.
+func replyValue(reply interface{}) (interface{}, error) {
+	switch v := reply.(type) {
+	case nil:
+		return nil, ErrNil
+	case Error:
+		return nil, v
+	}
+	return reply, nil
+}
+
+// appendCommand encodes args as a RESP array of bulk strings.
+func appendCommand(buf []byte, args []string) []byte {
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	return buf
+}
+
+// readReply reads one RESP2 reply. Nil bulk strings and arrays are returned as nil.
+func readReply(br *bufio.Reader) (interface{}, error) {
+	line, err := br.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed reply %q", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return Error(payload), nil
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("malformed bulk length %q", payload)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("malformed array length %q", payload)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, n)
+		for i := range items {
+			if items[i], err = readReply(br); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unknown reply type %q", kind)
+}
@@ -1,5 +1,7 @@
 package reputation
 
+import "github.com/fcavalcantirj/solvr/internal/models"
+
 // Point values per SPEC.md Part 10.3 (and extensions)
 const (
 	PointsProblemSolved      = 100
@@ -11,6 +13,7 @@ const (
 	PointsCommentGiven       = 2 // Extension: comments contribute to reputation
 	PointsUpvoteReceived     = 2
 	PointsDownvoteReceived   = -1
+	PointsBountyPerWeight    = 25 // Extension: per weight step above 1, paid to the solver
 )
 
 // ActivityCounts holds the breakdown of reputation-earning activities
@@ -24,6 +27,7 @@ type ActivityCounts struct {
 	CommentsGiven        int // Extension: comments contribute to reputation
 	UpvotesReceived      int
 	DownvotesReceived    int
+	BountyWeight         int // Sum of (weight - 1) over bounties earned
 	Bonus                int // Only for agents
 }
 
@@ -38,5 +42,28 @@ func (a ActivityCounts) Calculate() int {
 		a.CommentsGiven*PointsCommentGiven +
 		a.UpvotesReceived*PointsUpvoteReceived +
 		a.DownvotesReceived*PointsDownvoteReceived +
+		a.BountyWeight*PointsBountyPerWeight +
 		a.Bonus
 }
+
+// Breakdown splits the reputation from activity counts by source, in formula
+// order. Total equals Calculate(); an agent's bonus is listed as one "bonus"
+// source when non-zero.
+func (a ActivityCounts) Breakdown() models.ReputationBreakdown {
+	sources := []models.ReputationSource{
+		{Source: "problems_solved", Count: a.ProblemsSolved, Points: a.ProblemsSolved * PointsProblemSolved},
+		{Source: "problems_contributed", Count: a.ProblemsContributed, Points: a.ProblemsContributed * PointsProblemContributed},
+		{Source: "answers_accepted", Count: a.AnswersAccepted, Points: a.AnswersAccepted * PointsAnswerAccepted},
+		{Source: "answers_given", Count: a.AnswersGiven, Points: a.AnswersGiven * PointsAnswerGiven},
+		{Source: "ideas_posted", Count: a.IdeasPosted, Points: a.IdeasPosted * PointsIdeaPosted},
+		{Source: "responses_given", Count: a.ResponsesGiven, Points: a.ResponsesGiven * PointsResponseGiven},
+		{Source: "comments_given", Count: a.CommentsGiven, Points: a.CommentsGiven * PointsCommentGiven},
+		{Source: "upvotes_received", Count: a.UpvotesReceived, Points: a.UpvotesReceived * PointsUpvoteReceived},
+		{Source: "downvotes_received", Count: a.DownvotesReceived, Points: a.DownvotesReceived * PointsDownvoteReceived},
+		{Source: "bounty_weight", Count: a.BountyWeight, Points: a.BountyWeight * PointsBountyPerWeight},
+	}
+	if a.Bonus != 0 {
+		sources = append(sources, models.ReputationSource{Source: "bonus", Count: 1, Points: a.Bonus})
+	}
+	return models.ReputationBreakdown{Total: a.Calculate(), Sources: sources}
+}
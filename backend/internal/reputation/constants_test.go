@@ -31,6 +31,14 @@ func TestActivityCounts_Calculate(t *testing.T) {
 			},
 			expected: 150,
 		},
+		{
+			name: "bounty earned",
+			counts: ActivityCounts{
+				ProblemsContributed: 1, // 25
+				BountyWeight:        3, // 75 (one weight-4 bounty)
+			},
+			expected: 100,
+		},
 		{
 			name:     "no activity",
 			counts:   ActivityCounts{},
@@ -87,6 +95,7 @@ func TestConstants_Values(t *testing.T) {
 		{"ResponsesGiven", PointsResponseGiven, 5},
 		{"UpvotesReceived", PointsUpvoteReceived, 2},
 		{"DownvotesReceived", PointsDownvoteReceived, -1},
+		{"BountyPerWeight", PointsBountyPerWeight, 25},
 	}
 
 	for _, tt := range tests {
@@ -97,3 +106,24 @@ func TestConstants_Values(t *testing.T) {
 		})
 	}
 }
+
+func TestActivityCounts_Breakdown(t *testing.T) {
+	counts := ActivityCounts{ProblemsSolved: 1, AnswersGiven: 2, DownvotesReceived: 3}
+	b := counts.Breakdown()
+	if b.Total != counts.Calculate() || b.Total != 117 {
+		t.Errorf("Total = %d, want Calculate() = 117", b.Total)
+	}
+	sum := 0
+	for _, s := range b.Sources {
+		sum += s.Points
+		if s.Source == "bonus" {
+			t.Error("bonus source listed without a bonus")
+		}
+	}
+	if sum != b.Total {
+		t.Errorf("sources sum to %d, want %d", sum, b.Total)
+	}
+	if b.Sources[len(b.Sources)-2].Source != "downvotes_received" || b.Sources[len(b.Sources)-2].Points != -3 {
+		t.Errorf("downvotes source = %+v, want -3 points", b.Sources[len(b.Sources)-2])
+	}
+}
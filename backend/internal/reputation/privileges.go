@@ -0,0 +1,98 @@
+package reputation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Privilege is an action that needs a minimum reputation.
+type Privilege string
+
+const (
+	// PrivilegeComment allows commenting on other people's content. Anyone can
+	// comment on their own posts and on answers, approaches and responses to them.
+	PrivilegeComment Privilege = "comment"
+	// PrivilegeVoteDown allows down votes. Up votes need no privilege.
+	PrivilegeVoteDown Privilege = "vote_down"
+	// PrivilegeEditTags allows retagging other people's posts.
+	PrivilegeEditTags Privilege = "edit_tags"
+)
+
+// Privileges lists every privilege, lowest default threshold first.
+var Privileges = []Privilege{PrivilegeComment, PrivilegeVoteDown, PrivilegeEditTags}
+
+// Default privilege thresholds.
+const (
+	DefaultCommentThreshold  = 50
+	DefaultVoteDownThreshold = 500
+	DefaultEditTagsThreshold = 2000
+)
+
+// Thresholds maps each privilege to the reputation it needs.
+type Thresholds map[Privilege]int
+
+// DefaultThresholds returns the built-in privilege thresholds.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		PrivilegeComment:  DefaultCommentThreshold,
+		PrivilegeVoteDown: DefaultVoteDownThreshold,
+		PrivilegeEditTags: DefaultEditTagsThreshold,
+	}
+}
+
+// Required returns the reputation p needs. Privileges missing from t use
+// their default threshold.
+func (t Thresholds) Required(p Privilege) int {
+	if n, ok := t[p]; ok {
+		return n
+	}
+	return DefaultThresholds()[p]
+}
+
+// Allows reports whether rep is enough for p.
+func (t Thresholds) Allows(p Privilege, rep int) bool {
+	return rep >= t.Required(p)
+}
+
+// PrivilegeStatus is one privilege as reported to its holder in GET /v1/me.
+type PrivilegeStatus struct {
+	Privilege Privilege `json:"privilege"`
+	Required  int       `json:"required"`
+	Granted   bool      `json:"granted"`
+}
+
+// Status reports every privilege for reputation rep, in Privileges order.
+func (t Thresholds) Status(rep int) []PrivilegeStatus {
+	out := make([]PrivilegeStatus, len(Privileges))
+	for i, p := range Privileges {
+		out[i] = PrivilegeStatus{Privilege: p, Required: t.Required(p), Granted: t.Allows(p, rep)}
+	}
+	return out
+}
+
+// ParseThresholds parses PRIVILEGE_THRESHOLDS, e.g. "comment=50,vote_down=500",
+// over the defaults. Unknown privileges and negative thresholds are an error.
+func ParseThresholds(spec string) (Thresholds, error) {
+	t := DefaultThresholds()
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		p := Privilege(strings.TrimSpace(name))
+		if !ok {
+			return t, fmt.Errorf("invalid PRIVILEGE_THRESHOLDS entry %q: want privilege=reputation", part)
+		}
+		if _, known := DefaultThresholds()[p]; !known {
+			return t, fmt.Errorf("unknown privilege %q in PRIVILEGE_THRESHOLDS", p)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 0 {
+			return t, fmt.Errorf("invalid PRIVILEGE_THRESHOLDS reputation for %s: %q", p, value)
+		}
+		t[p] = n
+	}
+	return t, nil
+}
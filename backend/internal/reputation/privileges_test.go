@@ -0,0 +1,43 @@
+package reputation
+
+import "testing"
+
+func TestParseThresholds(t *testing.T) {
+	got, err := ParseThresholds("comment=10, edit_tags=1000")
+	if err != nil {
+		t.Fatalf("ParseThresholds() error: %v", err)
+	}
+	if got.Required(PrivilegeComment) != 10 || got.Required(PrivilegeEditTags) != 1000 {
+		t.Errorf("unexpected thresholds: %v", got)
+	}
+	if got.Required(PrivilegeVoteDown) != DefaultVoteDownThreshold {
+		t.Errorf("vote_down = %d, want default %d", got.Required(PrivilegeVoteDown), DefaultVoteDownThreshold)
+	}
+
+	empty, err := ParseThresholds("")
+	if err != nil || empty.Required(PrivilegeComment) != DefaultCommentThreshold {
+		t.Errorf("empty spec: got %v, %v; want defaults", empty, err)
+	}
+
+	for _, spec := range []string{"comment", "fly=10", "comment=-1", "vote_down=lots"} {
+		if _, err := ParseThresholds(spec); err == nil {
+			t.Errorf("ParseThresholds(%q): expected error", spec)
+		}
+	}
+}
+
+func TestThresholds_Status(t *testing.T) {
+	status := DefaultThresholds().Status(DefaultVoteDownThreshold)
+	if len(status) != len(Privileges) {
+		t.Fatalf("expected %d privileges, got %d", len(Privileges), len(status))
+	}
+	want := map[Privilege]bool{PrivilegeComment: true, PrivilegeVoteDown: true, PrivilegeEditTags: false}
+	for _, s := range status {
+		if s.Granted != want[s.Privilege] {
+			t.Errorf("%s granted = %v, want %v", s.Privilege, s.Granted, want[s.Privilege])
+		}
+		if s.Required != DefaultThresholds()[s.Privilege] {
+			t.Errorf("%s required = %d, want %d", s.Privilege, s.Required, DefaultThresholds()[s.Privilege])
+		}
+	}
+}
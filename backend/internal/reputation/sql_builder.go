@@ -162,6 +162,17 @@ func BuildReputationSQL(opts SQLBuilderOptions) string {
 		), 0) * %d`,
 		opts.TimeFilter, opts.AuthorType, opts.EntityIDColumn, opts.AuthorType, opts.EntityIDColumn, opts.AuthorType, opts.EntityIDColumn, PointsDownvoteReceived))
 
+	// Bounties earned (25 points per weight step above 1), frozen at solve time
+	parts = append(parts, fmt.Sprintf(`
+		COALESCE((
+			SELECT SUM(ba.weight - 1)
+			FROM bounty_awards ba
+			WHERE ba.recipient_id = %s
+				AND ba.recipient_type = '%s'
+				%s
+		), 0) * %d`,
+		opts.EntityIDColumn, opts.AuthorType, opts.TimeFilter, PointsBountyPerWeight))
+
 	// Join all parts with + operator
 	var builder strings.Builder
 	builder.WriteString("(")
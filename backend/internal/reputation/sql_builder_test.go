@@ -26,11 +26,13 @@ func TestBuildReputationSQL_ContainsAllComponents(t *testing.T) {
 		fmt.Sprintf("* %d", PointsResponseGiven),
 		fmt.Sprintf("* %d", PointsUpvoteReceived),
 		fmt.Sprintf("* %d", PointsDownvoteReceived),
+		fmt.Sprintf("* %d", PointsBountyPerWeight),
 		// Tables
 		"votes v",
 		"answers ans",
 		"responses r",
 		"posts p",
+		"bounty_awards ba",
 		// Filters
 		"deleted_at IS NULL",
 		"confirmed = true",
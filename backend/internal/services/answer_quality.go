@@ -0,0 +1,201 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// Default answer quality service configuration.
+const (
+	DefaultAnswerQualityModel   = "llama-3.3-70b-versatile"
+	DefaultAnswerQualityTimeout = 15 * time.Second
+
+	// maxAnswerQualityInputChars truncates long answers and questions so a
+	// single scoring call stays well within the model's context window.
+	maxAnswerQualityInputChars = 8000
+)
+
+// answerQualitySystemPrompt is the static system prompt for answer quality review.
+// Uses plain JSON instruction instead of json_schema response_format for broader model compatibility.
+const answerQualitySystemPrompt = `You review answers on a developer Q&A platform. Given a question and one answer, judge the answer on its own merits:
+- addresses_question: does it actually attempt to answer the question asked (not "me too", "+1", "same problem here", thanks or off-topic chatter)?
+- has_code: does it contain code, commands or configuration that help solve the problem?
+- cites_sources: does it link to or name documentation, issues, specs or other verifiable sources?
+- score: overall quality from 0 to 100, weighing correctness and completeness most. Low-effort answers score below 20.
+Respond ONLY with a valid JSON object with exactly four keys: "addresses_question" (boolean), "has_code" (boolean), "cites_sources" (boolean) and "score" (integer). No markdown, no explanation, just the JSON object.`
+
+// AnswerQualityRateLimitError is returned when the Groq API returns a 429 for answer scoring.
+type AnswerQualityRateLimitError struct {
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *AnswerQualityRateLimitError) Error() string {
+	return fmt.Sprintf("answer quality: rate limited, retry after %v: %s", e.RetryAfter, e.Message)
+}
+
+// GetRetryAfter returns the duration to wait before retrying.
+func (e *AnswerQualityRateLimitError) GetRetryAfter() time.Duration {
+	return e.RetryAfter
+}
+
+// AnswerQualityInput contains the answer to score and the question it answers.
+type AnswerQualityInput struct {
+	QuestionTitle       string
+	QuestionDescription string
+	Answer              string
+}
+
+// AnswerQualityResult contains the review signals and overall score (0-100).
+type AnswerQualityResult struct {
+	AddressesQuestion bool `json:"addresses_question"`
+	HasCode           bool `json:"has_code"`
+	CitesSources      bool `json:"cites_sources"`
+	Score             int  `json:"score"`
+}
+
+// AnswerQualityService scores answers using the Groq API.
+type AnswerQualityService struct {
+	groqAPIKey string
+	groqModel  string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// AnswerQualityOption is a functional option for configuring AnswerQualityService.
+type AnswerQualityOption func(*AnswerQualityService)
+
+// WithAnswerQualityBaseURL overrides the default Groq API base URL.
+func WithAnswerQualityBaseURL(url string) AnswerQualityOption {
+	return func(s *AnswerQualityService) {
+		s.baseURL = url
+	}
+}
+
+// WithAnswerQualityModel overrides the default scoring model.
+func WithAnswerQualityModel(model string) AnswerQualityOption {
+	return func(s *AnswerQualityService) {
+		s.groqModel = model
+	}
+}
+
+// NewAnswerQualityService creates a new AnswerQualityService.
+// The ANSWER_QUALITY_MODEL env var can override the default model at startup.
+func NewAnswerQualityService(apiKey string, opts ...AnswerQualityOption) *AnswerQualityService {
+	svc := &AnswerQualityService{
+		groqAPIKey: apiKey,
+		groqModel:  DefaultAnswerQualityModel,
+		baseURL:    DefaultGroqBaseURL,
+		httpClient: &http.Client{
+			Timeout: DefaultAnswerQualityTimeout,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+// ScoreAnswer reviews an answer against its question using the Groq API.
+// The score is clamped to 0-100, and capped at 20 when the model says the
+// answer does not address the question.
+// Returns a *AnswerQualityRateLimitError on HTTP 429, or a generic error on other failures.
+func (s *AnswerQualityService) ScoreAnswer(ctx context.Context, input AnswerQualityInput) (*AnswerQualityResult, error) {
+	userMessage := fmt.Sprintf("Question title: %s\nQuestion description: %s\n\nAnswer:\n%s",
+		input.QuestionTitle,
+		truncateForQuality(input.QuestionDescription),
+		truncateForQuality(input.Answer))
+
+	reqBody := groqChatRequest{
+		Model: s.groqModel,
+		Messages: []groqMessage{
+			{Role: "system", Content: answerQualitySystemPrompt},
+			{Role: "user", Content: userMessage},
+		},
+		// No ResponseFormat: JSON output is enforced via the system prompt.
+		Temperature:         0,
+		MaxCompletionTokens: 256,
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("answer quality: failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/chat/completions", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("answer quality: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.groqAPIKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("answer quality: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("answer quality: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &AnswerQualityRateLimitError{
+			RetryAfter: parseRetryAfterSeconds(resp.Header.Get("Retry-After")),
+			Message:    string(respBody),
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("answer quality: Groq API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var chatResp groqChatResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		return nil, fmt.Errorf("answer quality: failed to parse response envelope: %w", err)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return nil, fmt.Errorf("answer quality: empty choices in response")
+	}
+
+	content := sanitizeJSONControlChars(stripMarkdownFences(chatResp.Choices[0].Message.Content))
+	var result AnswerQualityResult
+	if err := json.Unmarshal([]byte(content), &result); err != nil {
+		return nil, fmt.Errorf("answer quality: failed to parse review result: %w", err)
+	}
+
+	if result.Score < 0 {
+		result.Score = 0
+	}
+	if result.Score > 100 {
+		result.Score = 100
+	}
+	if !result.AddressesQuestion && result.Score > 20 {
+		result.Score = 20
+	}
+
+	return &result, nil
+}
+
+// truncateForQuality cuts s to at most maxAnswerQualityInputChars bytes
+// without splitting a UTF-8 character.
+func truncateForQuality(s string) string {
+	if len(s) <= maxAnswerQualityInputChars {
+		return s
+	}
+	cut := maxAnswerQualityInputChars
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "\n[truncated]"
+}
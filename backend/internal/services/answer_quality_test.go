@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// groqAnswerQualityResponse builds a fake Groq chat completion response for answer scoring.
+func groqAnswerQualityResponse(content string) string {
+	resp := map[string]interface{}{
+		"id":      "chatcmpl-quality-test",
+		"object":  "chat.completion",
+		"created": 1700000000,
+		"model":   DefaultAnswerQualityModel,
+		"choices": []map[string]interface{}{
+			{
+				"index": 0,
+				"message": map[string]interface{}{
+					"role":    "assistant",
+					"content": content,
+				},
+				"finish_reason": "stop",
+			},
+		},
+	}
+	respBytes, _ := json.Marshal(resp)
+	return string(respBytes)
+}
+
+func TestScoreAnswer_HappyPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Error("expected Authorization header with API key")
+		}
+		var req groqChatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Messages) != 2 || !strings.Contains(req.Messages[1].Content, "pool.Close()") {
+			t.Errorf("expected answer in user message, got %+v", req.Messages)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(groqAnswerQualityResponse(
+			"```json\n{\"addresses_question\": true, \"has_code\": true, \"cites_sources\": false, \"score\": 82}\n```",
+		)))
+	}))
+	defer server.Close()
+
+	svc := NewAnswerQualityService("test-key", WithAnswerQualityBaseURL(server.URL))
+
+	result, err := svc.ScoreAnswer(context.Background(), AnswerQualityInput{
+		QuestionTitle:       "How do I close a pgx pool?",
+		QuestionDescription: "Connections leak on shutdown.",
+		Answer:              "Call pool.Close() in your shutdown handler.",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.AddressesQuestion || !result.HasCode || result.CitesSources || result.Score != 82 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestScoreAnswer_ClampsScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+	}{
+		{"above range", `{"addresses_question": true, "has_code": false, "cites_sources": false, "score": 150}`, 100},
+		{"below range", `{"addresses_question": true, "has_code": false, "cites_sources": false, "score": -5}`, 0},
+		{"off-topic capped", `{"addresses_question": false, "has_code": false, "cites_sources": false, "score": 70}`, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(groqAnswerQualityResponse(tt.content)))
+			}))
+			defer server.Close()
+
+			svc := NewAnswerQualityService("test-key", WithAnswerQualityBaseURL(server.URL))
+			result, err := svc.ScoreAnswer(context.Background(), AnswerQualityInput{Answer: "me too"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Score != tt.want {
+				t.Errorf("expected score %d, got %d", tt.want, result.Score)
+			}
+		})
+	}
+}
+
+func TestScoreAnswer_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"rate limit"}}`))
+	}))
+	defer server.Close()
+
+	svc := NewAnswerQualityService("test-key", WithAnswerQualityBaseURL(server.URL))
+	_, err := svc.ScoreAnswer(context.Background(), AnswerQualityInput{Answer: "answer"})
+
+	var rlErr *AnswerQualityRateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("expected AnswerQualityRateLimitError, got %v", err)
+	}
+	if rlErr.RetryAfter != 7*time.Second {
+		t.Errorf("expected retry after 7s, got %v", rlErr.RetryAfter)
+	}
+}
+
+func TestScoreAnswer_InvalidJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(groqAnswerQualityResponse("this answer looks fine")))
+	}))
+	defer server.Close()
+
+	svc := NewAnswerQualityService("test-key", WithAnswerQualityBaseURL(server.URL))
+	if _, err := svc.ScoreAnswer(context.Background(), AnswerQualityInput{Answer: "answer"}); err == nil {
+		t.Fatal("expected error for non-JSON review")
+	}
+}
+
+func TestTruncateForQuality_KeepsRunesIntact(t *testing.T) {
+	s := "a" + strings.Repeat("é", maxAnswerQualityInputChars)
+	got := truncateForQuality(s)
+	if !strings.HasSuffix(got, "\n[truncated]") {
+		t.Fatalf("expected truncation marker")
+	}
+	body := strings.TrimSuffix(got, "\n[truncated]")
+	if len(body) > maxAnswerQualityInputChars || !utf8.ValidString(body) {
+		t.Errorf("expected whole runes within limit, got %d bytes", len(body))
+	}
+}
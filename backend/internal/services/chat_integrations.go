@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// maxChatIntegrationFailures is how many consecutive failed posts disable an integration.
+const maxChatIntegrationFailures = 10
+
+// chatExcerptLength caps the post description shown in chat messages.
+const chatExcerptLength = 280
+
+// ChatIntegrationStore finds the integrations to notify and records delivery results.
+type ChatIntegrationStore interface {
+	ListMatching(ctx context.Context, postType string, tags []string) ([]models.ChatIntegration, error)
+	RecordDelivery(ctx context.Context, id string, deliveryErr error, maxFailures int) error
+}
+
+// ChatIntegrationNotifier posts new problems and questions to the Slack and Discord
+// webhooks whose tags match the post.
+type ChatIntegrationNotifier struct {
+	store      ChatIntegrationStore
+	httpClient *http.Client
+}
+
+// NewChatIntegrationNotifier creates a new ChatIntegrationNotifier.
+func NewChatIntegrationNotifier(store ChatIntegrationStore) *ChatIntegrationNotifier {
+	return &ChatIntegrationNotifier{
+		store:      store,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NotifyPostPublished announces a newly visible post to every matching integration.
+// Family-private posts are never announced. Delivery failures are recorded on the
+// integration rather than returned, so one broken webhook doesn't hide the others.
+func (n *ChatIntegrationNotifier) NotifyPostPublished(ctx context.Context, post *models.Post) error {
+	if post.Visibility == models.VisibilityFamily {
+		return nil
+	}
+	integrations, err := n.store.ListMatching(ctx, string(post.Type), post.Tags)
+	if err != nil {
+		return fmt.Errorf("list chat integrations: %w", err)
+	}
+	for i := range integrations {
+		deliveryErr := n.Send(ctx, &integrations[i], post)
+		if deliveryErr != nil {
+			log.Printf("Chat integration %s: delivery failed for post %s: %v", integrations[i].ID, post.ID, deliveryErr)
+		}
+		if err := n.store.RecordDelivery(ctx, integrations[i].ID, deliveryErr, maxChatIntegrationFailures); err != nil {
+			log.Printf("Chat integration %s: failed to record delivery: %v", integrations[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// Send posts one message about post to integration's webhook.
+func (n *ChatIntegrationNotifier) Send(ctx context.Context, integration *models.ChatIntegration, post *models.Post) error {
+	var payload interface{}
+	switch integration.Provider {
+	case models.ChatProviderSlack:
+		payload = slackChatMessage(post)
+	case models.ChatProviderDiscord:
+		payload = discordChatMessage(post)
+	default:
+		return fmt.Errorf("unknown chat provider %q", integration.Provider)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode chat message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, integration.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build chat request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s webhook request failed: %w", integration.Provider, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s webhook returned status %d: %s", integration.Provider, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// chatPostURL links to the post on the site.
+func chatPostURL(post *models.Post) string {
+	return fmt.Sprintf("%s/%ss/%s", emailSiteURL, post.Type, post.ID)
+}
+
+// chatExcerpt shortens a description to chatExcerptLength runes.
+func chatExcerpt(description string) string {
+	description = strings.TrimSpace(description)
+	runes := []rune(description)
+	if len(runes) <= chatExcerptLength {
+		return description
+	}
+	return strings.TrimSpace(string(runes[:chatExcerptLength])) + "…"
+}
+
+// slackEscape escapes the characters Slack treats as control sequences in mrkdwn.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func slackChatMessage(post *models.Post) map[string]interface{} {
+	text := fmt.Sprintf("*New %s:* <%s|%s>\nTags: %s",
+		post.Type, chatPostURL(post), slackEscape(post.Title), slackEscape(strings.Join(post.Tags, ", ")))
+	if excerpt := chatExcerpt(post.Description); excerpt != "" {
+		text += "\n>" + strings.ReplaceAll(slackEscape(excerpt), "\n", "\n>")
+	}
+	return map[string]interface{}{
+		"text":         text,
+		"unfurl_links": false,
+	}
+}
+
+func discordChatMessage(post *models.Post) map[string]interface{} {
+	return map[string]interface{}{
+		"username": "Solvr",
+		"content":  "New " + string(post.Type),
+		"embeds": []map[string]interface{}{{
+			"title":       post.Title,
+			"url":         chatPostURL(post),
+			"description": chatExcerpt(post.Description),
+			"footer":      map[string]string{"text": "Tags: " + strings.Join(post.Tags, ", ")},
+		}},
+		// Never let post text ping @everyone, roles or users.
+		"allowed_mentions": map[string]interface{}{"parse": []string{}},
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockChatIntegrationStore struct {
+	integrations []models.ChatIntegration
+	listed       bool
+	results      map[string]error
+}
+
+func (m *mockChatIntegrationStore) ListMatching(ctx context.Context, postType string, tags []string) ([]models.ChatIntegration, error) {
+	m.listed = true
+	return m.integrations, nil
+}
+
+func (m *mockChatIntegrationStore) RecordDelivery(ctx context.Context, id string, deliveryErr error, maxFailures int) error {
+	if m.results == nil {
+		m.results = map[string]error{}
+	}
+	m.results[id] = deliveryErr
+	return nil
+}
+
+func testChatPost() *models.Post {
+	return &models.Post{
+		ID:          "post-1",
+		Type:        models.PostTypeQuestion,
+		Title:       "How do I use <generics> & constraints?",
+		Description: "Details about the question.",
+		Tags:        []string{"go", "generics"},
+	}
+}
+
+func TestChatIntegrationNotifier_NotifyPostPublished(t *testing.T) {
+	var slackBody, discordBody map[string]interface{}
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&slackBody)
+	}))
+	defer slack.Close()
+	discord := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&discordBody)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer discord.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer broken.Close()
+
+	store := &mockChatIntegrationStore{integrations: []models.ChatIntegration{
+		{ID: "broken", Provider: models.ChatProviderSlack, WebhookURL: broken.URL},
+		{ID: "slack", Provider: models.ChatProviderSlack, WebhookURL: slack.URL},
+		{ID: "discord", Provider: models.ChatProviderDiscord, WebhookURL: discord.URL},
+	}}
+	notifier := NewChatIntegrationNotifier(store)
+
+	if err := notifier.NotifyPostPublished(context.Background(), testChatPost()); err != nil {
+		t.Fatalf("NotifyPostPublished() error = %v", err)
+	}
+
+	if err := store.results["broken"]; err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected broken webhook failure to be recorded, got %v", err)
+	}
+	if store.results["slack"] != nil || store.results["discord"] != nil {
+		t.Errorf("expected the other webhooks to succeed, got %v", store.results)
+	}
+
+	text, _ := slackBody["text"].(string)
+	if !strings.Contains(text, "<https://solvr.dev/questions/post-1|How do I use &lt;generics&gt; &amp; constraints?>") {
+		t.Errorf("unexpected slack text %q", text)
+	}
+	embeds, _ := discordBody["embeds"].([]interface{})
+	if len(embeds) != 1 || embeds[0].(map[string]interface{})["url"] != "https://solvr.dev/questions/post-1" {
+		t.Errorf("unexpected discord embeds %v", discordBody["embeds"])
+	}
+	if mentions, _ := discordBody["allowed_mentions"].(map[string]interface{}); mentions == nil {
+		t.Error("expected discord message to disable mentions")
+	}
+}
+
+func TestChatIntegrationNotifier_SkipsFamilyPosts(t *testing.T) {
+	store := &mockChatIntegrationStore{}
+	post := testChatPost()
+	post.Visibility = models.VisibilityFamily
+
+	if err := NewChatIntegrationNotifier(store).NotifyPostPublished(context.Background(), post); err != nil {
+		t.Fatalf("NotifyPostPublished() error = %v", err)
+	}
+	if store.listed {
+		t.Error("expected family post not to be announced")
+	}
+}
+
+func TestChatExcerpt_Truncates(t *testing.T) {
+	long := strings.Repeat("é", chatExcerptLength+10)
+	got := chatExcerpt(long)
+	if !strings.HasSuffix(got, "…") || len([]rune(got)) != chatExcerptLength+1 {
+		t.Errorf("unexpected excerpt length %d", len([]rune(got)))
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/modtemplate"
 )
 
 // Default content moderation service configuration.
@@ -61,6 +63,7 @@ type ModerationResult struct {
 // ContentModerationService moderates content using the Groq API.
 type ContentModerationService struct {
 	groqAPIKey string
+	modelMu    sync.RWMutex
 	groqModel  string
 	baseURL    string
 	httpClient *http.Client
@@ -117,6 +120,24 @@ func NewContentModerationService(apiKey string, opts ...Option) *ContentModerati
 	return svc
 }
 
+// SetGroqModel switches the model used by subsequent moderation calls, e.g. on a
+// config reload. An empty model restores DefaultGroqModel.
+func (s *ContentModerationService) SetGroqModel(model string) {
+	if model == "" {
+		model = DefaultGroqModel
+	}
+	s.modelMu.Lock()
+	defer s.modelMu.Unlock()
+	s.groqModel = model
+}
+
+// model returns the Groq model in use.
+func (s *ContentModerationService) model() string {
+	s.modelMu.RLock()
+	defer s.modelMu.RUnlock()
+	return s.groqModel
+}
+
 // ModerateContent sends post content to the Groq API for moderation.
 // Returns a ModerationResult on success, or an error on failure.
 // Returns *RateLimitError if Groq returns HTTP 429.
@@ -125,7 +146,7 @@ func (s *ContentModerationService) ModerateContent(ctx context.Context, input Mo
 		input.Title, input.Description, strings.Join(input.Tags, ", "))
 
 	reqBody := groqChatRequest{
-		Model: s.groqModel,
+		Model: s.model(),
 		Messages: []groqMessage{
 			{Role: "system", Content: contentModerationSystemPrompt},
 			{Role: "user", Content: userMessage},
@@ -312,22 +333,18 @@ type CommentCreator interface {
 	Create(ctx context.Context, comment *models.Comment) (*models.Comment, error)
 }
 
-// Moderation comment constants.
-const (
-	ModerationAuthorID       = "solvr-moderator"
-	ModerationApprovedText   = "Post approved by Solvr moderation. Your post is now visible in the feed."
-	ModerationRejectedFormat = "Post rejected by Solvr moderation.\n\nReason: %s\n\nYou can edit your post and resubmit for review."
-)
+// ModerationAuthorID is the author of moderation system comments.
+const ModerationAuthorID = "solvr-moderator"
 
 // CreateModerationComment creates a system comment on a post explaining
-// the moderation decision (approval or rejection).
+// the moderation decision (approval or rejection), rendered from the built-in
+// templates.
 func CreateModerationComment(ctx context.Context, commentRepo CommentCreator, postID string, approved bool, result *ModerationResult) error {
-	var content string
+	key := modtemplate.KeyRejected
 	if approved {
-		content = ModerationApprovedText
-	} else {
-		content = fmt.Sprintf(ModerationRejectedFormat, result.Explanation)
+		key = modtemplate.KeyApproved
 	}
+	content := RenderModerationComment(ctx, nil, key, postID, result)
 
 	comment := &models.Comment{
 		TargetType: models.CommentTargetPost,
@@ -340,3 +357,14 @@ func CreateModerationComment(ctx context.Context, commentRepo CommentCreator, po
 	_, err := commentRepo.Create(ctx, comment)
 	return err
 }
+
+// RenderModerationComment renders the system comment for key from result. A nil
+// renderer uses the built-in templates.
+func RenderModerationComment(ctx context.Context, renderer *modtemplate.Renderer, key modtemplate.Key, postID string, result *ModerationResult) string {
+	return renderer.Render(ctx, key, modtemplate.Vars{
+		PostID:      postID,
+		Explanation: result.Explanation,
+		Reasons:     result.RejectionReasons,
+		Language:    result.LanguageDetected,
+	})
+}
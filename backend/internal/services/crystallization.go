@@ -44,6 +44,12 @@ type ApproachLister interface {
 	ListApproaches(ctx context.Context, problemID string, opts models.ApproachListOptions) ([]models.ApproachWithAuthor, int, error)
 }
 
+// ApproachTranscriptFinder returns an approach's execution transcript, or nil
+// if it has none.
+type ApproachTranscriptFinder interface {
+	FindTranscript(ctx context.Context, approachID string) (*models.ApproachTranscript, error)
+}
+
 // IPFSContentAdder uploads content to IPFS and returns a CID.
 type IPFSContentAdder interface {
 	Add(ctx context.Context, reader io.Reader) (string, error)
@@ -75,6 +81,9 @@ type CrystallizationService struct {
 	ipfsAdder      IPFSContentAdder
 	ipfsPinner     IPFSContentPinner
 	config         CrystallizationConfig
+
+	// transcripts is optional; see SetTranscriptFinder.
+	transcripts ApproachTranscriptFinder
 }
 
 // NewCrystallizationService creates a new CrystallizationService with default config.
@@ -110,6 +119,12 @@ func NewCrystallizationServiceWithConfig(
 	}
 }
 
+// SetTranscriptFinder includes each approach's execution transcript in the
+// snapshot, so the crystallized solution can be reproduced step by step.
+func (s *CrystallizationService) SetTranscriptFinder(finder ApproachTranscriptFinder) {
+	s.transcripts = finder
+}
+
 // CrystallizeProblem snapshots a solved problem and its approaches to IPFS.
 // Returns the IPFS CID of the crystallized snapshot.
 func (s *CrystallizationService) CrystallizeProblem(ctx context.Context, problemID string) (string, error) {
@@ -147,6 +162,9 @@ func (s *CrystallizationService) CrystallizeProblem(ctx context.Context, problem
 
 	// 5. Build the snapshot
 	snapshot := s.BuildSnapshot(post, approaches)
+	if err := s.attachTranscripts(ctx, snapshot.Approaches, approaches); err != nil {
+		return "", fmt.Errorf("crystallize: find transcripts: %w", err)
+	}
 
 	// 6. Serialize to reader
 	reader, err := s.SnapshotToReader(snapshot)
@@ -241,6 +259,9 @@ type SnapshotApproach struct {
 	Solution    string         `json:"solution,omitempty"`
 	Author      SnapshotAuthor `json:"author"`
 	CreatedAt   time.Time      `json:"created_at"`
+
+	// Transcript is the execution transcript the author attached, if any.
+	Transcript []models.TranscriptEntry `json:"transcript,omitempty"`
 }
 
 // SnapshotAuthor is author information within a crystallization snapshot.
@@ -297,6 +318,24 @@ func (s *CrystallizationService) BuildSnapshot(
 	}
 }
 
+// attachTranscripts fills in the transcripts of the snapshot approaches, which
+// BuildSnapshot built from approaches in the same order.
+func (s *CrystallizationService) attachTranscripts(ctx context.Context, snapshot []SnapshotApproach, approaches []models.ApproachWithAuthor) error {
+	if s.transcripts == nil {
+		return nil
+	}
+	for i, a := range approaches {
+		t, err := s.transcripts.FindTranscript(ctx, a.ID)
+		if err != nil {
+			return err
+		}
+		if t != nil {
+			snapshot[i].Transcript = t.Entries
+		}
+	}
+	return nil
+}
+
 // SnapshotToReader serializes a CrystallizationSnapshot to an io.Reader.
 func (s *CrystallizationService) SnapshotToReader(snapshot CrystallizationSnapshot) (io.Reader, error) {
 	data, err := json.MarshalIndent(snapshot, "", "  ")
@@ -473,6 +473,50 @@ func TestCrystallizeProblem_SnapshotContainsAllApproaches(t *testing.T) {
 	}
 }
 
+// mockTranscriptFinder implements ApproachTranscriptFinder for testing.
+type mockTranscriptFinder struct {
+	transcripts map[string]*models.ApproachTranscript
+}
+
+func (m *mockTranscriptFinder) FindTranscript(ctx context.Context, approachID string) (*models.ApproachTranscript, error) {
+	return m.transcripts[approachID], nil
+}
+
+func TestCrystallizeProblem_IncludesTranscripts(t *testing.T) {
+	post := solvedProblemPost(time.Now().Add(-10 * 24 * time.Hour))
+	succeeded := succeededApproach()
+	approaches := []models.ApproachWithAuthor{succeeded, failedApproach()}
+
+	ipfsAdder := &mockIPFSAdder{cid: "bafytest"}
+	svc := NewCrystallizationService(
+		&mockPostFinder{post: post},
+		&mockCrystallizationCIDSetter{},
+		&mockApproachLister{approaches: approaches, total: 2},
+		ipfsAdder,
+		&mockIPFSPinner{},
+	)
+	svc.SetTranscriptFinder(&mockTranscriptFinder{transcripts: map[string]*models.ApproachTranscript{
+		succeeded.ID: {ApproachID: succeeded.ID, Entries: []models.TranscriptEntry{
+			{Type: models.TranscriptEntryCommand, Command: "go test ./..."},
+		}},
+	}})
+
+	if _, err := svc.CrystallizeProblem(context.Background(), "problem-uuid-123"); err != nil {
+		t.Fatalf("CrystallizeProblem() error = %v", err)
+	}
+
+	var snapshot CrystallizationSnapshot
+	if err := json.Unmarshal(ipfsAdder.content, &snapshot); err != nil {
+		t.Fatalf("Invalid snapshot JSON: %v", err)
+	}
+	if got := snapshot.Approaches[0].Transcript; len(got) != 1 || got[0].Command != "go test ./..." {
+		t.Errorf("succeeded approach transcript = %+v, want the attached command", got)
+	}
+	if got := snapshot.Approaches[1].Transcript; got != nil {
+		t.Errorf("failed approach transcript = %+v, want none", got)
+	}
+}
+
 func TestCrystallizeProblem_CustomStabilityPeriod(t *testing.T) {
 	// Problem solved 2 days ago, with 1-day stability period configured
 	createdAt := time.Now().Add(-2 * 24 * time.Hour)
@@ -5,9 +5,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html/template"
+	"strings"
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/emailutil"
+	"github.com/fcavalcantirj/solvr/internal/models"
 )
 
 // EmailConfig holds SMTP configuration settings.
@@ -413,3 +416,193 @@ Manage notifications: https://solvr.dev/settings/notifications
 		Text:    text,
 	}
 }
+
+// PostCrystallizedEmailTemplate generates an email when a solved problem is
+// crystallized (archived permanently to IPFS).
+func PostCrystallizedEmailTemplate(recipientName, problemTitle, cid, problemURL string) *EmailTemplate {
+	subject := "Your problem was crystallized"
+
+	content := fmt.Sprintf(`
+                            <h1 style="color: #1a1a1a; font-size: 24px; font-weight: 600; margin: 0 0 16px 0;">Hi %s,</h1>
+                            <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 0 0 16px 0;">Your solved problem is now crystallized: an immutable snapshot of it and its winning approach is stored on IPFS.</p>
+                            <div style="background: #f4f4f5; padding: 16px; margin: 0 0 16px 0; border-left: 3px solid #0a0a0a;">
+                                <strong style="color: #1a1a1a; font-size: 14px;">%s</strong>
+                            </div>
+                            <p style="color: #71717a; font-size: 12px; line-height: 1.6; margin: 0 0 24px 0; word-break: break-all;">CID: %s</p>
+                            <p style="margin: 0;">
+                                <a href="%s" style="display: inline-block; background-color: #0a0a0a; color: #ffffff; padding: 12px 24px; text-decoration: none; font-family: 'SF Mono', 'Fira Code', 'Consolas', 'Monaco', 'Courier New', monospace; font-size: 14px; font-weight: 600;">View Problem</a>
+                            </p>`, template.HTMLEscapeString(recipientName), template.HTMLEscapeString(problemTitle), cid, problemURL)
+
+	html := emailutil.WrapInBrandedTemplate(content, "https://solvr.dev/settings/notifications", "You posted a problem on Solvr")
+
+	text := fmt.Sprintf(`Hi %s,
+
+Your solved problem is now crystallized: an immutable snapshot of it and its winning approach is stored on IPFS.
+
+"%s"
+
+CID: %s
+
+View the problem: %s
+
+---
+You're receiving this because you posted a problem on Solvr.
+
+Manage notifications: https://solvr.dev/settings/notifications
+`, recipientName, problemTitle, cid, problemURL)
+
+	return &EmailTemplate{
+		Subject: subject,
+		HTML:    html,
+		Text:    text,
+	}
+}
+
+// ClaimLinkEmailTemplate generates the email sent to an agent's contact address when
+// the agent requests a claim link for its human operator.
+func ClaimLinkEmailTemplate(agentName, claimURL, expiresAt string) *EmailTemplate {
+	subject := fmt.Sprintf("Claim your agent %s on Solvr", agentName)
+
+	content := fmt.Sprintf(`
+                            <h1 style="color: #1a1a1a; font-size: 24px; font-weight: 600; margin: 0 0 16px 0;">Claim %s</h1>
+                            <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 0 0 16px 0;">Your agent <strong>%s</strong> asked to be linked to your Solvr account. Claiming it gives it the Human-Backed badge and +50 reputation.</p>
+                            <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 0 0 24px 0;">The link expires at %s.</p>
+                            <p style="margin: 0;">
+                                <a href="%s" style="display: inline-block; background-color: #0a0a0a; color: #ffffff; padding: 12px 24px; text-decoration: none; font-family: 'SF Mono', 'Fira Code', 'Consolas', 'Monaco', 'Courier New', monospace; font-size: 14px; font-weight: 600;">Claim Agent</a>
+                            </p>`, template.HTMLEscapeString(agentName), template.HTMLEscapeString(agentName), expiresAt, claimURL)
+
+	html := emailutil.WrapInBrandedTemplate(content, "https://solvr.dev/settings/notifications", "Your agent requested a claim link on Solvr")
+
+	text := fmt.Sprintf(`Claim %s
+
+Your agent %s asked to be linked to your Solvr account. Claiming it gives it the Human-Backed badge and +50 reputation.
+
+The link expires at %s.
+
+Claim the agent: %s
+
+---
+You're receiving this because your agent requested a claim link on Solvr.
+If you don't know this agent, ignore this email.
+`, agentName, agentName, expiresAt, claimURL)
+
+	return &EmailTemplate{
+		Subject: subject,
+		HTML:    html,
+		Text:    text,
+	}
+}
+
+// AgentEmailVerificationTemplate generates the email that asks a self-registered
+// agent's operator to confirm their address before the agent can make changes.
+func AgentEmailVerificationTemplate(agentName, verifyURL, expiresAt string) *EmailTemplate {
+	subject := fmt.Sprintf("Verify your agent %s on Solvr", agentName)
+
+	content := fmt.Sprintf(`
+                            <h1 style="color: #1a1a1a; font-size: 24px; font-weight: 600; margin: 0 0 16px 0;">Verify %s</h1>
+                            <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 0 0 16px 0;">The agent <strong>%s</strong> registered on Solvr with this address as its operator contact. Until you confirm it, the agent can read but not post, answer or vote.</p>
+                            <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 0 0 24px 0;">The link expires at %s.</p>
+                            <p style="margin: 0;">
+                                <a href="%s" style="display: inline-block; background-color: #0a0a0a; color: #ffffff; padding: 12px 24px; text-decoration: none; font-family: 'SF Mono', 'Fira Code', 'Consolas', 'Monaco', 'Courier New', monospace; font-size: 14px; font-weight: 600;">Verify Agent</a>
+                            </p>`, template.HTMLEscapeString(agentName), template.HTMLEscapeString(agentName), expiresAt, verifyURL)
+
+	html := emailutil.WrapInBrandedTemplate(content, "https://solvr.dev", "An agent registered on Solvr with this address")
+
+	text := fmt.Sprintf(`Verify %s
+
+The agent %s registered on Solvr with this address as its operator contact. Until you confirm it, the agent can read but not post, answer or vote.
+
+The link expires at %s.
+
+Verify the agent: %s
+
+---
+You're receiving this because an agent gave this address when registering on Solvr.
+If you don't run this agent, ignore this email.
+`, agentName, agentName, expiresAt, verifyURL)
+
+	return &EmailTemplate{
+		Subject: subject,
+		HTML:    html,
+		Text:    text,
+	}
+}
+
+// GuestPostClaimEmailTemplate generates the email sent to a guest who submitted a
+// problem without an account, with the link to claim it once they sign up.
+func GuestPostClaimEmailTemplate(postTitle, claimURL, expiresAt string) *EmailTemplate {
+	subject := fmt.Sprintf("Claim your problem \"%s\" on Solvr", postTitle)
+
+	content := fmt.Sprintf(`
+                            <h1 style="color: #1a1a1a; font-size: 24px; font-weight: 600; margin: 0 0 16px 0;">Claim your problem</h1>
+                            <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 0 0 16px 0;">Thanks for posting <strong>%s</strong> on Solvr. Guest posts get a stricter review and can't be voted on until they belong to an account.</p>
+                            <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 0 0 24px 0;">Sign in or create an account, then claim it with the link below. The link expires at %s.</p>
+                            <p style="margin: 0;">
+                                <a href="%s" style="display: inline-block; background-color: #0a0a0a; color: #ffffff; padding: 12px 24px; text-decoration: none; font-family: 'SF Mono', 'Fira Code', 'Consolas', 'Monaco', 'Courier New', monospace; font-size: 14px; font-weight: 600;">Claim Problem</a>
+                            </p>`, template.HTMLEscapeString(postTitle), expiresAt, claimURL)
+
+	html := emailutil.WrapInBrandedTemplate(content, "https://solvr.dev", "You posted a problem on Solvr as a guest")
+
+	text := fmt.Sprintf(`Claim your problem
+
+Thanks for posting "%s" on Solvr. Guest posts get a stricter review and can't be voted on until they belong to an account.
+
+Sign in or create an account, then claim it. The link expires at %s.
+
+Claim the problem: %s
+
+---
+You're receiving this because this address was given when posting a problem on Solvr as a guest.
+If that wasn't you, ignore this email.
+`, postTitle, expiresAt, claimURL)
+
+	return &EmailTemplate{
+		Subject: subject,
+		HTML:    html,
+		Text:    text,
+	}
+}
+
+// KnowledgeGapsEmailTemplate generates the weekly knowledge gap report sent to admins.
+func KnowledgeGapsEmailTemplate(week string, report *models.KnowledgeGapReport, reportURL string) *EmailTemplate {
+	subject := fmt.Sprintf("Solvr knowledge gaps: week of %s", week)
+
+	var gapsHTML, gapsText strings.Builder
+	for _, g := range report.Gaps {
+		fmt.Fprintf(&gapsHTML, `
+                            <div style="background: #f4f4f5; padding: 16px; margin: 0 0 12px 0; border-left: 3px solid #0a0a0a;">
+                                <strong style="color: #1a1a1a; font-size: 14px;">%s</strong>
+                                <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 8px 0 0 0;">%s</p>
+                            </div>`, template.HTMLEscapeString(g.Title), template.HTMLEscapeString(g.Description))
+		fmt.Fprintf(&gapsText, "- %s\n  %s\n", g.Title, g.Description)
+	}
+
+	content := fmt.Sprintf(`
+                            <h1 style="color: #1a1a1a; font-size: 24px; font-weight: 600; margin: 0 0 16px 0;">Knowledge gaps, week of %s</h1>
+                            <p style="color: #3f3f46; font-size: 14px; line-height: 1.6; margin: 0 0 16px 0;">%s</p>
+                            <p style="color: #71717a; font-size: 12px; line-height: 1.6; margin: 0 0 16px 0;">From %d zero-result searches and %d stuck problems.</p>%s
+                            <p style="margin: 12px 0 0 0;">
+                                <a href="%s" style="display: inline-block; background-color: #0a0a0a; color: #ffffff; padding: 12px 24px; text-decoration: none; font-family: 'SF Mono', 'Fira Code', 'Consolas', 'Monaco', 'Courier New', monospace; font-size: 14px; font-weight: 600;">View Report</a>
+                            </p>`, week, template.HTMLEscapeString(report.Summary), report.ZeroResultQueries, report.StuckProblems, gapsHTML.String(), reportURL)
+
+	html := emailutil.WrapInBrandedTemplate(content, "https://solvr.dev/admin", "You are a Solvr admin")
+
+	text := fmt.Sprintf(`Knowledge gaps, week of %s
+
+%s
+
+From %d zero-result searches and %d stuck problems.
+
+%s
+View the report: %s
+
+---
+You're receiving this because you are a Solvr admin.
+`, week, report.Summary, report.ZeroResultQueries, report.StuckProblems, gapsText.String(), reportURL)
+
+	return &EmailTemplate{
+		Subject: subject,
+		HTML:    html,
+		Text:    text,
+	}
+}
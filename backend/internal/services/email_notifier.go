@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// emailSiteURL prefixes links in notification emails.
+const emailSiteURL = "https://solvr.dev"
+
+// EmailQueueWriter stores rendered emails for the email queue job to deliver.
+type EmailQueueWriter interface {
+	Enqueue(ctx context.Context, item *models.EmailQueueItem) error
+}
+
+// EmailRecipientFinder resolves the human to email about content by an author.
+// Returns nil when there is none or they opted out of the event.
+type EmailRecipientFinder interface {
+	FindAuthorRecipient(ctx context.Context, authorType, authorID, event string) (*models.EmailRecipient, error)
+}
+
+// EmailPostFinder loads a post for notification emails.
+type EmailPostFinder interface {
+	FindByID(ctx context.Context, id string) (*models.PostWithAuthor, error)
+}
+
+// EmailNotifier renders notification emails for high-value events and queues them.
+// Delivery happens in the email queue job, so callers never wait on a mail provider.
+type EmailNotifier struct {
+	queue      EmailQueueWriter
+	recipients EmailRecipientFinder
+	posts      EmailPostFinder
+}
+
+// NewEmailNotifier creates a new EmailNotifier.
+func NewEmailNotifier(queue EmailQueueWriter, recipients EmailRecipientFinder, posts EmailPostFinder) *EmailNotifier {
+	return &EmailNotifier{queue: queue, recipients: recipients, posts: posts}
+}
+
+// NotifyAnswerAccepted emails the answer's author (or the human behind an agent
+// author) that their answer was accepted.
+func (n *EmailNotifier) NotifyAnswerAccepted(ctx context.Context, question *models.PostWithAuthor, answer *models.AnswerWithAuthor) error {
+	recipient, err := n.recipients.FindAuthorRecipient(ctx, string(answer.AuthorType), answer.AuthorID, models.EmailEventAnswerAccepted)
+	if err != nil || recipient == nil {
+		return err
+	}
+	answerURL := fmt.Sprintf("%s/questions/%s#answer-%s", emailSiteURL, question.ID, answer.ID)
+	tpl := AcceptedAnswerEmailTemplate(recipient.DisplayName, question.Title, answerURL)
+	return n.enqueue(ctx, models.EmailEventAnswerAccepted, recipient.Email, tpl)
+}
+
+// NotifyPostCrystallized emails the problem's author that it was crystallized.
+func (n *EmailNotifier) NotifyPostCrystallized(ctx context.Context, problemID, cid string) error {
+	post, err := n.posts.FindByID(ctx, problemID)
+	if err != nil {
+		return fmt.Errorf("load crystallized post: %w", err)
+	}
+	recipient, err := n.recipients.FindAuthorRecipient(ctx, string(post.PostedByType), post.PostedByID, models.EmailEventPostCrystallized)
+	if err != nil || recipient == nil {
+		return err
+	}
+	problemURL := fmt.Sprintf("%s/problems/%s", emailSiteURL, post.ID)
+	tpl := PostCrystallizedEmailTemplate(recipient.DisplayName, post.Title, cid, problemURL)
+	return n.enqueue(ctx, models.EmailEventPostCrystallized, recipient.Email, tpl)
+}
+
+// NotifyClaimLinkRequested emails a new claim link to the agent's contact address.
+// Agents without an email are skipped.
+func (n *EmailNotifier) NotifyClaimLinkRequested(ctx context.Context, agent *models.Agent, claimURL string, expiresAt time.Time) error {
+	if agent.Email == "" {
+		return nil
+	}
+	name := agent.DisplayName
+	if name == "" {
+		name = agent.ID
+	}
+	tpl := ClaimLinkEmailTemplate(name, claimURL, expiresAt.UTC().Format("2006-01-02 15:04 MST"))
+	return n.enqueue(ctx, models.EmailEventClaimLink, agent.Email, tpl)
+}
+
+// NotifyGuestPostClaimLink emails a guest the link to claim the problem they posted.
+func (n *EmailNotifier) NotifyGuestPostClaimLink(ctx context.Context, email string, post *models.Post, claimURL string, expiresAt time.Time) error {
+	tpl := GuestPostClaimEmailTemplate(post.Title, claimURL, expiresAt.UTC().Format("2006-01-02 15:04 MST"))
+	return n.enqueue(ctx, models.EmailEventGuestPostClaim, email, tpl)
+}
+
+// NotifyAgentEmailVerification emails the link that verifies a self-registered
+// agent's operator address and activates the agent.
+func (n *EmailNotifier) NotifyAgentEmailVerification(ctx context.Context, agent *models.Agent, verifyURL string, expiresAt time.Time) error {
+	name := agent.DisplayName
+	if name == "" {
+		name = agent.ID
+	}
+	tpl := AgentEmailVerificationTemplate(name, verifyURL, expiresAt.UTC().Format("2006-01-02 15:04 MST"))
+	return n.enqueue(ctx, models.EmailEventAgentVerify, agent.Email, tpl)
+}
+
+func (n *EmailNotifier) enqueue(ctx context.Context, event, to string, tpl *EmailTemplate) error {
+	return n.queue.Enqueue(ctx, &models.EmailQueueItem{
+		Event:   event,
+		To:      to,
+		Subject: tpl.Subject,
+		HTML:    tpl.HTML,
+		Text:    tpl.Text,
+	})
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockEmailQueue struct {
+	items []models.EmailQueueItem
+}
+
+func (m *mockEmailQueue) Enqueue(ctx context.Context, item *models.EmailQueueItem) error {
+	m.items = append(m.items, *item)
+	return nil
+}
+
+// mockEmailRecipients returns a recipient for every author except those listed in optedOut.
+type mockEmailRecipients struct {
+	optedOut map[string]bool
+	lastType string
+}
+
+func (m *mockEmailRecipients) FindAuthorRecipient(ctx context.Context, authorType, authorID, event string) (*models.EmailRecipient, error) {
+	m.lastType = authorType
+	if m.optedOut[authorID+"/"+event] {
+		return nil, nil
+	}
+	return &models.EmailRecipient{ID: "user-1", Email: "ana@example.com", DisplayName: "Ana"}, nil
+}
+
+type mockEmailPosts struct {
+	post *models.PostWithAuthor
+}
+
+func (m *mockEmailPosts) FindByID(ctx context.Context, id string) (*models.PostWithAuthor, error) {
+	return m.post, nil
+}
+
+func TestEmailNotifier_NotifyAnswerAccepted(t *testing.T) {
+	queue := &mockEmailQueue{}
+	recipients := &mockEmailRecipients{optedOut: map[string]bool{"agent_opted/" + models.EmailEventAnswerAccepted: true}}
+	notifier := NewEmailNotifier(queue, recipients, &mockEmailPosts{})
+
+	question := &models.PostWithAuthor{Post: models.Post{ID: "q-1", Title: "How do I size a pgx pool?"}}
+	answer := &models.AnswerWithAuthor{Answer: models.Answer{ID: "a-1", AuthorType: models.AuthorTypeAgent, AuthorID: "agent_helper"}}
+	if err := notifier.NotifyAnswerAccepted(context.Background(), question, answer); err != nil {
+		t.Fatalf("NotifyAnswerAccepted() error = %v", err)
+	}
+
+	if len(queue.items) != 1 {
+		t.Fatalf("expected 1 queued email, got %d", len(queue.items))
+	}
+	item := queue.items[0]
+	if item.Event != models.EmailEventAnswerAccepted || item.To != "ana@example.com" {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if !strings.Contains(item.Text, "https://solvr.dev/questions/q-1#answer-a-1") {
+		t.Errorf("expected answer link in body, got %q", item.Text)
+	}
+	if recipients.lastType != string(models.AuthorTypeAgent) {
+		t.Errorf("expected agent author lookup, got %q", recipients.lastType)
+	}
+
+	answer.AuthorID = "agent_opted"
+	if err := notifier.NotifyAnswerAccepted(context.Background(), question, answer); err != nil {
+		t.Fatalf("NotifyAnswerAccepted() error = %v", err)
+	}
+	if len(queue.items) != 1 {
+		t.Errorf("expected no email for an opted-out recipient, got %d queued", len(queue.items))
+	}
+}
+
+func TestEmailNotifier_NotifyPostCrystallized(t *testing.T) {
+	queue := &mockEmailQueue{}
+	posts := &mockEmailPosts{post: &models.PostWithAuthor{Post: models.Post{
+		ID: "p-1", Title: "Pool <exhaustion>", PostedByType: models.AuthorTypeHuman, PostedByID: "user-1",
+	}}}
+	notifier := NewEmailNotifier(queue, &mockEmailRecipients{}, posts)
+
+	if err := notifier.NotifyPostCrystallized(context.Background(), "p-1", "bafy123"); err != nil {
+		t.Fatalf("NotifyPostCrystallized() error = %v", err)
+	}
+	if len(queue.items) != 1 {
+		t.Fatalf("expected 1 queued email, got %d", len(queue.items))
+	}
+	item := queue.items[0]
+	if !strings.Contains(item.Text, "bafy123") || !strings.Contains(item.Text, "https://solvr.dev/problems/p-1") {
+		t.Errorf("expected CID and problem link in body, got %q", item.Text)
+	}
+	if !strings.Contains(item.HTML, "Pool &lt;exhaustion&gt;") {
+		t.Error("expected the title to be HTML-escaped")
+	}
+}
+
+func TestEmailNotifier_NotifyClaimLinkRequested(t *testing.T) {
+	queue := &mockEmailQueue{}
+	notifier := NewEmailNotifier(queue, &mockEmailRecipients{}, &mockEmailPosts{})
+	expires := time.Date(2026, 3, 1, 16, 0, 0, 0, time.UTC)
+
+	if err := notifier.NotifyClaimLinkRequested(context.Background(), &models.Agent{ID: "agent_x"}, "https://solvr.dev/claim/tok", expires); err != nil {
+		t.Fatalf("NotifyClaimLinkRequested() error = %v", err)
+	}
+	if len(queue.items) != 0 {
+		t.Fatalf("expected no email for an agent without email, got %d", len(queue.items))
+	}
+
+	agent := &models.Agent{ID: "agent_x", DisplayName: "Helper", Email: "ops@example.com"}
+	if err := notifier.NotifyClaimLinkRequested(context.Background(), agent, "https://solvr.dev/claim/tok", expires); err != nil {
+		t.Fatalf("NotifyClaimLinkRequested() error = %v", err)
+	}
+	if len(queue.items) != 1 {
+		t.Fatalf("expected 1 queued email, got %d", len(queue.items))
+	}
+	item := queue.items[0]
+	if item.To != "ops@example.com" || item.Event != models.EmailEventClaimLink || !strings.Contains(item.Subject, "Helper") {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if !strings.Contains(item.Text, "https://solvr.dev/claim/tok") || !strings.Contains(item.Text, "2026-03-01 16:00 UTC") {
+		t.Errorf("expected claim link and expiry in body, got %q", item.Text)
+	}
+}
+
+func TestEmailNotifier_NotifyAgentEmailVerification(t *testing.T) {
+	queue := &mockEmailQueue{}
+	notifier := NewEmailNotifier(queue, &mockEmailRecipients{}, &mockEmailPosts{})
+	expires := time.Date(2026, 3, 4, 16, 0, 0, 0, time.UTC)
+
+	agent := &models.Agent{ID: "agent_helper", DisplayName: "Helper", Email: "ops@example.com"}
+	if err := notifier.NotifyAgentEmailVerification(context.Background(), agent, "https://solvr.dev/agents/verify-email/solvr_ev_tok", expires); err != nil {
+		t.Fatalf("NotifyAgentEmailVerification() error = %v", err)
+	}
+	if len(queue.items) != 1 {
+		t.Fatalf("expected 1 queued email, got %d", len(queue.items))
+	}
+	item := queue.items[0]
+	if item.To != "ops@example.com" || item.Event != models.EmailEventAgentVerify || !strings.Contains(item.Subject, "Helper") {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if !strings.Contains(item.Text, "https://solvr.dev/agents/verify-email/solvr_ev_tok") || !strings.Contains(item.Text, "2026-03-04 16:00 UTC") {
+		t.Errorf("expected verification link and expiry in body, got %q", item.Text)
+	}
+}
+
+func TestEmailNotifier_NotifyGuestPostClaimLink(t *testing.T) {
+	queue := &mockEmailQueue{}
+	notifier := NewEmailNotifier(queue, &mockEmailRecipients{}, &mockEmailPosts{})
+	expires := time.Date(2026, 3, 31, 16, 0, 0, 0, time.UTC)
+
+	post := &models.Post{ID: "post-1", Title: "Build <fails> on ARM"}
+	if err := notifier.NotifyGuestPostClaimLink(context.Background(), "guest@example.com", post, "https://solvr.dev/guest/claim/tok", expires); err != nil {
+		t.Fatalf("NotifyGuestPostClaimLink() error = %v", err)
+	}
+	if len(queue.items) != 1 {
+		t.Fatalf("expected 1 queued email, got %d", len(queue.items))
+	}
+	item := queue.items[0]
+	if item.To != "guest@example.com" || item.Event != models.EmailEventGuestPostClaim || !strings.Contains(item.Subject, "Build <fails> on ARM") {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if !strings.Contains(item.Text, "https://solvr.dev/guest/claim/tok") || !strings.Contains(item.Text, "2026-03-31 16:00 UTC") {
+		t.Errorf("expected claim link and expiry in body, got %q", item.Text)
+	}
+	if strings.Contains(item.HTML, "<fails>") {
+		t.Error("expected the title to be HTML-escaped")
+	}
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Default feed briefing service configuration.
+const (
+	DefaultFeedBriefingModel   = "llama-3.3-70b-versatile"
+	DefaultFeedBriefingTimeout = 20 * time.Second
+
+	// maxFeedBriefingSnippetChars truncates each post's snippet so a full
+	// briefing fits in one request.
+	maxFeedBriefingSnippetChars = 300
+)
+
+// feedBriefingSystemPrompt is the static system prompt for daily feed briefings.
+const feedBriefingSystemPrompt = `You write the daily briefing for a reader of a developer knowledge base where AI agents and humans post problems, questions and ideas. You get the day's new problems, the problems solved and questions answered, and the trending questions, limited to the tags the reader follows.
+Write a compact briefing the reader can act on without opening the feed: what is new, what got solved, and what people keep asking about. Group related posts, mention each notable post by its title, and call out open problems nobody has solved yet. Skip sections with no posts. Use at most 8 short sentences of plain text, no markdown, no preamble.`
+
+// FeedBriefingService writes daily feed briefings using the Groq API.
+type FeedBriefingService struct {
+	groqAPIKey string
+	groqModel  string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// FeedBriefingOption is a functional option for configuring FeedBriefingService.
+type FeedBriefingOption func(*FeedBriefingService)
+
+// WithFeedBriefingBaseURL overrides the default Groq API base URL.
+func WithFeedBriefingBaseURL(url string) FeedBriefingOption {
+	return func(s *FeedBriefingService) {
+		s.baseURL = url
+	}
+}
+
+// WithFeedBriefingModel overrides the default model.
+func WithFeedBriefingModel(model string) FeedBriefingOption {
+	return func(s *FeedBriefingService) {
+		s.groqModel = model
+	}
+}
+
+// NewFeedBriefingService creates a new FeedBriefingService.
+func NewFeedBriefingService(apiKey string, opts ...FeedBriefingOption) *FeedBriefingService {
+	svc := &FeedBriefingService{
+		groqAPIKey: apiKey,
+		groqModel:  DefaultFeedBriefingModel,
+		baseURL:    DefaultGroqBaseURL,
+		httpClient: &http.Client{
+			Timeout: DefaultFeedBriefingTimeout,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+// SummarizeBriefing asks Groq for a plain-text summary of the briefing's sections.
+func (s *FeedBriefingService) SummarizeBriefing(ctx context.Context, b *models.FeedBriefing) (string, error) {
+	reqBody := groqChatRequest{
+		Model: s.groqModel,
+		Messages: []groqMessage{
+			{Role: "system", Content: feedBriefingSystemPrompt},
+			{Role: "user", Content: feedBriefingUserMessage(b)},
+		},
+		Temperature:         0.3,
+		MaxCompletionTokens: 600,
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("feed briefing: failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/chat/completions", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return "", fmt.Errorf("feed briefing: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.groqAPIKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("feed briefing: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("feed briefing: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("feed briefing: Groq API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var chatResp groqChatResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		return "", fmt.Errorf("feed briefing: failed to parse response envelope: %w", err)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return "", fmt.Errorf("feed briefing: empty choices in response")
+	}
+
+	summary := strings.TrimSpace(stripMarkdownFences(chatResp.Choices[0].Message.Content))
+	if summary == "" {
+		return "", fmt.Errorf("feed briefing: empty summary")
+	}
+	return summary, nil
+}
+
+// feedBriefingUserMessage lists the briefing's sections.
+func feedBriefingUserMessage(b *models.FeedBriefing) string {
+	var sb strings.Builder
+	if len(b.Tags) > 0 {
+		fmt.Fprintf(&sb, "Followed tags: %s\n", strings.Join(b.Tags, ", "))
+	} else {
+		sb.WriteString("Followed tags: (all)\n")
+	}
+
+	sections := []struct {
+		title string
+		items []models.FeedItem
+	}{
+		{"New problems", b.NewProblems},
+		{"Solved problems and answered questions", b.SolvedPosts},
+		{"Trending questions", b.TrendingQuestions},
+	}
+	for _, section := range sections {
+		fmt.Fprintf(&sb, "\n%s:\n", section.title)
+		if len(section.items) == 0 {
+			sb.WriteString("(none)\n")
+		}
+		for _, item := range section.items {
+			fmt.Fprintf(&sb, "- %s (%s, %s", item.Title, item.Type, item.Status)
+			if len(item.Tags) > 0 {
+				fmt.Fprintf(&sb, "; %s", strings.Join(item.Tags, ", "))
+			}
+			fmt.Fprintf(&sb, "; score %d, %d answers/approaches)\n  %s\n", item.VoteScore, item.AnswerCount+item.ApproachCount,
+				truncateForFeedBriefing(item.Snippet))
+		}
+	}
+	return sb.String()
+}
+
+// truncateForFeedBriefing cuts s to at most maxFeedBriefingSnippetChars
+// characters, marking the cut with an ellipsis.
+func truncateForFeedBriefing(s string) string {
+	r := []rune(s)
+	if len(r) <= maxFeedBriefingSnippetChars {
+		return s
+	}
+	return string(r[:maxFeedBriefingSnippetChars]) + "…"
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestSummarizeBriefing_HappyPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req groqChatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Messages) != 2 {
+			t.Fatalf("expected 2 messages, got %d", len(req.Messages))
+		}
+		user := req.Messages[1].Content
+		if !strings.Contains(user, "Followed tags: go, postgres") || !strings.Contains(user, "- pgx pool exhausted (problem, open; go, postgres") {
+			t.Errorf("expected tags and posts in user message, got %q", user)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(groqAnswerQualityResponse("  One new pgx problem is still open.  ")))
+	}))
+	defer server.Close()
+
+	svc := NewFeedBriefingService("test-key", WithFeedBriefingBaseURL(server.URL))
+	summary, err := svc.SummarizeBriefing(context.Background(), &models.FeedBriefing{
+		Tags: []string{"go", "postgres"},
+		NewProblems: []models.FeedItem{
+			{Title: "pgx pool exhausted", Type: "problem", Status: "open", Tags: []string{"go", "postgres"}, Snippet: "Pool hangs under load"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary != "One new pgx problem is still open." {
+		t.Errorf("unexpected summary: %q", summary)
+	}
+}
+
+func TestSummarizeBriefing_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	svc := NewFeedBriefingService("test-key", WithFeedBriefingBaseURL(server.URL))
+	if _, err := svc.SummarizeBriefing(context.Background(), &models.FeedBriefing{}); err == nil {
+		t.Fatal("expected error on non-2xx response")
+	}
+}
+
+func TestFeedBriefingUserMessage_Empty(t *testing.T) {
+	msg := feedBriefingUserMessage(&models.FeedBriefing{})
+	if !strings.Contains(msg, "Followed tags: (all)") || strings.Count(msg, "(none)") != 3 {
+		t.Errorf("expected all tags and every section marked empty, got %q", msg)
+	}
+}
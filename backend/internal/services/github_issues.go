@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// maxGitHubImportComments caps how many issue comments are fetched for an import.
+const maxGitHubImportComments = 30
+
+// GitHubIssueClient reads public issues from the GitHub REST API. A token is
+// optional; without one GitHub allows 60 requests per hour per IP.
+type GitHubIssueClient struct {
+	token      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewGitHubIssueClient creates a new GitHubIssueClient. token may be empty.
+func NewGitHubIssueClient(token string) *GitHubIssueClient {
+	return &GitHubIssueClient{
+		token:      token,
+		baseURL:    "https://api.github.com",
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// SetBaseURL overrides the API base URL (for testing with httptest).
+func (c *GitHubIssueClient) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+type githubIssueResponse struct {
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	State     string     `json:"state"`
+	HTMLURL   string     `json:"html_url"`
+	User      githubUser `json:"user"`
+	CreatedAt time.Time  `json:"created_at"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest *json.RawMessage `json:"pull_request"`
+}
+
+type githubCommentResponse struct {
+	Body      string     `json:"body"`
+	User      githubUser `json:"user"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// FetchIssue returns one issue.
+func (c *GitHubIssueClient) FetchIssue(ctx context.Context, ref models.GitHubIssueRef) (*models.GitHubIssue, error) {
+	var resp githubIssueResponse
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", ref.Owner, ref.Repo, ref.Number)
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	issue := &models.GitHubIssue{
+		Title:         resp.Title,
+		Body:          resp.Body,
+		State:         resp.State,
+		HTMLURL:       resp.HTMLURL,
+		Author:        resp.User.Login,
+		IsPullRequest: resp.PullRequest != nil,
+		CreatedAt:     resp.CreatedAt,
+	}
+	for _, label := range resp.Labels {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	return issue, nil
+}
+
+// FetchComments returns up to the first 30 comments on an issue, oldest first.
+func (c *GitHubIssueClient) FetchComments(ctx context.Context, ref models.GitHubIssueRef) ([]models.GitHubIssueComment, error) {
+	var resp []githubCommentResponse
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/comments?per_page=%d", ref.Owner, ref.Repo, ref.Number, maxGitHubImportComments)
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	comments := make([]models.GitHubIssueComment, 0, len(resp))
+	for _, rc := range resp {
+		comments = append(comments, models.GitHubIssueComment{Author: rc.User.Login, Body: rc.Body, CreatedAt: rc.CreatedAt})
+	}
+	return comments, nil
+}
+
+func (c *GitHubIssueClient) get(ctx context.Context, path string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("build github request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "solvr")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("github request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return models.ErrGitHubIssueNotFound
+	case resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0"):
+		return models.ErrGitHubRateLimited
+	case resp.StatusCode != http.StatusOK:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("github returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("decode github response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestGitHubIssueClient_FetchIssue(t *testing.T) {
+	var gotAuth, gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"title":"Crash on start","body":"Steps...","state":"closed",
+			"html_url":"https://github.com/acme/tool/issues/3","user":{"login":"octocat"},
+			"created_at":"2026-01-02T03:04:05Z","labels":[{"name":"bug"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewGitHubIssueClient("secret")
+	client.SetBaseURL(server.URL + "/")
+	issue, err := client.FetchIssue(context.Background(), models.GitHubIssueRef{Owner: "acme", Repo: "tool", Number: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/repos/acme/tool/issues/3" || gotAuth != "Bearer secret" {
+		t.Errorf("unexpected request: path=%s auth=%s", gotPath, gotAuth)
+	}
+	if issue.Title != "Crash on start" || issue.State != models.GitHubIssueClosed || issue.Author != "octocat" {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+	if len(issue.Labels) != 1 || issue.Labels[0] != "bug" || issue.IsPullRequest {
+		t.Errorf("unexpected labels or PR flag: %+v", issue)
+	}
+}
+
+func TestGitHubIssueClient_PullRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"title":"Fix","state":"open","pull_request":{"url":"x"}}`))
+	}))
+	defer server.Close()
+
+	client := NewGitHubIssueClient("")
+	client.SetBaseURL(server.URL)
+	issue, err := client.FetchIssue(context.Background(), models.GitHubIssueRef{Owner: "acme", Repo: "tool", Number: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !issue.IsPullRequest {
+		t.Error("expected pull request to be detected")
+	}
+}
+
+func TestGitHubIssueClient_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header string
+		want   error
+	}{
+		{"not found", http.StatusNotFound, "", models.ErrGitHubIssueNotFound},
+		{"gone", http.StatusGone, "", models.ErrGitHubIssueNotFound},
+		{"too many requests", http.StatusTooManyRequests, "", models.ErrGitHubRateLimited},
+		{"forbidden rate limit", http.StatusForbidden, "0", models.ErrGitHubRateLimited},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("X-RateLimit-Remaining", tt.header)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewGitHubIssueClient("")
+			client.SetBaseURL(server.URL)
+			_, err := client.FetchComments(context.Background(), models.GitHubIssueRef{Owner: "acme", Repo: "tool", Number: 1})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
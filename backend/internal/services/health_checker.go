@@ -2,6 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/fcavalcantirj/solvr/internal/models"
@@ -17,10 +20,28 @@ type IPFSNodeChecker interface {
 	NodeInfo(ctx context.Context) (*NodeInfoResult, error)
 }
 
-// HealthCheckerService performs real health checks against API, Database, and IPFS.
+// ExtensionChecker reports the installed version of a Postgres extension ("" if missing).
+type ExtensionChecker interface {
+	ExtensionVersion(ctx context.Context, name string) (string, error)
+}
+
+// ReachabilityChecker probes an external HTTP dependency and returns the response status code.
+type ReachabilityChecker interface {
+	Probe(ctx context.Context) (int, error)
+}
+
+// ErrHealthCheckNotConfigured is returned by CheckService for optional dependencies
+// that have no checker configured (e.g. Groq when GROQ_API_KEY is unset).
+var ErrHealthCheckNotConfigured = errors.New("health check not configured")
+
+// HealthCheckerService performs real health checks against API, Database, IPFS,
+// and the optional pgvector, Groq, and Voyage dependencies.
 type HealthCheckerService struct {
-	dbPinger  DBPinger
-	ipfsNode  IPFSNodeChecker
+	dbPinger   DBPinger
+	ipfsNode   IPFSNodeChecker
+	extensions ExtensionChecker
+	groq       ReachabilityChecker
+	voyage     ReachabilityChecker
 }
 
 // NewHealthCheckerService creates a new HealthCheckerService.
@@ -31,6 +52,21 @@ func NewHealthCheckerService(dbPinger DBPinger, ipfsNode IPFSNodeChecker) *Healt
 	}
 }
 
+// SetExtensionChecker enables the "pgvector" check.
+func (s *HealthCheckerService) SetExtensionChecker(c ExtensionChecker) {
+	s.extensions = c
+}
+
+// SetGroqChecker enables the "groq" reachability check.
+func (s *HealthCheckerService) SetGroqChecker(c ReachabilityChecker) {
+	s.groq = c
+}
+
+// SetVoyageChecker enables the "voyage" reachability check.
+func (s *HealthCheckerService) SetVoyageChecker(c ReachabilityChecker) {
+	s.voyage = c
+}
+
 // CheckService checks a named service and returns its status, response time, and any error.
 func (s *HealthCheckerService) CheckService(ctx context.Context, serviceName string) (models.ServiceCheckStatus, int, error) {
 	switch serviceName {
@@ -40,6 +76,12 @@ func (s *HealthCheckerService) CheckService(ctx context.Context, serviceName str
 		return s.checkDatabase(ctx)
 	case "ipfs":
 		return s.checkIPFS(ctx)
+	case "pgvector":
+		return s.checkPgvector(ctx)
+	case "groq":
+		return s.checkReachable(ctx, s.groq)
+	case "voyage":
+		return s.checkReachable(ctx, s.voyage)
 	default:
 		return models.ServiceStatusOutage, 0, nil
 	}
@@ -86,3 +128,77 @@ func (s *HealthCheckerService) checkIPFS(ctx context.Context) (models.ServiceChe
 
 	return models.ServiceStatusOperational, elapsed, nil
 }
+
+// checkPgvector verifies the vector extension is installed.
+func (s *HealthCheckerService) checkPgvector(ctx context.Context) (models.ServiceCheckStatus, int, error) {
+	if s.extensions == nil {
+		return "", 0, ErrHealthCheckNotConfigured
+	}
+
+	start := time.Now()
+	version, err := s.extensions.ExtensionVersion(ctx, "vector")
+	elapsed := int(time.Since(start).Milliseconds())
+
+	if err != nil {
+		return models.ServiceStatusOutage, elapsed, err
+	}
+	if version == "" {
+		return models.ServiceStatusOutage, elapsed, errors.New("pgvector extension not installed")
+	}
+
+	return models.ServiceStatusOperational, elapsed, nil
+}
+
+// checkReachable probes an external HTTP API. Any non-5xx response counts as reachable;
+// auth failures still prove the network path and upstream are up.
+func (s *HealthCheckerService) checkReachable(ctx context.Context, c ReachabilityChecker) (models.ServiceCheckStatus, int, error) {
+	if c == nil {
+		return "", 0, ErrHealthCheckNotConfigured
+	}
+
+	start := time.Now()
+	code, err := c.Probe(ctx)
+	elapsed := int(time.Since(start).Milliseconds())
+
+	if err != nil {
+		return models.ServiceStatusOutage, elapsed, err
+	}
+	if code >= 500 {
+		return models.ServiceStatusDegraded, elapsed, fmt.Errorf("upstream returned HTTP %d", code)
+	}
+
+	// Consider degraded if the upstream takes > 2s to respond
+	if elapsed > 2000 {
+		return models.ServiceStatusDegraded, elapsed, nil
+	}
+
+	return models.ServiceStatusOperational, elapsed, nil
+}
+
+// HTTPProbe is a ReachabilityChecker that issues a GET against a fixed URL.
+type HTTPProbe struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPProbe creates an HTTPProbe with the given request timeout.
+func NewHTTPProbe(url string, timeout time.Duration) *HTTPProbe {
+	return &HTTPProbe{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Probe issues the GET request and returns the response status code.
+func (p *HTTPProbe) Probe(ctx context.Context) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("build probe request: %w", err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type stubExtensionChecker struct {
+	version string
+	err     error
+}
+
+func (s *stubExtensionChecker) ExtensionVersion(ctx context.Context, name string) (string, error) {
+	return s.version, s.err
+}
+
+func TestCheckService_PgvectorInstalled(t *testing.T) {
+	svc := NewHealthCheckerService(nil, nil)
+	svc.SetExtensionChecker(&stubExtensionChecker{version: "0.7.0"})
+
+	status, _, err := svc.CheckService(context.Background(), "pgvector")
+	if err != nil || status != models.ServiceStatusOperational {
+		t.Errorf("expected operational, got %s (%v)", status, err)
+	}
+}
+
+func TestCheckService_PgvectorMissing(t *testing.T) {
+	svc := NewHealthCheckerService(nil, nil)
+	svc.SetExtensionChecker(&stubExtensionChecker{})
+
+	status, _, err := svc.CheckService(context.Background(), "pgvector")
+	if err == nil || status != models.ServiceStatusOutage {
+		t.Errorf("expected outage with error, got %s (%v)", status, err)
+	}
+}
+
+func TestCheckService_NotConfigured(t *testing.T) {
+	svc := NewHealthCheckerService(nil, nil)
+
+	for _, name := range []string{"pgvector", "groq", "voyage"} {
+		_, _, err := svc.CheckService(context.Background(), name)
+		if !errors.Is(err, ErrHealthCheckNotConfigured) {
+			t.Errorf("%s: expected ErrHealthCheckNotConfigured, got %v", name, err)
+		}
+	}
+}
+
+func TestCheckService_HTTPProbe(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   int
+		expect models.ServiceCheckStatus
+	}{
+		{"ok", http.StatusOK, models.ServiceStatusOperational},
+		{"unauthorized still reachable", http.StatusUnauthorized, models.ServiceStatusOperational},
+		{"server error", http.StatusBadGateway, models.ServiceStatusDegraded},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			svc := NewHealthCheckerService(nil, nil)
+			svc.SetGroqChecker(NewHTTPProbe(srv.URL, time.Second))
+
+			status, _, _ := svc.CheckService(context.Background(), "groq")
+			if status != tc.expect {
+				t.Errorf("expected %s, got %s", tc.expect, status)
+			}
+		})
+	}
+}
+
+func TestCheckService_HTTPProbeUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	svc := NewHealthCheckerService(nil, nil)
+	svc.SetVoyageChecker(NewHTTPProbe(url, time.Second))
+
+	status, _, err := svc.CheckService(context.Background(), "voyage")
+	if err == nil || status != models.ServiceStatusOutage {
+		t.Errorf("expected outage with error, got %s (%v)", status, err)
+	}
+}
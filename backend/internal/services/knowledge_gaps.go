@@ -0,0 +1,187 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// Default knowledge gap service configuration.
+const (
+	DefaultKnowledgeGapModel   = "llama-3.3-70b-versatile"
+	DefaultKnowledgeGapTimeout = 60 * time.Second
+
+	// maxKnowledgeGapStuckSummaryChars truncates each stuck problem's summary so
+	// a week of escalations fits in one request.
+	maxKnowledgeGapStuckSummaryChars = 600
+)
+
+// knowledgeGapSystemPrompt is the static system prompt for the weekly gap report.
+// Uses plain JSON instruction instead of json_schema response_format for broader model compatibility.
+const knowledgeGapSystemPrompt = `You analyze demand on a developer knowledge base where AI agents and humans post problems, questions and ideas. You get a week of searches that returned no results (with how often each was searched) and problems their authors escalated as stuck (with what was tried).
+Find the recurring unmet needs: topics people keep looking for or keep failing at. Merge queries and problems about the same topic into one gap. Ignore one-off typos and gibberish. Order gaps by how much demand they represent, at most 8.
+Respond ONLY with a valid JSON object with exactly two keys: "summary" (2-3 sentences on the week's overall picture) and "gaps" (array of objects with "title" (under 80 characters), "description" (1-2 sentences on what is missing and what kind of contribution would help) and "evidence" (array of the queries and problem titles behind the gap)). No markdown, no explanation, just the JSON object.`
+
+// KnowledgeGapInput is a week of demand signals.
+type KnowledgeGapInput struct {
+	ZeroResultQueries []models.ZeroResultQuery
+	StuckProblems     []models.StuckProblemSummary
+}
+
+// KnowledgeGapSummary is the model's summary of recurring unmet needs.
+type KnowledgeGapSummary struct {
+	Summary string                `json:"summary"`
+	Gaps    []models.KnowledgeGap `json:"gaps"`
+}
+
+// KnowledgeGapService summarizes unmet needs using the Groq API.
+type KnowledgeGapService struct {
+	groqAPIKey string
+	groqModel  string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// KnowledgeGapOption is a functional option for configuring KnowledgeGapService.
+type KnowledgeGapOption func(*KnowledgeGapService)
+
+// WithKnowledgeGapBaseURL overrides the default Groq API base URL.
+func WithKnowledgeGapBaseURL(url string) KnowledgeGapOption {
+	return func(s *KnowledgeGapService) {
+		s.baseURL = url
+	}
+}
+
+// WithKnowledgeGapModel overrides the default model.
+func WithKnowledgeGapModel(model string) KnowledgeGapOption {
+	return func(s *KnowledgeGapService) {
+		s.groqModel = model
+	}
+}
+
+// NewKnowledgeGapService creates a new KnowledgeGapService.
+func NewKnowledgeGapService(apiKey string, opts ...KnowledgeGapOption) *KnowledgeGapService {
+	svc := &KnowledgeGapService{
+		groqAPIKey: apiKey,
+		groqModel:  DefaultKnowledgeGapModel,
+		baseURL:    DefaultGroqBaseURL,
+		httpClient: &http.Client{
+			Timeout: DefaultKnowledgeGapTimeout,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(svc)
+	}
+
+	return svc
+}
+
+// SummarizeGaps asks Groq for the recurring unmet needs behind a week of
+// zero-result searches and stuck problems. Gaps without a title are dropped.
+func (s *KnowledgeGapService) SummarizeGaps(ctx context.Context, input KnowledgeGapInput) (*KnowledgeGapSummary, error) {
+	reqBody := groqChatRequest{
+		Model: s.groqModel,
+		Messages: []groqMessage{
+			{Role: "system", Content: knowledgeGapSystemPrompt},
+			{Role: "user", Content: knowledgeGapUserMessage(input)},
+		},
+		// No ResponseFormat: JSON output is enforced via the system prompt.
+		Temperature:         0.2,
+		MaxCompletionTokens: 2048,
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("knowledge gaps: failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/chat/completions", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("knowledge gaps: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.groqAPIKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("knowledge gaps: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("knowledge gaps: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("knowledge gaps: Groq API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var chatResp groqChatResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		return nil, fmt.Errorf("knowledge gaps: failed to parse response envelope: %w", err)
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return nil, fmt.Errorf("knowledge gaps: empty choices in response")
+	}
+
+	content := sanitizeJSONControlChars(stripMarkdownFences(chatResp.Choices[0].Message.Content))
+	var result KnowledgeGapSummary
+	if err := json.Unmarshal([]byte(content), &result); err != nil {
+		return nil, fmt.Errorf("knowledge gaps: failed to parse summary: %w", err)
+	}
+
+	gaps := result.Gaps[:0]
+	for _, g := range result.Gaps {
+		if strings.TrimSpace(g.Title) != "" {
+			gaps = append(gaps, g)
+		}
+	}
+	result.Gaps = gaps
+
+	return &result, nil
+}
+
+// knowledgeGapUserMessage lists the week's zero-result searches and stuck problems.
+func knowledgeGapUserMessage(input KnowledgeGapInput) string {
+	var b strings.Builder
+	b.WriteString("Searches with no results (query: times searched):\n")
+	if len(input.ZeroResultQueries) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, q := range input.ZeroResultQueries {
+		fmt.Fprintf(&b, "- %s: %d\n", q.Query, q.Count)
+	}
+
+	b.WriteString("\nProblems escalated as stuck:\n")
+	if len(input.StuckProblems) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, p := range input.StuckProblems {
+		fmt.Fprintf(&b, "- %s", p.Title)
+		if len(p.Tags) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(p.Tags, ", "))
+		}
+		fmt.Fprintf(&b, "\n  Tried: %s\n", truncateForKnowledgeGap(p.Summary))
+	}
+	return b.String()
+}
+
+// truncateForKnowledgeGap cuts s to at most maxKnowledgeGapStuckSummaryChars
+// characters, marking the cut with an ellipsis.
+func truncateForKnowledgeGap(s string) string {
+	r := []rune(s)
+	if len(r) <= maxKnowledgeGapStuckSummaryChars {
+		return s
+	}
+	return string(r[:maxKnowledgeGapStuckSummaryChars]) + "…"
+}
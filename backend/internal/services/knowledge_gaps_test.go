@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func TestSummarizeGaps_HappyPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req groqChatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Messages) != 2 {
+			t.Fatalf("expected 2 messages, got %d", len(req.Messages))
+		}
+		user := req.Messages[1].Content
+		if !strings.Contains(user, "- pgx pool leak: 7") || !strings.Contains(user, "Redis cluster failover [redis, ha]") {
+			t.Errorf("expected queries and problems in user message, got %q", user)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(groqAnswerQualityResponse(
+			"```json\n{\"summary\": \"Connection pooling dominates.\", \"gaps\": [" +
+				"{\"title\": \"pgx pool lifecycle\", \"description\": \"No guide on closing pools.\", \"evidence\": [\"pgx pool leak\"]}," +
+				"{\"title\": \" \", \"description\": \"dropped\"}]}\n```",
+		)))
+	}))
+	defer server.Close()
+
+	svc := NewKnowledgeGapService("test-key", WithKnowledgeGapBaseURL(server.URL))
+
+	result, err := svc.SummarizeGaps(context.Background(), KnowledgeGapInput{
+		ZeroResultQueries: []models.ZeroResultQuery{{Query: "pgx pool leak", Count: 7}},
+		StuckProblems: []models.StuckProblemSummary{
+			{Title: "Redis cluster failover", Tags: []string{"redis", "ha"}, Summary: "Tried sentinel."},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Summary != "Connection pooling dominates." {
+		t.Errorf("unexpected summary: %q", result.Summary)
+	}
+	if len(result.Gaps) != 1 || result.Gaps[0].Title != "pgx pool lifecycle" {
+		t.Errorf("expected one titled gap, got %+v", result.Gaps)
+	}
+}
+
+func TestSummarizeGaps_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	svc := NewKnowledgeGapService("test-key", WithKnowledgeGapBaseURL(server.URL))
+	if _, err := svc.SummarizeGaps(context.Background(), KnowledgeGapInput{}); err == nil {
+		t.Fatal("expected error on non-2xx response")
+	}
+}
+
+func TestKnowledgeGapUserMessage_Empty(t *testing.T) {
+	msg := knowledgeGapUserMessage(KnowledgeGapInput{})
+	if strings.Count(msg, "(none)") != 2 {
+		t.Errorf("expected both sections marked empty, got %q", msg)
+	}
+}
+
+func TestTruncateForKnowledgeGap(t *testing.T) {
+	long := strings.Repeat("é", maxKnowledgeGapStuckSummaryChars+10)
+	got := truncateForKnowledgeGap(long)
+	if utf8.RuneCountInString(got) != maxKnowledgeGapStuckSummaryChars+1 || !utf8.ValidString(got) {
+		t.Errorf("unexpected truncation: %d runes", utf8.RuneCountInString(got))
+	}
+	if truncateForKnowledgeGap("short") != "short" {
+		t.Error("short strings should be unchanged")
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ErrMailerNotConfigured is returned by NewMailer when no driver is selected.
+var ErrMailerNotConfigured = errors.New("no email driver configured")
+
+// Mailer delivers a single email. Drivers: SMTP, Amazon SES, SendGrid and Resend.
+type Mailer interface {
+	Send(ctx context.Context, msg *EmailMessage) error
+}
+
+// mailerHTTPTimeout bounds each API call made by the HTTP-based drivers.
+const mailerHTTPTimeout = 15 * time.Second
+
+// NewMailer builds the driver selected by cfg.Driver.
+// Returns ErrMailerNotConfigured when cfg.Driver is empty.
+func NewMailer(cfg models.MailerConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "":
+		return nil, ErrMailerNotConfigured
+	case models.MailerDriverSMTP:
+		client, err := NewDefaultSMTPClient(&EmailConfig{
+			SMTPHost:  cfg.SMTPHost,
+			SMTPPort:  cfg.SMTPPort,
+			SMTPUser:  cfg.SMTPUser,
+			SMTPPass:  cfg.SMTPPass,
+			FromEmail: cfg.FromEmail,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &SMTPMailer{client: client, fromEmail: cfg.FromEmail}, nil
+	case models.MailerDriverSES:
+		if cfg.SESAccessKeyID == "" || cfg.SESSecretAccessKey == "" {
+			return nil, errors.New("ses driver requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		return NewSESMailer(cfg.SESRegion, cfg.SESAccessKeyID, cfg.SESSecretAccessKey, cfg.FromEmail), nil
+	case models.MailerDriverSendGrid:
+		if cfg.SendGridAPIKey == "" {
+			return nil, errors.New("sendgrid driver requires SENDGRID_API_KEY")
+		}
+		return NewSendGridMailer(cfg.SendGridAPIKey, cfg.FromEmail), nil
+	case models.MailerDriverResend:
+		if cfg.ResendAPIKey == "" {
+			return nil, errors.New("resend driver requires RESEND_API_KEY")
+		}
+		return &ResendMailer{client: NewResendClient(cfg.ResendAPIKey, cfg.FromEmail)}, nil
+	default:
+		return nil, fmt.Errorf("unknown email driver %q", cfg.Driver)
+	}
+}
+
+// SMTPMailer adapts an SMTPClient to the Mailer interface.
+type SMTPMailer struct {
+	client    SMTPClient
+	fromEmail string
+}
+
+// NewSMTPMailer creates a Mailer that sends through client.
+func NewSMTPMailer(client SMTPClient, fromEmail string) *SMTPMailer {
+	return &SMTPMailer{client: client, fromEmail: fromEmail}
+}
+
+// Send delivers msg over SMTP. net/smtp has no context support, so ctx is only
+// checked before dialing.
+func (m *SMTPMailer) Send(ctx context.Context, msg *EmailMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if msg.From == "" {
+		msg.From = m.fromEmail
+	}
+	return m.client.Send(msg)
+}
+
+// ResendMailer adapts a ResendClient to the Mailer interface.
+type ResendMailer struct {
+	client *ResendClient
+}
+
+// NewResendMailer creates a Mailer that sends through the Resend API.
+func NewResendMailer(client *ResendClient) *ResendMailer {
+	return &ResendMailer{client: client}
+}
+
+// Send delivers msg via Resend. The sender is the client's configured address.
+func (m *ResendMailer) Send(ctx context.Context, msg *EmailMessage) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	return m.client.Send(ctx, msg.To, msg.Subject, msg.HTML, msg.Text)
+}
+
+// newMailerHTTPClient returns the HTTP client used by the API-based drivers.
+func newMailerHTTPClient() *http.Client {
+	return &http.Client{Timeout: mailerHTTPTimeout}
+}
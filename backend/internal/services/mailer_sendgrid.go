@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// defaultSendGridBaseURL is the SendGrid v3 API root.
+const defaultSendGridBaseURL = "https://api.sendgrid.com"
+
+// SendGridMailer sends email through the SendGrid v3 mail/send API.
+type SendGridMailer struct {
+	apiKey     string
+	fromEmail  string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewSendGridMailer creates a new SendGridMailer.
+func NewSendGridMailer(apiKey, fromEmail string) *SendGridMailer {
+	return &SendGridMailer{
+		apiKey:     apiKey,
+		fromEmail:  fromEmail,
+		baseURL:    defaultSendGridBaseURL,
+		httpClient: newMailerHTTPClient(),
+	}
+}
+
+// SetBaseURL overrides the SendGrid API base URL (for testing with httptest).
+func (m *SendGridMailer) SetBaseURL(baseURL string) {
+	m.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridRequest struct {
+	Personalizations []struct {
+		To []sendGridAddress `json:"to"`
+	} `json:"personalizations"`
+	From    sendGridAddress   `json:"from"`
+	Subject string            `json:"subject"`
+	Content []sendGridContent `json:"content"`
+}
+
+// Send delivers msg via SendGrid. SendGrid requires text/plain before text/html.
+func (m *SendGridMailer) Send(ctx context.Context, msg *EmailMessage) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	from := msg.From
+	if from == "" {
+		from = m.fromEmail
+	}
+
+	payload := sendGridRequest{
+		From:    sendGridAddress{Email: from, Name: "Solvr"},
+		Subject: msg.Subject,
+	}
+	payload.Personalizations = make([]struct {
+		To []sendGridAddress `json:"to"`
+	}, 1)
+	payload.Personalizations[0].To = []sendGridAddress{{Email: msg.To}}
+	if msg.Text != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/plain", Value: msg.Text})
+	}
+	if msg.HTML != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/html", Value: msg.HTML})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode sendgrid request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.baseURL+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build sendgrid request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sendgrid send failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sendgrid send failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
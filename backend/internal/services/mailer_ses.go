@@ -0,0 +1,162 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// sesSendPath is the SES v2 SendEmail endpoint.
+const sesSendPath = "/v2/email/outbound-emails"
+
+// SESMailer sends email through the Amazon SES v2 API. Requests are signed with
+// AWS Signature Version 4 so no AWS SDK is needed.
+type SESMailer struct {
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	fromEmail       string
+	baseURL         string
+	httpClient      *http.Client
+	now             func() time.Time
+}
+
+// NewSESMailer creates a new SESMailer for the given AWS region and credentials.
+func NewSESMailer(region, accessKeyID, secretAccessKey, fromEmail string) *SESMailer {
+	return &SESMailer{
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		fromEmail:       fromEmail,
+		baseURL:         "https://email." + region + ".amazonaws.com",
+		httpClient:      newMailerHTTPClient(),
+		now:             time.Now,
+	}
+}
+
+// SetBaseURL overrides the SES endpoint (for testing with httptest).
+func (m *SESMailer) SetBaseURL(baseURL string) {
+	m.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesSendRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				HTML *sesContent `json:"Html,omitempty"`
+				Text *sesContent `json:"Text,omitempty"`
+			} `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+// Send delivers msg via SES.
+func (m *SESMailer) Send(ctx context.Context, msg *EmailMessage) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	from := msg.From
+	if from == "" {
+		from = m.fromEmail
+	}
+
+	var payload sesSendRequest
+	payload.FromEmailAddress = "Solvr <" + from + ">"
+	payload.Destination.ToAddresses = []string{msg.To}
+	payload.Content.Simple.Subject = sesContent{Data: msg.Subject, Charset: "UTF-8"}
+	if msg.HTML != "" {
+		payload.Content.Simple.Body.HTML = &sesContent{Data: msg.HTML, Charset: "UTF-8"}
+	}
+	if msg.Text != "" {
+		payload.Content.Simple.Body.Text = &sesContent{Data: msg.Text, Charset: "UTF-8"}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode ses request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.baseURL+sesSendPath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build ses request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	m.sign(req, body)
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ses send failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("ses send failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// sign adds SigV4 X-Amz-Date and Authorization headers for the "ses" service.
+// Only content-type, host and x-amz-date are signed.
+func (m *SESMailer) sign(req *http.Request, body []byte) {
+	now := m.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	payloadHash := sha256.Sum256(body)
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURIPath(req.URL),
+		req.URL.RawQuery,
+		"content-type:" + req.Header.Get("Content-Type") + "\n" +
+			"host:" + req.URL.Host + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + m.region + "/ses/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+m.secretAccessKey), date)
+	key = hmacSHA256(key, m.region)
+	key = hmacSHA256(key, "ses")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		m.accessKeyID, scope, signedHeaders, signature))
+}
+
+func canonicalURIPath(u *url.URL) string {
+	if p := u.EscapedPath(); p != "" {
+		return p
+	}
+	return "/"
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+func testEmailMessage() *EmailMessage {
+	return &EmailMessage{To: "user@example.com", Subject: "Hello", HTML: "<p>Hi</p>", Text: "Hi"}
+}
+
+func TestNewMailer_SelectsDriver(t *testing.T) {
+	if _, err := NewMailer(models.MailerConfig{}); !errors.Is(err, ErrMailerNotConfigured) {
+		t.Errorf("expected ErrMailerNotConfigured, got %v", err)
+	}
+	if _, err := NewMailer(models.MailerConfig{Driver: "carrier-pigeon"}); err == nil {
+		t.Error("expected error for unknown driver")
+	}
+	if _, err := NewMailer(models.MailerConfig{Driver: models.MailerDriverSendGrid}); err == nil {
+		t.Error("expected error for sendgrid without API key")
+	}
+
+	cases := map[string]models.MailerConfig{
+		"smtp":     {Driver: models.MailerDriverSMTP, SMTPHost: "smtp.example.com", SMTPPort: 587, FromEmail: "noreply@solvr.dev"},
+		"ses":      {Driver: models.MailerDriverSES, SESRegion: "us-east-1", SESAccessKeyID: "AK", SESSecretAccessKey: "SK"},
+		"sendgrid": {Driver: models.MailerDriverSendGrid, SendGridAPIKey: "SG.key"},
+		"resend":   {Driver: models.MailerDriverResend, ResendAPIKey: "re_key"},
+	}
+	for name, cfg := range cases {
+		if m, err := NewMailer(cfg); err != nil || m == nil {
+			t.Errorf("%s: expected mailer, got %v", name, err)
+		}
+	}
+}
+
+func TestSendGridMailer_Send(t *testing.T) {
+	var payload sendGridRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/mail/send" {
+			t.Errorf("expected /v3/mail/send, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer SG.key" {
+			t.Errorf("unexpected Authorization %q", got)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	mailer := NewSendGridMailer("SG.key", "noreply@solvr.dev")
+	mailer.SetBaseURL(server.URL)
+	if err := mailer.Send(context.Background(), testEmailMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if payload.From.Email != "noreply@solvr.dev" || payload.Personalizations[0].To[0].Email != "user@example.com" {
+		t.Errorf("unexpected addresses: %+v", payload)
+	}
+	if len(payload.Content) != 2 || payload.Content[0].Type != "text/plain" || payload.Content[1].Type != "text/html" {
+		t.Errorf("expected text/plain then text/html, got %+v", payload.Content)
+	}
+}
+
+func TestSendGridMailer_Send_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":[{"message":"bad key"}]}`))
+	}))
+	defer server.Close()
+
+	mailer := NewSendGridMailer("bad", "noreply@solvr.dev")
+	mailer.SetBaseURL(server.URL)
+	err := mailer.Send(context.Background(), testEmailMessage())
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected 401 error, got %v", err)
+	}
+}
+
+func TestSESMailer_Send(t *testing.T) {
+	var payload sesSendRequest
+	var authHeader, amzDate string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != sesSendPath {
+			t.Errorf("expected %s, got %s", sesSendPath, r.URL.Path)
+		}
+		authHeader = r.Header.Get("Authorization")
+		amzDate = r.Header.Get("X-Amz-Date")
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		w.Write([]byte(`{"MessageId":"m-1"}`))
+	}))
+	defer server.Close()
+
+	mailer := NewSESMailer("eu-west-1", "AKIDEXAMPLE", "secret", "noreply@solvr.dev")
+	mailer.SetBaseURL(server.URL)
+	mailer.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	if err := mailer.Send(context.Background(), testEmailMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if amzDate != "20260301T120000Z" {
+		t.Errorf("unexpected X-Amz-Date %q", amzDate)
+	}
+	wantPrefix := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260301/eu-west-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature="
+	if !strings.HasPrefix(authHeader, wantPrefix) || len(authHeader) != len(wantPrefix)+64 {
+		t.Errorf("unexpected Authorization %q", authHeader)
+	}
+	if payload.Destination.ToAddresses[0] != "user@example.com" || payload.Content.Simple.Subject.Data != "Hello" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if payload.Content.Simple.Body.HTML == nil || payload.Content.Simple.Body.Text == nil {
+		t.Error("expected both HTML and text bodies")
+	}
+}
+
+func TestSMTPMailer_Send_SetsFrom(t *testing.T) {
+	client := &MockSMTPClient{}
+	mailer := NewSMTPMailer(client, "noreply@solvr.dev")
+	if err := mailer.Send(context.Background(), testEmailMessage()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	sent := client.GetSentEmails()
+	if len(sent) != 1 || sent[0].From != "noreply@solvr.dev" {
+		t.Errorf("expected From to default to noreply@solvr.dev, got %+v", sent)
+	}
+}
@@ -190,6 +190,7 @@ type NotificationService struct {
 	answerLookup   AnswerLookup
 	postLookup     PostLookup
 	approachLookup ApproachLookup
+	blockChecker   BlockChecker
 }
 
 // NewNotificationService creates a new notification service.
@@ -456,6 +457,18 @@ func (s *NotificationService) NotifyOnUpvoteMilestone(ctx context.Context, event
 	return nil
 }
 
+// BlockChecker reports whether a principal has blocked or muted another.
+// Implemented by db.PrincipalBlockRepository.
+type BlockChecker interface {
+	// BlockMode returns "block", "mute", or "" when there is no block.
+	BlockMode(ctx context.Context, blockerType, blockerID, blockedType, blockedID string) (string, error)
+}
+
+// SetBlockChecker makes NotifyOnMention skip users who blocked or muted the mentioner.
+func (s *NotificationService) SetBlockChecker(checker BlockChecker) {
+	s.blockChecker = checker
+}
+
 // NotifyOnMention sends notification when @username is mentioned in content.
 // Per PRD: "Notify on mention" - Parse @username in content, notify mentioned user.
 func (s *NotificationService) NotifyOnMention(ctx context.Context, event *MentionEvent, userLookup UserLookup) error {
@@ -472,6 +485,17 @@ func (s *NotificationService) NotifyOnMention(ctx context.Context, event *Mentio
 			continue
 		}
 
+		// Don't notify if the mentioned user blocked or muted the mentioner
+		if s.blockChecker != nil {
+			mode, err := s.blockChecker.BlockMode(ctx, "human", user.ID, event.MentionerType, event.MentionerID)
+			if err != nil {
+				return fmt.Errorf("failed to check blocks: %w", err)
+			}
+			if mode != "" {
+				continue
+			}
+		}
+
 		params := &CreateNotificationParams{
 			UserID: &user.ID,
 			Type:   NotificationTypeMention,
@@ -519,6 +519,52 @@ func TestNotifyOnMention_SelfMention(t *testing.T) {
 	}
 }
 
+type mockBlockChecker map[string]string
+
+func (m mockBlockChecker) BlockMode(ctx context.Context, blockerType, blockerID, blockedType, blockedID string) (string, error) {
+	return m[blockerType+":"+blockerID+">"+blockedType+":"+blockedID], nil
+}
+
+// TestNotifyOnMention_BlockedOrMuted tests no notification when the mentioned
+// user blocked or muted the mentioner.
+func TestNotifyOnMention_BlockedOrMuted(t *testing.T) {
+	mentionedUserID := uuid.New().String()
+
+	for _, mode := range []string{"block", "mute"} {
+		t.Run(mode, func(t *testing.T) {
+			var notifCreated bool
+			repo := &MockNotificationRepository{
+				createFunc: func(ctx context.Context, n *NotificationInput) (*NotificationRecord, error) {
+					notifCreated = true
+					return &NotificationRecord{ID: uuid.New().String()}, nil
+				},
+			}
+			userLookup := &MockUserLookup{
+				findByIDFunc: func(ctx context.Context, id string) (*UserInfo, error) {
+					return &UserInfo{ID: mentionedUserID, Username: id}, nil
+				},
+			}
+
+			svc := NewNotificationService(repo, userLookup, nil, nil, nil)
+			svc.SetBlockChecker(mockBlockChecker{"human:" + mentionedUserID + ">agent:spam_bot": mode})
+
+			err := svc.NotifyOnMention(context.Background(), &MentionEvent{
+				MentionedUsernames: []string{"testuser"},
+				MentionerID:        "spam_bot",
+				MentionerType:      "agent",
+				ContentType:        "post",
+				ContentID:          uuid.New().String(),
+			}, userLookup)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if notifCreated {
+				t.Errorf("should not create notification when the mentioner is %sed", mode)
+			}
+		})
+	}
+}
+
 // TestNotifyOnComment_SelfComment tests no notification for self-comment.
 func TestNotifyOnComment_SelfComment(t *testing.T) {
 	answerID := uuid.New().String()
@@ -44,6 +44,7 @@ func (a *OAuthUserServiceAdapter) FindOrCreateUser(ctx context.Context, info *ha
 		Email:       user.Email,
 		AvatarURL:   user.AvatarURL,
 		Role:        user.Role,
+		Status:      user.Status,
 	}
 
 	return result, isNew, nil
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// ErrObjectNotFound is returned when an object key does not exist.
+var ErrObjectNotFound = errors.New("object not found")
+
+// unsignedPayload is the x-amz-content-sha256 value for bodies that are not
+// hashed up front, so uploads can stream from disk.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Store reads and writes objects in an S3-compatible bucket. It uses
+// path-style URLs (endpoint/bucket/key), which every S3-compatible service
+// accepts, and signs requests with AWS Signature Version 4 like SESMailer.
+type S3Store struct {
+	endpoint        string
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	publicURL       string
+	httpClient      *http.Client
+	now             func() time.Time
+}
+
+// S3Object is one entry of a bucket listing.
+type S3Object struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// NewS3Store creates an S3Store for cfg. An empty endpoint means AWS S3 in cfg.Region.
+func NewS3Store(cfg models.ObjectStorageConfig) (*S3Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("bucket is required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("access key ID and secret access key are required")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	endpoint := strings.TrimSuffix(cfg.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+	return &S3Store{
+		endpoint:        endpoint,
+		region:          region,
+		bucket:          cfg.Bucket,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		publicURL:       strings.TrimSuffix(cfg.PublicURL, "/"),
+		// No overall timeout: dumps can take minutes to transfer. Callers bound
+		// requests with their context.
+		httpClient: &http.Client{},
+		now:        time.Now,
+	}, nil
+}
+
+// Put uploads size bytes from body as key, stored as application/gzip.
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, size int64) error {
+	return s.PutObject(ctx, key, body, size, "application/gzip")
+}
+
+// PutObject uploads size bytes from body as key with the given content type.
+func (s *S3Store) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key, nil), body)
+	if err != nil {
+		return fmt.Errorf("build s3 put: %w", err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("s3 put %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Get opens key for reading. The caller closes the returned body. Returns
+// ErrObjectNotFound if the key does not exist.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key, nil), nil)
+	if err != nil {
+		return nil, fmt.Errorf("build s3 get: %w", err)
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 get %s: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+// Delete removes key. Deleting a missing key is not an error.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key, nil), nil)
+	if err != nil {
+		return fmt.Errorf("build s3 delete: %w", err)
+	}
+	resp, err := s.do(req)
+	if err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return fmt.Errorf("s3 delete %s: %w", key, err)
+	}
+	if resp != nil {
+		resp.Body.Close()
+	}
+	return nil
+}
+
+// s3ListResult is the ListObjectsV2 response body.
+type s3ListResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns every object whose key starts with prefix, in key order.
+func (s *S3Store) List(ctx context.Context, prefix string) ([]S3Object, error) {
+	var objects []S3Object
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL("", query), nil)
+		if err != nil {
+			return nil, fmt.Errorf("build s3 list: %w", err)
+		}
+		resp, err := s.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("s3 list %s: %w", prefix, err)
+		}
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode s3 list: %w", err)
+		}
+		for _, c := range result.Contents {
+			objects = append(objects, S3Object{Key: c.Key, Size: c.Size, LastModified: c.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// PublicURL returns the URL key is served from: under the configured public
+// base URL (a CDN or public bucket domain) when set, else the bucket URL itself.
+func (s *S3Store) PublicURL(key string) string {
+	if s.publicURL != "" {
+		return s.publicURL + "/" + key
+	}
+	return s.objectURL(key, nil)
+}
+
+// objectURL builds the path-style URL of key (the bucket itself when key is
+// empty). Key segments are escaped once, as S3 expects in the canonical request.
+func (s *S3Store) objectURL(key string, query url.Values) string {
+	u := s.endpoint + "/" + url.PathEscape(s.bucket)
+	if key != "" {
+		segments := strings.Split(key, "/")
+		for i, seg := range segments {
+			segments[i] = url.PathEscape(seg)
+		}
+		u += "/" + strings.Join(segments, "/")
+	}
+	if len(query) > 0 {
+		// Encode sorts by key; SigV4 wants spaces as %20, not +.
+		u += "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+	}
+	return u
+}
+
+// do signs and sends req, mapping 404 to ErrObjectNotFound and other non-2xx
+// statuses to errors. On success the caller closes the response body.
+func (s *S3Store) do(req *http.Request) (*http.Response, error) {
+	s.sign(req)
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return resp, nil
+}
+
+// sign adds SigV4 X-Amz-Date, X-Amz-Content-Sha256 and Authorization headers
+// for the "s3" service. Only host, x-amz-content-sha256 and x-amz-date are signed.
+func (s *S3Store) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURIPath(req.URL),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + unsignedPayload + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature))
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// fakeS3 is an in-memory path-style bucket that lists one key per page.
+type fakeS3 struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string]string
+	auth    []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	if r.Header.Get("X-Amz-Content-Sha256") != "UNSIGNED-PAYLOAD" {
+		f.t.Errorf("missing X-Amz-Content-Sha256 on %s %s", r.Method, r.URL.Path)
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/backups-bucket/")
+	if !ok && r.URL.Path != "/backups-bucket" {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+	if !ok {
+		key = ""
+	}
+	switch {
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = string(body)
+	case r.Method == http.MethodGet && key != "":
+		body, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, body)
+	case r.Method == http.MethodGet:
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) && k > r.URL.Query().Get("continuation-token") {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		fmt.Fprint(w, `<ListBucketResult>`)
+		if len(keys) > 0 {
+			fmt.Fprintf(w, `<Contents><Key>%s</Key><Size>%d</Size><LastModified>2026-10-18T12:00:00.000Z</LastModified></Contents>`, keys[0], len(f.objects[keys[0]]))
+		}
+		if len(keys) > 1 {
+			fmt.Fprintf(w, `<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>`, keys[0])
+		}
+		fmt.Fprint(w, `</ListBucketResult>`)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Store_RoundTrip(t *testing.T) {
+	fake := &fakeS3{t: t, objects: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Store(models.ObjectStorageConfig{
+		Endpoint: server.URL, Region: "auto", Bucket: "backups-bucket",
+		AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("NewS3Store() error = %v", err)
+	}
+	store.now = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC) }
+	ctx := context.Background()
+
+	for _, key := range []string{"db/solvr-1.ndjson.gz", "db/solvr-2.ndjson.gz", "other/x"} {
+		if err := store.Put(ctx, key, strings.NewReader("dump "+key), int64(len("dump "+key))); err != nil {
+			t.Fatalf("Put(%s) error = %v", key, err)
+		}
+	}
+
+	objects, err := store.List(ctx, "db/")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 2 || objects[0].Key != "db/solvr-1.ndjson.gz" || objects[1].Key != "db/solvr-2.ndjson.gz" {
+		t.Fatalf("List() = %+v, want both db/ dumps across pages", objects)
+	}
+
+	body, err := store.Get(ctx, "db/solvr-2.ndjson.gz")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "dump db/solvr-2.ndjson.gz" {
+		t.Errorf("Get() = %q", data)
+	}
+
+	if err := store.Delete(ctx, "db/solvr-1.ndjson.gz"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "db/solvr-1.ndjson.gz"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrObjectNotFound", err)
+	}
+
+	wantPrefix := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20261018/auto/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="
+	for _, auth := range fake.auth {
+		if !strings.HasPrefix(auth, wantPrefix) || len(auth) != len(wantPrefix)+64 {
+			t.Fatalf("unexpected Authorization %q", auth)
+		}
+	}
+}
+
+func TestNewS3Store_RequiresBucketAndCredentials(t *testing.T) {
+	if _, err := NewS3Store(models.ObjectStorageConfig{AccessKeyID: "a", SecretAccessKey: "b"}); err == nil {
+		t.Error("expected an error without a bucket")
+	}
+	if _, err := NewS3Store(models.ObjectStorageConfig{Bucket: "b"}); err == nil {
+		t.Error("expected an error without credentials")
+	}
+}
+
+func TestS3Store_PublicURL(t *testing.T) {
+	cfg := models.ObjectStorageConfig{Endpoint: "http://minio:9000", Bucket: "media", AccessKeyID: "a", SecretAccessKey: "b"}
+	store, _ := NewS3Store(cfg)
+	if got := store.PublicURL("avatars/u/1-256.jpg"); got != "http://minio:9000/media/avatars/u/1-256.jpg" {
+		t.Errorf("PublicURL() = %q, want the bucket URL", got)
+	}
+
+	cfg.PublicURL = "https://cdn.example.com/"
+	store, _ = NewS3Store(cfg)
+	if got := store.PublicURL("avatars/u/1-256.jpg"); got != "https://cdn.example.com/avatars/u/1-256.jpg" {
+		t.Errorf("PublicURL() = %q, want the CDN URL", got)
+	}
+}
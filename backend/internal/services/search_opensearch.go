@@ -0,0 +1,455 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+// SearchMethodOpenSearch is the search method OpenSearchBackend reports.
+const SearchMethodOpenSearch = "opensearch"
+
+// openSearchSnippetLength is the fallback snippet length when nothing is highlighted.
+const openSearchSnippetLength = 300
+
+// openSearchMapping is the index mapping. Text is analyzed in English like the
+// PostgreSQL full-text search; everything filtered on is a keyword.
+var openSearchMapping = map[string]interface{}{
+	"mappings": map[string]interface{}{
+		"dynamic": "strict",
+		"properties": map[string]interface{}{
+			"source":           map[string]string{"type": "keyword"},
+			"id":               map[string]string{"type": "keyword"},
+			"post_id":          map[string]string{"type": "keyword"},
+			"type":             map[string]string{"type": "keyword"},
+			"title":            map[string]string{"type": "text", "analyzer": "english"},
+			"description":      map[string]string{"type": "text", "analyzer": "english"},
+			"tags":             map[string]string{"type": "keyword"},
+			"code_languages":   map[string]string{"type": "keyword"},
+			"status":           map[string]string{"type": "keyword"},
+			"author_type":      map[string]string{"type": "keyword"},
+			"author_id":        map[string]string{"type": "keyword"},
+			"author_name":      map[string]interface{}{"type": "keyword", "index": false},
+			"vote_score":       map[string]string{"type": "integer"},
+			"answers_count":    map[string]string{"type": "integer"},
+			"approaches_count": map[string]string{"type": "integer"},
+			"comments_count":   map[string]string{"type": "integer"},
+			"view_count":       map[string]string{"type": "integer"},
+			"visibility":       map[string]string{"type": "keyword"},
+			"owner_human_id":   map[string]string{"type": "keyword"},
+			"tenant_id":        map[string]string{"type": "keyword"},
+			"freshness_score":  map[string]string{"type": "integer"},
+			"outdated":         map[string]string{"type": "boolean"},
+			"created_at":       map[string]string{"type": "date"},
+			"updated_at":       map[string]string{"type": "date"},
+			"solved_at":        map[string]string{"type": "date"},
+		},
+	},
+}
+
+// OpenSearchBackend searches posts, answers and approaches in an OpenSearch
+// (or Elasticsearch) index and writes documents to it. It implements the
+// search backend interface; jobs.SearchIndexJob keeps the index in sync.
+type OpenSearchBackend struct {
+	baseURL    string
+	index      string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewOpenSearchBackend creates an OpenSearchBackend for cfg.
+func NewOpenSearchBackend(cfg models.SearchBackendConfig) (*OpenSearchBackend, error) {
+	if cfg.OpenSearchURL == "" {
+		return nil, errors.New("OPENSEARCH_URL is required")
+	}
+	if _, err := url.Parse(cfg.OpenSearchURL); err != nil {
+		return nil, fmt.Errorf("invalid OPENSEARCH_URL: %w", err)
+	}
+	if cfg.OpenSearchIndex == "" {
+		return nil, errors.New("index name is required")
+	}
+	return &OpenSearchBackend{
+		baseURL:    strings.TrimSuffix(cfg.OpenSearchURL, "/"),
+		index:      cfg.OpenSearchIndex,
+		username:   cfg.OpenSearchUsername,
+		password:   cfg.OpenSearchPassword,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// EnsureIndex creates the index with its mapping if it does not exist.
+func (b *OpenSearchBackend) EnsureIndex(ctx context.Context) error {
+	resp, err := b.do(ctx, http.MethodHead, "/"+url.PathEscape(b.index), "", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("check index: status %d", resp.StatusCode)
+	}
+
+	body, err := json.Marshal(openSearchMapping)
+	if err != nil {
+		return fmt.Errorf("marshal index mapping: %w", err)
+	}
+	resp, err = b.do(ctx, http.MethodPut, "/"+url.PathEscape(b.index), "application/json", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return openSearchError("create index", resp)
+	}
+	return nil
+}
+
+// Index writes docs and removes the documents with the given IDs (see
+// models.SearchDocumentID) in one bulk request. Removing a document that is not
+// in the index is not an error.
+func (b *OpenSearchBackend) Index(ctx context.Context, docs []models.SearchDocument, deleteIDs []string) error {
+	if len(docs) == 0 && len(deleteIDs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range docs {
+		action := map[string]interface{}{"index": map[string]string{"_id": docs[i].DocumentID()}}
+		if err := enc.Encode(action); err != nil {
+			return fmt.Errorf("encode bulk action: %w", err)
+		}
+		if err := enc.Encode(&docs[i]); err != nil {
+			return fmt.Errorf("encode search document: %w", err)
+		}
+	}
+	for _, id := range deleteIDs {
+		if err := enc.Encode(map[string]interface{}{"delete": map[string]string{"_id": id}}); err != nil {
+			return fmt.Errorf("encode bulk action: %w", err)
+		}
+	}
+
+	resp, err := b.do(ctx, http.MethodPost, "/"+url.PathEscape(b.index)+"/_bulk", "application/x-ndjson", buf.Bytes())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return openSearchError("bulk index", resp)
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode bulk response: %w", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		for action, r := range item {
+			if action == "delete" && r.Status == http.StatusNotFound {
+				continue
+			}
+			if r.Error != nil {
+				return fmt.Errorf("bulk %s %s: %s: %s", action, r.ID, r.Error.Type, r.Error.Reason)
+			}
+		}
+	}
+	return nil
+}
+
+// Search runs a keyword search with the same filters, sorting and pagination
+// as the PostgreSQL backend. There is no semantic measure, so the returned top
+// similarity is always nil and a MinSimilarity floor leaves nothing.
+func (b *OpenSearchBackend) Search(ctx context.Context, query string, opts models.SearchOptions) ([]models.SearchResult, int, string, *float64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []models.SearchResult{}, 0, "", nil, nil
+	}
+	if opts.MinSimilarity > 0 {
+		return []models.SearchResult{}, 0, SearchMethodOpenSearch, nil, nil
+	}
+
+	sources := openSearchSourceFilters(opts)
+	if len(sources) == 0 {
+		return []models.SearchResult{}, 0, SearchMethodOpenSearch, nil, nil
+	}
+	var viewerHuman string
+	if p := auth.PrincipalFromContext(ctx); p != nil {
+		viewerHuman = p.HumanID
+	}
+	filters := []interface{}{
+		openSearchVisibilityFilter(viewerHuman),
+		map[string]interface{}{"bool": map[string]interface{}{"should": sources, "minimum_should_match": 1}},
+	}
+	if id := tenant.FromContext(ctx); id != "" {
+		filters = append(filters, term("tenant_id", id))
+	}
+
+	limit := opts.PerPage
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 50 {
+		limit = 50
+	}
+	offset := (opts.Page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"from":             offset,
+		"size":             limit,
+		"track_total_hits": true,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":  query,
+						"fields": []string{"title^2", "description"},
+					},
+				},
+				"filter": filters,
+			},
+		},
+		"highlight": map[string]interface{}{
+			"pre_tags":  []string{"<mark>"},
+			"post_tags": []string{"</mark>"},
+			"fields": map[string]interface{}{
+				"description": map[string]interface{}{"fragment_size": openSearchSnippetLength, "number_of_fragments": 1},
+			},
+		},
+		"sort": openSearchSort(opts.Sort),
+	})
+	if err != nil {
+		return nil, 0, "", nil, fmt.Errorf("marshal search request: %w", err)
+	}
+
+	resp, err := b.do(ctx, http.MethodPost, "/"+url.PathEscape(b.index)+"/_search", "application/json", body)
+	if err != nil {
+		return nil, 0, "", nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, "", nil, openSearchError("search", resp)
+	}
+
+	var result struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Score     *float64              `json:"_score"`
+				Source    models.SearchDocument `json:"_source"`
+				Highlight map[string][]string   `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, "", nil, fmt.Errorf("decode search response: %w", err)
+	}
+
+	results := make([]models.SearchResult, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		doc := hit.Source
+		snippet := truncateRunes(doc.Description, openSearchSnippetLength)
+		if fragments := hit.Highlight["description"]; len(fragments) > 0 {
+			snippet = fragments[0]
+		}
+		title := doc.Title
+		if title == "" {
+			title = snippet
+		}
+		res := models.SearchResult{
+			ID:              doc.ID,
+			Type:            doc.Type,
+			Title:           title,
+			Description:     doc.Description,
+			Snippet:         snippet,
+			Tags:            doc.Tags,
+			Status:          doc.Status,
+			AuthorID:        doc.AuthorID,
+			AuthorType:      doc.AuthorType,
+			AuthorName:      doc.AuthorName,
+			VoteScore:       doc.VoteScore,
+			AnswersCount:    doc.AnswersCount,
+			ApproachesCount: doc.ApproachesCount,
+			CommentsCount:   doc.CommentsCount,
+			ViewCount:       doc.ViewCount,
+			CreatedAt:       doc.CreatedAt,
+			SolvedAt:        doc.SolvedAt,
+			Source:          doc.Source,
+			FreshnessScore:  doc.FreshnessScore,
+			Outdated:        doc.Outdated,
+		}
+		if hit.Score != nil {
+			res.Score = *hit.Score
+		}
+		results = append(results, res)
+	}
+	return results, result.Hits.Total.Value, SearchMethodOpenSearch, nil, nil
+}
+
+// openSearchSourceFilters returns one clause per content type searched, each
+// with the filters that apply to it. As in PostgreSQL search, post filters
+// only narrow posts, and approaches carry no code languages.
+func openSearchSourceFilters(opts models.SearchOptions) []interface{} {
+	searchAll := len(opts.ContentTypes) == 0
+	var clauses []interface{}
+
+	if searchAll || containsString(opts.ContentTypes, "posts") {
+		must := []interface{}{term("source", "post")}
+		if opts.Type != "" {
+			must = append(must, term("type", opts.Type))
+		}
+		if opts.Status != "" {
+			must = append(must, term("status", opts.Status))
+		}
+		if len(opts.Tags) > 0 {
+			must = append(must, terms("tags", opts.Tags))
+		}
+		if len(opts.Languages) > 0 {
+			must = append(must, terms("code_languages", opts.Languages))
+		}
+		if opts.Author != "" {
+			must = append(must, term("author_id", opts.Author))
+		}
+		if opts.AuthorType != "" {
+			must = append(must, term("author_type", opts.AuthorType))
+		}
+		if !opts.FromDate.IsZero() || !opts.ToDate.IsZero() {
+			bounds := map[string]interface{}{}
+			if !opts.FromDate.IsZero() {
+				bounds["gte"] = opts.FromDate.Format(time.RFC3339Nano)
+			}
+			if !opts.ToDate.IsZero() {
+				bounds["lte"] = opts.ToDate.Format(time.RFC3339Nano)
+			}
+			must = append(must, map[string]interface{}{"range": map[string]interface{}{"created_at": bounds}})
+		}
+		clauses = append(clauses, map[string]interface{}{"bool": map[string]interface{}{"filter": must}})
+	}
+
+	if containsString(opts.ContentTypes, "answers") {
+		must := []interface{}{term("source", "answer")}
+		if len(opts.Languages) > 0 {
+			must = append(must, terms("code_languages", opts.Languages))
+		}
+		clauses = append(clauses, map[string]interface{}{"bool": map[string]interface{}{"filter": must}})
+	}
+
+	if containsString(opts.ContentTypes, "approaches") && len(opts.Languages) == 0 {
+		clauses = append(clauses, term("source", "approach"))
+	}
+	return clauses
+}
+
+// openSearchVisibilityFilter matches public documents, plus the caller's own
+// family documents when viewerHuman is set.
+func openSearchVisibilityFilter(viewerHuman string) interface{} {
+	if viewerHuman == "" {
+		return term("visibility", models.VisibilityPublic)
+	}
+	return map[string]interface{}{"bool": map[string]interface{}{
+		"should":               []interface{}{term("visibility", models.VisibilityPublic), term("owner_human_id", viewerHuman)},
+		"minimum_should_match": 1,
+	}}
+}
+
+// openSearchSort maps SearchOptions.Sort to an OpenSearch sort.
+func openSearchSort(sort string) []interface{} {
+	desc := func(field string) interface{} {
+		return map[string]interface{}{field: map[string]string{"order": "desc"}}
+	}
+	switch sort {
+	case "newest":
+		return []interface{}{desc("created_at")}
+	case "votes":
+		return []interface{}{desc("vote_score"), desc("created_at")}
+	case "activity":
+		return []interface{}{desc("updated_at")}
+	default:
+		return []interface{}{desc("_score"), desc("created_at")}
+	}
+}
+
+func term(field, value string) interface{} {
+	return map[string]interface{}{"term": map[string]string{field: value}}
+}
+
+func terms(field string, values []string) interface{} {
+	return map[string]interface{}{"terms": map[string][]string{field: values}}
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// truncateRunes returns s cut to at most n characters.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// do sends a request to the cluster with basic auth when configured.
+func (b *OpenSearchBackend) do(ctx context.Context, method, path, contentType string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, b.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("create opensearch request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if b.username != "" {
+		req.SetBasicAuth(b.username, b.password)
+	}
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("opensearch %s %s: %w", method, path, err)
+	}
+	return resp, nil
+}
+
+// openSearchError describes a failed response, including the start of its body.
+func openSearchError(op string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("opensearch %s: status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/auth"
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/fcavalcantirj/solvr/internal/tenant"
+)
+
+func newTestOpenSearch(t *testing.T, handler http.HandlerFunc) *OpenSearchBackend {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	backend, err := NewOpenSearchBackend(models.SearchBackendConfig{
+		OpenSearchURL:      srv.URL + "/",
+		OpenSearchIndex:    "solvr",
+		OpenSearchUsername: "solvr",
+		OpenSearchPassword: "secret",
+	})
+	if err != nil {
+		t.Fatalf("NewOpenSearchBackend() error = %v", err)
+	}
+	return backend
+}
+
+func TestNewOpenSearchBackend_RequiresURL(t *testing.T) {
+	if _, err := NewOpenSearchBackend(models.SearchBackendConfig{OpenSearchIndex: "solvr"}); err == nil {
+		t.Error("expected error without OPENSEARCH_URL")
+	}
+}
+
+func TestOpenSearchBackend_EnsureIndexCreatesMissingIndex(t *testing.T) {
+	var created bool
+	backend := newTestOpenSearch(t, func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "solvr" || pass != "secret" {
+			t.Errorf("missing basic auth on %s %s", r.Method, r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodHead:
+			if created {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case http.MethodPut:
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["mappings"] == nil {
+				t.Errorf("create index body missing mappings: %v", err)
+			}
+			created = true
+			w.Write([]byte(`{"acknowledged":true}`))
+		}
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := backend.EnsureIndex(context.Background()); err != nil {
+			t.Fatalf("EnsureIndex() error = %v", err)
+		}
+	}
+	if !created {
+		t.Error("index was not created")
+	}
+}
+
+func TestOpenSearchBackend_IndexToleratesMissingDeletes(t *testing.T) {
+	var lines []string
+	backend := newTestOpenSearch(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/solvr/_bulk" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSpace(string(body)), "\n")
+		w.Write([]byte(`{"errors":true,"items":[
+			{"index":{"_id":"post:p1","status":201}},
+			{"delete":{"_id":"answer:a1","status":404,"error":{"type":"not_found","reason":"missing"}}}]}`))
+	})
+
+	err := backend.Index(context.Background(), []models.SearchDocument{{Source: "post", ID: "p1", Title: "Title"}}, []string{"answer:a1"})
+	if err != nil {
+		t.Fatalf("Index() error = %v", err)
+	}
+	if len(lines) != 3 || !strings.Contains(lines[0], `"_id":"post:p1"`) || !strings.Contains(lines[2], `"delete"`) {
+		t.Errorf("unexpected bulk body %q", lines)
+	}
+}
+
+func TestOpenSearchBackend_IndexReportsItemErrors(t *testing.T) {
+	backend := newTestOpenSearch(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errors":true,"items":[{"index":{"_id":"post:p1","status":400,"error":{"type":"mapper_parsing_exception","reason":"bad"}}}]}`))
+	})
+	if err := backend.Index(context.Background(), []models.SearchDocument{{Source: "post", ID: "p1"}}, nil); err == nil {
+		t.Error("expected error for a failed item")
+	}
+}
+
+func TestOpenSearchBackend_Search(t *testing.T) {
+	var request map[string]interface{}
+	backend := newTestOpenSearch(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/solvr/_search" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.Write([]byte(`{"hits":{"total":{"value":42},"hits":[
+			{"_score":3.5,"_source":{"source":"post","id":"p1","type":"question","title":"Pool exhausted",
+			 "description":"pgx pool exhausted under load","tags":["go"],"status":"open","author_type":"agent",
+			 "author_id":"agent_1","author_name":"Agent One","vote_score":4,"answers_count":2,
+			 "visibility":"public","tenant_id":"acme","freshness_score":80,"created_at":"2026-01-02T03:04:05Z"},
+			 "highlight":{"description":["pgx <mark>pool</mark> exhausted"]}},
+			{"_score":1.2,"_source":{"source":"answer","id":"a1","type":"answer","title":"",
+			 "description":"Raise MaxConns","visibility":"public","created_at":"2026-01-03T00:00:00Z"}}]}}`))
+	})
+
+	ctx := tenant.WithID(context.Background(), "acme")
+	ctx = auth.ContextWithPrincipal(ctx, &auth.Principal{Type: models.AuthorTypeHuman, ID: "human-1", HumanID: "human-1"})
+	results, total, method, top, err := backend.Search(ctx, "pool", models.SearchOptions{
+		Tags:         []string{"go"},
+		ContentTypes: []string{"posts", "answers"},
+		Page:         2,
+		PerPage:      10,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 42 || method != SearchMethodOpenSearch || top != nil {
+		t.Errorf("total=%d method=%q top=%v", total, method, top)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	post := results[0]
+	if post.ID != "p1" || post.Source != "post" || post.Score != 3.5 || post.AuthorName != "Agent One" ||
+		post.Snippet != "pgx <mark>pool</mark> exhausted" || post.FreshnessScore == nil || *post.FreshnessScore != 80 ||
+		!post.CreatedAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected post result %+v", post)
+	}
+	// Answers have no title of their own; the snippet stands in.
+	if results[1].Title != "Raise MaxConns" {
+		t.Errorf("answer title = %q", results[1].Title)
+	}
+
+	if request["from"] != float64(10) || request["size"] != float64(10) {
+		t.Errorf("pagination from=%v size=%v", request["from"], request["size"])
+	}
+	raw, _ := json.Marshal(request["query"])
+	for _, want := range []string{`{"term":{"tenant_id":"acme"}}`, `{"term":{"owner_human_id":"human-1"}}`,
+		`{"terms":{"tags":["go"]}}`, `{"term":{"source":"answer"}}`} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("query missing %s: %s", want, raw)
+		}
+	}
+}
+
+func TestOpenSearchBackend_SearchWithoutRequest(t *testing.T) {
+	backend := newTestOpenSearch(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	tests := []struct {
+		name  string
+		query string
+		opts  models.SearchOptions
+	}{
+		{"blank query", "  ", models.SearchOptions{}},
+		// No semantic measure, so nothing clears a similarity floor.
+		{"min similarity", "pool", models.SearchOptions{MinSimilarity: 0.5}},
+		// Approaches carry no code languages.
+		{"approaches by language", "pool", models.SearchOptions{ContentTypes: []string{"approaches"}, Languages: []string{"go"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, total, _, _, err := backend.Search(context.Background(), tt.query, tt.opts)
+			if err != nil || len(results) != 0 || total != 0 {
+				t.Errorf("Search() = %v, %d, %v; want empty", results, total, err)
+			}
+		})
+	}
+}
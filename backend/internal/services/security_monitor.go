@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+// NotificationTypeSecurityAlert is the notification created for an anomalous sign-in.
+const NotificationTypeSecurityAlert NotificationType = "security.alert"
+
+const (
+	// securityHistoryWindow is how far back a sign-in's country is looked for.
+	securityHistoryWindow = 90 * 24 * time.Hour
+	// securityHistoryLimit bounds the history loaded for each check.
+	securityHistoryLimit = 500
+	// securityKeyIPWindow is the period MaxIPsPerKey applies to.
+	securityKeyIPWindow = 24 * time.Hour
+	// securityUserAgentMaxLen matches the security_events.user_agent column.
+	securityUserAgentMaxLen = 500
+)
+
+// SecurityEventStore persists sign-in history.
+type SecurityEventStore interface {
+	Create(ctx context.Context, ev *models.SecurityEvent) error
+	History(ctx context.Context, principalType, principalID string, since time.Time, limit int) ([]models.SecurityEvent, error)
+}
+
+// SecurityNotificationCreator writes security alert notifications.
+type SecurityNotificationCreator interface {
+	Create(ctx context.Context, n *models.Notification) (*models.Notification, error)
+}
+
+// SecurityMonitor records sign-ins, flags the ones that don't fit the
+// principal's history and alerts the principal about them.
+type SecurityMonitor struct {
+	store         SecurityEventStore
+	notifications SecurityNotificationCreator
+	cfg           models.SecurityConfig
+	now           func() time.Time
+}
+
+// NewSecurityMonitor creates a SecurityMonitor. A nil notifications creator
+// records anomalies without alerting.
+func NewSecurityMonitor(store SecurityEventStore, notifications SecurityNotificationCreator, cfg models.SecurityConfig) *SecurityMonitor {
+	return &SecurityMonitor{store: store, notifications: notifications, cfg: cfg, now: time.Now}
+}
+
+// Country returns the client's country from the configured proxy header, or ""
+// when no header is configured or the proxy could not place the client.
+func (m *SecurityMonitor) Country(header http.Header) string {
+	if m.cfg.CountryHeader == "" {
+		return ""
+	}
+	country := strings.ToUpper(strings.TrimSpace(header.Get(m.cfg.CountryHeader)))
+	// Cloudflare uses XX for unknown and T1 for Tor exits
+	if len(country) != 2 || country == "XX" || country == "T1" {
+		return ""
+	}
+	return country
+}
+
+// Record compares ev with the principal's recent sign-ins, stores it with the
+// anomalies found and, when there are any, notifies the principal.
+func (m *SecurityMonitor) Record(ctx context.Context, ev *models.SecurityEvent) error {
+	if len(ev.UserAgent) > securityUserAgentMaxLen {
+		ev.UserAgent = ev.UserAgent[:securityUserAgentMaxLen]
+	}
+	now := m.now()
+	history, err := m.store.History(ctx, ev.PrincipalType, ev.PrincipalID, now.Add(-securityHistoryWindow), securityHistoryLimit)
+	if err != nil {
+		return fmt.Errorf("load security history: %w", err)
+	}
+	ev.Anomalies = detectSecurityAnomalies(ev, history, m.cfg, now)
+	if err := m.store.Create(ctx, ev); err != nil {
+		return err
+	}
+	if len(ev.Anomalies) > 0 && m.notifications != nil {
+		if _, err := m.notifications.Create(ctx, securityAlert(ev)); err != nil {
+			slog.Warn("security monitor: failed to notify", "error", err, "principal_type", ev.PrincipalType, "principal_id", ev.PrincipalID)
+		}
+	}
+	return nil
+}
+
+// detectSecurityAnomalies returns what is unusual about ev given the
+// principal's earlier events, newest first.
+func detectSecurityAnomalies(ev *models.SecurityEvent, history []models.SecurityEvent, cfg models.SecurityConfig, now time.Time) []string {
+	anomalies := []string{}
+
+	if ev.Country != "" {
+		var last *models.SecurityEvent
+		seen := false
+		for i := range history {
+			if history[i].Country == "" {
+				continue
+			}
+			if last == nil {
+				last = &history[i]
+			}
+			if history[i].Country == ev.Country {
+				seen = true
+			}
+		}
+		// A principal's first located sign-in has nothing to compare with
+		if last != nil && !seen {
+			anomalies = append(anomalies, models.SecurityAnomalyNewCountry)
+		}
+		if last != nil && last.Country != ev.Country && now.Sub(last.CreatedAt) < cfg.ImpossibleTravelWindow {
+			anomalies = append(anomalies, models.SecurityAnomalyImpossibleTravel)
+		}
+	}
+
+	if ev.Method == models.SecurityMethodAPIKey && cfg.MaxIPsPerKey > 0 {
+		ips := map[string]bool{ev.IPAddress: true}
+		flagged := false
+		for _, h := range history {
+			if now.Sub(h.CreatedAt) > securityKeyIPWindow {
+				break
+			}
+			if h.Method != models.SecurityMethodAPIKey {
+				continue
+			}
+			ips[h.IPAddress] = true
+			flagged = flagged || slices.Contains(h.Anomalies, models.SecurityAnomalyManyIPs)
+		}
+		// Alert once per window rather than on every further IP
+		if len(ips) > cfg.MaxIPsPerKey && !flagged {
+			anomalies = append(anomalies, models.SecurityAnomalyManyIPs)
+		}
+	}
+
+	return anomalies
+}
+
+// securityAlert builds the notification for an anomalous event.
+func securityAlert(ev *models.SecurityEvent) *models.Notification {
+	where := ev.IPAddress
+	if ev.Country != "" {
+		where += " (" + ev.Country + ")"
+	}
+
+	var title, body string
+	switch {
+	case slices.Contains(ev.Anomalies, models.SecurityAnomalyImpossibleTravel):
+		title = "Sign-ins from two countries"
+		body = fmt.Sprintf("Your account was used from %s shortly after a sign-in from another country.", where)
+	case slices.Contains(ev.Anomalies, models.SecurityAnomalyNewCountry):
+		title = "Sign-in from a new country"
+		body = fmt.Sprintf("Your account was used from %s, a country it has not been used from before.", where)
+	default:
+		title = "API key used from many IPs"
+		body = fmt.Sprintf("Your API key was used from a new IP, %s, and more IPs than usual today.", where)
+	}
+	if ev.Method == models.SecurityMethodAPIKey {
+		body += " If this wasn't you, rotate your API key."
+	} else {
+		body += " If this wasn't you, change your password."
+	}
+
+	n := &models.Notification{
+		Type:  string(NotificationTypeSecurityAlert),
+		Title: title,
+		Body:  body,
+	}
+	if ev.PrincipalType == string(models.AuthorTypeAgent) {
+		n.AgentID = &ev.PrincipalID
+	} else {
+		n.UserID = &ev.PrincipalID
+		n.Link = "/settings"
+	}
+	return n
+}
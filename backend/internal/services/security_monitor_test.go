@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+)
+
+type mockSecurityEventStore struct {
+	events []models.SecurityEvent // newest first
+}
+
+func (m *mockSecurityEventStore) Create(ctx context.Context, ev *models.SecurityEvent) error {
+	ev.ID = "event"
+	ev.CreatedAt = time.Now()
+	m.events = append([]models.SecurityEvent{*ev}, m.events...)
+	return nil
+}
+
+func (m *mockSecurityEventStore) History(ctx context.Context, principalType, principalID string, since time.Time, limit int) ([]models.SecurityEvent, error) {
+	return m.events, nil
+}
+
+type mockSecurityNotifications struct {
+	created []*models.Notification
+}
+
+func (m *mockSecurityNotifications) Create(ctx context.Context, n *models.Notification) (*models.Notification, error) {
+	m.created = append(m.created, n)
+	return n, nil
+}
+
+func TestDetectSecurityAnomalies(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	cfg := models.SecurityConfig{ImpossibleTravelWindow: 2 * time.Hour, MaxIPsPerKey: 2}
+	login := func(country string, ago time.Duration) models.SecurityEvent {
+		return models.SecurityEvent{Method: models.SecurityMethodPassword, IPAddress: "203.0.113.1", Country: country, CreatedAt: now.Add(-ago)}
+	}
+	keyUse := func(ip string, ago time.Duration, anomalies ...string) models.SecurityEvent {
+		return models.SecurityEvent{Method: models.SecurityMethodAPIKey, IPAddress: ip, CreatedAt: now.Add(-ago), Anomalies: anomalies}
+	}
+
+	tests := []struct {
+		name    string
+		ev      models.SecurityEvent
+		history []models.SecurityEvent
+		want    []string
+	}{
+		{"first sign-in", login("BR", 0), nil, []string{}},
+		{"known country", login("BR", 0), []models.SecurityEvent{login("BR", 48*time.Hour)}, []string{}},
+		{"new country", login("PT", 0), []models.SecurityEvent{login("BR", 48*time.Hour)}, []string{models.SecurityAnomalyNewCountry}},
+		{"impossible travel", login("BR", 0), []models.SecurityEvent{login("JP", 30*time.Minute), login("BR", 72*time.Hour)}, []string{models.SecurityAnomalyImpossibleTravel}},
+		{"unknown country", login("", 0), []models.SecurityEvent{login("BR", time.Minute)}, []string{}},
+		{"few IPs", keyUse("10.0.0.2", 0), []models.SecurityEvent{keyUse("10.0.0.1", time.Hour)}, []string{}},
+		{"many IPs", keyUse("10.0.0.3", 0), []models.SecurityEvent{keyUse("10.0.0.2", time.Hour), keyUse("10.0.0.1", 2*time.Hour)}, []string{models.SecurityAnomalyManyIPs}},
+		{"many IPs already flagged", keyUse("10.0.0.4", 0), []models.SecurityEvent{keyUse("10.0.0.3", time.Hour, models.SecurityAnomalyManyIPs), keyUse("10.0.0.2", time.Hour), keyUse("10.0.0.1", 2*time.Hour)}, []string{}},
+		{"IPs from yesterday", keyUse("10.0.0.3", 0), []models.SecurityEvent{keyUse("10.0.0.2", 25*time.Hour), keyUse("10.0.0.1", 26*time.Hour)}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := detectSecurityAnomalies(&tt.ev, tt.history, cfg, now)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("detectSecurityAnomalies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSecurityMonitor_RecordNotifies(t *testing.T) {
+	store := &mockSecurityEventStore{}
+	notifications := &mockSecurityNotifications{}
+	m := NewSecurityMonitor(store, notifications, models.SecurityConfig{ImpossibleTravelWindow: time.Hour})
+	ctx := context.Background()
+
+	first := &models.SecurityEvent{PrincipalType: "human", PrincipalID: "user-1", Method: models.SecurityMethodGitHub, IPAddress: "203.0.113.1", Country: "BR"}
+	if err := m.Record(ctx, first); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if len(notifications.created) != 0 {
+		t.Fatalf("expected no alert for the first sign-in, got %d", len(notifications.created))
+	}
+
+	second := &models.SecurityEvent{PrincipalType: "human", PrincipalID: "user-1", Method: models.SecurityMethodGitHub, IPAddress: "198.51.100.1", Country: "RU"}
+	if err := m.Record(ctx, second); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if len(second.Anomalies) != 2 || len(store.events) != 2 {
+		t.Fatalf("expected the second event stored with two anomalies, got %v", second.Anomalies)
+	}
+	if len(notifications.created) != 1 {
+		t.Fatalf("expected one alert, got %d", len(notifications.created))
+	}
+	n := notifications.created[0]
+	if n.Type != "security.alert" || n.UserID == nil || *n.UserID != "user-1" || n.AgentID != nil {
+		t.Errorf("unexpected alert %+v", n)
+	}
+}
+
+func TestSecurityMonitor_Country(t *testing.T) {
+	header := http.Header{}
+	header.Set("CF-IPCountry", "br")
+
+	if got := NewSecurityMonitor(nil, nil, models.SecurityConfig{}).Country(header); got != "" {
+		t.Errorf("Country() without a configured header = %q, want empty", got)
+	}
+	m := NewSecurityMonitor(nil, nil, models.SecurityConfig{CountryHeader: "CF-IPCountry"})
+	if got := m.Country(header); got != "BR" {
+		t.Errorf("Country() = %q, want BR", got)
+	}
+	header.Set("CF-IPCountry", "XX")
+	if got := m.Country(header); got != "" {
+		t.Errorf("Country() for an unknown location = %q, want empty", got)
+	}
+}
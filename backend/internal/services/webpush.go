@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrPushSubscriptionGone is returned when the push service reports that a
+// subscription expired or was unsubscribed; it should be deleted.
+var ErrPushSubscriptionGone = errors.New("push subscription is gone")
+
+// MaxWebPushPayload is the largest payload Send accepts. Push services reject
+// bodies over 4096 bytes, and encryption adds 103.
+const MaxWebPushPayload = 3993
+
+// webPushRecordSize is the aes128gcm record size; every payload fits one record.
+const webPushRecordSize = 4096
+
+// vapidTokenTTL is how long the VAPID JWT sent with each push is valid. Push
+// services reject tokens valid for more than 24 hours.
+const vapidTokenTTL = 12 * time.Hour
+
+// WebPushSender delivers encrypted Web Push messages (RFC 8030), identifying
+// the server with VAPID (RFC 8292) and encrypting payloads with aes128gcm
+// (RFC 8291).
+type WebPushSender struct {
+	publicKey  string // base64url, sent as the VAPID k parameter
+	privateKey *ecdsa.PrivateKey
+	subject    string
+	httpClient *http.Client
+}
+
+// NewWebPushSender creates a WebPushSender from the VAPID key pair in cfg.
+// Returns an error if the keys are malformed or don't belong together.
+func NewWebPushSender(cfg models.WebPushConfig) (*WebPushSender, error) {
+	priv, err := decodeBase64URL(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode VAPID private key: %w", err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(priv)
+	if err != nil {
+		return nil, fmt.Errorf("parse VAPID private key: %w", err)
+	}
+	pub := key.PublicKey().Bytes()
+	if cfg.PublicKey != "" {
+		configured, err := decodeBase64URL(cfg.PublicKey)
+		if err != nil || !bytes.Equal(configured, pub) {
+			return nil, errors.New("VAPID public key does not match the private key")
+		}
+	}
+
+	// pub is the uncompressed point 0x04 || X || Y.
+	signer := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(pub[1:33]),
+			Y:     new(big.Int).SetBytes(pub[33:]),
+		},
+		D: new(big.Int).SetBytes(priv),
+	}
+	return &WebPushSender{
+		publicKey:  base64.RawURLEncoding.EncodeToString(pub),
+		privateKey: signer,
+		subject:    cfg.Subject,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// PublicKey returns the VAPID public key (base64url) browsers subscribe with
+// as applicationServerKey.
+func (s *WebPushSender) PublicKey() string {
+	return s.publicKey
+}
+
+// Send encrypts payload for sub and posts it to the subscription's push
+// service, which holds it for up to ttl while the browser is offline.
+// Returns ErrPushSubscriptionGone if the service no longer knows the
+// subscription.
+func (s *WebPushSender) Send(ctx context.Context, sub *models.PushSubscription, payload []byte, ttl time.Duration) error {
+	if len(payload) > MaxWebPushPayload {
+		return fmt.Errorf("web push payload is %d bytes, max %d", len(payload), MaxWebPushPayload)
+	}
+	body, err := encryptWebPush(sub, payload)
+	if err != nil {
+		return err
+	}
+	token, err := s.vapidToken(sub.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build web push request: %w", err)
+	}
+	req.Header.Set("Authorization", "vapid t="+token+", k="+s.publicKey)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("web push send failed: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrPushSubscriptionGone
+	case resp.StatusCode >= 300:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("web push send failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// vapidToken signs the VAPID JWT for the push service that owns endpoint.
+func (s *WebPushSender) vapidToken(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("parse push endpoint: %w", err)
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(vapidTokenTTL).Unix(),
+		"sub": s.subject,
+	}).SignedString(s.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("sign VAPID token: %w", err)
+	}
+	return token, nil
+}
+
+// encryptWebPush encrypts payload for the subscription's keys as a single
+// aes128gcm record (RFC 8291 section 3.4), with a fresh sender key and salt.
+func encryptWebPush(sub *models.PushSubscription, payload []byte) ([]byte, error) {
+	uaPublic, err := decodeBase64URL(sub.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("decode subscription p256dh: %w", err)
+	}
+	uaKey, err := ecdh.P256().NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("parse subscription p256dh: %w", err)
+	}
+	authSecret, err := decodeBase64URL(sub.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("decode subscription auth: %w", err)
+	}
+
+	asKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate web push key: %w", err)
+	}
+	asPublic := asKey.PublicKey().Bytes()
+	sharedSecret, err := asKey.ECDH(uaKey)
+	if err != nil {
+		return nil, fmt.Errorf("derive web push secret: %w", err)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate web push salt: %w", err)
+	}
+
+	keyInfo := "WebPush: info\x00" + string(uaPublic) + string(asPublic)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	// 0x02 marks the last (and only) record, with no padding.
+	plaintext := append(append([]byte(nil), payload...), 0x02)
+
+	// Header: salt || record size || key id length || key id (the sender key).
+	out := make([]byte, 0, 16+4+1+len(asPublic)+len(plaintext)+gcm.Overhead())
+	out = append(out, salt...)
+	out = binary.BigEndian.AppendUint32(out, webPushRecordSize)
+	out = append(out, byte(len(asPublic)))
+	out = append(out, asPublic...)
+	return gcm.Seal(out, nonce, plaintext, nil), nil
+}
+
+// decodeBase64URL decodes base64url with or without padding, the forms
+// browsers and key generators use for push keys.
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fcavalcantirj/solvr/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newTestVAPIDConfig(t *testing.T) models.WebPushConfig {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return models.WebPushConfig{
+		PublicKey:  base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		PrivateKey: base64.RawURLEncoding.EncodeToString(key.Bytes()),
+		Subject:    "mailto:ops@solvr.dev",
+	}
+}
+
+// newTestBrowser returns a subscription for endpoint and the browser's keys.
+func newTestBrowser(t *testing.T, endpoint string) (*models.PushSubscription, *ecdh.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return &models.PushSubscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(auth),
+	}, key, auth
+}
+
+// decryptWebPush decrypts an aes128gcm body the way a browser does.
+func decryptWebPush(t *testing.T, body []byte, uaKey *ecdh.PrivateKey, authSecret []byte) []byte {
+	t.Helper()
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != webPushRecordSize {
+		t.Fatalf("record size = %d", rs)
+	}
+	idLen := int(body[20])
+	asPublic := body[21 : 21+idLen]
+	asKey, err := ecdh.P256().NewPublicKey(asPublic)
+	if err != nil {
+		t.Fatalf("sender key: %v", err)
+	}
+	secret, err := uaKey.ECDH(asKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ikm, _ := hkdf.Key(sha256.New, secret, authSecret, "WebPush: info\x00"+string(uaKey.PublicKey().Bytes())+string(asPublic), 32)
+	cek, _ := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plain, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if plain[len(plain)-1] != 0x02 {
+		t.Fatalf("missing last record delimiter")
+	}
+	return plain[:len(plain)-1]
+}
+
+func TestWebPushSender_Send(t *testing.T) {
+	cfg := newTestVAPIDConfig(t)
+	sender, err := NewWebPushSender(cfg)
+	if err != nil {
+		t.Fatalf("NewWebPushSender: %v", err)
+	}
+
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	sub, uaKey, authSecret := newTestBrowser(t, srv.URL+"/push/abc")
+	if err := sender.Send(context.Background(), sub, []byte(`{"title":"New approach"}`), time.Hour); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if got.Header.Get("Content-Encoding") != "aes128gcm" || got.Header.Get("TTL") != "3600" {
+		t.Errorf("unexpected headers %v", got.Header)
+	}
+	if plain := decryptWebPush(t, body, uaKey, authSecret); string(plain) != `{"title":"New approach"}` {
+		t.Errorf("payload = %q", plain)
+	}
+
+	auth := got.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "vapid t=") || !strings.HasSuffix(auth, ", k="+cfg.PublicKey) {
+		t.Fatalf("Authorization = %q", auth)
+	}
+	tokenString := strings.TrimSuffix(strings.TrimPrefix(auth, "vapid t="), ", k="+cfg.PublicKey)
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (interface{}, error) {
+		return &sender.privateKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"})); err != nil {
+		t.Fatalf("VAPID token does not verify: %v", err)
+	}
+	if claims["aud"] != srv.URL || claims["sub"] != "mailto:ops@solvr.dev" {
+		t.Errorf("claims = %v", claims)
+	}
+}
+
+func TestWebPushSender_SendGone(t *testing.T) {
+	sender, err := NewWebPushSender(newTestVAPIDConfig(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer srv.Close()
+
+	sub, _, _ := newTestBrowser(t, srv.URL)
+	if err := sender.Send(context.Background(), sub, []byte("{}"), time.Hour); !errors.Is(err, ErrPushSubscriptionGone) {
+		t.Errorf("expected ErrPushSubscriptionGone, got %v", err)
+	}
+}
+
+func TestNewWebPushSender_MismatchedKeys(t *testing.T) {
+	cfg := newTestVAPIDConfig(t)
+	cfg.PublicKey = newTestVAPIDConfig(t).PublicKey
+	if _, err := NewWebPushSender(cfg); err == nil {
+		t.Error("expected an error for a public key that doesn't match the private key")
+	}
+}
@@ -0,0 +1,40 @@
+// Package tenant carries the community (tenant) a request belongs to. The API
+// middleware resolves it from the hostname or the X-Solvr-Tenant header and
+// stores it in the request context; the database pool reads it back on every
+// connection checkout and scopes tenant-owned tables to it with row-level
+// security. A context without a tenant is unscoped: background jobs, migrations
+// and single-tenant deployments see every row.
+package tenant
+
+import (
+	"context"
+	"regexp"
+)
+
+// DefaultID is the tenant that owns every row created before multi-tenancy was
+// enabled, and rows created without a tenant in context.
+const DefaultID = "default"
+
+// Header is the request header naming the tenant on hosts that don't map to one.
+const Header = "X-Solvr-Tenant"
+
+// validID matches tenant IDs: lowercase slugs of 2-40 characters.
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,38}[a-z0-9]$`)
+
+type contextKey struct{}
+
+// ValidID reports whether id is a well-formed tenant ID.
+func ValidID(id string) bool {
+	return validID.MatchString(id)
+}
+
+// WithID returns a copy of ctx scoped to tenant id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant ctx is scoped to, or "" when it is unscoped.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
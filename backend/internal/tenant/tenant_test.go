@@ -0,0 +1,41 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	if got := FromContext(ctx); got != "" {
+		t.Errorf("expected unscoped context, got %q", got)
+	}
+	if got := FromContext(WithID(ctx, "rustaceans")); got != "rustaceans" {
+		t.Errorf("FromContext() = %q, want rustaceans", got)
+	}
+}
+
+func TestValidID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"default", true},
+		{"acme-corp", true},
+		{"k8s", true},
+		{"a", false},
+		{"-acme", false},
+		{"acme-", false},
+		{"Acme", false},
+		{"acme_corp", false},
+		{"acme.corp", false},
+		{"", false},
+		{"a123456789012345678901234567890123456789", true},
+		{"a1234567890123456789012345678901234567890", false},
+	}
+	for _, tt := range tests {
+		if got := ValidID(tt.id); got != tt.want {
+			t.Errorf("ValidID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
@@ -28,6 +28,16 @@ func GenerateAgentRoomToken() (plaintext string, hashHex string, err error) {
 	return generatePrefixedToken(agentRoomTokenPrefix)
 }
 
+// agentVerificationTokenPrefix marks the one-time links that verify a
+// self-registered agent's operator email.
+const agentVerificationTokenPrefix = "solvr_ev_"
+
+// GenerateAgentVerificationToken creates an agent email verification token (solvr_ev_...).
+// Returns the plaintext token (sent in the email) and its SHA-256 hash (stored).
+func GenerateAgentVerificationToken() (plaintext string, hashHex string, err error) {
+	return generatePrefixedToken(agentVerificationTokenPrefix)
+}
+
 // IsAgentRoomToken reports whether a plaintext token is a per-agent room token.
 func IsAgentRoomToken(plaintext string) bool {
 	return len(plaintext) > len(agentRoomTokenPrefix) && plaintext[:len(agentRoomTokenPrefix)] == agentRoomTokenPrefix
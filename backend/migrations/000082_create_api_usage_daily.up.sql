@@ -0,0 +1,22 @@
+-- Rolling per-principal API usage counters, aggregated per day and route pattern.
+-- Written in batches by the usage recorder middleware; powers GET /v1/me/usage.
+
+CREATE TABLE api_usage_daily (
+    principal_type    VARCHAR(10)  NOT NULL CHECK (principal_type IN ('human', 'agent')),
+    principal_id      VARCHAR(255) NOT NULL,
+    day               DATE         NOT NULL,
+    method            VARCHAR(10)  NOT NULL,
+    route             VARCHAR(255) NOT NULL,
+    request_count     BIGINT       NOT NULL DEFAULT 0,
+    error_count       BIGINT       NOT NULL DEFAULT 0,
+    total_duration_ms BIGINT       NOT NULL DEFAULT 0,
+    max_duration_ms   INTEGER      NOT NULL DEFAULT 0,
+    updated_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (principal_type, principal_id, day, method, route)
+);
+
+-- Per-principal lookups over a date window
+CREATE INDEX idx_api_usage_daily_principal_day ON api_usage_daily (principal_type, principal_id, day DESC);
+
+-- Retention pruning
+CREATE INDEX idx_api_usage_daily_day ON api_usage_daily (day);
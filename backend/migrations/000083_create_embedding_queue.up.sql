@@ -0,0 +1,13 @@
+-- Posts whose embedding could not be generated at write time (embedding service
+-- disabled or Voyage unavailable). Drained by the embedding queue job with backoff.
+
+CREATE TABLE embedding_queue (
+    post_id         UUID         PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    reason          VARCHAR(50)  NOT NULL,
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    enqueued_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    next_attempt_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_embedding_queue_next_attempt ON embedding_queue (next_attempt_at);
@@ -0,0 +1,4 @@
+-- Track answer edits so PATCH /v1/answers/{id} can detect concurrent modifications.
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE answers SET updated_at = created_at;
@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS trigger_posts_comments_count ON comments;
+DROP TRIGGER IF EXISTS trigger_posts_approaches_count ON approaches;
+DROP TRIGGER IF EXISTS trigger_posts_answers_count ON answers;
+
+DROP FUNCTION IF EXISTS maintain_post_comments_count();
+DROP FUNCTION IF EXISTS maintain_post_approaches_count();
+DROP FUNCTION IF EXISTS maintain_post_answers_count();
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS comments_count,
+    DROP COLUMN IF EXISTS approaches_count,
+    DROP COLUMN IF EXISTS answers_count;
@@ -0,0 +1,74 @@
+-- Denormalized child counts on posts, maintained by triggers in the same
+-- transaction as the child insert / soft-delete / hard delete.
+-- Only live (deleted_at IS NULL) children are counted.
+-- Drift (e.g. from bulk SQL with triggers disabled) is repaired by the
+-- post counter reconciliation job.
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS answers_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS approaches_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS comments_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE posts p SET
+    answers_count = (SELECT COUNT(*) FROM answers a WHERE a.question_id = p.id AND a.deleted_at IS NULL),
+    approaches_count = (SELECT COUNT(*) FROM approaches ap WHERE ap.problem_id = p.id AND ap.deleted_at IS NULL),
+    comments_count = (SELECT COUNT(*) FROM comments c WHERE c.target_type = 'post' AND c.target_id = p.id AND c.deleted_at IS NULL);
+
+-- answers.question_id -> posts.answers_count
+CREATE OR REPLACE FUNCTION maintain_post_answers_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.deleted_at IS NULL THEN
+        UPDATE posts SET answers_count = GREATEST(answers_count - 1, 0) WHERE id = OLD.question_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.deleted_at IS NULL THEN
+        UPDATE posts SET answers_count = answers_count + 1 WHERE id = NEW.question_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_posts_answers_count ON answers;
+CREATE TRIGGER trigger_posts_answers_count
+    AFTER INSERT OR DELETE OR UPDATE OF deleted_at, question_id ON answers
+    FOR EACH ROW
+    EXECUTE FUNCTION maintain_post_answers_count();
+
+-- approaches.problem_id -> posts.approaches_count
+CREATE OR REPLACE FUNCTION maintain_post_approaches_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.deleted_at IS NULL THEN
+        UPDATE posts SET approaches_count = GREATEST(approaches_count - 1, 0) WHERE id = OLD.problem_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.deleted_at IS NULL THEN
+        UPDATE posts SET approaches_count = approaches_count + 1 WHERE id = NEW.problem_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_posts_approaches_count ON approaches;
+CREATE TRIGGER trigger_posts_approaches_count
+    AFTER INSERT OR DELETE OR UPDATE OF deleted_at, problem_id ON approaches
+    FOR EACH ROW
+    EXECUTE FUNCTION maintain_post_approaches_count();
+
+-- comments (target_type = 'post') -> posts.comments_count
+CREATE OR REPLACE FUNCTION maintain_post_comments_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.target_type = 'post' AND OLD.deleted_at IS NULL THEN
+        UPDATE posts SET comments_count = GREATEST(comments_count - 1, 0) WHERE id = OLD.target_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.target_type = 'post' AND NEW.deleted_at IS NULL THEN
+        UPDATE posts SET comments_count = comments_count + 1 WHERE id = NEW.target_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_posts_comments_count ON comments;
+CREATE TRIGGER trigger_posts_comments_count
+    AFTER INSERT OR DELETE OR UPDATE OF deleted_at, target_type, target_id ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION maintain_post_comments_count();
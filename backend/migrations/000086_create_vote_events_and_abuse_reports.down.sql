@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS abuse_reports;
+
+DROP TRIGGER IF EXISTS trigger_record_vote_event ON votes;
+DROP FUNCTION IF EXISTS record_vote_event();
+DROP TABLE IF EXISTS vote_events;
@@ -0,0 +1,65 @@
+-- Append-only history of vote casts, direction changes and retractions.
+-- Written by trigger so every vote path (posts, answers, blog) is covered.
+CREATE TABLE IF NOT EXISTS vote_events (
+    id          BIGSERIAL    PRIMARY KEY,
+    target_type VARCHAR(20)  NOT NULL,
+    target_id   UUID         NOT NULL,
+    voter_type  VARCHAR(10)  NOT NULL,
+    voter_id    VARCHAR(255) NOT NULL,
+    action      VARCHAR(10)  NOT NULL CHECK (action IN ('cast', 'change', 'retract')),
+    -- New direction for cast/change; the removed direction for retract.
+    direction   VARCHAR(4)   NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vote_events_created_at ON vote_events(created_at);
+CREATE INDEX IF NOT EXISTS idx_vote_events_voter ON vote_events(voter_type, voter_id, created_at);
+
+CREATE OR REPLACE FUNCTION record_vote_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO vote_events (target_type, target_id, voter_type, voter_id, action, direction)
+        VALUES (NEW.target_type, NEW.target_id, NEW.voter_type, NEW.voter_id, 'cast', NEW.direction);
+    ELSIF TG_OP = 'UPDATE' THEN
+        IF NEW.direction IS DISTINCT FROM OLD.direction THEN
+            INSERT INTO vote_events (target_type, target_id, voter_type, voter_id, action, direction)
+            VALUES (NEW.target_type, NEW.target_id, NEW.voter_type, NEW.voter_id, 'change', NEW.direction);
+        END IF;
+    ELSE
+        INSERT INTO vote_events (target_type, target_id, voter_type, voter_id, action, direction)
+        VALUES (OLD.target_type, OLD.target_id, OLD.voter_type, OLD.voter_id, 'retract', OLD.direction);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_record_vote_event ON votes;
+CREATE TRIGGER trigger_record_vote_event
+    AFTER INSERT OR DELETE OR UPDATE OF direction ON votes
+    FOR EACH ROW
+    EXECUTE FUNCTION record_vote_event();
+
+-- Suspicious voting patterns flagged by the daily abuse detection job.
+-- subject is the account under suspicion; related is the other account
+-- involved (the ring partner or the upvoted author), empty for bursts.
+CREATE TABLE IF NOT EXISTS abuse_reports (
+    id           UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind         VARCHAR(30)  NOT NULL CHECK (kind IN ('vote_ring', 'targeted_upvoting', 'new_account_burst')),
+    subject_type VARCHAR(10)  NOT NULL,
+    subject_id   VARCHAR(255) NOT NULL,
+    related_type VARCHAR(10)  NOT NULL DEFAULT '',
+    related_id   VARCHAR(255) NOT NULL DEFAULT '',
+    details      JSONB        NOT NULL DEFAULT '{}',
+    status       VARCHAR(20)  NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'dismissed', 'actioned')),
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    reviewed_at  TIMESTAMPTZ,
+    review_note  TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_abuse_reports_status ON abuse_reports(status, created_at DESC);
+
+-- At most one pending report per pattern, so daily runs don't pile up duplicates.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_abuse_reports_pending_unique
+    ON abuse_reports(kind, subject_type, subject_id, related_type, related_id)
+    WHERE status = 'pending';
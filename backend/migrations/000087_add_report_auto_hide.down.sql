@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_reports_pending_target;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE answers DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS hidden_at;
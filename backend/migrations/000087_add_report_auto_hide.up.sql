@@ -0,0 +1,11 @@
+-- Content hidden by community reports.
+-- Set when a post, answer or comment collects enough independent pending
+-- reports; cleared when an admin dismisses the reports. Hidden content is
+-- left out of lists, search and the sitemap but stays reachable by ID.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMPTZ;
+
+-- Moderation queue: pending reports grouped by target, oldest first.
+CREATE INDEX IF NOT EXISTS idx_reports_pending_target
+    ON reports(target_type, target_id, created_at) WHERE status = 'pending';
@@ -0,0 +1,15 @@
+-- Account moderation state for humans.
+-- Suspended and banned users can't log in or use their API keys; the reason and
+-- the admin who made the change are kept for support follow-up.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active',
+    ADD COLUMN IF NOT EXISTS status_reason TEXT,
+    ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS status_changed_by VARCHAR(255);
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check CHECK (status IN ('active', 'suspended', 'banned'));
+
+-- Admin user list filters
+CREATE INDEX IF NOT EXISTS idx_users_status ON users(status) WHERE status <> 'active';
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at DESC);
@@ -0,0 +1,17 @@
+CREATE OR REPLACE FUNCTION prevent_agent_reclaim()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- If human_id was already set (not null) and we're trying to change it
+    IF OLD.human_id IS NOT NULL AND NEW.human_id IS DISTINCT FROM OLD.human_id THEN
+        RAISE EXCEPTION 'agent_already_claimed: Agent is already linked to a human and cannot be re-claimed'
+            USING ERRCODE = 'P0001';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_account_deletions_user;
+DROP INDEX IF EXISTS idx_account_deletions_due;
+DROP TABLE IF EXISTS account_deletions;
+
+-- Anonymized content stays attributed to the sentinel, so the row is kept.
@@ -0,0 +1,43 @@
+-- GDPR account deletion pipeline.
+-- DELETE /v1/me soft-deletes the user, reattributes their content to the
+-- deleted-user sentinel below and records a receipt. After the grace period the
+-- purge job hard-deletes the users row and everything still keyed to it.
+
+-- Sentinel author for anonymized content. Soft-deleted and banned so it never
+-- shows up in user lists or signs in; content joins still render its name.
+-- Username and referral code fall outside the generated/validated formats so they can't collide.
+INSERT INTO users (id, username, display_name, email, role, referral_code, status, deleted_at)
+VALUES ('00000000-0000-0000-0000-000000000000', '[deleted]', 'Deleted user', 'deleted@solvr.invalid',
+        'user', 'deleted', 'banned', NOW())
+ON CONFLICT (id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS account_deletions (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id            UUID NOT NULL,
+    requested_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    purge_after        TIMESTAMPTZ NOT NULL,
+    content_anonymized INTEGER NOT NULL DEFAULT 0,
+    votes_anonymized   INTEGER NOT NULL DEFAULT 0,
+    purged_at          TIMESTAMPTZ,
+    cancelled_at       TIMESTAMPTZ
+);
+
+-- Purge job scan: pending deletions whose grace period has passed
+CREATE INDEX IF NOT EXISTS idx_account_deletions_due
+    ON account_deletions(purge_after)
+    WHERE purged_at IS NULL AND cancelled_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_account_deletions_user ON account_deletions(user_id);
+
+-- Unclaiming an agent (human_id -> NULL) must be allowed when its owner deletes
+-- their account; moving an agent to a different human is still rejected.
+CREATE OR REPLACE FUNCTION prevent_agent_reclaim()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.human_id IS NOT NULL AND NEW.human_id IS NOT NULL AND NEW.human_id IS DISTINCT FROM OLD.human_id THEN
+        RAISE EXCEPTION 'agent_already_claimed: Agent is already linked to a human and cannot be re-claimed'
+            USING ERRCODE = 'P0001';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
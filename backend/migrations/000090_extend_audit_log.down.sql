@@ -0,0 +1,23 @@
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+DROP FUNCTION IF EXISTS prevent_audit_log_modification();
+
+DROP INDEX IF EXISTS idx_audit_log_route;
+DROP INDEX IF EXISTS idx_audit_log_actor;
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS request_id;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS status_code;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS path;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS route;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS actor_id;
+ALTER TABLE audit_log DROP COLUMN IF EXISTS actor_type;
+
+-- Non-UUID targets (agent IDs, slugs) can't be represented in the old schema
+ALTER TABLE audit_log ALTER COLUMN target_id TYPE UUID
+    USING CASE WHEN target_id ~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$' THEN target_id::uuid END;
+
+UPDATE audit_log SET admin_id = NULL WHERE admin_id IS NOT NULL AND admin_id NOT IN (SELECT id FROM users);
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_admin_id_fkey
+    FOREIGN KEY (admin_id) REFERENCES users(id) ON DELETE SET NULL;
+
+COMMENT ON TABLE audit_log IS 'Audit log for all administrative actions';
@@ -0,0 +1,47 @@
+-- Audit log for every authenticated write (POST/PATCH/PUT/DELETE).
+-- The audit middleware records who did what to which resource; rows are never
+-- updated or deleted, so the table is protected by append-only triggers below.
+
+-- Actors are humans, agents or the admin API key, so they're stored as a
+-- (type, id) pair instead of a users FK. The FK also has to go because
+-- ON DELETE SET NULL would update rows the append-only trigger rejects.
+ALTER TABLE audit_log DROP CONSTRAINT IF EXISTS audit_log_admin_id_fkey;
+
+-- Targets include agent IDs and slugs, not just UUIDs
+ALTER TABLE audit_log ALTER COLUMN target_id TYPE VARCHAR(255) USING target_id::text;
+
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS actor_type VARCHAR(10);
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS actor_id VARCHAR(255);
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS route TEXT;
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS path TEXT;
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS status_code INTEGER;
+ALTER TABLE audit_log ADD COLUMN IF NOT EXISTS request_id VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_type, actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_route ON audit_log(route);
+
+COMMENT ON TABLE audit_log IS 'Append-only audit log of authenticated writes and administrative actions';
+COMMENT ON COLUMN audit_log.actor_type IS 'Who performed the write: human, agent or admin (admin API key)';
+COMMENT ON COLUMN audit_log.actor_id IS 'User ID, agent ID, or "admin" for the admin API key';
+COMMENT ON COLUMN audit_log.route IS 'Route pattern, e.g. /v1/posts/{id}';
+COMMENT ON COLUMN audit_log.details IS 'Diff summary: names of the request body fields sent, never their values';
+
+CREATE OR REPLACE FUNCTION prevent_audit_log_modification()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log_append_only: audit log entries cannot be modified or deleted'
+        USING ERRCODE = 'P0001';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_audit_log_modification();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION prevent_audit_log_modification();
@@ -0,0 +1,2 @@
+ALTER TABLE user_api_keys DROP CONSTRAINT IF EXISTS user_api_keys_scope_check;
+ALTER TABLE user_api_keys DROP COLUMN IF EXISTS scope;
@@ -0,0 +1,6 @@
+-- API key scopes: read-only keys can query Solvr but not create or change content.
+-- Existing keys keep full access.
+ALTER TABLE user_api_keys ADD COLUMN IF NOT EXISTS scope VARCHAR(10) NOT NULL DEFAULT 'write';
+
+ALTER TABLE user_api_keys DROP CONSTRAINT IF EXISTS user_api_keys_scope_check;
+ALTER TABLE user_api_keys ADD CONSTRAINT user_api_keys_scope_check CHECK (scope IN ('read', 'write'));
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_resolve_problem_escalation ON posts;
+DROP FUNCTION IF EXISTS resolve_problem_escalation();
+DROP TABLE IF EXISTS problem_escalations;
@@ -0,0 +1,38 @@
+-- Stuck-problem escalations: the author marks a problem stuck with a summary of
+-- what was tried. Open escalations (resolved_at IS NULL) are boosted in
+-- /v1/feed/stuck; resolved_at - created_at is the time spent stuck.
+CREATE TABLE IF NOT EXISTS problem_escalations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    problem_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    summary TEXT NOT NULL,
+    escalated_by_type VARCHAR(10) NOT NULL CHECK (escalated_by_type IN ('human', 'agent')),
+    escalated_by_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+-- At most one open escalation per problem
+CREATE UNIQUE INDEX IF NOT EXISTS idx_problem_escalations_open
+    ON problem_escalations(problem_id) WHERE resolved_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_problem_escalations_resolved_at
+    ON problem_escalations(resolved_at) WHERE resolved_at IS NOT NULL;
+
+-- Close the open escalation when the problem leaves the working states
+CREATE OR REPLACE FUNCTION resolve_problem_escalation() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status IN ('solved', 'closed', 'stale') AND NEW.status IS DISTINCT FROM OLD.status THEN
+        UPDATE problem_escalations
+        SET resolved_at = NOW()
+        WHERE problem_id = NEW.id AND resolved_at IS NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_resolve_problem_escalation ON posts;
+CREATE TRIGGER trg_resolve_problem_escalation
+    AFTER UPDATE OF status ON posts
+    FOR EACH ROW
+    WHEN (NEW.type = 'problem')
+    EXECUTE FUNCTION resolve_problem_escalation();
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trg_award_problem_bounty ON posts;
+DROP FUNCTION IF EXISTS award_problem_bounty();
+DROP TABLE IF EXISTS bounty_awards;
+ALTER TABLE posts DROP COLUMN IF EXISTS weight_updated_at;
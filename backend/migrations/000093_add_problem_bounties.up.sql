@@ -0,0 +1,46 @@
+-- Bounties: a problem's weight (1-5) doubles as its bounty. Authors can raise it
+-- while the problem is open, it decays one step per inactive period, and the
+-- solver earns (weight - 1) * 25 bonus reputation for the weight at solve time.
+
+-- When weight was last raised or decayed; inactivity is measured from here
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS weight_updated_at TIMESTAMPTZ;
+
+-- One award per solved problem, frozen at solve time so later weight changes
+-- cannot move reputation. Summed by reputation.BuildReputationSQL.
+CREATE TABLE IF NOT EXISTS bounty_awards (
+    problem_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    recipient_type VARCHAR(10) NOT NULL CHECK (recipient_type IN ('human', 'agent')),
+    recipient_id VARCHAR(255) NOT NULL,
+    weight INT NOT NULL CHECK (weight >= 1 AND weight <= 5),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bounty_awards_recipient
+    ON bounty_awards(recipient_type, recipient_id);
+
+-- Award the bounty to the author of the most recent succeeded approach when a
+-- weighted problem is solved. Authors solving their own problem earn nothing.
+CREATE OR REPLACE FUNCTION award_problem_bounty() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status = 'solved' AND OLD.status IS DISTINCT FROM 'solved' AND COALESCE(NEW.weight, 1) > 1 THEN
+        INSERT INTO bounty_awards (problem_id, recipient_type, recipient_id, weight)
+        SELECT NEW.id, a.author_type, a.author_id, NEW.weight
+        FROM approaches a
+        WHERE a.problem_id = NEW.id
+          AND a.status = 'succeeded'
+          AND a.deleted_at IS NULL
+          AND NOT (a.author_type = NEW.posted_by_type AND a.author_id = NEW.posted_by_id)
+        ORDER BY a.updated_at DESC
+        LIMIT 1
+        ON CONFLICT (problem_id) DO NOTHING;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_award_problem_bounty ON posts;
+CREATE TRIGGER trg_award_problem_bounty
+    AFTER UPDATE OF status ON posts
+    FOR EACH ROW
+    WHEN (NEW.type = 'problem')
+    EXECUTE FUNCTION award_problem_bounty();
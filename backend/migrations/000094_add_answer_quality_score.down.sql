@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_answers_quality_unscored;
+ALTER TABLE answers DROP COLUMN IF EXISTS quality_attempts;
+ALTER TABLE answers DROP COLUMN IF EXISTS quality_scored_at;
+ALTER TABLE answers DROP COLUMN IF EXISTS quality_score;
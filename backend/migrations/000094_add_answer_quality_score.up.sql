@@ -0,0 +1,15 @@
+-- Answer quality scoring: an optional background job asks Groq whether an answer
+-- addresses its question, contains code and cites sources, and stores a 0-100
+-- score used by GET /v1/questions/{id}/answers?sort=quality.
+
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS quality_score SMALLINT
+    CHECK (quality_score IS NULL OR (quality_score >= 0 AND quality_score <= 100));
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS quality_scored_at TIMESTAMPTZ;
+
+-- Failed scoring attempts; the job gives up on an answer after a few tries
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS quality_attempts INT NOT NULL DEFAULT 0;
+
+-- Partial index for the job's candidate query (unscored, visible answers)
+CREATE INDEX IF NOT EXISTS idx_answers_quality_unscored
+    ON answers(created_at)
+    WHERE quality_scored_at IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_strip_blacklisted_tags ON posts;
+DROP FUNCTION IF EXISTS strip_blacklisted_tags();
+DROP TABLE IF EXISTS tag_blacklist;
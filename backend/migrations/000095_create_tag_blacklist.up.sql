@@ -0,0 +1,31 @@
+-- Tag moderation: blacklisted tags are stripped from posts on write, so no
+-- create/update path (REST, MCP, CLI) can reintroduce them.
+
+CREATE TABLE IF NOT EXISTS tag_blacklist (
+    name VARCHAR(50) PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION strip_blacklisted_tags()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.tags IS NOT NULL AND EXISTS (
+        SELECT 1 FROM tag_blacklist WHERE name = ANY(NEW.tags)
+    ) THEN
+        NEW.tags := ARRAY(
+            SELECT t
+            FROM unnest(NEW.tags) WITH ORDINALITY AS u(t, ord)
+            WHERE NOT EXISTS (SELECT 1 FROM tag_blacklist b WHERE b.name = u.t)
+            ORDER BY ord
+        );
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_strip_blacklisted_tags ON posts;
+CREATE TRIGGER trg_strip_blacklisted_tags
+    BEFORE INSERT OR UPDATE OF tags ON posts
+    FOR EACH ROW
+    EXECUTE FUNCTION strip_blacklisted_tags();
@@ -0,0 +1,11 @@
+-- Tag following: agents and humans follow tags to personalize GET /v1/feed?scope=following.
+
+CREATE TABLE IF NOT EXISTS tag_follows (
+    follower_type VARCHAR(10) NOT NULL CHECK (follower_type IN ('agent', 'human')),
+    follower_id VARCHAR(255) NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_type, follower_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_follows_tag ON tag_follows(tag);
@@ -0,0 +1,15 @@
+-- Trending scores, recomputed periodically by the trending job and read by
+-- GET /v1/stats/trending. Component columns expose the score breakdown for tuning:
+-- score = (1 + votes_score + views_score + answers_score) * decay
+
+CREATE TABLE IF NOT EXISTS trending_scores (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    votes_score DOUBLE PRECISION NOT NULL,
+    views_score DOUBLE PRECISION NOT NULL,
+    answers_score DOUBLE PRECISION NOT NULL,
+    decay DOUBLE PRECISION NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trending_scores_score ON trending_scores(score DESC);
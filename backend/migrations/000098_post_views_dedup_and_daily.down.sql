@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS post_views_daily;
+DROP INDEX IF EXISTS idx_post_views_dedup;
+
+-- Keep the earliest view per viewer so the old uniqueness constraint can return.
+DELETE FROM post_views pv
+USING post_views older
+WHERE pv.post_id = older.post_id
+  AND pv.viewer_type = older.viewer_type
+  AND pv.viewer_id IS NOT DISTINCT FROM older.viewer_id
+  AND (pv.viewed_at, pv.id) > (older.viewed_at, older.id);
+
+ALTER TABLE post_views DROP COLUMN IF EXISTS viewer_hash;
+ALTER TABLE post_views ADD CONSTRAINT post_views_post_id_viewer_type_viewer_id_key UNIQUE (post_id, viewer_type, viewer_id);
@@ -0,0 +1,21 @@
+-- View counting: a viewer (principal or IP, plus user agent, hashed) counts once
+-- per post per 24 hours instead of once ever, and post_views_daily keeps a
+-- per-day rollup for the views-over-time series in GET /v1/posts/{id}/views.
+
+ALTER TABLE post_views DROP CONSTRAINT IF EXISTS post_views_post_id_viewer_type_viewer_id_key;
+ALTER TABLE post_views ADD COLUMN IF NOT EXISTS viewer_hash CHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_post_views_dedup ON post_views(post_id, viewer_hash, viewed_at DESC);
+
+CREATE TABLE IF NOT EXISTS post_views_daily (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, day)
+);
+
+INSERT INTO post_views_daily (post_id, day, views)
+SELECT post_id, (viewed_at AT TIME ZONE 'UTC')::date, COUNT(*)
+FROM post_views
+GROUP BY post_id, (viewed_at AT TIME ZONE 'UTC')::date
+ON CONFLICT (post_id, day) DO NOTHING;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_vote_events_target;
+
+ALTER TABLE post_views DROP COLUMN IF EXISTS referrer_host;
+
+DROP INDEX IF EXISTS idx_search_queries_result_post_ids;
+ALTER TABLE search_queries DROP COLUMN IF EXISTS result_post_ids;
@@ -0,0 +1,11 @@
+-- Per-post analytics for authors (GET /v1/me/posts/{id}/analytics):
+-- search impressions need the posts each search returned, referrer breakdown
+-- needs the referring host of each counted view, and the vote trajectory reads
+-- vote_events by target.
+
+ALTER TABLE search_queries ADD COLUMN IF NOT EXISTS result_post_ids UUID[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_search_queries_result_post_ids ON search_queries USING GIN (result_post_ids);
+
+ALTER TABLE post_views ADD COLUMN IF NOT EXISTS referrer_host VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_vote_events_target ON vote_events(target_type, target_id, created_at);
@@ -0,0 +1,10 @@
+-- Daily snapshots of site-wide totals for growth charts (GET /v1/stats/history).
+-- One row per day and metric; the snapshot job upserts today's rows, so each
+-- day keeps its last snapshot.
+CREATE TABLE IF NOT EXISTS stats_daily (
+    day         DATE         NOT NULL,
+    metric      VARCHAR(50)  NOT NULL,
+    value       BIGINT       NOT NULL,
+    recorded_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (metric, day)
+);
@@ -0,0 +1,22 @@
+-- Transactional notification emails (answer accepted, post crystallized, claim link
+-- requested). Rows are rendered at enqueue time and drained by the email queue job,
+-- which retries failed sends with backoff and deletes rows once delivered.
+
+CREATE TABLE email_queue (
+    id              UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    event           VARCHAR(50)  NOT NULL,
+    to_email        VARCHAR(255) NOT NULL,
+    subject         TEXT         NOT NULL,
+    html_body       TEXT         NOT NULL,
+    text_body       TEXT         NOT NULL,
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    enqueued_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    next_attempt_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_email_queue_next_attempt ON email_queue (next_attempt_at);
+
+-- Per-event opt-outs for notification emails. email_unsubscribed_at still turns off
+-- every email for the user.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_opt_outs TEXT[] NOT NULL DEFAULT '{}';
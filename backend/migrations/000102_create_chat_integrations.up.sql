@@ -0,0 +1,20 @@
+-- Outgoing Slack/Discord integrations: new public problems and questions whose tags
+-- overlap an integration's tags are posted to its incoming webhook.
+-- Managed by admins via /v1/admin/integrations.
+
+CREATE TABLE chat_integrations (
+    id                UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    name              VARCHAR(100) NOT NULL,
+    provider          VARCHAR(20)  NOT NULL CHECK (provider IN ('slack', 'discord')),
+    webhook_url       TEXT         NOT NULL,
+    tags              TEXT[]       NOT NULL DEFAULT '{}',
+    post_types        TEXT[]       NOT NULL DEFAULT '{problem,question}',
+    enabled           BOOLEAN      NOT NULL DEFAULT TRUE,
+    failure_count     INTEGER      NOT NULL DEFAULT 0,
+    last_error        TEXT,
+    last_delivered_at TIMESTAMPTZ,
+    created_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_chat_integrations_tags ON chat_integrations USING GIN (tags) WHERE enabled;
@@ -0,0 +1,26 @@
+-- Links between posts and GitHub issues. Rows are created by
+-- POST /v1/posts/import/github (imported = TRUE, the post was built from the issue)
+-- or POST /v1/posts/{id}/github-link (an existing post). The GitHub sync job keeps
+-- issue_state current and closes/reopens the post to match.
+
+CREATE TABLE post_github_links (
+    post_id        UUID         PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    repo_owner     VARCHAR(100) NOT NULL,
+    repo_name      VARCHAR(100) NOT NULL,
+    issue_number   INTEGER      NOT NULL,
+    issue_url      TEXT         NOT NULL,
+    issue_state    VARCHAR(10)  NOT NULL DEFAULT 'open' CHECK (issue_state IN ('open', 'closed')),
+    imported       BOOLEAN      NOT NULL DEFAULT FALSE,
+    -- TRUE while the post is closed because the issue was closed, so a reopened
+    -- issue only reopens posts the sync closed itself.
+    closed_by_sync BOOLEAN      NOT NULL DEFAULT FALSE,
+    linked_by_type VARCHAR(10)  NOT NULL,
+    linked_by_id   VARCHAR(255) NOT NULL,
+    synced_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- An issue is imported at most once; re-importing returns the existing post.
+CREATE UNIQUE INDEX idx_post_github_links_imported
+    ON post_github_links (LOWER(repo_owner), LOWER(repo_name), issue_number) WHERE imported;
+CREATE INDEX idx_post_github_links_synced_at ON post_github_links (synced_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_posts_stack_fingerprint;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS stack_fingerprint;
+ALTER TABLE posts DROP COLUMN IF EXISTS stack_frames;
+ALTER TABLE posts DROP COLUMN IF EXISTS stack_exception_type;
+ALTER TABLE posts DROP COLUMN IF EXISTS stack_language;
@@ -0,0 +1,13 @@
+-- Stack-trace aware posts: when a description contains a stack trace, post
+-- creation extracts its language, exception type and normalized frames, plus a
+-- fingerprint of the crash signature. Posts sharing a fingerprint report the same
+-- crash and are offered as possible duplicates.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS stack_language TEXT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS stack_exception_type TEXT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS stack_frames TEXT[];
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS stack_fingerprint TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_posts_stack_fingerprint
+    ON posts(stack_fingerprint)
+    WHERE stack_fingerprint IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_answers_code_languages_unscanned;
+DROP INDEX IF EXISTS idx_posts_code_languages_unscanned;
+DROP INDEX IF EXISTS idx_answers_code_languages;
+DROP INDEX IF EXISTS idx_posts_code_languages;
+
+ALTER TABLE answers DROP COLUMN IF EXISTS code_languages;
+ALTER TABLE posts DROP COLUMN IF EXISTS code_languages;
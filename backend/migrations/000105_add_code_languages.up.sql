@@ -0,0 +1,23 @@
+-- Code-language indexing: post descriptions and answer bodies are scanned for
+-- fenced code blocks on write, and the detected languages are stored so search
+-- can filter by language (lang=go) and /v1/stats can report the distribution.
+-- NULL means the row has not been scanned yet; the code language backfill job
+-- fills in rows written before this migration.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS code_languages TEXT[];
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS code_languages TEXT[];
+
+CREATE INDEX IF NOT EXISTS idx_posts_code_languages
+    ON posts USING GIN (code_languages)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_answers_code_languages
+    ON answers USING GIN (code_languages)
+    WHERE deleted_at IS NULL;
+
+-- Lets the backfill job find unscanned rows cheaply; empty once it catches up.
+CREATE INDEX IF NOT EXISTS idx_posts_code_languages_unscanned
+    ON posts(id) WHERE code_languages IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_answers_code_languages_unscanned
+    ON answers(id) WHERE code_languages IS NULL;
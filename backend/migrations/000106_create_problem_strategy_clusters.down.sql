@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS problem_strategy_runs;
+DROP TABLE IF EXISTS problem_strategy_clusters;
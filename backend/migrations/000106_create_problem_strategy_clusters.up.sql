@@ -0,0 +1,22 @@
+-- Strategy clusters: the strategy clustering job groups each problem's approach
+-- embeddings by cosine similarity, so GET /v1/problems/{id}/insights can report how
+-- many fundamentally different angles were tried and which approach stands for each.
+
+-- One row per clustered approach. cluster_index 0 is the largest cluster.
+CREATE TABLE IF NOT EXISTS problem_strategy_clusters (
+    problem_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    approach_id UUID NOT NULL REFERENCES approaches(id) ON DELETE CASCADE,
+    cluster_index SMALLINT NOT NULL,
+    is_representative BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (problem_id, approach_id)
+);
+
+-- The last clustering of each problem. The job re-clusters a problem when an
+-- embedded approach is added, edited or deleted after computed_at.
+CREATE TABLE IF NOT EXISTS problem_strategy_runs (
+    problem_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    approach_count INT NOT NULL,
+    cluster_count INT NOT NULL,
+    similarity_threshold REAL NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
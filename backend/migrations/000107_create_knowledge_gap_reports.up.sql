@@ -0,0 +1,13 @@
+-- Weekly knowledge gap reports: recurring unmet needs summarized from zero-result
+-- searches and stuck problems. One report per week (week_start is the Monday);
+-- the report is published as a blog post and emailed to admins.
+CREATE TABLE IF NOT EXISTS knowledge_gap_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    week_start DATE NOT NULL UNIQUE,
+    summary TEXT NOT NULL,
+    gaps JSONB NOT NULL DEFAULT '[]',
+    zero_result_queries INT NOT NULL DEFAULT 0,
+    stuck_problems INT NOT NULL DEFAULT 0,
+    blog_post_id UUID REFERENCES blog_posts(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,38 @@
+-- Structured approach lifecycle events for GET /v1/approaches/{id}/timeline.
+-- data carries event details: status transitions ({"from", "to"}), the progress
+-- note, the recorded outcome, or whether the problem owner verified it.
+CREATE TABLE IF NOT EXISTS approach_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    approach_id UUID NOT NULL REFERENCES approaches(id) ON DELETE CASCADE,
+    event_type VARCHAR(30) NOT NULL CHECK (event_type IN (
+        'created', 'status_changed', 'progress_note', 'outcome_recorded', 'verified', 'abandoned'
+    )),
+    actor_type VARCHAR(10) NOT NULL CHECK (actor_type IN ('human', 'agent', 'system')),
+    actor_id VARCHAR(255) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_approach_events_approach ON approach_events(approach_id, created_at);
+
+-- Backfill timelines for existing approaches from what the approach rows still hold.
+INSERT INTO approach_events (approach_id, event_type, actor_type, actor_id, data, created_at)
+SELECT id, 'created', author_type, author_id, jsonb_build_object('status', 'starting'), COALESCE(created_at, NOW())
+FROM approaches;
+
+INSERT INTO approach_events (approach_id, event_type, actor_type, actor_id, data, created_at)
+SELECT n.approach_id, 'progress_note', a.author_type, a.author_id,
+       jsonb_build_object('note_id', n.id, 'content', n.content), COALESCE(n.created_at, a.created_at, NOW())
+FROM progress_notes n
+JOIN approaches a ON a.id = n.approach_id;
+
+INSERT INTO approach_events (approach_id, event_type, actor_type, actor_id, data, created_at)
+SELECT id, 'outcome_recorded', author_type, author_id,
+       jsonb_build_object('status', status, 'outcome', COALESCE(outcome, '')), COALESCE(updated_at, NOW())
+FROM approaches
+WHERE status IN ('succeeded', 'failed');
+
+INSERT INTO approach_events (approach_id, event_type, actor_type, actor_id, data, created_at)
+SELECT id, 'abandoned', author_type, author_id, '{}', COALESCE(updated_at, NOW())
+FROM approaches
+WHERE status = 'abandoned';
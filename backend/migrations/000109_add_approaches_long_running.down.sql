@@ -0,0 +1 @@
+ALTER TABLE approaches DROP COLUMN IF EXISTS long_running;
@@ -0,0 +1,5 @@
+-- Approaches their authors mark as long-running are exempt from the stale content
+-- job's abandonment warnings and auto-abandonment.
+ALTER TABLE approaches ADD COLUMN long_running BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN approaches.long_running IS 'Set by the author via PATCH /v1/approaches/:id; suppresses stale warnings and auto-abandon';
@@ -0,0 +1,10 @@
+DELETE FROM claim_tokens WHERE post_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_claim_tokens_post_id;
+ALTER TABLE claim_tokens DROP CONSTRAINT IF EXISTS claim_tokens_target_check;
+ALTER TABLE claim_tokens DROP COLUMN IF EXISTS email;
+ALTER TABLE claim_tokens DROP COLUMN IF EXISTS post_id;
+ALTER TABLE claim_tokens ALTER COLUMN agent_id SET NOT NULL;
+
+DELETE FROM posts WHERE posted_by_type = 'guest';
+ALTER TABLE posts DROP CONSTRAINT posts_posted_by_type_check;
+ALTER TABLE posts ADD CONSTRAINT posts_posted_by_type_check CHECK (posted_by_type IN ('human', 'agent'));
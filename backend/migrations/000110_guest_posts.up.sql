@@ -0,0 +1,19 @@
+-- Guest problem submissions (POST /v1/guest/problems).
+--
+-- Unauthenticated visitors can submit a problem. It is stored with posted_by_type
+-- 'guest' and stays quarantined (stricter moderation, no votes) until the submitter
+-- claims it with the claim token emailed to them, which reassigns it to their account.
+-- Guest claim tokens reuse claim_tokens: a row targets either an agent or a post.
+ALTER TABLE posts DROP CONSTRAINT posts_posted_by_type_check;
+ALTER TABLE posts ADD CONSTRAINT posts_posted_by_type_check CHECK (posted_by_type IN ('human', 'agent', 'guest'));
+
+ALTER TABLE claim_tokens ALTER COLUMN agent_id DROP NOT NULL;
+ALTER TABLE claim_tokens ADD COLUMN post_id UUID REFERENCES posts(id) ON DELETE CASCADE;
+ALTER TABLE claim_tokens ADD COLUMN email VARCHAR(255);
+ALTER TABLE claim_tokens ADD CONSTRAINT claim_tokens_target_check CHECK ((agent_id IS NULL) <> (post_id IS NULL));
+
+-- One claim token per guest post
+CREATE UNIQUE INDEX idx_claim_tokens_post_id ON claim_tokens(post_id) WHERE post_id IS NOT NULL;
+
+COMMENT ON COLUMN claim_tokens.post_id IS 'Guest post claimed by this token (agent_id is NULL)';
+COMMENT ON COLUMN claim_tokens.email IS 'Address the guest claim token was emailed to';
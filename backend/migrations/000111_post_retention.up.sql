@@ -0,0 +1,14 @@
+-- Per-post retention and legal hold metadata (PUT /v1/admin/posts/{id}/retention).
+--
+-- A post is held while legal_hold is set or retain_until is in the future. Held
+-- posts, and the answers, approaches, responses and comments on them, are left
+-- untouched by the stale content job and GDPR anonymization, and an account with
+-- held content is not purged until every hold on it is lifted.
+CREATE TABLE post_retention (
+    post_id      UUID         PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    legal_hold   BOOLEAN      NOT NULL DEFAULT FALSE,
+    hold_reason  TEXT,
+    retain_until TIMESTAMPTZ,
+    updated_by   VARCHAR(255) NOT NULL,
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,18 @@
+-- Admin-editable system comment templates for content moderation outcomes
+-- (GET/PUT/DELETE /v1/admin/moderation-templates). One row per key and language;
+-- the moderator's detected language picks the variant, falling back to 'en' and
+-- then to the built-in text. Placeholders: {explanation}, {reasons}, {language},
+-- {appeal_link}.
+CREATE TABLE moderation_templates (
+    key        VARCHAR(50)  NOT NULL CHECK (key IN ('approved', 'rejected', 'language_translating')),
+    language   VARCHAR(50)  NOT NULL,
+    body       TEXT         NOT NULL,
+    updated_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (key, language)
+);
+
+INSERT INTO moderation_templates (key, language, body, updated_by) VALUES
+    ('approved', 'en', 'Post approved by Solvr moderation. Your post is now visible in the feed.', 'system'),
+    ('rejected', 'en', E'Post rejected by Solvr moderation.\n\nReason: {explanation}\n\nYou can edit your post and resubmit for review.', 'system'),
+    ('language_translating', 'en', 'Your post appears to be in {language}. Translating to English now — your post should be live within minutes.', 'system');
@@ -0,0 +1,11 @@
+-- Scheduled publishing (POST /v1/posts with publish_at).
+--
+-- A scheduled post is created as a draft with publish_at set. The scheduled
+-- publish job opens it once publish_at has passed, clearing publish_at, and only
+-- then runs moderation and embedding.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_posts_publish_at ON posts(publish_at)
+    WHERE status = 'draft' AND publish_at IS NOT NULL AND deleted_at IS NULL;
+
+COMMENT ON COLUMN posts.publish_at IS 'When a scheduled draft is published; NULL once published or when not scheduled';
@@ -0,0 +1,3 @@
+DROP FUNCTION IF EXISTS latest_verified_answer_id(UUID);
+DROP INDEX IF EXISTS idx_answers_supersedes_answer_id;
+ALTER TABLE answers DROP COLUMN IF EXISTS supersedes_answer_id;
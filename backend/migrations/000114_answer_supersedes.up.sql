@@ -0,0 +1,33 @@
+-- Answer versioning: a new answer can declare that it supersedes an older answer
+-- on the same question (POST /v1/questions/{id}/answers with supersedes_answer_id).
+-- Versions form a chain, oldest first; each answer has at most one live successor.
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS supersedes_answer_id UUID REFERENCES answers(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_answers_supersedes_answer_id ON answers(supersedes_answer_id)
+    WHERE supersedes_answer_id IS NOT NULL AND deleted_at IS NULL;
+
+-- The newest verified version of an answer: the latest answer in its chain of
+-- successors that is accepted or net upvoted and still visible, or the answer
+-- itself when no newer version is verified. Deleted and hidden versions are
+-- followed but never returned. Used to down-weight superseded answers in search
+-- and to prefer improved versions of accepted answers in question context.
+CREATE OR REPLACE FUNCTION latest_verified_answer_id(start_id UUID) RETURNS UUID AS $$
+    WITH RECURSIVE chain AS (
+        SELECT id, 0 AS depth FROM answers WHERE id = start_id
+        UNION ALL
+        SELECT a.id, chain.depth + 1
+        FROM answers a
+        JOIN chain ON a.supersedes_answer_id = chain.id
+        WHERE chain.depth < 50
+    )
+    SELECT COALESCE((
+        SELECT a.id
+        FROM chain
+        JOIN answers a ON a.id = chain.id
+        WHERE chain.depth > 0
+          AND a.deleted_at IS NULL AND a.hidden_at IS NULL
+          AND (a.is_accepted OR a.upvotes - a.downvotes > 0)
+        ORDER BY chain.depth DESC, a.created_at DESC
+        LIMIT 1
+    ), start_id)
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_posts_freshness_review;
+DROP INDEX IF EXISTS idx_posts_freshness_scored_at;
+DROP TABLE IF EXISTS post_outdated_flags;
+ALTER TABLE posts DROP COLUMN IF EXISTS freshness_reviewed_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS freshness_scored_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS outdated_flag_count;
+ALTER TABLE posts DROP COLUMN IF EXISTS library_versions;
+ALTER TABLE posts DROP COLUMN IF EXISTS outdated;
+ALTER TABLE posts DROP COLUMN IF EXISTS freshness_score;
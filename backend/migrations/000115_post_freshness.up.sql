@@ -0,0 +1,31 @@
+-- Knowledge freshness: each post gets a 0-100 freshness score computed from its
+-- age, the library versions pinned in its code blocks and community "outdated"
+-- flags (POST /v1/posts/{id}/outdated). The freshness job rescores posts and
+-- search shows the score; outdated accepted solutions feed the admin review
+-- queue. freshness_score is NULL until the post is first scored.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS freshness_score SMALLINT;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS outdated BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS library_versions TEXT[];
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS outdated_flag_count INT NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS freshness_scored_at TIMESTAMPTZ;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS freshness_reviewed_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS post_outdated_flags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    flagger_type VARCHAR(10) NOT NULL CHECK (flagger_type IN ('human', 'agent')),
+    flagger_id VARCHAR(255) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(post_id, flagger_type, flagger_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_outdated_flags_post ON post_outdated_flags(post_id, created_at DESC);
+
+-- Lets the freshness job find unscored and stale scores cheaply.
+CREATE INDEX IF NOT EXISTS idx_posts_freshness_scored_at
+    ON posts(freshness_scored_at NULLS FIRST) WHERE deleted_at IS NULL;
+
+-- Review queue: outdated accepted solutions, least fresh first.
+CREATE INDEX IF NOT EXISTS idx_posts_freshness_review
+    ON posts(freshness_score) WHERE outdated AND deleted_at IS NULL;
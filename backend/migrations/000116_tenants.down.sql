@@ -0,0 +1,50 @@
+DROP POLICY IF EXISTS tenant_isolation ON rooms;
+ALTER TABLE rooms NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE rooms DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_rooms_tenant_id;
+ALTER TABLE rooms DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON comments;
+ALTER TABLE comments NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE comments DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_comments_tenant_id;
+ALTER TABLE comments DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON responses;
+ALTER TABLE responses NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE responses DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_responses_tenant_id;
+ALTER TABLE responses DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON approaches;
+ALTER TABLE approaches NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE approaches DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_approaches_tenant_id;
+ALTER TABLE approaches DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON answers;
+ALTER TABLE answers NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE answers DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_answers_tenant_id;
+ALTER TABLE answers DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON posts;
+ALTER TABLE posts NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE posts DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_posts_tenant_id;
+ALTER TABLE posts DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON agents;
+ALTER TABLE agents NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE agents DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_agents_tenant_id;
+ALTER TABLE agents DROP COLUMN IF EXISTS tenant_id;
+
+DROP POLICY IF EXISTS tenant_isolation ON users;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_users_tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+
+DROP FUNCTION IF EXISTS current_tenant_id();
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,105 @@
+-- Multi-tenant instances: one deployment serves several isolated communities.
+--
+-- Each request is resolved to a tenant (by hostname, or the X-Solvr-Tenant
+-- header on shared hosts) and the API sets app.tenant_id on the connection it
+-- uses. Row-level security then limits the core tables to that tenant's rows
+-- and stamps new rows with it. Sessions without app.tenant_id (background jobs,
+-- migrations, single-tenant deployments) see every row, and rows they insert
+-- belong to the default tenant. Superusers and BYPASSRLS roles skip the
+-- policies, so the API must connect as an ordinary role.
+CREATE TABLE IF NOT EXISTS tenants (
+    id VARCHAR(40) PRIMARY KEY CHECK (id ~ '^[a-z0-9][a-z0-9-]{0,38}[a-z0-9]$'),
+    name VARCHAR(100) NOT NULL,
+    hostnames TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO tenants (id, name) VALUES ('default', 'Solvr') ON CONFLICT (id) DO NOTHING;
+
+-- The tenant the current session is scoped to, or NULL when unscoped.
+CREATE OR REPLACE FUNCTION current_tenant_id() RETURNS TEXT AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')
+$$ LANGUAGE sql STABLE;
+
+-- Tenant-owned tables: tenant_id defaults to the session's tenant, and the
+-- tenant_isolation policy hides other tenants' rows from scoped sessions.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+CREATE INDEX IF NOT EXISTS idx_users_tenant_id ON users(tenant_id);
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON users;
+CREATE POLICY tenant_isolation ON users
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+CREATE INDEX IF NOT EXISTS idx_agents_tenant_id ON agents(tenant_id);
+ALTER TABLE agents ENABLE ROW LEVEL SECURITY;
+ALTER TABLE agents FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON agents;
+CREATE POLICY tenant_isolation ON agents
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+CREATE INDEX IF NOT EXISTS idx_posts_tenant_id ON posts(tenant_id);
+ALTER TABLE posts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE posts FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON posts;
+CREATE POLICY tenant_isolation ON posts
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+CREATE INDEX IF NOT EXISTS idx_answers_tenant_id ON answers(tenant_id);
+ALTER TABLE answers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE answers FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON answers;
+CREATE POLICY tenant_isolation ON answers
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+ALTER TABLE approaches ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+CREATE INDEX IF NOT EXISTS idx_approaches_tenant_id ON approaches(tenant_id);
+ALTER TABLE approaches ENABLE ROW LEVEL SECURITY;
+ALTER TABLE approaches FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON approaches;
+CREATE POLICY tenant_isolation ON approaches
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+ALTER TABLE responses ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+CREATE INDEX IF NOT EXISTS idx_responses_tenant_id ON responses(tenant_id);
+ALTER TABLE responses ENABLE ROW LEVEL SECURITY;
+ALTER TABLE responses FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON responses;
+CREATE POLICY tenant_isolation ON responses
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+CREATE INDEX IF NOT EXISTS idx_comments_tenant_id ON comments(tenant_id);
+ALTER TABLE comments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE comments FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON comments;
+CREATE POLICY tenant_isolation ON comments
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+CREATE INDEX IF NOT EXISTS idx_rooms_tenant_id ON rooms(tenant_id);
+ALTER TABLE rooms ENABLE ROW LEVEL SECURITY;
+ALTER TABLE rooms FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON rooms;
+CREATE POLICY tenant_isolation ON rooms
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
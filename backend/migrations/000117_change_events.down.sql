@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS trigger_change_events ON rooms;
+DROP TRIGGER IF EXISTS trigger_change_events ON comments;
+DROP TRIGGER IF EXISTS trigger_change_events ON responses;
+DROP TRIGGER IF EXISTS trigger_change_events ON approaches;
+DROP TRIGGER IF EXISTS trigger_change_events ON answers;
+DROP TRIGGER IF EXISTS trigger_change_events ON posts;
+DROP FUNCTION IF EXISTS record_change_event();
+
+DROP TABLE IF EXISTS change_events;
+DROP FUNCTION IF EXISTS prevent_change_event_modification();
+DROP SEQUENCE IF EXISTS change_events_seq;
@@ -0,0 +1,117 @@
+-- Change data capture: an append-only log of every insert, update and delete on
+-- the content tables, read incrementally through GET /v1/events/changes.
+-- Written by trigger so every write path (API, jobs, CLI tools) is covered.
+--
+-- Sequence numbers are assigned after commit, not by the trigger: a serial
+-- assigned at insert time can commit out of order, and a consumer that already
+-- read past it would never see the event. The reader assigns seq to committed,
+-- unsequenced events under an advisory lock, so seq order is commit order.
+CREATE SEQUENCE IF NOT EXISTS change_events_seq;
+
+CREATE TABLE IF NOT EXISTS change_events (
+    id         BIGSERIAL    PRIMARY KEY,
+    seq        BIGINT       UNIQUE,
+    entity     VARCHAR(20)  NOT NULL,
+    entity_id  TEXT         NOT NULL,
+    op         VARCHAR(10)  NOT NULL CHECK (op IN ('insert', 'update', 'delete')),
+    -- The row after the change (before it, for deletes), minus the columns
+    -- named in the trigger arguments.
+    payload    JSONB        NOT NULL,
+    tenant_id  VARCHAR(40)  NOT NULL DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_change_events_unsequenced ON change_events(id) WHERE seq IS NULL;
+CREATE INDEX IF NOT EXISTS idx_change_events_entity ON change_events(entity, seq);
+
+ALTER TABLE change_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE change_events FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON change_events;
+CREATE POLICY tenant_isolation ON change_events
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+-- TG_ARGV[0] is the entity name; the remaining arguments are columns left out
+-- of the payload. An update that only touches those columns (view counters,
+-- embeddings, scoring timestamps) records nothing.
+CREATE OR REPLACE FUNCTION record_change_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    dropped TEXT[] := TG_ARGV[1:];
+    row_new JSONB;
+    row_old JSONB;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        row_old := to_jsonb(OLD) - dropped;
+        INSERT INTO change_events (entity, entity_id, op, payload, tenant_id)
+        VALUES (TG_ARGV[0], OLD.id::text, 'delete', row_old, OLD.tenant_id);
+        RETURN NULL;
+    END IF;
+
+    row_new := to_jsonb(NEW) - dropped;
+    IF TG_OP = 'UPDATE' THEN
+        row_old := to_jsonb(OLD) - dropped;
+        IF row_new = row_old THEN
+            RETURN NULL;
+        END IF;
+    END IF;
+    INSERT INTO change_events (entity, entity_id, op, payload, tenant_id)
+    VALUES (TG_ARGV[0], NEW.id::text, lower(TG_OP), row_new, NEW.tenant_id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_change_events ON posts;
+CREATE TRIGGER trigger_change_events
+    AFTER INSERT OR UPDATE OR DELETE ON posts
+    FOR EACH ROW
+    EXECUTE FUNCTION record_change_event('post', 'embedding', 'view_count', 'freshness_scored_at');
+
+DROP TRIGGER IF EXISTS trigger_change_events ON answers;
+CREATE TRIGGER trigger_change_events
+    AFTER INSERT OR UPDATE OR DELETE ON answers
+    FOR EACH ROW
+    EXECUTE FUNCTION record_change_event('answer', 'embedding', 'quality_attempts', 'quality_scored_at');
+
+DROP TRIGGER IF EXISTS trigger_change_events ON approaches;
+CREATE TRIGGER trigger_change_events
+    AFTER INSERT OR UPDATE OR DELETE ON approaches
+    FOR EACH ROW
+    EXECUTE FUNCTION record_change_event('approach', 'embedding');
+
+DROP TRIGGER IF EXISTS trigger_change_events ON responses;
+CREATE TRIGGER trigger_change_events
+    AFTER INSERT OR UPDATE OR DELETE ON responses
+    FOR EACH ROW
+    EXECUTE FUNCTION record_change_event('response');
+
+DROP TRIGGER IF EXISTS trigger_change_events ON comments;
+CREATE TRIGGER trigger_change_events
+    AFTER INSERT OR UPDATE OR DELETE ON comments
+    FOR EACH ROW
+    EXECUTE FUNCTION record_change_event('comment');
+
+DROP TRIGGER IF EXISTS trigger_change_events ON rooms;
+CREATE TRIGGER trigger_change_events
+    AFTER INSERT OR UPDATE OR DELETE ON rooms
+    FOR EACH ROW
+    EXECUTE FUNCTION record_change_event('room', 'token_hash', 'message_count', 'last_active_at');
+
+-- Events are never edited or removed; the one permitted update assigns seq.
+CREATE OR REPLACE FUNCTION prevent_change_event_modification()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.seq IS NULL AND NEW.seq IS NOT NULL
+       AND (to_jsonb(NEW) - 'seq') = (to_jsonb(OLD) - 'seq') THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'change_events_append_only: change events cannot be modified or deleted'
+        USING ERRCODE = 'P0001';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS change_events_append_only ON change_events;
+CREATE TRIGGER change_events_append_only
+    BEFORE UPDATE OR DELETE ON change_events
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_change_event_modification();
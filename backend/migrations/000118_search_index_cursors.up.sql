@@ -0,0 +1,8 @@
+-- Progress of external search indexers through the change event log. An
+-- indexer without a row has never run and rebuilds its index from the tables;
+-- deleting the row forces a rebuild.
+CREATE TABLE IF NOT EXISTS search_index_cursors (
+    name       VARCHAR(50)  PRIMARY KEY,
+    last_seq   BIGINT       NOT NULL,
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
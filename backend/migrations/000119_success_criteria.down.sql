@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_sync_success_criteria ON posts;
+DROP FUNCTION IF EXISTS sync_success_criteria();
+DROP TABLE IF EXISTS success_criteria_overrides;
+DROP TABLE IF EXISTS success_criteria;
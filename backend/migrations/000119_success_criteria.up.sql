@@ -0,0 +1,63 @@
+-- Checkable success criteria. posts.success_criteria stays the source of the
+-- criteria text (set on create); a trigger mirrors it into success_criteria,
+-- one row per item, where the problem owner marks each one met with evidence.
+-- A problem can only become solved once every criterion is met, or with an
+-- override reason recorded in success_criteria_overrides.
+CREATE TABLE IF NOT EXISTS success_criteria (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    text TEXT NOT NULL,
+    met BOOLEAN NOT NULL DEFAULT false,
+    verified_by_type VARCHAR(10) CHECK (verified_by_type IN ('human', 'agent')),
+    verified_by_id VARCHAR(255),
+    evidence_url TEXT,
+    verified_at TIMESTAMPTZ,
+    UNIQUE(post_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS success_criteria_overrides (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    actor_type VARCHAR(10) NOT NULL CHECK (actor_type IN ('human', 'agent')),
+    actor_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- posts.success_criteria -> success_criteria rows. A criterion whose text
+-- changes is reset to unmet; removed trailing criteria are dropped.
+CREATE OR REPLACE FUNCTION sync_success_criteria()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM success_criteria
+    WHERE post_id = NEW.id
+      AND position >= COALESCE(cardinality(NEW.success_criteria), 0);
+
+    INSERT INTO success_criteria (post_id, position, text)
+    SELECT NEW.id, c.ord - 1, c.text
+    FROM unnest(COALESCE(NEW.success_criteria, '{}'::text[])) WITH ORDINALITY AS c(text, ord)
+    ON CONFLICT (post_id, position) DO UPDATE
+    SET text = EXCLUDED.text,
+        met = false,
+        verified_by_type = NULL,
+        verified_by_id = NULL,
+        evidence_url = NULL,
+        verified_at = NULL
+    WHERE success_criteria.text IS DISTINCT FROM EXCLUDED.text;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_sync_success_criteria ON posts;
+CREATE TRIGGER trigger_sync_success_criteria
+    AFTER INSERT OR UPDATE OF success_criteria ON posts
+    FOR EACH ROW
+    WHEN (NEW.type = 'problem')
+    EXECUTE FUNCTION sync_success_criteria();
+
+INSERT INTO success_criteria (post_id, position, text)
+SELECT p.id, c.ord - 1, c.text
+FROM posts p, unnest(p.success_criteria) WITH ORDINALITY AS c(text, ord)
+WHERE p.type = 'problem'
+ON CONFLICT (post_id, position) DO NOTHING;
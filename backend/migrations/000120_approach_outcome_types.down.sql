@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_approaches_problem_outcome_type;
+ALTER TABLE approaches DROP CONSTRAINT IF EXISTS approaches_failure_reason_check;
+ALTER TABLE approaches DROP CONSTRAINT IF EXISTS approaches_outcome_type_check;
+ALTER TABLE approaches DROP COLUMN IF EXISTS failure_reason;
+ALTER TABLE approaches DROP COLUMN IF EXISTS outcome_type;
@@ -0,0 +1,28 @@
+-- Structured approach outcomes: outcome_type classifies how an approach ended
+-- and failure_reason says why one that didn't fully work fell short. The
+-- free-text outcome stays as the narrative. Existing terminal approaches get
+-- the type their status implies.
+ALTER TABLE approaches ADD COLUMN IF NOT EXISTS outcome_type VARCHAR(30);
+ALTER TABLE approaches ADD COLUMN IF NOT EXISTS failure_reason VARCHAR(30);
+
+ALTER TABLE approaches DROP CONSTRAINT IF EXISTS approaches_outcome_type_check;
+ALTER TABLE approaches ADD CONSTRAINT approaches_outcome_type_check CHECK (outcome_type IN (
+    'worked', 'partially-worked', 'failed-hypothesis', 'blocked-external', 'superseded'
+));
+
+ALTER TABLE approaches DROP CONSTRAINT IF EXISTS approaches_failure_reason_check;
+ALTER TABLE approaches ADD CONSTRAINT approaches_failure_reason_check CHECK (
+    failure_reason IS NULL OR (
+        outcome_type IN ('partially-worked', 'failed-hypothesis', 'blocked-external')
+        AND failure_reason IN (
+            'wrong-assumption', 'environment-mismatch', 'dependency-issue', 'performance-limit',
+            'introduced-regression', 'missing-access', 'insufficient-information', 'other'
+        )
+    )
+);
+
+UPDATE approaches SET outcome_type = 'worked' WHERE status = 'succeeded' AND outcome_type IS NULL;
+UPDATE approaches SET outcome_type = 'failed-hypothesis' WHERE status = 'failed' AND outcome_type IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_approaches_problem_outcome_type
+    ON approaches(problem_id, outcome_type) WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_posts_entities_unextracted;
+ALTER TABLE posts DROP COLUMN IF EXISTS entities_extracted_at;
+DROP TABLE IF EXISTS post_entities;
+DROP TABLE IF EXISTS entities;
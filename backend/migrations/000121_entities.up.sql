@@ -0,0 +1,23 @@
+-- Knowledge graph entities: libraries, tools, error codes and CVEs mentioned
+-- in posts, extracted by the entity extraction job. entities_extracted_at is
+-- the updated_at the post had when it was last extracted, so an edit makes
+-- it pending again.
+CREATE TABLE IF NOT EXISTS entities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('library', 'error_code', 'cve', 'tool')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS post_entities (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    entity_id UUID NOT NULL REFERENCES entities(id) ON DELETE CASCADE,
+    PRIMARY KEY (post_id, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_entities_entity ON post_entities(entity_id);
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS entities_extracted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_posts_entities_unextracted
+    ON posts(updated_at) WHERE entities_extracted_at IS NULL;
@@ -0,0 +1,21 @@
+-- Web Push subscriptions: one row per browser a human allowed to receive push
+-- messages. p256dh and auth are the subscription's encryption keys. The web
+-- push job delivers new notifications of the configured types to them and
+-- records pushed_at, so each notification is pushed at most once.
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    endpoint TEXT NOT NULL UNIQUE,
+    p256dh VARCHAR(200) NOT NULL,
+    auth VARCHAR(100) NOT NULL,
+    user_agent VARCHAR(500),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_pushed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_user ON push_subscriptions(user_id);
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS pushed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_push_pending
+    ON notifications(created_at) WHERE pushed_at IS NULL AND user_id IS NOT NULL;
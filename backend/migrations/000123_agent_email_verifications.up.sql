@@ -0,0 +1,13 @@
+-- Operator email verification for self-registered agents.
+--
+-- With AGENT_REGISTRATION_REQUIRE_EMAIL on, POST /v1/agents/register creates the
+-- agent with status 'pending_verification' and emails a link to the operator
+-- address. Until it is followed the agent's API key is read-only. One live token
+-- per agent: resending replaces it. Only the token's SHA-256 is stored.
+CREATE TABLE IF NOT EXISTS agent_email_verifications (
+    agent_id   VARCHAR(50) PRIMARY KEY REFERENCES agents(id) ON DELETE CASCADE,
+    email      VARCHAR(255) NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,19 @@
+-- Sign-in history per principal: password and OAuth logins for humans, and the
+-- first use of an API key from each new IP. The security monitor compares each
+-- event with the principal's recent history and records what looked anomalous
+-- (new country, impossible travel, key used from many IPs); anomalous events also
+-- create a security.alert notification. Served by GET /v1/me/security/events.
+CREATE TABLE IF NOT EXISTS security_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    principal_type VARCHAR(10) NOT NULL CHECK (principal_type IN ('human', 'agent')),
+    principal_id VARCHAR(255) NOT NULL,
+    method VARCHAR(20) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(500) NOT NULL DEFAULT '',
+    country CHAR(2),
+    anomalies TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_principal
+    ON security_events(principal_type, principal_id, created_at DESC);
@@ -0,0 +1,7 @@
+DELETE FROM approach_events WHERE event_type = 'transcript_attached';
+ALTER TABLE approach_events DROP CONSTRAINT IF EXISTS approach_events_event_type_check;
+ALTER TABLE approach_events ADD CONSTRAINT approach_events_event_type_check CHECK (event_type IN (
+    'created', 'status_changed', 'progress_note', 'outcome_recorded', 'verified', 'abandoned'
+));
+
+DROP TABLE IF EXISTS approach_transcripts;
@@ -0,0 +1,20 @@
+-- Execution transcripts attached to approaches by POST /v1/approaches/{id}/transcript:
+-- the tool calls, commands and diffs an agent ran while working the approach.
+-- content is the gzip-compressed JSON array of entries; size_bytes is its
+-- uncompressed size. One transcript per approach; attaching again replaces it.
+CREATE TABLE IF NOT EXISTS approach_transcripts (
+    approach_id UUID PRIMARY KEY REFERENCES approaches(id) ON DELETE CASCADE,
+    content BYTEA NOT NULL,
+    entry_count INTEGER NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    compressed_bytes INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Attaching a transcript shows up on the approach timeline.
+ALTER TABLE approach_events DROP CONSTRAINT IF EXISTS approach_events_event_type_check;
+ALTER TABLE approach_events ADD CONSTRAINT approach_events_event_type_check CHECK (event_type IN (
+    'created', 'status_changed', 'progress_note', 'outcome_recorded', 'verified', 'abandoned',
+    'transcript_attached'
+));
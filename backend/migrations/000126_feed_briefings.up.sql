@@ -0,0 +1,12 @@
+-- Daily feed briefings served by GET /v1/feed/briefing: the LLM summary of the
+-- day's new problems, freshly solved posts and trending questions in a
+-- follower's tags, cached for the rest of the (UTC) day. Briefings older than a
+-- week are dropped when a newer one is saved.
+CREATE TABLE IF NOT EXISTS feed_briefings (
+    follower_type VARCHAR(10) NOT NULL CHECK (follower_type IN ('human', 'agent')),
+    follower_id VARCHAR(255) NOT NULL,
+    briefing_date DATE NOT NULL,
+    briefing JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_type, follower_id, briefing_date)
+);
@@ -0,0 +1,13 @@
+-- Usernames a user has given up via PATCH /v1/me. An old username can't be
+-- claimed by anyone else for USERNAME_REUSE_HOLD_DAYS after the change, and
+-- GET /v1/users/{old_username} redirects to the current one while nobody holds
+-- it. The latest row per user also enforces USERNAME_CHANGE_COOLDOWN_DAYS.
+CREATE TABLE IF NOT EXISTS username_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    username VARCHAR(30) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_username_history_username ON username_history(username, changed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_username_history_user ON username_history(user_id, changed_at DESC);
@@ -0,0 +1,14 @@
+-- Block and mute lists between principals (POST /v1/me/blocks/{principal}).
+-- Both modes stop the blocked party's @mentions from notifying the blocker;
+-- 'block' also hides their answers and comments from the blocker.
+CREATE TABLE IF NOT EXISTS principal_blocks (
+    blocker_type VARCHAR(10) NOT NULL CHECK (blocker_type IN ('human', 'agent')),
+    blocker_id VARCHAR(255) NOT NULL,
+    blocked_type VARCHAR(10) NOT NULL CHECK (blocked_type IN ('human', 'agent')),
+    blocked_id VARCHAR(255) NOT NULL,
+    mode VARCHAR(10) NOT NULL DEFAULT 'block' CHECK (mode IN ('block', 'mute')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_type, blocker_id, blocked_type, blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_principal_blocks_blocked ON principal_blocks(blocked_type, blocked_id);
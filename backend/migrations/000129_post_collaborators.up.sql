@@ -0,0 +1,13 @@
+-- Co-editors of a problem (POST /v1/posts/{id}/collaborators/{principal}).
+-- Collaborators may PATCH the post's title, description and tags; include_family
+-- on a human grant extends it to the agents that human has claimed.
+CREATE TABLE IF NOT EXISTS post_collaborators (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    collaborator_type VARCHAR(10) NOT NULL CHECK (collaborator_type IN ('human', 'agent')),
+    collaborator_id VARCHAR(255) NOT NULL,
+    include_family BOOLEAN NOT NULL DEFAULT FALSE,
+    granted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, collaborator_type, collaborator_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_collaborators_collaborator ON post_collaborators(collaborator_type, collaborator_id);
@@ -0,0 +1,13 @@
+-- Emoji reactions on answers and comments (POST /v1/answers/{id}/reactions).
+-- Unlike votes they carry no reputation: one row per reactor per emoji.
+CREATE TABLE IF NOT EXISTS reactions (
+    target_type VARCHAR(10) NOT NULL CHECK (target_type IN ('answer', 'comment')),
+    target_id UUID NOT NULL,
+    reactor_type VARCHAR(10) NOT NULL CHECK (reactor_type IN ('human', 'agent')),
+    reactor_id VARCHAR(255) NOT NULL,
+    emoji VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (target_type, target_id, reactor_type, reactor_id, emoji)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reactions_reactor ON reactions(reactor_type, reactor_id);
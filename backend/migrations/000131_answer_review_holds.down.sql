@@ -0,0 +1 @@
+ALTER TABLE answers DROP COLUMN IF EXISTS review_held_at;
@@ -0,0 +1,3 @@
+-- Answers held for re-moderation after a drastic edit. While set, hidden_at is
+-- set too, so the answer drops out of every listing until moderation releases it.
+ALTER TABLE answers ADD COLUMN IF NOT EXISTS review_held_at TIMESTAMPTZ;
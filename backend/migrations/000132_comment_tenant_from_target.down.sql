@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS comments_inherit_target_tenant ON comments;
+DROP FUNCTION IF EXISTS comments_inherit_target_tenant();
@@ -0,0 +1,22 @@
+-- Comments written by unscoped sessions (the translation and scheduled-publish
+-- jobs post system moderation comments) would otherwise fall back to the
+-- default tenant and be hidden from the post's own community. Take the tenant
+-- from the comment's target instead. Scoped sessions keep their own tenant.
+CREATE OR REPLACE FUNCTION comments_inherit_target_tenant() RETURNS TRIGGER AS $$
+BEGIN
+    IF current_tenant_id() IS NULL THEN
+        NEW.tenant_id := COALESCE(CASE NEW.target_type
+            WHEN 'post' THEN (SELECT tenant_id FROM posts WHERE id = NEW.target_id)
+            WHEN 'answer' THEN (SELECT tenant_id FROM answers WHERE id = NEW.target_id)
+            WHEN 'approach' THEN (SELECT tenant_id FROM approaches WHERE id = NEW.target_id)
+            WHEN 'response' THEN (SELECT tenant_id FROM responses WHERE id = NEW.target_id)
+        END, NEW.tenant_id);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS comments_inherit_target_tenant ON comments;
+CREATE TRIGGER comments_inherit_target_tenant
+    BEFORE INSERT ON comments
+    FOR EACH ROW EXECUTE FUNCTION comments_inherit_target_tenant();
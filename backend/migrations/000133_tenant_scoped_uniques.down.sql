@@ -0,0 +1,10 @@
+-- Fails if two tenants have since registered the same username, email or agent name.
+DROP INDEX IF EXISTS idx_agents_display_name_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agents_display_name_unique
+    ON agents (display_name);
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
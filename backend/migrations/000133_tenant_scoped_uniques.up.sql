@@ -0,0 +1,24 @@
+-- Usernames, emails and agent names are unique per tenant, not per deployment:
+-- each community registers its own. The constraint names are kept so the
+-- repositories' duplicate-key checks keep matching them.
+--
+-- Still global, deliberately:
+--   * agents.id, the agent handle every other table references, and the API
+--     key hashes (agents.key_sha256, user_api_keys.key_sha256). Keys are still
+--     only valid on their own tenant: the request's tenant is resolved before
+--     authentication, so key lookups run under it, and the auth middleware
+--     rejects keys and JWTs issued for another tenant.
+--   * Tables written by background jobs — stats_daily, trending_scores and
+--     entities — which aggregate across every tenant.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (tenant_id, username);
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (tenant_id, email);
+
+DROP INDEX IF EXISTS idx_agents_display_name_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agents_display_name_unique
+    ON agents (tenant_id, display_name);
+
+COMMENT ON INDEX idx_agents_display_name_unique IS
+    'Ensures agent names are unique within a tenant.';
@@ -0,0 +1,10 @@
+-- The read-only maintenance switch, shared by every API replica. One row,
+-- written by POST /v1/admin/maintenance and polled by each replica. Until it
+-- exists, each replica keeps the state MAINTENANCE_MODE gave it at startup.
+CREATE TABLE IF NOT EXISTS maintenance_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    message TEXT NOT NULL DEFAULT '',
+    since TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,27 @@
+CREATE OR REPLACE FUNCTION unscope_from_tenant(tbl TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('DROP TRIGGER IF EXISTS inherit_tenant ON %I', tbl);
+    EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', tbl);
+    EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', tbl);
+    EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', tbl);
+    EXECUTE format('DROP INDEX IF EXISTS %I', 'idx_' || tbl || '_tenant_id');
+    EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS tenant_id', tbl);
+END;
+$$ LANGUAGE plpgsql;
+
+SELECT unscope_from_tenant(tbl) FROM unnest(ARRAY[
+    'agent_email_verifications', 'claim_tokens', 'webhooks', 'account_deletions',
+    'push_subscriptions', 'refresh_tokens', 'auth_methods', 'user_api_keys',
+    'referrals', 'audit_log', 'api_usage_daily', 'security_events', 'search_queries',
+    'blog_posts', 'pins', 'badges', 'principal_blocks', 'feed_briefings', 'tag_follows',
+    'follows', 'notifications', 'agent_presence', 'room_agent_tokens', 'room_claims',
+    'room_events', 'room_members', 'messages', 'approach_relationships',
+    'approach_transcripts', 'approach_events', 'progress_notes', 'bounty_awards',
+    'problem_escalations', 'success_criteria_overrides', 'success_criteria', 'post_views',
+    'post_outdated_flags', 'post_github_links', 'post_collaborators', 'bookmarks',
+    'abuse_reports', 'flags', 'reports', 'reactions', 'vote_events', 'votes'
+]) AS tbl;
+
+DROP FUNCTION unscope_from_tenant(TEXT);
+DROP FUNCTION IF EXISTS inherit_tenant();
+DROP FUNCTION IF EXISTS tenant_of(TEXT, TEXT);
@@ -0,0 +1,165 @@
+-- Extend tenant isolation (000116) to the remaining tables that hold a
+-- community's content: votes, notifications, follows, bookmarks, reports, room
+-- messages, API keys and the rest. Each gets the same tenant_id column and
+-- tenant_isolation policy as the core tables.
+--
+-- Rows belong to the tenant of the row they hang off (the voted post, the
+-- notified user, the room a message was posted in). Existing rows are
+-- backfilled from it, and rows inserted later by unscoped sessions (background
+-- jobs) take it through the inherit_tenant trigger; scoped sessions keep their
+-- own tenant, as elsewhere.
+--
+-- Still deployment-wide: tags and post_tags (one shared vocabulary), tables
+-- owned by background jobs (stats_daily, trending_scores, entities,
+-- post_entities, post_views_daily, post_retention, embedding_queue,
+-- problem_strategy_*, knowledge_gap_reports, search_index_cursors, email_*),
+-- and operator settings (config, rate_limit*, tag_blacklist,
+-- moderation_templates, chat_integrations, maintenance_state, incidents,
+-- incident_updates, service_checks).
+
+-- The tenant of the row of the given kind ('post', 'user', 'agent', ...) with
+-- the given ID, or NULL when there is none.
+CREATE OR REPLACE FUNCTION tenant_of(kind TEXT, ref TEXT) RETURNS TEXT AS $$
+BEGIN
+    IF ref IS NULL THEN
+        RETURN NULL;
+    END IF;
+    IF kind = 'agent' THEN
+        RETURN (SELECT tenant_id FROM agents WHERE id = ref);
+    END IF;
+    IF ref !~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$' THEN
+        RETURN NULL;
+    END IF;
+    RETURN CASE kind
+        WHEN 'post' THEN (SELECT tenant_id FROM posts WHERE id = ref::uuid)
+        WHEN 'answer' THEN (SELECT tenant_id FROM answers WHERE id = ref::uuid)
+        WHEN 'approach' THEN (SELECT tenant_id FROM approaches WHERE id = ref::uuid)
+        WHEN 'response' THEN (SELECT tenant_id FROM responses WHERE id = ref::uuid)
+        WHEN 'comment' THEN (SELECT tenant_id FROM comments WHERE id = ref::uuid)
+        WHEN 'room' THEN (SELECT tenant_id FROM rooms WHERE id = ref::uuid)
+        WHEN 'human' THEN (SELECT tenant_id FROM users WHERE id = ref::uuid)
+        WHEN 'user' THEN (SELECT tenant_id FROM users WHERE id = ref::uuid)
+    END;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- BEFORE INSERT trigger taking (kind, id column) argument pairs. A kind
+-- starting with '$' names the column holding it (e.g. '$target_type'). Rows
+-- inserted by unscoped sessions take the tenant of the first referenced row
+-- that exists; otherwise they keep the column default.
+CREATE OR REPLACE FUNCTION inherit_tenant() RETURNS TRIGGER AS $$
+DECLARE
+    row_json JSONB;
+    kind TEXT;
+    parent_tenant TEXT;
+BEGIN
+    IF current_tenant_id() IS NOT NULL THEN
+        RETURN NEW;
+    END IF;
+    row_json := to_jsonb(NEW);
+    FOR i IN 0 .. TG_NARGS - 1 BY 2 LOOP
+        kind := TG_ARGV[i];
+        IF left(kind, 1) = '$' THEN
+            kind := row_json ->> substr(kind, 2);
+        END IF;
+        parent_tenant := tenant_of(kind, row_json ->> TG_ARGV[i + 1]);
+        IF parent_tenant IS NOT NULL THEN
+            NEW.tenant_id := parent_tenant;
+            RETURN NEW;
+        END IF;
+    END LOOP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Migration helper, dropped below: adds tenant_id to tbl, backfills it from
+-- the rows refs point at (same pairs as inherit_tenant), and installs the
+-- index, the tenant_isolation policy and the inherit_tenant trigger.
+CREATE OR REPLACE FUNCTION scope_to_tenant(tbl TEXT, VARIADIC refs TEXT[]) RETURNS VOID AS $$
+DECLARE
+    sources TEXT := '';
+BEGIN
+    EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+        DEFAULT COALESCE(current_tenant_id(), ''default'') REFERENCES tenants(id)', tbl);
+
+    FOR i IN 1 .. array_length(refs, 1) BY 2 LOOP
+        sources := sources || format('tenant_of(%s, %I::text), ',
+            CASE WHEN left(refs[i], 1) = '$' THEN quote_ident(substr(refs[i], 2)) ELSE quote_literal(refs[i]) END,
+            refs[i + 1]);
+    END LOOP;
+    EXECUTE format('UPDATE %I SET tenant_id = COALESCE(%stenant_id)', tbl, sources);
+
+    EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I(tenant_id)', 'idx_' || tbl || '_tenant_id', tbl);
+    EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', tbl);
+    EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', tbl);
+    EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', tbl);
+    EXECUTE format('CREATE POLICY tenant_isolation ON %I
+        USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+        WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())', tbl);
+
+    EXECUTE format('DROP TRIGGER IF EXISTS inherit_tenant ON %I', tbl);
+    EXECUTE format('CREATE TRIGGER inherit_tenant BEFORE INSERT ON %I
+        FOR EACH ROW EXECUTE FUNCTION inherit_tenant(%s)', tbl,
+        (SELECT string_agg(quote_literal(r), ', ') FROM unnest(refs) AS r));
+END;
+$$ LANGUAGE plpgsql;
+
+-- Votes, reactions and moderation on posts, answers, approaches, responses and comments
+SELECT scope_to_tenant('votes', '$target_type', 'target_id');
+SELECT scope_to_tenant('vote_events', '$target_type', 'target_id');
+SELECT scope_to_tenant('reactions', '$target_type', 'target_id');
+SELECT scope_to_tenant('reports', '$target_type', 'target_id');
+SELECT scope_to_tenant('flags', '$target_type', 'target_id');
+SELECT scope_to_tenant('abuse_reports', '$subject_type', 'subject_id');
+
+-- Post satellites
+SELECT scope_to_tenant('bookmarks', 'post', 'post_id');
+SELECT scope_to_tenant('post_collaborators', 'post', 'post_id');
+SELECT scope_to_tenant('post_github_links', 'post', 'post_id');
+SELECT scope_to_tenant('post_outdated_flags', 'post', 'post_id');
+SELECT scope_to_tenant('post_views', 'post', 'post_id');
+SELECT scope_to_tenant('success_criteria', 'post', 'post_id');
+SELECT scope_to_tenant('success_criteria_overrides', 'post', 'post_id');
+SELECT scope_to_tenant('problem_escalations', 'post', 'problem_id');
+SELECT scope_to_tenant('bounty_awards', 'post', 'problem_id');
+
+-- Approach satellites
+SELECT scope_to_tenant('progress_notes', 'approach', 'approach_id');
+SELECT scope_to_tenant('approach_events', 'approach', 'approach_id');
+SELECT scope_to_tenant('approach_transcripts', 'approach', 'approach_id');
+SELECT scope_to_tenant('approach_relationships', 'approach', 'from_approach_id');
+
+-- Rooms
+SELECT scope_to_tenant('messages', 'room', 'room_id');
+SELECT scope_to_tenant('room_members', 'room', 'room_id');
+SELECT scope_to_tenant('room_events', 'room', 'room_id');
+SELECT scope_to_tenant('room_claims', 'room', 'room_id');
+SELECT scope_to_tenant('room_agent_tokens', 'room', 'room_id');
+SELECT scope_to_tenant('agent_presence', 'room', 'room_id');
+
+-- Per-principal data
+SELECT scope_to_tenant('notifications', 'user', 'user_id', 'agent', 'agent_id');
+SELECT scope_to_tenant('follows', '$follower_type', 'follower_id');
+SELECT scope_to_tenant('tag_follows', '$follower_type', 'follower_id');
+SELECT scope_to_tenant('feed_briefings', '$follower_type', 'follower_id');
+SELECT scope_to_tenant('principal_blocks', '$blocker_type', 'blocker_id');
+SELECT scope_to_tenant('badges', '$owner_type', 'owner_id');
+SELECT scope_to_tenant('pins', '$owner_type', 'owner_id');
+SELECT scope_to_tenant('blog_posts', '$posted_by_type', 'posted_by_id');
+SELECT scope_to_tenant('search_queries', '$searcher_type', 'searcher_id');
+SELECT scope_to_tenant('security_events', '$principal_type', 'principal_id');
+SELECT scope_to_tenant('api_usage_daily', '$principal_type', 'principal_id');
+SELECT scope_to_tenant('audit_log', '$actor_type', 'actor_id');
+SELECT scope_to_tenant('referrals', 'user', 'referrer_id');
+
+-- Credentials and account state
+SELECT scope_to_tenant('user_api_keys', 'user', 'user_id');
+SELECT scope_to_tenant('auth_methods', 'user', 'user_id');
+SELECT scope_to_tenant('refresh_tokens', 'user', 'user_id');
+SELECT scope_to_tenant('push_subscriptions', 'user', 'user_id');
+SELECT scope_to_tenant('account_deletions', 'user', 'user_id');
+SELECT scope_to_tenant('webhooks', 'agent', 'agent_id');
+SELECT scope_to_tenant('claim_tokens', 'agent', 'agent_id');
+SELECT scope_to_tenant('agent_email_verifications', 'agent', 'agent_id');
+
+DROP FUNCTION scope_to_tenant(TEXT, TEXT[]);
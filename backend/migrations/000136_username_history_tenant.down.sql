@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS inherit_tenant ON username_history;
+DROP POLICY IF EXISTS tenant_isolation ON username_history;
+ALTER TABLE username_history NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE username_history DISABLE ROW LEVEL SECURITY;
+DROP INDEX IF EXISTS idx_username_history_tenant_id;
+ALTER TABLE username_history DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,17 @@
+-- Scope username_history (000127) to tenants like the other user-owned tables
+-- (000116, 000135): the reuse hold and old-username redirects then only apply
+-- within the tenant that owns the username. Rows belong to their user's tenant.
+ALTER TABLE username_history ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(40) NOT NULL
+    DEFAULT COALESCE(current_tenant_id(), 'default') REFERENCES tenants(id);
+UPDATE username_history h SET tenant_id = u.tenant_id FROM users u WHERE u.id = h.user_id;
+CREATE INDEX IF NOT EXISTS idx_username_history_tenant_id ON username_history(tenant_id);
+ALTER TABLE username_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE username_history FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON username_history;
+CREATE POLICY tenant_isolation ON username_history
+    USING (current_tenant_id() IS NULL OR tenant_id = current_tenant_id())
+    WITH CHECK (current_tenant_id() IS NULL OR tenant_id = current_tenant_id());
+
+DROP TRIGGER IF EXISTS inherit_tenant ON username_history;
+CREATE TRIGGER inherit_tenant BEFORE INSERT ON username_history
+    FOR EACH ROW EXECUTE FUNCTION inherit_tenant('user', 'user_id');
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// AcceptAnswerResponse is the response from accepting an answer
+type AcceptAnswerResponse struct {
+	Message  string `json:"message"`
+	AnswerID string `json:"answer_id"`
+}
+
+// NewAcceptCmd creates the accept command
+func NewAcceptCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var yes bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "accept <question_id> <answer_id>",
+		Short: "Accept an answer to your question",
+		Long: `Mark an answer as the accepted answer to your question.
+Only the question's author can accept an answer.
+
+You are asked to confirm first; pass --yes to skip the prompt in scripts.
+
+Examples:
+  solvr accept question_123 answer_456
+  solvr accept question_123 answer_456 --yes --json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			questionID, answerID := args[0], args[1]
+
+			key, baseURL, err := loadPinConfig(apiKey, apiURL)
+			if err != nil {
+				return err
+			}
+
+			if !yes && !confirmAction(cmd, fmt.Sprintf("Accept answer %s on question %s?", answerID, questionID)) {
+				return errAborted
+			}
+
+			var resp AcceptAnswerResponse
+			client := newAPIClient(baseURL, key)
+			if err := callAPI(client, http.MethodPost, "/questions/"+questionID+"/accept/"+answerID, nil, &resp); err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, resp); handled || err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Answer %s accepted on question %s.\n", answerID, questionID)
+			fmt.Fprintf(out, "\nView at: solvr get %s --include answers\n", questionID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON response")
+
+	return cmd
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAcceptCommand_AcceptsAnswer(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/questions/question-1/accept/answer-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message":"answer accepted","answer_id":"answer-1"}`))
+	}))
+	defer server.Close()
+
+	cmd := NewAcceptCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"question-1", "answer-1", "-y", "--json", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var resp AcceptAnswerResponse
+	if err := json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		t.Fatalf("expected only JSON output, got %s", buf.String())
+	}
+	if resp.AnswerID != "answer-1" {
+		t.Errorf("expected answer_id answer-1, got %q", resp.AnswerID)
+	}
+}
+
+func TestAcceptCommand_HandlesForbidden(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"code":"FORBIDDEN","message":"only the question owner can accept answers"}}`))
+	}))
+	defer server.Close()
+
+	cmd := NewAcceptCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetIn(strings.NewReader("y\n"))
+	cmd.SetArgs([]string{"question-1", "answer-1", "--api-url", server.URL})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "only the question owner") {
+		t.Errorf("expected API error, got %v", err)
+	}
+}
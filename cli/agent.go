@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+)
+
+// AgentProfileResponse is the response from GET /agents/{id}
+type AgentProfileResponse struct {
+	Data struct {
+		Agent AgentProfile `json:"agent"`
+		Stats AgentStats   `json:"stats"`
+	} `json:"data"`
+}
+
+// AgentProfile is the public profile of an agent
+type AgentProfile struct {
+	ID                  string   `json:"id"`
+	DisplayName         string   `json:"display_name"`
+	Bio                 string   `json:"bio,omitempty"`
+	Specialties         []string `json:"specialties,omitempty"`
+	Status              string   `json:"status"`
+	Reputation          int      `json:"reputation"`
+	HasHumanBackedBadge bool     `json:"has_human_backed_badge"`
+}
+
+// AgentStats are an agent's contribution counts
+type AgentStats struct {
+	ProblemsSolved      int `json:"problems_solved"`
+	ProblemsContributed int `json:"problems_contributed"`
+	QuestionsAsked      int `json:"questions_asked"`
+	QuestionsAnswered   int `json:"questions_answered"`
+	AnswersAccepted     int `json:"answers_accepted"`
+	IdeasPosted         int `json:"ideas_posted"`
+	ResponsesGiven      int `json:"responses_given"`
+	UpvotesReceived     int `json:"upvotes_received"`
+	Reputation          int `json:"reputation"`
+}
+
+// NewAgentCmd creates the agent command
+func NewAgentCmd() *cobra.Command {
+	agentCmd := &cobra.Command{
+		Use:   "agent",
+		Short: "Manage your agent identity",
+		Long: `Manage the agent identity behind the configured API key.
+
+Available subcommands:
+  claim  - Generate a claim token for your human operator
+  stats  - Show reputation and contribution stats`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	agentCmd.AddCommand(NewClaimCmd())
+	agentCmd.AddCommand(newAgentStatsCmd())
+
+	return agentCmd
+}
+
+// newAgentStatsCmd creates the agent stats subcommand
+func newAgentStatsCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "stats [agent_id]",
+		Short: "Show reputation and contribution stats",
+		Long: `Show an agent's reputation and contribution stats.
+
+Without an agent ID, shows the agent the configured API key belongs to.
+
+Examples:
+  solvr agent stats
+  solvr agent stats agent_claude_xyz
+  solvr agent stats --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var agentID string
+			if len(args) > 0 {
+				agentID = args[0]
+			}
+
+			// Try to load API key from config if not provided via flag
+			if apiKey == "" {
+				config, err := loadConfig()
+				if err == nil {
+					if key, ok := config["api-key"]; ok {
+						apiKey = key
+					}
+				}
+			}
+
+			// Try to load API URL from config if not overridden
+			if apiURL == defaultAPIURL {
+				config, err := loadConfig()
+				if err == nil {
+					if url, ok := config["api-url"]; ok {
+						apiURL = url
+					}
+				}
+			}
+
+			client := newAPIClient(apiURL, apiKey)
+
+			// Agent profiles are public; only resolving our own ID needs the API key
+			if agentID == "" {
+				if apiKey == "" {
+					return fmt.Errorf("API key not configured. Run 'solvr config set api-key <your-api-key>' first, or pass an agent ID")
+				}
+				me, err := fetchWhoami(client)
+				if err != nil {
+					return err
+				}
+				if !me.Data.isAgent() {
+					return fmt.Errorf("the configured API key belongs to a user, not an agent: pass an agent ID")
+				}
+				agentID = me.Data.ID
+			}
+
+			var resp AgentProfileResponse
+			if err := callAPI(client, http.MethodGet, "/agents/"+url.PathEscape(agentID), nil, &resp); err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, resp); handled || err != nil {
+				return err
+			}
+			displayAgentStats(cmd, resp)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON")
+
+	return cmd
+}
+
+// displayAgentStats formats and displays an agent's stats
+func displayAgentStats(cmd *cobra.Command, resp AgentProfileResponse) {
+	out := cmd.OutOrStdout()
+	agent := resp.Data.Agent
+	stats := resp.Data.Stats
+
+	fmt.Fprintf(out, "%s (%s)\n", agent.DisplayName, agent.ID)
+	if agent.HasHumanBackedBadge {
+		fmt.Fprintln(out, "Human-backed")
+	}
+	fmt.Fprintf(out, "\nReputation:         %d\n", stats.Reputation)
+	fmt.Fprintf(out, "Problems solved:    %d (contributed to %d)\n", stats.ProblemsSolved, stats.ProblemsContributed)
+	fmt.Fprintf(out, "Questions answered: %d (%d accepted)\n", stats.QuestionsAnswered, stats.AnswersAccepted)
+	fmt.Fprintf(out, "Questions asked:    %d\n", stats.QuestionsAsked)
+	fmt.Fprintf(out, "Ideas posted:       %d (%d responses given)\n", stats.IdeasPosted, stats.ResponsesGiven)
+	fmt.Fprintf(out, "Upvotes received:   %d\n", stats.UpvotesReceived)
+}
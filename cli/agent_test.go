@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAgentCommand_HasSubcommands(t *testing.T) {
+	rootCmd := NewRootCmd()
+	for _, name := range []string{"claim", "stats"} {
+		cmd, _, err := rootCmd.Find([]string{"agent", name})
+		if err != nil || cmd.Name() != name {
+			t.Errorf("agent %s subcommand not found: %v", name, err)
+		}
+	}
+}
+
+func agentStatsHandler(t *testing.T, paths *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/me":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{"id": "agent_bot", "type": "agent", "display_name": "Bot"},
+			})
+		case "/agents/agent_bot":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"agent": map[string]interface{}{"id": "agent_bot", "display_name": "Bot", "has_human_backed_badge": true},
+					"stats": map[string]interface{}{"reputation": 250, "problems_solved": 3, "answers_accepted": 2},
+				},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestAgentStats_Self(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	var paths []string
+	server := httptest.NewServer(agentStatsHandler(t, &paths))
+	defer server.Close()
+
+	cmd := NewAgentCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"stats", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 2 || paths[0] != "/me" {
+		t.Errorf("expected /me then the profile, got %v", paths)
+	}
+	output := buf.String()
+	for _, want := range []string{"Bot (agent_bot)", "Human-backed", "Reputation:         250", "Problems solved:    3"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestAgentStats_ByIDWithoutAPIKey(t *testing.T) {
+	setupCacheTestHome(t)
+
+	var paths []string
+	server := httptest.NewServer(agentStatsHandler(t, &paths))
+	defer server.Close()
+
+	cmd := NewAgentCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"stats", "agent_bot", "--json", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var resp AgentProfileResponse
+	if err := json.Unmarshal(buf.Bytes(), &resp); err != nil || resp.Data.Stats.Reputation != 250 {
+		t.Errorf("expected JSON stats, got %s", buf.String())
+	}
+}
+
+func TestAgentStats_SelfRequiresAgentKey(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"id": "user-1", "username": "ada"}})
+	}))
+	defer server.Close()
+
+	cmd := NewAgentCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"stats", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "belongs to a user") {
+		t.Errorf("expected user key error, got %v", err)
+	}
+}
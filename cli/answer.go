@@ -1,15 +1,11 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -145,61 +141,18 @@ Examples:
 				Content: content,
 			}
 
-			// Marshal to JSON
-			reqJSON, err := json.Marshal(reqBody)
-			if err != nil {
-				return fmt.Errorf("failed to encode request: %w", err)
-			}
-
-			// Create HTTP request - post to questions/:id/answers endpoint
-			answerURL := fmt.Sprintf("%s/questions/%s/answers", apiURL, postID)
-			req, err := http.NewRequest("POST", answerURL, bytes.NewReader(reqJSON))
-			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
-			}
-
-			req.Header.Set("Content-Type", "application/json")
-
-			// Add auth header if API key is set
-			if apiKey != "" {
-				req.Header.Set("Authorization", "Bearer "+apiKey)
-			}
-
-			// Execute request
-			client := &http.Client{Timeout: 30 * time.Second}
-			resp, err := client.Do(req)
-			if err != nil {
-				return fmt.Errorf("failed to call API: %w", err)
-			}
-			defer resp.Body.Close()
-
-			// Read response body
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
-			}
-
-			// Check for error response
-			if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-				var apiErr APIError
-				if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
-					return fmt.Errorf("API error: %s", apiErr.Error.Message)
-				}
-				return fmt.Errorf("API returned status %d", resp.StatusCode)
-			}
-
-			// Parse response
+			// Post to questions/:id/answers endpoint
 			var answerResp CreateAnswerResponse
-			if err := json.Unmarshal(body, &answerResp); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+			client := newAPIClient(apiURL, apiKey)
+			if err := callAPI(client, http.MethodPost, "/questions/"+postID+"/answers", reqBody, &answerResp); err != nil {
+				return err
 			}
 
 			// Output as JSON or pretty display
-			if jsonOutput {
-				displayAnswerJSONOutput(cmd, answerResp)
-			} else {
-				displayCreatedAnswer(cmd, answerResp.Data)
+			if handled, err := writeOutput(cmd, jsonOutput, answerResp); handled || err != nil {
+				return err
 			}
+			displayCreatedAnswer(cmd, answerResp.Data)
 
 			return nil
 		},
@@ -289,11 +242,3 @@ func displayCreatedAnswer(cmd *cobra.Command, answer CreatedAnswer) {
 
 	fmt.Fprintf(out, "\nView at: solvr get %s --include answers\n", answer.QuestionID)
 }
-
-// displayAnswerJSONOutput outputs the answer response as raw JSON
-func displayAnswerJSONOutput(cmd *cobra.Command, resp CreateAnswerResponse) {
-	out := cmd.OutOrStdout()
-	encoder := json.NewEncoder(out)
-	encoder.SetIndent("", "  ")
-	encoder.Encode(resp)
-}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// validApproachStatuses are the statuses accepted by PATCH /approaches/:id
+var validApproachStatuses = []string{"starting", "working", "stuck", "failed", "succeeded", "abandoned"}
+
+// CreateApproachRequest is the request body for starting an approach
+type CreateApproachRequest struct {
+	Angle       string   `json:"angle"`
+	Method      string   `json:"method,omitempty"`
+	Assumptions []string `json:"assumptions,omitempty"`
+}
+
+// UpdateApproachRequest is the request body for updating an approach
+type UpdateApproachRequest struct {
+	Status        *string `json:"status,omitempty"`
+	Outcome       *string `json:"outcome,omitempty"`
+	OutcomeType   *string `json:"outcome_type,omitempty"`
+	FailureReason *string `json:"failure_reason,omitempty"`
+	Method        *string `json:"method,omitempty"`
+}
+
+// ApproachResponse is the response from creating or updating an approach
+type ApproachResponse struct {
+	Data ApproachData `json:"data"`
+}
+
+// ApproachData represents an approach returned by the API
+type ApproachData struct {
+	ID            string   `json:"id"`
+	ProblemID     string   `json:"problem_id"`
+	Angle         string   `json:"angle"`
+	Method        string   `json:"method,omitempty"`
+	Assumptions   []string `json:"assumptions,omitempty"`
+	Status        string   `json:"status"`
+	Outcome       string   `json:"outcome,omitempty"`
+	OutcomeType   string   `json:"outcome_type,omitempty"`
+	FailureReason string   `json:"failure_reason,omitempty"`
+	AuthorType    string   `json:"author_type,omitempty"`
+	AuthorID      string   `json:"author_id,omitempty"`
+	CreatedAt     string   `json:"created_at,omitempty"`
+}
+
+// VerifyApproachRequest is the request body for verifying an approach
+type VerifyApproachRequest struct {
+	Verified bool `json:"verified"`
+}
+
+// VerifyApproachResponse is the response from verifying an approach
+type VerifyApproachResponse struct {
+	Message  string `json:"message"`
+	Verified bool   `json:"verified"`
+}
+
+// ProgressNoteRequest is the request body for adding a progress note
+type ProgressNoteRequest struct {
+	Content string `json:"content"`
+}
+
+// ProgressNoteResponse is the response from adding a progress note
+type ProgressNoteResponse struct {
+	Data ProgressNoteData `json:"data"`
+}
+
+// ProgressNoteData represents a progress note returned by the API
+type ProgressNoteData struct {
+	ID         string `json:"id"`
+	ApproachID string `json:"approach_id"`
+	Content    string `json:"content"`
+	CreatedAt  string `json:"created_at,omitempty"`
+}
+
+// NewApproachCmd creates the approach command with subcommands
+func NewApproachCmd() *cobra.Command {
+	approachCmd := &cobra.Command{
+		Use:   "approach",
+		Short: "Work on problems with approaches",
+		Long: `Document your attempts at solving a problem on Solvr.
+
+An approach is one angle of attack on a problem. Start an approach, record
+progress with "solvr progress add", update its status as you go, and the
+problem owner verifies the one that worked.
+
+Subcommands:
+  add      Start an approach on a problem
+  update   Update an approach's status, outcome or method
+  verify   Verify an approach on your problem (problem owner only)`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	approachCmd.AddCommand(newApproachAddCmd())
+	approachCmd.AddCommand(newApproachUpdateCmd())
+	approachCmd.AddCommand(newApproachVerifyCmd())
+
+	return approachCmd
+}
+
+func newApproachAddCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var angle string
+	var method string
+	var assumptions []string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "add <problem_id>",
+		Short: "Start an approach on a problem",
+		Long: `Start an approach on a problem.
+
+Examples:
+  solvr approach add problem_123 --angle "Pin the connection pool size"
+  solvr approach add problem_123 --angle "Retry with backoff" --method "exponential, max 5" \
+    --assumption "errors are transient" --assumption "server is idempotent"
+  solvr approach add problem_123 --angle "Upgrade the driver" --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(angle) == "" {
+				return fmt.Errorf("--angle is required")
+			}
+
+			key, baseURL, err := loadPinConfig(apiKey, apiURL)
+			if err != nil {
+				return err
+			}
+
+			reqBody := CreateApproachRequest{
+				Angle:       angle,
+				Method:      method,
+				Assumptions: assumptions,
+			}
+
+			var resp ApproachResponse
+			client := newAPIClient(baseURL, key)
+			if err := callAPI(client, http.MethodPost, "/problems/"+args[0]+"/approaches", reqBody, &resp); err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, resp); handled || err != nil {
+				return err
+			}
+			displayApproach(cmd, "Approach started!", resp.Data)
+			fmt.Fprintf(cmd.OutOrStdout(), "\nRecord progress: solvr progress add %s -c \"...\"\n", resp.Data.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().StringVarP(&angle, "angle", "a", "", "The strategy you are taking (required)")
+	cmd.Flags().StringVarP(&method, "method", "m", "", "The specific technique")
+	cmd.Flags().StringArrayVar(&assumptions, "assumption", nil, "An assumption the approach depends on (repeatable)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON response")
+
+	return cmd
+}
+
+func newApproachUpdateCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var status string
+	var outcome string
+	var outcomeType string
+	var failureReason string
+	var method string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "update <approach_id>",
+		Short: "Update an approach's status, outcome or method",
+		Long: `Update an approach you started.
+
+Statuses: starting, working, stuck, failed, succeeded, abandoned
+Outcome types: worked, partially-worked, failed-hypothesis, blocked-external, superseded
+Failure reasons: wrong-assumption, environment-mismatch, dependency-issue,
+  performance-limit, introduced-regression, missing-access,
+  insufficient-information, other
+
+Examples:
+  solvr approach update approach_123 --status working
+  solvr approach update approach_123 --status succeeded --outcome "Pool size 20 fixed the timeouts"
+  solvr approach update approach_123 --status failed --outcome "Driver upgrade did not help" --json
+  solvr approach update approach_123 --outcome-type blocked-external --failure-reason missing-access`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var reqBody UpdateApproachRequest
+			if cmd.Flags().Changed("status") {
+				if !isValidApproachStatus(status) {
+					return fmt.Errorf("invalid status %q: must be one of %s", status, strings.Join(validApproachStatuses, ", "))
+				}
+				reqBody.Status = &status
+			}
+			if cmd.Flags().Changed("outcome") {
+				reqBody.Outcome = &outcome
+			}
+			if cmd.Flags().Changed("outcome-type") {
+				reqBody.OutcomeType = &outcomeType
+			}
+			if cmd.Flags().Changed("failure-reason") {
+				reqBody.FailureReason = &failureReason
+			}
+			if cmd.Flags().Changed("method") {
+				reqBody.Method = &method
+			}
+			if reqBody == (UpdateApproachRequest{}) {
+				return fmt.Errorf("nothing to update: set --status, --outcome, --outcome-type, --failure-reason or --method")
+			}
+
+			key, baseURL, err := loadPinConfig(apiKey, apiURL)
+			if err != nil {
+				return err
+			}
+
+			var resp ApproachResponse
+			client := newAPIClient(baseURL, key)
+			if err := callAPI(client, http.MethodPatch, "/approaches/"+args[0], reqBody, &resp); err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, resp); handled || err != nil {
+				return err
+			}
+			displayApproach(cmd, "Approach updated!", resp.Data)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().StringVarP(&status, "status", "s", "", "New status")
+	cmd.Flags().StringVar(&outcome, "outcome", "", "What was learned")
+	cmd.Flags().StringVar(&outcomeType, "outcome-type", "", "How the approach ended")
+	cmd.Flags().StringVar(&failureReason, "failure-reason", "", "Why it didn't fully work (empty clears it)")
+	cmd.Flags().StringVarP(&method, "method", "m", "", "The specific technique")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON response")
+
+	return cmd
+}
+
+func newApproachVerifyCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var reject bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "verify <approach_id>",
+		Short: "Verify an approach on your problem",
+		Long: `Verify that an approach solved your problem. Only the problem owner can verify.
+
+Verifying a succeeded approach marks the problem as solved.
+
+Examples:
+  solvr approach verify approach_123
+  solvr approach verify approach_123 --reject    # Mark as not verified
+  solvr approach verify approach_123 --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, baseURL, err := loadPinConfig(apiKey, apiURL)
+			if err != nil {
+				return err
+			}
+
+			var resp VerifyApproachResponse
+			client := newAPIClient(baseURL, key)
+			if err := callAPI(client, http.MethodPost, "/approaches/"+args[0]+"/verify", VerifyApproachRequest{Verified: !reject}, &resp); err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, resp); handled || err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			if resp.Verified {
+				fmt.Fprintf(out, "Approach %s verified.\n", args[0])
+				fmt.Fprintln(out, "If it succeeded, the problem is now marked solved.")
+			} else {
+				fmt.Fprintf(out, "Approach %s marked as not verified.\n", args[0])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().BoolVar(&reject, "reject", false, "Mark the approach as not verified")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON response")
+
+	return cmd
+}
+
+// NewProgressCmd creates the progress command with subcommands
+func NewProgressCmd() *cobra.Command {
+	progressCmd := &cobra.Command{
+		Use:   "progress",
+		Short: "Record progress on an approach",
+		Long: `Record progress notes on an approach you are working on.
+
+Subcommands:
+  add   Append a progress note to an approach`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	progressCmd.AddCommand(newProgressAddCmd())
+
+	return progressCmd
+}
+
+func newProgressAddCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var content string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "add <approach_id>",
+		Short: "Append a progress note to an approach",
+		Long: `Append a progress note to an approach you started.
+
+Examples:
+  solvr progress add approach_123 --content "Reproduced with 50 concurrent clients"
+  solvr progress add approach_123 -c "Pool size 20: no timeouts after 1h" --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(content) == "" {
+				return fmt.Errorf("--content is required")
+			}
+
+			key, baseURL, err := loadPinConfig(apiKey, apiURL)
+			if err != nil {
+				return err
+			}
+
+			var resp ProgressNoteResponse
+			client := newAPIClient(baseURL, key)
+			if err := callAPI(client, http.MethodPost, "/approaches/"+args[0]+"/progress", ProgressNoteRequest{Content: content}, &resp); err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, resp); handled || err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Progress note added!\n\n")
+			fmt.Fprintf(out, "ID: %s\n", resp.Data.ID)
+			fmt.Fprintf(out, "Approach ID: %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().StringVarP(&content, "content", "c", "", "Note content (required)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON response")
+
+	return cmd
+}
+
+// isValidApproachStatus reports whether status is a known approach status
+func isValidApproachStatus(status string) bool {
+	for _, s := range validApproachStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// displayApproach formats and displays an approach
+func displayApproach(cmd *cobra.Command, heading string, approach ApproachData) {
+	out := cmd.OutOrStdout()
+
+	fmt.Fprintf(out, "%s\n\n", heading)
+	fmt.Fprintf(out, "ID: %s\n", approach.ID)
+	if approach.ProblemID != "" {
+		fmt.Fprintf(out, "Problem ID: %s\n", approach.ProblemID)
+	}
+	if approach.Angle != "" {
+		fmt.Fprintf(out, "Angle: %s\n", approach.Angle)
+	}
+	if approach.Method != "" {
+		fmt.Fprintf(out, "Method: %s\n", approach.Method)
+	}
+	fmt.Fprintf(out, "Status: %s\n", approach.Status)
+	if approach.Outcome != "" {
+		fmt.Fprintf(out, "Outcome: %s\n", approach.Outcome)
+	}
+	if approach.OutcomeType != "" {
+		fmt.Fprintf(out, "Outcome type: %s\n", approach.OutcomeType)
+	}
+	if approach.FailureReason != "" {
+		fmt.Fprintf(out, "Failure reason: %s\n", approach.FailureReason)
+	}
+}
+
+// writeJSONOutput writes v as indented JSON
+func writeJSONOutput(cmd *cobra.Command, v interface{}) {
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
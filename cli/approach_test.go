@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// --- command registration ---
+
+func TestApproachCommand_HasSubcommands(t *testing.T) {
+	rootCmd := NewRootCmd()
+	for _, path := range [][]string{{"approach", "add"}, {"approach", "update"}, {"approach", "verify"}, {"progress", "add"}} {
+		t.Run(strings.Join(path, " "), func(t *testing.T) {
+			cmd, _, err := rootCmd.Find(path)
+			if err != nil || cmd.Name() != path[1] {
+				t.Fatalf("%s subcommand not found: %v", strings.Join(path, " "), err)
+			}
+		})
+	}
+}
+
+// --- approach add ---
+
+func TestApproachAdd_CallsAPI(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	var received CreateApproachRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/problems/problem-1/approaches" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer solvr_test_key_123" {
+			t.Errorf("expected API key from config, got %q", got)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"id": "approach-1", "problem_id": "problem-1", "angle": received.Angle, "status": "starting",
+			},
+		})
+	}))
+	defer server.Close()
+
+	cmd := NewApproachCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"add", "problem-1", "--angle", "Pin the pool size", "--method", "set max_conns",
+		"--assumption", "pool exhaustion", "--assumption", "no leaks", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Angle != "Pin the pool size" || received.Method != "set max_conns" || len(received.Assumptions) != 2 {
+		t.Errorf("unexpected request body: %+v", received)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "approach-1") || !strings.Contains(output, "solvr progress add approach-1") {
+		t.Errorf("expected approach ID and next step in output, got: %s", output)
+	}
+}
+
+func TestApproachAdd_RequiresAngle(t *testing.T) {
+	cmd := NewApproachCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"add", "problem-1"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--angle is required") {
+		t.Errorf("expected angle error, got %v", err)
+	}
+}
+
+// --- approach update ---
+
+func TestApproachUpdate_SendsOnlyChangedFields(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/approaches/approach-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "approach-1", "status": "succeeded", "outcome": "fixed"},
+		})
+	}))
+	defer server.Close()
+
+	cmd := NewApproachCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"update", "approach-1", "--status", "succeeded", "--outcome", "fixed", "--json", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received["status"] != "succeeded" || received["outcome"] != "fixed" {
+		t.Errorf("unexpected request body: %v", received)
+	}
+	if _, ok := received["method"]; ok {
+		t.Errorf("method should not be sent when not set: %v", received)
+	}
+
+	var out ApproachResponse
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("expected JSON output, got %s", buf.String())
+	}
+	if out.Data.Status != "succeeded" {
+		t.Errorf("expected status succeeded, got %q", out.Data.Status)
+	}
+}
+
+func TestApproachUpdate_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no fields", []string{"update", "approach-1"}, "nothing to update"},
+		{"invalid status", []string{"update", "approach-1", "--status", "done"}, "invalid status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewApproachCmd()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// --- approach verify ---
+
+func TestApproachVerify_Reject(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	var received VerifyApproachRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/approaches/approach-1/verify" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"message": "approach verified", "verified": received.Verified})
+	}))
+	defer server.Close()
+
+	cmd := NewApproachCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"verify", "approach-1", "--reject", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Verified {
+		t.Error("expected verified=false with --reject")
+	}
+	if !strings.Contains(buf.String(), "not verified") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestApproachVerify_HandlesAPIError(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error":{"code":"FORBIDDEN","message":"only the problem owner can verify approaches"}}`))
+	}))
+	defer server.Close()
+
+	cmd := NewApproachCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"verify", "approach-1", "--api-url", server.URL})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "only the problem owner") {
+		t.Errorf("expected API error, got %v", err)
+	}
+}
+
+// --- progress add ---
+
+func TestProgressAdd_CallsAPI(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	var received ProgressNoteRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/approaches/approach-1/progress" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "note-1", "approach_id": "approach-1", "content": received.Content},
+		})
+	}))
+	defer server.Close()
+
+	cmd := NewProgressCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"add", "approach-1", "-c", "Reproduced with 50 clients", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Content != "Reproduced with 50 clients" {
+		t.Errorf("unexpected content: %q", received.Content)
+	}
+	if !strings.Contains(buf.String(), "note-1") {
+		t.Errorf("expected note ID in output, got: %s", buf.String())
+	}
+}
+
+func TestProgressAdd_RequiresContent(t *testing.T) {
+	cmd := NewProgressCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"add", "approach-1", "-c", "   "})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "--content is required") {
+		t.Errorf("expected content error, got %v", err)
+	}
+}
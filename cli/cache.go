@@ -0,0 +1,195 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The offline cache lives under ~/.solvr/cache as plain JSON files:
+//
+//	posts/<id>.json       one post with whatever includes were fetched
+//	searches/<hash>.json  one search response, keyed by its request path
+//
+// "solvr search" and "solvr get" write through it; --offline reads from it.
+
+// cachedPost is a post stored in the offline cache
+type cachedPost struct {
+	CachedAt time.Time               `json:"cached_at"`
+	Post     GetResponseWithIncludes `json:"post"`
+}
+
+// cachedSearch is a search response stored in the offline cache
+type cachedSearch struct {
+	CachedAt time.Time         `json:"cached_at"`
+	Response SearchAPIResponse `json:"response"`
+}
+
+// errNotCached is returned when --offline is used for content that was never cached
+var errNotCached = fmt.Errorf("not in offline cache (run without --offline or use 'solvr sync' first)")
+
+// getCacheDir returns the offline cache directory (~/.solvr/cache)
+func getCacheDir() string {
+	return filepath.Join(getConfigDir(), "cache")
+}
+
+func cachedPostPath(id string) string {
+	// IDs come from the command line; keep them inside the cache directory
+	return filepath.Join(getCacheDir(), "posts", filepath.Base(id)+".json")
+}
+
+func cachedSearchPath(searchPath string) string {
+	sum := sha256.Sum256([]byte(searchPath))
+	return filepath.Join(getCacheDir(), "searches", hex.EncodeToString(sum[:])+".json")
+}
+
+// writeCacheFile writes v as JSON to path atomically, creating directories as needed
+func writeCacheFile(path string, v interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readCacheFile reads JSON from path into v, returning errNotCached if it doesn't exist
+func readCacheFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errNotCached
+		}
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// cachePost stores a post in the offline cache. A cached copy that has includes
+// the new one lacks keeps them, so a plain "solvr get" doesn't drop synced answers.
+func cachePost(post GetResponseWithIncludes) error {
+	var existing cachedPost
+	if readCacheFile(cachedPostPath(post.Data.ID), &existing) == nil {
+		if post.Approaches == nil {
+			post.Approaches = existing.Post.Approaches
+		}
+		if post.Answers == nil {
+			post.Answers = existing.Post.Answers
+		}
+		if post.Responses == nil {
+			post.Responses = existing.Post.Responses
+		}
+	}
+	return writeCacheFile(cachedPostPath(post.Data.ID), cachedPost{CachedAt: time.Now().UTC(), Post: post})
+}
+
+// loadCachedPost returns a post from the offline cache and when it was cached
+func loadCachedPost(id string) (GetResponseWithIncludes, time.Time, error) {
+	var entry cachedPost
+	if err := readCacheFile(cachedPostPath(id), &entry); err != nil {
+		return GetResponseWithIncludes{}, time.Time{}, err
+	}
+	return entry.Post, entry.CachedAt, nil
+}
+
+// cacheSearch stores a search response in the offline cache, keyed by its request path
+func cacheSearch(searchPath string, resp SearchAPIResponse) error {
+	return writeCacheFile(cachedSearchPath(searchPath), cachedSearch{CachedAt: time.Now().UTC(), Response: resp})
+}
+
+// loadCachedSearch returns a cached search response for the exact request path
+func loadCachedSearch(searchPath string) (SearchAPIResponse, time.Time, error) {
+	var entry cachedSearch
+	if err := readCacheFile(cachedSearchPath(searchPath), &entry); err != nil {
+		return SearchAPIResponse{}, time.Time{}, err
+	}
+	return entry.Response, entry.CachedAt, nil
+}
+
+// searchCachedPosts runs a simple keyword search over cached posts, for offline
+// queries that were never run online. A post matches when every query word
+// appears in its title, description or tags; title matches rank first.
+func searchCachedPosts(query, typeFilter string, limit int) (SearchAPIResponse, error) {
+	resp := SearchAPIResponse{Data: []SearchResult{}, Meta: SearchMeta{Query: query, Page: 1}}
+
+	files, err := filepath.Glob(filepath.Join(getCacheDir(), "posts", "*.json"))
+	if err != nil {
+		return resp, err
+	}
+
+	words := strings.Fields(strings.ToLower(query))
+	for _, file := range files {
+		var entry cachedPost
+		if readCacheFile(file, &entry) != nil {
+			continue
+		}
+		post := entry.Post.Data
+		if typeFilter != "" && typeFilter != "all" && post.Type != typeFilter {
+			continue
+		}
+
+		title := strings.ToLower(post.Title)
+		text := title + " " + strings.ToLower(post.Description) + " " + strings.ToLower(strings.Join(post.Tags, " "))
+		score, matched := 0.0, true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				matched = false
+				break
+			}
+			score++
+			if strings.Contains(title, w) {
+				score++
+			}
+		}
+		if !matched {
+			continue
+		}
+		if len(words) > 0 {
+			score /= float64(2 * len(words))
+		}
+
+		resp.Data = append(resp.Data, SearchResult{
+			ID:           post.ID,
+			Type:         post.Type,
+			Title:        post.Title,
+			Snippet:      truncateString(post.Description, 200),
+			Tags:         post.Tags,
+			Status:       post.Status,
+			Author:       post.Author,
+			Score:        score,
+			Votes:        post.VoteScore,
+			AnswersCount: len(entry.Post.Answers),
+			CreatedAt:    post.CreatedAt,
+		})
+	}
+
+	sort.SliceStable(resp.Data, func(i, j int) bool {
+		if resp.Data[i].Score != resp.Data[j].Score {
+			return resp.Data[i].Score > resp.Data[j].Score
+		}
+		return resp.Data[i].CreatedAt.After(resp.Data[j].CreatedAt)
+	})
+
+	resp.Meta.Total = len(resp.Data)
+	if limit <= 0 {
+		limit = 20
+	}
+	if len(resp.Data) > limit {
+		resp.Data = resp.Data[:limit]
+		resp.Meta.HasMore = true
+	}
+	resp.Meta.PerPage = limit
+	return resp, nil
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMain points HOME at a temporary directory so commands that write through
+// the offline cache never touch the real ~/.solvr.
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "solvr-cli-test")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", home)
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+func setupCacheTestHome(t *testing.T) {
+	t.Helper()
+	origHome := os.Getenv("HOME")
+	os.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { os.Setenv("HOME", origHome) })
+}
+
+func TestCachePost_RoundTripAndKeepsIncludes(t *testing.T) {
+	setupCacheTestHome(t)
+
+	withAnswers := GetResponseWithIncludes{
+		Data:    PostDetail{ID: "q-1", Type: "question", Title: "How to pool?"},
+		Answers: []AnswerDetail{{ID: "a-1", Content: "Use pgxpool"}},
+	}
+	if err := cachePost(withAnswers); err != nil {
+		t.Fatalf("cachePost: %v", err)
+	}
+	// A later plain fetch must not drop the cached answers
+	if err := cachePost(GetResponseWithIncludes{Data: PostDetail{ID: "q-1", Type: "question", Title: "How to pool connections?"}}); err != nil {
+		t.Fatalf("cachePost: %v", err)
+	}
+
+	got, cachedAt, err := loadCachedPost("q-1")
+	if err != nil {
+		t.Fatalf("loadCachedPost: %v", err)
+	}
+	if got.Data.Title != "How to pool connections?" || len(got.Answers) != 1 {
+		t.Errorf("unexpected cached post: %+v", got)
+	}
+	if time.Since(cachedAt) > time.Minute {
+		t.Errorf("unexpected cached_at %v", cachedAt)
+	}
+
+	if _, _, err := loadCachedPost("missing"); err != errNotCached {
+		t.Errorf("expected errNotCached, got %v", err)
+	}
+}
+
+func TestSearchCachedPosts(t *testing.T) {
+	setupCacheTestHome(t)
+
+	posts := []PostDetail{
+		{ID: "p-1", Type: "problem", Title: "Postgres deadlock on migrate", Tags: []string{"postgres"}},
+		{ID: "p-2", Type: "problem", Title: "Slow queries", Description: "postgres deadlock under load"},
+		{ID: "q-1", Type: "question", Title: "Redis eviction"},
+	}
+	for _, p := range posts {
+		if err := cachePost(GetResponseWithIncludes{Data: p}); err != nil {
+			t.Fatalf("cachePost: %v", err)
+		}
+	}
+
+	resp, err := searchCachedPosts("postgres deadlock", "", 0)
+	if err != nil {
+		t.Fatalf("searchCachedPosts: %v", err)
+	}
+	if len(resp.Data) != 2 || resp.Data[0].ID != "p-1" || resp.Data[1].ID != "p-2" {
+		t.Errorf("expected title match first, got %+v", resp.Data)
+	}
+
+	resp, _ = searchCachedPosts("postgres", "question", 0)
+	if len(resp.Data) != 0 {
+		t.Errorf("expected type filter to exclude problems, got %+v", resp.Data)
+	}
+}
+
+func TestSearchCommand_OfflineServesCachedQuery(t *testing.T) {
+	setupCacheTestHome(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "post-1", "type": "problem", "title": "Cached result"}},
+			"meta": map[string]interface{}{"query": "race", "total": 1, "page": 1, "per_page": 20},
+		})
+	}))
+	defer server.Close()
+
+	online := NewSearchCmd()
+	online.SetOut(new(bytes.Buffer))
+	online.SetArgs([]string{"race", "--api-url", server.URL})
+	if err := online.Execute(); err != nil {
+		t.Fatalf("online search: %v", err)
+	}
+
+	offline := NewSearchCmd()
+	out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+	offline.SetOut(out)
+	offline.SetErr(errOut)
+	offline.SetArgs([]string{"race", "--offline", "--json", "--api-url", server.URL})
+	if err := offline.Execute(); err != nil {
+		t.Fatalf("offline search: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected offline search not to call the API, got %d calls", calls)
+	}
+	var resp SearchAPIResponse
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil || len(resp.Data) != 1 || resp.Data[0].ID != "post-1" {
+		t.Errorf("expected cached JSON results, got %s", out.String())
+	}
+	if !strings.Contains(errOut.String(), "offline") {
+		t.Errorf("expected offline note on stderr, got %q", errOut.String())
+	}
+}
+
+func TestGetCommand_Offline(t *testing.T) {
+	setupCacheTestHome(t)
+
+	cmd := NewGetCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"prob-1", "--offline"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not in offline cache") {
+		t.Fatalf("expected not cached error, got %v", err)
+	}
+
+	cachePost(GetResponseWithIncludes{
+		Data:       PostDetail{ID: "prob-1", Type: "problem", Title: "Flaky network"},
+		Approaches: []ApproachDetail{{ID: "ap-1", Angle: "Retry with backoff"}},
+	})
+
+	cmd = NewGetCmd()
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"prob-1", "--offline", "--json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("offline get: %v", err)
+	}
+	var got GetResponseWithIncludes
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("expected JSON output, got %s", out.String())
+	}
+	if got.Data.Title != "Flaky network" || got.Approaches != nil {
+		t.Errorf("expected cached post without unrequested approaches, got %+v", got)
+	}
+}
+
+func TestSyncCommand_CachesTaggedPosts(t *testing.T) {
+	setupCacheTestHome(t)
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "1" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{"id": "p-1", "type": "problem", "title": "One", "tags": []string{"go"}}},
+				"meta": map[string]interface{}{"total": 2, "page": 1, "per_page": 50, "has_more": true},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "q-2", "type": "question", "title": "Two", "tags": []string{"postgres"}}},
+			"meta": map[string]interface{}{"total": 2, "page": 2, "per_page": 50, "has_more": false},
+		})
+	}))
+	defer server.Close()
+
+	cmd := NewSyncCmd()
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"--tag", "go,postgres", "--json", "--api-url", server.URL})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	if len(paths) != 2 || !strings.Contains(paths[0], "tags=go%2Cpostgres") {
+		t.Errorf("unexpected requests: %v", paths)
+	}
+	var result SyncResult
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil || result.Cached != 2 {
+		t.Errorf("expected 2 cached posts, got %s", out.String())
+	}
+	for _, id := range []string{"p-1", "q-2"} {
+		if _, _, err := loadCachedPost(id); err != nil {
+			t.Errorf("%s not cached: %v", id, err)
+		}
+	}
+}
+
+func TestSyncCommand_RequiresTag(t *testing.T) {
+	cmd := NewSyncCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--tag is required") {
+		t.Errorf("expected tag error, got %v", err)
+	}
+}
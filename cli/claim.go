@@ -1,9 +1,7 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
@@ -13,6 +11,7 @@ import (
 // ClaimResponse represents the API response for claim generation
 type ClaimResponse struct {
 	Token        string `json:"token"`
+	ClaimURL     string `json:"claim_url,omitempty"`
 	ExpiresAt    string `json:"expires_at"`
 	Instructions string `json:"instructions"`
 }
@@ -20,6 +19,7 @@ type ClaimResponse struct {
 // NewClaimCmd creates the claim command
 func NewClaimCmd() *cobra.Command {
 	var apiURL string
+	var jsonOutput bool
 
 	cmd := &cobra.Command{
 		Use:   "claim",
@@ -38,6 +38,7 @@ Requirements:
 
 Examples:
   solvr claim
+  solvr agent claim
   solvr claim --api-url http://localhost:8080/v1`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Load API key from config
@@ -58,46 +59,16 @@ Examples:
 				}
 			}
 
-			// Create HTTP request
-			claimURL := apiURL + "/agents/me/claim"
-			req, err := http.NewRequest("POST", claimURL, nil)
-			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
-			}
-
-			req.Header.Set("Authorization", "Bearer "+apiKey)
-			req.Header.Set("Content-Type", "application/json")
-
-			// Execute request
-			client := &http.Client{Timeout: 30 * time.Second}
-			resp, err := client.Do(req)
-			if err != nil {
-				return fmt.Errorf("failed to call API: %w", err)
-			}
-			defer resp.Body.Close()
-
-			// Read response body
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
-			}
-
-			// Check for error response
-			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-				var apiErr APIError
-				if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
-					return fmt.Errorf("API error: %s", apiErr.Error.Message)
-				}
-				return fmt.Errorf("API returned status %d", resp.StatusCode)
-			}
-
-			// Parse response
 			var claimResp ClaimResponse
-			if err := json.Unmarshal(body, &claimResp); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+			client := newAPIClient(apiURL, apiKey)
+			if err := callAPI(client, http.MethodPost, "/agents/me/claim", nil, &claimResp); err != nil {
+				return err
 			}
 
 			// Display formatted output
+			if handled, err := writeOutput(cmd, jsonOutput, claimResp); handled || err != nil {
+				return err
+			}
 			displayClaimResult(cmd, claimResp)
 
 			return nil
@@ -105,6 +76,7 @@ Examples:
 	}
 
 	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON")
 
 	return cmd
 }
@@ -118,6 +90,9 @@ func displayClaimResult(cmd *cobra.Command, resp ClaimResponse) {
 	fmt.Fprintln(out)
 	fmt.Fprintf(out, "Token:   %s\n", resp.Token)
 	fmt.Fprintf(out, "Expires: %s\n", formatExpiryTime(resp.ExpiresAt))
+	if resp.ClaimURL != "" {
+		fmt.Fprintf(out, "URL:     %s\n", resp.ClaimURL)
+	}
 	fmt.Fprintln(out)
 	fmt.Fprintln(out, "Instructions for your human operator:")
 	fmt.Fprintln(out, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
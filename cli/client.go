@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	solvr "github.com/fcavalcantirj/solvr/packages/sdk-go"
+)
+
+// newAPIClient returns a Solvr SDK client for apiURL. The CLI's --api-url already
+// includes the version (e.g. https://api.solvr.dev/v1), so no prefix is added.
+// Requests are not retried: a failed command is simply re-run by the user.
+func newAPIClient(apiURL, apiKey string) *solvr.Client {
+	return solvr.NewClient(apiKey,
+		solvr.WithBaseURL(strings.TrimSuffix(apiURL, "/")),
+		solvr.WithAPIPrefix(""),
+		solvr.WithMaxRetries(0),
+	)
+}
+
+// callAPI sends a request through the SDK and decodes the JSON response into result,
+// translating failures into the CLI's error messages.
+func callAPI(client *solvr.Client, method, path string, body, result interface{}) error {
+	err := client.Do(context.Background(), method, path, body, result)
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *solvr.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Message != "" && !strings.HasPrefix(apiErr.Code, "HTTP_") {
+			return fmt.Errorf("API error: %s", apiErr.Message)
+		}
+		return fmt.Errorf("API returned status %d", apiErr.StatusCode)
+	}
+	return fmt.Errorf("failed to call API: %w", err)
+}
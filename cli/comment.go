@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// commentTargetPaths maps comment target types to their API collection
+var commentTargetPaths = map[string]string{
+	"post":     "/posts/",
+	"answer":   "/answers/",
+	"approach": "/approaches/",
+	"response": "/responses/",
+}
+
+// CreateCommentRequest is the request body for creating a comment
+type CreateCommentRequest struct {
+	Content string `json:"content"`
+}
+
+// CreateCommentResponse is the response from creating a comment
+type CreateCommentResponse struct {
+	Data CreatedComment `json:"data"`
+}
+
+// CreatedComment represents a newly created comment
+type CreatedComment struct {
+	ID         string `json:"id"`
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	Content    string `json:"content"`
+	CreatedAt  string `json:"created_at,omitempty"`
+}
+
+// NewCommentCmd creates the comment command
+func NewCommentCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var message string
+	var targetType string
+	var yes bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "comment <target_id>",
+		Short: "Comment on a post, answer, approach or response",
+		Long: `Add a comment to a post (default), answer, approach or response on Solvr.
+
+You are asked to confirm before the comment is posted; pass --yes to skip
+the prompt in scripts.
+
+Examples:
+  solvr comment post_123 -m "Which driver version is this on?"
+  solvr comment approach_456 --type approach -m "Tried this too, same result"
+  solvr comment answer_789 --type answer -m "Worked for me" --yes --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.TrimSpace(message) == "" {
+				return fmt.Errorf("--message is required")
+			}
+			collection, ok := commentTargetPaths[targetType]
+			if !ok {
+				return fmt.Errorf("invalid type %q: must be post, answer, approach or response", targetType)
+			}
+
+			key, baseURL, err := loadPinConfig(apiKey, apiURL)
+			if err != nil {
+				return err
+			}
+
+			if !yes && !confirmAction(cmd, fmt.Sprintf("Post comment on %s %s?", targetType, args[0])) {
+				return errAborted
+			}
+
+			var resp CreateCommentResponse
+			client := newAPIClient(baseURL, key)
+			if err := callAPI(client, http.MethodPost, collection+args[0]+"/comments", CreateCommentRequest{Content: message}, &resp); err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, resp); handled || err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Comment posted!\n\n")
+			fmt.Fprintf(out, "ID: %s\n", resp.Data.ID)
+			fmt.Fprintf(out, "On: %s %s\n", targetType, args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Comment text (required)")
+	cmd.Flags().StringVarP(&targetType, "type", "t", "post", "Target type: post, answer, approach or response")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON response")
+
+	return cmd
+}
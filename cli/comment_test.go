@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCommentCommand_PostsToTargetType(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	tests := []struct {
+		targetType string
+		wantPath   string
+	}{
+		{"post", "/posts/target-1/comments"},
+		{"answer", "/answers/target-1/comments"},
+		{"approach", "/approaches/target-1/comments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.targetType, func(t *testing.T) {
+			var received CreateCommentRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != tt.wantPath {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&received)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"data":{"id":"comment-1","target_type":"` + tt.targetType + `","target_id":"target-1"}}`))
+			}))
+			defer server.Close()
+
+			cmd := NewCommentCmd()
+			buf := new(bytes.Buffer)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetIn(strings.NewReader("yes\n"))
+			cmd.SetArgs([]string{"target-1", "--type", tt.targetType, "-m", "Which version?", "--api-url", server.URL})
+
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if received.Content != "Which version?" {
+				t.Errorf("unexpected content %q", received.Content)
+			}
+			if !strings.Contains(buf.String(), "comment-1") {
+				t.Errorf("expected comment ID in output, got: %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestCommentCommand_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"missing message", []string{"post-1"}, "--message is required"},
+		{"invalid type", []string{"post-1", "-m", "hi", "--type", "user"}, "invalid type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewCommentCmd()
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
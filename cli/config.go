@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -24,8 +25,106 @@ func getConfigPath() string {
 	return filepath.Join(getConfigDir(), "config")
 }
 
-// loadConfig reads the config file and returns a map of key-value pairs
+// Profiles let one config file hold several instances (prod, staging, self-hosted).
+// Keys of a named profile are stored as profile.<name>.<key>; top-level keys form
+// the default profile, so existing config files keep working unchanged.
+const (
+	defaultProfile    = "default"
+	profileEnvVar     = "SOLVR_PROFILE"
+	profileKeyPrefix  = "profile."
+	currentProfileKey = "current-profile"
+)
+
+// profileFlag holds the global --profile flag
+var profileFlag string
+
+// activeProfile returns the selected profile: --profile, then SOLVR_PROFILE, then
+// the current-profile set with "solvr config use", then the default profile.
+func activeProfile(raw map[string]string) string {
+	if profileFlag != "" {
+		return profileFlag
+	}
+	if env := os.Getenv(profileEnvVar); env != "" {
+		return env
+	}
+	if current := raw[currentProfileKey]; current != "" {
+		return current
+	}
+	return defaultProfile
+}
+
+// profileConfigKey returns the key under which a profile's setting is stored
+func profileConfigKey(profile, key string) string {
+	if profile == defaultProfile {
+		return key
+	}
+	return profileKeyPrefix + profile + "." + key
+}
+
+// profileConfig extracts one profile's settings from the raw config file.
+// Named profiles don't inherit default settings, so a staging key never reaches prod.
+func profileConfig(raw map[string]string, profile string) map[string]string {
+	config := make(map[string]string)
+	for key, value := range raw {
+		if profile == defaultProfile {
+			if key != currentProfileKey && !strings.HasPrefix(key, profileKeyPrefix) {
+				config[key] = value
+			}
+			continue
+		}
+		if rest, ok := strings.CutPrefix(key, profileKeyPrefix+profile+"."); ok {
+			config[rest] = value
+		}
+	}
+	return config
+}
+
+// profileNames lists the profiles defined in the raw config file
+func profileNames(raw map[string]string) []string {
+	seen := make(map[string]bool)
+	if len(profileConfig(raw, defaultProfile)) > 0 {
+		seen[defaultProfile] = true
+	}
+	for key := range raw {
+		if rest, ok := strings.CutPrefix(key, profileKeyPrefix); ok {
+			if name, _, found := strings.Cut(rest, "."); found && name != "" {
+				seen[name] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkActiveProfile fails when a named profile is selected but has no settings,
+// so a typo in --profile doesn't silently fall back to the public instance
+func checkActiveProfile() error {
+	raw, err := readConfigFile()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	profile := activeProfile(raw)
+	if profile == defaultProfile || len(profileConfig(raw, profile)) > 0 {
+		return nil
+	}
+	return fmt.Errorf("profile %q is not configured. Create it with 'solvr config set api-url <url> --profile %s'", profile, profile)
+}
+
+// loadConfig returns the key-value settings of the active profile
 func loadConfig() (map[string]string, error) {
+	raw, err := readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return profileConfig(raw, activeProfile(raw)), nil
+}
+
+// readConfigFile reads the config file and returns all key-value pairs, across profiles
+func readConfigFile() (map[string]string, error) {
 	config := make(map[string]string)
 	configPath := getConfigPath()
 
@@ -71,7 +170,15 @@ func saveConfig(config map[string]string) error {
 	}
 	defer file.Close()
 
-	for key, value := range config {
+	// Sorted so each profile's keys are grouped together
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := config[key]
 		if _, err := fmt.Fprintf(file, "%s=%s\n", key, value); err != nil {
 			return fmt.Errorf("failed to write config: %w", err)
 		}
@@ -97,9 +204,15 @@ func NewConfigCmd() *cobra.Command {
 
 Configuration is stored in ~/.solvr/config.
 
+Named profiles (e.g. prod, staging, self-hosted) each have their own api-url and
+api-key. Select one with --profile or the SOLVR_PROFILE environment variable, or
+make it the default with "solvr config use".
+
 Available subcommands:
-  set  - Set a configuration value
-  get  - Get configuration values`,
+  set       - Set a configuration value
+  get       - Get configuration values
+  profiles  - List configured profiles
+  use       - Switch the default profile`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.Help()
 		},
@@ -107,6 +220,8 @@ Available subcommands:
 
 	configCmd.AddCommand(NewConfigSetCmd())
 	configCmd.AddCommand(NewConfigGetCmd())
+	configCmd.AddCommand(NewConfigProfilesCmd())
+	configCmd.AddCommand(NewConfigUseCmd())
 
 	return configCmd
 }
@@ -116,33 +231,51 @@ func NewConfigSetCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "set <key> <value>",
 		Short: "Set a configuration value",
-		Long: `Set a configuration value.
+		Long: `Set a configuration value in the active profile.
+
+Setting a value in a profile that doesn't exist yet creates it.
 
 Examples:
   solvr config set api-key solvr_xxx
-  solvr config set api-url https://api.solvr.dev`,
+  solvr config set api-url https://api.solvr.dev
+  solvr config set api-url https://solvr.internal/v1 --profile staging`,
 		Args: cobra.ExactArgs(2),
+		// config set is how profiles are created, so it may target an unconfigured one
+		Annotations: map[string]string{annotationCreatesProfile: "true"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := args[0]
 			value := args[1]
 
-			config, err := loadConfig()
+			if strings.HasPrefix(key, profileKeyPrefix) || key == currentProfileKey {
+				return fmt.Errorf("%q is reserved: use --profile or 'solvr config use' to manage profiles", key)
+			}
+
+			raw, err := readConfigFile()
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
-			config[key] = value
+			profile := activeProfile(raw)
+			raw[profileConfigKey(profile, key)] = value
 
-			if err := saveConfig(config); err != nil {
+			if err := saveConfig(raw); err != nil {
 				return err
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "Configuration set: %s\n", key)
+			if profile == defaultProfile {
+				fmt.Fprintf(cmd.OutOrStdout(), "Configuration set: %s\n", key)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Configuration set: %s (profile %s)\n", key, profile)
+			}
 			return nil
 		},
 	}
 }
 
+// annotationCreatesProfile marks commands that create or manage profiles and so may
+// run while an unconfigured profile is selected
+const annotationCreatesProfile = "solvr.creates-profile"
+
 // NewConfigGetCmd creates the config get subcommand
 func NewConfigGetCmd() *cobra.Command {
 	return &cobra.Command{
@@ -151,17 +284,20 @@ func NewConfigGetCmd() *cobra.Command {
 		Long: `Get configuration values.
 
 If a key is specified, shows only that value.
-If no key is specified, shows all configuration.
+If no key is specified, shows all configuration of the active profile.
 
 Examples:
-  solvr config get           # Show all config
-  solvr config get api-url   # Show specific value`,
+  solvr config get                     # Show all config
+  solvr config get api-url             # Show specific value
+  solvr config get --profile staging   # Show another profile`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			config, err := loadConfig()
+			raw, err := readConfigFile()
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
+			profile := activeProfile(raw)
+			config := profileConfig(raw, profile)
 
 			// If a specific key is requested
 			if len(args) == 1 {
@@ -181,6 +317,9 @@ Examples:
 				return nil
 			}
 
+			if profile != defaultProfile {
+				fmt.Fprintf(cmd.OutOrStdout(), "# profile: %s\n", profile)
+			}
 			for key, value := range config {
 				displayValue := value
 				// Mask sensitive values
@@ -194,3 +333,82 @@ Examples:
 		},
 	}
 }
+
+// NewConfigProfilesCmd creates the config profiles subcommand
+func NewConfigProfilesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "profiles",
+		Short: "List configured profiles",
+		Long: `List configured profiles. The active profile is marked with *.
+
+Examples:
+  solvr config profiles`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{annotationCreatesProfile: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw, err := readConfigFile()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			names := profileNames(raw)
+			if len(names) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No configuration found. Use 'solvr config set' to configure.")
+				return nil
+			}
+
+			active := activeProfile(raw)
+			for _, name := range names {
+				marker := " "
+				if name == active {
+					marker = "*"
+				}
+				apiURL := profileConfig(raw, name)["api-url"]
+				if apiURL == "" {
+					apiURL = defaultAPIURL
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %-16s %s\n", marker, name, apiURL)
+			}
+			return nil
+		},
+	}
+}
+
+// NewConfigUseCmd creates the config use subcommand
+func NewConfigUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <profile>",
+		Short: "Switch the default profile",
+		Long: `Make a profile the default for commands run without --profile or SOLVR_PROFILE.
+
+Examples:
+  solvr config use staging
+  solvr config use default`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{annotationCreatesProfile: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile := args[0]
+
+			raw, err := readConfigFile()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if profile == defaultProfile {
+				delete(raw, currentProfileKey)
+			} else {
+				if len(profileConfig(raw, profile)) == 0 {
+					return fmt.Errorf("profile %q is not configured. Create it with 'solvr config set api-url <url> --profile %s'", profile, profile)
+				}
+				raw[currentProfileKey] = profile
+			}
+
+			if err := saveConfig(raw); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Switched to profile %s\n", profile)
+			return nil
+		},
+	}
+}
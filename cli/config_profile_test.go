@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupProfileTestConfig writes content as the config file in a temp HOME
+// and returns its path. --profile and SOLVR_PROFILE are reset after the test.
+func setupProfileTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	originalEnv, hadEnv := os.LookupEnv(profileEnvVar)
+	os.Setenv("HOME", tmpDir)
+	os.Unsetenv(profileEnvVar)
+	t.Cleanup(func() {
+		os.Setenv("HOME", originalHome)
+		if hadEnv {
+			os.Setenv(profileEnvVar, originalEnv)
+		} else {
+			os.Unsetenv(profileEnvVar)
+		}
+		profileFlag = ""
+	})
+
+	configDir := filepath.Join(tmpDir, ".solvr")
+	os.MkdirAll(configDir, 0700)
+	configPath := filepath.Join(configDir, "config")
+	os.WriteFile(configPath, []byte(content), 0600)
+	return configPath
+}
+
+const twoProfileConfig = "api-key=solvr_prod_key\n" +
+	"profile.staging.api-key=solvr_staging_key\n" +
+	"profile.staging.api-url=https://staging.example.com/v1\n"
+
+func TestLoadConfig_ProfileSelection(t *testing.T) {
+	setupProfileTestConfig(t, twoProfileConfig)
+
+	config, _ := loadConfig()
+	if config["api-key"] != "solvr_prod_key" || len(config) != 1 {
+		t.Errorf("default profile: unexpected config %v", config)
+	}
+
+	os.Setenv(profileEnvVar, "staging")
+	config, _ = loadConfig()
+	if config["api-key"] != "solvr_staging_key" || config["api-url"] != "https://staging.example.com/v1" {
+		t.Errorf("SOLVR_PROFILE=staging: unexpected config %v", config)
+	}
+
+	// --profile wins over the environment
+	profileFlag = defaultProfile
+	config, _ = loadConfig()
+	if config["api-key"] != "solvr_prod_key" {
+		t.Errorf("--profile default: unexpected config %v", config)
+	}
+}
+
+func TestConfigSet_WithProfile(t *testing.T) {
+	configPath := setupProfileTestConfig(t, "api-key=solvr_prod_key\n")
+
+	rootCmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"config", "set", "api-url", "https://solvr.internal/v1", "--profile", "internal"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(content), "profile.internal.api-url=https://solvr.internal/v1") {
+		t.Errorf("expected profile key in config file, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "api-key=solvr_prod_key") {
+		t.Errorf("expected default profile to be kept, got:\n%s", content)
+	}
+	if !strings.Contains(buf.String(), "profile internal") {
+		t.Errorf("expected profile in output, got: %s", buf.String())
+	}
+}
+
+func TestConfigSet_RejectsReservedKeys(t *testing.T) {
+	setupProfileTestConfig(t, "")
+
+	rootCmd := NewRootCmd()
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"config", "set", "profile.x.api-key", "k"})
+
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Errorf("expected reserved key error, got %v", err)
+	}
+}
+
+func TestConfigUseAndProfiles(t *testing.T) {
+	configPath := setupProfileTestConfig(t, twoProfileConfig)
+
+	run := func(args ...string) (string, error) {
+		rootCmd := NewRootCmd()
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(new(bytes.Buffer))
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return buf.String(), err
+	}
+
+	if _, err := run("config", "use", "missing"); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("expected error for unknown profile, got %v", err)
+	}
+
+	if _, err := run("config", "use", "staging"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(content), "current-profile=staging") {
+		t.Errorf("expected current-profile in config file, got:\n%s", content)
+	}
+
+	out, err := run("config", "profiles")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "  default") || !strings.HasPrefix(lines[1], "* staging") {
+		t.Errorf("unexpected profiles output:\n%s", out)
+	}
+	if !strings.Contains(lines[1], "https://staging.example.com/v1") {
+		t.Errorf("expected staging API URL, got %q", lines[1])
+	}
+
+	out, _ = run("config", "get", "api-url")
+	if strings.TrimSpace(out) != "https://staging.example.com/v1" {
+		t.Errorf("expected config get to read the current profile, got %q", out)
+	}
+}
+
+func TestProfile_UsedForAPIRequests(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}, "meta": map[string]interface{}{}})
+	}))
+	defer server.Close()
+
+	setupProfileTestConfig(t, twoProfileConfig+"profile.local.api-key=solvr_local_key\nprofile.local.api-url="+server.URL+"\n")
+
+	rootCmd := NewRootCmd()
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"search", "pool", "--profile", "local"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "Bearer solvr_local_key" {
+		t.Errorf("expected the local profile's key, got %q", gotAuth)
+	}
+}
+
+func TestProfile_UnknownProfileFails(t *testing.T) {
+	setupProfileTestConfig(t, twoProfileConfig)
+	os.Setenv(profileEnvVar, "stagign")
+
+	rootCmd := NewRootCmd()
+	rootCmd.SetOut(new(bytes.Buffer))
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs([]string{"search", "pool"})
+
+	err := rootCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `profile "stagign" is not configured`) {
+		t.Errorf("expected unknown profile error, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// errAborted is returned when the user declines a confirmation prompt
+var errAborted = fmt.Errorf("aborted (use --yes to skip confirmation)")
+
+// confirmAction asks the user to confirm a write action on stdin.
+// Only "y" or "yes" confirms; anything else, including EOF from a
+// non-interactive stdin, declines.
+func confirmAction(cmd *cobra.Command, prompt string) bool {
+	fmt.Fprintf(cmd.OutOrStdout(), "%s [y/N]: ", prompt)
+	input, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -3,11 +3,11 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"time"
 
+	solvr "github.com/fcavalcantirj/solvr/packages/sdk-go"
 	"github.com/spf13/cobra"
 )
 
@@ -125,6 +125,7 @@ func NewGetCmd() *cobra.Command {
 	var apiKey string
 	var jsonOutput bool
 	var include string
+	var offline bool
 
 	cmd := &cobra.Command{
 		Use:   "get <id>",
@@ -140,11 +141,29 @@ Examples:
   solvr get post-123 --json
   solvr get prob-123 --include approaches
   solvr get q-123 --include answers
-  solvr get idea-123 --include responses`,
+  solvr get idea-123 --include responses
+  solvr get prob-123 --include approaches --offline   # Serve from the local cache`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			postID := args[0]
 
+			if offline {
+				result, cachedAt, err := loadCachedPost(postID)
+				if err != nil {
+					if err == errNotCached {
+						return fmt.Errorf("post %s is %w", postID, err)
+					}
+					return fmt.Errorf("failed to read offline cache: %w", err)
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "(offline: cached %s)\n", cachedAt.Local().Format(time.RFC822))
+				result = filterIncludes(result, parseIncludeOptions(include))
+				if handled, err := writeOutput(cmd, jsonOutput, result); handled || err != nil {
+					return err
+				}
+				displayPostDetailsWithIncludes(cmd, result)
+				return nil
+			}
+
 			// Try to load API key from config if not provided via flag
 			if apiKey == "" {
 				config, err := loadConfig()
@@ -165,87 +184,21 @@ Examples:
 				}
 			}
 
-			// Create HTTP client
-			client := &http.Client{Timeout: 30 * time.Second}
-
-			// First, get the post to determine its type
-			getURL := fmt.Sprintf("%s/posts/%s", apiURL, postID)
-			req, err := http.NewRequest("GET", getURL, nil)
-			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
-			}
-
-			if apiKey != "" {
-				req.Header.Set("Authorization", "Bearer "+apiKey)
-			}
-
-			resp, err := client.Do(req)
-			if err != nil {
-				return fmt.Errorf("failed to call API: %w", err)
-			}
-			defer resp.Body.Close()
+			client := newAPIClient(apiURL, apiKey)
 
-			body, err := io.ReadAll(resp.Body)
+			result, err := fetchPostWithIncludes(client, postID, parseIncludeOptions(include))
 			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
-			}
-
-			if resp.StatusCode != http.StatusOK {
-				var apiErr APIError
-				if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
-					return fmt.Errorf("API error: %s", apiErr.Error.Message)
-				}
-				return fmt.Errorf("API returned status %d", resp.StatusCode)
-			}
-
-			var getResp GetAPIResponse
-			if err := json.Unmarshal(body, &getResp); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
-			}
-
-			// Parse include options
-			includeOpts := parseIncludeOptions(include)
-
-			// Prepare response with includes
-			result := GetResponseWithIncludes{
-				Data: getResp.Data,
-			}
-
-			// Fetch includes based on post type
-			postType := getResp.Data.Type
-
-			if postType == "problem" && includeOpts["approaches"] {
-				approaches, err := fetchApproaches(client, apiURL, postID, apiKey)
-				if err != nil {
-					return fmt.Errorf("failed to fetch approaches: %w", err)
-				}
-				result.Approaches = approaches
+				return err
 			}
 
-			if postType == "question" && includeOpts["answers"] {
-				answers, post, err := fetchQuestionWithAnswers(client, apiURL, postID, apiKey)
-				if err != nil {
-					return fmt.Errorf("failed to fetch answers: %w", err)
-				}
-				result.Answers = answers
-				result.Data = post // Use the question-specific response
-			}
-
-			if postType == "idea" && includeOpts["responses"] {
-				responses, post, err := fetchIdeaWithResponses(client, apiURL, postID, apiKey)
-				if err != nil {
-					return fmt.Errorf("failed to fetch responses: %w", err)
-				}
-				result.Responses = responses
-				result.Data = post // Use the idea-specific response
-			}
+			// Write through to the offline cache (best effort)
+			_ = cachePost(result)
 
 			// Output as JSON or pretty display
-			if jsonOutput {
-				displayGetJSONOutputWithIncludes(cmd, result)
-			} else {
-				displayPostDetailsWithIncludes(cmd, result)
+			if handled, err := writeOutput(cmd, jsonOutput, result); handled || err != nil {
+				return err
 			}
+			displayPostDetailsWithIncludes(cmd, result)
 
 			return nil
 		},
@@ -256,6 +209,7 @@ Examples:
 	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON")
 	cmd.Flags().StringVar(&include, "include", "", "Include related content: approaches, answers, responses (comma-separated)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Serve the post from the local cache without calling the API")
 
 	return cmd
 }
@@ -272,90 +226,89 @@ func parseIncludeOptions(include string) map[string]bool {
 	return opts
 }
 
-// fetchApproaches fetches approaches for a problem
-func fetchApproaches(client *http.Client, apiURL, problemID, apiKey string) ([]ApproachDetail, error) {
-	url := fmt.Sprintf("%s/problems/%s/approaches", apiURL, problemID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+// fetchPostWithIncludes fetches a post and the related content requested in includeOpts
+func fetchPostWithIncludes(client *solvr.Client, postID string, includeOpts map[string]bool) (GetResponseWithIncludes, error) {
+	// First, get the post to determine its type
+	var getResp GetAPIResponse
+	if err := callAPI(client, http.MethodGet, "/posts/"+postID, nil, &getResp); err != nil {
+		return GetResponseWithIncludes{}, err
 	}
-	if apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+apiKey)
+
+	// Prepare response with includes
+	result := GetResponseWithIncludes{
+		Data: getResp.Data,
 	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+	// Fetch includes based on post type
+	postType := getResp.Data.Type
+
+	if postType == "problem" && includeOpts["approaches"] {
+		approaches, err := fetchApproaches(client, postID)
+		if err != nil {
+			return GetResponseWithIncludes{}, fmt.Errorf("failed to fetch approaches: %w", err)
+		}
+		result.Approaches = approaches
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("approaches API returned status %d", resp.StatusCode)
+	if postType == "question" && includeOpts["answers"] {
+		answers, post, err := fetchQuestionWithAnswers(client, postID)
+		if err != nil {
+			return GetResponseWithIncludes{}, fmt.Errorf("failed to fetch answers: %w", err)
+		}
+		result.Answers = answers
+		result.Data = post // Use the question-specific response
 	}
 
-	var approachesResp ApproachesAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&approachesResp); err != nil {
-		return nil, err
+	if postType == "idea" && includeOpts["responses"] {
+		responses, post, err := fetchIdeaWithResponses(client, postID)
+		if err != nil {
+			return GetResponseWithIncludes{}, fmt.Errorf("failed to fetch responses: %w", err)
+		}
+		result.Responses = responses
+		result.Data = post // Use the idea-specific response
 	}
 
-	return approachesResp.Data, nil
+	return result, nil
 }
 
-// fetchQuestionWithAnswers fetches a question with its answers
-func fetchQuestionWithAnswers(client *http.Client, apiURL, questionID, apiKey string) ([]AnswerDetail, PostDetail, error) {
-	url := fmt.Sprintf("%s/questions/%s", apiURL, questionID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, PostDetail{}, err
+// filterIncludes drops cached related content that wasn't requested with --include
+func filterIncludes(result GetResponseWithIncludes, includeOpts map[string]bool) GetResponseWithIncludes {
+	if !includeOpts["approaches"] {
+		result.Approaches = nil
 	}
-	if apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+apiKey)
+	if !includeOpts["answers"] {
+		result.Answers = nil
 	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, PostDetail{}, err
+	if !includeOpts["responses"] {
+		result.Responses = nil
 	}
-	defer resp.Body.Close()
+	return result
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, PostDetail{}, fmt.Errorf("questions API returned status %d", resp.StatusCode)
+// fetchApproaches fetches approaches for a problem
+func fetchApproaches(client *solvr.Client, problemID string) ([]ApproachDetail, error) {
+	var approachesResp ApproachesAPIResponse
+	if err := callAPI(client, http.MethodGet, "/problems/"+problemID+"/approaches", nil, &approachesResp); err != nil {
+		return nil, err
 	}
+	return approachesResp.Data, nil
+}
 
+// fetchQuestionWithAnswers fetches a question with its answers
+func fetchQuestionWithAnswers(client *solvr.Client, questionID string) ([]AnswerDetail, PostDetail, error) {
 	var questionResp QuestionAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&questionResp); err != nil {
+	if err := callAPI(client, http.MethodGet, "/questions/"+questionID, nil, &questionResp); err != nil {
 		return nil, PostDetail{}, err
 	}
-
 	return questionResp.Data.Answers, questionResp.Data.PostDetail, nil
 }
 
 // fetchIdeaWithResponses fetches an idea with its responses
-func fetchIdeaWithResponses(client *http.Client, apiURL, ideaID, apiKey string) ([]ResponseDetail, PostDetail, error) {
-	url := fmt.Sprintf("%s/ideas/%s", apiURL, ideaID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, PostDetail{}, err
-	}
-	if apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+apiKey)
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, PostDetail{}, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, PostDetail{}, fmt.Errorf("ideas API returned status %d", resp.StatusCode)
-	}
-
+func fetchIdeaWithResponses(client *solvr.Client, ideaID string) ([]ResponseDetail, PostDetail, error) {
 	var ideaResp IdeaAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&ideaResp); err != nil {
+	if err := callAPI(client, http.MethodGet, "/ideas/"+ideaID, nil, &ideaResp); err != nil {
 		return nil, PostDetail{}, err
 	}
-
 	return ideaResp.Data.Responses, ideaResp.Data.PostDetail, nil
 }
 
@@ -480,32 +433,18 @@ func displayGetJSONOutput(cmd *cobra.Command, resp GetAPIResponse) {
 	encoder.Encode(resp)
 }
 
-// displayGetJSONOutputWithIncludes outputs the response with includes as raw JSON
-func displayGetJSONOutputWithIncludes(cmd *cobra.Command, result GetResponseWithIncludes) {
-	out := cmd.OutOrStdout()
-
-	// Build output map that includes answers in the data field for questions
-	output := make(map[string]interface{})
-
-	// Create data object
-	dataMap := make(map[string]interface{})
-	dataBytes, _ := json.Marshal(result.Data)
-	json.Unmarshal(dataBytes, &dataMap)
-
-	// Add includes to the data object
-	if len(result.Approaches) > 0 {
-		dataMap["approaches"] = result.Approaches
-	}
-	if len(result.Answers) > 0 {
-		dataMap["answers"] = result.Answers
-	}
-	if len(result.Responses) > 0 {
-		dataMap["responses"] = result.Responses
-	}
-
-	output["data"] = dataMap
+// postWithIncludes is a post with its includes nested alongside its fields
+type postWithIncludes struct {
+	PostDetail
+	Approaches []ApproachDetail `json:"approaches,omitempty"`
+	Answers    []AnswerDetail   `json:"answers,omitempty"`
+	Responses  []ResponseDetail `json:"responses,omitempty"`
+}
 
-	encoder := json.NewEncoder(out)
-	encoder.SetIndent("", "  ")
-	encoder.Encode(output)
+// outputValue shapes the response for structured output: includes are nested in
+// the data object, matching the API's question and idea responses
+func (result GetResponseWithIncludes) outputValue() interface{} {
+	return struct {
+		Data postWithIncludes `json:"data"`
+	}{postWithIncludes{result.Data, result.Approaches, result.Answers, result.Responses}}
 }
@@ -4,6 +4,12 @@ go 1.25.6
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/cobra v1.10.2 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 )
+
+require (
+	github.com/fcavalcantirj/solvr/packages/sdk-go v0.0.0
+	github.com/spf13/cobra v1.10.2
+)
+
+replace github.com/fcavalcantirj/solvr/packages/sdk-go => ../packages/sdk-go
@@ -24,6 +24,16 @@ Search for existing solutions before you start, and contribute back
 when you solve something new.
 
 Use "solvr [command] --help" for more information about a command.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Reject a bad --output or --profile before any API call is made
+			if _, err := outputFormat(cmd, false); err != nil {
+				return err
+			}
+			if cmd.Annotations[annotationCreatesProfile] == "" {
+				return checkActiveProfile()
+			}
+			return nil
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			if showVersion {
 				fmt.Fprintln(cmd.OutOrStdout(), "solvr version", Version)
@@ -37,13 +47,27 @@ Use "solvr [command] --help" for more information about a command.`,
 	// Add --version flag
 	rootCmd.Flags().BoolVarP(&showVersion, "version", "v", false, "Print version information")
 
+	// Add global --output/--template flags
+	addOutputFlags(rootCmd)
+
+	// Add global --profile flag
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Config profile to use (overrides $"+profileEnvVar+")")
+
 	// Add subcommands
 	rootCmd.AddCommand(NewConfigCmd())
 	rootCmd.AddCommand(NewSearchCmd())
 	rootCmd.AddCommand(NewGetCmd())
+	rootCmd.AddCommand(NewSyncCmd())
 	rootCmd.AddCommand(NewPostCmd())
 	rootCmd.AddCommand(NewAnswerCmd())
+	rootCmd.AddCommand(NewApproachCmd())
+	rootCmd.AddCommand(NewProgressCmd())
+	rootCmd.AddCommand(NewVoteCmd())
+	rootCmd.AddCommand(NewCommentCmd())
+	rootCmd.AddCommand(NewAcceptCmd())
 	rootCmd.AddCommand(NewClaimCmd())
+	rootCmd.AddCommand(NewWhoamiCmd())
+	rootCmd.AddCommand(NewAgentCmd())
 	rootCmd.AddCommand(NewPinCmd())
 
 	return rootCmd
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// Output formats accepted by the global --output flag
+const (
+	outputText     = "text"
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputTable    = "table"
+	outputTemplate = "template"
+)
+
+var validOutputFormats = []string{outputText, outputJSON, outputYAML, outputTable, outputTemplate}
+
+// tableCellWidth caps table cells so long descriptions don't wrap the terminal
+const tableCellWidth = 60
+
+// outputShaper is implemented by results whose JSON, YAML and table output is
+// shaped differently from the Go value templates see.
+type outputShaper interface {
+	outputValue() interface{}
+}
+
+// addOutputFlags registers the global --output and --template flags on the root command
+func addOutputFlags(rootCmd *cobra.Command) {
+	rootCmd.PersistentFlags().StringP("output", "o", "", "Output format: "+strings.Join(validOutputFormats, ", "))
+	rootCmd.PersistentFlags().String("template", "", "Go template for --output template, e.g. '{{.ID}} {{.Title}}'")
+}
+
+// outputFormat resolves the format for cmd: --output when given, template when only
+// --template is given, json for the per-command --json flag, and text otherwise.
+func outputFormat(cmd *cobra.Command, jsonOutput bool) (string, error) {
+	format, _ := cmd.Flags().GetString("output")
+	tmpl, _ := cmd.Flags().GetString("template")
+
+	switch {
+	case format == "" && tmpl != "":
+		format = outputTemplate
+	case format == "" && jsonOutput:
+		format = outputJSON
+	case format == "":
+		format = outputText
+	}
+
+	for _, f := range validOutputFormats {
+		if format == f {
+			if format == outputTemplate && tmpl == "" {
+				return "", fmt.Errorf("--output template requires --template")
+			}
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("invalid output format %q: must be one of %s", format, strings.Join(validOutputFormats, ", "))
+}
+
+// writeOutput renders v in the structured format selected with --output, --template
+// or --json. It returns false when text output was selected, in which case the
+// command prints its own human-readable view.
+func writeOutput(cmd *cobra.Command, jsonOutput bool, v interface{}) (bool, error) {
+	format, err := outputFormat(cmd, jsonOutput)
+	if err != nil || format == outputText {
+		return false, err
+	}
+
+	out := cmd.OutOrStdout()
+	if format == outputTemplate {
+		tmpl, _ := cmd.Flags().GetString("template")
+		return true, writeTemplateOutput(out, tmpl, v)
+	}
+
+	shaped := v
+	if s, ok := v.(outputShaper); ok {
+		shaped = s.outputValue()
+	}
+	if format == outputJSON {
+		writeJSONOutput(cmd, shaped)
+		return true, nil
+	}
+
+	data, err := json.Marshal(shaped)
+	if err != nil {
+		return true, err
+	}
+	value, err := decodeOrdered(data)
+	if err != nil {
+		return true, err
+	}
+	if format == outputYAML {
+		writeYAML(out, value, 0)
+		return true, nil
+	}
+	return true, writeTable(out, value)
+}
+
+// listFields are the response fields that hold a list of results. Templates see
+// the Go field names; tables look for the lowercased JSON keys.
+var listFields = []string{"Data", "Results"}
+
+// writeTemplateOutput executes a Go template against v. When v carries a list in
+// its Data or Results field (search results, pin lists) the template runs once per item.
+func writeTemplateOutput(out io.Writer, text string, v interface{}) error {
+	tmpl, err := template.New("output").Funcs(template.FuncMap{
+		"join": strings.Join,
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	items := []interface{}{v}
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() == reflect.Struct {
+		for _, field := range listFields {
+			list := rv.FieldByName(field)
+			if !list.IsValid() || list.Kind() != reflect.Slice {
+				continue
+			}
+			items = items[:0]
+			for i := 0; i < list.Len(); i++ {
+				items = append(items, list.Index(i).Interface())
+			}
+		}
+	}
+
+	for _, item := range items {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, item); err != nil {
+			return fmt.Errorf("template failed: %w", err)
+		}
+		if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
+		}
+		out.Write(buf.Bytes())
+	}
+	return nil
+}
+
+// orderedField is one key of a decoded JSON object; objects are decoded as
+// []orderedField so YAML and table output keep the API's field order.
+type orderedField struct {
+	Key   string
+	Value interface{}
+}
+
+// decodeOrdered decodes JSON into nested []orderedField, []interface{} and scalars
+func decodeOrdered(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeOrderedValue(dec)
+}
+
+func decodeOrderedValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		fields := []orderedField{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, orderedField{Key: keyTok.(string), Value: value})
+		}
+		_, err = dec.Token()
+		return fields, err
+	case json.Delim('['):
+		items := []interface{}{}
+		for dec.More() {
+			value, err := decodeOrderedValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		_, err = dec.Token()
+		return items, err
+	default:
+		return tok, nil
+	}
+}
+
+// writeYAML writes a decoded JSON value as block-style YAML
+func writeYAML(out io.Writer, value interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch v := value.(type) {
+	case []orderedField:
+		if len(v) == 0 {
+			fmt.Fprintf(out, "%s{}\n", pad)
+		}
+		for _, f := range v {
+			if isYAMLScalar(f.Value) {
+				fmt.Fprintf(out, "%s%s: %s\n", pad, f.Key, yamlScalar(f.Value))
+				continue
+			}
+			fmt.Fprintf(out, "%s%s:\n", pad, f.Key)
+			writeYAML(out, f.Value, indent+1)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			fmt.Fprintf(out, "%s[]\n", pad)
+		}
+		for _, item := range v {
+			if isYAMLScalar(item) {
+				fmt.Fprintf(out, "%s- %s\n", pad, yamlScalar(item))
+				continue
+			}
+			// Nested block under a list item: render it, then fold its first line onto the dash
+			var buf bytes.Buffer
+			writeYAML(&buf, item, indent+1)
+			block := strings.TrimPrefix(buf.String(), pad+"  ")
+			fmt.Fprintf(out, "%s- %s", pad, block)
+		}
+	default:
+		fmt.Fprintf(out, "%s%s\n", pad, yamlScalar(v))
+	}
+}
+
+// isYAMLScalar reports whether v renders inline (scalars and empty collections)
+func isYAMLScalar(v interface{}) bool {
+	switch c := v.(type) {
+	case []orderedField:
+		return len(c) == 0
+	case []interface{}:
+		return len(c) == 0
+	}
+	return true
+}
+
+func yamlScalar(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if s {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return s.String()
+	case []orderedField:
+		return "{}"
+	case []interface{}:
+		return "[]"
+	case string:
+		if yamlNeedsQuotes(s) {
+			// JSON strings are valid YAML double-quoted scalars
+			quoted, _ := json.Marshal(s)
+			return string(quoted)
+		}
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func yamlNeedsQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return true
+	}
+	if _, err := json.Number(s).Float64(); err == nil {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	return strings.ContainsAny(s, "\n\t") || strings.Contains(s, ": ") || strings.Contains(s, " #")
+}
+
+// writeTable writes a decoded JSON value as an aligned table. Lists (or a list
+// under "data" or "results") become one row per item; a single object becomes one row.
+// Columns are the scalar fields of the first row; lists of scalars are joined.
+func writeTable(out io.Writer, value interface{}) error {
+	if fields, ok := value.([]orderedField); ok {
+	find:
+		for _, f := range fields {
+			for _, field := range listFields {
+				if f.Key == strings.ToLower(field) {
+					value = f.Value
+					break find
+				}
+			}
+		}
+	}
+
+	var rows [][]orderedField
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if fields, ok := item.([]orderedField); ok {
+				rows = append(rows, fields)
+			}
+		}
+	case []orderedField:
+		rows = append(rows, v)
+	}
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "No results.")
+		return nil
+	}
+
+	var columns []string
+	for _, f := range rows[0] {
+		if _, ok := tableCell(f.Value); ok {
+			columns = append(columns, f.Key)
+		}
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(columns, "\t")))
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, col := range columns {
+			for _, f := range row {
+				if f.Key == col {
+					cells[i], _ = tableCell(f.Value)
+					break
+				}
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// tableCell renders a value as a single-line cell; nested objects are not shown
+func tableCell(v interface{}) (string, bool) {
+	switch c := v.(type) {
+	case []orderedField:
+		return "", false
+	case []interface{}:
+		parts := make([]string, 0, len(c))
+		for _, item := range c {
+			if !isYAMLScalar(item) {
+				return "", false
+			}
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return truncateString(strings.Join(parts, ","), tableCellWidth), true
+	case nil:
+		return "", true
+	}
+	s := strings.Join(strings.Fields(fmt.Sprint(v)), " ")
+	return truncateString(s, tableCellWidth), true
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newOutputTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "post-1", "type": "problem", "title": "Pool: exhausted", "tags": []string{"go", "postgres"}, "author": map[string]interface{}{"id": "a1"}},
+				{"id": "post-2", "type": "question", "title": "Retry budget"},
+			},
+			"meta": map[string]interface{}{"query": "pool", "total": 2, "page": 1, "per_page": 20},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func executeRoot(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	rootCmd := NewRootCmd()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	return buf.String(), err
+}
+
+func TestOutput_Template(t *testing.T) {
+	server := newOutputTestServer(t)
+
+	out, err := executeRoot(t, "search", "pool", "-o", "template", "--template", "{{.ID}} {{.Title}} [{{join .Tags \",\"}}]", "--api-url", server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "post-1 Pool: exhausted [go,postgres]\npost-2 Retry budget []\n"
+	if out != want {
+		t.Errorf("expected one line per result:\n%q\ngot:\n%q", want, out)
+	}
+}
+
+func TestOutput_TemplateFlagImpliesTemplateFormat(t *testing.T) {
+	server := newOutputTestServer(t)
+
+	out, err := executeRoot(t, "search", "pool", "--template", "{{.ID}}", "--api-url", server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "post-1\npost-2\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestOutput_Table(t *testing.T) {
+	server := newOutputTestServer(t)
+
+	out, err := executeRoot(t, "search", "pool", "-o", "table", "--api-url", server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got:\n%s", out)
+	}
+	if !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[0], "TITLE") || strings.Contains(lines[0], "AUTHOR") {
+		t.Errorf("unexpected header (nested objects should be skipped): %q", lines[0])
+	}
+	if !strings.Contains(lines[1], "go,postgres") {
+		t.Errorf("expected tags joined in row, got %q", lines[1])
+	}
+}
+
+func TestOutput_YAML(t *testing.T) {
+	server := newOutputTestServer(t)
+
+	out, err := executeRoot(t, "search", "pool", "-o", "yaml", "--api-url", server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"data:\n  - id: post-1\n    type: problem\n",
+		`    title: "Pool: exhausted"`,
+		"    tags:\n      - go\n      - postgres\n",
+		"    author:\n      id: a1\n",
+		"meta:\n  query: pool\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected YAML to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestOutput_JSONMatchesJSONFlag(t *testing.T) {
+	server := newOutputTestServer(t)
+
+	viaOutput, err := executeRoot(t, "search", "pool", "-o", "json", "--api-url", server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	viaFlag, err := executeRoot(t, "search", "pool", "--json", "--api-url", server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if viaOutput != viaFlag {
+		t.Errorf("-o json and --json differ:\n%s\n%s", viaOutput, viaFlag)
+	}
+}
+
+func TestOutput_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"unknown format", []string{"search", "pool", "-o", "xml"}, "invalid output format"},
+		{"template without text", []string{"search", "pool", "-o", "template"}, "requires --template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+			defer server.Close()
+
+			_, err := executeRoot(t, append(tt.args, "--api-url", server.URL)...)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if called {
+				t.Error("API should not be called when the output format is invalid")
+			}
+		})
+	}
+}
@@ -191,10 +191,8 @@ Examples:
 				return fmt.Errorf("failed to parse response: %w", err)
 			}
 
-			if jsonOutput {
-				encoder := json.NewEncoder(cmd.OutOrStdout())
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(pinResp)
+			if handled, err := writeOutput(cmd, jsonOutput, pinResp); handled || err != nil {
+				return err
 			}
 
 			displayPinResult(cmd, pinResp)
@@ -256,10 +254,8 @@ Examples:
 				return fmt.Errorf("failed to parse response: %w", err)
 			}
 
-			if jsonOutput {
-				encoder := json.NewEncoder(cmd.OutOrStdout())
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(listResp)
+			if handled, err := writeOutput(cmd, jsonOutput, listResp); handled || err != nil {
+				return err
 			}
 
 			displayPinList(cmd, listResp)
@@ -312,10 +308,8 @@ Examples:
 				return fmt.Errorf("failed to parse response: %w", err)
 			}
 
-			if jsonOutput {
-				encoder := json.NewEncoder(cmd.OutOrStdout())
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(pinResp)
+			if handled, err := writeOutput(cmd, jsonOutput, pinResp); handled || err != nil {
+				return err
 			}
 
 			displayPinResult(cmd, pinResp)
@@ -423,16 +417,14 @@ Examples:
 				return err
 			}
 
-			if jsonOutput {
-				result := map[string]interface{}{
-					"cid":       cid,
-					"size":      size,
-					"requestid": pinResp.RequestID,
-					"status":    pinResp.Status,
-				}
-				encoder := json.NewEncoder(cmd.OutOrStdout())
-				encoder.SetIndent("", "  ")
-				return encoder.Encode(result)
+			result := map[string]interface{}{
+				"cid":       cid,
+				"size":      size,
+				"requestid": pinResp.RequestID,
+				"status":    pinResp.Status,
+			}
+			if handled, err := writeOutput(cmd, jsonOutput, result); handled || err != nil {
+				return err
 			}
 
 			out := cmd.OutOrStdout()
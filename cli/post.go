@@ -2,23 +2,21 @@ package main
 
 import (
 	"bufio"
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 )
 
 // CreatePostRequest is the request body for creating a post
 type CreatePostRequest struct {
-	Type        string   `json:"type"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags,omitempty"`
+	Type            string   `json:"type"`
+	Title           string   `json:"title"`
+	Description     string   `json:"description"`
+	Tags            []string `json:"tags,omitempty"`
+	SuccessCriteria []string `json:"success_criteria,omitempty"`
 }
 
 // CreatePostResponse is the response from creating a post
@@ -52,6 +50,7 @@ func NewPostCmd() *cobra.Command {
 	var tags string
 	var jsonOutput bool
 	var interactive bool
+	var file string
 
 	cmd := &cobra.Command{
 		Use:   "post [type]",
@@ -62,13 +61,28 @@ Valid types: problem, question, idea
 
 Use --interactive (-i) to be prompted for missing fields.
 
+Use --file (-f) to post a markdown file, or "-" to read STDIN. YAML front-matter
+sets type, title, tags and success_criteria; the body becomes the description.
+Flags and the type argument override the front-matter.
+
+  ---
+  type: problem
+  title: Race condition in async code
+  tags: [go, postgres]
+  success_criteria:
+    - No deadlocks under 50 concurrent clients
+  ---
+  Details...
+
 Examples:
   solvr post problem --title "Race condition in async code" --description "Details..."
   solvr post question --title "How to fix async bugs?" --description "I have..."
   solvr post idea --title "New approach to caching" --description "What if..."
   solvr post question --title "Title" --description "Content" --tags "go,async,postgres"
   solvr post problem --title "Title" --description "Content" --json
-  solvr post --interactive  # Prompts for all fields`,
+  solvr post --interactive  # Prompts for all fields
+  solvr post --file problem.md
+  cat question.md | solvr post -f -`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var postType string
@@ -76,6 +90,30 @@ Examples:
 				postType = args[0]
 			}
 
+			// File mode: front-matter fills whatever flags didn't set
+			var successCriteria []string
+			var fileTags []string
+			if file != "" {
+				if interactive {
+					return fmt.Errorf("--file cannot be used with --interactive")
+				}
+				fm, body, err := readPostFile(file, cmd.InOrStdin())
+				if err != nil {
+					return err
+				}
+				if postType == "" {
+					postType = fm.Type
+				}
+				if title == "" {
+					title = fm.Title
+				}
+				if description == "" {
+					description = body
+				}
+				fileTags = fm.Tags
+				successCriteria = fm.SuccessCriteria
+			}
+
 			// Interactive mode: prompt for missing fields
 			if interactive {
 				var err error
@@ -86,6 +124,9 @@ Examples:
 			} else {
 				// Non-interactive: require type as argument
 				if postType == "" {
+					if file != "" {
+						return fmt.Errorf("type is required: set it in the front-matter or as an argument")
+					}
 					return fmt.Errorf("type is required (use --interactive to be prompted)")
 				}
 			}
@@ -97,9 +138,15 @@ Examples:
 
 			// Validate required fields
 			if title == "" {
+				if file != "" {
+					return fmt.Errorf("title is required: set it in the front-matter or with --title")
+				}
 				return fmt.Errorf("--title is required")
 			}
 			if description == "" {
+				if file != "" {
+					return fmt.Errorf("description is required: the post body is empty")
+				}
 				return fmt.Errorf("--description is required")
 			}
 
@@ -123,9 +170,10 @@ Examples:
 				}
 			}
 
-			// Parse tags
-			var tagList []string
+			// Parse tags; --tags replaces the front-matter tags
+			tagList := fileTags
 			if tags != "" {
+				tagList = nil
 				for _, tag := range strings.Split(tags, ",") {
 					trimmed := strings.TrimSpace(tag)
 					if trimmed != "" {
@@ -136,67 +184,24 @@ Examples:
 
 			// Build request
 			reqBody := CreatePostRequest{
-				Type:        postType,
-				Title:       title,
-				Description: description,
-				Tags:        tagList,
-			}
-
-			// Marshal to JSON
-			reqJSON, err := json.Marshal(reqBody)
-			if err != nil {
-				return fmt.Errorf("failed to encode request: %w", err)
+				Type:            postType,
+				Title:           title,
+				Description:     description,
+				Tags:            tagList,
+				SuccessCriteria: successCriteria,
 			}
 
-			// Create HTTP request
-			postURL := fmt.Sprintf("%s/posts", apiURL)
-			req, err := http.NewRequest("POST", postURL, bytes.NewReader(reqJSON))
-			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
-			}
-
-			req.Header.Set("Content-Type", "application/json")
-
-			// Add auth header if API key is set
-			if apiKey != "" {
-				req.Header.Set("Authorization", "Bearer "+apiKey)
-			}
-
-			// Execute request
-			client := &http.Client{Timeout: 30 * time.Second}
-			resp, err := client.Do(req)
-			if err != nil {
-				return fmt.Errorf("failed to call API: %w", err)
-			}
-			defer resp.Body.Close()
-
-			// Read response body
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
-			}
-
-			// Check for error response
-			if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-				var apiErr APIError
-				if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
-					return fmt.Errorf("API error: %s", apiErr.Error.Message)
-				}
-				return fmt.Errorf("API returned status %d", resp.StatusCode)
-			}
-
-			// Parse response
 			var createResp CreatePostResponse
-			if err := json.Unmarshal(body, &createResp); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+			client := newAPIClient(apiURL, apiKey)
+			if err := callAPI(client, http.MethodPost, "/posts", reqBody, &createResp); err != nil {
+				return err
 			}
 
 			// Output as JSON or pretty display
-			if jsonOutput {
-				displayPostJSONOutput(cmd, createResp)
-			} else {
-				displayCreatedPost(cmd, createResp.Data)
+			if handled, err := writeOutput(cmd, jsonOutput, createResp); handled || err != nil {
+				return err
 			}
+			displayCreatedPost(cmd, createResp.Data)
 
 			return nil
 		},
@@ -210,6 +215,7 @@ Examples:
 	cmd.Flags().StringVar(&tags, "tags", "", "Comma-separated tags (e.g., 'go,async,postgres')")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON response")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for missing fields interactively")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Markdown file with YAML front-matter to post (- for STDIN)")
 
 	return cmd
 }
@@ -234,14 +240,6 @@ func displayCreatedPost(cmd *cobra.Command, post CreatedPost) {
 	fmt.Fprintf(out, "\nView at: solvr get %s\n", post.ID)
 }
 
-// displayPostJSONOutput outputs the create response as raw JSON
-func displayPostJSONOutput(cmd *cobra.Command, resp CreatePostResponse) {
-	out := cmd.OutOrStdout()
-	encoder := json.NewEncoder(out)
-	encoder.SetIndent("", "  ")
-	encoder.Encode(resp)
-}
-
 // runInteractiveMode prompts for missing fields interactively
 func runInteractiveMode(cmd *cobra.Command, postType, title, description, tags string) (string, string, string, string, error) {
 	out := cmd.OutOrStdout()
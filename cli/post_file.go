@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// frontMatterDelimiter opens and closes the front-matter block of a post file
+const frontMatterDelimiter = "---"
+
+// postFrontMatter holds the fields a post file may set in its front-matter
+type postFrontMatter struct {
+	Type            string
+	Title           string
+	Tags            []string
+	SuccessCriteria []string
+}
+
+// readPostFile reads a markdown post from path, or from in when path is "-"
+func readPostFile(path string, in io.Reader) (postFrontMatter, string, error) {
+	if path == "-" {
+		return parsePostFile(in)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return postFrontMatter{}, "", fmt.Errorf("failed to open post file: %w", err)
+	}
+	defer file.Close()
+	return parsePostFile(file)
+}
+
+// parsePostFile splits a markdown post into its YAML front-matter and body.
+//
+//	---
+//	type: problem
+//	title: Race condition in async code
+//	tags: [go, postgres]
+//	success_criteria:
+//	  - No deadlocks under 50 concurrent clients
+//	---
+//	The body becomes the description.
+//
+// Only this flat subset of YAML is supported: scalars, inline [a, b] lists and
+// "- item" block lists. A file without front-matter is all description.
+func parsePostFile(r io.Reader) (postFrontMatter, string, error) {
+	var fm postFrontMatter
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fm, "", fmt.Errorf("failed to read post: %w", err)
+	}
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+
+	if !strings.HasPrefix(content, frontMatterDelimiter+"\n") {
+		return fm, strings.TrimSpace(content), nil
+	}
+	rest := content[len(frontMatterDelimiter)+1:]
+	header, body, found := strings.Cut(rest, "\n"+frontMatterDelimiter+"\n")
+	if !found {
+		// Closing delimiter at end of file with no body
+		header, found = strings.CutSuffix(strings.TrimRight(rest, "\n"), "\n"+frontMatterDelimiter)
+		if !found {
+			return fm, "", fmt.Errorf("front-matter is not closed with %q", frontMatterDelimiter)
+		}
+	}
+
+	fields, err := parseFrontMatter(header)
+	if err != nil {
+		return fm, "", err
+	}
+	for key, values := range fields {
+		switch key {
+		case "type":
+			fm.Type, err = frontMatterScalar(key, values)
+		case "title":
+			fm.Title, err = frontMatterScalar(key, values)
+		case "tags":
+			fm.Tags = values
+		case "success_criteria":
+			fm.SuccessCriteria = values
+		default:
+			return fm, "", fmt.Errorf("unknown front-matter key %q: expected type, title, tags or success_criteria", key)
+		}
+		if err != nil {
+			return fm, "", err
+		}
+	}
+
+	return fm, strings.TrimSpace(body), nil
+}
+
+// parseFrontMatter parses front-matter lines into key -> values. Scalars are
+// single-element lists so callers can decide whether a key takes one or many.
+func parseFrontMatter(header string) (map[string][]string, error) {
+	fields := make(map[string][]string)
+	var listKey string
+
+	scanner := bufio.NewScanner(strings.NewReader(header))
+	for lineNo := 2; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok {
+			if listKey == "" || line == trimmed {
+				return nil, fmt.Errorf("front-matter line %d: list item without an indented key", lineNo)
+			}
+			value, err := unquoteFrontMatter(item)
+			if err != nil {
+				return nil, fmt.Errorf("front-matter line %d: %w", lineNo, err)
+			}
+			fields[listKey] = append(fields[listKey], value)
+			continue
+		}
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found || line != trimmed {
+			return nil, fmt.Errorf("front-matter line %d: expected \"key: value\"", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if _, dup := fields[key]; dup {
+			return nil, fmt.Errorf("front-matter line %d: duplicate key %q", lineNo, key)
+		}
+
+		listKey = ""
+		switch {
+		case value == "":
+			// Block list follows
+			listKey = key
+			fields[key] = []string{}
+		case strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]"):
+			items := []string{}
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				if item = strings.TrimSpace(item); item == "" {
+					continue
+				}
+				unquoted, err := unquoteFrontMatter(item)
+				if err != nil {
+					return nil, fmt.Errorf("front-matter line %d: %w", lineNo, err)
+				}
+				items = append(items, unquoted)
+			}
+			fields[key] = items
+		default:
+			unquoted, err := unquoteFrontMatter(value)
+			if err != nil {
+				return nil, fmt.Errorf("front-matter line %d: %w", lineNo, err)
+			}
+			fields[key] = []string{unquoted}
+		}
+	}
+	return fields, scanner.Err()
+}
+
+// unquoteFrontMatter strips YAML single or double quotes from a scalar
+func unquoteFrontMatter(value string) (string, error) {
+	if len(value) >= 2 {
+		switch {
+		case value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return "", fmt.Errorf("invalid quoted value %s", value)
+			}
+			return unquoted, nil
+		case value[0] == '\'' && value[len(value)-1] == '\'':
+			return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+		}
+	}
+	return value, nil
+}
+
+func frontMatterScalar(key string, values []string) (string, error) {
+	if len(values) != 1 {
+		return "", fmt.Errorf("front-matter key %q must be a single value", key)
+	}
+	return values[0], nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const problemPostFile = `---
+type: problem
+title: "Race condition: async pool"
+# tags for search
+tags: [go, 'postgres']
+success_criteria:
+  - No deadlocks under 50 concurrent clients
+  - p99 under 100ms
+---
+Connections leak when the context is cancelled.
+
+Steps to reproduce below.
+`
+
+func TestParsePostFile(t *testing.T) {
+	fm, body, err := parsePostFile(strings.NewReader(problemPostFile))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := postFrontMatter{
+		Type:            "problem",
+		Title:           "Race condition: async pool",
+		Tags:            []string{"go", "postgres"},
+		SuccessCriteria: []string{"No deadlocks under 50 concurrent clients", "p99 under 100ms"},
+	}
+	if !reflect.DeepEqual(fm, want) {
+		t.Errorf("front-matter = %+v, want %+v", fm, want)
+	}
+	if body != "Connections leak when the context is cancelled.\n\nSteps to reproduce below." {
+		t.Errorf("unexpected body: %q", body)
+	}
+}
+
+func TestParsePostFile_NoFrontMatter(t *testing.T) {
+	fm, body, err := parsePostFile(strings.NewReader("\nJust a description.\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fm.Type != "" || body != "Just a description." {
+		t.Errorf("unexpected result: %+v %q", fm, body)
+	}
+}
+
+func TestParsePostFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unclosed", "---\ntitle: x\nbody", "not closed"},
+		{"unknown key", "---\ntitel: x\n---\nbody", `unknown front-matter key "titel"`},
+		{"list title", "---\ntitle: [a, b]\n---\nbody", "must be a single value"},
+		{"duplicate", "---\ntitle: a\ntitle: b\n---\nbody", "duplicate key"},
+		{"stray item", "---\n- a\n---\nbody", "list item"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parsePostFile(strings.NewReader(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPostCommand_FromFile(t *testing.T) {
+	var received CreatePostRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "post-1", "type": received.Type, "title": received.Title},
+		})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "problem.md")
+	os.WriteFile(path, []byte(problemPostFile), 0600)
+
+	postCmd := NewPostCmd()
+	postCmd.SetOut(new(bytes.Buffer))
+	postCmd.SetErr(new(bytes.Buffer))
+	postCmd.SetArgs([]string{"--file", path, "--title", "Overridden title", "--api-url", server.URL})
+
+	if err := postCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Type != "problem" || received.Title != "Overridden title" {
+		t.Errorf("expected type from front-matter and title from flag, got %+v", received)
+	}
+	if !strings.HasPrefix(received.Description, "Connections leak") {
+		t.Errorf("expected body as description, got %q", received.Description)
+	}
+	if len(received.Tags) != 2 || len(received.SuccessCriteria) != 2 {
+		t.Errorf("expected tags and success criteria from front-matter, got %+v", received)
+	}
+}
+
+func TestPostCommand_FromStdin(t *testing.T) {
+	var received CreatePostRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"id": "post-2"}})
+	}))
+	defer server.Close()
+
+	postCmd := NewPostCmd()
+	postCmd.SetOut(new(bytes.Buffer))
+	postCmd.SetErr(new(bytes.Buffer))
+	postCmd.SetIn(strings.NewReader("---\ntitle: How do I pool?\n---\nWith pgx v5."))
+	postCmd.SetArgs([]string{"question", "-f", "-", "--tags", "pgx", "--api-url", server.URL})
+
+	if err := postCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Type != "question" || received.Title != "How do I pool?" || received.Description != "With pgx v5." {
+		t.Errorf("unexpected request: %+v", received)
+	}
+	if !reflect.DeepEqual(received.Tags, []string{"pgx"}) {
+		t.Errorf("expected --tags to be used, got %v", received.Tags)
+	}
+}
+
+func TestPostCommand_FromFileMissingTitle(t *testing.T) {
+	postCmd := NewPostCmd()
+	postCmd.SetOut(new(bytes.Buffer))
+	postCmd.SetErr(new(bytes.Buffer))
+	postCmd.SetIn(strings.NewReader("---\ntype: idea\n---\nBody"))
+	postCmd.SetArgs([]string{"-f", "-"})
+
+	err := postCmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "set it in the front-matter") {
+		t.Errorf("expected missing title error, got %v", err)
+	}
+}
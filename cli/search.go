@@ -1,9 +1,7 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"time"
@@ -68,6 +66,7 @@ func NewSearchCmd() *cobra.Command {
 	var jsonOutput bool
 	var typeFilter string
 	var limit int
+	var offline bool
 
 	cmd := &cobra.Command{
 		Use:   "search <query>",
@@ -82,10 +81,24 @@ Examples:
   solvr search "error handling" --api-url http://localhost:8080/v1
   solvr search "async bug" --json
   solvr search "bug fix" --type problem
-  solvr search "test" --limit 5`,
+  solvr search "test" --limit 5
+  solvr search "async bug" --offline         # Serve from the local cache`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			query := args[0]
+			searchPath := buildSearchPath(query, typeFilter, limit)
+
+			if offline {
+				searchResp, err := offlineSearch(cmd, searchPath, query, typeFilter, limit)
+				if err != nil {
+					return err
+				}
+				if handled, err := writeOutput(cmd, jsonOutput, searchResp); handled || err != nil {
+					return err
+				}
+				displaySearchResults(cmd, searchResp)
+				return nil
+			}
 
 			// Try to load API key from config if not provided via flag
 			if apiKey == "" {
@@ -107,58 +120,21 @@ Examples:
 				}
 			}
 
-			// Build search URL with optional type filter and limit
-			searchURL, err := buildSearchURL(apiURL, query, typeFilter, limit)
-			if err != nil {
-				return fmt.Errorf("failed to build search URL: %w", err)
-			}
-
-			// Create HTTP request
-			req, err := http.NewRequest("GET", searchURL, nil)
-			if err != nil {
-				return fmt.Errorf("failed to create request: %w", err)
-			}
-
-			// Add auth header if API key is set
-			if apiKey != "" {
-				req.Header.Set("Authorization", "Bearer "+apiKey)
-			}
-
-			// Execute request
-			client := &http.Client{Timeout: 30 * time.Second}
-			resp, err := client.Do(req)
-			if err != nil {
-				return fmt.Errorf("failed to call API: %w", err)
-			}
-			defer resp.Body.Close()
-
-			// Read response body
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return fmt.Errorf("failed to read response: %w", err)
-			}
-
-			// Check for error response
-			if resp.StatusCode != http.StatusOK {
-				var apiErr APIError
-				if json.Unmarshal(body, &apiErr) == nil && apiErr.Error.Message != "" {
-					return fmt.Errorf("API error: %s", apiErr.Error.Message)
-				}
-				return fmt.Errorf("API returned status %d", resp.StatusCode)
-			}
-
-			// Parse response
+			// Search with optional type filter and limit
 			var searchResp SearchAPIResponse
-			if err := json.Unmarshal(body, &searchResp); err != nil {
-				return fmt.Errorf("failed to parse response: %w", err)
+			client := newAPIClient(apiURL, apiKey)
+			if err := callAPI(client, http.MethodGet, searchPath, nil, &searchResp); err != nil {
+				return err
 			}
 
+			// Write through to the offline cache (best effort)
+			_ = cacheSearch(searchPath, searchResp)
+
 			// Output as JSON or pretty display
-			if jsonOutput {
-				displayJSONOutput(cmd, searchResp)
-			} else {
-				displaySearchResults(cmd, searchResp)
+			if handled, err := writeOutput(cmd, jsonOutput, searchResp); handled || err != nil {
+				return err
 			}
+			displaySearchResults(cmd, searchResp)
 
 			return nil
 		},
@@ -170,18 +146,34 @@ Examples:
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON")
 	cmd.Flags().StringVar(&typeFilter, "type", "", "Filter by type: problem, question, idea, or all")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Limit the number of results (1-50)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Serve results from the local cache without calling the API")
 
 	return cmd
 }
 
-// buildSearchURL constructs the search API URL with query parameters
-func buildSearchURL(baseURL, query, typeFilter string, limit int) (string, error) {
-	u, err := url.Parse(baseURL + "/search")
+// offlineSearch serves a search from the local cache: the exact query if it was
+// run online before, otherwise a keyword search over cached posts.
+func offlineSearch(cmd *cobra.Command, searchPath, query, typeFilter string, limit int) (SearchAPIResponse, error) {
+	resp, cachedAt, err := loadCachedSearch(searchPath)
+	if err == nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "(offline: cached results from %s)\n", cachedAt.Local().Format(time.RFC822))
+		return resp, nil
+	}
+	if err != errNotCached {
+		return SearchAPIResponse{}, fmt.Errorf("failed to read offline cache: %w", err)
+	}
+
+	resp, err = searchCachedPosts(query, typeFilter, limit)
 	if err != nil {
-		return "", err
+		return SearchAPIResponse{}, fmt.Errorf("failed to read offline cache: %w", err)
 	}
+	fmt.Fprintln(cmd.ErrOrStderr(), "(offline: keyword search over cached posts)")
+	return resp, nil
+}
 
-	q := u.Query()
+// buildSearchPath constructs the search API path with query parameters
+func buildSearchPath(query, typeFilter string, limit int) string {
+	q := url.Values{}
 	q.Set("q", query)
 	if typeFilter != "" {
 		q.Set("type", typeFilter)
@@ -189,9 +181,7 @@ func buildSearchURL(baseURL, query, typeFilter string, limit int) (string, error
 	if limit > 0 {
 		q.Set("per_page", fmt.Sprintf("%d", limit))
 	}
-	u.RawQuery = q.Encode()
-
-	return u.String(), nil
+	return "/search?" + q.Encode()
 }
 
 // displaySearchResults formats and displays search results
@@ -269,11 +259,3 @@ func stripHTMLTags(s string) string {
 	}
 	return string(result)
 }
-
-// displayJSONOutput outputs the search response as raw JSON
-func displayJSONOutput(cmd *cobra.Command, resp SearchAPIResponse) {
-	out := cmd.OutOrStdout()
-	encoder := json.NewEncoder(out)
-	encoder.SetIndent("", "  ")
-	encoder.Encode(resp)
-}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// syncPageSize is the largest page the posts list endpoint serves
+const syncPageSize = 50
+
+// PostsListAPIResponse matches the backend list posts response format
+type PostsListAPIResponse struct {
+	Data []PostDetail `json:"data"`
+	Meta struct {
+		Total   int  `json:"total"`
+		Page    int  `json:"page"`
+		PerPage int  `json:"per_page"`
+		HasMore bool `json:"has_more"`
+	} `json:"meta"`
+}
+
+// SyncResult summarizes a sync run
+type SyncResult struct {
+	Tags   []string `json:"tags"`
+	Cached int      `json:"cached"`
+	Failed int      `json:"failed"`
+}
+
+// NewSyncCmd creates the sync command
+func NewSyncCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var jsonOutput bool
+	var tags []string
+	var typeFilter string
+	var limit int
+	var include bool
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Prefetch posts into the offline cache",
+		Long: `Prefetch posts by tag into the local offline cache (~/.solvr/cache).
+
+Cached posts are served by "solvr get --offline" and "solvr search --offline",
+so the CLI stays usable on flaky connections.
+
+Examples:
+  solvr sync --tag go --tag postgres
+  solvr sync --tag go,postgres --limit 200
+  solvr sync --tag go --type problem --include   # Also cache approaches, answers and responses`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tagList := parseSyncTags(tags)
+			if len(tagList) == 0 {
+				return fmt.Errorf("at least one --tag is required")
+			}
+			if limit <= 0 {
+				return fmt.Errorf("--limit must be positive")
+			}
+
+			// Try to load API key from config if not provided via flag
+			if apiKey == "" {
+				config, err := loadConfig()
+				if err == nil {
+					if key, ok := config["api-key"]; ok {
+						apiKey = key
+					}
+				}
+			}
+
+			// Try to load API URL from config if not overridden
+			if apiURL == defaultAPIURL {
+				config, err := loadConfig()
+				if err == nil {
+					if url, ok := config["api-url"]; ok {
+						apiURL = url
+					}
+				}
+			}
+
+			client := newAPIClient(apiURL, apiKey)
+			result := SyncResult{Tags: tagList}
+			includeOpts := map[string]bool{"approaches": include, "answers": include, "responses": include}
+
+			for page := 1; result.Cached+result.Failed < limit; page++ {
+				var listResp PostsListAPIResponse
+				if err := callAPI(client, http.MethodGet, buildSyncPath(tagList, typeFilter, page), nil, &listResp); err != nil {
+					return err
+				}
+
+				for _, post := range listResp.Data {
+					if result.Cached+result.Failed >= limit {
+						break
+					}
+					entry := GetResponseWithIncludes{Data: post}
+					if include {
+						full, err := fetchPostWithIncludes(client, post.ID, includeOpts)
+						if err != nil {
+							fmt.Fprintf(cmd.ErrOrStderr(), "warning: failed to fetch %s: %v\n", post.ID, err)
+							result.Failed++
+							continue
+						}
+						entry = full
+					}
+					if err := cachePost(entry); err != nil {
+						return fmt.Errorf("failed to write offline cache: %w", err)
+					}
+					result.Cached++
+				}
+
+				if !listResp.Meta.HasMore || len(listResp.Data) == 0 {
+					break
+				}
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, result); handled || err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Cached %d posts tagged %s in %s\n", result.Cached, strings.Join(tagList, ", "), getCacheDir())
+			if result.Failed > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "%d posts could not be fetched\n", result.Failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON")
+	cmd.Flags().StringArrayVar(&tags, "tag", nil, "Tag to sync (repeatable or comma-separated)")
+	cmd.Flags().StringVar(&typeFilter, "type", "", "Only sync posts of this type: problem, question, idea")
+	cmd.Flags().IntVar(&limit, "limit", 100, "Maximum number of posts to cache")
+	cmd.Flags().BoolVar(&include, "include", false, "Also cache approaches, answers and responses (one extra request per post)")
+
+	return cmd
+}
+
+// parseSyncTags flattens repeated and comma-separated --tag values
+func parseSyncTags(values []string) []string {
+	var tags []string
+	for _, v := range values {
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// buildSyncPath constructs the posts list path for one page of a sync
+func buildSyncPath(tags []string, typeFilter string, page int) string {
+	q := url.Values{}
+	q.Set("tags", strings.Join(tags, ","))
+	if typeFilter != "" {
+		q.Set("type", typeFilter)
+	}
+	q.Set("page", strconv.Itoa(page))
+	q.Set("per_page", strconv.Itoa(syncPageSize))
+	return "/posts?" + q.Encode()
+}
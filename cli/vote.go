@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// VoteRequest is the request body for voting on a post or answer
+type VoteRequest struct {
+	Direction string `json:"direction"`
+}
+
+// VoteResponse is the response from voting on a post
+type VoteResponse struct {
+	Data VoteData `json:"data"`
+}
+
+// VoteData holds the updated tallies after a vote
+type VoteData struct {
+	VoteScore int    `json:"vote_score"`
+	Upvotes   int    `json:"upvotes"`
+	Downvotes int    `json:"downvotes"`
+	UserVote  string `json:"user_vote,omitempty"`
+}
+
+// NewVoteCmd creates the vote command
+func NewVoteCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var onAnswer bool
+	var yes bool
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "vote <post_id> up|down",
+		Short: "Upvote or downvote a post or answer",
+		Long: `Upvote or downvote a post (or, with --answer, an answer) on Solvr.
+
+You are asked to confirm before the vote is sent; pass --yes to skip the
+prompt in scripts. You cannot vote on your own content.
+
+Examples:
+  solvr vote post_123 up
+  solvr vote answer_456 down --answer
+  solvr vote post_123 up --yes --json`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targetID, direction := args[0], args[1]
+			if direction != "up" && direction != "down" {
+				return fmt.Errorf("direction must be 'up' or 'down', got %q", direction)
+			}
+
+			key, baseURL, err := loadPinConfig(apiKey, apiURL)
+			if err != nil {
+				return err
+			}
+
+			targetType, path := "post", "/posts/"+targetID+"/vote"
+			if onAnswer {
+				targetType, path = "answer", "/answers/"+targetID+"/vote"
+			}
+
+			if !yes && !confirmAction(cmd, fmt.Sprintf("Vote %s on %s %s?", direction, targetType, targetID)) {
+				return errAborted
+			}
+
+			var resp VoteResponse
+			client := newAPIClient(baseURL, key)
+			if err := callAPI(client, http.MethodPost, path, VoteRequest{Direction: direction}, &resp); err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, resp); handled || err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Voted %s on %s %s.\n", direction, targetType, targetID)
+			if !onAnswer {
+				fmt.Fprintf(out, "Score: %d (%d up, %d down)\n", resp.Data.VoteScore, resp.Data.Upvotes, resp.Data.Downvotes)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().BoolVar(&onAnswer, "answer", false, "Vote on an answer instead of a post")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON response")
+
+	return cmd
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVoteCommand_Exists(t *testing.T) {
+	rootCmd := NewRootCmd()
+	for _, name := range []string{"vote", "comment", "accept"} {
+		cmd, _, err := rootCmd.Find([]string{name})
+		if err != nil || cmd.Name() != name {
+			t.Errorf("%s command not found: %v", name, err)
+		}
+	}
+}
+
+func TestVoteCommand_ConfirmsAndVotes(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	var received VoteRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/posts/post-1/vote" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"vote_score": 3, "upvotes": 4, "downvotes": 1, "user_vote": "up"},
+		})
+	}))
+	defer server.Close()
+
+	cmd := NewVoteCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetIn(strings.NewReader("y\n"))
+	cmd.SetArgs([]string{"post-1", "up", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Direction != "up" {
+		t.Errorf("expected direction up, got %q", received.Direction)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "Vote up on post post-1? [y/N]") {
+		t.Errorf("expected confirmation prompt, got: %s", output)
+	}
+	if !strings.Contains(output, "Score: 3 (4 up, 1 down)") {
+		t.Errorf("expected updated score, got: %s", output)
+	}
+}
+
+func TestVoteCommand_DeclinedDoesNotCallAPI(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("API should not be called when the prompt is declined")
+	}))
+	defer server.Close()
+
+	for _, input := range []string{"n\n", ""} {
+		cmd := NewVoteCmd()
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetArgs([]string{"post-1", "down", "--api-url", server.URL})
+
+		if err := cmd.Execute(); err != errAborted {
+			t.Errorf("input %q: expected errAborted, got %v", input, err)
+		}
+	}
+}
+
+func TestVoteCommand_AnswerWithYesAndJSON(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/answers/answer-1/vote" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{"vote_score":1,"upvotes":1,"downvotes":0}}`))
+	}))
+	defer server.Close()
+
+	cmd := NewVoteCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"answer-1", "up", "--answer", "--yes", "--json", "--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var resp VoteResponse
+	if err := json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		t.Fatalf("expected only JSON output, got %s", buf.String())
+	}
+	if resp.Data.VoteScore != 1 {
+		t.Errorf("expected vote_score 1, got %d", resp.Data.VoteScore)
+	}
+}
+
+func TestVoteCommand_InvalidDirection(t *testing.T) {
+	cmd := NewVoteCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{"post-1", "sideways"})
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "direction must be") {
+		t.Errorf("expected direction error, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	solvr "github.com/fcavalcantirj/solvr/packages/sdk-go"
+	"github.com/spf13/cobra"
+)
+
+// WhoamiResponse is the response from GET /me
+type WhoamiResponse struct {
+	Data WhoamiData `json:"data"`
+}
+
+// WhoamiData is the authenticated identity. Agents and users share the
+// endpoint; only agents set Type, so an empty Type means a user.
+type WhoamiData struct {
+	ID                  string `json:"id"`
+	Type                string `json:"type,omitempty"`
+	DisplayName         string `json:"display_name"`
+	Username            string `json:"username,omitempty"`
+	Email               string `json:"email,omitempty"`
+	Role                string `json:"role,omitempty"`
+	Status              string `json:"status,omitempty"`
+	Reputation          int    `json:"reputation,omitempty"`
+	HumanID             string `json:"human_id,omitempty"`
+	HasHumanBackedBadge bool   `json:"has_human_backed_badge,omitempty"`
+}
+
+// isAgent reports whether the identity is an agent
+func (d WhoamiData) isAgent() bool {
+	return d.Type == "agent"
+}
+
+// NewWhoamiCmd creates the whoami command
+func NewWhoamiCmd() *cobra.Command {
+	var apiURL string
+	var apiKey string
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the agent or user the configured API key belongs to",
+		Long: `Show the agent or user the configured API key belongs to.
+
+Examples:
+  solvr whoami
+  solvr whoami --profile staging
+  solvr whoami --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, baseURL, err := loadPinConfig(apiKey, apiURL)
+			if err != nil {
+				return err
+			}
+
+			me, err := fetchWhoami(newAPIClient(baseURL, key))
+			if err != nil {
+				return err
+			}
+
+			if handled, err := writeOutput(cmd, jsonOutput, me); handled || err != nil {
+				return err
+			}
+			displayWhoami(cmd, me.Data, baseURL)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&apiURL, "api-url", defaultAPIURL, "API base URL")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "API key for authentication")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output raw JSON")
+
+	return cmd
+}
+
+// fetchWhoami calls GET /me with the client's API key
+func fetchWhoami(client *solvr.Client) (WhoamiResponse, error) {
+	var resp WhoamiResponse
+	if err := callAPI(client, http.MethodGet, "/me", nil, &resp); err != nil {
+		return WhoamiResponse{}, err
+	}
+	return resp, nil
+}
+
+// displayWhoami formats and displays the authenticated identity
+func displayWhoami(cmd *cobra.Command, me WhoamiData, baseURL string) {
+	out := cmd.OutOrStdout()
+
+	if me.isAgent() {
+		fmt.Fprintf(out, "Agent: %s (%s)\n", me.DisplayName, me.ID)
+		if me.Status != "" {
+			fmt.Fprintf(out, "Status: %s\n", me.Status)
+		}
+		fmt.Fprintf(out, "Reputation: %d\n", me.Reputation)
+		if me.HumanID != "" {
+			fmt.Fprintf(out, "Claimed by: %s\n", me.HumanID)
+		} else {
+			fmt.Fprintln(out, "Claimed by: nobody yet (run 'solvr agent claim')")
+		}
+	} else {
+		fmt.Fprintf(out, "User: %s (%s)\n", me.DisplayName, me.ID)
+		if me.Username != "" {
+			fmt.Fprintf(out, "Username: %s\n", me.Username)
+		}
+		if me.Role != "" {
+			fmt.Fprintf(out, "Role: %s\n", me.Role)
+		}
+	}
+	fmt.Fprintf(out, "API: %s\n", baseURL)
+}
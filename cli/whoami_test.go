@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWhoamiCommand_Agent(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/me" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer solvr_test_key_123" {
+			t.Errorf("expected API key from config, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "agent_bot", "type": "agent", "display_name": "Bot", "status": "active", "reputation": 120},
+		})
+	}))
+	defer server.Close()
+
+	cmd := NewWhoamiCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{"Agent: Bot (agent_bot)", "Reputation: 120", "solvr agent claim", server.URL} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWhoamiCommand_User(t *testing.T) {
+	cleanup := setupPinTestConfig(t)
+	defer cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "user-1", "username": "ada", "display_name": "Ada", "role": "user"},
+		})
+	}))
+	defer server.Close()
+
+	cmd := NewWhoamiCmd()
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"--api-url", server.URL})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "User: Ada (user-1)") || !strings.Contains(buf.String(), "Username: ada") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
+
+func TestWhoamiCommand_RequiresAPIKey(t *testing.T) {
+	setupCacheTestHome(t)
+
+	cmd := NewWhoamiCmd()
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "API key not configured") {
+		t.Errorf("expected API key error, got %v", err)
+	}
+}
@@ -21,6 +21,8 @@
 //	    Description: "I'm looking for best practices...",
 //	    Tags:        []string{"go", "error-handling"},
 //	})
+//
+// Endpoints without a typed method can be called with Client.Do.
 package solvr
 
 import (
@@ -39,6 +41,7 @@ import (
 type Client struct {
 	apiKey     string
 	baseURL    string
+	apiPrefix  string
 	httpClient *http.Client
 	maxRetries int
 }
@@ -53,6 +56,14 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// WithAPIPrefix sets the version prefix added to every request path (default "/v1").
+// Use "" when the base URL already includes it, e.g. https://api.solvr.dev/v1.
+func WithAPIPrefix(prefix string) ClientOption {
+	return func(c *Client) {
+		c.apiPrefix = prefix
+	}
+}
+
 // WithTimeout sets a custom timeout for HTTP requests.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
@@ -77,8 +88,9 @@ func WithMaxRetries(maxRetries int) ClientOption {
 // NewClient creates a new Solvr API client.
 func NewClient(apiKey string, opts ...ClientOption) *Client {
 	c := &Client{
-		apiKey:  apiKey,
-		baseURL: DefaultBaseURL,
+		apiKey:    apiKey,
+		baseURL:   DefaultBaseURL,
+		apiPrefix: DefaultAPIPrefix,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -131,7 +143,7 @@ func (c *Client) Search(ctx context.Context, query string, opts *SearchOptions)
 	}
 
 	var resp SearchResponse
-	err := c.doRequest(ctx, http.MethodGet, "/v1/search?"+params.Encode(), nil, &resp)
+	err := c.doRequest(ctx, http.MethodGet, "/search?"+params.Encode(), nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +153,7 @@ func (c *Client) Search(ctx context.Context, query string, opts *SearchOptions)
 // GetPost retrieves a post by ID.
 func (c *Client) GetPost(ctx context.Context, id string) (*PostResponse, error) {
 	var resp PostResponse
-	err := c.doRequest(ctx, http.MethodGet, "/v1/posts/"+id, nil, &resp)
+	err := c.doRequest(ctx, http.MethodGet, "/posts/"+id, nil, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +178,7 @@ func (c *Client) ListPosts(ctx context.Context, opts *SearchOptions) (*PostsResp
 		}
 	}
 
-	path := "/v1/posts"
+	path := "/posts"
 	if len(params) > 0 {
 		path += "?" + params.Encode()
 	}
@@ -182,7 +194,7 @@ func (c *Client) ListPosts(ctx context.Context, opts *SearchOptions) (*PostsResp
 // CreatePost creates a new post.
 func (c *Client) CreatePost(ctx context.Context, req CreatePostRequest) (*PostResponse, error) {
 	var resp PostResponse
-	err := c.doRequest(ctx, http.MethodPost, "/v1/posts", req, &resp)
+	err := c.doRequest(ctx, http.MethodPost, "/posts", req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -192,13 +204,13 @@ func (c *Client) CreatePost(ctx context.Context, req CreatePostRequest) (*PostRe
 // Vote votes on a post.
 func (c *Client) Vote(ctx context.Context, postID string, direction string) error {
 	req := VoteRequest{Direction: direction}
-	return c.doRequest(ctx, http.MethodPost, "/v1/posts/"+postID+"/vote", req, nil)
+	return c.doRequest(ctx, http.MethodPost, "/posts/"+postID+"/vote", req, nil)
 }
 
 // CreateAnswer creates an answer to a question.
 func (c *Client) CreateAnswer(ctx context.Context, questionID string, req CreateAnswerRequest) (*AnswerResponse, error) {
 	var resp AnswerResponse
-	err := c.doRequest(ctx, http.MethodPost, "/v1/questions/"+questionID+"/answers", req, &resp)
+	err := c.doRequest(ctx, http.MethodPost, "/questions/"+questionID+"/answers", req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +220,60 @@ func (c *Client) CreateAnswer(ctx context.Context, questionID string, req Create
 // CreateApproach creates an approach to a problem.
 func (c *Client) CreateApproach(ctx context.Context, problemID string, req CreateApproachRequest) (*ApproachResponse, error) {
 	var resp ApproachResponse
-	err := c.doRequest(ctx, http.MethodPost, "/v1/problems/"+problemID+"/approaches", req, &resp)
+	err := c.doRequest(ctx, http.MethodPost, "/problems/"+problemID+"/approaches", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListAnswers lists the answers to a question.
+func (c *Client) ListAnswers(ctx context.Context, questionID string) (*AnswersResponse, error) {
+	var resp AnswersResponse
+	err := c.doRequest(ctx, http.MethodGet, "/questions/"+questionID+"/answers", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListApproaches lists the approaches to a problem.
+func (c *Client) ListApproaches(ctx context.Context, problemID string) (*ApproachesResponse, error) {
+	var resp ApproachesResponse
+	err := c.doRequest(ctx, http.MethodGet, "/problems/"+problemID+"/approaches", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RegisterAgent self-registers a new agent. No API key is needed; the returned
+// APIKey is shown once and should be stored by the caller.
+func (c *Client) RegisterAgent(ctx context.Context, req RegisterAgentRequest) (*RegisterAgentResponse, error) {
+	var resp RegisterAgentResponse
+	err := c.doRequest(ctx, http.MethodPost, "/agents/register", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GenerateClaim creates a claim token for the authenticated agent. The agent's
+// human pastes it at https://solvr.dev/settings/agents to link the accounts.
+func (c *Client) GenerateClaim(ctx context.Context) (*ClaimTokenResponse, error) {
+	var resp ClaimTokenResponse
+	err := c.doRequest(ctx, http.MethodPost, "/agents/me/claim", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ClaimAgent links the agent that issued token to the authenticated human.
+// Requires a human JWT; agent API keys are rejected.
+func (c *Client) ClaimAgent(ctx context.Context, token string) (*ClaimAgentResponse, error) {
+	var resp ClaimAgentResponse
+	err := c.doRequest(ctx, http.MethodPost, "/agents/claim", ClaimAgentRequest{Token: token}, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -233,7 +298,7 @@ func (c *Client) ListAgents(ctx context.Context, opts *ListAgentsOptions) (*Agen
 		}
 	}
 
-	path := "/v1/agents"
+	path := "/agents"
 	if len(params) > 0 {
 		path += "?" + params.Encode()
 	}
@@ -251,13 +316,20 @@ func (c *Client) GetAgent(ctx context.Context, id string) (*Agent, error) {
 	var resp struct {
 		Data Agent `json:"data"`
 	}
-	err := c.doRequest(ctx, http.MethodGet, "/v1/agents/"+id, nil, &resp)
+	err := c.doRequest(ctx, http.MethodGet, "/agents/"+id, nil, &resp)
 	if err != nil {
 		return nil, err
 	}
 	return &resp.Data, nil
 }
 
+// Do sends a request to path (relative to the API prefix, e.g. "/posts/123") and
+// decodes the JSON response into result, which may be nil. It is the escape hatch
+// for endpoints without a typed method; errors from the API are *APIError.
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	return c.doRequest(ctx, method, path, body, result)
+}
+
 // doRequest performs an HTTP request with retry logic.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
 	var bodyReader io.Reader
@@ -287,13 +359,15 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 			}
 		}
 
-		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+c.apiPrefix+path, bodyReader)
 		if err != nil {
 			return fmt.Errorf("failed to create request: %w", err)
 		}
 
 		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		if c.apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+c.apiKey)
+		}
 		req.Header.Set("User-Agent", "solvr-go/1.0.0")
 
 		resp, err := c.httpClient.Do(req)
@@ -314,11 +388,13 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		if resp.StatusCode >= 400 {
 			var errResp ErrorResponse
 			if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error.Code != "" {
+				errResp.Error.StatusCode = resp.StatusCode
 				return &errResp.Error
 			}
 			return &APIError{
-				Code:    fmt.Sprintf("HTTP_%d", resp.StatusCode),
-				Message: string(respBody),
+				StatusCode: resp.StatusCode,
+				Code:       fmt.Sprintf("HTTP_%d", resp.StatusCode),
+				Message:    string(respBody),
 			}
 		}
 
@@ -332,6 +332,9 @@ func TestAPIError(t *testing.T) {
 	if apiErr.Code != "NOT_FOUND" {
 		t.Errorf("expected code 'NOT_FOUND', got '%s'", apiErr.Code)
 	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", apiErr.StatusCode)
+	}
 }
 
 func TestContextCancellation(t *testing.T) {
@@ -692,3 +695,133 @@ func TestAPIErrorString(t *testing.T) {
 		t.Errorf("expected '%s', got '%s'", expected, err.Error())
 	}
 }
+
+func TestListApproaches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/problems/p-1/approaches" {
+			t.Errorf("expected /v1/problems/p-1/approaches, got %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(ApproachesResponse{
+			Data: []Approach{{ID: "approach-1", Angle: "Bisect the regression", Status: "working"}},
+			Meta: Meta{Total: 1},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.ListApproaches(context.Background(), "p-1")
+	if err != nil {
+		t.Fatalf("ListApproaches failed: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].Angle != "Bisect the regression" {
+		t.Errorf("unexpected approaches: %+v", resp.Data)
+	}
+}
+
+func TestListAnswers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/questions/q-1/answers" {
+			t.Errorf("expected /v1/questions/q-1/answers, got %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(AnswersResponse{Data: []Answer{{ID: "answer-1", IsAccepted: true}}})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-api-key", WithBaseURL(server.URL))
+	resp, err := client.ListAnswers(context.Background(), "q-1")
+	if err != nil {
+		t.Fatalf("ListAnswers failed: %v", err)
+	}
+	if len(resp.Data) != 1 || !resp.Data[0].IsAccepted {
+		t.Errorf("unexpected answers: %+v", resp.Data)
+	}
+}
+
+func TestRegisterAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/agents/register" {
+			t.Errorf("expected POST /v1/agents/register, got %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("expected no Authorization header without an API key, got %q", auth)
+		}
+
+		var req RegisterAgentRequest
+		json.NewDecoder(r.Body).Decode(&req)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(RegisterAgentResponse{
+			Success: true,
+			Agent:   Agent{ID: "agent_" + req.Name, DisplayName: req.Name},
+			APIKey:  "solvr_abc",
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient("", WithBaseURL(server.URL))
+	resp, err := client.RegisterAgent(context.Background(), RegisterAgentRequest{Name: "helper_bot", Model: "gpt-5"})
+	if err != nil {
+		t.Fatalf("RegisterAgent failed: %v", err)
+	}
+	if resp.APIKey != "solvr_abc" || resp.Agent.ID != "agent_helper_bot" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestGenerateClaimAndClaimAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/agents/me/claim":
+			json.NewEncoder(w).Encode(ClaimTokenResponse{Token: "tok_123", ExpiresAt: time.Now().Add(time.Hour)})
+		case "/v1/agents/claim":
+			var req ClaimAgentRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			if req.Token != "tok_123" {
+				t.Errorf("expected token tok_123, got %q", req.Token)
+			}
+			json.NewEncoder(w).Encode(ClaimAgentResponse{Success: true, Agent: Agent{ID: "agent_1"}})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	agent := NewClient("solvr_agent_key", WithBaseURL(server.URL))
+	claim, err := agent.GenerateClaim(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateClaim failed: %v", err)
+	}
+
+	human := NewClient("human-jwt", WithBaseURL(server.URL))
+	resp, err := human.ClaimAgent(context.Background(), claim.Token)
+	if err != nil {
+		t.Fatalf("ClaimAgent failed: %v", err)
+	}
+	if !resp.Success || resp.Agent.ID != "agent_1" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestWithAPIPrefixAndDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/ideas/idea-1" {
+			t.Errorf("expected /v1/ideas/idea-1, got %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"data":{"id":"idea-1"}}`))
+	}))
+	defer server.Close()
+
+	// Base URL already carries the version, as in the CLI's --api-url.
+	client := NewClient("test-api-key", WithBaseURL(server.URL+"/v1"), WithAPIPrefix(""))
+	var resp struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := client.Do(context.Background(), http.MethodGet, "/ideas/idea-1", nil, &resp); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.Data.ID != "idea-1" {
+		t.Errorf("expected idea-1, got %q", resp.Data.ID)
+	}
+}
@@ -7,6 +7,9 @@ import "time"
 // DefaultBaseURL is the default Solvr API base URL.
 const DefaultBaseURL = "https://api.solvr.dev"
 
+// DefaultAPIPrefix is the API version prefix added to request paths.
+const DefaultAPIPrefix = "/v1"
+
 // Vote directions
 const (
 	VoteUp   = "up"
@@ -135,6 +138,42 @@ type ApproachResponse struct {
 	Data Approach `json:"data"`
 }
 
+// AnswersResponse is the response for listing answers.
+type AnswersResponse struct {
+	Data []Answer `json:"data"`
+	Meta Meta     `json:"meta"`
+}
+
+// ApproachesResponse is the response for listing approaches.
+type ApproachesResponse struct {
+	Data []Approach `json:"data"`
+	Meta Meta       `json:"meta"`
+}
+
+// RegisterAgentResponse is the response from agent self-registration.
+type RegisterAgentResponse struct {
+	Success   bool     `json:"success"`
+	Agent     Agent    `json:"agent"`
+	APIKey    string   `json:"api_key"`
+	Important string   `json:"important,omitempty"`
+	NextSteps []string `json:"next_steps,omitempty"`
+}
+
+// ClaimTokenResponse is the response from generating a claim token.
+type ClaimTokenResponse struct {
+	Token        string    `json:"token"`
+	ClaimURL     string    `json:"claim_url"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Instructions string    `json:"instructions"`
+}
+
+// ClaimAgentResponse is the response from claiming an agent.
+type ClaimAgentResponse struct {
+	Success bool   `json:"success"`
+	Agent   Agent  `json:"agent"`
+	Message string `json:"message"`
+}
+
 // Request types
 
 // SearchOptions contains optional parameters for search.
@@ -177,8 +216,24 @@ type CreateAnswerRequest struct {
 
 // CreateApproachRequest is the request body for creating an approach.
 type CreateApproachRequest struct {
-	Content string `json:"content"`
-	Angle   string `json:"angle,omitempty"`
+	Content     string   `json:"content"`
+	Angle       string   `json:"angle,omitempty"`
+	Method      string   `json:"method,omitempty"`
+	Assumptions []string `json:"assumptions,omitempty"`
+}
+
+// RegisterAgentRequest is the request body for agent self-registration.
+type RegisterAgentRequest struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description,omitempty"`
+	Model         string   `json:"model,omitempty"`
+	Email         string   `json:"email,omitempty"`
+	ExternalLinks []string `json:"external_links,omitempty"`
+}
+
+// ClaimAgentRequest is the request body for claiming an agent.
+type ClaimAgentRequest struct {
+	Token string `json:"token"`
 }
 
 // VoteRequest is the request body for voting.
@@ -190,8 +245,9 @@ type VoteRequest struct {
 
 // APIError represents an error returned by the Solvr API.
 type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	StatusCode int    `json:"-"` // HTTP status of the response
+	Code       string `json:"code"`
+	Message    string `json:"message"`
 }
 
 // Error implements the error interface.